@@ -11,12 +11,17 @@ import (
 
 func main() {
 	const MB = 1024 * 1024
-	app := server.RegisterRoutesWithErrorHandler(fiber.New(), &server.RegisterRoutesConfig{
+	// Streaming lets bodies above the in-memory limit be spooled to disk instead of being buffered.
+	app := server.RegisterRoutesWithErrorHandler(fiber.New(fiber.Config{StreamRequestBody: true}), &server.RegisterRoutesConfig{
 		ARCAPIKey:        "YOUR_ARC_API_KEY",
 		ARCCallbackToken: "YOUR_CALLBACK_TOKEN",
 		AdminBearerToken: "YOUR_TOKEN",
 		Engine:           engine.NewEngine(engine.Engine{}), // Please remember to define the engine config.
 		OctetStreamLimit: 500 * MB,
+		RequestBody: server.RequestBodyConfig{
+			RouteLimits:      map[string]int64{"/api/v1/submit": 100 * MB},
+			MaxInFlightBytes: 1024 * MB,
+		},
 	})
 
 	if err := app.Listen("localhost:8080"); err != nil {
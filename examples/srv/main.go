@@ -10,8 +10,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"plugin"
+	"strings"
 	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/client"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/migrate"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/config"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/config/loaders"
@@ -24,6 +28,13 @@ func main() {
 }
 
 func execute() error {
+	if len(os.Args) > 1 && os.Args[1] == "migrate-storage" {
+		return migrateStorage(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay-lookup" {
+		return replayLookup(os.Args[2:])
+	}
+
 	configPath := flag.String("config", loaders.DefaultConfigFilePath, "Path to the configuration file")
 	flag.Parse()
 
@@ -33,9 +44,15 @@ func execute() error {
 	}
 
 	ctx := context.Background()
-	srv := server.New(server.WithConfig(cfg))
+	srv, err := server.New(server.WithConfig(cfg), server.WithConfigSource(config.Source(*configPath, "OVERLAY")))
+	if err != nil {
+		return fmt.Errorf("create http server op failed: %w", err)
+	}
 	done := make(chan struct{})
 
+	// Apply the changeable settings of the configuration file on SIGHUP.
+	go config.ReloadOnSignal(ctx, srv)
+
 	go func() {
 		sigint := make(chan os.Signal, 1)
 		signal.Notify(sigint, os.Interrupt)
@@ -59,3 +76,113 @@ func execute() error {
 
 	return nil
 }
+
+// migrateStorage implements the migrate-storage command, copying the data of the overlay engine from one storage
+// backend to another. This binary registers no backend itself: they are loaded from the Go plugins passed with
+// -plugins, built with -buildmode=plugin from a main package importing the storage packages, whose init functions
+// register them with migrate.RegisterBackend.
+func migrateStorage(args []string) error {
+	flags := flag.NewFlagSet("migrate-storage", flag.ContinueOnError)
+	plugins := flags.String("plugins", "", "Comma separated paths of Go plugins registering the storage backends")
+	from := flags.String("from", "", "DSN of the storage to migrate from, e.g. sqlite:///var/lib/overlay.db")
+	to := flags.String("to", "", "DSN of the empty storage to migrate to, e.g. postgres://user@localhost/overlay")
+	topics := flags.String("topics", "", "Comma separated topics to migrate, all topics when empty")
+	batchSize := flags.Int("batch-size", migrate.DefaultBatchSize, "Number of records read from the source at once")
+	skipVerify := flags.Bool("skip-verify", false, "Skip reading the migrated data back from the destination")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := loadBackendPlugins(*plugins); err != nil {
+		return err
+	}
+	if len(migrate.Backends()) == 0 {
+		return errors.New("migrate-storage requires storage backends, load them with -plugins")
+	}
+	if *from == "" || *to == "" {
+		return fmt.Errorf("migrate-storage requires -from and -to, registered backends: %v", migrate.Backends())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	src, err := migrate.OpenSource(ctx, *from)
+	if err != nil {
+		return fmt.Errorf("open source storage op failed: %w", err)
+	}
+	dst, err := migrate.Open(ctx, *to)
+	if err != nil {
+		return fmt.Errorf("open destination storage op failed: %w", err)
+	}
+
+	opts := migrate.Options{
+		BatchSize:  *batchSize,
+		SkipVerify: *skipVerify,
+		OnProgress: func(p migrate.Progress) {
+			log.Printf("%s %s: %d outputs, %d applied transactions, %d interactions", p.Stage, p.Topic, p.Outputs, p.AppliedTransactions, p.Interactions)
+		},
+	}
+	if *topics != "" {
+		opts.Topics = strings.Split(*topics, ",")
+	}
+	report, err := migrate.Migrate(ctx, src, dst, opts)
+	if err != nil {
+		return fmt.Errorf("migrate storage op failed: %w", err)
+	}
+
+	for _, topic := range report.Topics {
+		log.Printf("migrated topic %s: %d outputs, %d applied transactions, digest %s", topic.Topic, topic.Outputs, topic.AppliedTransactions, topic.Digest)
+	}
+	log.Printf("migrated %d interactions, verified: %t", report.Interactions, report.Verified)
+	return nil
+}
+
+// loadBackendPlugins opens the comma separated Go plugins, running the init functions registering their storage backends.
+func loadBackendPlugins(paths string) error {
+	if paths == "" {
+		return nil
+	}
+	for _, path := range strings.Split(paths, ",") {
+		if _, err := plugin.Open(path); err != nil {
+			return fmt.Errorf("load storage backend plugin %s op failed: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// replayLookup implements the replay-lookup command, asking a running overlay to backfill a newly registered
+// lookup service with the outputs already stored for a topic. A failed replay logs the score it reached, which
+// can be passed back with -from-score to resume it.
+func replayLookup(args []string) error {
+	flags := flag.NewFlagSet("replay-lookup", flag.ContinueOnError)
+	baseURL := flags.String("url", "http://localhost:3000", "Base URL of the running overlay")
+	token := flags.String("token", "", "Admin bearer token of the overlay")
+	topic := flags.String("topic", "", "Topic whose stored outputs are replayed")
+	service := flags.String("service", "", "Registered lookup service to replay the outputs into")
+	fromScore := flags.Float64("from-score", 0, "Score of the first output to replay")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *topic == "" || *service == "" {
+		return errors.New("replay-lookup requires -topic and -service")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// A failed replay is resumed from the score it reached rather than retried from the start.
+	overlay, err := client.NewOverlayClient(*baseURL, client.WithBearerToken(*token), client.WithRetries(-1, 0))
+	if err != nil {
+		return fmt.Errorf("create overlay client op failed: %w", err)
+	}
+	replay, err := overlay.ReplayTopicIntoLookupService(ctx, *topic, *service, *fromScore)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.Details["score"] != nil {
+			log.Printf("replay stopped at score %v, resume it with -from-score", apiErr.Details["score"])
+		}
+		return fmt.Errorf("replay topic into lookup service op failed: %w", err)
+	}
+
+	log.Printf("replayed topic %s into %s: %d admitted, %d spent, up to score %v", replay.Topic, replay.Service, replay.Admitted, replay.Spent, replay.Score)
+	return nil
+}
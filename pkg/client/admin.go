@@ -0,0 +1,703 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ReorgTopicImpact summarizes the impact of a simulated reorg on a single topic.
+type ReorgTopicImpact struct {
+	Outputs      int `json:"outputs"`
+	Transactions int `json:"transactions"`
+}
+
+// ReorgAffectedOutput is an output whose merkle proof a simulated reorg would invalidate.
+type ReorgAffectedOutput struct {
+	Outpoint    string `json:"outpoint"`
+	Topic       string `json:"topic"`
+	BlockHeight uint32 `json:"blockHeight"`
+	Spent       bool   `json:"spent"`
+}
+
+// ReorgSimulation is the report of a dry-run reorg returned by SimulateReorg.
+type ReorgSimulation struct {
+	Depth              uint32                       `json:"depth"`
+	CurrentHeight      uint32                       `json:"currentHeight"`
+	ForkHeight         uint32                       `json:"forkHeight"`
+	UTXOsOnly          bool                         `json:"utxosOnly"`
+	InvalidatedOutputs []ReorgAffectedOutput        `json:"invalidatedOutputs"`
+	AffectedTopics     map[string]*ReorgTopicImpact `json:"affectedTopics"`
+	ResyncTransactions int                          `json:"resyncTransactions"`
+	ResyncBytes        int                          `json:"resyncBytes"`
+}
+
+// DeadLetter is a failed submission recorded in the overlay's dead-letter queue.
+type DeadLetter struct {
+	ID            string    `json:"id"`
+	Txid          string    `json:"txid"`
+	Topics        []string  `json:"topics"`
+	Mode          string    `json:"mode"`
+	Stage         string    `json:"stage"`
+	Reason        string    `json:"reason"`
+	Attempts      int       `json:"attempts"`
+	FirstFailedAt time.Time `json:"firstFailedAt"`
+	LastFailedAt  time.Time `json:"lastFailedAt"`
+}
+
+// Webhook is a subscription notified with the STEAK of submissions for its topics, or for every topic when it has none.
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Topics    []string  `json:"topics"`
+	Secret    string    `json:"secret,omitempty"` // only returned by RegisterWebhook
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// PrunedTopic lists the outpoints pruned from a topic by PruneOutputs.
+type PrunedTopic struct {
+	Topic  string   `json:"topic"`
+	Pruned []string `json:"pruned"`
+}
+
+// AuditEntry records an admin action or a transaction submission in the overlay's audit log.
+type AuditEntry struct {
+	ID         string    `json:"id"`
+	Time       time.Time `json:"time"`
+	Action     string    `json:"action"`
+	Caller     string    `json:"caller"`
+	RemoteAddr string    `json:"remoteAddr,omitempty"`
+	RequestID  string    `json:"requestId,omitempty"`
+	Topics     []string  `json:"topics,omitempty"`
+	Txid       string    `json:"txid,omitempty"`
+	Result     string    `json:"result"`
+	Status     int       `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditQuery selects the entries returned by AuditLog. Empty fields match every entry.
+type AuditQuery struct {
+	Action string
+	Caller string
+	Txid   string
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+// PlannedAdvertisement is an advertisement a SyncAdvertisements run would create or revoke.
+type PlannedAdvertisement struct {
+	Protocol       string `json:"protocol"`
+	TopicOrService string `json:"topicOrService"`
+	Domain         string `json:"domain,omitempty"` // set for revoked advertisements
+}
+
+// AdvertisementPlan is the preview of a SyncAdvertisements run returned by PlanAdvertisements.
+type AdvertisementPlan struct {
+	Create []PlannedAdvertisement `json:"create"`
+	Revoke []PlannedAdvertisement `json:"revoke"`
+	Cost   struct {
+		OutputsCreated int    `json:"outputsCreated"`
+		OutputsRevoked int    `json:"outputsRevoked"`
+		LockedSatoshis uint64 `json:"lockedSatoshis"`
+		FeeSatoshis    uint64 `json:"feeSatoshis"`
+		TotalSatoshis  uint64 `json:"totalSatoshis"`
+		BudgetSatoshis uint64 `json:"budgetSatoshis"` // zero when uncapped
+		WithinBudget   bool   `json:"withinBudget"`
+	} `json:"cost"`
+}
+
+// PlanAdvertisements previews the advertisements a SyncAdvertisements run would create and revoke,
+// and their estimated satoshi cost, without executing it. Requires the admin bearer token.
+func (c *OverlayClient) PlanAdvertisements(ctx context.Context) (*AdvertisementPlan, error) {
+	var plan AdvertisementPlan
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/advertisementPlan"}, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// Advertisement is a current SHIP or SLAP advertisement of the overlay, held in the output at Outpoint.
+type Advertisement struct {
+	Outpoint       string `json:"outpoint"`
+	Protocol       string `json:"protocol"`
+	IdentityKey    string `json:"identityKey"`
+	Domain         string `json:"domain"`
+	TopicOrService string `json:"topicOrService"`
+}
+
+// ListAdvertisements returns the current SHIP and SLAP advertisements of the overlay. Requires the admin bearer token.
+func (c *OverlayClient) ListAdvertisements(ctx context.Context) ([]Advertisement, error) {
+	var response struct {
+		Advertisements []Advertisement `json:"advertisements"`
+	}
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/advertisements"}, &response); err != nil {
+		return nil, err
+	}
+	return response.Advertisements, nil
+}
+
+// CreateAdvertisement creates an advertisement of the topic or lookup service for the protocol, SHIP or SLAP,
+// whether or not the overlay hosts it, and returns the STEAK of the advertisement transaction.
+// Requires the admin bearer token.
+func (c *OverlayClient) CreateAdvertisement(ctx context.Context, protocol overlay.Protocol, topicOrService string) (overlay.Steak, error) {
+	var response steakResponse
+	err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/advertisements", map[string]any{
+		"protocol":       protocol,
+		"topicOrService": topicOrService,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.steak()
+}
+
+// RevokeAdvertisement revokes the advertisement held in the output given as "txID.outputIndex" and returns
+// the STEAK of the revocation transaction. Requires the admin bearer token.
+func (c *OverlayClient) RevokeAdvertisement(ctx context.Context, outpoint string) (overlay.Steak, error) {
+	var response steakResponse
+	err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/advertisements",
+		query:  map[string]string{"outpoint": outpoint},
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.steak()
+}
+
+// QueuedBroadcast is a transaction waiting to be re-broadcast after its broadcast failed.
+type QueuedBroadcast struct {
+	Txid          string    `json:"txid"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"lastError"`
+	FirstFailedAt time.Time `json:"firstFailedAt"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	Abandoned     bool      `json:"abandoned"`
+}
+
+// BroadcastQueue is the re-broadcast queue and its metrics returned by BroadcastQueue.
+type BroadcastQueue struct {
+	Broadcasts []QueuedBroadcast `json:"broadcasts"`
+	Metrics    struct {
+		Queued    uint64 `json:"queued"`
+		Retried   uint64 `json:"retried"`
+		Succeeded uint64 `json:"succeeded"`
+		Abandoned uint64 `json:"abandoned"`
+	} `json:"metrics"`
+}
+
+// BroadcastQueue lists the transactions waiting to be re-broadcast after a failed broadcast,
+// together with the retry metrics. Requires the admin bearer token.
+func (c *OverlayClient) BroadcastQueue(ctx context.Context) (*BroadcastQueue, error) {
+	var queue BroadcastQueue
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/broadcastQueue"}, &queue); err != nil {
+		return nil, err
+	}
+	return &queue, nil
+}
+
+// TopicStatsEntry is the statistics of a single topic returned by TopicStats.
+type TopicStatsEntry struct {
+	Topic            string             `json:"topic"`
+	TotalOutputs     uint64             `json:"totalOutputs"`
+	UnspentOutputs   uint64             `json:"unspentOutputs"`
+	SpentOutputs     uint64             `json:"spentOutputs"`
+	UnminedOutputs   uint64             `json:"unminedOutputs"`
+	PeerInteractions map[string]float64 `json:"peerInteractions"` // last GASP interaction score, keyed by peer
+}
+
+// TopicStats is the statistics of every hosted topic returned by TopicStats.
+type TopicStats struct {
+	Topics                []TopicStatsEntry `json:"topics"`
+	LastAdvertisementSync *time.Time        `json:"lastAdvertisementSync,omitempty"` // nil when unknown
+}
+
+// TopicStats returns the output counts and GASP sync progress of every hosted topic, together with
+// the time of the last advertisement sync. Requires the admin bearer token.
+func (c *OverlayClient) TopicStats(ctx context.Context) (*TopicStats, error) {
+	var stats TopicStats
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/stats"}, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GASPPeerSyncStatus is the GASP sync of a topic with a peer returned by GASPSyncStatus.
+type GASPPeerSyncStatus struct {
+	Topic           string     `json:"topic"`
+	Peer            string     `json:"peer"`
+	Running         bool       `json:"running"`
+	LastInteraction float64    `json:"lastInteraction"`
+	PagesCompleted  int        `json:"pagesCompleted"`
+	NodesIngested   int        `json:"nodesIngested"`
+	Errors          int        `json:"errors"`
+	LastError       string     `json:"lastError,omitempty"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"` // nil when no sync ran since the overlay started
+	EndedAt         *time.Time `json:"endedAt,omitempty"`   // nil while running or when no sync ran
+	Resumable       bool       `json:"resumable"`
+	CheckpointNodes int        `json:"checkpointNodes"`
+}
+
+// GASPSyncStatus returns the GASP sync progress of every topic peer, sorted by topic and peer.
+// Requires the admin bearer token.
+func (c *OverlayClient) GASPSyncStatus(ctx context.Context) ([]GASPPeerSyncStatus, error) {
+	var response struct {
+		Peers []GASPPeerSyncStatus `json:"peers"`
+	}
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/syncStatus"}, &response); err != nil {
+		return nil, err
+	}
+	return response.Peers, nil
+}
+
+// PeerSyncFailure is a peer a topic could not be synced with, reported by a TopicSyncJob.
+type PeerSyncFailure struct {
+	Peer  string `json:"peer"`
+	Error string `json:"error"`
+}
+
+// TopicSyncJob is a one-off sync of a single topic returned by SyncTopic and GetTopicSyncJob.
+type TopicSyncJob struct {
+	JobID          string            `json:"jobId"`
+	Topic          string            `json:"topic"`
+	Status         string            `json:"status"` // "running", "completed" or "failed"
+	SucceededPeers []string          `json:"succeededPeers"`
+	FailedPeers    []PeerSyncFailure `json:"failedPeers"`
+	IngestedGraphs int               `json:"ingestedGraphs"`
+	Error          string            `json:"error,omitempty"` // set once the job failed
+	CreatedAt      time.Time         `json:"createdAt"`
+	CompletedAt    *time.Time        `json:"completedAt,omitempty"` // nil while running
+}
+
+// SyncTopic asks the overlay to run a one-off GASP sync of the topic and returns the running job; poll it with
+// GetTopicSyncJob. Non-empty peers are synced instead of the peers configured for the topic, and a non-nil since
+// is the score to sync from instead of the last interaction with each peer. Requires the admin bearer token.
+func (c *OverlayClient) SyncTopic(ctx context.Context, topic string, peers []string, since *float64) (*TopicSyncJob, error) {
+	payload := map[string]any{"topic": topic}
+	if len(peers) > 0 {
+		payload["peers"] = peers
+	}
+	if since != nil {
+		payload["since"] = *since
+	}
+
+	var job TopicSyncJob
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/syncTopic", payload, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetTopicSyncJob returns the topic sync with the given job ID, including its per-peer outcome once completed.
+// Requires the admin bearer token.
+func (c *OverlayClient) GetTopicSyncJob(ctx context.Context, jobID string) (*TopicSyncJob, error) {
+	var job TopicSyncJob
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/syncTopic/" + url.PathEscape(jobID)}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// SyncAdvertisements asks the overlay to synchronize its SHIP and SLAP advertisements. Requires the admin bearer token.
+func (c *OverlayClient) SyncAdvertisements(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/api/v1/admin/syncAdvertisements"}, nil)
+}
+
+// StartGASPSync asks the overlay to start a GASP sync. Requires the admin bearer token.
+func (c *OverlayClient) StartGASPSync(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/api/v1/admin/startGASPSync"}, nil)
+}
+
+// RegisterTopicManager enables the named topic manager on the running overlay.
+// syncType is one of "peers", "SHIP" or "none"; peers is used with "peers". Requires the admin bearer token.
+func (c *OverlayClient) RegisterTopicManager(ctx context.Context, name, syncType string, peers []string) error {
+	return c.doJSON(ctx, http.MethodPost, "/api/v1/admin/topicManagers", map[string]any{
+		"name":     name,
+		"syncType": syncType,
+		"peers":    peers,
+	}, nil)
+}
+
+// UnregisterTopicManager disables the named topic manager on the running overlay. Requires the admin bearer token.
+func (c *OverlayClient) UnregisterTopicManager(ctx context.Context, name string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/topicManagers",
+		query:  map[string]string{"topicManager": name},
+	}, nil)
+}
+
+// RegisterLookupService enables the named lookup service on the running overlay. Requires the admin bearer token.
+func (c *OverlayClient) RegisterLookupService(ctx context.Context, name string) error {
+	return c.doJSON(ctx, http.MethodPost, "/api/v1/admin/lookupServices", map[string]any{"name": name}, nil)
+}
+
+// UnregisterLookupService disables the named lookup service on the running overlay. Requires the admin bearer token.
+func (c *OverlayClient) UnregisterLookupService(ctx context.Context, name string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/lookupServices",
+		query:  map[string]string{"lookupService": name},
+	}, nil)
+}
+
+// LookupReplay is the outcome of a ReplayTopicIntoLookupService call.
+type LookupReplay struct {
+	Topic    string  `json:"topic"`
+	Service  string  `json:"service"`
+	Admitted int     `json:"admitted"`
+	Spent    int     `json:"spent"`
+	Score    float64 `json:"score"` // score of the last replayed output, to resume from after a failure
+}
+
+// ReplayTopicIntoLookupService backfills the named lookup service with the outputs stored for the topic, scored at
+// or after fromScore, by replaying their admissions and spends in score order. Requires the admin bearer token.
+func (c *OverlayClient) ReplayTopicIntoLookupService(ctx context.Context, topic, service string, fromScore float64) (*LookupReplay, error) {
+	var replay LookupReplay
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/lookupServices/replay", map[string]any{
+		"topic":     topic,
+		"service":   service,
+		"fromScore": fromScore,
+	}, &replay); err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
+
+// PinOutput pins the output, given as "txID.outputIndex", against pruning and eviction. Requires the admin bearer token.
+func (c *OverlayClient) PinOutput(ctx context.Context, topic, outpoint string) error {
+	return c.doJSON(ctx, http.MethodPost, "/api/v1/admin/pinnedOutputs", map[string]any{
+		"topic":    topic,
+		"outpoint": outpoint,
+	}, nil)
+}
+
+// UnpinOutput unpins the output, given as "txID.outputIndex". Requires the admin bearer token.
+func (c *OverlayClient) UnpinOutput(ctx context.Context, topic, outpoint string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/pinnedOutputs",
+		query:  map[string]string{"topic": topic, "outpoint": outpoint},
+	}, nil)
+}
+
+// pausedSyncTopicsResponse is the response of the endpoints pausing and resuming the sync of topics.
+type pausedSyncTopicsResponse struct {
+	Topics []string `json:"topics"`
+}
+
+// PausedSyncTopics returns the topics whose GASP sync and advertisement are paused. Requires the admin bearer token.
+func (c *OverlayClient) PausedSyncTopics(ctx context.Context) ([]string, error) {
+	var response pausedSyncTopicsResponse
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/pausedSyncTopics"}, &response); err != nil {
+		return nil, err
+	}
+	return response.Topics, nil
+}
+
+// PauseTopicSync pauses the GASP sync and advertisement of the topic until ResumeTopicSync is called,
+// returning the paused topics. Requires the admin bearer token.
+func (c *OverlayClient) PauseTopicSync(ctx context.Context, topic string) ([]string, error) {
+	var response pausedSyncTopicsResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/pausedSyncTopics", map[string]any{"topic": topic}, &response); err != nil {
+		return nil, err
+	}
+	return response.Topics, nil
+}
+
+// ResumeTopicSync resumes the GASP sync and advertisement of the topic, returning the topics still paused.
+// Requires the admin bearer token.
+func (c *OverlayClient) ResumeTopicSync(ctx context.Context, topic string) ([]string, error) {
+	var response pausedSyncTopicsResponse
+	err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/pausedSyncTopics",
+		query:  map[string]string{"topic": topic},
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Topics, nil
+}
+
+// Trackers are the SHIP and SLAP trackers of an overlay node.
+type Trackers struct {
+	SHIP []string `json:"ship"`
+	SLAP []string `json:"slap"`
+}
+
+// Trackers returns the SHIP and SLAP trackers of the overlay node. Requires the admin bearer token.
+func (c *OverlayClient) Trackers(ctx context.Context) (*Trackers, error) {
+	var trackers Trackers
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/trackers"}, &trackers); err != nil {
+		return nil, err
+	}
+	return &trackers, nil
+}
+
+// AddTracker adds the tracker of the kind, "ship" or "slap", to the overlay node, returning the resulting
+// trackers. The change is persisted when the storage of the node supports it. Requires the admin bearer token.
+func (c *OverlayClient) AddTracker(ctx context.Context, kind, url string) (*Trackers, error) {
+	var trackers Trackers
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/trackers", map[string]any{"kind": kind, "url": url}, &trackers); err != nil {
+		return nil, err
+	}
+	return &trackers, nil
+}
+
+// RemoveTracker removes the tracker of the kind, "ship" or "slap", from the overlay node, returning the
+// remaining trackers. Requires the admin bearer token.
+func (c *OverlayClient) RemoveTracker(ctx context.Context, kind, url string) (*Trackers, error) {
+	var trackers Trackers
+	err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/trackers",
+		query:  map[string]string{"kind": kind, "url": url},
+	}, &trackers)
+	if err != nil {
+		return nil, err
+	}
+	return &trackers, nil
+}
+
+// TopicQuota bounds the outputs a topic manager admits. Zero limits are disabled.
+type TopicQuota struct {
+	MaxOutputs      uint64 `json:"maxOutputs"`
+	MaxOutputsPerTx int    `json:"maxOutputsPerTx"`
+	MaxSatoshis     uint64 `json:"maxSatoshis"`
+	Action          string `json:"action"`
+}
+
+// TopicQuotas are the configured output quotas of the topics of an overlay node and the overrides set at runtime.
+type TopicQuotas struct {
+	Default   TopicQuota            `json:"default"`
+	Topics    map[string]TopicQuota `json:"topics"`
+	Overrides map[string]TopicQuota `json:"overrides"`
+}
+
+// TopicQuotas returns the output quotas of the topics of the overlay node. Requires the admin bearer token.
+func (c *OverlayClient) TopicQuotas(ctx context.Context) (*TopicQuotas, error) {
+	var quotas TopicQuotas
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/topicQuotas"}, &quotas); err != nil {
+		return nil, err
+	}
+	return &quotas, nil
+}
+
+// SetTopicQuota overrides the output quota of the topic until it is removed or the node restarts,
+// returning the resulting quotas. Requires the admin bearer token.
+func (c *OverlayClient) SetTopicQuota(ctx context.Context, topic string, quota TopicQuota) (*TopicQuotas, error) {
+	body := map[string]any{
+		"topic":           topic,
+		"maxOutputs":      quota.MaxOutputs,
+		"maxOutputsPerTx": quota.MaxOutputsPerTx,
+		"maxSatoshis":     quota.MaxSatoshis,
+		"action":          quota.Action,
+	}
+	var quotas TopicQuotas
+	if err := c.doJSON(ctx, http.MethodPut, "/api/v1/admin/topicQuotas", body, &quotas); err != nil {
+		return nil, err
+	}
+	return &quotas, nil
+}
+
+// RemoveTopicQuota removes the quota override of the topic, restoring its configured quota, and returns
+// the resulting quotas. Requires the admin bearer token.
+func (c *OverlayClient) RemoveTopicQuota(ctx context.Context, topic string) (*TopicQuotas, error) {
+	var quotas TopicQuotas
+	err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/topicQuotas",
+		query:  map[string]string{"topic": topic},
+	}, &quotas)
+	if err != nil {
+		return nil, err
+	}
+	return &quotas, nil
+}
+
+// SimulateReorg dry-runs a reorg of the given depth against the overlay's storage. Requires the admin bearer token.
+func (c *OverlayClient) SimulateReorg(ctx context.Context, depth uint32) (*ReorgSimulation, error) {
+	var simulation ReorgSimulation
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/api/v1/admin/reorgSimulation",
+		query:  map[string]string{"depth": strconv.FormatUint(uint64(depth), 10)},
+	}, &simulation)
+	if err != nil {
+		return nil, err
+	}
+	return &simulation, nil
+}
+
+// ListDeadLetters returns the submissions recorded in the overlay's dead-letter queue. Requires the admin bearer token.
+func (c *OverlayClient) ListDeadLetters(ctx context.Context) ([]DeadLetter, error) {
+	var response struct {
+		DeadLetters []DeadLetter `json:"deadLetters"`
+	}
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/deadLetters"}, &response); err != nil {
+		return nil, err
+	}
+	return response.DeadLetters, nil
+}
+
+// ReplayDeadLetter resubmits the dead letter with the given ID and returns the resulting STEAK.
+// Requires the admin bearer token.
+func (c *OverlayClient) ReplayDeadLetter(ctx context.Context, id string) (overlay.Steak, error) {
+	var response steakResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/deadLetters/replay", map[string]any{"id": id}, &response); err != nil {
+		return nil, err
+	}
+	return response.steak()
+}
+
+// RegisterWebhook subscribes the callback URL to the STEAK of submissions for the given topics, or for every
+// topic when none are given. An empty secret makes the overlay generate one; the returned webhook is the only
+// place it is exposed. Requires the admin bearer token.
+func (c *OverlayClient) RegisterWebhook(ctx context.Context, callbackURL string, topics []string, secret string) (*Webhook, error) {
+	body := map[string]any{"url": callbackURL}
+	if len(topics) > 0 {
+		body["topics"] = topics
+	}
+	if secret != "" {
+		body["secret"] = secret
+	}
+	var webhook Webhook
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/webhooks", body, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// ListWebhooks returns the webhook subscriptions of the overlay, without their secrets. Requires the admin bearer token.
+func (c *OverlayClient) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	var response struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/webhooks"}, &response); err != nil {
+		return nil, err
+	}
+	return response.Webhooks, nil
+}
+
+// UnregisterWebhook removes the webhook subscription with the given ID. Requires the admin bearer token.
+func (c *OverlayClient) UnregisterWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   "/api/v1/admin/webhooks",
+		query:  map[string]string{"id": id},
+	}, nil)
+}
+
+// PromoteStandby makes a warm standby overlay stop following its primary and accept writes.
+// Requires the admin bearer token.
+func (c *OverlayClient) PromoteStandby(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/api/v1/admin/promoteStandby"}, nil)
+}
+
+// ReloadConfig makes the overlay re-read its configuration file and apply the changed settings that do not
+// require a restart, returning their names. Requires the admin bearer token.
+func (c *OverlayClient) ReloadConfig(ctx context.Context) ([]string, error) {
+	var response struct {
+		Applied []string `json:"applied"`
+	}
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/admin/config/reload"}, &response); err != nil {
+		return nil, err
+	}
+	return response.Applied, nil
+}
+
+// PruneOutputs applies the overlay's retention policies immediately instead of waiting for the
+// background pruner. Requires the admin bearer token.
+func (c *OverlayClient) PruneOutputs(ctx context.Context) ([]PrunedTopic, error) {
+	var response struct {
+		Topics []PrunedTopic `json:"topics"`
+	}
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/api/v1/admin/pruneOutputs"}, &response); err != nil {
+		return nil, err
+	}
+	return response.Topics, nil
+}
+
+// AuditLog returns the entries of the overlay's audit log selected by the query, most recent first.
+// Requires the admin bearer token.
+func (c *OverlayClient) AuditLog(ctx context.Context, query AuditQuery) ([]AuditEntry, error) {
+	params := map[string]string{}
+	for key, value := range map[string]string{"action": query.Action, "caller": query.Caller, "txid": query.Txid} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	if !query.Since.IsZero() {
+		params["since"] = query.Since.Format(time.RFC3339Nano)
+	}
+	if !query.Until.IsZero() {
+		params["until"] = query.Until.Format(time.RFC3339Nano)
+	}
+	if query.Limit > 0 {
+		params["limit"] = strconv.Itoa(query.Limit)
+	}
+
+	var response struct {
+		Entries []AuditEntry `json:"entries"`
+	}
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/admin/auditLog", query: params}, &response); err != nil {
+		return nil, err
+	}
+	return response.Entries, nil
+}
+
+// OutputGraphNode is an output of an OutputGraph.
+type OutputGraphNode struct {
+	Outpoint    string `json:"outpoint"`
+	Depth       uint32 `json:"depth"`   // number of relations between the root and the output
+	Missing     bool   `json:"missing"` // referenced by a relation but no longer stored in the topic
+	Spent       bool   `json:"spent"`
+	Pinned      bool   `json:"pinned"`
+	BlockHeight uint32 `json:"blockHeight"`
+}
+
+// OutputGraphEdge is a relation of an OutputGraph: the transaction of To consumed From.
+type OutputGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// OutputGraph is the dependency graph of an output returned by OutputGraph.
+type OutputGraph struct {
+	Root      string            `json:"root"`
+	Topic     string            `json:"topic"`
+	Depth     uint32            `json:"depth"`
+	Truncated bool              `json:"truncated"` // cut short by the overlay's node limit before reaching the depth
+	Nodes     []OutputGraphNode `json:"nodes"`
+	Edges     []OutputGraphEdge `json:"edges"`
+}
+
+// OutputGraph returns the dependency graph of the output admitted to the topic, walked through the outputs its
+// transaction consumed and the outputs consuming it up to depth relations away, 0 meaning the overlay's default.
+// Requires the admin bearer token.
+func (c *OverlayClient) OutputGraph(ctx context.Context, outpoint *transaction.Outpoint, topic string, depth uint32) (*OutputGraph, error) {
+	query := map[string]string{"topic": topic}
+	if depth > 0 {
+		query["depth"] = strconv.FormatUint(uint64(depth), 10)
+	}
+
+	var graph OutputGraph
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/admin/graph/%s/%d", outpoint.Txid, outpoint.Index),
+		query:  query,
+	}, &graph)
+	if err != nil {
+		return nil, err
+	}
+	return &graph, nil
+}
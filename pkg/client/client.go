@@ -0,0 +1,312 @@
+// Package client provides a typed Go client for the overlay services HTTP API.
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried after a retryable failure.
+	DefaultMaxRetries = 2
+
+	// DefaultRetryBackoff is the delay before the first retry. It doubles with every attempt
+	// unless the server responds with a Retry-After header.
+	DefaultRetryBackoff = 250 * time.Millisecond
+)
+
+// DefaultResponseLimits bounds the responses decoded by the client when no limits are configured.
+// Lookup answers, GASP initial responses and UTXO histories may legitimately be large; every other response is small.
+var DefaultResponseLimits = ResponseLimits{
+	Default: jsonlimit.Limits{
+		MaxBytes:        16 * 1024 * 1024,
+		MaxStringLength: 1024 * 1024,
+		MaxArrayLength:  100_000,
+		MaxDepth:        32,
+	},
+	LookupAnswer: jsonlimit.Limits{
+		MaxBytes:        1000 * 1024 * 1024,
+		MaxStringLength: 500 * 1024 * 1024,
+		MaxArrayLength:  1_000_000,
+		MaxDepth:        32,
+	},
+	SyncResponse: jsonlimit.Limits{
+		MaxBytes:        256 * 1024 * 1024,
+		MaxStringLength: 1024,
+		MaxArrayLength:  2_000_000,
+		MaxDepth:        8,
+	},
+	UTXOHistory: jsonlimit.Limits{
+		MaxBytes:        64 * 1024 * 1024,
+		MaxStringLength: 48 * 1024 * 1024,
+		MaxArrayLength:  1024,
+		MaxDepth:        8,
+	},
+}
+
+// ResponseLimits bounds the JSON responses decoded by the client, per message type.
+// Unset limits fall back to DefaultResponseLimits; negative limits are disabled.
+// A response exceeding its limits fails with an error wrapping ErrInvalidResponse and jsonlimit.ErrLimitExceeded.
+type ResponseLimits struct {
+	Default      jsonlimit.Limits // every response without a dedicated limit
+	LookupAnswer jsonlimit.Limits // Lookup answers
+	SyncResponse jsonlimit.Limits // RequestSyncResponse GASP initial responses
+	UTXOHistory  jsonlimit.Limits // UTXOHistory hydrated BEEFs
+}
+
+var (
+	// ErrEmptyBaseURL is returned by NewOverlayClient when no base URL is given.
+	ErrEmptyBaseURL = errors.New("overlay client base URL cannot be empty")
+	// ErrInvalidResponse is returned when a successful response cannot be decoded.
+	ErrInvalidResponse = errors.New("invalid overlay API response")
+)
+
+// APIError is returned when the overlay responds with a non-2xx status code.
+// Errors of overlays answering with RFC 7807 problem details carry their machine-readable code and details.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Code       string         // machine-readable error code, e.g. ERR_UNKNOWN_TOPIC
+	Details    map[string]any // additional machine-readable information, e.g. the exceeded limit
+	RetryAfter time.Duration  // set from the Retry-After header of 503 and 429 responses
+
+	retryable *bool
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("overlay API responded with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("overlay API responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed when retried. It follows the retryable flag
+// of problem details responses and otherwise decides by the status code.
+func (e *APIError) Retryable() bool {
+	if e.retryable != nil {
+		return *e.retryable
+	}
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// Option configures an OverlayClient.
+type Option func(*OverlayClient)
+
+// WithHTTPClient sets the HTTP client used to send requests. Defaults to http.DefaultClient.
+func WithHTTPClient(client util.HTTPClient) Option {
+	return func(c *OverlayClient) {
+		c.HTTPClient = client
+	}
+}
+
+// WithBearerToken sets the bearer token sent with every request. The admin bearer token of the
+// overlay grants access to the admin endpoints and to trusted-only submission topics.
+func WithBearerToken(token string) Option {
+	return func(c *OverlayClient) {
+		c.BearerToken = token
+	}
+}
+
+// WithARCCallbackToken sets the token sent with ArcIngest requests.
+func WithARCCallbackToken(token string) Option {
+	return func(c *OverlayClient) {
+		c.ARCCallbackToken = token
+	}
+}
+
+// WithResponseLimits sets the limits enforced while decoding responses.
+func WithResponseLimits(limits ResponseLimits) Option {
+	return func(c *OverlayClient) {
+		c.ResponseLimits = limits
+	}
+}
+
+// WithRetries sets how many times retryable failures are retried and the initial backoff.
+// A negative maxRetries disables retries.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *OverlayClient) {
+		c.MaxRetries = maxRetries
+		c.RetryBackoff = backoff
+	}
+}
+
+// OverlayClient is a typed client for the overlay services HTTP API.
+// It retries transport failures, 429 and 5xx responses, honoring the Retry-After header.
+type OverlayClient struct {
+	BaseURL          string
+	HTTPClient       util.HTTPClient
+	BearerToken      string
+	ARCCallbackToken string
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	ResponseLimits   ResponseLimits
+}
+
+// NewOverlayClient creates an OverlayClient for the overlay served at baseURL, e.g. "https://overlay.example.com".
+func NewOverlayClient(baseURL string, opts ...Option) (*OverlayClient, error) {
+	if baseURL == "" {
+		return nil, ErrEmptyBaseURL
+	}
+
+	client := &OverlayClient{
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		HTTPClient:   http.DefaultClient,
+		MaxRetries:   DefaultMaxRetries,
+		RetryBackoff: DefaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client, nil
+}
+
+// request describes a single API call.
+type request struct {
+	method      string
+	path        string
+	query       map[string]string
+	headers     map[string]string
+	contentType string
+	body        []byte
+	token       string
+	limits      jsonlimit.Limits // limits of the response; unset limits fall back to ResponseLimits.Default
+}
+
+// do sends the request, retrying retryable failures, and decodes the JSON response into result when not nil.
+func (c *OverlayClient) do(ctx context.Context, r request, result any) error {
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, r, result)
+		if err == nil || attempt >= c.MaxRetries || !retryable(err) {
+			return err
+		}
+
+		wait := backoff
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *OverlayClient) send(ctx context.Context, r request, result any) error {
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, c.BaseURL+r.path, body)
+	if err != nil {
+		return permanentError{err}
+	}
+
+	if len(r.query) > 0 {
+		query := req.URL.Query()
+		for key, value := range r.query {
+			query.Set(key, value)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	for key, value := range r.headers {
+		req.Header.Set(key, value)
+	}
+	token := r.token
+	if token == "" {
+		token = c.BearerToken
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newAPIError(resp)
+	}
+	if result == nil {
+		return nil
+	}
+	limits := r.limits.Or(c.ResponseLimits.Default).Or(DefaultResponseLimits.Default)
+	if err := jsonlimit.Decode(resp.Body, result, limits); err != nil {
+		return permanentError{fmt.Errorf("%w: %w", ErrInvalidResponse, err)}
+	}
+	return nil
+}
+
+// permanentError marks failures that retrying the request cannot fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+func (c *OverlayClient) doJSON(ctx context.Context, method, path string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, request{method: method, path: path, contentType: "application/json", body: body}, result)
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var payload struct {
+		Detail    string         `json:"detail"`
+		Message   string         `json:"message"`
+		Code      string         `json:"code"`
+		Retryable *bool          `json:"retryable"`
+		Details   map[string]any `json:"details"`
+	}
+	if raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16)); err == nil {
+		if json.Unmarshal(raw, &payload) == nil {
+			apiErr.Message = cmp.Or(payload.Detail, payload.Message)
+			apiErr.Code = payload.Code
+			apiErr.Details = payload.Details
+			apiErr.retryable = payload.Retryable
+		} else {
+			apiErr.Message = strings.TrimSpace(string(raw))
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+	var permanent permanentError
+	return !errors.As(err, &permanent)
+}
@@ -0,0 +1,667 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/client"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/steak"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...client.Option) *client.OverlayClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewOverlayClient(srv.URL, append([]client.Option{client.WithRetries(2, time.Millisecond)}, opts...)...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestOverlayClient_SubmitTaggedBEEF(t *testing.T) {
+	// given:
+	beef := []byte{1, 2, 3}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, "/api/v1/submit", r.URL.Path)
+		require.Equal(t, "tm_a,tm_b", r.Header.Get("x-topics"))
+		require.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, beef, body)
+
+		_, _ = w.Write([]byte(`{"STEAK":{"tm_a":{"outputsToAdmit":[0],"coinsToRetain":[],"coinsRemoved":[1],"ancillaryTxIDs":[]}}}`))
+	}, client.WithBearerToken("token"))
+
+	var streamed overlay.Steak
+
+	// when:
+	steak, err := c.SubmitTaggedBEEF(context.Background(), overlay.TaggedBEEF{Beef: beef, Topics: []string{"tm_a", "tm_b"}}, func(steak overlay.Steak) {
+		streamed = steak
+	})
+
+	// then:
+	require.NoError(t, err)
+	expected := overlay.Steak{"tm_a": {OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{}, CoinsRemoved: []uint32{1}}}
+	require.Equal(t, expected, steak)
+	require.Equal(t, expected, streamed)
+}
+
+func TestOverlayClient_SubmitTaggedBEEF_ShouldDecodeNegotiatedSTEAKVersion(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, steak.Latest.String(), r.Header.Get(steak.Header))
+		w.Header().Set(steak.Header, "2")
+		_, _ = w.Write([]byte(`{"version":2,"topics":[{"topic":"tm_a","outputsToAdmit":[0],"coinsToRetain":[],"coinsRemoved":[1],"ancillaryTxids":[]}]}`))
+	})
+
+	// when:
+	submitted, err := c.SubmitTaggedBEEF(context.Background(), overlay.TaggedBEEF{Beef: []byte{1}, Topics: []string{"tm_a"}}, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, overlay.Steak{"tm_a": {OutputsToAdmit: []uint32{0}, CoinsRemoved: []uint32{1}}}, submitted)
+}
+
+func TestOverlayClient_EvaluateTaggedBEEF(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/submit", r.URL.Path)
+		require.Equal(t, "dry-run", r.URL.Query().Get("mode"))
+		require.Equal(t, "tm_a", r.Header.Get("x-topics"))
+
+		w.Header().Set(steak.Header, "2")
+		_, _ = w.Write([]byte(`{"version":2,"topics":[{"topic":"tm_a","outputsToAdmit":[0],"coinsToRetain":[],"coinsRemoved":[],"ancillaryTxids":[]}]}`))
+	})
+
+	// when:
+	evaluated, err := c.EvaluateTaggedBEEF(context.Background(), overlay.TaggedBEEF{Beef: []byte{1}, Topics: []string{"tm_a"}})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, overlay.Steak{"tm_a": {OutputsToAdmit: []uint32{0}}}, evaluated)
+}
+
+func TestOverlayClient_SubmitTaggedBEEFAsync(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/submit", r.URL.Path)
+		require.Equal(t, "async", r.URL.Query().Get("mode"))
+		require.Equal(t, "https://client.example/jobs", r.URL.Query().Get("callbackUrl"))
+		require.Equal(t, "tm_a", r.Header.Get("x-topics"))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"jobId":"job-1","status":"pending","topics":["tm_a"],"createdAt":"2025-01-01T00:00:00Z"}`))
+	})
+
+	// when:
+	job, err := c.SubmitTaggedBEEFAsync(context.Background(), overlay.TaggedBEEF{Beef: []byte{1}, Topics: []string{"tm_a"}}, "https://client.example/jobs")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &client.SubmitJob{
+		ID:        "job-1",
+		Status:    "pending",
+		Topics:    []string{"tm_a"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, job)
+}
+
+func TestOverlayClient_GetSubmitJob(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/submit/job-1", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"jobId":"job-1","status":"completed","topics":["tm_a"],"createdAt":"2025-01-01T00:00:00Z",` +
+			`"completedAt":"2025-01-01T00:00:01Z","STEAK":{"tm_a":{"outputsToAdmit":[0],"coinsToRetain":[],"coinsRemoved":[],"ancillaryTxIDs":[]}}}`))
+	})
+
+	// when:
+	job, err := c.GetSubmitJob(context.Background(), "job-1")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, "completed", job.Status)
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC), job.CompletedAt)
+	require.Equal(t, overlay.Steak{"tm_a": {OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{}, CoinsRemoved: []uint32{}}}, job.Steak)
+}
+
+func TestOverlayClient_GetLookupQuerySchema(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/lookup/ls_a/schema", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"type":"object","required":["name"]}`))
+	})
+
+	// when:
+	schema, err := c.GetLookupQuerySchema(context.Background(), "ls_a")
+
+	// then:
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"object","required":["name"]}`, string(schema))
+}
+
+func TestOverlayClient_ShouldRetry_WhenServiceUnavailable(t *testing.T) {
+	// given:
+	attempts := 0
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tm_a":{"name":"Topic A"}}`))
+	})
+
+	// when:
+	metadata, err := c.ListTopicManagers(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, map[string]*overlay.MetaData{"tm_a": {Name: "Topic A"}}, metadata)
+}
+
+func TestOverlayClient_ShouldNotRetry_WhenRequestRejected(t *testing.T) {
+	// given:
+	attempts := 0
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"bad question"}`))
+	})
+
+	// when:
+	answer, err := c.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_a", Query: json.RawMessage(`{}`)})
+
+	// then:
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal(t, "bad question", apiErr.Message)
+	require.Nil(t, answer)
+	require.Equal(t, 1, attempts)
+}
+
+func TestOverlayClient_ShouldReadProblemDetails_WhenRequestRejected(t *testing.T) {
+	// given:
+	attempts := 0
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"title":"Internal Server Error","status":500,"detail":"lookup service failed",` +
+			`"code":"ERR_INVALID_CONFIG","retryable":false,"details":{"settings":["port"]}}`))
+	})
+
+	// when:
+	answer, err := c.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_a", Query: json.RawMessage(`{}`)})
+
+	// then:
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	require.Equal(t, "lookup service failed", apiErr.Message)
+	require.Equal(t, "ERR_INVALID_CONFIG", apiErr.Code)
+	require.Equal(t, map[string]any{"settings": []any{"port"}}, apiErr.Details)
+	require.False(t, apiErr.Retryable())
+	require.Nil(t, answer)
+	require.Equal(t, 1, attempts)
+}
+
+func TestOverlayClient_ShouldReject_WhenLookupAnswerExceedsLimits(t *testing.T) {
+	// given:
+	attempts := 0
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		_, _ = w.Write([]byte(`{"type":"output-list","outputs":[{"beef":[],"outputIndex":0},{"beef":[],"outputIndex":1}]}`))
+	}, client.WithResponseLimits(client.ResponseLimits{LookupAnswer: jsonlimit.Limits{MaxArrayLength: 1}}))
+
+	// when:
+	answer, err := c.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_a", Query: json.RawMessage(`{}`)})
+
+	// then:
+	require.ErrorIs(t, err, client.ErrInvalidResponse)
+	require.ErrorIs(t, err, jsonlimit.ErrLimitExceeded)
+	require.Nil(t, answer)
+	require.Equal(t, 1, attempts)
+}
+
+func TestOverlayClient_RequestSyncResponse(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var initialRequest gasp.InitialRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&initialRequest))
+		require.Equal(t, "tm_a", r.Header.Get("X-BSV-Topic"))
+		require.InDelta(t, 10.0, initialRequest.Since, 0)
+
+		_, _ = w.Write([]byte(`{"UTXOList":[],"since":20}`))
+	})
+
+	// when:
+	response, err := c.RequestSyncResponse(context.Background(), "tm_a", &gasp.InitialRequest{Version: 1, Since: 10})
+
+	// then:
+	require.NoError(t, err)
+	require.InDelta(t, 20.0, response.Since, 0)
+}
+
+func TestOverlayClient_UTXOHistory(t *testing.T) {
+	// given:
+	depth := uint32(2)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Txid        string `json:"txid"`
+			OutputIndex uint32 `json:"outputIndex"`
+			Topic       string `json:"topic"`
+			Depth       uint32 `json:"depth"`
+		}
+		require.Equal(t, "/api/v1/history", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, uint32(1), body.OutputIndex)
+		require.Equal(t, "tm_a", body.Topic)
+		require.Equal(t, depth, body.Depth)
+
+		_, _ = w.Write([]byte(`{"txid":"` + body.Txid + `","outputIndex":1,"topic":"tm_a","beef":"AQI="}`))
+	})
+
+	// when:
+	beef, err := c.UTXOHistory(context.Background(), &transaction.Outpoint{Index: 1}, "tm_a", &depth)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02}, beef)
+}
+
+func TestOverlayClient_OutputStatus(t *testing.T) {
+	// given:
+	outpoint := &transaction.Outpoint{Index: 3}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/outputs/"+outpoint.Txid.String()+"/3", r.URL.Path)
+		require.Equal(t, "topic=tm_a", r.URL.RawQuery)
+
+		_, _ = w.Write([]byte(`{"txid":"` + outpoint.Txid.String() + `","vout":3,"topic":"tm_a","transactionApplied":true,"admitted":true,` +
+			`"spent":false,"blockHeight":0,"blockIdx":0,"consumedBy":[],"merkleState":"unmined","metadata":{"ticker":"TEST"}}`))
+	})
+
+	// when:
+	status, err := c.OutputStatus(context.Background(), outpoint, "tm_a")
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, status.Admitted)
+	require.Equal(t, "unmined", status.MerkleState)
+	require.Equal(t, map[string]string{"ticker": "TEST"}, status.Metadata)
+}
+
+func TestOverlayClient_ArcIngestBatch(t *testing.T) {
+	// given:
+	txid := chainhash.Hash{1}
+	isTxid := true
+	proof := &transaction.MerklePath{BlockHeight: 100, Path: [][]*transaction.PathElement{{{Offset: 0, Hash: &txid, Txid: &isTxid}}}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/arc-ingest/batch", r.URL.Path)
+
+		var entries []map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&entries))
+		require.Equal(t, []map[string]any{{"txid": txid.String(), "merklePath": proof.Hex(), "blockHeight": float64(100)}}, entries)
+
+		_, _ = w.Write([]byte(`{"results":[{"txid":"` + txid.String() + `","status":"error","code":"ERR_INVALID_INPUT","message":"rejected"}]}`))
+	})
+
+	// when:
+	results, err := c.ArcIngestBatch(context.Background(), []client.ARCIngestProof{{TxID: &txid, MerklePath: proof}})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []client.ARCIngestResult{{Txid: txid.String(), Status: "error", Code: "ERR_INVALID_INPUT", Message: "rejected"}}, results)
+}
+
+func TestOverlayClient_OutputGraph(t *testing.T) {
+	// given:
+	outpoint := &transaction.Outpoint{Index: 3}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/admin/graph/"+outpoint.Txid.String()+"/3", r.URL.Path)
+		require.Equal(t, "depth=2&topic=tm_a", r.URL.RawQuery)
+		require.Equal(t, "Bearer admin", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{"root":"` + outpoint.String() + `","topic":"tm_a","depth":2,"truncated":false,` +
+			`"nodes":[{"outpoint":"` + outpoint.String() + `","depth":0,"missing":false,"spent":true,"pinned":false,"blockHeight":7}],"edges":[]}`))
+	}, client.WithBearerToken("admin"))
+
+	// when:
+	graph, err := c.OutputGraph(context.Background(), outpoint, "tm_a", 2)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &client.OutputGraph{
+		Root:  outpoint.String(),
+		Topic: "tm_a",
+		Depth: 2,
+		Nodes: []client.OutputGraphNode{{Outpoint: outpoint.String(), Spent: true, BlockHeight: 7}},
+		Edges: []client.OutputGraphEdge{},
+	}, graph)
+}
+
+func TestOverlayClient_OutputsExist(t *testing.T) {
+	// given:
+	outpoints := []*transaction.Outpoint{{Index: 0}, {Index: 1}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Topic       string   `json:"topic"`
+			Outpoints   []string `json:"outpoints"`
+			UnspentOnly bool     `json:"unspentOnly"`
+		}
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/outputs/exists", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "tm_a", body.Topic)
+		require.Equal(t, []string{outpoints[0].String(), outpoints[1].String()}, body.Outpoints)
+		require.True(t, body.UnspentOnly)
+
+		_, _ = w.Write([]byte(`{"exists":[true,false]}`))
+	})
+
+	// when:
+	exists, err := c.OutputsExist(context.Background(), outpoints, "tm_a", true)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false}, exists)
+}
+
+func TestOverlayClient_AdminCalls(t *testing.T) {
+	tests := map[string]struct {
+		call           func(c *client.OverlayClient) error
+		expectedMethod string
+		expectedPath   string
+		expectedQuery  string
+	}{
+		"Unregisters a topic manager": {
+			call:           func(c *client.OverlayClient) error { return c.UnregisterTopicManager(context.Background(), "tm_a") },
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/api/v1/admin/topicManagers",
+			expectedQuery:  "topicManager=tm_a",
+		},
+		"Replays a topic into a lookup service": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.ReplayTopicIntoLookupService(context.Background(), "tm_a", "ls_a", 0)
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/lookupServices/replay",
+		},
+		"Pins an output": {
+			call:           func(c *client.OverlayClient) error { return c.PinOutput(context.Background(), "tm_a", "00.0") },
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/pinnedOutputs",
+		},
+		"Pauses the sync of a topic": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.PauseTopicSync(context.Background(), "tm_a")
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/pausedSyncTopics",
+		},
+		"Resumes the sync of a topic": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.ResumeTopicSync(context.Background(), "tm_a")
+				return err
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/api/v1/admin/pausedSyncTopics",
+			expectedQuery:  "topic=tm_a",
+		},
+		"Adds a tracker": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.AddTracker(context.Background(), "slap", "https://tracker.example")
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/trackers",
+		},
+		"Removes a tracker": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.RemoveTracker(context.Background(), "ship", "https://tracker.example")
+				return err
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/api/v1/admin/trackers",
+			expectedQuery:  "kind=ship&url=https%3A%2F%2Ftracker.example",
+		},
+		"Overrides a topic quota": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.SetTopicQuota(context.Background(), "tm_a", client.TopicQuota{MaxOutputsPerTx: 10})
+				return err
+			},
+			expectedMethod: http.MethodPut,
+			expectedPath:   "/api/v1/admin/topicQuotas",
+		},
+		"Removes a topic quota override": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.RemoveTopicQuota(context.Background(), "tm_a")
+				return err
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/api/v1/admin/topicQuotas",
+			expectedQuery:  "topic=tm_a",
+		},
+		"Starts a GASP sync": {
+			call:           func(c *client.OverlayClient) error { return c.StartGASPSync(context.Background()) },
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/startGASPSync",
+		},
+		"Syncs a topic": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.SyncTopic(context.Background(), "tm_a", []string{"https://peer.example"}, nil)
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/syncTopic",
+		},
+		"Polls a topic sync": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.GetTopicSyncJob(context.Background(), "job-1")
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/syncTopic/job-1",
+		},
+		"Prunes outputs": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.PruneOutputs(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/pruneOutputs",
+		},
+		"Reloads the configuration": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.ReloadConfig(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/config/reload",
+		},
+		"Plans advertisements": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.PlanAdvertisements(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/advertisementPlan",
+		},
+		"Lists advertisements": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.ListAdvertisements(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/advertisements",
+		},
+		"Creates an advertisement": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.CreateAdvertisement(context.Background(), overlay.ProtocolSHIP, "tm_a")
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/advertisements",
+		},
+		"Revokes an advertisement": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.RevokeAdvertisement(context.Background(), "00.1")
+				return err
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/api/v1/admin/advertisements",
+			expectedQuery:  "outpoint=00.1",
+		},
+		"Lists the broadcast queue": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.BroadcastQueue(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/broadcastQueue",
+		},
+		"Queries the audit log": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.AuditLog(context.Background(), client.AuditQuery{Caller: "admin", Limit: 10})
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/auditLog",
+			expectedQuery:  "caller=admin&limit=10",
+		},
+		"Reports the topic statistics": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.TopicStats(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/stats",
+		},
+		"Reports the GASP sync status": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.GASPSyncStatus(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/syncStatus",
+		},
+		"Registers a webhook": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.RegisterWebhook(context.Background(), "https://example.com/steak", []string{"tm_a"}, "")
+				return err
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/webhooks",
+		},
+		"Lists webhooks": {
+			call: func(c *client.OverlayClient) error {
+				_, err := c.ListWebhooks(context.Background())
+				return err
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/api/v1/admin/webhooks",
+		},
+		"Unregisters a webhook": {
+			call:           func(c *client.OverlayClient) error { return c.UnregisterWebhook(context.Background(), "id") },
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/api/v1/admin/webhooks",
+			expectedQuery:  "id=id",
+		},
+		"Promotes a standby": {
+			call:           func(c *client.OverlayClient) error { return c.PromoteStandby(context.Background()) },
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/api/v1/admin/promoteStandby",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tc.expectedMethod, r.Method)
+				require.Equal(t, tc.expectedPath, r.URL.Path)
+				require.Equal(t, tc.expectedQuery, r.URL.RawQuery)
+				require.Equal(t, "Bearer admin", r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte(`{"message":"OK"}`))
+			}, client.WithBearerToken("admin"))
+
+			// when:
+			err := tc.call(c)
+
+			// then:
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewOverlayClient_ShouldFail_WhenBaseURLEmpty(t *testing.T) {
+	// when:
+	c, err := client.NewOverlayClient("")
+
+	// then:
+	require.ErrorIs(t, err, client.ErrEmptyBaseURL)
+	require.Nil(t, c)
+}
+
+func TestOverlayClient_LookupPage_ShouldSendLimitAndCursor(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/lookup", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("limit"))
+		require.Equal(t, "cursor", r.URL.Query().Get("cursor"))
+		_, _ = w.Write([]byte(`{"type":"output-list","outputs":[{"beef":"AQ==","outputIndex":3}],"result":"","nextCursor":"next"}`))
+	})
+
+	// when:
+	answer, next, err := c.LookupPage(context.Background(), &lookup.LookupQuestion{Service: "ls_a", Query: json.RawMessage(`{}`)}, 2, "cursor")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, "next", next)
+	require.Equal(t, lookup.AnswerTypeOutputList, answer.Type)
+	require.Equal(t, []*lookup.OutputListItem{{Beef: []byte{1}, OutputIndex: 3}}, answer.Outputs)
+}
+
+func TestOverlayClient_LookupHydrated_ShouldSendHydrationAndDepth(t *testing.T) {
+	// given:
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/lookup", r.URL.Path)
+		require.Equal(t, "full", r.URL.Query().Get("hydrate"))
+		require.Equal(t, "1", r.URL.Query().Get("depth"))
+		_, _ = w.Write([]byte(`{"type":"output-list","outputs":[{"beef":"AQ==","outputIndex":3,"txid":"ab","offChainValues":"Ag=="}],"result":""}`))
+	})
+	depth := uint32(1)
+
+	// when:
+	outputs, err := c.LookupHydrated(context.Background(), &lookup.LookupQuestion{Service: "ls_a", Query: json.RawMessage(`{}`)}, "", &depth)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*client.HydratedOutput{{Txid: "ab", OutputIndex: 3, Beef: []byte{1}, OffChainValues: []byte{2}}}, outputs)
+}
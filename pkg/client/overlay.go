@@ -0,0 +1,517 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/steak"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// OnSteakReady is called with the STEAK of a submitted transaction as soon as the overlay returns it.
+type OnSteakReady func(steak overlay.Steak)
+
+// admittanceInstructions mirrors the JSON encoding of overlay.AdmittanceInstructions used by the overlay API.
+type admittanceInstructions struct {
+	OutputsToAdmit []uint32 `json:"outputsToAdmit"`
+	CoinsToRetain  []uint32 `json:"coinsToRetain"`
+	CoinsRemoved   []uint32 `json:"coinsRemoved"`
+	AncillaryTxIDs []string `json:"ancillaryTxIDs"`
+}
+
+// steakResponse mirrors the JSON encoding of a STEAK returned by the overlay API.
+type steakResponse struct {
+	STEAK map[string]admittanceInstructions `json:"STEAK"`
+}
+
+func (r steakResponse) steak() (overlay.Steak, error) {
+	steak := make(overlay.Steak, len(r.STEAK))
+	for topic, instructions := range r.STEAK {
+		admit := &overlay.AdmittanceInstructions{
+			OutputsToAdmit: instructions.OutputsToAdmit,
+			CoinsToRetain:  instructions.CoinsToRetain,
+			CoinsRemoved:   instructions.CoinsRemoved,
+		}
+		for _, txid := range instructions.AncillaryTxIDs {
+			hash, err := chainhash.NewHashFromHex(txid)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+			}
+			admit.AncillaryTxids = append(admit.AncillaryTxids, hash)
+		}
+		steak[topic] = admit
+	}
+	return steak, nil
+}
+
+// negotiatedSteak decodes a STEAK in the version the overlay answered the X-STEAK-Version header with,
+// recognizing the version 1 shape returned by overlays predating the header.
+type negotiatedSteak struct {
+	steak overlay.Steak
+}
+
+func (n *negotiatedSteak) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Version steak.Version `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	version := steak.Version1
+	if probe.Version != 0 {
+		version = probe.Version
+	}
+	decoded, err := steak.UnmarshalJSON(data, version)
+	if err != nil {
+		return err
+	}
+	n.steak = decoded
+	return nil
+}
+
+// SubmitTaggedBEEF submits the tagged BEEF to the overlay and returns the resulting STEAK, negotiating the
+// latest STEAK serialization the overlay supports. When onSteakReady is not nil, it is called with the STEAK
+// before SubmitTaggedBEEF returns.
+func (c *OverlayClient) SubmitTaggedBEEF(ctx context.Context, taggedBEEF overlay.TaggedBEEF, onSteakReady OnSteakReady) (overlay.Steak, error) {
+	var response negotiatedSteak
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/v1/submit",
+		headers: map[string]string{
+			"x-topics":   strings.Join(taggedBEEF.Topics, ","),
+			steak.Header: steak.Latest.String(),
+		},
+		contentType: "application/octet-stream",
+		body:        taggedBEEF.Beef,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if onSteakReady != nil {
+		onSteakReady(response.steak)
+	}
+	return response.steak, nil
+}
+
+// EvaluateTaggedBEEF submits the tagged BEEF to the overlay in the dry-run mode and returns the STEAK its
+// submission would result in. The overlay verifies the transaction without storing or broadcasting it.
+func (c *OverlayClient) EvaluateTaggedBEEF(ctx context.Context, taggedBEEF overlay.TaggedBEEF) (overlay.Steak, error) {
+	var response negotiatedSteak
+	err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/v1/submit",
+		query:  map[string]string{"mode": "dry-run"},
+		headers: map[string]string{
+			"x-topics":   strings.Join(taggedBEEF.Topics, ","),
+			steak.Header: steak.Latest.String(),
+		},
+		contentType: "application/octet-stream",
+		body:        taggedBEEF.Beef,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.steak, nil
+}
+
+// SubmitJob is an asynchronous submission returned by SubmitTaggedBEEFAsync and GetSubmitJob.
+type SubmitJob struct {
+	ID          string
+	Status      string // "pending", "processing", "completed" or "failed"
+	Topics      []string
+	Steak       overlay.Steak // set once the job completed
+	Error       string        // set once the job failed
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+// submitJobResponse mirrors the JSON encoding of a SubmitJob returned by the overlay API.
+type submitJobResponse struct {
+	steakResponse
+	JobID       string     `json:"jobId"`
+	Status      string     `json:"status"`
+	Topics      []string   `json:"topics"`
+	Error       string     `json:"error"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
+
+func (r submitJobResponse) job() (*SubmitJob, error) {
+	job := &SubmitJob{
+		ID:        r.JobID,
+		Status:    r.Status,
+		Topics:    r.Topics,
+		Error:     r.Error,
+		CreatedAt: r.CreatedAt,
+	}
+	if r.CompletedAt != nil {
+		job.CompletedAt = *r.CompletedAt
+	}
+	if r.STEAK != nil {
+		steak, err := r.steak()
+		if err != nil {
+			return nil, err
+		}
+		job.Steak = steak
+	}
+	return job, nil
+}
+
+// SubmitTaggedBEEFAsync enqueues the tagged BEEF for asynchronous processing by the overlay and returns
+// the pending job without waiting for the STEAK; poll it with GetSubmitJob. A non-empty callbackURL
+// additionally receives the finished job from the overlay.
+func (c *OverlayClient) SubmitTaggedBEEFAsync(ctx context.Context, taggedBEEF overlay.TaggedBEEF, callbackURL string) (*SubmitJob, error) {
+	query := map[string]string{"mode": "async"}
+	if callbackURL != "" {
+		query["callbackUrl"] = callbackURL
+	}
+
+	var response submitJobResponse
+	err := c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/submit",
+		query:       query,
+		headers:     map[string]string{"x-topics": strings.Join(taggedBEEF.Topics, ",")},
+		contentType: "application/octet-stream",
+		body:        taggedBEEF.Beef,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.job()
+}
+
+// GetSubmitJob returns the asynchronous submission with the given job ID, including its STEAK once completed.
+func (c *OverlayClient) GetSubmitJob(ctx context.Context, jobID string) (*SubmitJob, error) {
+	var response submitJobResponse
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/api/v1/submit/" + url.PathEscape(jobID),
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.job()
+}
+
+// Lookup asks the overlay's lookup service the given question.
+func (c *OverlayClient) Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	body, err := json.Marshal(question)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer lookup.LookupAnswer
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/lookup",
+		contentType: "application/json",
+		body:        body,
+		limits:      c.ResponseLimits.LookupAnswer.Or(DefaultResponseLimits.LookupAnswer),
+	}, &answer)
+	if err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+// LookupPage asks the overlay's lookup service the given question and returns at most limit outputs of the answer,
+// or every remaining output when limit is zero, starting at the cursor returned with the previous page, or at the
+// first output when cursor is empty. It also returns the cursor of the next page, empty after the last page.
+func (c *OverlayClient) LookupPage(ctx context.Context, question *lookup.LookupQuestion, limit uint32, cursor string) (*lookup.LookupAnswer, string, error) {
+	body, err := json.Marshal(question)
+	if err != nil {
+		return nil, "", err
+	}
+	query := make(map[string]string, 2)
+	if limit > 0 {
+		query["limit"] = strconv.FormatUint(uint64(limit), 10)
+	}
+	if cursor != "" {
+		query["cursor"] = cursor
+	}
+
+	var page struct {
+		lookup.LookupAnswer
+
+		NextCursor string `json:"nextCursor"`
+	}
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/lookup",
+		query:       query,
+		contentType: "application/json",
+		body:        body,
+		limits:      c.ResponseLimits.LookupAnswer.Or(DefaultResponseLimits.LookupAnswer),
+	}, &page)
+	if err != nil {
+		return nil, "", err
+	}
+	return &page.LookupAnswer, page.NextCursor, nil
+}
+
+// HydratedOutput is an output of a lookup answer returned by LookupHydrated.
+type HydratedOutput struct {
+	Txid           string            `json:"txid"` // empty for outputs listed by the lookup service rather than hydrated by the overlay
+	OutputIndex    uint32            `json:"outputIndex"`
+	Beef           []byte            `json:"beef"`  // set when hydrated in full
+	RawTx          []byte            `json:"rawTx"` // set when hydrated with "rawtx"
+	Metadata       map[string]string `json:"metadata"`
+	OffChainValues []byte            `json:"offChainValues"` // off-chain values submitted with the transaction, if any
+}
+
+// LookupHydrated asks the overlay's lookup service the given question and returns the outputs of the answer
+// hydrated as selected by hydrate: "none" and "txid" return outpoints only, "rawtx" the raw transaction of
+// each output and "full", or empty, its BEEF. With full hydration, a non-nil depth bounds the ancestor levels
+// included in the BEEF. Clients needing only outpoints thereby avoid the cost of assembling BEEF.
+func (c *OverlayClient) LookupHydrated(ctx context.Context, question *lookup.LookupQuestion, hydrate string, depth *uint32) ([]*HydratedOutput, error) {
+	body, err := json.Marshal(question)
+	if err != nil {
+		return nil, err
+	}
+	query := map[string]string{"hydrate": hydrate}
+	if hydrate == "" {
+		query["hydrate"] = "full"
+	}
+	if depth != nil {
+		query["depth"] = strconv.FormatUint(uint64(*depth), 10)
+	}
+
+	var answer struct {
+		Outputs []*HydratedOutput `json:"outputs"`
+	}
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/lookup",
+		query:       query,
+		contentType: "application/json",
+		body:        body,
+		limits:      c.ResponseLimits.LookupAnswer.Or(DefaultResponseLimits.LookupAnswer),
+	}, &answer)
+	if err != nil {
+		return nil, err
+	}
+	return answer.Outputs, nil
+}
+
+// GetLookupQuerySchema returns the JSON Schema the overlay validates the queries of the lookup service against.
+func (c *OverlayClient) GetLookupQuerySchema(ctx context.Context, service string) (json.RawMessage, error) {
+	var schema json.RawMessage
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/api/v1/lookup/" + url.PathEscape(service) + "/schema",
+	}, &schema)
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// UTXOHistory returns the BEEF of the output admitted to the topic, hydrated with its ancestors admitted
+// to the topic up to the given depth. A nil depth hydrates up to the limit configured by the overlay.
+func (c *OverlayClient) UTXOHistory(ctx context.Context, outpoint *transaction.Outpoint, topic string, depth *uint32) ([]byte, error) {
+	body, err := json.Marshal(map[string]any{
+		"txid":        outpoint.Txid.String(),
+		"outputIndex": outpoint.Index,
+		"topic":       topic,
+		"depth":       depth,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var history struct {
+		Beef []byte `json:"beef"`
+	}
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/history",
+		contentType: "application/json",
+		body:        body,
+		limits:      c.ResponseLimits.UTXOHistory.Or(DefaultResponseLimits.UTXOHistory),
+	}, &history)
+	if err != nil {
+		return nil, err
+	}
+	return history.Beef, nil
+}
+
+// OutputStatus is the status of an outpoint in a topic returned by OutputStatus.
+type OutputStatus struct {
+	Txid               string            `json:"txid"`
+	Vout               uint32            `json:"vout"`
+	Topic              string            `json:"topic"`
+	TransactionApplied bool              `json:"transactionApplied"`
+	Admitted           bool              `json:"admitted"`
+	Spent              bool              `json:"spent"`
+	BlockHeight        uint32            `json:"blockHeight"`
+	BlockIdx           uint64            `json:"blockIdx"`
+	ConsumedBy         []string          `json:"consumedBy"`
+	MerkleState        string            `json:"merkleState,omitempty"` // "unmined", "mined", "invalidated" or "immutable"; empty when not admitted
+	Metadata           map[string]string `json:"metadata,omitempty"`    // tags set by the topic manager, if any
+}
+
+// OutputStatus reports whether the transaction of the outpoint was applied to the topic and, when the output
+// was admitted, its spent state, block position, consumers and merkle state.
+func (c *OverlayClient) OutputStatus(ctx context.Context, outpoint *transaction.Outpoint, topic string) (*OutputStatus, error) {
+	var status OutputStatus
+	err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/outputs/%s/%d", outpoint.Txid, outpoint.Index),
+		query:  map[string]string{"topic": topic},
+	}, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// OutputsExist reports, for each outpoint in order, whether it is held by the topic. When unspentOnly is set,
+// spent outputs are reported as missing. The overlay checks at most 1000 outpoints per call.
+func (c *OverlayClient) OutputsExist(ctx context.Context, outpoints []*transaction.Outpoint, topic string, unspentOnly bool) ([]bool, error) {
+	requested := make([]string, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		requested = append(requested, outpoint.String())
+	}
+	body, err := json.Marshal(map[string]any{
+		"topic":       topic,
+		"outpoints":   requested,
+		"unspentOnly": unspentOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Exists []bool `json:"exists"`
+	}
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/outputs/exists",
+		contentType: "application/json",
+		body:        body,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Exists, nil
+}
+
+// ListTopicManagers returns the metadata of the topic managers hosted by the overlay.
+func (c *OverlayClient) ListTopicManagers(ctx context.Context) (map[string]*overlay.MetaData, error) {
+	var metadata map[string]*overlay.MetaData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/listTopicManagers"}, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// ListLookupServiceProviders returns the metadata of the lookup services hosted by the overlay.
+func (c *OverlayClient) ListLookupServiceProviders(ctx context.Context) (map[string]*overlay.MetaData, error) {
+	var metadata map[string]*overlay.MetaData
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/api/v1/listLookupServiceProviders"}, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// RequestSyncResponse requests the overlay's GASP initial response for the topic.
+func (c *OverlayClient) RequestSyncResponse(ctx context.Context, topic string, initialRequest *gasp.InitialRequest) (*gasp.InitialResponse, error) {
+	body, err := json.Marshal(initialRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var response gasp.InitialResponse
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/requestSyncResponse",
+		headers:     map[string]string{"X-BSV-Topic": topic},
+		contentType: "application/json",
+		body:        body,
+		limits:      c.ResponseLimits.SyncResponse.Or(DefaultResponseLimits.SyncResponse),
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ArcIngest notifies the overlay of the merkle proof of a mined transaction.
+// It authenticates with the ARC callback token.
+func (c *OverlayClient) ArcIngest(ctx context.Context, txid *chainhash.Hash, merklePath *transaction.MerklePath) error {
+	body, err := json.Marshal(map[string]any{
+		"txid":        txid.String(),
+		"merklePath":  merklePath.Hex(),
+		"blockHeight": merklePath.BlockHeight,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/arc-ingest",
+		contentType: "application/json",
+		body:        body,
+		token:       c.ARCCallbackToken,
+	}, nil)
+}
+
+// ARCIngestProof is the merkle proof of a mined transaction, as delivered by ArcIngestBatch.
+type ARCIngestProof struct {
+	TxID       *chainhash.Hash
+	MerklePath *transaction.MerklePath
+}
+
+// ARCIngestResult is the outcome of an ARCIngestProof of a batch.
+type ARCIngestResult struct {
+	Txid    string `json:"txid"`
+	Status  string `json:"status"`         // "success" or "error"
+	Code    string `json:"code,omitempty"` // machine-readable code of the error, if any
+	Message string `json:"message"`
+}
+
+// ArcIngestBatch notifies the overlay of the merkle proofs of several mined transactions at once
+// and returns the outcome of each proof, in order. Proofs fail independently of each other.
+// It authenticates with the ARC callback token.
+func (c *OverlayClient) ArcIngestBatch(ctx context.Context, proofs []ARCIngestProof) ([]ARCIngestResult, error) {
+	entries := make([]map[string]any, len(proofs))
+	for i, proof := range proofs {
+		entries[i] = map[string]any{
+			"txid":        proof.TxID.String(),
+			"merklePath":  proof.MerklePath.Hex(),
+			"blockHeight": proof.MerklePath.BlockHeight,
+		}
+	}
+	body, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Results []ARCIngestResult `json:"results"`
+	}
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/api/v1/arc-ingest/batch",
+		contentType: "application/json",
+		body:        body,
+		token:       c.ARCCallbackToken,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
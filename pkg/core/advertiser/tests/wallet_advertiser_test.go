@@ -0,0 +1,223 @@
+package advertiser_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/discovery"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWallet funds actions with a dummy input and tracks the basket outputs it created,
+// delegating key operations to a proto wallet.
+type fakeWallet struct {
+	*wallet.CompletedProtoWallet
+
+	t       *testing.T
+	beef    *transaction.Beef
+	basket  []wallet.Output
+	pending *transaction.Transaction
+}
+
+func newFakeWallet(t *testing.T) *fakeWallet {
+	t.Helper()
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewCompletedProtoWallet(key)
+	require.NoError(t, err)
+	return &fakeWallet{CompletedProtoWallet: w, t: t, beef: transaction.NewBeefV2()}
+}
+
+func (w *fakeWallet) atomicBEEF(tx *transaction.Transaction) []byte {
+	beef, err := transaction.NewBeefFromTransaction(tx)
+	require.NoError(w.t, err)
+	bytes, err := beef.AtomicBytes(tx.TxID())
+	require.NoError(w.t, err)
+	return bytes
+}
+
+func (w *fakeWallet) CreateAction(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+	if len(args.Inputs) == 0 {
+		tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(1).TX()
+		for _, output := range args.Outputs {
+			tx.AddOutput(&transaction.TransactionOutput{Satoshis: output.Satoshis, LockingScript: script.NewFromBytes(output.LockingScript)})
+		}
+		for i, output := range args.Outputs {
+			if output.Basket != "" {
+				w.basket = append(w.basket, wallet.Output{Outpoint: transaction.Outpoint{Txid: *tx.TxID(), Index: uint32(i + 1)}, Spendable: true}) //nolint:gosec // test index
+			}
+		}
+		txBEEF := w.atomicBEEF(tx)
+		require.NoError(w.t, w.beef.MergeBeefBytes(txBEEF))
+		return &wallet.CreateActionResult{Txid: *tx.TxID(), Tx: txBEEF}, nil
+	}
+
+	inputBEEF, err := transaction.NewBeefFromBytes(args.InputBEEF)
+	require.NoError(w.t, err)
+	tx := transaction.NewTransaction()
+	for _, input := range args.Inputs {
+		txid := input.Outpoint.Txid
+		tx.AddInput(&transaction.TransactionInput{
+			SourceTXID:        &txid,
+			SourceTxOutIndex:  input.Outpoint.Index,
+			SourceTransaction: inputBEEF.FindTransactionByHash(&txid),
+			SequenceNumber:    transaction.DefaultSequenceNumber,
+		})
+	}
+	change, err := script.NewFromHex("76a914000000000000000000000000000000000000000088ac")
+	require.NoError(w.t, err)
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: change})
+	w.pending = tx
+	return &wallet.CreateActionResult{SignableTransaction: &wallet.SignableTransaction{Tx: w.atomicBEEF(tx), Reference: []byte("ref")}}, nil
+}
+
+func (w *fakeWallet) SignAction(_ context.Context, args wallet.SignActionArgs, _ string) (*wallet.SignActionResult, error) {
+	for vin, spend := range args.Spends {
+		w.pending.Inputs[vin].UnlockingScript = script.NewFromBytes(spend.UnlockingScript)
+	}
+	return &wallet.SignActionResult{Txid: *w.pending.TxID(), Tx: w.atomicBEEF(w.pending)}, nil
+}
+
+func (w *fakeWallet) ListOutputs(_ context.Context, _ wallet.ListOutputsArgs, _ string) (*wallet.ListOutputsResult, error) {
+	bytes, err := w.beef.Bytes()
+	require.NoError(w.t, err)
+	return &wallet.ListOutputsResult{TotalOutputs: uint32(len(w.basket)), BEEF: bytes, Outputs: w.basket}, nil //nolint:gosec // test count
+}
+
+func newWalletAdvertiser(t *testing.T, w wallet.Interface, cfg advertiser.WalletAdvertiserConfig) *advertiser.WalletAdvertiser {
+	t.Helper()
+
+	if cfg.Domain == "" {
+		cfg.Domain = "https://overlay.example.com"
+	}
+	a, err := advertiser.NewWalletAdvertiser(w, cfg)
+	require.NoError(t, err)
+	return a
+}
+
+func TestNewWalletAdvertiser_ShouldRejectInvalidConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg           advertiser.WalletAdvertiserConfig
+		expectedError error
+	}{
+		"http domain": {
+			cfg:           advertiser.WalletAdvertiserConfig{Domain: "http://overlay.example.com"},
+			expectedError: advertiser.ErrInvalidAdvertiserDomain,
+		},
+		"missing domain": {
+			cfg:           advertiser.WalletAdvertiserConfig{},
+			expectedError: advertiser.ErrInvalidAdvertiserDomain,
+		},
+		"private key that is not hex": {
+			cfg:           advertiser.WalletAdvertiserConfig{Domain: "https://overlay.example.com", PrivateKey: "not a key"},
+			expectedError: advertiser.ErrInvalidAdvertiserPrivateKey,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			a, err := advertiser.NewWalletAdvertiser(newFakeWallet(t), tc.cfg)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Nil(t, a)
+		})
+	}
+}
+
+func TestWalletAdvertiser_CreateAdvertisements_ShouldCreateAdvertisementsAdmittedByDiscovery(t *testing.T) {
+	// given:
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	a := newWalletAdvertiser(t, newFakeWallet(t), advertiser.WalletAdvertiserConfig{PrivateKey: key.Hex()})
+
+	// when:
+	taggedBEEF, err := a.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_example"},
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{discovery.SHIPTopic, discovery.SLAPTopic}, taggedBEEF.Topics)
+
+	tx, err := transaction.NewTransactionFromBEEF(taggedBEEF.Beef)
+	require.NoError(t, err)
+	ship, err := discovery.ParseAdvertisement(tx.Outputs[1].LockingScript, overlay.ProtocolSHIP)
+	require.NoError(t, err)
+	require.Equal(t, key.PubKey().ToDERHex(), ship.IdentityKey)
+	require.Equal(t, "https://overlay.example.com", ship.Domain)
+	require.Equal(t, "tm_example", ship.TopicOrService)
+
+	slap, err := discovery.ParseAdvertisement(tx.Outputs[2].LockingScript, overlay.ProtocolSLAP)
+	require.NoError(t, err)
+	require.Equal(t, "ls_example", slap.TopicOrService)
+}
+
+func TestWalletAdvertiser_ShouldFindAndRevokeItsAdvertisements(t *testing.T) {
+	// given:
+	w := newFakeWallet(t)
+	a := newWalletAdvertiser(t, w, advertiser.WalletAdvertiserConfig{})
+	created, err := a.CreateAdvertisements([]*advertiser.AdvertisementData{
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_example"},
+		{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_example"},
+		{Protocol: overlay.ProtocolSHIP, TopicOrServiceName: "tm_other"},
+	})
+	require.NoError(t, err)
+	createdTx, err := transaction.NewTransactionFromBEEF(created.Beef)
+	require.NoError(t, err)
+
+	// when:
+	advertisements, err := a.FindAllAdvertisements(overlay.ProtocolSHIP)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, advertisements, 2)
+	require.Equal(t, "tm_example", advertisements[0].TopicOrService)
+	require.Equal(t, uint32(1), advertisements[0].OutputIndex)
+	require.Equal(t, "tm_other", advertisements[1].TopicOrService)
+	require.Equal(t, uint32(3), advertisements[1].OutputIndex)
+
+	// when:
+	revocation, err := a.RevokeAdvertisements(advertisements)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{discovery.SHIPTopic}, revocation.Topics)
+
+	revocationTx, err := transaction.NewTransactionFromBEEF(revocation.Beef)
+	require.NoError(t, err)
+	require.Len(t, revocationTx.Inputs, 2)
+	for vin, input := range revocationTx.Inputs {
+		err := interpreter.NewEngine().Execute(
+			interpreter.WithTx(revocationTx, vin, createdTx.Outputs[input.SourceTxOutIndex]),
+			interpreter.WithForkID(),
+			interpreter.WithAfterGenesis(),
+		)
+		require.NoError(t, err)
+	}
+}
+
+func TestWalletAdvertiser_ParseAdvertisement_ShouldRejectOtherScripts(t *testing.T) {
+	// given:
+	a := newWalletAdvertiser(t, newFakeWallet(t), advertiser.WalletAdvertiserConfig{})
+	p2pkh, err := script.NewFromHex("76a914000000000000000000000000000000000000000088ac")
+	require.NoError(t, err)
+
+	// when:
+	advertisement, err := a.ParseAdvertisement(p2pkh)
+
+	// then:
+	require.ErrorIs(t, err, advertiser.ErrNotAnAdvertisement)
+	require.Nil(t, advertisement)
+}
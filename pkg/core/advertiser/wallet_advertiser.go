@@ -0,0 +1,375 @@
+package advertiser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	admintoken "github.com/bsv-blockchain/go-sdk/overlay/admin-token"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/pushdrop"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+const (
+	// DefaultAdvertisementSatoshis is the amount locked in each advertisement output when no amount is configured.
+	DefaultAdvertisementSatoshis = 1
+
+	// DefaultAdvertisementBasket is the wallet basket tracking the advertisement outputs when no basket is configured.
+	DefaultAdvertisementBasket = "overlay advertisements"
+)
+
+// advertisementKeyID is the key ID the advertisement keys are derived with.
+const advertisementKeyID = "1"
+
+// advertisementUnlockingScriptLength is the length of the signature unlocking an advertisement output.
+const advertisementUnlockingScriptLength = 73
+
+// maxListedAdvertisements is the page size used to list the advertisement outputs of the wallet.
+const maxListedAdvertisements = 10000
+
+var (
+	// ErrInvalidAdvertiserDomain is returned when the advertised domain is not an https URL
+	ErrInvalidAdvertiserDomain = errors.New("advertiser domain must be an https URL")
+
+	// ErrInvalidAdvertiserPrivateKey is returned when the configured private key is not a hex encoded private key
+	ErrInvalidAdvertiserPrivateKey = errors.New("invalid advertiser private key")
+
+	// ErrNotAnAdvertisement is returned when parsing a locking script that is not a SHIP or SLAP advertisement
+	ErrNotAnAdvertisement = errors.New("locking script is not a SHIP or SLAP advertisement")
+
+	// ErrUnsupportedProtocol is returned for advertisements of other protocols than SHIP and SLAP
+	ErrUnsupportedProtocol = errors.New("unsupported advertisement protocol")
+
+	// ErrWalletReturnedNoTransaction is returned when the wallet does not return the transaction of an advertisement action
+	ErrWalletReturnedNoTransaction = errors.New("wallet returned no transaction")
+)
+
+// protocolTopics maps the advertisement protocols to the topics their advertisements are submitted to.
+var protocolTopics = map[overlay.Protocol]string{
+	overlay.ProtocolSHIP: "tm_ship",
+	overlay.ProtocolSLAP: "tm_slap",
+}
+
+// WalletAdvertiserConfig configures the advertisements created by a WalletAdvertiser.
+type WalletAdvertiserConfig struct {
+	// PrivateKey is the hex private key whose identity signs the advertisements.
+	// Empty uses the identity key of the wallet.
+	PrivateKey string `mapstructure:"private_key"`
+
+	// Domain is the https URL advertised for the hosted topics and lookup services, typically the engine HostingURL.
+	Domain string `mapstructure:"domain"`
+
+	// Satoshis is the amount locked in each advertisement output. Zero falls back to DefaultAdvertisementSatoshis.
+	Satoshis uint64 `mapstructure:"satoshis"`
+
+	// Basket is the wallet basket tracking the advertisement outputs. Empty falls back to DefaultAdvertisementBasket.
+	Basket string `mapstructure:"basket"`
+}
+
+// WalletAdvertiser is an Advertiser backed by a BRC-100 wallet. Advertisements are PushDrop tokens locked
+// with, and signed by, the key derived from the advertiser identity for the protocol ID of the advertisement,
+// key ID "1" and the "anyone" counterparty, so any node can verify them. The wallet funds the advertisement
+// transactions and tracks the advertisement outputs in a basket, so they can be found and revoked later.
+type WalletAdvertiser struct {
+	wallet   wallet.Interface // funds and tracks the advertisements
+	signer   wallet.Interface // signs the advertisements with the advertiser identity
+	domain   string
+	satoshis uint64
+	basket   string
+}
+
+var _ Advertiser = (*WalletAdvertiser)(nil)
+
+// NewWalletAdvertiser creates a WalletAdvertiser funding its advertisements from the given wallet. The advertisements
+// are signed with the configured private key, or with the identity key of the wallet when no private key is configured.
+// Returns ErrInvalidAdvertiserDomain when the domain is not an https URL and ErrInvalidAdvertiserPrivateKey when the
+// private key cannot be decoded. Panics if the wallet is nil.
+func NewWalletAdvertiser(w wallet.Interface, cfg WalletAdvertiserConfig) (*WalletAdvertiser, error) {
+	if w == nil {
+		panic("advertiser wallet cannot be nil")
+	}
+	if u, err := url.Parse(cfg.Domain); err != nil || (u.Scheme != "https" && !strings.HasPrefix(u.Scheme, "https+")) || u.Host == "" {
+		return nil, ErrInvalidAdvertiserDomain
+	}
+
+	signer := w
+	if cfg.PrivateKey != "" {
+		key, err := ec.PrivateKeyFromHex(cfg.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAdvertiserPrivateKey, err)
+		}
+		if signer, err = wallet.NewCompletedProtoWallet(key); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAdvertiserPrivateKey, err)
+		}
+	}
+
+	satoshis := cfg.Satoshis
+	if satoshis == 0 {
+		satoshis = DefaultAdvertisementSatoshis
+	}
+	basket := cfg.Basket
+	if basket == "" {
+		basket = DefaultAdvertisementBasket
+	}
+	return &WalletAdvertiser{wallet: w, signer: signer, domain: cfg.Domain, satoshis: satoshis, basket: basket}, nil
+}
+
+// CreateAdvertisements creates one advertisement output per advertisement in a single wallet funded transaction,
+// and returns it tagged with the topics of the advertised protocols, ready to be submitted to the engine.
+func (a *WalletAdvertiser) CreateAdvertisements(adsData []*AdvertisementData) (overlay.TaggedBEEF, error) {
+	ctx := context.Background()
+	identityKey, err := a.identityKey(ctx)
+	if err != nil {
+		return overlay.TaggedBEEF{}, err
+	}
+
+	outputs := make([]wallet.CreateActionOutput, 0, len(adsData))
+	topics := make([]string, 0, len(protocolTopics))
+	for _, ad := range adsData {
+		topic, ok := protocolTopics[ad.Protocol]
+		if !ok {
+			return overlay.TaggedBEEF{}, fmt.Errorf("%w: %s", ErrUnsupportedProtocol, ad.Protocol)
+		}
+		pd := &pushdrop.PushDrop{Wallet: a.signer}
+		lockingScript, err := pd.Lock(
+			ctx,
+			[][]byte{[]byte(ad.Protocol), identityKey.Compressed(), []byte(a.domain), []byte(ad.TopicOrServiceName)},
+			protocolID(ad.Protocol),
+			advertisementKeyID,
+			wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone},
+			true,
+			true,
+			pushdrop.LockBefore,
+		)
+		if err != nil {
+			slog.Error("failed to lock advertisement", "protocol", ad.Protocol, "topicOrService", ad.TopicOrServiceName, "error", err)
+			return overlay.TaggedBEEF{}, err
+		}
+		outputs = append(outputs, wallet.CreateActionOutput{
+			LockingScript:     lockingScript.Bytes(),
+			Satoshis:          a.satoshis,
+			OutputDescription: fmt.Sprintf("%s advertisement of %s", ad.Protocol, ad.TopicOrServiceName),
+			Basket:            a.basket,
+		})
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	randomize := false
+	result, err := a.wallet.CreateAction(ctx, wallet.CreateActionArgs{
+		Description: "SHIP/SLAP advertisements",
+		Outputs:     outputs,
+		Options:     &wallet.CreateActionOptions{RandomizeOutputs: &randomize},
+	}, "")
+	if err != nil {
+		slog.Error("failed to create advertisement transaction", "error", err)
+		return overlay.TaggedBEEF{}, err
+	}
+	if result == nil || len(result.Tx) == 0 {
+		slog.Error("failed to create advertisement transaction", "error", ErrWalletReturnedNoTransaction)
+		return overlay.TaggedBEEF{}, ErrWalletReturnedNoTransaction
+	}
+	return overlay.TaggedBEEF{Beef: result.Tx, Topics: topics}, nil
+}
+
+// FindAllAdvertisements returns the advertisements of the protocol tracked in the wallet basket
+// that were created by the advertiser identity.
+func (a *WalletAdvertiser) FindAllAdvertisements(protocol overlay.Protocol) ([]*Advertisement, error) {
+	ctx := context.Background()
+	identityKey, err := a.identityKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := uint32(maxListedAdvertisements)
+	result, err := a.wallet.ListOutputs(ctx, wallet.ListOutputsArgs{
+		Basket:  a.basket,
+		Include: wallet.OutputIncludeEntireTransactions,
+		Limit:   &limit,
+	}, "")
+	if err != nil {
+		slog.Error("failed to list advertisement outputs", "basket", a.basket, "error", err)
+		return nil, err
+	}
+	if result == nil || len(result.Outputs) == 0 {
+		return nil, nil
+	}
+	beef, err := transaction.NewBeefFromBytes(result.BEEF)
+	if err != nil {
+		slog.Error("failed to parse advertisement outputs BEEF", "basket", a.basket, "error", err)
+		return nil, err
+	}
+
+	var advertisements []*Advertisement
+	for _, output := range result.Outputs {
+		tx := beef.FindTransactionByHash(&output.Outpoint.Txid)
+		if tx == nil || int(output.Outpoint.Index) >= len(tx.Outputs) {
+			continue
+		}
+		advertisement, err := a.ParseAdvertisement(tx.Outputs[output.Outpoint.Index].LockingScript)
+		if err != nil || advertisement.Protocol != protocol || advertisement.IdentityKey != identityKey.ToDERHex() {
+			continue
+		}
+		if advertisement.Beef, err = beef.AtomicBytes(&output.Outpoint.Txid); err != nil {
+			slog.Error("failed to build advertisement BEEF", "outpoint", output.Outpoint.String(), "error", err)
+			return nil, err
+		}
+		advertisement.OutputIndex = output.Outpoint.Index
+		advertisements = append(advertisements, advertisement)
+	}
+	return advertisements, nil
+}
+
+// RevokeAdvertisements spends the advertisement outputs in a single wallet funded transaction and returns it
+// tagged with the topics of the revoked protocols, so the topic managers remove the advertisements.
+func (a *WalletAdvertiser) RevokeAdvertisements(advertisements []*Advertisement) (overlay.TaggedBEEF, error) {
+	ctx := context.Background()
+	inputBEEF := transaction.NewBeefV2()
+	inputs := make([]wallet.CreateActionInput, 0, len(advertisements))
+	protocols := make([]overlay.Protocol, 0, len(advertisements))
+	topics := make([]string, 0, len(protocolTopics))
+	for _, advertisement := range advertisements {
+		topic, ok := protocolTopics[advertisement.Protocol]
+		if !ok {
+			return overlay.TaggedBEEF{}, fmt.Errorf("%w: %s", ErrUnsupportedProtocol, advertisement.Protocol)
+		}
+		tx, err := transaction.NewTransactionFromBEEF(advertisement.Beef)
+		if err != nil {
+			slog.Error("failed to parse advertisement BEEF", "error", err)
+			return overlay.TaggedBEEF{}, err
+		}
+		if err := inputBEEF.MergeBeefBytes(advertisement.Beef); err != nil {
+			slog.Error("failed to merge advertisement BEEF", "txid", tx.TxID().String(), "error", err)
+			return overlay.TaggedBEEF{}, err
+		}
+		inputs = append(inputs, wallet.CreateActionInput{
+			Outpoint:              transaction.Outpoint{Txid: *tx.TxID(), Index: advertisement.OutputIndex},
+			InputDescription:      fmt.Sprintf("Revoke %s advertisement of %s", advertisement.Protocol, advertisement.TopicOrService),
+			UnlockingScriptLength: advertisementUnlockingScriptLength,
+		})
+		protocols = append(protocols, advertisement.Protocol)
+		if !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	beefBytes, err := inputBEEF.Bytes()
+	if err != nil {
+		slog.Error("failed to serialize advertisement input BEEF", "error", err)
+		return overlay.TaggedBEEF{}, err
+	}
+
+	created, err := a.wallet.CreateAction(ctx, wallet.CreateActionArgs{
+		Description: "Revoke SHIP/SLAP advertisements",
+		InputBEEF:   beefBytes,
+		Inputs:      inputs,
+	}, "")
+	if err != nil {
+		slog.Error("failed to create advertisement revocation", "error", err)
+		return overlay.TaggedBEEF{}, err
+	}
+	if created == nil || created.SignableTransaction == nil {
+		slog.Error("failed to create advertisement revocation", "error", ErrWalletReturnedNoTransaction)
+		return overlay.TaggedBEEF{}, ErrWalletReturnedNoTransaction
+	}
+
+	tx, err := a.signableTransaction(created.SignableTransaction.Tx, inputBEEF)
+	if err != nil {
+		return overlay.TaggedBEEF{}, err
+	}
+	spends := make(map[uint32]wallet.SignActionSpend, len(inputs))
+	for vin, input := range tx.Inputs {
+		i := indexOfOutpoint(inputs, input.SourceTXID, input.SourceTxOutIndex)
+		if i < 0 {
+			continue
+		}
+		pd := &pushdrop.PushDrop{Wallet: a.signer}
+		unlocker := pd.Unlock(ctx, protocolID(protocols[i]), advertisementKeyID,
+			wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone}, wallet.SignOutputsAll, false)
+		unlockingScript, err := unlocker.Sign(tx, vin)
+		if err != nil {
+			slog.Error("failed to unlock advertisement", "outpoint", inputs[i].Outpoint.String(), "error", err)
+			return overlay.TaggedBEEF{}, err
+		}
+		spends[uint32(vin)] = wallet.SignActionSpend{UnlockingScript: unlockingScript.Bytes()} //nolint:gosec // index bounded by slice length
+	}
+
+	signed, err := a.wallet.SignAction(ctx, wallet.SignActionArgs{
+		Reference: created.SignableTransaction.Reference,
+		Spends:    spends,
+	}, "")
+	if err != nil {
+		slog.Error("failed to sign advertisement revocation", "error", err)
+		return overlay.TaggedBEEF{}, err
+	}
+	if signed == nil || len(signed.Tx) == 0 {
+		slog.Error("failed to sign advertisement revocation", "error", ErrWalletReturnedNoTransaction)
+		return overlay.TaggedBEEF{}, ErrWalletReturnedNoTransaction
+	}
+	return overlay.TaggedBEEF{Beef: signed.Tx, Topics: topics}, nil
+}
+
+// ParseAdvertisement decodes a SHIP or SLAP advertisement from its locking script.
+// Returns ErrNotAnAdvertisement for other scripts. The signature is verified by the topic managers, not here.
+func (a *WalletAdvertiser) ParseAdvertisement(outputScript *script.Script) (*Advertisement, error) {
+	if outputScript == nil {
+		return nil, ErrNotAnAdvertisement
+	}
+	token := admintoken.Decode(outputScript)
+	if token == nil {
+		return nil, ErrNotAnAdvertisement
+	}
+	return &Advertisement{
+		Protocol:       token.Protocol,
+		IdentityKey:    token.IdentityKey,
+		Domain:         token.Domain,
+		TopicOrService: token.TopicOrService,
+	}, nil
+}
+
+func (a *WalletAdvertiser) identityKey(ctx context.Context) (*ec.PublicKey, error) {
+	result, err := a.signer.GetPublicKey(ctx, wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	if err != nil {
+		slog.Error("failed to get advertiser identity key", "error", err)
+		return nil, err
+	}
+	return result.PublicKey, nil
+}
+
+// signableTransaction parses the transaction the wallet asks to sign, linking the advertisement outputs it spends
+// so that their signature hashes can be computed.
+func (a *WalletAdvertiser) signableTransaction(atomicBEEF []byte, inputBEEF *transaction.Beef) (*transaction.Transaction, error) {
+	tx, err := transaction.NewTransactionFromBEEF(atomicBEEF)
+	if err != nil {
+		slog.Error("failed to parse signable advertisement revocation", "error", err)
+		return nil, err
+	}
+	for _, input := range tx.Inputs {
+		if input.SourceTransaction == nil && input.SourceTXID != nil {
+			input.SourceTransaction = inputBEEF.FindTransactionByHash(input.SourceTXID)
+		}
+	}
+	return tx, nil
+}
+
+func protocolID(protocol overlay.Protocol) wallet.Protocol {
+	return wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: string(protocol.ID())}
+}
+
+func indexOfOutpoint(inputs []wallet.CreateActionInput, txid *chainhash.Hash, index uint32) int {
+	for i, input := range inputs {
+		if txid != nil && input.Outpoint.Txid.Equal(*txid) && input.Outpoint.Index == index {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,113 @@
+// Package beefstore implements a content-addressable store of BEEF with reference counting, so that the outputs
+// of a transaction and the ancillary dependencies of outputs share a single copy of identical BEEF instead of each
+// holding its own. Storage implementations acquire the BEEF they hold and release it when the output referencing it
+// is deleted or its BEEF replaced:
+//
+//	stored.Beef = store.Acquire(output.Beef)
+//	...
+//	store.Release(stored.Beef)
+package beefstore
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// Key returns the content address of the BEEF.
+func Key(beef []byte) chainhash.Hash {
+	return chainhash.HashH(beef)
+}
+
+// Stats describes the content of a Store.
+type Stats struct {
+	Blobs      int // distinct BEEF held
+	References int // references to the held BEEF
+	Bytes      int // size of the held BEEF, each counted once
+}
+
+type blob struct {
+	beef []byte
+	refs int
+}
+
+// Store holds BEEF by content address, each distinct BEEF once, until its last reference is released.
+// It is safe for concurrent use.
+type Store struct {
+	mu    sync.Mutex
+	blobs map[chainhash.Hash]*blob
+}
+
+// New creates an empty Store.
+func New() *Store {
+	return &Store{blobs: make(map[chainhash.Hash]*blob)}
+}
+
+// Acquire adds a reference to the BEEF, storing a copy of it unless the store already holds the same content, and
+// returns the stored BEEF shared by every reference. Callers must not modify it. Empty BEEF is not stored and is
+// returned as nil.
+func (s *Store) Acquire(beef []byte) []byte {
+	if len(beef) == 0 {
+		return nil
+	}
+	key := Key(beef)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.blobs[key]
+	if !ok {
+		stored = &blob{beef: bytes.Clone(beef)}
+		s.blobs[key] = stored
+	}
+	stored.refs++
+	return stored.beef
+}
+
+// Get returns the stored BEEF with the content address, or nil if the store does not hold it.
+func (s *Store) Get(key chainhash.Hash) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.blobs[key]; ok {
+		return stored.beef
+	}
+	return nil
+}
+
+// Release drops a reference to the BEEF, deleting it once no reference remains. Releasing empty BEEF or BEEF the
+// store does not hold is a no-op.
+func (s *Store) Release(beef []byte) {
+	if len(beef) == 0 {
+		return
+	}
+	key := Key(beef)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.blobs[key]
+	if !ok {
+		return
+	}
+	stored.refs--
+	if stored.refs <= 0 {
+		delete(s.blobs, key)
+	}
+}
+
+// Replace releases the old BEEF and acquires the new one, returning the stored copy of the new BEEF.
+func (s *Store) Replace(old, beef []byte) []byte {
+	acquired := s.Acquire(beef)
+	s.Release(old)
+	return acquired
+}
+
+// Stats returns the number of distinct BEEF held, their references and their size.
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var stats Stats
+	for _, stored := range s.blobs {
+		stats.Blobs++
+		stats.References += stored.refs
+		stats.Bytes += len(stored.beef)
+	}
+	return stats
+}
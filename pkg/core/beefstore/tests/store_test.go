@@ -0,0 +1,107 @@
+package beefstore_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/beefstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Acquire_ShouldShareIdenticalBEEF(t *testing.T) {
+	// given:
+	store := beefstore.New()
+	beef := []byte("beef of a transaction")
+
+	// when:
+	first := store.Acquire(beef)
+	second := store.Acquire([]byte("beef of a transaction"))
+
+	// then:
+	require.Equal(t, beef, first)
+	require.Same(t, &first[0], &second[0], "identical BEEF must be stored once")
+	require.Equal(t, beefstore.Stats{Blobs: 1, References: 2, Bytes: len(beef)}, store.Stats())
+	require.Equal(t, beef, store.Get(beefstore.Key(beef)))
+}
+
+func TestStore_Acquire_ShouldCopyTheBEEF(t *testing.T) {
+	// given:
+	store := beefstore.New()
+	beef := []byte("beef of a transaction")
+
+	// when:
+	stored := store.Acquire(beef)
+	beef[0] = 'B'
+
+	// then:
+	require.Equal(t, []byte("beef of a transaction"), stored, "the stored BEEF must not change with the buffer of the caller")
+}
+
+func TestStore_Release_ShouldDeleteBEEFWithoutReferences(t *testing.T) {
+	// given:
+	store := beefstore.New()
+	beef := []byte("beef of a transaction")
+	store.Acquire(beef)
+	store.Acquire(beef)
+
+	// when:
+	store.Release(beef)
+
+	// then:
+	require.Equal(t, beefstore.Stats{Blobs: 1, References: 1, Bytes: len(beef)}, store.Stats())
+
+	// when:
+	store.Release(beef)
+	store.Release(beef)
+
+	// then:
+	require.Equal(t, beefstore.Stats{}, store.Stats())
+	require.Nil(t, store.Get(beefstore.Key(beef)))
+}
+
+func TestStore_Replace_ShouldMoveTheReference(t *testing.T) {
+	// given:
+	store := beefstore.New()
+	old := store.Acquire([]byte("unmined beef"))
+
+	// when:
+	replaced := store.Replace(old, []byte("mined beef"))
+
+	// then:
+	require.Equal(t, []byte("mined beef"), replaced)
+	require.Equal(t, beefstore.Stats{Blobs: 1, References: 1, Bytes: len(replaced)}, store.Stats())
+}
+
+func TestStore_ShouldIgnoreEmptyBEEF(t *testing.T) {
+	// given:
+	store := beefstore.New()
+
+	// when:
+	stored := store.Acquire(nil)
+	store.Release([]byte{})
+
+	// then:
+	require.Nil(t, stored)
+	require.Equal(t, beefstore.Stats{}, store.Stats())
+}
+
+func TestStore_ShouldCountConcurrentReferences(t *testing.T) {
+	// given:
+	store := beefstore.New()
+	beef := []byte("beef of a transaction")
+	const references = 64
+
+	// when:
+	var wg sync.WaitGroup
+	for range references {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store.Acquire(beef)
+		}()
+	}
+	wg.Wait()
+
+	// then:
+	require.Equal(t, beefstore.Stats{Blobs: 1, References: references, Bytes: len(beef)}, store.Stats())
+}
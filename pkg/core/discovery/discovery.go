@@ -0,0 +1,192 @@
+// Package discovery implements the SHIP and SLAP topic managers and lookup services, so an overlay node
+// can take part in peer discovery out of the box.
+//
+// SHIP (Service Host Interconnect Protocol) advertisements announce that a domain hosts a topic, and
+// SLAP (Service Lookup Availability Protocol) advertisements announce that a domain answers a lookup
+// service. Both are PushDrop tokens with the fields:
+//
+//	protocol ("SHIP" or "SLAP") | identity key | domain | topic or service name | signature
+//
+// locked with, and signed by, the key derived from the identity key for the protocol ID of the
+// advertisement, key ID "1" and the "anyone" counterparty. The topic managers admit well-formed,
+// correctly signed advertisements and the lookup services index them by domain, identity key and
+// topic or service name.
+package discovery
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	hash "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/pushdrop"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+const (
+	// SHIPTopic is the topic of SHIP advertisements.
+	SHIPTopic = "tm_ship"
+
+	// SLAPTopic is the topic of SLAP advertisements.
+	SLAPTopic = "tm_slap"
+
+	// SHIPLookupService is the lookup service answering which domains host a topic.
+	SHIPLookupService = "ls_ship"
+
+	// SLAPLookupService is the lookup service answering which domains provide a lookup service.
+	SLAPLookupService = "ls_slap"
+)
+
+// advertisementKeyID is the key ID the advertisement keys are derived with.
+const advertisementKeyID = "1"
+
+var (
+	// ErrNotAnAdvertisement is returned when a locking script is not a SHIP or SLAP PushDrop token
+	ErrNotAnAdvertisement = errors.New("locking script is not a SHIP or SLAP advertisement")
+
+	// ErrProtocolMismatch is returned when an advertisement is of another protocol than expected
+	ErrProtocolMismatch = errors.New("advertisement protocol mismatch")
+
+	// ErrInvalidTopicOrServiceName is returned when the advertised topic or service name is not a valid name for the protocol
+	ErrInvalidTopicOrServiceName = errors.New("invalid advertised topic or service name")
+
+	// ErrInvalidDomain is returned when the advertised domain is not an advertisable https URL
+	ErrInvalidDomain = errors.New("invalid advertised domain")
+
+	// ErrInvalidAdvertisementSignature is returned when the advertisement is not locked with, or signed by, the key derived from its identity key
+	ErrInvalidAdvertisementSignature = errors.New("invalid advertisement signature")
+)
+
+// topicOrServiceName matches the part of a topic or service name after its "tm_" or "ls_" prefix:
+// lowercase letters in underscore separated words.
+var topicOrServiceName = regexp.MustCompile(`^[a-z]+(?:_[a-z]+)*$`)
+
+// maxTopicOrServiceNameLength is the maximum length of a topic or service name, including its prefix.
+const maxTopicOrServiceNameLength = 50
+
+// Advertisement is a decoded and verified SHIP or SLAP advertisement.
+type Advertisement struct {
+	Protocol       overlay.Protocol
+	IdentityKey    string
+	Domain         string
+	TopicOrService string
+}
+
+// ParseAdvertisement decodes the locking script as an advertisement of the given protocol and verifies it.
+// Returns ErrNotAnAdvertisement for other scripts, ErrProtocolMismatch for advertisements of the other protocol,
+// ErrInvalidTopicOrServiceName or ErrInvalidDomain for malformed advertisements and ErrInvalidAdvertisementSignature
+// when the token is not locked with, or signed by, the key derived from its identity key.
+func ParseAdvertisement(lockingScript *script.Script, protocol overlay.Protocol) (*Advertisement, error) {
+	if lockingScript == nil {
+		return nil, ErrNotAnAdvertisement
+	}
+	token := pushdrop.Decode(lockingScript)
+	if token == nil || len(token.Fields) < 5 {
+		return nil, ErrNotAnAdvertisement
+	}
+	advertised := overlay.Protocol(token.Fields[0])
+	if advertised != overlay.ProtocolSHIP && advertised != overlay.ProtocolSLAP {
+		return nil, ErrNotAnAdvertisement
+	}
+	if advertised != protocol {
+		return nil, ErrProtocolMismatch
+	}
+
+	identityKey, err := ec.PublicKeyFromBytes(token.Fields[1])
+	if err != nil {
+		return nil, ErrInvalidAdvertisementSignature
+	}
+	advertisement := &Advertisement{
+		Protocol:       protocol,
+		IdentityKey:    identityKey.ToDERHex(),
+		Domain:         string(token.Fields[2]),
+		TopicOrService: string(token.Fields[3]),
+	}
+	if !IsValidTopicOrServiceName(protocol, advertisement.TopicOrService) {
+		return nil, ErrInvalidTopicOrServiceName
+	}
+	if !IsAdvertisableDomain(advertisement.Domain) {
+		return nil, ErrInvalidDomain
+	}
+	if !isSignedByIdentityKey(token, identityKey, protocol) {
+		return nil, ErrInvalidAdvertisementSignature
+	}
+	return advertisement, nil
+}
+
+// IsValidTopicOrServiceName reports whether the name can be advertised with the protocol:
+// SHIP advertises "tm_" topics and SLAP advertises "ls_" lookup services, of at most 50 characters
+// made of lowercase letters in underscore separated words.
+func IsValidTopicOrServiceName(protocol overlay.Protocol, name string) bool {
+	var prefix string
+	switch protocol {
+	case overlay.ProtocolSHIP:
+		prefix = "tm_"
+	case overlay.ProtocolSLAP:
+		prefix = "ls_"
+	default:
+		return false
+	}
+	if len(name) > maxTopicOrServiceNameLength || !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	return topicOrServiceName.MatchString(strings.TrimPrefix(name, prefix))
+}
+
+// IsAdvertisableDomain reports whether the domain can be reached by other nodes: an https URL, or a URL
+// of an https based scheme such as "https+bsvauth", with a host that is not localhost.
+func IsAdvertisableDomain(domain string) bool {
+	u, err := url.Parse(domain)
+	if err != nil || (u.Scheme != "https" && !strings.HasPrefix(u.Scheme, "https+")) {
+		return false
+	}
+	host := u.Hostname()
+	return host != "" && host != "localhost"
+}
+
+// isSignedByIdentityKey verifies that the token is locked with, and its fields are signed by, the public key
+// anyone can derive from the identity key for the protocol ID of the advertisement.
+func isSignedByIdentityKey(token *pushdrop.PushDropData, identityKey *ec.PublicKey, protocol overlay.Protocol) bool {
+	expected, err := wallet.NewKeyDeriver(nil).DerivePublicKey(
+		wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: string(protocol.ID())},
+		advertisementKeyID,
+		wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: identityKey},
+		false,
+	)
+	if err != nil || token.LockingPublicKey == nil || !token.LockingPublicKey.IsEqual(expected) {
+		return false
+	}
+
+	signed := token.Fields[:len(token.Fields)-1]
+	signature, err := ec.FromDER(token.Fields[len(token.Fields)-1])
+	if err != nil {
+		return false
+	}
+	var data []byte
+	for _, field := range signed {
+		data = append(data, field...)
+	}
+	return signature.Verify(hash.Sha256(data), expected)
+}
+
+// TopicManagers returns the SHIP and SLAP topic managers keyed by their topics, to be registered in engine.Engine.Managers.
+func TopicManagers() map[string]engine.TopicManager {
+	return map[string]engine.TopicManager{
+		SHIPTopic: NewSHIPTopicManager(),
+		SLAPTopic: NewSLAPTopicManager(),
+	}
+}
+
+// LookupServices returns the SHIP and SLAP lookup services keyed by their names, backed by the given storages,
+// to be registered in engine.Engine.LookupServices.
+func LookupServices(shipStorage, slapStorage Storage) map[string]engine.LookupService {
+	return map[string]engine.LookupService{
+		SHIPLookupService: NewSHIPLookupService(shipStorage),
+		SLAPLookupService: NewSLAPLookupService(slapStorage),
+	}
+}
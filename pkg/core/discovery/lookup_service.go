@@ -0,0 +1,224 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// findAllQuery is the query selecting every advertisement of a lookup service.
+const findAllQuery = "findAll"
+
+var (
+	// ErrUnsupportedLookupService is returned when a lookup service is asked a question addressed to another service
+	ErrUnsupportedLookupService = errors.New("lookup service not supported")
+
+	// ErrInvalidQuery is returned when a lookup question is neither "findAll" nor a query object of the lookup service
+	ErrInvalidQuery = errors.New("invalid discovery query")
+)
+
+var (
+	_ engine.LookupService           = (*LookupService)(nil)
+	_ engine.LookupServiceWithSchema = (*LookupService)(nil)
+)
+
+// SHIPQuery is the query object of the ls_ship lookup service. Empty fields match every advertisement.
+type SHIPQuery struct {
+	Domain      string   `json:"domain,omitempty"`
+	Topics      []string `json:"topics,omitempty"`
+	IdentityKey string   `json:"identityKey,omitempty"`
+}
+
+// SLAPQuery is the query object of the ls_slap lookup service. Empty fields match every advertisement.
+type SLAPQuery struct {
+	Domain      string `json:"domain,omitempty"`
+	Service     string `json:"service,omitempty"`
+	IdentityKey string `json:"identityKey,omitempty"`
+}
+
+// LookupService indexes the advertisements admitted to its topic and answers which domains advertise
+// a topic or service. Answers are formulas, so the engine hydrates them with the advertisement outputs.
+type LookupService struct {
+	name     string
+	topic    string
+	protocol overlay.Protocol
+	storage  Storage
+	metaData *overlay.MetaData
+	docs     string
+	schema   json.RawMessage
+}
+
+// NewSHIPLookupService creates the ls_ship lookup service, indexing the tm_ship advertisements in the storage.
+// Panics if the storage is nil.
+func NewSHIPLookupService(storage Storage) *LookupService {
+	if storage == nil {
+		panic("SHIP lookup service storage cannot be nil")
+	}
+	return &LookupService{
+		name:     SHIPLookupService,
+		topic:    SHIPTopic,
+		protocol: overlay.ProtocolSHIP,
+		storage:  storage,
+		metaData: &overlay.MetaData{
+			Name:        "SHIP Lookup Service",
+			Description: "Provides lookup capabilities for SHIP tokens.",
+		},
+		docs: `The SHIP lookup service answers which domains host a topic. ` +
+			`Query it with "findAll" for every advertisement, or with an object selecting advertisements by ` +
+			`"domain", "identityKey" and any of the "topics", e.g. {"topics": ["tm_example"]}.`,
+		schema: json.RawMessage(`{"anyOf": [` +
+			`{"type": "string", "enum": ["findAll"]},` +
+			`{"type": "object", "additionalProperties": false, "properties": {` +
+			`"domain": {"type": "string"},` +
+			`"topics": {"type": "array", "items": {"type": "string"}},` +
+			`"identityKey": {"type": "string"}}}]}`),
+	}
+}
+
+// NewSLAPLookupService creates the ls_slap lookup service, indexing the tm_slap advertisements in the storage.
+// Panics if the storage is nil.
+func NewSLAPLookupService(storage Storage) *LookupService {
+	if storage == nil {
+		panic("SLAP lookup service storage cannot be nil")
+	}
+	return &LookupService{
+		name:     SLAPLookupService,
+		topic:    SLAPTopic,
+		protocol: overlay.ProtocolSLAP,
+		storage:  storage,
+		metaData: &overlay.MetaData{
+			Name:        "SLAP Lookup Service",
+			Description: "Provides lookup capabilities for SLAP tokens.",
+		},
+		docs: `The SLAP lookup service answers which domains provide a lookup service. ` +
+			`Query it with "findAll" for every advertisement, or with an object selecting advertisements by ` +
+			`"domain", "identityKey" and "service", e.g. {"service": "ls_example"}.`,
+		schema: json.RawMessage(`{"anyOf": [` +
+			`{"type": "string", "enum": ["findAll"]},` +
+			`{"type": "object", "additionalProperties": false, "properties": {` +
+			`"domain": {"type": "string"},` +
+			`"service": {"type": "string"},` +
+			`"identityKey": {"type": "string"}}}]}`),
+	}
+}
+
+// OutputAdmittedByTopic indexes the advertisements admitted to the topic of the lookup service.
+func (s *LookupService) OutputAdmittedByTopic(ctx context.Context, payload *engine.OutputAdmittedByTopic) error {
+	if payload.Topic != s.topic {
+		return nil
+	}
+	advertisement, err := ParseAdvertisement(payload.LockingScript, s.protocol)
+	if err != nil {
+		slog.Error("failed to index admitted advertisement", "service", s.name, "outpoint", payload.Outpoint.String(), "error", err)
+		return err
+	}
+	return s.storage.StoreRecord(ctx, &Record{
+		Outpoint:      *payload.Outpoint,
+		Advertisement: *advertisement,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// OutputSpent removes the advertisements revoked by spending them.
+func (s *LookupService) OutputSpent(ctx context.Context, payload *engine.OutputSpent) error {
+	if payload.Topic != s.topic {
+		return nil
+	}
+	return s.storage.DeleteRecord(ctx, payload.Outpoint)
+}
+
+// OutputNoLongerRetainedInHistory does nothing: spent advertisements are already removed.
+func (s *LookupService) OutputNoLongerRetainedInHistory(_ context.Context, _ *transaction.Outpoint, _ string) error {
+	return nil
+}
+
+// OutputEvicted removes the advertisement of the outpoint.
+func (s *LookupService) OutputEvicted(ctx context.Context, outpoint *transaction.Outpoint) error {
+	return s.storage.DeleteRecord(ctx, outpoint)
+}
+
+// OutputBlockHeightUpdated does nothing: advertisements are not indexed by block height.
+func (s *LookupService) OutputBlockHeightUpdated(_ context.Context, _ *chainhash.Hash, _ uint32, _ uint64) error {
+	return nil
+}
+
+// Lookup answers with the advertisements matching the query, as formulas of their outpoints.
+// Returns ErrUnsupportedLookupService for questions addressed to another service and ErrInvalidQuery
+// for queries that are neither "findAll" nor a query object of the lookup service.
+func (s *LookupService) Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	if question.Service != s.name {
+		return nil, ErrUnsupportedLookupService
+	}
+	query, err := s.parseQuery(question.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.storage.FindRecords(ctx, query)
+	if err != nil {
+		slog.Error("failed to find advertisements", "service", s.name, "error", err)
+		return nil, err
+	}
+	formulas := make([]lookup.LookupFormula, 0, len(records))
+	for _, record := range records {
+		outpoint := record.Outpoint
+		formulas = append(formulas, lookup.LookupFormula{Outpoint: &outpoint})
+	}
+	return &lookup.LookupAnswer{Type: lookup.AnswerTypeFormula, Formulas: formulas}, nil
+}
+
+func (s *LookupService) parseQuery(raw json.RawMessage) (Query, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		if name != findAllQuery {
+			return Query{}, fmt.Errorf("%w: %q", ErrInvalidQuery, name)
+		}
+		return Query{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	switch s.protocol {
+	case overlay.ProtocolSHIP:
+		var query SHIPQuery
+		if err := decoder.Decode(&query); err != nil {
+			return Query{}, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		return Query{Domain: query.Domain, IdentityKey: query.IdentityKey, TopicsOrServices: query.Topics}, nil
+	default:
+		var query SLAPQuery
+		if err := decoder.Decode(&query); err != nil {
+			return Query{}, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		var services []string
+		if query.Service != "" {
+			services = []string{query.Service}
+		}
+		return Query{Domain: query.Domain, IdentityKey: query.IdentityKey, TopicsOrServices: services}, nil
+	}
+}
+
+// QuerySchema returns the schema of the queries of the lookup service: "findAll" or its query object.
+func (s *LookupService) QuerySchema() json.RawMessage {
+	return s.schema
+}
+
+// GetDocumentation returns the documentation of the lookup service.
+func (s *LookupService) GetDocumentation() string {
+	return s.docs
+}
+
+// GetMetaData returns the metadata of the lookup service.
+func (s *LookupService) GetMetaData() *overlay.MetaData {
+	return s.metaData
+}
@@ -0,0 +1,95 @@
+package discovery
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Record is an admitted advertisement indexed by a lookup service.
+type Record struct {
+	Outpoint transaction.Outpoint
+	Advertisement
+	CreatedAt time.Time
+}
+
+// Query selects the records of a Storage. Empty fields match every record; TopicsOrServices matches
+// records advertising any of the given names.
+type Query struct {
+	Domain           string
+	IdentityKey      string
+	TopicsOrServices []string
+}
+
+// Storage persists the advertisements indexed by a lookup service. Each lookup service owns its storage.
+type Storage interface {
+	// StoreRecord stores the record, replacing any record of the same outpoint.
+	StoreRecord(ctx context.Context, record *Record) error
+
+	// DeleteRecord deletes the record of the outpoint, if any.
+	DeleteRecord(ctx context.Context, outpoint *transaction.Outpoint) error
+
+	// FindRecords returns the records matching the query, oldest first.
+	FindRecords(ctx context.Context, query Query) ([]*Record, error)
+}
+
+// MemoryStorage is an in-memory Storage, suitable for tests and for nodes that rebuild
+// their discovery indexes from the engine storage on startup.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	records map[transaction.Outpoint]*Record
+}
+
+// NewMemoryStorage creates an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{records: make(map[transaction.Outpoint]*Record)}
+}
+
+// StoreRecord stores the record, replacing any record of the same outpoint.
+func (s *MemoryStorage) StoreRecord(_ context.Context, record *Record) error {
+	cp := *record
+	s.mu.Lock()
+	s.records[record.Outpoint] = &cp
+	s.mu.Unlock()
+	return nil
+}
+
+// DeleteRecord deletes the record of the outpoint, if any.
+func (s *MemoryStorage) DeleteRecord(_ context.Context, outpoint *transaction.Outpoint) error {
+	s.mu.Lock()
+	delete(s.records, *outpoint)
+	s.mu.Unlock()
+	return nil
+}
+
+// FindRecords returns the records matching the query, oldest first.
+func (s *MemoryStorage) FindRecords(_ context.Context, query Query) ([]*Record, error) {
+	s.mu.RLock()
+	records := make([]*Record, 0, len(s.records))
+	for _, record := range s.records {
+		if query.Domain != "" && record.Domain != query.Domain {
+			continue
+		}
+		if query.IdentityKey != "" && record.IdentityKey != query.IdentityKey {
+			continue
+		}
+		if len(query.TopicsOrServices) > 0 && !slices.Contains(query.TopicsOrServices, record.TopicOrService) {
+			continue
+		}
+		cp := *record
+		records = append(records, &cp)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].CreatedAt.Before(records[j].CreatedAt)
+		}
+		return records[i].Outpoint.String() < records[j].Outpoint.String()
+	})
+	return records, nil
+}
@@ -0,0 +1,234 @@
+package discovery_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/discovery"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/pushdrop"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T) *ec.PrivateKey {
+	t.Helper()
+
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	return key
+}
+
+// lockAdvertisement builds the advertisement the way SHIP and SLAP advertisers do, advertising the identity key
+// and signing with the signer key, which differ in forged advertisements.
+func lockAdvertisement(t *testing.T, signer *ec.PrivateKey, identityKey *ec.PublicKey, protocol overlay.Protocol, domain, topicOrService string) *script.Script {
+	t.Helper()
+
+	w, err := wallet.NewCompletedProtoWallet(signer)
+	require.NoError(t, err)
+
+	pd := &pushdrop.PushDrop{Wallet: w}
+	lockingScript, err := pd.Lock(
+		context.Background(),
+		[][]byte{[]byte(protocol), identityKey.Compressed(), []byte(domain), []byte(topicOrService)},
+		wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: string(protocol.ID())},
+		"1",
+		wallet.Counterparty{Type: wallet.CounterpartyTypeAnyone},
+		true,
+		true,
+		pushdrop.LockBefore,
+	)
+	require.NoError(t, err)
+	return lockingScript
+}
+
+func newAdvertisement(t *testing.T, protocol overlay.Protocol, domain, topicOrService string) (*script.Script, *ec.PrivateKey) {
+	t.Helper()
+
+	key := newKey(t)
+	return lockAdvertisement(t, key, key.PubKey(), protocol, domain, topicOrService), key
+}
+
+func TestParseAdvertisement(t *testing.T) {
+	shipScript, key := newAdvertisement(t, overlay.ProtocolSHIP, "https://overlay.example.com", "tm_example")
+	slapScript, _ := newAdvertisement(t, overlay.ProtocolSLAP, "https://overlay.example.com", "ls_example")
+	invalidNameScript, _ := newAdvertisement(t, overlay.ProtocolSHIP, "https://overlay.example.com", "tm_Example")
+	wrongPrefixScript, _ := newAdvertisement(t, overlay.ProtocolSHIP, "https://overlay.example.com", "ls_example")
+	httpScript, _ := newAdvertisement(t, overlay.ProtocolSHIP, "http://overlay.example.com", "tm_example")
+	localhostScript, _ := newAdvertisement(t, overlay.ProtocolSHIP, "https://localhost:8080", "tm_example")
+	forgedScript := lockAdvertisement(t, newKey(t), key.PubKey(), overlay.ProtocolSHIP, "https://overlay.example.com", "tm_example")
+	p2pkhScript, err := script.NewFromHex("76a914000000000000000000000000000000000000000088ac")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		lockingScript *script.Script
+		protocol      overlay.Protocol
+		expectedError error
+	}{
+		"valid SHIP advertisement": {
+			lockingScript: shipScript,
+			protocol:      overlay.ProtocolSHIP,
+		},
+		"valid SLAP advertisement": {
+			lockingScript: slapScript,
+			protocol:      overlay.ProtocolSLAP,
+		},
+		"advertisement of the other protocol": {
+			lockingScript: slapScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrProtocolMismatch,
+		},
+		"topic name with uppercase letters": {
+			lockingScript: invalidNameScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrInvalidTopicOrServiceName,
+		},
+		"service name advertised over SHIP": {
+			lockingScript: wrongPrefixScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrInvalidTopicOrServiceName,
+		},
+		"http domain": {
+			lockingScript: httpScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrInvalidDomain,
+		},
+		"localhost domain": {
+			lockingScript: localhostScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrInvalidDomain,
+		},
+		"advertisement signed by another key than its identity key": {
+			lockingScript: forgedScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrInvalidAdvertisementSignature,
+		},
+		"script that is not a PushDrop token": {
+			lockingScript: p2pkhScript,
+			protocol:      overlay.ProtocolSHIP,
+			expectedError: discovery.ErrNotAnAdvertisement,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			advertisement, err := discovery.ParseAdvertisement(tc.lockingScript, tc.protocol)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				require.Equal(t, tc.protocol, advertisement.Protocol)
+				require.Equal(t, "https://overlay.example.com", advertisement.Domain)
+			}
+		})
+	}
+}
+
+func TestTopicManager_IdentifyAdmissibleOutputs_ShouldAdmitOnlyValidAdvertisements(t *testing.T) {
+	// given:
+	shipScript, _ := newAdvertisement(t, overlay.ProtocolSHIP, "https://overlay.example.com", "tm_example")
+	slapScript, _ := newAdvertisement(t, overlay.ProtocolSLAP, "https://overlay.example.com", "ls_example")
+
+	tx := testabilities.GivenTX().WithInput(1000).WithP2PKHOutput(1).TX()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: shipScript})
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: slapScript})
+	beef, err := transaction.NewBeefFromTransaction(tx)
+	require.NoError(t, err)
+	atomicBEEF, err := beef.AtomicBytes(tx.TxID())
+	require.NoError(t, err)
+
+	managers := discovery.TopicManagers()
+
+	// when:
+	shipAdmit, err := managers[discovery.SHIPTopic].IdentifyAdmissibleOutputs(context.Background(), atomicBEEF, nil)
+	require.NoError(t, err)
+	slapAdmit, err := managers[discovery.SLAPTopic].IdentifyAdmissibleOutputs(context.Background(), atomicBEEF, nil)
+	require.NoError(t, err)
+
+	// then:
+	require.Equal(t, []uint32{1}, shipAdmit.OutputsToAdmit)
+	require.Equal(t, []uint32{2}, slapAdmit.OutputsToAdmit)
+	require.Empty(t, shipAdmit.CoinsToRetain)
+	require.Empty(t, slapAdmit.CoinsToRetain)
+}
+
+func TestLookupService_ShouldAnswerWithAdmittedAdvertisements_UntilSpent(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	services := discovery.LookupServices(discovery.NewMemoryStorage(), discovery.NewMemoryStorage())
+	ship := services[discovery.SHIPLookupService]
+
+	exampleScript, key := newAdvertisement(t, overlay.ProtocolSHIP, "https://one.example.com", "tm_example")
+	otherScript, _ := newAdvertisement(t, overlay.ProtocolSHIP, "https://two.example.com", "tm_other")
+	example := &transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}
+	other := &transaction.Outpoint{Txid: chainhash.Hash{2}, Index: 0}
+
+	require.NoError(t, ship.OutputAdmittedByTopic(ctx, &engine.OutputAdmittedByTopic{Topic: discovery.SHIPTopic, Outpoint: example, LockingScript: exampleScript}))
+	require.NoError(t, ship.OutputAdmittedByTopic(ctx, &engine.OutputAdmittedByTopic{Topic: discovery.SHIPTopic, Outpoint: other, LockingScript: otherScript}))
+
+	lookupOutpoints := func(query string) []*transaction.Outpoint {
+		answer, err := ship.Lookup(ctx, &lookup.LookupQuestion{Service: discovery.SHIPLookupService, Query: json.RawMessage(query)})
+		require.NoError(t, err)
+		require.Equal(t, lookup.AnswerTypeFormula, answer.Type)
+		outpoints := make([]*transaction.Outpoint, 0, len(answer.Formulas))
+		for _, formula := range answer.Formulas {
+			outpoints = append(outpoints, formula.Outpoint)
+		}
+		return outpoints
+	}
+
+	// when & then:
+	require.ElementsMatch(t, []*transaction.Outpoint{example, other}, lookupOutpoints(`"findAll"`))
+	require.Equal(t, []*transaction.Outpoint{example}, lookupOutpoints(`{"topics": ["tm_example"]}`))
+	require.Equal(t, []*transaction.Outpoint{other}, lookupOutpoints(`{"domain": "https://two.example.com"}`))
+	require.Equal(t, []*transaction.Outpoint{example}, lookupOutpoints(`{"identityKey": "`+key.PubKey().ToDERHex()+`"}`))
+
+	// when:
+	require.NoError(t, ship.OutputSpent(ctx, &engine.OutputSpent{Topic: discovery.SHIPTopic, Outpoint: example}))
+
+	// then:
+	require.Equal(t, []*transaction.Outpoint{other}, lookupOutpoints(`"findAll"`))
+}
+
+func TestLookupService_Lookup_ShouldRejectInvalidQueries(t *testing.T) {
+	// given:
+	slap := discovery.NewSLAPLookupService(discovery.NewMemoryStorage())
+
+	tests := map[string]struct {
+		question      *lookup.LookupQuestion
+		expectedError error
+	}{
+		"question addressed to another service": {
+			question:      &lookup.LookupQuestion{Service: discovery.SHIPLookupService, Query: json.RawMessage(`"findAll"`)},
+			expectedError: discovery.ErrUnsupportedLookupService,
+		},
+		"unknown string query": {
+			question:      &lookup.LookupQuestion{Service: discovery.SLAPLookupService, Query: json.RawMessage(`"findSome"`)},
+			expectedError: discovery.ErrInvalidQuery,
+		},
+		"query object with fields of the SHIP query": {
+			question:      &lookup.LookupQuestion{Service: discovery.SLAPLookupService, Query: json.RawMessage(`{"topics": ["tm_example"]}`)},
+			expectedError: discovery.ErrInvalidQuery,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			answer, err := slap.Lookup(context.Background(), tc.question)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Nil(t, answer)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package discovery
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var _ engine.TopicManager = (*TopicManager)(nil)
+
+// TopicManager admits the outputs of a transaction that are valid, correctly signed advertisements of its protocol.
+// Spending an advertisement revokes it, so no coins are ever retained.
+type TopicManager struct {
+	protocol overlay.Protocol
+	metaData *overlay.MetaData
+	docs     string
+}
+
+// NewSHIPTopicManager creates the tm_ship topic manager, admitting SHIP advertisements.
+func NewSHIPTopicManager() *TopicManager {
+	return &TopicManager{
+		protocol: overlay.ProtocolSHIP,
+		metaData: &overlay.MetaData{
+			Name:        "SHIP Topic Manager",
+			Description: "Manages SHIP tokens for service host interconnect.",
+		},
+		docs: "The SHIP topic manager admits SHIP advertisements: PushDrop tokens announcing that a domain hosts a topic. " +
+			"An advertisement is admitted when it names a valid tm_ topic, an advertisable https domain, and is locked with " +
+			"and signed by the key derived from its identity key. Spending an advertisement revokes it.",
+	}
+}
+
+// NewSLAPTopicManager creates the tm_slap topic manager, admitting SLAP advertisements.
+func NewSLAPTopicManager() *TopicManager {
+	return &TopicManager{
+		protocol: overlay.ProtocolSLAP,
+		metaData: &overlay.MetaData{
+			Name:        "SLAP Topic Manager",
+			Description: "Manages SLAP tokens for service lookup availability.",
+		},
+		docs: "The SLAP topic manager admits SLAP advertisements: PushDrop tokens announcing that a domain provides a lookup service. " +
+			"An advertisement is admitted when it names a valid ls_ service, an advertisable https domain, and is locked with " +
+			"and signed by the key derived from its identity key. Spending an advertisement revokes it.",
+	}
+}
+
+// IdentifyAdmissibleOutputs admits the outputs of the transaction that are valid advertisements of the protocol.
+func (m *TopicManager) IdentifyAdmissibleOutputs(_ context.Context, beef []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	tx, err := transaction.NewTransactionFromBEEF(beef)
+	if err != nil {
+		slog.Error("failed to parse advertisement transaction", "protocol", m.protocol, "error", err)
+		return overlay.AdmittanceInstructions{}, err
+	}
+
+	var admit overlay.AdmittanceInstructions
+	for vout, output := range tx.Outputs {
+		advertisement, err := ParseAdvertisement(output.LockingScript, m.protocol)
+		if err != nil {
+			continue
+		}
+		slog.Debug("admitting advertisement", "protocol", m.protocol, "txid", tx.TxID().String(), "vout", vout,
+			"domain", advertisement.Domain, "topicOrService", advertisement.TopicOrService)
+		admit.OutputsToAdmit = append(admit.OutputsToAdmit, uint32(vout)) //nolint:gosec // index bounded by slice length
+	}
+	return admit, nil
+}
+
+// IdentifyNeededInputs returns no inputs: advertisements are validated from their own locking scripts.
+func (m *TopicManager) IdentifyNeededInputs(_ context.Context, _ []byte) ([]*transaction.Outpoint, error) {
+	return nil, nil
+}
+
+// GetDocumentation returns the documentation of the topic manager.
+func (m *TopicManager) GetDocumentation() string {
+	return m.docs
+}
+
+// GetMetaData returns the metadata of the topic manager.
+func (m *TopicManager) GetMetaData() *overlay.MetaData {
+	return m.metaData
+}
@@ -0,0 +1,377 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultAdmissionOracleTimeout bounds a single call to an admission oracle when no timeout is configured.
+	DefaultAdmissionOracleTimeout = 5 * time.Second
+
+	// DefaultAdmissionOracleCacheSize is the number of decisions cached when caching is enabled without a size.
+	DefaultAdmissionOracleCacheSize = 10000
+)
+
+// AdmissionOracleFailurePolicy decides what an AdmissionOracle answers when the oracle cannot be reached
+// or returns an invalid decision.
+type AdmissionOracleFailurePolicy string
+
+const (
+	// AdmissionOracleFailClosed fails the admission with ErrAdmissionOracleUnavailable, so nothing is admitted.
+	// It is the default policy.
+	AdmissionOracleFailClosed AdmissionOracleFailurePolicy = "fail-closed"
+
+	// AdmissionOracleFailOpen admits every output of the transaction and retains every previous coin.
+	AdmissionOracleFailOpen AdmissionOracleFailurePolicy = "fail-open"
+)
+
+var (
+	// ErrAdmissionOracleUnavailable is returned under AdmissionOracleFailClosed when the oracle fails
+	ErrAdmissionOracleUnavailable = errors.New("admission oracle unavailable")
+
+	// ErrInvalidAdmissionOracleConfig is returned when creating an admission oracle without an endpoint or transport
+	ErrInvalidAdmissionOracleConfig = errors.New("admission oracle requires an endpoint or a transport")
+)
+
+// AdmissionOracleConfig configures a topic whose admission decisions are delegated to an external oracle service.
+type AdmissionOracleConfig struct {
+	// Endpoint is the base URL of an HTTP oracle. Decisions are requested with a POST to
+	// Endpoint/identifyAdmissibleOutputs and needed inputs with a POST to Endpoint/identifyNeededInputs.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// BearerToken, when set, is sent as an Authorization bearer token on every HTTP request.
+	BearerToken string `mapstructure:"bearer_token"`
+
+	// Timeout bounds each call to the oracle. Zero falls back to DefaultAdmissionOracleTimeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// CacheTTL is how long successful decisions are cached, keyed by BEEF and previous coins. Zero disables caching.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+
+	// CacheSize bounds the number of cached decisions. Zero falls back to DefaultAdmissionOracleCacheSize.
+	CacheSize int `mapstructure:"cache_size"`
+
+	// FailurePolicy decides the answer when the oracle fails. Empty falls back to AdmissionOracleFailClosed.
+	FailurePolicy AdmissionOracleFailurePolicy `mapstructure:"failure_policy"`
+
+	// Documentation is returned by GetDocumentation.
+	Documentation string `mapstructure:"documentation"`
+
+	// MetaData is returned by GetMetaData.
+	MetaData overlay.MetaData `mapstructure:"meta_data"`
+}
+
+// AdmissionOracleCoin is a previous coin spent by the transaction under evaluation.
+type AdmissionOracleCoin struct {
+	Satoshis      uint64 `json:"satoshis"`
+	LockingScript string `json:"lockingScript"`
+}
+
+// AdmissionOracleRequest is the input of an admission decision. Beef is encoded as base64 in JSON
+// and previous coins are keyed by the index of the input spending them.
+type AdmissionOracleRequest struct {
+	Beef          []byte                         `json:"beef"`
+	PreviousCoins map[uint32]AdmissionOracleCoin `json:"previousCoins"`
+}
+
+// AdmissionOracleDecision is the admission decision returned by an oracle.
+type AdmissionOracleDecision struct {
+	OutputsToAdmit []uint32 `json:"outputsToAdmit"`
+	CoinsToRetain  []uint32 `json:"coinsToRetain"`
+	CoinsRemoved   []uint32 `json:"coinsRemoved"`
+	AncillaryTxids []string `json:"ancillaryTxids"`
+}
+
+// AdmissionOracleTransport calls an admission oracle. The HTTP transport is used when none is given;
+// other protocols, e.g. gRPC, are supported by implementing this interface.
+type AdmissionOracleTransport interface {
+	IdentifyAdmissibleOutputs(ctx context.Context, request *AdmissionOracleRequest) (*AdmissionOracleDecision, error)
+	IdentifyNeededInputs(ctx context.Context, beef []byte) ([]*transaction.Outpoint, error)
+}
+
+// AdmissionOracle is a TopicManager delegating its decisions to an external oracle service, enabling
+// admission logic written in other languages. The engine provides the BEEF and previous coins, while the
+// oracle's answers are validated against the transaction and cached when configured. It is safe for concurrent use.
+type AdmissionOracle struct {
+	cfg       AdmissionOracleConfig
+	transport AdmissionOracleTransport
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedAdmission
+}
+
+type cachedAdmission struct {
+	admit   overlay.AdmittanceInstructions
+	expires time.Time
+}
+
+// NewAdmissionOracle creates an AdmissionOracle calling the given transport, or the HTTP oracle at
+// cfg.Endpoint when transport is nil.
+func NewAdmissionOracle(cfg AdmissionOracleConfig, transport AdmissionOracleTransport) (*AdmissionOracle, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultAdmissionOracleTimeout
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = DefaultAdmissionOracleCacheSize
+	}
+	if cfg.FailurePolicy == "" {
+		cfg.FailurePolicy = AdmissionOracleFailClosed
+	}
+	if transport == nil {
+		if cfg.Endpoint == "" {
+			return nil, ErrInvalidAdmissionOracleConfig
+		}
+		transport = &httpAdmissionOracle{
+			endpoint:    strings.TrimRight(cfg.Endpoint, "/"),
+			bearerToken: cfg.BearerToken,
+			client:      &http.Client{Timeout: cfg.Timeout},
+		}
+	}
+	return &AdmissionOracle{
+		cfg:       cfg,
+		transport: transport,
+		cache:     make(map[[sha256.Size]byte]cachedAdmission),
+	}, nil
+}
+
+// IdentifyAdmissibleOutputs asks the oracle which outputs to admit, applying the failure policy when it fails.
+func (o *AdmissionOracle) IdentifyAdmissibleOutputs(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	_, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return overlay.AdmittanceInstructions{}, err
+	} else if tx == nil {
+		return overlay.AdmittanceInstructions{}, ErrInvalidBeef
+	}
+
+	key := admissionCacheKey(beef, previousCoins)
+	if admit, ok := o.cached(key); ok {
+		return admit, nil
+	}
+
+	request := &AdmissionOracleRequest{Beef: beef, PreviousCoins: make(map[uint32]AdmissionOracleCoin, len(previousCoins))}
+	for vin, coin := range previousCoins {
+		oracleCoin := AdmissionOracleCoin{Satoshis: coin.Satoshis}
+		if coin.LockingScript != nil {
+			oracleCoin.LockingScript = coin.LockingScript.String()
+		}
+		request.PreviousCoins[vin] = oracleCoin
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.cfg.Timeout)
+	defer cancel()
+	decision, err := o.transport.IdentifyAdmissibleOutputs(ctx, request)
+	if err == nil {
+		var admit overlay.AdmittanceInstructions
+		if admit, err = decision.instructions(tx, previousCoins); err == nil {
+			o.store(key, admit)
+			return admit, nil
+		}
+	}
+
+	if o.cfg.FailurePolicy == AdmissionOracleFailOpen {
+		slog.Warn("admission oracle failed, admitting every output", "txid", tx.TxID(), "error", err)
+		return failOpenAdmission(tx, previousCoins), nil
+	}
+	slog.Error("admission oracle failed, rejecting transaction", "txid", tx.TxID(), "error", err)
+	return overlay.AdmittanceInstructions{}, fmt.Errorf("%w: %w", ErrAdmissionOracleUnavailable, err)
+}
+
+// IdentifyNeededInputs asks the oracle which inputs are needed to evaluate the transaction.
+// Failures are returned as is, since no inputs can be assumed on the oracle's behalf.
+func (o *AdmissionOracle) IdentifyNeededInputs(ctx context.Context, beef []byte) ([]*transaction.Outpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.cfg.Timeout)
+	defer cancel()
+	return o.transport.IdentifyNeededInputs(ctx, beef)
+}
+
+// GetDocumentation returns the configured documentation.
+func (o *AdmissionOracle) GetDocumentation() string {
+	return o.cfg.Documentation
+}
+
+// GetMetaData returns the configured metadata.
+func (o *AdmissionOracle) GetMetaData() *overlay.MetaData {
+	metaData := o.cfg.MetaData
+	return &metaData
+}
+
+func (o *AdmissionOracle) cached(key [sha256.Size]byte) (overlay.AdmittanceInstructions, bool) {
+	if o.cfg.CacheTTL <= 0 {
+		return overlay.AdmittanceInstructions{}, false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry, ok := o.cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(o.cache, key)
+		return overlay.AdmittanceInstructions{}, false
+	}
+	return entry.admit, true
+}
+
+func (o *AdmissionOracle) store(key [sha256.Size]byte, admit overlay.AdmittanceInstructions) {
+	if o.cfg.CacheTTL <= 0 {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	if len(o.cache) >= o.cfg.CacheSize {
+		for k, entry := range o.cache {
+			if now.After(entry.expires) {
+				delete(o.cache, k)
+			}
+		}
+	}
+	for k := range o.cache {
+		if len(o.cache) < o.cfg.CacheSize {
+			break
+		}
+		delete(o.cache, k)
+	}
+	o.cache[key] = cachedAdmission{admit: admit, expires: now.Add(o.cfg.CacheTTL)}
+}
+
+// instructions validates the decision against the transaction, since a misbehaving oracle must not
+// admit outputs or retain coins that do not exist.
+func (d *AdmissionOracleDecision) instructions(tx *transaction.Transaction, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	admit := overlay.AdmittanceInstructions{
+		OutputsToAdmit: d.OutputsToAdmit,
+		CoinsToRetain:  d.CoinsToRetain,
+		CoinsRemoved:   d.CoinsRemoved,
+	}
+	for _, vout := range d.OutputsToAdmit {
+		if int(vout) >= len(tx.Outputs) {
+			return overlay.AdmittanceInstructions{}, fmt.Errorf("%w: output %d to admit does not exist", ErrInvalidTransaction, vout)
+		}
+	}
+	for _, vin := range d.CoinsToRetain {
+		if _, ok := previousCoins[vin]; !ok {
+			return overlay.AdmittanceInstructions{}, fmt.Errorf("%w: coin %d to retain is not a previous coin", ErrInvalidTransaction, vin)
+		}
+	}
+	for _, txid := range d.AncillaryTxids {
+		hash, err := chainhash.NewHashFromHex(txid)
+		if err != nil {
+			return overlay.AdmittanceInstructions{}, fmt.Errorf("invalid ancillary txid %q: %w", txid, err)
+		}
+		admit.AncillaryTxids = append(admit.AncillaryTxids, hash)
+	}
+	return admit, nil
+}
+
+func failOpenAdmission(tx *transaction.Transaction, previousCoins map[uint32]*transaction.TransactionOutput) overlay.AdmittanceInstructions {
+	admit := overlay.AdmittanceInstructions{
+		OutputsToAdmit: make([]uint32, 0, len(tx.Outputs)),
+		CoinsToRetain:  make([]uint32, 0, len(previousCoins)),
+	}
+	for vout := range tx.Outputs {
+		admit.OutputsToAdmit = append(admit.OutputsToAdmit, uint32(vout)) //nolint:gosec // index bounded by slice length
+	}
+	for vin := range previousCoins {
+		admit.CoinsToRetain = append(admit.CoinsToRetain, vin)
+	}
+	sort.Slice(admit.CoinsToRetain, func(i, j int) bool { return admit.CoinsToRetain[i] < admit.CoinsToRetain[j] })
+	return admit
+}
+
+// admissionCacheKey identifies a decision by the BEEF and the previous coins it was made for.
+func admissionCacheKey(beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) [sha256.Size]byte {
+	vins := make([]uint32, 0, len(previousCoins))
+	for vin := range previousCoins {
+		vins = append(vins, vin)
+	}
+	sort.Slice(vins, func(i, j int) bool { return vins[i] < vins[j] })
+
+	h := sha256.New()
+	h.Write(beef)
+	for _, vin := range vins {
+		coin := previousCoins[vin]
+		_ = binary.Write(h, binary.LittleEndian, vin)
+		_ = binary.Write(h, binary.LittleEndian, coin.Satoshis)
+		if coin.LockingScript != nil {
+			h.Write(*coin.LockingScript)
+		}
+	}
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// httpAdmissionOracle is the AdmissionOracleTransport of oracles served over HTTP with JSON bodies.
+type httpAdmissionOracle struct {
+	endpoint    string
+	bearerToken string
+	client      *http.Client
+}
+
+func (h *httpAdmissionOracle) IdentifyAdmissibleOutputs(ctx context.Context, request *AdmissionOracleRequest) (*AdmissionOracleDecision, error) {
+	var decision AdmissionOracleDecision
+	if err := h.post(ctx, "/identifyAdmissibleOutputs", request, &decision); err != nil {
+		return nil, err
+	}
+	return &decision, nil
+}
+
+func (h *httpAdmissionOracle) IdentifyNeededInputs(ctx context.Context, beef []byte) ([]*transaction.Outpoint, error) {
+	var response struct {
+		Inputs []string `json:"inputs"`
+	}
+	if err := h.post(ctx, "/identifyNeededInputs", map[string][]byte{"beef": beef}, &response); err != nil {
+		return nil, err
+	}
+	inputs := make([]*transaction.Outpoint, 0, len(response.Inputs))
+	for _, input := range response.Inputs {
+		outpoint, err := transaction.OutpointFromString(input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid needed input %q: %w", input, err)
+		}
+		inputs = append(inputs, outpoint)
+	}
+	return inputs, nil
+}
+
+func (h *httpAdmissionOracle) post(ctx context.Context, path string, body, result any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+h.bearerToken)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("admission oracle responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg))) //nolint:err113 // dynamic error needed for context
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
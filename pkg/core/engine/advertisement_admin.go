@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var (
+	// ErrAdvertiserNotConfigured is returned when managing advertisements on an engine without an advertiser.
+	ErrAdvertiserNotConfigured = errors.New("advertiser is not configured")
+
+	// ErrAdvertisementNotFound is returned when revoking an outpoint that is not one of the node's advertisements.
+	ErrAdvertisementNotFound = errors.New("advertisement not found")
+
+	// ErrInvalidAdvertisementProtocol is returned when creating an advertisement for a protocol other than SHIP or SLAP.
+	ErrInvalidAdvertisementProtocol = errors.New("advertisement protocol must be SHIP or SLAP")
+)
+
+// ListedAdvertisement is a current SHIP or SLAP advertisement of the node, identified by the outpoint of its output.
+type ListedAdvertisement struct {
+	Outpoint       transaction.Outpoint
+	Protocol       overlay.Protocol
+	IdentityKey    string
+	Domain         string
+	TopicOrService string
+}
+
+// ListAdvertisements returns the current SHIP and SLAP advertisements of the node, as found by the advertiser.
+func (e *Engine) ListAdvertisements(ctx context.Context) ([]*ListedAdvertisement, error) {
+	advertisements, err := e.findAdvertisements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listed := make([]*ListedAdvertisement, 0, len(advertisements))
+	for _, ad := range advertisements {
+		listed = append(listed, &ListedAdvertisement{
+			Outpoint:       ad.outpoint,
+			Protocol:       ad.Protocol,
+			IdentityKey:    ad.IdentityKey,
+			Domain:         ad.Domain,
+			TopicOrService: ad.TopicOrService,
+		})
+	}
+	return listed, nil
+}
+
+// CreateAdvertisement creates an advertisement of the topic or lookup service for the given protocol and submits it,
+// whether or not the node hosts it. Unlike SyncAdvertisements, it does not look at the other advertisements.
+func (e *Engine) CreateAdvertisement(ctx context.Context, protocol overlay.Protocol, topicOrService string) (overlay.Steak, error) {
+	if e.Advertiser == nil {
+		return nil, ErrAdvertiserNotConfigured
+	}
+	if protocol != overlay.ProtocolSHIP && protocol != overlay.ProtocolSLAP {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAdvertisementProtocol, protocol)
+	}
+
+	taggedBEEF, err := e.Advertiser.CreateAdvertisements([]*advertiser.AdvertisementData{{
+		Protocol:           protocol,
+		TopicOrServiceName: topicOrService,
+	}})
+	if err != nil {
+		slog.Error("failed to create advertisement", "protocol", protocol, "topicOrService", topicOrService, "error", err)
+		return nil, err
+	}
+	steak, err := e.Submit(ctx, taggedBEEF, SubmitModeCurrent, nil)
+	if err != nil {
+		slog.Error("failed to submit advertisement", "protocol", protocol, "topicOrService", topicOrService, "error", err)
+		return nil, err
+	}
+	return steak, nil
+}
+
+// RevokeAdvertisement revokes the advertisement of the node held in the output at the given outpoint and submits the revocation.
+func (e *Engine) RevokeAdvertisement(ctx context.Context, outpoint *transaction.Outpoint) (overlay.Steak, error) {
+	advertisements, err := e.findAdvertisements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var revoked *advertiser.Advertisement
+	for _, ad := range advertisements {
+		if ad.outpoint == *outpoint {
+			revoked = ad.Advertisement
+			break
+		}
+	}
+	if revoked == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAdvertisementNotFound, outpoint)
+	}
+
+	taggedBEEF, err := e.Advertiser.RevokeAdvertisements([]*advertiser.Advertisement{revoked})
+	if err != nil {
+		slog.Error("failed to revoke advertisement", "outpoint", outpoint.String(), "error", err)
+		return nil, err
+	}
+	steak, err := e.Submit(ctx, taggedBEEF, SubmitModeCurrent, nil)
+	if err != nil {
+		slog.Error("failed to submit advertisement revocation", "outpoint", outpoint.String(), "error", err)
+		return nil, err
+	}
+	return steak, nil
+}
+
+type foundAdvertisement struct {
+	*advertiser.Advertisement
+	outpoint transaction.Outpoint
+}
+
+// findAdvertisements returns the SHIP and SLAP advertisements found by the advertiser with their outpoints.
+func (e *Engine) findAdvertisements(_ context.Context) ([]foundAdvertisement, error) {
+	if e.Advertiser == nil {
+		return nil, ErrAdvertiserNotConfigured
+	}
+	var found []foundAdvertisement
+	for _, protocol := range []overlay.Protocol{overlay.ProtocolSHIP, overlay.ProtocolSLAP} {
+		advertisements, err := e.Advertiser.FindAllAdvertisements(protocol)
+		if err != nil {
+			slog.Error("failed to find advertisements", "protocol", protocol, "error", err)
+			return nil, err
+		}
+		for _, ad := range advertisements {
+			tx, err := transaction.NewTransactionFromBEEF(ad.Beef)
+			if err != nil {
+				slog.Error("failed to parse advertisement BEEF", "protocol", protocol, "topicOrService", ad.TopicOrService, "error", err)
+				return nil, err
+			}
+			found = append(found, foundAdvertisement{
+				Advertisement: ad,
+				outpoint:      transaction.Outpoint{Txid: *tx.TxID(), Index: ad.OutputIndex},
+			})
+		}
+	}
+	return found, nil
+}
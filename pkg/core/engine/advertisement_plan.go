@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sort"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+const (
+	// DefaultAdvertisementSatoshis is the amount locked in each advertisement output when no amount is configured.
+	DefaultAdvertisementSatoshis = 1
+
+	// DefaultAdvertisementFeeRate is the fee rate, in satoshis per kilobyte, used to estimate the cost of
+	// advertisement transactions when no rate is configured. It errs on the high side so that the budget
+	// cap is not exceeded by an underestimate.
+	DefaultAdvertisementFeeRate = 100
+)
+
+// Estimated sizes, in bytes, of the parts of advertisement transactions.
+const (
+	advertisementTxOverhead     = 10
+	advertisementFundingInput   = 148
+	advertisementChangeOutput   = 34
+	advertisementOutputSize     = 200 // PushDrop output holding the protocol, identity key, domain, topic and signature
+	advertisementRevocationSize = 120 // input unlocking an advertisement output
+)
+
+// ErrAdvertisementBudgetExceeded is returned by SyncAdvertisements when the estimated cost of the
+// advertisement batch exceeds AdvertisementBudget.MaxSatoshisPerRun.
+var ErrAdvertisementBudgetExceeded = errors.New("advertisement batch exceeds budget")
+
+// AdvertisementBudget configures how the cost of advertisement batches is estimated and capped.
+type AdvertisementBudget struct {
+	// SatoshisPerOutput is the amount locked in each advertisement output. Zero falls back to DefaultAdvertisementSatoshis.
+	SatoshisPerOutput uint64 `mapstructure:"satoshis_per_output"`
+
+	// FeeRate is the fee rate in satoshis per kilobyte. Zero falls back to DefaultAdvertisementFeeRate.
+	FeeRate uint64 `mapstructure:"fee_rate"`
+
+	// MaxSatoshisPerRun caps the estimated cost of a single SyncAdvertisements run. Zero disables the cap.
+	MaxSatoshisPerRun uint64 `mapstructure:"max_satoshis_per_run"`
+}
+
+// AdvertisementCost is the estimated satoshi cost of an advertisement batch to the advertiser wallet.
+type AdvertisementCost struct {
+	OutputsCreated int    // advertisement outputs created
+	OutputsRevoked int    // advertisement outputs spent by revocations
+	LockedSatoshis uint64 // satoshis locked in the created advertisement outputs
+	FeeSatoshis    uint64 // fees of the creation and revocation transactions
+	TotalSatoshis  uint64 // locked satoshis and fees; the satoshis reclaimed by revocations are not deducted
+	BudgetSatoshis uint64 // AdvertisementBudget.MaxSatoshisPerRun, zero when uncapped
+	WithinBudget   bool
+}
+
+// AdvertisementCostEstimator is an optional Advertiser capability used to estimate the cost of an
+// advertisement batch more precisely than the engine's size-based estimate, e.g. from the wallet's fee model.
+type AdvertisementCostEstimator interface {
+	// EstimateAdvertisementCost returns the locked satoshis and fees of creating and revoking the advertisements.
+	EstimateAdvertisementCost(create []*advertiser.AdvertisementData, revoke []*advertiser.Advertisement) (lockedSatoshis, feeSatoshis uint64, err error)
+}
+
+// AdvertisementPlan describes the advertisements a SyncAdvertisements run would create and revoke, and its estimated cost.
+type AdvertisementPlan struct {
+	Create []*advertiser.AdvertisementData
+	Revoke []*advertiser.Advertisement
+	Cost   AdvertisementCost
+}
+
+// PlanAdvertisements computes the advertisements SyncAdvertisements would create and revoke, without
+// touching them, together with the estimated cost of the batch. An engine without an advertiser plans nothing.
+// The SHIP advertisements of topics whose sync is paused are neither created nor revoked.
+func (e *Engine) PlanAdvertisements(ctx context.Context) (*AdvertisementPlan, error) {
+	plan := &AdvertisementPlan{}
+	if e.Advertiser == nil {
+		plan.Cost = e.estimateAdvertisementCost(plan)
+		return plan, nil
+	}
+
+	for _, protocol := range []overlay.Protocol{overlay.ProtocolSHIP, overlay.ProtocolSLAP} {
+		required := make(map[string]struct{})
+		paused := make(map[string]struct{})
+		if protocol == overlay.ProtocolSHIP {
+			for name := range e.topicManagers() {
+				required[name] = struct{}{}
+			}
+			for _, name := range e.PausedSyncTopics(ctx) {
+				paused[name] = struct{}{}
+			}
+		} else {
+			for name := range e.lookupServices() {
+				required[name] = struct{}{}
+			}
+		}
+
+		current, err := e.Advertiser.FindAllAdvertisements(protocol)
+		if err != nil {
+			slog.Error("failed to find advertisements", "protocol", protocol, "error", err)
+			return nil, err
+		}
+		missing := make([]string, 0, len(required))
+		for name := range required {
+			if _, ok := paused[name]; ok {
+				continue
+			}
+			if slices.IndexFunc(current, func(ad *advertiser.Advertisement) bool {
+				return ad.TopicOrService == name && ad.Domain == e.HostingURL
+			}) == -1 {
+				missing = append(missing, name)
+			}
+		}
+		sort.Strings(missing)
+		for _, name := range missing {
+			plan.Create = append(plan.Create, &advertiser.AdvertisementData{
+				Protocol:           protocol,
+				TopicOrServiceName: name,
+			})
+		}
+		for _, ad := range current {
+			if _, ok := paused[ad.TopicOrService]; ok {
+				continue
+			}
+			if _, ok := required[ad.TopicOrService]; !ok {
+				plan.Revoke = append(plan.Revoke, ad)
+			}
+		}
+	}
+
+	if estimator, ok := e.Advertiser.(AdvertisementCostEstimator); ok {
+		locked, fees, err := estimator.EstimateAdvertisementCost(plan.Create, plan.Revoke)
+		if err != nil {
+			slog.Error("failed to estimate advertisement cost", "error", err)
+			return nil, err
+		}
+		plan.Cost = e.budgetedAdvertisementCost(plan, locked, fees)
+		return plan, nil
+	}
+	plan.Cost = e.estimateAdvertisementCost(plan)
+	return plan, nil
+}
+
+// estimateAdvertisementCost estimates the cost of the plan from the expected size of its transactions.
+// Creations and revocations are each assumed to be a single transaction funded by one input with change.
+func (e *Engine) estimateAdvertisementCost(plan *AdvertisementPlan) AdvertisementCost {
+	satoshisPerOutput, feeRate := uint64(DefaultAdvertisementSatoshis), uint64(DefaultAdvertisementFeeRate)
+	if e.AdvertisementBudget != nil {
+		if e.AdvertisementBudget.SatoshisPerOutput > 0 {
+			satoshisPerOutput = e.AdvertisementBudget.SatoshisPerOutput
+		}
+		if e.AdvertisementBudget.FeeRate > 0 {
+			feeRate = e.AdvertisementBudget.FeeRate
+		}
+	}
+
+	fee := func(size uint64) uint64 { return (size*feeRate + 999) / 1000 }
+	var fees uint64
+	if n := uint64(len(plan.Create)); n > 0 {
+		fees += fee(advertisementTxOverhead + advertisementFundingInput + advertisementChangeOutput + n*advertisementOutputSize)
+	}
+	if n := uint64(len(plan.Revoke)); n > 0 {
+		fees += fee(advertisementTxOverhead + advertisementFundingInput + advertisementChangeOutput + n*advertisementRevocationSize)
+	}
+	return e.budgetedAdvertisementCost(plan, uint64(len(plan.Create))*satoshisPerOutput, fees)
+}
+
+// budgetedAdvertisementCost checks the locked satoshis and fees of the plan against the budget.
+func (e *Engine) budgetedAdvertisementCost(plan *AdvertisementPlan, locked, fees uint64) AdvertisementCost {
+	cost := AdvertisementCost{
+		OutputsCreated: len(plan.Create),
+		OutputsRevoked: len(plan.Revoke),
+		LockedSatoshis: locked,
+		FeeSatoshis:    fees,
+		TotalSatoshis:  locked + fees,
+		WithinBudget:   true,
+	}
+	if e.AdvertisementBudget != nil && e.AdvertisementBudget.MaxSatoshisPerRun > 0 {
+		cost.BudgetSatoshis = e.AdvertisementBudget.MaxSatoshisPerRun
+		cost.WithinBudget = cost.TotalSatoshis <= cost.BudgetSatoshis
+	}
+	return cost
+}
@@ -0,0 +1,464 @@
+package engine
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/broadcaster"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultARCHealthCheckInterval is how often the ARC endpoints are checked when no interval is configured.
+	DefaultARCHealthCheckInterval = time.Minute
+
+	// DefaultARCFailureThreshold is how many consecutive failures mark an ARC endpoint unhealthy when no threshold is configured.
+	DefaultARCFailureThreshold = 3
+
+	// DefaultARCCallbackTokenRotation is how often the callback tokens are rotated when no interval is configured.
+	DefaultARCCallbackTokenRotation = 24 * time.Hour
+
+	// DefaultARCCallbackTokenGracePeriod is how long a rotated callback token keeps being accepted when no grace
+	// period is configured. ARC delivers the callback of a transaction once it is mined, which may be long after
+	// the broadcast that handed the token out.
+	DefaultARCCallbackTokenGracePeriod = 72 * time.Hour
+
+	// DefaultARCRequestTimeout bounds a single broadcast or health check request to an ARC endpoint.
+	DefaultARCRequestTimeout = 30 * time.Second
+
+	// maxARCHealthResponseSize bounds the responses read from the health endpoint of ARC.
+	maxARCHealthResponseSize = 64 << 10
+)
+
+var (
+	// ErrNoARCEndpoints is returned when creating an ARC pool without endpoints
+	ErrNoARCEndpoints = errors.New("no ARC endpoints configured")
+
+	// ErrInvalidARCEndpoint is returned when creating an ARC pool with an endpoint that has no URL
+	ErrInvalidARCEndpoint = errors.New("invalid ARC endpoint")
+
+	// ErrARCUnhealthy is returned by a health check of an ARC endpoint that reports itself unhealthy
+	ErrARCUnhealthy = errors.New("ARC endpoint is unhealthy")
+)
+
+// ARCEndpointConfig configures a single ARC instance of an ARCPool.
+type ARCEndpointConfig struct {
+	// Name identifies the endpoint in logs and status reports. Defaults to the URL.
+	Name string `mapstructure:"name"`
+
+	// URL is the base URL of the ARC instance, without the /v1 API prefix.
+	URL string `mapstructure:"url"`
+
+	// APIKey, when set, authenticates the requests to the endpoint.
+	APIKey string `mapstructure:"api_key"`
+
+	// CallbackToken is the initial callback token handed to the endpoint. A random token is generated when empty.
+	CallbackToken string `mapstructure:"callback_token"`
+
+	// SigningSecret, when set, is the HMAC key the endpoint signs the payload of its callbacks with, and callbacks
+	// presenting a token of the endpoint are only accepted with a valid signature. See SignARCCallback.
+	SigningSecret string `mapstructure:"signing_secret"`
+}
+
+// ARCPoolConfig configures the ARC instances transactions are broadcast to and the callback tokens handed to them.
+type ARCPoolConfig struct {
+	// Endpoints are the ARC instances, in order of preference.
+	Endpoints []ARCEndpointConfig `mapstructure:"endpoints"`
+
+	// CallbackURL is the URL of the arc-ingest endpoint ARC delivers merkle proofs to. Callbacks are not requested when empty.
+	CallbackURL string `mapstructure:"callback_url"`
+
+	// HealthCheckInterval is how often the endpoints are checked. Zero falls back to DefaultARCHealthCheckInterval.
+	HealthCheckInterval time.Duration `mapstructure:"health_check_interval"`
+
+	// FailureThreshold is how many consecutive failed broadcasts or health checks mark an endpoint unhealthy.
+	// Zero falls back to DefaultARCFailureThreshold.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// TokenRotationInterval is how often the callback tokens are rotated. Zero falls back to
+	// DefaultARCCallbackTokenRotation and a negative interval disables the rotation.
+	TokenRotationInterval time.Duration `mapstructure:"token_rotation_interval"`
+
+	// TokenGracePeriod is how long a rotated callback token keeps being accepted. Zero falls back to
+	// DefaultARCCallbackTokenGracePeriod.
+	TokenGracePeriod time.Duration `mapstructure:"token_grace_period"`
+}
+
+// ARCEndpointStatus describes the health of an ARC endpoint of an ARCPool.
+type ARCEndpointStatus struct {
+	Name                string
+	URL                 string
+	Healthy             bool
+	ConsecutiveFailures int
+	LastCheck           time.Time // zero until the first health check
+	LastError           string    // error of the last failed broadcast or health check
+}
+
+// retiredToken is a rotated callback token that is accepted until it expires.
+type retiredToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// arcEndpoint holds the state of an ARC instance. Its fields are guarded by the mutex of the pool.
+type arcEndpoint struct {
+	name   string
+	url    string
+	apiKey string
+	secret string
+
+	token   string
+	retired []retiredToken
+
+	healthy   bool
+	failures  int
+	lastCheck time.Time
+	lastError string
+}
+
+// ARCPool is a transaction.Broadcaster spreading broadcasts over several ARC instances. Transactions are
+// broadcast to the first healthy endpoint and fail over to the next one when an endpoint cannot be reached.
+// Each endpoint is handed its own callback token, which is rotated periodically, and callbacks presenting the
+// current or a recently rotated token of any endpoint are accepted through ValidCallbackToken.
+type ARCPool struct {
+	cfg       ARCPoolConfig
+	client    *http.Client
+	endpoints []*arcEndpoint
+
+	mu sync.Mutex
+}
+
+// NewARCPool creates an ARCPool broadcasting to the configured endpoints, all of which start healthy.
+func NewARCPool(cfg ARCPoolConfig) (*ARCPool, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, ErrNoARCEndpoints
+	}
+
+	pool := &ARCPool{cfg: cfg, client: &http.Client{Timeout: DefaultARCRequestTimeout}}
+	for i, endpoint := range cfg.Endpoints {
+		if endpoint.URL == "" {
+			return nil, fmt.Errorf("%w: endpoint %d has no URL", ErrInvalidARCEndpoint, i)
+		}
+		name := endpoint.Name
+		if name == "" {
+			name = endpoint.URL
+		}
+		token := endpoint.CallbackToken
+		if token == "" {
+			token = uuid.NewString()
+		}
+		pool.endpoints = append(pool.endpoints, &arcEndpoint{
+			name:    name,
+			url:     strings.TrimRight(endpoint.URL, "/"),
+			apiKey:  endpoint.APIKey,
+			secret:  endpoint.SigningSecret,
+			token:   token,
+			healthy: true,
+		})
+	}
+	return pool, nil
+}
+
+// Broadcast broadcasts the transaction to the first healthy ARC endpoint.
+func (p *ARCPool) Broadcast(tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	return p.BroadcastCtx(context.Background(), tx)
+}
+
+// BroadcastCtx broadcasts the transaction to the healthy ARC endpoints in order of preference until one of them
+// accepts or rejects it. Endpoints that cannot be reached, fail internally or refuse the API key are skipped, and
+// every endpoint is tried when none is considered healthy. A rejection of the transaction itself is returned as is.
+func (p *ARCPool) BroadcastCtx(ctx context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+	var failure *transaction.BroadcastFailure
+	for _, endpoint := range p.candidates() {
+		success, rejected := p.arc(endpoint).BroadcastCtx(ctx, tx)
+		if rejected == nil {
+			p.recordSuccess(endpoint)
+			return success, nil
+		}
+		if !isARCEndpointFailure(rejected) {
+			p.recordSuccess(endpoint)
+			return nil, rejected
+		}
+		slog.Warn("ARC endpoint failed to broadcast, failing over", "endpoint", endpoint.name, "code", rejected.Code, "error", rejected.Description)
+		p.recordFailure(endpoint, rejected)
+		failure = rejected
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, failure
+}
+
+// candidates returns the healthy endpoints in order of preference, or every endpoint when none is healthy.
+func (p *ARCPool) candidates() []*arcEndpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	healthy := make([]*arcEndpoint, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.healthy {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	if len(healthy) == 0 {
+		return p.endpoints
+	}
+	return healthy
+}
+
+// arc returns an ARC broadcaster for the endpoint handing out its current callback token.
+func (p *ARCPool) arc(endpoint *arcEndpoint) *broadcaster.Arc {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	arc := &broadcaster.Arc{ApiUrl: endpoint.url + "/v1", ApiKey: endpoint.apiKey, Client: p.client}
+	if p.cfg.CallbackURL != "" {
+		callbackURL, token := p.cfg.CallbackURL, endpoint.token
+		arc.CallbackUrl = &callbackURL
+		arc.CallbackToken = &token
+	}
+	return arc
+}
+
+// isARCEndpointFailure reports whether a broadcast failed because of the endpoint rather than the transaction.
+func isARCEndpointFailure(failure *transaction.BroadcastFailure) bool {
+	code, err := strconv.Atoi(failure.Code)
+	if err != nil {
+		return true
+	}
+	return code >= http.StatusInternalServerError || code == http.StatusUnauthorized ||
+		code == http.StatusForbidden || code == http.StatusTooManyRequests
+}
+
+func (p *ARCPool) recordSuccess(endpoint *arcEndpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !endpoint.healthy {
+		slog.Info("ARC endpoint recovered", "endpoint", endpoint.name)
+	}
+	endpoint.healthy = true
+	endpoint.failures = 0
+}
+
+func (p *ARCPool) recordFailure(endpoint *arcEndpoint, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint.failures++
+	endpoint.lastError = err.Error()
+	threshold := p.cfg.FailureThreshold
+	if threshold <= 0 {
+		threshold = DefaultARCFailureThreshold
+	}
+	if endpoint.healthy && endpoint.failures >= threshold {
+		slog.Error("ARC endpoint marked unhealthy", "endpoint", endpoint.name, "failures", endpoint.failures, "error", err)
+		endpoint.healthy = false
+	}
+}
+
+// CheckHealth queries the health endpoint of every ARC instance, marking the ones that respond healthy and
+// counting a failure against the others.
+func (p *ARCPool) CheckHealth(ctx context.Context) {
+	for _, endpoint := range p.endpoints {
+		err := p.checkHealth(ctx, endpoint)
+
+		p.mu.Lock()
+		endpoint.lastCheck = time.Now()
+		p.mu.Unlock()
+
+		if err != nil {
+			slog.Warn("ARC health check failed", "endpoint", endpoint.name, "error", err)
+			p.recordFailure(endpoint, err)
+			continue
+		}
+		p.recordSuccess(endpoint)
+	}
+}
+
+func (p *ARCPool) checkHealth(ctx context.Context, endpoint *arcEndpoint) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.url+"/v1/health", nil)
+	if err != nil {
+		return err
+	}
+	if endpoint.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+endpoint.apiKey)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: responded with %s", ErrARCUnhealthy, resp.Status)
+	}
+	var health struct {
+		Healthy *bool  `json:"healthy"`
+		Reason  string `json:"reason"`
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxARCHealthResponseSize))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, &health); err == nil && health.Healthy != nil && !*health.Healthy {
+		return fmt.Errorf("%w: %s", ErrARCUnhealthy, health.Reason)
+	}
+	return nil
+}
+
+// RotateCallbackTokens hands a new callback token to every endpoint. The previous tokens keep being accepted
+// for the configured grace period, so that callbacks of transactions broadcast before the rotation still arrive.
+func (p *ARCPool) RotateCallbackTokens() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	grace := p.cfg.TokenGracePeriod
+	if grace <= 0 {
+		grace = DefaultARCCallbackTokenGracePeriod
+	}
+	for _, endpoint := range p.endpoints {
+		endpoint.retired = append(unexpiredTokens(endpoint.retired, now), retiredToken{token: endpoint.token, expiresAt: now.Add(grace)})
+		endpoint.token = uuid.NewString()
+	}
+	slog.Info("ARC callback tokens rotated", "endpoints", len(p.endpoints), "gracePeriod", grace)
+}
+
+// unexpiredTokens drops the retired tokens that expired by now.
+func unexpiredTokens(tokens []retiredToken, now time.Time) []retiredToken {
+	kept := tokens[:0]
+	for _, retired := range tokens {
+		if now.Before(retired.expiresAt) {
+			kept = append(kept, retired)
+		}
+	}
+	return kept
+}
+
+// ValidCallbackToken reports whether the token is the current callback token of any endpoint, or one rotated
+// out within the grace period.
+func (p *ARCPool) ValidCallbackToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	valid := false
+	for _, endpoint := range p.endpoints {
+		valid = tokensEqual(endpoint.token, token) || valid
+		for _, retired := range endpoint.retired {
+			valid = (now.Before(retired.expiresAt) && tokensEqual(retired.token, token)) || valid
+		}
+	}
+	return valid
+}
+
+// ValidCallbackSignature reports whether the signature of the callback payload satisfies the endpoint the token
+// was handed to: endpoints without a SigningSecret accept any signature, others only the SignARCCallback of the
+// payload with their secret, optionally prefixed with "sha256=". Tokens of no endpoint are never valid.
+func (p *ARCPool) ValidCallbackSignature(token string, payload []byte, signature string) bool {
+	if token == "" {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for _, endpoint := range p.endpoints {
+		owned := tokensEqual(endpoint.token, token)
+		for _, retired := range endpoint.retired {
+			owned = owned || (now.Before(retired.expiresAt) && tokensEqual(retired.token, token))
+		}
+		if !owned {
+			continue
+		}
+		if endpoint.secret == "" {
+			return true
+		}
+		expected := SignARCCallback(endpoint.secret, payload)
+		return tokensEqual(expected, strings.ToLower(strings.TrimPrefix(signature, "sha256=")))
+	}
+	return false
+}
+
+// SignARCCallback returns the hex encoded HMAC-SHA256 of the callback payload keyed with the signing secret of
+// an ARC endpoint, as expected by ARCPool.ValidCallbackSignature.
+func SignARCCallback(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// tokensEqual compares tokens in constant time.
+func tokensEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// Status returns the health of every endpoint in order of preference.
+func (p *ARCPool) Status() []ARCEndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := make([]ARCEndpointStatus, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		status = append(status, ARCEndpointStatus{
+			Name:                endpoint.name,
+			URL:                 endpoint.url,
+			Healthy:             endpoint.healthy,
+			ConsecutiveFailures: endpoint.failures,
+			LastCheck:           endpoint.lastCheck,
+			LastError:           endpoint.lastError,
+		})
+	}
+	return status
+}
+
+// Run checks the health of the endpoints every ARCPoolConfig.HealthCheckInterval and rotates the callback tokens
+// every ARCPoolConfig.TokenRotationInterval until ctx is done.
+func (p *ARCPool) Run(ctx context.Context) {
+	interval := p.cfg.HealthCheckInterval
+	if interval <= 0 {
+		interval = DefaultARCHealthCheckInterval
+	}
+	health := time.NewTicker(interval)
+	defer health.Stop()
+
+	var rotate <-chan time.Time
+	if rotation := p.cfg.TokenRotationInterval; rotation >= 0 {
+		if rotation == 0 {
+			rotation = DefaultARCCallbackTokenRotation
+		}
+		ticker := time.NewTicker(rotation)
+		defer ticker.Stop()
+		rotate = ticker.C
+	}
+
+	p.CheckHealth(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-health.C:
+			p.CheckHealth(ctx)
+		case <-rotate:
+			p.RotateCallbackTokens()
+		}
+	}
+}
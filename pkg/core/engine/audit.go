@@ -0,0 +1,261 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultAuditQueryLimit is the number of entries returned by FindAuditEntries when the query sets no limit.
+	DefaultAuditQueryLimit = 100
+
+	// MaxAuditQueryLimit bounds the number of entries returned by a single FindAuditEntries call.
+	MaxAuditQueryLimit = 1000
+
+	// DefaultMemoryAuditStoreSize is the number of entries kept by a MemoryAuditStore when no size is configured.
+	DefaultMemoryAuditStoreSize = 10000
+)
+
+// ErrAuditLogNotConfigured is returned when querying the audit log of an engine without an AuditStore.
+var ErrAuditLogNotConfigured = errors.New("audit log not configured")
+
+// AuditResult is the outcome of an audited action.
+type AuditResult string
+
+const (
+	// AuditResultSuccess marks an action that completed.
+	AuditResultSuccess AuditResult = "success"
+
+	// AuditResultFailure marks an action that was rejected or failed.
+	AuditResultFailure AuditResult = "failure"
+)
+
+// AuditEntry records who performed an action on the overlay node, such as an admin API call or
+// a transaction submission, when, and with which result.
+type AuditEntry struct {
+	ID         string      `json:"id"`
+	Time       time.Time   `json:"time"`
+	Action     string      `json:"action"`
+	Caller     string      `json:"caller"`
+	RemoteAddr string      `json:"remoteAddr,omitempty"`
+	RequestID  string      `json:"requestId,omitempty"`
+	Topics     []string    `json:"topics,omitempty"`
+	Txid       string      `json:"txid,omitempty"`
+	Result     AuditResult `json:"result"`
+	Status     int         `json:"status,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// AuditQuery selects audit entries. Empty fields match every entry.
+type AuditQuery struct {
+	Action string
+	Caller string
+	Txid   string
+	Since  time.Time
+	Until  time.Time
+	// Limit bounds the number of returned entries. Zero falls back to DefaultAuditQueryLimit,
+	// and limits above MaxAuditQueryLimit are capped.
+	Limit int
+}
+
+// Matches reports whether the entry is selected by the query.
+func (q AuditQuery) Matches(entry *AuditEntry) bool {
+	switch {
+	case q.Action != "" && entry.Action != q.Action:
+		return false
+	case q.Caller != "" && entry.Caller != q.Caller:
+		return false
+	case q.Txid != "" && entry.Txid != q.Txid:
+		return false
+	case !q.Since.IsZero() && entry.Time.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && !entry.Time.Before(q.Until):
+		return false
+	}
+	return true
+}
+
+func (q AuditQuery) limit() int {
+	if q.Limit <= 0 {
+		return DefaultAuditQueryLimit
+	}
+	return min(q.Limit, MaxAuditQueryLimit)
+}
+
+// AuditStore is the dedicated append-only store of the audit log. Entries are never modified or
+// deleted through it.
+type AuditStore interface {
+	// AppendAuditEntry appends the entry to the log.
+	AppendAuditEntry(ctx context.Context, entry *AuditEntry) error
+
+	// FindAuditEntries returns the entries selected by the query, most recent first.
+	FindAuditEntries(ctx context.Context, query AuditQuery) ([]*AuditEntry, error)
+}
+
+// RecordAudit appends the entry to the audit log, assigning its ID and time, and the request ID of ctx
+// when the entry has none. It is a no-op when the engine has no Audit store.
+func (e *Engine) RecordAudit(ctx context.Context, entry *AuditEntry) error {
+	if e.Audit == nil {
+		return nil
+	}
+	entry.ID = uuid.NewString()
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = RequestID(ctx)
+	}
+	if err := e.Audit.AppendAuditEntry(ctx, entry); err != nil {
+		logger(ctx).Error("failed to record audit entry", "action", entry.Action, "caller", entry.Caller, "error", err)
+		return err
+	}
+	return nil
+}
+
+// FindAuditEntries returns the audit entries selected by the query, most recent first.
+// Returns ErrAuditLogNotConfigured when the engine has no Audit store.
+func (e *Engine) FindAuditEntries(ctx context.Context, query AuditQuery) ([]*AuditEntry, error) {
+	if e.Audit == nil {
+		slog.Error("cannot query audit log", "error", ErrAuditLogNotConfigured)
+		return nil, ErrAuditLogNotConfigured
+	}
+	entries, err := e.Audit.FindAuditEntries(ctx, query)
+	if err != nil {
+		slog.Error("failed to query audit log", "error", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// MemoryAuditStore is an AuditStore keeping the most recent entries in memory. Once full, the oldest
+// entries are dropped, so it suits development and tests rather than compliance records.
+type MemoryAuditStore struct {
+	mu      sync.RWMutex
+	size    int
+	entries []*AuditEntry
+}
+
+// NewMemoryAuditStore creates a MemoryAuditStore keeping up to size entries.
+// A size below one falls back to DefaultMemoryAuditStoreSize.
+func NewMemoryAuditStore(size int) *MemoryAuditStore {
+	if size < 1 {
+		size = DefaultMemoryAuditStoreSize
+	}
+	return &MemoryAuditStore{size: size}
+}
+
+// AppendAuditEntry implements AuditStore.
+func (s *MemoryAuditStore) AppendAuditEntry(_ context.Context, entry *AuditEntry) error {
+	stored := *entry
+	stored.Topics = slices.Clone(entry.Topics)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) == s.size {
+		s.entries = slices.Delete(s.entries, 0, 1)
+	}
+	s.entries = append(s.entries, &stored)
+	return nil
+}
+
+// FindAuditEntries implements AuditStore.
+func (s *MemoryAuditStore) FindAuditEntries(_ context.Context, query AuditQuery) ([]*AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return findAuditEntries(slices.Backward(s.entries), query), nil
+}
+
+// FileAuditStore is an AuditStore appending entries as JSON lines to a file opened in append-only mode.
+// Every entry is synced to disk before AppendAuditEntry returns.
+type FileAuditStore struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileAuditStore opens, or creates, the audit log file at path.
+func NewFileAuditStore(path string) (*FileAuditStore, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // path is operator configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &FileAuditStore{path: path, file: file}, nil
+}
+
+// AppendAuditEntry implements AuditStore.
+func (s *FileAuditStore) AppendAuditEntry(_ context.Context, entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return s.file.Sync()
+}
+
+// FindAuditEntries implements AuditStore. It scans the whole file.
+func (s *FileAuditStore) FindAuditEntries(_ context.Context, query AuditQuery) ([]*AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []*AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode audit entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return findAuditEntries(slices.Backward(entries), query), nil
+}
+
+// Close closes the audit log file.
+func (s *FileAuditStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// findAuditEntries returns up to the query limit of the entries selected by the query, in iteration order.
+func findAuditEntries(entries iter.Seq2[int, *AuditEntry], query AuditQuery) []*AuditEntry {
+	limit := query.limit()
+	found := make([]*AuditEntry, 0, min(limit, 16))
+	for _, entry := range entries {
+		if !query.Matches(entry) {
+			continue
+		}
+		copied := *entry
+		copied.Topics = slices.Clone(entry.Topics)
+		found = append(found, &copied)
+		if len(found) == limit {
+			break
+		}
+	}
+	return found
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/steak"
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+const (
+	// DefaultAuthBroadcastTimeout bounds the propagation of a transaction to a single peer, including the
+	// BRC-31 handshake when no session with the peer exists yet.
+	DefaultAuthBroadcastTimeout = 10 * time.Second
+
+	// AuthTopicsHeader carries the comma-separated topics of a transaction propagated over BRC-31.
+	// BRC-31 only signs x-bsv-* headers, so it replaces the x-topics header for authenticated submissions.
+	AuthTopicsHeader = "x-bsv-topics"
+)
+
+// ErrAuthBroadcastRejected is returned when a peer does not accept a transaction propagated over BRC-31.
+var ErrAuthBroadcastRejected = errors.New("peer rejected the propagated transaction")
+
+// AuthBroadcastFacilitator propagates transactions to overlay peers over BRC-31 (Authrite) mutually
+// authenticated requests. Assign it to engine.Engine.BroadcastFacilitator.
+type AuthBroadcastFacilitator struct {
+	// Timeout bounds the propagation to a single peer. Zero falls back to DefaultAuthBroadcastTimeout.
+	Timeout time.Duration
+
+	client util.HTTPClient
+}
+
+// NewAuthBroadcastFacilitator creates an AuthBroadcastFacilitator authenticating with the identity of the wallet.
+// Panics if the wallet is nil.
+func NewAuthBroadcastFacilitator(w wallet.Interface) *AuthBroadcastFacilitator {
+	if w == nil {
+		panic("auth broadcast facilitator wallet cannot be nil")
+	}
+	return &AuthBroadcastFacilitator{client: &authFetchHTTPClient{fetch: authhttp.New(w)}}
+}
+
+// Send submits the tagged BEEF to the peer at the given URL and returns the STEAK it responds with,
+// in any of the JSON versions served by the submit endpoint of this module.
+func (f *AuthBroadcastFacilitator) Send(url string, taggedBEEF *overlay.TaggedBEEF) (*overlay.Steak, error) {
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultAuthBroadcastTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/submit", bytes.NewReader(taggedBEEF.Beef))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set(AuthTopicsHeader, strings.Join(taggedBEEF.Topics, ","))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &util.HTTPError{StatusCode: resp.StatusCode, Err: ErrAuthBroadcastRejected}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	submitted, err := unmarshalSubmitResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	return &submitted, nil
+}
+
+// unmarshalSubmitResponse decodes the STEAK of a submit response. BRC-31 only signs x-bsv-* headers, so the
+// STEAK version cannot be negotiated and is read from the body: Version2 carries it, Version1 does not.
+func unmarshalSubmitResponse(data []byte) (overlay.Steak, error) {
+	var probe struct {
+		Version steak.Version `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	version := steak.Version1
+	if probe.Version != 0 {
+		version = probe.Version
+	}
+	return steak.UnmarshalJSON(data, version)
+}
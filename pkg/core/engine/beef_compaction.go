@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultBEEFCompactionConfirmations is how many blocks must confirm a transaction, the block mining it included,
+	// before its BEEF is compacted when no number of confirmations is configured.
+	DefaultBEEFCompactionConfirmations = 100
+
+	// DefaultBEEFCompactionInterval is how often the background compactor runs when no interval is configured.
+	DefaultBEEFCompactionInterval = time.Hour
+
+	// DefaultBEEFCompactionBatchSize is how many unspent outputs of each topic a compaction run scans when no batch size is configured.
+	DefaultBEEFCompactionBatchSize = 1000
+)
+
+// ErrBEEFCompactionNotConfigured is returned when compacting BEEF with an engine without a compactor
+var ErrBEEFCompactionNotConfigured = errors.New("no BEEF compaction configured")
+
+// BEEFCompactionConfig configures the background compaction of the BEEF of deeply confirmed transactions.
+type BEEFCompactionConfig struct {
+	// Confirmations is how many blocks must confirm a transaction, the block mining it included, before its BEEF is
+	// compacted. Zero falls back to DefaultBEEFCompactionConfirmations.
+	Confirmations uint32 `mapstructure:"confirmations"`
+
+	// Interval is how often the background compactor runs. Zero falls back to DefaultBEEFCompactionInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// BatchSize is how many unspent outputs of each topic a run scans. Zero falls back to DefaultBEEFCompactionBatchSize.
+	BatchSize uint32 `mapstructure:"batch_size"`
+}
+
+// BEEFCompactor rewrites the BEEF of the unspent outputs whose transaction is buried under Confirmations blocks to
+// its minimal form: the transaction and its merkle path, without the ancestry accumulated while it was unmined.
+// Each run resumes the scan of a topic where the previous run stopped.
+type BEEFCompactor struct {
+	Confirmations uint32
+	Interval      time.Duration
+	BatchSize     uint32
+
+	mu      sync.Mutex
+	cursors map[string]float64
+}
+
+// NewBEEFCompactor creates a BEEFCompactor from the configuration.
+func NewBEEFCompactor(cfg BEEFCompactionConfig) *BEEFCompactor {
+	return &BEEFCompactor{
+		Confirmations: cfg.Confirmations,
+		Interval:      cfg.Interval,
+		BatchSize:     cfg.BatchSize,
+	}
+}
+
+// cursor returns the score the next scan of the topic starts from.
+func (c *BEEFCompactor) cursor(topic string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cursors[topic]
+}
+
+// advance records where the next scan of the topic starts from, wrapping around once a scan reaches the end of the topic.
+func (c *BEEFCompactor) advance(topic string, scanned []*Output, limit uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cursors == nil {
+		c.cursors = make(map[string]float64)
+	}
+	if len(scanned) < int(limit) {
+		delete(c.cursors, topic)
+		return
+	}
+	c.cursors[topic] = math.Nextafter(scanned[len(scanned)-1].Score, math.Inf(1))
+}
+
+// BEEFCompactionReport describes the outcome of a compaction run.
+type BEEFCompactionReport struct {
+	Scanned    int               // unspent outputs scanned
+	Compacted  []*chainhash.Hash // transactions whose BEEF was rewritten
+	BytesSaved int               // size of the BEEF removed from the storage, counted once per transaction
+	Failed     int               // outputs whose BEEF could not be compacted
+}
+
+// CompactBEEF scans up to BEEFCompactor.BatchSize unspent outputs of every topic and rewrites the BEEF of the
+// transactions buried under BEEFCompactor.Confirmations blocks to the transaction and its merkle path, through
+// Storage.UpdateTransactionBEEF. BEEF that is already minimal is left untouched. An output that fails is logged
+// and retried on the next scan of its topic.
+func (e *Engine) CompactBEEF(ctx context.Context) (*BEEFCompactionReport, error) {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting CompactBEEF while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	if e.BEEFCompactor == nil {
+		slog.Error("cannot compact BEEF", "error", ErrBEEFCompactionNotConfigured)
+		return nil, ErrBEEFCompactionNotConfigured
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting CompactBEEF in degraded mode", "error", err)
+		return nil, err
+	}
+	currentHeight, err := e.ChainTracker.CurrentHeight(ctx)
+	if err != nil {
+		slog.Error("failed to get current height in CompactBEEF", "error", err)
+		return nil, err
+	}
+
+	compactor := e.BEEFCompactor
+	limit := compactor.BatchSize
+	if limit == 0 {
+		limit = DefaultBEEFCompactionBatchSize
+	}
+	confirmations := compactor.Confirmations
+	if confirmations == 0 {
+		confirmations = DefaultBEEFCompactionConfirmations
+	}
+
+	report := &BEEFCompactionReport{}
+	compacted := make(map[chainhash.Hash]struct{})
+	for topic := range e.topicManagers() {
+		outputs, err := e.Storage.FindUTXOsForTopic(ctx, topic, compactor.cursor(topic), limit, true)
+		if err != nil {
+			slog.Error("failed to find UTXOs for topic in CompactBEEF", "topic", topic, "error", err)
+			return nil, err
+		}
+		report.Scanned += len(outputs)
+		for _, output := range outputs {
+			txid := output.Outpoint.Txid
+			if output.BlockHeight == 0 || output.BlockHeight+confirmations > currentHeight+1 {
+				continue
+			} else if _, done := compacted[txid]; done {
+				continue
+			}
+			saved, err := e.compactTransactionBEEF(ctx, output)
+			if err != nil {
+				slog.Error("failed to compact BEEF", "outpoint", output.Outpoint.String(), "topic", topic, "error", err)
+				report.Failed++
+				continue
+			}
+			compacted[txid] = struct{}{}
+			if saved > 0 {
+				report.Compacted = append(report.Compacted, &txid)
+				report.BytesSaved += saved
+			}
+		}
+		compactor.advance(topic, outputs, limit)
+	}
+	slog.Info("BEEF compacted", "scanned", report.Scanned, "compacted", len(report.Compacted), "bytesSaved", report.BytesSaved, "failed", report.Failed)
+	return report, nil
+}
+
+// RunBEEFCompactor compacts BEEF every BEEFCompactor.Interval until ctx is done or the engine stops. It returns
+// immediately when no compactor is configured. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunBEEFCompactor(ctx context.Context) {
+	if e.BEEFCompactor == nil {
+		return
+	}
+	interval := e.BEEFCompactor.Interval
+	if interval <= 0 {
+		interval = DefaultBEEFCompactionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.CompactBEEF(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled BEEF compaction failed", "interval", interval, "error", err)
+		}
+	}
+}
+
+// compactTransactionBEEF rewrites the BEEF of the transaction of the mined output to the transaction and its
+// merkle path when that is smaller, and returns how many bytes it saved.
+func (e *Engine) compactTransactionBEEF(ctx context.Context, output *Output) (int, error) {
+	txid := output.Outpoint.Txid
+	beef, _, _, err := transaction.ParseBeef(output.Beef)
+	if err != nil {
+		return 0, err
+	}
+	tx := beef.FindTransactionByHash(&txid)
+	if tx == nil {
+		return 0, ErrMissingTransaction
+	}
+	tx.MerklePath = beef.FindBumpByHash(&txid)
+	if tx.MerklePath == nil {
+		return 0, ErrMissingMerkleProof
+	}
+	compacted, err := tx.AtomicBEEF(false)
+	if err != nil {
+		return 0, err
+	}
+	if len(compacted) >= len(output.Beef) {
+		return 0, nil
+	}
+	if err := e.trackWrite(e.Storage.UpdateTransactionBEEF(ctx, &txid, compacted)); err != nil {
+		return 0, err
+	}
+	return len(output.Beef) - len(compacted), nil
+}
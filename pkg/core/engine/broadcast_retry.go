@@ -0,0 +1,316 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultBroadcastRetryInterval is how often queued broadcasts are checked when no interval is configured.
+	DefaultBroadcastRetryInterval = 30 * time.Second
+
+	// DefaultBroadcastInitialBackoff is the delay before the first re-broadcast when no backoff is configured.
+	DefaultBroadcastInitialBackoff = 30 * time.Second
+
+	// DefaultBroadcastMaxBackoff caps the delay between re-broadcasts when no cap is configured.
+	DefaultBroadcastMaxBackoff = time.Hour
+
+	// DefaultBroadcastMaxAttempts is how many times a broadcast is attempted before it is abandoned when no cap is configured.
+	DefaultBroadcastMaxAttempts = 20
+)
+
+var (
+	// ErrBroadcastQueueNotSupported is returned when accessing the re-broadcast queue with a storage that does not implement BroadcastQueueStorage
+	ErrBroadcastQueueNotSupported = errors.New("storage does not support a re-broadcast queue")
+
+	// ErrBroadcastRetryNotConfigured is returned when accessing the re-broadcast queue of an engine without a BroadcastRetry
+	ErrBroadcastRetryNotConfigured = errors.New("no broadcast retry configured")
+)
+
+// QueuedBroadcast is a transaction whose broadcast failed after it was admitted and that is waiting to be re-broadcast.
+type QueuedBroadcast struct {
+	Txid          chainhash.Hash
+	Beef          []byte // BEEF of the transaction as submitted
+	Attempts      int    // broadcasts attempted so far, including the one made by Submit
+	LastError     string
+	FirstFailedAt time.Time
+	NextAttemptAt time.Time
+	Abandoned     bool // set once MaxAttempts is reached; abandoned broadcasts are kept for inspection but not retried
+}
+
+// BroadcastQueueStorage is an optional Storage capability used to persist failed broadcasts
+// so they survive restarts while being retried.
+type BroadcastQueueStorage interface {
+	// InsertQueuedBroadcast inserts or replaces the queued broadcast with the same Txid.
+	InsertQueuedBroadcast(ctx context.Context, broadcast *QueuedBroadcast) error
+
+	// FindQueuedBroadcasts returns all queued broadcasts, including abandoned ones.
+	FindQueuedBroadcasts(ctx context.Context) ([]*QueuedBroadcast, error)
+
+	// DeleteQueuedBroadcast removes the queued broadcast of the transaction.
+	DeleteQueuedBroadcast(ctx context.Context, txid *chainhash.Hash) error
+}
+
+// BroadcastRetryConfig configures the re-broadcast queue of transactions whose broadcast failed.
+type BroadcastRetryConfig struct {
+	// Interval is how often queued broadcasts are checked. Zero falls back to DefaultBroadcastRetryInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// InitialBackoff is the delay before the first re-broadcast; it doubles after every failed attempt.
+	// Zero falls back to DefaultBroadcastInitialBackoff.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// MaxBackoff caps the delay between re-broadcasts. Zero falls back to DefaultBroadcastMaxBackoff.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+
+	// MaxAttempts is how many broadcasts are attempted before a transaction is abandoned.
+	// Zero falls back to DefaultBroadcastMaxAttempts.
+	MaxAttempts int `mapstructure:"max_attempts"`
+}
+
+// BroadcastRetryMetrics counts the activity of the re-broadcast queue since the engine started.
+type BroadcastRetryMetrics struct {
+	Queued    uint64 `json:"queued"`    // failed broadcasts added to the queue
+	Retried   uint64 `json:"retried"`   // re-broadcasts attempted
+	Succeeded uint64 `json:"succeeded"` // re-broadcasts accepted by the broadcaster
+	Abandoned uint64 `json:"abandoned"` // transactions that reached MaxAttempts
+}
+
+// BroadcastRetry re-broadcasts, with exponential backoff, the transactions whose broadcast failed during Submit.
+// Instead of failing the submission, the transaction is queued in a BroadcastQueueStorage and retried in the background.
+// It implements expvar.Var, so its metrics can be published with expvar.Publish.
+type BroadcastRetry struct {
+	cfg BroadcastRetryConfig
+
+	queued    atomic.Uint64
+	retried   atomic.Uint64
+	succeeded atomic.Uint64
+	abandoned atomic.Uint64
+}
+
+// NewBroadcastRetry creates a BroadcastRetry with the given configuration.
+func NewBroadcastRetry(cfg BroadcastRetryConfig) *BroadcastRetry {
+	return &BroadcastRetry{cfg: cfg}
+}
+
+// Metrics returns the counters of the re-broadcast queue.
+func (r *BroadcastRetry) Metrics() BroadcastRetryMetrics {
+	return BroadcastRetryMetrics{
+		Queued:    r.queued.Load(),
+		Retried:   r.retried.Load(),
+		Succeeded: r.succeeded.Load(),
+		Abandoned: r.abandoned.Load(),
+	}
+}
+
+// String returns the JSON encoded metrics, implementing expvar.Var.
+func (r *BroadcastRetry) String() string {
+	bb, err := json.Marshal(r.Metrics())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (r *BroadcastRetry) backoff(attempts int) time.Duration {
+	delay, maxDelay := r.cfg.InitialBackoff, r.cfg.MaxBackoff
+	if delay <= 0 {
+		delay = DefaultBroadcastInitialBackoff
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultBroadcastMaxBackoff
+	}
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+func (r *BroadcastRetry) maxAttempts() int {
+	if r.cfg.MaxAttempts > 0 {
+		return r.cfg.MaxAttempts
+	}
+	return DefaultBroadcastMaxAttempts
+}
+
+// BroadcastQueueStatus describes the re-broadcast queue.
+type BroadcastQueueStatus struct {
+	Broadcasts []*QueuedBroadcast
+	Metrics    BroadcastRetryMetrics
+}
+
+// BroadcastRetryReport describes the outcome of a re-broadcast run.
+type BroadcastRetryReport struct {
+	Retried   int // queued broadcasts that were due and attempted
+	Succeeded int // attempts accepted by the broadcaster and removed from the queue
+	Abandoned int // attempts that failed for the last time
+}
+
+// queueBroadcast adds a transaction whose broadcast failed to the re-broadcast queue. It reports false,
+// leaving the failure to the caller, when no BroadcastRetry is configured, the storage has no queue or
+// the broadcast cannot be queued.
+func (e *Engine) queueBroadcast(ctx context.Context, txid *chainhash.Hash, beef []byte, cause error) bool {
+	if e.BroadcastRetry == nil {
+		return false
+	}
+	queue, ok := storageCapability[BroadcastQueueStorage](e.Storage)
+	if !ok {
+		return false
+	}
+
+	now := time.Now()
+	broadcast := &QueuedBroadcast{
+		Txid:          *txid,
+		Beef:          beef,
+		Attempts:      1,
+		LastError:     cause.Error(),
+		FirstFailedAt: now,
+		NextAttemptAt: now.Add(e.BroadcastRetry.backoff(1)),
+	}
+	if err := e.trackWrite(queue.InsertQueuedBroadcast(ctx, broadcast)); err != nil {
+		logger(ctx).Error("failed to queue broadcast", "txid", txid, "error", err)
+		return false
+	}
+	e.BroadcastRetry.queued.Add(1)
+	logger(ctx).Warn("broadcast failed, queued for retry", "txid", txid, "nextAttemptAt", broadcast.NextAttemptAt, "reason", broadcast.LastError)
+	return true
+}
+
+// BroadcastQueueStatus returns the queued broadcasts, including abandoned ones, and the metrics of the queue.
+func (e *Engine) BroadcastQueueStatus(ctx context.Context) (*BroadcastQueueStatus, error) {
+	if e.BroadcastRetry == nil {
+		slog.Error("cannot inspect broadcast queue", "error", ErrBroadcastRetryNotConfigured)
+		return nil, ErrBroadcastRetryNotConfigured
+	}
+	queue, ok := storageCapability[BroadcastQueueStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot inspect broadcast queue", "error", ErrBroadcastQueueNotSupported)
+		return nil, ErrBroadcastQueueNotSupported
+	}
+
+	broadcasts, err := queue.FindQueuedBroadcasts(ctx)
+	if err != nil {
+		slog.Error("failed to find queued broadcasts", "error", err)
+		return nil, err
+	}
+	return &BroadcastQueueStatus{Broadcasts: broadcasts, Metrics: e.BroadcastRetry.Metrics()}, nil
+}
+
+// RetryBroadcasts re-broadcasts the queued transactions that are due. Accepted transactions leave the queue;
+// the others are rescheduled with an exponentially growing delay until BroadcastRetryConfig.MaxAttempts is
+// reached, after which they are abandoned.
+func (e *Engine) RetryBroadcasts(ctx context.Context) (*BroadcastRetryReport, error) {
+	if e.BroadcastRetry == nil {
+		slog.Error("cannot retry broadcasts", "error", ErrBroadcastRetryNotConfigured)
+		return nil, ErrBroadcastRetryNotConfigured
+	}
+	queue, ok := storageCapability[BroadcastQueueStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot retry broadcasts", "error", ErrBroadcastQueueNotSupported)
+		return nil, ErrBroadcastQueueNotSupported
+	}
+	if e.Broadcaster == nil {
+		return &BroadcastRetryReport{}, nil
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting RetryBroadcasts in degraded mode", "error", err)
+		return nil, err
+	}
+
+	broadcasts, err := queue.FindQueuedBroadcasts(ctx)
+	if err != nil {
+		slog.Error("failed to find queued broadcasts", "error", err)
+		return nil, err
+	}
+
+	report := &BroadcastRetryReport{}
+	now := time.Now()
+	for _, broadcast := range broadcasts {
+		if broadcast.Abandoned || broadcast.NextAttemptAt.After(now) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+
+		report.Retried++
+		e.BroadcastRetry.retried.Add(1)
+		cause := e.rebroadcast(ctx, broadcast)
+		if cause == nil {
+			if err := e.trackWrite(queue.DeleteQueuedBroadcast(ctx, &broadcast.Txid)); err != nil {
+				slog.Error("failed to remove re-broadcast transaction from queue", "txid", broadcast.Txid.String(), "error", err)
+				return report, err
+			}
+			report.Succeeded++
+			e.BroadcastRetry.succeeded.Add(1)
+			slog.Info("queued broadcast succeeded", "txid", broadcast.Txid.String(), "attempts", broadcast.Attempts+1)
+			continue
+		}
+
+		broadcast.Attempts++
+		broadcast.LastError = cause.Error()
+		if broadcast.Attempts >= e.BroadcastRetry.maxAttempts() {
+			broadcast.Abandoned = true
+			report.Abandoned++
+			e.BroadcastRetry.abandoned.Add(1)
+			slog.Error("abandoning broadcast after max attempts", "txid", broadcast.Txid.String(), "attempts", broadcast.Attempts, "error", cause)
+		} else {
+			broadcast.NextAttemptAt = now.Add(e.BroadcastRetry.backoff(broadcast.Attempts))
+			slog.Warn("queued broadcast failed again", "txid", broadcast.Txid.String(), "attempts", broadcast.Attempts, "nextAttemptAt", broadcast.NextAttemptAt, "error", cause)
+		}
+		if err := e.trackWrite(queue.InsertQueuedBroadcast(ctx, broadcast)); err != nil {
+			slog.Error("failed to reschedule queued broadcast", "txid", broadcast.Txid.String(), "error", err)
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// rebroadcast broadcasts the transaction of a queued broadcast.
+func (e *Engine) rebroadcast(ctx context.Context, broadcast *QueuedBroadcast) error {
+	_, tx, _, err := transaction.ParseBeef(broadcast.Beef)
+	if err != nil {
+		return err
+	} else if tx == nil {
+		return ErrInvalidBeef
+	}
+	if _, failure := e.Broadcaster.BroadcastCtx(ctx, tx); failure != nil {
+		return failure
+	}
+	return nil
+}
+
+// RunBroadcastRetrier retries queued broadcasts every BroadcastRetryConfig.Interval until ctx is done or the engine stops.
+// It returns immediately when no BroadcastRetry is configured. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunBroadcastRetrier(ctx context.Context) {
+	if e.BroadcastRetry == nil {
+		return
+	}
+	interval := e.BroadcastRetry.cfg.Interval
+	if interval <= 0 {
+		interval = DefaultBroadcastRetryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.RetryBroadcasts(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil && ctx.Err() == nil {
+			slog.Error("scheduled broadcast retry failed", "interval", interval, "error", err)
+		}
+	}
+}
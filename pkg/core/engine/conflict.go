@@ -0,0 +1,259 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ConflictPolicy decides what Submit does with an unconfirmed transaction spending the same topical
+// output as another unconfirmed transaction that was already admitted.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyNone disables conflict detection. Conflicting transactions are admitted like any other.
+	ConflictPolicyNone ConflictPolicy = ""
+
+	// ConflictPolicyFirstSeen keeps the transaction admitted first. The conflicting transaction is
+	// ignored for the topic, as if it had already been applied, and is not broadcast.
+	ConflictPolicyFirstSeen ConflictPolicy = "first-seen"
+
+	// ConflictPolicyRejectNew fails the submission of the conflicting transaction with ErrConflictingTransaction.
+	ConflictPolicyRejectNew ConflictPolicy = "reject-new"
+
+	// ConflictPolicyDispute admits the conflicting transaction and marks the outputs of both as disputed
+	// until one of them is mined. The outputs of the transactions that lost are then removed.
+	ConflictPolicyDispute ConflictPolicy = "dispute"
+)
+
+// ErrConflictingTransaction is returned by Submit under ConflictPolicyRejectNew
+var ErrConflictingTransaction = errors.New("conflicting-transaction")
+
+// OutputDisputed contains information about an admitted output whose transaction conflicts with
+// another unconfirmed transaction.
+type OutputDisputed struct {
+	Outpoint         *transaction.Outpoint
+	Topic            string
+	ConflictingTxids []*chainhash.Hash
+}
+
+// OutputDisputeResolved contains information about a disputed output once one of the conflicting
+// transactions was mined. Confirmed is false for outputs of transactions that lost the dispute,
+// which are removed from storage right after the notification.
+type OutputDisputeResolved struct {
+	Outpoint  *transaction.Outpoint
+	Topic     string
+	Confirmed bool
+}
+
+// DisputeAwareLookupService is an optional LookupService capability notified about disputed outputs
+// under ConflictPolicyDispute.
+type DisputeAwareLookupService interface {
+	// OutputDisputed is invoked when an admitted output becomes disputed.
+	OutputDisputed(ctx context.Context, payload *OutputDisputed) error
+
+	// OutputDisputeResolved is invoked for every disputed output once one of the conflicting transactions is mined.
+	OutputDisputeResolved(ctx context.Context, payload *OutputDisputeResolved) error
+}
+
+// OutputDisputeStorage is an optional Storage capability used to persist the disputed flag of outputs.
+type OutputDisputeStorage interface {
+	// UpdateOutputDisputed sets the disputed flag of the output admitted into the given topic.
+	UpdateOutputDisputed(ctx context.Context, outpoint *transaction.Outpoint, topic string, disputed bool) error
+}
+
+// findConflicts returns the unconfirmed transactions other than txid that spend any of the given
+// topical outputs. Spenders are found through the ConsumedBy links of the spent outputs.
+func (e *Engine) findConflicts(ctx context.Context, topic string, txid *chainhash.Hash, spent []*Output) ([]*chainhash.Hash, error) {
+	seen := make(map[chainhash.Hash]struct{})
+	var conflicts []*chainhash.Hash
+	for _, output := range spent {
+		if output == nil || !output.Spent {
+			continue
+		}
+		for _, consumer := range output.ConsumedBy {
+			if consumer.Txid.Equal(*txid) {
+				continue
+			}
+			if _, ok := seen[consumer.Txid]; ok {
+				continue
+			}
+			seen[consumer.Txid] = struct{}{}
+
+			rival, err := e.storage(ctx).FindOutput(ctx, consumer, &topic, nil, false)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				logger(ctx).Error("failed to find conflicting output", "outpoint", consumer.String(), "topic", topic, "error", err)
+				return nil, err
+			}
+			if rival != nil && rival.BlockHeight == 0 {
+				conflicting := consumer.Txid
+				conflicts = append(conflicts, &conflicting)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// conflictError describes a conflict rejected under ConflictPolicyRejectNew.
+func conflictError(txid *chainhash.Hash, conflicts []*chainhash.Hash) error {
+	return fmt.Errorf("%w: %s conflicts with unconfirmed %s", ErrConflictingTransaction, txid, conflicts[0])
+}
+
+// disputeOutputs marks the outputs of the given transactions admitted into topic as disputed and
+// notifies dispute aware lookup services.
+func (e *Engine) disputeOutputs(ctx context.Context, topic string, txids []*chainhash.Hash) error {
+	for i, txid := range txids {
+		outputs, err := e.storage(ctx).FindOutputsForTransaction(ctx, txid, false)
+		if err != nil {
+			logger(ctx).Error("failed to find outputs of disputed transaction", "txid", txid, "topic", topic, "error", err)
+			return err
+		}
+
+		conflicting := make([]*chainhash.Hash, 0, len(txids)-1)
+		conflicting = append(conflicting, txids[:i]...)
+		conflicting = append(conflicting, txids[i+1:]...)
+		for _, output := range outputs {
+			if output.Topic != topic {
+				continue
+			}
+			if err := e.setOutputDisputed(ctx, output, true); err != nil {
+				return err
+			}
+			for _, l := range e.lookupServices() {
+				if disputes, ok := l.(DisputeAwareLookupService); ok {
+					if err := disputes.OutputDisputed(ctx, &OutputDisputed{
+						Outpoint:         &output.Outpoint,
+						Topic:            topic,
+						ConflictingTxids: conflicting,
+					}); err != nil {
+						logger(ctx).Error("failed to notify lookup service about disputed output", "outpoint", output.Outpoint.String(), "topic", topic, "error", err)
+						return err
+					}
+				}
+			}
+		}
+	}
+	logger(ctx).Warn("conflicting unconfirmed transactions disputed", "topic", topic, "txids", txids)
+	return nil
+}
+
+// resolveDisputes settles the disputes of a transaction that was just mined. Its outputs are no longer
+// disputed, while the outputs of unconfirmed transactions spending the same outputs are removed.
+func (e *Engine) resolveDisputes(ctx context.Context, txid *chainhash.Hash, outputs []*Output) error {
+	for _, output := range outputs {
+		lost := 0
+		for _, parent := range output.OutputsConsumed {
+			spent, err := e.Storage.FindOutput(ctx, parent, &output.Topic, nil, false)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				slog.Error("failed to find disputed input", "outpoint", parent.String(), "topic", output.Topic, "error", err)
+				return err
+			} else if spent == nil {
+				continue
+			}
+			for _, consumer := range spent.ConsumedBy {
+				if consumer.Txid.Equal(*txid) {
+					continue
+				}
+				rival, err := e.Storage.FindOutput(ctx, consumer, &output.Topic, nil, false)
+				if err != nil && !errors.Is(err, ErrNotFound) {
+					slog.Error("failed to find losing output", "outpoint", consumer.String(), "topic", output.Topic, "error", err)
+					return err
+				} else if rival == nil || rival.BlockHeight != 0 {
+					continue
+				}
+				if err := e.removeLosingOutput(ctx, rival); err != nil {
+					return err
+				}
+				lost++
+				slog.Info("disputed output lost to mined transaction", "outpoint", rival.Outpoint.String(), "topic", rival.Topic, "txid", txid)
+			}
+		}
+
+		if !output.Disputed && lost == 0 {
+			continue
+		}
+		if err := e.setOutputDisputed(ctx, output, false); err != nil {
+			return err
+		}
+		if err := e.notifyDisputeResolved(ctx, output, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLosingOutput deletes an output of a transaction that lost a dispute and unlinks it from the
+// outputs it consumed. Unlike deleteUTXODeep, the consumed outputs are kept, as they are now spent
+// by the mined transaction.
+func (e *Engine) removeLosingOutput(ctx context.Context, output *Output) error {
+	if err := e.notifyDisputeResolved(ctx, output, false); err != nil {
+		return err
+	}
+	if output.Pinned {
+		slog.Info("keeping pinned output that lost a dispute", "outpoint", output.Outpoint.String(), "topic", output.Topic)
+		return nil
+	}
+
+	for _, outpoint := range output.OutputsConsumed {
+		consumed, err := e.Storage.FindOutput(ctx, outpoint, &output.Topic, nil, false)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			slog.Error("failed to find output consumed by losing output", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		} else if consumed == nil {
+			continue
+		}
+		consumedBy := make([]*transaction.Outpoint, 0, len(consumed.ConsumedBy))
+		for _, consumer := range consumed.ConsumedBy {
+			if !consumer.Txid.Equal(output.Outpoint.Txid) {
+				consumedBy = append(consumedBy, consumer)
+			}
+		}
+		if err := e.trackWrite(e.Storage.UpdateConsumedBy(ctx, &consumed.Outpoint, consumed.Topic, consumedBy)); err != nil {
+			slog.Error("failed to update consumed by of output consumed by losing output", "outpoint", consumed.Outpoint.String(), "topic", consumed.Topic, "error", err)
+			return err
+		}
+	}
+
+	if err := e.removeOutput(ctx, e.Storage, &output.Outpoint, output.Topic, TombstoneReasonDisputeLost); err != nil {
+		slog.Error("failed to delete losing output", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+		return err
+	}
+	if e.OutpointFilter != nil {
+		e.OutpointFilter.MarkDeleted(output.Topic)
+	}
+	return nil
+}
+
+func (e *Engine) notifyDisputeResolved(ctx context.Context, output *Output, confirmed bool) error {
+	for _, l := range e.lookupServices() {
+		if disputes, ok := l.(DisputeAwareLookupService); ok {
+			if err := disputes.OutputDisputeResolved(ctx, &OutputDisputeResolved{
+				Outpoint:  &output.Outpoint,
+				Topic:     output.Topic,
+				Confirmed: confirmed,
+			}); err != nil {
+				slog.Error("failed to notify lookup service about resolved dispute", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Engine) setOutputDisputed(ctx context.Context, output *Output, disputed bool) error {
+	output.Disputed = disputed
+	disputes, ok := storageCapability[OutputDisputeStorage](e.storage(ctx))
+	if !ok {
+		return nil
+	}
+	if err := e.trackWrite(disputes.UpdateOutputDisputed(ctx, &output.Outpoint, output.Topic, disputed)); err != nil {
+		slog.Error("failed to update output dispute", "outpoint", output.Outpoint.String(), "topic", output.Topic, "disputed", disputed, "error", err)
+		return err
+	}
+	e.replicate(&Mutation{Op: MutationUpdateOutputDisputed, Outpoint: &output.Outpoint, Topic: output.Topic, Disputed: disputed})
+	return nil
+}
@@ -3,11 +3,13 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
@@ -16,15 +18,62 @@ import (
 // migrating the engine code.
 type OverlayEngineProvider interface {
 	Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error)
+	Evaluate(ctx context.Context, taggedBEEF overlay.TaggedBEEF) (overlay.Steak, error)
 	Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+	LookupPaged(ctx context.Context, question *lookup.LookupQuestion, page LookupPage) (*LookupPageAnswer, error)
 	GetUTXOHistory(ctx context.Context, output *Output, historySelector func(beef []byte, outputIndex, currentDepth uint32) bool, currentDepth uint32) (*Output, error)
 	SyncAdvertisements(ctx context.Context) error
 	StartGASPSync(ctx context.Context) error
 	ProvideForeignSyncResponse(ctx context.Context, initialRequest *gasp.InitialRequest, topic string) (*gasp.InitialResponse, error)
-	ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, topic string) (*gasp.Node, error)
+	ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, topic string, metadata bool) (*gasp.Node, error)
 	ListTopicManagers() map[string]*overlay.MetaData
 	ListLookupServiceProviders() map[string]*overlay.MetaData
 	GetDocumentationForLookupServiceProvider(provider string) (string, error)
 	GetDocumentationForTopicManager(provider string) (string, error)
 	HandleNewMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath) error
+	HandleAttestedMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath, attestation MerkleProofAttestation) error
+	AddTopicManager(ctx context.Context, name string, syncConfig SyncConfiguration) error
+	UnregisterTopicManager(ctx context.Context, name string) error
+	AddLookupService(ctx context.Context, name string) error
+	UnregisterLookupService(ctx context.Context, name string) error
+	ReplayTopicIntoLookupService(ctx context.Context, topic, service string, fromScore float64) (*LookupReplayProgress, error)
+	PinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
+	UnpinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
+	PauseTopicSync(ctx context.Context, topic string) error
+	ResumeTopicSync(ctx context.Context, topic string) error
+	PausedSyncTopics(ctx context.Context) []string
+	Trackers(ctx context.Context) Trackers
+	AddTracker(ctx context.Context, kind TrackerKind, tracker string) error
+	RemoveTracker(ctx context.Context, kind TrackerKind, tracker string) error
+	TopicQuotaSettings(ctx context.Context) TopicQuotaSettings
+	SetTopicQuota(ctx context.Context, topic string, quota TopicQuota) error
+	RemoveTopicQuota(ctx context.Context, topic string) error
+	RecordAudit(ctx context.Context, entry *AuditEntry) error
+	FindAuditEntries(ctx context.Context, query AuditQuery) ([]*AuditEntry, error)
+	SimulateReorg(ctx context.Context, depth uint32) (*ReorgSimulation, error)
+	ListDeadLetters(ctx context.Context) ([]*DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) (overlay.Steak, error)
+	PruneOutputs(ctx context.Context) ([]*PruneReport, error)
+	NextMutations(ctx context.Context, epoch string, since uint64) ([]*Mutation, error)
+	PromoteStandby(ctx context.Context) error
+	PlanAdvertisements(ctx context.Context) (*AdvertisementPlan, error)
+	ListAdvertisements(ctx context.Context) ([]*ListedAdvertisement, error)
+	CreateAdvertisement(ctx context.Context, protocol overlay.Protocol, topicOrService string) (overlay.Steak, error)
+	RevokeAdvertisement(ctx context.Context, outpoint *transaction.Outpoint) (overlay.Steak, error)
+	BroadcastQueueStatus(ctx context.Context) (*BroadcastQueueStatus, error)
+	FindUTXOHistory(ctx context.Context, query UTXOHistoryQuery) (*Output, error)
+	GetOutputStatus(ctx context.Context, outpoint *transaction.Outpoint, topic string) (*OutputStatus, error)
+	OutputGraph(ctx context.Context, outpoint *transaction.Outpoint, topic string, depth uint32) (*OutputGraph, error)
+	HasOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, unspentOnly bool) ([]bool, error)
+	TopicStats(ctx context.Context) (*OverlayStats, error)
+	GASPSyncStatus(ctx context.Context) ([]*GASPPeerSyncStatus, error)
+	SyncTopic(ctx context.Context, request TopicSyncRequest) (*TopicSyncJob, error)
+	FindTopicSyncJob(ctx context.Context, id string) (*TopicSyncJob, error)
+	EnqueueSubmit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, callbackURL string) (*SubmitJob, error)
+	FindSubmitJob(ctx context.Context, id string) (*SubmitJob, error)
+	RegisterWebhook(ctx context.Context, callbackURL string, topics []string, secret string) (*WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]*WebhookSubscription, error)
+	UnregisterWebhook(ctx context.Context, id string) error
+	GetLookupQuerySchema(ctx context.Context, service string) (json.RawMessage, error)
+	VerifyPayment(ctx context.Context, beef []byte, lockingScript *script.Script, satoshis uint64) (*transaction.Outpoint, error)
 }
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+const (
+	// DeadLetterStageBroadcast marks a submission that failed while broadcasting the transaction.
+	DeadLetterStageBroadcast = "broadcast"
+
+	// DeadLetterStageLookupService marks a submission that failed while notifying a lookup service.
+	DeadLetterStageLookupService = "lookup-service"
+)
+
+var (
+	// ErrDeadLetterQueueNotSupported is returned when accessing the dead-letter queue with a storage that does not implement DeadLetterStorage
+	ErrDeadLetterQueueNotSupported = errors.New("storage does not support a dead-letter queue")
+
+	// ErrDeadLetterNotFound is returned when replaying a dead letter that does not exist
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+)
+
+// DeadLetter records a submission that failed after it was validated, when storage may already be
+// partially updated. Replaying it resubmits the original tagged BEEF in the original mode.
+type DeadLetter struct {
+	ID            string
+	Txid          chainhash.Hash
+	Topics        []string
+	Beef          []byte
+	Mode          SumbitMode
+	Stage         string
+	Reason        string
+	Attempts      int
+	FirstFailedAt time.Time
+	LastFailedAt  time.Time
+}
+
+// DeadLetterStorage is an optional Storage capability used to persist failed submissions
+// so they can be inspected and replayed by an operator.
+type DeadLetterStorage interface {
+	// InsertDeadLetter inserts or replaces the dead letter with the same ID.
+	InsertDeadLetter(ctx context.Context, deadLetter *DeadLetter) error
+
+	// FindDeadLetter returns the dead letter with the given ID, or nil if none exists.
+	FindDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+
+	// FindDeadLetters returns all dead letters.
+	FindDeadLetters(ctx context.Context) ([]*DeadLetter, error)
+
+	// DeleteDeadLetter removes the dead letter with the given ID.
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
+
+// ListDeadLetters returns every submission recorded in the dead-letter queue.
+func (e *Engine) ListDeadLetters(ctx context.Context) ([]*DeadLetter, error) {
+	deadLetters, ok := storageCapability[DeadLetterStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot list dead letters", "error", ErrDeadLetterQueueNotSupported)
+		return nil, ErrDeadLetterQueueNotSupported
+	}
+
+	found, err := deadLetters.FindDeadLetters(ctx)
+	if err != nil {
+		slog.Error("failed to find dead letters", "error", err)
+		return nil, err
+	}
+	return found, nil
+}
+
+// ReplayDeadLetter resubmits the dead letter with the given ID and removes it from the queue on success.
+// A replay failing at the same stage again updates the dead letter's attempt count and reason.
+func (e *Engine) ReplayDeadLetter(ctx context.Context, id string) (overlay.Steak, error) {
+	deadLetters, ok := storageCapability[DeadLetterStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot replay dead letter", "id", id, "error", ErrDeadLetterQueueNotSupported)
+		return nil, ErrDeadLetterQueueNotSupported
+	}
+
+	deadLetter, err := deadLetters.FindDeadLetter(ctx, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		slog.Error("failed to find dead letter", "id", id, "error", err)
+		return nil, err
+	}
+	if deadLetter == nil {
+		slog.Error("dead letter to replay not found", "id", id, "error", ErrDeadLetterNotFound)
+		return nil, ErrDeadLetterNotFound
+	}
+
+	steak, err := e.Submit(ctx, overlay.TaggedBEEF{Beef: deadLetter.Beef, Topics: deadLetter.Topics}, deadLetter.Mode, nil)
+	if err != nil {
+		slog.Error("failed to replay dead letter", "id", id, "error", err)
+		return nil, err
+	}
+
+	if err := e.trackWrite(deadLetters.DeleteDeadLetter(ctx, id)); err != nil {
+		slog.Error("failed to delete replayed dead letter", "id", id, "error", err)
+		return nil, err
+	}
+	slog.Info("dead letter replayed", "id", id, "txid", deadLetter.Txid.String())
+	return steak, nil
+}
+
+// recordDeadLetter persists a failed submission when the storage supports a dead-letter queue.
+// Failures to record are logged and do not mask the original submission error.
+func (e *Engine) recordDeadLetter(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, txid *chainhash.Hash, stage string, cause error) {
+	deadLetters, ok := storageCapability[DeadLetterStorage](e.Storage)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	id := txid.String()
+	deadLetter, err := deadLetters.FindDeadLetter(ctx, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		logger(ctx).Error("failed to find dead letter", "id", id, "error", err)
+		return
+	}
+	if deadLetter == nil {
+		deadLetter = &DeadLetter{
+			ID:            id,
+			Txid:          *txid,
+			Topics:        taggedBEEF.Topics,
+			Beef:          taggedBEEF.Beef,
+			Mode:          mode,
+			FirstFailedAt: now,
+		}
+	}
+	deadLetter.Stage = stage
+	deadLetter.Reason = cause.Error()
+	deadLetter.Attempts++
+	deadLetter.LastFailedAt = now
+
+	if err := e.trackWrite(deadLetters.InsertDeadLetter(ctx, deadLetter)); err != nil {
+		logger(ctx).Error("failed to record dead letter", "id", id, "stage", stage, "error", err)
+		return
+	}
+	logger(ctx).Warn("submission recorded in dead-letter queue", "id", id, "stage", stage, "attempts", deadLetter.Attempts, "reason", deadLetter.Reason)
+}
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
+	"maps"
 	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
@@ -17,7 +19,6 @@ import (
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 	"github.com/bsv-blockchain/go-sdk/overlay/topic"
-	"github.com/bsv-blockchain/go-sdk/spv"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
 )
@@ -42,6 +43,24 @@ var (
 	SubmitModeCurrent SumbitMode = "current-tx"
 )
 
+type propagationKey struct{}
+
+// WithPropagation returns a context that overrides, for a single Submit, whether the admitted transaction
+// is propagated to the overlay peers hosting its topics. Broadcasting to miners is unaffected, so historical
+// submissions can be forwarded to downstream followers without being re-broadcast.
+func WithPropagation(ctx context.Context, propagate bool) context.Context {
+	return context.WithValue(ctx, propagationKey{}, propagate)
+}
+
+// shouldPropagate reports whether Submit propagates the transaction to overlay peers: the override set with
+// WithPropagation, or else current submissions and, when PropagateHistorical is set, historical ones.
+func (e *Engine) shouldPropagate(ctx context.Context, mode SumbitMode) bool {
+	if propagate, ok := ctx.Value(propagationKey{}).(bool); ok {
+		return propagate
+	}
+	return mode != SubmitModeHistorical || e.PropagateHistorical
+}
+
 // SyncConfigurationType represents the type of synchronization configuration
 type SyncConfigurationType int
 
@@ -59,6 +78,22 @@ type SyncConfiguration struct {
 	Type        SyncConfigurationType
 	Peers       []string
 	Concurrency int
+	// Remote configures the HTTP client used for every peer of the topic.
+	Remote GASPRemoteConfig
+	// PeerRemotes overrides Remote for specific peers, keyed by peer URL.
+	PeerRemotes map[string]GASPRemoteConfig
+	// MaxAnchorAgeBlocks rejects GASP graphs whose anchor transaction was mined more than
+	// this many blocks ago. Zero accepts graphs of any age.
+	MaxAnchorAgeBlocks uint32
+	// RequireMinedHistory rejects historical submissions whose transaction carries no merkle proof, for topics
+	// that only index confirmed state. Since GASP submits every transaction of a graph historically, an
+	// unmined transaction anywhere in a synced chain is rejected.
+	RequireMinedHistory bool
+	// Paused skips the topic in GASP syncs and advertisement syncs. See Engine.PauseTopicSync.
+	Paused bool
+	// PeerConcurrency is the number of peers of the topic synced concurrently. Zero or one syncs
+	// them one at a time.
+	PeerConcurrency int
 }
 
 // OnSteakReady is a callback function that is called when a steak is ready
@@ -72,6 +107,11 @@ type LookupResolverProvider interface {
 }
 
 // Engine is the core overlay services engine
+//
+// Managers, LookupServices and SyncConfiguration are read concurrently by submissions, lookups and syncs.
+// Once the engine is in use, change them only through RegisterTopicManager, RegisterLookupService and the
+// other registry methods, and read them through Registry. Engines created with NewEngine guard them with a lock
+// of their own; engines built directly share a package lock.
 type Engine struct {
 	Managers                map[string]TopicManager
 	LookupServices          map[string]LookupService
@@ -86,8 +126,50 @@ type Engine struct {
 	LogTime                 bool
 	LogPrefix               string
 	ErrorOnBroadcastFailure bool
+	PropagateHistorical     bool
 	BroadcastFacilitator    topic.Facilitator
 	LookupResolver          LookupResolverProvider
+	LookupCache             *LookupCache
+	LookupNotifications     *LookupNotifications
+	LocalLookupCache        *LookupCache
+	PeerReputation          *PeerReputation
+	ManagerMetrics          *TopicManagerMetrics
+	ManagerSandbox          *TopicManagerSandbox
+	TopicManagerFactory     TopicManagerFactory
+	LookupServiceFactory    LookupServiceFactory
+	StorageDegradation      *StorageDegradation
+	ScoreStrategy           ScoreStrategy
+	Lifecycle               *Lifecycle
+	ConflictPolicy          ConflictPolicy
+	Retention               *RetentionConfig
+	Standby                 *ReplicationFollower
+	ProofFetcher            *MerkleProofFetcher
+	UnminedEviction         *UnminedEvictor
+	SpendReconciler         *SpendReconciler
+	AdvertisementBudget     *AdvertisementBudget
+	BroadcastRetry          *BroadcastRetry
+	HistoryLimits           *UTXOHistoryLimits
+	SubmitJobs              *SubmitJobQueue
+	Idempotency             *SubmitIdempotency
+	SubmitReplays           *SubmitReplayCache
+	Webhooks                *Webhooks
+	SPVVerifier             *SPVVerifier
+	VerifiedTxs             *VerifiedTxCache
+	OutpointFilter          *OutpointFilter
+	SubmitScheduler         *SubmitScheduler
+	PeriodicSync            *PeriodicSync
+	Events                  *EventBus
+	Audit                   AuditStore
+	SyncWorkers             int
+	SyncProgress            *GASPSyncProgress
+	TopicSyncJobs           *TopicSyncJobs
+	GASPServeLimiter        *GASPServeLimiter
+	OutputQuotas            *TopicQuotas
+	Tombstones              *TombstoneConfig
+	MerkleStates            *MerkleStateConfig
+	BEEFCompactor           *BEEFCompactor
+
+	registryMu *sync.RWMutex // guards the registry maps and the trackers, see registryLock
 	// Logger				  Logger //TODO: Implement Logger Interface
 }
 
@@ -105,6 +187,16 @@ func NewEngine(cfg Engine) *Engine {
 	if cfg.LookupResolver == nil {
 		cfg.LookupResolver = NewLookupResolver()
 	}
+	if len(cfg.SLAPTrackers) > 0 {
+		cfg.LookupResolver.SetSLAPTrackers(cfg.SLAPTrackers)
+	}
+	if cfg.SyncProgress == nil {
+		cfg.SyncProgress = NewGASPSyncProgress()
+	}
+	if cfg.TopicSyncJobs == nil {
+		cfg.TopicSyncJobs = NewTopicSyncJobs()
+	}
+	cfg.registryMu = new(sync.RWMutex)
 
 	for name, manager := range cfg.Managers {
 		config := cfg.SyncConfiguration[name]
@@ -148,6 +240,8 @@ var (
 	ErrInvalidBeef = errors.New("invalid-beef")
 	// ErrInvalidTransaction is returned when a transaction is invalid
 	ErrInvalidTransaction = errors.New("invalid-transaction")
+	// ErrUnminedHistoricalSubmission is returned when a topic requiring mined history receives an unmined historical submission
+	ErrUnminedHistoricalSubmission = errors.New("historical submission is not mined")
 	// ErrMissingInput is returned when an input is missing
 	ErrMissingInput = errors.New("missing-input")
 	// ErrMissingOutput is returned when an output is missing
@@ -168,142 +262,170 @@ var (
 	ErrNoDocumentationFound = errors.New("no documentation found")
 )
 
-// Submit submits a transaction to the overlay service
+// Submit submits a transaction to the overlay service.
+// When ctx is done before the submission is applied, Submit returns ErrSubmitCanceled and rolls back the inputs it
+// marked as spent; once lookup services are notified, the submission is applied in full regardless of ctx.
+// A lookup service failing to be notified fails the submission, unless LookupNotifications configures a policy
+// ignoring the failure or retrying the notification in the background.
+// With SubmitReplays configured, a transaction submitted again within the replay window to topics it is still
+// applied to returns the STEAK of the original submission without being verified again.
+// With Idempotency configured, a repeated submission of the same idempotency key returns the STEAK
+// of the original submission instead of being processed again, or ErrIdempotencyKeyConflict when the
+// key was used for another transaction or other topics; see WithIdempotencyKey.
 func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error) {
-	start := time.Now()
+	if e.SubmitReplays != nil {
+		return e.submitReplayable(ctx, taggedBEEF, mode, onSteakReady)
+	}
+	return e.submitOnce(ctx, taggedBEEF, mode, onSteakReady)
+}
+
+// submitOnce processes the submission, once per idempotency key when Idempotency is configured.
+func (e *Engine) submitOnce(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error) {
+	if e.Idempotency != nil {
+		if key, payload := submitIdempotencyKey(ctx, taggedBEEF); key != "" {
+			return e.submitIdempotent(ctx, key, payload, taggedBEEF, mode, onSteakReady)
+		}
+	}
+	return e.submit(ctx, taggedBEEF, mode, onSteakReady)
+}
+
+// Evaluate runs a submission without applying it: it verifies the transaction and identifies the outputs
+// each tagged topic would admit, returning the STEAK Submit would, including the coins it would remove.
+// It writes nothing to storage and neither broadcasts the transaction nor notifies lookup services,
+// subscribers or webhooks.
+func (e *Engine) Evaluate(ctx context.Context, taggedBEEF overlay.TaggedBEEF) (overlay.Steak, error) {
 	for _, topic := range taggedBEEF.Topics {
-		if _, ok := e.Managers[topic]; !ok {
-			slog.Error("unknown topic in Submit", "topic", topic, "error", ErrUnknownTopic)
+		if _, ok := e.topicManager(topic); !ok {
+			logger(ctx).Error("unknown topic in Evaluate", "topic", topic, "error", ErrUnknownTopic)
 			return nil, ErrUnknownTopic
 		}
 	}
-
-	var tx *transaction.Transaction
-	beef, tx, txid, err := transaction.ParseBeef(taggedBEEF.Beef)
+	evaluated, err := e.evaluate(ctx, e.storage(ctx), taggedBEEF, SubmitModeCurrent)
 	if err != nil {
-		slog.Error("failed to parse BEEF in Submit", "error", err)
 		return nil, err
-	} else if tx == nil {
-		slog.Error("invalid BEEF in Submit - tx is nil", "error", ErrInvalidBeef)
-		return nil, ErrInvalidBeef
 	}
-	if valid, err := spv.Verify(ctx, tx, e.ChainTracker, nil); err != nil {
-		slog.Error("SPV verification failed in Submit", "txid", txid, "error", err)
+	for topic, admit := range evaluated.steak {
+		if _, ok := evaluated.dupeTopics[topic]; ok {
+			continue
+		}
+		for _, vin := range slices.Sorted(maps.Keys(evaluated.topicInputs[topic])) {
+			if !slices.Contains(admit.CoinsToRetain, vin) {
+				admit.CoinsRemoved = append(admit.CoinsRemoved, vin)
+			}
+		}
+	}
+	return evaluated.steak, nil
+}
+
+func (e *Engine) submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error) {
+	start := time.Now()
+	opCtx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		logger(ctx).Error("rejecting Submit while stopping", "error", err)
 		return nil, err
-	} else if !valid {
-		slog.Error("invalid transaction in Submit", "txid", txid, "error", ErrInvalidTransaction)
-		return nil, ErrInvalidTransaction
 	}
-	slog.Debug("transaction validated", "duration", time.Since(start))
-	start = time.Now()
-	steak := make(overlay.Steak, len(taggedBEEF.Topics))
-	topicInputs := make(map[string]map[uint32]*Output, len(tx.Inputs))
-	inpoints := make([]*transaction.Outpoint, 0, len(tx.Inputs))
-	ancillaryBeefs := make(map[string][]byte, len(taggedBEEF.Topics))
-	for _, input := range tx.Inputs {
-		inpoints = append(inpoints, &transaction.Outpoint{
-			Txid:  *input.SourceTXID,
-			Index: input.SourceTxOutIndex,
-		})
+	defer done()
+	ctx = opCtx
+	if err := e.allowWrite(); err != nil {
+		logger(ctx).Error("rejecting Submit in degraded mode", "error", err)
+		return nil, err
 	}
-	dupeTopics := make(map[string]struct{}, len(taggedBEEF.Topics))
 	for _, topic := range taggedBEEF.Topics {
-		if exists, err := e.Storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{
-			Txid:  txid,
-			Topic: topic,
-		}); err != nil {
-			slog.Error("failed to check if transaction exists", "txid", txid, "topic", topic, "error", err)
-			return nil, err
-		} else if exists {
-			steak[topic] = &overlay.AdmittanceInstructions{}
-			dupeTopics[topic] = struct{}{}
-			continue
+		if _, ok := e.topicManager(topic); !ok {
+			logger(ctx).Error("unknown topic in Submit", "topic", topic, "error", ErrUnknownTopic)
+			return nil, ErrUnknownTopic
 		}
-		topicInputs[topic] = make(map[uint32]*Output, len(tx.Inputs))
-		previousCoins := make(map[uint32]*transaction.TransactionOutput, len(tx.Inputs))
-		outputs, err := e.Storage.FindOutputs(ctx, inpoints, topic, nil, false)
+	}
+	if e.SubmitScheduler != nil && len(taggedBEEF.Topics) > 0 {
+		release, err := e.SubmitScheduler.AcquireTopics(ctx, taggedBEEF.Topics, mode == SubmitModeHistorical)
 		if err != nil {
-			slog.Error("failed to find outputs", "topic", topic, "error", err)
+			logger(ctx).Error("rejecting Submit while saturated", "topics", taggedBEEF.Topics, "error", err)
 			return nil, err
 		}
-		for vin := 0; vin < len(outputs); vin++ {
-			output := outputs[vin]
-			if output != nil {
-				previousCoins[uint32(vin)] = &transaction.TransactionOutput{ //nolint:gosec // index bounded by slice length
-					LockingScript: output.Script,
-					Satoshis:      output.Satoshis,
-				}
-				topicInputs[topic][uint32(vin)] = output //nolint:gosec // index bounded by slice length
-			}
-		}
+		defer release()
+	}
 
-		admit, err := e.Managers[topic].IdentifyAdmissibleOutputs(ctx, taggedBEEF.Beef, previousCoins)
-		if err != nil {
-			slog.Error("failed to identify admissible outputs", "topic", topic, "error", err)
-			return nil, err
+	storage := e.storage(ctx)
+	if err := submitCanceled(ctx, "verifying the transaction"); err != nil {
+		return nil, err
+	}
+	evaluated, err := e.evaluate(ctx, storage, taggedBEEF, mode)
+	if err != nil {
+		return nil, err
+	}
+	tx, txid, steak := evaluated.tx, evaluated.txid, evaluated.steak
+	inpoints, topicInputs, dupeTopics := evaluated.inpoints, evaluated.topicInputs, evaluated.dupeTopics
+	ancillaryBeefs, outputMetadata := evaluated.ancillaryBeefs, evaluated.outputMetadata
+	disputes, conflicted := evaluated.disputes, evaluated.conflicted
+	start = time.Now()
+
+	if err := submitCanceled(ctx, "marking inputs as spent"); err != nil {
+		return nil, err
+	}
+	spentTopics := make([]string, 0, len(taggedBEEF.Topics))
+	for _, topic := range taggedBEEF.Topics {
+		if _, ok := dupeTopics[topic]; ok {
+			continue
 		}
-		slog.Debug("admissible outputs identified", "duration", time.Since(start))
-		start = time.Now()
-		if len(admit.AncillaryTxids) > 0 {
-			ancillaryBeef := transaction.Beef{
-				Version:      transaction.BEEF_V2,
-				Transactions: make(map[chainhash.Hash]*transaction.BeefTx, len(admit.AncillaryTxids)),
-			}
-			for _, txid := range admit.AncillaryTxids {
-				if foundTx := beef.FindTransaction(txid.String()); foundTx == nil {
-					missingErr := ErrMissingDependencyTx
-					slog.Error("missing dependency transaction", "txid", txid, "error", missingErr)
-					return nil, missingErr
-				} else if beefBytes, err := foundTx.BEEF(); err != nil {
-					slog.Error("failed to get BEEF bytes", "txid", txid, "error", err)
-					return nil, err
-				} else if err := ancillaryBeef.MergeBeefBytes(beefBytes); err != nil {
-					slog.Error("failed to merge BEEF bytes", "txid", txid, "error", err)
-					return nil, err
-				}
-			}
-			beefBytes, err := ancillaryBeef.Bytes()
-			if err != nil {
-				slog.Error("failed to get ancillary BEEF bytes", "topic", topic, "error", err)
-				return nil, err
+		if err := e.trackWrite(storage.MarkUTXOsAsSpent(ctx, inpoints, topic, txid)); err != nil {
+			logger(ctx).Error("failed to mark UTXOs as spent", "topic", topic, "txid", txid, "error", err)
+			if canceled := submitCanceled(ctx, "marking inputs as spent"); canceled != nil {
+				e.rollbackSpent(ctx, storage, txid, inpoints, spentTopics)
+				return nil, canceled
 			}
-			ancillaryBeefs[topic] = beefBytes
+			return nil, err
 		}
-		steak[topic] = &admit
+		spentTopics = append(spentTopics, topic)
+	}
+	if err := submitCanceled(ctx, "notifying lookup services"); err != nil {
+		e.rollbackSpent(ctx, storage, txid, inpoints, spentTopics)
+		return nil, err
 	}
 
+	// Past this point lookup services learn about the transaction and it is broadcast, so it is applied in full:
+	// the remaining writes are not interrupted when ctx is done, while its propagation to other nodes still is.
+	propagationCtx := ctx
+	ctx = context.WithoutCancel(ctx)
 	for _, topic := range taggedBEEF.Topics {
 		if _, ok := dupeTopics[topic]; ok {
 			continue
 		}
-		if err := e.Storage.MarkUTXOsAsSpent(ctx, inpoints, topic, txid); err != nil {
-			slog.Error("failed to mark UTXOs as spent", "topic", topic, "txid", txid, "error", err)
-			return nil, err
+		for vin, output := range topicInputs[topic] {
+			e.publish(ctx, OutputSpentEvent{Outpoint: output.Outpoint, Topic: topic, SpendingTxid: *txid, InputIndex: vin})
 		}
 		for vin := 0; vin < len(inpoints); vin++ {
 			outpoint := inpoints[vin]
-			for _, l := range e.LookupServices {
-				if err := l.OutputSpent(ctx, &OutputSpent{
-					Outpoint:           outpoint,
-					Topic:              topic,
-					SpendingTxid:       txid,
-					InputIndex:         uint32(vin), //nolint:gosec // index bounded by slice length
-					UnlockingScript:    tx.Inputs[vin].UnlockingScript,
-					SequenceNumber:     tx.Inputs[vin].SequenceNumber,
-					SpendingAtomicBEEF: taggedBEEF.Beef,
+			spent := &OutputSpent{
+				Outpoint:           outpoint,
+				Topic:              topic,
+				SpendingTxid:       txid,
+				InputIndex:         uint32(vin), //nolint:gosec // index bounded by slice length
+				UnlockingScript:    tx.Inputs[vin].UnlockingScript,
+				SequenceNumber:     tx.Inputs[vin].SequenceNumber,
+				SpendingAtomicBEEF: taggedBEEF.Beef,
+			}
+			for name, l := range e.lookupServices() {
+				if err := e.notifyLookupService(ctx, name, l, LookupHookOutputSpent, func(ctx context.Context, l LookupService) error {
+					return l.OutputSpent(ctx, spent)
 				}); err != nil {
-					slog.Error("failed to notify lookup service about spent output", "topic", topic, "txid", txid, "error", err)
+					logger(ctx).Error("failed to notify lookup service about spent output", "service", name, "topic", topic, "txid", txid, "error", err)
+					e.recordDeadLetter(ctx, taggedBEEF, mode, txid, DeadLetterStageLookupService, err)
 					return nil, err
 				}
 			}
 		}
 	}
-	slog.Debug("UTXOs marked as spent", "duration", time.Since(start))
+	logger(ctx).Debug("UTXOs marked as spent", "duration", time.Since(start))
 	start = time.Now()
-	if mode != SubmitModeHistorical && e.Broadcaster != nil {
+	if mode != SubmitModeHistorical && e.Broadcaster != nil && !conflicted {
 		if _, failure := e.Broadcaster.Broadcast(tx); failure != nil {
-			slog.Error("failed to broadcast transaction", "txid", txid, "error", failure)
-			return nil, failure
+			logger(ctx).Error("failed to broadcast transaction", "txid", txid, "error", failure)
+			queued := e.queueBroadcast(ctx, txid, taggedBEEF.Beef, failure)
+			e.publish(ctx, BroadcastFailedEvent{Txid: *txid, Err: failure, Queued: queued})
+			if !queued {
+				e.recordDeadLetter(ctx, taggedBEEF, mode, txid, DeadLetterStageBroadcast, failure)
+				return nil, failure
+			}
 		}
 	}
 
@@ -311,6 +433,7 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 		onSteakReady(&steak)
 	}
 
+	tip := e.chainTip()
 	for _, topic := range taggedBEEF.Topics {
 		if _, ok := dupeTopics[topic]; ok {
 			continue
@@ -331,7 +454,7 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 
 		for vin, output := range topicInputs[topic] {
 			if err := e.deleteUTXODeep(ctx, output); err != nil {
-				slog.Error("failed to delete UTXO deep", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
+				logger(ctx).Error("failed to delete UTXO deep", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
 				return nil, err
 			}
 			admit.CoinsRemoved = append(admit.CoinsRemoved, vin)
@@ -352,8 +475,14 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 				Beef:            taggedBEEF.Beef,
 				AncillaryTxids:  admit.AncillaryTxids,
 				AncillaryBeef:   ancillaryBeefs[topic],
+				ReceivedAt:      time.Now(),
+				Source:          outputSource(ctx),
+				MerkleState:     MerkleStateUnmined,
+				Metadata:        outputMetadata[topic][vout],
+				OffChainValues:  taggedBEEF.OffChainValues,
 			}
 			if tx.MerklePath != nil {
+				output.MerkleState = MerkleStateMined
 				output.BlockHeight = tx.MerklePath.BlockHeight
 				for _, leaf := range tx.MerklePath.Path[0] {
 					if leaf.Hash != nil && leaf.Hash.Equal(output.Outpoint.Txid) {
@@ -362,46 +491,79 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 					}
 				}
 			}
-			if err := e.Storage.InsertOutput(ctx, output); err != nil {
-				slog.Error("failed to insert output", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
+			if err := e.scoreOutput(ctx, output, tip); err != nil {
 				return nil, err
 			}
+			if err := e.trackWrite(storage.InsertOutput(ctx, output)); err != nil {
+				logger(ctx).Error("failed to insert output", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
+				return nil, err
+			}
+			if e.OutpointFilter != nil {
+				e.OutpointFilter.Add(topic, &output.Outpoint)
+			}
 			newOutpoints = append(newOutpoints, &output.Outpoint)
-			for _, l := range e.LookupServices {
-				if err := l.OutputAdmittedByTopic(ctx, &OutputAdmittedByTopic{
-					Topic:         topic,
-					Outpoint:      &output.Outpoint,
-					Satoshis:      output.Satoshis,
-					LockingScript: output.Script,
-					AtomicBEEF:    taggedBEEF.Beef,
+			admitted := &OutputAdmittedByTopic{
+				Topic:          topic,
+				Outpoint:       &output.Outpoint,
+				Satoshis:       output.Satoshis,
+				LockingScript:  output.Script,
+				AtomicBEEF:     taggedBEEF.Beef,
+				Metadata:       output.Metadata,
+				OffChainValues: output.OffChainValues,
+			}
+			for name, l := range e.lookupServices() {
+				if err := e.notifyLookupService(ctx, name, l, LookupHookOutputAdmittedByTopic, func(ctx context.Context, l LookupService) error {
+					return l.OutputAdmittedByTopic(ctx, admitted)
 				}); err != nil {
-					slog.Error("failed to notify lookup service about admitted output", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
+					logger(ctx).Error("failed to notify lookup service about admitted output", "service", name, "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
+					e.recordDeadLetter(ctx, taggedBEEF, mode, txid, DeadLetterStageLookupService, err)
 					return nil, err
 				}
 			}
 		}
-		slog.Debug("outputs added", "duration", time.Since(start))
+		logger(ctx).Debug("outputs added", "duration", time.Since(start))
 		start = time.Now()
 		for _, output := range outputsConsumed {
 			output.ConsumedBy = append(output.ConsumedBy, newOutpoints...)
 
-			if err := e.Storage.UpdateConsumedBy(ctx, &output.Outpoint, output.Topic, output.ConsumedBy); err != nil {
-				slog.Error("failed to update consumed by", "topic", output.Topic, "outpoint", output.Outpoint.String(), "error", err)
+			if err := e.trackWrite(storage.UpdateConsumedBy(ctx, &output.Outpoint, output.Topic, output.ConsumedBy)); err != nil {
+				logger(ctx).Error("failed to update consumed by", "topic", output.Topic, "outpoint", output.Outpoint.String(), "error", err)
+				return nil, err
+			}
+		}
+		logger(ctx).Debug("consumed by references updated", "duration", time.Since(start))
+		if depth := e.historyRetentionDepth(topic); depth > 0 && len(outputsConsumed) > 0 {
+			if err := e.trimHistory(ctx, topic, outputsConsumed, depth); err != nil {
+				logger(ctx).Error("failed to trim history", "topic", topic, "txid", txid, "depth", depth, "error", err)
+				return nil, err
+			}
+		}
+		if conflicts, ok := disputes[topic]; ok {
+			if err := e.disputeOutputs(ctx, topic, append([]*chainhash.Hash{txid}, conflicts...)); err != nil {
+				logger(ctx).Error("failed to dispute conflicting outputs", "topic", topic, "txid", txid, "error", err)
 				return nil, err
 			}
 		}
-		slog.Debug("consumed by references updated", "duration", time.Since(start))
 		start = time.Now()
-		if err := e.Storage.InsertAppliedTransaction(ctx, &overlay.AppliedTransaction{
+		if err := e.trackWrite(storage.InsertAppliedTransaction(ctx, &overlay.AppliedTransaction{
 			Txid:  txid,
 			Topic: topic,
-		}); err != nil {
-			slog.Error("failed to insert applied transaction", "topic", topic, "txid", txid, "error", err)
+		})); err != nil {
+			logger(ctx).Error("failed to insert applied transaction", "topic", topic, "txid", txid, "error", err)
 			return nil, err
 		}
-		slog.Debug("transaction applied", "duration", time.Since(start))
+		logger(ctx).Debug("transaction applied", "duration", time.Since(start))
+		e.publish(ctx, TransactionAdmittedEvent{
+			Txid:            *txid,
+			Topic:           topic,
+			OutputsAdmitted: admit.OutputsToAdmit,
+			CoinsRetained:   admit.CoinsToRetain,
+			CoinsRemoved:    admit.CoinsRemoved,
+			Historical:      mode == SubmitModeHistorical,
+		})
 	}
-	if e.Advertiser == nil || mode == SubmitModeHistorical {
+	e.notifyWebhooks(ctx, txid, steak, dupeTopics)
+	if e.Advertiser == nil || !e.shouldPropagate(ctx, mode) {
 		return steak, nil
 	}
 
@@ -418,57 +580,272 @@ func (e *Engine) Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode
 		return steak, nil
 	}
 
-	broadcasterCfg := &topic.BroadcasterConfig{}
-	if len(e.SLAPTrackers) > 0 {
+	broadcasterCfg := &topic.BroadcasterConfig{Facilitator: e.BroadcastFacilitator}
+	if slapTrackers := e.slapTrackers(); len(slapTrackers) > 0 {
 		broadcasterCfg.Resolver = lookup.NewLookupResolver(&lookup.LookupResolver{
-			SLAPTrackers: e.SLAPTrackers,
+			SLAPTrackers: slapTrackers,
 		})
 	}
 
 	if broadcaster, err := topic.NewBroadcaster(releventTopics, broadcasterCfg); err != nil {
-		slog.Error("failed to create broadcaster for propagation", "topics", releventTopics, "error", err)
-	} else if _, failure := broadcaster.BroadcastCtx(ctx, tx); failure != nil {
-		slog.Error("failed to propagate transaction to other nodes", "txid", txid, "error", failure)
+		logger(ctx).Error("failed to create broadcaster for propagation", "topics", releventTopics, "error", err)
+	} else if _, failure := broadcaster.BroadcastCtx(propagationCtx, tx); failure != nil {
+		logger(ctx).Error("failed to propagate transaction to other nodes", "txid", txid, "error", failure)
 	}
 	return steak, nil
 }
 
+// submission is a submitted transaction evaluated against the topics it is tagged with, before anything is written.
+type submission struct {
+	tx             *transaction.Transaction
+	txid           *chainhash.Hash
+	steak          overlay.Steak
+	inpoints       []*transaction.Outpoint
+	topicInputs    map[string]map[uint32]*Output // outputs of each topic spent by the transaction, by input index
+	ancillaryBeefs map[string][]byte
+	outputMetadata map[string]map[uint32]map[string]string
+	dupeTopics     map[string]struct{} // topics the transaction was already applied to, or ignored for by ConflictPolicyFirstSeen or a faulty topic manager
+	disputes       map[string][]*chainhash.Hash
+	conflicted     bool
+}
+
+// evaluate verifies the submitted transaction and identifies the outputs each of its topics admits,
+// reading storage without writing to it.
+func (e *Engine) evaluate(ctx context.Context, storage Storage, taggedBEEF overlay.TaggedBEEF, mode SumbitMode) (*submission, error) {
+	start := time.Now()
+	var tx *transaction.Transaction
+	beef, tx, txid, err := transaction.ParseBeef(taggedBEEF.Beef)
+	if err != nil {
+		logger(ctx).Error("failed to parse BEEF in Submit", "error", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBeef, err)
+	} else if tx == nil {
+		logger(ctx).Error("invalid BEEF in Submit - tx is nil", "error", ErrInvalidBeef)
+		return nil, ErrInvalidBeef
+	}
+	if valid, err := e.verifySPV(ctx, tx); err != nil {
+		logger(ctx).Error("SPV verification failed in Submit", "txid", txid, "error", err)
+		return nil, err
+	} else if !valid {
+		logger(ctx).Error("invalid transaction in Submit", "txid", txid, "error", ErrInvalidTransaction)
+		return nil, ErrInvalidTransaction
+	}
+	if mode == SubmitModeHistorical && tx.MerklePath == nil {
+		for _, topic := range taggedBEEF.Topics {
+			if config, _ := e.syncConfiguration(topic); config.RequireMinedHistory {
+				logger(ctx).Error("rejecting unmined historical submission", "txid", txid, "topic", topic, "error", ErrUnminedHistoricalSubmission)
+				return nil, ErrUnminedHistoricalSubmission
+			}
+		}
+	}
+	logger(ctx).Debug("transaction validated", "duration", time.Since(start))
+	start = time.Now()
+	steak := make(overlay.Steak, len(taggedBEEF.Topics))
+	topicInputs := make(map[string]map[uint32]*Output, len(tx.Inputs))
+	inpoints := make([]*transaction.Outpoint, 0, len(tx.Inputs))
+	ancillaryBeefs := make(map[string][]byte, len(taggedBEEF.Topics))
+	outputMetadata := make(map[string]map[uint32]map[string]string, len(taggedBEEF.Topics))
+	for _, input := range tx.Inputs {
+		inpoints = append(inpoints, &transaction.Outpoint{
+			Txid:  *input.SourceTXID,
+			Index: input.SourceTxOutIndex,
+		})
+	}
+	dupeTopics := make(map[string]struct{}, len(taggedBEEF.Topics))
+	disputes := make(map[string][]*chainhash.Hash)
+	conflicted := false
+	for _, topic := range taggedBEEF.Topics {
+		if err := submitCanceled(ctx, "evaluating topic "+topic); err != nil {
+			return nil, err
+		}
+		if exists, err := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{
+			Txid:  txid,
+			Topic: topic,
+		}); err != nil {
+			logger(ctx).Error("failed to check if transaction exists", "txid", txid, "topic", topic, "error", err)
+			return nil, err
+		} else if exists {
+			steak[topic] = &overlay.AdmittanceInstructions{}
+			dupeTopics[topic] = struct{}{}
+			continue
+		}
+		topicInputs[topic] = make(map[uint32]*Output, len(tx.Inputs))
+		previousCoins := make(map[uint32]*transaction.TransactionOutput, len(tx.Inputs))
+		outputs, err := e.findInputs(ctx, storage, inpoints, topic, e.ConflictPolicy != ConflictPolicyNone && tx.MerklePath == nil)
+		if err != nil {
+			logger(ctx).Error("failed to find outputs", "topic", topic, "error", err)
+			return nil, err
+		}
+		for vin := 0; vin < len(outputs); vin++ {
+			output := outputs[vin]
+			if output != nil {
+				previousCoins[uint32(vin)] = &transaction.TransactionOutput{ //nolint:gosec // index bounded by slice length
+					LockingScript: output.Script,
+					Satoshis:      output.Satoshis,
+				}
+				topicInputs[topic][uint32(vin)] = output //nolint:gosec // index bounded by slice length
+			}
+		}
+
+		if e.ConflictPolicy != ConflictPolicyNone && tx.MerklePath == nil {
+			conflicts, err := e.findConflicts(ctx, topic, txid, outputs)
+			if err != nil {
+				logger(ctx).Error("failed to find conflicting transactions", "topic", topic, "txid", txid, "error", err)
+				return nil, err
+			}
+			if len(conflicts) > 0 {
+				switch e.ConflictPolicy {
+				case ConflictPolicyRejectNew:
+					err := conflictError(txid, conflicts)
+					logger(ctx).Error("rejecting conflicting transaction", "topic", topic, "txid", txid, "error", err)
+					return nil, err
+				case ConflictPolicyFirstSeen:
+					logger(ctx).Info("ignoring conflicting transaction, first seen wins", "topic", topic, "txid", txid, "conflicts", conflicts)
+					steak[topic] = &overlay.AdmittanceInstructions{}
+					dupeTopics[topic] = struct{}{}
+					conflicted = true
+					continue
+				case ConflictPolicyDispute:
+					disputes[topic] = conflicts
+					conflicted = true
+				}
+			}
+		}
+
+		admit, err := e.identifyAdmissibleOutputs(ctx, topic, taggedBEEF.Beef, previousCoins)
+		if errors.Is(err, ErrTopicManagerFault) {
+			logger(ctx).Warn("topic manager failed, nothing admitted in its topic", "topic", topic, "txid", txid, "error", err)
+			steak[topic] = &overlay.AdmittanceInstructions{}
+			dupeTopics[topic] = struct{}{}
+			continue
+		}
+		if err != nil {
+			logger(ctx).Error("failed to identify admissible outputs", "topic", topic, "error", err)
+			return nil, err
+		}
+		if admit, err = e.enforceTopicQuota(ctx, storage, topic, tx, admit); err != nil {
+			return nil, err
+		}
+		if outputMetadata[topic], err = e.identifyOutputMetadata(ctx, topic, taggedBEEF.Beef, admit); err != nil {
+			logger(ctx).Error("failed to identify output metadata", "topic", topic, "error", err)
+			return nil, err
+		}
+		logger(ctx).Debug("admissible outputs identified", "duration", time.Since(start))
+		start = time.Now()
+		if len(admit.AncillaryTxids) > 0 {
+			ancillaryBeef := transaction.Beef{
+				Version:      transaction.BEEF_V2,
+				Transactions: make(map[chainhash.Hash]*transaction.BeefTx, len(admit.AncillaryTxids)),
+			}
+			for _, txid := range admit.AncillaryTxids {
+				if foundTx := beef.FindTransaction(txid.String()); foundTx == nil {
+					missingErr := ErrMissingDependencyTx
+					logger(ctx).Error("missing dependency transaction", "txid", txid, "error", missingErr)
+					return nil, missingErr
+				} else if beefBytes, err := foundTx.BEEF(); err != nil {
+					logger(ctx).Error("failed to get BEEF bytes", "txid", txid, "error", err)
+					return nil, err
+				} else if err := ancillaryBeef.MergeBeefBytes(beefBytes); err != nil {
+					logger(ctx).Error("failed to merge BEEF bytes", "txid", txid, "error", err)
+					return nil, err
+				}
+			}
+			beefBytes, err := ancillaryBeef.Bytes()
+			if err != nil {
+				logger(ctx).Error("failed to get ancillary BEEF bytes", "topic", topic, "error", err)
+				return nil, err
+			}
+			ancillaryBeefs[topic] = beefBytes
+		}
+		steak[topic] = &admit
+	}
+
+	return &submission{
+		tx:             tx,
+		txid:           txid,
+		steak:          steak,
+		inpoints:       inpoints,
+		topicInputs:    topicInputs,
+		ancillaryBeefs: ancillaryBeefs,
+		outputMetadata: outputMetadata,
+		dupeTopics:     dupeTopics,
+		disputes:       disputes,
+		conflicted:     conflicted,
+	}, nil
+}
+
 // Lookup performs a lookup query on the overlay service
 func (e *Engine) Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
-	l, ok := e.LookupServices[question.Service]
+	l, ok := e.lookupService(question.Service)
+	if !ok && e.LookupCache != nil && e.LookupResolver != nil {
+		return e.proxyLookup(ctx, question)
+	}
 	if !ok {
-		slog.Error("unknown lookup service", "service", question.Service, "error", ErrUnknownTopic)
+		logger(ctx).Error("unknown lookup service", "service", question.Service, "error", ErrUnknownTopic)
 		return nil, ErrUnknownTopic
 	}
+	if err := validateLookupQuery(l, question); err != nil {
+		logger(ctx).Error("rejecting lookup question", "service", question.Service, "error", err)
+		return nil, err
+	}
+	if e.LocalLookupCache == nil || !lookupHydration(ctx).isDefault() {
+		return e.lookupLocal(ctx, l, question)
+	}
+	if answer, ok := e.LocalLookupCache.Get(question); ok {
+		logger(ctx).Debug("serving lookup from cache", "service", question.Service)
+		return answer, nil
+	}
+	answer, err := e.lookupLocal(ctx, l, question)
+	if err != nil {
+		return nil, err
+	}
+	e.LocalLookupCache.Set(question, answer)
+	return answer, nil
+}
+
+// lookupLocal answers the question with a locally hosted lookup service, hydrating output formulas from storage.
+func (e *Engine) lookupLocal(ctx context.Context, l LookupService, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
 	result, err := l.Lookup(ctx, question)
 	if err != nil {
-		slog.Error("lookup service failed", "service", question.Service, "error", err)
+		logger(ctx).Error("lookup service failed", "service", question.Service, "error", err)
 		return nil, err
 	}
 	if result.Type == lookup.AnswerTypeFreeform || result.Type == lookup.AnswerTypeOutputList {
 		return result, nil
 	}
 	hydratedOutputs := make([]*lookup.OutputListItem, 0, len(result.Outputs))
+	metadata := make(LookupOutputMetadata, 0, len(result.Formulas))
+	offChainValues := make([][]byte, 0, len(result.Formulas))
+	tagged, offChain := false, false
 	for _, formula := range result.Formulas {
-		if output, err := e.Storage.FindOutput(ctx, formula.Outpoint, nil, nil, true); err != nil {
-			slog.Error("failed to find output in Lookup", "outpoint", formula.Outpoint.String(), "error", err)
+		hydratedOutput, err := e.hydrateFormula(ctx, formula)
+		if err != nil {
 			return nil, err
-		} else if output != nil && output.Beef != nil {
-			if hydratedOutput, err := e.GetUTXOHistory(ctx, output, formula.History, 0); err != nil {
-				slog.Error("failed to get UTXO history in Lookup", "outpoint", formula.Outpoint.String(), "error", err)
-				return nil, err
-			} else if hydratedOutput != nil {
-				hydratedOutputs = append(hydratedOutputs, &lookup.OutputListItem{
-					Beef:        hydratedOutput.Beef,
-					OutputIndex: hydratedOutput.Outpoint.Index,
-				})
+		} else if hydratedOutput != nil {
+			beef := hydratedOutput.Beef
+			if hydratedOutput.RawTx != nil {
+				beef = hydratedOutput.RawTx
 			}
+			hydratedOutputs = append(hydratedOutputs, &lookup.OutputListItem{
+				Beef:        beef,
+				OutputIndex: hydratedOutput.OutputIndex,
+			})
+			metadata = append(metadata, hydratedOutput.Metadata)
+			offChainValues = append(offChainValues, hydratedOutput.OffChainValues)
+			tagged = tagged || len(hydratedOutput.Metadata) > 0
+			offChain = offChain || len(hydratedOutput.OffChainValues) > 0
 		}
 	}
-	return &lookup.LookupAnswer{
+	answer := &lookup.LookupAnswer{
 		Type:    lookup.AnswerTypeOutputList,
 		Outputs: hydratedOutputs,
-	}, nil
+	}
+	switch {
+	case offChain:
+		answer.Result = LookupOutputDetails{Metadata: metadata, OffChainValues: offChainValues}
+	case tagged:
+		answer.Result = metadata
+	}
+	return answer, nil
 }
 
 // GetUTXOHistory retrieves the history of a UTXO
@@ -483,15 +860,18 @@ func (e *Engine) GetUTXOHistory(ctx context.Context, output *Output, historySele
 	if output != nil && len(output.OutputsConsumed) == 0 {
 		return output, nil
 	}
+	if depth := e.historyRetentionDepth(output.Topic); depth > 0 && currentDepth >= uint32(depth) { //nolint:gosec // depth is positive
+		return output, nil
+	}
 	outputsConsumed := output.OutputsConsumed[:]
 	childHistories := make(map[string]*Output, len(outputsConsumed))
 	for _, outpoint := range outputsConsumed {
 		if childOutput, err := e.Storage.FindOutput(ctx, outpoint, nil, nil, true); err != nil {
-			slog.Error("failed to find output in GetUTXOHistory", "outpoint", outpoint.String(), "error", err)
+			logger(ctx).Error("failed to find output in GetUTXOHistory", "outpoint", outpoint.String(), "error", err)
 			return nil, err
 		} else if childOutput != nil {
 			if child, err := e.GetUTXOHistory(ctx, childOutput, historySelector, currentDepth+1); err != nil {
-				slog.Error("failed to get child UTXO history", "outpoint", outpoint.String(), "depth", currentDepth+1, "error", err)
+				logger(ctx).Error("failed to get child UTXO history", "outpoint", outpoint.String(), "depth", currentDepth+1, "error", err)
 				return nil, err
 			} else if child != nil {
 				childHistories[child.Outpoint.String()] = child
@@ -501,7 +881,7 @@ func (e *Engine) GetUTXOHistory(ctx context.Context, output *Output, historySele
 
 	tx, err := transaction.NewTransactionFromBEEF(output.Beef)
 	if err != nil {
-		slog.Error("failed to create transaction from BEEF in GetUTXOHistory", "outpoint", output.Outpoint.String(), "error", err)
+		logger(ctx).Error("failed to create transaction from BEEF in GetUTXOHistory", "outpoint", output.Outpoint.String(), "error", err)
 		return nil, err
 	}
 	for _, txin := range tx.Inputs {
@@ -512,221 +892,354 @@ func (e *Engine) GetUTXOHistory(ctx context.Context, output *Output, historySele
 		if input := childHistories[outpoint.String()]; input != nil {
 			if input.Beef == nil {
 				beefErr := ErrMissingBeef
-				slog.Error("missing BEEF in GetUTXOHistory", "outpoint", outpoint.String(), "error", beefErr)
+				logger(ctx).Error("missing BEEF in GetUTXOHistory", "outpoint", outpoint.String(), "error", beefErr)
 				return nil, beefErr
 			} else if txin.SourceTransaction, err = transaction.NewTransactionFromBEEF(input.Beef); err != nil {
-				slog.Error("failed to create source transaction from BEEF", "outpoint", outpoint.String(), "error", err)
+				logger(ctx).Error("failed to create source transaction from BEEF", "outpoint", outpoint.String(), "error", err)
 				return nil, err
 			}
 		}
 	}
 	beef, err := tx.BEEF()
 	if err != nil {
-		slog.Error("failed to get BEEF from transaction in GetUTXOHistory", "outpoint", output.Outpoint.String(), "error", err)
+		logger(ctx).Error("failed to get BEEF from transaction in GetUTXOHistory", "outpoint", output.Outpoint.String(), "error", err)
 		return nil, err
 	}
 	output.Beef = beef
 	return output, nil
 }
 
-// SyncAdvertisements synchronizes advertisements from topic managers
+// SyncAdvertisements creates the SHIP and SLAP advertisements missing for the hosted topic managers
+// and lookup services, and revokes the advertisements of those no longer hosted. It returns
+// ErrAdvertisementBudgetExceeded without touching the advertisements when the estimated cost
+// of the batch exceeds AdvertisementBudget.MaxSatoshisPerRun.
 func (e *Engine) SyncAdvertisements(ctx context.Context) error {
-	if e.Advertiser == nil {
-		return nil
-	}
-	configuredTopics := make([]string, 0, len(e.Managers))
-	requiredSHIPAdvertisements := make(map[string]struct{}, len(configuredTopics))
-	for name := range e.Managers {
-		configuredTopics = append(configuredTopics, name)
-		requiredSHIPAdvertisements[name] = struct{}{}
-	}
-	configuredServices := make([]string, 0, len(e.LookupServices))
-	requiredSLAPAdvertisements := make(map[string]struct{}, len(configuredServices))
-	for name := range e.LookupServices {
-		configuredServices = append(configuredServices, name)
-		requiredSLAPAdvertisements[name] = struct{}{}
-	}
-	currentSHIPAdvertisements, err := e.Advertiser.FindAllAdvertisements("SHIP")
+	plan, err := e.PlanAdvertisements(ctx)
 	if err != nil {
-		slog.Error("failed to find SHIP advertisements", "error", err)
 		return err
 	}
-	shipsToCreate := make([]string, 0, len(requiredSHIPAdvertisements))
-	for topic := range requiredSHIPAdvertisements {
-		if slices.IndexFunc(currentSHIPAdvertisements, func(ad *advertiser.Advertisement) bool {
-			return ad.TopicOrService == topic && ad.Domain == e.HostingURL
-		}) == -1 {
-			shipsToCreate = append(shipsToCreate, topic)
-		}
-	}
-	shipsToRevoke := make([]*advertiser.Advertisement, 0, len(currentSHIPAdvertisements))
-	for _, ad := range currentSHIPAdvertisements {
-		if _, ok := requiredSHIPAdvertisements[ad.TopicOrService]; !ok {
-			shipsToRevoke = append(shipsToRevoke, ad)
-		}
-	}
-
-	currentSLAPAdvertisements, err := e.Advertiser.FindAllAdvertisements("SLAP")
-	if err != nil {
-		slog.Error("failed to find SLAP advertisements", "error", err)
+	if !plan.Cost.WithinBudget {
+		err := fmt.Errorf("%w: estimated %d satoshis, budget %d satoshis", ErrAdvertisementBudgetExceeded, plan.Cost.TotalSatoshis, plan.Cost.BudgetSatoshis)
+		slog.Error("refusing to sync advertisements", "error", err)
 		return err
 	}
-	slapsToCreate := make([]string, 0, len(requiredSLAPAdvertisements))
-	for service := range requiredSLAPAdvertisements {
-		if slices.IndexFunc(currentSLAPAdvertisements, func(ad *advertiser.Advertisement) bool {
-			return ad.TopicOrService == service && ad.Domain == e.HostingURL
-		}) == -1 {
-			slapsToCreate = append(slapsToCreate, service)
-		}
-	}
-	slapsToRevoke := make([]*advertiser.Advertisement, 0, len(currentSLAPAdvertisements))
-	for _, ad := range currentSLAPAdvertisements {
-		if _, ok := requiredSLAPAdvertisements[ad.TopicOrService]; !ok {
-			slapsToRevoke = append(slapsToRevoke, ad)
-		}
-	}
-	advertisementData := make([]*advertiser.AdvertisementData, 0, len(shipsToCreate)+len(slapsToCreate))
-	for _, topic := range shipsToCreate {
-		advertisementData = append(advertisementData, &advertiser.AdvertisementData{
-			Protocol:           "SHIP",
-			TopicOrServiceName: topic,
-		})
-	}
-	for _, service := range slapsToCreate {
-		advertisementData = append(advertisementData, &advertiser.AdvertisementData{
-			Protocol:           "SLAP",
-			TopicOrServiceName: service,
-		})
-	}
-	if len(advertisementData) > 0 {
-		if taggedBEEF, err := e.Advertiser.CreateAdvertisements(advertisementData); err != nil {
+	synced := e.Advertiser != nil
+	if len(plan.Create) > 0 {
+		if taggedBEEF, err := e.Advertiser.CreateAdvertisements(plan.Create); err != nil {
 			slog.Error("failed to create SHIP/SLAP advertisements", "error", err)
+			synced = false
 		} else if _, err := e.Submit(ctx, taggedBEEF, SubmitModeCurrent, nil); err != nil {
 			slog.Error("failed to submit SHIP/SLAP advertisements", "error", err)
+			synced = false
 		}
 	}
-	revokeData := make([]*advertiser.Advertisement, 0, len(shipsToRevoke)+len(slapsToRevoke))
-	revokeData = append(revokeData, shipsToRevoke...)
-	revokeData = append(revokeData, slapsToRevoke...)
-	if len(revokeData) > 0 {
-		if taggedBEEF, err := e.Advertiser.RevokeAdvertisements(revokeData); err != nil {
+	if len(plan.Revoke) > 0 {
+		if taggedBEEF, err := e.Advertiser.RevokeAdvertisements(plan.Revoke); err != nil {
 			slog.Error("failed to revoke SHIP/SLAP advertisements", "error", err)
+			synced = false
 		} else if _, err := e.Submit(ctx, taggedBEEF, SubmitModeCurrent, nil); err != nil {
 			slog.Error("failed to submit SHIP/SLAP advertisement revocation", "error", err)
+			synced = false
 		}
 	}
+	if synced {
+		e.recordAdvertisementSync(ctx)
+	}
 	return nil
 }
 
-// StartGASPSync starts the GASP synchronization process
+// StartGASPSync starts the GASP synchronization process. See SyncGASP for the report of the peers synced.
 func (e *Engine) StartGASPSync(ctx context.Context) error {
-	for topic := range e.SyncConfiguration {
-		syncEndpoints, ok := e.SyncConfiguration[topic]
-		if !ok {
+	_, err := e.SyncGASP(ctx)
+	return err
+}
+
+// SyncGASP runs a GASP sync of every unpaused topic with its peers and reports which peers succeeded or failed.
+// Up to SyncWorkers topics are synced concurrently, and up to the PeerConcurrency of its SyncConfiguration peers
+// of each topic, sharing the graphs ingested so that a graph is requested from a single peer. A failing peer is
+// reported without failing the sync; an error is returned when the peers of a topic cannot be discovered or the
+// engine stops.
+func (e *Engine) SyncGASP(ctx context.Context) (*GASPSyncReport, error) {
+	ctx, done, err := e.beginOperation(ctx, operationGASPSync)
+	if err != nil {
+		slog.Error("rejecting GASP sync while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	if err := e.rejectWhileDegraded(); err != nil {
+		slog.Error("skipping GASP sync in degraded mode", "error", err)
+		return nil, err
+	}
+
+	var topics []topicSync
+	for topic, syncEndpoints := range e.syncConfigurations() {
+		if syncEndpoints.Paused {
+			slog.Info("skipping GASP sync of paused topic", "topic", topic)
 			continue
 		}
+		peers, err := e.gaspSyncPeers(ctx, topic, syncEndpoints)
+		if err != nil {
+			return nil, err
+		}
+		if len(peers) > 0 {
+			topics = append(topics, topicSync{topic: topic, config: syncEndpoints, peers: peers})
+		}
+	}
+
+	report := &GASPSyncReport{Topics: make([]TopicSyncReport, len(topics))}
+	var stopped atomic.Bool
+	forEachConcurrently(e.SyncWorkers, len(topics), func(i int) {
+		if stopped.Load() {
+			return
+		}
+		topicReport, err := e.syncTopic(ctx, topics[i])
+		report.Topics[i] = topicReport
+		if err != nil {
+			stopped.Store(true)
+		}
+	})
+	if stopped.Load() {
+		return report, ErrEngineStopping
+	}
+	slog.Info("GASP sync completed", "topics", len(report.Topics), "failedPeers", report.FailedPeers())
+	return report, nil
+}
 
-		if syncEndpoints.Type == SyncConfigurationSHIP {
-			e.LookupResolver.SetSLAPTrackers(e.SLAPTrackers)
+// gaspSyncPeers returns the peers to sync the topic with, discovering them through the SHIP lookup service for
+// SHIP-based topics. The hosting URL is left out and the peers are ordered by PeerReputation when configured.
+func (e *Engine) gaspSyncPeers(ctx context.Context, topic string, syncEndpoints SyncConfiguration) ([]string, error) {
+	if syncEndpoints.Type == SyncConfigurationSHIP {
+		query, err := json.Marshal(map[string]any{"topics": []string{topic}})
+		if err != nil {
+			slog.Error("failed to marshal query for GASP sync", "topic", topic, "error", err)
+			return nil, err
+		}
 
-			query, err := json.Marshal(map[string]any{"topics": []string{topic}})
-			if err != nil {
-				slog.Error("failed to marshal query for GASP sync", "topic", topic, "error", err)
-				return err
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		lookupAnswer, err := e.LookupResolver.Query(timeoutCtx, &lookup.LookupQuestion{Service: "ls_ship", Query: query})
+		if err != nil {
+			slog.Error("failed to query lookup resolver for GASP sync", "topic", topic, "error", err)
+			return nil, err
+		}
+
+		if lookupAnswer.Type == lookup.AnswerTypeOutputList {
+			endpointSet := make(map[string]struct{}, len(lookupAnswer.Outputs))
+			for _, output := range lookupAnswer.Outputs {
+				tx, err := transaction.NewTransactionFromBEEF(output.Beef)
+				if err != nil {
+					slog.Error("failed to parse advertisement output BEEF", "topic", topic, "error", err)
+					continue
+				}
+
+				advertisement, err := e.Advertiser.ParseAdvertisement(tx.Outputs[output.OutputIndex].LockingScript)
+				if err != nil {
+					slog.Error("failed to parse advertisement from locking script", "topic", topic, "error", err)
+					continue
+				}
+
+				if advertisement != nil && advertisement.Protocol == "SHIP" {
+					endpointSet[advertisement.Domain] = struct{}{}
+				}
 			}
 
-			timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			defer cancel()
-			lookupAnswer, err := e.LookupResolver.Query(timeoutCtx, &lookup.LookupQuestion{Service: "ls_ship", Query: query})
-			if err != nil {
-				slog.Error("failed to query lookup resolver for GASP sync", "topic", topic, "error", err)
-				return err
+			syncEndpoints.Peers = make([]string, 0, len(endpointSet))
+			for endpoint := range endpointSet {
+				syncEndpoints.Peers = append(syncEndpoints.Peers, endpoint)
 			}
+		}
+	}
 
-			if lookupAnswer.Type == lookup.AnswerTypeOutputList {
-				endpointSet := make(map[string]struct{}, len(lookupAnswer.Outputs))
-				for _, output := range lookupAnswer.Outputs {
-					tx, err := transaction.NewTransactionFromBEEF(output.Beef)
-					if err != nil {
-						slog.Error("failed to parse advertisement output BEEF", "topic", topic, "error", err)
-						continue
-					}
+	peers := make([]string, 0, len(syncEndpoints.Peers))
+	for _, peer := range syncEndpoints.Peers {
+		if peer != e.HostingURL {
+			peers = append(peers, peer)
+		}
+	}
+	if e.PeerReputation != nil {
+		peers = e.PeerReputation.Prioritize(peers)
+	}
+	return peers, nil
+}
 
-					advertisement, err := e.Advertiser.ParseAdvertisement(tx.Outputs[output.OutputIndex].LockingScript)
-					if err != nil {
-						slog.Error("failed to parse advertisement from locking script", "topic", topic, "error", err)
-						continue
-					}
+// syncTopic syncs a topic with each of its peers, up to PeerConcurrency at a time, returning ErrEngineStopping
+// when the engine stops during the sync.
+func (e *Engine) syncTopic(ctx context.Context, job topicSync) (TopicSyncReport, error) {
+	ingested := gasp.NewIngestedGraphs()
+	results := make([]error, len(job.peers))
+	forEachConcurrently(job.config.PeerConcurrency, len(job.peers), func(i int) {
+		if e.interruptedByStop(ctx) {
+			results[i] = ErrEngineStopping
+			return
+		}
+		results[i] = e.syncTopicWithPeer(ctx, job, job.peers[i], ingested)
+	})
 
-					if advertisement != nil && advertisement.Protocol == "SHIP" {
-						endpointSet[advertisement.Domain] = struct{}{}
-					}
-				}
+	report := TopicSyncReport{Topic: job.topic, IngestedGraphs: ingested.Len()}
+	for i, err := range results {
+		switch {
+		case errors.Is(err, ErrEngineStopping):
+			return report, ErrEngineStopping
+		case err != nil:
+			report.Failed = append(report.Failed, PeerSyncFailure{Peer: job.peers[i], Err: err})
+		default:
+			report.Succeeded = append(report.Succeeded, job.peers[i])
+		}
+	}
+	e.publish(ctx, SyncCompletedEvent{Topic: job.topic, Peers: job.peers, Failed: report.FailedPeers()})
+	return report, nil
+}
 
-				syncEndpoints.Peers = make([]string, 0, len(endpointSet))
-				for endpoint := range endpointSet {
-					if endpoint != e.HostingURL {
-						syncEndpoints.Peers = append(syncEndpoints.Peers, endpoint)
-					}
-				}
+// syncTopicWithPeer runs a GASP sync of a single topic with a single peer, skipping the graphs already ingested
+// from another peer. When the storage supports GASP checkpoints, any interrupted progress for the pair is
+// restored first and progress is checkpointed once per synced page.
+// The progress of the sync is tracked in SyncProgress.
+func (e *Engine) syncTopicWithPeer(ctx context.Context, job topicSync, peer string, ingested *gasp.IngestedGraphs) error {
+	e.SyncProgress.start(job.topic, peer)
+	err := e.runGASPSync(ctx, job, peer, ingested)
+	e.SyncProgress.finish(job.topic, peer, err)
+	return err
+}
+
+// runGASPSync runs the GASP sync of syncTopicWithPeer, from the since score of the job when set
+// instead of the last interaction with the peer.
+func (e *Engine) runGASPSync(ctx context.Context, job topicSync, peer string, ingested *gasp.IngestedGraphs) error {
+	topic, syncConfig := job.topic, job.config
+	logPrefix := "[GASP Sync of " + topic + " with " + peer + "]"
+
+	slog.Info("GASP sync starting", "topic", topic, "peer", peer)
+
+	// Read the last interaction score from storage
+	lastInteraction, err := e.Storage.GetLastInteraction(ctx, peer, topic)
+	if err != nil {
+		slog.Error("Failed to get last interaction", "topic", topic, "peer", peer, "error", err)
+		return err
+	}
+	e.SyncProgress.interacted(topic, peer, lastInteraction)
+
+	storedInteraction := lastInteraction
+	if job.since != nil {
+		lastInteraction = *job.since
+	}
+	syncedInteraction := lastInteraction
+
+	remote, err := NewOverlayGASPRemote(peer, topic, syncConfig.RemoteFor(peer))
+	if err != nil {
+		slog.Error("Failed to create GASP remote", "topic", topic, "peer", peer, "error", err)
+		return err
+	}
+
+	storage := NewOverlayGASPStorage(topic, e, nil)
+	storage.Peer = peer
+	storage.onGraphFinalized = func(nodes int) {
+		e.SyncProgress.nodesIngested(topic, peer, nodes)
+	}
+	var restoredGraphs []*transaction.Outpoint
+	checkpoints, checkpointing := storageCapability[GASPCheckpointStorage](e.Storage)
+	if checkpointing {
+		checkpoint, err := checkpoints.FindGASPCheckpoint(ctx, peer, topic)
+		if err != nil {
+			slog.Error("Failed to find GASP checkpoint", "topic", topic, "peer", peer, "error", err)
+			return err
+		}
+		if checkpoint != nil {
+			if job.since == nil && checkpoint.LastInteraction > lastInteraction {
+				lastInteraction = checkpoint.LastInteraction
+			}
+			if restoredGraphs, err = storage.RestoreInFlightNodes(ctx, checkpoint.Nodes); err != nil {
+				slog.Error("Failed to restore GASP checkpoint", "topic", topic, "peer", peer, "error", err)
+				return err
 			}
 		}
+		storage.Checkpoints = checkpoints
+		// A sync interrupted before its first page checkpoints the score it resumed from.
+		syncedInteraction = lastInteraction
+	}
 
-		if len(syncEndpoints.Peers) > 0 {
-			peers := make([]string, 0, len(syncEndpoints.Peers))
-			for _, peer := range syncEndpoints.Peers {
-				if peer != e.HostingURL {
-					peers = append(peers, peer)
-				}
+	// Create a new GASP provider for each peer to avoid state conflicts
+	gaspProvider := gasp.NewGASP(gasp.Params{
+		Storage:         storage,
+		Remote:          remote,
+		LastInteraction: lastInteraction,
+		LogPrefix:       &logPrefix,
+		Unidirectional:  true,
+		Concurrency:     syncConfig.Concurrency,
+		Ingested:        ingested,
+		OnPageSynced: func(ctx context.Context, score float64) {
+			syncedInteraction = score
+			e.SyncProgress.pageSynced(topic, peer, score)
+			if err := storage.Checkpoint(ctx, score); err != nil {
+				slog.Error("Failed to save GASP checkpoint", "topic", topic, "peer", peer, "error", err)
 			}
+		},
+	})
 
-			for _, peer := range peers {
-				logPrefix := "[GASP Sync of " + topic + " with " + peer + "]"
+	// Complete the graphs that were in flight when the previous sync was interrupted
+	for _, graphID := range restoredGraphs {
+		if err := gaspProvider.CompleteGraph(ctx, graphID); err != nil {
+			slog.Warn("failed to complete restored graph", "topic", topic, "peer", peer, "graphID", graphID.String(), "error", err)
+		}
+	}
 
-				slog.Info("GASP sync starting", "topic", topic, "peer", peer)
+	started := time.Now()
+	if err := gaspProvider.Sync(ctx, peer, DefaultGASPSyncLimit); err != nil {
+		if e.interruptedByStop(ctx) {
+			e.persistInterruptedSync(context.WithoutCancel(ctx), storage, storedInteraction, syncedInteraction)
+			return ErrEngineStopping
+		}
+		slog.Error("failed to sync with peer", "topic", topic, "peer", peer, "error", err)
+		if e.PeerReputation != nil {
+			e.PeerReputation.RecordFailure(peer, time.Since(started))
+		}
+		return err
+	}
+	slog.Info("GASP sync successful", "topic", topic, "peer", peer)
+	if e.PeerReputation != nil {
+		e.PeerReputation.RecordSuccess(peer, time.Since(started))
+	}
 
-				// Read the last interaction score from storage
-				lastInteraction, err := e.Storage.GetLastInteraction(ctx, peer, topic)
-				if err != nil {
-					slog.Error("Failed to get last interaction", "topic", topic, "peer", peer, "error", err)
-					return err
-				}
+	// Save the updated last interaction score
+	if gaspProvider.LastInteraction > storedInteraction {
+		if err := e.trackWrite(e.Storage.UpdateLastInteraction(ctx, peer, topic, gaspProvider.LastInteraction)); err == nil {
+			slog.Info("Updated last interaction score", "topic", topic, "peer", peer, "score", gaspProvider.LastInteraction)
+		}
+	}
 
-				// Create a new GASP provider for each peer to avoid state conflicts
-				gaspProvider := gasp.NewGASP(gasp.Params{
-					Storage: NewOverlayGASPStorage(topic, e, nil),
-					Remote: &OverlayGASPRemote{
-						EndpointURL: peer,
-						Topic:       topic,
-						HTTPClient:  http.DefaultClient,
-					},
-					LastInteraction: lastInteraction,
-					LogPrefix:       &logPrefix,
-					Unidirectional:  true,
-					Concurrency:     syncEndpoints.Concurrency,
-				})
-
-				if err := gaspProvider.Sync(ctx, peer, DefaultGASPSyncLimit); err != nil {
-					slog.Error("failed to sync with peer", "topic", topic, "peer", peer, "error", err)
-				} else {
-					slog.Info("GASP sync successful", "topic", topic, "peer", peer)
-
-					// Save the updated last interaction score
-					if gaspProvider.LastInteraction > lastInteraction {
-						if err := e.Storage.UpdateLastInteraction(ctx, peer, topic, gaspProvider.LastInteraction); err == nil {
-							slog.Info("Updated last interaction score", "topic", topic, "peer", peer, "score", gaspProvider.LastInteraction)
-						}
-					}
-				}
-			}
+	if checkpointing {
+		if err := checkpoints.DeleteGASPCheckpoint(ctx, peer, topic); err != nil {
+			slog.Error("Failed to delete GASP checkpoint", "topic", topic, "peer", peer, "error", err)
 		}
 	}
 	return nil
 }
 
-// ProvideForeignSyncResponse provides a synchronization response for foreign peers
+// persistInterruptedSync saves the progress of a GASP sync interrupted by Stop: the in-flight graphs when
+// checkpoints are supported, and the score of the last fully synced page as the peer's last interaction.
+func (e *Engine) persistInterruptedSync(ctx context.Context, storage *OverlayGASPStorage, storedInteraction, syncedInteraction float64) {
+	slog.Warn("GASP sync interrupted by engine stop", "topic", storage.Topic, "peer", storage.Peer, "score", syncedInteraction)
+	if err := storage.Checkpoint(ctx, syncedInteraction); err != nil {
+		slog.Error("Failed to save GASP checkpoint", "topic", storage.Topic, "peer", storage.Peer, "error", err)
+	}
+	if syncedInteraction > storedInteraction {
+		if err := e.trackWrite(e.Storage.UpdateLastInteraction(ctx, storage.Peer, storage.Topic, syncedInteraction)); err != nil {
+			slog.Error("Failed to update last interaction", "topic", storage.Topic, "peer", storage.Peer, "error", err)
+		}
+	}
+}
+
+// ProvideForeignSyncResponse provides a synchronization response for foreign peers. With a GASPServeLimiter,
+// the requests of a busy peer are rejected with a GASPPeerBusyError and responses beyond its UTXO budget are
+// truncated and marked partial.
 func (e *Engine) ProvideForeignSyncResponse(ctx context.Context, initialRequest *gasp.InitialRequest, topic string) (*gasp.InitialResponse, error) {
-	utxos, err := e.Storage.FindUTXOsForTopic(ctx, topic, initialRequest.Since, initialRequest.Limit, false)
+	release, err := e.GASPServeLimiter.acquire(gaspPeer(ctx))
+	if err != nil {
+		slog.Warn("rejecting GASP sync request of busy peer", "topic", topic, "error", err)
+		return nil, err
+	}
+	defer release()
+
+	limit, capped := e.GASPServeLimiter.utxoLimit(initialRequest.Limit)
+	utxos, err := e.Storage.FindUTXOsForTopic(ctx, topic, initialRequest.Since, limit, false)
 	if err != nil {
 		slog.Error("failed to find UTXOs for topic in ProvideForeignSyncResponse", "topic", topic, "error", err)
 		return nil, err
@@ -741,14 +1254,29 @@ func (e *Engine) ProvideForeignSyncResponse(ctx context.Context, initialRequest
 		})
 	}
 
+	partial := capped && len(utxos) >= int(limit)
+	if partial {
+		e.GASPServeLimiter.countTruncated()
+	}
 	return &gasp.InitialResponse{
 		UTXOList: gaspOutputs,
 		Since:    initialRequest.Since,
+		Partial:  partial,
 	}, nil
 }
 
-// ProvideForeignGASPNode provides a GASP node for foreign peers
-func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, topic string) (*gasp.Node, error) {
+// ProvideForeignGASPNode provides a GASP node for foreign peers. When metadata is requested,
+// the node carries the provenance of the output it was hydrated from. With a GASPServeLimiter, the requests of a
+// busy peer are rejected with a GASPPeerBusyError and nodes beyond its budget with ErrGASPResponseBudgetExceeded.
+func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, topic string, metadata bool) (*gasp.Node, error) {
+	release, err := e.GASPServeLimiter.acquire(gaspPeer(ctx))
+	if err != nil {
+		slog.Warn("rejecting GASP node request of busy peer", "graphID", graphID.String(), "topic", topic, "error", err)
+		return nil, err
+	}
+	defer release()
+
+	lookups, maxLookups := 0, e.GASPServeLimiter.maxNodeLookups()
 	var hydrator func(ctx context.Context, output *Output) (*gasp.Node, error)
 	hydrator = func(ctx context.Context, output *Output) (*gasp.Node, error) {
 		if output.Beef == nil {
@@ -762,6 +1290,12 @@ func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *
 		}
 		if tx == nil {
 			for _, outpoint := range output.OutputsConsumed {
+				if lookups++; maxLookups > 0 && lookups > maxLookups {
+					e.GASPServeLimiter.countOverBudget()
+					err := fmt.Errorf("%w: more than %d lookups", ErrGASPResponseBudgetExceeded, maxLookups)
+					slog.Error("GASP node exceeds the lookup budget in ProvideForeignGASPNode", "graphID", graphID.String(), "error", err)
+					return nil, err
+				}
 				if foundOutput, err := e.Storage.FindOutput(ctx, outpoint, &topic, nil, false); err == nil {
 					return hydrator(ctx, foundOutput)
 				}
@@ -771,15 +1305,23 @@ func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *
 			return nil, err
 		}
 		node := &gasp.Node{
-			GraphID:       graphID,
-			RawTx:         tx.Hex(),
-			OutputIndex:   outpoint.Index,
-			AncillaryBeef: output.AncillaryBeef,
+			GraphID:        graphID,
+			RawTx:          tx.Hex(),
+			OutputIndex:    outpoint.Index,
+			AncillaryBeef:  output.AncillaryBeef,
+			OffChainValues: output.OffChainValues,
 		}
 		if tx.MerklePath != nil {
 			proof := tx.MerklePath.Hex()
 			node.Proof = &proof
 		}
+		if metadata {
+			node.Provenance = output.gaspProvenance()
+		}
+		if err := e.GASPServeLimiter.checkNode(node); err != nil {
+			slog.Error("GASP node exceeds the size budget in ProvideForeignGASPNode", "graphID", graphID.String(), "error", err)
+			return nil, err
+		}
 		return node, nil
 	}
 	output, err := e.Storage.FindOutput(ctx, graphID, &topic, nil, true)
@@ -794,14 +1336,23 @@ func (e *Engine) ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *
 }
 
 func (e *Engine) deleteUTXODeep(ctx context.Context, output *Output) error {
+	if output.Pinned {
+		logger(ctx).Info("keeping pinned output in deleteUTXODeep", "outpoint", output.Outpoint.String(), "topic", output.Topic)
+		return nil
+	}
 	if len(output.ConsumedBy) == 0 {
-		if err := e.Storage.DeleteOutput(ctx, &output.Outpoint, output.Topic); err != nil {
-			slog.Error("failed to delete output in deleteUTXODeep", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+		if err := e.removeOutput(ctx, e.storage(ctx), &output.Outpoint, output.Topic, TombstoneReasonNoLongerRetained); err != nil {
+			logger(ctx).Error("failed to delete output in deleteUTXODeep", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
 			return err
 		}
-		for _, l := range e.LookupServices {
-			if err := l.OutputNoLongerRetainedInHistory(ctx, &output.Outpoint, output.Topic); err != nil {
-				slog.Error("failed to notify lookup service about output removal", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+		if e.OutpointFilter != nil {
+			e.OutpointFilter.MarkDeleted(output.Topic)
+		}
+		for name, l := range e.lookupServices() {
+			if err := e.notifyLookupService(ctx, name, l, LookupHookOutputNoLongerRetainedInHistory, func(ctx context.Context, l LookupService) error {
+				return l.OutputNoLongerRetainedInHistory(ctx, &output.Outpoint, output.Topic)
+			}); err != nil {
+				logger(ctx).Error("failed to notify lookup service about output removal", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
 				return err
 			}
 		}
@@ -811,9 +1362,9 @@ func (e *Engine) deleteUTXODeep(ctx context.Context, output *Output) error {
 	}
 
 	for _, outpoint := range output.OutputsConsumed {
-		staleOutput, err := e.Storage.FindOutput(ctx, outpoint, &output.Topic, nil, false)
+		staleOutput, err := e.storage(ctx).FindOutput(ctx, outpoint, &output.Topic, nil, false)
 		if err != nil {
-			slog.Error("failed to find stale output in deleteUTXODeep", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
+			logger(ctx).Error("failed to find stale output in deleteUTXODeep", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
 			return err
 		} else if staleOutput == nil {
 			continue
@@ -826,14 +1377,14 @@ func (e *Engine) deleteUTXODeep(ctx context.Context, output *Output) error {
 					staleOutput.ConsumedBy = append(staleOutput.ConsumedBy, outpoint)
 				}
 			}
-			if err := e.Storage.UpdateConsumedBy(ctx, &staleOutput.Outpoint, staleOutput.Topic, staleOutput.ConsumedBy); err != nil {
-				slog.Error("failed to update consumed by in deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
+			if err := e.trackWrite(e.storage(ctx).UpdateConsumedBy(ctx, &staleOutput.Outpoint, staleOutput.Topic, staleOutput.ConsumedBy)); err != nil {
+				logger(ctx).Error("failed to update consumed by in deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
 				return err
 			}
 		}
 
 		if err := e.deleteUTXODeep(ctx, staleOutput); err != nil {
-			slog.Error("failed recursive deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
+			logger(ctx).Error("failed recursive deleteUTXODeep", "outpoint", staleOutput.Outpoint.String(), "topic", staleOutput.Topic, "error", err)
 			return err
 		}
 	}
@@ -931,7 +1482,7 @@ func (e *Engine) updateMerkleProof(ctx context.Context, output *Output, txid cha
 			break
 		}
 	}
-	if err = e.Storage.UpdateTransactionBEEF(ctx, &output.Outpoint.Txid, atomicBytes); err != nil {
+	if err = e.trackWrite(e.Storage.UpdateTransactionBEEF(ctx, &output.Outpoint.Txid, atomicBytes)); err != nil {
 		slog.Error("failed to update transaction BEEF", "txid", output.Outpoint.Txid, "error", err)
 		return err
 	}
@@ -953,10 +1504,24 @@ func (e *Engine) updateMerkleProof(ctx context.Context, output *Output, txid cha
 
 // HandleNewMerkleProof handles a new Merkle proof
 func (e *Engine) HandleNewMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath) error {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting HandleNewMerkleProof while stopping", "txid", txid, "error", err)
+		return err
+	}
+	defer done()
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting HandleNewMerkleProof in degraded mode", "txid", txid, "error", err)
+		return err
+	}
+	if e.VerifiedTxs != nil && !e.VerifiedTxs.Contains(*txid, proof) {
+		// The transaction may have been verified against a block it is no longer part of.
+		e.VerifiedTxs.Invalidate(*txid)
+	}
 	if outputs, err := e.Storage.FindOutputsForTransaction(ctx, txid, true); err != nil {
 		slog.Error("failed to find outputs for transaction in HandleNewMerkleProof", "txid", txid, "error", err)
 		return err
-	} else if len(outputs) > 0 {
+	} else if outputs = mutableOutputs(txid, outputs); len(outputs) > 0 {
 		var blockIdx *uint64
 		for _, leaf := range proof.Path[0] {
 			if leaf.Hash != nil && leaf.Hash.Equal(*txid) {
@@ -974,25 +1539,37 @@ func (e *Engine) HandleNewMerkleProof(ctx context.Context, txid *chainhash.Hash,
 			if err := e.updateMerkleProof(ctx, output, *txid, proof); err != nil {
 				slog.Error("failed to update merkle proof in HandleNewMerkleProof", "outpoint", output.Outpoint.String(), "error", err)
 				return err
-			} else if err := e.Storage.UpdateOutputBlockHeight(ctx, &output.Outpoint, output.Topic, output.BlockHeight, output.BlockIdx, output.AncillaryBeef); err != nil {
+			} else if err := e.trackWrite(e.Storage.UpdateOutputBlockHeight(ctx, &output.Outpoint, output.Topic, output.BlockHeight, output.BlockIdx, output.AncillaryBeef)); err != nil {
 				slog.Error("failed to update output block height", "outpoint", output.Outpoint.String(), "error", err)
 				return err
+			} else if err := e.setMerkleState(ctx, output, MerkleStateMined); err != nil {
+				return err
+			}
+		}
+		if e.ConflictPolicy == ConflictPolicyDispute {
+			if err := e.resolveDisputes(ctx, txid, outputs); err != nil {
+				slog.Error("failed to resolve disputes in HandleNewMerkleProof", "txid", txid, "error", err)
+				return err
 			}
 		}
-		for _, l := range e.LookupServices {
-			if err := l.OutputBlockHeightUpdated(ctx, txid, blockHeight, *blockIdx); err != nil {
+		for name, l := range e.lookupServices() {
+			if err := e.notifyLookupService(ctx, name, l, LookupHookOutputBlockHeightUpdated, func(ctx context.Context, l LookupService) error {
+				return l.OutputBlockHeightUpdated(ctx, txid, blockHeight, *blockIdx)
+			}); err != nil {
 				slog.Error("failed to notify lookup service about block height update", "txid", txid, "blockHeight", blockHeight, "error", err)
 				return err
 			}
 		}
+		e.publish(ctx, ProofUpdatedEvent{Txid: *txid, BlockHeight: blockHeight, BlockIdx: *blockIdx, Outputs: len(outputs)})
 	}
 	return nil
 }
 
 // ListTopicManagers returns a list of topic managers and their metadata
 func (e *Engine) ListTopicManagers() map[string]*overlay.MetaData {
-	result := make(map[string]*overlay.MetaData, len(e.Managers))
-	for name, manager := range e.Managers {
+	managers := e.topicManagers()
+	result := make(map[string]*overlay.MetaData, len(managers))
+	for name, manager := range managers {
 		result[name] = manager.GetMetaData()
 	}
 	return result
@@ -1000,8 +1577,9 @@ func (e *Engine) ListTopicManagers() map[string]*overlay.MetaData {
 
 // ListLookupServiceProviders returns a list of lookup service providers and their metadata
 func (e *Engine) ListLookupServiceProviders() map[string]*overlay.MetaData {
-	result := make(map[string]*overlay.MetaData, len(e.LookupServices))
-	for name, provider := range e.LookupServices {
+	services := e.lookupServices()
+	result := make(map[string]*overlay.MetaData, len(services))
+	for name, provider := range services {
 		result[name] = provider.GetMetaData()
 	}
 	return result
@@ -1009,7 +1587,7 @@ func (e *Engine) ListLookupServiceProviders() map[string]*overlay.MetaData {
 
 // GetDocumentationForTopicManager returns documentation for a topic manager
 func (e *Engine) GetDocumentationForTopicManager(manager string) (string, error) {
-	tm, ok := e.Managers[manager]
+	tm, ok := e.topicManager(manager)
 	if !ok {
 		err := ErrNoDocumentationFound
 		slog.Error("topic manager not found", "manager", manager, "error", err)
@@ -1020,7 +1598,7 @@ func (e *Engine) GetDocumentationForTopicManager(manager string) (string, error)
 
 // GetDocumentationForLookupServiceProvider returns documentation for a lookup service provider
 func (e *Engine) GetDocumentationForLookupServiceProvider(provider string) (string, error) {
-	l, ok := e.LookupServices[provider]
+	l, ok := e.lookupService(provider)
 	if !ok {
 		err := ErrNoDocumentationFound
 		slog.Error("lookup service provider not found", "provider", provider, "error", err)
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// EventType identifies the kind of an Event published by the engine.
+type EventType string
+
+const (
+	// EventTransactionAdmitted is published once a submitted transaction is applied to a topic.
+	EventTransactionAdmitted EventType = "TransactionAdmitted"
+
+	// EventOutputSpent is published for every output of a topic spent by a submitted transaction.
+	EventOutputSpent EventType = "OutputSpent"
+
+	// EventProofUpdated is published once the outputs of a transaction are updated with a new merkle proof.
+	EventProofUpdated EventType = "ProofUpdated"
+
+	// EventSyncCompleted is published once a topic is synced with all of its GASP peers.
+	EventSyncCompleted EventType = "SyncCompleted"
+
+	// EventBroadcastFailed is published when a submitted transaction cannot be broadcast.
+	EventBroadcastFailed EventType = "BroadcastFailed"
+)
+
+// Event is a typed notification published on the EventBus of an engine.
+type Event interface {
+	// Type returns the kind of the event.
+	Type() EventType
+}
+
+// TransactionAdmittedEvent reports a transaction applied to a topic, with the admittance instructions
+// the topic manager returned for it.
+type TransactionAdmittedEvent struct {
+	Txid            chainhash.Hash
+	Topic           string
+	OutputsAdmitted []uint32
+	CoinsRetained   []uint32
+	CoinsRemoved    []uint32
+	Historical      bool
+}
+
+// Type returns EventTransactionAdmitted.
+func (TransactionAdmittedEvent) Type() EventType { return EventTransactionAdmitted }
+
+// OutputSpentEvent reports an output of a topic spent by a submitted transaction.
+type OutputSpentEvent struct {
+	Outpoint     transaction.Outpoint
+	Topic        string
+	SpendingTxid chainhash.Hash
+	InputIndex   uint32
+}
+
+// Type returns EventOutputSpent.
+func (OutputSpentEvent) Type() EventType { return EventOutputSpent }
+
+// ProofUpdatedEvent reports the outputs of a transaction updated with a new merkle proof.
+type ProofUpdatedEvent struct {
+	Txid        chainhash.Hash
+	BlockHeight uint32
+	BlockIdx    uint64
+	Outputs     int
+}
+
+// Type returns EventProofUpdated.
+func (ProofUpdatedEvent) Type() EventType { return EventProofUpdated }
+
+// SyncCompletedEvent reports a topic synced with all of its GASP peers. Failed lists the peers whose sync failed.
+type SyncCompletedEvent struct {
+	Topic  string
+	Peers  []string
+	Failed []string
+}
+
+// Type returns EventSyncCompleted.
+func (SyncCompletedEvent) Type() EventType { return EventSyncCompleted }
+
+// BroadcastFailedEvent reports a submitted transaction that could not be broadcast. Queued reports
+// whether the broadcast was queued for a retry instead of failing the submission.
+type BroadcastFailedEvent struct {
+	Txid   chainhash.Hash
+	Err    error
+	Queued bool
+}
+
+// Type returns EventBroadcastFailed.
+func (BroadcastFailedEvent) Type() EventType { return EventBroadcastFailed }
+
+// EventSubscriber receives the events published on an EventBus. HandleEvent is called synchronously
+// on the goroutine publishing the event, so subscribers doing slow work, such as network calls,
+// should hand the event off to a goroutine of their own.
+type EventSubscriber interface {
+	HandleEvent(ctx context.Context, event Event)
+}
+
+// EventSubscriberFunc adapts a function to an EventSubscriber.
+type EventSubscriberFunc func(ctx context.Context, event Event)
+
+// HandleEvent calls f(ctx, event).
+func (f EventSubscriberFunc) HandleEvent(ctx context.Context, event Event) {
+	f(ctx, event)
+}
+
+// EventBus publishes the events of an engine to its subscribers, so that features such as metrics,
+// notifications or audit logging can observe submissions, proofs, syncs and broadcasts without
+// patching the engine. Subscribers are called in the order they were registered, and a panicking
+// subscriber is recovered and logged without affecting the others or the engine.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers []EventSubscriber
+}
+
+// NewEventBus creates an EventBus publishing to the given subscribers.
+func NewEventBus(subscribers ...EventSubscriber) *EventBus {
+	return &EventBus{subscribers: slices.Clone(subscribers)}
+}
+
+// Subscribe registers a subscriber receiving every event published after the call.
+func (b *EventBus) Subscribe(subscriber EventSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish delivers the event to every subscriber.
+func (b *EventBus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+	for _, subscriber := range subscribers {
+		b.deliver(ctx, subscriber, event)
+	}
+}
+
+func (b *EventBus) deliver(ctx context.Context, subscriber EventSubscriber, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger(ctx).Error("event subscriber panicked", "event", event.Type(), "panic", r)
+		}
+	}()
+	subscriber.HandleEvent(ctx, event)
+}
+
+// publish publishes the event on the EventBus of the engine, if any.
+func (e *Engine) publish(ctx context.Context, event Event) {
+	if e.Events != nil {
+		e.Events.Publish(ctx, event)
+	}
+}
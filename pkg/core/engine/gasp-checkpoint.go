@@ -0,0 +1,166 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// GASPCheckpointNode is a single in-flight graph node captured in a GASP checkpoint.
+type GASPCheckpointNode struct {
+	Key     string     `json:"key"`
+	SpentBy string     `json:"spentBy,omitempty"`
+	Node    *gasp.Node `json:"node"`
+}
+
+// GASPCheckpoint captures the resumable state of a GASP sync for a single (peer, topic) pair.
+// Nodes are ordered so that every node appears after the node that spends it.
+type GASPCheckpoint struct {
+	Peer            string                `json:"peer"`
+	Topic           string                `json:"topic"`
+	LastInteraction float64               `json:"lastInteraction"`
+	Nodes           []*GASPCheckpointNode `json:"nodes"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+}
+
+// GASPCheckpointStorage is an optional Storage capability used to persist GASP sync progress
+// so an interrupted sync can be resumed after a restart instead of starting over.
+type GASPCheckpointStorage interface {
+	// SaveGASPCheckpoint inserts or replaces the checkpoint for the checkpoint's (peer, topic) pair.
+	SaveGASPCheckpoint(ctx context.Context, checkpoint *GASPCheckpoint) error
+
+	// FindGASPCheckpoint returns the checkpoint for the given (peer, topic) pair, or nil if none exists.
+	FindGASPCheckpoint(ctx context.Context, peer, topic string) (*GASPCheckpoint, error)
+
+	// FindGASPCheckpoints returns all persisted checkpoints.
+	FindGASPCheckpoints(ctx context.Context) ([]*GASPCheckpoint, error)
+
+	// DeleteGASPCheckpoint removes the checkpoint for the given (peer, topic) pair.
+	DeleteGASPCheckpoint(ctx context.Context, peer, topic string) error
+}
+
+// ResumeGASPSync resumes every GASP sync interrupted before completion, using the checkpoints
+// persisted by the storage. It is a no-op when the storage does not implement GASPCheckpointStorage.
+func (e *Engine) ResumeGASPSync(ctx context.Context) error {
+	checkpoints, ok := storageCapability[GASPCheckpointStorage](e.Storage)
+	if !ok {
+		return nil
+	}
+
+	ctx, done, err := e.beginOperation(ctx, operationGASPSync)
+	if err != nil {
+		slog.Error("rejecting GASP sync resume while stopping", "error", err)
+		return err
+	}
+	defer done()
+
+	found, err := checkpoints.FindGASPCheckpoints(ctx)
+	if err != nil {
+		slog.Error("failed to find GASP checkpoints", "error", err)
+		return err
+	}
+
+	for _, checkpoint := range found {
+		syncConfig, configured := e.syncConfiguration(checkpoint.Topic)
+		if _, managed := e.topicManager(checkpoint.Topic); !configured || !managed {
+			slog.Warn("discarding GASP checkpoint for unsynced topic", "topic", checkpoint.Topic, "peer", checkpoint.Peer)
+			if err := checkpoints.DeleteGASPCheckpoint(ctx, checkpoint.Peer, checkpoint.Topic); err != nil {
+				slog.Error("failed to delete GASP checkpoint", "topic", checkpoint.Topic, "peer", checkpoint.Peer, "error", err)
+				return err
+			}
+			continue
+		}
+		if syncConfig.Paused {
+			slog.Info("keeping GASP checkpoint of paused topic", "topic", checkpoint.Topic, "peer", checkpoint.Peer)
+			continue
+		}
+
+		slog.Info("GASP sync resuming", "topic", checkpoint.Topic, "peer", checkpoint.Peer, "nodes", len(checkpoint.Nodes))
+		if err := e.syncTopicWithPeer(ctx, topicSync{topic: checkpoint.Topic, config: syncConfig}, checkpoint.Peer, nil); errors.Is(err, ErrEngineStopping) {
+			return err
+		} else if err != nil {
+			slog.Warn("failed to resume GASP sync", "topic", checkpoint.Topic, "peer", checkpoint.Peer, "error", err)
+		}
+	}
+	return nil
+}
+
+// InFlightNodes returns the nodes of every graph that has not yet been finalized,
+// ordered so that each node follows the node spending it.
+func (s *OverlayGASPStorage) InFlightNodes() []*GASPCheckpointNode {
+	nodes := make([]*GASPCheckpointNode, 0)
+	visited := make(map[string]struct{})
+
+	var visit func(key, spentBy string, node *GraphNode)
+	visit = func(key, spentBy string, node *GraphNode) {
+		if _, ok := visited[key]; ok {
+			return
+		}
+		visited[key] = struct{}{}
+
+		gaspNode := node.Node
+		nodes = append(nodes, &GASPCheckpointNode{Key: key, SpentBy: spentBy, Node: &gaspNode})
+		for _, child := range node.Children {
+			childKey := (&transaction.Outpoint{Txid: *child.Txid, Index: child.OutputIndex}).String()
+			visit(childKey, key, child)
+		}
+	}
+
+	s.tempGraphNodeRefs.Range(func(key, ref any) bool {
+		node := ref.(*GraphNode)
+		if node.Parent != nil {
+			return true
+		}
+		if _, finalized := s.finalizedGraphs.Load(key); finalized {
+			return true
+		}
+		visit(key.(string), "", node)
+		return true
+	})
+	return nodes
+}
+
+// RestoreInFlightNodes rebuilds the temporary graph store from nodes previously captured by InFlightNodes
+// and returns the IDs of the restored graphs.
+func (s *OverlayGASPStorage) RestoreInFlightNodes(ctx context.Context, nodes []*GASPCheckpointNode) ([]*transaction.Outpoint, error) {
+	graphIDs := make([]*transaction.Outpoint, 0)
+	for _, node := range nodes {
+		var spentBy *transaction.Outpoint
+		if node.SpentBy != "" {
+			var err error
+			if spentBy, err = transaction.OutpointFromString(node.SpentBy); err != nil {
+				return nil, err
+			}
+		} else {
+			graphIDs = append(graphIDs, node.Node.GraphID)
+		}
+		if err := s.appendToGraph(ctx, node.Node, spentBy); err != nil {
+			return nil, err
+		}
+	}
+	return graphIDs, nil
+}
+
+// Checkpoint persists the current in-flight graph state together with the given last interaction score.
+// The engine calls it once per synced page and when a sync is interrupted. It is a no-op when no checkpoint
+// storage is configured.
+func (s *OverlayGASPStorage) Checkpoint(ctx context.Context, lastInteraction float64) error {
+	if s.Checkpoints == nil {
+		return nil
+	}
+
+	s.checkpointMu.Lock()
+	defer s.checkpointMu.Unlock()
+
+	return s.Checkpoints.SaveGASPCheckpoint(ctx, &GASPCheckpoint{
+		Peer:            s.Peer,
+		Topic:           s.Topic,
+		LastInteraction: lastInteraction,
+		Nodes:           s.InFlightNodes(),
+		UpdatedAt:       time.Now(),
+	})
+}
@@ -0,0 +1,248 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+const (
+	// DefaultGASPRemoteTimeout bounds a single GASP request to a peer when no timeout is configured.
+	DefaultGASPRemoteTimeout = 60 * time.Second
+
+	// DefaultGASPRemoteRetryBackoff is the initial delay between retries when no backoff is configured.
+	DefaultGASPRemoteRetryBackoff = 500 * time.Millisecond
+)
+
+// DefaultGASPPayloadLimits bounds the GASP payloads decoded from a peer when no limits are configured.
+// An initial response carries one small entry per UTXO; a node carries a raw transaction, its proof and
+// ancillary BEEF, which may each be large.
+var DefaultGASPPayloadLimits = GASPPayloadLimits{
+	InitialResponse: jsonlimit.Limits{
+		MaxBytes:        256 * 1024 * 1024,
+		MaxStringLength: 1024,
+		MaxArrayLength:  2_000_000,
+		MaxDepth:        8,
+	},
+	Node: jsonlimit.Limits{
+		MaxBytes:        1000 * 1024 * 1024,
+		MaxStringLength: 500 * 1024 * 1024,
+		MaxArrayLength:  100_000,
+		MaxDepth:        16,
+	},
+}
+
+// GASPPayloadLimits bounds the JSON payloads decoded from a GASP peer, per message type.
+// Unset limits fall back to DefaultGASPPayloadLimits; negative limits are disabled.
+type GASPPayloadLimits struct {
+	InitialResponse jsonlimit.Limits `mapstructure:"initial_response"`
+	Node            jsonlimit.Limits `mapstructure:"node"`
+}
+
+func (l GASPPayloadLimits) withDefaults() GASPPayloadLimits {
+	return GASPPayloadLimits{
+		InitialResponse: l.InitialResponse.Or(DefaultGASPPayloadLimits.InitialResponse),
+		Node:            l.Node.Or(DefaultGASPPayloadLimits.Node),
+	}
+}
+
+// ErrInvalidGASPRemoteCAFile is returned when the configured CA file contains no usable certificates.
+var ErrInvalidGASPRemoteCAFile = errors.New("GASP remote CA file contains no valid certificates")
+
+// GASPRemoteConfig configures the HTTP client used to talk to a GASP sync peer.
+type GASPRemoteConfig struct {
+	// Timeout bounds each request to the peer. Zero falls back to DefaultGASPRemoteTimeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxRetries is the number of additional attempts made after a network error or a 429/5xx response.
+	MaxRetries int `mapstructure:"max_retries"`
+
+	// RetryBackoff is the delay before the first retry; it doubles on every subsequent retry.
+	// Zero falls back to DefaultGASPRemoteRetryBackoff.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// BearerToken, when set, is sent as an Authorization bearer token on every request.
+	BearerToken string `mapstructure:"bearer_token"`
+
+	// TLSCAFile is an optional PEM file with additional root certificates trusted for the peer.
+	TLSCAFile string `mapstructure:"tls_ca_file"`
+
+	// TLSInsecureSkipVerify disables verification of the peer's certificate. Intended for testing only.
+	TLSInsecureSkipVerify bool `mapstructure:"tls_insecure_skip_verify"`
+
+	// PayloadLimits bounds the GASP responses decoded from the peer.
+	PayloadLimits GASPPayloadLimits `mapstructure:"payload_limits"`
+
+	// Wallet, when set, enables BRC-31 mutual authentication with the peer.
+	Wallet wallet.Interface `mapstructure:"-"`
+}
+
+// NewHTTPClient builds the HTTP client described by the configuration.
+func (c GASPRemoteConfig) NewHTTPClient() (util.HTTPClient, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultGASPRemoteTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+
+	if c.TLSCAFile != "" || c.TLSInsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: c.TLSInsecureSkipVerify, // #nosec G402 -- explicitly opted into by the operator
+		}
+		if c.TLSCAFile != "" {
+			pem, err := os.ReadFile(c.TLSCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read GASP remote CA file: %w", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, ErrInvalidGASPRemoteCAFile
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		client.Transport = transport
+	}
+
+	var base util.HTTPClient = client
+	if c.Wallet != nil {
+		base = &authFetchHTTPClient{fetch: authhttp.New(c.Wallet, authhttp.WithHttpClient(client))}
+	}
+
+	backoff := c.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultGASPRemoteRetryBackoff
+	}
+	return &gaspHTTPClient{
+		client:       base,
+		bearerToken:  c.BearerToken,
+		maxRetries:   c.MaxRetries,
+		retryBackoff: backoff,
+	}, nil
+}
+
+// NewOverlayGASPRemote creates an OverlayGASPRemote for the given peer and topic using the HTTP client described by cfg.
+func NewOverlayGASPRemote(endpointURL, topic string, cfg GASPRemoteConfig) (*OverlayGASPRemote, error) {
+	client, err := cfg.NewHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return &OverlayGASPRemote{
+		EndpointURL: endpointURL,
+		Topic:       topic,
+		HTTPClient:  client,
+		Limits:      cfg.PayloadLimits,
+	}, nil
+}
+
+// RemoteFor returns the GASP remote configuration for the given peer, preferring a per-peer override.
+func (s SyncConfiguration) RemoteFor(peer string) GASPRemoteConfig {
+	if cfg, ok := s.PeerRemotes[peer]; ok {
+		return cfg
+	}
+	return s.Remote
+}
+
+// gaspHTTPClient decorates an HTTP client with bearer authentication and retries with exponential backoff.
+type gaspHTTPClient struct {
+	client       util.HTTPClient
+	bearerToken  string
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// Do sends the request, retrying network errors and 429/5xx responses up to maxRetries times.
+func (c *gaspHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := c.client.Do(req)
+		if attempt >= c.maxRetries || !shouldRetryGASPRequest(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		slog.Warn("retrying GASP request", "url", req.URL.String(), "attempt", attempt+1, "backoff", backoff, "error", err)
+		if err := sleepContext(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+func shouldRetryGASPRequest(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// authFetchHTTPClient adapts a BRC-31 AuthFetch client to the util.HTTPClient interface.
+type authFetchHTTPClient struct {
+	fetch *authhttp.AuthFetch
+}
+
+// Do sends the request through the mutually authenticated AuthFetch client.
+func (c *authFetchHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	headers := make(map[string]string, len(req.Header))
+	for key := range req.Header {
+		headers[key] = req.Header.Get(key)
+	}
+	return c.fetch.Fetch(req.Context(), req.URL.String(), &authhttp.SimplifiedFetchRequestOptions{
+		Method:  req.Method,
+		Headers: headers,
+		Body:    body,
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/util"
 )
@@ -17,11 +18,17 @@ import (
 // ErrNotImplemented is returned when a method is not implemented for the OverlayGASPRemote.
 var ErrNotImplemented = errors.New("not-implemented")
 
+// ErrUnexpectedGASPResponseStatus is wrapped in the util.HTTPError returned when a peer answers a GASP request with a non-200 status.
+var ErrUnexpectedGASPResponseStatus = errors.New("unexpected GASP response status")
+
 // OverlayGASPRemote provides a remote GASP implementation that communicates with overlay endpoints.
 type OverlayGASPRemote struct {
 	EndpointURL string
 	Topic       string
 	HTTPClient  util.HTTPClient
+
+	// Limits bounds the responses decoded from the peer. Unset limits fall back to DefaultGASPPayloadLimits.
+	Limits GASPPayloadLimits
 }
 
 // GetInitialResponse sends a GASP initial request to the remote overlay and returns the response.
@@ -46,11 +53,12 @@ func (r *OverlayGASPRemote) GetInitialResponse(ctx context.Context, request *gas
 	if resp.StatusCode != http.StatusOK {
 		return nil, &util.HTTPError{
 			StatusCode: resp.StatusCode,
-			Err:        err,
+			Err:        ErrUnexpectedGASPResponseStatus,
 		}
 	}
 	result := &gasp.InitialResponse{}
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+	if err := jsonlimit.Decode(resp.Body, result, r.Limits.withDefaults().InitialResponse); err != nil {
+		slog.Error("failed to decode GASP initial response", "endpoint", r.EndpointURL, "topic", r.Topic, "error", err)
 		return nil, err
 	}
 	return result, nil
@@ -81,11 +89,12 @@ func (r *OverlayGASPRemote) RequestNode(ctx context.Context, graphID, outpoint *
 	if resp.StatusCode != http.StatusOK {
 		return nil, &util.HTTPError{
 			StatusCode: resp.StatusCode,
-			Err:        err,
+			Err:        ErrUnexpectedGASPResponseStatus,
 		}
 	}
 	result := &gasp.Node{}
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+	if err := jsonlimit.Decode(resp.Body, result, r.Limits.withDefaults().Node); err != nil {
+		slog.Error("failed to decode GASP node", "endpoint", r.EndpointURL, "topic", r.Topic, "error", err)
 		return nil, err
 	}
 	return result, nil
@@ -4,13 +4,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"slices"
 	"sync"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay"
-	"github.com/bsv-blockchain/go-sdk/spv"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
@@ -30,8 +30,28 @@ var (
 	ErrUnableToFindRootNodeInGraph = errors.New("unable to find root node in graph for finalization")
 	// ErrRequiredInputNodeNotFoundInTempGraph indicates that a required input node was not found in the temporary graph store
 	ErrRequiredInputNodeNotFoundInTempGraph = errors.New("required input node for unproven parent not found in temporary graph store")
+	// ErrGraphAnchorTooOld indicates that the graph anchor was mined earlier than the topic accepts
+	ErrGraphAnchorTooOld = errors.New("graph anchor is older than the maximum accepted age")
 )
 
+// GraphAnchorTooOldError describes a graph rejected because its anchor transaction exceeds
+// the topic's maximum accepted block age. It matches ErrGraphAnchorTooOld with errors.Is.
+type GraphAnchorTooOldError struct {
+	Topic         string
+	BlockHeight   uint32
+	CurrentHeight uint32
+	MaxAgeBlocks  uint32
+}
+
+// Error returns the error message.
+func (e *GraphAnchorTooOldError) Error() string {
+	return fmt.Sprintf("%s: topic %s accepts anchors up to %d blocks old, anchor mined at height %d is %d blocks old",
+		ErrGraphAnchorTooOld, e.Topic, e.MaxAgeBlocks, e.BlockHeight, e.CurrentHeight-e.BlockHeight)
+}
+
+// Unwrap returns ErrGraphAnchorTooOld.
+func (e *GraphAnchorTooOldError) Unwrap() error { return ErrGraphAnchorTooOld }
+
 // GraphNode represents a node in the GASP graph
 type GraphNode struct {
 	gasp.Node
@@ -44,11 +64,18 @@ type GraphNode struct {
 
 // OverlayGASPStorage implements GASP storage using the overlay engine
 type OverlayGASPStorage struct {
-	Topic              string
-	Engine             *Engine
-	MaxNodesInGraph    *int
+	Topic           string
+	Engine          *Engine
+	MaxNodesInGraph *int
+	// Peer identifies the remote the graphs are synced from. When Checkpoints is set, the in-flight
+	// graph state is persisted as a GASPCheckpoint for (Peer, Topic) with every Checkpoint call.
+	Peer               string
+	Checkpoints        GASPCheckpointStorage
 	tempGraphNodeRefs  sync.Map
 	tempGraphNodeCount int
+	finalizedGraphs    sync.Map
+	checkpointMu       sync.Mutex
+	onGraphFinalized   func(nodes int)
 }
 
 // NewOverlayGASPStorage creates a new OverlayGASPStorage instance
@@ -82,8 +109,8 @@ func (s *OverlayGASPStorage) FindKnownUTXOs(ctx context.Context, since float64,
 	return gaspOutputs, nil
 }
 
-// HydrateGASPNode hydrates a GASP node from storage
-func (s *OverlayGASPStorage) HydrateGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, _ bool) (*gasp.Node, error) {
+// HydrateGASPNode hydrates a GASP node from storage, including the output's provenance when metadata is requested.
+func (s *OverlayGASPStorage) HydrateGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, metadata bool) (*gasp.Node, error) {
 	output, err := s.Engine.Storage.FindOutput(ctx, outpoint, nil, nil, true)
 	if err != nil {
 		return nil, err
@@ -103,14 +130,18 @@ func (s *OverlayGASPStorage) HydrateGASPNode(ctx context.Context, graphID, outpo
 	}
 
 	node := &gasp.Node{
-		GraphID:     graphID,
-		OutputIndex: outpoint.Index,
-		RawTx:       tx.Hex(),
+		GraphID:        graphID,
+		OutputIndex:    outpoint.Index,
+		RawTx:          tx.Hex(),
+		OffChainValues: output.OffChainValues,
 	}
 	if tx.MerklePath != nil {
 		proof := tx.MerklePath.Hex()
 		node.Proof = &proof
 	}
+	if metadata {
+		node.Provenance = output.gaspProvenance()
+	}
 	return node, nil
 }
 
@@ -177,12 +208,12 @@ func (s *OverlayGASPStorage) FindNeededInputs(ctx context.Context, gaspTx *gasp.
 	if err != nil {
 		return nil, err
 	}
-	admit, err := s.Engine.Managers[s.Topic].IdentifyAdmissibleOutputs(ctx, beefBytes, previousCoins)
+	admit, err := s.Engine.identifyAdmissibleOutputs(ctx, s.Topic, beefBytes, previousCoins)
 	if err != nil {
 		return nil, err
 	}
 	if !slices.Contains(admit.OutputsToAdmit, gaspTx.OutputIndex) {
-		neededInputs, err := s.Engine.Managers[s.Topic].IdentifyNeededInputs(ctx, beefBytes)
+		neededInputs, err := s.Engine.identifyNeededInputs(ctx, s.Topic, beefBytes)
 		if err != nil {
 			return nil, err
 		}
@@ -209,6 +240,12 @@ func (s *OverlayGASPStorage) stripAlreadyKnowInputs(ctx context.Context, respons
 		if err != nil {
 			return nil, err
 		}
+		if filter := s.Engine.OutpointFilter; filter != nil {
+			s.Engine.refreshOutpointFilter(s.Topic)
+			if !filter.MayContain(s.Topic, outpoint) {
+				continue
+			}
+		}
 		found, err := s.Engine.Storage.FindOutput(ctx, outpoint, &s.Topic, nil, false)
 		if err != nil {
 			return nil, err
@@ -224,7 +261,11 @@ func (s *OverlayGASPStorage) stripAlreadyKnowInputs(ctx context.Context, respons
 }
 
 // AppendToGraph adds a GASP node to the temporary graph store for later validation and finalization.
-func (s *OverlayGASPStorage) AppendToGraph(_ context.Context, gaspTx *gasp.Node, spentBy *transaction.Outpoint) error {
+func (s *OverlayGASPStorage) AppendToGraph(ctx context.Context, gaspTx *gasp.Node, spentBy *transaction.Outpoint) error {
+	return s.appendToGraph(ctx, gaspTx, spentBy)
+}
+
+func (s *OverlayGASPStorage) appendToGraph(_ context.Context, gaspTx *gasp.Node, spentBy *transaction.Outpoint) error {
 	if s.MaxNodesInGraph != nil && s.tempGraphNodeCount >= *s.MaxNodesInGraph {
 		return ErrGraphFull
 	}
@@ -269,24 +310,36 @@ func (s *OverlayGASPStorage) AppendToGraph(_ context.Context, gaspTx *gasp.Node,
 }
 
 // ValidateGraphAnchor verifies that the graph anchor transaction is valid and results in topical admittance.
+// Invalid graphs count against the peer's reputation when the engine tracks one.
 func (s *OverlayGASPStorage) ValidateGraphAnchor(ctx context.Context, graphID *transaction.Outpoint) error {
+	err := s.validateGraphAnchor(ctx, graphID)
+	if err != nil && s.Peer != "" && s.Engine.PeerReputation != nil {
+		s.Engine.PeerReputation.RecordInvalidGraph(s.Peer)
+	}
+	return err
+}
+
+func (s *OverlayGASPStorage) validateGraphAnchor(ctx context.Context, graphID *transaction.Outpoint) error {
 	if rootNode, ok := s.tempGraphNodeRefs.Load(graphID.String()); !ok {
 		return ErrMissingInput
 	} else if beef, err := s.getBEEFForNode(rootNode.(*GraphNode)); err != nil {
 		return err
 	} else if tx, err := transaction.NewTransactionFromBEEF(beef); err != nil {
 		return err
-	} else if valid, err := spv.Verify(ctx, tx, s.Engine.ChainTracker, nil); err != nil {
+	} else if valid, err := s.Engine.verifySPV(ctx, tx); err != nil {
 		return err
 	} else if !valid {
 		return ErrGraphAnchorInvalidTx
+	} else if err := s.verifyAnchorAge(ctx, tx); err != nil {
+		return err
 	}
-	beefs, beefsErr := s.computeOrderedBEEFsForGraph(ctx, graphID)
-	if beefsErr != nil {
-		return beefsErr
+	graph, graphErr := s.computeOrderedBEEFsForGraph(ctx, graphID)
+	if graphErr != nil {
+		return graphErr
 	}
 	coins := make(map[string]struct{})
-	for _, beefBytes := range beefs {
+	for _, tagged := range graph {
+		beefBytes := tagged.Beef
 		tx, err := transaction.NewTransactionFromBEEF(beefBytes)
 		if err != nil {
 			return err
@@ -311,7 +364,7 @@ func (s *OverlayGASPStorage) ValidateGraphAnchor(ctx context.Context, graphID *t
 				}
 			}
 		}
-		admit, admitErr := s.Engine.Managers[s.Topic].IdentifyAdmissibleOutputs(ctx, beefBytes, previousCoins)
+		admit, admitErr := s.Engine.identifyAdmissibleOutputs(ctx, s.Topic, beefBytes, previousCoins)
 		if admitErr != nil {
 			return admitErr
 		}
@@ -329,6 +382,29 @@ func (s *OverlayGASPStorage) ValidateGraphAnchor(ctx context.Context, graphID *t
 	return nil
 }
 
+// verifyAnchorAge rejects anchors mined longer ago than the topic's MaxAnchorAgeBlocks.
+// Unmined anchors are always recent enough.
+func (s *OverlayGASPStorage) verifyAnchorAge(ctx context.Context, anchor *transaction.Transaction) error {
+	config, _ := s.Engine.syncConfiguration(s.Topic)
+	maxAge := config.MaxAnchorAgeBlocks
+	if maxAge == 0 || anchor.MerklePath == nil {
+		return nil
+	}
+	currentHeight, err := s.Engine.ChainTracker.CurrentHeight(ctx)
+	if err != nil {
+		return err
+	}
+	if currentHeight > anchor.MerklePath.BlockHeight && currentHeight-anchor.MerklePath.BlockHeight > maxAge {
+		return &GraphAnchorTooOldError{
+			Topic:         s.Topic,
+			BlockHeight:   anchor.MerklePath.BlockHeight,
+			CurrentHeight: currentHeight,
+			MaxAgeBlocks:  maxAge,
+		}
+	}
+	return nil
+}
+
 // DiscardGraph removes all nodes associated with the specified graph from the temporary storage.
 func (s *OverlayGASPStorage) DiscardGraph(_ context.Context, graphID *transaction.Outpoint) error {
 	// First, find all nodes that belong to this graph
@@ -362,39 +438,56 @@ func (s *OverlayGASPStorage) DiscardGraph(_ context.Context, graphID *transactio
 }
 
 // FinalizeGraph submits all transactions in the graph to the overlay engine for processing.
+// When the engine storage implements BatchStorage, the outputs and applied transactions of the graph
+// are staged in memory while the transactions are submitted and written in batches once all are processed.
 func (s *OverlayGASPStorage) FinalizeGraph(ctx context.Context, graphID *transaction.Outpoint) error {
-	beefs, err := s.computeOrderedBEEFsForGraph(ctx, graphID)
+	graph, err := s.computeOrderedBEEFsForGraph(ctx, graphID)
 	if err != nil {
 		return err
 	}
-	for _, beef := range beefs {
-		if _, err := s.Engine.Submit(
-			ctx,
-			overlay.TaggedBEEF{
-				Topics: []string{s.Topic},
-				Beef:   beef,
-			},
-			SubmitModeHistorical,
-			nil,
-		); err != nil {
+	source := s.Peer
+	if source == "" {
+		source = OutputSourceGASP
+	}
+	submitCtx := WithOutputSource(ctx, source)
+	batch, batching := storageCapability[BatchStorage](s.Engine.Storage)
+	var staged *stagedStorage
+	if batching {
+		staged = newStagedStorage(s.Engine)
+		submitCtx = withStagedStorage(submitCtx, staged)
+	}
+	for _, tagged := range graph {
+		if _, err := s.Engine.Submit(submitCtx, tagged, SubmitModeHistorical, nil); err != nil {
 			return err
 		}
 	}
+	if staged != nil {
+		if err := staged.flush(ctx, batch); err != nil {
+			return err
+		}
+	}
+	s.finalizedGraphs.Store(graphID.String(), struct{}{})
+	if s.onGraphFinalized != nil {
+		s.onGraphFinalized(len(graph))
+	}
 	return nil
 }
 
-func (s *OverlayGASPStorage) computeOrderedBEEFsForGraph(_ context.Context, graphID *transaction.Outpoint) ([][]byte, error) {
-	beefs := make([][]byte, 0)
+// computeOrderedBEEFsForGraph returns the transactions of the graph tagged with the topic, ancestors first,
+// carrying the off-chain values the peer served with their nodes.
+func (s *OverlayGASPStorage) computeOrderedBEEFsForGraph(_ context.Context, graphID *transaction.Outpoint) ([]overlay.TaggedBEEF, error) {
+	beefs := make([]overlay.TaggedBEEF, 0)
 	var hydrator func(node *GraphNode) error
 	hydrator = func(node *GraphNode) error {
 		currentBeef, err := s.getBEEFForNode(node)
 		if err != nil {
 			return err
 		}
-		if slices.IndexFunc(beefs, func(beef []byte) bool {
-			return bytes.Equal(beef, currentBeef)
+		if slices.IndexFunc(beefs, func(tagged overlay.TaggedBEEF) bool {
+			return bytes.Equal(tagged.Beef, currentBeef)
 		}) == -1 {
-			beefs = append([][]byte{currentBeef}, beefs...)
+			tagged := overlay.TaggedBEEF{Topics: []string{s.Topic}, Beef: currentBeef, OffChainValues: node.OffChainValues}
+			beefs = append([]overlay.TaggedBEEF{tagged}, beefs...)
 		}
 		for _, child := range node.Children {
 			if err := hydrator(child); err != nil {
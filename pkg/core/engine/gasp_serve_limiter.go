@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+)
+
+// DefaultGASPServeRetryAfter is advertised to GASP requests rejected while their peer has too many in progress.
+const DefaultGASPServeRetryAfter = time.Second
+
+var (
+	// ErrGASPPeerBusy is returned, wrapped in a GASPPeerBusyError, when a peer already has as many GASP requests
+	// in progress as GASPServeLimits.MaxConcurrentPerPeer allows.
+	ErrGASPPeerBusy = errors.New("peer has too many GASP requests in progress")
+
+	// ErrGASPResponseBudgetExceeded is returned when serving a GASP node would exceed GASPServeLimits.MaxNodeBytes
+	// or GASPServeLimits.MaxNodeLookups.
+	ErrGASPResponseBudgetExceeded = errors.New("GASP response exceeds the budget")
+)
+
+// GASPPeerBusyError is returned by ProvideForeignSyncResponse and ProvideForeignGASPNode when the GASPServeLimiter
+// rejects a request of a peer that already has too many in progress.
+type GASPPeerBusyError struct {
+	// Peer identifies the rejected peer, as set with WithGASPPeer.
+	Peer string
+	// RetryAfter is how long the peer should wait before retrying.
+	RetryAfter time.Duration
+}
+
+func (e *GASPPeerBusyError) Error() string {
+	return fmt.Sprintf("%s, peer %q, retry after %s", ErrGASPPeerBusy, e.Peer, e.RetryAfter)
+}
+
+// Unwrap returns ErrGASPPeerBusy so that callers can match the error with errors.Is.
+func (e *GASPPeerBusyError) Unwrap() error { return ErrGASPPeerBusy }
+
+// GASPServeLimits bounds the GASP requests the engine serves to remote peers. Zero limits are disabled.
+type GASPServeLimits struct {
+	// MaxConcurrentPerPeer is how many GASP requests of a single peer are served at once.
+	// Further requests are rejected with a GASPPeerBusyError.
+	MaxConcurrentPerPeer int `mapstructure:"max_concurrent_per_peer"`
+
+	// MaxUTXOsPerResponse caps the UTXOs of an initial response. Responses truncated to the cap are marked
+	// partial, and the peer requests the following pages from the score of the last UTXO it received.
+	MaxUTXOsPerResponse uint32 `mapstructure:"max_utxos_per_response"`
+
+	// MaxNodeBytes caps the size of the raw transaction, proof and ancillary BEEF of a served node.
+	MaxNodeBytes int `mapstructure:"max_node_bytes"`
+
+	// MaxNodeLookups caps the storage lookups made to hydrate a served node whose output holds no transaction.
+	MaxNodeLookups int `mapstructure:"max_node_lookups"`
+
+	// RetryAfter is advertised to rejected requests. Zero falls back to DefaultGASPServeRetryAfter.
+	RetryAfter time.Duration `mapstructure:"retry_after"`
+}
+
+// GASPServeMetrics describes the GASP requests served since the engine started.
+type GASPServeMetrics struct {
+	InFlight   map[string]int `json:"inFlight"`   // requests in progress, keyed by peer
+	Served     uint64         `json:"served"`     // requests that received a slot
+	Rejected   uint64         `json:"rejected"`   // requests rejected while their peer was busy
+	Truncated  uint64         `json:"truncated"`  // initial responses served partially
+	OverBudget uint64         `json:"overBudget"` // nodes rejected for exceeding the response budget
+}
+
+// GASPServeLimiter enforces GASPServeLimits on the GASP requests served to remote peers, so that a syncing
+// peer requesting deep graphs cannot exhaust the node. Peers are identified by WithGASPPeer; requests of
+// unidentified peers share a single budget. It is safe for concurrent use and implements expvar.Var,
+// so its metrics can be published with expvar.Publish.
+type GASPServeLimiter struct {
+	limits GASPServeLimits
+
+	mu      sync.Mutex
+	metrics GASPServeMetrics
+}
+
+// NewGASPServeLimiter creates a GASPServeLimiter enforcing the given limits.
+func NewGASPServeLimiter(limits GASPServeLimits) *GASPServeLimiter {
+	return &GASPServeLimiter{limits: limits, metrics: GASPServeMetrics{InFlight: make(map[string]int)}}
+}
+
+// acquire takes a slot of the peer, returning the function releasing it, or a GASPPeerBusyError when
+// the peer has no free slot. A nil limiter admits every request.
+func (l *GASPServeLimiter) acquire(peer string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limits.MaxConcurrentPerPeer > 0 && l.metrics.InFlight[peer] >= l.limits.MaxConcurrentPerPeer {
+		l.metrics.Rejected++
+		retryAfter := l.limits.RetryAfter
+		if retryAfter <= 0 {
+			retryAfter = DefaultGASPServeRetryAfter
+		}
+		return nil, &GASPPeerBusyError{Peer: peer, RetryAfter: retryAfter}
+	}
+	l.metrics.InFlight[peer]++
+	l.metrics.Served++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.metrics.InFlight[peer]--; l.metrics.InFlight[peer] <= 0 {
+			delete(l.metrics.InFlight, peer)
+		}
+	}, nil
+}
+
+// utxoLimit returns the number of UTXOs to serve for the requested limit, zero meaning no limit,
+// and whether the limiter capped it.
+func (l *GASPServeLimiter) utxoLimit(requested uint32) (uint32, bool) {
+	if l == nil || l.limits.MaxUTXOsPerResponse == 0 || (requested > 0 && requested <= l.limits.MaxUTXOsPerResponse) {
+		return requested, false
+	}
+	return l.limits.MaxUTXOsPerResponse, true
+}
+
+// maxNodeLookups returns the storage lookups allowed to hydrate a node, zero meaning no limit.
+func (l *GASPServeLimiter) maxNodeLookups() int {
+	if l == nil {
+		return 0
+	}
+	return l.limits.MaxNodeLookups
+}
+
+// checkNode returns ErrGASPResponseBudgetExceeded when the node exceeds MaxNodeBytes.
+func (l *GASPServeLimiter) checkNode(node *gasp.Node) error {
+	if l == nil || l.limits.MaxNodeBytes <= 0 {
+		return nil
+	}
+	size := len(node.RawTx)/2 + len(node.AncillaryBeef) + len(node.OffChainValues)
+	if node.Proof != nil {
+		size += len(*node.Proof) / 2
+	}
+	if size > l.limits.MaxNodeBytes {
+		l.countOverBudget()
+		return fmt.Errorf("%w: node of %d bytes exceeds %d", ErrGASPResponseBudgetExceeded, size, l.limits.MaxNodeBytes)
+	}
+	return nil
+}
+
+func (l *GASPServeLimiter) countTruncated() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.metrics.Truncated++
+}
+
+func (l *GASPServeLimiter) countOverBudget() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.metrics.OverBudget++
+}
+
+// Metrics returns a snapshot of the GASP requests served since the engine started.
+func (l *GASPServeLimiter) Metrics() GASPServeMetrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	metrics := l.metrics
+	metrics.InFlight = make(map[string]int, len(l.metrics.InFlight))
+	for peer, n := range l.metrics.InFlight {
+		metrics.InFlight[peer] = n
+	}
+	return metrics
+}
+
+// String returns the JSON encoded metrics, implementing expvar.Var.
+func (l *GASPServeLimiter) String() string {
+	bb, err := json.Marshal(l.Metrics())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+type gaspPeerKey struct{}
+
+// WithGASPPeer returns a context identifying the remote peer whose GASP request is served,
+// e.g. by its BRC-31 identity key or IP address, so that GASPServeLimiter can bound its requests.
+func WithGASPPeer(ctx context.Context, peer string) context.Context {
+	return context.WithValue(ctx, gaspPeerKey{}, peer)
+}
+
+// gaspPeer returns the peer set with WithGASPPeer, or an empty string for unidentified peers.
+func gaspPeer(ctx context.Context) string {
+	peer, _ := ctx.Value(gaspPeerKey{}).(string)
+	return peer
+}
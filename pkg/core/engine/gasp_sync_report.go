@@ -0,0 +1,75 @@
+package engine
+
+import "sync"
+
+// GASPSyncReport aggregates the outcome of a GASP sync of every topic with its peers.
+type GASPSyncReport struct {
+	Topics []TopicSyncReport
+}
+
+// FailedPeers returns the number of topic peers whose sync failed.
+func (r *GASPSyncReport) FailedPeers() int {
+	failed := 0
+	for _, topic := range r.Topics {
+		failed += len(topic.Failed)
+	}
+	return failed
+}
+
+// TopicSyncReport reports which peers a topic was synced with and which failed.
+type TopicSyncReport struct {
+	Topic     string
+	Succeeded []string
+	Failed    []PeerSyncFailure
+	// IngestedGraphs is the number of graphs ingested from the peers of the topic,
+	// each requested from a single peer.
+	IngestedGraphs int
+}
+
+// FailedPeers returns the peers whose sync failed.
+func (r TopicSyncReport) FailedPeers() []string {
+	peers := make([]string, 0, len(r.Failed))
+	for _, failure := range r.Failed {
+		peers = append(peers, failure.Peer)
+	}
+	return peers
+}
+
+// PeerSyncFailure is the error a GASP sync with a peer failed with.
+type PeerSyncFailure struct {
+	Peer string
+	Err  error
+}
+
+// topicSync is a topic to sync with its peers, from the since score when set.
+type topicSync struct {
+	topic  string
+	config SyncConfiguration
+	peers  []string
+	since  *float64
+}
+
+// forEachConcurrently calls fn for every index below n, running up to workers calls at a time,
+// and returns once all of them have returned. Zero or one worker calls fn sequentially.
+func forEachConcurrently(workers, n int, fn func(i int)) {
+	if workers <= 1 {
+		for i := range n {
+			fn(i)
+		}
+		return
+	}
+	limiter := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func() {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
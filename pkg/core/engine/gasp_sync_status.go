@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GASPPeerSyncStatus reports the GASP sync of a topic with a peer.
+type GASPPeerSyncStatus struct {
+	Topic string
+	Peer  string
+	// Running reports whether a sync with the peer is in progress.
+	Running bool
+	// LastInteraction is the score up to which the topic is synced with the peer.
+	LastInteraction float64
+	// PagesCompleted and NodesIngested count the pages of the initial response processed and the
+	// transactions of the graphs ingested by the running or last sync.
+	PagesCompleted int
+	NodesIngested  int
+	// Errors counts the failed syncs with the peer since the engine started, LastError holding the error of the last one.
+	Errors    int
+	LastError string
+	StartedAt time.Time // zero when no sync ran since the engine started
+	EndedAt   time.Time // zero while running or when no sync ran
+	// Resumable reports a GASP checkpoint holding CheckpointNodes in-flight nodes of an interrupted sync.
+	Resumable       bool
+	CheckpointNodes int
+}
+
+type gaspSyncKey struct {
+	topic string
+	peer  string
+}
+
+// GASPSyncProgress tracks the GASP syncs the engine runs with every topic peer, reported by Engine.GASPSyncStatus.
+// NewEngine creates one when none is set; without one only the progress persisted in storage is reported.
+type GASPSyncProgress struct {
+	mu    sync.Mutex
+	peers map[gaspSyncKey]*GASPPeerSyncStatus
+}
+
+// NewGASPSyncProgress creates an empty GASPSyncProgress.
+func NewGASPSyncProgress() *GASPSyncProgress {
+	return &GASPSyncProgress{peers: make(map[gaspSyncKey]*GASPPeerSyncStatus)}
+}
+
+// update applies fn to the status of the topic peer, when p is not nil.
+func (p *GASPSyncProgress) update(topic, peer string, fn func(status *GASPPeerSyncStatus)) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := gaspSyncKey{topic: topic, peer: peer}
+	status, ok := p.peers[key]
+	if !ok {
+		status = &GASPPeerSyncStatus{Topic: topic, Peer: peer}
+		p.peers[key] = status
+	}
+	fn(status)
+}
+
+func (p *GASPSyncProgress) start(topic, peer string) {
+	p.update(topic, peer, func(status *GASPPeerSyncStatus) {
+		status.Running = true
+		status.PagesCompleted = 0
+		status.NodesIngested = 0
+		status.StartedAt = time.Now()
+		status.EndedAt = time.Time{}
+	})
+}
+
+func (p *GASPSyncProgress) interacted(topic, peer string, score float64) {
+	p.update(topic, peer, func(status *GASPPeerSyncStatus) {
+		status.LastInteraction = max(status.LastInteraction, score)
+	})
+}
+
+func (p *GASPSyncProgress) pageSynced(topic, peer string, score float64) {
+	p.update(topic, peer, func(status *GASPPeerSyncStatus) {
+		status.PagesCompleted++
+		status.LastInteraction = max(status.LastInteraction, score)
+	})
+}
+
+func (p *GASPSyncProgress) nodesIngested(topic, peer string, nodes int) {
+	p.update(topic, peer, func(status *GASPPeerSyncStatus) {
+		status.NodesIngested += nodes
+	})
+}
+
+func (p *GASPSyncProgress) finish(topic, peer string, err error) {
+	p.update(topic, peer, func(status *GASPPeerSyncStatus) {
+		status.Running = false
+		status.EndedAt = time.Now()
+		if err != nil {
+			status.Errors++
+			status.LastError = err.Error()
+		}
+	})
+}
+
+// snapshot returns a copy of the tracked statuses keyed by topic peer.
+func (p *GASPSyncProgress) snapshot() map[gaspSyncKey]*GASPPeerSyncStatus {
+	statuses := make(map[gaspSyncKey]*GASPPeerSyncStatus)
+	if p == nil {
+		return statuses
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, status := range p.peers {
+		statusCopy := *status
+		statuses[key] = &statusCopy
+	}
+	return statuses
+}
+
+// GASPSyncStatus reports the GASP sync of every topic peer, sorted by topic and peer. The syncs run since the engine
+// started are reported from SyncProgress, completed by the last interactions of TopicStatsStorage and the
+// checkpoints of GASPCheckpointStorage when the storage implements them, so that the progress of syncs run
+// before a restart and the interrupted syncs that will resume are reported too.
+func (e *Engine) GASPSyncStatus(ctx context.Context) ([]*GASPPeerSyncStatus, error) {
+	statuses := e.SyncProgress.snapshot()
+	status := func(topic, peer string) *GASPPeerSyncStatus {
+		key := gaspSyncKey{topic: topic, peer: peer}
+		if _, ok := statuses[key]; !ok {
+			statuses[key] = &GASPPeerSyncStatus{Topic: topic, Peer: peer}
+		}
+		return statuses[key]
+	}
+
+	if storage, ok := storageCapability[TopicStatsStorage](e.Storage); ok {
+		for topic, config := range e.syncConfigurations() {
+			if config.Type == SyncConfigurationNone {
+				continue
+			}
+			interactions, err := storage.FindLastInteractions(ctx, topic)
+			if err != nil {
+				slog.Error("failed to find last interactions", "topic", topic, "error", err)
+				return nil, err
+			}
+			for peer, score := range interactions {
+				peerStatus := status(topic, peer)
+				peerStatus.LastInteraction = max(peerStatus.LastInteraction, score)
+			}
+		}
+	}
+	if checkpoints, ok := storageCapability[GASPCheckpointStorage](e.Storage); ok {
+		found, err := checkpoints.FindGASPCheckpoints(ctx)
+		if err != nil {
+			slog.Error("failed to find GASP checkpoints", "error", err)
+			return nil, err
+		}
+		for _, checkpoint := range found {
+			peerStatus := status(checkpoint.Topic, checkpoint.Peer)
+			peerStatus.Resumable = true
+			peerStatus.CheckpointNodes = len(checkpoint.Nodes)
+			peerStatus.LastInteraction = max(peerStatus.LastInteraction, checkpoint.LastInteraction)
+		}
+	}
+
+	result := make([]*GASPPeerSyncStatus, 0, len(statuses))
+	for _, peerStatus := range statuses {
+		result = append(result, peerStatus)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Topic != result[j].Topic {
+			return result[i].Topic < result[j].Topic
+		}
+		return result[i].Peer < result[j].Peer
+	})
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// historyRetentionDepth returns the HistoryRetentionDepth of the retention policy of the topic,
+// zero when the topic has none.
+func (e *Engine) historyRetentionDepth(topic string) int {
+	if e.Retention == nil {
+		return 0
+	}
+	return max(e.Retention.Topics[topic].HistoryRetentionDepth, 0)
+}
+
+// trimHistory purges the ancestors of the retained outputs lying more than depth spends away from the
+// transaction retaining them. The retained outputs are one spend away. An ancestor is released by the
+// outputs at the retention depth, and deleted with deleteUTXODeep once no other output consumes it,
+// so ancestors still within the depth of another output of the topic are kept.
+func (e *Engine) trimHistory(ctx context.Context, topic string, retained []*Output, depth int) error {
+	kept := make(map[string]struct{}, len(retained))
+	for _, output := range retained {
+		kept[output.Outpoint.String()] = struct{}{}
+	}
+	level := retained
+	for range depth - 1 {
+		var next []*Output
+		for _, output := range level {
+			for _, outpoint := range output.OutputsConsumed {
+				if _, ok := kept[outpoint.String()]; ok {
+					continue
+				}
+				kept[outpoint.String()] = struct{}{}
+				ancestor, err := e.storage(ctx).FindOutput(ctx, outpoint, &topic, nil, false)
+				if err != nil {
+					logger(ctx).Error("failed to find ancestor output in trimHistory", "outpoint", outpoint.String(), "topic", topic, "error", err)
+					return err
+				} else if ancestor != nil {
+					next = append(next, ancestor)
+				}
+			}
+		}
+		level = next
+	}
+
+	for _, output := range level {
+		for _, outpoint := range output.OutputsConsumed {
+			if _, ok := kept[outpoint.String()]; ok {
+				continue
+			}
+			if err := e.releaseAncestor(ctx, output, outpoint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// releaseAncestor removes output from the consumers of the ancestor at outpoint, deleting the ancestor
+// when no other output consumes it.
+func (e *Engine) releaseAncestor(ctx context.Context, output *Output, outpoint *transaction.Outpoint) error {
+	ancestor, err := e.storage(ctx).FindOutput(ctx, outpoint, &output.Topic, nil, false)
+	if err != nil {
+		logger(ctx).Error("failed to find ancestor output in trimHistory", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
+		return err
+	} else if ancestor == nil {
+		return nil
+	}
+
+	consumedBy := slices.DeleteFunc(slices.Clone(ancestor.ConsumedBy), func(consumer *transaction.Outpoint) bool {
+		return bytes.Equal(consumer.TxBytes(), output.Outpoint.TxBytes())
+	})
+	if len(consumedBy) < len(ancestor.ConsumedBy) {
+		ancestor.ConsumedBy = consumedBy
+		if err := e.trackWrite(e.storage(ctx).UpdateConsumedBy(ctx, &ancestor.Outpoint, ancestor.Topic, ancestor.ConsumedBy)); err != nil {
+			logger(ctx).Error("failed to update consumed by in trimHistory", "outpoint", ancestor.Outpoint.String(), "topic", ancestor.Topic, "error", err)
+			return err
+		}
+	}
+	if len(ancestor.ConsumedBy) > 0 {
+		return nil
+	}
+	logger(ctx).Debug("purging output beyond history retention depth", "outpoint", ancestor.Outpoint.String(), "topic", ancestor.Topic)
+	return e.deleteUTXODeep(ctx, ancestor)
+}
@@ -0,0 +1,282 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultDrainTimeout is how long Stop waits for in-flight operations before interrupting GASP syncs.
+const DefaultDrainTimeout = 30 * time.Second
+
+var (
+	// ErrEngineStopping is returned by operations started after Stop was called.
+	ErrEngineStopping = errors.New("engine is stopping")
+
+	// ErrDrainIncomplete is returned by Stop when in-flight operations did not finish before its context was done.
+	ErrDrainIncomplete = errors.New("engine stopped before in-flight operations drained")
+)
+
+// operationKind distinguishes operations that may be interrupted on shutdown from those that must run to completion.
+type operationKind int
+
+const (
+	// operationSubmit is a write that is always allowed to finish, since interrupting it could leave partial state.
+	operationSubmit operationKind = iota
+
+	// operationGASPSync is a long running sync that is interrupted once the drain timeout elapses.
+	// Interrupted syncs persist their progress and are resumed by ResumeGASPSync.
+	operationGASPSync
+)
+
+// inFlightKey marks contexts of tracked operations so that nested operations, e.g. the submits
+// of a GASP sync, are not tracked or rejected a second time.
+type inFlightKey struct{}
+
+// Lifecycle tracks the in-flight operations of an Engine so that it can be stopped gracefully.
+// Once stopping, new operations are rejected with ErrEngineStopping while running ones drain.
+type Lifecycle struct {
+	// DrainTimeout bounds how long Stop waits before interrupting GASP syncs. Zero uses DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
+	mu       sync.Mutex
+	stopping bool
+	inFlight int
+	nextID   int
+	syncs    map[int]context.CancelFunc
+	drained  chan struct{}
+}
+
+// NewLifecycle creates a Lifecycle that drains in-flight operations for up to drainTimeout on Stop.
+func NewLifecycle(drainTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{DrainTimeout: drainTimeout}
+}
+
+// InFlight returns the number of operations currently running.
+func (l *Lifecycle) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inFlight
+}
+
+// Stopping reports whether the engine has stopped accepting new operations.
+func (l *Lifecycle) Stopping() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stopping
+}
+
+func (l *Lifecycle) start() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopping = false
+	l.drained = nil
+}
+
+func (l *Lifecycle) begin(ctx context.Context, kind operationKind) (context.Context, func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stopping {
+		return nil, nil, ErrEngineStopping
+	}
+
+	l.inFlight++
+	ctx = context.WithValue(ctx, inFlightKey{}, struct{}{})
+	id := l.nextID
+	l.nextID++
+
+	cancel := context.CancelFunc(func() {})
+	if kind == operationGASPSync {
+		ctx, cancel = context.WithCancel(ctx)
+		if l.syncs == nil {
+			l.syncs = make(map[int]context.CancelFunc)
+		}
+		l.syncs[id] = cancel
+	}
+
+	return ctx, func() {
+		cancel()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.inFlight--
+		delete(l.syncs, id)
+		l.signalDrained()
+	}, nil
+}
+
+// beginStop stops accepting operations and returns a channel closed once all in-flight operations finished.
+func (l *Lifecycle) beginStop() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopping = true
+	if l.drained == nil {
+		l.drained = make(chan struct{})
+		l.signalDrained()
+	}
+	return l.drained
+}
+
+// signalDrained closes the drained channel once stopping with nothing in flight. The caller must hold mu.
+func (l *Lifecycle) signalDrained() {
+	if !l.stopping || l.inFlight > 0 || l.drained == nil {
+		return
+	}
+	select {
+	case <-l.drained:
+	default:
+		close(l.drained)
+	}
+}
+
+func (l *Lifecycle) interruptSyncs() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, cancel := range l.syncs {
+		cancel()
+	}
+	return len(l.syncs)
+}
+
+func (l *Lifecycle) drainTimeout() time.Duration {
+	if l.DrainTimeout > 0 {
+		return l.DrainTimeout
+	}
+	return DefaultDrainTimeout
+}
+
+// Start makes the engine accept submits and GASP syncs, creating a Lifecycle with DefaultDrainTimeout
+// when none is configured. It must be called before the engine serves requests. When ctx is done,
+// the engine is stopped as if Stop had been called with a background context.
+// When a retention is configured, Start also runs the background pruner until ctx is done or the engine stops,
+// and when the engine is a standby it follows the primary until ctx is done or the standby is promoted.
+// When tombstoning is enabled, tombstones older than their retention are purged in the background.
+// When an unmined eviction is configured, stale unmined transactions are re-broadcast or evicted in the background.
+// When a spend reconciler is configured, outputs spent outside the overlay are reconciled in the background.
+// When a broadcast retry is configured, failed broadcasts are retried in the background until ctx is done or the engine stops.
+// When the broadcaster is an ARCPool, its health checks and callback token rotation run until ctx is done.
+// When a periodic sync is configured, every topic is synced with GASP at its interval until ctx is done or the engine stops.
+// When the storage implements TopicSyncPauseStorage, the topic sync pauses it persisted are restored first,
+// and so are the tracker sets it persisted when it implements TrackerStorage.
+func (e *Engine) Start(ctx context.Context) error {
+	if err := e.restorePausedTopicSyncs(ctx); err != nil {
+		return err
+	}
+	if err := e.restoreTrackers(ctx); err != nil {
+		return err
+	}
+	if e.Lifecycle == nil {
+		e.Lifecycle = NewLifecycle(DefaultDrainTimeout)
+	}
+	e.Lifecycle.start()
+	slog.Info("engine started")
+
+	if e.Retention != nil {
+		go e.RunPruner(ctx)
+	}
+	if e.tombstonesEnabled() {
+		go e.RunTombstonePurger(ctx)
+	}
+	if e.ProofFetcher != nil {
+		go e.RunProofFetcher(ctx)
+	}
+	if e.UnminedEviction != nil {
+		go e.RunUnminedEvictor(ctx)
+	}
+	if e.MerkleStates != nil {
+		go e.RunMerkleStateSync(ctx)
+	}
+	if e.BEEFCompactor != nil {
+		go e.RunBEEFCompactor(ctx)
+	}
+	if e.SpendReconciler != nil {
+		go e.RunSpendReconciler(ctx)
+	}
+	if e.BroadcastRetry != nil {
+		go e.RunBroadcastRetrier(ctx)
+	}
+	if e.LookupNotifications != nil {
+		go e.RunLookupRetrier(ctx)
+	}
+	if pool, ok := e.Broadcaster.(*ARCPool); ok {
+		go pool.Run(ctx)
+	}
+	if e.PeriodicSync != nil {
+		go e.RunPeriodicSync(ctx)
+	}
+	if e.Standby != nil {
+		go func() {
+			if err := e.Standby.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("standby stopped following the primary", "error", err)
+			}
+		}()
+	}
+
+	if ctx.Done() != nil {
+		go func() {
+			<-ctx.Done()
+			if err := e.Stop(context.Background()); err != nil {
+				slog.Error("failed to stop engine", "error", err)
+			}
+		}()
+	}
+	return nil
+}
+
+// Stop rejects new submits and GASP syncs with ErrEngineStopping and waits for in-flight operations to finish.
+// GASP syncs still running after the drain timeout are interrupted; they checkpoint their progress and can be
+// resumed with ResumeGASPSync after a restart. Submits are always allowed to complete. Stop returns
+// ErrDrainIncomplete if ctx is done before every operation finished.
+func (e *Engine) Stop(ctx context.Context) error {
+	if e.Lifecycle == nil {
+		return nil
+	}
+
+	drained := e.Lifecycle.beginStop()
+	slog.Info("engine stopping", "inFlight", e.Lifecycle.InFlight(), "drainTimeout", e.Lifecycle.drainTimeout())
+
+	timer := time.NewTimer(e.Lifecycle.drainTimeout())
+	defer timer.Stop()
+
+	select {
+	case <-drained:
+		slog.Info("engine stopped")
+		return nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	if interrupted := e.Lifecycle.interruptSyncs(); interrupted > 0 {
+		slog.Warn("interrupting GASP syncs still running after drain timeout", "syncs", interrupted)
+	}
+
+	select {
+	case <-drained:
+		slog.Info("engine stopped")
+		return nil
+	case <-ctx.Done():
+		inFlight := e.Lifecycle.InFlight()
+		slog.Error("engine stopped before in-flight operations drained", "inFlight", inFlight, "error", ErrDrainIncomplete)
+		return fmt.Errorf("%w: %d operations in flight", ErrDrainIncomplete, inFlight)
+	}
+}
+
+// beginOperation registers an operation with the Lifecycle, rejecting it with ErrEngineStopping while stopping.
+// The returned context must be used by the operation and the returned func called once it finishes.
+// Operations nested in an already tracked one, and engines without a Lifecycle, are not tracked.
+func (e *Engine) beginOperation(ctx context.Context, kind operationKind) (context.Context, func(), error) {
+	if e.Lifecycle == nil || ctx.Value(inFlightKey{}) != nil {
+		return ctx, func() {}, nil
+	}
+	return e.Lifecycle.begin(ctx, kind)
+}
+
+// interruptedByStop reports whether ctx was canceled because the engine is stopping.
+func (e *Engine) interruptedByStop(ctx context.Context) bool {
+	return e.Lifecycle != nil && ctx.Err() != nil && e.Lifecycle.Stopping()
+}
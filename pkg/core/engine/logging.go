@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the request an engine operation serves, such as
+// the X-Request-ID of an HTTP request. The log records of Submit and Lookup run with the context carry
+// it as the request_id attribute, so that they can be correlated with the logs of the server.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string if it carries none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// logger returns the logger of an operation run with ctx: the default logger, with the request_id
+// attribute when ctx carries a request ID.
+func logger(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
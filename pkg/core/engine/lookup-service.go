@@ -12,11 +12,13 @@ import (
 
 // OutputAdmittedByTopic contains information about an output that has been admitted by a topic manager.
 type OutputAdmittedByTopic struct {
-	Topic         string
-	Outpoint      *transaction.Outpoint
-	Satoshis      uint64
-	LockingScript *script.Script
-	AtomicBEEF    []byte
+	Topic          string
+	Outpoint       *transaction.Outpoint
+	Satoshis       uint64
+	LockingScript  *script.Script
+	AtomicBEEF     []byte
+	Metadata       map[string]string // tags set by the topic manager, see OutputMetadataIdentifier
+	OffChainValues []byte            // off-chain values submitted with the transaction, if any
 }
 
 // OutputSpent contains information about an output that has been spent.
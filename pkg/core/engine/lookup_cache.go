@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+)
+
+// DefaultLookupCacheTTL is the time-to-live applied to cached lookup answers when none is configured.
+const DefaultLookupCacheTTL = 5 * time.Minute
+
+// LookupCacheConfig configures a LookupCache.
+type LookupCacheConfig struct {
+	// TTL is the time-to-live of cached answers. Zero falls back to DefaultLookupCacheTTL.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// MaxEntries caps the cached answers. Zero means unbounded.
+	MaxEntries int `mapstructure:"max_entries"`
+
+	// ServiceTTLs overrides TTL for specific lookup services, keyed by service name.
+	ServiceTTLs map[string]time.Duration `mapstructure:"service_ttls"`
+}
+
+type lookupCacheEntry struct {
+	service   string
+	answer    *lookup.LookupAnswer
+	expiresAt time.Time
+}
+
+// LookupCache is an in-memory TTL cache of lookup answers keyed by lookup service and query hash.
+// Set as the Engine's LookupCache, it enables read-through proxy mode: questions for lookup services
+// not hosted locally are resolved against SLAP-discovered hosts and the answers are cached.
+// Set as the Engine's LocalLookupCache, it caches the answers of locally hosted lookup services,
+// which are invalidated whenever the service is notified of an admitted, spent or evicted output.
+type LookupCache struct {
+	TTL         time.Duration
+	MaxEntries  int
+	ServiceTTLs map[string]time.Duration // overrides TTL per lookup service
+
+	mu      sync.Mutex
+	entries map[string]*lookupCacheEntry
+	now     func() time.Time
+}
+
+// NewLookupCache creates a LookupCache with the given TTL and entry limit.
+// A non-positive TTL falls back to DefaultLookupCacheTTL; a non-positive limit means unbounded.
+func NewLookupCache(ttl time.Duration, maxEntries int) *LookupCache {
+	if ttl <= 0 {
+		ttl = DefaultLookupCacheTTL
+	}
+	return &LookupCache{
+		TTL:        ttl,
+		MaxEntries: maxEntries,
+		entries:    make(map[string]*lookupCacheEntry),
+		now:        time.Now,
+	}
+}
+
+// NewLookupCacheWithConfig creates a LookupCache with the given configuration.
+func NewLookupCacheWithConfig(cfg LookupCacheConfig) *LookupCache {
+	cache := NewLookupCache(cfg.TTL, cfg.MaxEntries)
+	cache.ServiceTTLs = cfg.ServiceTTLs
+	return cache
+}
+
+// Get returns the cached answer for the question, if present and not expired.
+func (c *LookupCache) Get(question *lookup.LookupQuestion) (*lookup.LookupAnswer, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := lookupCacheKey(question)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.answer, true
+}
+
+// Set stores the answer for the question, evicting expired entries or, if still full, the entry closest to expiry.
+func (c *LookupCache) Set(question *lookup.LookupQuestion, answer *lookup.LookupAnswer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]*lookupCacheEntry)
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+
+	now := c.now()
+	key := lookupCacheKey(question)
+	if _, exists := c.entries[key]; !exists && c.MaxEntries > 0 && len(c.entries) >= c.MaxEntries {
+		c.evict(now)
+	}
+	c.entries[key] = &lookupCacheEntry{service: question.Service, answer: answer, expiresAt: now.Add(c.ttl(question.Service))}
+}
+
+// Invalidate drops every cached answer of the lookup service.
+func (c *LookupCache) Invalidate(service string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.service == service {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *LookupCache) ttl(service string) time.Duration {
+	if ttl, ok := c.ServiceTTLs[service]; ok && ttl > 0 {
+		return ttl
+	}
+	return c.TTL
+}
+
+// Len returns the number of entries currently held, including expired ones not yet evicted.
+func (c *LookupCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *LookupCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, entry.expiresAt
+		}
+	}
+	if len(c.entries) >= c.MaxEntries && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
+
+func lookupCacheKey(question *lookup.LookupQuestion) string {
+	hash := sha256.Sum256(question.Query)
+	return question.Service + "\x00" + hex.EncodeToString(hash[:])
+}
+
+// proxyLookup resolves a question for a lookup service not hosted locally against SLAP-discovered hosts,
+// serving and populating the engine's LookupCache.
+func (e *Engine) proxyLookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	if answer, ok := e.LookupCache.Get(question); ok {
+		logger(ctx).Debug("serving lookup from cache", "service", question.Service)
+		return answer, nil
+	}
+
+	answer, err := e.LookupResolver.Query(ctx, question)
+	if err != nil {
+		logger(ctx).Error("failed to proxy lookup to remote hosts", "service", question.Service, "error", err)
+		return nil, err
+	}
+
+	e.LookupCache.Set(question, answer)
+	return answer, nil
+}
+
+// invalidateLookupAnswers drops the answers of the lookup service cached in the engine's LocalLookupCache,
+// after the service was notified of a change to the outputs it indexes.
+func (e *Engine) invalidateLookupAnswers(service string) {
+	if e.LocalLookupCache != nil {
+		e.LocalLookupCache.Invalidate(service)
+	}
+}
@@ -0,0 +1,128 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// LookupHydrationMode selects what the engine hydrates the outputs of formula answers with.
+type LookupHydrationMode string
+
+// Lookup hydration modes, from the cheapest to the most expensive.
+const (
+	// LookupHydrationNone returns the outpoints of the formulas as answered by the lookup service, without reading storage.
+	LookupHydrationNone LookupHydrationMode = "none"
+
+	// LookupHydrationTxid returns the outpoints of the outputs still stored, read without their BEEF.
+	LookupHydrationTxid LookupHydrationMode = "txid"
+
+	// LookupHydrationRawTx returns the raw transaction of each output, without its ancestors.
+	LookupHydrationRawTx LookupHydrationMode = "rawtx"
+
+	// LookupHydrationFull returns the BEEF of each output with the history selected by its formula. It is the default.
+	LookupHydrationFull LookupHydrationMode = "full"
+)
+
+// ErrInvalidLookupHydration is returned when a lookup requests an unknown hydration mode.
+var ErrInvalidLookupHydration = errors.New("invalid lookup hydration")
+
+// LookupHydration selects how the outputs of formula answers are hydrated. Output-list and freeform
+// answers are returned as built by the lookup service.
+type LookupHydration struct {
+	Mode  LookupHydrationMode // empty hydrates with LookupHydrationFull
+	Depth *uint32             // with LookupHydrationFull, bounds the ancestor levels selected by the formula; nil leaves them unbounded
+}
+
+// Validate returns ErrInvalidLookupHydration when the mode is unknown.
+func (h LookupHydration) Validate() error {
+	switch h.Mode {
+	case "", LookupHydrationNone, LookupHydrationTxid, LookupHydrationRawTx, LookupHydrationFull:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidLookupHydration, h.Mode)
+	}
+}
+
+// isDefault reports whether the hydration is the full, unbounded one of lookups without hydration options.
+func (h LookupHydration) isDefault() bool {
+	return (h.Mode == "" || h.Mode == LookupHydrationFull) && h.Depth == nil
+}
+
+type lookupHydrationKey struct{}
+
+// WithLookupHydration returns a context that makes Lookup and LookupPaged hydrate the outputs of formula
+// answers as selected. Lookup answers carry the raw transaction in the Beef of LookupHydrationRawTx outputs
+// and no Beef for LookupHydrationTxid and LookupHydrationNone ones, whose txids LookupPaged returns.
+// Lookups hydrated other than fully bypass the lookup caches.
+func WithLookupHydration(ctx context.Context, hydration LookupHydration) context.Context {
+	return context.WithValue(ctx, lookupHydrationKey{}, hydration)
+}
+
+// lookupHydration returns the hydration set with WithLookupHydration, defaulting to the full one.
+func lookupHydration(ctx context.Context) LookupHydration {
+	hydration, _ := ctx.Value(lookupHydrationKey{}).(LookupHydration)
+	return hydration
+}
+
+// hydrateFormula returns the output of a lookup formula hydrated as selected with WithLookupHydration,
+// or nil when the output is not stored or the history selector rejects it.
+func (e *Engine) hydrateFormula(ctx context.Context, formula lookup.LookupFormula) (*LookupOutput, error) {
+	hydration := lookupHydration(ctx)
+	if hydration.Mode == LookupHydrationNone {
+		return &LookupOutput{Txid: formula.Outpoint.Txid, OutputIndex: formula.Outpoint.Index}, nil
+	}
+
+	includeBEEF := hydration.Mode != LookupHydrationTxid
+	output, err := e.Storage.FindOutput(ctx, formula.Outpoint, nil, nil, includeBEEF)
+	if err != nil {
+		logger(ctx).Error("failed to find output in Lookup", "outpoint", formula.Outpoint.String(), "error", err)
+		return nil, err
+	} else if output == nil || (includeBEEF && output.Beef == nil) {
+		return nil, nil //nolint:nilnil // formulas of outputs no longer stored are skipped
+	}
+	hydrated := &LookupOutput{
+		Txid:           output.Outpoint.Txid,
+		OutputIndex:    output.Outpoint.Index,
+		Metadata:       output.Metadata,
+		OffChainValues: output.OffChainValues,
+	}
+
+	switch hydration.Mode {
+	case LookupHydrationTxid:
+		return hydrated, nil
+	case LookupHydrationRawTx:
+		_, tx, _, err := transaction.ParseBeef(output.Beef)
+		if err != nil {
+			logger(ctx).Error("failed to parse BEEF in Lookup", "outpoint", formula.Outpoint.String(), "error", err)
+			return nil, err
+		} else if tx == nil {
+			logger(ctx).Error("missing transaction in BEEF in Lookup", "outpoint", formula.Outpoint.String(), "error", ErrInvalidBeef)
+			return nil, ErrInvalidBeef
+		}
+		hydrated.RawTx = tx.Bytes()
+		return hydrated, nil
+	}
+
+	selector := formula.History
+	if selector != nil && hydration.Depth != nil {
+		depth := *hydration.Depth
+		selector = func(beef []byte, outputIndex, currentDepth uint32) bool {
+			return currentDepth <= depth && formula.History(beef, outputIndex, currentDepth)
+		}
+	}
+	historical, err := e.GetUTXOHistory(ctx, output, selector, 0)
+	if err != nil {
+		logger(ctx).Error("failed to get UTXO history in Lookup", "outpoint", formula.Outpoint.String(), "error", err)
+		return nil, err
+	} else if historical == nil {
+		return nil, nil //nolint:nilnil // outputs rejected by the history selector are skipped
+	}
+	hydrated.Beef = historical.Beef
+	hydrated.Metadata = historical.Metadata
+	hydrated.OffChainValues = historical.OffChainValues
+	return hydrated, nil
+}
@@ -0,0 +1,390 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LookupErrorPolicy decides what happens to a submission when notifying a lookup service fails.
+type LookupErrorPolicy string
+
+const (
+	// LookupErrorPolicyFailSubmit fails the submission and records it in the dead-letter queue. It is the default policy.
+	LookupErrorPolicyFailSubmit LookupErrorPolicy = "fail-submit"
+	// LookupErrorPolicyLogAndContinue logs the failure and goes on with the submission; the notification is lost.
+	LookupErrorPolicyLogAndContinue LookupErrorPolicy = "log-and-continue"
+	// LookupErrorPolicyRetryAsync goes on with the submission and queues the notification to be retried in the background.
+	LookupErrorPolicyRetryAsync LookupErrorPolicy = "retry-async"
+)
+
+// Lookup service hooks whose failures are subject to a LookupErrorPolicy.
+const (
+	LookupHookOutputAdmittedByTopic           = "OutputAdmittedByTopic"
+	LookupHookOutputSpent                     = "OutputSpent"
+	LookupHookOutputEvicted                   = "OutputEvicted"
+	LookupHookOutputBlockHeightUpdated        = "OutputBlockHeightUpdated"
+	LookupHookOutputNoLongerRetainedInHistory = "OutputNoLongerRetainedInHistory"
+)
+
+const (
+	// DefaultLookupRetryInterval is how often queued lookup notifications are checked when no interval is configured.
+	DefaultLookupRetryInterval = 10 * time.Second
+
+	// DefaultLookupRetryInitialBackoff is the delay before the first retry of a notification when no backoff is configured.
+	DefaultLookupRetryInitialBackoff = 5 * time.Second
+
+	// DefaultLookupRetryMaxBackoff caps the delay between retries of a notification when no cap is configured.
+	DefaultLookupRetryMaxBackoff = 10 * time.Minute
+
+	// DefaultLookupRetryMaxAttempts is how many times a notification is attempted before it is abandoned when no cap is configured.
+	DefaultLookupRetryMaxAttempts = 10
+
+	// DefaultLookupRetryMaxQueued caps the notifications waiting to be retried when no cap is configured.
+	DefaultLookupRetryMaxQueued = 10000
+)
+
+// ErrLookupServicePanic is returned when a lookup service panics while being notified
+var ErrLookupServicePanic = errors.New("lookup service panicked")
+
+// LookupServicePolicy holds the error policies of a lookup service.
+type LookupServicePolicy struct {
+	// OnError applies to the hooks without a policy of their own. Empty falls back to LookupErrorPolicyFailSubmit.
+	OnError LookupErrorPolicy `mapstructure:"on_error"`
+
+	// Hooks maps lookup service hooks, such as LookupHookOutputSpent, to their policy, replacing OnError.
+	Hooks map[string]LookupErrorPolicy `mapstructure:"hooks"`
+}
+
+// LookupRetryConfig configures the queue of lookup notifications retried under LookupErrorPolicyRetryAsync.
+type LookupRetryConfig struct {
+	// Interval is how often queued notifications are checked. Zero falls back to DefaultLookupRetryInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// InitialBackoff is the delay before the first retry; it doubles after every failed attempt.
+	// Zero falls back to DefaultLookupRetryInitialBackoff.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// MaxBackoff caps the delay between retries. Zero falls back to DefaultLookupRetryMaxBackoff.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+
+	// MaxAttempts is how many times a notification is attempted, including by Submit, before it is abandoned.
+	// Zero falls back to DefaultLookupRetryMaxAttempts.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// MaxQueued caps the notifications waiting to be retried; failures beyond it are dropped.
+	// Zero falls back to DefaultLookupRetryMaxQueued.
+	MaxQueued int `mapstructure:"max_queued"`
+}
+
+// LookupNotificationsConfig holds the error policies of the engine's lookup services.
+type LookupNotificationsConfig struct {
+	// Default applies to the lookup services without a policy of their own.
+	Default LookupServicePolicy `mapstructure:"default"`
+
+	// Services maps lookup service names to their policy, replacing the default one.
+	Services map[string]LookupServicePolicy `mapstructure:"services"`
+
+	// Retry configures the queue of notifications retried under LookupErrorPolicyRetryAsync.
+	Retry LookupRetryConfig `mapstructure:"retry"`
+}
+
+// LookupNotificationMetrics counts the failed lookup notifications since the engine started.
+type LookupNotificationMetrics struct {
+	Failed    uint64 `json:"failed"`    // notifications that failed during a submission, panics included
+	Panics    uint64 `json:"panics"`    // notifications that panicked
+	Ignored   uint64 `json:"ignored"`   // failures ignored under LookupErrorPolicyLogAndContinue
+	Queued    uint64 `json:"queued"`    // failures queued under LookupErrorPolicyRetryAsync
+	Dropped   uint64 `json:"dropped"`   // failures not queued because the queue was full
+	Retried   uint64 `json:"retried"`   // retries attempted
+	Succeeded uint64 `json:"succeeded"` // retries accepted by the lookup service
+	Abandoned uint64 `json:"abandoned"` // notifications that reached MaxAttempts
+	Pending   int    `json:"pending"`   // notifications waiting to be retried
+}
+
+// LookupRetryReport describes the outcome of a lookup notification retry run.
+type LookupRetryReport struct {
+	Retried   int // queued notifications that were due and attempted
+	Succeeded int // attempts accepted by the lookup service and removed from the queue
+	Abandoned int // attempts that failed for the last time
+}
+
+// queuedLookupNotification is a failed notification waiting to be retried.
+type queuedLookupNotification struct {
+	service       string
+	hook          string
+	notify        func(ctx context.Context, l LookupService) error
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+}
+
+// LookupNotifications applies per lookup service and per hook error policies to the notifications of the engine, so
+// that a failing ancillary indexer does not block admissions. Notifications retried under LookupErrorPolicyRetryAsync
+// are queued in memory: they are lost on restart, and may reach the lookup service after later notifications about
+// the same outputs. Panics of lookup services are recovered whether or not the engine has LookupNotifications.
+// It is safe for concurrent use and implements expvar.Var, so its metrics can be published with expvar.Publish.
+type LookupNotifications struct {
+	config LookupNotificationsConfig
+
+	mu    sync.Mutex
+	queue []*queuedLookupNotification
+
+	failed    atomic.Uint64
+	panics    atomic.Uint64
+	ignored   atomic.Uint64
+	queued    atomic.Uint64
+	dropped   atomic.Uint64
+	retried   atomic.Uint64
+	succeeded atomic.Uint64
+	abandoned atomic.Uint64
+}
+
+// NewLookupNotifications creates LookupNotifications enforcing the given configuration.
+func NewLookupNotifications(cfg LookupNotificationsConfig) *LookupNotifications {
+	return &LookupNotifications{config: cfg}
+}
+
+// Policy returns the error policy of the lookup service's hook: the policy of the hook, the OnError policy of the
+// service or of the default one. Unknown policies fall back to LookupErrorPolicyFailSubmit.
+func (n *LookupNotifications) Policy(service, hook string) LookupErrorPolicy {
+	policy, ok := n.config.Services[service]
+	if !ok {
+		policy = n.config.Default
+	}
+	onError, ok := policy.Hooks[hook]
+	if !ok {
+		onError = policy.OnError
+	}
+	switch onError {
+	case LookupErrorPolicyLogAndContinue, LookupErrorPolicyRetryAsync:
+		return onError
+	default:
+		return LookupErrorPolicyFailSubmit
+	}
+}
+
+// Metrics returns the counters of the failed notifications.
+func (n *LookupNotifications) Metrics() LookupNotificationMetrics {
+	n.mu.Lock()
+	pending := len(n.queue)
+	n.mu.Unlock()
+	return LookupNotificationMetrics{
+		Failed:    n.failed.Load(),
+		Panics:    n.panics.Load(),
+		Ignored:   n.ignored.Load(),
+		Queued:    n.queued.Load(),
+		Dropped:   n.dropped.Load(),
+		Retried:   n.retried.Load(),
+		Succeeded: n.succeeded.Load(),
+		Abandoned: n.abandoned.Load(),
+		Pending:   pending,
+	}
+}
+
+// String returns the JSON encoded metrics, implementing expvar.Var.
+func (n *LookupNotifications) String() string {
+	bb, err := json.Marshal(n.Metrics())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (n *LookupNotifications) backoff(attempts int) time.Duration {
+	delay, maxDelay := n.config.Retry.InitialBackoff, n.config.Retry.MaxBackoff
+	if delay <= 0 {
+		delay = DefaultLookupRetryInitialBackoff
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultLookupRetryMaxBackoff
+	}
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+func (n *LookupNotifications) maxAttempts() int {
+	if n.config.Retry.MaxAttempts > 0 {
+		return n.config.Retry.MaxAttempts
+	}
+	return DefaultLookupRetryMaxAttempts
+}
+
+func (n *LookupNotifications) maxQueued() int {
+	if n.config.Retry.MaxQueued > 0 {
+		return n.config.Retry.MaxQueued
+	}
+	return DefaultLookupRetryMaxQueued
+}
+
+// enqueue queues a notification for retry, reporting false when the queue is full.
+func (n *LookupNotifications) enqueue(notification *queuedLookupNotification) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.queue) >= n.maxQueued() {
+		return false
+	}
+	n.queue = append(n.queue, notification)
+	return true
+}
+
+// due removes and returns the queued notifications whose next attempt is due.
+func (n *LookupNotifications) due(now time.Time) []*queuedLookupNotification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var due []*queuedLookupNotification
+	pending := n.queue[:0]
+	for _, notification := range n.queue {
+		if notification.nextAttemptAt.After(now) {
+			pending = append(pending, notification)
+		} else {
+			due = append(due, notification)
+		}
+	}
+	clear(n.queue[len(pending):])
+	n.queue = pending
+	return due
+}
+
+// notifyLookupService calls the hook of a lookup service through notify, recovering panics, and applies the error
+// policy of the hook to failures. It returns the failure only under LookupErrorPolicyFailSubmit.
+func (e *Engine) notifyLookupService(ctx context.Context, name string, l LookupService, hook string, notify func(ctx context.Context, l LookupService) error) error {
+	err := recoverLookupHook(ctx, name, hook, l, notify)
+	if err == nil {
+		e.invalidateLookupAnswers(name)
+		return nil
+	}
+	n := e.LookupNotifications
+	if n == nil {
+		return err
+	}
+	n.failed.Add(1)
+	if errors.Is(err, ErrLookupServicePanic) {
+		n.panics.Add(1)
+	}
+	switch n.Policy(name, hook) {
+	case LookupErrorPolicyLogAndContinue:
+		n.ignored.Add(1)
+		logger(ctx).Warn("ignoring failed lookup service notification", "service", name, "hook", hook, "error", err)
+		return nil
+	case LookupErrorPolicyRetryAsync:
+		queued := n.enqueue(&queuedLookupNotification{
+			service:       name,
+			hook:          hook,
+			notify:        notify,
+			attempts:      1,
+			lastError:     err.Error(),
+			nextAttemptAt: time.Now().Add(n.backoff(1)),
+		})
+		if !queued {
+			n.dropped.Add(1)
+			logger(ctx).Error("lookup notification retry queue full, dropping notification", "service", name, "hook", hook, "error", err)
+			return nil
+		}
+		n.queued.Add(1)
+		logger(ctx).Warn("lookup service notification failed, queued for retry", "service", name, "hook", hook, "error", err)
+		return nil
+	default:
+		return err
+	}
+}
+
+// recoverLookupHook calls notify, converting a panic of the lookup service into an error wrapping ErrLookupServicePanic.
+func recoverLookupHook(ctx context.Context, name, hook string, l LookupService, notify func(ctx context.Context, l LookupService) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %s of lookup service %q: %v", ErrLookupServicePanic, hook, name, recovered)
+			logger(ctx).Error("recovered lookup service panic", "service", name, "hook", hook, "error", err, "stack", string(debug.Stack()))
+		}
+	}()
+	return notify(ctx, l)
+}
+
+// RetryLookupNotifications retries the queued lookup notifications that are due. Accepted notifications leave the
+// queue; the others are rescheduled with an exponentially growing delay until LookupRetryConfig.MaxAttempts is
+// reached, after which they are abandoned. Notifications of lookup services no longer registered are abandoned.
+func (e *Engine) RetryLookupNotifications(ctx context.Context) (*LookupRetryReport, error) {
+	n := e.LookupNotifications
+	report := &LookupRetryReport{}
+	if n == nil {
+		return report, nil
+	}
+	now := time.Now()
+	due := n.due(now)
+	for i, notification := range due {
+		if ctx.Err() != nil {
+			for _, pending := range due[i:] {
+				n.enqueue(pending)
+			}
+			return report, ctx.Err()
+		}
+		l, ok := e.lookupService(notification.service)
+		if !ok {
+			report.Abandoned++
+			n.abandoned.Add(1)
+			slog.Warn("abandoning lookup notification of unregistered service", "service", notification.service, "hook", notification.hook)
+			continue
+		}
+
+		report.Retried++
+		n.retried.Add(1)
+		cause := recoverLookupHook(ctx, notification.service, notification.hook, l, notification.notify)
+		if cause == nil {
+			e.invalidateLookupAnswers(notification.service)
+			report.Succeeded++
+			n.succeeded.Add(1)
+			slog.Info("queued lookup notification succeeded", "service", notification.service, "hook", notification.hook, "attempts", notification.attempts+1)
+			continue
+		}
+
+		notification.attempts++
+		notification.lastError = cause.Error()
+		if notification.attempts >= n.maxAttempts() {
+			report.Abandoned++
+			n.abandoned.Add(1)
+			slog.Error("abandoning lookup notification after max attempts", "service", notification.service, "hook", notification.hook, "attempts", notification.attempts, "error", cause)
+			continue
+		}
+		notification.nextAttemptAt = now.Add(n.backoff(notification.attempts))
+		slog.Warn("queued lookup notification failed again", "service", notification.service, "hook", notification.hook, "attempts", notification.attempts, "nextAttemptAt", notification.nextAttemptAt, "error", cause)
+		if !n.enqueue(notification) {
+			n.dropped.Add(1)
+			slog.Error("lookup notification retry queue full, dropping notification", "service", notification.service, "hook", notification.hook)
+		}
+	}
+	return report, nil
+}
+
+// RunLookupRetrier retries queued lookup notifications every LookupRetryConfig.Interval until ctx is done.
+// It returns immediately when no LookupNotifications is configured.
+func (e *Engine) RunLookupRetrier(ctx context.Context) {
+	if e.LookupNotifications == nil {
+		return
+	}
+	interval := e.LookupNotifications.config.Retry.Interval
+	if interval <= 0 {
+		interval = DefaultLookupRetryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.RetryLookupNotifications(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("scheduled lookup notification retry failed", "interval", interval, "error", err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+)
+
+// ErrInvalidLookupCursor is returned when paging a lookup answer from a cursor it did not return.
+var ErrInvalidLookupCursor = errors.New("invalid lookup cursor")
+
+// LookupPage selects a page of the outputs of a lookup answer.
+type LookupPage struct {
+	// Limit is the maximum number of outputs of the page. Zero returns every remaining output.
+	Limit uint32
+
+	// Cursor is where the page starts, as returned in the NextCursor of the previous page.
+	// Empty starts at the first output.
+	Cursor string
+}
+
+// LookupOutput is an output of a paged lookup answer.
+type LookupOutput struct {
+	Beef        []byte // BEEF of the output; nil unless hydrated with LookupHydrationFull
+	RawTx       []byte // raw transaction of the output when hydrated with LookupHydrationRawTx
+	Txid        chainhash.Hash
+	OutputIndex uint32
+	Metadata    map[string]string // tags set by the topic manager when admitting the output, if any
+
+	// OffChainValues holds the off-chain values submitted with the transaction of the output, if any.
+	OffChainValues []byte
+}
+
+// LookupPageAnswer is a page of a lookup answer. Its outputs are hydrated with their BEEF as they are
+// iterated, so that large answers are never held in memory at once.
+type LookupPageAnswer struct {
+	Type lookup.AnswerType
+
+	// Result is the result of freeform answers, which are not paged.
+	Result any
+
+	// NextCursor selects the next page; empty when the page holds the last outputs of the answer.
+	NextCursor string
+
+	// Outputs yields the outputs of the page, stopping at the first error.
+	Outputs iter.Seq2[*LookupOutput, error]
+}
+
+// PagedLookupService is an optional LookupService capability used to page large answers at their source.
+// Answers of lookup services without it are paged by the engine once the whole answer is computed,
+// still hydrating only the outputs of the requested page.
+type PagedLookupService interface {
+	// LookupPage answers the question with at most page.Limit outputs or formulas starting at page.Cursor,
+	// a cursor previously returned by the service, and returns the cursor of the next page, empty after the last one.
+	LookupPage(ctx context.Context, question *lookup.LookupQuestion, page LookupPage) (*lookup.LookupAnswer, string, error)
+}
+
+// LookupPaged answers a lookup question one page at a time. Lookup services implementing PagedLookupService
+// page their answers themselves; the answers of other services are paged by the engine with offset cursors.
+// Outputs of formulas that are no longer stored are skipped, so a page may hold fewer outputs than its limit
+// while more follow. Paged answers bypass the lookup caches.
+func (e *Engine) LookupPaged(ctx context.Context, question *lookup.LookupQuestion, page LookupPage) (*LookupPageAnswer, error) {
+	l, ok := e.lookupService(question.Service)
+	if !ok && e.LookupCache != nil && e.LookupResolver != nil {
+		answer, err := e.proxyLookup(ctx, question)
+		if err != nil {
+			return nil, err
+		}
+		return e.pageAnswer(ctx, question, answer, page)
+	}
+	if !ok {
+		logger(ctx).Error("unknown lookup service", "service", question.Service, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	if err := validateLookupQuery(l, question); err != nil {
+		logger(ctx).Error("rejecting lookup question", "service", question.Service, "error", err)
+		return nil, err
+	}
+
+	paged, ok := l.(PagedLookupService)
+	if !ok {
+		answer, err := l.Lookup(ctx, question)
+		if err != nil {
+			logger(ctx).Error("lookup service failed", "service", question.Service, "error", err)
+			return nil, err
+		}
+		return e.pageAnswer(ctx, question, answer, page)
+	}
+	answer, next, err := paged.LookupPage(ctx, question, page)
+	if err != nil {
+		logger(ctx).Error("lookup service failed", "service", question.Service, "error", err)
+		return nil, err
+	}
+	result := e.answerOutputs(ctx, answer, 0, len(answer.Outputs)+len(answer.Formulas))
+	if result.Type != lookup.AnswerTypeFreeform {
+		result.NextCursor = next
+	}
+	return result, nil
+}
+
+// pageAnswer selects the page of a whole answer with an offset cursor.
+func (e *Engine) pageAnswer(ctx context.Context, question *lookup.LookupQuestion, answer *lookup.LookupAnswer, page LookupPage) (*LookupPageAnswer, error) {
+	offset, err := decodeLookupCursor(page.Cursor)
+	if err != nil {
+		logger(ctx).Error("rejecting lookup page", "service", question.Service, "cursor", page.Cursor, "error", err)
+		return nil, err
+	}
+	total := len(answer.Outputs)
+	if answer.Type == lookup.AnswerTypeFormula {
+		total = len(answer.Formulas)
+	}
+	start := min(offset, total)
+	end := total
+	if page.Limit > 0 && uint64(start)+uint64(page.Limit) < uint64(total) {
+		end = start + int(page.Limit)
+	}
+
+	result := e.answerOutputs(ctx, answer, start, end)
+	if result.Type != lookup.AnswerTypeFreeform && end < total {
+		result.NextCursor = encodeLookupCursor(end)
+	}
+	return result, nil
+}
+
+// answerOutputs returns the page of an answer holding its outputs or formulas from start to end,
+// hydrating formulas as the outputs are iterated.
+func (e *Engine) answerOutputs(ctx context.Context, answer *lookup.LookupAnswer, start, end int) *LookupPageAnswer {
+	if answer.Type == lookup.AnswerTypeFreeform {
+		return &LookupPageAnswer{Type: answer.Type, Result: answer.Result, Outputs: func(func(*LookupOutput, error) bool) {}}
+	}
+
+	result := &LookupPageAnswer{Type: lookup.AnswerTypeOutputList}
+	if answer.Type == lookup.AnswerTypeFormula {
+		formulas := answer.Formulas[start:min(end, len(answer.Formulas))]
+		result.Outputs = func(yield func(*LookupOutput, error) bool) {
+			for _, formula := range formulas {
+				output, err := e.hydrateFormula(ctx, formula)
+				if err != nil {
+					yield(nil, err)
+					return
+				} else if output == nil {
+					continue
+				}
+				if !yield(output, nil) {
+					return
+				}
+			}
+		}
+		return result
+	}
+
+	details, detailed := LookupAnswerDetails(answer)
+	end = min(end, len(answer.Outputs))
+	result.Outputs = func(yield func(*LookupOutput, error) bool) {
+		for i := start; i < end; i++ {
+			output := &LookupOutput{Beef: answer.Outputs[i].Beef, OutputIndex: answer.Outputs[i].OutputIndex}
+			if detailed {
+				output.Metadata = details.Metadata[i]
+				output.OffChainValues = details.OffChainValues[i]
+			}
+			if !yield(output, nil) {
+				return
+			}
+		}
+	}
+	return result
+}
+
+// encodeLookupCursor returns the opaque cursor of the page starting at the offset.
+func encodeLookupCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeLookupCursor returns the offset of the page selected by a cursor returned by encodeLookupCursor.
+func decodeLookupCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidLookupCursor, err)
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLookupCursor, cursor)
+	}
+	return offset, nil
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	// ErrInvalidLookupQuery is returned when the query of a lookup question does not match the schema declared by its lookup service
+	ErrInvalidLookupQuery = errors.New("invalid lookup query")
+
+	// ErrLookupQuerySchemaNotDeclared is returned when requesting the query schema of a lookup service that does not declare one
+	ErrLookupQuerySchemaNotDeclared = errors.New("lookup service does not declare a query schema")
+)
+
+// LookupServiceWithSchema is an optional LookupService capability declaring the format of its queries.
+// Engine.Lookup validates questions against the schema before they reach the lookup service, so
+// malformed queries are rejected with ErrInvalidLookupQuery instead of failing inside provider code.
+type LookupServiceWithSchema interface {
+	// QuerySchema returns the JSON Schema of the query, in the dialect of OpenAPI 3 schema objects.
+	QuerySchema() json.RawMessage
+}
+
+// compiledQuerySchemas caches parsed query schemas keyed by their raw JSON.
+var compiledQuerySchemas sync.Map
+
+// GetLookupQuerySchema returns the query schema declared by the lookup service.
+// Returns ErrUnknownTopic for unknown lookup services and ErrLookupQuerySchemaNotDeclared
+// for lookup services that do not implement LookupServiceWithSchema.
+func (e *Engine) GetLookupQuerySchema(_ context.Context, service string) (json.RawMessage, error) {
+	l, ok := e.lookupService(service)
+	if !ok {
+		slog.Error("unknown lookup service", "service", service, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	withSchema, ok := l.(LookupServiceWithSchema)
+	if !ok {
+		slog.Error("cannot get lookup query schema", "service", service, "error", ErrLookupQuerySchemaNotDeclared)
+		return nil, ErrLookupQuerySchemaNotDeclared
+	}
+	return withSchema.QuerySchema(), nil
+}
+
+// validateLookupQuery checks the query of the question against the schema declared by the lookup service, if any.
+func validateLookupQuery(l LookupService, question *lookup.LookupQuestion) error {
+	withSchema, ok := l.(LookupServiceWithSchema)
+	if !ok {
+		return nil
+	}
+	raw := withSchema.QuerySchema()
+	if len(raw) == 0 {
+		return nil
+	}
+
+	schema, err := compileQuerySchema(raw)
+	if err != nil {
+		slog.Error("invalid query schema declared by lookup service", "service", question.Service, "error", err)
+		return err
+	}
+
+	var query any
+	if err := json.Unmarshal(question.Query, &query); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLookupQuery, err)
+	}
+	if err := schema.VisitJSON(query); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLookupQuery, err)
+	}
+	return nil
+}
+
+func compileQuerySchema(raw json.RawMessage) (*openapi3.Schema, error) {
+	if schema, ok := compiledQuerySchemas.Load(string(raw)); ok {
+		return schema.(*openapi3.Schema), nil
+	}
+
+	var schema openapi3.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse query schema: %w", err)
+	}
+	compiledQuerySchemas.Store(string(raw), &schema)
+	return &schema, nil
+}
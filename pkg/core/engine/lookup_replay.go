@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultLookupReplayBatchSize is the number of UTXOs ReplayTopicToLookupService reads from storage at once.
+// A page holding only UTXOs of the score it started from is read again twice as large, until the page moves past
+// that score, so that any number of UTXOs sharing a score are replayed.
+const DefaultLookupReplayBatchSize = 500
+
+// LookupReplayProgress reports how far ReplayTopicToLookupService went through the outputs of a topic.
+type LookupReplayProgress struct {
+	Topic    string
+	Service  string
+	Admitted int     // OutputAdmittedByTopic events emitted
+	Spent    int     // OutputSpent events emitted
+	Score    float64 // score of the last UTXO replayed, from which an interrupted replay can be resumed
+}
+
+// ReplayTopicToLookupService re-emits the OutputAdmittedByTopic and OutputSpent events of every output stored
+// for the topic to the named lookup service alone, so that a rebuilt lookup index can be repopulated without
+// resubmitting anything or touching the other lookup services. The UTXOs of the topic are paged by score like
+// GASP does, and the spent outputs they retain are replayed before them, each followed by its OutputSpent event,
+// so the service sees the history in the order it was admitted. onProgress, when set, is called after every page.
+// A replay failing midway returns the progress it reached along with the error.
+func (e *Engine) ReplayTopicToLookupService(ctx context.Context, topic, service string, onProgress func(LookupReplayProgress)) (*LookupReplayProgress, error) {
+	return e.replayTopic(ctx, "ReplayTopicToLookupService", topic, service, 0, onProgress)
+}
+
+// ReplayTopicIntoLookupService backfills a lookup service registered after the topic was populated, which otherwise
+// only learns about new transactions, like ReplayTopicToLookupService does, starting from the UTXOs scored at or
+// after fromScore. Passing the Score of the progress returned by an interrupted backfill resumes it; the UTXOs
+// scored exactly fromScore, and the spent outputs they retain, are replayed again.
+func (e *Engine) ReplayTopicIntoLookupService(ctx context.Context, topic, service string, fromScore float64) (*LookupReplayProgress, error) {
+	return e.replayTopic(ctx, "ReplayTopicIntoLookupService", topic, service, fromScore, nil)
+}
+
+// replayTopic replays the UTXOs of the topic scored at or after fromScore, and their history, to the lookup service.
+// op names the exported method replaying, for the logs.
+func (e *Engine) replayTopic(ctx context.Context, op, topic, service string, fromScore float64, onProgress func(LookupReplayProgress)) (*LookupReplayProgress, error) {
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("unknown topic in "+op, "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	l, ok := e.lookupService(service)
+	if !ok {
+		slog.Error("unknown lookup service in "+op, "service", service, "error", ErrLookupServiceNotRegistered)
+		return nil, ErrLookupServiceNotRegistered
+	}
+
+	r := &lookupReplay{
+		engine:   e,
+		service:  l,
+		progress: LookupReplayProgress{Topic: topic, Service: service, Score: fromScore},
+		admitted: make(map[string]struct{}),
+		spent:    make(map[string]struct{}),
+	}
+	since := fromScore
+	limit := DefaultLookupReplayBatchSize
+	for {
+		if err := ctx.Err(); err != nil {
+			return &r.progress, err
+		}
+		page, err := e.Storage.FindUTXOsForTopic(ctx, topic, since, uint32(limit), true) //nolint:gosec // limit is positive and bounded by the UTXOs sharing a score
+		if err != nil {
+			slog.Error("failed to find UTXOs to replay", "op", op, "topic", topic, "since", since, "limit", limit, "error", err)
+			return &r.progress, err
+		}
+
+		// Pages start at an inclusive score, so the outputs at the score a page ends with are read again with
+		// the next page and skipped.
+		start := since
+		for _, utxo := range page {
+			if _, ok := r.admitted[utxo.Outpoint.String()]; ok {
+				continue
+			}
+			if err := r.replay(ctx, utxo); err != nil {
+				return &r.progress, err
+			}
+			if utxo.Score > since {
+				since = utxo.Score
+			}
+			r.progress.Score = since
+		}
+		if onProgress != nil {
+			onProgress(r.progress)
+		}
+		if len(page) < limit {
+			break
+		}
+		if since == start {
+			limit *= 2
+		} else {
+			limit = DefaultLookupReplayBatchSize
+		}
+	}
+
+	slog.Info("replayed topic to lookup service", "op", op, "topic", topic, "service", service, "fromScore", fromScore, "admitted", r.progress.Admitted, "spent", r.progress.Spent)
+	return &r.progress, nil
+}
+
+// lookupReplay holds the state of a single ReplayTopicToLookupService run.
+type lookupReplay struct {
+	engine   *Engine
+	service  LookupService
+	progress LookupReplayProgress
+	admitted map[string]struct{}
+	spent    map[string]struct{}
+}
+
+// replay emits the events of the outputs consumed by output, then the admission of output itself.
+func (r *lookupReplay) replay(ctx context.Context, output *Output) error {
+	key := output.Outpoint.String()
+	if _, ok := r.admitted[key]; ok {
+		return nil
+	}
+	r.admitted[key] = struct{}{}
+
+	if len(output.OutputsConsumed) > 0 {
+		_, tx, _, err := transaction.ParseBeef(output.Beef)
+		if err != nil {
+			slog.Error("failed to parse BEEF of replayed output", "outpoint", key, "error", err)
+			return err
+		}
+		for _, outpoint := range output.OutputsConsumed {
+			if err := r.replaySpent(ctx, outpoint, output, tx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.service.OutputAdmittedByTopic(ctx, &OutputAdmittedByTopic{
+		Topic:          r.progress.Topic,
+		Outpoint:       &output.Outpoint,
+		Satoshis:       output.Satoshis,
+		LockingScript:  output.Script,
+		AtomicBEEF:     output.Beef,
+		Metadata:       output.Metadata,
+		OffChainValues: output.OffChainValues,
+	}); err != nil {
+		slog.Error("failed to replay admitted output to lookup service", "topic", r.progress.Topic, "service", r.progress.Service, "outpoint", key, "error", err)
+		return err
+	}
+	r.progress.Admitted++
+	return nil
+}
+
+// replaySpent replays the consumed output, then its OutputSpent event with spender, whose transaction is tx.
+func (r *lookupReplay) replaySpent(ctx context.Context, outpoint *transaction.Outpoint, spender *Output, tx *transaction.Transaction) error {
+	topic := r.progress.Topic
+	consumed, err := r.engine.Storage.FindOutput(ctx, outpoint, &topic, nil, true)
+	if errors.Is(err, ErrNotFound) || (err == nil && consumed == nil) {
+		return nil
+	} else if err != nil {
+		slog.Error("failed to find consumed output to replay", "topic", topic, "outpoint", outpoint.String(), "error", err)
+		return err
+	}
+	if err := r.replay(ctx, consumed); err != nil {
+		return err
+	}
+
+	key := outpoint.String()
+	if _, ok := r.spent[key]; ok {
+		return nil
+	}
+	for vin, input := range tx.Inputs {
+		if input.SourceTXID == nil || !input.SourceTXID.Equal(outpoint.Txid) || input.SourceTxOutIndex != outpoint.Index {
+			continue
+		}
+		r.spent[key] = struct{}{}
+		if err := r.service.OutputSpent(ctx, &OutputSpent{
+			Outpoint:           outpoint,
+			Topic:              topic,
+			SpendingTxid:       &spender.Outpoint.Txid,
+			InputIndex:         uint32(vin), //nolint:gosec // index bounded by slice length
+			UnlockingScript:    input.UnlockingScript,
+			SequenceNumber:     input.SequenceNumber,
+			SpendingAtomicBEEF: spender.Beef,
+		}); err != nil {
+			slog.Error("failed to replay spent output to lookup service", "topic", topic, "service", r.progress.Service, "outpoint", key, "error", err)
+			return err
+		}
+		r.progress.Spent++
+		return nil
+	}
+	return nil
+}
@@ -2,43 +2,327 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 )
 
+// Lookup resolver defaults applied when the LookupResolverConfig leaves them zero.
+const (
+	// DefaultTrackerCacheTTL is how long the answers of SHIP and SLAP queries are cached.
+	DefaultTrackerCacheTTL = 5 * time.Minute
+
+	// DefaultTrackerNegativeCacheTTL is how long failed SHIP and SLAP queries are cached.
+	DefaultTrackerNegativeCacheTTL = 30 * time.Second
+
+	// DefaultTrackerBackoff is how long an unreachable host is skipped after its first failure.
+	DefaultTrackerBackoff = 5 * time.Second
+
+	// DefaultTrackerMaxBackoff bounds how long an unreachable host is skipped.
+	DefaultTrackerMaxBackoff = 10 * time.Minute
+)
+
+// ErrTrackerBackingOff is returned for lookups to a host skipped after it failed to answer previous lookups.
+var ErrTrackerBackingOff = errors.New("tracker backing off")
+
+// LookupResolverConfig configures the caching and backoff of a LookupResolver.
+type LookupResolverConfig struct {
+	// CacheTTL is how long the answers of ls_ship and ls_slap queries are cached, so that repeated
+	// syncs do not query the trackers while the peers are unchanged. Zero falls back to
+	// DefaultTrackerCacheTTL and a negative TTL disables the caching.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+
+	// NegativeCacheTTL is how long failed ls_ship and ls_slap queries are cached. Zero falls back to
+	// DefaultTrackerNegativeCacheTTL and a negative TTL disables the caching.
+	NegativeCacheTTL time.Duration `mapstructure:"negative_cache_ttl"`
+
+	// Backoff is how long a tracker or host failing a lookup is skipped, doubled with every further
+	// consecutive failure up to MaxBackoff. Zero falls back to DefaultTrackerBackoff and a negative
+	// backoff disables it.
+	Backoff time.Duration `mapstructure:"backoff"`
+
+	// MaxBackoff bounds the doubled Backoff. Zero falls back to DefaultTrackerMaxBackoff, raised to Backoff if lower.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+
+	// Facilitator sends the lookups to the trackers and hosts. Nil uses HTTPS with http.DefaultClient.
+	Facilitator lookup.Facilitator `mapstructure:"-"`
+
+	// Metrics records the latency of every lookup sent to a tracker or host when set.
+	Metrics *TrackerMetrics `mapstructure:"-"`
+}
+
 // LookupResolver wraps the underlying lookup.LookupResolver to expose
 // a simplified interface for querying and managing SLAP trackers.
+// It caches the answers of SHIP and SLAP queries, including failed ones, and backs off from trackers and hosts
+// failing to answer, as configured by its LookupResolverConfig.
+// It is safe for concurrent use: queries share the resolver while trackers are only replaced between them.
 type LookupResolver struct {
+	mu       sync.RWMutex
 	resolver *lookup.LookupResolver
+
+	cacheTTL         time.Duration
+	negativeCacheTTL time.Duration
+	cacheMu          sync.Mutex
+	cache            map[string]*trackerCacheEntry
+}
+
+type trackerCacheEntry struct {
+	answer    *lookup.LookupAnswer
+	err       error
+	expiresAt time.Time
 }
 
-// NewLookupResolver creates and initializes a LookupResolver with a default HTTPS facilitator.
+// NewLookupResolver creates and initializes a LookupResolver with a default HTTPS facilitator
+// and the default caching and backoff.
 func NewLookupResolver() *LookupResolver {
-	return &LookupResolver{
-		resolver: &lookup.LookupResolver{
-			Facilitator: &lookup.HTTPSOverlayLookupFacilitator{
-				Client: http.DefaultClient,
-			},
+	return NewLookupResolverWithConfig(LookupResolverConfig{})
+}
+
+// NewLookupResolverWithConfig creates a LookupResolver with the caching, backoff and facilitator of the config.
+func NewLookupResolverWithConfig(cfg LookupResolverConfig) *LookupResolver {
+	facilitator := cfg.Facilitator
+	if facilitator == nil {
+		facilitator = &lookup.HTTPSOverlayLookupFacilitator{Client: http.DefaultClient}
+	}
+	backoff := withDefaultDuration(cfg.Backoff, DefaultTrackerBackoff)
+	maxBackoff := max(withDefaultDuration(cfg.MaxBackoff, DefaultTrackerMaxBackoff), backoff)
+	resolver := &LookupResolver{
+		cacheTTL:         withDefaultDuration(cfg.CacheTTL, DefaultTrackerCacheTTL),
+		negativeCacheTTL: withDefaultDuration(cfg.NegativeCacheTTL, DefaultTrackerNegativeCacheTTL),
+		cache:            make(map[string]*trackerCacheEntry),
+	}
+	resolver.resolver = &lookup.LookupResolver{
+		Facilitator: &backoffFacilitator{
+			facilitator: facilitator,
+			metrics:     cfg.Metrics,
+			backoff:     backoff,
+			maxBackoff:  maxBackoff,
+			hosts:       make(map[string]*hostBackoff),
 		},
 	}
+	return resolver
 }
 
-// SetSLAPTrackers configures the SLAP trackers for the resolver.
-// If the given slice is empty, it leaves the resolver unchanged.
+// withDefaultDuration returns fallback for a zero duration and the duration otherwise.
+func withDefaultDuration(d, fallback time.Duration) time.Duration {
+	if d == 0 {
+		return fallback
+	}
+	return d
+}
+
+// SetSLAPTrackers configures the SLAP trackers for the resolver, dropping the cached answers of the previous ones.
+// If the given slice is empty, or holds the trackers already configured, it leaves the resolver unchanged.
 func (l *LookupResolver) SetSLAPTrackers(trackers []string) {
-	if len(trackers) == 0 {
+	if len(trackers) == 0 || slices.Equal(l.SLAPTrackers(), trackers) {
 		return
 	}
-	l.resolver.SLAPTrackers = trackers
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resolver.SLAPTrackers = slices.Clone(trackers)
+
+	l.cacheMu.Lock()
+	defer l.cacheMu.Unlock()
+	clear(l.cache)
 }
 
 // SLAPTrackers returns the currently configured SLAP trackers.
 func (l *LookupResolver) SLAPTrackers() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 	return l.resolver.SLAPTrackers
 }
 
 // Query performs a lookup using the configured resolver with the given question and timeout.
+// Answers and failures of ls_ship and ls_slap questions are served from the cache while fresh.
 func (l *LookupResolver) Query(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
-	return l.resolver.Query(ctx, question)
+	cached := question.Service == "ls_ship" || question.Service == "ls_slap"
+	if cached {
+		if entry, ok := l.cached(question); ok {
+			return entry.answer, entry.err
+		}
+	}
+
+	l.mu.RLock()
+	answer, err := l.resolver.Query(ctx, question)
+	l.mu.RUnlock()
+
+	if cached && ctx.Err() == nil {
+		l.store(question, answer, err)
+	}
+	return answer, err
+}
+
+// cached returns the fresh cache entry of the question, if any.
+func (l *LookupResolver) cached(question *lookup.LookupQuestion) (*trackerCacheEntry, bool) {
+	l.cacheMu.Lock()
+	defer l.cacheMu.Unlock()
+
+	key := lookupCacheKey(question)
+	entry, ok := l.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(l.cache, key)
+		return nil, false
+	}
+	return entry, true
+}
+
+// store caches the answer or failure of the question unless its TTL disables the caching.
+func (l *LookupResolver) store(question *lookup.LookupQuestion, answer *lookup.LookupAnswer, err error) {
+	ttl := l.cacheTTL
+	if err != nil {
+		ttl = l.negativeCacheTTL
+	}
+	if ttl < 0 {
+		return
+	}
+
+	l.cacheMu.Lock()
+	defer l.cacheMu.Unlock()
+	l.cache[lookupCacheKey(question)] = &trackerCacheEntry{answer: answer, err: err, expiresAt: time.Now().Add(ttl)}
+}
+
+// hostBackoff is the backoff state of a tracker or host that failed to answer lookups.
+type hostBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// backoffFacilitator sends lookups through the wrapped facilitator, recording their latency and skipping
+// the trackers and hosts that failed to answer until their exponential backoff expires.
+type backoffFacilitator struct {
+	facilitator lookup.Facilitator
+	metrics     *TrackerMetrics
+	backoff     time.Duration
+	maxBackoff  time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostBackoff
+}
+
+// Lookup implements lookup.Facilitator.
+func (f *backoffFacilitator) Lookup(ctx context.Context, url string, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	if until, ok := f.backingOff(url); ok {
+		return nil, fmt.Errorf("%w: %s until %s", ErrTrackerBackingOff, url, until.Format(time.RFC3339))
+	}
+
+	started := time.Now()
+	answer, err := f.facilitator.Lookup(ctx, url, question)
+	if f.metrics != nil {
+		f.metrics.Observe(url, time.Since(started), err)
+	}
+	// Lookups abandoned by their caller, e.g. because a faster tracker answered, say nothing about the host.
+	if err != nil && ctx.Err() == nil {
+		f.recordFailure(url)
+	} else if err == nil {
+		f.recordSuccess(url)
+	}
+	return answer, err
+}
+
+// backingOff reports whether lookups to the url are skipped, and until when.
+func (f *backoffFacilitator) backingOff(url string) (time.Time, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	host, ok := f.hosts[url]
+	if !ok || !time.Now().Before(host.until) {
+		return time.Time{}, false
+	}
+	return host.until, true
+}
+
+func (f *backoffFacilitator) recordFailure(url string) {
+	if f.backoff < 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	host, ok := f.hosts[url]
+	if !ok {
+		host = &hostBackoff{}
+		f.hosts[url] = host
+	}
+	backoff := f.backoff
+	for range host.failures {
+		if backoff >= f.maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	host.failures++
+	host.until = time.Now().Add(min(backoff, f.maxBackoff))
+}
+
+func (f *backoffFacilitator) recordSuccess(url string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.hosts, url)
+}
+
+// TrackerMetrics records per-host latency and error histograms of the lookups a LookupResolver sends to
+// SLAP trackers and to the hosts they point to. It is safe for concurrent use and implements expvar.Var,
+// so it can be published with expvar.Publish.
+type TrackerMetrics struct {
+	buckets []time.Duration
+
+	mu         sync.Mutex
+	histograms map[string]*LatencyHistogram
+}
+
+// NewTrackerMetrics creates TrackerMetrics using the given ascending bucket upper bounds,
+// or DefaultTopicManagerLatencyBuckets when none are given.
+func NewTrackerMetrics(buckets ...time.Duration) *TrackerMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultTopicManagerLatencyBuckets
+	}
+	return &TrackerMetrics{buckets: buckets, histograms: make(map[string]*LatencyHistogram)}
+}
+
+// Observe records a single lookup sent to the host.
+func (m *TrackerMetrics) Observe(host string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.histograms == nil {
+		m.histograms = make(map[string]*LatencyHistogram)
+	}
+	if len(m.buckets) == 0 {
+		m.buckets = DefaultTopicManagerLatencyBuckets
+	}
+	h, ok := m.histograms[host]
+	if !ok {
+		h = newLatencyHistogram(m.buckets)
+		m.histograms[host] = h
+	}
+	h.observe(latency, err)
+}
+
+// Snapshot returns a copy of the recorded histograms keyed by host.
+func (m *TrackerMetrics) Snapshot() map[string]LatencyHistogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]LatencyHistogram, len(m.histograms))
+	for host, h := range m.histograms {
+		snapshot[host] = h.clone()
+	}
+	return snapshot
+}
+
+// String returns the JSON encoded snapshot, implementing expvar.Var.
+func (m *TrackerMetrics) String() string {
+	bb, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
 }
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+)
+
+var (
+	// ErrLookupServiceAlreadyRegistered is returned when registering a lookup service under a name already in use
+	ErrLookupServiceAlreadyRegistered = errors.New("lookup service already registered")
+	// ErrLookupServiceNotRegistered is returned when unregistering a lookup service that is not registered
+	ErrLookupServiceNotRegistered = errors.New("lookup service not registered")
+	// ErrLookupServiceFactoryNotConfigured is returned when adding a lookup service by name without a LookupServiceFactory
+	ErrLookupServiceFactoryNotConfigured = errors.New("lookup service factory not configured")
+	// ErrInvalidLookupService is returned when registering a nil lookup service or one with an empty name
+	ErrInvalidLookupService = errors.New("invalid lookup service")
+)
+
+// LookupServiceFactory builds the lookup service registered under the given name.
+// It lets operators enable lookup services compiled into the node at runtime, e.g. over the admin API.
+type LookupServiceFactory func(ctx context.Context, name string) (LookupService, error)
+
+// lookupService returns the lookup service registered under the given name.
+func (e *Engine) lookupService(name string) (LookupService, bool) {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	service, ok := e.LookupServices[name]
+	return service, ok
+}
+
+// lookupServices returns the registered lookup services. Like topicManagers,
+// the returned map is never mutated by registration.
+func (e *Engine) lookupServices() map[string]LookupService {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	return e.LookupServices
+}
+
+// RegisterLookupService adds a lookup service to the running engine and synchronizes
+// the engine's SLAP advertisements so that peers learn about the new service.
+func (e *Engine) RegisterLookupService(ctx context.Context, name string, service LookupService) error {
+	if name == "" || service == nil {
+		slog.Error("invalid lookup service in RegisterLookupService", "service", name, "error", ErrInvalidLookupService)
+		return ErrInvalidLookupService
+	}
+
+	e.registryLock().Lock()
+	if _, ok := e.LookupServices[name]; ok {
+		e.registryLock().Unlock()
+		slog.Error("lookup service already registered", "service", name, "error", ErrLookupServiceAlreadyRegistered)
+		return ErrLookupServiceAlreadyRegistered
+	}
+	services := maps.Clone(e.LookupServices)
+	if services == nil {
+		services = make(map[string]LookupService)
+	}
+	services[name] = service
+	e.LookupServices = services
+	e.registryLock().Unlock()
+
+	slog.Info("lookup service registered", "service", name)
+	e.syncRegisteredAdvertisements(ctx)
+	return nil
+}
+
+// AddLookupService builds the named lookup service with the LookupServiceFactory and registers it.
+func (e *Engine) AddLookupService(ctx context.Context, name string) error {
+	if e.LookupServiceFactory == nil {
+		slog.Error("cannot add lookup service", "service", name, "error", ErrLookupServiceFactoryNotConfigured)
+		return ErrLookupServiceFactoryNotConfigured
+	}
+	service, err := e.LookupServiceFactory(ctx, name)
+	if err != nil {
+		slog.Error("failed to build lookup service", "service", name, "error", err)
+		return err
+	}
+	return e.RegisterLookupService(ctx, name, service)
+}
+
+// UnregisterLookupService removes a lookup service from the running engine and synchronizes
+// the engine's SLAP advertisements so that the service's advertisement is revoked.
+// The lookup service stops receiving output notifications; its own records are left untouched.
+func (e *Engine) UnregisterLookupService(ctx context.Context, name string) error {
+	e.registryLock().Lock()
+	if _, ok := e.LookupServices[name]; !ok {
+		e.registryLock().Unlock()
+		slog.Error("lookup service not registered", "service", name, "error", ErrLookupServiceNotRegistered)
+		return ErrLookupServiceNotRegistered
+	}
+	services := maps.Clone(e.LookupServices)
+	delete(services, name)
+	e.LookupServices = services
+	e.registryLock().Unlock()
+	e.invalidateLookupAnswers(name)
+
+	slog.Info("lookup service unregistered", "service", name)
+	e.syncRegisteredAdvertisements(ctx)
+	return nil
+}
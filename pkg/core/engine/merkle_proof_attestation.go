@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var (
+	// ErrChainTrackerRequired is returned when a merkle proof attesting a block hash is delivered to an engine without a chain tracker
+	ErrChainTrackerRequired = errors.New("a chain tracker is required to validate the block of a merkle proof")
+
+	// ErrBlockHashMismatch is returned when the block hash attested with a merkle proof is not the block at the proof height
+	ErrBlockHashMismatch = errors.New("block hash does not match the chain at the proof height")
+)
+
+// MerkleProofAttestation identifies the block a merkle proof belongs to and the service that delivered it.
+type MerkleProofAttestation struct {
+	BlockHash *chainhash.Hash // block the proof claims to belong to; nil when not attested
+	Source    string          // service that delivered the proof, e.g. the ARC instance; empty when unknown
+}
+
+// BlockHashTracker is an optional ChainTracker capability resolving the hash of the block at a height,
+// used to check the block hash attested with a merkle proof.
+type BlockHashTracker interface {
+	// BlockHashAtHeight returns the hash of the block at the given height of the active chain.
+	BlockHashAtHeight(ctx context.Context, height uint32) (*chainhash.Hash, error)
+}
+
+// MerkleProofRecord records the delivery of a merkle proof for auditing.
+type MerkleProofRecord struct {
+	Txid        chainhash.Hash
+	BlockHeight uint32
+	BlockHash   *chainhash.Hash // nil when not attested
+	Source      string
+	ReceivedAt  time.Time
+}
+
+// MerkleProofAuditStorage is an optional Storage capability used to keep the source of every attested
+// merkle proof, so that a proof attached by a faulty miner or ARC instance can be traced back to it.
+type MerkleProofAuditStorage interface {
+	// InsertMerkleProofRecord appends the record to the audit log of merkle proof deliveries.
+	InsertMerkleProofRecord(ctx context.Context, record *MerkleProofRecord) error
+}
+
+// HandleAttestedMerkleProof validates the attestation of a merkle proof before applying it with HandleNewMerkleProof.
+// When a block hash is attested, the proof must compute a valid root at its height according to the ChainTracker and,
+// when the ChainTracker implements BlockHashTracker, the block at that height must have the attested hash. Once the
+// proof is applied, the attestation is recorded when the storage implements MerkleProofAuditStorage.
+func (e *Engine) HandleAttestedMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath, attestation MerkleProofAttestation) error {
+	if attestation.BlockHash != nil {
+		if err := e.verifyAttestedBlock(ctx, txid, proof, attestation.BlockHash); err != nil {
+			slog.Error("rejecting attested merkle proof", "txid", txid, "blockHeight", proof.BlockHeight, "blockHash", attestation.BlockHash, "source", attestation.Source, "error", err)
+			return err
+		}
+	}
+
+	if err := e.HandleNewMerkleProof(ctx, txid, proof); err != nil {
+		return err
+	}
+
+	audits, ok := storageCapability[MerkleProofAuditStorage](e.Storage)
+	if !ok || (attestation.BlockHash == nil && attestation.Source == "") {
+		return nil
+	}
+	record := &MerkleProofRecord{
+		Txid:        *txid,
+		BlockHeight: proof.BlockHeight,
+		BlockHash:   attestation.BlockHash,
+		Source:      attestation.Source,
+		ReceivedAt:  time.Now(),
+	}
+	if err := e.trackWrite(audits.InsertMerkleProofRecord(ctx, record)); err != nil {
+		slog.Error("failed to record merkle proof source", "txid", txid, "source", attestation.Source, "error", err)
+		return err
+	}
+	return nil
+}
+
+// verifyAttestedBlock checks that the proof belongs to the block with the given hash on the chain followed by the ChainTracker.
+func (e *Engine) verifyAttestedBlock(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath, blockHash *chainhash.Hash) error {
+	if e.ChainTracker == nil {
+		return ErrChainTrackerRequired
+	}
+	if e.VerifiedTxs == nil || !e.VerifiedTxs.Contains(*txid, proof) {
+		valid, err := proof.Verify(ctx, txid, e.ChainTracker)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("%w: root is not valid at height %d", ErrInvalidMerkleProof, proof.BlockHeight)
+		}
+		if e.VerifiedTxs != nil {
+			e.VerifiedTxs.Add(*txid, proof)
+		}
+	}
+
+	tracker, ok := e.ChainTracker.(BlockHashTracker)
+	if !ok {
+		return nil
+	}
+	actual, err := tracker.BlockHashAtHeight(ctx, proof.BlockHeight)
+	if err != nil {
+		return err
+	}
+	if !actual.IsEqual(blockHash) {
+		return fmt.Errorf("%w: block %d is %s, not %s", ErrBlockHashMismatch, proof.BlockHeight, actual, blockHash)
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+package engine
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/broadcaster"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+const (
+	// DefaultProofFetchInterval is how often the background proof fetcher runs when no interval is configured.
+	DefaultProofFetchInterval = 10 * time.Minute
+
+	// DefaultProofFetchBatchSize is how many unmined transactions a proof fetch run scans when no batch size is configured.
+	DefaultProofFetchBatchSize = 100
+
+	// DefaultProofSourceTimeout bounds a single request to a merkle proof source.
+	DefaultProofSourceTimeout = 30 * time.Second
+
+	// DefaultWhatsOnChainURL is the WhatsOnChain API used when no URL is configured; the network is appended to it.
+	DefaultWhatsOnChainURL = "https://api.whatsonchain.com/v1/bsv"
+
+	// DefaultJungleBusURL is the JungleBus API used when no URL is configured.
+	DefaultJungleBusURL = "https://junglebus.gorillapool.io"
+
+	// maxProofResponseSize bounds the responses read from a merkle proof source.
+	maxProofResponseSize = 1 << 20
+)
+
+// Merkle proof sources supported by NewMerkleProofSource.
+const (
+	MerkleProofSourceARC          = "arc"
+	MerkleProofSourceWhatsOnChain = "whatsonchain"
+	MerkleProofSourceJungleBus    = "junglebus"
+)
+
+var (
+	// ErrMerkleProofNotFound is returned by a MerkleProofSource when the transaction is not mined yet
+	ErrMerkleProofNotFound = errors.New("merkle proof not found")
+
+	// ErrUnknownMerkleProofSource is returned when configuring a merkle proof source that is not supported
+	ErrUnknownMerkleProofSource = errors.New("unknown merkle proof source")
+
+	// ErrInvalidMerkleProof is returned when a fetched merkle proof does not prove the transaction
+	ErrInvalidMerkleProof = errors.New("invalid merkle proof")
+
+	// ErrUnminedLookupNotSupported is returned when fetching proofs with a storage that does not implement UnminedTransactionStorage
+	ErrUnminedLookupNotSupported = errors.New("storage does not support finding unmined transactions")
+
+	// ErrProofFetcherNotConfigured is returned when fetching proofs with an engine without a proof fetcher
+	ErrProofFetcherNotConfigured = errors.New("no merkle proof fetcher configured")
+)
+
+// UnminedTransactionStorage is an optional Storage capability used to find the transactions
+// whose merkle proof was never delivered.
+type UnminedTransactionStorage interface {
+	// FindUnminedTransactions returns the ids of up to limit transactions with outputs that have
+	// no block height yet, oldest admission first.
+	FindUnminedTransactions(ctx context.Context, limit uint32) ([]*chainhash.Hash, error)
+}
+
+// MerkleProofSource fetches the merkle proofs of mined transactions from an external service.
+type MerkleProofSource interface {
+	// FetchMerkleProof returns the merkle path of the transaction, or ErrMerkleProofNotFound when it is not mined yet.
+	FetchMerkleProof(ctx context.Context, txid *chainhash.Hash) (*transaction.MerklePath, error)
+}
+
+// MerkleProofFetcherConfig configures the background acquisition of merkle proofs that were not
+// delivered by ARC callbacks.
+type MerkleProofFetcherConfig struct {
+	// Source is the service proofs are fetched from: MerkleProofSourceARC, MerkleProofSourceWhatsOnChain
+	// or MerkleProofSourceJungleBus.
+	Source string `mapstructure:"source"`
+
+	// URL is the base URL of the source. It is required for ARC and defaults to the public API otherwise.
+	URL string `mapstructure:"url"`
+
+	// APIKey, when set, authenticates the requests to the source.
+	APIKey string `mapstructure:"api_key"`
+
+	// Network is the WhatsOnChain network, "main" or "test". Defaults to "main".
+	Network string `mapstructure:"network"`
+
+	// Interval is how often the background fetcher runs. Zero falls back to DefaultProofFetchInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MinBlocks is how many blocks a transaction must stay unmined before its proof is fetched,
+	// leaving time for the ARC callback to arrive first.
+	MinBlocks uint32 `mapstructure:"min_blocks"`
+
+	// BatchSize is how many unmined transactions a run scans. Zero falls back to DefaultProofFetchBatchSize.
+	BatchSize uint32 `mapstructure:"batch_size"`
+}
+
+// NewMerkleProofSource creates the merkle proof source described by the configuration.
+func NewMerkleProofSource(cfg MerkleProofFetcherConfig) (MerkleProofSource, error) {
+	client := &http.Client{Timeout: DefaultProofSourceTimeout}
+	switch cfg.Source {
+	case MerkleProofSourceARC:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("%w: ARC requires a URL", ErrUnknownMerkleProofSource)
+		}
+		return &arcProofSource{url: strings.TrimRight(cfg.URL, "/"), apiKey: cfg.APIKey, client: client}, nil
+	case MerkleProofSourceWhatsOnChain:
+		network := cfg.Network
+		if network == "" {
+			network = "main"
+		}
+		url := cfg.URL
+		if url == "" {
+			url = DefaultWhatsOnChainURL + "/" + network
+		}
+		return &whatsOnChainProofSource{url: strings.TrimRight(url, "/"), apiKey: cfg.APIKey, client: client}, nil
+	case MerkleProofSourceJungleBus:
+		url := cfg.URL
+		if url == "" {
+			url = DefaultJungleBusURL
+		}
+		return &jungleBusProofSource{url: strings.TrimRight(url, "/"), apiKey: cfg.APIKey, client: client}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMerkleProofSource, cfg.Source)
+	}
+}
+
+// MerkleProofFetcher periodically fetches the merkle proofs of transactions that stayed unmined,
+// closing the gap left when ARC callbacks are not delivered.
+type MerkleProofFetcher struct {
+	Source    MerkleProofSource
+	Interval  time.Duration
+	MinBlocks uint32
+	BatchSize uint32
+
+	mu        sync.Mutex
+	firstSeen map[chainhash.Hash]uint32 // chain height at which each unmined transaction was first scanned
+}
+
+// NewMerkleProofFetcher creates a MerkleProofFetcher using the source described by the configuration.
+func NewMerkleProofFetcher(cfg MerkleProofFetcherConfig) (*MerkleProofFetcher, error) {
+	source, err := NewMerkleProofSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &MerkleProofFetcher{
+		Source:    source,
+		Interval:  cfg.Interval,
+		MinBlocks: cfg.MinBlocks,
+		BatchSize: cfg.BatchSize,
+	}, nil
+}
+
+// due returns the transactions unmined for at least MinBlocks blocks and forgets the transactions
+// that are no longer unmined.
+func (f *MerkleProofFetcher) due(txids []*chainhash.Hash, height uint32, heightKnown bool) []*chainhash.Hash {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seen := make(map[chainhash.Hash]uint32, len(txids))
+	due := make([]*chainhash.Hash, 0, len(txids))
+	for _, txid := range txids {
+		first, ok := f.firstSeen[*txid]
+		if !ok {
+			first = height
+		}
+		seen[*txid] = first
+		if !heightKnown || (height >= first && height-first >= f.MinBlocks) {
+			due = append(due, txid)
+		}
+	}
+	f.firstSeen = seen
+	return due
+}
+
+// ProofFetchReport describes the outcome of a proof fetch run.
+type ProofFetchReport struct {
+	Scanned int               // unmined transactions found in storage
+	Fetched []*chainhash.Hash // transactions whose proof was fetched and applied
+	Pending int               // transactions not unmined for long enough or not mined yet
+	Failed  int               // transactions whose proof could not be fetched or applied
+}
+
+// FetchMerkleProofs fetches the proofs of the transactions that stayed unmined for at least
+// MerkleProofFetcher.MinBlocks blocks and applies them with HandleNewMerkleProof. Fetched proofs
+// are verified against the ChainTracker when one is configured; without one, every unmined
+// transaction is due. A transaction that fails is logged and retried on the next run.
+func (e *Engine) FetchMerkleProofs(ctx context.Context) (*ProofFetchReport, error) {
+	if e.ProofFetcher == nil {
+		slog.Error("cannot fetch merkle proofs", "error", ErrProofFetcherNotConfigured)
+		return nil, ErrProofFetcherNotConfigured
+	}
+	unmined, ok := storageCapability[UnminedTransactionStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot fetch merkle proofs", "error", ErrUnminedLookupNotSupported)
+		return nil, ErrUnminedLookupNotSupported
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting FetchMerkleProofs in degraded mode", "error", err)
+		return nil, err
+	}
+
+	limit := e.ProofFetcher.BatchSize
+	if limit == 0 {
+		limit = DefaultProofFetchBatchSize
+	}
+	txids, err := unmined.FindUnminedTransactions(ctx, limit)
+	if err != nil {
+		slog.Error("failed to find unmined transactions", "error", err)
+		return nil, err
+	}
+
+	var height uint32
+	if e.ChainTracker != nil {
+		if height, err = e.ChainTracker.CurrentHeight(ctx); err != nil {
+			slog.Error("failed to get current chain height", "error", err)
+			return nil, err
+		}
+	}
+
+	report := &ProofFetchReport{Scanned: len(txids)}
+	due := e.ProofFetcher.due(txids, height, e.ChainTracker != nil)
+	report.Pending = len(txids) - len(due)
+	for _, txid := range due {
+		proof, err := e.ProofFetcher.Source.FetchMerkleProof(ctx, txid)
+		if errors.Is(err, ErrMerkleProofNotFound) {
+			report.Pending++
+			continue
+		} else if err == nil && e.ChainTracker != nil {
+			var valid bool
+			if valid, err = proof.Verify(ctx, txid, e.ChainTracker); err == nil && !valid {
+				err = ErrInvalidMerkleProof
+			}
+		}
+		if err == nil {
+			err = e.HandleNewMerkleProof(ctx, txid, proof)
+		}
+		if errors.Is(err, ErrEngineStopping) {
+			return nil, err
+		} else if err != nil {
+			slog.Error("failed to acquire merkle proof", "txid", txid, "error", err)
+			report.Failed++
+			continue
+		}
+		report.Fetched = append(report.Fetched, txid)
+	}
+	slog.Info("merkle proofs fetched", "scanned", report.Scanned, "fetched", len(report.Fetched), "pending", report.Pending, "failed", report.Failed)
+	return report, nil
+}
+
+// RunProofFetcher fetches merkle proofs every MerkleProofFetcher.Interval until ctx is done or the engine stops.
+// It returns immediately when no proof fetcher is configured. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunProofFetcher(ctx context.Context) {
+	if e.ProofFetcher == nil {
+		return
+	}
+	interval := e.ProofFetcher.Interval
+	if interval <= 0 {
+		interval = DefaultProofFetchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.FetchMerkleProofs(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled merkle proof fetch failed", "interval", interval, "error", err)
+		}
+	}
+}
+
+// fetchProof sends a GET request to the source and returns the response body, mapping 404 to ErrMerkleProofNotFound.
+func fetchProof(ctx context.Context, client *http.Client, url, authorization string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrMerkleProofNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, &util.HTTPError{StatusCode: resp.StatusCode, Err: fmt.Errorf("merkle proof source responded with %s", resp.Status)} //nolint:err113 // dynamic error needed for context
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxProofResponseSize))
+}
+
+// arcProofSource fetches merkle paths from the transaction status endpoint of ARC.
+type arcProofSource struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func (s *arcProofSource) FetchMerkleProof(ctx context.Context, txid *chainhash.Hash) (*transaction.MerklePath, error) {
+	var authorization string
+	if s.apiKey != "" {
+		authorization = "Bearer " + s.apiKey
+	}
+	body, err := fetchProof(ctx, s.client, s.url+"/v1/tx/"+txid.String(), authorization)
+	if err != nil {
+		return nil, err
+	}
+	var status broadcaster.ArcResponse
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, err
+	}
+	if status.MerklePath == "" {
+		return nil, ErrMerkleProofNotFound
+	}
+	return transaction.NewMerklePathFromHex(status.MerklePath)
+}
+
+// jungleBusProofSource fetches binary merkle paths from JungleBus.
+type jungleBusProofSource struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func (s *jungleBusProofSource) FetchMerkleProof(ctx context.Context, txid *chainhash.Hash) (*transaction.MerklePath, error) {
+	body, err := fetchProof(ctx, s.client, s.url+"/v1/transaction/proof/"+txid.String(), s.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, ErrMerkleProofNotFound
+	}
+	return transaction.NewMerklePathFromBinary(body)
+}
+
+// whatsOnChainProofSource fetches TSC proofs from WhatsOnChain and converts them into merkle paths.
+type whatsOnChainProofSource struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// tscProof is a merkle proof in the TSC format, whose nodes are listed from the leaf level up
+// and where "*" duplicates the other node of the pair.
+type tscProof struct {
+	Index  uint64   `json:"index"`
+	Target string   `json:"target"`
+	Nodes  []string `json:"nodes"`
+}
+
+func (s *whatsOnChainProofSource) FetchMerkleProof(ctx context.Context, txid *chainhash.Hash) (*transaction.MerklePath, error) {
+	body, err := fetchProof(ctx, s.client, s.url+"/tx/"+txid.String()+"/proof/tsc", s.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	var proofs []tscProof
+	if err := json.Unmarshal(body, &proofs); err != nil {
+		return nil, err
+	}
+	if len(proofs) == 0 {
+		return nil, ErrMerkleProofNotFound
+	}
+	proof := proofs[0]
+
+	body, err = fetchProof(ctx, s.client, s.url+"/block/hash/"+proof.Target, s.apiKey)
+	if err != nil {
+		return nil, err
+	}
+	var block struct {
+		Height uint32 `json:"height"`
+	}
+	if err := json.Unmarshal(body, &block); err != nil {
+		return nil, err
+	}
+	return merklePathFromTSC(txid, block.Height, proof)
+}
+
+// merklePathFromTSC converts a TSC proof of the transaction mined at the given height into a merkle path.
+func merklePathFromTSC(txid *chainhash.Hash, height uint32, proof tscProof) (*transaction.MerklePath, error) {
+	isTxid, duplicate := true, true
+	path := make([][]*transaction.PathElement, max(len(proof.Nodes), 1))
+	path[0] = []*transaction.PathElement{{Offset: proof.Index, Hash: txid, Txid: &isTxid}}
+	for level, node := range proof.Nodes {
+		sibling := &transaction.PathElement{Offset: (proof.Index >> level) ^ 1}
+		if node == "*" {
+			sibling.Duplicate = &duplicate
+		} else {
+			hash, err := chainhash.NewHashFromHex(node)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidMerkleProof, err)
+			}
+			sibling.Hash = hash
+		}
+		path[level] = append(path[level], sibling)
+	}
+	slices.SortFunc(path[0], func(a, b *transaction.PathElement) int { return cmp.Compare(a.Offset, b.Offset) })
+	return transaction.NewMerklePath(height, path), nil
+}
@@ -0,0 +1,314 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultImmutableConfirmations is how many blocks must confirm a transaction, the block mining it included,
+	// before its outputs become immutable when no number of confirmations is configured.
+	DefaultImmutableConfirmations = 6
+
+	// DefaultMerkleStateSyncInterval is how often the merkle states of mined outputs are synced when no interval is configured.
+	DefaultMerkleStateSyncInterval = 10 * time.Minute
+
+	// DefaultMerkleStateBatchSize is how many mined outputs of each topic a sync checks when no batch size is configured.
+	DefaultMerkleStateBatchSize = 1000
+)
+
+var (
+	// ErrMerkleStatesNotSupported is returned when syncing merkle states with a storage that does not implement MerkleStateStorage
+	ErrMerkleStatesNotSupported = errors.New("storage does not support tracking merkle states")
+
+	// ErrInvalidMerkleStateTransition is returned when moving an output to a merkle state its current state cannot lead to
+	ErrInvalidMerkleStateTransition = errors.New("invalid merkle state transition")
+
+	// ErrMissingMerkleProof is returned when the BEEF of a mined output holds no merkle proof of its transaction
+	ErrMissingMerkleProof = errors.New("missing merkle proof")
+)
+
+// MerkleState describes whether the transaction of an output is proven to be mined.
+type MerkleState string
+
+const (
+	// MerkleStateUnmined is the state of outputs still waiting for the merkle proof of their transaction.
+	MerkleStateUnmined MerkleState = "unmined"
+	// MerkleStateMined is the state of outputs whose transaction has a merkle proof.
+	MerkleStateMined MerkleState = "mined"
+	// MerkleStateInvalidated is the state of outputs whose merkle proof no longer verifies against the chain
+	// tracker, e.g. after a reorg, until a new proof is delivered.
+	MerkleStateInvalidated MerkleState = "invalidated"
+	// MerkleStateImmutable is the state of outputs whose transaction is confirmed by
+	// MerkleStateConfig.ImmutableConfirmations blocks. Their BEEF is trimmed and their proof is no longer updated.
+	MerkleStateImmutable MerkleState = "immutable"
+)
+
+// merkleStateTransitions lists the states each merkle state can lead to.
+var merkleStateTransitions = map[MerkleState][]MerkleState{
+	MerkleStateUnmined:     {MerkleStateMined},
+	MerkleStateMined:       {MerkleStateInvalidated, MerkleStateImmutable},
+	MerkleStateInvalidated: {MerkleStateMined},
+}
+
+// CanTransitionTo reports whether an output in the state can move to the next state.
+func (s MerkleState) CanTransitionTo(next MerkleState) bool {
+	return slices.Contains(merkleStateTransitions[s], next)
+}
+
+// MerkleStateConfig configures the background sync of the merkle states of mined outputs.
+type MerkleStateConfig struct {
+	// ImmutableConfirmations is how many blocks must confirm a transaction, the block mining it included, before its
+	// outputs become immutable. Zero falls back to DefaultImmutableConfirmations.
+	ImmutableConfirmations uint32 `mapstructure:"immutable_confirmations"`
+
+	// Interval is how often the background sync runs. Zero falls back to DefaultMerkleStateSyncInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// BatchSize is how many mined outputs of each topic a sync checks. Zero falls back to DefaultMerkleStateBatchSize.
+	BatchSize uint32 `mapstructure:"batch_size"`
+}
+
+// MerkleStateStorage is an optional Storage capability used to persist the merkle state of outputs, so that
+// outputs invalidated by a reorg or made immutable are tracked the same way by every backend.
+type MerkleStateStorage interface {
+	// UpdateMerkleState sets the merkle state of the output admitted into the given topic.
+	UpdateMerkleState(ctx context.Context, outpoint *transaction.Outpoint, topic string, state MerkleState) error
+
+	// FindOutpointsByMerkleState returns up to limit outpoints of the topic in the given state, spent or not,
+	// lowest block height first. Outputs stored without a merkle state are in the state derived from their block
+	// height: mined when they have one and unmined otherwise.
+	FindOutpointsByMerkleState(ctx context.Context, topic string, state MerkleState, limit uint32) ([]*transaction.Outpoint, error)
+}
+
+// MerkleStateSyncReport describes the outcome of a merkle state sync.
+type MerkleStateSyncReport struct {
+	Checked     int                     // mined outputs whose proof was verified
+	Invalidated []*transaction.Outpoint // outputs whose proof no longer verifies
+	Immutable   []*transaction.Outpoint // outputs confirmed by enough blocks to become immutable
+	Failed      int                     // outputs that could not be checked or updated
+}
+
+// CurrentMerkleState returns the merkle state of the output, derived from its block height when the storage does not persist it.
+func (o *Output) CurrentMerkleState() MerkleState {
+	if o.MerkleState != "" {
+		return o.MerkleState
+	}
+	if o.BlockHeight > 0 {
+		return MerkleStateMined
+	}
+	return MerkleStateUnmined
+}
+
+// SyncInvalidatedOutputs verifies the merkle proofs of the mined outputs of every topic against the chain tracker.
+// Outputs whose proof no longer verifies are invalidated: their block height is reset, so that their proof is fetched
+// again, and lookup services are notified with OutputBlockHeightUpdated. Outputs confirmed by
+// MerkleStateConfig.ImmutableConfirmations blocks become immutable and their BEEF is trimmed to their transaction and
+// its proof. An output that fails is logged and retried on the next sync.
+// Returns ErrMerkleStatesNotSupported when the storage does not implement MerkleStateStorage.
+func (e *Engine) SyncInvalidatedOutputs(ctx context.Context) (*MerkleStateSyncReport, error) {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting SyncInvalidatedOutputs while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	states, ok := storageCapability[MerkleStateStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot sync merkle states", "error", ErrMerkleStatesNotSupported)
+		return nil, ErrMerkleStatesNotSupported
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting SyncInvalidatedOutputs in degraded mode", "error", err)
+		return nil, err
+	}
+	currentHeight, err := e.ChainTracker.CurrentHeight(ctx)
+	if err != nil {
+		slog.Error("failed to get current height in SyncInvalidatedOutputs", "error", err)
+		return nil, err
+	}
+
+	run := &merkleStateSync{
+		report:        &MerkleStateSyncReport{},
+		currentHeight: currentHeight,
+		invalidated:   make(map[chainhash.Hash]struct{}),
+		trimmed:       make(map[chainhash.Hash]struct{}),
+	}
+	for topic := range e.topicManagers() {
+		outpoints, err := states.FindOutpointsByMerkleState(ctx, topic, MerkleStateMined, e.merkleStateBatchSize())
+		if err != nil {
+			slog.Error("failed to find mined outputs in SyncInvalidatedOutputs", "topic", topic, "error", err)
+			return nil, err
+		} else if len(outpoints) == 0 {
+			continue
+		}
+		outputs, err := e.Storage.FindOutputs(ctx, outpoints, topic, nil, true)
+		if err != nil {
+			slog.Error("failed to find outputs in SyncInvalidatedOutputs", "topic", topic, "error", err)
+			return nil, err
+		}
+		for _, output := range outputs {
+			if output == nil {
+				continue
+			}
+			if err := e.syncMerkleState(ctx, output, run); err != nil {
+				slog.Error("failed to sync merkle state", "outpoint", output.Outpoint.String(), "topic", topic, "error", err)
+				run.report.Failed++
+			}
+		}
+	}
+	slog.Info("merkle states synced", "checked", run.report.Checked, "invalidated", len(run.report.Invalidated), "immutable", len(run.report.Immutable), "failed", run.report.Failed)
+	return run.report, nil
+}
+
+// merkleStateSync holds the progress of a SyncInvalidatedOutputs run, whose per-transaction writes are
+// performed once for all the outputs of a transaction.
+type merkleStateSync struct {
+	report        *MerkleStateSyncReport
+	currentHeight uint32
+	invalidated   map[chainhash.Hash]struct{}
+	trimmed       map[chainhash.Hash]struct{}
+}
+
+// syncMerkleState invalidates the mined output when its proof no longer verifies, and makes it immutable when
+// it is confirmed by enough blocks.
+func (e *Engine) syncMerkleState(ctx context.Context, output *Output, run *merkleStateSync) error {
+	txid := output.Outpoint.Txid
+	beef, _, _, err := transaction.ParseBeef(output.Beef)
+	if err != nil {
+		return err
+	}
+	proof := beef.FindBumpByHash(&txid)
+	if proof == nil {
+		return ErrMissingMerkleProof
+	}
+	valid, err := proof.Verify(ctx, &txid, e.ChainTracker)
+	if err != nil {
+		return err
+	}
+	run.report.Checked++
+
+	if !valid {
+		if err := e.setMerkleState(ctx, output, MerkleStateInvalidated); err != nil {
+			return err
+		}
+		if err := e.trackWrite(e.Storage.UpdateOutputBlockHeight(ctx, &output.Outpoint, output.Topic, 0, 0, output.AncillaryBeef)); err != nil {
+			return err
+		}
+		run.report.Invalidated = append(run.report.Invalidated, &output.Outpoint)
+		if _, notified := run.invalidated[txid]; notified {
+			return nil
+		}
+		run.invalidated[txid] = struct{}{}
+		if e.VerifiedTxs != nil {
+			e.VerifiedTxs.Invalidate(txid)
+		}
+		for name, l := range e.lookupServices() {
+			if err := e.notifyLookupService(ctx, name, l, LookupHookOutputBlockHeightUpdated, func(ctx context.Context, l LookupService) error {
+				return l.OutputBlockHeightUpdated(ctx, &txid, 0, 0)
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if proof.BlockHeight+e.immutableConfirmations() > run.currentHeight+1 {
+		return nil
+	}
+	if _, done := run.trimmed[txid]; !done {
+		if _, err := e.compactTransactionBEEF(ctx, output); err != nil {
+			return err
+		}
+		run.trimmed[txid] = struct{}{}
+	}
+	if err := e.setMerkleState(ctx, output, MerkleStateImmutable); err != nil {
+		return err
+	}
+	run.report.Immutable = append(run.report.Immutable, &output.Outpoint)
+	return nil
+}
+
+// RunMerkleStateSync syncs the merkle states of mined outputs every MerkleStateConfig.Interval until ctx is done
+// or the engine stops. It returns immediately when no merkle state sync is configured. Failed runs are logged and
+// retried on the next tick.
+func (e *Engine) RunMerkleStateSync(ctx context.Context) {
+	if e.MerkleStates == nil {
+		return
+	}
+	interval := e.MerkleStates.Interval
+	if interval <= 0 {
+		interval = DefaultMerkleStateSyncInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.SyncInvalidatedOutputs(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled merkle state sync failed", "interval", interval, "error", err)
+		}
+	}
+}
+
+func (e *Engine) immutableConfirmations() uint32 {
+	if e.MerkleStates == nil || e.MerkleStates.ImmutableConfirmations == 0 {
+		return DefaultImmutableConfirmations
+	}
+	return e.MerkleStates.ImmutableConfirmations
+}
+
+func (e *Engine) merkleStateBatchSize() uint32 {
+	if e.MerkleStates == nil || e.MerkleStates.BatchSize == 0 {
+		return DefaultMerkleStateBatchSize
+	}
+	return e.MerkleStates.BatchSize
+}
+
+// setMerkleState moves the output to the given merkle state, persisting it when the storage implements
+// MerkleStateStorage. Returns ErrInvalidMerkleStateTransition when the current state cannot lead to it.
+func (e *Engine) setMerkleState(ctx context.Context, output *Output, state MerkleState) error {
+	if current := output.CurrentMerkleState(); current != state && !current.CanTransitionTo(state) {
+		slog.Error("invalid merkle state transition", "outpoint", output.Outpoint.String(), "topic", output.Topic, "from", current, "to", state, "error", ErrInvalidMerkleStateTransition)
+		return ErrInvalidMerkleStateTransition
+	}
+	if output.MerkleState == state {
+		return nil
+	}
+	output.MerkleState = state
+	states, ok := storageCapability[MerkleStateStorage](e.Storage)
+	if !ok {
+		return nil
+	}
+	if err := e.trackWrite(states.UpdateMerkleState(ctx, &output.Outpoint, output.Topic, state)); err != nil {
+		slog.Error("failed to update merkle state", "outpoint", output.Outpoint.String(), "topic", output.Topic, "state", state, "error", err)
+		return err
+	}
+	e.replicate(&Mutation{Op: MutationUpdateMerkleState, Outpoint: &output.Outpoint, Topic: output.Topic, MerkleState: state})
+	return nil
+}
+
+// mutableOutputs returns the outputs whose merkle proof may still be updated, leaving out the immutable ones.
+func mutableOutputs(txid *chainhash.Hash, outputs []*Output) []*Output {
+	mutable := outputs[:0:0]
+	for _, output := range outputs {
+		if output.CurrentMerkleState() == MerkleStateImmutable {
+			slog.Warn("ignoring merkle proof of immutable output", "txid", txid, "outpoint", output.Outpoint.String(), "topic", output.Topic)
+			continue
+		}
+		mutable = append(mutable, output)
+	}
+	return mutable
+}
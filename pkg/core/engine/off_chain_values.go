@@ -0,0 +1,39 @@
+package engine
+
+import "github.com/bsv-blockchain/go-sdk/overlay/lookup"
+
+// LookupOutputDetails is the Result of the output-list answers the engine hydrates from lookup formulas when any
+// of their outputs carries off-chain values. It holds the metadata and the off-chain values of every output of the
+// answer, in the same order, with nil for outputs without them. Answers whose outputs only carry metadata keep
+// the LookupOutputMetadata result.
+type LookupOutputDetails struct {
+	Metadata       LookupOutputMetadata
+	OffChainValues [][]byte
+}
+
+// LookupAnswerDetails returns the metadata and off-chain values of the outputs of an answer hydrated by the engine,
+// with an entry for every output of the answer. It reports false when the result of the answer is neither a
+// LookupOutputMetadata nor a LookupOutputDetails matching its outputs.
+func LookupAnswerDetails(answer *lookup.LookupAnswer) (LookupOutputDetails, bool) {
+	var details LookupOutputDetails
+	switch result := answer.Result.(type) {
+	case LookupOutputMetadata:
+		details.Metadata = result
+	case LookupOutputDetails:
+		details = result
+	default:
+		return LookupOutputDetails{}, false
+	}
+
+	n := len(answer.Outputs)
+	if details.Metadata == nil {
+		details.Metadata = make(LookupOutputMetadata, n)
+	}
+	if details.OffChainValues == nil {
+		details.OffChainValues = make([][]byte, n)
+	}
+	if len(details.Metadata) != n || len(details.OffChainValues) != n {
+		return LookupOutputDetails{}, false
+	}
+	return details, true
+}
@@ -0,0 +1,404 @@
+package engine
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultOutpointFilterExpectedOutputs is the number of outputs per topic a filter is sized for when no size is configured.
+	DefaultOutpointFilterExpectedOutputs = 100000
+
+	// DefaultOutpointFilterFalsePositiveRate is the targeted false positive rate when none is configured.
+	DefaultOutpointFilterFalsePositiveRate = 0.01
+
+	// DefaultOutpointFilterRebuildRatio is the share of deleted or excess outputs after which a filter is rebuilt
+	// when none is configured.
+	DefaultOutpointFilterRebuildRatio = 0.25
+
+	// outpointFilterPageSize is the number of outputs read from storage per FindUTXOsForTopic call while building a filter.
+	outpointFilterPageSize = 1000
+
+	// outpointFilterRetryInterval is how long a filter that failed to build is not consulted before it is built again.
+	outpointFilterRetryInterval = time.Minute
+)
+
+// ErrOutpointFilterStalled is returned when building a filter stops making progress because more outputs of the topic
+// share a score than fit in a page of FindUTXOsForTopic.
+var ErrOutpointFilterStalled = errors.New("outpoint filter build stalled on outputs sharing a score")
+
+// OutpointFilterConfig configures an OutpointFilter.
+type OutpointFilterConfig struct {
+	// ExpectedOutputs is the minimum number of outputs per topic the filters are sized for.
+	// Zero falls back to DefaultOutpointFilterExpectedOutputs.
+	ExpectedOutputs uint64 `mapstructure:"expected_outputs"`
+
+	// FalsePositiveRate is the targeted rate of inputs wrongly reported as possibly topical.
+	// Zero falls back to DefaultOutpointFilterFalsePositiveRate.
+	FalsePositiveRate float64 `mapstructure:"false_positive_rate"`
+
+	// RebuildRatio is the share of the outputs of a filter that may be deleted, or added beyond its size,
+	// before the filter is rebuilt from storage. Zero falls back to DefaultOutpointFilterRebuildRatio.
+	RebuildRatio float64 `mapstructure:"rebuild_ratio"`
+}
+
+// OutpointFilterTopicMetrics describes the filter of a single topic.
+type OutpointFilterTopicMetrics struct {
+	Built   bool   `json:"built"`
+	Outputs uint64 `json:"outputs"` // outputs the filter holds, including the ones deleted since it was built
+	Deleted uint64 `json:"deleted"` // outputs deleted since the filter was built
+	Bits    uint64 `json:"bits"`
+}
+
+// OutpointFilterMetrics describes the use of an OutpointFilter since the engine started.
+type OutpointFilterMetrics struct {
+	Checks         uint64                                `json:"checks"`         // inputs checked against a built filter
+	Skipped        uint64                                `json:"skipped"`        // inputs not looked up in storage
+	FalsePositives uint64                                `json:"falsePositives"` // inputs looked up in storage but not found
+	Builds         uint64                                `json:"builds"`
+	Topics         map[string]OutpointFilterTopicMetrics `json:"topics"`
+}
+
+// OutpointFilter is an in-memory probabilistic index of the outputs admitted into each topic. Submit and GASP
+// sync consult it before looking up the inputs of a transaction in storage, skipping the inputs that are
+// definitely not topical outputs. It never reports an output it holds as absent, but reports some absent
+// outputs as possibly present, at the configured false positive rate.
+//
+// The filter of a topic is built from the unspent outputs in storage the first time it is needed, then
+// maintained as outputs are admitted and deleted. Bloom filters cannot forget outputs, so the filter is
+// rebuilt in the background once enough outputs were deleted or added beyond its size. Outputs already
+// spent when the filter was built are not part of it: lookups of unmined transactions are therefore not
+// filtered under a ConflictPolicy other than ConflictPolicyNone, which detects conflicts through spent outputs.
+// It is safe for concurrent use and implements expvar.Var, so its metrics can be published with expvar.Publish.
+type OutpointFilter struct {
+	cfg OutpointFilterConfig
+
+	mu             sync.Mutex
+	topics         map[string]*topicOutpointFilter
+	checks         uint64
+	skipped        uint64
+	falsePositives uint64
+	builds         uint64
+}
+
+// topicOutpointFilter is the bloom filter of a topic. While it is being built, the outputs added
+// to the topic are kept in pending to be added to the new filter.
+type topicOutpointFilter struct {
+	bits     []uint64
+	hashes   uint64
+	capacity uint64
+	outputs  uint64
+	deleted  uint64
+	building bool
+	pending  []outpointHash
+	retryAt  time.Time
+}
+
+type outpointHash struct{ h1, h2 uint64 }
+
+// NewOutpointFilter creates an OutpointFilter with the given configuration. Its filters are built when first needed.
+func NewOutpointFilter(cfg OutpointFilterConfig) *OutpointFilter {
+	if cfg.ExpectedOutputs == 0 {
+		cfg.ExpectedOutputs = DefaultOutpointFilterExpectedOutputs
+	}
+	if cfg.FalsePositiveRate <= 0 || cfg.FalsePositiveRate >= 1 {
+		cfg.FalsePositiveRate = DefaultOutpointFilterFalsePositiveRate
+	}
+	if cfg.RebuildRatio <= 0 {
+		cfg.RebuildRatio = DefaultOutpointFilterRebuildRatio
+	}
+	return &OutpointFilter{cfg: cfg, topics: make(map[string]*topicOutpointFilter)}
+}
+
+// Build builds the filter of the topic from its unspent outputs in storage, replacing the current one.
+// Outputs added to the topic while it is being built are kept. Does nothing if the filter is already being built.
+func (f *OutpointFilter) Build(ctx context.Context, storage Storage, topic string) error {
+	f.mu.Lock()
+	current := f.topic(topic)
+	if current.building {
+		f.mu.Unlock()
+		return nil
+	}
+	current.building = true
+	current.pending = nil
+	f.mu.Unlock()
+
+	hashes, err := f.scan(ctx, storage, topic)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current.building = false
+	if err != nil {
+		current.pending = nil
+		current.retryAt = time.Now().Add(outpointFilterRetryInterval)
+		return err
+	}
+	hashes = append(hashes, current.pending...)
+	built := newTopicOutpointFilter(max(f.cfg.ExpectedOutputs, 2*uint64(len(hashes))), f.cfg.FalsePositiveRate)
+	for _, h := range hashes {
+		built.add(h)
+	}
+	f.topics[topic] = built
+	f.builds++
+	slog.Info("outpoint filter built", "topic", topic, "outputs", len(hashes), "bits", len(built.bits)*64)
+	return nil
+}
+
+// scan returns the hashes of the unspent outputs of the topic in storage. As pages start at an inclusive score,
+// the outputs at the score a page ends with are read again with the next page and skipped.
+func (f *OutpointFilter) scan(ctx context.Context, storage Storage, topic string) ([]outpointHash, error) {
+	var hashes []outpointHash
+	since := 0.0
+	boundary := make(map[transaction.Outpoint]struct{})
+	for {
+		page, err := storage.FindUTXOsForTopic(ctx, topic, since, outpointFilterPageSize, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, output := range page {
+			if _, seen := boundary[output.Outpoint]; seen && output.Score == since {
+				continue
+			}
+			hashes = append(hashes, hashOutpoint(&output.Outpoint))
+		}
+		if len(page) < outpointFilterPageSize {
+			return hashes, nil
+		}
+		last := page[len(page)-1].Score
+		if last <= since {
+			return nil, ErrOutpointFilterStalled
+		}
+		since = last
+		clear(boundary)
+		for _, output := range page {
+			if output.Score == last {
+				boundary[output.Outpoint] = struct{}{}
+			}
+		}
+	}
+}
+
+// Built reports whether the filter of the topic was built and can be consulted.
+func (f *OutpointFilter) Built(topic string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.topics[topic]
+	return ok && t.bits != nil
+}
+
+// MayContain reports whether the output may have been admitted into the topic. It reports true
+// for every output of a topic whose filter is not built.
+func (f *OutpointFilter) MayContain(topic string, outpoint *transaction.Outpoint) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.topics[topic]
+	if !ok || t.bits == nil {
+		return true
+	}
+	f.checks++
+	if t.contains(hashOutpoint(outpoint)) {
+		return true
+	}
+	f.skipped++
+	return false
+}
+
+// Add records the output as admitted into the topic.
+func (f *OutpointFilter) Add(topic string, outpoint *transaction.Outpoint) {
+	h := hashOutpoint(outpoint)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := f.topic(topic)
+	if t.building {
+		t.pending = append(t.pending, h)
+	}
+	if t.bits != nil {
+		t.add(h)
+	}
+}
+
+// MarkDeleted records that an output of the topic was deleted. Bloom filters cannot forget outputs,
+// so the filter keeps reporting it as possibly present until it is rebuilt.
+func (f *OutpointFilter) MarkDeleted(topic string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t, ok := f.topics[topic]; ok && t.bits != nil {
+		t.deleted++
+	}
+}
+
+// Reset forgets the filters of every topic, so that they are built again from storage when next needed.
+func (f *OutpointFilter) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for topic, t := range f.topics {
+		if !t.building {
+			delete(f.topics, topic)
+		}
+	}
+}
+
+// stale reports whether the filter of the topic is missing or must be rebuilt, and is not being built
+// nor waiting to retry a failed build.
+func (f *OutpointFilter) stale(topic string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.topics[topic]
+	if !ok {
+		return true
+	}
+	if t.building || time.Now().Before(t.retryAt) {
+		return false
+	}
+	if t.bits == nil {
+		return true
+	}
+	slack := uint64(float64(t.capacity) * f.cfg.RebuildRatio)
+	return t.deleted > slack || t.outputs > t.capacity+slack
+}
+
+// recordFalsePositives counts inputs looked up in storage on the filter's advice but not found.
+func (f *OutpointFilter) recordFalsePositives(n uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.falsePositives += n
+}
+
+// topic returns the filter of the topic, creating an unbuilt one if needed. Must be called with the lock held.
+func (f *OutpointFilter) topic(topic string) *topicOutpointFilter {
+	t, ok := f.topics[topic]
+	if !ok {
+		t = &topicOutpointFilter{}
+		f.topics[topic] = t
+	}
+	return t
+}
+
+// Metrics returns the counters of the filter and the state of the filter of every topic.
+func (f *OutpointFilter) Metrics() OutpointFilterMetrics {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	metrics := OutpointFilterMetrics{
+		Checks:         f.checks,
+		Skipped:        f.skipped,
+		FalsePositives: f.falsePositives,
+		Builds:         f.builds,
+		Topics:         make(map[string]OutpointFilterTopicMetrics, len(f.topics)),
+	}
+	for topic, t := range f.topics {
+		metrics.Topics[topic] = OutpointFilterTopicMetrics{
+			Built:   t.bits != nil,
+			Outputs: t.outputs,
+			Deleted: t.deleted,
+			Bits:    uint64(len(t.bits)) * 64,
+		}
+	}
+	return metrics
+}
+
+// String returns the JSON encoded metrics, implementing expvar.Var.
+func (f *OutpointFilter) String() string {
+	bb, err := json.Marshal(f.Metrics())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+// newTopicOutpointFilter returns an empty bloom filter sized for the capacity at the false positive rate.
+func newTopicOutpointFilter(capacity uint64, falsePositiveRate float64) *topicOutpointFilter {
+	bits := math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	words := max(uint64(bits+63)/64, 1)
+	hashes := max(uint64(math.Round(float64(words*64)/float64(capacity)*math.Ln2)), 1)
+	return &topicOutpointFilter{bits: make([]uint64, words), hashes: hashes, capacity: capacity}
+}
+
+func (t *topicOutpointFilter) add(h outpointHash) {
+	size := uint64(len(t.bits)) * 64
+	for i := range t.hashes {
+		bit := (h.h1 + i*h.h2) % size
+		t.bits[bit/64] |= 1 << (bit % 64)
+	}
+	t.outputs++
+}
+
+func (t *topicOutpointFilter) contains(h outpointHash) bool {
+	size := uint64(len(t.bits)) * 64
+	for i := range t.hashes {
+		bit := (h.h1 + i*h.h2) % size
+		if t.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashOutpoint derives the two hashes of the double hashing scheme from the txid, which is already
+// uniformly distributed, mixed with the output index.
+func hashOutpoint(outpoint *transaction.Outpoint) outpointHash {
+	index := uint64(outpoint.Index)
+	return outpointHash{
+		h1: binary.LittleEndian.Uint64(outpoint.Txid[0:8]) ^ (index * 0x9E3779B97F4A7C15),
+		h2: (binary.LittleEndian.Uint64(outpoint.Txid[8:16]) ^ (index * 0xC2B2AE3D27D4EB4F)) | 1,
+	}
+}
+
+// refreshOutpointFilter builds the filter of the topic in the background when it is missing or stale.
+func (e *Engine) refreshOutpointFilter(topic string) {
+	if !e.OutpointFilter.stale(topic) {
+		return
+	}
+	go func() {
+		if err := e.OutpointFilter.Build(context.Background(), e.Storage, topic); err != nil {
+			slog.Error("failed to build outpoint filter", "topic", topic, "error", err)
+		}
+	}()
+}
+
+// findInputs finds the outputs of the topic spent by the inputs, answering in their order with nil for
+// the inputs that are not topical outputs. Unless unfiltered, the inputs the OutpointFilter rules out
+// are not looked up in storage.
+func (e *Engine) findInputs(ctx context.Context, storage Storage, inpoints []*transaction.Outpoint, topic string, unfiltered bool) ([]*Output, error) {
+	if e.OutpointFilter == nil || unfiltered {
+		return storage.FindOutputs(ctx, inpoints, topic, nil, false)
+	}
+	e.refreshOutpointFilter(topic)
+	if !e.OutpointFilter.Built(topic) {
+		return storage.FindOutputs(ctx, inpoints, topic, nil, false)
+	}
+
+	candidates := make([]int, 0, len(inpoints))
+	lookups := make([]*transaction.Outpoint, 0, len(inpoints))
+	for vin, inpoint := range inpoints {
+		if e.OutpointFilter.MayContain(topic, inpoint) {
+			candidates = append(candidates, vin)
+			lookups = append(lookups, inpoint)
+		}
+	}
+	outputs := make([]*Output, len(inpoints))
+	if len(lookups) == 0 {
+		return outputs, nil
+	}
+	found, err := storage.FindOutputs(ctx, lookups, topic, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	var misses uint64
+	for i, vin := range candidates {
+		if i < len(found) && found[i] != nil {
+			outputs[vin] = found[i]
+		} else {
+			misses++
+		}
+	}
+	e.OutpointFilter.recordFalsePositives(misses)
+	return outputs, nil
+}
@@ -1,11 +1,24 @@
 package engine
 
 import (
+	"context"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
+const (
+	// OutputSourceSubmit is the Source recorded for outputs admitted through a direct submission.
+	OutputSourceSubmit = "submit"
+	// OutputSourceGASP is the Source recorded for outputs synced over GASP from an unnamed peer.
+	OutputSourceGASP = "gasp"
+	// OutputSourceSnapshot is the Source recorded for outputs imported from a snapshot of an unnamed peer.
+	OutputSourceSnapshot = "snapshot"
+)
+
 // Output represents a transaction output with its metadata, history, and BEEF data.
 type Output struct {
 	Outpoint        transaction.Outpoint
@@ -17,8 +30,49 @@ type Output struct {
 	ConsumedBy      []*transaction.Outpoint
 	BlockHeight     uint32
 	BlockIdx        uint64
-	Score           float64 // sort score for outputs. Usage is up to Storage implementation.
+	Score           float64 // sort score for outputs, set by the ScoreStrategy of the engine. Zero lets the Storage implementation assign it.
 	Beef            []byte
 	AncillaryTxids  []*chainhash.Hash
 	AncillaryBeef   []byte
+	ReceivedAt      time.Time // when this node admitted the output. Zero if the storage does not persist it.
+	Source          string    // where the output came from, e.g. OutputSourceSubmit or the GASP peer it was synced from.
+	Pinned          bool      // pinned outputs are never pruned or evicted. See OutputPinStorage.
+	Disputed        bool      // disputed outputs conflict with another unconfirmed transaction. See ConflictPolicyDispute.
+	// MerkleState tracks the merkle proof of the transaction of the output. Empty if the storage does not persist
+	// it, in which case it is derived from BlockHeight. See MerkleStateStorage.
+	MerkleState MerkleState
+	// Metadata holds the tags set by the topic manager when admitting the output. See OutputMetadataIdentifier.
+	// Nil if the output has none or the storage does not persist it.
+	Metadata map[string]string
+	// OffChainValues holds the off-chain values submitted with the transaction of the output, see
+	// overlay.TaggedBEEF.OffChainValues. Nil if there were none or the storage does not persist them.
+	OffChainValues []byte
+}
+
+type outputSourceKey struct{}
+
+// WithOutputSource returns a context that makes Submit record the given source on admitted outputs.
+func WithOutputSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, outputSourceKey{}, source)
+}
+
+// outputSource returns the source set with WithOutputSource, defaulting to OutputSourceSubmit.
+func outputSource(ctx context.Context) string {
+	if source, ok := ctx.Value(outputSourceKey{}).(string); ok && source != "" {
+		return source
+	}
+	return OutputSourceSubmit
+}
+
+// gaspProvenance returns the provenance served to GASP peers that request node metadata.
+func (o *Output) gaspProvenance() *gasp.NodeProvenance {
+	provenance := &gasp.NodeProvenance{
+		Source: o.Source,
+		Score:  o.Score,
+	}
+	if !o.ReceivedAt.IsZero() {
+		receivedAt := o.ReceivedAt.UTC()
+		provenance.ReceivedAt = &receivedAt
+	}
+	return provenance
 }
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// MaxOutpointsPerExistenceCheck is the number of outpoints a single HasOutputs call checks at most.
+const MaxOutpointsPerExistenceCheck = 1000
+
+// ErrTooManyOutpoints is returned when HasOutputs is asked to check more than MaxOutpointsPerExistenceCheck outpoints
+var ErrTooManyOutpoints = errors.New("too many outpoints")
+
+// HasOutputs reports, for each outpoint in order, whether the output is held by the topic. When unspentOnly
+// is set, spent outputs are reported as missing. The outpoints are looked up with a single FindOutputs call,
+// so that wallets can reconcile their state without a lookup per output.
+func (e *Engine) HasOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, unspentOnly bool) ([]bool, error) {
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("unknown topic in HasOutputs", "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	if len(outpoints) > MaxOutpointsPerExistenceCheck {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyOutpoints, len(outpoints), MaxOutpointsPerExistenceCheck)
+	}
+	exists := make([]bool, len(outpoints))
+	if len(outpoints) == 0 {
+		return exists, nil
+	}
+
+	var spent *bool
+	if unspentOnly {
+		spent = new(bool)
+	}
+	outputs, err := e.Storage.FindOutputs(ctx, outpoints, topic, spent, false)
+	if err != nil {
+		slog.Error("failed to find outputs in HasOutputs", "topic", topic, "count", len(outpoints), "error", err)
+		return nil, err
+	}
+	for i, output := range outputs {
+		if i < len(exists) && output != nil {
+			exists[i] = true
+		}
+	}
+	return exists, nil
+}
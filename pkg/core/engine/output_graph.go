@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Output graph walk bounds.
+const (
+	// DefaultOutputGraphDepth is the number of relations OutputGraph follows from the root when no depth is given.
+	DefaultOutputGraphDepth uint32 = 10
+
+	// MaxOutputGraphDepth bounds the depth of an OutputGraph walk.
+	MaxOutputGraphDepth uint32 = 100
+
+	// MaxOutputGraphNodes bounds the number of outputs of an OutputGraph; larger graphs are truncated.
+	MaxOutputGraphNodes = 1000
+)
+
+// ErrInvalidOutputGraphDepth is returned when an OutputGraph depth exceeds MaxOutputGraphDepth.
+var ErrInvalidOutputGraphDepth = errors.New("invalid output graph depth")
+
+// OutputGraphNode is an output of an OutputGraph.
+type OutputGraphNode struct {
+	Outpoint    transaction.Outpoint
+	Depth       uint32 // number of relations between the root and the output
+	Missing     bool   // the output is referenced by a relation but no longer stored in the topic; the fields below are unset
+	Spent       bool
+	Pinned      bool
+	BlockHeight uint32
+}
+
+// OutputGraphEdge is a relation of an OutputGraph: the transaction of To consumed From.
+type OutputGraphEdge struct {
+	From transaction.Outpoint
+	To   transaction.Outpoint
+}
+
+// OutputGraph is the dependency graph of an output in a topic, made of the outputs its transaction
+// consumed and the outputs consuming it, followed recursively.
+type OutputGraph struct {
+	Root      transaction.Outpoint
+	Topic     string
+	Depth     uint32
+	Nodes     []*OutputGraphNode // in breadth-first order from the root
+	Edges     []OutputGraphEdge
+	Truncated bool // the walk stopped at MaxOutputGraphNodes before reaching the depth
+}
+
+// OutputGraph walks the OutputsConsumed and ConsumedBy relations of the output admitted into the topic
+// up to depth relations away, 0 meaning DefaultOutputGraphDepth. Outputs referenced by a relation
+// but no longer stored, e.g. because deleteUTXODeep pruned them, are reported as missing rather than failing the walk.
+// It returns ErrUnknownTopic for topics the engine does not host and ErrMissingOutput when the output itself is not stored.
+func (e *Engine) OutputGraph(ctx context.Context, outpoint *transaction.Outpoint, topic string, depth uint32) (*OutputGraph, error) {
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("unknown topic in OutputGraph", "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	if depth > MaxOutputGraphDepth {
+		err := fmt.Errorf("%w: %d exceeds %d", ErrInvalidOutputGraphDepth, depth, MaxOutputGraphDepth)
+		slog.Error("rejecting OutputGraph", "outpoint", outpoint.String(), "topic", topic, "error", err)
+		return nil, err
+	} else if depth == 0 {
+		depth = DefaultOutputGraphDepth
+	}
+
+	root, err := e.Storage.FindOutput(ctx, outpoint, &topic, nil, false)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		slog.Error("failed to find output in OutputGraph", "outpoint", outpoint.String(), "topic", topic, "error", err)
+		return nil, err
+	} else if root == nil {
+		slog.Error("output of OutputGraph not found", "outpoint", outpoint.String(), "topic", topic, "error", ErrMissingOutput)
+		return nil, ErrMissingOutput
+	}
+
+	graph := &OutputGraph{Root: *outpoint, Topic: topic, Depth: depth}
+	seen := map[transaction.Outpoint]struct{}{*outpoint: {}}
+	edges := make(map[OutputGraphEdge]struct{})
+	level := []*Output{root}
+	for current := uint32(0); len(level) > 0; current++ {
+		var next []*transaction.Outpoint
+		for _, output := range level {
+			graph.Nodes = append(graph.Nodes, &OutputGraphNode{
+				Outpoint:    output.Outpoint,
+				Depth:       current,
+				Spent:       output.Spent,
+				Pinned:      output.Pinned,
+				BlockHeight: output.BlockHeight,
+			})
+			if current == depth {
+				continue
+			}
+			for _, consumed := range output.OutputsConsumed {
+				var held bool
+				if next, held = graph.visit(seen, next, consumed); held {
+					graph.addEdge(edges, OutputGraphEdge{From: *consumed, To: output.Outpoint})
+				}
+			}
+			for _, consumer := range output.ConsumedBy {
+				var held bool
+				if next, held = graph.visit(seen, next, consumer); held {
+					graph.addEdge(edges, OutputGraphEdge{From: output.Outpoint, To: *consumer})
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+
+		found, err := e.Storage.FindOutputs(ctx, next, topic, nil, false)
+		if err != nil {
+			slog.Error("failed to find outputs in OutputGraph", "outpoint", outpoint.String(), "topic", topic, "error", err)
+			return nil, err
+		}
+		level = nil
+		for i, related := range next {
+			if i < len(found) && found[i] != nil {
+				level = append(level, found[i])
+			} else {
+				graph.Nodes = append(graph.Nodes, &OutputGraphNode{Outpoint: *related, Depth: current + 1, Missing: true})
+			}
+		}
+	}
+	return graph, nil
+}
+
+// visit queues the related outpoint unless it was already seen or the graph is full, in which case it is truncated.
+// It reports whether the outpoint is, or is queued to be, a node of the graph.
+func (g *OutputGraph) visit(seen map[transaction.Outpoint]struct{}, next []*transaction.Outpoint, related *transaction.Outpoint) ([]*transaction.Outpoint, bool) {
+	if _, ok := seen[*related]; ok {
+		return next, true
+	}
+	if len(seen) >= MaxOutputGraphNodes {
+		g.Truncated = true
+		return next, false
+	}
+	seen[*related] = struct{}{}
+	return append(next, related), true
+}
+
+// addEdge adds the edge to the graph unless it holds it already.
+func (g *OutputGraph) addEdge(edges map[OutputGraphEdge]struct{}, edge OutputGraphEdge) {
+	if _, ok := edges[edge]; ok {
+		return
+	}
+	edges[edge] = struct{}{}
+	g.Edges = append(g.Edges, edge)
+}
+
+// DOT renders the graph in the Graphviz DOT language, with edges pointing from the consumed output
+// to the output of the consuming transaction. The root is drawn bold and missing outputs dashed.
+func (g *OutputGraph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.Topic)
+	for _, node := range g.Nodes {
+		var attrs []string
+		switch {
+		case node.Outpoint == g.Root:
+			attrs = append(attrs, "style=bold")
+		case node.Missing:
+			attrs = append(attrs, "style=dashed")
+		}
+		if node.Spent {
+			attrs = append(attrs, "color=gray")
+		}
+		fmt.Fprintf(&b, "  %q", node.Outpoint.String())
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %q -> %q;\n", edge.From.String(), edge.To.String())
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// OutputMetadataIdentifier is an optional TopicManager capability tagging the outputs it admits with metadata,
+// such as the parsed fields of a token. The metadata is stored with the outputs, passed to lookup services in
+// OutputAdmittedByTopic, and returned in lookup answers and output statuses, so that lookup services need no
+// parallel database for what the topic manager already parsed.
+type OutputMetadataIdentifier interface {
+	// IdentifyOutputMetadata returns the metadata of the outputs admitted by the instructions, keyed by output index.
+	// Outputs without metadata may be left out. An error fails the submission.
+	IdentifyOutputMetadata(ctx context.Context, beef []byte, admit overlay.AdmittanceInstructions) (map[uint32]map[string]string, error)
+}
+
+// LookupOutputMetadata is the Result of the output-list answers the engine hydrates from lookup formulas when any
+// of their outputs carries metadata. It holds the metadata of every output of the answer, in the same order,
+// with nil for outputs without metadata.
+type LookupOutputMetadata []map[string]string
+
+// identifyOutputMetadata asks the topic's manager for the metadata of the admitted outputs,
+// returning nil when the manager does not implement OutputMetadataIdentifier.
+func (e *Engine) identifyOutputMetadata(ctx context.Context, topic string, beef []byte, admit overlay.AdmittanceInstructions) (map[uint32]map[string]string, error) {
+	if len(admit.OutputsToAdmit) == 0 {
+		return nil, nil //nolint:nilnil // no admitted outputs, no metadata
+	}
+	manager, ok := e.topicManager(topic)
+	if !ok {
+		return nil, ErrUnknownTopic
+	}
+	identifier, ok := manager.(OutputMetadataIdentifier)
+	if !ok {
+		return nil, nil //nolint:nilnil // the manager does not tag outputs
+	}
+	return identifier.IdentifyOutputMetadata(ctx, beef, admit)
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrOutputPinningNotSupported is returned when pinning outputs with a storage that does not implement OutputPinStorage
+var ErrOutputPinningNotSupported = errors.New("storage does not support pinning outputs")
+
+// OutputPinStorage is an optional Storage capability used to pin outputs. Pinned outputs are never
+// pruned by the engine, and storage implementations applying their own retention or eviction
+// policies must keep them regardless of topical activity.
+type OutputPinStorage interface {
+	// UpdateOutputPinned sets the pinned flag of the output admitted into the given topic.
+	UpdateOutputPinned(ctx context.Context, outpoint *transaction.Outpoint, topic string, pinned bool) error
+}
+
+// PinOutput marks the output admitted into the given topic as never prunable or evictable.
+func (e *Engine) PinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	return e.setOutputPinned(ctx, outpoint, topic, true)
+}
+
+// UnpinOutput makes the output admitted into the given topic subject to the retention policy again.
+func (e *Engine) UnpinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	return e.setOutputPinned(ctx, outpoint, topic, false)
+}
+
+func (e *Engine) setOutputPinned(ctx context.Context, outpoint *transaction.Outpoint, topic string, pinned bool) error {
+	pins, ok := storageCapability[OutputPinStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot update output pin", "outpoint", outpoint.String(), "topic", topic, "error", ErrOutputPinningNotSupported)
+		return ErrOutputPinningNotSupported
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting output pin update in degraded mode", "outpoint", outpoint.String(), "topic", topic, "error", err)
+		return err
+	}
+
+	output, err := e.Storage.FindOutput(ctx, outpoint, &topic, nil, false)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		slog.Error("failed to find output to update pin", "outpoint", outpoint.String(), "topic", topic, "error", err)
+		return err
+	}
+	if output == nil {
+		slog.Error("output to update pin not found", "outpoint", outpoint.String(), "topic", topic, "error", ErrMissingOutput)
+		return ErrMissingOutput
+	}
+	if output.Pinned == pinned {
+		return nil
+	}
+
+	if err := e.trackWrite(pins.UpdateOutputPinned(ctx, outpoint, topic, pinned)); err != nil {
+		slog.Error("failed to update output pin", "outpoint", outpoint.String(), "topic", topic, "pinned", pinned, "error", err)
+		return err
+	}
+	e.replicate(&Mutation{Op: MutationUpdateOutputPinned, Outpoint: outpoint, Topic: topic, Pinned: pinned})
+	slog.Info("output pin updated", "outpoint", outpoint.String(), "topic", topic, "pinned", pinned)
+	return nil
+}
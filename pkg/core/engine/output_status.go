@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// OutputStatus reports what a topic knows about an outpoint.
+type OutputStatus struct {
+	Outpoint           transaction.Outpoint
+	Topic              string
+	TransactionApplied bool // the transaction was processed for the topic, whether or not the output was admitted
+	Admitted           bool // the output is held by the topic; the fields below are only set when it is
+	Spent              bool
+	BlockHeight        uint32
+	BlockIdx           uint64
+	ConsumedBy         []*transaction.Outpoint
+	MerkleState        MerkleState
+	Metadata           map[string]string // tags set by the topic manager, see OutputMetadataIdentifier
+}
+
+// GetOutputStatus reports whether the transaction of the outpoint was applied to the topic and, when the
+// output was admitted, its spent state, block position, consumers and merkle state. It answers "did my
+// transaction get admitted to this topic?" without querying the storage directly.
+func (e *Engine) GetOutputStatus(ctx context.Context, outpoint *transaction.Outpoint, topic string) (*OutputStatus, error) {
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("unknown topic in GetOutputStatus", "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+
+	applied, err := e.Storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: &outpoint.Txid, Topic: topic})
+	if err != nil {
+		slog.Error("failed to check if transaction was applied in GetOutputStatus", "outpoint", outpoint.String(), "topic", topic, "error", err)
+		return nil, err
+	}
+	status := &OutputStatus{Outpoint: *outpoint, Topic: topic, TransactionApplied: applied}
+	if !applied {
+		return status, nil
+	}
+
+	output, err := e.Storage.FindOutput(ctx, outpoint, &topic, nil, false)
+	if err != nil {
+		slog.Error("failed to find output in GetOutputStatus", "outpoint", outpoint.String(), "topic", topic, "error", err)
+		return nil, err
+	} else if output == nil {
+		return status, nil
+	}
+
+	status.Admitted = true
+	status.Spent = output.Spent
+	status.BlockHeight = output.BlockHeight
+	status.BlockIdx = output.BlockIdx
+	status.ConsumedBy = output.ConsumedBy
+	status.Metadata = output.Metadata
+	status.MerkleState = output.CurrentMerkleState()
+	return status, nil
+}
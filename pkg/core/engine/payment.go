@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+var (
+	// ErrInvalidPaymentTransaction is returned when a payment does not carry a parsable transaction.
+	ErrInvalidPaymentTransaction = errors.New("invalid payment transaction")
+
+	// ErrInsufficientPayment is returned when no output of a payment pays the required amount to the expected locking script.
+	ErrInsufficientPayment = errors.New("insufficient payment")
+
+	// ErrPaymentSPVFailed is returned when a payment transaction fails SPV verification.
+	ErrPaymentSPVFailed = errors.New("payment transaction failed SPV verification")
+)
+
+// VerifyPayment verifies that an output of the transaction carried by the (Atomic) BEEF pays at least the given amount
+// of satoshis to the locking script, validates the transaction with SPV against the chain tracker and broadcasts it.
+// Returns the outpoint of the paying output.
+func (e *Engine) VerifyPayment(ctx context.Context, beef []byte, lockingScript *script.Script, satoshis uint64) (*transaction.Outpoint, error) {
+	_, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPaymentTransaction, err)
+	}
+	if tx == nil {
+		return nil, ErrInvalidPaymentTransaction
+	}
+	txid := tx.TxID()
+
+	var outpoint *transaction.Outpoint
+	for vout, output := range tx.Outputs {
+		if output.LockingScript != nil && output.LockingScript.Equals(lockingScript) && output.Satoshis >= satoshis {
+			outpoint = &transaction.Outpoint{Txid: *txid, Index: uint32(vout)} //nolint:gosec // index bounded by slice length
+			break
+		}
+	}
+	if outpoint == nil {
+		return nil, fmt.Errorf("%w: expected %d satoshis", ErrInsufficientPayment, satoshis)
+	}
+
+	valid, err := e.verifySPV(ctx, tx)
+	if err != nil {
+		slog.Error("failed to verify payment transaction", "txid", txid, "error", err)
+		return nil, fmt.Errorf("%w: %w", ErrPaymentSPVFailed, err)
+	}
+	if !valid {
+		return nil, ErrPaymentSPVFailed
+	}
+
+	if e.Broadcaster != nil {
+		if _, failure := e.Broadcaster.BroadcastCtx(ctx, tx); failure != nil {
+			slog.Error("failed to broadcast payment transaction", "txid", txid, "error", failure)
+			return nil, failure
+		}
+	}
+	return outpoint, nil
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPeerFailureThreshold is the number of consecutive failures after which a peer is blacklisted.
+	DefaultPeerFailureThreshold = 3
+
+	// DefaultPeerBlacklistDuration is how long a misbehaving peer is skipped by GASP sync.
+	DefaultPeerBlacklistDuration = 30 * time.Minute
+)
+
+// PeerStats holds the sync track record of a single GASP peer.
+type PeerStats struct {
+	Successes           int
+	Failures            int
+	InvalidGraphs       int
+	ConsecutiveFailures int
+	AverageLatency      time.Duration
+	BlacklistedUntil    time.Time
+}
+
+// Score returns the peer's smoothed success rate in the range (0, 1); higher is better.
+// Invalid graph submissions count as failures.
+func (s PeerStats) Score() float64 {
+	return float64(s.Successes+1) / float64(s.Successes+s.Failures+s.InvalidGraphs+2)
+}
+
+// PeerReputation records per-peer GASP sync outcomes and uses them to deprioritize
+// or temporarily blacklist misbehaving peers. It is safe for concurrent use.
+type PeerReputation struct {
+	FailureThreshold  int
+	BlacklistDuration time.Duration
+
+	mu    sync.Mutex
+	peers map[string]*PeerStats
+}
+
+// NewPeerReputation creates a PeerReputation. Non-positive arguments fall back to
+// DefaultPeerFailureThreshold and DefaultPeerBlacklistDuration.
+func NewPeerReputation(failureThreshold int, blacklistDuration time.Duration) *PeerReputation {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultPeerFailureThreshold
+	}
+	if blacklistDuration <= 0 {
+		blacklistDuration = DefaultPeerBlacklistDuration
+	}
+	return &PeerReputation{
+		FailureThreshold:  failureThreshold,
+		BlacklistDuration: blacklistDuration,
+		peers:             make(map[string]*PeerStats),
+	}
+}
+
+// RecordSuccess records a successful sync with the peer and resets its consecutive failures.
+func (r *PeerReputation) RecordSuccess(peer string, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.statsFor(peer)
+	stats.Successes++
+	stats.ConsecutiveFailures = 0
+	r.observeLatency(stats, latency)
+}
+
+// RecordFailure records a failed sync with the peer, blacklisting it once the failure threshold is reached.
+func (r *PeerReputation) RecordFailure(peer string, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.statsFor(peer)
+	stats.Failures++
+	r.observeLatency(stats, latency)
+	r.strike(stats)
+}
+
+// RecordInvalidGraph records a graph from the peer that failed validation.
+func (r *PeerReputation) RecordInvalidGraph(peer string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.statsFor(peer)
+	stats.InvalidGraphs++
+	r.strike(stats)
+}
+
+// IsBlacklisted reports whether the peer is currently blacklisted.
+func (r *PeerReputation) IsBlacklisted(peer string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.peers[peer]
+	return ok && time.Now().Before(stats.BlacklistedUntil)
+}
+
+// Stats returns a snapshot of the peer's recorded statistics.
+func (r *PeerReputation) Stats(peer string) PeerStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stats, ok := r.peers[peer]; ok {
+		return *stats
+	}
+	return PeerStats{}
+}
+
+// Prioritize returns the peers that are not blacklisted, ordered from the best to the worst score.
+// Peers with equal scores are ordered by lower average latency, then by their original order.
+func (r *PeerReputation) Prioritize(peers []string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	prioritized := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		if stats, ok := r.peers[peer]; ok && now.Before(stats.BlacklistedUntil) {
+			continue
+		}
+		prioritized = append(prioritized, peer)
+	}
+
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		a, b := r.snapshot(prioritized[i]), r.snapshot(prioritized[j])
+		if a.Score() != b.Score() {
+			return a.Score() > b.Score()
+		}
+		return a.AverageLatency < b.AverageLatency
+	})
+	return prioritized
+}
+
+func (r *PeerReputation) statsFor(peer string) *PeerStats {
+	if r.peers == nil {
+		r.peers = make(map[string]*PeerStats)
+	}
+	stats, ok := r.peers[peer]
+	if !ok {
+		stats = &PeerStats{}
+		r.peers[peer] = stats
+	}
+	return stats
+}
+
+func (r *PeerReputation) snapshot(peer string) PeerStats {
+	if stats, ok := r.peers[peer]; ok {
+		return *stats
+	}
+	return PeerStats{}
+}
+
+func (r *PeerReputation) observeLatency(stats *PeerStats, latency time.Duration) {
+	samples := stats.Successes + stats.Failures
+	stats.AverageLatency += (latency - stats.AverageLatency) / time.Duration(samples)
+}
+
+func (r *PeerReputation) strike(stats *PeerStats) {
+	threshold, duration := r.FailureThreshold, r.BlacklistDuration
+	if threshold <= 0 {
+		threshold = DefaultPeerFailureThreshold
+	}
+	if duration <= 0 {
+		duration = DefaultPeerBlacklistDuration
+	}
+
+	stats.ConsecutiveFailures++
+	if stats.ConsecutiveFailures >= threshold {
+		stats.BlacklistedUntil = time.Now().Add(duration)
+		stats.ConsecutiveFailures = 0
+	}
+}
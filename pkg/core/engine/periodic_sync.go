@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrPeriodicSyncNotConfigured is returned when changing the sync interval of an engine without a PeriodicSync.
+var ErrPeriodicSyncNotConfigured = errors.New("periodic sync not configured")
+
+// PeriodicSync schedules GASP syncs of every topic at an interval that can be changed while the engine runs,
+// e.g. when the configuration of the node is reloaded. A zero interval pauses the syncs until a positive one is set.
+type PeriodicSync struct {
+	mu       sync.Mutex
+	interval time.Duration
+	changed  chan struct{}
+}
+
+// NewPeriodicSync creates a PeriodicSync syncing every interval.
+func NewPeriodicSync(interval time.Duration) *PeriodicSync {
+	return &PeriodicSync{interval: interval, changed: make(chan struct{})}
+}
+
+// Interval returns the current interval between syncs.
+func (p *PeriodicSync) Interval() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.interval
+}
+
+// SetInterval changes the interval between syncs. The next sync is scheduled an interval after the change.
+func (p *PeriodicSync) SetInterval(interval time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if interval == p.interval {
+		return
+	}
+	p.interval = interval
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// next returns the current interval and a channel closed once it changes.
+func (p *PeriodicSync) next() (time.Duration, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.interval, p.changed
+}
+
+// SetSyncInterval changes the interval of the periodic GASP syncs of a running engine.
+// Returns ErrPeriodicSyncNotConfigured when the engine has no PeriodicSync.
+func (e *Engine) SetSyncInterval(interval time.Duration) error {
+	if e.PeriodicSync == nil {
+		slog.Error("cannot change sync interval", "interval", interval, "error", ErrPeriodicSyncNotConfigured)
+		return ErrPeriodicSyncNotConfigured
+	}
+	e.PeriodicSync.SetInterval(interval)
+	slog.Info("sync interval changed", "interval", interval)
+	return nil
+}
+
+// RunPeriodicSync runs StartGASPSync every PeriodicSync interval until ctx is done or the engine stops.
+// It returns immediately when no PeriodicSync is configured. Failed syncs are logged and retried on the next tick.
+func (e *Engine) RunPeriodicSync(ctx context.Context) {
+	if e.PeriodicSync == nil {
+		return
+	}
+	for {
+		interval, changed := e.PeriodicSync.next()
+		var tick <-chan time.Time
+		var timer *time.Timer
+		if interval > 0 {
+			timer = time.NewTimer(interval)
+			tick = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-changed:
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		case <-tick:
+		}
+		if err := e.StartGASPSync(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil && ctx.Err() == nil {
+			slog.Error("scheduled GASP sync failed", "interval", interval, "error", err)
+		}
+	}
+}
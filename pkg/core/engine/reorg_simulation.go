@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrInvalidReorgDepth is returned when simulating a reorg deeper than the current chain or of depth zero
+var ErrInvalidReorgDepth = errors.New("invalid reorg depth")
+
+// OutputsByBlockHeightStorage is an optional Storage capability used by SimulateReorg to find every
+// output, spent or not, mined at or above a block height. Without it the simulation only covers UTXOs.
+type OutputsByBlockHeightStorage interface {
+	// FindOutputsSinceBlockHeight returns all outputs mined at or above the given block height.
+	FindOutputsSinceBlockHeight(ctx context.Context, blockHeight uint32, includeBEEF bool) ([]*Output, error)
+}
+
+// ReorgAffectedOutput is an output whose merkle proof would be invalidated by a reorg.
+type ReorgAffectedOutput struct {
+	Outpoint    transaction.Outpoint
+	Topic       string
+	BlockHeight uint32
+	Spent       bool
+}
+
+// ReorgTopicImpact summarizes the impact of a reorg on a single topic.
+type ReorgTopicImpact struct {
+	Outputs      int
+	Transactions int
+}
+
+// ReorgSimulation is the report of a simulated reorg. Re-sync work is estimated as the
+// distinct transactions whose proofs would have to be fetched again and their BEEF size.
+type ReorgSimulation struct {
+	Depth              uint32
+	CurrentHeight      uint32
+	ForkHeight         uint32 // lowest block height that would be reorganized
+	UTXOsOnly          bool   // set when the storage cannot report spent outputs
+	InvalidatedOutputs []*ReorgAffectedOutput
+	AffectedTopics     map[string]*ReorgTopicImpact
+	ResyncTransactions int
+	ResyncBytes        int
+}
+
+// SimulateReorg reports which outputs' proofs a reorg of the given depth would invalidate,
+// which topics it would affect and the estimated re-sync work, without mutating the storage.
+func (e *Engine) SimulateReorg(ctx context.Context, depth uint32) (*ReorgSimulation, error) {
+	currentHeight, err := e.ChainTracker.CurrentHeight(ctx)
+	if err != nil {
+		slog.Error("failed to get current height in SimulateReorg", "error", err)
+		return nil, err
+	}
+	if depth == 0 || depth > currentHeight+1 {
+		slog.Error("invalid reorg depth in SimulateReorg", "depth", depth, "currentHeight", currentHeight, "error", ErrInvalidReorgDepth)
+		return nil, ErrInvalidReorgDepth
+	}
+
+	simulation := &ReorgSimulation{
+		Depth:          depth,
+		CurrentHeight:  currentHeight,
+		ForkHeight:     currentHeight + 1 - depth,
+		AffectedTopics: make(map[string]*ReorgTopicImpact),
+	}
+	outputs, err := e.findOutputsSinceBlockHeight(ctx, simulation)
+	if err != nil {
+		return nil, err
+	}
+
+	resyncTxs := make(map[chainhash.Hash]struct{})
+	topicTxs := make(map[string]map[chainhash.Hash]struct{})
+	for _, output := range outputs {
+		// Unmined outputs carry no proof to invalidate.
+		if output.BlockHeight < simulation.ForkHeight || output.BlockHeight == 0 {
+			continue
+		}
+		simulation.InvalidatedOutputs = append(simulation.InvalidatedOutputs, &ReorgAffectedOutput{
+			Outpoint:    output.Outpoint,
+			Topic:       output.Topic,
+			BlockHeight: output.BlockHeight,
+			Spent:       output.Spent,
+		})
+
+		impact, ok := simulation.AffectedTopics[output.Topic]
+		if !ok {
+			impact = &ReorgTopicImpact{}
+			simulation.AffectedTopics[output.Topic] = impact
+			topicTxs[output.Topic] = make(map[chainhash.Hash]struct{})
+		}
+		impact.Outputs++
+		if _, seen := topicTxs[output.Topic][output.Outpoint.Txid]; !seen {
+			topicTxs[output.Topic][output.Outpoint.Txid] = struct{}{}
+			impact.Transactions++
+		}
+		if _, seen := resyncTxs[output.Outpoint.Txid]; !seen {
+			resyncTxs[output.Outpoint.Txid] = struct{}{}
+			simulation.ResyncBytes += len(output.Beef)
+		}
+	}
+	simulation.ResyncTransactions = len(resyncTxs)
+
+	sort.Slice(simulation.InvalidatedOutputs, func(i, j int) bool {
+		a, b := simulation.InvalidatedOutputs[i], simulation.InvalidatedOutputs[j]
+		if a.BlockHeight != b.BlockHeight {
+			return a.BlockHeight < b.BlockHeight
+		}
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Outpoint.String() < b.Outpoint.String()
+	})
+	return simulation, nil
+}
+
+// findOutputsSinceBlockHeight returns the outputs mined at or above the simulation's fork height,
+// falling back to scanning the UTXOs of every managed topic when the storage cannot filter by height.
+func (e *Engine) findOutputsSinceBlockHeight(ctx context.Context, simulation *ReorgSimulation) ([]*Output, error) {
+	if storage, ok := storageCapability[OutputsByBlockHeightStorage](e.Storage); ok {
+		outputs, err := storage.FindOutputsSinceBlockHeight(ctx, simulation.ForkHeight, true)
+		if err != nil {
+			slog.Error("failed to find outputs since block height in SimulateReorg", "blockHeight", simulation.ForkHeight, "error", err)
+			return nil, err
+		}
+		return outputs, nil
+	}
+
+	simulation.UTXOsOnly = true
+	var outputs []*Output
+	for topic := range e.topicManagers() {
+		utxos, err := e.Storage.FindUTXOsForTopic(ctx, topic, 0, 0, true)
+		if err != nil {
+			slog.Error("failed to find UTXOs for topic in SimulateReorg", "topic", topic, "error", err)
+			return nil, err
+		}
+		outputs = append(outputs, utxos...)
+	}
+	return outputs, nil
+}
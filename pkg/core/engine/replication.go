@@ -0,0 +1,604 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultReplicationLogCapacity is the number of mutations a ReplicationLog keeps when no capacity is given.
+	DefaultReplicationLogCapacity = 100000
+
+	// DefaultReplicationRetryInterval is how long a ReplicationFollower waits before reconnecting to the primary.
+	DefaultReplicationRetryInterval = 5 * time.Second
+
+	// DefaultReplicationBatchSize bounds the number of mutations returned by a single NextMutations call.
+	DefaultReplicationBatchSize = 1000
+
+	// DefaultReplicationPollTimeout is how long the primary holds a mutation stream request open while no new mutation is recorded.
+	DefaultReplicationPollTimeout = 25 * time.Second
+
+	// ReplicationStreamPath is the path of the mutation stream served by a primary overlay node.
+	ReplicationStreamPath = "/api/v1/replication/stream"
+)
+
+var (
+	// ErrReplicationGap is returned when a follower asks for mutations the primary no longer holds, either because
+	// they were evicted from its ReplicationLog or because the primary restarted with a new epoch. The standby
+	// storage must then be reseeded, e.g. with a GASP sync, before following the primary again.
+	ErrReplicationGap = errors.New("replication-gap")
+
+	// ErrReplicationNotConfigured is returned when streaming mutations from an engine whose storage is not a ReplicatingStorage.
+	ErrReplicationNotConfigured = errors.New("replication not configured")
+
+	// ErrNotStandby is returned when promoting an engine that does not follow a primary.
+	ErrNotStandby = errors.New("node is not a standby")
+
+	// ErrStandbyNode is returned, wrapped in a StorageReadOnlyError, by writes to a standby that was not promoted yet.
+	ErrStandbyNode = errors.New("node is a standby replica")
+)
+
+// MutationOp identifies the storage write carried by a Mutation.
+type MutationOp string
+
+const (
+	MutationInsertOutput             MutationOp = "insert-output"
+	MutationDeleteOutput             MutationOp = "delete-output"
+	MutationMarkUTXOsAsSpent         MutationOp = "mark-utxos-as-spent"
+	MutationUpdateConsumedBy         MutationOp = "update-consumed-by"
+	MutationUpdateTransactionBEEF    MutationOp = "update-transaction-beef"
+	MutationUpdateOutputBlockHeight  MutationOp = "update-output-block-height"
+	MutationInsertAppliedTransaction MutationOp = "insert-applied-transaction"
+	MutationUpdateLastInteraction    MutationOp = "update-last-interaction"
+	MutationUpdateOutputDisputed     MutationOp = "update-output-disputed"
+	MutationTombstoneOutput          MutationOp = "tombstone-output"
+	MutationPurgeTombstones          MutationOp = "purge-tombstones"
+	MutationUpdateMerkleState        MutationOp = "update-merkle-state"
+	MutationMarkUTXOsAsUnspent       MutationOp = "mark-utxos-as-unspent"
+	MutationUpdateOutputScore        MutationOp = "update-output-score"
+	MutationUpdateOutputPinned       MutationOp = "update-output-pinned"
+)
+
+// Mutation is a storage write streamed from a primary to its standby. Only the fields used by Op are set.
+// Epoch identifies the ReplicationLog that assigned Seq, so that a follower notices when the primary restarted.
+type Mutation struct {
+	Epoch              string                      `json:"epoch"`
+	Seq                uint64                      `json:"seq"`
+	Op                 MutationOp                  `json:"op"`
+	Output             *Output                     `json:"output,omitempty"`
+	Outpoint           *transaction.Outpoint       `json:"outpoint,omitempty"`
+	Outpoints          []*transaction.Outpoint     `json:"outpoints,omitempty"`
+	Topic              string                      `json:"topic,omitempty"`
+	Txid               *chainhash.Hash             `json:"txid,omitempty"`
+	Beef               []byte                      `json:"beef,omitempty"`
+	BlockHeight        uint32                      `json:"blockHeight,omitempty"`
+	BlockIdx           uint64                      `json:"blockIdx,omitempty"`
+	AncillaryBeef      []byte                      `json:"ancillaryBeef,omitempty"`
+	AppliedTransaction *overlay.AppliedTransaction `json:"appliedTransaction,omitempty"`
+	Host               string                      `json:"host,omitempty"`
+	Since              float64                     `json:"since,omitempty"`
+	Disputed           bool                        `json:"disputed,omitempty"`
+	Reason             TombstoneReason             `json:"reason,omitempty"`
+	DeletedAt          time.Time                   `json:"deletedAt,omitzero"`
+	MerkleState        MerkleState                 `json:"merkleState,omitempty"`
+	Score              float64                     `json:"score,omitempty"`
+	Pinned             bool                        `json:"pinned,omitempty"`
+}
+
+// Apply performs the mutation on the given storage.
+func (m *Mutation) Apply(ctx context.Context, storage Storage) error {
+	switch m.Op {
+	case MutationInsertOutput:
+		return storage.InsertOutput(ctx, m.Output)
+	case MutationDeleteOutput:
+		return storage.DeleteOutput(ctx, m.Outpoint, m.Topic)
+	case MutationMarkUTXOsAsSpent:
+		return storage.MarkUTXOsAsSpent(ctx, m.Outpoints, m.Topic, m.Txid)
+	case MutationUpdateConsumedBy:
+		return storage.UpdateConsumedBy(ctx, m.Outpoint, m.Topic, m.Outpoints)
+	case MutationUpdateTransactionBEEF:
+		return storage.UpdateTransactionBEEF(ctx, m.Txid, m.Beef)
+	case MutationUpdateOutputBlockHeight:
+		return storage.UpdateOutputBlockHeight(ctx, m.Outpoint, m.Topic, m.BlockHeight, m.BlockIdx, m.AncillaryBeef)
+	case MutationInsertAppliedTransaction:
+		return storage.InsertAppliedTransaction(ctx, m.AppliedTransaction)
+	case MutationUpdateLastInteraction:
+		return storage.UpdateLastInteraction(ctx, m.Host, m.Topic, m.Since)
+	case MutationUpdateOutputDisputed:
+		if disputes, ok := storageCapability[OutputDisputeStorage](storage); ok {
+			return disputes.UpdateOutputDisputed(ctx, m.Outpoint, m.Topic, m.Disputed)
+		}
+		return nil
+	case MutationTombstoneOutput:
+		if tombstones, ok := storageCapability[OutputTombstoneStorage](storage); ok {
+			return tombstones.TombstoneOutput(ctx, m.Outpoint, m.Topic, m.Reason, m.DeletedAt)
+		}
+		return storage.DeleteOutput(ctx, m.Outpoint, m.Topic)
+	case MutationPurgeTombstones:
+		if tombstones, ok := storageCapability[OutputTombstoneStorage](storage); ok {
+			_, err := tombstones.PurgeTombstones(ctx, m.DeletedAt)
+			return err
+		}
+		return nil
+	case MutationUpdateMerkleState:
+		if states, ok := storageCapability[MerkleStateStorage](storage); ok {
+			return states.UpdateMerkleState(ctx, m.Outpoint, m.Topic, m.MerkleState)
+		}
+		return nil
+	case MutationMarkUTXOsAsUnspent:
+		if rollback, ok := storageCapability[SpendRollbackStorage](storage); ok {
+			return rollback.MarkUTXOsAsUnspent(ctx, m.Outpoints, m.Topic)
+		}
+		return nil
+	case MutationUpdateOutputScore:
+		if scores, ok := storageCapability[OutputScoreStorage](storage); ok {
+			return scores.UpdateOutputScore(ctx, m.Outpoint, m.Topic, m.Score)
+		}
+		return nil
+	case MutationUpdateOutputPinned:
+		if pins, ok := storageCapability[OutputPinStorage](storage); ok {
+			return pins.UpdateOutputPinned(ctx, m.Outpoint, m.Topic, m.Pinned)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown mutation op %q", m.Op) //nolint:err113 // dynamic error needed for context
+	}
+}
+
+// ReplicationLog keeps the most recent storage mutations of a primary in a ring buffer so that standbys can stream them.
+// Mutations are numbered from 1 within the epoch of the log, which is chosen randomly when the log is created.
+// It is safe for concurrent use.
+type ReplicationLog struct {
+	epoch    string
+	capacity int
+
+	mu       sync.Mutex
+	entries  []*Mutation
+	lastSeq  uint64
+	appended chan struct{}
+}
+
+// NewReplicationLog creates a ReplicationLog keeping up to capacity mutations.
+// A capacity of zero or less falls back to DefaultReplicationLogCapacity.
+func NewReplicationLog(capacity int) *ReplicationLog {
+	if capacity <= 0 {
+		capacity = DefaultReplicationLogCapacity
+	}
+	epoch := make([]byte, 8)
+	_, _ = rand.Read(epoch)
+	return &ReplicationLog{
+		epoch:    hex.EncodeToString(epoch),
+		capacity: capacity,
+		appended: make(chan struct{}),
+	}
+}
+
+// Epoch returns the epoch of the log.
+func (l *ReplicationLog) Epoch() string {
+	return l.epoch
+}
+
+// LastSeq returns the sequence number of the latest mutation, or zero when none was appended.
+func (l *ReplicationLog) LastSeq() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastSeq
+}
+
+// Append numbers the mutation, stores it and wakes up the streams waiting for it.
+// The oldest mutation is evicted once the log is full.
+func (l *ReplicationLog) Append(m *Mutation) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastSeq++
+	m.Epoch = l.epoch
+	m.Seq = l.lastSeq
+	if len(l.entries) < l.capacity {
+		l.entries = append(l.entries, m)
+	} else {
+		l.entries[(m.Seq-1)%uint64(l.capacity)] = m
+	}
+	close(l.appended)
+	l.appended = make(chan struct{})
+}
+
+// Next returns up to limit mutations following since, in order, waiting for a new mutation when there is none yet.
+// It returns no mutations when ctx is done first. An empty epoch is accepted by any log. Next returns
+// ErrReplicationGap when the epoch does not match the log or the mutations following since were evicted.
+func (l *ReplicationLog) Next(ctx context.Context, epoch string, since uint64, limit int) ([]*Mutation, error) {
+	for {
+		batch, appended, err := l.after(epoch, since, limit)
+		if err != nil || len(batch) > 0 {
+			return batch, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-appended:
+		}
+	}
+}
+
+// after returns up to limit mutations following since and a channel closed on the next Append.
+func (l *ReplicationLog) after(epoch string, since uint64, limit int) ([]*Mutation, <-chan struct{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if epoch != "" && epoch != l.epoch {
+		return nil, nil, fmt.Errorf("%w: epoch %s does not match the primary epoch %s", ErrReplicationGap, epoch, l.epoch)
+	}
+	if since > l.lastSeq {
+		return nil, nil, fmt.Errorf("%w: sequence %d is ahead of the primary sequence %d", ErrReplicationGap, since, l.lastSeq)
+	}
+	oldest := l.lastSeq - uint64(len(l.entries)) + 1
+	if since+1 < oldest {
+		return nil, nil, fmt.Errorf("%w: sequence %d was evicted, the oldest retained is %d", ErrReplicationGap, since+1, oldest)
+	}
+	last := min(l.lastSeq, since+uint64(max(limit, 1)))
+	batch := make([]*Mutation, 0, last-since)
+	for seq := since + 1; seq <= last; seq++ {
+		batch = append(batch, l.entries[(seq-1)%uint64(l.capacity)])
+	}
+	return batch, l.appended, nil
+}
+
+// ReplicatingStorage wraps the Storage of a primary and records every successful write in a ReplicationLog.
+// Outputs pruned by retention and dispute flags are recorded too. Other node-local state kept by optional
+// storage capabilities, such as pins, dead letters and GASP checkpoints, is not replicated.
+type ReplicatingStorage struct {
+	Storage
+	log *ReplicationLog
+}
+
+// NewReplicatingStorage wraps storage so that its writes are recorded in log.
+func NewReplicatingStorage(storage Storage, log *ReplicationLog) *ReplicatingStorage {
+	return &ReplicatingStorage{Storage: storage, log: log}
+}
+
+// Unwrap returns the wrapped storage, letting the engine find its optional capabilities.
+func (s *ReplicatingStorage) Unwrap() Storage {
+	return s.Storage
+}
+
+// Log returns the ReplicationLog the writes are recorded in.
+func (s *ReplicatingStorage) Log() *ReplicationLog {
+	return s.log
+}
+
+func (s *ReplicatingStorage) record(err error, m *Mutation) error {
+	if err == nil {
+		s.log.Append(m)
+	}
+	return err
+}
+
+func (s *ReplicatingStorage) InsertOutput(ctx context.Context, utxo *Output) error {
+	return s.record(s.Storage.InsertOutput(ctx, utxo), &Mutation{Op: MutationInsertOutput, Output: utxo})
+}
+
+func (s *ReplicatingStorage) DeleteOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	return s.record(s.Storage.DeleteOutput(ctx, outpoint, topic), &Mutation{Op: MutationDeleteOutput, Outpoint: outpoint, Topic: topic})
+}
+
+func (s *ReplicatingStorage) MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+	return s.record(s.Storage.MarkUTXOsAsSpent(ctx, outpoints, topic, spendTxid), &Mutation{Op: MutationMarkUTXOsAsSpent, Outpoints: outpoints, Topic: topic, Txid: spendTxid})
+}
+
+func (s *ReplicatingStorage) UpdateConsumedBy(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error {
+	return s.record(s.Storage.UpdateConsumedBy(ctx, outpoint, topic, consumedBy), &Mutation{Op: MutationUpdateConsumedBy, Outpoint: outpoint, Topic: topic, Outpoints: consumedBy})
+}
+
+func (s *ReplicatingStorage) UpdateTransactionBEEF(ctx context.Context, txid *chainhash.Hash, beef []byte) error {
+	return s.record(s.Storage.UpdateTransactionBEEF(ctx, txid, beef), &Mutation{Op: MutationUpdateTransactionBEEF, Txid: txid, Beef: beef})
+}
+
+func (s *ReplicatingStorage) UpdateOutputBlockHeight(ctx context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error {
+	return s.record(s.Storage.UpdateOutputBlockHeight(ctx, outpoint, topic, blockHeight, blockIndex, ancillaryBeef), &Mutation{
+		Op:            MutationUpdateOutputBlockHeight,
+		Outpoint:      outpoint,
+		Topic:         topic,
+		BlockHeight:   blockHeight,
+		BlockIdx:      blockIndex,
+		AncillaryBeef: ancillaryBeef,
+	})
+}
+
+func (s *ReplicatingStorage) InsertAppliedTransaction(ctx context.Context, tx *overlay.AppliedTransaction) error {
+	return s.record(s.Storage.InsertAppliedTransaction(ctx, tx), &Mutation{Op: MutationInsertAppliedTransaction, AppliedTransaction: tx})
+}
+
+func (s *ReplicatingStorage) UpdateLastInteraction(ctx context.Context, host, topic string, since float64) error {
+	return s.record(s.Storage.UpdateLastInteraction(ctx, host, topic, since), &Mutation{Op: MutationUpdateLastInteraction, Host: host, Topic: topic, Since: since})
+}
+
+// storageUnwrapper is implemented by storage decorators such as ReplicatingStorage.
+type storageUnwrapper interface {
+	Unwrap() Storage
+}
+
+// storageCapability returns the first storage implementing the optional capability T,
+// looking through storage decorators.
+func storageCapability[T any](storage Storage) (T, bool) {
+	for storage != nil {
+		if capability, ok := storage.(T); ok {
+			return capability, true
+		}
+		unwrapper, ok := storage.(storageUnwrapper)
+		if !ok {
+			break
+		}
+		storage = unwrapper.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
+
+// replicate records a write performed through an optional storage capability, bypassing ReplicatingStorage.
+func (e *Engine) replicate(m *Mutation) {
+	if replicating, ok := storageCapability[*ReplicatingStorage](e.Storage); ok {
+		replicating.log.Append(m)
+	}
+}
+
+// NextMutations returns up to DefaultReplicationBatchSize storage mutations following since in the given epoch,
+// waiting for a new mutation until ctx is done when there is none yet. It returns ErrReplicationNotConfigured
+// when the storage is not a ReplicatingStorage and ErrReplicationGap when the requested mutations are no longer available.
+func (e *Engine) NextMutations(ctx context.Context, epoch string, since uint64) ([]*Mutation, error) {
+	replicating, ok := storageCapability[*ReplicatingStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot stream storage mutations", "error", ErrReplicationNotConfigured)
+		return nil, ErrReplicationNotConfigured
+	}
+	batch, err := replicating.log.Next(ctx, epoch, since, DefaultReplicationBatchSize)
+	if err != nil {
+		slog.Error("cannot stream storage mutations to standby", "epoch", epoch, "since", since, "error", err)
+		return nil, err
+	}
+	return batch, nil
+}
+
+// ReplicationSource returns the storage mutations of a primary following the given epoch and sequence,
+// waiting for a new one when there is none yet. Implementations return ErrReplicationGap when the primary
+// no longer holds them. Engine implements ReplicationSource for standbys running in the same process.
+type ReplicationSource interface {
+	NextMutations(ctx context.Context, epoch string, since uint64) ([]*Mutation, error)
+}
+
+// StandbyConfig configures a standby following a primary overlay node over HTTP.
+type StandbyConfig struct {
+	// PrimaryURL is the base URL of the primary. An empty URL disables the standby mode.
+	PrimaryURL string `mapstructure:"primary_url"`
+
+	// Token is the replication token the primary requires on its mutation stream.
+	Token string `mapstructure:"token"`
+
+	// RetryInterval is how long to wait before reconnecting. Zero falls back to DefaultReplicationRetryInterval.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+}
+
+// NewHTTPReplicationSource returns a ReplicationSource reading the mutation stream of the primary at cfg.PrimaryURL.
+func NewHTTPReplicationSource(cfg StandbyConfig) ReplicationSource {
+	return &httpReplicationSource{
+		primaryURL: strings.TrimRight(cfg.PrimaryURL, "/"),
+		token:      cfg.Token,
+		client:     &http.Client{},
+	}
+}
+
+// httpReplicationSource reads the newline delimited JSON mutations served at ReplicationStreamPath.
+// The primary ends each response once it sent a batch or DefaultReplicationPollTimeout elapsed.
+type httpReplicationSource struct {
+	primaryURL string
+	token      string
+	client     *http.Client
+}
+
+func (h *httpReplicationSource) NextMutations(ctx context.Context, epoch string, since uint64) ([]*Mutation, error) {
+	query := url.Values{"since": {strconv.FormatUint(since, 10)}}
+	if epoch != "" {
+		query.Set("epoch", epoch)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.primaryURL+ReplicationStreamPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+h.token)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		// The stream parameters sent by the follower are always well formed, so the primary
+		// rejects them only when it cannot serve the requested position anymore.
+		return nil, ErrReplicationGap
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("primary responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg))) //nolint:err113 // dynamic error needed for context
+	}
+
+	var batch []*Mutation
+	decoder := json.NewDecoder(bufio.NewReader(resp.Body))
+	for {
+		var m Mutation
+		if err := decoder.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return batch, nil
+			}
+			return nil, err
+		}
+		batch = append(batch, &m)
+	}
+}
+
+// ReplicationFollower applies the storage mutations streamed from a primary to the storage of a standby,
+// keeping it ready to take over without a GASP catch-up. Until promoted, the engine following the primary
+// rejects writes; once promoted the follower stops applying mutations and the engine accepts writes.
+// It is safe for concurrent use.
+type ReplicationFollower struct {
+	// Storage is the storage of the standby the mutations are applied to.
+	Storage Storage
+
+	// Source streams the mutations of the primary.
+	Source ReplicationSource
+
+	// RetryInterval is how long to wait before reconnecting. Zero falls back to DefaultReplicationRetryInterval.
+	RetryInterval time.Duration
+
+	mu       sync.Mutex
+	epoch    string
+	seq      uint64
+	promoted bool
+	cancel   context.CancelFunc
+}
+
+// NewReplicationFollower creates a ReplicationFollower applying the mutations of source to storage.
+func NewReplicationFollower(storage Storage, source ReplicationSource) *ReplicationFollower {
+	return &ReplicationFollower{Storage: storage, Source: source}
+}
+
+// Position returns the epoch and sequence number of the last applied mutation.
+func (f *ReplicationFollower) Position() (string, uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.epoch, f.seq
+}
+
+// Promoted reports whether the standby was promoted.
+func (f *ReplicationFollower) Promoted() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.promoted
+}
+
+// Promote stops following the primary. It waits for the mutation being applied, if any, and makes Run return.
+func (f *ReplicationFollower) Promote() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.promoted = true
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// Run follows the primary until ctx is done or the standby is promoted, reconnecting after failures.
+// It returns ErrReplicationGap when the primary no longer holds the mutations following the last applied one.
+func (f *ReplicationFollower) Run(ctx context.Context) error {
+	f.mu.Lock()
+	if f.promoted {
+		f.mu.Unlock()
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	f.cancel = cancel
+	f.mu.Unlock()
+
+	retryInterval := f.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = DefaultReplicationRetryInterval
+	}
+	for {
+		epoch, seq := f.Position()
+		batch, err := f.Source.NextMutations(ctx, epoch, seq)
+		if err == nil {
+			err = f.applyAll(batch)
+		}
+		if f.Promoted() {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrReplicationGap) {
+			slog.Error("standby fell behind the primary and must be reseeded", "epoch", epoch, "seq", seq, "error", err)
+			return err
+		}
+		slog.Warn("replication stream interrupted, reconnecting", "epoch", epoch, "seq", seq, "retryAfter", retryInterval, "error", err)
+
+		select {
+		case <-ctx.Done():
+			if f.Promoted() {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// applyAll applies the mutations of batch that were not applied yet, stopping once the standby is promoted.
+func (f *ReplicationFollower) applyAll(batch []*Mutation) error {
+	for _, m := range batch {
+		if err := f.apply(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply applies m unless it was already applied or the standby was promoted.
+func (f *ReplicationFollower) apply(m *Mutation) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.promoted || (m.Epoch == f.epoch && m.Seq <= f.seq) {
+		return nil
+	}
+	if err := m.Apply(context.Background(), f.Storage); err != nil {
+		slog.Error("failed to apply replicated mutation", "epoch", m.Epoch, "seq", m.Seq, "op", m.Op, "error", err)
+		return err
+	}
+	f.epoch, f.seq = m.Epoch, m.Seq
+	return nil
+}
+
+// PromoteStandby turns a standby into a primary: it stops following the primary and starts accepting writes.
+// It returns ErrNotStandby when the engine does not follow a primary.
+func (e *Engine) PromoteStandby(context.Context) error {
+	if e.Standby == nil {
+		slog.Error("cannot promote standby", "error", ErrNotStandby)
+		return ErrNotStandby
+	}
+	e.Standby.Promote()
+	if e.OutpointFilter != nil {
+		// Outputs replicated from the primary bypassed the filter.
+		e.OutpointFilter.Reset()
+	}
+	epoch, seq := e.Standby.Position()
+	slog.Info("standby promoted", "epoch", epoch, "seq", seq)
+	return nil
+}
+
+// rejectOnStandby returns a StorageReadOnlyError while the engine follows a primary.
+func (e *Engine) rejectOnStandby() error {
+	if e.Standby == nil || e.Standby.Promoted() {
+		return nil
+	}
+	return &StorageReadOnlyError{Cause: ErrStandbyNode}
+}
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultPruneInterval is how often the background pruner runs when no interval is configured.
+const DefaultPruneInterval = time.Hour
+
+var (
+	// ErrPruningNotSupported is returned when pruning outputs with a storage that does not implement OutputPruneStorage
+	ErrPruningNotSupported = errors.New("storage does not support pruning outputs")
+
+	// ErrRetentionNotConfigured is returned when pruning outputs of an engine without a retention configuration
+	ErrRetentionNotConfigured = errors.New("no retention policy configured")
+)
+
+// RetentionPolicy decides how long the spent outputs of a topic are kept. Unspent and pinned
+// outputs are never pruned. A spent output is pruned as soon as any of the configured limits is
+// exceeded; a policy without limits keeps every output.
+type RetentionPolicy struct {
+	// MaxAge prunes spent outputs admitted longer ago than this. Zero keeps them regardless of age.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// UnspentOnly prunes every spent output.
+	UnspentOnly bool `mapstructure:"unspent_only"`
+
+	// MaxHistoryDepth prunes spent outputs more than this many spends away from an unspent output
+	// of the topic. Zero keeps the full history.
+	MaxHistoryDepth int `mapstructure:"max_history_depth"`
+
+	// HistoryRetentionDepth keeps the outputs retained by the topic manager with CoinsToRetain up to this many
+	// spends deep, purging older ancestors as transactions are submitted, and bounds the history served by
+	// GetUTXOHistory to the same depth. Unlike MaxHistoryDepth, it needs no OutputPruneStorage and is not
+	// applied by the background pruner. Zero keeps the full history.
+	HistoryRetentionDepth int `mapstructure:"history_retention_depth"`
+}
+
+// enabled reports whether the policy prunes anything.
+func (p RetentionPolicy) enabled() bool {
+	return p.MaxAge > 0 || p.UnspentOnly || p.MaxHistoryDepth > 0
+}
+
+// RetentionConfig holds the retention policies of the engine's topics.
+type RetentionConfig struct {
+	// Interval is how often the background pruner runs. Zero falls back to DefaultPruneInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Topics maps topic names to their retention policy. Topics without a policy are never pruned.
+	Topics map[string]RetentionPolicy `mapstructure:"topics"`
+}
+
+// PruneCriteria selects the spent outputs of a topic that a storage prunes. Outputs matching any
+// of the set criteria are pruned, while unspent and pinned outputs must always be kept.
+type PruneCriteria struct {
+	Topic string
+
+	// AllSpent matches every spent output.
+	AllSpent bool
+
+	// ReceivedBefore matches spent outputs received before this time. Zero matches none.
+	ReceivedBefore time.Time
+
+	// MaxHistoryDepth matches spent outputs more than this many spends away from an unspent output. Zero matches none.
+	MaxHistoryDepth int
+}
+
+// OutputPruneStorage is an optional Storage capability used to enforce retention policies.
+type OutputPruneStorage interface {
+	// PruneOutputs deletes the outputs matching the criteria, together with their BEEF when no other
+	// output references it, and returns the outpoints of the deleted outputs.
+	PruneOutputs(ctx context.Context, criteria *PruneCriteria) ([]*transaction.Outpoint, error)
+}
+
+// PruneReport describes the outputs pruned from a topic.
+type PruneReport struct {
+	Topic  string
+	Pruned []*transaction.Outpoint
+}
+
+// PruneOutputs applies the retention policy of every configured topic, deleting the outputs it no
+// longer retains and notifying lookup services with OutputEvicted. Reports are sorted by topic.
+func (e *Engine) PruneOutputs(ctx context.Context) ([]*PruneReport, error) {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting PruneOutputs while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	if e.Retention == nil {
+		slog.Error("cannot prune outputs", "error", ErrRetentionNotConfigured)
+		return nil, ErrRetentionNotConfigured
+	}
+	prunes, ok := storageCapability[OutputPruneStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot prune outputs", "error", ErrPruningNotSupported)
+		return nil, ErrPruningNotSupported
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting PruneOutputs in degraded mode", "error", err)
+		return nil, err
+	}
+
+	topics := make([]string, 0, len(e.Retention.Topics))
+	for topic, policy := range e.Retention.Topics {
+		if policy.enabled() {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+
+	now := time.Now()
+	reports := make([]*PruneReport, 0, len(topics))
+	for _, topic := range topics {
+		policy := e.Retention.Topics[topic]
+		criteria := &PruneCriteria{
+			Topic:           topic,
+			AllSpent:        policy.UnspentOnly,
+			MaxHistoryDepth: policy.MaxHistoryDepth,
+		}
+		if policy.MaxAge > 0 {
+			criteria.ReceivedBefore = now.Add(-policy.MaxAge)
+		}
+
+		pruned, err := prunes.PruneOutputs(ctx, criteria)
+		if err := e.trackWrite(err); err != nil {
+			slog.Error("failed to prune outputs", "topic", topic, "error", err)
+			return nil, err
+		}
+		pruned, err = e.withoutPinnedOutputs(ctx, topic, pruned)
+		if err != nil {
+			return nil, err
+		}
+		for _, outpoint := range pruned {
+			e.replicate(&Mutation{Op: MutationDeleteOutput, Outpoint: outpoint, Topic: topic})
+			if e.OutpointFilter != nil {
+				e.OutpointFilter.MarkDeleted(topic)
+			}
+			for name, l := range e.lookupServices() {
+				if err := e.notifyLookupService(ctx, name, l, LookupHookOutputEvicted, func(ctx context.Context, l LookupService) error {
+					return l.OutputEvicted(ctx, outpoint)
+				}); err != nil {
+					slog.Error("failed to notify lookup service about pruned output", "topic", topic, "outpoint", outpoint.String(), "error", err)
+					return nil, err
+				}
+			}
+		}
+		slog.Info("outputs pruned", "topic", topic, "pruned", len(pruned))
+		reports = append(reports, &PruneReport{Topic: topic, Pruned: pruned})
+	}
+	return reports, nil
+}
+
+// withoutPinnedOutputs drops the outpoints of pinned outputs the storage still holds from the outpoints it
+// reported as pruned, so that a storage violating the OutputPruneStorage contract does not get them evicted
+// from lookup services and standbys.
+func (e *Engine) withoutPinnedOutputs(ctx context.Context, topic string, pruned []*transaction.Outpoint) ([]*transaction.Outpoint, error) {
+	if len(pruned) == 0 {
+		return pruned, nil
+	}
+	remaining, err := e.Storage.FindOutputs(ctx, pruned, topic, nil, false)
+	if err != nil {
+		slog.Error("failed to find pruned outputs", "topic", topic, "error", err)
+		return nil, err
+	}
+	kept := make([]*transaction.Outpoint, 0, len(pruned))
+	for i, outpoint := range pruned {
+		if i < len(remaining) && remaining[i] != nil && remaining[i].Pinned {
+			slog.Warn("storage reported a pinned output as pruned", "topic", topic, "outpoint", outpoint.String())
+			continue
+		}
+		kept = append(kept, outpoint)
+	}
+	return kept, nil
+}
+
+// RunPruner prunes outputs every RetentionConfig.Interval until ctx is done or the engine stops.
+// It returns immediately when no retention is configured. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunPruner(ctx context.Context) {
+	if e.Retention == nil {
+		return
+	}
+	interval := e.Retention.Interval
+	if interval <= 0 {
+		interval = DefaultPruneInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.PruneOutputs(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled pruning failed", "interval", interval, "error", err)
+		}
+	}
+}
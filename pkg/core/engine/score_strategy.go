@@ -0,0 +1,277 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// ScoreStrategyHeight names the HeightScoreStrategy.
+	ScoreStrategyHeight = "height"
+	// ScoreStrategyTimestamp names the TimestampScoreStrategy.
+	ScoreStrategyTimestamp = "timestamp"
+	// ScoreStrategyHybrid names the HybridScoreStrategy.
+	ScoreStrategyHybrid = "hybrid"
+)
+
+// DefaultRescoreBatchSize is the number of UTXOs RescoreOutputs reads from storage at once.
+const DefaultRescoreBatchSize = 500
+
+// blockIdxScale spreads the index of a transaction within its block over the fractional part of a height score.
+const blockIdxScale = 1 << 31
+
+var (
+	// ErrUnknownScoreStrategy is returned by NewScoreStrategy for a name that is not a built-in strategy
+	ErrUnknownScoreStrategy = errors.New("unknown score strategy")
+	// ErrScoreStrategyNotConfigured is returned when rescoring outputs with an engine without ScoreStrategy
+	ErrScoreStrategyNotConfigured = errors.New("score strategy not configured")
+	// ErrOutputRescoringNotSupported is returned when rescoring outputs with a storage that does not implement OutputScoreStorage
+	ErrOutputRescoringNotSupported = errors.New("storage does not support rescoring outputs")
+)
+
+// ChainTipFunc returns the height of the chain tip.
+type ChainTipFunc func(ctx context.Context) (uint32, error)
+
+// ScoreStrategy computes the Score of the outputs admitted by the engine. The score orders the UTXOs returned by
+// FindUTXOsForTopic, and therefore the pages served to GASP peers by FindKnownUTXOs and the last interaction
+// scores they record, so it must not decrease for outputs admitted later if peers are to sync incrementally.
+// Scores are assigned once, when the output is stored; mining the transaction afterwards does not change them.
+type ScoreStrategy interface {
+	// Name identifies the strategy, e.g. ScoreStrategyHeight.
+	Name() string
+
+	// Score returns the score of the output about to be stored. tip is only called, for outputs that are not
+	// mined yet, by strategies that need the height of the chain tip.
+	Score(ctx context.Context, output *Output, tip ChainTipFunc) (float64, error)
+}
+
+// NewScoreStrategy returns the built-in strategy with the given name: ScoreStrategyHeight, ScoreStrategyTimestamp
+// or ScoreStrategyHybrid. An empty name returns nil, leaving the storage to assign scores.
+func NewScoreStrategy(name string) (ScoreStrategy, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case ScoreStrategyHeight:
+		return HeightScoreStrategy{}, nil
+	case ScoreStrategyTimestamp:
+		return TimestampScoreStrategy{}, nil
+	case ScoreStrategyHybrid:
+		return HybridScoreStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScoreStrategy, name)
+	}
+}
+
+// HeightScoreStrategy scores outputs by their position in the chain: the block height, plus the index of the
+// transaction within the block as a fraction. Outputs that are not mined yet are scored as the first transaction
+// of the next block. Scores are the same on every node holding the output, but an output of an old block admitted
+// late scores below outputs admitted before it, so peers syncing incrementally from later scores miss it.
+// The outputs of a transaction share its score.
+type HeightScoreStrategy struct{}
+
+// Name returns ScoreStrategyHeight.
+func (HeightScoreStrategy) Name() string { return ScoreStrategyHeight }
+
+// Score returns the block height of the output plus its block index as a fraction.
+func (HeightScoreStrategy) Score(ctx context.Context, output *Output, tip ChainTipFunc) (float64, error) {
+	if output.BlockHeight == 0 {
+		height, err := tip(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return float64(height) + 1, nil
+	}
+	return heightScore(output), nil
+}
+
+// TimestampScoreStrategy scores outputs by the time they were received, in microseconds since the Unix epoch,
+// like the reference TypeScript implementation scores them by time. Scores increase with every admission, so
+// peers syncing incrementally never miss an output, but they differ between the nodes holding the output.
+type TimestampScoreStrategy struct{}
+
+// Name returns ScoreStrategyTimestamp.
+func (TimestampScoreStrategy) Name() string { return ScoreStrategyTimestamp }
+
+// Score returns the time the output was received in microseconds.
+func (TimestampScoreStrategy) Score(_ context.Context, output *Output, _ ChainTipFunc) (float64, error) {
+	return timestampScore(output), nil
+}
+
+// HybridScoreStrategy scores the outputs admitted with a merkle proof like HeightScoreStrategy and the unmined
+// ones by the time they were received, in seconds since the Unix epoch with a microsecond fraction, which is
+// above any block height. Historical and synced outputs are ordered by block, and the outputs broadcast to the
+// node are ordered by arrival after them without querying the chain tip.
+type HybridScoreStrategy struct{}
+
+// Name returns ScoreStrategyHybrid.
+func (HybridScoreStrategy) Name() string { return ScoreStrategyHybrid }
+
+// Score returns the height score of a mined output and the receive time in seconds of an unmined one.
+func (HybridScoreStrategy) Score(_ context.Context, output *Output, _ ChainTipFunc) (float64, error) {
+	if output.BlockHeight == 0 {
+		return timestampScore(output) / 1e6, nil
+	}
+	return heightScore(output), nil
+}
+
+// heightScore returns the block height of the output plus its block index as a fraction.
+func heightScore(output *Output) float64 {
+	idx := min(output.BlockIdx, blockIdxScale-1)
+	return float64(output.BlockHeight) + float64(idx)/blockIdxScale
+}
+
+// timestampScore returns the time the output was received in microseconds since the Unix epoch.
+func timestampScore(output *Output) float64 {
+	receivedAt := output.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+	return float64(receivedAt.UnixMicro())
+}
+
+// chainTip returns a ChainTipFunc asking the chain tracker of the engine for the height of the chain tip once,
+// so that scoring the outputs of a submission queries it at most once.
+func (e *Engine) chainTip() ChainTipFunc {
+	var once sync.Once
+	var height uint32
+	var err error
+	return func(ctx context.Context) (uint32, error) {
+		once.Do(func() {
+			if e.ChainTracker == nil {
+				err = ErrChainTrackerRequired
+				return
+			}
+			height, err = e.ChainTracker.CurrentHeight(ctx)
+		})
+		return height, err
+	}
+}
+
+// scoreOutput sets the score of the output with the score strategy of the engine, if any. Without one the
+// storage assigns the score.
+func (e *Engine) scoreOutput(ctx context.Context, output *Output, tip ChainTipFunc) error {
+	if e.ScoreStrategy == nil {
+		return nil
+	}
+	score, err := e.ScoreStrategy.Score(ctx, output, tip)
+	if err != nil {
+		logger(ctx).Error("failed to score output", "strategy", e.ScoreStrategy.Name(), "topic", output.Topic, "outpoint", output.Outpoint.String(), "error", err)
+		return err
+	}
+	output.Score = score
+	return nil
+}
+
+// OutputScoreStorage is an optional Storage capability used to migrate the scores of stored outputs to the
+// score strategy of the engine. See RescoreOutputs.
+type OutputScoreStorage interface {
+	// UpdateOutputScore sets the score of the output admitted into the given topic.
+	UpdateOutputScore(ctx context.Context, outpoint *transaction.Outpoint, topic string, score float64) error
+}
+
+// RescoreReport summarizes a RescoreOutputs run.
+type RescoreReport struct {
+	Topic    string
+	Strategy string
+	Scanned  int // UTXOs of the topic read from storage
+	Rescored int // UTXOs whose score changed
+}
+
+// RescoreOutputs migrates the scores of the UTXOs of the topic, assigned by the storage or by another strategy,
+// to the score strategy of the engine, so that existing data pages like newly admitted outputs. The UTXOs are read
+// in their current score order before any is updated. Outputs stored without ReceivedAt are scored as received when
+// the migration started, a microsecond apart in their previous order. Spent outputs keep their score, as they are
+// not paged by score.
+//
+// Peers that synced from this node hold last interaction scores of the previous strategy: when the new scores
+// are lower, e.g. moving from timestamps to heights, they must sync from zero again to see the outputs admitted
+// afterwards.
+func (e *Engine) RescoreOutputs(ctx context.Context, topic string) (*RescoreReport, error) {
+	if e.ScoreStrategy == nil {
+		slog.Error("cannot rescore outputs", "topic", topic, "error", ErrScoreStrategyNotConfigured)
+		return nil, ErrScoreStrategyNotConfigured
+	}
+	scores, ok := storageCapability[OutputScoreStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot rescore outputs", "topic", topic, "error", ErrOutputRescoringNotSupported)
+		return nil, ErrOutputRescoringNotSupported
+	}
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("unknown topic in RescoreOutputs", "topic", topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting output rescoring in degraded mode", "topic", topic, "error", err)
+		return nil, err
+	}
+
+	utxos, err := e.scanUTXOs(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RescoreReport{Topic: topic, Strategy: e.ScoreStrategy.Name(), Scanned: len(utxos)}
+	started := time.Now()
+	tip := e.chainTip()
+	for i, utxo := range utxos {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		scored := *utxo
+		if scored.ReceivedAt.IsZero() {
+			scored.ReceivedAt = started.Add(time.Duration(i) * time.Microsecond)
+		}
+		score, err := e.ScoreStrategy.Score(ctx, &scored, tip)
+		if err != nil {
+			slog.Error("failed to score output", "strategy", report.Strategy, "topic", topic, "outpoint", utxo.Outpoint.String(), "error", err)
+			return report, err
+		}
+		if score == utxo.Score {
+			continue
+		}
+		if err := e.trackWrite(scores.UpdateOutputScore(ctx, &utxo.Outpoint, topic, score)); err != nil {
+			slog.Error("failed to update output score", "topic", topic, "outpoint", utxo.Outpoint.String(), "score", score, "error", err)
+			return report, err
+		}
+		e.replicate(&Mutation{Op: MutationUpdateOutputScore, Outpoint: &utxo.Outpoint, Topic: topic, Score: score})
+		report.Rescored++
+	}
+
+	slog.Info("outputs rescored", "topic", topic, "strategy", report.Strategy, "scanned", report.Scanned, "rescored", report.Rescored)
+	return report, nil
+}
+
+// scanUTXOs reads every UTXO of the topic, without BEEF, in score order. As pages start at an inclusive score,
+// the outputs at the score a page ends with are read again with the next page and skipped.
+func (e *Engine) scanUTXOs(ctx context.Context, topic string) ([]*Output, error) {
+	var utxos []*Output
+	seen := make(map[transaction.Outpoint]struct{})
+	since := 0.0
+	for {
+		page, err := e.Storage.FindUTXOsForTopic(ctx, topic, since, DefaultRescoreBatchSize, false)
+		if err != nil {
+			slog.Error("failed to find UTXOs to rescore", "topic", topic, "since", since, "error", err)
+			return nil, err
+		}
+		fresh := false
+		for _, utxo := range page {
+			if _, ok := seen[utxo.Outpoint]; ok {
+				continue
+			}
+			seen[utxo.Outpoint] = struct{}{}
+			utxos = append(utxos, utxo)
+			fresh = true
+		}
+		if len(page) < DefaultRescoreBatchSize || !fresh {
+			break
+		}
+		since = page[len(page)-1].Score
+	}
+	return utxos, nil
+}
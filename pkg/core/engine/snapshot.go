@@ -0,0 +1,217 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/snapshot"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// DefaultSnapshotBatchSize is the number of UTXOs ExportSnapshot reads from storage at once.
+const DefaultSnapshotBatchSize = 500
+
+// SnapshotImport reports the outcome of ImportSnapshot.
+type SnapshotImport struct {
+	// Manifest is the verified manifest of the imported snapshot.
+	Manifest *snapshot.Manifest
+	// Imported counts the records submitted per topic.
+	Imported map[string]int
+	// Watermarks holds the highest score imported per topic, recorded as the last interaction with the source.
+	Watermarks map[string]float64
+}
+
+// ExportSnapshot streams the unspent outputs of the given topics, or of every registered topic when none is given,
+// to w in the snapshot format, together with their BEEF. The spent outputs retained in the history of a UTXO
+// precede it, so that an importing node admits the UTXO with its previous coins as GASP would. The UTXOs of
+// a topic are paged by score; outputs admitted while the export runs score higher than those already written,
+// so the snapshot stays consistent with the watermarks a peer syncs the delta from.
+func (e *Engine) ExportSnapshot(ctx context.Context, w io.Writer, topics []string) (*snapshot.Manifest, error) {
+	if len(topics) == 0 {
+		for topic := range e.topicManagers() {
+			topics = append(topics, topic)
+		}
+		slices.Sort(topics)
+	}
+	for _, topic := range topics {
+		if _, ok := e.topicManager(topic); !ok {
+			slog.Error("unknown topic in ExportSnapshot", "topic", topic, "error", ErrUnknownTopic)
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+		}
+	}
+
+	writer, err := snapshot.NewWriter(w)
+	if err != nil {
+		slog.Error("failed to write snapshot header", "error", err)
+		return nil, err
+	}
+	for _, topic := range topics {
+		export := &snapshotExport{engine: e, writer: writer, topic: topic, written: make(map[string]struct{})}
+		if err := export.run(ctx); err != nil {
+			return nil, err
+		}
+		slog.Info("exported topic to snapshot", "topic", topic, "records", len(export.written))
+	}
+	if err := writer.Close(); err != nil {
+		slog.Error("failed to write snapshot manifest", "error", err)
+		return nil, err
+	}
+	manifest := writer.Manifest()
+	return &manifest, nil
+}
+
+// snapshotExport holds the state of the export of a single topic.
+type snapshotExport struct {
+	engine  *Engine
+	writer  *snapshot.Writer
+	topic   string
+	written map[string]struct{}
+}
+
+func (s *snapshotExport) run(ctx context.Context) error {
+	var since float64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := s.engine.Storage.FindUTXOsForTopic(ctx, s.topic, since, DefaultSnapshotBatchSize, true)
+		if err != nil {
+			slog.Error("failed to find UTXOs to export", "topic", s.topic, "since", since, "error", err)
+			return err
+		}
+
+		// Pages may overlap on equal scores; a page holding no new output ends the export.
+		exported := false
+		for _, utxo := range page {
+			if _, ok := s.written[utxo.Outpoint.String()]; ok {
+				continue
+			}
+			exported = true
+			if err := s.write(ctx, utxo); err != nil {
+				return err
+			}
+			if utxo.Score > since {
+				since = utxo.Score
+			}
+		}
+		if len(page) < DefaultSnapshotBatchSize || !exported {
+			return nil
+		}
+	}
+}
+
+// write writes the retained outputs consumed by output, then output itself.
+func (s *snapshotExport) write(ctx context.Context, output *Output) error {
+	key := output.Outpoint.String()
+	if _, ok := s.written[key]; ok {
+		return nil
+	}
+	s.written[key] = struct{}{}
+
+	for _, outpoint := range output.OutputsConsumed {
+		consumed, err := s.engine.Storage.FindOutput(ctx, outpoint, &s.topic, nil, true)
+		if errors.Is(err, ErrNotFound) || (err == nil && consumed == nil) {
+			continue
+		} else if err != nil {
+			slog.Error("failed to find consumed output to export", "topic", s.topic, "outpoint", outpoint.String(), "error", err)
+			return err
+		}
+		if err := s.write(ctx, consumed); err != nil {
+			return err
+		}
+	}
+
+	if len(output.Beef) == 0 {
+		slog.Error("missing BEEF of exported output", "topic", s.topic, "outpoint", key, "error", ErrMissingInput)
+		return fmt.Errorf("%w: %s", ErrMissingInput, key)
+	}
+	if err := s.writer.WriteRecord(&snapshot.Record{
+		Topic:       s.topic,
+		Outpoint:    output.Outpoint,
+		BlockHeight: output.BlockHeight,
+		Score:       output.Score,
+		Spent:       output.Spent,
+		BEEF:        output.Beef,
+	}); err != nil {
+		slog.Error("failed to write snapshot record", "topic", s.topic, "outpoint", key, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ImportSnapshot submits the records of a snapshot read from r as historical transactions, so that a fresh node
+// bootstraps large topics without syncing them over GASP. The outputs are recorded with source as their Source,
+// or OutputSourceSnapshot when source is empty. Once the snapshot and its checksums are verified, the highest score
+// imported for each topic is recorded as the last interaction with source, the GASP peer the snapshot was exported
+// from, so that the next sync with it only fetches the delta. Records are submitted as they are read; a snapshot
+// failing verification leaves the records imported so far in place but records no last interaction.
+func (e *Engine) ImportSnapshot(ctx context.Context, r io.Reader, source string) (*SnapshotImport, error) {
+	opCtx, done, err := e.beginOperation(ctx, operationGASPSync)
+	if err != nil {
+		slog.Error("rejecting ImportSnapshot while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+
+	reader, err := snapshot.NewReader(r)
+	if err != nil {
+		slog.Error("failed to read snapshot header", "error", err)
+		return nil, err
+	}
+
+	outputSource := source
+	if outputSource == "" {
+		outputSource = OutputSourceSnapshot
+	}
+	submitCtx := WithOutputSource(opCtx, outputSource)
+	result := &SnapshotImport{Imported: make(map[string]int), Watermarks: make(map[string]float64)}
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			slog.Error("failed to read snapshot record", "error", err)
+			return nil, err
+		}
+		if _, err := e.Submit(submitCtx, overlay.TaggedBEEF{Topics: []string{record.Topic}, Beef: record.BEEF}, SubmitModeHistorical, nil); err != nil {
+			slog.Error("failed to import snapshot record", "topic", record.Topic, "outpoint", record.Outpoint.String(), "error", err)
+			return nil, fmt.Errorf("import %s in topic %s: %w", record.Outpoint.String(), record.Topic, err)
+		}
+		result.Imported[record.Topic]++
+		if record.Score > result.Watermarks[record.Topic] {
+			result.Watermarks[record.Topic] = record.Score
+		}
+	}
+	result.Manifest = reader.Manifest()
+
+	if source != "" {
+		for topic, watermark := range result.Watermarks {
+			if err := e.recordSnapshotWatermark(opCtx, source, topic, watermark); err != nil {
+				return nil, err
+			}
+		}
+	}
+	slog.Info("imported snapshot", "source", source, "topics", len(result.Imported), "watermark", result.Manifest.ScoreWatermark)
+	return result, nil
+}
+
+// recordSnapshotWatermark raises the last interaction with the peer to the watermark of an imported snapshot.
+func (e *Engine) recordSnapshotWatermark(ctx context.Context, peer, topic string, watermark float64) error {
+	stored, err := e.Storage.GetLastInteraction(ctx, peer, topic)
+	if err != nil {
+		slog.Error("failed to get last interaction in ImportSnapshot", "topic", topic, "peer", peer, "error", err)
+		return err
+	}
+	if watermark <= stored {
+		return nil
+	}
+	if err := e.trackWrite(e.Storage.UpdateLastInteraction(ctx, peer, topic, watermark)); err != nil {
+		slog.Error("failed to update last interaction in ImportSnapshot", "topic", topic, "peer", peer, "error", err)
+		return err
+	}
+	return nil
+}
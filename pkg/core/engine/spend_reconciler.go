@@ -0,0 +1,367 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+const (
+	// DefaultSpendReconciliationInterval is how often the background reconciler runs when no interval is configured.
+	DefaultSpendReconciliationInterval = time.Hour
+
+	// DefaultSpendReconciliationBatchSize is how many unspent outputs of a topic a reconciliation run checks
+	// when no batch size is configured.
+	DefaultSpendReconciliationBatchSize = 100
+)
+
+// Spend sources supported by NewSpendSource.
+const (
+	SpendSourceWhatsOnChain = "whatsonchain"
+)
+
+var (
+	// ErrSpendReconciliationNotConfigured is returned when reconciling spends with an engine without a reconciler
+	ErrSpendReconciliationNotConfigured = errors.New("no spend reconciliation configured")
+
+	// ErrUnknownSpendSource is returned when configuring a spend source that is not supported
+	ErrUnknownSpendSource = errors.New("unknown spend source")
+)
+
+// Spend identifies the input spending an output.
+type Spend struct {
+	Txid       chainhash.Hash
+	InputIndex uint32
+}
+
+// SpendSource looks up the spends of outputs on chain, independently of the transactions submitted to the overlay.
+type SpendSource interface {
+	// FindSpend returns the input spending the outpoint, or nil when it is unspent.
+	FindSpend(ctx context.Context, outpoint *transaction.Outpoint) (*Spend, error)
+}
+
+// SpendReconciliationPolicy decides what happens to the outputs of a topic found spent outside the overlay.
+type SpendReconciliationPolicy struct {
+	// Evict deletes the outputs and notifies lookup services with OutputEvicted, instead of marking them
+	// spent and notifying lookup services with OutputSpent.
+	Evict bool `mapstructure:"evict"`
+}
+
+// SpendReconciliationConfig configures the background reconciliation of unspent outputs against an external
+// UTXO source, catching outputs spent by transactions never submitted to the overlay.
+type SpendReconciliationConfig struct {
+	// Source is the service spends are looked up with: SpendSourceWhatsOnChain.
+	Source string `mapstructure:"source"`
+
+	// URL is the base URL of the source. Defaults to the public API.
+	URL string `mapstructure:"url"`
+
+	// APIKey, when set, authenticates the requests to the source.
+	APIKey string `mapstructure:"api_key"`
+
+	// Network is the WhatsOnChain network, "main" or "test". Defaults to "main".
+	Network string `mapstructure:"network"`
+
+	// Interval is how often the background reconciler runs. Zero falls back to DefaultSpendReconciliationInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// BatchSize is how many unspent outputs of each topic a run checks, resuming where the previous run stopped.
+	// Zero falls back to DefaultSpendReconciliationBatchSize.
+	BatchSize uint32 `mapstructure:"batch_size"`
+
+	// Topics maps topic names to their reconciliation policy. Topics without a policy are never reconciled.
+	Topics map[string]SpendReconciliationPolicy `mapstructure:"topics"`
+}
+
+// NewSpendSource creates the spend source described by the configuration.
+func NewSpendSource(cfg SpendReconciliationConfig) (SpendSource, error) {
+	switch cfg.Source {
+	case SpendSourceWhatsOnChain:
+		network := cfg.Network
+		if network == "" {
+			network = "main"
+		}
+		url := cfg.URL
+		if url == "" {
+			url = DefaultWhatsOnChainURL + "/" + network
+		}
+		return &whatsOnChainSpendSource{
+			url:    strings.TrimRight(url, "/"),
+			apiKey: cfg.APIKey,
+			client: &http.Client{Timeout: DefaultProofSourceTimeout},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSpendSource, cfg.Source)
+	}
+}
+
+// SpendReconciler periodically checks the unspent outputs of its topics against a SpendSource.
+type SpendReconciler struct {
+	Source    SpendSource
+	Interval  time.Duration
+	BatchSize uint32
+	Topics    map[string]SpendReconciliationPolicy
+
+	mu      sync.Mutex
+	cursors map[string]float64 // score each topic's next run resumes from
+}
+
+// NewSpendReconciler creates a SpendReconciler using the source described by the configuration.
+func NewSpendReconciler(cfg SpendReconciliationConfig) (*SpendReconciler, error) {
+	source, err := NewSpendSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &SpendReconciler{
+		Source:    source,
+		Interval:  cfg.Interval,
+		BatchSize: cfg.BatchSize,
+		Topics:    cfg.Topics,
+	}, nil
+}
+
+func (r *SpendReconciler) cursor(topic string) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cursors[topic]
+}
+
+func (r *SpendReconciler) advance(topic string, since float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cursors == nil {
+		r.cursors = make(map[string]float64)
+	}
+	r.cursors[topic] = since
+}
+
+// SpendReconciliationReport describes the outputs of a topic reconciled by a run.
+type SpendReconciliationReport struct {
+	Topic   string
+	Checked int                     // unspent outputs looked up in the spend source
+	Spent   []*transaction.Outpoint // outputs marked spent
+	Evicted []*transaction.Outpoint // outputs evicted
+	Failed  int                     // outputs that could not be looked up or updated
+}
+
+// ReconcileSpends checks a batch of the unspent outputs of every configured topic against the spend source,
+// resuming where the previous run of the topic stopped and starting over once every output was checked.
+// Outputs spent on chain by a transaction never submitted to the overlay are marked spent, notifying lookup
+// services with OutputSpent, or evicted under a policy with Evict, notifying them with OutputEvicted.
+// Pinned outputs are never evicted. An output that fails is logged and checked again on the next pass.
+// Reports are sorted by topic.
+func (e *Engine) ReconcileSpends(ctx context.Context) ([]*SpendReconciliationReport, error) {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting ReconcileSpends while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	if e.SpendReconciler == nil {
+		slog.Error("cannot reconcile spends", "error", ErrSpendReconciliationNotConfigured)
+		return nil, ErrSpendReconciliationNotConfigured
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting ReconcileSpends in degraded mode", "error", err)
+		return nil, err
+	}
+
+	topics := make([]string, 0, len(e.SpendReconciler.Topics))
+	for topic := range e.SpendReconciler.Topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	reports := make([]*SpendReconciliationReport, 0, len(topics))
+	for _, topic := range topics {
+		report, err := e.reconcileTopicSpends(ctx, topic, e.SpendReconciler.Topics[topic])
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("spends reconciled", "topic", topic, "checked", report.Checked, "spent", len(report.Spent), "evicted", len(report.Evicted), "failed", report.Failed)
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (e *Engine) reconcileTopicSpends(ctx context.Context, topic string, policy SpendReconciliationPolicy) (*SpendReconciliationReport, error) {
+	reconciler := e.SpendReconciler
+	limit := reconciler.BatchSize
+	if limit == 0 {
+		limit = DefaultSpendReconciliationBatchSize
+	}
+	since := reconciler.cursor(topic)
+	utxos, err := e.Storage.FindUTXOsForTopic(ctx, topic, since, limit, false)
+	if err != nil {
+		slog.Error("failed to find UTXOs to reconcile", "topic", topic, "since", since, "error", err)
+		return nil, err
+	}
+
+	report := &SpendReconciliationReport{Topic: topic}
+	next := since
+	for _, utxo := range utxos {
+		if utxo.Score > next {
+			next = utxo.Score
+		}
+		if utxo.Spent {
+			continue
+		}
+		report.Checked++
+		spend, err := reconciler.Source.FindSpend(ctx, &utxo.Outpoint)
+		if err != nil {
+			slog.Error("failed to look up spend of output", "topic", topic, "outpoint", utxo.Outpoint.String(), "error", err)
+			report.Failed++
+			continue
+		} else if spend == nil {
+			continue
+		}
+
+		if policy.Evict && !utxo.Pinned {
+			err = e.evictSpentOutput(ctx, utxo)
+		} else {
+			err = e.markSpentOffOverlay(ctx, utxo, spend)
+		}
+		if errors.Is(err, ErrEngineStopping) {
+			return nil, err
+		} else if err != nil {
+			report.Failed++
+			continue
+		}
+		if policy.Evict && !utxo.Pinned {
+			report.Evicted = append(report.Evicted, &utxo.Outpoint)
+		} else {
+			report.Spent = append(report.Spent, &utxo.Outpoint)
+		}
+	}
+
+	if uint32(len(utxos)) < limit { //nolint:gosec // page length bounded by limit
+		next = 0
+	}
+	reconciler.advance(topic, next)
+	return report, nil
+}
+
+// markSpentOffOverlay marks an output spent by a transaction unknown to the overlay and notifies lookup services.
+func (e *Engine) markSpentOffOverlay(ctx context.Context, output *Output, spend *Spend) error {
+	if err := e.trackWrite(e.Storage.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&output.Outpoint}, output.Topic, &spend.Txid)); err != nil {
+		slog.Error("failed to mark output spent off overlay", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+		return err
+	}
+	e.publish(ctx, OutputSpentEvent{Outpoint: output.Outpoint, Topic: output.Topic, SpendingTxid: spend.Txid, InputIndex: spend.InputIndex})
+	spent := &OutputSpent{
+		Outpoint:     &output.Outpoint,
+		Topic:        output.Topic,
+		SpendingTxid: &spend.Txid,
+		InputIndex:   spend.InputIndex,
+	}
+	for name, l := range e.lookupServices() {
+		if err := e.notifyLookupService(ctx, name, l, LookupHookOutputSpent, func(ctx context.Context, l LookupService) error {
+			return l.OutputSpent(ctx, spent)
+		}); err != nil {
+			slog.Error("failed to notify lookup service about output spent off overlay", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// evictSpentOutput deletes an output spent by a transaction unknown to the overlay and notifies lookup services.
+func (e *Engine) evictSpentOutput(ctx context.Context, output *Output) error {
+	if err := e.removeOutput(ctx, e.Storage, &output.Outpoint, output.Topic, TombstoneReasonSpentOffOverlay); err != nil {
+		slog.Error("failed to evict output spent off overlay", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+		return err
+	}
+	if e.OutpointFilter != nil {
+		e.OutpointFilter.MarkDeleted(output.Topic)
+	}
+	for name, l := range e.lookupServices() {
+		if err := e.notifyLookupService(ctx, name, l, LookupHookOutputEvicted, func(ctx context.Context, l LookupService) error {
+			return l.OutputEvicted(ctx, &output.Outpoint)
+		}); err != nil {
+			slog.Error("failed to notify lookup service about output evicted off overlay", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// RunSpendReconciler reconciles spends every SpendReconciler.Interval until ctx is done or the engine stops.
+// It returns immediately when no reconciler is configured. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunSpendReconciler(ctx context.Context) {
+	if e.SpendReconciler == nil {
+		return
+	}
+	interval := e.SpendReconciler.Interval
+	if interval <= 0 {
+		interval = DefaultSpendReconciliationInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.ReconcileSpends(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled spend reconciliation failed", "interval", interval, "error", err)
+		}
+	}
+}
+
+// whatsOnChainSpendSource looks up spends with the spent output endpoint of WhatsOnChain.
+type whatsOnChainSpendSource struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func (s *whatsOnChainSpendSource) FindSpend(ctx context.Context, outpoint *transaction.Outpoint) (*Spend, error) {
+	url := fmt.Sprintf("%s/tx/%s/%d/spent", s.url, outpoint.Txid.String(), outpoint.Index)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", s.apiKey)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, &util.HTTPError{StatusCode: resp.StatusCode, Err: fmt.Errorf("spend source responded with %s", resp.Status)} //nolint:err113 // dynamic error needed for context
+	}
+	var spent struct {
+		Txid string `json:"txid"`
+		Vin  uint32 `json:"vin"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxProofResponseSize)).Decode(&spent); err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHashFromHex(spent.Txid)
+	if err != nil {
+		return nil, err
+	}
+	return &Spend{Txid: *txid, InputIndex: spent.Vin}, nil
+}
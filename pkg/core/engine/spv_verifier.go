@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script/interpreter"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+// SPVVerifierConfig configures the verification worker pool.
+type SPVVerifierConfig struct {
+	// Workers bounds the merkle path and script checks running at once, across all submissions.
+	// Zero falls back to the number of usable CPUs.
+	Workers int `mapstructure:"workers"`
+}
+
+// SPVVerifier verifies transactions like spv.Verify, without a fee model, but checks the merkle paths and input
+// scripts of the transaction graph in parallel on a shared pool of workers.
+type SPVVerifier struct {
+	workers chan struct{}
+}
+
+// NewSPVVerifier creates an SPVVerifier with the given configuration.
+func NewSPVVerifier(cfg SPVVerifierConfig) *SPVVerifier {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &SPVVerifier{workers: make(chan struct{}, workers)}
+}
+
+// Verify reports whether the transaction and its unproven ancestry are valid: every transaction of the graph either
+// carries a merkle path accepted by the chain tracker or has inputs whose scripts unlock their source outputs.
+// Transactions found in the verified cache are not checked again, and the graph is added to it once verified;
+// a nil cache disables caching. Like spv.Verify, it uses the WhatsOnChain mainnet chain tracker when none is given.
+func (v *SPVVerifier) Verify(ctx context.Context, tx *transaction.Transaction, chainTracker chaintracker.ChainTracker, verified *VerifiedTxCache) (bool, error) {
+	return verifyGraph(ctx, tx, chainTracker, verified, v.run)
+}
+
+// verifyGraph collects the checks of the transaction graph not found in the verified cache, executes them
+// with run and caches the graph once all of them passed.
+func verifyGraph(
+	ctx context.Context,
+	tx *transaction.Transaction,
+	chainTracker chaintracker.ChainTracker,
+	verified *VerifiedTxCache,
+	run func(context.Context, []func() error) error,
+) (bool, error) {
+	if chainTracker == nil {
+		chainTracker = chaintracker.NewWhatsOnChain(chaintracker.MainNet, "")
+	}
+
+	var checks []func() error
+	graph := make(map[chainhash.Hash]*transaction.Transaction)
+	queue := []*transaction.Transaction{tx}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		txid := *current.TxID()
+		if _, ok := graph[txid]; ok {
+			continue
+		}
+		if verified != nil && verified.Contains(txid, current.MerklePath) {
+			continue
+		}
+		graph[txid] = current
+
+		if current.MerklePath != nil {
+			checks = append(checks, func() error {
+				valid, err := current.MerklePath.Verify(ctx, &txid, chainTracker)
+				if err != nil {
+					return err
+				}
+				if !valid {
+					return fmt.Errorf("invalid merkle path for transaction %s", txid)
+				}
+				return nil
+			})
+			continue
+		}
+
+		for vin, input := range current.Inputs {
+			sourceOutput := input.SourceTxOutput()
+			if sourceOutput == nil {
+				return false, fmt.Errorf("input %d has no source transaction", vin)
+			}
+			if input.SourceTransaction != nil {
+				queue = append(queue, input.SourceTransaction)
+			}
+			checks = append(checks, func() error {
+				return interpreter.NewEngine().Execute(
+					interpreter.WithTx(current, vin, sourceOutput),
+					interpreter.WithForkID(),
+					interpreter.WithAfterGenesis(),
+				)
+			})
+		}
+	}
+
+	if err := run(ctx, checks); err != nil {
+		return false, err
+	}
+	if verified != nil {
+		for txid, current := range graph {
+			verified.Add(txid, current.MerklePath)
+		}
+	}
+	return true, nil
+}
+
+// run executes the checks on the worker pool and returns the first error, skipping the checks not yet started.
+func (v *SPVVerifier) run(ctx context.Context, checks []func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+loop:
+	for _, check := range checks {
+		select {
+		case <-ctx.Done():
+			break loop
+		case v.workers <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			<-v.workers
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-v.workers }()
+			if err := check(); err != nil {
+				once.Do(func() { firstErr = err })
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// runSerially executes the checks one after the other and returns the first error.
+func runSerially(ctx context.Context, checks []func() error) error {
+	for _, check := range checks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifySPV verifies the transaction with the SPVVerifier when configured, serially against the VerifiedTxs cache
+// when only that one is configured, or else with spv.Verify.
+func (e *Engine) verifySPV(ctx context.Context, tx *transaction.Transaction) (bool, error) {
+	switch {
+	case e.SPVVerifier != nil:
+		return e.SPVVerifier.Verify(ctx, tx, e.ChainTracker, e.VerifiedTxs)
+	case e.VerifiedTxs != nil:
+		return verifyGraph(ctx, tx, e.ChainTracker, e.VerifiedTxs, runSerially)
+	default:
+		return spv.Verify(ctx, tx, e.ChainTracker, nil)
+	}
+}
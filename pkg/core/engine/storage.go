@@ -14,7 +14,7 @@ var ErrNotFound = fmt.Errorf("not-found")
 
 // Storage defines the interface for persisting and retrieving overlay transaction data.
 type Storage interface {
-	// Adds a new output to storage
+	// Adds a new output to storage, keeping its Score unless it is zero
 	InsertOutput(ctx context.Context, utxo *Output) error
 
 	// Finds an output from storage
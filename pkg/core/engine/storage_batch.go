@@ -0,0 +1,324 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultStorageBatchSize bounds the number of outputs or applied transactions written by a single
+// InsertOutputs or InsertAppliedTransactions call.
+const DefaultStorageBatchSize = 500
+
+// BatchStorage is an optional Storage capability used to write many outputs and applied transactions at once,
+// e.g. with multi-row inserts in a single database transaction. When the storage implements it, GASP graphs
+// are finalized by staging their outputs and applied transactions in memory and flushing them in batches
+// of DefaultStorageBatchSize, instead of writing them one by one as every transaction of the graph is submitted.
+type BatchStorage interface {
+	// InsertOutputs adds the outputs to storage, as InsertOutput would one by one.
+	InsertOutputs(ctx context.Context, outputs []*Output) error
+
+	// InsertAppliedTransactions inserts records of the applied transactions, as InsertAppliedTransaction would one by one.
+	InsertAppliedTransactions(ctx context.Context, txs []*overlay.AppliedTransaction) error
+}
+
+type stagedStorageKey struct{}
+
+// withStagedStorage returns a context that makes Submit write outputs and applied transactions to the staged storage.
+func withStagedStorage(ctx context.Context, staged *stagedStorage) context.Context {
+	return context.WithValue(ctx, stagedStorageKey{}, staged)
+}
+
+// storage returns the storage the submissions made with the context read from and write to:
+// the staged storage set with withStagedStorage, or the engine storage.
+func (e *Engine) storage(ctx context.Context) Storage {
+	if staged, ok := ctx.Value(stagedStorageKey{}).(*stagedStorage); ok && staged.engine == e {
+		return staged
+	}
+	return e.Storage
+}
+
+// stagedStorage keeps the outputs and applied transactions inserted through it in memory until they are
+// flushed to the batch capability of the engine storage. Reads and writes of staged outputs are answered
+// from memory, so that the transactions of a GASP graph can spend the outputs of their ancestors before
+// they are flushed. Other writes go through to the engine storage immediately.
+// Staged outputs are not visible to FindUTXOsForTopic nor to readers of the engine storage until flushed.
+type stagedStorage struct {
+	Storage
+	engine *Engine
+
+	mu      sync.Mutex
+	outputs map[string]*Output
+	order   []string
+	applied []*overlay.AppliedTransaction
+}
+
+func newStagedStorage(e *Engine) *stagedStorage {
+	return &stagedStorage{Storage: e.Storage, engine: e, outputs: make(map[string]*Output)}
+}
+
+func stagedOutputKey(outpoint *transaction.Outpoint, topic string) string {
+	return outpoint.String() + " " + topic
+}
+
+// Unwrap returns the engine storage, letting the engine find its optional capabilities.
+func (s *stagedStorage) Unwrap() Storage {
+	return s.Storage
+}
+
+// find returns the staged output matching the filters. Must be called with the lock held.
+func (s *stagedStorage) find(outpoint *transaction.Outpoint, topic *string, spent *bool) *Output {
+	for _, key := range s.order {
+		output, ok := s.outputs[key]
+		if !ok || output.Outpoint != *outpoint || (topic != nil && output.Topic != *topic) || (spent != nil && output.Spent != *spent) {
+			continue
+		}
+		return output
+	}
+	return nil
+}
+
+// copyOf returns a copy of the staged output, so that callers cannot alter it without going through the storage.
+func (s *stagedStorage) copyOf(output *Output, includeBEEF bool) *Output {
+	found := *output
+	found.OutputsConsumed = slices.Clone(output.OutputsConsumed)
+	found.ConsumedBy = slices.Clone(output.ConsumedBy)
+	if !includeBEEF {
+		found.Beef = nil
+	}
+	return &found
+}
+
+func (s *stagedStorage) InsertOutput(_ context.Context, utxo *Output) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := stagedOutputKey(&utxo.Outpoint, utxo.Topic)
+	if _, ok := s.outputs[key]; !ok {
+		s.order = append(s.order, key)
+	}
+	stored := *utxo
+	s.outputs[key] = &stored
+	return nil
+}
+
+func (s *stagedStorage) FindOutput(ctx context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*Output, error) {
+	s.mu.Lock()
+	output := s.find(outpoint, topic, spent)
+	s.mu.Unlock()
+	if output != nil {
+		return s.copyOf(output, includeBEEF), nil
+	}
+	return s.Storage.FindOutput(ctx, outpoint, topic, spent, includeBEEF)
+}
+
+func (s *stagedStorage) FindOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*Output, error) {
+	found, err := s.Storage.FindOutputs(ctx, outpoints, topic, spent, includeBEEF)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) < len(outpoints) {
+		found = append(found, make([]*Output, len(outpoints)-len(found))...)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, outpoint := range outpoints {
+		if _, ok := s.outputs[stagedOutputKey(outpoint, topic)]; !ok {
+			continue
+		}
+		found[i] = nil
+		if output := s.find(outpoint, &topic, spent); output != nil {
+			found[i] = s.copyOf(output, includeBEEF)
+		}
+	}
+	return found, nil
+}
+
+func (s *stagedStorage) FindOutputsForTransaction(ctx context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*Output, error) {
+	found, err := s.Storage.FindOutputsForTransaction(ctx, txid, includeBEEF)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range s.order {
+		if output, ok := s.outputs[key]; ok && output.Outpoint.Txid.Equal(*txid) {
+			found = append(found, s.copyOf(output, includeBEEF))
+		}
+	}
+	return found, nil
+}
+
+func (s *stagedStorage) DeleteOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	s.mu.Lock()
+	key := stagedOutputKey(outpoint, topic)
+	_, ok := s.outputs[key]
+	delete(s.outputs, key)
+	s.mu.Unlock()
+	if ok {
+		return nil
+	}
+	return s.Storage.DeleteOutput(ctx, outpoint, topic)
+}
+
+func (s *stagedStorage) MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+	s.mu.Lock()
+	stored := make([]*transaction.Outpoint, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		if output, ok := s.outputs[stagedOutputKey(outpoint, topic)]; ok {
+			output.Spent = true
+		} else {
+			stored = append(stored, outpoint)
+		}
+	}
+	s.mu.Unlock()
+	if len(stored) == 0 {
+		return nil
+	}
+	return s.Storage.MarkUTXOsAsSpent(ctx, stored, topic, spendTxid)
+}
+
+// MarkUTXOsAsUnspent marks staged outputs as unspent, and stored outputs when the engine storage can roll them back.
+func (s *stagedStorage) MarkUTXOsAsUnspent(ctx context.Context, outpoints []*transaction.Outpoint, topic string) error {
+	s.mu.Lock()
+	stored := make([]*transaction.Outpoint, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		if output, ok := s.outputs[stagedOutputKey(outpoint, topic)]; ok {
+			output.Spent = false
+		} else {
+			stored = append(stored, outpoint)
+		}
+	}
+	s.mu.Unlock()
+	rollback, ok := storageCapability[SpendRollbackStorage](s.Storage)
+	if len(stored) == 0 || !ok {
+		return nil
+	}
+	return rollback.MarkUTXOsAsUnspent(ctx, stored, topic)
+}
+
+func (s *stagedStorage) UpdateConsumedBy(ctx context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error {
+	s.mu.Lock()
+	output, ok := s.outputs[stagedOutputKey(outpoint, topic)]
+	if ok {
+		output.ConsumedBy = slices.Clone(consumedBy)
+	}
+	s.mu.Unlock()
+	if ok {
+		return nil
+	}
+	return s.Storage.UpdateConsumedBy(ctx, outpoint, topic, consumedBy)
+}
+
+// UpdateOutputDisputed sets the disputed flag of a staged output, or of a stored output when the
+// engine storage persists it.
+func (s *stagedStorage) UpdateOutputDisputed(ctx context.Context, outpoint *transaction.Outpoint, topic string, disputed bool) error {
+	s.mu.Lock()
+	output, ok := s.outputs[stagedOutputKey(outpoint, topic)]
+	if ok {
+		output.Disputed = disputed
+	}
+	s.mu.Unlock()
+	if ok {
+		return nil
+	}
+	if disputes, ok := storageCapability[OutputDisputeStorage](s.Storage); ok {
+		return disputes.UpdateOutputDisputed(ctx, outpoint, topic, disputed)
+	}
+	return nil
+}
+
+// TombstoneOutput drops a staged output, which was never stored, or tombstones a stored output when the
+// engine storage supports it and deletes it otherwise.
+func (s *stagedStorage) TombstoneOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string, reason TombstoneReason, deletedAt time.Time) error {
+	s.mu.Lock()
+	key := stagedOutputKey(outpoint, topic)
+	_, ok := s.outputs[key]
+	delete(s.outputs, key)
+	s.mu.Unlock()
+	if ok {
+		return nil
+	}
+	if tombstones, ok := storageCapability[OutputTombstoneStorage](s.Storage); ok {
+		return tombstones.TombstoneOutput(ctx, outpoint, topic, reason, deletedAt)
+	}
+	return s.Storage.DeleteOutput(ctx, outpoint, topic)
+}
+
+// FindTombstones finds the tombstones of the engine storage.
+func (s *stagedStorage) FindTombstones(ctx context.Context, query *TombstoneQuery) ([]*Tombstone, error) {
+	if tombstones, ok := storageCapability[OutputTombstoneStorage](s.Storage); ok {
+		return tombstones.FindTombstones(ctx, query)
+	}
+	return nil, ErrTombstonesNotSupported
+}
+
+// PurgeTombstones purges the tombstones of the engine storage.
+func (s *stagedStorage) PurgeTombstones(ctx context.Context, deletedBefore time.Time) (int, error) {
+	if tombstones, ok := storageCapability[OutputTombstoneStorage](s.Storage); ok {
+		return tombstones.PurgeTombstones(ctx, deletedBefore)
+	}
+	return 0, ErrTombstonesNotSupported
+}
+
+func (s *stagedStorage) InsertAppliedTransaction(_ context.Context, tx *overlay.AppliedTransaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applied = append(s.applied, tx)
+	return nil
+}
+
+func (s *stagedStorage) DoesAppliedTransactionExist(ctx context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	s.mu.Lock()
+	staged := slices.ContainsFunc(s.applied, func(applied *overlay.AppliedTransaction) bool {
+		return applied.Topic == tx.Topic && applied.Txid.Equal(*tx.Txid)
+	})
+	s.mu.Unlock()
+	if staged {
+		return true, nil
+	}
+	return s.Storage.DoesAppliedTransactionExist(ctx, tx)
+}
+
+// flush writes the staged outputs, then the staged applied transactions, to the batch capability of the
+// engine storage, recording them for replication. The staged storage is empty afterwards.
+func (s *stagedStorage) flush(ctx context.Context, batch BatchStorage) error {
+	s.mu.Lock()
+	outputs := make([]*Output, 0, len(s.outputs))
+	for _, key := range s.order {
+		if output, ok := s.outputs[key]; ok {
+			outputs = append(outputs, output)
+		}
+	}
+	applied := s.applied
+	s.outputs = make(map[string]*Output)
+	s.order = nil
+	s.applied = nil
+	s.mu.Unlock()
+
+	for chunk := range slices.Chunk(outputs, DefaultStorageBatchSize) {
+		if err := s.engine.trackWrite(batch.InsertOutputs(ctx, chunk)); err != nil {
+			slog.Error("failed to insert staged outputs", "count", len(chunk), "error", err)
+			return err
+		}
+		for _, output := range chunk {
+			s.engine.replicate(&Mutation{Op: MutationInsertOutput, Output: output})
+		}
+	}
+	for chunk := range slices.Chunk(applied, DefaultStorageBatchSize) {
+		if err := s.engine.trackWrite(batch.InsertAppliedTransactions(ctx, chunk)); err != nil {
+			slog.Error("failed to insert staged applied transactions", "count", len(chunk), "error", err)
+			return err
+		}
+		for _, tx := range chunk {
+			s.engine.replicate(&Mutation{Op: MutationInsertAppliedTransaction, AppliedTransaction: tx})
+		}
+	}
+	slog.Debug("staged storage writes flushed", "outputs", len(outputs), "appliedTransactions", len(applied))
+	return nil
+}
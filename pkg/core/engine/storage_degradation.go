@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultStorageRetryAfter is how long a degraded engine waits before letting a write probe the storage again.
+const DefaultStorageRetryAfter = 30 * time.Second
+
+// ErrStorageReadOnly is returned, wrapped in a StorageReadOnlyError, while the engine rejects writes
+// because the storage has become read-only.
+var ErrStorageReadOnly = errors.New("storage is read-only")
+
+// StorageReadOnlyError is returned by write operations while the engine runs in degraded mode.
+type StorageReadOnlyError struct {
+	// RetryAfter is how long the caller should wait before retrying.
+	RetryAfter time.Duration
+	// Cause is the write failure that switched the engine to degraded mode.
+	Cause error
+}
+
+func (e *StorageReadOnlyError) Error() string {
+	return fmt.Sprintf("%s, retry after %s: %v", ErrStorageReadOnly, e.RetryAfter, e.Cause)
+}
+
+// Unwrap returns ErrStorageReadOnly so that callers can match the error with errors.Is.
+func (e *StorageReadOnlyError) Unwrap() error { return ErrStorageReadOnly }
+
+// IsReadOnlyStorageError reports whether the storage write error indicates that the storage
+// cannot accept writes at all, e.g. a read-only file system, a full disk or a demoted replica.
+func IsReadOnlyStorageError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrStorageReadOnly) || errors.Is(err, syscall.EROFS) || errors.Is(err, syscall.ENOSPC) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, hint := range []string{"read-only", "readonly", "read only", "no space left", "not primary", "notwritableprimary"} {
+		if strings.Contains(msg, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// StorageDegradation switches the engine to a degraded, read-only mode when storage writes fail
+// because the storage became read-only. While degraded, lookups and GASP reads keep being served
+// but submits and GASP syncs are rejected with a StorageReadOnlyError. Once RetryAfter has elapsed
+// a single write is let through to probe the storage; the engine recovers when it succeeds.
+// It is safe for concurrent use.
+type StorageDegradation struct {
+	// RetryAfter is advertised to rejected callers and paces the write probes.
+	// Zero falls back to DefaultStorageRetryAfter.
+	RetryAfter time.Duration
+	// IsReadOnlyError classifies write errors. Nil falls back to IsReadOnlyStorageError.
+	IsReadOnlyError func(error) bool
+
+	mu      sync.Mutex
+	since   time.Time
+	probeAt time.Time
+	cause   error
+}
+
+// NewStorageDegradation creates a StorageDegradation advertising the given retry interval.
+func NewStorageDegradation(retryAfter time.Duration) *StorageDegradation {
+	return &StorageDegradation{RetryAfter: retryAfter}
+}
+
+// Degraded reports whether the engine currently rejects writes, the time it switched
+// to degraded mode and the write failure that caused it.
+func (d *StorageDegradation) Degraded() (bool, time.Time, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.cause != nil, d.since, d.cause
+}
+
+// AllowWrite returns a StorageReadOnlyError while degraded, except for one probing write per RetryAfter interval.
+func (d *StorageDegradation) AllowWrite() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cause == nil {
+		return nil
+	}
+	now := time.Now()
+	if now.Before(d.probeAt) {
+		return &StorageReadOnlyError{RetryAfter: d.probeAt.Sub(now), Cause: d.cause}
+	}
+	d.probeAt = now.Add(d.retryAfter())
+	return nil
+}
+
+// readOnlyError returns a StorageReadOnlyError while degraded, without consuming a probe.
+func (d *StorageDegradation) readOnlyError() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cause == nil {
+		return nil
+	}
+	return &StorageReadOnlyError{RetryAfter: max(time.Until(d.probeAt), 0), Cause: d.cause}
+}
+
+// RecordWrite records the outcome of a storage write, switching to degraded mode on a read-only
+// failure and recovering on success.
+func (d *StorageDegradation) RecordWrite(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case err == nil:
+		if d.cause != nil {
+			slog.Info("storage accepts writes again, leaving degraded mode", "degradedFor", time.Since(d.since))
+			d.since, d.probeAt, d.cause = time.Time{}, time.Time{}, nil
+		}
+	case d.isReadOnlyError(err):
+		now := time.Now()
+		if d.cause == nil {
+			slog.Error("storage rejected a write, entering degraded read-only mode", "error", err)
+			d.since = now
+		}
+		d.cause = err
+		d.probeAt = now.Add(d.retryAfter())
+	}
+}
+
+func (d *StorageDegradation) retryAfter() time.Duration {
+	if d.RetryAfter <= 0 {
+		return DefaultStorageRetryAfter
+	}
+	return d.RetryAfter
+}
+
+func (d *StorageDegradation) isReadOnlyError(err error) bool {
+	if d.IsReadOnlyError != nil {
+		return d.IsReadOnlyError(err)
+	}
+	return IsReadOnlyStorageError(err)
+}
+
+// allowWrite rejects writes while the engine is an unpromoted standby or StorageDegradation is configured and degraded.
+func (e *Engine) allowWrite() error {
+	if err := e.rejectOnStandby(); err != nil {
+		return err
+	}
+	if e.StorageDegradation == nil {
+		return nil
+	}
+	return e.StorageDegradation.AllowWrite()
+}
+
+// rejectWhileDegraded returns a StorageReadOnlyError while the engine is an unpromoted standby or StorageDegradation is configured and degraded.
+// Unlike allowWrite it never lets the call through as a probe, so it suits long running writers such as GASP sync.
+func (e *Engine) rejectWhileDegraded() error {
+	if err := e.rejectOnStandby(); err != nil {
+		return err
+	}
+	if e.StorageDegradation == nil {
+		return nil
+	}
+	return e.StorageDegradation.readOnlyError()
+}
+
+// trackWrite records the outcome of a storage write in StorageDegradation when configured and returns err unchanged.
+func (e *Engine) trackWrite(err error) error {
+	if e.StorageDegradation != nil {
+		e.StorageDegradation.RecordWrite(err)
+	}
+	return err
+}
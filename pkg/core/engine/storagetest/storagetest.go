@@ -0,0 +1,766 @@
+// Package storagetest provides a black-box conformance suite for engine.Storage implementations.
+//
+// The engine relies on contracts the Storage interface cannot express: a missing output is reported as
+// nil without an error, FindOutputs answers in the order of the requested outpoints, FindUTXOsForTopic
+// pages by ascending score with an inclusive since, and so on. Backends verify them with a single call:
+//
+//	func TestStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) engine.Storage {
+//			return newEmptyStorage(t)
+//		})
+//	}
+//
+// The tests of optional capabilities, such as engine.BatchStorage, engine.OutputTombstoneStorage and
+// engine.MerkleStateStorage, are skipped for storages not implementing them.
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// TopicA is the topic most outputs of the suite are admitted to.
+	TopicA = "tm_storagetest_a"
+	// TopicB is the topic used to check that storages keep topics apart.
+	TopicB = "tm_storagetest_b"
+)
+
+// ConcurrentWriters is the number of goroutines writing at the same time in the concurrency tests.
+const ConcurrentWriters = 32
+
+// NewStorageFunc returns a new, empty storage. It is called once per test of the suite.
+type NewStorageFunc func(t *testing.T) engine.Storage
+
+// Run runs the whole conformance suite against the storages returned by newStorage, each test in its own subtest.
+func Run(t *testing.T, newStorage NewStorageFunc) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, storage engine.Storage)
+	}{
+		{"InsertOutput stores every field", testInsertOutputRoundTrip},
+		{"FindOutput returns nil without error for a missing output", testFindOutputMissing},
+		{"FindOutput filters by topic", testFindOutputTopicFilter},
+		{"FindOutput filters by spent state", testFindOutputSpentFilter},
+		{"FindOutputs answers in the order of the outpoints", testFindOutputsOrder},
+		{"FindOutputsForTransaction returns the outputs of the transaction", testFindOutputsForTransaction},
+		{"FindUTXOsForTopic pages unspent outputs by score", testFindUTXOsForTopic},
+		{"InsertOutput keeps the score assigned by the engine", testInsertOutputScore},
+		{"MarkUTXOsAsSpent only touches the given topic", testMarkUTXOsAsSpent},
+		{"MarkUTXOsAsUnspent reverts MarkUTXOsAsSpent in the given topic", testMarkUTXOsAsUnspent},
+		{"UpdateConsumedBy replaces the consumers", testUpdateConsumedBy},
+		{"UpdateOutputBlockHeight records the block position", testUpdateOutputBlockHeight},
+		{"UpdateTransactionBEEF updates every output of the transaction", testUpdateTransactionBEEF},
+		{"DeleteOutput only removes the given topic", testDeleteOutput},
+		{"Applied transactions are recorded per topic", testAppliedTransactions},
+		{"Last interactions are recorded per host and topic", testLastInteractions},
+		{"Concurrent inserts are all stored", testConcurrentInserts},
+		{"Concurrent interaction updates are all stored", testConcurrentInteractions},
+		{"InsertOutputs stores every output in order", testBatchInsertOutputs},
+		{"InsertAppliedTransactions records every transaction", testBatchInsertAppliedTransactions},
+		{"TombstoneOutput hides the output and keeps its tombstone", testTombstoneOutput},
+		{"FindTombstones filters tombstones", testFindTombstones},
+		{"PurgeTombstones only purges older tombstones", testPurgeTombstones},
+		{"UpdateMerkleState only touches the given topic", testUpdateMerkleState},
+		{"FindOutpointsByMerkleState pages outputs by block height", testFindOutpointsByMerkleState},
+		{"UpdateOutputScore moves the output within the score order", testUpdateOutputScore},
+		{"PruneOutputs keeps pinned outputs", testPruneOutputsKeepsPinned},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, newStorage(t))
+		})
+	}
+}
+
+// NewOutput returns an unspent, unmined output of the topic at the given index of a transaction derived from name.
+func NewOutput(name string, index uint32, topic string) *engine.Output {
+	return &engine.Output{
+		Outpoint: transaction.Outpoint{Txid: TxID(name), Index: index},
+		Topic:    topic,
+		Script:   &script.Script{script.OpTRUE},
+		Satoshis: 1000 + uint64(index),
+		Beef:     []byte("beef of " + name),
+	}
+}
+
+// TxID returns a transaction ID derived from name.
+func TxID(name string) chainhash.Hash {
+	return chainhash.DoubleHashH([]byte(name))
+}
+
+func insert(t *testing.T, storage engine.Storage, outputs ...*engine.Output) {
+	t.Helper()
+	for _, output := range outputs {
+		require.NoError(t, storage.InsertOutput(context.Background(), output), "InsertOutput %s", output.Outpoint)
+	}
+}
+
+func find(t *testing.T, storage engine.Storage, outpoint transaction.Outpoint, topic string) *engine.Output {
+	t.Helper()
+	found, err := storage.FindOutput(context.Background(), &outpoint, &topic, nil, true)
+	require.NoError(t, err, "FindOutput %s", outpoint)
+	return found
+}
+
+func testInsertOutputRoundTrip(t *testing.T, storage engine.Storage) {
+	// given:
+	consumed := NewOutput("parent", 0, TopicA)
+	output := NewOutput("child", 1, TopicA)
+	output.OutputsConsumed = []*transaction.Outpoint{&consumed.Outpoint}
+	output.AncillaryTxids = []*chainhash.Hash{&consumed.Outpoint.Txid}
+	output.AncillaryBeef = []byte("ancillary")
+
+	// when:
+	insert(t, storage, output)
+	found := find(t, storage, output.Outpoint, TopicA)
+
+	// then:
+	require.NotNil(t, found)
+	require.Equal(t, output.Outpoint, found.Outpoint)
+	require.Equal(t, TopicA, found.Topic)
+	require.Equal(t, output.Satoshis, found.Satoshis)
+	require.NotNil(t, found.Script)
+	require.Equal(t, output.Script.Bytes(), found.Script.Bytes())
+	require.False(t, found.Spent)
+	require.Equal(t, output.Beef, found.Beef)
+	require.Equal(t, output.OutputsConsumed, found.OutputsConsumed)
+	require.Equal(t, output.AncillaryTxids, found.AncillaryTxids)
+	require.Equal(t, output.AncillaryBeef, found.AncillaryBeef)
+}
+
+func testFindOutputMissing(t *testing.T, storage engine.Storage) {
+	// given:
+	outpoint := NewOutput("missing", 0, TopicA).Outpoint
+
+	// when:
+	found, err := storage.FindOutput(context.Background(), &outpoint, nil, nil, false)
+
+	// then:
+	require.NoError(t, err, "a missing output must not be an error, the engine treats every FindOutput error as fatal")
+	require.Nil(t, found)
+}
+
+func testFindOutputTopicFilter(t *testing.T, storage engine.Storage) {
+	// given:
+	output := NewOutput("topic-filter", 0, TopicA)
+	insert(t, storage, output)
+
+	// when:
+	inA := find(t, storage, output.Outpoint, TopicA)
+	inB := find(t, storage, output.Outpoint, TopicB)
+	anyTopic, err := storage.FindOutput(context.Background(), &output.Outpoint, nil, nil, false)
+
+	// then:
+	require.NotNil(t, inA)
+	require.Nil(t, inB)
+	require.NoError(t, err)
+	require.NotNil(t, anyTopic)
+	require.Equal(t, TopicA, anyTopic.Topic)
+}
+
+func testFindOutputSpentFilter(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	topic := TopicA
+	output := NewOutput("spent-filter", 0, TopicA)
+	insert(t, storage, output)
+	spendTxid := TxID("spent-filter spender")
+	require.NoError(t, storage.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&output.Outpoint}, TopicA, &spendTxid))
+
+	// when:
+	unspent, err := storage.FindOutput(ctx, &output.Outpoint, &topic, &engine.FALSE, false)
+	require.NoError(t, err)
+	spent, err := storage.FindOutput(ctx, &output.Outpoint, &topic, &engine.TRUE, false)
+	require.NoError(t, err)
+
+	// then:
+	require.Nil(t, unspent)
+	require.NotNil(t, spent)
+	require.True(t, spent.Spent)
+}
+
+func testFindOutputsOrder(t *testing.T, storage engine.Storage) {
+	// given:
+	first := NewOutput("order", 0, TopicA)
+	second := NewOutput("order", 1, TopicA)
+	other := NewOutput("order", 2, TopicB)
+	missing := NewOutput("order missing", 0, TopicA)
+	insert(t, storage, first, second, other)
+
+	// when:
+	found, err := storage.FindOutputs(context.Background(), []*transaction.Outpoint{
+		&second.Outpoint, &missing.Outpoint, &first.Outpoint, &other.Outpoint,
+	}, TopicA, nil, false)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, found, 4, "FindOutputs must answer every outpoint, with nil for those not found")
+	require.NotNil(t, found[0])
+	require.Equal(t, second.Outpoint, found[0].Outpoint)
+	require.Nil(t, found[1])
+	require.NotNil(t, found[2])
+	require.Equal(t, first.Outpoint, found[2].Outpoint)
+	require.Nil(t, found[3], "FindOutputs must only return outputs of the topic")
+}
+
+func testFindOutputsForTransaction(t *testing.T, storage engine.Storage) {
+	// given:
+	outputs := []*engine.Output{NewOutput("tx", 0, TopicA), NewOutput("tx", 1, TopicA), NewOutput("tx", 0, TopicB)}
+	insert(t, storage, append(outputs, NewOutput("other tx", 0, TopicA))...)
+	txid := TxID("tx")
+
+	// when:
+	found, err := storage.FindOutputsForTransaction(context.Background(), &txid, true)
+
+	// then:
+	require.NoError(t, err)
+	require.ElementsMatch(t, keys(outputs), keys(found))
+	for _, output := range found {
+		require.Equal(t, []byte("beef of tx"), output.Beef)
+	}
+}
+
+func testFindUTXOsForTopic(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	utxos := []*engine.Output{NewOutput("utxos", 0, TopicA), NewOutput("utxos", 1, TopicA), NewOutput("utxos", 2, TopicA)}
+	spent := NewOutput("utxos spent", 0, TopicA)
+	insert(t, storage, utxos...)
+	insert(t, storage, spent, NewOutput("utxos", 0, TopicB))
+	spendTxid := TxID("utxos spender")
+	require.NoError(t, storage.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&spent.Outpoint}, TopicA, &spendTxid))
+
+	// when:
+	all, err := storage.FindUTXOsForTopic(ctx, TopicA, 0, 0, false)
+
+	// then:
+	require.NoError(t, err)
+	require.ElementsMatch(t, keys(utxos), keys(all), "FindUTXOsForTopic must return the unspent outputs of the topic, a zero limit returning them all")
+	require.True(t, sort.SliceIsSorted(all, func(i, j int) bool { return all[i].Score < all[j].Score }), "FindUTXOsForTopic must order outputs by ascending score")
+
+	// when:
+	page, err := storage.FindUTXOsForTopic(ctx, TopicA, 0, 1, false)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, all[0].Outpoint, page[0].Outpoint)
+
+	// when:
+	since, err := storage.FindUTXOsForTopic(ctx, TopicA, all[1].Score, 0, false)
+
+	// then:
+	require.NoError(t, err)
+	for _, output := range since {
+		require.GreaterOrEqual(t, output.Score, all[1].Score)
+	}
+	require.Contains(t, keys(since), key(all[1]), "since must be inclusive")
+	require.Contains(t, keys(since), key(all[2]))
+}
+
+func testInsertOutputScore(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	later := NewOutput("scored later", 0, TopicA)
+	earlier := NewOutput("scored earlier", 0, TopicA)
+	later.Score, earlier.Score = 800002.5, 800001.5
+	insert(t, storage, later, earlier)
+
+	// when:
+	all, err := storage.FindUTXOsForTopic(ctx, TopicA, 800002, 0, false)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{key(later)}, keys(all), "FindUTXOsForTopic must page by the score given to InsertOutput")
+	require.InDelta(t, later.Score, all[0].Score, 0)
+	require.InDelta(t, earlier.Score, find(t, storage, earlier.Outpoint, TopicA).Score, 0)
+}
+
+func testMarkUTXOsAsSpent(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	inA := NewOutput("mark", 0, TopicA)
+	inB := NewOutput("mark", 0, TopicB)
+	insert(t, storage, inA, inB)
+	spendTxid := TxID("mark spender")
+
+	// when:
+	err := storage.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&inA.Outpoint}, TopicA, &spendTxid)
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, find(t, storage, inA.Outpoint, TopicA).Spent)
+	require.False(t, find(t, storage, inB.Outpoint, TopicB).Spent)
+}
+
+func testMarkUTXOsAsUnspent(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	rollback, ok := storage.(engine.SpendRollbackStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.SpendRollbackStorage")
+	}
+	inA := NewOutput("unmark", 0, TopicA)
+	inB := NewOutput("unmark", 0, TopicB)
+	insert(t, storage, inA, inB)
+	spendTxid := TxID("unmark spender")
+	outpoints := []*transaction.Outpoint{&inA.Outpoint}
+	require.NoError(t, storage.MarkUTXOsAsSpent(ctx, outpoints, TopicA, &spendTxid))
+	require.NoError(t, storage.MarkUTXOsAsSpent(ctx, outpoints, TopicB, &spendTxid))
+
+	// when:
+	err := rollback.MarkUTXOsAsUnspent(ctx, outpoints, TopicA)
+
+	// then:
+	require.NoError(t, err)
+	require.False(t, find(t, storage, inA.Outpoint, TopicA).Spent)
+	require.True(t, find(t, storage, inB.Outpoint, TopicB).Spent)
+}
+
+func testUpdateConsumedBy(t *testing.T, storage engine.Storage) {
+	// given:
+	output := NewOutput("consumed", 0, TopicA)
+	insert(t, storage, output)
+	first := NewOutput("consumer", 0, TopicA).Outpoint
+	second := NewOutput("consumer", 1, TopicA).Outpoint
+	require.NoError(t, storage.UpdateConsumedBy(context.Background(), &output.Outpoint, TopicA, []*transaction.Outpoint{&first}))
+
+	// when:
+	err := storage.UpdateConsumedBy(context.Background(), &output.Outpoint, TopicA, []*transaction.Outpoint{&first, &second})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{&first, &second}, find(t, storage, output.Outpoint, TopicA).ConsumedBy)
+}
+
+func testUpdateOutputBlockHeight(t *testing.T, storage engine.Storage) {
+	// given:
+	output := NewOutput("mined", 0, TopicA)
+	insert(t, storage, output)
+
+	// when:
+	err := storage.UpdateOutputBlockHeight(context.Background(), &output.Outpoint, TopicA, 800000, 12, []byte("ancillary"))
+
+	// then:
+	require.NoError(t, err)
+	found := find(t, storage, output.Outpoint, TopicA)
+	require.Equal(t, uint32(800000), found.BlockHeight)
+	require.Equal(t, uint64(12), found.BlockIdx)
+	require.Equal(t, []byte("ancillary"), found.AncillaryBeef)
+}
+
+func testUpdateTransactionBEEF(t *testing.T, storage engine.Storage) {
+	// given:
+	outputs := []*engine.Output{NewOutput("rebeef", 0, TopicA), NewOutput("rebeef", 1, TopicB)}
+	insert(t, storage, outputs...)
+	txid := TxID("rebeef")
+
+	// when:
+	err := storage.UpdateTransactionBEEF(context.Background(), &txid, []byte("proven beef"))
+
+	// then:
+	require.NoError(t, err)
+	for _, output := range outputs {
+		require.Equal(t, []byte("proven beef"), find(t, storage, output.Outpoint, output.Topic).Beef)
+	}
+}
+
+func testDeleteOutput(t *testing.T, storage engine.Storage) {
+	// given:
+	inA := NewOutput("delete", 0, TopicA)
+	inB := NewOutput("delete", 0, TopicB)
+	insert(t, storage, inA, inB)
+
+	// when:
+	err := storage.DeleteOutput(context.Background(), &inA.Outpoint, TopicA)
+
+	// then:
+	require.NoError(t, err)
+	require.Nil(t, find(t, storage, inA.Outpoint, TopicA))
+	require.NotNil(t, find(t, storage, inB.Outpoint, TopicB))
+}
+
+func testAppliedTransactions(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	txid := TxID("applied")
+	require.NoError(t, storage.InsertAppliedTransaction(ctx, &overlay.AppliedTransaction{Txid: &txid, Topic: TopicA}))
+
+	// when:
+	inA, errA := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: &txid, Topic: TopicA})
+	inB, errB := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: &txid, Topic: TopicB})
+
+	// then:
+	require.NoError(t, errA)
+	require.NoError(t, errB)
+	require.True(t, inA)
+	require.False(t, inB)
+}
+
+// batchStorage returns the BatchStorage capability of the storage, skipping the test if it does not implement it.
+func batchStorage(t *testing.T, storage engine.Storage) engine.BatchStorage {
+	t.Helper()
+	batch, ok := storage.(engine.BatchStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.BatchStorage")
+	}
+	return batch
+}
+
+func testBatchInsertOutputs(t *testing.T, storage engine.Storage) {
+	// given:
+	batch := batchStorage(t, storage)
+	spent := NewOutput("batch spent", 0, TopicA)
+	spent.Spent = true
+	spent.ConsumedBy = []*transaction.Outpoint{{Txid: TxID("batch spender"), Index: 0}}
+	outputs := []*engine.Output{NewOutput("batch", 1, TopicA), NewOutput("batch", 0, TopicA), spent, NewOutput("batch", 0, TopicB)}
+
+	// when:
+	err := batch.InsertOutputs(context.Background(), outputs)
+
+	// then:
+	require.NoError(t, err)
+	for _, output := range outputs {
+		found := find(t, storage, output.Outpoint, output.Topic)
+		require.NotNil(t, found, "InsertOutputs must store %s", key(output))
+		require.Equal(t, output.Satoshis, found.Satoshis)
+		require.Equal(t, output.Spent, found.Spent)
+		require.ElementsMatch(t, output.ConsumedBy, found.ConsumedBy)
+	}
+
+	utxos, err := storage.FindUTXOsForTopic(context.Background(), TopicA, 0, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, keys(outputs[:2]), keys(utxos), "InsertOutputs must score the outputs in the order of the batch")
+}
+
+func testBatchInsertAppliedTransactions(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	batch := batchStorage(t, storage)
+	first, second := TxID("batch applied 1"), TxID("batch applied 2")
+
+	// when:
+	err := batch.InsertAppliedTransactions(ctx, []*overlay.AppliedTransaction{
+		{Txid: &first, Topic: TopicA},
+		{Txid: &second, Topic: TopicB},
+	})
+
+	// then:
+	require.NoError(t, err)
+	for _, applied := range []*overlay.AppliedTransaction{{Txid: &first, Topic: TopicA}, {Txid: &second, Topic: TopicB}} {
+		exists, err := storage.DoesAppliedTransactionExist(ctx, applied)
+		require.NoError(t, err)
+		require.True(t, exists, "InsertAppliedTransactions must record %s in %s", applied.Txid, applied.Topic)
+	}
+	exists, err := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: &first, Topic: TopicB})
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func testLastInteractions(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	const host = "https://peer.example"
+
+	// when:
+	missing, err := storage.GetLastInteraction(ctx, host, TopicA)
+
+	// then:
+	require.NoError(t, err, "a missing interaction must not be an error")
+	require.Zero(t, missing)
+
+	// when:
+	require.NoError(t, storage.UpdateLastInteraction(ctx, host, TopicA, 10))
+	require.NoError(t, storage.UpdateLastInteraction(ctx, host, TopicA, 20))
+	updated, err := storage.GetLastInteraction(ctx, host, TopicA)
+	require.NoError(t, err)
+	otherTopic, err := storage.GetLastInteraction(ctx, host, TopicB)
+	require.NoError(t, err)
+	otherHost, err := storage.GetLastInteraction(ctx, "https://other.example", TopicA)
+	require.NoError(t, err)
+
+	// then:
+	require.InDelta(t, 20.0, updated, 0)
+	require.Zero(t, otherTopic)
+	require.Zero(t, otherHost)
+}
+
+func testConcurrentInserts(t *testing.T, storage engine.Storage) {
+	// given:
+	outputs := make([]*engine.Output, ConcurrentWriters)
+	outpoints := make([]*transaction.Outpoint, ConcurrentWriters)
+	for i := range outputs {
+		outputs[i] = NewOutput(fmt.Sprintf("concurrent %d", i), 0, TopicA)
+		outpoints[i] = &outputs[i].Outpoint
+	}
+
+	// when:
+	errs := make([]error, len(outputs))
+	var wg sync.WaitGroup
+	for i, output := range outputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = storage.InsertOutput(context.Background(), output)
+		}()
+	}
+	wg.Wait()
+
+	// then:
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	found, err := storage.FindOutputs(context.Background(), outpoints, TopicA, nil, false)
+	require.NoError(t, err)
+	require.Len(t, found, len(outputs))
+	for i, output := range found {
+		require.NotNil(t, output, "output %s was lost", outpoints[i])
+	}
+}
+
+func testConcurrentInteractions(t *testing.T, storage engine.Storage) {
+	// when:
+	errs := make([]error, ConcurrentWriters)
+	var wg sync.WaitGroup
+	for i := range ConcurrentWriters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = storage.UpdateLastInteraction(context.Background(), fmt.Sprintf("https://peer-%d.example", i), TopicA, float64(i+1))
+		}()
+	}
+	wg.Wait()
+
+	// then:
+	for i, err := range errs {
+		require.NoError(t, err)
+		score, err := storage.GetLastInteraction(context.Background(), fmt.Sprintf("https://peer-%d.example", i), TopicA)
+		require.NoError(t, err)
+		require.InDelta(t, float64(i+1), score, 0)
+	}
+}
+
+func tombstoneStorage(t *testing.T, storage engine.Storage) engine.OutputTombstoneStorage {
+	t.Helper()
+	tombstones, ok := storage.(engine.OutputTombstoneStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.OutputTombstoneStorage")
+	}
+	return tombstones
+}
+
+func testTombstoneOutput(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	tombstones := tombstoneStorage(t, storage)
+	inA := NewOutput("tombstone", 0, TopicA)
+	inB := NewOutput("tombstone", 0, TopicB)
+	insert(t, storage, inA, inB)
+	deletedAt := time.Now().Truncate(time.Millisecond)
+
+	// when:
+	err := tombstones.TombstoneOutput(ctx, &inA.Outpoint, TopicA, engine.TombstoneReasonDisputeLost, deletedAt)
+
+	// then:
+	require.NoError(t, err)
+	require.Nil(t, find(t, storage, inA.Outpoint, TopicA), "TombstoneOutput must hide the output from FindOutput")
+	require.NotNil(t, find(t, storage, inB.Outpoint, TopicB))
+	utxos, err := storage.FindUTXOsForTopic(ctx, TopicA, 0, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, utxos, "TombstoneOutput must hide the output from FindUTXOsForTopic")
+
+	found, err := tombstones.FindTombstones(ctx, &engine.TombstoneQuery{Outpoint: &inA.Outpoint})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, inA.Outpoint, found[0].Output.Outpoint)
+	require.Equal(t, TopicA, found[0].Output.Topic)
+	require.Equal(t, inA.Beef, found[0].Output.Beef, "TombstoneOutput must keep the BEEF of the output")
+	require.Equal(t, engine.TombstoneReasonDisputeLost, found[0].Reason)
+	require.True(t, deletedAt.Equal(found[0].DeletedAt), "expected deletion at %s, got %s", deletedAt, found[0].DeletedAt)
+}
+
+func testFindTombstones(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	tombstones := tombstoneStorage(t, storage)
+	first, second, other := NewOutput("tombstones", 0, TopicA), NewOutput("tombstones", 1, TopicA), NewOutput("tombstones", 0, TopicB)
+	insert(t, storage, first, second, other)
+	now := time.Now().Truncate(time.Millisecond)
+	require.NoError(t, tombstones.TombstoneOutput(ctx, &second.Outpoint, TopicA, engine.TombstoneReasonUnminedEvicted, now))
+	require.NoError(t, tombstones.TombstoneOutput(ctx, &first.Outpoint, TopicA, engine.TombstoneReasonNoLongerRetained, now.Add(-time.Hour)))
+	require.NoError(t, tombstones.TombstoneOutput(ctx, &other.Outpoint, TopicB, engine.TombstoneReasonNoLongerRetained, now))
+
+	// when:
+	byTopic, err := tombstones.FindTombstones(ctx, &engine.TombstoneQuery{Topic: TopicA})
+	require.NoError(t, err)
+	byReason, err := tombstones.FindTombstones(ctx, &engine.TombstoneQuery{Reason: engine.TombstoneReasonNoLongerRetained})
+	require.NoError(t, err)
+	recent, err := tombstones.FindTombstones(ctx, &engine.TombstoneQuery{Topic: TopicA, DeletedAfter: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	limited, err := tombstones.FindTombstones(ctx, &engine.TombstoneQuery{Limit: 1})
+	require.NoError(t, err)
+
+	// then:
+	require.Equal(t, []string{key(first), key(second)}, tombstoneKeys(byTopic), "FindTombstones must return the oldest deletion first")
+	require.ElementsMatch(t, []string{key(first), key(other)}, tombstoneKeys(byReason))
+	require.Equal(t, []string{key(second)}, tombstoneKeys(recent))
+	require.Equal(t, []string{key(first)}, tombstoneKeys(limited))
+}
+
+func testPurgeTombstones(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	tombstones := tombstoneStorage(t, storage)
+	old, recent := NewOutput("purge", 0, TopicA), NewOutput("purge", 1, TopicA)
+	insert(t, storage, old, recent)
+	now := time.Now().Truncate(time.Millisecond)
+	require.NoError(t, tombstones.TombstoneOutput(ctx, &old.Outpoint, TopicA, engine.TombstoneReasonNoLongerRetained, now.Add(-48*time.Hour)))
+	require.NoError(t, tombstones.TombstoneOutput(ctx, &recent.Outpoint, TopicA, engine.TombstoneReasonNoLongerRetained, now))
+
+	// when:
+	purged, err := tombstones.PurgeTombstones(ctx, now.Add(-24*time.Hour))
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	remaining, err := tombstones.FindTombstones(ctx, &engine.TombstoneQuery{})
+	require.NoError(t, err)
+	require.Equal(t, []string{key(recent)}, tombstoneKeys(remaining))
+}
+
+func merkleStateStorage(t *testing.T, storage engine.Storage) engine.MerkleStateStorage {
+	t.Helper()
+	states, ok := storage.(engine.MerkleStateStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.MerkleStateStorage")
+	}
+	return states
+}
+
+func testUpdateMerkleState(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	states := merkleStateStorage(t, storage)
+	inA := NewOutput("reorged", 0, TopicA)
+	inB := NewOutput("reorged", 0, TopicB)
+	inA.BlockHeight, inB.BlockHeight = 800000, 800000
+	insert(t, storage, inA, inB)
+
+	// when:
+	err := states.UpdateMerkleState(ctx, &inA.Outpoint, TopicA, engine.MerkleStateInvalidated)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, engine.MerkleStateInvalidated, find(t, storage, inA.Outpoint, TopicA).CurrentMerkleState())
+	require.Equal(t, engine.MerkleStateMined, find(t, storage, inB.Outpoint, TopicB).CurrentMerkleState())
+}
+
+func testFindOutpointsByMerkleState(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	states := merkleStateStorage(t, storage)
+	late, early, unmined, immutable, other := NewOutput("states", 0, TopicA), NewOutput("states", 1, TopicA),
+		NewOutput("states", 2, TopicA), NewOutput("states", 3, TopicA), NewOutput("states", 0, TopicB)
+	late.BlockHeight, early.BlockHeight, immutable.BlockHeight, other.BlockHeight = 800002, 800001, 800000, 800000
+	early.MerkleState = engine.MerkleStateMined
+	insert(t, storage, late, early, unmined, immutable, other)
+	require.NoError(t, states.UpdateMerkleState(ctx, &immutable.Outpoint, TopicA, engine.MerkleStateImmutable))
+	require.NoError(t, storage.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&late.Outpoint}, TopicA, nil))
+
+	// when:
+	mined, err := states.FindOutpointsByMerkleState(ctx, TopicA, engine.MerkleStateMined, 0)
+	require.NoError(t, err)
+	limited, err := states.FindOutpointsByMerkleState(ctx, TopicA, engine.MerkleStateMined, 1)
+	require.NoError(t, err)
+	unminedFound, err := states.FindOutpointsByMerkleState(ctx, TopicA, engine.MerkleStateUnmined, 0)
+	require.NoError(t, err)
+
+	// then:
+	require.Equal(t, []*transaction.Outpoint{&early.Outpoint, &late.Outpoint}, mined, "outputs stored without a merkle state must be found by their block height, spent or not")
+	require.Equal(t, []*transaction.Outpoint{&early.Outpoint}, limited)
+	require.Equal(t, []*transaction.Outpoint{&unmined.Outpoint}, unminedFound)
+}
+
+func testUpdateOutputScore(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	scores, ok := storage.(engine.OutputScoreStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.OutputScoreStorage")
+	}
+	first, second := NewOutput("rescored", 0, TopicA), NewOutput("rescored", 1, TopicA)
+	first.Score, second.Score = 1, 2
+	insert(t, storage, first, second)
+
+	// when:
+	err := scores.UpdateOutputScore(ctx, &first.Outpoint, TopicA, 3)
+
+	// then:
+	require.NoError(t, err)
+	all, err := storage.FindUTXOsForTopic(ctx, TopicA, 0, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{key(second), key(first)}, keys(all))
+}
+
+func testPruneOutputsKeepsPinned(t *testing.T, storage engine.Storage) {
+	// given:
+	ctx := context.Background()
+	prunes, ok := storage.(engine.OutputPruneStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.OutputPruneStorage")
+	}
+	pins, ok := storage.(engine.OutputPinStorage)
+	if !ok {
+		t.Skip("storage does not implement engine.OutputPinStorage")
+	}
+	pinned, pruned, unspent := NewOutput("pruned", 0, TopicA), NewOutput("pruned", 1, TopicA), NewOutput("pruned", 2, TopicA)
+	pinned.Spent, pruned.Spent = true, true
+	insert(t, storage, pinned, pruned, unspent)
+	require.NoError(t, pins.UpdateOutputPinned(ctx, &pinned.Outpoint, TopicA, true))
+
+	// when:
+	deleted, err := prunes.PruneOutputs(ctx, &engine.PruneCriteria{Topic: TopicA, AllSpent: true})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{&pruned.Outpoint}, deleted)
+	kept := find(t, storage, pinned.Outpoint, TopicA)
+	require.NotNil(t, kept, "pinned outputs must not be pruned")
+	require.True(t, kept.Pinned)
+	require.Nil(t, find(t, storage, pruned.Outpoint, TopicA))
+	require.NotNil(t, find(t, storage, unspent.Outpoint, TopicA), "unspent outputs must not be pruned")
+}
+
+func tombstoneKeys(tombstones []*engine.Tombstone) []string {
+	keys := make([]string, 0, len(tombstones))
+	for _, tombstone := range tombstones {
+		keys = append(keys, key(tombstone.Output))
+	}
+	return keys
+}
+
+func key(output *engine.Output) string {
+	return output.Outpoint.String() + " " + output.Topic
+}
+
+func keys(outputs []*engine.Output) []string {
+	keys := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		keys = append(keys, key(output))
+	}
+	return keys
+}
@@ -0,0 +1,21 @@
+package storagetest_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine/storagetest"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+)
+
+func TestRun_MemoryStorage(t *testing.T) {
+	storagetest.Run(t, func(_ *testing.T) engine.Storage {
+		return testharness.NewMemoryStorage()
+	})
+}
+
+func TestRun_ReplicatingStorage(t *testing.T) {
+	storagetest.Run(t, func(_ *testing.T) engine.Storage {
+		return engine.NewReplicatingStorage(testharness.NewMemoryStorage(), engine.NewReplicationLog(0))
+	})
+}
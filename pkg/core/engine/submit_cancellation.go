@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// ErrSubmitCanceled is returned by Submit when its context is done before the submission is applied.
+// It wraps the error of the context, e.g. context.DeadlineExceeded.
+var ErrSubmitCanceled = errors.New("submission canceled")
+
+// submitRollbackTimeout bounds the rollback of the writes of a canceled submission, which outlives its context.
+const submitRollbackTimeout = 30 * time.Second
+
+// SpendRollbackStorage is an optional Storage capability used to roll back the outputs a canceled submission
+// marked as spent. Without it, the outputs stay spent until the transaction is submitted again.
+type SpendRollbackStorage interface {
+	// MarkUTXOsAsUnspent reverts MarkUTXOsAsSpent for the outpoints of the topic.
+	MarkUTXOsAsUnspent(ctx context.Context, outpoints []*transaction.Outpoint, topic string) error
+}
+
+// submitCanceled returns ErrSubmitCanceled, wrapping the error of ctx, when ctx is done before the phase of a
+// submission, and nil otherwise.
+func submitCanceled(ctx context.Context, phase string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	err := fmt.Errorf("%w before %s: %w", ErrSubmitCanceled, phase, ctx.Err())
+	logger(ctx).Warn("submission canceled", "phase", phase, "cause", context.Cause(ctx), "error", err)
+	return err
+}
+
+// rollbackSpent marks the inputs of a canceled submission as unspent again in the topics it already marked
+// them as spent in. The rollback is not interrupted by the cancellation of ctx.
+func (e *Engine) rollbackSpent(ctx context.Context, storage Storage, txid *chainhash.Hash, inpoints []*transaction.Outpoint, topics []string) {
+	if len(topics) == 0 {
+		return
+	}
+	rollback, ok := storageCapability[SpendRollbackStorage](storage)
+	if !ok {
+		logger(ctx).Error("cannot roll back inputs marked as spent by canceled submission", "txid", txid, "topics", topics)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), submitRollbackTimeout)
+	defer cancel()
+	for _, topic := range topics {
+		if err := e.trackWrite(rollback.MarkUTXOsAsUnspent(ctx, inpoints, topic)); err != nil {
+			logger(ctx).Error("failed to roll back inputs marked as spent", "topic", topic, "txid", txid, "error", err)
+			continue
+		}
+		e.replicate(&Mutation{Op: MutationMarkUTXOsAsUnspent, Outpoints: inpoints, Topic: topic})
+	}
+}
@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultSubmitIdempotencyTTL is how long the STEAK of a submission is returned to retries when no TTL is configured.
+	DefaultSubmitIdempotencyTTL = 24 * time.Hour
+
+	// DefaultSubmitIdempotencyMaxEntries caps the remembered submissions when no cap is configured.
+	DefaultSubmitIdempotencyMaxEntries = 10000
+)
+
+// ErrIdempotencyKeyConflict is returned when an idempotency key is reused for a submission of another
+// transaction or of other topics than the submission it was first used for.
+var ErrIdempotencyKeyConflict = errors.New("idempotency key reused for a different submission")
+
+// SubmitIdempotencyConfig configures how long and how many successful submissions are remembered.
+type SubmitIdempotencyConfig struct {
+	// TTL is how long the STEAK of a submission is returned to retries. Zero falls back to DefaultSubmitIdempotencyTTL.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// MaxEntries caps the remembered submissions; the oldest ones are forgotten first.
+	// Zero falls back to DefaultSubmitIdempotencyMaxEntries.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// SubmitIdempotency remembers the STEAK of successful submissions by idempotency key, so retried
+// submissions return the original STEAK instead of re-running topic managers and lookup service
+// notifications. Concurrent submissions of the same key wait for the first one. Failed submissions
+// are forgotten, so they can be retried.
+type SubmitIdempotency struct {
+	cfg SubmitIdempotencyConfig
+
+	mu      sync.Mutex
+	entries map[string]*idempotentSubmission
+}
+
+type idempotentSubmission struct {
+	payload string        // txid and sorted topics of the submission
+	ready   chan struct{} // closed once steak or err is set
+	steak   overlay.Steak
+	err     error
+	readyAt time.Time
+}
+
+// NewSubmitIdempotency creates a SubmitIdempotency with the given configuration.
+func NewSubmitIdempotency(cfg SubmitIdempotencyConfig) *SubmitIdempotency {
+	return &SubmitIdempotency{cfg: cfg, entries: make(map[string]*idempotentSubmission)}
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context that sets the idempotency key of a single Submit, typically taken
+// from the Idempotency-Key header of a client request. Without a key, Submit derives one from the txid
+// and the topics of the tagged BEEF.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// submitIdempotencyKey returns the key set with WithIdempotencyKey, or else the key derived from the payload,
+// along with the payload of the submission: its txid and sorted topics. It returns an empty key when the BEEF
+// does not identify its transaction.
+func submitIdempotencyKey(ctx context.Context, taggedBEEF overlay.TaggedBEEF) (key, payload string) {
+	_, _, txid, err := transaction.ParseBeef(taggedBEEF.Beef)
+	if err != nil || txid == nil {
+		return "", ""
+	}
+	topics := slices.Clone(taggedBEEF.Topics)
+	slices.Sort(topics)
+	payload = txid.String() + ":" + strings.Join(topics, ",")
+
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok && key != "" {
+		return "key:" + key, payload
+	}
+	return "tx:" + payload, payload
+}
+
+func (i *SubmitIdempotency) ttl() time.Duration {
+	if i.cfg.TTL > 0 {
+		return i.cfg.TTL
+	}
+	return DefaultSubmitIdempotencyTTL
+}
+
+func (i *SubmitIdempotency) maxEntries() int {
+	if i.cfg.MaxEntries > 0 {
+		return i.cfg.MaxEntries
+	}
+	return DefaultSubmitIdempotencyMaxEntries
+}
+
+// begin returns the submission remembered for the key and whether the caller owns it, i.e. has to process it.
+// It returns ErrIdempotencyKeyConflict when the key is remembered for another payload.
+func (i *SubmitIdempotency) begin(key, payload string) (*idempotentSubmission, bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := i.entries[key]; ok && (entry.readyAt.IsZero() || now.Sub(entry.readyAt) <= i.ttl()) {
+		if entry.payload != payload {
+			return nil, false, ErrIdempotencyKeyConflict
+		}
+		return entry, false, nil
+	}
+	i.evict(now)
+
+	entry := &idempotentSubmission{payload: payload, ready: make(chan struct{})}
+	i.entries[key] = entry
+	return entry, true, nil
+}
+
+// evict forgets expired submissions and, above the cap, the oldest ones. The caller must hold the lock.
+func (i *SubmitIdempotency) evict(now time.Time) {
+	for key, entry := range i.entries {
+		if !entry.readyAt.IsZero() && now.Sub(entry.readyAt) > i.ttl() {
+			delete(i.entries, key)
+		}
+	}
+	for len(i.entries) >= i.maxEntries() {
+		oldestKey, oldest := "", time.Time{}
+		for key, entry := range i.entries {
+			if !entry.readyAt.IsZero() && (oldest.IsZero() || entry.readyAt.Before(oldest)) {
+				oldestKey, oldest = key, entry.readyAt
+			}
+		}
+		if oldestKey == "" {
+			return // only in-flight submissions remain
+		}
+		delete(i.entries, oldestKey)
+	}
+}
+
+// resolve records the STEAK of the submission, releasing the waiting retries. Only the first call has an effect.
+func (i *SubmitIdempotency) resolve(entry *idempotentSubmission, steak overlay.Steak) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !entry.readyAt.IsZero() {
+		return
+	}
+	entry.steak = steak
+	entry.readyAt = time.Now()
+	close(entry.ready)
+}
+
+// fail forgets the submission so it can be retried, releasing the waiting retries with the error
+// unless its STEAK was already returned.
+func (i *SubmitIdempotency) fail(key string, entry *idempotentSubmission, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.entries[key] == entry {
+		delete(i.entries, key)
+	}
+	if !entry.readyAt.IsZero() {
+		return
+	}
+	entry.err = err
+	entry.readyAt = time.Now()
+	close(entry.ready)
+}
+
+// submitIdempotent processes the submission once per idempotency key and returns the original STEAK to retries
+// of the same transaction and topics. Reusing the key for another submission fails with ErrIdempotencyKeyConflict.
+func (e *Engine) submitIdempotent(ctx context.Context, key, payload string, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error) {
+	entry, owner, err := e.Idempotency.begin(key, payload)
+	if err != nil {
+		logger(ctx).Error("rejecting idempotent submission", "key", key, "payload", payload, "error", err)
+		return nil, err
+	}
+	if !owner {
+		select {
+		case <-entry.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.err != nil {
+			logger(ctx).Error("idempotent submission failed", "key", key, "error", entry.err)
+			return nil, entry.err
+		}
+		logger(ctx).Info("returning the STEAK of an idempotent submission", "key", key)
+		steak := entry.steak
+		if onSteakReady != nil {
+			onSteakReady(&steak)
+		}
+		return steak, nil
+	}
+
+	steak, err := e.submit(ctx, taggedBEEF, mode, func(steak *overlay.Steak) {
+		e.Idempotency.resolve(entry, *steak)
+		if onSteakReady != nil {
+			onSteakReady(steak)
+		}
+	})
+	if err != nil {
+		e.Idempotency.fail(key, entry, err)
+		return nil, err
+	}
+	e.Idempotency.resolve(entry, steak)
+	return steak, nil
+}
@@ -0,0 +1,357 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/google/uuid"
+)
+
+const (
+	// SubmitJobPending marks a job waiting for a free worker.
+	SubmitJobPending = "pending"
+
+	// SubmitJobProcessing marks a job whose submission is being processed.
+	SubmitJobProcessing = "processing"
+
+	// SubmitJobCompleted marks a job whose submission succeeded; its STEAK is available.
+	SubmitJobCompleted = "completed"
+
+	// SubmitJobFailed marks a job whose submission failed; its error is available.
+	SubmitJobFailed = "failed"
+)
+
+const (
+	// DefaultSubmitJobWorkers is how many submissions are processed concurrently when no limit is configured.
+	DefaultSubmitJobWorkers = 4
+
+	// DefaultSubmitJobMaxQueued is how many unfinished jobs are accepted when no limit is configured.
+	DefaultSubmitJobMaxQueued = 1000
+
+	// DefaultSubmitJobRetention is how long finished jobs can be polled when no retention is configured.
+	DefaultSubmitJobRetention = time.Hour
+
+	// DefaultSubmitJobCallbackTimeout bounds the delivery of a job to its callback URL when no timeout is configured.
+	DefaultSubmitJobCallbackTimeout = 10 * time.Second
+)
+
+var (
+	// ErrSubmitJobsNotConfigured is returned when enqueuing or polling a submission on an engine without a SubmitJobQueue
+	ErrSubmitJobsNotConfigured = errors.New("no submit job queue configured")
+
+	// ErrSubmitJobNotFound is returned when polling a job that does not exist or is no longer retained
+	ErrSubmitJobNotFound = errors.New("submit job not found")
+
+	// ErrSubmitJobQueueFull is returned when enqueuing a submission while the maximum of unfinished jobs is reached
+	ErrSubmitJobQueueFull = errors.New("submit job queue is full")
+
+	// ErrSubmitJobCallbackNotAllowed is returned when enqueuing a submission with a callback URL that is not
+	// covered by the CallbackAllowlist of the queue, or when the queue has no allowlist
+	ErrSubmitJobCallbackNotAllowed = errors.New("submit job callback URL is not allowed")
+
+	// ErrSubmitJobCallbackAddressForbidden is returned when delivering a job to a callback URL resolving to a
+	// loopback, private, link-local or unspecified address
+	ErrSubmitJobCallbackAddressForbidden = errors.New("submit job callback address is forbidden")
+)
+
+// SubmitJob tracks a submission processed asynchronously. It is also the JSON payload delivered to the callback URL.
+type SubmitJob struct {
+	ID          string        `json:"jobId"`
+	Status      string        `json:"status"`
+	Topics      []string      `json:"topics"`
+	Steak       overlay.Steak `json:"steak,omitempty"` // set once the job completed
+	Error       string        `json:"error,omitempty"` // set once the job failed
+	CallbackURL string        `json:"-"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	CompletedAt time.Time     `json:"completedAt,omitzero"`
+}
+
+// SubmitJobQueueConfig configures the queue of asynchronous submissions.
+type SubmitJobQueueConfig struct {
+	// Workers is how many submissions are processed concurrently. Zero falls back to DefaultSubmitJobWorkers.
+	Workers int `mapstructure:"workers"`
+
+	// MaxQueued is how many unfinished jobs are accepted before enqueuing fails with ErrSubmitJobQueueFull.
+	// Zero falls back to DefaultSubmitJobMaxQueued.
+	MaxQueued int `mapstructure:"max_queued"`
+
+	// Retention is how long finished jobs can be polled. Zero falls back to DefaultSubmitJobRetention.
+	Retention time.Duration `mapstructure:"retention"`
+
+	// CallbackTimeout bounds the delivery of a finished job to its callback URL.
+	// Zero falls back to DefaultSubmitJobCallbackTimeout.
+	CallbackTimeout time.Duration `mapstructure:"callback_timeout"`
+
+	// CallbackAllowlist lists the callback URLs jobs may be delivered to, as hosts, e.g. "client.example" or
+	// "client.example:8443", or as URL prefixes, e.g. "https://client.example/jobs/". Submissions with other
+	// callback URLs are rejected with ErrSubmitJobCallbackNotAllowed; without an allowlist callbacks are disabled.
+	CallbackAllowlist []string `mapstructure:"callback_allowlist"`
+
+	// CallbackAllowPrivateNetworks allows delivering jobs to callback URLs resolving to loopback, private or
+	// link-local addresses, which are refused by default so that clients cannot reach the internal network of
+	// the node. Redirects are never followed.
+	CallbackAllowPrivateNetworks bool `mapstructure:"callback_allow_private_networks"`
+}
+
+// SubmitJobQueue processes submissions in the background so clients do not have to hold their
+// connection open while long SPV or lookup chains are evaluated. Jobs are kept in memory and
+// finished jobs are forgotten once their retention elapses.
+type SubmitJobQueue struct {
+	cfg    SubmitJobQueueConfig
+	slots  chan struct{}
+	client *http.Client
+
+	mu         sync.Mutex
+	jobs       map[string]*SubmitJob
+	unfinished int
+}
+
+// NewSubmitJobQueue creates a SubmitJobQueue with the given configuration.
+func NewSubmitJobQueue(cfg SubmitJobQueueConfig) *SubmitJobQueue {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = DefaultSubmitJobWorkers
+	}
+	timeout := cfg.CallbackTimeout
+	if timeout <= 0 {
+		timeout = DefaultSubmitJobCallbackTimeout
+	}
+	return &SubmitJobQueue{
+		cfg:    cfg,
+		slots:  make(chan struct{}, workers),
+		client: newSubmitJobCallbackClient(timeout, cfg.CallbackAllowPrivateNetworks),
+		jobs:   make(map[string]*SubmitJob),
+	}
+}
+
+// newSubmitJobCallbackClient returns the client delivering jobs to their callback URLs. It does not follow
+// redirects and, unless allowPrivate, refuses to connect to loopback, private, link-local and unspecified
+// addresses, checking the address resolved at dial time so that DNS cannot rebind the callback host to them.
+func newSubmitJobCallbackClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+				return fmt.Errorf("%w: %s", ErrSubmitJobCallbackAddressForbidden, host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// allowsCallback reports whether the callback URL is covered by the CallbackAllowlist: its host matches a host
+// entry, or its scheme and host match a URL prefix entry and its path starts with the path of the entry.
+func (q *SubmitJobQueue) allowsCallback(callbackURL string) bool {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return false
+	}
+	for _, allowed := range q.cfg.CallbackAllowlist {
+		if strings.Contains(allowed, "://") {
+			prefix, err := url.Parse(allowed)
+			if err == nil && parsed.Scheme == prefix.Scheme && strings.EqualFold(parsed.Host, prefix.Host) &&
+				strings.HasPrefix(parsed.Path, prefix.Path) {
+				return true
+			}
+		} else if strings.EqualFold(parsed.Host, allowed) || strings.EqualFold(parsed.Hostname(), allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *SubmitJobQueue) maxQueued() int {
+	if q.cfg.MaxQueued > 0 {
+		return q.cfg.MaxQueued
+	}
+	return DefaultSubmitJobMaxQueued
+}
+
+func (q *SubmitJobQueue) retention() time.Duration {
+	if q.cfg.Retention > 0 {
+		return q.cfg.Retention
+	}
+	return DefaultSubmitJobRetention
+}
+
+// add registers a new pending job, forgetting finished jobs whose retention elapsed.
+func (q *SubmitJobQueue) add(topics []string, callbackURL string) (*SubmitJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	for id, job := range q.jobs {
+		if !job.CompletedAt.IsZero() && now.Sub(job.CompletedAt) > q.retention() {
+			delete(q.jobs, id)
+		}
+	}
+	if q.unfinished >= q.maxQueued() {
+		return nil, ErrSubmitJobQueueFull
+	}
+
+	job := &SubmitJob{
+		ID:          uuid.NewString(),
+		Status:      SubmitJobPending,
+		Topics:      topics,
+		CallbackURL: callbackURL,
+		CreatedAt:   now,
+	}
+	q.jobs[job.ID] = job
+	q.unfinished++
+	return job.snapshot(), nil
+}
+
+// find returns a copy of the job with the given ID, or nil if it is unknown or no longer retained.
+func (q *SubmitJobQueue) find(id string) *SubmitJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok || (!job.CompletedAt.IsZero() && time.Since(job.CompletedAt) > q.retention()) {
+		return nil
+	}
+	return job.snapshot()
+}
+
+// update applies fn to the job with the given ID and returns a copy of the result.
+func (q *SubmitJobQueue) update(id string, fn func(job *SubmitJob)) *SubmitJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job := q.jobs[id]
+	fn(job)
+	if job.Status == SubmitJobCompleted || job.Status == SubmitJobFailed {
+		job.CompletedAt = time.Now()
+		q.unfinished--
+	}
+	return job.snapshot()
+}
+
+func (j *SubmitJob) snapshot() *SubmitJob {
+	cp := *j
+	return &cp
+}
+
+// EnqueueSubmit accepts the tagged BEEF for submission in the current mode and returns the pending job
+// immediately. The submission is processed in the background; poll its outcome with FindSubmitJob.
+// A non-empty callbackURL additionally receives the finished job as a JSON POST request; it must be covered by
+// the CallbackAllowlist of the queue.
+// Returns ErrSubmitJobsNotConfigured without a SubmitJobQueue, ErrSubmitJobCallbackNotAllowed for a callback URL
+// outside the allowlist and ErrSubmitJobQueueFull when the queue is full.
+func (e *Engine) EnqueueSubmit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, callbackURL string) (*SubmitJob, error) {
+	if e.SubmitJobs == nil {
+		slog.Error("cannot enqueue submission", "error", ErrSubmitJobsNotConfigured)
+		return nil, ErrSubmitJobsNotConfigured
+	}
+	if callbackURL != "" && !e.SubmitJobs.allowsCallback(callbackURL) {
+		slog.Error("cannot enqueue submission", "callbackURL", callbackURL, "error", ErrSubmitJobCallbackNotAllowed)
+		return nil, ErrSubmitJobCallbackNotAllowed
+	}
+
+	job, err := e.SubmitJobs.add(taggedBEEF.Topics, callbackURL)
+	if err != nil {
+		slog.Error("cannot enqueue submission", "error", err)
+		return nil, err
+	}
+
+	go e.processSubmitJob(context.WithoutCancel(ctx), job.ID, taggedBEEF, callbackURL)
+	return job, nil
+}
+
+// FindSubmitJob returns the job with the given ID.
+// Returns ErrSubmitJobsNotConfigured without a SubmitJobQueue and ErrSubmitJobNotFound for unknown or expired jobs.
+func (e *Engine) FindSubmitJob(_ context.Context, id string) (*SubmitJob, error) {
+	if e.SubmitJobs == nil {
+		slog.Error("cannot find submit job", "id", id, "error", ErrSubmitJobsNotConfigured)
+		return nil, ErrSubmitJobsNotConfigured
+	}
+
+	job := e.SubmitJobs.find(id)
+	if job == nil {
+		slog.Error("submit job not found", "id", id, "error", ErrSubmitJobNotFound)
+		return nil, ErrSubmitJobNotFound
+	}
+	return job, nil
+}
+
+// processSubmitJob waits for a free worker, submits the tagged BEEF and records the outcome of the job.
+func (e *Engine) processSubmitJob(ctx context.Context, id string, taggedBEEF overlay.TaggedBEEF, callbackURL string) {
+	queue := e.SubmitJobs
+	queue.slots <- struct{}{}
+	queue.update(id, func(job *SubmitJob) { job.Status = SubmitJobProcessing })
+
+	steak, err := e.Submit(ctx, taggedBEEF, SubmitModeCurrent, nil)
+	<-queue.slots
+
+	job := queue.update(id, func(job *SubmitJob) {
+		if err != nil {
+			job.Status = SubmitJobFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = SubmitJobCompleted
+		job.Steak = steak
+	})
+	if err != nil {
+		slog.Error("submit job failed", "id", id, "error", err)
+	} else {
+		slog.Info("submit job completed", "id", id)
+	}
+
+	if callbackURL != "" {
+		if err := queue.deliver(ctx, callbackURL, job); err != nil {
+			slog.Error("failed to deliver submit job to callback URL", "id", id, "callbackURL", callbackURL, "error", err)
+		}
+	}
+}
+
+// deliver posts the finished job to its callback URL. A redirect fails the delivery like any non-2xx status.
+func (q *SubmitJobQueue) deliver(ctx context.Context, callbackURL string, job *SubmitJob) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := q.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("callback responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package engine
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultSubmitReplayTTL is how long the STEAK of a submitted transaction is returned to its replays when no TTL is configured.
+	DefaultSubmitReplayTTL = 10 * time.Minute
+
+	// DefaultSubmitReplayMaxEntries caps the remembered transactions when no cap is configured.
+	DefaultSubmitReplayMaxEntries = 100000
+)
+
+// SubmitReplayCacheConfig configures the window in which replayed submissions are answered from memory.
+type SubmitReplayCacheConfig struct {
+	// TTL is how long the STEAK of a submitted transaction is returned to its replays. Zero falls back to DefaultSubmitReplayTTL.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// MaxEntries caps the remembered transactions; the least recently submitted ones are forgotten first.
+	// Zero falls back to DefaultSubmitReplayMaxEntries.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// SubmitReplayMetrics is a snapshot of the counters of a SubmitReplayCache.
+type SubmitReplayMetrics struct {
+	Hits      uint64 `json:"hits"`      // replays answered from the cache
+	Misses    uint64 `json:"misses"`    // submissions processed in full
+	Evictions uint64 `json:"evictions"` // transactions forgotten above MaxEntries before their TTL expired
+	Entries   int    `json:"entries"`   // transactions remembered
+}
+
+// SubmitReplayCache remembers, by txid, the admittance instructions every topic returned for recently submitted
+// transactions. A transaction submitted again to topics it was already applied to, as happens when several peers
+// forward it, is answered with the original STEAK after checking that its BEEF holds the transaction and, with
+// Storage.DoesAppliedTransactionExist, that it is still applied, without verifying it again nor running topic managers.
+type SubmitReplayCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu        sync.Mutex
+	order     *list.List // most recently submitted first
+	entries   map[chainhash.Hash]*list.Element
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+type submitReplay struct {
+	txid      chainhash.Hash
+	steak     overlay.Steak
+	expiresAt time.Time
+}
+
+// NewSubmitReplayCache creates an empty SubmitReplayCache with the given configuration.
+func NewSubmitReplayCache(cfg SubmitReplayCacheConfig) *SubmitReplayCache {
+	c := &SubmitReplayCache{
+		ttl:        cfg.TTL,
+		maxEntries: cfg.MaxEntries,
+		order:      list.New(),
+		entries:    make(map[chainhash.Hash]*list.Element),
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultSubmitReplayTTL
+	}
+	if c.maxEntries <= 0 {
+		c.maxEntries = DefaultSubmitReplayMaxEntries
+	}
+	return c
+}
+
+// Metrics returns a snapshot of the counters of the cache.
+func (c *SubmitReplayCache) Metrics() SubmitReplayMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return SubmitReplayMetrics{Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Entries: c.order.Len()}
+}
+
+// String returns the JSON encoded metrics, implementing expvar.Var.
+func (c *SubmitReplayCache) String() string {
+	bb, err := json.Marshal(c.Metrics())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+// Len returns the number of remembered transactions.
+func (c *SubmitReplayCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// get returns a copy of the admittance instructions of the topics when every one of them is remembered for the transaction.
+func (c *SubmitReplayCache) get(txid chainhash.Hash, topics []string) (overlay.Steak, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[txid]
+	if !ok || len(topics) == 0 {
+		return nil, false
+	}
+	entry := elem.Value.(*submitReplay)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, txid)
+		return nil, false
+	}
+	steak := make(overlay.Steak, len(topics))
+	for _, topic := range topics {
+		admit, ok := entry.steak[topic]
+		if !ok {
+			return nil, false
+		}
+		steak[topic] = cloneAdmittanceInstructions(admit)
+	}
+	return steak, true
+}
+
+// add remembers the admittance instructions of the topics of the transaction, restarting its TTL and forgetting
+// expired transactions and, above the cap, the least recently submitted ones.
+func (c *SubmitReplayCache) add(txid chainhash.Hash, steak overlay.Steak) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry := &submitReplay{txid: txid, steak: make(overlay.Steak, len(steak)), expiresAt: now.Add(c.ttl)}
+	if elem, ok := c.entries[txid]; ok {
+		previous := elem.Value.(*submitReplay)
+		if now.Before(previous.expiresAt) {
+			entry.steak = previous.steak
+		}
+		c.order.Remove(elem)
+	}
+	for topic, admit := range steak {
+		entry.steak[topic] = cloneAdmittanceInstructions(admit)
+	}
+	c.entries[txid] = c.order.PushFront(entry)
+
+	for oldest := c.order.Back(); oldest != nil; oldest = c.order.Back() {
+		expired := now.After(oldest.Value.(*submitReplay).expiresAt)
+		if !expired && c.order.Len() <= c.maxEntries {
+			break
+		} else if !expired {
+			c.evictions++
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*submitReplay).txid)
+	}
+}
+
+// forget forgets the transaction.
+func (c *SubmitReplayCache) forget(txid chainhash.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[txid]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, txid)
+	}
+}
+
+// record counts a replay answered from the cache, or a submission processed in full.
+func (c *SubmitReplayCache) record(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// cloneAdmittanceInstructions returns a copy of the instructions that does not share their slices.
+func cloneAdmittanceInstructions(admit *overlay.AdmittanceInstructions) *overlay.AdmittanceInstructions {
+	if admit == nil {
+		return &overlay.AdmittanceInstructions{}
+	}
+	return &overlay.AdmittanceInstructions{
+		OutputsToAdmit: slices.Clone(admit.OutputsToAdmit),
+		CoinsToRetain:  slices.Clone(admit.CoinsToRetain),
+		CoinsRemoved:   slices.Clone(admit.CoinsRemoved),
+		AncillaryTxids: slices.Clone(admit.AncillaryTxids),
+	}
+}
+
+// submittedTxid returns the txid of the transaction of the BEEF, after checking that the transaction the BEEF
+// carries hashes to it, so that a BEEF cannot claim the txid of another transaction in its atomic header.
+func submittedTxid(beef []byte) (*chainhash.Hash, error) {
+	_, tx, txid, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return nil, err
+	} else if txid == nil || tx == nil || !tx.TxID().Equal(*txid) {
+		return nil, ErrInvalidBeef
+	}
+	return txid, nil
+}
+
+// submitReplayable answers a replayed submission from SubmitReplays, and otherwise processes the submission and
+// remembers its STEAK for the replays to come. Replays are rejected like submissions while the engine is stopping
+// or its storage is degraded.
+func (e *Engine) submitReplayable(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode SumbitMode, onSteakReady OnSteakReady) (overlay.Steak, error) {
+	opCtx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		logger(ctx).Error("rejecting Submit while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	ctx = opCtx
+	if err := e.allowWrite(); err != nil {
+		logger(ctx).Error("rejecting Submit in degraded mode", "error", err)
+		return nil, err
+	}
+
+	txid, err := submittedTxid(taggedBEEF.Beef)
+	if err != nil {
+		return e.submitOnce(ctx, taggedBEEF, mode, onSteakReady) // rejected with the error of the BEEF
+	}
+	if steak, ok := e.replaySubmission(ctx, *txid, taggedBEEF.Topics); ok {
+		logger(ctx).Info("returning the STEAK of a replayed submission", "txid", txid)
+		if onSteakReady != nil {
+			onSteakReady(&steak)
+		}
+		return steak, nil
+	}
+
+	steak, err := e.submitOnce(ctx, taggedBEEF, mode, onSteakReady)
+	if err != nil {
+		return nil, err
+	}
+	e.SubmitReplays.add(*txid, steak)
+	return steak, nil
+}
+
+// replaySubmission returns the remembered STEAK of the transaction when it is still applied to every topic.
+func (e *Engine) replaySubmission(ctx context.Context, txid chainhash.Hash, topics []string) (overlay.Steak, bool) {
+	steak, ok := e.SubmitReplays.get(txid, topics)
+	if ok {
+		storage := e.storage(ctx)
+		for _, topic := range topics {
+			if _, known := e.topicManager(topic); !known {
+				ok = false
+				break
+			}
+			exists, err := storage.DoesAppliedTransactionExist(ctx, &overlay.AppliedTransaction{Txid: &txid, Topic: topic})
+			if err != nil || !exists {
+				if err != nil {
+					logger(ctx).Error("failed to check if replayed transaction exists", "txid", txid, "topic", topic, "error", err)
+				}
+				e.SubmitReplays.forget(txid)
+				ok = false
+				break
+			}
+		}
+	}
+	e.SubmitReplays.record(ok)
+	return steak, ok
+}
@@ -0,0 +1,327 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSubmitMaxConcurrent is how many submissions are processed concurrently when no limit is configured.
+	DefaultSubmitMaxConcurrent = 8
+
+	// DefaultSubmitMaxQueued is how many submissions may wait for a free slot when no limit is configured.
+	DefaultSubmitMaxQueued = 256
+
+	// DefaultSubmitMaxWait bounds the wait of a submission for a free slot when no limit is configured.
+	DefaultSubmitMaxWait = 30 * time.Second
+
+	// DefaultSubmitRetryAfter is advertised to submissions rejected while the engine is saturated.
+	DefaultSubmitRetryAfter = time.Second
+)
+
+// ErrSubmitSaturated is returned, wrapped in a SubmitSaturatedError, when a submission is rejected
+// because the engine already processes and queues as many submissions as it is configured to.
+var ErrSubmitSaturated = errors.New("engine is saturated with submissions")
+
+// SubmitSaturatedError is returned by Submit when the SubmitScheduler cannot take another submission.
+type SubmitSaturatedError struct {
+	// Topic is the topic the rejected submission was scheduled under, the first in sorted order of a submission
+	// tagged with several topics.
+	Topic string
+	// RetryAfter is how long the caller should wait before retrying.
+	RetryAfter time.Duration
+}
+
+func (e *SubmitSaturatedError) Error() string {
+	return fmt.Sprintf("%s, topic %q, retry after %s", ErrSubmitSaturated, e.Topic, e.RetryAfter)
+}
+
+// Unwrap returns ErrSubmitSaturated so that callers can match the error with errors.Is.
+func (e *SubmitSaturatedError) Unwrap() error { return ErrSubmitSaturated }
+
+// SubmitSchedulerConfig configures the concurrency limits and fair scheduling of submissions.
+type SubmitSchedulerConfig struct {
+	// MaxConcurrent is how many submissions are processed at once across all topics.
+	// Zero falls back to DefaultSubmitMaxConcurrent.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+
+	// MaxQueued is how many submissions may wait for a free slot before new ones are rejected.
+	// Zero falls back to DefaultSubmitMaxQueued.
+	MaxQueued int `mapstructure:"max_queued"`
+
+	// MaxWait bounds the wait of a submission for a free slot. Zero falls back to DefaultSubmitMaxWait.
+	MaxWait time.Duration `mapstructure:"max_wait"`
+
+	// RetryAfter is advertised to rejected submissions. Zero falls back to DefaultSubmitRetryAfter.
+	RetryAfter time.Duration `mapstructure:"retry_after"`
+
+	// TopicWeights sets the share of the free slots each topic receives while several topics wait.
+	// Topics without a weight, or with a weight below one, have a weight of one.
+	TopicWeights map[string]int `mapstructure:"topic_weights"`
+}
+
+// SubmitTopicMetrics describes the submissions scheduled under a single topic.
+type SubmitTopicMetrics struct {
+	Weight   int    `json:"weight"`
+	Running  int    `json:"running"`  // submissions being processed
+	Queued   int    `json:"queued"`   // submissions waiting for a free slot
+	Admitted uint64 `json:"admitted"` // submissions that received a slot
+	Rejected uint64 `json:"rejected"` // submissions rejected while saturated or given up while waiting
+}
+
+// SubmitSchedulerMetrics describes the submissions scheduled since the engine started.
+type SubmitSchedulerMetrics struct {
+	Running  int                           `json:"running"`
+	Queued   int                           `json:"queued"`
+	Admitted uint64                        `json:"admitted"`
+	Rejected uint64                        `json:"rejected"`
+	Topics   map[string]SubmitTopicMetrics `json:"topics"`
+}
+
+// SubmitScheduler bounds how many submissions the engine processes at once, protecting storages that serialize
+// writes, such as SQLite. Submissions beyond the limit wait in per-topic queues that are served in proportion
+// to the topic weights, so that a flood of submissions to one busy topic cannot starve the others. Submissions
+// are rejected with a SubmitSaturatedError when the queues are full or their wait exceeds MaxWait.
+// Historical submissions, made by GASP sync, wait for a slot but are never rejected.
+// A submission tagged with several topics takes a single slot, charged to each of its topics: it waits in the
+// queue of every topic and is served as late as the busiest of them, so tagging a submission with an idle topic
+// does not let it bypass the queue of a busy one.
+// It is safe for concurrent use and implements expvar.Var, so its metrics can be published with expvar.Publish.
+type SubmitScheduler struct {
+	cfg SubmitSchedulerConfig
+
+	mu       sync.Mutex
+	running  int
+	queued   int
+	vtime    float64
+	admitted uint64
+	rejected uint64
+	topics   map[string]*submitTopicQueue
+}
+
+// submitTopicQueue holds the submissions scheduled under a topic. Its pass is the virtual time of its next
+// grant, advanced by the inverse of its weight on every grant: the waiting topic with the lowest pass is served first.
+type submitTopicQueue struct {
+	weight   int
+	pass     float64
+	running  int
+	waiters  []*submitWaiter
+	admitted uint64
+	rejected uint64
+}
+
+type submitWaiter struct {
+	ready   chan struct{}
+	granted bool
+	queues  []*submitTopicQueue
+}
+
+// NewSubmitScheduler creates a SubmitScheduler with the given configuration.
+func NewSubmitScheduler(cfg SubmitSchedulerConfig) *SubmitScheduler {
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = DefaultSubmitMaxConcurrent
+	}
+	if cfg.MaxQueued <= 0 {
+		cfg.MaxQueued = DefaultSubmitMaxQueued
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = DefaultSubmitMaxWait
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = DefaultSubmitRetryAfter
+	}
+	return &SubmitScheduler{cfg: cfg, topics: make(map[string]*submitTopicQueue)}
+}
+
+// Acquire waits for a slot to process a submission scheduled under the topic and returns the function
+// releasing it. Unless historical, the submission is rejected with a SubmitSaturatedError when the queues
+// are full or no slot frees up within MaxWait. Returns the context error if the context ends first.
+func (s *SubmitScheduler) Acquire(ctx context.Context, topic string, historical bool) (func(), error) {
+	return s.AcquireTopics(ctx, []string{topic}, historical)
+}
+
+// AcquireTopics is like Acquire for a submission tagged with several topics, which must not be empty.
+// The slot is charged to every topic, in sorted order, and the returned function releases it for all of them.
+func (s *SubmitScheduler) AcquireTopics(ctx context.Context, topics []string, historical bool) (func(), error) {
+	topics = slices.Compact(slices.Sorted(slices.Values(topics)))
+	s.mu.Lock()
+	queues := make([]*submitTopicQueue, len(topics))
+	for i, topic := range topics {
+		queues[i] = s.queue(topic)
+	}
+	if s.running < s.cfg.MaxConcurrent && s.queued == 0 {
+		s.grant(queues)
+		s.mu.Unlock()
+		return s.releaser(queues), nil
+	}
+	if !historical && s.queued >= s.cfg.MaxQueued {
+		s.reject(queues)
+		s.mu.Unlock()
+		return nil, &SubmitSaturatedError{Topic: topics[0], RetryAfter: s.cfg.RetryAfter}
+	}
+	w := &submitWaiter{ready: make(chan struct{}), queues: queues}
+	for _, q := range queues {
+		q.waiters = append(q.waiters, w)
+	}
+	s.queued++
+	s.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if !historical {
+		timer := time.NewTimer(s.cfg.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+	select {
+	case <-w.ready:
+		return s.releaser(queues), nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		err = &SubmitSaturatedError{Topic: topics[0], RetryAfter: s.cfg.RetryAfter}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w.granted {
+		// The slot was granted while giving up; pass it on to the next waiter.
+		s.release(queues)
+		s.admitted--
+		for _, q := range queues {
+			q.admitted--
+		}
+	} else {
+		s.dequeue(w)
+	}
+	s.reject(queues)
+	return nil, err
+}
+
+// Metrics returns the state of the submission queues and the counters of scheduled submissions.
+func (s *SubmitScheduler) Metrics() SubmitSchedulerMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := SubmitSchedulerMetrics{
+		Running:  s.running,
+		Queued:   s.queued,
+		Admitted: s.admitted,
+		Rejected: s.rejected,
+		Topics:   make(map[string]SubmitTopicMetrics, len(s.topics)),
+	}
+	for topic, q := range s.topics {
+		metrics.Topics[topic] = SubmitTopicMetrics{
+			Weight:   q.weight,
+			Running:  q.running,
+			Queued:   len(q.waiters),
+			Admitted: q.admitted,
+			Rejected: q.rejected,
+		}
+	}
+	return metrics
+}
+
+// String returns the JSON encoded metrics, implementing expvar.Var.
+func (s *SubmitScheduler) String() string {
+	bb, err := json.Marshal(s.Metrics())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+// queue returns the queue of the topic, creating it if needed. Must be called with the lock held.
+func (s *SubmitScheduler) queue(topic string) *submitTopicQueue {
+	q, ok := s.topics[topic]
+	if !ok {
+		q = &submitTopicQueue{weight: max(s.cfg.TopicWeights[topic], 1)}
+		s.topics[topic] = q
+	}
+	return q
+}
+
+// pass returns the virtual time at which a submission charged to the queues is served: the pass of the
+// busiest of them. Must be called with the lock held.
+func (s *SubmitScheduler) pass(queues []*submitTopicQueue) float64 {
+	pass := s.vtime
+	for _, q := range queues {
+		pass = max(pass, q.pass)
+	}
+	return pass
+}
+
+// grant hands a slot to the next submission charged to the queues. Must be called with the lock held.
+func (s *SubmitScheduler) grant(queues []*submitTopicQueue) {
+	start := s.pass(queues)
+	s.vtime = start
+	s.running++
+	s.admitted++
+	for _, q := range queues {
+		q.pass = start + 1/float64(q.weight)
+		q.running++
+		q.admitted++
+	}
+}
+
+// reject counts a rejected submission charged to the queues. Must be called with the lock held.
+func (s *SubmitScheduler) reject(queues []*submitTopicQueue) {
+	s.rejected++
+	for _, q := range queues {
+		q.rejected++
+	}
+}
+
+// dequeue removes the waiter from the queues of its topics. Must be called with the lock held.
+func (s *SubmitScheduler) dequeue(w *submitWaiter) {
+	for _, q := range w.queues {
+		q.waiters = slices.DeleteFunc(q.waiters, func(waiter *submitWaiter) bool { return waiter == w })
+	}
+	s.queued--
+}
+
+// releaser returns the function releasing the slot of a submission charged to the queues, at most once.
+func (s *SubmitScheduler) releaser(queues []*submitTopicQueue) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.release(queues)
+		})
+	}
+}
+
+// release frees the slot of a submission charged to the queues and hands the free slots to the first waiters
+// of the queues with the lowest pass. Must be called with the lock held.
+func (s *SubmitScheduler) release(queues []*submitTopicQueue) {
+	s.running--
+	for _, q := range queues {
+		q.running--
+	}
+	for s.running < s.cfg.MaxConcurrent && s.queued > 0 {
+		var next *submitWaiter
+		var nextPass float64
+		var nextTopic string
+		for topic, candidate := range s.topics {
+			if len(candidate.waiters) == 0 {
+				continue
+			}
+			w := candidate.waiters[0]
+			pass := s.pass(w.queues)
+			if next == nil || pass < nextPass || (pass == nextPass && topic < nextTopic) {
+				next, nextPass, nextTopic = w, pass, topic
+			}
+		}
+		s.dequeue(next)
+		s.grant(next.queues)
+		next.granted = true
+		close(next.ready)
+	}
+}
@@ -0,0 +1,137 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdmissionOracleServer(t *testing.T, calls *atomic.Int32, handler http.HandlerFunc) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestAdmissionOracle_IdentifyAdmissibleOutputs_ShouldReturnAndCacheOracleDecision(t *testing.T) {
+	// given:
+	var calls atomic.Int32
+	beef := createDummyBEEF(t)
+	previousCoins := map[uint32]*transaction.TransactionOutput{0: {Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}}}
+	endpoint := newAdmissionOracleServer(t, &calls, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/identifyAdmissibleOutputs", r.URL.Path)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var request engine.AdmissionOracleRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		require.Equal(t, beef, request.Beef)
+		require.Equal(t, engine.AdmissionOracleCoin{Satoshis: 1000, LockingScript: "51"}, request.PreviousCoins[0])
+
+		_ = json.NewEncoder(w).Encode(engine.AdmissionOracleDecision{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}})
+	})
+	sut, err := engine.NewAdmissionOracle(engine.AdmissionOracleConfig{Endpoint: endpoint, BearerToken: "secret", CacheTTL: time.Minute}, nil)
+	require.NoError(t, err)
+
+	// when:
+	first, firstErr := sut.IdentifyAdmissibleOutputs(context.Background(), beef, previousCoins)
+	second, secondErr := sut.IdentifyAdmissibleOutputs(context.Background(), beef, previousCoins)
+
+	// then:
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	expected := overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}}
+	require.Equal(t, expected, first)
+	require.Equal(t, expected, second)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestAdmissionOracle_IdentifyAdmissibleOutputs_ShouldApplyFailurePolicy(t *testing.T) {
+	previousCoins := map[uint32]*transaction.TransactionOutput{0: {Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}}}
+
+	tests := map[string]struct {
+		handler       http.HandlerFunc
+		policy        engine.AdmissionOracleFailurePolicy
+		expected      overlay.AdmittanceInstructions
+		expectedError error
+	}{
+		"fail-closed rejects the transaction when the oracle errors": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedError: engine.ErrAdmissionOracleUnavailable,
+		},
+		"fail-closed rejects a decision admitting an output that does not exist": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(engine.AdmissionOracleDecision{OutputsToAdmit: []uint32{5}})
+			},
+			policy:        engine.AdmissionOracleFailClosed,
+			expectedError: engine.ErrAdmissionOracleUnavailable,
+		},
+		"fail-open admits every output and retains every previous coin when the oracle errors": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			policy:   engine.AdmissionOracleFailOpen,
+			expected: overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			var calls atomic.Int32
+			endpoint := newAdmissionOracleServer(t, &calls, tc.handler)
+			sut, err := engine.NewAdmissionOracle(engine.AdmissionOracleConfig{Endpoint: endpoint, FailurePolicy: tc.policy, CacheTTL: time.Minute}, nil)
+			require.NoError(t, err)
+
+			// when:
+			actual, err := sut.IdentifyAdmissibleOutputs(context.Background(), createDummyBEEF(t), previousCoins)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestAdmissionOracle_IdentifyNeededInputs_ShouldReturnOracleInputs(t *testing.T) {
+	// given:
+	var calls atomic.Int32
+	endpoint := newAdmissionOracleServer(t, &calls, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/identifyNeededInputs", r.URL.Path)
+		_, _ = w.Write([]byte(`{"inputs":["0000000000000000000000000000000000000000000000000000000000000001.2"]}`))
+	})
+	sut, err := engine.NewAdmissionOracle(engine.AdmissionOracleConfig{Endpoint: endpoint + "/"}, nil)
+	require.NoError(t, err)
+
+	// when:
+	inputs, err := sut.IdentifyNeededInputs(context.Background(), createDummyBEEF(t))
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, inputs, 1)
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001.2", inputs[0].String())
+}
+
+func TestNewAdmissionOracle_ShouldFail_WhenNoEndpointOrTransport(t *testing.T) {
+	// when:
+	sut, err := engine.NewAdmissionOracle(engine.AdmissionOracleConfig{}, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrInvalidAdmissionOracleConfig)
+	require.Nil(t, sut)
+}
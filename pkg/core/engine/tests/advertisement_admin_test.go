@@ -0,0 +1,128 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func newListedAdvertisements(t *testing.T) (*transaction.Transaction, map[overlay.Protocol][]*advertiser.Advertisement) {
+	t.Helper()
+
+	tx := newMinedTx(3)
+	beef, err := tx.AtomicBEEF(false)
+	require.NoError(t, err)
+	return tx, map[overlay.Protocol][]*advertiser.Advertisement{
+		overlay.ProtocolSHIP: {{Protocol: overlay.ProtocolSHIP, Domain: "https://overlay.example.com", TopicOrService: "tm_example", Beef: beef, OutputIndex: 1}},
+		overlay.ProtocolSLAP: {{Protocol: overlay.ProtocolSLAP, Domain: "https://overlay.example.com", TopicOrService: "ls_example", Beef: beef, OutputIndex: 2}},
+	}
+}
+
+func TestEngine_ListAdvertisements_ShouldListAdvertisementsWithOutpoints(t *testing.T) {
+	// given:
+	tx, advertisements := newListedAdvertisements(t)
+	sut := &engine.Engine{Advertiser: fakeAdvertiser{
+		findAllAdvertisements: func(protocol overlay.Protocol) ([]*advertiser.Advertisement, error) {
+			return advertisements[protocol], nil
+		},
+	}}
+
+	// when:
+	listed, err := sut.ListAdvertisements(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*engine.ListedAdvertisement{
+		{
+			Outpoint:       transaction.Outpoint{Txid: *tx.TxID(), Index: 1},
+			Protocol:       overlay.ProtocolSHIP,
+			Domain:         "https://overlay.example.com",
+			TopicOrService: "tm_example",
+		},
+		{
+			Outpoint:       transaction.Outpoint{Txid: *tx.TxID(), Index: 2},
+			Protocol:       overlay.ProtocolSLAP,
+			Domain:         "https://overlay.example.com",
+			TopicOrService: "ls_example",
+		},
+	}, listed)
+}
+
+func TestEngine_AdvertisementManagement_ShouldFailWithoutAdvertiser(t *testing.T) {
+	// given:
+	sut := &engine.Engine{}
+
+	// when:
+	_, listErr := sut.ListAdvertisements(context.Background())
+	_, createErr := sut.CreateAdvertisement(context.Background(), overlay.ProtocolSHIP, "tm_example")
+	_, revokeErr := sut.RevokeAdvertisement(context.Background(), &transaction.Outpoint{})
+
+	// then:
+	require.ErrorIs(t, listErr, engine.ErrAdvertiserNotConfigured)
+	require.ErrorIs(t, createErr, engine.ErrAdvertiserNotConfigured)
+	require.ErrorIs(t, revokeErr, engine.ErrAdvertiserNotConfigured)
+}
+
+func TestEngine_CreateAdvertisement_ShouldCreateTheRequestedAdvertisement(t *testing.T) {
+	// given:
+	var created []*advertiser.AdvertisementData
+	sut := &engine.Engine{Advertiser: fakeAdvertiser{
+		createAdvertisements: func(data []*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+			created = data
+			return overlay.TaggedBEEF{}, errCreateFailed
+		},
+	}}
+
+	// when:
+	steak, err := sut.CreateAdvertisement(context.Background(), overlay.ProtocolSLAP, "ls_unhosted")
+
+	// then:
+	require.ErrorIs(t, err, errCreateFailed)
+	require.Nil(t, steak)
+	require.Equal(t, []*advertiser.AdvertisementData{{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_unhosted"}}, created)
+}
+
+func TestEngine_CreateAdvertisement_ShouldRejectUnknownProtocol(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Advertiser: fakeAdvertiser{}}
+
+	// when:
+	steak, err := sut.CreateAdvertisement(context.Background(), overlay.Protocol("GASP"), "tm_example")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrInvalidAdvertisementProtocol)
+	require.Nil(t, steak)
+}
+
+func TestEngine_RevokeAdvertisement_ShouldRevokeOnlyTheAdvertisementAtTheOutpoint(t *testing.T) {
+	// given:
+	tx, advertisements := newListedAdvertisements(t)
+	var revoked []*advertiser.Advertisement
+	sut := &engine.Engine{Advertiser: fakeAdvertiser{
+		findAllAdvertisements: func(protocol overlay.Protocol) ([]*advertiser.Advertisement, error) {
+			return advertisements[protocol], nil
+		},
+		revokeAdvertisements: func(data []*advertiser.Advertisement) (overlay.TaggedBEEF, error) {
+			revoked = data
+			return overlay.TaggedBEEF{}, errRevokeFailed
+		},
+	}}
+
+	// when:
+	_, err := sut.RevokeAdvertisement(context.Background(), &transaction.Outpoint{Txid: *tx.TxID(), Index: 2})
+
+	// then:
+	require.ErrorIs(t, err, errRevokeFailed)
+	require.Equal(t, advertisements[overlay.ProtocolSLAP], revoked)
+
+	// when:
+	_, err = sut.RevokeAdvertisement(context.Background(), &transaction.Outpoint{Txid: *tx.TxID(), Index: 0})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrAdvertisementNotFound)
+}
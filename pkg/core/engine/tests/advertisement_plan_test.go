@@ -0,0 +1,112 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEstimatingAdvertiser is an AdvertisementCostEstimator layered on top of fakeAdvertiser.
+type fakeEstimatingAdvertiser struct {
+	fakeAdvertiser
+}
+
+func (fakeEstimatingAdvertiser) EstimateAdvertisementCost(create []*advertiser.AdvertisementData, revoke []*advertiser.Advertisement) (uint64, uint64, error) {
+	return uint64(len(create)) * 10, uint64(len(create)+len(revoke)) * 5, nil
+}
+
+// newPlannedEngine returns an engine hosting tm_a and ls_a that advertises tm_a and the no longer hosted tm_old.
+func newPlannedEngine(e engine.Engine) *engine.Engine {
+	e.Managers = map[string]engine.TopicManager{"tm_a": fakeTopicManager{}}
+	e.LookupServices = map[string]engine.LookupService{"ls_a": fakeLookupService{}}
+	e.HostingURL = "https://overlay.example.com"
+	return &e
+}
+
+func advertisedTopics(protocol overlay.Protocol) ([]*advertiser.Advertisement, error) {
+	if protocol != overlay.ProtocolSHIP {
+		return nil, nil
+	}
+	return []*advertiser.Advertisement{
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_a", Domain: "https://overlay.example.com"},
+		{Protocol: overlay.ProtocolSHIP, TopicOrService: "tm_old", Domain: "https://overlay.example.com"},
+	}, nil
+}
+
+func TestEngine_PlanAdvertisements_ShouldEstimateCostFromTransactionSizes(t *testing.T) {
+	// given:
+	sut := newPlannedEngine(engine.Engine{
+		Advertiser:          fakeAdvertiser{findAllAdvertisements: advertisedTopics},
+		AdvertisementBudget: &engine.AdvertisementBudget{FeeRate: 1000, MaxSatoshisPerRun: 1000},
+	})
+
+	// when:
+	plan, err := sut.PlanAdvertisements(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*advertiser.AdvertisementData{{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_a"}}, plan.Create)
+	require.Len(t, plan.Revoke, 1)
+	require.Equal(t, "tm_old", plan.Revoke[0].TopicOrService)
+	require.Equal(t, engine.AdvertisementCost{
+		OutputsCreated: 1,
+		OutputsRevoked: 1,
+		LockedSatoshis: 1,
+		FeeSatoshis:    392 + 312,
+		TotalSatoshis:  1 + 392 + 312,
+		BudgetSatoshis: 1000,
+		WithinBudget:   true,
+	}, plan.Cost)
+}
+
+func TestEngine_PlanAdvertisements_ShouldPreferAdvertiserEstimate(t *testing.T) {
+	// given:
+	sut := newPlannedEngine(engine.Engine{
+		Advertiser: fakeEstimatingAdvertiser{fakeAdvertiser{findAllAdvertisements: advertisedTopics}},
+	})
+
+	// when:
+	plan, err := sut.PlanAdvertisements(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, engine.AdvertisementCost{
+		OutputsCreated: 1,
+		OutputsRevoked: 1,
+		LockedSatoshis: 10,
+		FeeSatoshis:    10,
+		TotalSatoshis:  20,
+		WithinBudget:   true,
+	}, plan.Cost)
+}
+
+func TestEngine_SyncAdvertisements_ShouldNotTouchAdvertisements_WhenBudgetIsExceeded(t *testing.T) {
+	// given:
+	var created, revoked bool
+	sut := newPlannedEngine(engine.Engine{
+		Advertiser: fakeAdvertiser{
+			findAllAdvertisements: advertisedTopics,
+			createAdvertisements: func([]*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+				created = true
+				return overlay.TaggedBEEF{}, nil
+			},
+			revokeAdvertisements: func([]*advertiser.Advertisement) (overlay.TaggedBEEF, error) {
+				revoked = true
+				return overlay.TaggedBEEF{}, nil
+			},
+		},
+		AdvertisementBudget: &engine.AdvertisementBudget{MaxSatoshisPerRun: 10},
+	})
+
+	// when:
+	err := sut.SyncAdvertisements(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrAdvertisementBudgetExceeded)
+	require.False(t, created)
+	require.False(t, revoked)
+}
@@ -0,0 +1,204 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeARC serves the broadcast and health endpoints of ARC, recording the callback tokens it was handed.
+type fakeARC struct {
+	*httptest.Server
+
+	broadcastStatus int
+	broadcastBody   string
+	healthy         bool
+	tokens          []string
+}
+
+func newFakeARC(t *testing.T, broadcastStatus int, broadcastBody string) *fakeARC {
+	arc := &fakeARC{broadcastStatus: broadcastStatus, broadcastBody: broadcastBody, healthy: true}
+	arc.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tx":
+			arc.tokens = append(arc.tokens, r.Header.Get("X-CallbackToken"))
+			w.WriteHeader(arc.broadcastStatus)
+			_, _ = w.Write([]byte(arc.broadcastBody))
+		case "/v1/health":
+			if arc.healthy {
+				_, _ = w.Write([]byte(`{"healthy":true}`))
+			} else {
+				_, _ = w.Write([]byte(`{"healthy":false,"reason":"metamorph unavailable"}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(arc.Close)
+	return arc
+}
+
+const arcAccepted = `{"status":200,"txid":"abcd","title":"OK","txStatus":"SEEN_ON_NETWORK"}`
+
+func TestARCPool_BroadcastCtx_ShouldFailOverToTheNextEndpoint_WhenAnEndpointFails(t *testing.T) {
+	// given:
+	primary := newFakeARC(t, http.StatusInternalServerError, `{"status":500,"title":"Internal Server Error"}`)
+	secondary := newFakeARC(t, http.StatusOK, arcAccepted)
+	sut, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints: []engine.ARCEndpointConfig{
+			{Name: "primary", URL: primary.URL, CallbackToken: "primary-token"},
+			{Name: "secondary", URL: secondary.URL, CallbackToken: "secondary-token"},
+		},
+		CallbackURL:      "https://overlay.example.com/api/v1/arc-ingest",
+		FailureThreshold: 1,
+	})
+	require.NoError(t, err)
+
+	// when:
+	success, failure := sut.BroadcastCtx(context.Background(), transaction.NewTransaction())
+
+	// then:
+	require.Nil(t, failure)
+	require.Equal(t, "abcd", success.Txid)
+	require.Equal(t, []string{"primary-token"}, primary.tokens)
+	require.Equal(t, []string{"secondary-token"}, secondary.tokens)
+
+	status := sut.Status()
+	require.False(t, status[0].Healthy)
+	require.Equal(t, 1, status[0].ConsecutiveFailures)
+	require.True(t, status[1].Healthy)
+}
+
+func TestARCPool_BroadcastCtx_ShouldNotFailOver_WhenTheTransactionIsRejected(t *testing.T) {
+	// given:
+	primary := newFakeARC(t, http.StatusOK, `{"status":200,"txStatus":"REJECTED","extraInfo":"missing inputs"}`)
+	secondary := newFakeARC(t, http.StatusOK, arcAccepted)
+	sut, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints: []engine.ARCEndpointConfig{{URL: primary.URL}, {URL: secondary.URL}},
+	})
+	require.NoError(t, err)
+
+	// when:
+	success, failure := sut.BroadcastCtx(context.Background(), transaction.NewTransaction())
+
+	// then:
+	require.Nil(t, success)
+	require.Equal(t, &transaction.BroadcastFailure{Code: "400", Description: "missing inputs"}, failure)
+	require.Len(t, primary.tokens, 1)
+	require.Empty(t, secondary.tokens)
+	require.True(t, sut.Status()[0].Healthy)
+}
+
+func TestARCPool_CheckHealth_ShouldTrackUnhealthyAndRecoveredEndpoints(t *testing.T) {
+	// given:
+	arc := newFakeARC(t, http.StatusOK, arcAccepted)
+	arc.healthy = false
+	sut, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints:        []engine.ARCEndpointConfig{{Name: "arc", URL: arc.URL}},
+		FailureThreshold: 2,
+	})
+	require.NoError(t, err)
+
+	// when:
+	sut.CheckHealth(context.Background())
+	afterOneFailure := sut.Status()[0]
+	sut.CheckHealth(context.Background())
+	afterTwoFailures := sut.Status()[0]
+	arc.healthy = true
+	sut.CheckHealth(context.Background())
+	recovered := sut.Status()[0]
+
+	// then:
+	require.True(t, afterOneFailure.Healthy)
+	require.False(t, afterTwoFailures.Healthy)
+	require.Contains(t, afterTwoFailures.LastError, "metamorph unavailable")
+	require.True(t, recovered.Healthy)
+	require.Zero(t, recovered.ConsecutiveFailures)
+	require.False(t, recovered.LastCheck.IsZero())
+}
+
+func TestARCPool_ValidCallbackToken_ShouldAcceptRotatedTokensWithinTheGracePeriod(t *testing.T) {
+	// given:
+	sut, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints: []engine.ARCEndpointConfig{
+			{URL: "https://arc-a.example.com", CallbackToken: "token-a"},
+			{URL: "https://arc-b.example.com", CallbackToken: "token-b"},
+		},
+	})
+	require.NoError(t, err)
+	expiring, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints:        []engine.ARCEndpointConfig{{URL: "https://arc-c.example.com", CallbackToken: "token-c"}},
+		TokenGracePeriod: time.Nanosecond,
+	})
+	require.NoError(t, err)
+
+	// when:
+	sut.RotateCallbackTokens()
+	expiring.RotateCallbackTokens()
+	time.Sleep(time.Millisecond)
+
+	// then:
+	require.True(t, sut.ValidCallbackToken("token-a"))
+	require.True(t, sut.ValidCallbackToken("token-b"))
+	require.False(t, sut.ValidCallbackToken("token-c"))
+	require.False(t, sut.ValidCallbackToken(""))
+	require.False(t, expiring.ValidCallbackToken("token-c"))
+}
+
+func TestARCPool_ValidCallbackSignature_ShouldRequireSignaturesOfSigningEndpoints(t *testing.T) {
+	// given:
+	sut, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints: []engine.ARCEndpointConfig{
+			{URL: "https://arc-a.example.com", CallbackToken: "token-a", SigningSecret: "secret-a"},
+			{URL: "https://arc-b.example.com", CallbackToken: "token-b"},
+		},
+	})
+	require.NoError(t, err)
+	payload := []byte(`{"txid":"00"}`)
+	signature := engine.SignARCCallback("secret-a", payload)
+
+	// when:
+	sut.RotateCallbackTokens()
+
+	// then:
+	require.True(t, sut.ValidCallbackSignature("token-a", payload, signature))
+	require.True(t, sut.ValidCallbackSignature("token-a", payload, "sha256="+signature))
+	require.False(t, sut.ValidCallbackSignature("token-a", payload, ""))
+	require.False(t, sut.ValidCallbackSignature("token-a", []byte(`{"txid":"01"}`), signature))
+	require.False(t, sut.ValidCallbackSignature("token-a", payload, engine.SignARCCallback("secret-b", payload)))
+	require.True(t, sut.ValidCallbackSignature("token-b", payload, ""))
+	require.False(t, sut.ValidCallbackSignature("token-c", payload, signature))
+}
+
+func TestNewARCPool_ShouldReturnError_WhenMisconfigured(t *testing.T) {
+	tests := map[string]struct {
+		cfg         engine.ARCPoolConfig
+		expectedErr error
+	}{
+		"no endpoints": {
+			cfg:         engine.ARCPoolConfig{},
+			expectedErr: engine.ErrNoARCEndpoints,
+		},
+		"endpoint without URL": {
+			cfg:         engine.ARCPoolConfig{Endpoints: []engine.ARCEndpointConfig{{Name: "arc"}}},
+			expectedErr: engine.ErrInvalidARCEndpoint,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			pool, err := engine.NewARCPool(tc.cfg)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, pool)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package engine_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_RecordAudit_ShouldBeQueryableMostRecentFirst(t *testing.T) {
+	stores := map[string]func(t *testing.T) engine.AuditStore{
+		"memory": func(*testing.T) engine.AuditStore {
+			return engine.NewMemoryAuditStore(0)
+		},
+		"file": func(t *testing.T) engine.AuditStore {
+			store, err := engine.NewFileAuditStore(filepath.Join(t.TempDir(), "audit.log"))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = store.Close() })
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			ctx := engine.WithRequestID(context.Background(), "request-id")
+			sut := &engine.Engine{Audit: newStore(t)}
+			start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			// when:
+			require.NoError(t, sut.RecordAudit(ctx, &engine.AuditEntry{Time: start, Action: "POST /api/v1/submit", Caller: "anonymous", Topics: []string{"tm_a"}, Txid: "txid", Result: engine.AuditResultSuccess}))
+			require.NoError(t, sut.RecordAudit(ctx, &engine.AuditEntry{Time: start.Add(time.Minute), Action: "POST /api/v1/admin/startGASPSync", Caller: "admin", Result: engine.AuditResultSuccess}))
+			require.NoError(t, sut.RecordAudit(ctx, &engine.AuditEntry{Time: start.Add(2 * time.Minute), Action: "POST /api/v1/admin/startGASPSync", Caller: "admin", Result: engine.AuditResultFailure}))
+
+			all, err := sut.FindAuditEntries(ctx, engine.AuditQuery{})
+			require.NoError(t, err)
+			byCaller, err := sut.FindAuditEntries(ctx, engine.AuditQuery{Caller: "admin", Limit: 1})
+			require.NoError(t, err)
+			byTxid, err := sut.FindAuditEntries(ctx, engine.AuditQuery{Txid: "txid"})
+			require.NoError(t, err)
+			inRange, err := sut.FindAuditEntries(ctx, engine.AuditQuery{Since: start.Add(time.Minute), Until: start.Add(2 * time.Minute)})
+			require.NoError(t, err)
+
+			// then:
+			require.Len(t, all, 3)
+			require.Equal(t, engine.AuditResultFailure, all[0].Result)
+			require.Equal(t, "POST /api/v1/submit", all[2].Action)
+			require.Equal(t, []string{"tm_a"}, all[2].Topics)
+			for _, entry := range all {
+				require.NotEmpty(t, entry.ID)
+				require.Equal(t, "request-id", entry.RequestID)
+			}
+
+			require.Len(t, byCaller, 1)
+			require.Equal(t, all[0], byCaller[0])
+
+			require.Len(t, byTxid, 1)
+			require.Equal(t, all[2], byTxid[0])
+
+			require.Len(t, inRange, 1)
+			require.Equal(t, all[1], inRange[0])
+		})
+	}
+}
+
+func TestFileAuditStore_ShouldKeepEntriesAcrossReopening(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	store, err := engine.NewFileAuditStore(path)
+	require.NoError(t, err)
+	require.NoError(t, (&engine.Engine{Audit: store}).RecordAudit(ctx, &engine.AuditEntry{Action: "POST /api/v1/submit", Caller: "anonymous", Result: engine.AuditResultSuccess}))
+	require.NoError(t, store.Close())
+
+	// when:
+	reopened, err := engine.NewFileAuditStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reopened.Close() })
+	require.NoError(t, (&engine.Engine{Audit: reopened}).RecordAudit(ctx, &engine.AuditEntry{Action: "POST /api/v1/submit", Caller: "admin", Result: engine.AuditResultSuccess}))
+	entries, err := reopened.FindAuditEntries(ctx, engine.AuditQuery{})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "admin", entries[0].Caller)
+	require.Equal(t, "anonymous", entries[1].Caller)
+}
+
+func TestMemoryAuditStore_ShouldDropTheOldestEntriesOnceFull(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewMemoryAuditStore(2)
+
+	// when:
+	for _, caller := range []string{"first", "second", "third"} {
+		require.NoError(t, sut.AppendAuditEntry(ctx, &engine.AuditEntry{Caller: caller}))
+	}
+	entries, err := sut.FindAuditEntries(ctx, engine.AuditQuery{})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "third", entries[0].Caller)
+	require.Equal(t, "second", entries[1].Caller)
+}
+
+func TestEngine_AuditLog_WithoutStore(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := &engine.Engine{}
+
+	// when:
+	recordErr := sut.RecordAudit(ctx, &engine.AuditEntry{Action: "POST /api/v1/submit"})
+	entries, findErr := sut.FindAuditEntries(ctx, engine.AuditQuery{})
+
+	// then:
+	require.NoError(t, recordErr)
+	require.Nil(t, entries)
+	require.ErrorIs(t, findErr, engine.ErrAuditLogNotConfigured)
+}
@@ -0,0 +1,98 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthWallet(t *testing.T) *wallet.CompletedProtoWallet {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewCompletedProtoWallet(key)
+	require.NoError(t, err)
+	return w
+}
+
+// serveBRC31Peer serves the engine over HTTP on a local port, requiring BRC-31 authentication of every request,
+// and returns the URL of its API.
+func serveBRC31Peer(t *testing.T, e *engine.Engine) string {
+	t.Helper()
+	cfg := server.DefaultConfig
+	cfg.BRC31 = server.BRC31AuthConfig{Required: true}
+	srv, err := server.New(server.WithEngine(e), server.WithBRC31Wallet(newAuthWallet(t)), server.WithConfig(cfg))
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(context.Background(), ln) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, srv.Shutdown(ctx))
+		if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("peer stopped serving: %v", err)
+		}
+	})
+	return "http://" + ln.Addr().String() + "/api/v1"
+}
+
+func TestAuthBroadcastFacilitator_ShouldPropagateToBRC31Peers(t *testing.T) {
+	// given:
+	tracker := testutil.NewChainTracker(800000)
+	builder := testutil.NewBuilder(t, tracker)
+	peer := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"tm_a": &testharness.TopicManager{Topic: "tm_a"},
+			"tm_b": &testharness.TopicManager{Topic: "tm_b"},
+		},
+		Storage:      testharness.NewMemoryStorage(),
+		ChainTracker: tracker,
+	})
+	url := serveBRC31Peer(t, peer)
+	tx := builder.Mined(800001, 1000, 2000)
+	facilitator := engine.NewAuthBroadcastFacilitator(newAuthWallet(t))
+
+	// when:
+	steak, err := facilitator.Send(url, &overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{"tm_a", "tm_b"}})
+
+	// then:
+	require.NoError(t, err)
+	testutil.RequireAdmitted(t, *steak, "tm_a", 0, 1)
+	testutil.RequireAdmitted(t, *steak, "tm_b", 0, 1)
+}
+
+func TestAuthBroadcastFacilitator_ShouldReportPeerRejections(t *testing.T) {
+	// given:
+	peer := engine.NewEngine(engine.Engine{
+		Managers:     map[string]engine.TopicManager{"tm_a": &testharness.TopicManager{Topic: "tm_a"}},
+		Storage:      testharness.NewMemoryStorage(),
+		ChainTracker: testutil.NewChainTracker(800000),
+	})
+	url := serveBRC31Peer(t, peer)
+	facilitator := engine.NewAuthBroadcastFacilitator(newAuthWallet(t))
+
+	// when:
+	_, err := facilitator.Send(url, &overlay.TaggedBEEF{Beef: []byte{1, 2, 3}, Topics: []string{"tm_a"}})
+
+	// then:
+	var httpErr *util.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusUnprocessableEntity, httpErr.StatusCode)
+	require.ErrorIs(t, httpErr.Err, engine.ErrAuthBroadcastRejected)
+}
@@ -0,0 +1,120 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const compactionTopic = "tm_compaction"
+
+// insertMinedWithAncestry mines a chain of two transactions in consecutive blocks on top of the tracker and stores
+// the output of the second one with a BEEF holding both, as when a mined transaction is submitted with its ancestry.
+func insertMinedWithAncestry(t *testing.T, storage engine.Storage, builder *testutil.Builder, tracker *testutil.ChainTracker) (*engine.Output, *transaction.Transaction) {
+	t.Helper()
+	chain := builder.Chain(2)
+	height, err := tracker.CurrentHeight(t.Context())
+	require.NoError(t, err)
+	builder.Mine(height+1, chain[1])
+
+	beef := transaction.NewBeefV2()
+	for _, tx := range chain {
+		_, err := beef.MergeTransaction(tx)
+		require.NoError(t, err)
+	}
+	beefBytes, err := beef.Bytes()
+	require.NoError(t, err)
+	output := &engine.Output{
+		Outpoint:    transaction.Outpoint{Txid: *chain[1].TxID()},
+		Topic:       compactionTopic,
+		BlockHeight: height + 1,
+		Beef:        beefBytes,
+	}
+	require.NoError(t, storage.InsertOutput(t.Context(), output))
+	return output, chain[1]
+}
+
+func newCompactionEngine(storage engine.Storage, tracker *testutil.ChainTracker, cfg engine.BEEFCompactionConfig) *engine.Engine {
+	return engine.NewEngine(engine.Engine{
+		Managers:      map[string]engine.TopicManager{compactionTopic: &testharness.TopicManager{Topic: compactionTopic}},
+		Storage:       storage,
+		ChainTracker:  tracker,
+		BEEFCompactor: engine.NewBEEFCompactor(cfg),
+	})
+}
+
+func findCompactionOutput(t *testing.T, storage engine.Storage, output *engine.Output) *engine.Output {
+	t.Helper()
+	topic := compactionTopic
+	found, err := storage.FindOutput(t.Context(), &output.Outpoint, &topic, nil, true)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	return found
+}
+
+func TestEngine_CompactBEEF_ShouldCompactDeeplyConfirmedTransactions(t *testing.T) {
+	// given:
+	storage := testharness.NewMemoryStorage()
+	tracker := testutil.NewChainTracker(800000)
+	builder := testutil.NewBuilder(t, tracker)
+	deep, deepTx := insertMinedWithAncestry(t, storage, builder, tracker)
+	recent, _ := insertMinedWithAncestry(t, storage, builder, tracker)
+	sut := newCompactionEngine(storage, tracker, engine.BEEFCompactionConfig{Confirmations: 3})
+
+	// when:
+	report, err := sut.CompactBEEF(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Scanned)
+	require.Equal(t, []*chainhash.Hash{deepTx.TxID()}, report.Compacted)
+	compacted := testutil.AtomicBEEF(t, deepTx)
+	require.Equal(t, len(deep.Beef)-len(compacted), report.BytesSaved)
+	require.Equal(t, compacted, findCompactionOutput(t, storage, deep).Beef, "buried transactions must keep only their merkle path")
+	require.Equal(t, recent.Beef, findCompactionOutput(t, storage, recent).Beef, "recent transactions must keep their ancestry")
+
+	// when:
+	report, err = sut.CompactBEEF(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Empty(t, report.Compacted, "compacted BEEF must not be rewritten again")
+}
+
+func TestEngine_CompactBEEF_ShouldResumeWhereThePreviousRunStopped(t *testing.T) {
+	// given:
+	storage := testharness.NewMemoryStorage()
+	tracker := testutil.NewChainTracker(800000)
+	builder := testutil.NewBuilder(t, tracker)
+	_, first := insertMinedWithAncestry(t, storage, builder, tracker)
+	_, second := insertMinedWithAncestry(t, storage, builder, tracker)
+	sut := newCompactionEngine(storage, tracker, engine.BEEFCompactionConfig{Confirmations: 1, BatchSize: 1})
+
+	// when:
+	firstRun, err := sut.CompactBEEF(context.Background())
+	require.NoError(t, err)
+	secondRun, err := sut.CompactBEEF(context.Background())
+	require.NoError(t, err)
+
+	// then:
+	require.Equal(t, []*chainhash.Hash{first.TxID()}, firstRun.Compacted)
+	require.Equal(t, []*chainhash.Hash{second.TxID()}, secondRun.Compacted)
+}
+
+func TestEngine_CompactBEEF_ShouldFail_WhenNotConfigured(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}, ChainTracker: testutil.NewChainTracker(800000)}
+
+	// when:
+	report, err := sut.CompactBEEF(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrBEEFCompactionNotConfigured)
+	require.Nil(t, report)
+}
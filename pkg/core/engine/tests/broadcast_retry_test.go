@@ -0,0 +1,184 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBroadcastQueueStorage is an in-memory BroadcastQueueStorage layered on top of fakeDeadLetterStorage.
+type fakeBroadcastQueueStorage struct {
+	*fakeDeadLetterStorage
+
+	broadcasts map[chainhash.Hash]*engine.QueuedBroadcast
+}
+
+func (f *fakeBroadcastQueueStorage) InsertQueuedBroadcast(_ context.Context, broadcast *engine.QueuedBroadcast) error {
+	f.broadcasts[broadcast.Txid] = broadcast
+	return nil
+}
+
+func (f *fakeBroadcastQueueStorage) FindQueuedBroadcasts(_ context.Context) ([]*engine.QueuedBroadcast, error) {
+	found := make([]*engine.QueuedBroadcast, 0, len(f.broadcasts))
+	for _, broadcast := range f.broadcasts {
+		copied := *broadcast
+		found = append(found, &copied)
+	}
+	return found, nil
+}
+
+func (f *fakeBroadcastQueueStorage) DeleteQueuedBroadcast(_ context.Context, txid *chainhash.Hash) error {
+	delete(f.broadcasts, *txid)
+	return nil
+}
+
+func newBroadcastQueueStorage() *fakeBroadcastQueueStorage {
+	return &fakeBroadcastQueueStorage{
+		fakeDeadLetterStorage: newDeadLetterStorage(),
+		broadcasts:            make(map[chainhash.Hash]*engine.QueuedBroadcast),
+	}
+}
+
+// only returns a copy of the single queued broadcast.
+func (f *fakeBroadcastQueueStorage) only(t *testing.T) engine.QueuedBroadcast {
+	require.Len(t, f.broadcasts, 1)
+	for _, broadcast := range f.broadcasts {
+		return *broadcast
+	}
+	return engine.QueuedBroadcast{}
+}
+
+// makeDue moves the next attempt of every queued broadcast to the past.
+func (f *fakeBroadcastQueueStorage) makeDue() {
+	for _, broadcast := range f.broadcasts {
+		broadcast.NextAttemptAt = time.Now().Add(-time.Second)
+	}
+}
+
+func TestEngine_Submit_ShouldQueueBroadcast_WhenBroadcastFailsAndRetryIsConfigured(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := true
+	storage := newBroadcastQueueStorage()
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	sut.BroadcastRetry = engine.NewBroadcastRetry(engine.BroadcastRetryConfig{InitialBackoff: time.Minute})
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+	// when:
+	steak, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Contains(t, steak, "test-topic")
+	require.Empty(t, storage.deadLetters)
+
+	status, err := sut.BroadcastQueueStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, status.Broadcasts, 1)
+	require.Equal(t, taggedBEEF.Beef, status.Broadcasts[0].Beef)
+	require.Equal(t, 1, status.Broadcasts[0].Attempts)
+	require.Equal(t, "forced failure for testing", status.Broadcasts[0].LastError)
+	require.WithinDuration(t, time.Now().Add(time.Minute), status.Broadcasts[0].NextAttemptAt, 5*time.Second)
+	require.Equal(t, engine.BroadcastRetryMetrics{Queued: 1}, status.Metrics)
+}
+
+func TestEngine_RetryBroadcasts_ShouldBackOffExponentiallyAndRemoveSucceededBroadcasts(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := true
+	storage := newBroadcastQueueStorage()
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	sut.BroadcastRetry = engine.NewBroadcastRetry(engine.BroadcastRetryConfig{InitialBackoff: time.Minute, MaxBackoff: 3 * time.Minute})
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	// when:
+	notDue, notDueErr := sut.RetryBroadcasts(ctx)
+	storage.makeDue()
+	failed, failedErr := sut.RetryBroadcasts(ctx)
+	afterSecondFailure := time.Now()
+	doubled := storage.only(t)
+	storage.makeDue()
+	_, _ = sut.RetryBroadcasts(ctx)
+	capped := time.Until(storage.only(t).NextAttemptAt)
+	storage.makeDue()
+	broadcastFails = false
+	succeeded, succeededErr := sut.RetryBroadcasts(ctx)
+
+	// then:
+	require.NoError(t, notDueErr)
+	require.Equal(t, &engine.BroadcastRetryReport{}, notDue)
+
+	require.NoError(t, failedErr)
+	require.Equal(t, &engine.BroadcastRetryReport{Retried: 1}, failed)
+	require.Equal(t, 2, doubled.Attempts)
+	require.WithinDuration(t, afterSecondFailure.Add(2*time.Minute), doubled.NextAttemptAt, 5*time.Second)
+	require.InDelta(t, 3*time.Minute, capped, float64(5*time.Second))
+
+	require.NoError(t, succeededErr)
+	require.Equal(t, &engine.BroadcastRetryReport{Retried: 1, Succeeded: 1}, succeeded)
+	require.Empty(t, storage.broadcasts)
+	require.Equal(t, engine.BroadcastRetryMetrics{Queued: 1, Retried: 3, Succeeded: 1}, sut.BroadcastRetry.Metrics())
+}
+
+func TestEngine_RetryBroadcasts_ShouldAbandonBroadcast_WhenMaxAttemptsIsReached(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := true
+	storage := newBroadcastQueueStorage()
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	sut.BroadcastRetry = engine.NewBroadcastRetry(engine.BroadcastRetryConfig{MaxAttempts: 2})
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	// when:
+	storage.makeDue()
+	abandoned, abandonedErr := sut.RetryBroadcasts(ctx)
+	storage.makeDue()
+	skipped, skippedErr := sut.RetryBroadcasts(ctx)
+
+	// then:
+	require.NoError(t, abandonedErr)
+	require.Equal(t, &engine.BroadcastRetryReport{Retried: 1, Abandoned: 1}, abandoned)
+	require.NoError(t, skippedErr)
+	require.Equal(t, &engine.BroadcastRetryReport{}, skipped)
+
+	status, err := sut.BroadcastQueueStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, status.Broadcasts, 1)
+	require.True(t, status.Broadcasts[0].Abandoned)
+	require.Equal(t, 2, status.Broadcasts[0].Attempts)
+	require.Equal(t, uint64(1), status.Metrics.Abandoned)
+}
+
+func TestEngine_BroadcastQueueStatus_ShouldReturnError_WhenNotConfigured(t *testing.T) {
+	tests := map[string]struct {
+		engine      *engine.Engine
+		expectedErr error
+	}{
+		"no broadcast retry": {
+			engine:      &engine.Engine{Storage: newBroadcastQueueStorage()},
+			expectedErr: engine.ErrBroadcastRetryNotConfigured,
+		},
+		"storage without a broadcast queue": {
+			engine:      &engine.Engine{Storage: fakeStorage{}, BroadcastRetry: engine.NewBroadcastRetry(engine.BroadcastRetryConfig{})},
+			expectedErr: engine.ErrBroadcastQueueNotSupported,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			status, err := tc.engine.BroadcastQueueStatus(context.Background())
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, status)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConflictStorage is an in-memory output store layered on top of fakeStorage, implementing OutputDisputeStorage.
+type fakeConflictStorage struct {
+	fakeStorage
+
+	outputs map[transaction.Outpoint]*engine.Output
+	deleted []transaction.Outpoint
+}
+
+func (f *fakeConflictStorage) FindOutput(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+	return f.outputs[*outpoint], nil
+}
+
+func (f *fakeConflictStorage) FindOutputs(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+	found := make([]*engine.Output, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		found = append(found, f.outputs[*outpoint])
+	}
+	return found, nil
+}
+
+func (f *fakeConflictStorage) FindOutputsForTransaction(_ context.Context, txid *chainhash.Hash, _ bool) ([]*engine.Output, error) {
+	var found []*engine.Output
+	for outpoint, output := range f.outputs {
+		if outpoint.Txid.Equal(*txid) {
+			found = append(found, output)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeConflictStorage) InsertOutput(_ context.Context, output *engine.Output) error {
+	f.outputs[output.Outpoint] = output
+	return nil
+}
+
+func (f *fakeConflictStorage) MarkUTXOsAsSpent(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+	for _, outpoint := range outpoints {
+		if output, ok := f.outputs[*outpoint]; ok {
+			output.Spent = true
+		}
+	}
+	return nil
+}
+
+func (f *fakeConflictStorage) UpdateConsumedBy(_ context.Context, outpoint *transaction.Outpoint, _ string, consumedBy []*transaction.Outpoint) error {
+	f.outputs[*outpoint].ConsumedBy = consumedBy
+	return nil
+}
+
+func (f *fakeConflictStorage) DeleteOutput(_ context.Context, outpoint *transaction.Outpoint, _ string) error {
+	delete(f.outputs, *outpoint)
+	f.deleted = append(f.deleted, *outpoint)
+	return nil
+}
+
+func (f *fakeConflictStorage) UpdateOutputDisputed(_ context.Context, outpoint *transaction.Outpoint, _ string, disputed bool) error {
+	f.outputs[*outpoint].Disputed = disputed
+	return nil
+}
+
+// fakeDisputeLookupService records the dispute notifications it receives.
+type fakeDisputeLookupService struct {
+	fakeLookupService
+
+	disputed []*engine.OutputDisputed
+	resolved []*engine.OutputDisputeResolved
+}
+
+func (f *fakeDisputeLookupService) OutputAdmittedByTopic(_ context.Context, _ *engine.OutputAdmittedByTopic) error {
+	return nil
+}
+
+func (f *fakeDisputeLookupService) OutputSpent(_ context.Context, _ *engine.OutputSpent) error {
+	return nil
+}
+
+func (f *fakeDisputeLookupService) OutputBlockHeightUpdated(_ context.Context, _ *chainhash.Hash, _ uint32, _ uint64) error {
+	return nil
+}
+
+func (f *fakeDisputeLookupService) OutputDisputed(_ context.Context, payload *engine.OutputDisputed) error {
+	f.disputed = append(f.disputed, payload)
+	return nil
+}
+
+func (f *fakeDisputeLookupService) OutputDisputeResolved(_ context.Context, payload *engine.OutputDisputeResolved) error {
+	f.resolved = append(f.resolved, payload)
+	return nil
+}
+
+// newConflictFixture returns a tagged BEEF whose transaction spends a topical output that an
+// unconfirmed rival transaction already spent, along with the storage holding both.
+func newConflictFixture(t *testing.T) (overlay.TaggedBEEF, *fakeConflictStorage, transaction.Outpoint) {
+	t.Helper()
+
+	beef := createDummyBEEF(t)
+	tx := parseBEEFToTx(t, beef)
+	parent := transaction.Outpoint{Txid: *tx.Inputs[0].SourceTXID, Index: tx.Inputs[0].SourceTxOutIndex}
+	rival := transaction.Outpoint{Txid: chainhash.Hash{0xaa}, Index: 0}
+
+	storage := &fakeConflictStorage{
+		fakeStorage: fakeStorage{
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		outputs: map[transaction.Outpoint]*engine.Output{
+			parent: {Outpoint: parent, Topic: "test-topic", Script: &script.Script{script.OpTRUE}, Satoshis: 1000, Spent: true, BlockHeight: 100, ConsumedBy: []*transaction.Outpoint{&rival}},
+			rival:  {Outpoint: rival, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{&parent}},
+		},
+	}
+	return overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: beef}, storage, rival
+}
+
+func newConflictEngine(storage engine.Storage, policy engine.ConflictPolicy, lookupService engine.LookupService) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}}, nil
+				},
+			},
+		},
+		LookupServices: map[string]engine.LookupService{"test-service": lookupService},
+		Storage:        storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Broadcaster: fakeBroadcasterFail{
+			broadcastFunc: func(_ *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+				panic("conflicting transactions must not be broadcast")
+			},
+		},
+		ConflictPolicy: policy,
+	}
+}
+
+func TestEngine_Submit_ShouldRejectConflictingTransaction_WhenPolicyIsRejectNew(t *testing.T) {
+	// given:
+	taggedBEEF, storage, _ := newConflictFixture(t)
+	sut := newConflictEngine(storage, engine.ConflictPolicyRejectNew, &fakeDisputeLookupService{})
+
+	// when:
+	steak, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrConflictingTransaction)
+	require.Nil(t, steak)
+	require.Len(t, storage.outputs, 2)
+}
+
+func TestEngine_Submit_ShouldIgnoreConflictingTransaction_WhenPolicyIsFirstSeen(t *testing.T) {
+	// given:
+	taggedBEEF, storage, _ := newConflictFixture(t)
+	sut := newConflictEngine(storage, engine.ConflictPolicyFirstSeen, &fakeDisputeLookupService{})
+
+	// when:
+	steak, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &overlay.AdmittanceInstructions{}, steak["test-topic"])
+	require.Len(t, storage.outputs, 2)
+}
+
+func TestEngine_Submit_ShouldDisputeBothTransactions_WhenPolicyIsDispute(t *testing.T) {
+	// given:
+	taggedBEEF, storage, rival := newConflictFixture(t)
+	lookupService := &fakeDisputeLookupService{}
+	sut := newConflictEngine(storage, engine.ConflictPolicyDispute, lookupService)
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+
+	// when:
+	steak, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+
+	admitted := storage.outputs[transaction.Outpoint{Txid: *txid}]
+	require.NotNil(t, admitted)
+	require.True(t, admitted.Disputed)
+	require.True(t, storage.outputs[rival].Disputed)
+
+	require.Len(t, lookupService.disputed, 2)
+	require.Equal(t, admitted.Outpoint, *lookupService.disputed[0].Outpoint)
+	require.Equal(t, []*chainhash.Hash{&rival.Txid}, lookupService.disputed[0].ConflictingTxids)
+	require.Equal(t, rival, *lookupService.disputed[1].Outpoint)
+	require.Equal(t, []*chainhash.Hash{txid}, lookupService.disputed[1].ConflictingTxids)
+}
+
+func TestEngine_Submit_ShouldIgnoreConfirmedSpender_WhenLookingForConflicts(t *testing.T) {
+	// given:
+	taggedBEEF, storage, rival := newConflictFixture(t)
+	storage.outputs[rival].BlockHeight = 101
+	lookupService := &fakeDisputeLookupService{}
+	sut := newConflictEngine(storage, engine.ConflictPolicyRejectNew, lookupService)
+	sut.Broadcaster = nil
+
+	// when:
+	steak, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+	require.Empty(t, lookupService.disputed)
+}
+
+func TestEngine_HandleNewMerkleProof_ShouldResolveDispute_WhenDisputedTransactionIsMined(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	taggedBEEF, storage, rival := newConflictFixture(t)
+	storage.updateTransactionBEEF = func(_ context.Context, _ *chainhash.Hash, _ []byte) error {
+		return nil
+	}
+	storage.updateOutputBlockHeight = func(_ context.Context, _ *transaction.Outpoint, _ string, _ uint32, _ uint64, _ []byte) error {
+		return nil
+	}
+	lookupService := &fakeDisputeLookupService{}
+	sut := newConflictEngine(storage, engine.ConflictPolicyDispute, lookupService)
+
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+
+	txid := parseBEEFToTx(t, taggedBEEF.Beef).TxID()
+	winner := transaction.Outpoint{Txid: *txid}
+	parent := *storage.outputs[winner].OutputsConsumed[0]
+	proof := &transaction.MerklePath{
+		BlockHeight: 814435,
+		Path:        [][]*transaction.PathElement{{{Hash: txid, Offset: 0}}},
+	}
+
+	// when:
+	err = sut.HandleNewMerkleProof(ctx, txid, proof)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []transaction.Outpoint{rival}, storage.deleted)
+	require.False(t, storage.outputs[winner].Disputed)
+	require.Equal(t, []*transaction.Outpoint{&winner}, storage.outputs[parent].ConsumedBy)
+
+	require.Len(t, lookupService.resolved, 2)
+	require.Equal(t, rival, *lookupService.resolved[0].Outpoint)
+	require.False(t, lookupService.resolved[0].Confirmed)
+	require.Equal(t, winner, *lookupService.resolved[1].Outpoint)
+	require.True(t, lookupService.resolved[1].Confirmed)
+}
@@ -0,0 +1,181 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeadLetterStorage is an in-memory DeadLetterStorage layered on top of fakeStorage.
+type fakeDeadLetterStorage struct {
+	fakeStorage
+
+	deadLetters map[string]*engine.DeadLetter
+}
+
+func (f *fakeDeadLetterStorage) InsertDeadLetter(_ context.Context, deadLetter *engine.DeadLetter) error {
+	f.deadLetters[deadLetter.ID] = deadLetter
+	return nil
+}
+
+func (f *fakeDeadLetterStorage) FindDeadLetter(_ context.Context, id string) (*engine.DeadLetter, error) {
+	return f.deadLetters[id], nil
+}
+
+func (f *fakeDeadLetterStorage) FindDeadLetters(_ context.Context) ([]*engine.DeadLetter, error) {
+	found := make([]*engine.DeadLetter, 0, len(f.deadLetters))
+	for _, deadLetter := range f.deadLetters {
+		found = append(found, deadLetter)
+	}
+	return found, nil
+}
+
+func (f *fakeDeadLetterStorage) DeleteDeadLetter(_ context.Context, id string) error {
+	delete(f.deadLetters, id)
+	return nil
+}
+
+func newDeadLetterStorage() *fakeDeadLetterStorage {
+	return &fakeDeadLetterStorage{
+		fakeStorage: fakeStorage{
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				return nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		deadLetters: make(map[string]*engine.DeadLetter),
+	}
+}
+
+func newDeadLetterEngine(storage engine.Storage, broadcastFails *bool) *engine.Engine {
+	broadcast := func(_ *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+		if *broadcastFails {
+			return nil, &transaction.BroadcastFailure{Description: "forced failure for testing"}
+		}
+		return &transaction.BroadcastSuccess{}, nil
+	}
+
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Broadcaster: fakeBroadcasterFail{
+			broadcastFunc: broadcast,
+			broadcastCtxFunc: func(_ context.Context, tx *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+				return broadcast(tx)
+			},
+		},
+	}
+}
+
+func TestEngine_Submit_ShouldRecordDeadLetter_WhenBroadcastFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := true
+	storage := newDeadLetterStorage()
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+	// when:
+	_, firstErr := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	_, secondErr := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.Error(t, firstErr)
+	require.Error(t, secondErr)
+
+	deadLetters, err := sut.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+
+	deadLetter := deadLetters[0]
+	require.Equal(t, deadLetter.Txid.String(), deadLetter.ID)
+	require.Equal(t, taggedBEEF.Topics, deadLetter.Topics)
+	require.Equal(t, taggedBEEF.Beef, deadLetter.Beef)
+	require.Equal(t, engine.SubmitModeCurrent, deadLetter.Mode)
+	require.Equal(t, engine.DeadLetterStageBroadcast, deadLetter.Stage)
+	require.Equal(t, "forced failure for testing", deadLetter.Reason)
+	require.Equal(t, 2, deadLetter.Attempts)
+	require.False(t, deadLetter.LastFailedAt.Before(deadLetter.FirstFailedAt))
+}
+
+func TestEngine_ReplayDeadLetter_ShouldResubmitAndRemoveDeadLetter(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := true
+	storage := newDeadLetterStorage()
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.Error(t, err)
+
+	deadLetters, err := sut.ListDeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	broadcastFails = false
+
+	// when:
+	steak, err := sut.ReplayDeadLetter(ctx, deadLetters[0].ID)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+	require.Empty(t, storage.deadLetters)
+}
+
+func TestEngine_ReplayDeadLetter_ShouldFail_WhenDeadLetterNotFound(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: newDeadLetterStorage()}
+
+	// when:
+	steak, err := sut.ReplayDeadLetter(context.Background(), "missing")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrDeadLetterNotFound)
+	require.Nil(t, steak)
+}
+
+func TestEngine_ListDeadLetters_ShouldFail_WhenStorageDoesNotSupportDeadLetters(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}}
+
+	// when:
+	deadLetters, err := sut.ListDeadLetters(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrDeadLetterQueueNotSupported)
+	require.Nil(t, deadLetters)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/stretchr/testify/require"
@@ -152,3 +155,145 @@ func TestEngine_Lookup_ShouldHydrateOutputs_WhenFormulasProvided(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedAnswer, actualAnswer)
 }
+
+func TestEngine_Lookup_ShouldProxyAndCache_WhenServiceNotHostedAndLookupCacheConfigured(t *testing.T) {
+	// given
+	expectedAnswer := &lookup.LookupAnswer{
+		Type:   lookup.AnswerTypeFreeform,
+		Result: map[string]interface{}{"key": "value"},
+	}
+	resolver := &LookupResolverMock{ExpectedAnswer: expectedAnswer}
+	question := &lookup.LookupQuestion{Service: "ls_remote", Query: []byte(`{"name":"alice"}`)}
+
+	sut := &engine.Engine{
+		LookupServices: make(map[string]engine.LookupService),
+		LookupResolver: resolver,
+		LookupCache:    engine.NewLookupCache(time.Minute, 0),
+	}
+
+	// when
+	firstAnswer, firstErr := sut.Lookup(context.Background(), question)
+	resolver.QueryCalled = false
+	secondAnswer, secondErr := sut.Lookup(context.Background(), question)
+
+	// then
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, expectedAnswer, firstAnswer)
+	require.Equal(t, expectedAnswer, secondAnswer)
+	require.False(t, resolver.QueryCalled, "expected second lookup to be served from cache")
+	require.Equal(t, 1, sut.LookupCache.Len())
+}
+
+func TestEngine_Lookup_ShouldProxyWithoutResettingTheSLAPTrackers(t *testing.T) {
+	// given
+	resolver := &LookupResolverMock{ExpectedAnswer: &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform}}
+	sut := engine.NewEngine(engine.Engine{
+		SLAPTrackers:   []string{"https://slap.example.com"},
+		LookupResolver: resolver,
+		LookupCache:    engine.NewLookupCache(time.Minute, 0),
+	})
+	require.Equal(t, []string{"https://slap.example.com"}, resolver.ReceivedTrackers, "NewEngine hands the trackers to the resolver")
+	resolver.SetTrackersCalled = false
+
+	// when
+	_, err := sut.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_remote"})
+
+	// then
+	require.NoError(t, err)
+	require.True(t, resolver.QueryCalled)
+	require.False(t, resolver.SetTrackersCalled, "trackers are only set when they change")
+}
+
+func TestEngine_Lookup_ShouldReturnError_WhenProxiedQueryFails(t *testing.T) {
+	// given
+	resolver := &LookupResolverMock{ExpectedError: errInternalError}
+
+	sut := &engine.Engine{
+		LookupServices: make(map[string]engine.LookupService),
+		LookupResolver: resolver,
+		LookupCache:    engine.NewLookupCache(time.Minute, 0),
+	}
+
+	// when
+	actualAnswer, err := sut.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_remote"})
+
+	// then
+	require.ErrorIs(t, err, errInternalError)
+	require.Nil(t, actualAnswer)
+	require.Zero(t, sut.LookupCache.Len())
+}
+
+func TestEngine_Lookup_ShouldCacheLocalAnswers_UntilOutputsChange(t *testing.T) {
+	// given
+	ctx := context.Background()
+	lookups := 0
+	question := &lookup.LookupQuestion{Service: "ls_test", Query: []byte(`{"name":"alice"}`)}
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		LookupServices: map[string]engine.LookupService{
+			"ls_test": fakeLookupService{
+				lookupFunc: func(_ context.Context, _ *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+					lookups++
+					return &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: lookups}, nil
+				},
+				outputAdmittedByTopicFunc: func(_ context.Context, _ *engine.OutputAdmittedByTopic) error {
+					return nil
+				},
+				outputSpentFunc: func(_ context.Context, _ *engine.OutputSpent) error {
+					return nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				return nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return nil, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		LocalLookupCache: engine.NewLookupCache(time.Minute, 0),
+	}
+
+	// when
+	first, err := sut.Lookup(ctx, question)
+	require.NoError(t, err)
+	cached, err := sut.Lookup(ctx, question)
+	require.NoError(t, err)
+	_, err = sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	recomputed, err := sut.Lookup(ctx, question)
+	require.NoError(t, err)
+
+	// then
+	require.Equal(t, 1, first.Result)
+	require.Equal(t, 1, cached.Result)
+	require.Equal(t, 2, recomputed.Result)
+}
@@ -16,6 +16,8 @@ func TestEngine_NewEngine_ShouldInitializeFields_WhenNilProvided(t *testing.T) {
 		LookupServices:    map[string]engine.LookupService{},
 		SyncConfiguration: map[string]engine.SyncConfiguration{},
 		LookupResolver:    engine.NewLookupResolver(),
+		SyncProgress:      engine.NewGASPSyncProgress(),
+		TopicSyncJobs:     engine.NewTopicSyncJobs(),
 	}
 
 	// when:
@@ -23,7 +25,12 @@ func TestEngine_NewEngine_ShouldInitializeFields_WhenNilProvided(t *testing.T) {
 
 	// then:
 	require.NotNil(t, actual)
-	require.Equal(t, expected, actual)
+	require.Equal(t, expected.Managers, actual.Managers)
+	require.Equal(t, expected.LookupServices, actual.LookupServices)
+	require.Equal(t, expected.SyncConfiguration, actual.SyncConfiguration)
+	require.Equal(t, expected.LookupResolver, actual.LookupResolver)
+	require.Equal(t, expected.SyncProgress, actual.SyncProgress)
+	require.Equal(t, expected.TopicSyncJobs, actual.TopicSyncJobs)
 }
 
 func TestEngine_NewEngine_ShouldMergeTrackers_WhenManagerIsShipType(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
@@ -35,13 +36,48 @@ func TestEngine_ProvideForeignGASPNode_Success(t *testing.T) {
 	}
 
 	// when:
-	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic")
+	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic", false)
 
 	// then:
 	require.NoError(t, err)
 	require.Equal(t, expectedNode, node)
 }
 
+func TestEngine_ProvideForeignGASPNode_WithMetadata_ShouldIncludeProvenance(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	graphID := &transaction.Outpoint{}
+	outpoint := &transaction.Outpoint{Index: 1}
+	receivedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sut := &engine.Engine{
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{
+					Beef:       createDummyBEEF(t),
+					Score:      42,
+					ReceivedAt: receivedAt,
+					Source:     "https://peer.example.com",
+				}, nil
+			},
+		},
+	}
+
+	// when:
+	withMetadata, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic", true)
+	require.NoError(t, err)
+	withoutMetadata, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic", false)
+	require.NoError(t, err)
+
+	// then:
+	require.Equal(t, &gasp.NodeProvenance{
+		ReceivedAt: &receivedAt,
+		Source:     "https://peer.example.com",
+		Score:      42,
+	}, withMetadata.Provenance)
+	require.Nil(t, withoutMetadata.Provenance)
+}
+
 func TestEngine_ProvideForeignGASPNode_MissingBeef_ShouldReturnError(t *testing.T) {
 	// given:
 	ctx := context.Background()
@@ -57,7 +93,7 @@ func TestEngine_ProvideForeignGASPNode_MissingBeef_ShouldReturnError(t *testing.
 	}
 
 	// when:
-	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic")
+	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic", false)
 
 	// then:
 	require.ErrorIs(t, err, engine.ErrMissingInput)
@@ -79,7 +115,7 @@ func TestEngine_ProvideForeignGASPNode_CannotFindOutput_ShouldReturnError(t *tes
 	}
 
 	// when:
-	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic")
+	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic", false)
 
 	// then:
 	require.ErrorIs(t, err, errForcedError)
@@ -101,7 +137,7 @@ func TestEngine_ProvideForeignGASPNode_TransactionNotFound_ShouldReturnError(t *
 	}
 
 	// when:
-	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic")
+	node, err := sut.ProvideForeignGASPNode(ctx, graphID, outpoint, "test-topic", false)
 
 	// then:
 	require.ErrorContains(t, err, "invalid-version") // temp solution
@@ -258,7 +258,9 @@ func (f fakeBroadcasterFail) BroadcastCtx(ctx context.Context, tx *transaction.T
 }
 
 type fakeLookupService struct {
-	lookupFunc func(_ context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+	lookupFunc                func(_ context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+	outputAdmittedByTopicFunc func(_ context.Context, payload *engine.OutputAdmittedByTopic) error
+	outputSpentFunc           func(_ context.Context, payload *engine.OutputSpent) error
 }
 
 func (f fakeLookupService) Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
@@ -268,11 +270,17 @@ func (f fakeLookupService) Lookup(ctx context.Context, question *lookup.LookupQu
 	panic("func not defined")
 }
 
-func (f fakeLookupService) OutputAdmittedByTopic(_ context.Context, _ *engine.OutputAdmittedByTopic) error {
+func (f fakeLookupService) OutputAdmittedByTopic(ctx context.Context, payload *engine.OutputAdmittedByTopic) error {
+	if f.outputAdmittedByTopicFunc != nil {
+		return f.outputAdmittedByTopicFunc(ctx, payload)
+	}
 	panic("func not defined")
 }
 
-func (f fakeLookupService) OutputSpent(_ context.Context, _ *engine.OutputSpent) error {
+func (f fakeLookupService) OutputSpent(ctx context.Context, payload *engine.OutputSpent) error {
+	if f.outputSpentFunc != nil {
+		return f.outputSpentFunc(ctx, payload)
+	}
 	panic("func not defined")
 }
 
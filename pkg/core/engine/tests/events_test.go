@@ -0,0 +1,83 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSubscriber is an EventSubscriber recording the published events.
+type recordingSubscriber struct {
+	events []engine.Event
+}
+
+func (r *recordingSubscriber) HandleEvent(_ context.Context, event engine.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestEngine_Submit_ShouldPublishEvents(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := false
+	subscriber := &recordingSubscriber{}
+	sut := newDeadLetterEngine(newDeadLetterStorage(), &broadcastFails)
+	sut.Events = engine.NewEventBus(subscriber)
+
+	// when:
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, subscriber.events, 2)
+
+	spent, ok := subscriber.events[0].(engine.OutputSpentEvent)
+	require.True(t, ok)
+	require.Equal(t, engine.EventOutputSpent, spent.Type())
+	require.Equal(t, "test-topic", spent.Topic)
+
+	admitted, ok := subscriber.events[1].(engine.TransactionAdmittedEvent)
+	require.True(t, ok)
+	require.Equal(t, engine.EventTransactionAdmitted, admitted.Type())
+	require.Equal(t, "test-topic", admitted.Topic)
+	require.Equal(t, spent.SpendingTxid, admitted.Txid)
+	require.Equal(t, []uint32{0}, admitted.OutputsAdmitted)
+	require.False(t, admitted.Historical)
+}
+
+func TestEngine_Submit_ShouldPublishBroadcastFailed(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	broadcastFails := true
+	subscriber := &recordingSubscriber{}
+	sut := newDeadLetterEngine(newDeadLetterStorage(), &broadcastFails)
+	sut.Events = engine.NewEventBus(subscriber)
+
+	// when:
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.Error(t, err)
+	failed, ok := subscriber.events[len(subscriber.events)-1].(engine.BroadcastFailedEvent)
+	require.True(t, ok)
+	require.False(t, failed.Queued)
+	require.ErrorContains(t, failed.Err, "forced failure for testing")
+}
+
+func TestEventBus_Publish_ShouldRecoverPanickingSubscriber(t *testing.T) {
+	// given:
+	subscriber := &recordingSubscriber{}
+	sut := engine.NewEventBus(engine.EventSubscriberFunc(func(_ context.Context, _ engine.Event) {
+		panic("subscriber failure")
+	}))
+	sut.Subscribe(subscriber)
+	event := engine.SyncCompletedEvent{Topic: "test-topic", Peers: []string{"https://peer.example.com"}}
+
+	// when:
+	sut.Publish(context.Background(), event)
+
+	// then:
+	require.Equal(t, []engine.Event{event}, subscriber.events)
+}
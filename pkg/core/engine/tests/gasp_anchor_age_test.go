@@ -0,0 +1,85 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayGASPStorage_ValidateGraphAnchor_ShouldRejectAnchorOlderThanMaxAge(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	anchorTx := transaction.NewTransaction()
+	anchorTx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+	anchorTx.MerklePath = transaction.NewMerklePath(100, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: anchorTx.TxID(), Txid: ptr(true)},
+	}})
+	proof := anchorTx.MerklePath.Hex()
+	graphID := &transaction.Outpoint{Txid: *anchorTx.TxID(), Index: 0}
+
+	sut := engine.NewOverlayGASPStorage("test-topic", &engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {MaxAnchorAgeBlocks: 10},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+			currentHeightFunc: func(_ context.Context) (uint32, error) {
+				return 1000, nil
+			},
+		},
+	}, nil)
+	require.NoError(t, sut.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: anchorTx.Hex(), Proof: &proof}, nil))
+
+	// when:
+	err := sut.ValidateGraphAnchor(ctx, graphID)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrGraphAnchorTooOld)
+
+	var tooOld *engine.GraphAnchorTooOldError
+	require.ErrorAs(t, err, &tooOld)
+	require.Equal(t, uint32(100), tooOld.BlockHeight)
+	require.Equal(t, uint32(1000), tooOld.CurrentHeight)
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestOverlayGASPStorage_ValidateGraphAnchor_ShouldApplyMaxAgeOfTopicsRegisteredAtRuntime(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	anchorTx := transaction.NewTransaction()
+	anchorTx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+	anchorTx.MerklePath = transaction.NewMerklePath(100, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: anchorTx.TxID(), Txid: ptr(true)},
+	}})
+	proof := anchorTx.MerklePath.Hex()
+	graphID := &transaction.Outpoint{Txid: *anchorTx.TxID(), Index: 0}
+
+	e := engine.NewEngine(engine.Engine{
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+			currentHeightFunc: func(_ context.Context) (uint32, error) {
+				return 1000, nil
+			},
+		},
+	})
+	require.NoError(t, e.RegisterTopicManager(ctx, "test-topic", fakeManager{}, engine.SyncConfiguration{MaxAnchorAgeBlocks: 10}))
+	sut := engine.NewOverlayGASPStorage("test-topic", e, nil)
+	require.NoError(t, sut.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: anchorTx.Hex(), Proof: &proof}, nil))
+
+	// when:
+	err := sut.ValidateGraphAnchor(ctx, graphID)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrGraphAnchorTooOld)
+}
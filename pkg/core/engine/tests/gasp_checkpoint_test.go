@@ -0,0 +1,134 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCheckpointStorage is an in-memory GASPCheckpointStorage layered on top of fakeStorage.
+type fakeCheckpointStorage struct {
+	fakeStorage
+
+	checkpoints map[string]*engine.GASPCheckpoint
+}
+
+func newFakeCheckpointStorage() *fakeCheckpointStorage {
+	return &fakeCheckpointStorage{checkpoints: make(map[string]*engine.GASPCheckpoint)}
+}
+
+func (f *fakeCheckpointStorage) SaveGASPCheckpoint(_ context.Context, checkpoint *engine.GASPCheckpoint) error {
+	f.checkpoints[checkpoint.Peer+"|"+checkpoint.Topic] = checkpoint
+	return nil
+}
+
+func (f *fakeCheckpointStorage) FindGASPCheckpoint(_ context.Context, peer, topic string) (*engine.GASPCheckpoint, error) {
+	return f.checkpoints[peer+"|"+topic], nil
+}
+
+func (f *fakeCheckpointStorage) FindGASPCheckpoints(_ context.Context) ([]*engine.GASPCheckpoint, error) {
+	checkpoints := make([]*engine.GASPCheckpoint, 0, len(f.checkpoints))
+	for _, checkpoint := range f.checkpoints {
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	return checkpoints, nil
+}
+
+func (f *fakeCheckpointStorage) DeleteGASPCheckpoint(_ context.Context, peer, topic string) error {
+	delete(f.checkpoints, peer+"|"+topic)
+	return nil
+}
+
+func TestOverlayGASPStorage_Checkpoint_PersistsAndRestoresInFlightNodes(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	checkpoints := newFakeCheckpointStorage()
+
+	storage := engine.NewOverlayGASPStorage("test-topic", &engine.Engine{Storage: checkpoints}, nil)
+	storage.Peer = "https://peer.example.com"
+	storage.Checkpoints = checkpoints
+
+	rootTx := transaction.NewTransaction()
+	rootTx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{}})
+	graphID := &transaction.Outpoint{Txid: *rootTx.TxID(), Index: 0}
+
+	childTx := transaction.NewTransaction()
+	childTx.AddOutput(&transaction.TransactionOutput{Satoshis: 500, LockingScript: &script.Script{}})
+
+	// when:
+	require.NoError(t, storage.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: rootTx.Hex()}, nil))
+	require.NoError(t, storage.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: childTx.Hex()}, graphID))
+	require.NoError(t, storage.Checkpoint(ctx, 42))
+
+	// then:
+	checkpoint, err := checkpoints.FindGASPCheckpoint(ctx, "https://peer.example.com", "test-topic")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	require.InDelta(t, 42.0, checkpoint.LastInteraction, 0)
+	require.Len(t, checkpoint.Nodes, 2)
+	require.Equal(t, graphID.String(), checkpoint.Nodes[0].Key)
+	require.Empty(t, checkpoint.Nodes[0].SpentBy)
+	require.Equal(t, graphID.String(), checkpoint.Nodes[1].SpentBy)
+
+	restored := engine.NewOverlayGASPStorage("test-topic", &engine.Engine{Storage: checkpoints}, nil)
+	graphIDs, err := restored.RestoreInFlightNodes(ctx, checkpoint.Nodes)
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{graphID}, graphIDs)
+	require.Equal(t, checkpoint.Nodes, restored.InFlightNodes())
+}
+
+func TestOverlayGASPStorage_AppendToGraph_DoesNotCheckpoint(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	checkpoints := newFakeCheckpointStorage()
+
+	storage := engine.NewOverlayGASPStorage("test-topic", &engine.Engine{Storage: checkpoints}, nil)
+	storage.Peer = "https://peer.example.com"
+	storage.Checkpoints = checkpoints
+
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{}})
+	graphID := &transaction.Outpoint{Txid: *tx.TxID(), Index: 0}
+
+	// when:
+	require.NoError(t, storage.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: tx.Hex()}, nil))
+	require.NoError(t, storage.DiscardGraph(ctx, graphID))
+
+	// then:
+	require.Empty(t, checkpoints.checkpoints, "checkpoints are only saved once per synced page")
+}
+
+func TestEngine_ResumeGASPSync_StorageWithoutCheckpointSupport(t *testing.T) {
+	// given:
+	sut := engine.NewEngine(engine.Engine{Storage: &fakeStorage{}})
+
+	// when:
+	err := sut.ResumeGASPSync(context.Background())
+
+	// then:
+	require.NoError(t, err)
+}
+
+func TestEngine_ResumeGASPSync_DiscardsCheckpointForUnsyncedTopic(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	checkpoints := newFakeCheckpointStorage()
+	require.NoError(t, checkpoints.SaveGASPCheckpoint(ctx, &engine.GASPCheckpoint{
+		Peer:  "https://peer.example.com",
+		Topic: "unknown-topic",
+	}))
+
+	sut := engine.NewEngine(engine.Engine{Storage: checkpoints})
+
+	// when:
+	err := sut.ResumeGASPSync(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Empty(t, checkpoints.checkpoints)
+}
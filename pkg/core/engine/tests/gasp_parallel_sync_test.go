@@ -0,0 +1,146 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/stretchr/testify/require"
+)
+
+// newGASPPeer starts a GASP peer serving an empty initial response, calling before first when set.
+func newGASPPeer(t *testing.T, before func(w http.ResponseWriter) bool) string {
+	t.Helper()
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if before != nil && !before(w) {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&gasp.InitialResponse{UTXOList: []*gasp.Output{}})
+	}))
+	t.Cleanup(peer.Close)
+	return peer.URL
+}
+
+// newGASPSyncBarrier returns a peer hook blocking every request until n of them are in flight,
+// failing the request when they are not after a second.
+func newGASPSyncBarrier(n int) func(w http.ResponseWriter) bool {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	return func(w http.ResponseWriter) bool {
+		wg.Done()
+		reached := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(reached)
+		}()
+		select {
+		case <-reached:
+			return true
+		case <-time.After(time.Second):
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return false
+		}
+	}
+}
+
+func newGASPSyncStorage() fakeStorage {
+	return fakeStorage{
+		getLastInteractionFunc: func(_ context.Context, _, _ string) (float64, error) {
+			return 0, nil
+		},
+		findUTXOsForTopicFunc: func(_ context.Context, _ string, _ float64, _ uint32, _ bool) ([]*engine.Output, error) {
+			return nil, nil
+		},
+		updateLastInteractionFunc: func(_ context.Context, _, _ string, _ float64) error {
+			return nil
+		},
+	}
+}
+
+func TestEngine_SyncGASP_ShouldReportSucceededAndFailedPeers(t *testing.T) {
+	// given:
+	healthy := newGASPPeer(t, nil)
+	failing := newGASPPeer(t, func(w http.ResponseWriter) bool {
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	})
+	var completed []engine.SyncCompletedEvent
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{healthy, failing}, PeerConcurrency: 2},
+		},
+		Storage: newGASPSyncStorage(),
+		Events: engine.NewEventBus(engine.EventSubscriberFunc(func(_ context.Context, event engine.Event) {
+			if event, ok := event.(engine.SyncCompletedEvent); ok {
+				completed = append(completed, event)
+			}
+		})),
+	}
+
+	// when:
+	report, err := sut.SyncGASP(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, report.Topics, 1)
+	require.Equal(t, "test-topic", report.Topics[0].Topic)
+	require.Equal(t, []string{healthy}, report.Topics[0].Succeeded)
+	require.Equal(t, []string{failing}, report.Topics[0].FailedPeers())
+	require.Error(t, report.Topics[0].Failed[0].Err)
+	require.Equal(t, 1, report.FailedPeers())
+
+	require.Len(t, completed, 1)
+	require.Equal(t, []string{failing}, completed[0].Failed)
+}
+
+func TestEngine_SyncGASP_ShouldSyncConcurrently(t *testing.T) {
+	tests := map[string]func(first, second string) *engine.Engine{
+		"peers of a topic": func(first, second string) *engine.Engine {
+			return &engine.Engine{
+				Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+				SyncConfiguration: map[string]engine.SyncConfiguration{
+					"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{first, second}, PeerConcurrency: 2},
+				},
+				Storage: newGASPSyncStorage(),
+			}
+		},
+		"topics": func(first, second string) *engine.Engine {
+			return &engine.Engine{
+				Managers: map[string]engine.TopicManager{"tm_first": fakeTopicManager{}, "tm_second": fakeTopicManager{}},
+				SyncConfiguration: map[string]engine.SyncConfiguration{
+					"tm_first":  {Type: engine.SyncConfigurationPeers, Peers: []string{first}},
+					"tm_second": {Type: engine.SyncConfigurationPeers, Peers: []string{second}},
+				},
+				Storage:     newGASPSyncStorage(),
+				SyncWorkers: 2,
+			}
+		},
+	}
+
+	for name, newEngine := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			barrier := newGASPSyncBarrier(2)
+			sut := newEngine(newGASPPeer(t, barrier), newGASPPeer(t, barrier))
+
+			// when:
+			report, err := sut.SyncGASP(context.Background())
+
+			// then:
+			require.NoError(t, err)
+			require.Zero(t, report.FailedPeers())
+			succeeded := 0
+			for _, topic := range report.Topics {
+				succeeded += len(topic.Succeeded)
+			}
+			require.Equal(t, 2, succeeded)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayGASPRemote_ShouldRetryAndAuthenticate_WhenConfigured(t *testing.T) {
+	// given:
+	var attempts atomic.Int32
+	var authorization, topic atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		topic.Store(r.Header.Get("X-BSV-Topic"))
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"UTXOList":[],"since":7}`))
+	}))
+	defer srv.Close()
+
+	sut, err := engine.NewOverlayGASPRemote(srv.URL, "test-topic", engine.GASPRemoteConfig{
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+		BearerToken:  "secret",
+	})
+	require.NoError(t, err)
+
+	// when:
+	resp, err := sut.GetInitialResponse(context.Background(), &gasp.InitialRequest{Version: 1})
+
+	// then:
+	require.NoError(t, err)
+	require.InDelta(t, 7.0, resp.Since, 0)
+	require.Equal(t, int32(3), attempts.Load())
+	require.Equal(t, "Bearer secret", authorization.Load())
+	require.Equal(t, "test-topic", topic.Load())
+}
+
+func TestOverlayGASPRemote_ShouldFail_WhenPeerExceedsTimeout(t *testing.T) {
+	// given:
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sut, err := engine.NewOverlayGASPRemote(srv.URL, "test-topic", engine.GASPRemoteConfig{Timeout: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	// when:
+	resp, err := sut.GetInitialResponse(context.Background(), &gasp.InitialRequest{Version: 1})
+
+	// then:
+	require.Error(t, err)
+	require.Nil(t, resp)
+}
+
+func TestOverlayGASPRemote_ShouldReject_WhenPeerResponseExceedsPayloadLimits(t *testing.T) {
+	// given:
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/requestSyncResponse") {
+			_, _ = w.Write([]byte(`{"UTXOList":[{},{},{}],"since":7}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"rawTx":"` + strings.Repeat("00", 64) + `","outputIndex":0}`))
+	}))
+	defer srv.Close()
+
+	sut, err := engine.NewOverlayGASPRemote(srv.URL, "test-topic", engine.GASPRemoteConfig{
+		PayloadLimits: engine.GASPPayloadLimits{
+			InitialResponse: jsonlimit.Limits{MaxArrayLength: 2},
+			Node:            jsonlimit.Limits{MaxStringLength: 100},
+		},
+	})
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Index: 0}
+
+	// when:
+	resp, responseErr := sut.GetInitialResponse(context.Background(), &gasp.InitialRequest{Version: 1})
+	node, nodeErr := sut.RequestNode(context.Background(), outpoint, outpoint, false)
+
+	// then:
+	require.ErrorIs(t, responseErr, jsonlimit.ErrLimitExceeded)
+	require.Nil(t, resp)
+	require.ErrorIs(t, nodeErr, jsonlimit.ErrLimitExceeded)
+	require.Nil(t, node)
+}
+
+func TestSyncConfiguration_RemoteFor_ShouldPreferPeerOverride(t *testing.T) {
+	// given:
+	sut := engine.SyncConfiguration{
+		Remote:      engine.GASPRemoteConfig{Timeout: time.Second},
+		PeerRemotes: map[string]engine.GASPRemoteConfig{"https://slow.example.com": {Timeout: time.Minute}},
+	}
+
+	// when:
+	defaultRemote := sut.RemoteFor("https://other.example.com")
+	overridden := sut.RemoteFor("https://slow.example.com")
+
+	// then:
+	require.Equal(t, time.Second, defaultRemote.Timeout)
+	require.Equal(t, time.Minute, overridden.Timeout)
+}
@@ -0,0 +1,139 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_ProvideForeignSyncResponse_ShouldRejectBusyPeer(t *testing.T) {
+	// given:
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	limiter := engine.NewGASPServeLimiter(engine.GASPServeLimits{MaxConcurrentPerPeer: 1})
+	sut := &engine.Engine{
+		GASPServeLimiter: limiter,
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(ctx context.Context, _ string, _ float64, _ uint32, _ bool) ([]*engine.Output, error) {
+				if ctx.Value(blockingRequestKey{}) != nil {
+					close(started)
+					<-unblock
+				}
+				return nil, nil
+			},
+		},
+	}
+	alice := engine.WithGASPPeer(context.Background(), "alice")
+	done := make(chan error, 1)
+	go func() {
+		_, err := sut.ProvideForeignSyncResponse(context.WithValue(alice, blockingRequestKey{}, true), &gasp.InitialRequest{}, "test-topic")
+		done <- err
+	}()
+	<-started
+
+	// when:
+	_, busyErr := sut.ProvideForeignSyncResponse(alice, &gasp.InitialRequest{}, "test-topic")
+	_, bobErr := sut.ProvideForeignSyncResponse(engine.WithGASPPeer(context.Background(), "bob"), &gasp.InitialRequest{}, "test-topic")
+	_, nodeErr := sut.ProvideForeignGASPNode(alice, &transaction.Outpoint{}, &transaction.Outpoint{}, "test-topic", false)
+	close(unblock)
+
+	// then:
+	require.NoError(t, <-done)
+	require.NoError(t, bobErr)
+	require.ErrorIs(t, busyErr, engine.ErrGASPPeerBusy)
+	require.ErrorIs(t, nodeErr, engine.ErrGASPPeerBusy)
+
+	var target *engine.GASPPeerBusyError
+	require.True(t, errors.As(busyErr, &target))
+	require.Equal(t, "alice", target.Peer)
+	require.Equal(t, engine.DefaultGASPServeRetryAfter, target.RetryAfter)
+
+	metrics := limiter.Metrics()
+	require.Equal(t, uint64(2), metrics.Served)
+	require.Equal(t, uint64(2), metrics.Rejected)
+	require.Empty(t, metrics.InFlight)
+}
+
+func TestEngine_ProvideForeignSyncResponse_ShouldTruncateResponsesBeyondBudget(t *testing.T) {
+	tests := map[string]struct {
+		requestedLimit  uint32
+		expectedLimit   uint32
+		expectedPartial bool
+	}{
+		"unlimited request is capped": {
+			requestedLimit:  0,
+			expectedLimit:   2,
+			expectedPartial: true,
+		},
+		"request beyond the cap is capped": {
+			requestedLimit:  10,
+			expectedLimit:   2,
+			expectedPartial: true,
+		},
+		"request within the cap is served as is": {
+			requestedLimit:  1,
+			expectedLimit:   1,
+			expectedPartial: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			var actualLimit uint32
+			limiter := engine.NewGASPServeLimiter(engine.GASPServeLimits{MaxUTXOsPerResponse: 2})
+			sut := &engine.Engine{
+				GASPServeLimiter: limiter,
+				Storage: fakeStorage{
+					findUTXOsForTopicFunc: func(_ context.Context, _ string, _ float64, limit uint32, _ bool) ([]*engine.Output, error) {
+						actualLimit = limit
+						outputs := make([]*engine.Output, limit)
+						for i := range outputs {
+							outputs[i] = &engine.Output{Outpoint: transaction.Outpoint{Txid: fakeTxID(t), Index: uint32(i)}, Score: float64(i)}
+						}
+						return outputs, nil
+					},
+				},
+			}
+
+			// when:
+			response, err := sut.ProvideForeignSyncResponse(context.Background(), &gasp.InitialRequest{Limit: tc.requestedLimit}, "test-topic")
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedLimit, actualLimit)
+			require.Len(t, response.UTXOList, int(tc.expectedLimit))
+			require.Equal(t, tc.expectedPartial, response.Partial)
+			if tc.expectedPartial {
+				require.Equal(t, uint64(1), limiter.Metrics().Truncated)
+			}
+		})
+	}
+}
+
+func TestEngine_ProvideForeignGASPNode_ShouldRejectNodesBeyondBudget(t *testing.T) {
+	// given:
+	limiter := engine.NewGASPServeLimiter(engine.GASPServeLimits{MaxNodeBytes: 10})
+	sut := &engine.Engine{
+		GASPServeLimiter: limiter,
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{Beef: createDummyBEEF(t)}, nil
+			},
+		},
+	}
+
+	// when:
+	node, err := sut.ProvideForeignGASPNode(context.Background(), &transaction.Outpoint{}, &transaction.Outpoint{}, "test-topic", false)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrGASPResponseBudgetExceeded)
+	require.Nil(t, node)
+	require.Equal(t, uint64(1), limiter.Metrics().OverBudget)
+}
+
+type blockingRequestKey struct{}
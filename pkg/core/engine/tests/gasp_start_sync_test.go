@@ -19,6 +19,7 @@ func TestEngine_StartGASPSync_CallsSyncSuccessfully(t *testing.T) {
 	resolver := LookupResolverMock{
 		ExpectQueryCall:       true,
 		ExpectSetTrackersCall: true,
+		ExpectedTrackers:      []string{"http://localhost"},
 		ExpectedAnswer: &lookup.LookupAnswer{
 			Type: lookup.AnswerTypeOutputList,
 			Outputs: []*lookup.OutputListItem{
@@ -52,6 +53,7 @@ func TestEngine_StartGASPSync_CallsSyncSuccessfully(t *testing.T) {
 		Advertiser:        &advertiser,
 		HostingURL:        "http://localhost",
 		SHIPTrackers:      []string{"http://localhost"},
+		SLAPTrackers:      []string{"http://localhost"},
 		LookupResolver:    &resolver,
 		Storage:           mockStorage,
 	})
@@ -71,6 +73,7 @@ func TestEngine_StartGASPSync_ResolverQueryFails(t *testing.T) {
 	resolver := LookupResolverMock{
 		ExpectQueryCall:       true,
 		ExpectSetTrackersCall: true,
+		ExpectedTrackers:      []string{"http://localhost"},
 		ExpectedError:         errInternalQueryCallFailure,
 		ExpectedAnswer: &lookup.LookupAnswer{
 			Type: lookup.AnswerTypeOutputList,
@@ -106,6 +109,7 @@ func TestEngine_StartGASPSync_ResolverQueryFails(t *testing.T) {
 		Advertiser:        &advertiser,
 		HostingURL:        "http://localhost",
 		SHIPTrackers:      []string{"http://localhost"},
+		SLAPTrackers:      []string{"http://localhost"},
 		LookupResolver:    &resolver,
 		Storage:           mockStorage,
 	})
@@ -124,6 +128,7 @@ func TestEngine_StartGASPSync_GaspSyncFails(t *testing.T) {
 	resolver := LookupResolverMock{
 		ExpectQueryCall:       true,
 		ExpectSetTrackersCall: true,
+		ExpectedTrackers:      []string{"http://localhost"},
 		ExpectedAnswer: &lookup.LookupAnswer{
 			Type: lookup.AnswerTypeOutputList,
 			Outputs: []*lookup.OutputListItem{
@@ -158,6 +163,7 @@ func TestEngine_StartGASPSync_GaspSyncFails(t *testing.T) {
 		Advertiser:        &advertiser,
 		HostingURL:        "http://localhost",
 		SHIPTrackers:      []string{"http://localhost"},
+		SLAPTrackers:      []string{"http://localhost"},
 		LookupResolver:    &resolver,
 		Storage:           mockStorage,
 	})
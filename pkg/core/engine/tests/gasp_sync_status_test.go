@@ -0,0 +1,98 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// syncStatusStorage is a TopicStatsStorage also holding GASP checkpoints.
+type syncStatusStorage struct {
+	*fakeTopicStatsStorage
+
+	checkpoints []*engine.GASPCheckpoint
+}
+
+func (s *syncStatusStorage) SaveGASPCheckpoint(_ context.Context, _ *engine.GASPCheckpoint) error {
+	return nil
+}
+
+func (s *syncStatusStorage) FindGASPCheckpoint(_ context.Context, _, _ string) (*engine.GASPCheckpoint, error) {
+	return nil, nil //nolint:nilnil // no checkpoint to resume
+}
+
+func (s *syncStatusStorage) FindGASPCheckpoints(_ context.Context) ([]*engine.GASPCheckpoint, error) {
+	return s.checkpoints, nil
+}
+
+func (s *syncStatusStorage) DeleteGASPCheckpoint(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func TestEngine_GASPSyncStatus_ShouldReportTheSyncsOfEveryPeer(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	healthy := newGASPPeer(t, nil)
+	failing := newGASPPeer(t, func(w http.ResponseWriter) bool {
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	})
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{healthy, failing}},
+		},
+		Storage: newGASPSyncStorage(),
+	})
+	require.NoError(t, sut.StartGASPSync(ctx))
+
+	// when:
+	statuses, err := sut.GASPSyncStatus(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	byPeer := map[string]*engine.GASPPeerSyncStatus{statuses[0].Peer: statuses[0], statuses[1].Peer: statuses[1]}
+
+	require.Equal(t, "test-topic", byPeer[healthy].Topic)
+	require.False(t, byPeer[healthy].Running)
+	require.Equal(t, 1, byPeer[healthy].PagesCompleted)
+	require.Zero(t, byPeer[healthy].Errors)
+	require.False(t, byPeer[healthy].StartedAt.IsZero())
+	require.False(t, byPeer[healthy].EndedAt.Before(byPeer[healthy].StartedAt))
+
+	require.Zero(t, byPeer[failing].PagesCompleted)
+	require.Equal(t, 1, byPeer[failing].Errors)
+	require.NotEmpty(t, byPeer[failing].LastError)
+}
+
+func TestEngine_GASPSyncStatus_ShouldReportProgressPersistedInStorage(t *testing.T) {
+	// given:
+	storage := &syncStatusStorage{
+		fakeTopicStatsStorage: &fakeTopicStatsStorage{
+			interactions: map[string]map[string]float64{
+				"tm_a": {"https://peer-1.example.com": 10, "https://peer-2.example.com": 20},
+			},
+		},
+		checkpoints: []*engine.GASPCheckpoint{
+			{Topic: "tm_a", Peer: "https://peer-2.example.com", LastInteraction: 25, Nodes: []*engine.GASPCheckpointNode{{}, {}}},
+		},
+	}
+	sut := &engine.Engine{
+		SyncConfiguration: map[string]engine.SyncConfiguration{"tm_a": {Type: engine.SyncConfigurationPeers}},
+		Storage:           storage,
+	}
+
+	// when:
+	statuses, err := sut.GASPSyncStatus(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*engine.GASPPeerSyncStatus{
+		{Topic: "tm_a", Peer: "https://peer-1.example.com", LastInteraction: 10},
+		{Topic: "tm_a", Peer: "https://peer-2.example.com", LastInteraction: 25, Resumable: true, CheckpointNodes: 2},
+	}, statuses)
+}
@@ -0,0 +1,136 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryRetentionFixture returns a tagged BEEF spending a topical output with two spent ancestors,
+// along with the storage holding the chain and the outpoints of the output and its ancestors.
+func newHistoryRetentionFixture(t *testing.T) (overlay.TaggedBEEF, *fakeConflictStorage, []transaction.Outpoint) {
+	t.Helper()
+
+	beef := createDummyBEEF(t)
+	tx := parseBEEFToTx(t, beef)
+	parent := transaction.Outpoint{Txid: *tx.Inputs[0].SourceTXID, Index: tx.Inputs[0].SourceTxOutIndex}
+	grandparent := transaction.Outpoint{Txid: chainhash.Hash{0x01}, Index: 0}
+	greatGrandparent := transaction.Outpoint{Txid: chainhash.Hash{0x02}, Index: 0}
+
+	storage := &fakeConflictStorage{
+		fakeStorage: fakeStorage{
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		outputs: map[transaction.Outpoint]*engine.Output{
+			parent:           {Outpoint: parent, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{&grandparent}},
+			grandparent:      {Outpoint: grandparent, Topic: "test-topic", Spent: true, OutputsConsumed: []*transaction.Outpoint{&greatGrandparent}, ConsumedBy: []*transaction.Outpoint{&parent}},
+			greatGrandparent: {Outpoint: greatGrandparent, Topic: "test-topic", Spent: true, ConsumedBy: []*transaction.Outpoint{&grandparent}},
+		},
+	}
+	return overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: beef}, storage, []transaction.Outpoint{parent, grandparent, greatGrandparent}
+}
+
+func newHistoryRetentionEngine(storage engine.Storage, depth int) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsToRetain: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Retention: &engine.RetentionConfig{
+			Topics: map[string]engine.RetentionPolicy{"test-topic": {HistoryRetentionDepth: depth}},
+		},
+	}
+}
+
+func TestEngine_Submit_ShouldPurgeAncestorsBeyondHistoryRetentionDepth(t *testing.T) {
+	tests := map[string]struct {
+		depth        int
+		expectedKept int
+	}{
+		"Keeps the full history without a depth":   {depth: 0, expectedKept: 3},
+		"Keeps only the retained output":           {depth: 1, expectedKept: 1},
+		"Keeps the retained output and its parent": {depth: 2, expectedKept: 2},
+		"Keeps every ancestor within the depth":    {depth: 3, expectedKept: 3},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			taggedBEEF, storage, chain := newHistoryRetentionFixture(t)
+			sut := newHistoryRetentionEngine(storage, tc.depth)
+
+			// when:
+			_, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+
+			// then:
+			require.NoError(t, err)
+			for i, outpoint := range chain {
+				_, kept := storage.outputs[outpoint]
+				require.Equal(t, i < tc.expectedKept, kept, "ancestor %d", i)
+			}
+			require.Len(t, storage.outputs[chain[0]].ConsumedBy, 1)
+		})
+	}
+}
+
+func TestEngine_Submit_ShouldKeepPinnedAncestorsBeyondHistoryRetentionDepth(t *testing.T) {
+	// given:
+	taggedBEEF, storage, chain := newHistoryRetentionFixture(t)
+	storage.outputs[chain[1]].Pinned = true
+	sut := newHistoryRetentionEngine(storage, 1)
+
+	// when:
+	_, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Contains(t, storage.outputs, chain[1])
+	require.Contains(t, storage.outputs, chain[2])
+}
+
+func TestEngine_GetUTXOHistory_ShouldStopAtHistoryRetentionDepth(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	parent := &transaction.Outpoint{Txid: chainhash.Hash{0x01}, Index: 0}
+	grandparent := &transaction.Outpoint{Txid: chainhash.Hash{0x02}, Index: 0}
+	var found []transaction.Outpoint
+	sut := &engine.Engine{
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				found = append(found, *outpoint)
+				return &engine.Output{Outpoint: *outpoint, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{grandparent}}, nil
+			},
+		},
+		Retention: &engine.RetentionConfig{
+			Topics: map[string]engine.RetentionPolicy{"test-topic": {HistoryRetentionDepth: 1}},
+		},
+	}
+	output := &engine.Output{Topic: "test-topic", Beef: createDummyBEEF(t), OutputsConsumed: []*transaction.Outpoint{parent}}
+
+	// when:
+	history, err := sut.GetUTXOHistory(ctx, output, func([]byte, uint32, uint32) bool { return true }, 0)
+
+	// then:
+	require.NoError(t, err)
+	require.NotNil(t, history)
+	require.Equal(t, []transaction.Outpoint{*parent}, found)
+}
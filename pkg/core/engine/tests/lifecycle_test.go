@@ -0,0 +1,167 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Submit_ShouldBeRejected_WhenEngineStopped(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := &engine.Engine{Lifecycle: engine.NewLifecycle(time.Second)}
+	require.NoError(t, sut.Start(ctx))
+	require.NoError(t, sut.Stop(ctx))
+
+	// when:
+	steak, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrEngineStopping)
+	require.Nil(t, steak)
+}
+
+func TestEngine_Stop_ShouldWaitForInFlightSubmit(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	admitting := make(chan struct{})
+	release := make(chan struct{})
+	storage := newDeadLetterStorage()
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					close(admitting)
+					<-release
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Lifecycle: engine.NewLifecycle(time.Minute),
+	}
+	require.NoError(t, sut.Start(ctx))
+
+	submitted := make(chan error, 1)
+	go func() {
+		_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+		submitted <- err
+	}()
+	<-admitting
+
+	// when:
+	stopped := make(chan error, 1)
+	go func() { stopped <- sut.Stop(ctx) }()
+
+	// then:
+	require.Eventually(t, sut.Lifecycle.Stopping, time.Second, time.Millisecond)
+	require.Equal(t, 1, sut.Lifecycle.InFlight())
+	require.Never(t, func() bool { return len(stopped) > 0 }, 50*time.Millisecond, 5*time.Millisecond)
+
+	close(release)
+	require.NoError(t, <-submitted)
+	require.NoError(t, <-stopped)
+	require.Equal(t, 0, sut.Lifecycle.InFlight())
+}
+
+func TestEngine_Stop_ShouldInterruptGASPSyncAndPersistLastInteraction_WhenDrainTimeoutElapses(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	page := &gasp.InitialResponse{UTXOList: make([]*gasp.Output, 0, engine.DefaultGASPSyncLimit)}
+	known := make([]*engine.Output, 0, engine.DefaultGASPSyncLimit)
+	for i := range uint32(engine.DefaultGASPSyncLimit) {
+		page.UTXOList = append(page.UTXOList, &gasp.Output{OutputIndex: i, Score: float64(i + 1)})
+		known = append(known, &engine.Output{Outpoint: transaction.Outpoint{Index: i}})
+	}
+
+	var requests atomic.Int32
+	hang := make(chan struct{})
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			_ = json.NewEncoder(w).Encode(page)
+			return
+		}
+		<-hang
+	}))
+	t.Cleanup(peer.Close)
+	t.Cleanup(func() { close(hang) })
+
+	var persisted atomic.Value
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{peer.URL}},
+		},
+		Storage: fakeStorage{
+			getLastInteractionFunc: func(_ context.Context, _, _ string) (float64, error) {
+				return 0, nil
+			},
+			findUTXOsForTopicFunc: func(_ context.Context, _ string, _ float64, _ uint32, _ bool) ([]*engine.Output, error) {
+				return known, nil
+			},
+			updateLastInteractionFunc: func(_ context.Context, _, _ string, score float64) error {
+				persisted.Store(score)
+				return nil
+			},
+		},
+		Lifecycle: engine.NewLifecycle(10 * time.Millisecond),
+	}
+	require.NoError(t, sut.Start(ctx))
+
+	synced := make(chan error, 1)
+	go func() { synced <- sut.StartGASPSync(ctx) }()
+	require.Eventually(t, func() bool { return requests.Load() == 2 }, 5*time.Second, time.Millisecond)
+
+	// when:
+	err := sut.Stop(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.ErrorIs(t, <-synced, engine.ErrEngineStopping)
+	require.InDelta(t, float64(engine.DefaultGASPSyncLimit), persisted.Load(), 0)
+}
+
+func TestEngine_Stop_ShouldReportIncompleteDrain_WhenContextDone(t *testing.T) {
+	// given:
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	sut := &engine.Engine{
+		Storage: fakeStorage{
+			findOutputsForTransaction: func(_ context.Context, _ *chainhash.Hash, _ bool) ([]*engine.Output, error) {
+				<-release
+				return nil, nil
+			},
+		},
+		Lifecycle: engine.NewLifecycle(time.Minute),
+	}
+	require.NoError(t, sut.Start(context.Background()))
+	go func() {
+		_ = sut.HandleNewMerkleProof(context.Background(), &chainhash.Hash{}, &transaction.MerklePath{})
+	}()
+	require.Eventually(t, func() bool { return sut.Lifecycle.InFlight() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// when:
+	err := sut.Stop(ctx)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrDrainIncomplete)
+}
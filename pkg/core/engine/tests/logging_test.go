@@ -0,0 +1,92 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogs makes the default logger write JSON records to the returned buffer for the duration of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+// logRecords decodes the JSON records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		require.NoError(t, dec.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestEngine_Submit_ShouldLogRequestID(t *testing.T) {
+	// given:
+	logs := captureLogs(t)
+	ctx := engine.WithRequestID(context.Background(), "req-submit")
+	sut := &engine.Engine{
+		Managers:     map[string]engine.TopicManager{},
+		Storage:      fakeStorage{},
+		ChainTracker: fakeChainTracker{},
+	}
+
+	// when:
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"unknown-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	records := logRecords(t, logs)
+	require.NotEmpty(t, records)
+	for _, record := range records {
+		require.Equal(t, "req-submit", record["request_id"], record["msg"])
+	}
+}
+
+func TestEngine_Lookup_ShouldLogRequestID(t *testing.T) {
+	// given:
+	logs := captureLogs(t)
+	ctx := engine.WithRequestID(context.Background(), "req-lookup")
+	sut := &engine.Engine{LookupServices: map[string]engine.LookupService{}}
+
+	// when:
+	_, err := sut.Lookup(ctx, &lookup.LookupQuestion{Service: "non-existing"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	records := logRecords(t, logs)
+	require.NotEmpty(t, records)
+	for _, record := range records {
+		require.Equal(t, "req-lookup", record["request_id"], record["msg"])
+	}
+}
+
+func TestEngine_Lookup_ShouldNotLogRequestID_WhenContextCarriesNone(t *testing.T) {
+	// given:
+	logs := captureLogs(t)
+	sut := &engine.Engine{LookupServices: map[string]engine.LookupService{}}
+
+	// when:
+	_, err := sut.Lookup(context.Background(), &lookup.LookupQuestion{Service: "non-existing"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	for _, record := range logRecords(t, logs) {
+		require.NotContains(t, record, "request_id")
+	}
+}
@@ -0,0 +1,82 @@
+package engine_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCache_ShouldExpireEntriesAfterTTL(t *testing.T) {
+	// given
+	sut := engine.NewLookupCache(time.Millisecond, 0)
+	question := &lookup.LookupQuestion{Service: "ls_test", Query: []byte(`{}`)}
+	sut.Set(question, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+
+	// when
+	time.Sleep(5 * time.Millisecond)
+	answer, ok := sut.Get(question)
+
+	// then
+	require.False(t, ok)
+	require.Nil(t, answer)
+}
+
+func TestLookupCache_ShouldEvict_WhenMaxEntriesReached(t *testing.T) {
+	// given
+	sut := engine.NewLookupCache(time.Minute, 1)
+	first := &lookup.LookupQuestion{Service: "ls_test", Query: []byte(`{"n":1}`)}
+	second := &lookup.LookupQuestion{Service: "ls_test", Query: []byte(`{"n":2}`)}
+
+	// when
+	sut.Set(first, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+	sut.Set(second, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+
+	// then
+	_, firstCached := sut.Get(first)
+	_, secondCached := sut.Get(second)
+	require.False(t, firstCached)
+	require.True(t, secondCached)
+	require.Equal(t, 1, sut.Len())
+}
+
+func TestLookupCache_ShouldApplyServiceTTL(t *testing.T) {
+	// given
+	sut := engine.NewLookupCacheWithConfig(engine.LookupCacheConfig{
+		TTL:         time.Minute,
+		ServiceTTLs: map[string]time.Duration{"ls_short": time.Millisecond},
+	})
+	short := &lookup.LookupQuestion{Service: "ls_short", Query: []byte(`{}`)}
+	long := &lookup.LookupQuestion{Service: "ls_long", Query: []byte(`{}`)}
+	sut.Set(short, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+	sut.Set(long, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+
+	// when
+	time.Sleep(5 * time.Millisecond)
+	_, shortCached := sut.Get(short)
+	_, longCached := sut.Get(long)
+
+	// then
+	require.False(t, shortCached)
+	require.True(t, longCached)
+}
+
+func TestLookupCache_ShouldInvalidateOnlyTheService(t *testing.T) {
+	// given
+	sut := engine.NewLookupCache(time.Minute, 0)
+	first := &lookup.LookupQuestion{Service: "ls_first", Query: []byte(`{}`)}
+	second := &lookup.LookupQuestion{Service: "ls_second", Query: []byte(`{}`)}
+	sut.Set(first, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+	sut.Set(second, &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform})
+
+	// when
+	sut.Invalidate("ls_first")
+
+	// then
+	_, firstCached := sut.Get(first)
+	_, secondCached := sut.Get(second)
+	require.False(t, firstCached)
+	require.True(t, secondCached)
+}
@@ -0,0 +1,126 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newHydrationEngine returns an engine answering lookups with a formula for the outpoint
+// and recording the calls made to FindOutput.
+func newHydrationEngine(outpoint *transaction.Outpoint, beef []byte, history func([]byte, uint32, uint32) bool, includeBEEFs *[]bool) *engine.Engine {
+	return &engine.Engine{
+		LookupServices: map[string]engine.LookupService{
+			"test": fakeLookupService{
+				lookupFunc: func(context.Context, *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+					return &lookup.LookupAnswer{Type: lookup.AnswerTypeFormula, Formulas: []lookup.LookupFormula{{Outpoint: outpoint, History: history}}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, found *transaction.Outpoint, _ *string, _ *bool, includeBEEF bool) (*engine.Output, error) {
+				*includeBEEFs = append(*includeBEEFs, includeBEEF)
+				output := &engine.Output{Outpoint: *found, Metadata: map[string]string{"kind": "test"}}
+				if includeBEEF {
+					output.Beef = beef
+				}
+				return output, nil
+			},
+		},
+	}
+}
+
+func TestEngine_LookupPaged_ShouldHydrateOutputsAsSelected(t *testing.T) {
+	beef := createDummyBEEF(t)
+	_, tx, txid, err := transaction.ParseBeef(beef)
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *txid, Index: 0}
+
+	tests := map[string]struct {
+		hydration           engine.LookupHydration
+		expectedOutput      *engine.LookupOutput
+		expectedIncludeBEEF []bool
+	}{
+		"none": {
+			hydration:      engine.LookupHydration{Mode: engine.LookupHydrationNone},
+			expectedOutput: &engine.LookupOutput{Txid: *txid},
+		},
+		"txid": {
+			hydration:           engine.LookupHydration{Mode: engine.LookupHydrationTxid},
+			expectedOutput:      &engine.LookupOutput{Txid: *txid, Metadata: map[string]string{"kind": "test"}},
+			expectedIncludeBEEF: []bool{false},
+		},
+		"rawtx": {
+			hydration:           engine.LookupHydration{Mode: engine.LookupHydrationRawTx},
+			expectedOutput:      &engine.LookupOutput{Txid: *txid, RawTx: tx.Bytes(), Metadata: map[string]string{"kind": "test"}},
+			expectedIncludeBEEF: []bool{true},
+		},
+		"full": {
+			expectedOutput:      &engine.LookupOutput{Txid: *txid, Beef: beef, Metadata: map[string]string{"kind": "test"}},
+			expectedIncludeBEEF: []bool{true},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			var includeBEEFs []bool
+			sut := newHydrationEngine(outpoint, beef, nil, &includeBEEFs)
+			ctx := engine.WithLookupHydration(context.Background(), tc.hydration)
+
+			// when:
+			answer, err := sut.LookupPaged(ctx, &lookup.LookupQuestion{Service: "test"}, engine.LookupPage{})
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, []*engine.LookupOutput{tc.expectedOutput}, collectLookupPage(t, answer))
+			require.Equal(t, tc.expectedIncludeBEEF, includeBEEFs)
+		})
+	}
+}
+
+func TestEngine_Lookup_ShouldBoundFormulaHistoryByHydrationDepth(t *testing.T) {
+	// given:
+	beef := createDummyBEEF(t)
+	parent := &transaction.Outpoint{Txid: chainhash.Hash{7}, Index: 0}
+	outpoint := &transaction.Outpoint{Txid: chainhash.Hash{8}, Index: 0}
+	var depths []uint32
+	history := func(_ []byte, _, depth uint32) bool {
+		depths = append(depths, depth)
+		return true
+	}
+	var includeBEEFs []bool
+	sut := newHydrationEngine(outpoint, beef, history, &includeBEEFs)
+	sut.Storage = fakeStorage{
+		findOutputFunc: func(_ context.Context, found *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+			output := &engine.Output{Outpoint: *found, Beef: beef}
+			if *found == *outpoint {
+				output.OutputsConsumed = []*transaction.Outpoint{parent}
+			}
+			return output, nil
+		},
+	}
+	depth := uint32(0)
+	ctx := engine.WithLookupHydration(context.Background(), engine.LookupHydration{Depth: &depth})
+
+	// when:
+	answer, err := sut.Lookup(ctx, &lookup.LookupQuestion{Service: "test"})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, answer.Outputs, 1)
+	require.Equal(t, []uint32{0}, depths)
+}
+
+func TestLookupHydration_Validate_ShouldRejectUnknownMode(t *testing.T) {
+	// when:
+	err := engine.LookupHydration{Mode: "beefy"}.Validate()
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrInvalidLookupHydration)
+}
@@ -0,0 +1,212 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	notificationsTopic   = "tm_notifications"
+	notificationsService = "ls_notifications"
+)
+
+var errIndexerDown = errors.New("indexer down")
+
+type notificationsFixture struct {
+	storage  *testharness.MemoryStorage
+	builder  *testutil.Builder
+	engine   engine.Engine
+	failures atomic.Int32 // admissions to fail before accepting them
+	admitted atomic.Int32
+}
+
+func newNotificationsFixture(t *testing.T) *notificationsFixture {
+	t.Helper()
+	tracker := testutil.NewChainTracker(800000)
+	f := &notificationsFixture{
+		storage: testharness.NewMemoryStorage(),
+		builder: testutil.NewBuilder(t, tracker),
+	}
+	f.engine = engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			notificationsTopic: &testharness.TopicManager{Topic: notificationsTopic},
+		},
+		LookupServices: map[string]engine.LookupService{
+			notificationsService: fakeLookupService{
+				outputAdmittedByTopicFunc: func(context.Context, *engine.OutputAdmittedByTopic) error {
+					if f.failures.Add(-1) >= 0 {
+						return errIndexerDown
+					}
+					f.admitted.Add(1)
+					return nil
+				},
+			},
+		},
+		Storage:      f.storage,
+		ChainTracker: tracker,
+	}
+	return f
+}
+
+func (f *notificationsFixture) submit(t *testing.T, sut *engine.Engine, tx *transaction.Transaction) (overlay.Steak, error) {
+	t.Helper()
+	taggedBEEF := overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{notificationsTopic}}
+	return sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+}
+
+func TestEngine_Submit_ShouldFailSubmissions_WhenALookupServicePanicsWithoutPolicy(t *testing.T) {
+	// given:
+	f := newNotificationsFixture(t)
+	f.engine.LookupServices = map[string]engine.LookupService{
+		notificationsService: fakeLookupService{
+			outputAdmittedByTopicFunc: func(context.Context, *engine.OutputAdmittedByTopic) error {
+				panic("nil index")
+			},
+		},
+	}
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+
+	// when:
+	steak, err := f.submit(t, sut, tx)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrLookupServicePanic)
+	require.Nil(t, steak)
+}
+
+func TestEngine_Submit_ShouldAdmitOutputs_WhenTheFailingHookLogsAndContinues(t *testing.T) {
+	// given:
+	f := newNotificationsFixture(t)
+	f.failures.Store(1)
+	f.engine.LookupNotifications = engine.NewLookupNotifications(engine.LookupNotificationsConfig{
+		Services: map[string]engine.LookupServicePolicy{
+			notificationsService: {Hooks: map[string]engine.LookupErrorPolicy{
+				engine.LookupHookOutputAdmittedByTopic: engine.LookupErrorPolicyLogAndContinue,
+			}},
+		},
+	})
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+
+	// when:
+	steak, err := f.submit(t, sut, tx)
+
+	// then:
+	require.NoError(t, err)
+	testutil.RequireAdmitted(t, steak, notificationsTopic, 0)
+	exists, err := f.storage.DoesAppliedTransactionExist(context.Background(), &overlay.AppliedTransaction{Txid: tx.TxID(), Topic: notificationsTopic})
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	metrics := f.engine.LookupNotifications.Metrics()
+	require.Equal(t, uint64(1), metrics.Failed)
+	require.Equal(t, uint64(1), metrics.Ignored)
+	require.Zero(t, metrics.Pending)
+}
+
+func TestEngine_RetryLookupNotifications_ShouldDeliverQueuedNotifications_WhenTheServiceRecovers(t *testing.T) {
+	// given:
+	f := newNotificationsFixture(t)
+	f.failures.Store(2)
+	f.engine.LookupNotifications = engine.NewLookupNotifications(engine.LookupNotificationsConfig{
+		Default: engine.LookupServicePolicy{OnError: engine.LookupErrorPolicyRetryAsync},
+		Retry:   engine.LookupRetryConfig{InitialBackoff: time.Nanosecond},
+	})
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+	steak, err := f.submit(t, sut, tx)
+	require.NoError(t, err)
+	testutil.RequireAdmitted(t, steak, notificationsTopic, 0)
+	require.Equal(t, 1, f.engine.LookupNotifications.Metrics().Pending)
+
+	// when:
+	first, firstErr := sut.RetryLookupNotifications(context.Background())
+	time.Sleep(time.Millisecond)
+	second, secondErr := sut.RetryLookupNotifications(context.Background())
+
+	// then:
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, &engine.LookupRetryReport{Retried: 1}, first)
+	require.Equal(t, &engine.LookupRetryReport{Retried: 1, Succeeded: 1}, second)
+	require.Equal(t, int32(1), f.admitted.Load())
+
+	metrics := f.engine.LookupNotifications.Metrics()
+	require.Equal(t, uint64(1), metrics.Queued)
+	require.Equal(t, uint64(1), metrics.Succeeded)
+	require.Zero(t, metrics.Pending)
+}
+
+func TestEngine_RetryLookupNotifications_ShouldAbandonNotifications_WhenMaxAttemptsIsReached(t *testing.T) {
+	// given:
+	f := newNotificationsFixture(t)
+	f.failures.Store(10)
+	f.engine.LookupNotifications = engine.NewLookupNotifications(engine.LookupNotificationsConfig{
+		Default: engine.LookupServicePolicy{OnError: engine.LookupErrorPolicyRetryAsync},
+		Retry:   engine.LookupRetryConfig{InitialBackoff: time.Nanosecond, MaxAttempts: 2},
+	})
+	sut := engine.NewEngine(f.engine)
+	_, err := f.submit(t, sut, f.builder.Mined(800001, 1000))
+	require.NoError(t, err)
+
+	// when:
+	report, err := sut.RetryLookupNotifications(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.LookupRetryReport{Retried: 1, Abandoned: 1}, report)
+	metrics := f.engine.LookupNotifications.Metrics()
+	require.Equal(t, uint64(1), metrics.Abandoned)
+	require.Zero(t, metrics.Pending)
+}
+
+func TestEngine_Submit_ShouldDropNotifications_WhenTheRetryQueueIsFull(t *testing.T) {
+	// given:
+	f := newNotificationsFixture(t)
+	f.failures.Store(10)
+	f.engine.LookupNotifications = engine.NewLookupNotifications(engine.LookupNotificationsConfig{
+		Default: engine.LookupServicePolicy{OnError: engine.LookupErrorPolicyRetryAsync},
+		Retry:   engine.LookupRetryConfig{MaxQueued: 1},
+	})
+	sut := engine.NewEngine(f.engine)
+
+	// when:
+	_, firstErr := f.submit(t, sut, f.builder.Mined(800001, 1000))
+	_, secondErr := f.submit(t, sut, f.builder.Mined(800002, 1000))
+
+	// then:
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	metrics := f.engine.LookupNotifications.Metrics()
+	require.Equal(t, uint64(1), metrics.Queued)
+	require.Equal(t, uint64(1), metrics.Dropped)
+	require.Equal(t, 1, metrics.Pending)
+}
+
+func TestLookupNotifications_Policy_ShouldPreferHookPoliciesOverServiceAndDefaultOnes(t *testing.T) {
+	// given:
+	sut := engine.NewLookupNotifications(engine.LookupNotificationsConfig{
+		Default: engine.LookupServicePolicy{OnError: engine.LookupErrorPolicyLogAndContinue},
+		Services: map[string]engine.LookupServicePolicy{
+			"ls_critical": {Hooks: map[string]engine.LookupErrorPolicy{engine.LookupHookOutputSpent: engine.LookupErrorPolicyRetryAsync}},
+			"ls_unknown":  {OnError: "explode"},
+		},
+	})
+
+	// then:
+	require.Equal(t, engine.LookupErrorPolicyLogAndContinue, sut.Policy("ls_other", engine.LookupHookOutputSpent))
+	require.Equal(t, engine.LookupErrorPolicyRetryAsync, sut.Policy("ls_critical", engine.LookupHookOutputSpent))
+	require.Equal(t, engine.LookupErrorPolicyFailSubmit, sut.Policy("ls_critical", engine.LookupHookOutputAdmittedByTopic))
+	require.Equal(t, engine.LookupErrorPolicyFailSubmit, sut.Policy("ls_unknown", engine.LookupHookOutputSpent))
+}
@@ -0,0 +1,141 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePagedLookupService is a PagedLookupService recording the pages it was asked for.
+type fakePagedLookupService struct {
+	fakeLookupService
+
+	pages  []engine.LookupPage
+	answer *lookup.LookupAnswer
+	next   string
+}
+
+func (f *fakePagedLookupService) LookupPage(_ context.Context, _ *lookup.LookupQuestion, page engine.LookupPage) (*lookup.LookupAnswer, string, error) {
+	f.pages = append(f.pages, page)
+	return f.answer, f.next, nil
+}
+
+// collectLookupPage returns the outputs of a page, failing the test on an error.
+func collectLookupPage(t *testing.T, page *engine.LookupPageAnswer) []*engine.LookupOutput {
+	t.Helper()
+	var outputs []*engine.LookupOutput
+	for output, err := range page.Outputs {
+		require.NoError(t, err)
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+func TestEngine_LookupPaged_ShouldPageFormulasWithOffsetCursors(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	formulas := make([]lookup.LookupFormula, 3)
+	for i := range formulas {
+		formulas[i] = lookup.LookupFormula{Outpoint: &transaction.Outpoint{Txid: chainhash.Hash{byte(i + 1)}, Index: uint32(i)}}
+	}
+	var hydrated []*transaction.Outpoint
+	sut := &engine.Engine{
+		LookupServices: map[string]engine.LookupService{
+			"test": fakeLookupService{
+				lookupFunc: func(context.Context, *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+					return &lookup.LookupAnswer{Type: lookup.AnswerTypeFormula, Formulas: formulas}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				hydrated = append(hydrated, outpoint)
+				return &engine.Output{Outpoint: *outpoint, Beef: []byte{outpoint.Txid[0]}}, nil
+			},
+		},
+	}
+	question := &lookup.LookupQuestion{Service: "test"}
+
+	// when:
+	first, firstErr := sut.LookupPaged(ctx, question, engine.LookupPage{Limit: 2})
+	require.NoError(t, firstErr)
+	firstOutputs := collectLookupPage(t, first)
+	last, lastErr := sut.LookupPaged(ctx, question, engine.LookupPage{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, lastErr)
+	lastOutputs := collectLookupPage(t, last)
+
+	// then:
+	require.Equal(t, lookup.AnswerTypeOutputList, first.Type)
+	require.NotEmpty(t, first.NextCursor)
+	require.Equal(t, []*engine.LookupOutput{
+		{Beef: []byte{1}, Txid: chainhash.Hash{1}, OutputIndex: 0},
+		{Beef: []byte{2}, Txid: chainhash.Hash{2}, OutputIndex: 1},
+	}, firstOutputs)
+	require.Empty(t, last.NextCursor)
+	require.Equal(t, []*engine.LookupOutput{{Beef: []byte{3}, Txid: chainhash.Hash{3}, OutputIndex: 2}}, lastOutputs)
+	require.Equal(t, []*transaction.Outpoint{formulas[0].Outpoint, formulas[1].Outpoint, formulas[2].Outpoint}, hydrated)
+}
+
+func TestEngine_LookupPaged_ShouldDelegateToPagedLookupService(t *testing.T) {
+	// given:
+	service := &fakePagedLookupService{
+		answer: &lookup.LookupAnswer{Type: lookup.AnswerTypeOutputList, Outputs: []*lookup.OutputListItem{{Beef: []byte{1}, OutputIndex: 4}}},
+		next:   "service-cursor",
+	}
+	sut := &engine.Engine{LookupServices: map[string]engine.LookupService{"test": service}}
+	page := engine.LookupPage{Limit: 1, Cursor: "previous"}
+
+	// when:
+	answer, err := sut.LookupPaged(context.Background(), &lookup.LookupQuestion{Service: "test"}, page)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, "service-cursor", answer.NextCursor)
+	require.Equal(t, []*engine.LookupOutput{{Beef: []byte{1}, OutputIndex: 4}}, collectLookupPage(t, answer))
+	require.Equal(t, []engine.LookupPage{page}, service.pages)
+}
+
+func TestEngine_LookupPaged_ShouldReturnError(t *testing.T) {
+	tests := map[string]struct {
+		service     string
+		page        engine.LookupPage
+		expectedErr error
+	}{
+		"unknown lookup service": {
+			service:     "unknown",
+			expectedErr: engine.ErrUnknownTopic,
+		},
+		"cursor not returned by the engine": {
+			service:     "test",
+			page:        engine.LookupPage{Cursor: "not a cursor"},
+			expectedErr: engine.ErrInvalidLookupCursor,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := &engine.Engine{
+				LookupServices: map[string]engine.LookupService{
+					"test": fakeLookupService{
+						lookupFunc: func(context.Context, *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+							return &lookup.LookupAnswer{Type: lookup.AnswerTypeOutputList}, nil
+						},
+					},
+				},
+			}
+
+			// when:
+			answer, err := sut.LookupPaged(context.Background(), &lookup.LookupQuestion{Service: tc.service}, tc.page)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, answer)
+		})
+	}
+}
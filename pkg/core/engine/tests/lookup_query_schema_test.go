@@ -0,0 +1,97 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSchemaLookupService struct {
+	fakeLookupService
+	schema json.RawMessage
+}
+
+func (f fakeSchemaLookupService) QuerySchema() json.RawMessage {
+	return f.schema
+}
+
+var testQuerySchema = json.RawMessage(`{
+	"type": "object",
+	"required": ["name"],
+	"properties": {"name": {"type": "string", "minLength": 1}}
+}`)
+
+func TestEngine_Lookup_ShouldRejectQuery_WhenItDoesNotMatchTheSchema(t *testing.T) {
+	tests := map[string]json.RawMessage{
+		"missing property": json.RawMessage(`{"other":"alice"}`),
+		"wrong type":       json.RawMessage(`{"name":42}`),
+		"not JSON":         json.RawMessage(`not json`),
+	}
+
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			sut := &engine.Engine{
+				LookupServices: map[string]engine.LookupService{
+					"ls_test": fakeSchemaLookupService{schema: testQuerySchema},
+				},
+			}
+
+			// when
+			answer, err := sut.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_test", Query: query})
+
+			// then
+			require.ErrorIs(t, err, engine.ErrInvalidLookupQuery)
+			require.Nil(t, answer)
+		})
+	}
+}
+
+func TestEngine_Lookup_ShouldPassQuery_WhenItMatchesTheSchema(t *testing.T) {
+	// given
+	expectedAnswer := &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "ok"}
+	sut := &engine.Engine{
+		LookupServices: map[string]engine.LookupService{
+			"ls_test": fakeSchemaLookupService{
+				fakeLookupService: fakeLookupService{
+					lookupFunc: func(_ context.Context, _ *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+						return expectedAnswer, nil
+					},
+				},
+				schema: testQuerySchema,
+			},
+		},
+	}
+
+	// when
+	answer, err := sut.Lookup(context.Background(), &lookup.LookupQuestion{Service: "ls_test", Query: json.RawMessage(`{"name":"alice"}`)})
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, expectedAnswer, answer)
+}
+
+func TestEngine_GetLookupQuerySchema(t *testing.T) {
+	// given
+	sut := &engine.Engine{
+		LookupServices: map[string]engine.LookupService{
+			"ls_schema":    fakeSchemaLookupService{schema: testQuerySchema},
+			"ls_no_schema": fakeLookupService{},
+		},
+	}
+
+	// when
+	schema, err := sut.GetLookupQuerySchema(context.Background(), "ls_schema")
+	_, notDeclaredErr := sut.GetLookupQuerySchema(context.Background(), "ls_no_schema")
+	_, unknownErr := sut.GetLookupQuerySchema(context.Background(), "ls_unknown")
+
+	// then
+	require.NoError(t, err)
+	require.JSONEq(t, string(testQuerySchema), string(schema))
+	require.ErrorIs(t, notDeclaredErr, engine.ErrLookupQuerySchemaNotDeclared)
+	require.ErrorIs(t, unknownErr, engine.ErrUnknownTopic)
+}
@@ -0,0 +1,195 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReplayLookupService records the events it receives, in order.
+type fakeReplayLookupService struct {
+	fakeLookupService
+
+	events []string
+	spent  []*engine.OutputSpent
+}
+
+func (f *fakeReplayLookupService) OutputAdmittedByTopic(_ context.Context, payload *engine.OutputAdmittedByTopic) error {
+	f.events = append(f.events, "admitted "+payload.Outpoint.String())
+	return nil
+}
+
+func (f *fakeReplayLookupService) OutputSpent(_ context.Context, payload *engine.OutputSpent) error {
+	f.events = append(f.events, "spent "+payload.Outpoint.String())
+	f.spent = append(f.spent, payload)
+	return nil
+}
+
+func TestEngine_ReplayTopicToLookupService_ShouldReplayHistoryInOrder(t *testing.T) {
+	// given:
+	taggedBEEF, prevTxID := createDummyValidTaggedBEEF(t)
+	spender := parseBEEFToTx(t, taggedBEEF.Beef)
+	consumedOutpoint := &transaction.Outpoint{Txid: *prevTxID, Index: 0}
+	consumed := &engine.Output{Outpoint: *consumedOutpoint, Topic: "test-topic", Spent: true, Beef: createDummyBEEF(t)}
+	utxos := []*engine.Output{
+		{Outpoint: transaction.Outpoint{Txid: *spender.TxID(), Index: 0}, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{consumedOutpoint}, Beef: taggedBEEF.Beef, Score: 1},
+		{Outpoint: transaction.Outpoint{Txid: *spender.TxID(), Index: 1}, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{consumedOutpoint}, Beef: taggedBEEF.Beef, Score: 2},
+	}
+	lookupService := &fakeReplayLookupService{}
+	other := &fakeReplayLookupService{}
+	sut := &engine.Engine{
+		Managers:       map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		LookupServices: map[string]engine.LookupService{"ls_rebuilt": lookupService, "ls_other": other},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, topic string, since float64, _ uint32, includeBEEF bool) ([]*engine.Output, error) {
+				require.Equal(t, "test-topic", topic)
+				require.Zero(t, since)
+				require.True(t, includeBEEF)
+				return utxos, nil
+			},
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				require.Equal(t, consumedOutpoint, outpoint)
+				return consumed, nil
+			},
+		},
+	}
+	var reported []engine.LookupReplayProgress
+
+	// when:
+	progress, err := sut.ReplayTopicToLookupService(context.Background(), "test-topic", "ls_rebuilt", func(p engine.LookupReplayProgress) {
+		reported = append(reported, p)
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"admitted " + consumedOutpoint.String(),
+		"spent " + consumedOutpoint.String(),
+		"admitted " + utxos[0].Outpoint.String(),
+		"admitted " + utxos[1].Outpoint.String(),
+	}, lookupService.events)
+	require.Equal(t, spender.TxID(), lookupService.spent[0].SpendingTxid)
+	require.Zero(t, lookupService.spent[0].InputIndex)
+	require.Empty(t, other.events)
+	expected := engine.LookupReplayProgress{Topic: "test-topic", Service: "ls_rebuilt", Admitted: 3, Spent: 1, Score: 2}
+	require.Equal(t, &expected, progress)
+	require.Equal(t, []engine.LookupReplayProgress{expected}, reported)
+}
+
+func TestEngine_ReplayTopicToLookupService_ShouldReplayEveryUTXO_WhenMoreThanAPageShareAScore(t *testing.T) {
+	// given:
+	utxos := make([]*engine.Output, 2*engine.DefaultLookupReplayBatchSize+100)
+	for i := range utxos {
+		score := 1.0
+		if i == len(utxos)-1 {
+			score = 2
+		}
+		utxos[i] = &engine.Output{Outpoint: transaction.Outpoint{Index: uint32(i)}, Topic: "test-topic", Score: score} //nolint:gosec // bounded test index
+	}
+	lookupService := &fakeReplayLookupService{}
+	sut := &engine.Engine{
+		Managers:       map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		LookupServices: map[string]engine.LookupService{"ls_rebuilt": lookupService},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, _ string, since float64, limit uint32, _ bool) ([]*engine.Output, error) {
+				var page []*engine.Output
+				for _, utxo := range utxos {
+					if utxo.Score >= since && len(page) < int(limit) {
+						page = append(page, utxo)
+					}
+				}
+				return page, nil
+			},
+		},
+	}
+
+	// when:
+	progress, err := sut.ReplayTopicToLookupService(context.Background(), "test-topic", "ls_rebuilt", nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, lookupService.events, len(utxos))
+	require.Equal(t, "admitted "+utxos[len(utxos)-1].Outpoint.String(), lookupService.events[len(utxos)-1])
+	require.Equal(t, len(utxos), progress.Admitted)
+	require.InDelta(t, 2.0, progress.Score, 0)
+}
+
+func TestEngine_ReplayTopicToLookupService_ShouldFail_WhenLookupServiceUnknown(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}}}
+
+	// when:
+	progress, err := sut.ReplayTopicToLookupService(context.Background(), "test-topic", "ls_missing", nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrLookupServiceNotRegistered)
+	require.Nil(t, progress)
+}
+
+func TestEngine_ReplayTopicToLookupService_ShouldFail_WhenTopicUnknown(t *testing.T) {
+	// given:
+	sut := &engine.Engine{LookupServices: map[string]engine.LookupService{"ls_rebuilt": &fakeReplayLookupService{}}}
+
+	// when:
+	progress, err := sut.ReplayTopicToLookupService(context.Background(), "unknown-topic", "ls_rebuilt", nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, progress)
+}
+
+func TestEngine_ReplayTopicIntoLookupService_ShouldResumeFromScore(t *testing.T) {
+	// given:
+	taggedBEEF, _ := createDummyValidTaggedBEEF(t)
+	tx := parseBEEFToTx(t, taggedBEEF.Beef)
+	utxo := &engine.Output{Outpoint: transaction.Outpoint{Txid: *tx.TxID(), Index: 1}, Topic: "test-topic", Beef: taggedBEEF.Beef, Score: 7}
+	lookupService := &fakeReplayLookupService{}
+	sut := &engine.Engine{
+		Managers:       map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		LookupServices: map[string]engine.LookupService{"ls_new": lookupService},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, _ string, since float64, _ uint32, _ bool) ([]*engine.Output, error) {
+				require.InDelta(t, 5.0, since, 0)
+				return []*engine.Output{utxo}, nil
+			},
+		},
+	}
+
+	// when:
+	progress, err := sut.ReplayTopicIntoLookupService(context.Background(), "test-topic", "ls_new", 5)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{"admitted " + utxo.Outpoint.String()}, lookupService.events)
+	require.Equal(t, &engine.LookupReplayProgress{Topic: "test-topic", Service: "ls_new", Admitted: 1, Score: 7}, progress)
+}
+
+func TestEngine_ReplayTopicIntoLookupService_ShouldReturnTheProgressReached_WhenTheServiceFails(t *testing.T) {
+	// given:
+	taggedBEEF, _ := createDummyValidTaggedBEEF(t)
+	tx := parseBEEFToTx(t, taggedBEEF.Beef)
+	utxo := &engine.Output{Outpoint: transaction.Outpoint{Txid: *tx.TxID(), Index: 1}, Topic: "test-topic", Beef: taggedBEEF.Beef, Score: 7}
+	failure := errors.New("index unavailable")
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		LookupServices: map[string]engine.LookupService{"ls_new": fakeLookupService{
+			outputAdmittedByTopicFunc: func(context.Context, *engine.OutputAdmittedByTopic) error { return failure },
+		}},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(context.Context, string, float64, uint32, bool) ([]*engine.Output, error) {
+				return []*engine.Output{utxo}, nil
+			},
+		},
+	}
+
+	// when:
+	progress, err := sut.ReplayTopicIntoLookupService(context.Background(), "test-topic", "ls_new", 3)
+
+	// then:
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, &engine.LookupReplayProgress{Topic: "test-topic", Service: "ls_new", Score: 3}, progress)
+}
@@ -3,7 +3,10 @@ package engine_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
@@ -154,3 +157,110 @@ func TestLookupResolver_ErrorScenarios(t *testing.T) {
 		_ = question
 	})
 }
+
+// countingFacilitator answers lookups with its answers by URL, failing for URLs without one, and counts them.
+type countingFacilitator struct {
+	mu      sync.Mutex
+	answers map[string]*lookup.LookupAnswer
+	calls   map[string]int
+}
+
+func (f *countingFacilitator) Lookup(_ context.Context, url string, _ *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[url]++
+	if answer, ok := f.answers[url]; ok {
+		return answer, nil
+	}
+	return nil, errors.New("tracker unreachable")
+}
+
+func (f *countingFacilitator) callsTo(url string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[url]
+}
+
+func newCountingFacilitator(answers map[string]*lookup.LookupAnswer) *countingFacilitator {
+	return &countingFacilitator{answers: answers, calls: make(map[string]int)}
+}
+
+func TestLookupResolver_Query_ShouldCacheTrackerAnswers(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	answer := &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "hosts"}
+	facilitator := newCountingFacilitator(map[string]*lookup.LookupAnswer{"https://tracker": answer})
+	metrics := engine.NewTrackerMetrics()
+	resolver := engine.NewLookupResolverWithConfig(engine.LookupResolverConfig{Facilitator: facilitator, Metrics: metrics})
+	resolver.SetSLAPTrackers([]string{"https://tracker"})
+	question := &lookup.LookupQuestion{Service: "ls_slap", Query: json.RawMessage(`{"service":"ls_test"}`)}
+
+	// when:
+	first, err := resolver.Query(ctx, question)
+	require.NoError(t, err)
+	second, err := resolver.Query(ctx, question)
+	require.NoError(t, err)
+
+	// then:
+	require.Equal(t, answer, first)
+	require.Equal(t, answer, second)
+	require.Equal(t, 1, facilitator.callsTo("https://tracker"))
+	require.Equal(t, uint64(1), metrics.Snapshot()["https://tracker"].Calls)
+
+	// when:
+	resolver.SetSLAPTrackers([]string{"https://tracker", "https://other-tracker"})
+	_, err = resolver.Query(ctx, question)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 2, facilitator.callsTo("https://tracker"))
+}
+
+func TestLookupResolver_Query_ShouldCacheFailedQueries(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	facilitator := newCountingFacilitator(nil)
+	resolver := engine.NewLookupResolverWithConfig(engine.LookupResolverConfig{Facilitator: facilitator, Backoff: -1})
+	resolver.SetSLAPTrackers([]string{"https://tracker"})
+	question := &lookup.LookupQuestion{Service: "ls_slap", Query: json.RawMessage(`{"service":"ls_test"}`)}
+
+	// when:
+	_, firstErr := resolver.Query(ctx, question)
+	_, secondErr := resolver.Query(ctx, question)
+
+	// then:
+	require.Error(t, firstErr)
+	require.Equal(t, firstErr, secondErr)
+	require.Equal(t, 1, facilitator.callsTo("https://tracker"))
+}
+
+func TestLookupResolver_Query_ShouldBackOffFromUnreachableTrackers(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	answer := &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "hosts"}
+	facilitator := newCountingFacilitator(map[string]*lookup.LookupAnswer{"https://tracker": answer})
+	metrics := engine.NewTrackerMetrics()
+	resolver := engine.NewLookupResolverWithConfig(engine.LookupResolverConfig{
+		Facilitator:      facilitator,
+		Metrics:          metrics,
+		CacheTTL:         -1,
+		NegativeCacheTTL: -1,
+		Backoff:          time.Hour,
+	})
+	resolver.SetSLAPTrackers([]string{"https://tracker", "https://unreachable"})
+	question := &lookup.LookupQuestion{Service: "ls_slap", Query: json.RawMessage(`{"service":"ls_test"}`)}
+
+	// when:
+	for range 3 {
+		_, err := resolver.Query(ctx, question)
+		require.NoError(t, err)
+	}
+
+	// then:
+	require.Equal(t, 3, facilitator.callsTo("https://tracker"))
+	require.Equal(t, 1, facilitator.callsTo("https://unreachable"))
+	snapshot := metrics.Snapshot()
+	require.Equal(t, uint64(3), snapshot["https://tracker"].Calls)
+	require.Equal(t, uint64(1), snapshot["https://unreachable"].Calls)
+	require.Equal(t, uint64(1), snapshot["https://unreachable"].Errors)
+}
@@ -0,0 +1,100 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_RegisterLookupService_ShouldAdvertiseNewService(t *testing.T) {
+	// given
+	var advertised []*advertiser.AdvertisementData
+	sut := engine.NewEngine(engine.Engine{
+		HostingURL: "https://overlay.example.com",
+		Advertiser: fakeAdvertiser{
+			createAdvertisements: func(data []*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+				advertised = data
+				return overlay.TaggedBEEF{}, errCreateFailed
+			},
+		},
+	})
+
+	// when
+	err := sut.RegisterLookupService(context.Background(), "ls_new", fakeLookupService{})
+
+	// then
+	require.NoError(t, err)
+	require.Contains(t, sut.ListLookupServiceProviders(), "ls_new")
+	require.Equal(t, []*advertiser.AdvertisementData{{Protocol: "SLAP", TopicOrServiceName: "ls_new"}}, advertised)
+}
+
+func TestEngine_RegisterLookupService_ShouldFail_WhenAlreadyRegistered(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{
+		LookupServices: map[string]engine.LookupService{"ls_existing": fakeLookupService{}},
+	})
+
+	// when
+	err := sut.RegisterLookupService(context.Background(), "ls_existing", fakeLookupService{})
+
+	// then
+	require.ErrorIs(t, err, engine.ErrLookupServiceAlreadyRegistered)
+}
+
+func TestEngine_UnregisterLookupService_ShouldRevokeServiceAdvertisement(t *testing.T) {
+	// given
+	const hostingURL = "https://overlay.example.com"
+	advertisement := &advertiser.Advertisement{Protocol: "SLAP", TopicOrService: "ls_old", Domain: hostingURL}
+	var revoked []*advertiser.Advertisement
+	sut := engine.NewEngine(engine.Engine{
+		HostingURL:     hostingURL,
+		LookupServices: map[string]engine.LookupService{"ls_old": fakeLookupService{}},
+		Advertiser: fakeAdvertiser{
+			findAllAdvertisements: func(protocol overlay.Protocol) ([]*advertiser.Advertisement, error) {
+				if protocol == "SLAP" {
+					return []*advertiser.Advertisement{advertisement}, nil
+				}
+				return nil, nil
+			},
+			revokeAdvertisements: func(data []*advertiser.Advertisement) (overlay.TaggedBEEF, error) {
+				revoked = data
+				return overlay.TaggedBEEF{}, errRevokeFailed
+			},
+		},
+	})
+
+	// when
+	err := sut.UnregisterLookupService(context.Background(), "ls_old")
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, sut.ListLookupServiceProviders())
+	require.Equal(t, []*advertiser.Advertisement{advertisement}, revoked)
+}
+
+func TestEngine_UnregisterLookupService_ShouldFail_WhenNotRegistered(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{})
+
+	// when
+	err := sut.UnregisterLookupService(context.Background(), "ls_missing")
+
+	// then
+	require.ErrorIs(t, err, engine.ErrLookupServiceNotRegistered)
+}
+
+func TestEngine_AddLookupService_ShouldFail_WhenFactoryNotConfigured(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{})
+
+	// when
+	err := sut.AddLookupService(context.Background(), "ls_factory")
+
+	// then
+	require.ErrorIs(t, err, engine.ErrLookupServiceFactoryNotConfigured)
+	require.Empty(t, sut.ListLookupServiceProviders())
+}
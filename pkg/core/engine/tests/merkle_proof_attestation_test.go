@@ -0,0 +1,118 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuditStorage is a MerkleProofAuditStorage layered on top of fakeStorage.
+type fakeAuditStorage struct {
+	fakeStorage
+
+	records []*engine.MerkleProofRecord
+}
+
+func (f *fakeAuditStorage) InsertMerkleProofRecord(_ context.Context, record *engine.MerkleProofRecord) error {
+	f.records = append(f.records, record)
+	return nil
+}
+
+// fakeBlockHashTracker is a BlockHashTracker layered on top of fakeChainTracker.
+type fakeBlockHashTracker struct {
+	fakeChainTracker
+
+	hashes map[uint32]*chainhash.Hash
+}
+
+func (f fakeBlockHashTracker) BlockHashAtHeight(_ context.Context, height uint32) (*chainhash.Hash, error) {
+	return f.hashes[height], nil
+}
+
+func newAttestedProof(txid *chainhash.Hash) *transaction.MerklePath {
+	isTxid := true
+	return transaction.NewMerklePath(800000, [][]*transaction.PathElement{{{Offset: 0, Hash: txid, Txid: &isTxid}}})
+}
+
+func newAttestationEngine(storage *fakeAuditStorage, validRoot bool) *engine.Engine {
+	storage.findOutputsForTransaction = func(context.Context, *chainhash.Hash, bool) ([]*engine.Output, error) {
+		return nil, nil
+	}
+	return &engine.Engine{
+		Storage: storage,
+		ChainTracker: fakeBlockHashTracker{
+			fakeChainTracker: fakeChainTracker{
+				isValidRootForHeight: func(context.Context, *chainhash.Hash, uint32) (bool, error) { return validRoot, nil },
+			},
+			hashes: map[uint32]*chainhash.Hash{800000: {0xb1}},
+		},
+	}
+}
+
+func TestEngine_HandleAttestedMerkleProof_ShouldRecordTheProofSource(t *testing.T) {
+	// given:
+	txid := &chainhash.Hash{1}
+	storage := &fakeAuditStorage{}
+	sut := newAttestationEngine(storage, true)
+
+	// when:
+	err := sut.HandleAttestedMerkleProof(context.Background(), txid, newAttestedProof(txid), engine.MerkleProofAttestation{
+		BlockHash: &chainhash.Hash{0xb1},
+		Source:    "arc-taal",
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, storage.records, 1)
+	require.Equal(t, *txid, storage.records[0].Txid)
+	require.Equal(t, uint32(800000), storage.records[0].BlockHeight)
+	require.Equal(t, &chainhash.Hash{0xb1}, storage.records[0].BlockHash)
+	require.Equal(t, "arc-taal", storage.records[0].Source)
+}
+
+func TestEngine_HandleAttestedMerkleProof_ShouldRejectProofsOfAnotherChain(t *testing.T) {
+	tests := map[string]struct {
+		engine      func(storage *fakeAuditStorage) *engine.Engine
+		blockHash   *chainhash.Hash
+		expectedErr error
+	}{
+		"block hash is not the block at the proof height": {
+			engine:      func(storage *fakeAuditStorage) *engine.Engine { return newAttestationEngine(storage, true) },
+			blockHash:   &chainhash.Hash{0xb2},
+			expectedErr: engine.ErrBlockHashMismatch,
+		},
+		"root is not valid at the proof height": {
+			engine:      func(storage *fakeAuditStorage) *engine.Engine { return newAttestationEngine(storage, false) },
+			blockHash:   &chainhash.Hash{0xb1},
+			expectedErr: engine.ErrInvalidMerkleProof,
+		},
+		"no chain tracker": {
+			engine:      func(storage *fakeAuditStorage) *engine.Engine { return &engine.Engine{Storage: storage} },
+			blockHash:   &chainhash.Hash{0xb1},
+			expectedErr: engine.ErrChainTrackerRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			txid := &chainhash.Hash{1}
+			storage := &fakeAuditStorage{}
+			sut := tc.engine(storage)
+
+			// when:
+			err := sut.HandleAttestedMerkleProof(context.Background(), txid, newAttestedProof(txid), engine.MerkleProofAttestation{
+				BlockHash: tc.blockHash,
+				Source:    "arc-taal",
+			})
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Empty(t, storage.records)
+		})
+	}
+}
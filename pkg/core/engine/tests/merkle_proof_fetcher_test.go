@@ -0,0 +1,154 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUnminedStorage is an UnminedTransactionStorage layered on top of fakeStorage.
+type fakeUnminedStorage struct {
+	fakeStorage
+
+	unmined []*chainhash.Hash
+}
+
+func (f *fakeUnminedStorage) FindUnminedTransactions(_ context.Context, limit uint32) ([]*chainhash.Hash, error) {
+	return f.unmined[:min(int(limit), len(f.unmined))], nil
+}
+
+// fakeProofSource serves the proofs it holds and reports every other transaction as not mined yet.
+type fakeProofSource struct {
+	proofs  map[chainhash.Hash]*transaction.MerklePath
+	fetched []*chainhash.Hash
+}
+
+func (f *fakeProofSource) FetchMerkleProof(_ context.Context, txid *chainhash.Hash) (*transaction.MerklePath, error) {
+	f.fetched = append(f.fetched, txid)
+	if proof, ok := f.proofs[*txid]; ok {
+		return proof, nil
+	}
+	return nil, engine.ErrMerkleProofNotFound
+}
+
+func TestEngine_FetchMerkleProofs_ShouldApplyProofsOfTransactionsUnminedForMinBlocks(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	mined, unmined := &chainhash.Hash{1}, &chainhash.Hash{2}
+	isTxid := true
+	source := &fakeProofSource{proofs: map[chainhash.Hash]*transaction.MerklePath{
+		*mined: transaction.NewMerklePath(800000, [][]*transaction.PathElement{{{Offset: 0, Hash: mined, Txid: &isTxid}}}),
+	}}
+
+	var applied []*chainhash.Hash
+	height := uint32(800000)
+	sut := &engine.Engine{
+		Storage: &fakeUnminedStorage{
+			fakeStorage: fakeStorage{findOutputsForTransaction: func(_ context.Context, txid *chainhash.Hash, _ bool) ([]*engine.Output, error) {
+				applied = append(applied, txid)
+				return nil, nil
+			}},
+			unmined: []*chainhash.Hash{mined, unmined},
+		},
+		ChainTracker: fakeChainTracker{
+			currentHeightFunc:    func(context.Context) (uint32, error) { return height, nil },
+			isValidRootForHeight: func(context.Context, *chainhash.Hash, uint32) (bool, error) { return true, nil },
+		},
+		ProofFetcher: &engine.MerkleProofFetcher{Source: source, MinBlocks: 2},
+	}
+
+	// when:
+	early, earlyErr := sut.FetchMerkleProofs(ctx)
+	height += 2
+	due, dueErr := sut.FetchMerkleProofs(ctx)
+
+	// then:
+	require.NoError(t, earlyErr)
+	require.Equal(t, &engine.ProofFetchReport{Scanned: 2, Pending: 2}, early)
+
+	require.NoError(t, dueErr)
+	require.Equal(t, &engine.ProofFetchReport{Scanned: 2, Fetched: []*chainhash.Hash{mined}, Pending: 1}, due)
+	require.Equal(t, []*chainhash.Hash{mined, unmined}, source.fetched)
+	require.Equal(t, []*chainhash.Hash{mined}, applied)
+}
+
+func TestEngine_FetchMerkleProofs_ShouldReturnError_WhenNotConfigured(t *testing.T) {
+	tests := map[string]struct {
+		engine      *engine.Engine
+		expectedErr error
+	}{
+		"no proof fetcher": {
+			engine:      &engine.Engine{Storage: &fakeUnminedStorage{}},
+			expectedErr: engine.ErrProofFetcherNotConfigured,
+		},
+		"storage cannot find unmined transactions": {
+			engine:      &engine.Engine{Storage: fakeStorage{}, ProofFetcher: &engine.MerkleProofFetcher{}},
+			expectedErr: engine.ErrUnminedLookupNotSupported,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			report, err := tc.engine.FetchMerkleProofs(context.Background())
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, report)
+		})
+	}
+}
+
+func TestNewMerkleProofSource_ShouldConvertWhatsOnChainTSCProofs(t *testing.T) {
+	// given:
+	txid := &chainhash.Hash{3}
+	sibling := &chainhash.Hash{4}
+	woc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "woc-key", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/tx/" + txid.String() + "/proof/tsc":
+			_, _ = w.Write([]byte(`[{"index":1,"txOrId":"` + txid.String() + `","target":"blockhash","nodes":["` + sibling.String() + `","*"]}]`))
+		case "/block/hash/blockhash":
+			_, _ = w.Write([]byte(`{"height":800123}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer woc.Close()
+
+	sut, err := engine.NewMerkleProofSource(engine.MerkleProofFetcherConfig{
+		Source: engine.MerkleProofSourceWhatsOnChain,
+		URL:    woc.URL,
+		APIKey: "woc-key",
+	})
+	require.NoError(t, err)
+
+	// when:
+	proof, proofErr := sut.FetchMerkleProof(context.Background(), txid)
+	_, missingErr := sut.FetchMerkleProof(context.Background(), sibling)
+
+	// then:
+	require.NoError(t, proofErr)
+	require.ErrorIs(t, missingErr, engine.ErrMerkleProofNotFound)
+	require.Equal(t, uint32(800123), proof.BlockHeight)
+
+	parent := transaction.MerkleTreeParent(sibling, txid)
+	root, err := proof.ComputeRoot(txid)
+	require.NoError(t, err)
+	require.Equal(t, transaction.MerkleTreeParent(parent, parent), root)
+}
+
+func TestNewMerkleProofSource_ShouldReturnError_WhenSourceIsUnknown(t *testing.T) {
+	// when:
+	source, err := engine.NewMerkleProofSource(engine.MerkleProofFetcherConfig{Source: "carrier-pigeon"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownMerkleProofSource)
+	require.Nil(t, source)
+}
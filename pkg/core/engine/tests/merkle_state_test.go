@@ -0,0 +1,198 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const merkleStateTopic = "tm_merkle_state"
+
+// newMerkleStateEngine returns an engine admitting every output of merkleStateTopic into a MemoryStorage,
+// verifying proofs against a tracker on top of block 800000.
+func newMerkleStateEngine(t *testing.T, cfg *engine.MerkleStateConfig) (*engine.Engine, *testharness.MemoryStorage, *testutil.ChainTracker, *testutil.Builder) {
+	t.Helper()
+	tracker := testutil.NewChainTracker(800000)
+	storage := testharness.NewMemoryStorage()
+	sut := engine.NewEngine(engine.Engine{
+		Managers:     map[string]engine.TopicManager{merkleStateTopic: &testharness.TopicManager{Topic: merkleStateTopic}},
+		Storage:      storage,
+		ChainTracker: tracker,
+		MerkleStates: cfg,
+	})
+	return sut, storage, tracker, testutil.NewBuilder(t, tracker)
+}
+
+func submitMerkleStateTx(t *testing.T, sut *engine.Engine, tx *transaction.Transaction) *transaction.Outpoint {
+	t.Helper()
+	steak, err := sut.Submit(context.Background(), overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{merkleStateTopic}}, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	testutil.RequireAdmitted(t, steak, merkleStateTopic, 0)
+	return &transaction.Outpoint{Txid: *tx.TxID(), Index: 0}
+}
+
+func findMerkleStateOutput(t *testing.T, storage engine.Storage, outpoint *transaction.Outpoint) *engine.Output {
+	t.Helper()
+	topic := merkleStateTopic
+	output, err := storage.FindOutput(context.Background(), outpoint, &topic, nil, true)
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	return output
+}
+
+func TestMerkleState_CanTransitionTo(t *testing.T) {
+	tests := map[string]struct {
+		from, to engine.MerkleState
+		expected bool
+	}{
+		"unmined to mined":       {from: engine.MerkleStateUnmined, to: engine.MerkleStateMined, expected: true},
+		"mined to invalidated":   {from: engine.MerkleStateMined, to: engine.MerkleStateInvalidated, expected: true},
+		"mined to immutable":     {from: engine.MerkleStateMined, to: engine.MerkleStateImmutable, expected: true},
+		"invalidated to mined":   {from: engine.MerkleStateInvalidated, to: engine.MerkleStateMined, expected: true},
+		"unmined to immutable":   {from: engine.MerkleStateUnmined, to: engine.MerkleStateImmutable},
+		"invalidated to unmined": {from: engine.MerkleStateInvalidated, to: engine.MerkleStateUnmined},
+		"immutable to mined":     {from: engine.MerkleStateImmutable, to: engine.MerkleStateMined},
+		"immutable to unmined":   {from: engine.MerkleStateImmutable, to: engine.MerkleStateUnmined},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+}
+
+func TestEngine_Submit_ShouldRecordMerkleState(t *testing.T) {
+	// given:
+	sut, storage, _, builder := newMerkleStateEngine(t, nil)
+	parent := builder.Mined(800002, 1000)
+
+	// when:
+	mined := submitMerkleStateTx(t, sut, builder.Mined(800001, 1000))
+	unmined := submitMerkleStateTx(t, sut, builder.Spend([]testutil.Input{{Tx: parent}}, 1000))
+
+	// then:
+	require.Equal(t, engine.MerkleStateMined, findMerkleStateOutput(t, storage, mined).MerkleState)
+	require.Equal(t, engine.MerkleStateUnmined, findMerkleStateOutput(t, storage, unmined).MerkleState)
+}
+
+func TestEngine_HandleNewMerkleProof_ShouldMoveUnminedOutputsToMined(t *testing.T) {
+	// given:
+	sut, storage, _, builder := newMerkleStateEngine(t, nil)
+	chain := builder.Chain(2)
+	outpoint := submitMerkleStateTx(t, sut, chain[1])
+	builder.Mine(800002, chain[1])
+
+	// when:
+	err := sut.HandleNewMerkleProof(context.Background(), chain[1].TxID(), chain[1].MerklePath)
+
+	// then:
+	require.NoError(t, err)
+	output := findMerkleStateOutput(t, storage, outpoint)
+	require.Equal(t, engine.MerkleStateMined, output.MerkleState)
+	require.Equal(t, uint32(800002), output.BlockHeight)
+}
+
+func TestEngine_SyncInvalidatedOutputs_ShouldInvalidateReorgedOutputs_UntilANewProofArrives(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, _, builder := newMerkleStateEngine(t, nil)
+	tx := builder.Mined(800001, 1000)
+	outpoint := submitMerkleStateTx(t, sut, tx)
+	builder.Mine(800001, builder.Spend(nil, 1)) // a reorg replaces the block mining tx
+
+	// when:
+	report, err := sut.SyncInvalidatedOutputs(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Checked)
+	require.Equal(t, []*transaction.Outpoint{outpoint}, report.Invalidated)
+	require.Empty(t, report.Immutable)
+	output := findMerkleStateOutput(t, storage, outpoint)
+	require.Equal(t, engine.MerkleStateInvalidated, output.MerkleState)
+	require.Zero(t, output.BlockHeight, "invalidated outputs must have their proof fetched again")
+	status, err := sut.GetOutputStatus(ctx, outpoint, merkleStateTopic)
+	require.NoError(t, err)
+	require.Equal(t, engine.MerkleStateInvalidated, status.MerkleState)
+
+	// when:
+	builder.Mine(800002, tx, builder.Spend(nil, 2))
+	err = sut.HandleNewMerkleProof(ctx, tx.TxID(), tx.MerklePath)
+
+	// then:
+	require.NoError(t, err)
+	output = findMerkleStateOutput(t, storage, outpoint)
+	require.Equal(t, engine.MerkleStateMined, output.MerkleState)
+	require.Equal(t, uint32(800002), output.BlockHeight)
+}
+
+func TestEngine_SyncInvalidatedOutputs_ShouldMakeConfirmedOutputsImmutable(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut, storage, tracker, builder := newMerkleStateEngine(t, &engine.MerkleStateConfig{ImmutableConfirmations: 3})
+	tx := builder.Mined(800001, 1000)
+	beef := transaction.NewBeefV2()
+	_, err := beef.MergeTransaction(tx)
+	require.NoError(t, err)
+	_, err = beef.MergeTransaction(builder.Spend(nil, 1, 1, 1, 1))
+	require.NoError(t, err)
+	beefBytes, err := beef.Bytes()
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *tx.TxID(), Index: 0}
+	require.NoError(t, storage.InsertOutput(ctx, &engine.Output{
+		Outpoint:    *outpoint,
+		Topic:       merkleStateTopic,
+		BlockHeight: 800001,
+		Beef:        beefBytes,
+		MerkleState: engine.MerkleStateMined,
+	}))
+	tracker.SetHeight(800002)
+
+	// when:
+	report, err := sut.SyncInvalidatedOutputs(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Checked)
+	require.Empty(t, report.Immutable, "outputs confirmed by fewer blocks than configured must stay mined")
+	require.Equal(t, engine.MerkleStateMined, findMerkleStateOutput(t, storage, outpoint).MerkleState)
+
+	// when:
+	tracker.SetHeight(800003)
+	report, err = sut.SyncInvalidatedOutputs(ctx)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{outpoint}, report.Immutable)
+	output := findMerkleStateOutput(t, storage, outpoint)
+	require.Equal(t, engine.MerkleStateImmutable, output.MerkleState)
+	require.Equal(t, testutil.AtomicBEEF(t, tx), output.Beef, "the BEEF of immutable outputs must be trimmed to their transaction and its proof")
+
+	// when:
+	builder.Mine(800004, tx, builder.Spend(nil, 2))
+	err = sut.HandleNewMerkleProof(ctx, tx.TxID(), tx.MerklePath)
+
+	// then:
+	require.NoError(t, err)
+	output = findMerkleStateOutput(t, storage, outpoint)
+	require.Equal(t, engine.MerkleStateImmutable, output.MerkleState)
+	require.Equal(t, uint32(800001), output.BlockHeight, "the proof of immutable outputs must not be updated")
+}
+
+func TestEngine_SyncInvalidatedOutputs_ShouldFail_WhenStorageDoesNotTrackMerkleStates(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}, ChainTracker: testutil.NewChainTracker(800000)}
+
+	// when:
+	report, err := sut.SyncInvalidatedOutputs(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrMerkleStatesNotSupported)
+	require.Nil(t, report)
+}
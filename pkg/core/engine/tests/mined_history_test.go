@@ -0,0 +1,76 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newMinedHistoryEngine returns an engine whose test-topic requires mined history.
+func newMinedHistoryEngine() *engine.Engine {
+	broadcastFails := false
+	sut := newDeadLetterEngine(newDeadLetterStorage(), &broadcastFails)
+	sut.SyncConfiguration = map[string]engine.SyncConfiguration{
+		"test-topic": {RequireMinedHistory: true},
+	}
+	return sut
+}
+
+func TestEngine_Submit_ShouldRejectUnminedHistoricalSubmission_WhenTopicRequiresMinedHistory(t *testing.T) {
+	// given:
+	sut := newMinedHistoryEngine()
+
+	// when:
+	steak, err := sut.Submit(context.Background(), overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}, engine.SubmitModeHistorical, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnminedHistoricalSubmission)
+	require.Nil(t, steak)
+}
+
+func TestEngine_Submit_ShouldAcceptMinedHistoricalSubmission_WhenTopicRequiresMinedHistory(t *testing.T) {
+	// given:
+	sut := newMinedHistoryEngine()
+
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+	tx.MerklePath = transaction.NewMerklePath(100, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: tx.TxID(), Txid: ptr(true)},
+	}})
+	beef, err := transaction.NewBeefFromTransaction(tx)
+	require.NoError(t, err)
+	beefBytes, err := beef.AtomicBytes(tx.TxID())
+	require.NoError(t, err)
+
+	// when:
+	steak, err := sut.Submit(context.Background(), overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   beefBytes,
+	}, engine.SubmitModeHistorical, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+}
+
+func TestEngine_Submit_ShouldAcceptUnminedCurrentSubmission_WhenTopicRequiresMinedHistory(t *testing.T) {
+	// given:
+	sut := newMinedHistoryEngine()
+
+	// when:
+	_, err := sut.Submit(context.Background(), overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+}
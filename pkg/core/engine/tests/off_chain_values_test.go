@@ -0,0 +1,155 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Submit_ShouldStoreAndForwardOffChainValues(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	offChainValues := []byte("encrypted payload")
+	broadcastFails := false
+	storage := newDeadLetterStorage()
+	var inserted *engine.Output
+	storage.insertOutputFunc = func(_ context.Context, output *engine.Output) error {
+		inserted = output
+		return nil
+	}
+	lookupService := &admissionRecordingLookupService{}
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	sut.Managers["test-topic"] = newTaggingManager(nil, nil)
+	sut.LookupServices = map[string]engine.LookupService{"ls_test": lookupService}
+
+	// when:
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t), OffChainValues: offChainValues}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.NotNil(t, inserted)
+	require.Equal(t, offChainValues, inserted.OffChainValues)
+	require.NotNil(t, lookupService.admitted)
+	require.Equal(t, offChainValues, lookupService.admitted.OffChainValues)
+}
+
+func TestEngine_Lookup_ShouldReturnOffChainValues_WhenHydratedOutputsCarryThem(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	metadata := map[string]string{"ticker": "TEST"}
+	offChainValues := []byte("encrypted payload")
+	withValues := &transaction.Outpoint{Txid: fakeTxID(t), Index: 0}
+	tagged := &transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+	sut := &engine.Engine{
+		LookupServices: map[string]engine.LookupService{
+			"test": fakeLookupService{
+				lookupFunc: func(_ context.Context, _ *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+					return &lookup.LookupAnswer{
+						Type:     lookup.AnswerTypeFormula,
+						Formulas: []lookup.LookupFormula{{Outpoint: withValues}, {Outpoint: tagged}},
+					}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				output := &engine.Output{Outpoint: *outpoint, Beef: []byte("beef")}
+				if *outpoint == *withValues {
+					output.OffChainValues = offChainValues
+				} else {
+					output.Metadata = metadata
+				}
+				return output, nil
+			},
+		},
+	}
+
+	// when:
+	answer, err := sut.Lookup(ctx, &lookup.LookupQuestion{Service: "test"})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, answer.Outputs, 2)
+	require.Equal(t, engine.LookupOutputDetails{
+		Metadata:       engine.LookupOutputMetadata{nil, metadata},
+		OffChainValues: [][]byte{offChainValues, nil},
+	}, answer.Result)
+
+	details, ok := engine.LookupAnswerDetails(answer)
+	require.True(t, ok)
+	require.Equal(t, offChainValues, details.OffChainValues[0])
+	require.Equal(t, metadata, details.Metadata[1])
+}
+
+func TestLookupAnswerDetails_ShouldAcceptMetadataResults(t *testing.T) {
+	// given:
+	metadata := map[string]string{"ticker": "TEST"}
+	answer := &lookup.LookupAnswer{
+		Type:    lookup.AnswerTypeOutputList,
+		Outputs: []*lookup.OutputListItem{{OutputIndex: 0}, {OutputIndex: 1}},
+		Result:  engine.LookupOutputMetadata{metadata, nil},
+	}
+
+	// when:
+	details, ok := engine.LookupAnswerDetails(answer)
+	_, mismatched := engine.LookupAnswerDetails(&lookup.LookupAnswer{Outputs: answer.Outputs, Result: engine.LookupOutputMetadata{metadata}})
+	_, freeform := engine.LookupAnswerDetails(&lookup.LookupAnswer{Outputs: answer.Outputs, Result: "freeform"})
+
+	// then:
+	require.True(t, ok)
+	require.Equal(t, engine.LookupOutputDetails{Metadata: engine.LookupOutputMetadata{metadata, nil}, OffChainValues: [][]byte{nil, nil}}, details)
+	require.False(t, mismatched)
+	require.False(t, freeform)
+}
+
+func TestEngine_ProvideForeignGASPNode_ShouldIncludeOffChainValues(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	offChainValues := []byte("encrypted payload")
+	sut := &engine.Engine{
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{Beef: createDummyBEEF(t), OffChainValues: offChainValues}, nil
+			},
+		},
+	}
+
+	// when:
+	node, err := sut.ProvideForeignGASPNode(ctx, &transaction.Outpoint{}, &transaction.Outpoint{Index: 1}, "test-topic", false)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, offChainValues, node.OffChainValues)
+}
+
+func TestOverlayGASPStorage_FinalizeGraph_ShouldSubmitOffChainValuesOfNodes(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	offChainValues := []byte("encrypted payload")
+	storage := &fakeBatchStorage{fakeStorage: unbatchedStorage(t)}
+	sut := engine.NewOverlayGASPStorage("test-topic", newBatchingEngine(storage), nil)
+
+	parent := newMinedTx(1)
+	child := newSpendingTx(&script.Script{script.OpTRUE}, parent)
+	graphID := &transaction.Outpoint{Txid: *child.TxID(), Index: 0}
+	proof := parent.MerklePath.Hex()
+	require.NoError(t, sut.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: child.Hex(), OffChainValues: offChainValues}, nil))
+	require.NoError(t, sut.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: parent.Hex(), OutputIndex: 0, Proof: &proof}, graphID))
+
+	// when:
+	err := sut.FinalizeGraph(ctx, graphID)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, storage.outputBatches, 1)
+	require.Len(t, storage.outputBatches[0], 2)
+	require.Nil(t, storage.outputBatches[0][0].OffChainValues)
+	require.Equal(t, offChainValues, storage.outputBatches[0][1].OffChainValues)
+}
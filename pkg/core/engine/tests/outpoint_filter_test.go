@@ -0,0 +1,197 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func filterOutpoint(n int) *transaction.Outpoint {
+	var seed [8]byte
+	binary.LittleEndian.PutUint64(seed[:], uint64(n))                                        //nolint:gosec // test index is never negative
+	return &transaction.Outpoint{Txid: chainhash.DoubleHashH(seed[:]), Index: uint32(n % 4)} //nolint:gosec // bounded by the modulo
+}
+
+// utxoStorage returns a storage holding the given unspent outputs of a topic, scored by their position.
+func utxoStorage(outpoints ...*transaction.Outpoint) fakeStorage {
+	return fakeStorage{
+		findUTXOsForTopicFunc: func(_ context.Context, _ string, since float64, limit uint32, _ bool) ([]*engine.Output, error) {
+			var page []*engine.Output
+			for i, outpoint := range outpoints {
+				if score := float64(i + 1); score >= since && uint32(len(page)) < limit { //nolint:gosec // test pages are small
+					page = append(page, &engine.Output{Outpoint: *outpoint, Score: score})
+				}
+			}
+			return page, nil
+		},
+	}
+}
+
+func TestOutpointFilter_ShouldNeverRuleOutStoredOrAddedOutputs(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	stored := make([]*transaction.Outpoint, 0, 2500)
+	for i := range 2500 {
+		stored = append(stored, filterOutpoint(i))
+	}
+	added := filterOutpoint(50000)
+	sut := engine.NewOutpointFilter(engine.OutpointFilterConfig{ExpectedOutputs: 5000})
+
+	// when:
+	unbuilt := sut.MayContain("tm_a", filterOutpoint(100000))
+	err := sut.Build(ctx, utxoStorage(stored...), "tm_a")
+	sut.Add("tm_a", added)
+
+	// then:
+	require.True(t, unbuilt, "topics without a built filter must not rule out any output")
+	require.NoError(t, err)
+	require.True(t, sut.Built("tm_a"))
+	require.True(t, sut.MayContain("tm_a", added))
+	for _, outpoint := range stored {
+		require.True(t, sut.MayContain("tm_a", outpoint), "stored output %s ruled out", outpoint)
+	}
+
+	falsePositives := 0
+	for i := 100000; i < 110000; i++ {
+		if sut.MayContain("tm_a", filterOutpoint(i)) {
+			falsePositives++
+		}
+	}
+	require.Less(t, falsePositives, 300, "false positive rate far above the configured one")
+
+	metrics := sut.Metrics()
+	require.Equal(t, uint64(1), metrics.Builds)
+	require.Equal(t, uint64(2501), metrics.Topics["tm_a"].Outputs)
+	require.Equal(t, uint64(10000-falsePositives), metrics.Skipped)
+}
+
+func TestOutpointFilter_ShouldKeepOutputsAddedWhileBuilding(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	added := filterOutpoint(1)
+	sut := engine.NewOutpointFilter(engine.OutpointFilterConfig{})
+	storage := fakeStorage{
+		findUTXOsForTopicFunc: func(_ context.Context, _ string, _ float64, _ uint32, _ bool) ([]*engine.Output, error) {
+			sut.Add("tm_a", added)
+			return nil, nil
+		},
+	}
+
+	// when:
+	err := sut.Build(ctx, storage, "tm_a")
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, sut.MayContain("tm_a", added))
+}
+
+func TestOutpointFilter_ShouldNotBuild_WhenPagingStalls(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewOutpointFilter(engine.OutpointFilterConfig{})
+	storage := fakeStorage{
+		findUTXOsForTopicFunc: func(_ context.Context, _ string, _ float64, limit uint32, _ bool) ([]*engine.Output, error) {
+			unscored := make([]*engine.Output, limit)
+			for i := range unscored {
+				unscored[i] = &engine.Output{Outpoint: *filterOutpoint(i)}
+			}
+			return unscored, nil
+		},
+	}
+
+	// when:
+	err := sut.Build(ctx, storage, "tm_a")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrOutpointFilterStalled)
+	require.False(t, sut.Built("tm_a"))
+	require.True(t, sut.MayContain("tm_a", filterOutpoint(5000)))
+}
+
+// newFilteredSubmitEngine returns an engine admitting the first output of every transaction submitted to
+// test-topic, counting the FindOutputs calls made to its storage.
+func newFilteredSubmitEngine(findOutputsCalls *atomic.Int32) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, _ string, _ float64, _ uint32, _ bool) ([]*engine.Output, error) {
+				return nil, nil
+			},
+			findOutputsFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				findOutputsCalls.Add(1)
+				return make([]*engine.Output, len(outpoints)), nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		OutpointFilter: engine.NewOutpointFilter(engine.OutpointFilterConfig{}),
+	}
+}
+
+func TestEngine_Submit_ShouldNotLookUpInputs_WhenFilterRulesThemOut(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	var findOutputsCalls atomic.Int32
+	sut := newFilteredSubmitEngine(&findOutputsCalls)
+	require.NoError(t, sut.OutpointFilter.Build(ctx, sut.Storage, "test-topic"))
+
+	tx := newSpendingTx(&script.Script{script.OpTRUE}, newMinedTx(1))
+	beef, err := tx.AtomicBEEF(false)
+	require.NoError(t, err)
+
+	// when:
+	_, err = sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: beef}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, int32(0), findOutputsCalls.Load())
+	require.True(t, sut.OutpointFilter.MayContain("test-topic", &transaction.Outpoint{Txid: *tx.TxID(), Index: 0}))
+}
+
+func TestEngine_Submit_ShouldLookUpInputsOfUnminedTransactions_WhenConflictsAreDetected(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	var findOutputsCalls atomic.Int32
+	sut := newFilteredSubmitEngine(&findOutputsCalls)
+	sut.ConflictPolicy = engine.ConflictPolicyRejectNew
+	require.NoError(t, sut.OutpointFilter.Build(ctx, sut.Storage, "test-topic"))
+
+	beef, err := newSpendingTx(&script.Script{script.OpTRUE}, newMinedTx(1)).AtomicBEEF(false)
+	require.NoError(t, err)
+
+	// when:
+	_, err = sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: beef}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, int32(1), findOutputsCalls.Load())
+}
@@ -0,0 +1,67 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_HasOutputs_ShouldReportExistenceInOrder(t *testing.T) {
+	// given:
+	outpoints := []*transaction.Outpoint{
+		{Txid: fakeTxID(t), Index: 0},
+		{Txid: fakeTxID(t), Index: 1},
+		{Txid: fakeTxID(t), Index: 2},
+	}
+	var calls int
+	var spentFilter *bool
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		Storage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, actual []*transaction.Outpoint, _ string, spent *bool, _ bool) ([]*engine.Output, error) {
+				calls++
+				spentFilter = spent
+				require.Equal(t, outpoints, actual)
+				return []*engine.Output{{}, nil, {}}, nil
+			},
+		},
+	}
+
+	// when:
+	exists, err := sut.HasOutputs(context.Background(), outpoints, "test-topic", true)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false, true}, exists)
+	require.Equal(t, 1, calls)
+	require.NotNil(t, spentFilter)
+	require.False(t, *spentFilter)
+}
+
+func TestEngine_HasOutputs_ShouldFail_WhenTooManyOutpoints(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}}}
+	outpoints := make([]*transaction.Outpoint, engine.MaxOutpointsPerExistenceCheck+1)
+
+	// when:
+	exists, err := sut.HasOutputs(context.Background(), outpoints, "test-topic", false)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrTooManyOutpoints)
+	require.Nil(t, exists)
+}
+
+func TestEngine_HasOutputs_ShouldFail_WhenTopicUnknown(t *testing.T) {
+	// given:
+	sut := &engine.Engine{}
+
+	// when:
+	exists, err := sut.HasOutputs(context.Background(), nil, "unknown-topic", false)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, exists)
+}
@@ -0,0 +1,139 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newOutputGraphEngine returns an engine hosting the topic "test" whose storage holds the outputs.
+func newOutputGraphEngine(outputs ...*engine.Output) *engine.Engine {
+	stored := make(map[transaction.Outpoint]*engine.Output, len(outputs))
+	for _, output := range outputs {
+		stored[output.Outpoint] = output
+	}
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test": fakeManager{}},
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return stored[*outpoint], nil
+			},
+			findOutputsFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				found := make([]*engine.Output, len(outpoints))
+				for i, outpoint := range outpoints {
+					found[i] = stored[*outpoint]
+				}
+				return found, nil
+			},
+		},
+	}
+}
+
+func TestEngine_OutputGraph(t *testing.T) {
+	pruned := &transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}
+	parent := &transaction.Outpoint{Txid: chainhash.Hash{2}, Index: 0}
+	root := &transaction.Outpoint{Txid: chainhash.Hash{3}, Index: 1}
+	child := &transaction.Outpoint{Txid: chainhash.Hash{4}, Index: 0}
+	outputs := []*engine.Output{
+		{Outpoint: *parent, Spent: true, OutputsConsumed: []*transaction.Outpoint{pruned}, ConsumedBy: []*transaction.Outpoint{root}},
+		{Outpoint: *root, Spent: true, BlockHeight: 100, OutputsConsumed: []*transaction.Outpoint{parent}, ConsumedBy: []*transaction.Outpoint{child}},
+		{Outpoint: *child, OutputsConsumed: []*transaction.Outpoint{root}},
+	}
+
+	tests := map[string]struct {
+		depth         uint32
+		expectedNodes []*engine.OutputGraphNode
+		expectedEdges []engine.OutputGraphEdge
+	}{
+		"bounded by depth": {
+			depth: 1,
+			expectedNodes: []*engine.OutputGraphNode{
+				{Outpoint: *root, Spent: true, BlockHeight: 100},
+				{Outpoint: *parent, Depth: 1, Spent: true},
+				{Outpoint: *child, Depth: 1},
+			},
+			expectedEdges: []engine.OutputGraphEdge{{From: *parent, To: *root}, {From: *root, To: *child}},
+		},
+		"reports pruned outputs as missing": {
+			expectedNodes: []*engine.OutputGraphNode{
+				{Outpoint: *root, Spent: true, BlockHeight: 100},
+				{Outpoint: *parent, Depth: 1, Spent: true},
+				{Outpoint: *child, Depth: 1},
+				{Outpoint: *pruned, Depth: 2, Missing: true},
+			},
+			expectedEdges: []engine.OutputGraphEdge{{From: *parent, To: *root}, {From: *root, To: *child}, {From: *pruned, To: *parent}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := newOutputGraphEngine(outputs...)
+
+			// when:
+			graph, err := sut.OutputGraph(context.Background(), root, "test", tc.depth)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, *root, graph.Root)
+			require.Equal(t, tc.expectedNodes, graph.Nodes)
+			require.Equal(t, tc.expectedEdges, graph.Edges)
+			require.False(t, graph.Truncated)
+		})
+	}
+}
+
+func TestEngine_OutputGraph_ShouldRejectInvalidRequests(t *testing.T) {
+	root := &transaction.Outpoint{Txid: chainhash.Hash{3}, Index: 1}
+
+	tests := map[string]struct {
+		topic       string
+		depth       uint32
+		expectedErr error
+	}{
+		"unknown topic":   {topic: "unknown", expectedErr: engine.ErrUnknownTopic},
+		"missing output":  {topic: "test", expectedErr: engine.ErrMissingOutput},
+		"depth too large": {topic: "test", depth: engine.MaxOutputGraphDepth + 1, expectedErr: engine.ErrInvalidOutputGraphDepth},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := newOutputGraphEngine()
+
+			// when:
+			graph, err := sut.OutputGraph(context.Background(), root, tc.topic, tc.depth)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, graph)
+		})
+	}
+}
+
+func TestOutputGraph_DOT(t *testing.T) {
+	// given:
+	root := transaction.Outpoint{Txid: chainhash.Hash{3}, Index: 1}
+	pruned := transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}
+	graph := &engine.OutputGraph{
+		Root:  root,
+		Topic: "test",
+		Nodes: []*engine.OutputGraphNode{{Outpoint: root, Spent: true}, {Outpoint: pruned, Depth: 1, Missing: true}},
+		Edges: []engine.OutputGraphEdge{{From: pruned, To: root}},
+	}
+
+	// when:
+	dot := graph.DOT()
+
+	// then:
+	expected := "digraph \"test\" {\n" +
+		"  \"" + root.String() + "\" [style=bold, color=gray];\n" +
+		"  \"" + pruned.String() + "\" [style=dashed];\n" +
+		"  \"" + pruned.String() + "\" -> \"" + root.String() + "\";\n" +
+		"}\n"
+	require.Equal(t, expected, dot)
+}
@@ -0,0 +1,174 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// taggingManager is a TopicManager admitting the first output and tagging it with metadata.
+type taggingManager struct {
+	fakeManager
+	metadata map[uint32]map[string]string
+	err      error
+}
+
+func (m taggingManager) IdentifyOutputMetadata(_ context.Context, _ []byte, admit overlay.AdmittanceInstructions) (map[uint32]map[string]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	tagged := make(map[uint32]map[string]string, len(admit.OutputsToAdmit))
+	for _, vout := range admit.OutputsToAdmit {
+		tagged[vout] = m.metadata[vout]
+	}
+	return tagged, nil
+}
+
+// admissionRecordingLookupService is a LookupService recording the last admitted output.
+type admissionRecordingLookupService struct {
+	fakeLookupService
+	admitted *engine.OutputAdmittedByTopic
+}
+
+func (s *admissionRecordingLookupService) OutputAdmittedByTopic(_ context.Context, payload *engine.OutputAdmittedByTopic) error {
+	s.admitted = payload
+	return nil
+}
+
+func (s *admissionRecordingLookupService) OutputSpent(_ context.Context, _ *engine.OutputSpent) error {
+	return nil
+}
+
+func (s *admissionRecordingLookupService) OutputNoLongerRetainedInHistory(_ context.Context, _ *transaction.Outpoint, _ string) error {
+	return nil
+}
+
+func newTaggingManager(metadata map[uint32]map[string]string, err error) taggingManager {
+	return taggingManager{
+		fakeManager: fakeManager{
+			identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+				return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+			},
+		},
+		metadata: metadata,
+		err:      err,
+	}
+}
+
+func TestEngine_Submit_ShouldStoreAndForwardOutputMetadata(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	metadata := map[string]string{"ticker": "TEST", "amount": "1000"}
+	broadcastFails := false
+	storage := newDeadLetterStorage()
+	var inserted *engine.Output
+	storage.insertOutputFunc = func(_ context.Context, output *engine.Output) error {
+		inserted = output
+		return nil
+	}
+	lookupService := &admissionRecordingLookupService{}
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	sut.Managers["test-topic"] = newTaggingManager(map[uint32]map[string]string{0: metadata}, nil)
+	sut.LookupServices = map[string]engine.LookupService{"ls_test": lookupService}
+
+	// when:
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.NotNil(t, inserted)
+	require.Equal(t, metadata, inserted.Metadata)
+	require.NotNil(t, lookupService.admitted)
+	require.Equal(t, metadata, lookupService.admitted.Metadata)
+}
+
+func TestEngine_Submit_ShouldFail_WhenOutputMetadataCannotBeIdentified(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	identifyErr := errors.New("cannot parse token")
+	broadcastFails := false
+	storage := newDeadLetterStorage()
+	inserted := false
+	storage.insertOutputFunc = func(_ context.Context, _ *engine.Output) error {
+		inserted = true
+		return nil
+	}
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+	sut.Managers["test-topic"] = newTaggingManager(nil, identifyErr)
+
+	// when:
+	_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, identifyErr)
+	require.False(t, inserted)
+}
+
+func TestEngine_Lookup_ShouldReturnOutputMetadata_WhenHydratedOutputsAreTagged(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	metadata := map[string]string{"ticker": "TEST"}
+	tagged := &transaction.Outpoint{Txid: fakeTxID(t), Index: 0}
+	untagged := &transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+	sut := &engine.Engine{
+		LookupServices: map[string]engine.LookupService{
+			"test": fakeLookupService{
+				lookupFunc: func(_ context.Context, _ *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+					return &lookup.LookupAnswer{
+						Type:     lookup.AnswerTypeFormula,
+						Formulas: []lookup.LookupFormula{{Outpoint: tagged}, {Outpoint: untagged}},
+					}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				output := &engine.Output{Outpoint: *outpoint, Beef: []byte("beef")}
+				if *outpoint == *tagged {
+					output.Metadata = metadata
+				}
+				return output, nil
+			},
+		},
+	}
+
+	// when:
+	answer, err := sut.Lookup(ctx, &lookup.LookupQuestion{Service: "test"})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, answer.Outputs, 2)
+	require.Equal(t, engine.LookupOutputMetadata{metadata, nil}, answer.Result)
+}
+
+func TestEngine_GetOutputStatus_ShouldReturnOutputMetadata(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	metadata := map[string]string{"ticker": "TEST"}
+	outpoint := &transaction.Outpoint{Txid: fakeTxID(t), Index: 0}
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		Storage: fakeStorage{
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return true, nil
+			},
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{Outpoint: *outpoint, Metadata: metadata}, nil
+			},
+		},
+	}
+
+	// when:
+	status, err := sut.GetOutputStatus(ctx, outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, status.Admitted)
+	require.Equal(t, metadata, status.Metadata)
+}
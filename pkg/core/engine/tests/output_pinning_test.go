@@ -0,0 +1,176 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePinStorage is an in-memory OutputPinStorage layered on top of fakeStorage.
+type fakePinStorage struct {
+	fakeStorage
+
+	pins map[string]bool
+}
+
+func (f *fakePinStorage) UpdateOutputPinned(_ context.Context, outpoint *transaction.Outpoint, topic string, pinned bool) error {
+	f.pins[outpoint.String()+"|"+topic] = pinned
+	return nil
+}
+
+func TestEngine_PinOutput_ShouldUpdateStorage(t *testing.T) {
+	// given:
+	outpoint := &transaction.Outpoint{Index: 1}
+	storage := &fakePinStorage{
+		fakeStorage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+		},
+		pins: make(map[string]bool),
+	}
+	sut := &engine.Engine{Storage: storage}
+
+	// when:
+	err := sut.PinOutput(context.Background(), outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, storage.pins[outpoint.String()+"|test-topic"])
+}
+
+func TestEngine_UnpinOutput_ShouldUpdateStorage(t *testing.T) {
+	// given:
+	outpoint := &transaction.Outpoint{Index: 1}
+	storage := &fakePinStorage{
+		fakeStorage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{Pinned: true}, nil
+			},
+		},
+		pins: map[string]bool{outpoint.String() + "|test-topic": true},
+	}
+	sut := &engine.Engine{Storage: storage}
+
+	// when:
+	err := sut.UnpinOutput(context.Background(), outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.False(t, storage.pins[outpoint.String()+"|test-topic"])
+}
+
+func TestEngine_PinOutput_ShouldReplicateThePinToStandbys(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	outpoint := &transaction.Outpoint{Index: 1}
+	log := engine.NewReplicationLog(10)
+	primary := &fakePinStorage{
+		fakeStorage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+		},
+		pins: make(map[string]bool),
+	}
+	standby := &fakePinStorage{pins: make(map[string]bool)}
+	sut := &engine.Engine{Storage: engine.NewReplicatingStorage(primary, log)}
+
+	// when:
+	err := sut.PinOutput(ctx, outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	mutations, err := log.Next(ctx, log.Epoch(), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, mutations, 1)
+	require.Equal(t, engine.MutationUpdateOutputPinned, mutations[0].Op)
+
+	require.NoError(t, mutations[0].Apply(ctx, standby))
+	require.True(t, standby.pins[outpoint.String()+"|test-topic"])
+}
+
+func TestEngine_PinOutput_ShouldFail_WhenOutputNotFound(t *testing.T) {
+	// given:
+	sut := &engine.Engine{
+		Storage: &fakePinStorage{
+			fakeStorage: fakeStorage{
+				findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+					return nil, nil
+				},
+			},
+		},
+	}
+
+	// when:
+	err := sut.PinOutput(context.Background(), &transaction.Outpoint{}, "test-topic")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrMissingOutput)
+}
+
+func TestEngine_PinOutput_ShouldFail_WhenStorageCannotPin(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}}
+
+	// when:
+	err := sut.PinOutput(context.Background(), &transaction.Outpoint{}, "test-topic")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrOutputPinningNotSupported)
+}
+
+func TestEngine_Submit_ShouldKeepPinnedOutputs_WhenTopicStopsRetainingThem(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				t.Fatal("pinned output must not be deleted")
+				return nil
+			},
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{Pinned: true}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+	}
+	taggedBEEF := overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}
+
+	// when:
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+}
@@ -0,0 +1,96 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newOutputStatusEngine returns an engine hosting test-topic whose storage reports the given applied state and output.
+func newOutputStatusEngine(applied bool, output *engine.Output) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		Storage: fakeStorage{
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return applied, nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return output, nil
+			},
+		},
+	}
+}
+
+func TestEngine_GetOutputStatus_ShouldReportAdmittedOutput(t *testing.T) {
+	// given:
+	outpoint := transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+	consumer := &transaction.Outpoint{Txid: fakeTxID(t), Index: 0}
+	sut := newOutputStatusEngine(true, &engine.Output{
+		Outpoint:    outpoint,
+		Topic:       "test-topic",
+		Spent:       true,
+		BlockHeight: 800000,
+		BlockIdx:    7,
+		ConsumedBy:  []*transaction.Outpoint{consumer},
+	})
+
+	// when:
+	status, err := sut.GetOutputStatus(context.Background(), &outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.OutputStatus{
+		Outpoint:           outpoint,
+		Topic:              "test-topic",
+		TransactionApplied: true,
+		Admitted:           true,
+		Spent:              true,
+		BlockHeight:        800000,
+		BlockIdx:           7,
+		ConsumedBy:         []*transaction.Outpoint{consumer},
+		MerkleState:        engine.MerkleStateMined,
+	}, status)
+}
+
+func TestEngine_GetOutputStatus_ShouldReportUnadmittedOutput_WhenTransactionWasApplied(t *testing.T) {
+	// given:
+	outpoint := transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+	sut := newOutputStatusEngine(true, nil)
+
+	// when:
+	status, err := sut.GetOutputStatus(context.Background(), &outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.OutputStatus{Outpoint: outpoint, Topic: "test-topic", TransactionApplied: true}, status)
+}
+
+func TestEngine_GetOutputStatus_ShouldReportUnknownTransaction(t *testing.T) {
+	// given:
+	outpoint := transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+	sut := newOutputStatusEngine(false, nil)
+
+	// when:
+	status, err := sut.GetOutputStatus(context.Background(), &outpoint, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.OutputStatus{Outpoint: outpoint, Topic: "test-topic"}, status)
+}
+
+func TestEngine_GetOutputStatus_ShouldFail_WhenTopicUnknown(t *testing.T) {
+	// given:
+	outpoint := transaction.Outpoint{Txid: fakeTxID(t), Index: 1}
+	sut := newOutputStatusEngine(false, nil)
+
+	// when:
+	status, err := sut.GetOutputStatus(context.Background(), &outpoint, "unknown-topic")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, status)
+}
@@ -0,0 +1,104 @@
+package engine_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_VerifyPayment_ShouldVerifyAndBroadcastPayment(t *testing.T) {
+	// given:
+	var calls atomic.Int32
+	var broadcasted atomic.Int32
+	payee := &script.Script{script.OpTRUE, script.OpTRUE}
+	payment := newSpendingTx(payee, newMinedTx(1))
+	beef, err := payment.AtomicBEEF(false)
+	require.NoError(t, err)
+
+	sut := &engine.Engine{
+		ChainTracker: countingChainTracker(true, &calls),
+		Broadcaster: fakeBroadcasterFail{
+			broadcastCtxFunc: func(_ context.Context, _ *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+				broadcasted.Add(1)
+				return &transaction.BroadcastSuccess{}, nil
+			},
+		},
+	}
+
+	// when:
+	outpoint, err := sut.VerifyPayment(context.Background(), beef, payee, 900)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &transaction.Outpoint{Txid: *payment.TxID(), Index: 0}, outpoint)
+	require.Equal(t, int32(1), calls.Load())
+	require.Equal(t, int32(1), broadcasted.Load())
+}
+
+func TestEngine_VerifyPayment_ShouldRejectInvalidPayments(t *testing.T) {
+	payee := &script.Script{script.OpTRUE, script.OpTRUE}
+
+	tests := map[string]struct {
+		beef          func(t *testing.T) []byte
+		satoshis      uint64
+		valid         bool
+		expectedError error
+	}{
+		"unparsable transaction": {
+			beef:          func(_ *testing.T) []byte { return []byte{1, 2, 3} },
+			satoshis:      900,
+			valid:         true,
+			expectedError: engine.ErrInvalidPaymentTransaction,
+		},
+		"output paying less than required": {
+			beef: func(t *testing.T) []byte {
+				beef, err := newSpendingTx(payee, newMinedTx(1)).AtomicBEEF(false)
+				require.NoError(t, err)
+				return beef
+			},
+			satoshis:      901,
+			valid:         true,
+			expectedError: engine.ErrInsufficientPayment,
+		},
+		"no output paying to the locking script": {
+			beef: func(t *testing.T) []byte {
+				beef, err := newSpendingTx(&script.Script{script.OpTRUE}, newMinedTx(1)).AtomicBEEF(false)
+				require.NoError(t, err)
+				return beef
+			},
+			satoshis:      900,
+			valid:         true,
+			expectedError: engine.ErrInsufficientPayment,
+		},
+		"ancestor with a merkle path rejected by the chain tracker": {
+			beef: func(t *testing.T) []byte {
+				beef, err := newSpendingTx(payee, newMinedTx(1)).AtomicBEEF(false)
+				require.NoError(t, err)
+				return beef
+			},
+			satoshis:      900,
+			valid:         false,
+			expectedError: engine.ErrPaymentSPVFailed,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			var calls atomic.Int32
+			sut := &engine.Engine{ChainTracker: countingChainTracker(tc.valid, &calls)}
+
+			// when:
+			outpoint, err := sut.VerifyPayment(context.Background(), tc.beef(t), payee, tc.satoshis)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Nil(t, outpoint)
+		})
+	}
+}
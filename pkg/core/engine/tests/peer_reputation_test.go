@@ -0,0 +1,53 @@
+package engine_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerReputation_ShouldBlacklistPeer_WhenFailureThresholdReached(t *testing.T) {
+	// given:
+	sut := engine.NewPeerReputation(2, time.Hour)
+
+	// when:
+	sut.RecordFailure("https://broken.example.com", time.Second)
+	sut.RecordInvalidGraph("https://broken.example.com")
+
+	// then:
+	require.True(t, sut.IsBlacklisted("https://broken.example.com"))
+	require.Equal(t, []string{"https://good.example.com"}, sut.Prioritize([]string{"https://broken.example.com", "https://good.example.com"}))
+
+	stats := sut.Stats("https://broken.example.com")
+	require.Equal(t, 1, stats.Failures)
+	require.Equal(t, 1, stats.InvalidGraphs)
+}
+
+func TestPeerReputation_ShouldPrioritizePeersByScoreThenLatency(t *testing.T) {
+	// given:
+	sut := engine.NewPeerReputation(10, time.Hour)
+	sut.RecordFailure("https://flaky.example.com", time.Second)
+	sut.RecordSuccess("https://slow.example.com", 10*time.Second)
+	sut.RecordSuccess("https://fast.example.com", time.Second)
+
+	// when:
+	prioritized := sut.Prioritize([]string{"https://flaky.example.com", "https://slow.example.com", "https://fast.example.com"})
+
+	// then:
+	require.Equal(t, []string{"https://fast.example.com", "https://slow.example.com", "https://flaky.example.com"}, prioritized)
+}
+
+func TestPeerReputation_ShouldResetConsecutiveFailures_WhenSyncSucceeds(t *testing.T) {
+	// given:
+	sut := engine.NewPeerReputation(2, time.Hour)
+
+	// when:
+	sut.RecordFailure("https://peer.example.com", time.Second)
+	sut.RecordSuccess("https://peer.example.com", time.Second)
+	sut.RecordFailure("https://peer.example.com", time.Second)
+
+	// then:
+	require.False(t, sut.IsBlacklisted("https://peer.example.com"))
+}
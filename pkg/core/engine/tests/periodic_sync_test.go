@@ -0,0 +1,106 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/stretchr/testify/require"
+)
+
+var errLookupUnavailable = errors.New("lookup unavailable")
+
+// countingLookupResolver counts the SHIP queries made by GASP syncs, failing every one of them.
+type countingLookupResolver struct {
+	queries atomic.Int32
+}
+
+func (r *countingLookupResolver) SLAPTrackers() []string { return nil }
+
+func (r *countingLookupResolver) SetSLAPTrackers([]string) {}
+
+func (r *countingLookupResolver) Query(context.Context, *lookup.LookupQuestion) (*lookup.LookupAnswer, error) {
+	r.queries.Add(1)
+	return nil, errLookupUnavailable
+}
+
+func TestEngine_RunPeriodicSync_ShouldSyncAtTheChangedInterval(t *testing.T) {
+	// given:
+	resolver := &countingLookupResolver{}
+	sut := &engine.Engine{
+		Managers:          map[string]engine.TopicManager{"tm_a": fakeManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{"tm_a": {Type: engine.SyncConfigurationSHIP}},
+		LookupResolver:    resolver,
+		PeriodicSync:      engine.NewPeriodicSync(0),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sut.RunPeriodicSync(ctx)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	paused := resolver.queries.Load()
+
+	// when:
+	err := sut.SetSyncInterval(time.Millisecond)
+
+	// then:
+	require.NoError(t, err)
+	require.Zero(t, paused, "a zero interval must pause the syncs")
+	require.Eventually(t, func() bool { return resolver.queries.Load() >= 2 }, time.Second, time.Millisecond)
+	require.Equal(t, time.Millisecond, sut.PeriodicSync.Interval())
+
+	cancel()
+	<-done
+}
+
+func TestEngine_SetSyncInterval_ShouldFail_WhenPeriodicSyncIsNotConfigured(t *testing.T) {
+	// given:
+	sut := &engine.Engine{}
+
+	// when:
+	err := sut.SetSyncInterval(time.Minute)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrPeriodicSyncNotConfigured)
+}
+
+func TestEngine_SetSyncPeers_ShouldReplaceThePeersOfTheTopic(t *testing.T) {
+	// given:
+	original := map[string]engine.SyncConfiguration{"tm_a": {Type: engine.SyncConfigurationPeers, Peers: []string{"https://a"}, Concurrency: 4}}
+	sut := &engine.Engine{
+		Managers:          map[string]engine.TopicManager{"tm_a": fakeManager{}},
+		SyncConfiguration: original,
+	}
+	remotes := map[string]engine.GASPRemoteConfig{"https://b": {Timeout: time.Second}}
+
+	// when:
+	err := sut.SetSyncPeers("tm_a", []string{"https://b"})
+	sut.SetPeerRemotes(remotes)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, engine.SyncConfiguration{
+		Type:        engine.SyncConfigurationPeers,
+		Peers:       []string{"https://b"},
+		Concurrency: 4,
+		PeerRemotes: remotes,
+	}, sut.SyncConfiguration["tm_a"])
+	require.Equal(t, []string{"https://a"}, original["tm_a"].Peers, "running syncs may still range over the previous configuration")
+}
+
+func TestEngine_SetSyncPeers_ShouldFail_WhenTopicIsNotRegistered(t *testing.T) {
+	// given:
+	sut := &engine.Engine{}
+
+	// when:
+	err := sut.SetSyncPeers("tm_unknown", []string{"https://a"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrTopicManagerNotRegistered)
+}
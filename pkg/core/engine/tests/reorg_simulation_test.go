@@ -0,0 +1,105 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHeightStorage is an OutputsByBlockHeightStorage layered on top of fakeStorage.
+type fakeHeightStorage struct {
+	fakeStorage
+
+	outputs []*engine.Output
+}
+
+func (f fakeHeightStorage) FindOutputsSinceBlockHeight(_ context.Context, blockHeight uint32, _ bool) ([]*engine.Output, error) {
+	var outputs []*engine.Output
+	for _, output := range f.outputs {
+		if output.BlockHeight >= blockHeight {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+func TestEngine_SimulateReorg_ShouldReportInvalidatedOutputs(t *testing.T) {
+	// given:
+	txA, txB, txC := chainhash.Hash{1}, chainhash.Hash{2}, chainhash.Hash{3}
+	sut := &engine.Engine{
+		ChainTracker: fakeChainTracker{
+			currentHeightFunc: func(_ context.Context) (uint32, error) { return 100, nil },
+		},
+		Storage: fakeHeightStorage{
+			outputs: []*engine.Output{
+				{Outpoint: transaction.Outpoint{Txid: txA, Index: 0}, Topic: "tm_a", BlockHeight: 99, Beef: make([]byte, 10)},
+				{Outpoint: transaction.Outpoint{Txid: txA, Index: 1}, Topic: "tm_a", BlockHeight: 99, Beef: make([]byte, 10), Spent: true},
+				{Outpoint: transaction.Outpoint{Txid: txA, Index: 0}, Topic: "tm_b", BlockHeight: 99, Beef: make([]byte, 10)},
+				{Outpoint: transaction.Outpoint{Txid: txB, Index: 0}, Topic: "tm_b", BlockHeight: 100, Beef: make([]byte, 5)},
+				{Outpoint: transaction.Outpoint{Txid: txC, Index: 0}, Topic: "tm_b", BlockHeight: 98, Beef: make([]byte, 7)},
+			},
+		},
+	}
+
+	// when:
+	simulation, err := sut.SimulateReorg(context.Background(), 2)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, uint32(99), simulation.ForkHeight)
+	require.False(t, simulation.UTXOsOnly)
+	require.Len(t, simulation.InvalidatedOutputs, 4)
+	require.Equal(t, map[string]*engine.ReorgTopicImpact{
+		"tm_a": {Outputs: 2, Transactions: 1},
+		"tm_b": {Outputs: 2, Transactions: 2},
+	}, simulation.AffectedTopics)
+	require.Equal(t, 2, simulation.ResyncTransactions)
+	require.Equal(t, 15, simulation.ResyncBytes)
+}
+
+func TestEngine_SimulateReorg_ShouldFallBackToUTXOs(t *testing.T) {
+	// given:
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"tm_a": fakeTopicManager{}},
+		ChainTracker: fakeChainTracker{
+			currentHeightFunc: func(_ context.Context) (uint32, error) { return 100, nil },
+		},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, topic string, _ float64, _ uint32, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{
+					{Topic: topic, BlockHeight: 100},
+					{Topic: topic, BlockHeight: 0},
+				}, nil
+			},
+		},
+	}
+
+	// when:
+	simulation, err := sut.SimulateReorg(context.Background(), 1)
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, simulation.UTXOsOnly)
+	require.Len(t, simulation.InvalidatedOutputs, 1)
+}
+
+func TestEngine_SimulateReorg_ShouldFail_WhenDepthInvalid(t *testing.T) {
+	// given:
+	sut := &engine.Engine{
+		ChainTracker: fakeChainTracker{
+			currentHeightFunc: func(_ context.Context) (uint32, error) { return 10, nil },
+		},
+	}
+
+	// when:
+	_, zeroErr := sut.SimulateReorg(context.Background(), 0)
+	_, deepErr := sut.SimulateReorg(context.Background(), 12)
+
+	// then:
+	require.ErrorIs(t, zeroErr, engine.ErrInvalidReorgDepth)
+	require.ErrorIs(t, deepErr, engine.ErrInvalidReorgDepth)
+}
@@ -0,0 +1,254 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReplicaStorage is an in-memory output store layered on top of fakeStorage, safe for concurrent use.
+type fakeReplicaStorage struct {
+	fakeStorage
+
+	mu      sync.Mutex
+	outputs map[transaction.Outpoint]*engine.Output
+}
+
+func newFakeReplicaStorage() *fakeReplicaStorage {
+	return &fakeReplicaStorage{outputs: make(map[transaction.Outpoint]*engine.Output)}
+}
+
+func (f *fakeReplicaStorage) InsertOutput(_ context.Context, output *engine.Output) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.outputs[output.Outpoint] = output
+	return nil
+}
+
+func (f *fakeReplicaStorage) MarkUTXOsAsSpent(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, outpoint := range outpoints {
+		if output, ok := f.outputs[*outpoint]; ok {
+			output.Spent = true
+		}
+	}
+	return nil
+}
+
+func (f *fakeReplicaStorage) DeleteOutput(_ context.Context, outpoint *transaction.Outpoint, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.outputs, *outpoint)
+	return nil
+}
+
+func (f *fakeReplicaStorage) output(outpoint transaction.Outpoint) *engine.Output {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.outputs[outpoint]
+}
+
+func replicatedOutput(t *testing.T, index uint32) *engine.Output {
+	t.Helper()
+	txid, err := chainhash.NewHashFromHex("03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119")
+	require.NoError(t, err)
+	return &engine.Output{
+		Outpoint: transaction.Outpoint{Txid: *txid, Index: index},
+		Topic:    "test-topic",
+		Script:   script.NewFromBytes([]byte{script.OpTRUE}),
+		Satoshis: 1000,
+		Beef:     []byte{1, 2, 3},
+	}
+}
+
+func TestReplicatingStorage_ShouldRecordSuccessfulWritesOnly(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	log := engine.NewReplicationLog(10)
+	sut := engine.NewReplicatingStorage(fakeStorage{
+		insertOutputFunc: func(_ context.Context, _ *engine.Output) error { return nil },
+		deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error { return errInsertFailed },
+	}, log)
+	output := replicatedOutput(t, 0)
+
+	// when:
+	insertErr := sut.InsertOutput(ctx, output)
+	deleteErr := sut.DeleteOutput(ctx, &output.Outpoint, output.Topic)
+
+	// then:
+	require.NoError(t, insertErr)
+	require.ErrorIs(t, deleteErr, errInsertFailed)
+	require.Equal(t, uint64(1), log.LastSeq())
+}
+
+func TestReplicationLog_Next_ShouldReturnGap_WhenMutationsAreNoLongerAvailable(t *testing.T) {
+	// given:
+	log := engine.NewReplicationLog(2)
+	for range 3 {
+		log.Append(&engine.Mutation{Op: engine.MutationUpdateLastInteraction})
+	}
+
+	tests := map[string]struct {
+		epoch string
+		since uint64
+	}{
+		"evicted mutations":    {epoch: log.Epoch(), since: 0},
+		"another epoch":        {epoch: "restarted", since: 3},
+		"ahead of the primary": {epoch: log.Epoch(), since: 4},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			batch, err := log.Next(context.Background(), tc.epoch, tc.since, 10)
+
+			// then:
+			require.ErrorIs(t, err, engine.ErrReplicationGap)
+			require.Empty(t, batch)
+		})
+	}
+}
+
+func TestReplicationFollower_ShouldApplyPrimaryWritesUntilPromoted(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	primaryStorage := engine.NewReplicatingStorage(newFakeReplicaStorage(), engine.NewReplicationLog(10))
+	primary := &engine.Engine{Storage: primaryStorage}
+	standbyStorage := newFakeReplicaStorage()
+	follower := engine.NewReplicationFollower(standbyStorage, primary)
+	standby := &engine.Engine{Storage: standbyStorage, Standby: follower}
+
+	kept, spent := replicatedOutput(t, 0), replicatedOutput(t, 1)
+	require.NoError(t, primaryStorage.InsertOutput(ctx, kept))
+	require.NoError(t, primaryStorage.InsertOutput(ctx, spent))
+
+	done := make(chan error, 1)
+	go func() { done <- follower.Run(ctx) }()
+
+	// when:
+	require.NoError(t, primaryStorage.MarkUTXOsAsSpent(ctx, []*transaction.Outpoint{&spent.Outpoint}, spent.Topic, &kept.Outpoint.Txid))
+	require.Eventually(t, func() bool {
+		_, seq := follower.Position()
+		return seq == 3
+	}, time.Second, 5*time.Millisecond)
+
+	_, submitErr := standby.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}}, engine.SubmitModeCurrent, nil)
+	promoteErr := standby.PromoteStandby(ctx)
+
+	// then:
+	var readOnlyErr *engine.StorageReadOnlyError
+	require.ErrorAs(t, submitErr, &readOnlyErr)
+	require.ErrorIs(t, readOnlyErr.Cause, engine.ErrStandbyNode)
+
+	require.NoError(t, promoteErr)
+	require.NoError(t, <-done)
+	require.True(t, follower.Promoted())
+	require.NotNil(t, standbyStorage.output(kept.Outpoint))
+	require.True(t, standbyStorage.output(spent.Outpoint).Spent)
+}
+
+func TestEngine_PromoteStandby_ShouldReturnError_WhenEngineIsNotStandby(t *testing.T) {
+	// given:
+	sut := &engine.Engine{}
+
+	// when:
+	err := sut.PromoteStandby(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrNotStandby)
+}
+
+func TestReplicationLog_Next_ShouldReturnBatchesInOrder(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	log := engine.NewReplicationLog(10)
+	for range 3 {
+		log.Append(&engine.Mutation{Op: engine.MutationUpdateLastInteraction})
+	}
+
+	// when:
+	first, firstErr := log.Next(ctx, "", 0, 2)
+	second, secondErr := log.Next(ctx, log.Epoch(), 2, 2)
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	idle, idleErr := log.Next(timeout, log.Epoch(), 3, 2)
+
+	// then:
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, idleErr)
+	require.Len(t, first, 2)
+	require.Equal(t, uint64(1), first[0].Seq)
+	require.Equal(t, uint64(2), first[1].Seq)
+	require.Len(t, second, 1)
+	require.Equal(t, uint64(3), second[0].Seq)
+	require.Empty(t, idle)
+}
+
+func TestEngine_NextMutations_ShouldReturnError_WhenStorageIsNotReplicating(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}}
+
+	// when:
+	batch, err := sut.NextMutations(context.Background(), "", 0)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrReplicationNotConfigured)
+	require.Empty(t, batch)
+}
+
+func TestHTTPReplicationSource_ShouldDecodeStreamedMutations(t *testing.T) {
+	// given:
+	output := replicatedOutput(t, 0)
+	log := engine.NewReplicationLog(10)
+	log.Append(&engine.Mutation{Op: engine.MutationInsertOutput, Output: output})
+
+	var authorization string
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.URL.Query().Get("since") != "0" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batch, _ := log.Next(r.Context(), r.URL.Query().Get("epoch"), 0, 10)
+		encoder := json.NewEncoder(w)
+		for _, m := range batch {
+			_ = encoder.Encode(m)
+		}
+	}))
+	defer primary.Close()
+
+	sut := engine.NewHTTPReplicationSource(engine.StandbyConfig{PrimaryURL: primary.URL, Token: "replication-token"})
+	standbyStorage := newFakeReplicaStorage()
+
+	// when:
+	batch, err := sut.NextMutations(context.Background(), "", 0)
+	_, gapErr := sut.NextMutations(context.Background(), log.Epoch(), 1)
+
+	// then:
+	require.NoError(t, err)
+	require.ErrorIs(t, gapErr, engine.ErrReplicationGap)
+	require.Len(t, batch, 1)
+	require.Equal(t, log.Epoch(), batch[0].Epoch)
+	require.NoError(t, batch[0].Apply(context.Background(), standbyStorage))
+	require.Equal(t, "Bearer replication-token", authorization)
+
+	replicated := standbyStorage.output(output.Outpoint)
+	require.NotNil(t, replicated)
+	require.Equal(t, output.Satoshis, replicated.Satoshis)
+	require.Equal(t, output.Script.Bytes(), replicated.Script.Bytes())
+	require.Equal(t, output.Beef, replicated.Beef)
+}
@@ -0,0 +1,200 @@
+package engine_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePruneStorage is an OutputPruneStorage layered on top of fakeStorage that records the criteria it was called with.
+type fakePruneStorage struct {
+	fakeStorage
+
+	mu       sync.Mutex
+	criteria []*engine.PruneCriteria
+	pruned   map[string][]*transaction.Outpoint
+}
+
+func (f *fakePruneStorage) PruneOutputs(_ context.Context, criteria *engine.PruneCriteria) ([]*transaction.Outpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.criteria = append(f.criteria, criteria)
+	return f.pruned[criteria.Topic], nil
+}
+
+func (f *fakePruneStorage) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.criteria)
+}
+
+// fakeEvictionLookupService records the outpoints it was asked to evict.
+type fakeEvictionLookupService struct {
+	fakeLookupService
+
+	evicted []*transaction.Outpoint
+}
+
+func (f *fakeEvictionLookupService) OutputEvicted(_ context.Context, outpoint *transaction.Outpoint) error {
+	f.evicted = append(f.evicted, outpoint)
+	return nil
+}
+
+func TestEngine_PruneOutputs_ShouldApplyTopicPoliciesAndNotifyLookupServices(t *testing.T) {
+	// given:
+	pruned := &transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}
+	storage := &fakePruneStorage{
+		fakeStorage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return make([]*engine.Output, len(outpoints)), nil
+			},
+		},
+		pruned: map[string][]*transaction.Outpoint{"tm_b": {pruned}},
+	}
+	lookupService := &fakeEvictionLookupService{}
+	sut := &engine.Engine{
+		Storage:        storage,
+		LookupServices: map[string]engine.LookupService{"ls_b": lookupService},
+		Retention: &engine.RetentionConfig{Topics: map[string]engine.RetentionPolicy{
+			"tm_a": {MaxAge: 24 * time.Hour, MaxHistoryDepth: 3},
+			"tm_b": {UnspentOnly: true},
+			"tm_c": {},
+		}},
+	}
+	before := time.Now()
+
+	// when:
+	reports, err := sut.PruneOutputs(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*engine.PruneReport{
+		{Topic: "tm_a"},
+		{Topic: "tm_b", Pruned: []*transaction.Outpoint{pruned}},
+	}, reports)
+
+	require.Len(t, storage.criteria, 2)
+	require.Equal(t, "tm_a", storage.criteria[0].Topic)
+	require.False(t, storage.criteria[0].AllSpent)
+	require.Equal(t, 3, storage.criteria[0].MaxHistoryDepth)
+	require.WithinDuration(t, before.Add(-24*time.Hour), storage.criteria[0].ReceivedBefore, time.Second)
+	require.Equal(t, &engine.PruneCriteria{Topic: "tm_b", AllSpent: true}, storage.criteria[1])
+
+	require.Equal(t, []*transaction.Outpoint{pruned}, lookupService.evicted)
+}
+
+func TestEngine_PruneOutputs_ShouldNotEvictPinnedOutputs_ReportedAsPrunedByTheStorage(t *testing.T) {
+	// given:
+	pinned := &transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}
+	deleted := &transaction.Outpoint{Txid: chainhash.Hash{2}, Index: 0}
+	storage := &fakePruneStorage{
+		fakeStorage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, outpoints []*transaction.Outpoint, topic string, _ *bool, _ bool) ([]*engine.Output, error) {
+				require.Equal(t, []*transaction.Outpoint{pinned, deleted}, outpoints)
+				return []*engine.Output{{Outpoint: *pinned, Topic: topic, Pinned: true}, nil}, nil
+			},
+		},
+		pruned: map[string][]*transaction.Outpoint{"tm_a": {pinned, deleted}},
+	}
+	lookupService := &fakeEvictionLookupService{}
+	sut := &engine.Engine{
+		Storage:        storage,
+		LookupServices: map[string]engine.LookupService{"ls_a": lookupService},
+		Retention:      &engine.RetentionConfig{Topics: map[string]engine.RetentionPolicy{"tm_a": {UnspentOnly: true}}},
+	}
+
+	// when:
+	reports, err := sut.PruneOutputs(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*engine.PruneReport{{Topic: "tm_a", Pruned: []*transaction.Outpoint{deleted}}}, reports)
+	require.Equal(t, []*transaction.Outpoint{deleted}, lookupService.evicted)
+}
+
+func TestEngine_PruneOutputs_ShouldApplyTheLookupErrorPolicyOfOutputEvicted(t *testing.T) {
+	// given:
+	pruned := &transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}
+	storage := &fakePruneStorage{
+		fakeStorage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return make([]*engine.Output, len(outpoints)), nil
+			},
+		},
+		pruned: map[string][]*transaction.Outpoint{"tm_a": {pruned}},
+	}
+	sut := &engine.Engine{
+		Storage:        storage,
+		LookupServices: map[string]engine.LookupService{"ls_a": fakeLookupService{}}, // panics on OutputEvicted
+		Retention:      &engine.RetentionConfig{Topics: map[string]engine.RetentionPolicy{"tm_a": {UnspentOnly: true}}},
+		LookupNotifications: engine.NewLookupNotifications(engine.LookupNotificationsConfig{
+			Default: engine.LookupServicePolicy{Hooks: map[string]engine.LookupErrorPolicy{
+				engine.LookupHookOutputEvicted: engine.LookupErrorPolicyLogAndContinue,
+			}},
+		}),
+	}
+
+	// when:
+	reports, err := sut.PruneOutputs(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*engine.PruneReport{{Topic: "tm_a", Pruned: []*transaction.Outpoint{pruned}}}, reports)
+	metrics := sut.LookupNotifications.Metrics()
+	require.Equal(t, uint64(1), metrics.Panics)
+	require.Equal(t, uint64(1), metrics.Ignored)
+}
+
+func TestEngine_PruneOutputs_ShouldFail_WhenRetentionNotConfigured(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: &fakePruneStorage{}}
+
+	// when:
+	reports, err := sut.PruneOutputs(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrRetentionNotConfigured)
+	require.Nil(t, reports)
+}
+
+func TestEngine_PruneOutputs_ShouldFail_WhenStorageDoesNotSupportPruning(t *testing.T) {
+	// given:
+	sut := &engine.Engine{
+		Storage:   fakeStorage{},
+		Retention: &engine.RetentionConfig{Topics: map[string]engine.RetentionPolicy{"tm_a": {UnspentOnly: true}}},
+	}
+
+	// when:
+	reports, err := sut.PruneOutputs(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrPruningNotSupported)
+	require.Nil(t, reports)
+}
+
+func TestEngine_Start_ShouldRunPrunerOnInterval(t *testing.T) {
+	// given:
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	storage := &fakePruneStorage{}
+	sut := &engine.Engine{
+		Storage: storage,
+		Retention: &engine.RetentionConfig{
+			Interval: 5 * time.Millisecond,
+			Topics:   map[string]engine.RetentionPolicy{"tm_a": {UnspentOnly: true}},
+		},
+	}
+
+	// when:
+	require.NoError(t, sut.Start(ctx))
+
+	// then:
+	require.Eventually(t, func() bool { return storage.calls() >= 2 }, time.Second, time.Millisecond)
+	require.NoError(t, sut.Stop(context.Background()))
+}
@@ -0,0 +1,178 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const scoreTopic = "tm_scores"
+
+type scoreFixture struct {
+	storage *testharness.MemoryStorage
+	tracker *testutil.ChainTracker
+	builder *testutil.Builder
+	engine  engine.Engine
+}
+
+func newScoreFixture(t *testing.T, strategy engine.ScoreStrategy) *scoreFixture {
+	t.Helper()
+	tracker := testutil.NewChainTracker(800010)
+	f := &scoreFixture{
+		storage: testharness.NewMemoryStorage(),
+		tracker: tracker,
+		builder: testutil.NewBuilder(t, tracker),
+	}
+	f.engine = engine.Engine{
+		Managers:      map[string]engine.TopicManager{scoreTopic: &testharness.TopicManager{Topic: scoreTopic}},
+		Storage:       f.storage,
+		ChainTracker:  tracker,
+		ScoreStrategy: strategy,
+	}
+	return f
+}
+
+func (f *scoreFixture) submit(t *testing.T, sut *engine.Engine, txs ...*transaction.Transaction) {
+	t.Helper()
+	for _, tx := range txs {
+		taggedBEEF := overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{scoreTopic}}
+		_, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+		require.NoError(t, err)
+	}
+}
+
+func (f *scoreFixture) output(t *testing.T, tx *transaction.Transaction) *engine.Output {
+	t.Helper()
+	topic := scoreTopic
+	output, err := f.storage.FindOutput(context.Background(), &transaction.Outpoint{Txid: *tx.TxID()}, &topic, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	return output
+}
+
+func TestEngine_Submit_ShouldScoreOutputsByBlockPosition_WithTheHeightStrategy(t *testing.T) {
+	// given:
+	f := newScoreFixture(t, engine.HeightScoreStrategy{})
+	sut := engine.NewEngine(f.engine)
+	first := f.builder.Spend(nil, 1000)
+	second := f.builder.Spend(nil, 1000)
+	f.builder.Mine(800001, first, second)
+	unmined := f.builder.Spend([]testutil.Input{{Tx: f.builder.Mined(800000, 1000)}}, 900)
+
+	// when:
+	f.submit(t, sut, second, first, unmined)
+
+	// then:
+	require.InDelta(t, 800001, f.output(t, first).Score, 0)
+	require.InDelta(t, 800001+1.0/(1<<31), f.output(t, second).Score, 0)
+	require.InDelta(t, 800011, f.output(t, unmined).Score, 0, "unmined outputs score as the next block")
+
+	utxos, err := f.storage.FindUTXOsForTopic(context.Background(), scoreTopic, 0, 0, false)
+	require.NoError(t, err)
+	require.Len(t, utxos, 3)
+	require.Equal(t, *first.TxID(), utxos[0].Outpoint.Txid, "outputs page in block order, not in admission order")
+}
+
+func TestEngine_Submit_ShouldScoreOutputsByReceiveTime_WithTheTimestampStrategy(t *testing.T) {
+	// given:
+	f := newScoreFixture(t, engine.TimestampScoreStrategy{})
+	sut := engine.NewEngine(f.engine)
+	older := f.builder.Mined(800005, 1000)
+	newer := f.builder.Mined(800001, 1000)
+
+	// when:
+	f.submit(t, sut, older)
+	time.Sleep(time.Millisecond)
+	f.submit(t, sut, newer)
+
+	// then:
+	olderOutput, newerOutput := f.output(t, older), f.output(t, newer)
+	require.InDelta(t, float64(olderOutput.ReceivedAt.UnixMicro()), olderOutput.Score, 0)
+	require.InDelta(t, float64(newerOutput.ReceivedAt.UnixMicro()), newerOutput.Score, 0)
+	require.Less(t, olderOutput.Score, newerOutput.Score, "outputs page in admission order, not in block order")
+}
+
+func TestEngine_Submit_ShouldScoreUnminedOutputsAfterMinedOnes_WithTheHybridStrategy(t *testing.T) {
+	// given:
+	f := newScoreFixture(t, engine.HybridScoreStrategy{})
+	f.tracker.SetHeight(0) // the hybrid strategy never asks for the chain tip
+	sut := engine.NewEngine(f.engine)
+	mined := f.builder.Mined(800001, 1000)
+	unmined := f.builder.Spend([]testutil.Input{{Tx: f.builder.Mined(800000, 1000)}}, 900)
+	late := f.builder.Mined(800002, 1000)
+
+	// when:
+	f.submit(t, sut, mined, unmined, late)
+
+	// then:
+	unminedOutput := f.output(t, unmined)
+	require.InDelta(t, 800001, f.output(t, mined).Score, 0)
+	require.InDelta(t, 800002, f.output(t, late).Score, 0)
+	require.InDelta(t, float64(unminedOutput.ReceivedAt.UnixMicro())/1e6, unminedOutput.Score, 0)
+	require.Greater(t, unminedOutput.Score, f.output(t, late).Score)
+}
+
+func TestEngine_RescoreOutputs_ShouldMigrateStorageAssignedScores(t *testing.T) {
+	// given:
+	f := newScoreFixture(t, nil)
+	first := f.builder.Spend(nil, 1000)
+	second := f.builder.Spend(nil, 1000)
+	f.builder.Mine(800003, first)
+	f.builder.Mine(800002, second)
+	f.submit(t, engine.NewEngine(f.engine), first, second)
+	require.InDelta(t, 1, f.output(t, first).Score, 0, "the storage scores outputs in insertion order")
+
+	f.engine.ScoreStrategy = engine.HeightScoreStrategy{}
+	sut := engine.NewEngine(f.engine)
+
+	// when:
+	report, err := sut.RescoreOutputs(context.Background(), scoreTopic)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.RescoreReport{Topic: scoreTopic, Strategy: engine.ScoreStrategyHeight, Scanned: 2, Rescored: 2}, report)
+	require.InDelta(t, 800003, f.output(t, first).Score, 0)
+	require.InDelta(t, 800002, f.output(t, second).Score, 0)
+
+	// when:
+	again, err := sut.RescoreOutputs(context.Background(), scoreTopic)
+
+	// then:
+	require.NoError(t, err)
+	require.Zero(t, again.Rescored, "rescoring is idempotent")
+}
+
+func TestEngine_RescoreOutputs_ShouldFail_WhenNoStrategyIsConfigured(t *testing.T) {
+	// given:
+	f := newScoreFixture(t, nil)
+	sut := engine.NewEngine(f.engine)
+
+	// when:
+	report, err := sut.RescoreOutputs(context.Background(), scoreTopic)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrScoreStrategyNotConfigured)
+	require.Nil(t, report)
+}
+
+func TestNewScoreStrategy_ShouldResolveTheBuiltInStrategies(t *testing.T) {
+	for _, name := range []string{engine.ScoreStrategyHeight, engine.ScoreStrategyTimestamp, engine.ScoreStrategyHybrid} {
+		strategy, err := engine.NewScoreStrategy(name)
+		require.NoError(t, err)
+		require.Equal(t, name, strategy.Name())
+	}
+
+	none, err := engine.NewScoreStrategy("")
+	require.NoError(t, err)
+	require.Nil(t, none)
+
+	_, err = engine.NewScoreStrategy("random")
+	require.ErrorIs(t, err, engine.ErrUnknownScoreStrategy)
+}
@@ -0,0 +1,177 @@
+package engine_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/snapshot"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newSnapshotSourceEngine returns an engine storing two UTXOs of test-topic that consume a retained spent output.
+func newSnapshotSourceEngine(t *testing.T) (*engine.Engine, *engine.Output, []*engine.Output) {
+	t.Helper()
+
+	taggedBEEF, prevTxID := createDummyValidTaggedBEEF(t)
+	spender := parseBEEFToTx(t, taggedBEEF.Beef)
+	consumedOutpoint := &transaction.Outpoint{Txid: *prevTxID, Index: 0}
+	consumed := &engine.Output{Outpoint: *consumedOutpoint, Topic: "test-topic", Spent: true, Beef: createDummyBEEF(t), Score: 1}
+	utxos := []*engine.Output{
+		{Outpoint: transaction.Outpoint{Txid: *spender.TxID(), Index: 0}, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{consumedOutpoint}, Beef: createDummyBEEF(t), Score: 2, BlockHeight: 100},
+		{Outpoint: transaction.Outpoint{Txid: *spender.TxID(), Index: 1}, Topic: "test-topic", OutputsConsumed: []*transaction.Outpoint{consumedOutpoint}, Beef: createDummyBEEF(t), Score: 3},
+	}
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, _ string, since float64, _ uint32, includeBEEF bool) ([]*engine.Output, error) {
+				require.Zero(t, since)
+				require.True(t, includeBEEF)
+				return utxos, nil
+			},
+			findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				require.Equal(t, consumedOutpoint, outpoint)
+				return consumed, nil
+			},
+		},
+	}, consumed, utxos
+}
+
+func TestEngine_ExportSnapshot_ShouldWriteHistoryBeforeUTXOs(t *testing.T) {
+	// given:
+	sut, consumed, utxos := newSnapshotSourceEngine(t)
+	var buf bytes.Buffer
+
+	// when:
+	manifest, err := sut.ExportSnapshot(context.Background(), &buf, nil)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, float64(3), manifest.ScoreWatermark)
+	require.Len(t, manifest.Topics, 1)
+	require.Equal(t, uint64(3), manifest.Topics[0].Records)
+
+	reader, err := snapshot.NewReader(&buf)
+	require.NoError(t, err)
+	var records []*snapshot.Record
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+	require.Equal(t, []*snapshot.Record{
+		{Topic: "test-topic", Outpoint: consumed.Outpoint, Score: 1, Spent: true, BEEF: consumed.Beef},
+		{Topic: "test-topic", Outpoint: utxos[0].Outpoint, Score: 2, BlockHeight: 100, BEEF: utxos[0].Beef},
+		{Topic: "test-topic", Outpoint: utxos[1].Outpoint, Score: 3, BEEF: utxos[1].Beef},
+	}, records)
+}
+
+func TestEngine_ExportSnapshot_ShouldRejectUnknownTopic(t *testing.T) {
+	// given:
+	sut, _, _ := newSnapshotSourceEngine(t)
+	var buf bytes.Buffer
+
+	// when:
+	manifest, err := sut.ExportSnapshot(context.Background(), &buf, []string{"unknown-topic"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, manifest)
+	require.Zero(t, buf.Len())
+}
+
+func TestEngine_ImportSnapshot_ShouldSubmitRecordsAndRecordWatermark(t *testing.T) {
+	tests := map[string]struct {
+		source            string
+		storedInteraction float64
+		expectedSource    string
+		expectedUpdates   map[string]float64
+	}{
+		"records the watermark of the source": {
+			source:          "https://peer.example.com",
+			expectedSource:  "https://peer.example.com",
+			expectedUpdates: map[string]float64{"https://peer.example.com": 3},
+		},
+		"keeps a later interaction with the source": {
+			source:            "https://peer.example.com",
+			storedInteraction: 10,
+			expectedSource:    "https://peer.example.com",
+			expectedUpdates:   map[string]float64{},
+		},
+		"records no watermark without a source": {
+			expectedSource:  engine.OutputSourceSnapshot,
+			expectedUpdates: map[string]float64{},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			source, _, _ := newSnapshotSourceEngine(t)
+			var buf bytes.Buffer
+			_, err := source.ExportSnapshot(context.Background(), &buf, nil)
+			require.NoError(t, err)
+
+			var sources []string
+			updates := make(map[string]float64)
+			storage := newDeadLetterStorage()
+			storage.insertOutputFunc = func(_ context.Context, output *engine.Output) error {
+				sources = append(sources, output.Source)
+				return nil
+			}
+			storage.getLastInteractionFunc = func(_ context.Context, _, topic string) (float64, error) {
+				require.Equal(t, "test-topic", topic)
+				return tc.storedInteraction, nil
+			}
+			storage.updateLastInteractionFunc = func(_ context.Context, host, topic string, since float64) error {
+				require.Equal(t, "test-topic", topic)
+				updates[host] = since
+				return nil
+			}
+			broadcastFails := false
+			sut := newDeadLetterEngine(storage, &broadcastFails)
+
+			// when:
+			result, err := sut.ImportSnapshot(context.Background(), &buf, tc.source)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, map[string]int{"test-topic": 3}, result.Imported)
+			require.Equal(t, map[string]float64{"test-topic": 3}, result.Watermarks)
+			require.Equal(t, float64(3), result.Manifest.ScoreWatermark)
+			require.Equal(t, []string{tc.expectedSource, tc.expectedSource, tc.expectedSource}, sources)
+			require.Equal(t, tc.expectedUpdates, updates)
+		})
+	}
+}
+
+func TestEngine_ImportSnapshot_ShouldRecordNoWatermark_WhenSnapshotIsCorrupt(t *testing.T) {
+	// given:
+	source, _, _ := newSnapshotSourceEngine(t)
+	var buf bytes.Buffer
+	_, err := source.ExportSnapshot(context.Background(), &buf, nil)
+	require.NoError(t, err)
+	corrupt := buf.Bytes()
+	corrupt[len(corrupt)-1] ^= 0xff
+
+	storage := newDeadLetterStorage()
+	storage.updateLastInteractionFunc = func(_ context.Context, _, _ string, _ float64) error {
+		t.Fatal("unexpected last interaction update")
+		return nil
+	}
+	broadcastFails := false
+	sut := newDeadLetterEngine(storage, &broadcastFails)
+
+	// when:
+	result, err := sut.ImportSnapshot(context.Background(), bytes.NewReader(corrupt), "https://peer.example.com")
+
+	// then:
+	require.ErrorIs(t, err, snapshot.ErrChecksumMismatch)
+	require.Nil(t, result)
+}
@@ -0,0 +1,178 @@
+package engine_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSpendSource reports the spends it holds and every other outpoint as unspent.
+type fakeSpendSource struct {
+	spends  map[transaction.Outpoint]*engine.Spend
+	checked []*transaction.Outpoint
+}
+
+func (f *fakeSpendSource) FindSpend(_ context.Context, outpoint *transaction.Outpoint) (*engine.Spend, error) {
+	f.checked = append(f.checked, outpoint)
+	return f.spends[*outpoint], nil
+}
+
+func TestEngine_ReconcileSpends_ShouldApplyTopicPolicies(t *testing.T) {
+	// given:
+	spentA := engine.Output{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 0}, Topic: "tm_a", Score: 1}
+	unspentA := engine.Output{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{2}, Index: 0}, Topic: "tm_a", Score: 2}
+	spentB := engine.Output{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{3}, Index: 1}, Topic: "tm_b", Score: 1}
+	pinnedB := engine.Output{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{4}, Index: 0}, Topic: "tm_b", Score: 2, Pinned: true}
+	spend := &engine.Spend{Txid: chainhash.Hash{9}, InputIndex: 2}
+	source := &fakeSpendSource{spends: map[transaction.Outpoint]*engine.Spend{
+		spentA.Outpoint:  spend,
+		spentB.Outpoint:  spend,
+		pinnedB.Outpoint: spend,
+	}}
+
+	var marked, deleted []*transaction.Outpoint
+	var spentNotified []*engine.OutputSpent
+	lookupService := &fakeEvictionLookupService{fakeLookupService: fakeLookupService{
+		outputSpentFunc: func(_ context.Context, payload *engine.OutputSpent) error {
+			spentNotified = append(spentNotified, payload)
+			return nil
+		},
+	}}
+	sut := &engine.Engine{
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, topic string, since float64, _ uint32, _ bool) ([]*engine.Output, error) {
+				require.Zero(t, since)
+				if topic == "tm_a" {
+					return []*engine.Output{&spentA, &unspentA}, nil
+				}
+				return []*engine.Output{&spentB, &pinnedB}, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, spendTxid *chainhash.Hash) error {
+				require.Equal(t, &spend.Txid, spendTxid)
+				marked = append(marked, outpoints...)
+				return nil
+			},
+			deleteOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, topic string) error {
+				require.Equal(t, "tm_b", topic)
+				deleted = append(deleted, outpoint)
+				return nil
+			},
+		},
+		LookupServices: map[string]engine.LookupService{"ls_a": lookupService},
+		SpendReconciler: &engine.SpendReconciler{
+			Source: source,
+			Topics: map[string]engine.SpendReconciliationPolicy{"tm_a": {}, "tm_b": {Evict: true}},
+		},
+	}
+
+	// when:
+	reports, err := sut.ReconcileSpends(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*engine.SpendReconciliationReport{
+		{Topic: "tm_a", Checked: 2, Spent: []*transaction.Outpoint{&spentA.Outpoint}},
+		{Topic: "tm_b", Checked: 2, Spent: []*transaction.Outpoint{&pinnedB.Outpoint}, Evicted: []*transaction.Outpoint{&spentB.Outpoint}},
+	}, reports)
+	require.Equal(t, []*transaction.Outpoint{&spentA.Outpoint, &pinnedB.Outpoint}, marked)
+	require.Equal(t, []*transaction.Outpoint{&spentB.Outpoint}, deleted)
+	require.Equal(t, []*transaction.Outpoint{&spentB.Outpoint}, lookupService.evicted)
+	require.Equal(t, []*engine.OutputSpent{
+		{Outpoint: &spentA.Outpoint, Topic: "tm_a", SpendingTxid: &spend.Txid, InputIndex: 2},
+		{Outpoint: &pinnedB.Outpoint, Topic: "tm_b", SpendingTxid: &spend.Txid, InputIndex: 2},
+	}, spentNotified)
+}
+
+func TestEngine_ReconcileSpends_ShouldResumeWhereThePreviousRunStopped(t *testing.T) {
+	// given:
+	utxos := []*engine.Output{
+		{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{1}}, Topic: "tm_a", Score: 1},
+		{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{2}}, Topic: "tm_a", Score: 2},
+		{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{3}}, Topic: "tm_a", Score: 3},
+	}
+	var sinces []float64
+	sut := &engine.Engine{
+		Storage: fakeStorage{
+			findUTXOsForTopicFunc: func(_ context.Context, _ string, since float64, limit uint32, _ bool) ([]*engine.Output, error) {
+				sinces = append(sinces, since)
+				page := make([]*engine.Output, 0, limit)
+				for _, utxo := range utxos {
+					if utxo.Score > since && len(page) < int(limit) {
+						page = append(page, utxo)
+					}
+				}
+				return page, nil
+			},
+		},
+		SpendReconciler: &engine.SpendReconciler{
+			Source:    &fakeSpendSource{},
+			BatchSize: 2,
+			Topics:    map[string]engine.SpendReconciliationPolicy{"tm_a": {}},
+		},
+	}
+
+	// when:
+	for range 3 {
+		_, err := sut.ReconcileSpends(context.Background())
+		require.NoError(t, err)
+	}
+
+	// then:
+	require.Equal(t, []float64{0, 2, 0}, sinces)
+}
+
+func TestEngine_ReconcileSpends_ShouldFail_WhenNotConfigured(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}}
+
+	// when:
+	reports, err := sut.ReconcileSpends(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrSpendReconciliationNotConfigured)
+	require.Nil(t, reports)
+}
+
+func TestNewSpendSource_ShouldLookUpWhatsOnChainSpends(t *testing.T) {
+	// given:
+	spent := &transaction.Outpoint{Txid: chainhash.Hash{1}, Index: 3}
+	unspent := &transaction.Outpoint{Txid: chainhash.Hash{2}, Index: 0}
+	spendTxid := chainhash.Hash{9}
+	woc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "woc-key", r.Header.Get("Authorization"))
+		if r.URL.Path == "/tx/"+spent.Txid.String()+"/3/spent" {
+			_, _ = w.Write([]byte(`{"txid":"` + spendTxid.String() + `","vin":1}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(woc.Close)
+
+	source, err := engine.NewSpendSource(engine.SpendReconciliationConfig{Source: engine.SpendSourceWhatsOnChain, URL: woc.URL, APIKey: "woc-key"})
+	require.NoError(t, err)
+
+	// when:
+	spend, spentErr := source.FindSpend(context.Background(), spent)
+	none, unspentErr := source.FindSpend(context.Background(), unspent)
+
+	// then:
+	require.NoError(t, spentErr)
+	require.Equal(t, &engine.Spend{Txid: spendTxid, InputIndex: 1}, spend)
+	require.NoError(t, unspentErr)
+	require.Nil(t, none)
+}
+
+func TestNewSpendSource_ShouldRejectUnknownSource(t *testing.T) {
+	// when:
+	source, err := engine.NewSpendSource(engine.SpendReconciliationConfig{Source: "unknown"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownSpendSource)
+	require.Nil(t, source)
+}
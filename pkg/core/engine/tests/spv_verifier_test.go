@@ -0,0 +1,133 @@
+package engine_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func newMinedTx(outputs int) *transaction.Transaction {
+	tx := transaction.NewTransaction()
+	for range outputs {
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1000, LockingScript: &script.Script{script.OpTRUE}})
+	}
+	tx.MerklePath = transaction.NewMerklePath(100, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: tx.TxID(), Txid: ptr(true)},
+	}})
+	return tx
+}
+
+func newSpendingTx(lockingScript *script.Script, sources ...*transaction.Transaction) *transaction.Transaction {
+	tx := transaction.NewTransaction()
+	for _, source := range sources {
+		tx.AddInput(&transaction.TransactionInput{
+			SourceTXID:        source.TxID(),
+			SourceTxOutIndex:  0,
+			SourceTransaction: source,
+			UnlockingScript:   &script.Script{},
+			SequenceNumber:    transaction.DefaultSequenceNumber,
+		})
+	}
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: 900, LockingScript: lockingScript})
+	return tx
+}
+
+func countingChainTracker(valid bool, calls *atomic.Int32) fakeChainTracker {
+	return fakeChainTracker{
+		isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+			calls.Add(1)
+			return valid, nil
+		},
+	}
+}
+
+func TestSPVVerifier_Verify_ShouldVerifyGraphOnce(t *testing.T) {
+	// given:
+	var calls atomic.Int32
+	tracker := countingChainTracker(true, &calls)
+	verifier := engine.NewSPVVerifier(engine.SPVVerifierConfig{Workers: 4})
+	verified := engine.NewVerifiedTxCache(engine.VerifiedTxCacheConfig{})
+
+	root := newMinedTx(2)
+	left := newSpendingTx(&script.Script{script.OpTRUE}, root)
+	right := newSpendingTx(&script.Script{script.OpTRUE}, root)
+	right.Inputs[0].SourceTxOutIndex = 1
+	tip := newSpendingTx(&script.Script{script.OpTRUE}, left, right)
+
+	// when:
+	valid, err := verifier.Verify(context.Background(), tip, tracker, verified)
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, valid)
+	require.Equal(t, int32(1), calls.Load())
+
+	// when:
+	next := newSpendingTx(&script.Script{script.OpTRUE}, tip)
+	valid, err = verifier.Verify(context.Background(), next, tracker, verified)
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, valid)
+	require.Equal(t, int32(1), calls.Load(), "verified ancestry should not be verified again")
+}
+
+func TestSPVVerifier_Verify_ShouldRejectInvalidGraph(t *testing.T) {
+	var calls atomic.Int32
+
+	tests := map[string]struct {
+		tx      func() *transaction.Transaction
+		tracker fakeChainTracker
+	}{
+		"input whose script does not unlock its source output": {
+			tx: func() *transaction.Transaction {
+				locked := newSpendingTx(&script.Script{script.OpFALSE}, newMinedTx(1))
+				return newSpendingTx(&script.Script{script.OpTRUE}, locked)
+			},
+			tracker: countingChainTracker(true, &calls),
+		},
+		"ancestor with a merkle path rejected by the chain tracker": {
+			tx: func() *transaction.Transaction {
+				return newSpendingTx(&script.Script{script.OpTRUE}, newMinedTx(1))
+			},
+			tracker: countingChainTracker(false, &calls),
+		},
+		"input without source transaction": {
+			tx: func() *transaction.Transaction {
+				tx := newSpendingTx(&script.Script{script.OpTRUE}, newMinedTx(1))
+				tx.Inputs[0].SourceTransaction = nil
+				return tx
+			},
+			tracker: countingChainTracker(true, &calls),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			verifier := engine.NewSPVVerifier(engine.SPVVerifierConfig{Workers: 2})
+			verified := engine.NewVerifiedTxCache(engine.VerifiedTxCacheConfig{})
+			tx := tc.tx()
+
+			// when:
+			valid, err := verifier.Verify(context.Background(), tx, tc.tracker, verified)
+
+			// then:
+			require.Error(t, err)
+			require.False(t, valid)
+
+			// when:
+			valid, err = verifier.Verify(context.Background(), tx, tc.tracker, verified)
+
+			// then:
+			require.Error(t, err, "failed verifications should not be cached")
+			require.False(t, valid)
+		})
+	}
+}
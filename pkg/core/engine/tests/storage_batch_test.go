@@ -0,0 +1,149 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errBatchInsertFailed = errors.New("batch insert failed")
+
+// fakeBatchStorage records the batches written through engine.BatchStorage.
+type fakeBatchStorage struct {
+	fakeStorage
+
+	insertOutputsErr error
+	outputBatches    [][]*engine.Output
+	appliedBatches   [][]*overlay.AppliedTransaction
+}
+
+func (f *fakeBatchStorage) InsertOutputs(_ context.Context, outputs []*engine.Output) error {
+	if f.insertOutputsErr != nil {
+		return f.insertOutputsErr
+	}
+	f.outputBatches = append(f.outputBatches, outputs)
+	return nil
+}
+
+func (f *fakeBatchStorage) InsertAppliedTransactions(_ context.Context, txs []*overlay.AppliedTransaction) error {
+	f.appliedBatches = append(f.appliedBatches, txs)
+	return nil
+}
+
+// newBatchedGraph returns a mined transaction and an unmined transaction spending it, appended to a GASP graph
+// rooted at the output of the spending transaction.
+func newBatchedGraph(t *testing.T, sut *engine.OverlayGASPStorage) (parent, child *transaction.Transaction, graphID *transaction.Outpoint) {
+	t.Helper()
+	ctx := context.Background()
+	parent = newMinedTx(1)
+	child = newSpendingTx(&script.Script{script.OpTRUE}, parent)
+	graphID = &transaction.Outpoint{Txid: *child.TxID(), Index: 0}
+	proof := parent.MerklePath.Hex()
+
+	require.NoError(t, sut.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: child.Hex()}, nil))
+	require.NoError(t, sut.AppendToGraph(ctx, &gasp.Node{GraphID: graphID, RawTx: parent.Hex(), OutputIndex: 0, Proof: &proof}, graphID))
+	return parent, child, graphID
+}
+
+func newBatchingEngine(storage engine.Storage) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					admit := overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}
+					for vin := range previousCoins {
+						admit.CoinsToRetain = append(admit.CoinsToRetain, vin)
+					}
+					return admit, nil
+				},
+			},
+		},
+		Storage: storage,
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+	}
+}
+
+// unbatchedStorage returns a storage holding no outputs that fails the test on writes expected to be batched.
+func unbatchedStorage(t *testing.T) fakeStorage {
+	return fakeStorage{
+		findOutputsFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+			return make([]*engine.Output, len(outpoints)), nil
+		},
+		doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+			return false, nil
+		},
+		markUTXOsAsSpentFunc: func(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+			t.Errorf("staged outputs %v marked as spent in storage", outpoints)
+			return nil
+		},
+		insertOutputFunc: func(_ context.Context, utxo *engine.Output) error {
+			t.Errorf("output %s inserted without batching", utxo.Outpoint.String())
+			return nil
+		},
+		updateConsumedByFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ string, _ []*transaction.Outpoint) error {
+			t.Errorf("consumers of staged output %s updated in storage", outpoint.String())
+			return nil
+		},
+		insertAppliedTransactionFunc: func(_ context.Context, tx *overlay.AppliedTransaction) error {
+			t.Errorf("applied transaction %s inserted without batching", tx.Txid)
+			return nil
+		},
+	}
+}
+
+func TestOverlayGASPStorage_FinalizeGraph_ShouldBatchWrites_WhenStorageSupportsBatches(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := &fakeBatchStorage{fakeStorage: unbatchedStorage(t)}
+	sut := engine.NewOverlayGASPStorage("test-topic", newBatchingEngine(storage), nil)
+	parent, child, graphID := newBatchedGraph(t, sut)
+
+	// when:
+	err := sut.FinalizeGraph(ctx, graphID)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, storage.outputBatches, 1)
+	require.Len(t, storage.outputBatches[0], 2)
+
+	parentOutput, childOutput := storage.outputBatches[0][0], storage.outputBatches[0][1]
+	require.Equal(t, transaction.Outpoint{Txid: *parent.TxID(), Index: 0}, parentOutput.Outpoint)
+	require.True(t, parentOutput.Spent)
+	require.Equal(t, []*transaction.Outpoint{graphID}, parentOutput.ConsumedBy)
+	require.Equal(t, *graphID, childOutput.Outpoint)
+	require.False(t, childOutput.Spent)
+	require.Equal(t, []*transaction.Outpoint{&parentOutput.Outpoint}, childOutput.OutputsConsumed)
+	require.Equal(t, engine.OutputSourceGASP, childOutput.Source)
+
+	require.Len(t, storage.appliedBatches, 1)
+	require.Len(t, storage.appliedBatches[0], 2)
+	require.Equal(t, parent.TxID(), storage.appliedBatches[0][0].Txid)
+	require.Equal(t, child.TxID(), storage.appliedBatches[0][1].Txid)
+}
+
+func TestOverlayGASPStorage_FinalizeGraph_ShouldFail_WhenBatchWriteFails(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := &fakeBatchStorage{fakeStorage: unbatchedStorage(t), insertOutputsErr: errBatchInsertFailed}
+	sut := engine.NewOverlayGASPStorage("test-topic", newBatchingEngine(storage), nil)
+	_, _, graphID := newBatchedGraph(t, sut)
+
+	// when:
+	err := sut.FinalizeGraph(ctx, graphID)
+
+	// then:
+	require.ErrorIs(t, err, errBatchInsertFailed)
+	require.Empty(t, storage.appliedBatches)
+}
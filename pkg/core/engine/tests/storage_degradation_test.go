@@ -0,0 +1,162 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReadOnlyStorageError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		readOnly bool
+	}{
+		"nil error":                {err: nil, readOnly: false},
+		"read-only file system":    {err: fmt.Errorf("write: %w", syscall.EROFS), readOnly: true},
+		"disk full":                {err: fmt.Errorf("write: %w", syscall.ENOSPC), readOnly: true},
+		"demoted replica":          {err: errors.New("(NotWritablePrimary) not primary"), readOnly: true},
+		"read-only database":       {err: errors.New("attempt to write a readonly database"), readOnly: true},
+		"unrelated storage error":  {err: errInsertFailed, readOnly: false},
+		"engine read-only failure": {err: &engine.StorageReadOnlyError{}, readOnly: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			readOnly := engine.IsReadOnlyStorageError(tc.err)
+
+			// then:
+			require.Equal(t, tc.readOnly, readOnly)
+		})
+	}
+}
+
+func TestEngine_Submit_ShouldDegradeAndRecover_WhenStorageBecomesReadOnly(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	writable := false
+	degradation := engine.NewStorageDegradation(50 * time.Millisecond)
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				if !writable {
+					return fmt.Errorf("mark spent: %w", syscall.EROFS)
+				}
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				return nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		StorageDegradation: degradation,
+	}
+	taggedBEEF := overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}
+
+	// when:
+	_, failedErr := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	degradedAfterFailure, _, cause := degradation.Degraded()
+	_, rejectedErr := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	gaspErr := sut.StartGASPSync(ctx)
+
+	writable = true
+	time.Sleep(60 * time.Millisecond)
+	_, probeErr := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	degradedAfterProbe, _, _ := degradation.Degraded()
+
+	// then:
+	require.ErrorIs(t, failedErr, syscall.EROFS)
+	require.True(t, degradedAfterFailure)
+	require.ErrorIs(t, cause, syscall.EROFS)
+
+	var readOnlyErr *engine.StorageReadOnlyError
+	require.ErrorAs(t, rejectedErr, &readOnlyErr)
+	require.ErrorIs(t, rejectedErr, engine.ErrStorageReadOnly)
+	require.Positive(t, readOnlyErr.RetryAfter)
+	require.ErrorIs(t, gaspErr, engine.ErrStorageReadOnly)
+
+	require.NoError(t, probeErr)
+	require.False(t, degradedAfterProbe)
+}
+
+func TestEngine_Submit_ShouldNotDegrade_WhenWriteFailureIsNotReadOnly(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	degradation := engine.NewStorageDegradation(time.Minute)
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return errInsertFailed
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		StorageDegradation: degradation,
+	}
+	taggedBEEF := overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}
+
+	// when:
+	_, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+	degraded, _, _ := degradation.Degraded()
+
+	// then:
+	require.ErrorIs(t, err, errInsertFailed)
+	require.False(t, degraded)
+}
@@ -0,0 +1,154 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	cancelTopicA = "tm_cancel_a"
+	cancelTopicB = "tm_cancel_b"
+)
+
+// cancelingStorage cancels the submission marking inputs as spent in cancelTopic, as a storage honouring the
+// deadline of its context would fail.
+type cancelingStorage struct {
+	*testharness.MemoryStorage
+	cancelTopic string
+	cancel      context.CancelFunc
+}
+
+func (s cancelingStorage) MarkUTXOsAsSpent(ctx context.Context, outpoints []*transaction.Outpoint, topic string, spendTxid *chainhash.Hash) error {
+	if topic == s.cancelTopic && s.cancel != nil {
+		s.cancel()
+		return ctx.Err()
+	}
+	return s.MemoryStorage.MarkUTXOsAsSpent(ctx, outpoints, topic, spendTxid)
+}
+
+// cancelingLookupService cancels the submission once it is notified about an admitted output.
+type cancelingLookupService struct {
+	fakeLookupService
+	cancel context.CancelFunc
+}
+
+func (s cancelingLookupService) OutputAdmittedByTopic(context.Context, *engine.OutputAdmittedByTopic) error {
+	s.cancel()
+	return nil
+}
+
+func (s cancelingLookupService) OutputSpent(context.Context, *engine.OutputSpent) error {
+	return nil
+}
+
+type cancellationFixture struct {
+	storage *cancelingStorage
+	builder *testutil.Builder
+	engine  engine.Engine
+}
+
+func newCancellationFixture(t *testing.T) *cancellationFixture {
+	t.Helper()
+	tracker := testutil.NewChainTracker(800000)
+	f := &cancellationFixture{
+		storage: &cancelingStorage{MemoryStorage: testharness.NewMemoryStorage()},
+		builder: testutil.NewBuilder(t, tracker),
+	}
+	f.engine = engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			cancelTopicA: &testharness.TopicManager{Topic: cancelTopicA},
+			cancelTopicB: &testharness.TopicManager{Topic: cancelTopicB},
+		},
+		Storage:      f.storage,
+		ChainTracker: tracker,
+	}
+	return f
+}
+
+func (f *cancellationFixture) submit(ctx context.Context, t *testing.T, sut *engine.Engine, tx *transaction.Transaction) (overlay.Steak, error) {
+	t.Helper()
+	taggedBEEF := overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{cancelTopicA, cancelTopicB}}
+	return sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+}
+
+func (f *cancellationFixture) requireApplied(t *testing.T, tx *transaction.Transaction, topic string, applied bool) {
+	t.Helper()
+	exists, err := f.storage.DoesAppliedTransactionExist(context.Background(), &overlay.AppliedTransaction{Txid: tx.TxID(), Topic: topic})
+	require.NoError(t, err)
+	require.Equal(t, applied, exists, "transaction applied to %s", topic)
+}
+
+func TestEngine_Submit_ShouldRejectSubmissions_WhenTheContextIsDoneBeforehand(t *testing.T) {
+	// given:
+	f := newCancellationFixture(t)
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when:
+	steak, err := f.submit(ctx, t, sut, tx)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrSubmitCanceled)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Nil(t, steak)
+	f.requireApplied(t, tx, cancelTopicA, false)
+}
+
+func TestEngine_Submit_ShouldRollBackSpentInputs_WhenCanceledWhileMarkingThem(t *testing.T) {
+	// given:
+	f := newCancellationFixture(t)
+	sut := engine.NewEngine(f.engine)
+	root := f.builder.Mined(800001, 1000)
+	_, err := f.submit(context.Background(), t, sut, root)
+	require.NoError(t, err)
+	spend := f.builder.Spend([]testutil.Input{{Tx: root, Vout: 0}}, 900)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f.storage.cancelTopic, f.storage.cancel = cancelTopicB, cancel
+
+	// when:
+	_, err = f.submit(ctx, t, sut, spend)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrSubmitCanceled)
+	require.ErrorIs(t, err, context.Canceled)
+	spent := true
+	for _, topic := range []string{cancelTopicA, cancelTopicB} {
+		output, err := f.storage.FindOutput(context.Background(), &transaction.Outpoint{Txid: *root.TxID(), Index: 0}, &topic, &spent, false)
+		require.NoError(t, err)
+		require.Nil(t, output, "the input must be unspent in %s", topic)
+		f.requireApplied(t, spend, topic, false)
+	}
+}
+
+func TestEngine_Submit_ShouldApplySubmissionsInFull_WhenCanceledAfterNotifyingLookupServices(t *testing.T) {
+	// given:
+	f := newCancellationFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f.engine.LookupServices = map[string]engine.LookupService{"ls_cancel": cancelingLookupService{cancel: cancel}}
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+
+	// when:
+	steak, err := f.submit(ctx, t, sut, tx)
+
+	// then:
+	require.NoError(t, err)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	testutil.RequireAdmitted(t, steak, cancelTopicA, 0)
+	testutil.RequireAdmitted(t, steak, cancelTopicB, 0)
+	f.requireApplied(t, tx, cancelTopicA, true)
+	f.requireApplied(t, tx, cancelTopicB, true)
+}
@@ -0,0 +1,83 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newEvaluateEngine returns an engine whose storage only answers reads, so that any write panics.
+func newEvaluateEngine(applied bool) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return applied, nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Broadcaster: fakeBroadcasterFail{},
+	}
+}
+
+func TestEngine_Evaluate_ShouldReturnSteakWithoutWriting(t *testing.T) {
+	// given:
+	sut := newEvaluateEngine(false)
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, overlay.Steak{
+		"test-topic": &overlay.AdmittanceInstructions{
+			OutputsToAdmit: []uint32{0},
+			CoinsRemoved:   []uint32{0},
+		},
+	}, steak)
+}
+
+func TestEngine_Evaluate_ShouldReturnEmptySteakForAppliedTransaction(t *testing.T) {
+	// given:
+	sut := newEvaluateEngine(true)
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{}}, steak)
+}
+
+func TestEngine_Evaluate_ShouldRejectUnknownTopic(t *testing.T) {
+	// given:
+	sut := newEvaluateEngine(false)
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"unknown"}, Beef: createDummyBEEF(t)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, steak)
+}
@@ -0,0 +1,146 @@
+package engine_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func newIdempotentEngine(t *testing.T, evaluations *atomic.Int32) *engine.Engine {
+	t.Helper()
+	applied := &atomic.Bool{}
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					evaluations.Add(1)
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				return nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return applied.Load(), nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				applied.Store(true)
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Idempotency: engine.NewSubmitIdempotency(engine.SubmitIdempotencyConfig{}),
+	}
+}
+
+func TestEngine_Submit_Idempotency(t *testing.T) {
+	expectedSteak := overlay.Steak{
+		"test-topic": &overlay.AdmittanceInstructions{
+			OutputsToAdmit: []uint32{0},
+			CoinsRemoved:   []uint32{0},
+		},
+	}
+
+	t.Run("retry with a derived key returns the original STEAK", func(t *testing.T) {
+		// given:
+		var evaluations atomic.Int32
+		sut := newIdempotentEngine(t, &evaluations)
+		taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+		// when:
+		first, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+		require.NoError(t, err)
+
+		var streamed *overlay.Steak
+		retried, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, func(steak *overlay.Steak) {
+			streamed = steak
+		})
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, expectedSteak, first)
+		require.Equal(t, expectedSteak, retried)
+		require.Equal(t, expectedSteak, *streamed)
+		require.Equal(t, int32(1), evaluations.Load())
+	})
+
+	t.Run("retry with an explicit key returns the original STEAK", func(t *testing.T) {
+		// given:
+		var evaluations atomic.Int32
+		sut := newIdempotentEngine(t, &evaluations)
+		ctx := engine.WithIdempotencyKey(context.Background(), "request-1")
+		taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+		// when:
+		first, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+		require.NoError(t, err)
+
+		retried, err := sut.Submit(ctx, taggedBEEF, engine.SubmitModeCurrent, nil)
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, first, retried)
+		require.Equal(t, int32(1), evaluations.Load())
+	})
+
+	t.Run("explicit key reused for other topics is a conflict", func(t *testing.T) {
+		// given:
+		var evaluations atomic.Int32
+		sut := newIdempotentEngine(t, &evaluations)
+		ctx := engine.WithIdempotencyKey(context.Background(), "request-1")
+		beef := createDummyBEEF(t)
+
+		_, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: beef}, engine.SubmitModeCurrent, nil)
+		require.NoError(t, err)
+
+		// when:
+		steak, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic", "other-topic"}, Beef: beef}, engine.SubmitModeCurrent, nil)
+
+		// then:
+		require.ErrorIs(t, err, engine.ErrIdempotencyKeyConflict)
+		require.Nil(t, steak)
+		require.Equal(t, int32(1), evaluations.Load())
+	})
+
+	t.Run("failed submission is processed again", func(t *testing.T) {
+		// given:
+		var evaluations atomic.Int32
+		sut := newIdempotentEngine(t, &evaluations)
+		ctx := engine.WithIdempotencyKey(context.Background(), "request-1")
+		invalid := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: []byte{0xFF}}
+
+		// when:
+		_, firstErr := sut.Submit(ctx, invalid, engine.SubmitModeCurrent, nil)
+		steak, retryErr := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+		// then:
+		require.Error(t, firstErr)
+		require.NoError(t, retryErr)
+		require.Equal(t, expectedSteak, steak)
+		require.Equal(t, int32(1), evaluations.Load())
+	})
+}
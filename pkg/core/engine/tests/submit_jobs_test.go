@@ -0,0 +1,241 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_EnqueueSubmit_CompletesJob(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				return nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		SubmitJobs: engine.NewSubmitJobQueue(engine.SubmitJobQueueConfig{}),
+	}
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}
+
+	// when:
+	job, err := sut.EnqueueSubmit(ctx, taggedBEEF, "")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, taggedBEEF.Topics, job.Topics)
+	require.Contains(t, []string{engine.SubmitJobPending, engine.SubmitJobProcessing}, job.Status)
+
+	require.Eventually(t, func() bool {
+		polled, err := sut.FindSubmitJob(ctx, job.ID)
+		require.NoError(t, err)
+		return polled.Status == engine.SubmitJobCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	polled, err := sut.FindSubmitJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, polled.Steak["test-topic"].OutputsToAdmit)
+	require.Empty(t, polled.Error)
+	require.False(t, polled.CompletedAt.IsZero())
+}
+
+func TestEngine_EnqueueSubmit_DeliversFailedJobToCallback(t *testing.T) {
+	// given:
+	delivered := make(chan engine.SubmitJob, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var job engine.SubmitJob
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&job))
+		delivered <- job
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callback.Close()
+
+	sut := &engine.Engine{
+		Managers:     map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		Storage:      fakeStorage{},
+		ChainTracker: fakeChainTracker{},
+		SubmitJobs: engine.NewSubmitJobQueue(engine.SubmitJobQueueConfig{
+			Workers:                      1,
+			CallbackAllowlist:            []string{callback.URL + "/"},
+			CallbackAllowPrivateNetworks: true,
+		}),
+	}
+
+	// when:
+	job, err := sut.EnqueueSubmit(context.Background(), overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: []byte{0xFF}}, callback.URL+"/jobs")
+	require.NoError(t, err)
+
+	// then:
+	select {
+	case got := <-delivered:
+		require.Equal(t, job.ID, got.ID)
+		require.Equal(t, engine.SubmitJobFailed, got.Status)
+		require.Contains(t, got.Error, "invalid-version")
+	case <-time.After(time.Second):
+		t.Fatal("finished job was not delivered to the callback URL")
+	}
+
+	polled, err := sut.FindSubmitJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, engine.SubmitJobFailed, polled.Status)
+}
+
+func TestEngine_EnqueueSubmit_ShouldRejectCallbackURLs_OutsideTheAllowlist(t *testing.T) {
+	tests := map[string]struct {
+		allowlist   []string
+		callbackURL string
+	}{
+		"no allowlist":            {callbackURL: "https://client.example/jobs"},
+		"other host":              {allowlist: []string{"client.example"}, callbackURL: "https://other.example/jobs"},
+		"loopback host":           {allowlist: []string{"client.example"}, callbackURL: "http://127.0.0.1/jobs"},
+		"host extending a prefix": {allowlist: []string{"https://client.example"}, callbackURL: "https://client.example.evil/jobs"},
+		"path outside a prefix":   {allowlist: []string{"https://client.example/jobs/"}, callbackURL: "https://client.example/admin"},
+		"scheme outside a prefix": {allowlist: []string{"https://client.example/"}, callbackURL: "http://client.example/jobs"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			sut := &engine.Engine{SubmitJobs: engine.NewSubmitJobQueue(engine.SubmitJobQueueConfig{CallbackAllowlist: tc.allowlist})}
+
+			// when:
+			job, err := sut.EnqueueSubmit(context.Background(), overlay.TaggedBEEF{Topics: []string{"test-topic"}}, tc.callbackURL)
+
+			// then:
+			require.ErrorIs(t, err, engine.ErrSubmitJobCallbackNotAllowed)
+			require.Nil(t, job)
+		})
+	}
+}
+
+// enqueueFailingSubmit enqueues a submission failing to parse with the callback URL and waits for the job to finish.
+func enqueueFailingSubmit(t *testing.T, cfg engine.SubmitJobQueueConfig, callbackURL string) {
+	t.Helper()
+	sut := &engine.Engine{
+		Managers:   map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		SubmitJobs: engine.NewSubmitJobQueue(cfg),
+	}
+	job, err := sut.EnqueueSubmit(context.Background(), overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: []byte{0xFF}}, callbackURL)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		polled, err := sut.FindSubmitJob(context.Background(), job.ID)
+		return err == nil && polled.Status == engine.SubmitJobFailed
+	}, time.Second, time.Millisecond)
+}
+
+func TestEngine_EnqueueSubmit_ShouldNotDeliverJobsToLoopbackCallbacks(t *testing.T) {
+	// given:
+	var delivered atomic.Bool
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delivered.Store(true)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callback.Close()
+	callbackURL, err := url.Parse(callback.URL)
+	require.NoError(t, err)
+
+	// when:
+	enqueueFailingSubmit(t, engine.SubmitJobQueueConfig{CallbackAllowlist: []string{"127.0.0.1"}}, "http://127.0.0.1:"+callbackURL.Port()+"/jobs")
+
+	// then:
+	require.Never(t, delivered.Load, 100*time.Millisecond, 5*time.Millisecond, "the callback address resolves to loopback")
+}
+
+func TestEngine_EnqueueSubmit_ShouldNotFollowCallbackRedirects(t *testing.T) {
+	// given:
+	var delivered atomic.Bool
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delivered.Store(true)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer internal.Close()
+
+	redirected := make(chan struct{}, 1)
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected <- struct{}{}
+		http.Redirect(w, r, internal.URL+"/admin", http.StatusTemporaryRedirect)
+	}))
+	defer redirector.Close()
+
+	// when:
+	enqueueFailingSubmit(t, engine.SubmitJobQueueConfig{
+		CallbackAllowlist:            []string{redirector.URL + "/"},
+		CallbackAllowPrivateNetworks: true,
+	}, redirector.URL+"/jobs")
+
+	// then:
+	select {
+	case <-redirected:
+	case <-time.After(time.Second):
+		t.Fatal("finished job was not delivered to the callback URL")
+	}
+	require.Never(t, delivered.Load, 100*time.Millisecond, 5*time.Millisecond, "the redirect is not followed")
+}
+
+func TestEngine_SubmitJobs_Errors(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		// given:
+		sut := &engine.Engine{}
+
+		// when:
+		_, enqueueErr := sut.EnqueueSubmit(context.Background(), overlay.TaggedBEEF{}, "")
+		_, findErr := sut.FindSubmitJob(context.Background(), "job")
+
+		// then:
+		require.ErrorIs(t, enqueueErr, engine.ErrSubmitJobsNotConfigured)
+		require.ErrorIs(t, findErr, engine.ErrSubmitJobsNotConfigured)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		// given:
+		sut := &engine.Engine{SubmitJobs: engine.NewSubmitJobQueue(engine.SubmitJobQueueConfig{})}
+
+		// when:
+		job, err := sut.FindSubmitJob(context.Background(), "job")
+
+		// then:
+		require.ErrorIs(t, err, engine.ErrSubmitJobNotFound)
+		require.Nil(t, job)
+	})
+}
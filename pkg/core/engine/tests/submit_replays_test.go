@@ -0,0 +1,179 @@
+package engine_test
+
+import (
+	"context"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const replayTopic = "tm_replay"
+
+// countingTopicManager admits every output and counts the transactions it evaluates.
+type countingTopicManager struct {
+	*testharness.TopicManager
+	evaluations *atomic.Int32
+}
+
+func (m countingTopicManager) IdentifyAdmissibleOutputs(ctx context.Context, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	m.evaluations.Add(1)
+	return m.TopicManager.IdentifyAdmissibleOutputs(ctx, beef, previousCoins)
+}
+
+// unappliableStorage reports transactions as not applied once forgetApplied is set.
+type unappliableStorage struct {
+	*testharness.MemoryStorage
+	forgetApplied *atomic.Bool
+}
+
+func (s unappliableStorage) DoesAppliedTransactionExist(ctx context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	if s.forgetApplied.Load() {
+		return false, nil
+	}
+	return s.MemoryStorage.DoesAppliedTransactionExist(ctx, tx)
+}
+
+type replayFixture struct {
+	sut           *engine.Engine
+	builder       *testutil.Builder
+	evaluations   *atomic.Int32
+	validations   *atomic.Int32
+	forgetApplied *atomic.Bool
+}
+
+func newReplayFixture(t *testing.T, cfg engine.SubmitReplayCacheConfig) *replayFixture {
+	t.Helper()
+	tracker := testutil.NewChainTracker(800000)
+	f := &replayFixture{builder: testutil.NewBuilder(t, tracker), evaluations: &atomic.Int32{}, validations: &atomic.Int32{}, forgetApplied: &atomic.Bool{}}
+	f.sut = engine.NewEngine(engine.Engine{
+		Managers:      map[string]engine.TopicManager{replayTopic: countingTopicManager{TopicManager: &testharness.TopicManager{Topic: replayTopic}, evaluations: f.evaluations}},
+		Storage:       unappliableStorage{MemoryStorage: testharness.NewMemoryStorage(), forgetApplied: f.forgetApplied},
+		ChainTracker:  countingChainTracker(true, f.validations),
+		SubmitReplays: engine.NewSubmitReplayCache(cfg),
+	})
+	return f
+}
+
+func (f *replayFixture) submit(t *testing.T, tx *transaction.Transaction) overlay.Steak {
+	t.Helper()
+	steak, err := f.sut.Submit(context.Background(), overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{replayTopic}}, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	return steak
+}
+
+func TestEngine_Submit_ShouldAnswerReplaysFromTheCache(t *testing.T) {
+	// given:
+	f := newReplayFixture(t, engine.SubmitReplayCacheConfig{})
+	tx := f.builder.Mined(800001, 1000, 2000)
+	original := f.submit(t, tx)
+	testutil.RequireAdmitted(t, original, replayTopic, 0, 1)
+
+	// when:
+	var streamed *overlay.Steak
+	replayed, err := f.sut.Submit(context.Background(), overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{replayTopic}}, engine.SubmitModeCurrent, func(steak *overlay.Steak) {
+		streamed = steak
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, original, replayed)
+	require.Equal(t, original, *streamed)
+	require.Equal(t, int32(1), f.evaluations.Load(), "replays must not run topic managers")
+	require.Equal(t, int32(1), f.validations.Load(), "replays must not be verified again")
+	require.Equal(t, engine.SubmitReplayMetrics{Hits: 1, Misses: 1, Entries: 1}, f.sut.SubmitReplays.Metrics())
+}
+
+func TestEngine_Submit_ShouldProcessReplays_WhenTheTransactionIsNoLongerApplied(t *testing.T) {
+	// given:
+	f := newReplayFixture(t, engine.SubmitReplayCacheConfig{})
+	tx := f.builder.Mined(800001, 1000)
+	f.submit(t, tx)
+	f.forgetApplied.Store(true)
+
+	// when:
+	f.submit(t, tx)
+
+	// then:
+	require.Equal(t, int32(2), f.evaluations.Load())
+	require.Equal(t, engine.SubmitReplayMetrics{Misses: 2, Entries: 1}, f.sut.SubmitReplays.Metrics())
+}
+
+func TestEngine_Submit_ShouldForgetTheLeastRecentlySubmittedTransactions(t *testing.T) {
+	// given:
+	f := newReplayFixture(t, engine.SubmitReplayCacheConfig{MaxEntries: 1})
+	first, second := f.builder.Mined(800001, 1000), f.builder.Mined(800002, 1000)
+	f.submit(t, first)
+	f.submit(t, second)
+
+	// when:
+	f.submit(t, second)
+	f.submit(t, first)
+
+	// then:
+	require.Equal(t, engine.SubmitReplayMetrics{Hits: 1, Misses: 3, Evictions: 2, Entries: 1}, f.sut.SubmitReplays.Metrics(),
+		"only the most recently submitted transaction must be answered from the cache")
+}
+
+func TestEngine_Submit_ShouldNotAnswerFromTheCache_WhenTheBEEFClaimsTheTxidOfAnotherTransaction(t *testing.T) {
+	// given:
+	f := newReplayFixture(t, engine.SubmitReplayCacheConfig{})
+	submitted := f.builder.Mined(800001, 1000)
+	f.submit(t, submitted)
+	forged := testutil.AtomicBEEF(t, f.builder.Mined(800002, 2000))
+	copy(forged[4:36], submitted.TxID().CloneBytes())
+
+	// when:
+	var streamed *overlay.Steak
+	steak, err := f.sut.Submit(context.Background(), overlay.TaggedBEEF{Beef: forged, Topics: []string{replayTopic}}, engine.SubmitModeCurrent, func(steak *overlay.Steak) {
+		streamed = steak
+	})
+
+	// then:
+	require.Error(t, err)
+	require.Nil(t, steak)
+	require.Nil(t, streamed, "the cached STEAK must not be streamed for a forged BEEF")
+	require.Equal(t, int32(1), f.evaluations.Load())
+	require.Equal(t, engine.SubmitReplayMetrics{Misses: 1, Entries: 1}, f.sut.SubmitReplays.Metrics())
+}
+
+func TestEngine_Submit_ShouldRejectReplays_WhenTheEngineIsStopped(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	f := newReplayFixture(t, engine.SubmitReplayCacheConfig{})
+	tx := f.builder.Mined(800001, 1000)
+	f.submit(t, tx)
+	f.sut.Lifecycle = engine.NewLifecycle(time.Second)
+	require.NoError(t, f.sut.Start(ctx))
+	require.NoError(t, f.sut.Stop(ctx))
+
+	// when:
+	_, err := f.sut.Submit(ctx, overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{replayTopic}}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrEngineStopping)
+	require.Equal(t, engine.SubmitReplayMetrics{Misses: 1, Entries: 1}, f.sut.SubmitReplays.Metrics())
+}
+
+func TestEngine_Submit_ShouldRejectReplays_WhenStorageIsDegraded(t *testing.T) {
+	// given:
+	f := newReplayFixture(t, engine.SubmitReplayCacheConfig{})
+	tx := f.builder.Mined(800001, 1000)
+	f.submit(t, tx)
+	f.sut.StorageDegradation = engine.NewStorageDegradation(time.Minute)
+	f.sut.StorageDegradation.RecordWrite(syscall.EROFS)
+
+	// when:
+	_, err := f.sut.Submit(context.Background(), overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{replayTopic}}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrStorageReadOnly)
+	require.Equal(t, engine.SubmitReplayMetrics{Misses: 1, Entries: 1}, f.sut.SubmitReplays.Metrics())
+}
@@ -0,0 +1,263 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync acquires a slot of the scheduler in the background and sends the result once granted or rejected.
+func acquireAsync(ctx context.Context, s *engine.SubmitScheduler, topic string, historical bool, results chan<- string) {
+	go func() {
+		release, err := s.Acquire(ctx, topic, historical)
+		if err != nil {
+			results <- "rejected:" + topic
+			return
+		}
+		results <- topic
+		release()
+	}()
+}
+
+// waitForQueued waits until the scheduler queues the given number of submissions.
+func waitForQueued(t *testing.T, s *engine.SubmitScheduler, queued int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return s.Metrics().Queued == queued }, time.Second, time.Millisecond)
+}
+
+func TestSubmitScheduler_ShouldBoundConcurrentSubmissions(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{MaxConcurrent: 2})
+	first, err := sut.Acquire(ctx, "tm_a", false)
+	require.NoError(t, err)
+	second, err := sut.Acquire(ctx, "tm_a", false)
+	require.NoError(t, err)
+
+	// when:
+	results := make(chan string, 1)
+	acquireAsync(ctx, sut, "tm_b", false, results)
+	waitForQueued(t, sut, 1)
+	queued := sut.Metrics()
+	first()
+	first()
+
+	// then:
+	require.Equal(t, "tm_b", <-results)
+	second()
+
+	require.Equal(t, 2, queued.Running)
+	require.Equal(t, 1, queued.Topics["tm_b"].Queued)
+
+	metrics := sut.Metrics()
+	require.Equal(t, 0, metrics.Running)
+	require.Equal(t, 0, metrics.Queued)
+	require.Equal(t, uint64(3), metrics.Admitted)
+	require.Equal(t, uint64(2), metrics.Topics["tm_a"].Admitted)
+	require.Equal(t, uint64(1), metrics.Topics["tm_b"].Admitted)
+}
+
+func TestSubmitScheduler_ShouldServeTopicsInProportionToTheirWeights(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{
+		MaxConcurrent: 1,
+		TopicWeights:  map[string]int{"tm_quiet": 2},
+	})
+	release, err := sut.Acquire(ctx, "tm_busy", false)
+	require.NoError(t, err)
+
+	results := make(chan string, 9)
+	for i := range 6 {
+		acquireAsync(ctx, sut, "tm_busy", false, results)
+		waitForQueued(t, sut, i+1)
+	}
+	for i := range 3 {
+		acquireAsync(ctx, sut, "tm_quiet", false, results)
+		waitForQueued(t, sut, 7+i)
+	}
+
+	// when:
+	release()
+
+	// then:
+	var order []string
+	for range 9 {
+		order = append(order, <-results)
+	}
+	require.Equal(t, []string{
+		"tm_quiet", "tm_quiet", "tm_busy", "tm_quiet", "tm_busy", "tm_busy", "tm_busy", "tm_busy", "tm_busy",
+	}, order)
+}
+
+func TestSubmitScheduler_ShouldQueueSubmissionsTaggedWithSeveralTopicsBehindTheBusiestOne(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{MaxConcurrent: 1})
+	release, err := sut.Acquire(ctx, "tm_busy", false)
+	require.NoError(t, err)
+
+	results := make(chan string, 4)
+	acquireAsync(ctx, sut, "tm_busy", false, results)
+	waitForQueued(t, sut, 1)
+	acquireAsync(ctx, sut, "tm_busy", false, results)
+	waitForQueued(t, sut, 2)
+	go func() {
+		release, err := sut.AcquireTopics(ctx, []string{"tm_idle", "tm_busy"}, false)
+		if err != nil {
+			results <- "rejected:both"
+			return
+		}
+		results <- "both"
+		release()
+	}()
+	waitForQueued(t, sut, 3)
+	acquireAsync(ctx, sut, "tm_idle", false, results)
+	waitForQueued(t, sut, 4)
+	queued := sut.Metrics()
+
+	// when:
+	release()
+
+	// then:
+	var order []string
+	for range 4 {
+		order = append(order, <-results)
+	}
+	require.Equal(t, []string{"tm_busy", "tm_busy", "both", "tm_idle"}, order, "tagging an idle topic does not bypass the busy one")
+	require.Equal(t, 3, queued.Topics["tm_busy"].Queued)
+	require.Equal(t, 2, queued.Topics["tm_idle"].Queued)
+
+	metrics := sut.Metrics()
+	require.Equal(t, 0, metrics.Running)
+	require.Equal(t, uint64(5), metrics.Admitted)
+	require.Equal(t, uint64(4), metrics.Topics["tm_busy"].Admitted)
+	require.Equal(t, uint64(2), metrics.Topics["tm_idle"].Admitted)
+	require.Zero(t, metrics.Topics["tm_busy"].Running)
+	require.Zero(t, metrics.Topics["tm_idle"].Running)
+}
+
+func TestSubmitScheduler_ShouldRejectSubmissions_WhenSaturated(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{
+		MaxConcurrent: 1,
+		MaxQueued:     1,
+		MaxWait:       20 * time.Millisecond,
+		RetryAfter:    3 * time.Second,
+	})
+	release, err := sut.Acquire(ctx, "tm_a", false)
+	require.NoError(t, err)
+	defer release()
+
+	// when:
+	results := make(chan string, 1)
+	acquireAsync(ctx, sut, "tm_a", false, results)
+	waitForQueued(t, sut, 1)
+	_, fullErr := sut.Acquire(ctx, "tm_b", false)
+
+	// then:
+	var saturatedErr *engine.SubmitSaturatedError
+	require.ErrorAs(t, fullErr, &saturatedErr)
+	require.ErrorIs(t, fullErr, engine.ErrSubmitSaturated)
+	require.Equal(t, "tm_b", saturatedErr.Topic)
+	require.Equal(t, 3*time.Second, saturatedErr.RetryAfter)
+
+	require.Equal(t, "rejected:tm_a", <-results)
+
+	metrics := sut.Metrics()
+	require.Equal(t, 0, metrics.Queued)
+	require.Equal(t, uint64(2), metrics.Rejected)
+	require.Equal(t, uint64(1), metrics.Topics["tm_a"].Rejected)
+	require.Equal(t, uint64(1), metrics.Topics["tm_b"].Rejected)
+}
+
+func TestSubmitScheduler_ShouldQueueHistoricalSubmissions_WhenSaturated(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{
+		MaxConcurrent: 1,
+		MaxQueued:     1,
+		MaxWait:       time.Millisecond,
+	})
+	release, err := sut.Acquire(ctx, "tm_a", false)
+	require.NoError(t, err)
+
+	// when:
+	results := make(chan string, 2)
+	acquireAsync(ctx, sut, "tm_a", true, results)
+	waitForQueued(t, sut, 1)
+	acquireAsync(ctx, sut, "tm_a", true, results)
+	waitForQueued(t, sut, 2)
+	time.Sleep(10 * time.Millisecond)
+	release()
+
+	// then:
+	require.Equal(t, "tm_a", <-results)
+	require.Equal(t, "tm_a", <-results)
+	require.Equal(t, uint64(0), sut.Metrics().Rejected)
+}
+
+func TestSubmitScheduler_ShouldStopWaiting_WhenContextIsCanceled(t *testing.T) {
+	// given:
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{MaxConcurrent: 1})
+	release, err := sut.Acquire(context.Background(), "tm_a", false)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// when:
+	_, err = sut.Acquire(ctx, "tm_a", false)
+
+	// then:
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 0, sut.Metrics().Queued)
+}
+
+func TestSubmitScheduler_ShouldPublishMetricsAsJSON(t *testing.T) {
+	// given:
+	sut := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{TopicWeights: map[string]int{"tm_a": 3}})
+	release, err := sut.Acquire(context.Background(), "tm_a", false)
+	require.NoError(t, err)
+	defer release()
+
+	// when:
+	var metrics engine.SubmitSchedulerMetrics
+	err = json.Unmarshal([]byte(sut.String()), &metrics)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.Running)
+	require.Equal(t, engine.SubmitTopicMetrics{Weight: 3, Running: 1, Admitted: 1}, metrics.Topics["tm_a"])
+}
+
+func TestEngine_Submit_ShouldRejectSubmissions_WhenSchedulerIsSaturated(t *testing.T) {
+	// given:
+	scheduler := engine.NewSubmitScheduler(engine.SubmitSchedulerConfig{MaxConcurrent: 1, MaxWait: 10 * time.Millisecond})
+	release, err := scheduler.Acquire(context.Background(), "test-topic", false)
+	require.NoError(t, err)
+	defer release()
+
+	sut := &engine.Engine{
+		Managers:        map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		SubmitScheduler: scheduler,
+	}
+
+	// when:
+	steak, err := sut.Submit(context.Background(), overlay.TaggedBEEF{
+		Topics: []string{"test-topic"},
+		Beef:   createDummyBEEF(t),
+	}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrSubmitSaturated)
+	require.Nil(t, steak)
+	require.Equal(t, uint64(1), scheduler.Metrics().Rejected)
+}
@@ -0,0 +1,140 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTombstoneStorage records the tombstones and purges requested by the engine.
+type fakeTombstoneStorage struct {
+	fakeStorage
+	tombstones    []*engine.Tombstone
+	deletedBefore time.Time
+}
+
+func (f *fakeTombstoneStorage) TombstoneOutput(_ context.Context, outpoint *transaction.Outpoint, topic string, reason engine.TombstoneReason, deletedAt time.Time) error {
+	f.tombstones = append(f.tombstones, &engine.Tombstone{
+		Output:    &engine.Output{Outpoint: *outpoint, Topic: topic},
+		Reason:    reason,
+		DeletedAt: deletedAt,
+	})
+	return nil
+}
+
+func (f *fakeTombstoneStorage) FindTombstones(_ context.Context, query *engine.TombstoneQuery) ([]*engine.Tombstone, error) {
+	var found []*engine.Tombstone
+	for _, tombstone := range f.tombstones {
+		if query.Topic == "" || tombstone.Output.Topic == query.Topic {
+			found = append(found, tombstone)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeTombstoneStorage) PurgeTombstones(_ context.Context, deletedBefore time.Time) (int, error) {
+	f.deletedBefore = deletedBefore
+	return len(f.tombstones), nil
+}
+
+func newTombstoneEngine(storage engine.Storage, cfg *engine.TombstoneConfig, spent engine.Output) *engine.Engine {
+	return &engine.Engine{
+		Storage: storage,
+		SpendReconciler: &engine.SpendReconciler{
+			Source: &fakeSpendSource{spends: map[transaction.Outpoint]*engine.Spend{
+				spent.Outpoint: {Txid: chainhash.Hash{9}},
+			}},
+			Topics: map[string]engine.SpendReconciliationPolicy{spent.Topic: {Evict: true}},
+		},
+		Tombstones: cfg,
+	}
+}
+
+func TestEngine_ReconcileSpends_ShouldTombstoneEvictedOutputs_WhenTombstonesAreEnabled(t *testing.T) {
+	// given:
+	spent := engine.Output{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{1}}, Topic: "tm_a", Score: 1}
+	storage := &fakeTombstoneStorage{fakeStorage: fakeStorage{
+		findUTXOsForTopicFunc: func(context.Context, string, float64, uint32, bool) ([]*engine.Output, error) {
+			return []*engine.Output{&spent}, nil
+		},
+		deleteOutputFunc: func(context.Context, *transaction.Outpoint, string) error {
+			require.Fail(t, "tombstoned outputs must not be deleted")
+			return nil
+		},
+	}}
+	sut := newTombstoneEngine(storage, &engine.TombstoneConfig{Enabled: true}, spent)
+	before := time.Now()
+
+	// when:
+	_, err := sut.ReconcileSpends(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, storage.tombstones, 1)
+	require.Equal(t, &engine.Output{Outpoint: spent.Outpoint, Topic: "tm_a"}, storage.tombstones[0].Output)
+	require.Equal(t, engine.TombstoneReasonSpentOffOverlay, storage.tombstones[0].Reason)
+	require.False(t, storage.tombstones[0].DeletedAt.Before(before))
+
+	tombstones, err := sut.FindTombstones(context.Background(), &engine.TombstoneQuery{Topic: "tm_a"})
+	require.NoError(t, err)
+	require.Equal(t, storage.tombstones, tombstones)
+}
+
+func TestEngine_ReconcileSpends_ShouldDeleteEvictedOutputs_WhenTombstonesAreDisabled(t *testing.T) {
+	// given:
+	spent := engine.Output{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{1}}, Topic: "tm_a", Score: 1}
+	var deleted []*transaction.Outpoint
+	storage := &fakeTombstoneStorage{fakeStorage: fakeStorage{
+		findUTXOsForTopicFunc: func(context.Context, string, float64, uint32, bool) ([]*engine.Output, error) {
+			return []*engine.Output{&spent}, nil
+		},
+		deleteOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ string) error {
+			deleted = append(deleted, outpoint)
+			return nil
+		},
+	}}
+	sut := newTombstoneEngine(storage, nil, spent)
+
+	// when:
+	_, err := sut.ReconcileSpends(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.Outpoint{&spent.Outpoint}, deleted)
+	require.Empty(t, storage.tombstones)
+}
+
+func TestEngine_PurgeTombstones_ShouldPurgeTombstonesOlderThanRetention(t *testing.T) {
+	// given:
+	storage := &fakeTombstoneStorage{tombstones: []*engine.Tombstone{{}, {}}}
+	sut := &engine.Engine{Storage: storage, Tombstones: &engine.TombstoneConfig{Enabled: true, Retention: time.Hour}}
+	before := time.Now()
+
+	// when:
+	purged, err := sut.PurgeTombstones(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, 2, purged)
+	require.WithinDuration(t, before.Add(-time.Hour), storage.deletedBefore, time.Second)
+}
+
+func TestEngine_Tombstones_ShouldReturnError_WhenStorageDoesNotSupportTombstones(t *testing.T) {
+	// given:
+	sut := &engine.Engine{Storage: fakeStorage{}, Tombstones: &engine.TombstoneConfig{Enabled: true}}
+
+	// when:
+	tombstones, findErr := sut.FindTombstones(context.Background(), nil)
+	purged, purgeErr := sut.PurgeTombstones(context.Background())
+
+	// then:
+	require.ErrorIs(t, findErr, engine.ErrTombstonesNotSupported)
+	require.Nil(t, tombstones)
+	require.ErrorIs(t, purgeErr, engine.ErrTombstonesNotSupported)
+	require.Zero(t, purged)
+}
@@ -0,0 +1,33 @@
+package engine_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+var errManagerFailure = errors.New("manager failure")
+
+func TestTopicManagerMetrics_Observe_ShouldRecordLatencyBucketsAndErrors(t *testing.T) {
+	// given:
+	sut := engine.NewTopicManagerMetrics(10*time.Millisecond, 100*time.Millisecond)
+
+	// when:
+	sut.Observe("tm_test", engine.TopicManagerOpIdentifyAdmissibleOutputs, 5*time.Millisecond, nil)
+	sut.Observe("tm_test", engine.TopicManagerOpIdentifyAdmissibleOutputs, 50*time.Millisecond, nil)
+	sut.Observe("tm_test", engine.TopicManagerOpIdentifyAdmissibleOutputs, time.Second, errManagerFailure)
+	sut.Observe("tm_test", engine.TopicManagerOpIdentifyNeededInputs, time.Millisecond, nil)
+
+	// then:
+	snapshot := sut.Snapshot()
+	admissible := snapshot["tm_test"][engine.TopicManagerOpIdentifyAdmissibleOutputs]
+	require.Equal(t, []uint64{1, 1, 1}, admissible.Counts)
+	require.Equal(t, uint64(3), admissible.Calls)
+	require.Equal(t, uint64(1), admissible.Errors)
+	require.InDelta(t, 1.0/3.0, admissible.ErrorRate(), 1e-9)
+	require.Equal(t, uint64(1), snapshot["tm_test"][engine.TopicManagerOpIdentifyNeededInputs].Calls)
+	require.Contains(t, sut.String(), `"tm_test"`)
+}
@@ -0,0 +1,228 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTopicManagerFactory = errors.New("unknown topic manager")
+
+func TestEngine_RegisterTopicManager_ShouldAdvertiseNewTopic(t *testing.T) {
+	// given
+	var advertised []*advertiser.AdvertisementData
+	sut := engine.NewEngine(engine.Engine{
+		HostingURL: "https://overlay.example.com",
+		Advertiser: fakeAdvertiser{
+			createAdvertisements: func(data []*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+				advertised = data
+				return overlay.TaggedBEEF{}, errCreateFailed
+			},
+		},
+	})
+	syncConfig := engine.SyncConfiguration{Type: engine.SyncConfigurationPeers, Peers: []string{"https://peer.example.com"}}
+
+	// when
+	err := sut.RegisterTopicManager(context.Background(), "tm_new", fakeTopicManager{}, syncConfig)
+
+	// then
+	require.NoError(t, err)
+	require.Contains(t, sut.ListTopicManagers(), "tm_new")
+	require.Equal(t, syncConfig, sut.SyncConfiguration["tm_new"])
+	require.Equal(t, []*advertiser.AdvertisementData{{Protocol: "SHIP", TopicOrServiceName: "tm_new"}}, advertised)
+}
+
+func TestEngine_RegisterTopicManager_ShouldFail_WhenAlreadyRegistered(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{"tm_existing": fakeTopicManager{}},
+	})
+
+	// when
+	err := sut.RegisterTopicManager(context.Background(), "tm_existing", fakeTopicManager{}, engine.SyncConfiguration{})
+
+	// then
+	require.ErrorIs(t, err, engine.ErrTopicManagerAlreadyRegistered)
+}
+
+func TestEngine_RegisterTopicManager_ShouldFail_WhenManagerIsNil(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{})
+
+	// when
+	err := sut.RegisterTopicManager(context.Background(), "tm_nil", nil, engine.SyncConfiguration{})
+
+	// then
+	require.ErrorIs(t, err, engine.ErrInvalidTopicManager)
+	require.Empty(t, sut.ListTopicManagers())
+}
+
+func TestEngine_UnregisterTopicManager_ShouldRevokeTopicAdvertisement(t *testing.T) {
+	// given
+	const hostingURL = "https://overlay.example.com"
+	advertisement := &advertiser.Advertisement{Protocol: "SHIP", TopicOrService: "tm_old", Domain: hostingURL}
+	var revoked []*advertiser.Advertisement
+	sut := engine.NewEngine(engine.Engine{
+		HostingURL: hostingURL,
+		Managers:   map[string]engine.TopicManager{"tm_old": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"tm_old": {Type: engine.SyncConfigurationNone},
+		},
+		Advertiser: fakeAdvertiser{
+			findAllAdvertisements: func(protocol overlay.Protocol) ([]*advertiser.Advertisement, error) {
+				if protocol == "SHIP" {
+					return []*advertiser.Advertisement{advertisement}, nil
+				}
+				return nil, nil
+			},
+			revokeAdvertisements: func(data []*advertiser.Advertisement) (overlay.TaggedBEEF, error) {
+				revoked = data
+				return overlay.TaggedBEEF{}, errRevokeFailed
+			},
+		},
+	})
+
+	// when
+	err := sut.UnregisterTopicManager(context.Background(), "tm_old")
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, sut.ListTopicManagers())
+	require.NotContains(t, sut.SyncConfiguration, "tm_old")
+	require.Equal(t, []*advertiser.Advertisement{advertisement}, revoked)
+}
+
+func TestEngine_UnregisterTopicManager_ShouldFail_WhenNotRegistered(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{})
+
+	// when
+	err := sut.UnregisterTopicManager(context.Background(), "tm_missing")
+
+	// then
+	require.ErrorIs(t, err, engine.ErrTopicManagerNotRegistered)
+}
+
+func TestEngine_AddTopicManager(t *testing.T) {
+	tests := map[string]struct {
+		factory       engine.TopicManagerFactory
+		expectedError error
+	}{
+		"Registers the topic manager built by the factory": {
+			factory: func(_ context.Context, _ string) (engine.TopicManager, error) {
+				return fakeTopicManager{}, nil
+			},
+		},
+		"Fails when the factory is not configured": {
+			expectedError: engine.ErrTopicManagerFactoryNotConfigured,
+		},
+		"Fails when the factory cannot build the topic manager": {
+			factory: func(_ context.Context, _ string) (engine.TopicManager, error) {
+				return nil, errTopicManagerFactory
+			},
+			expectedError: errTopicManagerFactory,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			sut := engine.NewEngine(engine.Engine{TopicManagerFactory: tc.factory})
+
+			// when
+			err := sut.AddTopicManager(context.Background(), "tm_factory", engine.SyncConfiguration{})
+
+			// then
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				require.Empty(t, sut.ListTopicManagers())
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, sut.ListTopicManagers(), "tm_factory")
+		})
+	}
+}
+
+func TestEngine_InstallTopicManager_ShouldRegisterWithoutAdvertising(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{
+		Advertiser: fakeAdvertiser{
+			createAdvertisements: func(_ []*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+				t.Fatal("installing a topic manager must not create advertisements")
+				return overlay.TaggedBEEF{}, nil
+			},
+		},
+		Managers:          map[string]engine.TopicManager{"tm_existing": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{"tm_new": {Type: engine.SyncConfigurationPeers}},
+	})
+
+	// when
+	err := sut.InstallTopicManager("tm_new", fakeTopicManager{})
+	duplicateErr := sut.InstallTopicManager("tm_existing", fakeTopicManager{})
+
+	// then
+	require.NoError(t, err)
+	require.ErrorIs(t, duplicateErr, engine.ErrTopicManagerAlreadyRegistered)
+	registry := sut.Registry()
+	require.Contains(t, registry.Managers, "tm_new")
+	require.Equal(t, engine.SyncConfiguration{Type: engine.SyncConfigurationPeers}, registry.SyncConfiguration["tm_new"])
+}
+
+func TestEngine_Registry_ShouldReturnSnapshotDetachedFromEngine(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{
+		Managers:          map[string]engine.TopicManager{"tm_a": fakeTopicManager{}},
+		LookupServices:    map[string]engine.LookupService{"ls_a": fakeLookupService{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{"tm_a": {Type: engine.SyncConfigurationPeers, Peers: []string{"https://peer.example.com"}}},
+	})
+
+	// when
+	registry := sut.Registry()
+	registry.SyncConfiguration["tm_a"].Peers[0] = "https://changed.example.com"
+	delete(registry.Managers, "tm_a")
+	require.NoError(t, sut.RegisterTopicManager(context.Background(), "tm_b", fakeTopicManager{}, engine.SyncConfiguration{}))
+
+	// then
+	require.Equal(t, map[string]engine.LookupService{"ls_a": fakeLookupService{}}, registry.LookupServices)
+	require.NotContains(t, registry.Managers, "tm_b")
+	require.Equal(t, []string{"https://peer.example.com"}, sut.Registry().SyncConfiguration["tm_a"].Peers)
+	require.Contains(t, sut.Registry().Managers, "tm_a")
+}
+
+func TestEngine_Registry_ShouldBeSafeForConcurrentRegistration(t *testing.T) {
+	// given
+	sut := engine.NewEngine(engine.Engine{})
+	ctx := context.Background()
+	var wg sync.WaitGroup
+
+	// when
+	for i := range 8 {
+		name := fmt.Sprintf("tm_%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, sut.RegisterTopicManager(ctx, name, fakeTopicManager{}, engine.SyncConfiguration{}))
+			assert.NoError(t, sut.SetSyncPeers(name, []string{"https://peer.example.com"}))
+		}()
+		go func() {
+			defer wg.Done()
+			_ = sut.Registry()
+			_ = sut.ListTopicManagers()
+		}()
+	}
+	wg.Wait()
+
+	// then
+	registry := sut.Registry()
+	require.Len(t, registry.Managers, 8)
+	require.Len(t, registry.SyncConfiguration, 8)
+}
@@ -0,0 +1,158 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sandboxFaultyTopic  = "tm_sandbox_faulty"
+	sandboxHealthyTopic = "tm_sandbox_healthy"
+)
+
+type sandboxFixture struct {
+	storage *testharness.MemoryStorage
+	builder *testutil.Builder
+	engine  engine.Engine
+}
+
+func newSandboxFixture(t *testing.T, faulty func(ctx context.Context) (overlay.AdmittanceInstructions, error)) *sandboxFixture {
+	t.Helper()
+	tracker := testutil.NewChainTracker(800000)
+	f := &sandboxFixture{
+		storage: testharness.NewMemoryStorage(),
+		builder: testutil.NewBuilder(t, tracker),
+	}
+	f.engine = engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			sandboxFaultyTopic: fakeManager{
+				identifyAdmissibleOutputsFunc: func(ctx context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return faulty(ctx)
+				},
+			},
+			sandboxHealthyTopic: &testharness.TopicManager{Topic: sandboxHealthyTopic},
+		},
+		Storage:      f.storage,
+		ChainTracker: tracker,
+	}
+	return f
+}
+
+func (f *sandboxFixture) submit(t *testing.T, sut *engine.Engine, tx *transaction.Transaction) overlay.Steak {
+	t.Helper()
+	taggedBEEF := overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, tx), Topics: []string{sandboxFaultyTopic, sandboxHealthyTopic}}
+	steak, err := sut.Submit(context.Background(), taggedBEEF, engine.SubmitModeCurrent, nil)
+	require.NoError(t, err)
+	return steak
+}
+
+func (f *sandboxFixture) requireFaultyTopicSkipped(t *testing.T, steak overlay.Steak, tx *transaction.Transaction) {
+	t.Helper()
+	require.Empty(t, steak[sandboxFaultyTopic].OutputsToAdmit)
+	testutil.RequireAdmitted(t, steak, sandboxHealthyTopic, 0)
+
+	exists, err := f.storage.DoesAppliedTransactionExist(context.Background(), &overlay.AppliedTransaction{Txid: tx.TxID(), Topic: sandboxFaultyTopic})
+	require.NoError(t, err)
+	require.False(t, exists, "the transaction must not be applied to the faulty topic")
+	exists, err = f.storage.DoesAppliedTransactionExist(context.Background(), &overlay.AppliedTransaction{Txid: tx.TxID(), Topic: sandboxHealthyTopic})
+	require.NoError(t, err)
+	require.True(t, exists, "the transaction must be applied to the healthy topic")
+}
+
+func TestEngine_Submit_ShouldIsolatePanickingTopicManagers_WithoutSandbox(t *testing.T) {
+	// given:
+	f := newSandboxFixture(t, func(context.Context) (overlay.AdmittanceInstructions, error) {
+		panic("corrupt script")
+	})
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+
+	// when:
+	steak := f.submit(t, sut, tx)
+
+	// then:
+	f.requireFaultyTopicSkipped(t, steak, tx)
+}
+
+func TestEngine_Submit_ShouldAbandonHangingTopicManagers_WhenTheirTimeoutExpires(t *testing.T) {
+	// given:
+	release := make(chan struct{})
+	defer close(release)
+	f := newSandboxFixture(t, func(context.Context) (overlay.AdmittanceInstructions, error) {
+		<-release
+		return overlay.AdmittanceInstructions{}, nil
+	})
+	f.engine.ManagerSandbox = engine.NewTopicManagerSandbox(engine.TopicManagerSandboxConfig{
+		Topics: map[string]engine.TopicManagerLimits{sandboxFaultyTopic: {Timeout: 50 * time.Millisecond}},
+	})
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+
+	// when:
+	started := time.Now()
+	steak := f.submit(t, sut, tx)
+
+	// then:
+	require.Less(t, time.Since(started), 5*time.Second)
+	f.requireFaultyTopicSkipped(t, steak, tx)
+}
+
+func TestEngine_Submit_ShouldAbandonTopicManagers_WhenTheHeapGrowsBeyondTheirMemoryLimit(t *testing.T) {
+	// given:
+	f := newSandboxFixture(t, func(ctx context.Context) (overlay.AdmittanceInstructions, error) {
+		held := make([]byte, 64<<20)
+		for i := range held {
+			held[i] = byte(i)
+		}
+		<-ctx.Done()
+		runtime.KeepAlive(held)
+		return overlay.AdmittanceInstructions{}, ctx.Err()
+	})
+	f.engine.ManagerSandbox = engine.NewTopicManagerSandbox(engine.TopicManagerSandboxConfig{
+		Default: engine.TopicManagerLimits{Timeout: time.Minute, MaxMemory: 8 << 20},
+	})
+	sut := engine.NewEngine(f.engine)
+	tx := f.builder.Mined(800001, 1000)
+
+	// when:
+	steak := f.submit(t, sut, tx)
+
+	// then:
+	f.requireFaultyTopicSkipped(t, steak, tx)
+}
+
+func TestTopicManagerSandbox_ShouldFallBackToTheDefaultLimits(t *testing.T) {
+	// given:
+	sut := engine.NewTopicManagerSandbox(engine.TopicManagerSandboxConfig{
+		Default: engine.TopicManagerLimits{MaxMemory: 1 << 20},
+		Topics:  map[string]engine.TopicManagerLimits{sandboxFaultyTopic: {Timeout: time.Second}},
+	})
+
+	// when:
+	topicLimits := sut.Limits(sandboxFaultyTopic)
+	defaultLimits := sut.Limits(sandboxHealthyTopic)
+
+	// then:
+	require.Equal(t, engine.TopicManagerLimits{Timeout: time.Second}, topicLimits)
+	require.Equal(t, engine.TopicManagerLimits{Timeout: engine.DefaultTopicManagerTimeout, MaxMemory: 1 << 20}, defaultLimits)
+}
+
+func TestTopicManagerFault_ShouldMatchItsCause(t *testing.T) {
+	// given:
+	var err error = &engine.TopicManagerFault{Topic: sandboxFaultyTopic, Op: engine.TopicManagerOpIdentifyNeededInputs, Cause: engine.ErrTopicManagerTimeout}
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrTopicManagerFault)
+	require.ErrorIs(t, err, engine.ErrTopicManagerTimeout)
+	require.False(t, errors.Is(err, engine.ErrTopicManagerPanic))
+}
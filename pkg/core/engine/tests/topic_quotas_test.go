@@ -0,0 +1,156 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+// createOutputsBEEF creates a BEEF whose transaction has a P2PKH output of each of the given values.
+func createOutputsBEEF(t *testing.T, satoshis ...uint64) []byte {
+	t.Helper()
+
+	spec := testabilities.GivenTX().WithInput(100_000)
+	for _, value := range satoshis {
+		spec = spec.WithP2PKHOutput(value)
+	}
+	tx := spec.TX()
+
+	beef, err := transaction.NewBeefFromTransaction(tx)
+	require.NoError(t, err)
+	bytes, err := beef.AtomicBytes(tx.TxID())
+	require.NoError(t, err)
+	return bytes
+}
+
+// newQuotaEngine returns an engine whose topic manager admits every output of the submitted transaction.
+func newQuotaEngine(outputs int, quotas engine.TopicQuotasConfig) *engine.Engine {
+	sut := newEvaluateEngine(false)
+	sut.Managers["test-topic"] = fakeManager{
+		identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+			admit := make([]uint32, 0, outputs)
+			for vout := range outputs {
+				admit = append(admit, uint32(vout)) //nolint:gosec // test output count is small
+			}
+			return overlay.AdmittanceInstructions{OutputsToAdmit: admit}, nil
+		},
+	}
+	sut.OutputQuotas = engine.NewTopicQuotas(quotas)
+	return sut
+}
+
+func TestEngine_Evaluate_ShouldRejectOutputsExceedingTopicQuota(t *testing.T) {
+	// given:
+	sut := newQuotaEngine(3, engine.TopicQuotasConfig{Default: engine.TopicQuota{MaxOutputsPerTx: 2}})
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createOutputsBEEF(t, 100, 100, 100)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrTopicQuotaExceeded)
+	require.Nil(t, steak)
+}
+
+func TestEngine_Evaluate_ShouldTruncateOutputsExceedingSatoshiQuota(t *testing.T) {
+	// given:
+	sut := newQuotaEngine(3, engine.TopicQuotasConfig{
+		Topics: map[string]engine.TopicQuota{
+			"test-topic": {MaxSatoshis: 300, Action: engine.TopicQuotaActionTruncate},
+		},
+	})
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createOutputsBEEF(t, 100, 500, 200)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 2}, steak["test-topic"].OutputsToAdmit)
+}
+
+func TestEngine_Evaluate_ShouldTruncateOutputsExceedingTopicOutputQuota(t *testing.T) {
+	// given:
+	sut := newQuotaEngine(3, engine.TopicQuotasConfig{Default: engine.TopicQuota{MaxOutputs: 10, Action: engine.TopicQuotaActionTruncate}})
+	sut.Storage = &fakeTopicStatsStorage{
+		fakeStorage: sut.Storage.(fakeStorage),
+		counts:      map[string]*engine.TopicOutputCounts{"test-topic": {Total: 9}},
+	}
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createOutputsBEEF(t, 100, 100, 100)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0}, steak["test-topic"].OutputsToAdmit)
+}
+
+func TestEngine_Evaluate_ShouldAdmitOutputsWithinTopicQuota(t *testing.T) {
+	// given:
+	sut := newQuotaEngine(3, engine.TopicQuotasConfig{Default: engine.TopicQuota{MaxOutputsPerTx: 3, MaxSatoshis: 300}})
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createOutputsBEEF(t, 100, 100, 100)}
+
+	// when:
+	steak, err := sut.Evaluate(context.Background(), taggedBEEF)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 1, 2}, steak["test-topic"].OutputsToAdmit)
+}
+
+func TestEngine_SetTopicQuota_ShouldOverrideConfiguredQuota(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	sut := newQuotaEngine(3, engine.TopicQuotasConfig{Default: engine.TopicQuota{MaxOutputsPerTx: 1}})
+	taggedBEEF := overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createOutputsBEEF(t, 100, 100, 100)}
+	override := engine.TopicQuota{MaxOutputsPerTx: 2, Action: engine.TopicQuotaActionTruncate}
+
+	// when:
+	err := sut.SetTopicQuota(ctx, "test-topic", override)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, map[string]engine.TopicQuota{"test-topic": override}, sut.TopicQuotaSettings(ctx).Overrides)
+	steak, err := sut.Evaluate(ctx, taggedBEEF)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 1}, steak["test-topic"].OutputsToAdmit)
+
+	// when:
+	err = sut.RemoveTopicQuota(ctx, "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.Empty(t, sut.TopicQuotaSettings(ctx).Overrides)
+	_, err = sut.Evaluate(ctx, taggedBEEF)
+	require.ErrorIs(t, err, engine.ErrTopicQuotaExceeded)
+}
+
+func TestEngine_SetTopicQuota_ShouldRejectInvalidOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown topic", func(t *testing.T) {
+		sut := newQuotaEngine(1, engine.TopicQuotasConfig{})
+		require.ErrorIs(t, sut.SetTopicQuota(ctx, "unknown", engine.TopicQuota{}), engine.ErrUnknownTopic)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		sut := newQuotaEngine(1, engine.TopicQuotasConfig{})
+		require.ErrorIs(t, sut.SetTopicQuota(ctx, "test-topic", engine.TopicQuota{Action: "drop"}), engine.ErrInvalidTopicQuota)
+	})
+
+	t.Run("topic quotas not configured", func(t *testing.T) {
+		sut := newEvaluateEngine(false)
+		require.ErrorIs(t, sut.SetTopicQuota(ctx, "test-topic", engine.TopicQuota{}), engine.ErrTopicQuotasNotConfigured)
+	})
+
+	t.Run("topic without override", func(t *testing.T) {
+		sut := newQuotaEngine(1, engine.TopicQuotasConfig{})
+		require.ErrorIs(t, sut.RemoveTopicQuota(ctx, "test-topic"), engine.ErrTopicQuotaNotOverridden)
+	})
+}
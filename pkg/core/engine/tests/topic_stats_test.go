@@ -0,0 +1,135 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTopicStatsStorage struct {
+	fakeStorage
+	counts       map[string]*engine.TopicOutputCounts
+	interactions map[string]map[string]float64
+	lastSync     time.Time
+}
+
+func (f *fakeTopicStatsStorage) CountTopicOutputs(_ context.Context, topic string) (*engine.TopicOutputCounts, error) {
+	if counts, ok := f.counts[topic]; ok {
+		return counts, nil
+	}
+	return &engine.TopicOutputCounts{}, nil
+}
+
+func (f *fakeTopicStatsStorage) FindLastInteractions(_ context.Context, topic string) (map[string]float64, error) {
+	return f.interactions[topic], nil
+}
+
+func (f *fakeTopicStatsStorage) UpdateLastAdvertisementSync(_ context.Context, at time.Time) error {
+	f.lastSync = at
+	return nil
+}
+
+func (f *fakeTopicStatsStorage) GetLastAdvertisementSync(_ context.Context) (time.Time, error) {
+	return f.lastSync, nil
+}
+
+func TestEngine_TopicStats_ShouldAggregateEveryHostedTopic(t *testing.T) {
+	// given:
+	lastSync := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	storage := &fakeTopicStatsStorage{
+		counts: map[string]*engine.TopicOutputCounts{
+			"tm_b": {Total: 5, Unspent: 3, Spent: 2, Unmined: 1},
+		},
+		interactions: map[string]map[string]float64{
+			"tm_b": {"https://peer.example": 42},
+		},
+		lastSync: lastSync,
+	}
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"tm_b": fakeTopicManager{}, "tm_a": fakeTopicManager{}},
+		Storage:  storage,
+	}
+
+	// when:
+	stats, err := sut.TopicStats(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.OverlayStats{
+		Topics: []*engine.TopicStats{
+			{Topic: "tm_a", PeerInteractions: map[string]float64{}},
+			{
+				Topic:            "tm_b",
+				Outputs:          engine.TopicOutputCounts{Total: 5, Unspent: 3, Spent: 2, Unmined: 1},
+				PeerInteractions: map[string]float64{"https://peer.example": 42},
+			},
+		},
+		LastAdvertisementSync: lastSync,
+	}, stats)
+}
+
+func TestEngine_TopicStats_ShouldFail_WhenStorageDoesNotSupportStats(t *testing.T) {
+	// given:
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"tm_a": fakeTopicManager{}},
+		Storage:  fakeStorage{},
+	}
+
+	// when:
+	stats, err := sut.TopicStats(context.Background())
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrTopicStatsNotSupported)
+	require.Nil(t, stats)
+}
+
+func TestEngine_SyncAdvertisements_ShouldRecordSyncTime(t *testing.T) {
+	// given:
+	storage := &fakeTopicStatsStorage{}
+	sut := &engine.Engine{
+		Advertiser: fakeAdvertiser{
+			findAllAdvertisementsFunc: func(_ overlay.Protocol) ([]*advertiser.Advertisement, error) {
+				return []*advertiser.Advertisement{}, nil
+			},
+		},
+		Storage: storage,
+	}
+	before := time.Now()
+
+	// when:
+	err := sut.SyncAdvertisements(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.False(t, storage.lastSync.Before(before))
+}
+
+func TestEngine_SyncAdvertisements_ShouldNotRecordSyncTime_WhenCreateAdvertisementsFails(t *testing.T) {
+	// given:
+	storage := &fakeTopicStatsStorage{}
+	sut := &engine.Engine{
+		Advertiser: fakeAdvertiser{
+			findAllAdvertisementsFunc: func(_ overlay.Protocol) ([]*advertiser.Advertisement, error) {
+				return []*advertiser.Advertisement{}, nil
+			},
+			createAdvertisementsFunc: func(_ []*advertiser.AdvertisementData) (overlay.TaggedBEEF, error) {
+				return overlay.TaggedBEEF{}, errCreateFailed
+			},
+		},
+		Managers:   map[string]engine.TopicManager{"tm_a": fakeTopicManager{}},
+		HostingURL: "http://localhost",
+		Storage:    storage,
+	}
+
+	// when:
+	err := sut.SyncAdvertisements(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, storage.lastSync.IsZero())
+}
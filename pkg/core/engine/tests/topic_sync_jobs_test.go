@@ -0,0 +1,127 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/stretchr/testify/require"
+)
+
+func awaitTopicSyncJob(t *testing.T, sut *engine.Engine, id string) *engine.TopicSyncJob {
+	t.Helper()
+	var job *engine.TopicSyncJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = sut.FindTopicSyncJob(context.Background(), id)
+		require.NoError(t, err)
+		return job.Status != engine.TopicSyncJobRunning
+	}, time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestEngine_SyncTopic_ShouldSyncTheTopicWithItsConfiguredPeers(t *testing.T) {
+	// given:
+	configured := newGASPPeer(t, nil)
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}, "other-topic": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic":  {Type: engine.SyncConfigurationPeers, Peers: []string{configured}},
+			"other-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{configured}},
+		},
+		Storage: newGASPSyncStorage(),
+	})
+
+	// when:
+	job, err := sut.SyncTopic(context.Background(), engine.TopicSyncRequest{Topic: "test-topic"})
+
+	// then:
+	require.NoError(t, err)
+	require.NotEmpty(t, job.ID)
+	require.Equal(t, "test-topic", job.Topic)
+
+	completed := awaitTopicSyncJob(t, sut, job.ID)
+	require.Equal(t, engine.TopicSyncJobCompleted, completed.Status)
+	require.Equal(t, "test-topic", completed.Report.Topic)
+	require.Equal(t, []string{configured}, completed.Report.Succeeded)
+	require.False(t, completed.CompletedAt.IsZero())
+}
+
+func TestEngine_SyncTopic_ShouldSyncTheRequestedPeersFromTheRequestedScore(t *testing.T) {
+	// given:
+	requests := make(chan gasp.InitialRequest, 1)
+	requested := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request gasp.InitialRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests <- request
+		_ = json.NewEncoder(w).Encode(&gasp.InitialResponse{UTXOList: []*gasp.Output{}})
+	}))
+	t.Cleanup(requested.Close)
+	configured := newGASPPeer(t, func(w http.ResponseWriter) bool {
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	})
+	since := 42.0
+	sut := engine.NewEngine(engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{
+			"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{configured}},
+		},
+		Storage: newGASPSyncStorage(),
+	})
+
+	// when:
+	job, err := sut.SyncTopic(context.Background(), engine.TopicSyncRequest{Topic: "test-topic", Peers: []string{requested.URL}, Since: &since})
+
+	// then:
+	require.NoError(t, err)
+	completed := awaitTopicSyncJob(t, sut, job.ID)
+	require.Equal(t, engine.TopicSyncJobCompleted, completed.Status)
+	require.Equal(t, []string{requested.URL}, completed.Report.Succeeded)
+	require.Empty(t, completed.Report.Failed)
+	require.InDelta(t, since, (<-requests).Since, 0)
+}
+
+func TestEngine_SyncTopic_ShouldFail(t *testing.T) {
+	tests := map[string]struct {
+		sut         *engine.Engine
+		expectedErr error
+	}{
+		"for a topic the engine does not host": {
+			sut:         engine.NewEngine(engine.Engine{Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}}}),
+			expectedErr: engine.ErrUnknownTopic,
+		},
+		"without topic sync jobs": {
+			sut:         &engine.Engine{Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}}},
+			expectedErr: engine.ErrTopicSyncJobsNotConfigured,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			job, err := tc.sut.SyncTopic(context.Background(), engine.TopicSyncRequest{Topic: "unknown-topic"})
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, job)
+		})
+	}
+}
+
+func TestEngine_FindTopicSyncJob_ShouldFail_WhenTheJobIsUnknown(t *testing.T) {
+	// given:
+	sut := engine.NewEngine(engine.Engine{})
+
+	// when:
+	job, err := sut.FindTopicSyncJob(context.Background(), "unknown-job")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrTopicSyncJobNotFound)
+	require.Nil(t, job)
+}
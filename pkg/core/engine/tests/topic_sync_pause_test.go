@@ -0,0 +1,127 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSyncPauseStorage is an in-memory TopicSyncPauseStorage layered on top of fakeStorage.
+type fakeSyncPauseStorage struct {
+	fakeStorage
+
+	paused map[string]bool
+}
+
+func (f *fakeSyncPauseStorage) UpdateTopicSyncPaused(_ context.Context, topic string, paused bool) error {
+	f.paused[topic] = paused
+	return nil
+}
+
+func (f *fakeSyncPauseStorage) FindPausedSyncTopics(_ context.Context) ([]string, error) {
+	var topics []string
+	for topic, paused := range f.paused {
+		if paused {
+			topics = append(topics, topic)
+		}
+	}
+	return topics, nil
+}
+
+func TestEngine_PauseTopicSync_ShouldPersistPause(t *testing.T) {
+	// given:
+	storage := &fakeSyncPauseStorage{paused: make(map[string]bool)}
+	sut := &engine.Engine{
+		Managers:          map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{"test-topic": {Type: engine.SyncConfigurationPeers, Peers: []string{"http://peer"}}},
+		Storage:           storage,
+	}
+
+	// when:
+	err := sut.PauseTopicSync(context.Background(), "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, storage.paused["test-topic"])
+	require.Equal(t, []string{"test-topic"}, sut.PausedSyncTopics(context.Background()))
+	require.Equal(t, []string{"http://peer"}, sut.SyncConfiguration["test-topic"].Peers)
+
+	// when:
+	err = sut.ResumeTopicSync(context.Background(), "test-topic")
+
+	// then:
+	require.NoError(t, err)
+	require.False(t, storage.paused["test-topic"])
+	require.Empty(t, sut.PausedSyncTopics(context.Background()))
+}
+
+func TestEngine_PauseTopicSync_ShouldFail_WhenTopicUnknown(t *testing.T) {
+	// given:
+	storage := &fakeSyncPauseStorage{paused: make(map[string]bool)}
+	sut := &engine.Engine{Storage: storage}
+
+	// when:
+	err := sut.PauseTopicSync(context.Background(), "test-topic")
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Empty(t, storage.paused)
+}
+
+func TestEngine_Start_ShouldRestorePausedTopicSyncs(t *testing.T) {
+	// given:
+	storage := &fakeSyncPauseStorage{paused: map[string]bool{"test-topic": true, "unmanaged-topic": true}}
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		Storage:  storage,
+	}
+
+	// when:
+	err := sut.Start(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{"test-topic"}, sut.PausedSyncTopics(context.Background()))
+}
+
+func TestEngine_StartGASPSync_ShouldSkipPausedTopic(t *testing.T) {
+	// given:
+	resolver := LookupResolverMock{}
+	sut := engine.NewEngine(engine.Engine{
+		Managers:          map[string]engine.TopicManager{"test-topic": fakeManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{"test-topic": {Type: engine.SyncConfigurationSHIP}},
+		LookupResolver:    &resolver,
+		Storage:           &fakeStorage{},
+	})
+	require.NoError(t, sut.PauseTopicSync(context.Background(), "test-topic"))
+
+	// when:
+	err := sut.StartGASPSync(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.False(t, resolver.QueryCalled)
+	require.False(t, resolver.SetTrackersCalled)
+}
+
+func TestEngine_PlanAdvertisements_ShouldSkipPausedTopic(t *testing.T) {
+	// given:
+	sut := newPlannedEngine(engine.Engine{
+		Advertiser: fakeAdvertiser{findAllAdvertisements: func(_ overlay.Protocol) ([]*advertiser.Advertisement, error) {
+			return nil, nil
+		}},
+	})
+	require.NoError(t, sut.PauseTopicSync(context.Background(), "tm_a"))
+
+	// when:
+	plan, err := sut.PlanAdvertisements(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []*advertiser.AdvertisementData{{Protocol: overlay.ProtocolSLAP, TopicOrServiceName: "ls_a"}}, plan.Create)
+	require.Empty(t, plan.Revoke)
+}
@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrackerStorage is an in-memory TrackerStorage layered on top of fakeStorage.
+type fakeTrackerStorage struct {
+	fakeStorage
+
+	trackers map[engine.TrackerKind][]string
+}
+
+func (f *fakeTrackerStorage) UpdateTrackers(_ context.Context, kind engine.TrackerKind, trackers []string) error {
+	f.trackers[kind] = trackers
+	return nil
+}
+
+func (f *fakeTrackerStorage) FindTrackers(_ context.Context, kind engine.TrackerKind) ([]string, bool, error) {
+	trackers, found := f.trackers[kind]
+	return trackers, found, nil
+}
+
+func TestEngine_AddTracker_ShouldPersistAndApplyTrackers(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	storage := &fakeTrackerStorage{trackers: make(map[engine.TrackerKind][]string)}
+	resolver := engine.NewLookupResolver()
+	sut := engine.NewEngine(engine.Engine{
+		Managers:          map[string]engine.TopicManager{"tm_slap": fakeManager{}},
+		SyncConfiguration: map[string]engine.SyncConfiguration{"tm_slap": {Type: engine.SyncConfigurationPeers, Peers: []string{"https://peer"}}},
+		SLAPTrackers:      []string{"https://old-tracker"},
+		LookupResolver:    resolver,
+		Storage:           storage,
+	})
+
+	// when:
+	err := sut.AddTracker(ctx, engine.TrackerKindSLAP, "https://new-tracker")
+
+	// then:
+	require.NoError(t, err)
+	expected := []string{"https://old-tracker", "https://new-tracker"}
+	require.Equal(t, expected, storage.trackers[engine.TrackerKindSLAP])
+	require.Equal(t, engine.Trackers{SLAP: expected}, sut.Trackers(ctx))
+	require.Equal(t, expected, resolver.SLAPTrackers())
+	require.ElementsMatch(t, []string{"https://peer", "https://old-tracker", "https://new-tracker"}, sut.Registry().SyncConfiguration["tm_slap"].Peers)
+
+	// when:
+	err = sut.RemoveTracker(ctx, engine.TrackerKindSLAP, "https://old-tracker")
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://new-tracker"}, storage.trackers[engine.TrackerKindSLAP])
+	require.Equal(t, []string{"https://new-tracker"}, resolver.SLAPTrackers())
+	require.ElementsMatch(t, []string{"https://peer", "https://new-tracker"}, sut.Registry().SyncConfiguration["tm_slap"].Peers)
+}
+
+func TestEngine_AddTracker_ShouldRejectInvalidChanges(t *testing.T) {
+	tests := map[string]struct {
+		change      func(ctx context.Context, sut *engine.Engine) error
+		expectedErr error
+	}{
+		"unknown kind": {
+			change: func(ctx context.Context, sut *engine.Engine) error {
+				return sut.AddTracker(ctx, "gasp", "https://tracker")
+			},
+			expectedErr: engine.ErrInvalidTracker,
+		},
+		"URL without scheme": {
+			change: func(ctx context.Context, sut *engine.Engine) error {
+				return sut.AddTracker(ctx, engine.TrackerKindSHIP, "tracker")
+			},
+			expectedErr: engine.ErrInvalidTracker,
+		},
+		"duplicate tracker": {
+			change: func(ctx context.Context, sut *engine.Engine) error {
+				return sut.AddTracker(ctx, engine.TrackerKindSHIP, "https://tracker")
+			},
+			expectedErr: engine.ErrTrackerAlreadyConfigured,
+		},
+		"tracker not configured": {
+			change: func(ctx context.Context, sut *engine.Engine) error {
+				return sut.RemoveTracker(ctx, engine.TrackerKindSLAP, "https://tracker")
+			},
+			expectedErr: engine.ErrTrackerNotConfigured,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			storage := &fakeTrackerStorage{trackers: make(map[engine.TrackerKind][]string)}
+			sut := &engine.Engine{SHIPTrackers: []string{"https://tracker"}, Storage: storage}
+
+			// when:
+			err := tc.change(context.Background(), sut)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Empty(t, storage.trackers)
+			require.Equal(t, engine.Trackers{SHIP: []string{"https://tracker"}}, sut.Trackers(context.Background()))
+		})
+	}
+}
+
+func TestEngine_Start_ShouldRestorePersistedTrackers(t *testing.T) {
+	// given:
+	storage := &fakeTrackerStorage{trackers: map[engine.TrackerKind][]string{engine.TrackerKindSHIP: {}}}
+	sut := &engine.Engine{
+		SHIPTrackers: []string{"https://configured-ship"},
+		SLAPTrackers: []string{"https://configured-slap"},
+		Storage:      storage,
+	}
+
+	// when:
+	err := sut.Start(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, engine.Trackers{SHIP: []string{}, SLAP: []string{"https://configured-slap"}}, sut.Trackers(context.Background()))
+}
@@ -0,0 +1,171 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_EvictStaleUnmined_ShouldRebroadcastThenEvict(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	txid := &chainhash.Hash{1}
+	consumedOutpoint := &transaction.Outpoint{Txid: chainhash.Hash{2}, Index: 0}
+	output := &engine.Output{
+		Outpoint:        transaction.Outpoint{Txid: *txid, Index: 0},
+		Topic:           "tm_a",
+		Beef:            createDummyBEEF(t),
+		OutputsConsumed: []*transaction.Outpoint{consumedOutpoint},
+		ReceivedAt:      time.Now().Add(-2 * time.Hour),
+	}
+	consumed := &engine.Output{
+		Outpoint:   *consumedOutpoint,
+		Topic:      "tm_a",
+		Spent:      true,
+		ConsumedBy: []*transaction.Outpoint{&output.Outpoint, {Txid: chainhash.Hash{3}, Index: 0}},
+	}
+
+	var deleted []*transaction.Outpoint
+	var consumedBy []*transaction.Outpoint
+	var broadcasts int
+	lookupService := &fakeEvictionLookupService{}
+	sut := &engine.Engine{
+		Storage: &fakeUnminedStorage{
+			fakeStorage: fakeStorage{
+				findOutputsForTransaction: func(_ context.Context, _ *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error) {
+					require.True(t, includeBEEF)
+					return []*engine.Output{output}, nil
+				},
+				findOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+					require.Equal(t, consumedOutpoint, outpoint)
+					return consumed, nil
+				},
+				updateConsumedByFunc: func(_ context.Context, outpoint *transaction.Outpoint, _ string, outpoints []*transaction.Outpoint) error {
+					require.Equal(t, consumedOutpoint, outpoint)
+					consumedBy = outpoints
+					return nil
+				},
+				deleteOutputFunc: func(_ context.Context, outpoint *transaction.Outpoint, topic string) error {
+					require.Equal(t, "tm_a", topic)
+					deleted = append(deleted, outpoint)
+					return nil
+				},
+			},
+			unmined: []*chainhash.Hash{txid},
+		},
+		Broadcaster: fakeBroadcasterFail{
+			broadcastCtxFunc: func(context.Context, *transaction.Transaction) (*transaction.BroadcastSuccess, *transaction.BroadcastFailure) {
+				broadcasts++
+				return &transaction.BroadcastSuccess{}, nil
+			},
+		},
+		LookupServices:  map[string]engine.LookupService{"ls_a": lookupService},
+		UnminedEviction: &engine.UnminedEvictor{MaxAge: time.Nanosecond, MaxRebroadcasts: 1},
+	}
+
+	// when:
+	first, firstErr := sut.EvictStaleUnmined(ctx)
+	time.Sleep(time.Millisecond)
+	second, secondErr := sut.EvictStaleUnmined(ctx)
+
+	// then:
+	require.NoError(t, firstErr)
+	require.Equal(t, &engine.UnminedEvictionReport{Scanned: 1, Rebroadcast: []*chainhash.Hash{txid}}, first)
+	require.Equal(t, 1, broadcasts)
+
+	require.NoError(t, secondErr)
+	require.Equal(t, &engine.UnminedEvictionReport{Scanned: 1, Evicted: []*transaction.Outpoint{&output.Outpoint}}, second)
+	require.Equal(t, 1, broadcasts)
+	require.Equal(t, []*transaction.Outpoint{&output.Outpoint}, deleted)
+	require.Equal(t, []*transaction.Outpoint{{Txid: chainhash.Hash{3}, Index: 0}}, consumedBy)
+	require.Equal(t, []*transaction.Outpoint{&output.Outpoint}, lookupService.evicted)
+}
+
+func TestEngine_EvictStaleUnmined_ShouldKeepTransactionsYoungerThanMaxAge(t *testing.T) {
+	// given:
+	txid := &chainhash.Hash{1}
+	sut := &engine.Engine{
+		Storage: &fakeUnminedStorage{
+			fakeStorage: fakeStorage{
+				findOutputsForTransaction: func(context.Context, *chainhash.Hash, bool) ([]*engine.Output, error) {
+					return []*engine.Output{{Outpoint: transaction.Outpoint{Txid: *txid}, Topic: "tm_a", ReceivedAt: time.Now()}}, nil
+				},
+			},
+			unmined: []*chainhash.Hash{txid},
+		},
+		UnminedEviction: &engine.UnminedEvictor{MaxAge: time.Hour},
+	}
+
+	// when:
+	report, err := sut.EvictStaleUnmined(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.UnminedEvictionReport{Scanned: 1, Pending: 1}, report)
+}
+
+func TestEngine_EvictStaleUnmined_ShouldApplyProofFoundOnRecheck(t *testing.T) {
+	// given:
+	txid := &chainhash.Hash{1}
+	isTxid := true
+	source := &fakeProofSource{proofs: map[chainhash.Hash]*transaction.MerklePath{
+		*txid: transaction.NewMerklePath(800000, [][]*transaction.PathElement{{{Offset: 0, Hash: txid, Txid: &isTxid}}}),
+	}}
+	var lookups int
+	sut := &engine.Engine{
+		Storage: &fakeUnminedStorage{
+			fakeStorage: fakeStorage{
+				findOutputsForTransaction: func(context.Context, *chainhash.Hash, bool) ([]*engine.Output, error) {
+					lookups++
+					if lookups > 1 {
+						return nil, nil
+					}
+					return []*engine.Output{{Outpoint: transaction.Outpoint{Txid: *txid}, Topic: "tm_a", ReceivedAt: time.Now().Add(-time.Hour)}}, nil
+				},
+			},
+			unmined: []*chainhash.Hash{txid},
+		},
+		UnminedEviction: &engine.UnminedEvictor{MaxAge: time.Minute, Recheck: source},
+	}
+
+	// when:
+	report, err := sut.EvictStaleUnmined(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, &engine.UnminedEvictionReport{Scanned: 1, Mined: []*chainhash.Hash{txid}}, report)
+	require.Equal(t, []*chainhash.Hash{txid}, source.fetched)
+	require.Equal(t, 2, lookups)
+}
+
+func TestEngine_EvictStaleUnmined_ShouldReturnError_WhenNotConfigured(t *testing.T) {
+	tests := map[string]struct {
+		engine      *engine.Engine
+		expectedErr error
+	}{
+		"no unmined eviction": {
+			engine:      &engine.Engine{Storage: &fakeUnminedStorage{}},
+			expectedErr: engine.ErrUnminedEvictionNotConfigured,
+		},
+		"storage cannot find unmined transactions": {
+			engine:      &engine.Engine{Storage: fakeStorage{}, UnminedEviction: &engine.UnminedEvictor{}},
+			expectedErr: engine.ErrUnminedLookupNotSupported,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			report, err := tc.engine.EvictStaleUnmined(context.Background())
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, report)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryEngine returns an engine hosting test-topic whose storage holds the given output, if any.
+func newHistoryEngine(output *engine.Output, limits *engine.UTXOHistoryLimits) *engine.Engine {
+	return &engine.Engine{
+		Managers: map[string]engine.TopicManager{"test-topic": fakeTopicManager{}},
+		Storage: fakeStorage{
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return output, nil
+			},
+		},
+		HistoryLimits: limits,
+	}
+}
+
+func TestEngine_FindUTXOHistory_ShouldReturnOutputWithBEEF(t *testing.T) {
+	// given:
+	beef := createDummyBEEF(t)
+	outpoint := transaction.Outpoint{Txid: *parseBEEFToTx(t, beef).TxID(), Index: 0}
+	sut := newHistoryEngine(&engine.Output{Outpoint: outpoint, Topic: "test-topic", Beef: beef}, nil)
+
+	// when:
+	history, err := sut.FindUTXOHistory(context.Background(), engine.UTXOHistoryQuery{Outpoint: outpoint, Topic: "test-topic"})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, beef, history.Beef)
+}
+
+func TestEngine_FindUTXOHistory_ShouldFail_WhenDepthExceedsLimit(t *testing.T) {
+	// given:
+	depth := uint32(11)
+	sut := newHistoryEngine(nil, &engine.UTXOHistoryLimits{MaxDepth: 10})
+
+	// when:
+	history, err := sut.FindUTXOHistory(context.Background(), engine.UTXOHistoryQuery{Topic: "test-topic", Depth: &depth})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUTXOHistoryDepthExceeded)
+	require.Nil(t, history)
+}
+
+func TestEngine_FindUTXOHistory_ShouldFail_WhenHistoryExceedsSizeLimit(t *testing.T) {
+	// given:
+	beef := createDummyBEEF(t)
+	sut := newHistoryEngine(&engine.Output{Topic: "test-topic", Beef: beef}, &engine.UTXOHistoryLimits{MaxBytes: len(beef) - 1})
+
+	// when:
+	history, err := sut.FindUTXOHistory(context.Background(), engine.UTXOHistoryQuery{Topic: "test-topic"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUTXOHistoryTooLarge)
+	require.Nil(t, history)
+}
+
+func TestEngine_FindUTXOHistory_ShouldFail_WhenOutputNotFound(t *testing.T) {
+	// given:
+	sut := newHistoryEngine(nil, nil)
+
+	// when:
+	history, err := sut.FindUTXOHistory(context.Background(), engine.UTXOHistoryQuery{Topic: "test-topic"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnableToFindOutput)
+	require.Nil(t, history)
+}
+
+func TestEngine_FindUTXOHistory_ShouldFail_WhenTopicUnknown(t *testing.T) {
+	// given:
+	sut := newHistoryEngine(nil, nil)
+
+	// when:
+	history, err := sut.FindUTXOHistory(context.Background(), engine.UTXOHistoryQuery{Topic: "unknown-topic"})
+
+	// then:
+	require.ErrorIs(t, err, engine.ErrUnknownTopic)
+	require.Nil(t, history)
+}
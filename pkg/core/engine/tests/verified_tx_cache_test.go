@@ -0,0 +1,97 @@
+package engine_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifiedTxCache_Contains_ShouldMatchTheVerifiedBlock(t *testing.T) {
+	// given:
+	cache := engine.NewVerifiedTxCache(engine.VerifiedTxCacheConfig{})
+	mined := newMinedTx(1)
+	unmined := newSpendingTx(&script.Script{script.OpTRUE}, mined)
+
+	// when:
+	cache.Add(*mined.TxID(), mined.MerklePath)
+	cache.Add(*unmined.TxID(), nil)
+
+	// then:
+	require.True(t, cache.Contains(*mined.TxID(), mined.MerklePath))
+	require.True(t, cache.Contains(*mined.TxID(), nil))
+	require.True(t, cache.Contains(*unmined.TxID(), nil))
+
+	otherBlock := transaction.NewMerklePath(101, mined.MerklePath.Path)
+	require.False(t, cache.Contains(*mined.TxID(), otherBlock), "a proof into another block should be verified again")
+	require.False(t, cache.Contains(*unmined.TxID(), mined.MerklePath), "a newly mined transaction should have its proof verified")
+}
+
+func TestVerifiedTxCache_Add_ShouldForgetTheLeastRecentlyUsedTxids(t *testing.T) {
+	// given:
+	cache := engine.NewVerifiedTxCache(engine.VerifiedTxCacheConfig{MaxEntries: 2})
+	first, second, third := newMinedTx(1), newMinedTx(2), newMinedTx(3)
+	cache.Add(*first.TxID(), first.MerklePath)
+	cache.Add(*second.TxID(), second.MerklePath)
+	require.True(t, cache.Contains(*first.TxID(), first.MerklePath))
+
+	// when:
+	cache.Add(*third.TxID(), third.MerklePath)
+
+	// then:
+	require.Equal(t, 2, cache.Len())
+	require.True(t, cache.Contains(*first.TxID(), first.MerklePath))
+	require.False(t, cache.Contains(*second.TxID(), second.MerklePath))
+	require.True(t, cache.Contains(*third.TxID(), third.MerklePath))
+}
+
+func TestVerifiedTxCache_InvalidateFromHeight_ShouldForgetReorgedAndUnminedTxids(t *testing.T) {
+	// given:
+	cache := engine.NewVerifiedTxCache(engine.VerifiedTxCacheConfig{})
+	kept := newMinedTx(1)
+	reorged := newMinedTx(2)
+	reorged.MerklePath = transaction.NewMerklePath(200, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: reorged.TxID(), Txid: ptr(true)},
+	}})
+	unmined := newSpendingTx(&script.Script{script.OpTRUE}, reorged)
+	cache.Add(*kept.TxID(), kept.MerklePath)
+	cache.Add(*reorged.TxID(), reorged.MerklePath)
+	cache.Add(*unmined.TxID(), nil)
+
+	// when:
+	cache.InvalidateFromHeight(150)
+
+	// then:
+	require.Equal(t, 1, cache.Len())
+	require.True(t, cache.Contains(*kept.TxID(), kept.MerklePath))
+	require.False(t, cache.Contains(*reorged.TxID(), reorged.MerklePath))
+	require.False(t, cache.Contains(*unmined.TxID(), nil))
+}
+
+func TestSPVVerifier_Verify_ShouldReverifyAfterReorg(t *testing.T) {
+	// given:
+	var calls atomic.Int32
+	tracker := countingChainTracker(true, &calls)
+	verifier := engine.NewSPVVerifier(engine.SPVVerifierConfig{Workers: 2})
+	verified := engine.NewVerifiedTxCache(engine.VerifiedTxCacheConfig{})
+	tx := newSpendingTx(&script.Script{script.OpTRUE}, newMinedTx(1))
+
+	_, err := verifier.Verify(context.Background(), tx, tracker, verified)
+	require.NoError(t, err)
+	_, err = verifier.Verify(context.Background(), tx, tracker, verified)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), calls.Load())
+
+	// when:
+	verified.InvalidateFromHeight(100)
+	valid, err := verifier.Verify(context.Background(), tx, tracker, verified)
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, valid)
+	require.Equal(t, int32(2), calls.Load())
+}
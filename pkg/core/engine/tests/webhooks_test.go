@@ -0,0 +1,149 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Submit_NotifiesWebhooks(t *testing.T) {
+	// given:
+	type delivery struct {
+		header       http.Header
+		body         []byte
+		notification engine.WebhookNotification
+	}
+	delivered := make(chan delivery, 1)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var notification engine.WebhookNotification
+		require.NoError(t, json.Unmarshal(body, &notification))
+		delivered <- delivery{header: r.Header, body: body, notification: notification}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer subscriber.Close()
+
+	ctx := context.Background()
+	sut := &engine.Engine{
+		Managers: map[string]engine.TopicManager{
+			"test-topic": fakeManager{
+				identifyAdmissibleOutputsFunc: func(_ context.Context, _ []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+					return overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}, nil
+				},
+			},
+		},
+		Storage: fakeStorage{
+			deleteOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ string) error {
+				return nil
+			},
+			findOutputFunc: func(_ context.Context, _ *transaction.Outpoint, _ *string, _ *bool, _ bool) (*engine.Output, error) {
+				return &engine.Output{}, nil
+			},
+			findOutputsFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *bool, _ bool) ([]*engine.Output, error) {
+				return []*engine.Output{{}}, nil
+			},
+			doesAppliedTransactionExistFunc: func(_ context.Context, _ *overlay.AppliedTransaction) (bool, error) {
+				return false, nil
+			},
+			markUTXOsAsSpentFunc: func(_ context.Context, _ []*transaction.Outpoint, _ string, _ *chainhash.Hash) error {
+				return nil
+			},
+			insertOutputFunc: func(_ context.Context, _ *engine.Output) error {
+				return nil
+			},
+			insertAppliedTransactionFunc: func(_ context.Context, _ *overlay.AppliedTransaction) error {
+				return nil
+			},
+		},
+		ChainTracker: fakeChainTracker{
+			isValidRootForHeight: func(_ context.Context, _ *chainhash.Hash, _ uint32) (bool, error) {
+				return true, nil
+			},
+		},
+		Webhooks: engine.NewWebhooks(engine.WebhooksConfig{}),
+	}
+	subscription, err := sut.RegisterWebhook(ctx, subscriber.URL, []string{"test-topic"}, "secret")
+	require.NoError(t, err)
+
+	// when:
+	steak, err := sut.Submit(ctx, overlay.TaggedBEEF{Topics: []string{"test-topic"}, Beef: createDummyBEEF(t)}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	select {
+	case got := <-delivered:
+		require.Equal(t, subscription.ID, got.header.Get(engine.WebhookIDHeader))
+		require.Equal(t, engine.SignWebhookPayload("secret", got.body), got.header.Get(engine.WebhookSignatureHeader))
+		require.Equal(t, steak["test-topic"].OutputsToAdmit, got.notification.Steak["test-topic"].OutputsToAdmit)
+		require.NotEmpty(t, got.notification.Txid)
+	case <-time.After(time.Second):
+		t.Fatal("STEAK was not delivered to the webhook")
+	}
+}
+
+func TestEngine_Webhooks_Subscriptions(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		// given:
+		sut := &engine.Engine{}
+
+		// when:
+		_, registerErr := sut.RegisterWebhook(context.Background(), "https://example.com", nil, "")
+		_, listErr := sut.ListWebhooks(context.Background())
+		unregisterErr := sut.UnregisterWebhook(context.Background(), "id")
+
+		// then:
+		require.ErrorIs(t, registerErr, engine.ErrWebhooksNotConfigured)
+		require.ErrorIs(t, listErr, engine.ErrWebhooksNotConfigured)
+		require.ErrorIs(t, unregisterErr, engine.ErrWebhooksNotConfigured)
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		// given:
+		sut := &engine.Engine{
+			Managers: map[string]engine.TopicManager{"test-topic": fakeManager{}},
+			Webhooks: engine.NewWebhooks(engine.WebhooksConfig{}),
+		}
+
+		// when:
+		_, urlErr := sut.RegisterWebhook(context.Background(), "ftp://example.com", nil, "")
+		_, topicErr := sut.RegisterWebhook(context.Background(), "https://example.com", []string{"unknown-topic"}, "")
+
+		// then:
+		require.ErrorIs(t, urlErr, engine.ErrInvalidWebhookURL)
+		require.ErrorIs(t, topicErr, engine.ErrUnknownTopic)
+	})
+
+	t.Run("register, list and unregister", func(t *testing.T) {
+		// given:
+		sut := &engine.Engine{
+			Managers: map[string]engine.TopicManager{"test-topic": fakeManager{}},
+			Webhooks: engine.NewWebhooks(engine.WebhooksConfig{}),
+		}
+
+		// when:
+		subscription, err := sut.RegisterWebhook(context.Background(), "https://example.com", []string{"test-topic"}, "")
+		require.NoError(t, err)
+		listed, err := sut.ListWebhooks(context.Background())
+		require.NoError(t, err)
+
+		// then:
+		require.Len(t, subscription.Secret, 64)
+		require.Len(t, listed, 1)
+		require.Equal(t, subscription.ID, listed[0].ID)
+		require.Empty(t, listed[0].Secret)
+
+		require.NoError(t, sut.UnregisterWebhook(context.Background(), subscription.ID))
+		require.ErrorIs(t, sut.UnregisterWebhook(context.Background(), subscription.ID), engine.ErrWebhookNotFound)
+	})
+}
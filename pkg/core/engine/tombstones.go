@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultTombstoneRetention is how long tombstoned outputs are kept when no retention is configured.
+	DefaultTombstoneRetention = 7 * 24 * time.Hour
+
+	// DefaultTombstonePurgeInterval is how often tombstones are purged when no interval is configured.
+	DefaultTombstonePurgeInterval = time.Hour
+)
+
+// ErrTombstonesNotSupported is returned when querying or purging tombstones with a storage that does not implement OutputTombstoneStorage
+var ErrTombstonesNotSupported = errors.New("storage does not support tombstoning outputs")
+
+// TombstoneReason records why the engine deleted an output.
+type TombstoneReason string
+
+const (
+	// TombstoneReasonNoLongerRetained marks outputs spent without being retained by the topic manager,
+	// or purged from the history beyond RetentionPolicy.HistoryRetentionDepth.
+	TombstoneReasonNoLongerRetained TombstoneReason = "no-longer-retained"
+	// TombstoneReasonDisputeLost marks outputs of transactions that lost a dispute under ConflictPolicyDispute.
+	TombstoneReasonDisputeLost TombstoneReason = "dispute-lost"
+	// TombstoneReasonSpentOffOverlay marks outputs evicted by the SpendReconciler after being spent outside the overlay.
+	TombstoneReasonSpentOffOverlay TombstoneReason = "spent-off-overlay"
+	// TombstoneReasonUnminedEvicted marks outputs of transactions evicted by the UnminedEvictor.
+	TombstoneReasonUnminedEvicted TombstoneReason = "unmined-evicted"
+)
+
+// TombstoneConfig enables the soft deletion of outputs.
+type TombstoneConfig struct {
+	// Enabled makes the engine tombstone the outputs it deletes instead of removing them, when the storage
+	// implements OutputTombstoneStorage.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Retention is how long tombstoned outputs are kept before they are purged. Zero falls back to DefaultTombstoneRetention.
+	Retention time.Duration `mapstructure:"retention"`
+
+	// PurgeInterval is how often the background purger runs. Zero falls back to DefaultTombstonePurgeInterval.
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// Tombstone is an output deleted by the engine and kept by the storage until purged.
+type Tombstone struct {
+	Output    *Output
+	Reason    TombstoneReason
+	DeletedAt time.Time
+}
+
+// TombstoneQuery selects tombstones. Zero fields match every tombstone.
+type TombstoneQuery struct {
+	Topic        string
+	Outpoint     *transaction.Outpoint
+	Reason       TombstoneReason
+	DeletedAfter time.Time
+
+	// Limit caps the number of returned tombstones. Zero returns them all.
+	Limit int
+}
+
+// OutputTombstoneStorage is an optional Storage capability used to soft-delete outputs, so that outputs
+// removed because of a buggy topic manager or admission can be inspected and recovered until they are purged.
+type OutputTombstoneStorage interface {
+	// TombstoneOutput deletes the output admitted into the topic as DeleteOutput would, hiding it from every
+	// other read of the storage, but keeps it with its BEEF, the reason and the time of the deletion.
+	TombstoneOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string, reason TombstoneReason, deletedAt time.Time) error
+
+	// FindTombstones returns the tombstones matching the query, oldest deletion first.
+	FindTombstones(ctx context.Context, query *TombstoneQuery) ([]*Tombstone, error)
+
+	// PurgeTombstones deletes the tombstones of outputs deleted before the given time, together with their BEEF
+	// when no other output references it, and returns how many were purged.
+	PurgeTombstones(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// TombstoneFinder gives lookup services access to the outputs deleted by the engine, e.g. to explain why an
+// output they indexed disappeared. Engine implements it.
+type TombstoneFinder interface {
+	FindTombstones(ctx context.Context, query *TombstoneQuery) ([]*Tombstone, error)
+}
+
+// FindTombstones returns the outputs deleted by the engine and kept by the storage, oldest deletion first.
+// Returns ErrTombstonesNotSupported when the storage does not implement OutputTombstoneStorage.
+func (e *Engine) FindTombstones(ctx context.Context, query *TombstoneQuery) ([]*Tombstone, error) {
+	tombstones, ok := storageCapability[OutputTombstoneStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot find tombstones", "error", ErrTombstonesNotSupported)
+		return nil, ErrTombstonesNotSupported
+	}
+	if query == nil {
+		query = &TombstoneQuery{}
+	}
+	found, err := tombstones.FindTombstones(ctx, query)
+	if err != nil {
+		slog.Error("failed to find tombstones", "topic", query.Topic, "error", err)
+		return nil, err
+	}
+	return found, nil
+}
+
+// PurgeTombstones deletes the tombstones older than TombstoneConfig.Retention and returns how many were purged.
+// Returns ErrTombstonesNotSupported when the storage does not implement OutputTombstoneStorage.
+func (e *Engine) PurgeTombstones(ctx context.Context) (int, error) {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting PurgeTombstones while stopping", "error", err)
+		return 0, err
+	}
+	defer done()
+	tombstones, ok := storageCapability[OutputTombstoneStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot purge tombstones", "error", ErrTombstonesNotSupported)
+		return 0, ErrTombstonesNotSupported
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting PurgeTombstones in degraded mode", "error", err)
+		return 0, err
+	}
+
+	deletedBefore := time.Now().Add(-e.tombstoneRetention())
+	purged, err := tombstones.PurgeTombstones(ctx, deletedBefore)
+	if err := e.trackWrite(err); err != nil {
+		slog.Error("failed to purge tombstones", "deletedBefore", deletedBefore, "error", err)
+		return 0, err
+	}
+	e.replicate(&Mutation{Op: MutationPurgeTombstones, DeletedAt: deletedBefore})
+	slog.Info("tombstones purged", "purged", purged, "deletedBefore", deletedBefore)
+	return purged, nil
+}
+
+// RunTombstonePurger purges tombstones every TombstoneConfig.PurgeInterval until ctx is done or the engine stops.
+// It returns immediately when tombstoning is not enabled. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunTombstonePurger(ctx context.Context) {
+	if !e.tombstonesEnabled() {
+		return
+	}
+	interval := e.Tombstones.PurgeInterval
+	if interval <= 0 {
+		interval = DefaultTombstonePurgeInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.PurgeTombstones(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled tombstone purge failed", "interval", interval, "error", err)
+		}
+	}
+}
+
+func (e *Engine) tombstonesEnabled() bool {
+	return e.Tombstones != nil && e.Tombstones.Enabled
+}
+
+func (e *Engine) tombstoneRetention() time.Duration {
+	if e.Tombstones == nil || e.Tombstones.Retention <= 0 {
+		return DefaultTombstoneRetention
+	}
+	return e.Tombstones.Retention
+}
+
+// removeOutput deletes the output admitted into the topic from the storage, tombstoning it with the reason
+// when tombstoning is enabled and the storage supports it.
+func (e *Engine) removeOutput(ctx context.Context, storage Storage, outpoint *transaction.Outpoint, topic string, reason TombstoneReason) error {
+	if !e.tombstonesEnabled() {
+		return e.trackWrite(storage.DeleteOutput(ctx, outpoint, topic))
+	}
+	tombstones, ok := storageCapability[OutputTombstoneStorage](storage)
+	if !ok {
+		return e.trackWrite(storage.DeleteOutput(ctx, outpoint, topic))
+	}
+	deletedAt := time.Now()
+	if err := e.trackWrite(tombstones.TombstoneOutput(ctx, outpoint, topic, reason, deletedAt)); err != nil {
+		return err
+	}
+	e.replicate(&Mutation{Op: MutationTombstoneOutput, Outpoint: outpoint, Topic: topic, Reason: reason, DeletedAt: deletedAt})
+	return nil
+}
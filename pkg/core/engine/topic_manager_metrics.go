@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Topic manager operations recorded by TopicManagerMetrics.
+const (
+	TopicManagerOpIdentifyAdmissibleOutputs = "IdentifyAdmissibleOutputs"
+	TopicManagerOpIdentifyNeededInputs      = "IdentifyNeededInputs"
+)
+
+// DefaultTopicManagerLatencyBuckets are the latency histogram upper bounds used when none are configured.
+var DefaultTopicManagerLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// LatencyHistogram is a snapshot of the recorded latencies and errors of a single topic manager operation.
+// Counts[i] holds the calls that took at most Buckets[i]; the final element holds slower calls.
+type LatencyHistogram struct {
+	Buckets []time.Duration `json:"buckets"`
+	Counts  []uint64        `json:"counts"`
+	Calls   uint64          `json:"calls"`
+	Errors  uint64          `json:"errors"`
+	Total   time.Duration   `json:"total"`
+}
+
+// ErrorRate returns the fraction of calls that returned an error.
+func (h LatencyHistogram) ErrorRate() float64 {
+	if h.Calls == 0 {
+		return 0
+	}
+	return float64(h.Errors) / float64(h.Calls)
+}
+
+func newLatencyHistogram(buckets []time.Duration) *LatencyHistogram {
+	return &LatencyHistogram{Buckets: buckets, Counts: make([]uint64, len(buckets)+1)}
+}
+
+// observe records a single call in the bucket of its latency.
+func (h *LatencyHistogram) observe(latency time.Duration, err error) {
+	bucket := len(h.Buckets)
+	for i, upper := range h.Buckets {
+		if latency <= upper {
+			bucket = i
+			break
+		}
+	}
+	h.Counts[bucket]++
+	h.Calls++
+	h.Total += latency
+	if err != nil {
+		h.Errors++
+	}
+}
+
+// clone returns a copy of the histogram that does not share its counts.
+func (h *LatencyHistogram) clone() LatencyHistogram {
+	copied := *h
+	copied.Counts = append([]uint64(nil), h.Counts...)
+	return copied
+}
+
+// TopicManagerMetrics records per-topic-manager latency and error histograms for the engine's
+// IdentifyAdmissibleOutputs and IdentifyNeededInputs calls. It is safe for concurrent use and
+// implements expvar.Var, so it can be published with expvar.Publish.
+type TopicManagerMetrics struct {
+	buckets []time.Duration
+
+	mu         sync.Mutex
+	histograms map[string]map[string]*LatencyHistogram
+}
+
+// NewTopicManagerMetrics creates TopicManagerMetrics using the given ascending bucket upper bounds,
+// or DefaultTopicManagerLatencyBuckets when none are given.
+func NewTopicManagerMetrics(buckets ...time.Duration) *TopicManagerMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultTopicManagerLatencyBuckets
+	}
+	return &TopicManagerMetrics{
+		buckets:    buckets,
+		histograms: make(map[string]map[string]*LatencyHistogram),
+	}
+}
+
+// Observe records a single call of the operation on the topic's manager.
+func (m *TopicManagerMetrics) Observe(topic, op string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.histograms == nil {
+		m.histograms = make(map[string]map[string]*LatencyHistogram)
+	}
+	if len(m.buckets) == 0 {
+		m.buckets = DefaultTopicManagerLatencyBuckets
+	}
+	ops, ok := m.histograms[topic]
+	if !ok {
+		ops = make(map[string]*LatencyHistogram)
+		m.histograms[topic] = ops
+	}
+	h, ok := ops[op]
+	if !ok {
+		h = newLatencyHistogram(m.buckets)
+		ops[op] = h
+	}
+	h.observe(latency, err)
+}
+
+// Snapshot returns a copy of the recorded histograms keyed by topic and operation.
+func (m *TopicManagerMetrics) Snapshot() map[string]map[string]LatencyHistogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]map[string]LatencyHistogram, len(m.histograms))
+	for topic, ops := range m.histograms {
+		snapshot[topic] = make(map[string]LatencyHistogram, len(ops))
+		for op, h := range ops {
+			snapshot[topic][op] = h.clone()
+		}
+	}
+	return snapshot
+}
+
+// String returns the JSON encoded snapshot, implementing expvar.Var.
+func (m *TopicManagerMetrics) String() string {
+	bb, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(bb)
+}
+
+// identifyAdmissibleOutputs calls the topic's manager under ManagerSandbox, recording the call in ManagerMetrics
+// when configured.
+func (e *Engine) identifyAdmissibleOutputs(ctx context.Context, topic string, beef []byte, previousCoins map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	manager, ok := e.topicManager(topic)
+	if !ok {
+		return overlay.AdmittanceInstructions{}, ErrUnknownTopic
+	}
+	started := time.Now()
+	admit, err := sandboxCall(ctx, e.ManagerSandbox, topic, TopicManagerOpIdentifyAdmissibleOutputs, func(ctx context.Context) (overlay.AdmittanceInstructions, error) {
+		return manager.IdentifyAdmissibleOutputs(ctx, beef, previousCoins)
+	})
+	if e.ManagerMetrics != nil {
+		e.ManagerMetrics.Observe(topic, TopicManagerOpIdentifyAdmissibleOutputs, time.Since(started), err)
+	}
+	return admit, err
+}
+
+// identifyNeededInputs calls the topic's manager under ManagerSandbox, recording the call in ManagerMetrics when
+// configured.
+func (e *Engine) identifyNeededInputs(ctx context.Context, topic string, beef []byte) ([]*transaction.Outpoint, error) {
+	manager, ok := e.topicManager(topic)
+	if !ok {
+		return nil, ErrUnknownTopic
+	}
+	started := time.Now()
+	inputs, err := sandboxCall(ctx, e.ManagerSandbox, topic, TopicManagerOpIdentifyNeededInputs, func(ctx context.Context) ([]*transaction.Outpoint, error) {
+		return manager.IdentifyNeededInputs(ctx, beef)
+	})
+	if e.ManagerMetrics != nil {
+		e.ManagerMetrics.Observe(topic, TopicManagerOpIdentifyNeededInputs, time.Since(started), err)
+	}
+	return inputs, err
+}
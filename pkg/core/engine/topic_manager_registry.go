@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrTopicManagerAlreadyRegistered is returned when registering a topic manager under a name already in use
+	ErrTopicManagerAlreadyRegistered = errors.New("topic manager already registered")
+	// ErrTopicManagerNotRegistered is returned when unregistering a topic manager that is not registered
+	ErrTopicManagerNotRegistered = errors.New("topic manager not registered")
+	// ErrTopicManagerFactoryNotConfigured is returned when adding a topic manager by name without a TopicManagerFactory
+	ErrTopicManagerFactoryNotConfigured = errors.New("topic manager factory not configured")
+	// ErrInvalidTopicManager is returned when registering a nil topic manager or one with an empty name
+	ErrInvalidTopicManager = errors.New("invalid topic manager")
+)
+
+// TopicManagerFactory builds the topic manager registered under the given name.
+// It lets operators enable topic managers compiled into the node at runtime, e.g. over the admin API.
+type TopicManagerFactory func(ctx context.Context, name string) (TopicManager, error)
+
+// sharedRegistryMu guards the registry of the engines built without NewEngine, which have no lock of their own.
+var sharedRegistryMu sync.RWMutex
+
+// registryLock returns the lock guarding the Managers, SyncConfiguration and LookupServices maps and the trackers
+// of the engine against runtime registration. The lock is held through a pointer set by NewEngine, so that engines
+// keep being safe to construct and copy by value.
+func (e *Engine) registryLock() *sync.RWMutex {
+	if e.registryMu != nil {
+		return e.registryMu
+	}
+	return &sharedRegistryMu
+}
+
+// topicManager returns the topic manager registered under the given name.
+func (e *Engine) topicManager(name string) (TopicManager, bool) {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	manager, ok := e.Managers[name]
+	return manager, ok
+}
+
+// topicManagers returns the registered topic managers. Registration replaces the map
+// instead of mutating it, so the returned map is safe to range over without holding the lock.
+func (e *Engine) topicManagers() map[string]TopicManager {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	return e.Managers
+}
+
+// syncConfigurations returns the sync configuration of every topic. Like topicManagers,
+// the returned map is never mutated by registration.
+func (e *Engine) syncConfigurations() map[string]SyncConfiguration {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	return e.SyncConfiguration
+}
+
+// syncConfiguration returns the sync configuration of the topic.
+func (e *Engine) syncConfiguration(topic string) (SyncConfiguration, bool) {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	config, ok := e.SyncConfiguration[topic]
+	return config, ok
+}
+
+// Registry is a consistent snapshot of the topic managers, lookup services and sync configuration of an engine.
+type Registry struct {
+	Managers          map[string]TopicManager
+	LookupServices    map[string]LookupService
+	SyncConfiguration map[string]SyncConfiguration
+}
+
+// Registry returns a snapshot of the registered topic managers, lookup services and sync configuration,
+// taken at once so that they agree with each other. The snapshot is a copy: it is not updated by later
+// registry changes, and changing it does not affect the engine.
+func (e *Engine) Registry() Registry {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+
+	syncConfigs := make(map[string]SyncConfiguration, len(e.SyncConfiguration))
+	for topic, config := range e.SyncConfiguration {
+		config.Peers = slices.Clone(config.Peers)
+		config.PeerRemotes = maps.Clone(config.PeerRemotes)
+		syncConfigs[topic] = config
+	}
+	return Registry{
+		Managers:          maps.Clone(e.Managers),
+		LookupServices:    maps.Clone(e.LookupServices),
+		SyncConfiguration: syncConfigs,
+	}
+}
+
+// RegisterTopicManager adds a topic manager to the running engine, together with the sync configuration of its topic,
+// and synchronizes the engine's SHIP advertisements so that peers learn about the new topic.
+func (e *Engine) RegisterTopicManager(ctx context.Context, name string, manager TopicManager, syncConfig SyncConfiguration) error {
+	if name == "" || manager == nil {
+		slog.Error("invalid topic manager in RegisterTopicManager", "topic", name, "error", ErrInvalidTopicManager)
+		return ErrInvalidTopicManager
+	}
+
+	e.registryLock().Lock()
+	if _, ok := e.Managers[name]; ok {
+		e.registryLock().Unlock()
+		slog.Error("topic manager already registered", "topic", name, "error", ErrTopicManagerAlreadyRegistered)
+		return ErrTopicManagerAlreadyRegistered
+	}
+	managers := maps.Clone(e.Managers)
+	if managers == nil {
+		managers = make(map[string]TopicManager)
+	}
+	managers[name] = manager
+	syncConfigs := maps.Clone(e.SyncConfiguration)
+	if syncConfigs == nil {
+		syncConfigs = make(map[string]SyncConfiguration)
+	}
+	syncConfigs[name] = syncConfig
+	e.Managers, e.SyncConfiguration = managers, syncConfigs
+	e.registryLock().Unlock()
+
+	slog.Info("topic manager registered", "topic", name)
+	e.syncRegisteredAdvertisements(ctx)
+	return nil
+}
+
+// InstallTopicManager adds a topic manager to an engine that is not started yet, leaving its sync configuration
+// unchanged and its SHIP advertisement to be created when the engine synchronizes its advertisements.
+func (e *Engine) InstallTopicManager(name string, manager TopicManager) error {
+	if name == "" || manager == nil {
+		slog.Error("invalid topic manager in InstallTopicManager", "topic", name, "error", ErrInvalidTopicManager)
+		return ErrInvalidTopicManager
+	}
+
+	e.registryLock().Lock()
+	defer e.registryLock().Unlock()
+	if _, ok := e.Managers[name]; ok {
+		slog.Error("topic manager already registered", "topic", name, "error", ErrTopicManagerAlreadyRegistered)
+		return ErrTopicManagerAlreadyRegistered
+	}
+	managers := maps.Clone(e.Managers)
+	if managers == nil {
+		managers = make(map[string]TopicManager)
+	}
+	managers[name] = manager
+	e.Managers = managers
+	return nil
+}
+
+// AddTopicManager builds the named topic manager with the TopicManagerFactory and registers it.
+func (e *Engine) AddTopicManager(ctx context.Context, name string, syncConfig SyncConfiguration) error {
+	if e.TopicManagerFactory == nil {
+		slog.Error("cannot add topic manager", "topic", name, "error", ErrTopicManagerFactoryNotConfigured)
+		return ErrTopicManagerFactoryNotConfigured
+	}
+	manager, err := e.TopicManagerFactory(ctx, name)
+	if err != nil {
+		slog.Error("failed to build topic manager", "topic", name, "error", err)
+		return err
+	}
+	return e.RegisterTopicManager(ctx, name, manager, syncConfig)
+}
+
+// UnregisterTopicManager removes a topic manager and the sync configuration of its topic from the running engine,
+// and synchronizes the engine's SHIP advertisements so that the topic's advertisement is revoked.
+// Outputs already admitted to the topic are kept in storage.
+func (e *Engine) UnregisterTopicManager(ctx context.Context, name string) error {
+	e.registryLock().Lock()
+	if _, ok := e.Managers[name]; !ok {
+		e.registryLock().Unlock()
+		slog.Error("topic manager not registered", "topic", name, "error", ErrTopicManagerNotRegistered)
+		return ErrTopicManagerNotRegistered
+	}
+	managers := maps.Clone(e.Managers)
+	delete(managers, name)
+	syncConfigs := maps.Clone(e.SyncConfiguration)
+	delete(syncConfigs, name)
+	e.Managers, e.SyncConfiguration = managers, syncConfigs
+	e.registryLock().Unlock()
+
+	slog.Info("topic manager unregistered", "topic", name)
+	e.syncRegisteredAdvertisements(ctx)
+	return nil
+}
+
+// SetSyncPeers replaces the peers the topic is synced with through GASP, leaving the rest of its sync configuration unchanged.
+// Returns ErrTopicManagerNotRegistered when no topic manager is registered for the topic.
+func (e *Engine) SetSyncPeers(topic string, peers []string) error {
+	e.registryLock().Lock()
+	defer e.registryLock().Unlock()
+	if _, ok := e.Managers[topic]; !ok {
+		slog.Error("cannot set sync peers", "topic", topic, "error", ErrTopicManagerNotRegistered)
+		return ErrTopicManagerNotRegistered
+	}
+	syncConfigs := maps.Clone(e.SyncConfiguration)
+	if syncConfigs == nil {
+		syncConfigs = make(map[string]SyncConfiguration)
+	}
+	config := syncConfigs[topic]
+	config.Peers = slices.Clone(peers)
+	syncConfigs[topic] = config
+	e.SyncConfiguration = syncConfigs
+	slog.Info("sync peers changed", "topic", topic, "peers", len(peers))
+	return nil
+}
+
+// SetPeerRemotes replaces the per-peer HTTP client settings used by the GASP syncs of every topic.
+func (e *Engine) SetPeerRemotes(remotes map[string]GASPRemoteConfig) {
+	e.registryLock().Lock()
+	defer e.registryLock().Unlock()
+	syncConfigs := make(map[string]SyncConfiguration, len(e.SyncConfiguration))
+	for topic, config := range e.SyncConfiguration {
+		config.PeerRemotes = maps.Clone(remotes)
+		syncConfigs[topic] = config
+	}
+	e.SyncConfiguration = syncConfigs
+	slog.Info("sync peer remotes changed", "peers", len(remotes))
+}
+
+// syncRegisteredAdvertisements brings the advertisements in line with a changed registry.
+// A failure is only logged since the registry change has already taken effect.
+func (e *Engine) syncRegisteredAdvertisements(ctx context.Context) {
+	if err := e.SyncAdvertisements(ctx); err != nil {
+		slog.Error("failed to sync advertisements after registry change", "error", err)
+	}
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"runtime/metrics"
+	"time"
+)
+
+// DefaultTopicManagerTimeout bounds a topic manager call when the sandbox limits configure no timeout.
+const DefaultTopicManagerTimeout = 30 * time.Second
+
+// topicManagerMemorySampleInterval is how often the sandbox samples the heap while a call with a memory limit runs.
+const topicManagerMemorySampleInterval = 10 * time.Millisecond
+
+// heapObjectsMetric is the runtime metric holding the bytes of live and not yet swept heap objects.
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+var (
+	// ErrTopicManagerFault is returned when a topic manager call panics, times out or exceeds its memory limit
+	ErrTopicManagerFault = errors.New("topic manager fault")
+	// ErrTopicManagerPanic is returned when a topic manager call panics
+	ErrTopicManagerPanic = errors.New("topic manager panicked")
+	// ErrTopicManagerTimeout is returned when a topic manager call exceeds its timeout
+	ErrTopicManagerTimeout = errors.New("topic manager timed out")
+	// ErrTopicManagerMemoryExceeded is returned when the heap grows beyond the memory limit during a topic manager call
+	ErrTopicManagerMemoryExceeded = errors.New("topic manager exceeded its memory limit")
+)
+
+// TopicManagerFault describes a topic manager call that panicked, timed out or exceeded its memory limit.
+// Submit treats it as a failed admission in the topic of the manager only: the topic admits nothing and the
+// other topics of the submission are unaffected.
+type TopicManagerFault struct {
+	// Topic is the topic of the faulty manager.
+	Topic string
+	// Op is the topic manager operation, TopicManagerOpIdentifyAdmissibleOutputs or TopicManagerOpIdentifyNeededInputs.
+	Op string
+	// Cause is ErrTopicManagerPanic, ErrTopicManagerTimeout or ErrTopicManagerMemoryExceeded.
+	Cause error
+	// Panic holds the recovered value of a panicking call.
+	Panic any
+}
+
+func (f *TopicManagerFault) Error() string {
+	if f.Panic != nil {
+		return fmt.Sprintf("%s: %s of topic %q: %v", f.Cause, f.Op, f.Topic, f.Panic)
+	}
+	return fmt.Sprintf("%s: %s of topic %q", f.Cause, f.Op, f.Topic)
+}
+
+// Unwrap returns ErrTopicManagerFault and the cause so that callers can match the error with errors.Is.
+func (f *TopicManagerFault) Unwrap() []error { return []error{ErrTopicManagerFault, f.Cause} }
+
+// TopicManagerLimits bounds the calls of a topic manager.
+type TopicManagerLimits struct {
+	// Timeout bounds a single IdentifyAdmissibleOutputs or IdentifyNeededInputs call. The context of the call
+	// is canceled when it expires; a manager ignoring it is abandoned and keeps running in the background until
+	// it returns. Zero falls back to DefaultTopicManagerTimeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxMemory bounds, in bytes, the growth of the heap during a single call. The Go runtime cannot attribute
+	// memory to a goroutine, so the guard is best-effort: it samples the heap of the whole process and concurrent
+	// work counts against the limit. Zero disables the guard.
+	MaxMemory uint64 `mapstructure:"max_memory"`
+}
+
+// TopicManagerSandboxConfig holds the limits of the engine's topic managers.
+type TopicManagerSandboxConfig struct {
+	// Default applies to the topics without limits of their own.
+	Default TopicManagerLimits `mapstructure:"default"`
+
+	// Topics maps topic names to their limits, replacing the default ones.
+	Topics map[string]TopicManagerLimits `mapstructure:"topics"`
+}
+
+// TopicManagerSandbox runs topic manager calls under the configured timeouts and memory limits, so that a
+// hanging or runaway third-party topic manager fails the admission of its topic instead of blocking the
+// submission. Panics of topic managers are recovered whether or not the engine has a sandbox.
+// It is safe for concurrent use.
+type TopicManagerSandbox struct {
+	config TopicManagerSandboxConfig
+}
+
+// NewTopicManagerSandbox creates a TopicManagerSandbox enforcing the given configuration.
+func NewTopicManagerSandbox(cfg TopicManagerSandboxConfig) *TopicManagerSandbox {
+	return &TopicManagerSandbox{config: cfg}
+}
+
+// Limits returns the limits enforced on the topic's manager, with the defaults applied.
+func (s *TopicManagerSandbox) Limits(topic string) TopicManagerLimits {
+	limits, ok := s.config.Topics[topic]
+	if !ok {
+		limits = s.config.Default
+	}
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultTopicManagerTimeout
+	}
+	return limits
+}
+
+// sandboxResult is the outcome of a topic manager call run by sandboxCall.
+type sandboxResult[T any] struct {
+	value T
+	err   error
+}
+
+// sandboxCall runs call for the topic's manager, converting a panic into a TopicManagerFault. With a sandbox,
+// call runs in its own goroutine under the topic's limits and is abandoned once it exceeds them.
+func sandboxCall[T any](ctx context.Context, sandbox *TopicManagerSandbox, topic, op string, call func(context.Context) (T, error)) (T, error) {
+	if sandbox == nil {
+		return recoverCall(ctx, topic, op, call)
+	}
+	limits := sandbox.Limits(topic)
+	callCtx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	done := make(chan sandboxResult[T], 1)
+	go func() {
+		value, err := recoverCall(callCtx, topic, op, call)
+		done <- sandboxResult[T]{value: value, err: err}
+	}()
+
+	var sample <-chan time.Time
+	var baseline uint64
+	if limits.MaxMemory > 0 {
+		baseline = heapObjectsBytes()
+		ticker := time.NewTicker(topicManagerMemorySampleInterval)
+		defer ticker.Stop()
+		sample = ticker.C
+	}
+	var zero T
+	for {
+		select {
+		case result := <-done:
+			return result.value, result.err
+		case <-callCtx.Done():
+			if ctx.Err() != nil {
+				return zero, ctx.Err()
+			}
+			fault := &TopicManagerFault{Topic: topic, Op: op, Cause: ErrTopicManagerTimeout}
+			logger(ctx).Error("abandoning topic manager call", "topic", topic, "op", op, "timeout", limits.Timeout, "error", fault)
+			return zero, fault
+		case <-sample:
+			if heap := heapObjectsBytes(); heap > baseline && heap-baseline > limits.MaxMemory {
+				fault := &TopicManagerFault{Topic: topic, Op: op, Cause: ErrTopicManagerMemoryExceeded}
+				logger(ctx).Error("abandoning topic manager call", "topic", topic, "op", op, "heapGrowth", heap-baseline, "maxMemory", limits.MaxMemory, "error", fault)
+				return zero, fault
+			}
+		}
+	}
+}
+
+// recoverCall runs call, converting a panic into a TopicManagerFault.
+func recoverCall[T any](ctx context.Context, topic, op string, call func(context.Context) (T, error)) (value T, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			var zero T
+			value = zero
+			err = &TopicManagerFault{Topic: topic, Op: op, Cause: ErrTopicManagerPanic, Panic: recovered}
+			logger(ctx).Error("recovered topic manager panic", "topic", topic, "op", op, "error", err, "stack", string(debug.Stack()))
+		}
+	}()
+	return call(ctx)
+}
+
+// heapObjectsBytes returns the bytes currently occupied by heap objects.
+func heapObjectsBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,253 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// TopicQuotaAction decides what happens to a submission admitting more outputs than the quota of its topic allows.
+type TopicQuotaAction string
+
+const (
+	// TopicQuotaActionReject rejects the whole submission with ErrTopicQuotaExceeded. It is the default action.
+	TopicQuotaActionReject TopicQuotaAction = "reject"
+	// TopicQuotaActionTruncate admits the outputs, in the order returned by the topic manager, that fit within
+	// the quota and drops the others.
+	TopicQuotaActionTruncate TopicQuotaAction = "truncate"
+)
+
+var (
+	// ErrTopicQuotaExceeded is returned when a topic manager admits more outputs than the quota of its topic allows
+	ErrTopicQuotaExceeded = errors.New("topic quota exceeded")
+	// ErrInvalidTopicQuota is returned when setting a topic quota with an unknown action
+	ErrInvalidTopicQuota = errors.New("invalid topic quota")
+	// ErrTopicQuotasNotConfigured is returned when overriding the topic quotas of an engine without TopicQuotas
+	ErrTopicQuotasNotConfigured = errors.New("topic quotas not configured")
+	// ErrTopicQuotaNotOverridden is returned when removing the override of a topic that has none
+	ErrTopicQuotaNotOverridden = errors.New("topic quota not overridden")
+)
+
+// TopicQuota bounds the outputs a topic manager admits, so that a buggy or malicious topic manager cannot
+// fill the storage. Zero limits are disabled.
+type TopicQuota struct {
+	// MaxOutputs caps the outputs of the topic held by the storage, spent or not. It is only enforced
+	// when the storage implements TopicStatsStorage.
+	MaxOutputs uint64 `mapstructure:"max_outputs"`
+
+	// MaxOutputsPerTx caps the outputs admitted from a single transaction.
+	MaxOutputsPerTx int `mapstructure:"max_outputs_per_tx"`
+
+	// MaxSatoshis caps the summed satoshis of the outputs admitted from a single transaction.
+	MaxSatoshis uint64 `mapstructure:"max_satoshis"`
+
+	// Action decides whether submissions exceeding the quota are rejected or truncated.
+	// Empty falls back to TopicQuotaActionReject.
+	Action TopicQuotaAction `mapstructure:"action"`
+}
+
+// enabled reports whether the quota limits anything.
+func (q TopicQuota) enabled() bool {
+	return q.MaxOutputs > 0 || q.MaxOutputsPerTx > 0 || q.MaxSatoshis > 0
+}
+
+// validate returns ErrInvalidTopicQuota for unknown actions and negative limits.
+func (q TopicQuota) validate() error {
+	switch q.Action {
+	case "", TopicQuotaActionReject, TopicQuotaActionTruncate:
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidTopicQuota, q.Action)
+	}
+	if q.MaxOutputsPerTx < 0 {
+		return fmt.Errorf("%w: negative max outputs per transaction", ErrInvalidTopicQuota)
+	}
+	return nil
+}
+
+// TopicQuotasConfig holds the output quotas of the engine's topics.
+type TopicQuotasConfig struct {
+	// Default applies to the topics without a quota of their own.
+	Default TopicQuota `mapstructure:"default"`
+
+	// Topics maps topic names to their quota, replacing the default one.
+	Topics map[string]TopicQuota `mapstructure:"topics"`
+}
+
+// TopicQuotaSettings is a snapshot of the configured topic quotas and of the overrides set at runtime.
+type TopicQuotaSettings struct {
+	Default   TopicQuota
+	Topics    map[string]TopicQuota
+	Overrides map[string]TopicQuota
+}
+
+// TopicQuotas enforces the configured output quotas on the outputs admitted by topic managers, after
+// IdentifyAdmissibleOutputs and before the outputs are stored. Admins can override the quota of a topic
+// at runtime with Engine.SetTopicQuota; overrides take precedence over the configuration and are not
+// persisted. It is safe for concurrent use.
+type TopicQuotas struct {
+	config TopicQuotasConfig
+
+	mu        sync.RWMutex
+	overrides map[string]TopicQuota
+}
+
+// NewTopicQuotas creates TopicQuotas enforcing the given configuration.
+func NewTopicQuotas(cfg TopicQuotasConfig) *TopicQuotas {
+	return &TopicQuotas{config: cfg, overrides: make(map[string]TopicQuota)}
+}
+
+// Quota returns the quota enforced on the topic: its override, its configured quota or the default one.
+func (q *TopicQuotas) Quota(topic string) TopicQuota {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if quota, ok := q.overrides[topic]; ok {
+		return quota
+	}
+	if quota, ok := q.config.Topics[topic]; ok {
+		return quota
+	}
+	return q.config.Default
+}
+
+// Settings returns a copy of the configured quotas and of the overrides.
+func (q *TopicQuotas) Settings() TopicQuotaSettings {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	topics := maps.Clone(q.config.Topics)
+	if topics == nil {
+		topics = make(map[string]TopicQuota)
+	}
+	return TopicQuotaSettings{Default: q.config.Default, Topics: topics, Overrides: maps.Clone(q.overrides)}
+}
+
+func (q *TopicQuotas) setOverride(topic string, quota TopicQuota) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.overrides[topic] = quota
+}
+
+func (q *TopicQuotas) removeOverride(topic string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.overrides[topic]; !ok {
+		return false
+	}
+	delete(q.overrides, topic)
+	return true
+}
+
+// TopicQuotaSettings returns the configured topic quotas and the overrides set at runtime. It returns empty
+// settings when the engine has no TopicQuotas.
+func (e *Engine) TopicQuotaSettings(_ context.Context) TopicQuotaSettings {
+	if e.OutputQuotas == nil {
+		return TopicQuotaSettings{Topics: make(map[string]TopicQuota), Overrides: make(map[string]TopicQuota)}
+	}
+	return e.OutputQuotas.Settings()
+}
+
+// SetTopicQuota overrides the quota of the topic until RemoveTopicQuota is called or the node restarts,
+// e.g. to contain a topic manager admitting far more outputs than expected without redeploying the node.
+// Returns ErrTopicQuotasNotConfigured when the engine has no TopicQuotas, ErrUnknownTopic when no topic
+// manager is registered for the topic and ErrInvalidTopicQuota for invalid quotas.
+func (e *Engine) SetTopicQuota(_ context.Context, topic string, quota TopicQuota) error {
+	if e.OutputQuotas == nil {
+		slog.Error("cannot set topic quota", "topic", topic, "error", ErrTopicQuotasNotConfigured)
+		return ErrTopicQuotasNotConfigured
+	}
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("cannot set topic quota", "topic", topic, "error", ErrUnknownTopic)
+		return ErrUnknownTopic
+	}
+	if err := quota.validate(); err != nil {
+		slog.Error("cannot set topic quota", "topic", topic, "error", err)
+		return err
+	}
+	e.OutputQuotas.setOverride(topic, quota)
+	slog.Info("topic quota overridden", "topic", topic, "max_outputs", quota.MaxOutputs,
+		"max_outputs_per_tx", quota.MaxOutputsPerTx, "max_satoshis", quota.MaxSatoshis, "action", quota.Action)
+	return nil
+}
+
+// RemoveTopicQuota removes the override of the topic, restoring its configured quota.
+// Returns ErrTopicQuotasNotConfigured when the engine has no TopicQuotas and ErrTopicQuotaNotOverridden
+// when the topic has no override.
+func (e *Engine) RemoveTopicQuota(_ context.Context, topic string) error {
+	if e.OutputQuotas == nil {
+		slog.Error("cannot remove topic quota", "topic", topic, "error", ErrTopicQuotasNotConfigured)
+		return ErrTopicQuotasNotConfigured
+	}
+	if !e.OutputQuotas.removeOverride(topic) {
+		slog.Error("cannot remove topic quota", "topic", topic, "error", ErrTopicQuotaNotOverridden)
+		return ErrTopicQuotaNotOverridden
+	}
+	slog.Info("topic quota override removed", "topic", topic)
+	return nil
+}
+
+// enforceTopicQuota applies the quota of the topic to the outputs admitted from the transaction, returning
+// the admittance instructions with the excess outputs dropped, or ErrTopicQuotaExceeded when the quota
+// rejects the submission.
+func (e *Engine) enforceTopicQuota(ctx context.Context, storage Storage, topic string, tx *transaction.Transaction, admit overlay.AdmittanceInstructions) (overlay.AdmittanceInstructions, error) {
+	if e.OutputQuotas == nil || len(admit.OutputsToAdmit) == 0 {
+		return admit, nil
+	}
+	quota := e.OutputQuotas.Quota(topic)
+	if !quota.enabled() {
+		return admit, nil
+	}
+
+	allowed := len(admit.OutputsToAdmit)
+	if quota.MaxOutputsPerTx > 0 {
+		allowed = min(allowed, quota.MaxOutputsPerTx)
+	}
+	if quota.MaxOutputs > 0 {
+		if stats, ok := storageCapability[TopicStatsStorage](storage); !ok {
+			logger(ctx).Warn("max outputs quota is not enforced, since the storage cannot count the outputs of a topic", "topic", topic)
+		} else if counts, err := stats.CountTopicOutputs(ctx, topic); err != nil {
+			logger(ctx).Error("failed to count topic outputs for its quota", "topic", topic, "error", err)
+			return admit, err
+		} else if counts.Total >= quota.MaxOutputs {
+			allowed = 0
+		} else {
+			allowed = int(min(uint64(allowed), quota.MaxOutputs-counts.Total)) //nolint:gosec // bounded by allowed
+		}
+	}
+
+	kept := make([]uint32, 0, allowed)
+	var satoshis uint64
+	for _, vout := range admit.OutputsToAdmit {
+		if len(kept) == allowed {
+			break
+		}
+		var value uint64
+		if int(vout) < len(tx.Outputs) {
+			value = tx.Outputs[vout].Satoshis
+		}
+		if quota.MaxSatoshis > 0 && satoshis+value > quota.MaxSatoshis {
+			if quota.Action == TopicQuotaActionTruncate {
+				continue
+			}
+			break
+		}
+		satoshis += value
+		kept = append(kept, vout)
+	}
+	if len(kept) == len(admit.OutputsToAdmit) {
+		return admit, nil
+	}
+
+	if quota.Action != TopicQuotaActionTruncate {
+		err := fmt.Errorf("%w: topic %q admits %d outputs, the quota allows %d", ErrTopicQuotaExceeded, topic, len(admit.OutputsToAdmit), len(kept))
+		logger(ctx).Error("rejecting submission exceeding topic quota", "topic", topic, "error", err)
+		return admit, err
+	}
+	logger(ctx).Warn("truncating outputs exceeding topic quota", "topic", topic, "admitted", len(admit.OutputsToAdmit), "kept", len(kept))
+	admit.OutputsToAdmit = kept
+	return admit, nil
+}
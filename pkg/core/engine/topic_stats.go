@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// ErrTopicStatsNotSupported is returned when requesting topic statistics from a storage that does not implement TopicStatsStorage
+var ErrTopicStatsNotSupported = errors.New("storage does not support topic statistics")
+
+// TopicOutputCounts aggregates the outputs admitted to a topic.
+type TopicOutputCounts struct {
+	Total   uint64
+	Unspent uint64
+	Spent   uint64
+	Unmined uint64 // outputs whose transaction has no merkle proof, either not mined yet or invalidated
+}
+
+// TopicStatsStorage is an optional Storage capability providing the aggregate queries behind TopicStats.
+type TopicStatsStorage interface {
+	// CountTopicOutputs aggregates the outputs admitted to the topic.
+	CountTopicOutputs(ctx context.Context, topic string) (*TopicOutputCounts, error)
+
+	// FindLastInteractions returns the last GASP interaction score of every peer the topic synced with, keyed by peer.
+	FindLastInteractions(ctx context.Context, topic string) (map[string]float64, error)
+}
+
+// AdvertisementSyncStorage is an optional Storage capability used to remember when the SHIP and SLAP
+// advertisements were last synced, so that a stalled advertiser shows up in TopicStats.
+type AdvertisementSyncStorage interface {
+	// UpdateLastAdvertisementSync records the time of the last successful advertisement sync.
+	UpdateLastAdvertisementSync(ctx context.Context, at time.Time) error
+
+	// GetLastAdvertisementSync returns the time of the last successful advertisement sync, zero if none was recorded.
+	GetLastAdvertisementSync(ctx context.Context) (time.Time, error)
+}
+
+// TopicStats describes the outputs of a hosted topic and how far it synced with each GASP peer.
+type TopicStats struct {
+	Topic            string
+	Outputs          TopicOutputCounts
+	PeerInteractions map[string]float64 // last GASP interaction score, keyed by peer
+}
+
+// OverlayStats holds the statistics of every hosted topic.
+type OverlayStats struct {
+	Topics                []*TopicStats // sorted by topic name
+	LastAdvertisementSync time.Time     // zero when unknown or never synced
+}
+
+// TopicStats aggregates the statistics of every hosted topic through TopicStatsStorage. The time of the last
+// advertisement sync is included when the storage also implements AdvertisementSyncStorage.
+func (e *Engine) TopicStats(ctx context.Context) (*OverlayStats, error) {
+	storage, ok := storageCapability[TopicStatsStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot aggregate topic statistics", "error", ErrTopicStatsNotSupported)
+		return nil, ErrTopicStatsNotSupported
+	}
+
+	managers := e.topicManagers()
+	stats := &OverlayStats{Topics: make([]*TopicStats, 0, len(managers))}
+	for topic := range managers {
+		counts, err := storage.CountTopicOutputs(ctx, topic)
+		if err != nil {
+			slog.Error("failed to count topic outputs", "topic", topic, "error", err)
+			return nil, err
+		}
+		interactions, err := storage.FindLastInteractions(ctx, topic)
+		if err != nil {
+			slog.Error("failed to find last interactions", "topic", topic, "error", err)
+			return nil, err
+		}
+		if interactions == nil {
+			interactions = make(map[string]float64)
+		}
+		stats.Topics = append(stats.Topics, &TopicStats{Topic: topic, Outputs: *counts, PeerInteractions: interactions})
+	}
+	sort.Slice(stats.Topics, func(i, j int) bool { return stats.Topics[i].Topic < stats.Topics[j].Topic })
+
+	if syncs, ok := storageCapability[AdvertisementSyncStorage](e.Storage); ok {
+		at, err := syncs.GetLastAdvertisementSync(ctx)
+		if err != nil {
+			slog.Error("failed to get last advertisement sync", "error", err)
+			return nil, err
+		}
+		stats.LastAdvertisementSync = at
+	}
+	return stats, nil
+}
+
+// recordAdvertisementSync remembers the time of a successful advertisement sync when the storage implements AdvertisementSyncStorage.
+func (e *Engine) recordAdvertisementSync(ctx context.Context) {
+	syncs, ok := storageCapability[AdvertisementSyncStorage](e.Storage)
+	if !ok {
+		return
+	}
+	if err := e.trackWrite(syncs.UpdateLastAdvertisementSync(ctx, time.Now())); err != nil {
+		slog.Error("failed to record advertisement sync", "error", err)
+	}
+}
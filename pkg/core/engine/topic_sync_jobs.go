@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TopicSyncJobRunning marks a topic sync in progress.
+	TopicSyncJobRunning = "running"
+
+	// TopicSyncJobCompleted marks a topic sync that ran with every peer; its report is available.
+	TopicSyncJobCompleted = "completed"
+
+	// TopicSyncJobFailed marks a topic sync whose peers could not be discovered or that was interrupted; its error is available.
+	TopicSyncJobFailed = "failed"
+)
+
+// DefaultTopicSyncJobRetention is how long finished topic sync jobs can be polled.
+const DefaultTopicSyncJobRetention = time.Hour
+
+var (
+	// ErrTopicSyncJobsNotConfigured is returned when syncing a single topic on an engine without TopicSyncJobs
+	ErrTopicSyncJobsNotConfigured = errors.New("no topic sync jobs configured")
+
+	// ErrTopicSyncJobNotFound is returned when polling a topic sync job that does not exist or is no longer retained
+	ErrTopicSyncJobNotFound = errors.New("topic sync job not found")
+)
+
+// TopicSyncRequest selects the topic synced by Engine.SyncTopic, optionally restricted to some peers
+// and starting from a given score.
+type TopicSyncRequest struct {
+	Topic string
+	// Peers are synced instead of the peers of the topic's SyncConfiguration when not empty.
+	Peers []string
+	// Since is the score the sync starts from instead of the last interaction with each peer when set.
+	Since *float64
+}
+
+// TopicSyncJob tracks a one-off GASP sync of a single topic run in the background.
+type TopicSyncJob struct {
+	ID          string
+	Topic       string
+	Peers       []string // the requested peers, empty when syncing with the peers of the topic's SyncConfiguration
+	Since       *float64
+	Status      string
+	Report      *TopicSyncReport // set once the job completed
+	Error       string           // set once the job failed
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+// TopicSyncJobs keeps the topic sync jobs started with Engine.SyncTopic in memory. Finished jobs are
+// forgotten once DefaultTopicSyncJobRetention elapses. NewEngine creates one when none is set.
+type TopicSyncJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*TopicSyncJob
+}
+
+// NewTopicSyncJobs creates an empty TopicSyncJobs.
+func NewTopicSyncJobs() *TopicSyncJobs {
+	return &TopicSyncJobs{jobs: make(map[string]*TopicSyncJob)}
+}
+
+// add registers a new running job, forgetting finished jobs whose retention elapsed.
+func (j *TopicSyncJobs) add(request TopicSyncRequest) *TopicSyncJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	for id, job := range j.jobs {
+		if !job.CompletedAt.IsZero() && now.Sub(job.CompletedAt) > DefaultTopicSyncJobRetention {
+			delete(j.jobs, id)
+		}
+	}
+	job := &TopicSyncJob{
+		ID:        uuid.NewString(),
+		Topic:     request.Topic,
+		Peers:     request.Peers,
+		Since:     request.Since,
+		Status:    TopicSyncJobRunning,
+		CreatedAt: now,
+	}
+	j.jobs[job.ID] = job
+	return job.snapshot()
+}
+
+// find returns a copy of the job with the given ID, or nil if it is unknown or no longer retained.
+func (j *TopicSyncJobs) find(id string) *TopicSyncJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job, ok := j.jobs[id]
+	if !ok || (!job.CompletedAt.IsZero() && time.Since(job.CompletedAt) > DefaultTopicSyncJobRetention) {
+		return nil
+	}
+	return job.snapshot()
+}
+
+// finish records the outcome of the job with the given ID.
+func (j *TopicSyncJobs) finish(id string, report *TopicSyncReport, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job := j.jobs[id]
+	job.CompletedAt = time.Now()
+	if err != nil {
+		job.Status = TopicSyncJobFailed
+		job.Error = err.Error()
+		return
+	}
+	job.Status = TopicSyncJobCompleted
+	job.Report = report
+}
+
+func (j *TopicSyncJob) snapshot() *TopicSyncJob {
+	cp := *j
+	return &cp
+}
+
+// SyncTopic starts a one-off GASP sync of a single topic and returns the running job immediately, e.g. to
+// re-sync a topic after an incident without syncing the whole node. The topic is synced with the requested
+// peers, or with the peers of its SyncConfiguration, even while its sync is paused. Poll the outcome with
+// FindTopicSyncJob. Returns ErrUnknownTopic for topics not hosted by the engine.
+func (e *Engine) SyncTopic(ctx context.Context, request TopicSyncRequest) (*TopicSyncJob, error) {
+	if e.TopicSyncJobs == nil {
+		slog.Error("cannot sync topic", "topic", request.Topic, "error", ErrTopicSyncJobsNotConfigured)
+		return nil, ErrTopicSyncJobsNotConfigured
+	}
+	if _, ok := e.topicManager(request.Topic); !ok {
+		slog.Error("cannot sync unknown topic", "topic", request.Topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+	if err := e.rejectWhileDegraded(); err != nil {
+		slog.Error("skipping topic sync in degraded mode", "topic", request.Topic, "error", err)
+		return nil, err
+	}
+	syncCtx, done, err := e.beginOperation(context.WithoutCancel(ctx), operationGASPSync)
+	if err != nil {
+		slog.Error("rejecting topic sync while stopping", "topic", request.Topic, "error", err)
+		return nil, err
+	}
+
+	job := e.TopicSyncJobs.add(request)
+	go func() {
+		defer done()
+		report, err := e.runTopicSyncJob(syncCtx, request)
+		e.TopicSyncJobs.finish(job.ID, report, err)
+		if err != nil {
+			slog.Error("topic sync job failed", "id", job.ID, "topic", request.Topic, "error", err)
+		} else {
+			slog.Info("topic sync job completed", "id", job.ID, "topic", request.Topic, "failedPeers", len(report.Failed))
+		}
+	}()
+	return job, nil
+}
+
+// runTopicSyncJob syncs the topic of the request with its peers.
+func (e *Engine) runTopicSyncJob(ctx context.Context, request TopicSyncRequest) (*TopicSyncReport, error) {
+	config, _ := e.syncConfiguration(request.Topic)
+	job := topicSync{topic: request.Topic, config: config, peers: request.Peers, since: request.Since}
+	if len(job.peers) == 0 {
+		peers, err := e.gaspSyncPeers(ctx, job.topic, job.config)
+		if err != nil {
+			return nil, err
+		}
+		job.peers = peers
+	}
+	report, err := e.syncTopic(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// FindTopicSyncJob returns the topic sync job with the given ID.
+// Returns ErrTopicSyncJobsNotConfigured without TopicSyncJobs and ErrTopicSyncJobNotFound for unknown or expired jobs.
+func (e *Engine) FindTopicSyncJob(_ context.Context, id string) (*TopicSyncJob, error) {
+	if e.TopicSyncJobs == nil {
+		slog.Error("cannot find topic sync job", "id", id, "error", ErrTopicSyncJobsNotConfigured)
+		return nil, ErrTopicSyncJobsNotConfigured
+	}
+
+	job := e.TopicSyncJobs.find(id)
+	if job == nil {
+		slog.Error("topic sync job not found", "id", id, "error", ErrTopicSyncJobNotFound)
+		return nil, ErrTopicSyncJobNotFound
+	}
+	return job, nil
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// TopicSyncPauseStorage is an optional Storage capability used to persist the topics whose GASP sync
+// and advertisement are paused, so that pauses survive restarts of the node.
+type TopicSyncPauseStorage interface {
+	// UpdateTopicSyncPaused sets the paused flag of the sync of the topic.
+	UpdateTopicSyncPaused(ctx context.Context, topic string, paused bool) error
+
+	// FindPausedSyncTopics returns the topics whose sync is paused.
+	FindPausedSyncTopics(ctx context.Context) ([]string, error)
+}
+
+// PauseTopicSync pauses the GASP sync and SHIP advertisement of the topic until ResumeTopicSync is called,
+// e.g. to isolate a topic whose peers flood the node with invalid graphs without stopping it. GASP syncs skip
+// the topic, and SyncAdvertisements neither creates nor revokes its advertisements. Submissions to the topic
+// are still admitted. When the storage implements TopicSyncPauseStorage, the pause is persisted and restored
+// by Start. Returns ErrUnknownTopic when no topic manager is registered for the topic.
+func (e *Engine) PauseTopicSync(ctx context.Context, topic string) error {
+	return e.setTopicSyncPaused(ctx, topic, true)
+}
+
+// ResumeTopicSync resumes the GASP sync and SHIP advertisement of a topic paused with PauseTopicSync.
+// Returns ErrUnknownTopic when no topic manager is registered for the topic.
+func (e *Engine) ResumeTopicSync(ctx context.Context, topic string) error {
+	return e.setTopicSyncPaused(ctx, topic, false)
+}
+
+// PausedSyncTopics returns the sorted topics whose GASP sync and advertisement are paused.
+func (e *Engine) PausedSyncTopics(_ context.Context) []string {
+	paused := make([]string, 0)
+	for topic, config := range e.syncConfigurations() {
+		if config.Paused {
+			paused = append(paused, topic)
+		}
+	}
+	slices.Sort(paused)
+	return paused
+}
+
+func (e *Engine) setTopicSyncPaused(ctx context.Context, topic string, paused bool) error {
+	if _, ok := e.topicManager(topic); !ok {
+		slog.Error("cannot change topic sync pause", "topic", topic, "error", ErrUnknownTopic)
+		return ErrUnknownTopic
+	}
+	if pauses, ok := storageCapability[TopicSyncPauseStorage](e.Storage); ok {
+		if err := e.allowWrite(); err != nil {
+			slog.Error("rejecting topic sync pause update in degraded mode", "topic", topic, "error", err)
+			return err
+		}
+		if err := e.trackWrite(pauses.UpdateTopicSyncPaused(ctx, topic, paused)); err != nil {
+			slog.Error("failed to persist topic sync pause", "topic", topic, "paused", paused, "error", err)
+			return err
+		}
+	} else {
+		slog.Warn("topic sync pause is not persisted, since the storage does not support it", "topic", topic, "paused", paused)
+	}
+
+	e.applyTopicSyncPaused(topic, paused)
+	slog.Info("topic sync pause changed", "topic", topic, "paused", paused)
+	return nil
+}
+
+// applyTopicSyncPaused sets the paused flag of the sync configuration of the topic.
+func (e *Engine) applyTopicSyncPaused(topic string, paused bool) {
+	e.registryLock().Lock()
+	defer e.registryLock().Unlock()
+	syncConfigs := maps.Clone(e.SyncConfiguration)
+	if syncConfigs == nil {
+		syncConfigs = make(map[string]SyncConfiguration)
+	}
+	config := syncConfigs[topic]
+	config.Paused = paused
+	syncConfigs[topic] = config
+	e.SyncConfiguration = syncConfigs
+}
+
+// restorePausedTopicSyncs pauses the syncs of the topics persisted as paused by the storage.
+// It is a no-op when the storage does not implement TopicSyncPauseStorage.
+func (e *Engine) restorePausedTopicSyncs(ctx context.Context) error {
+	pauses, ok := storageCapability[TopicSyncPauseStorage](e.Storage)
+	if !ok {
+		return nil
+	}
+	topics, err := pauses.FindPausedSyncTopics(ctx)
+	if err != nil {
+		slog.Error("failed to find paused sync topics", "error", err)
+		return err
+	}
+	for _, topic := range topics {
+		if _, managed := e.topicManager(topic); !managed {
+			slog.Warn("ignoring sync pause of unmanaged topic", "topic", topic)
+			continue
+		}
+		e.applyTopicSyncPaused(topic, true)
+		slog.Info("topic sync paused", "topic", topic)
+	}
+	return nil
+}
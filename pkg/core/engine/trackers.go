@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+)
+
+// TrackerKind identifies the SHIP or SLAP tracker set of an engine.
+type TrackerKind string
+
+const (
+	// TrackerKindSHIP identifies the SHIP trackers, synced with as peers of the tm_ship topic.
+	TrackerKindSHIP TrackerKind = "ship"
+	// TrackerKindSLAP identifies the SLAP trackers, used by the LookupResolver to discover lookup hosts
+	// and synced with as peers of the tm_slap topic.
+	TrackerKindSLAP TrackerKind = "slap"
+)
+
+var (
+	// ErrInvalidTracker is returned when adding a tracker of an unknown kind or whose URL is not an HTTP(S) URL
+	ErrInvalidTracker = errors.New("invalid tracker")
+	// ErrTrackerAlreadyConfigured is returned when adding a tracker already in the tracker set
+	ErrTrackerAlreadyConfigured = errors.New("tracker already configured")
+	// ErrTrackerNotConfigured is returned when removing a tracker missing from the tracker set
+	ErrTrackerNotConfigured = errors.New("tracker not configured")
+)
+
+// TrackerStorage is an optional Storage capability used to persist the tracker sets changed at runtime,
+// so that the changes survive restarts of the node.
+type TrackerStorage interface {
+	// UpdateTrackers replaces the persisted trackers of the kind.
+	UpdateTrackers(ctx context.Context, kind TrackerKind, trackers []string) error
+
+	// FindTrackers returns the persisted trackers of the kind. found is false when the
+	// trackers of the kind were never persisted, as opposed to persisted as an empty set.
+	FindTrackers(ctx context.Context, kind TrackerKind) (trackers []string, found bool, err error)
+}
+
+// Trackers is a snapshot of the SHIP and SLAP trackers of an engine.
+type Trackers struct {
+	SHIP []string
+	SLAP []string
+}
+
+// Trackers returns a copy of the SHIP and SLAP trackers of the engine.
+func (e *Engine) Trackers(_ context.Context) Trackers {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+	return Trackers{SHIP: slices.Clone(e.SHIPTrackers), SLAP: slices.Clone(e.SLAPTrackers)}
+}
+
+// AddTracker adds the tracker to the SHIP or SLAP tracker set of the running engine. The change takes effect
+// immediately: SLAP trackers are handed to the LookupResolver, and trackers of either kind are synced with
+// as peers of the tm_ship or tm_slap topic when it is synced with configured peers. When the storage implements
+// TrackerStorage, the tracker set is persisted and restored by Start, taking precedence over the configured one.
+// Returns ErrInvalidTracker for unknown kinds and non-HTTP(S) URLs and ErrTrackerAlreadyConfigured for duplicates.
+func (e *Engine) AddTracker(ctx context.Context, kind TrackerKind, tracker string) error {
+	if u, err := url.Parse(tracker); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		slog.Error("invalid tracker URL in AddTracker", "kind", kind, "tracker", tracker, "error", ErrInvalidTracker)
+		return ErrInvalidTracker
+	}
+	current, err := e.trackers(kind)
+	if err != nil {
+		slog.Error("invalid tracker kind in AddTracker", "kind", kind, "error", err)
+		return err
+	}
+	if slices.Contains(current, tracker) {
+		slog.Error("tracker already configured", "kind", kind, "tracker", tracker, "error", ErrTrackerAlreadyConfigured)
+		return ErrTrackerAlreadyConfigured
+	}
+	return e.setTrackers(ctx, kind, append(slices.Clone(current), tracker))
+}
+
+// RemoveTracker removes the tracker from the SHIP or SLAP tracker set of the running engine, persisting the
+// change like AddTracker. The tracker is also removed from the peers of the tm_ship or tm_slap topic, even when
+// it was configured as a peer as well. Removing the last SLAP tracker leaves the LookupResolver with the trackers
+// it was last given, since it cannot resolve without any.
+// Returns ErrInvalidTracker for unknown kinds and ErrTrackerNotConfigured when the tracker is not in the set.
+func (e *Engine) RemoveTracker(ctx context.Context, kind TrackerKind, tracker string) error {
+	current, err := e.trackers(kind)
+	if err != nil {
+		slog.Error("invalid tracker kind in RemoveTracker", "kind", kind, "error", err)
+		return err
+	}
+	if !slices.Contains(current, tracker) {
+		slog.Error("tracker not configured", "kind", kind, "tracker", tracker, "error", ErrTrackerNotConfigured)
+		return ErrTrackerNotConfigured
+	}
+	remaining := slices.DeleteFunc(slices.Clone(current), func(t string) bool { return t == tracker })
+	return e.setTrackers(ctx, kind, remaining)
+}
+
+// trackers returns the trackers of the kind, or ErrInvalidTracker for unknown kinds.
+func (e *Engine) trackers(kind TrackerKind) ([]string, error) {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+	switch kind {
+	case TrackerKindSHIP:
+		return e.SHIPTrackers, nil
+	case TrackerKindSLAP:
+		return e.SLAPTrackers, nil
+	default:
+		return nil, ErrInvalidTracker
+	}
+}
+
+// slapTrackers returns the SLAP trackers of the engine. AddTracker and RemoveTracker replace the slice
+// instead of mutating it, so the returned slice is safe to read without holding the lock.
+func (e *Engine) slapTrackers() []string {
+	e.registryLock().RLock()
+	defer e.registryLock().RUnlock()
+	return e.SLAPTrackers
+}
+
+func (e *Engine) setTrackers(ctx context.Context, kind TrackerKind, trackers []string) error {
+	if storage, ok := storageCapability[TrackerStorage](e.Storage); ok {
+		if err := e.allowWrite(); err != nil {
+			slog.Error("rejecting tracker update in degraded mode", "kind", kind, "error", err)
+			return err
+		}
+		if err := e.trackWrite(storage.UpdateTrackers(ctx, kind, trackers)); err != nil {
+			slog.Error("failed to persist trackers", "kind", kind, "trackers", len(trackers), "error", err)
+			return err
+		}
+	} else {
+		slog.Warn("trackers are not persisted, since the storage does not support it", "kind", kind, "trackers", len(trackers))
+	}
+
+	e.applyTrackers(kind, trackers)
+	slog.Info("trackers changed", "kind", kind, "trackers", len(trackers))
+	return nil
+}
+
+// applyTrackers replaces the trackers of the kind, replacing the trackers among the peers of the topic
+// they are synced with, and hands SLAP trackers to the LookupResolver.
+func (e *Engine) applyTrackers(kind TrackerKind, trackers []string) {
+	e.registryLock().Lock()
+	var topic string
+	var previous []string
+	switch kind {
+	case TrackerKindSHIP:
+		topic, previous, e.SHIPTrackers = "tm_ship", e.SHIPTrackers, trackers
+	case TrackerKindSLAP:
+		topic, previous, e.SLAPTrackers = "tm_slap", e.SLAPTrackers, trackers
+	}
+	if config, ok := e.SyncConfiguration[topic]; ok && config.Type == SyncConfigurationPeers {
+		peers := slices.DeleteFunc(slices.Clone(config.Peers), func(peer string) bool {
+			return slices.Contains(previous, peer) && !slices.Contains(trackers, peer)
+		})
+		for _, tracker := range trackers {
+			if !slices.Contains(peers, tracker) {
+				peers = append(peers, tracker)
+			}
+		}
+		config.Peers = peers
+		syncConfigs := maps.Clone(e.SyncConfiguration)
+		syncConfigs[topic] = config
+		e.SyncConfiguration = syncConfigs
+	}
+	e.registryLock().Unlock()
+
+	if kind == TrackerKindSLAP && e.LookupResolver != nil {
+		e.LookupResolver.SetSLAPTrackers(trackers)
+	}
+}
+
+// restoreTrackers replaces the configured trackers with the tracker sets persisted by the storage.
+// It is a no-op when the storage does not implement TrackerStorage.
+func (e *Engine) restoreTrackers(ctx context.Context) error {
+	storage, ok := storageCapability[TrackerStorage](e.Storage)
+	if !ok {
+		return nil
+	}
+	for _, kind := range []TrackerKind{TrackerKindSHIP, TrackerKindSLAP} {
+		trackers, found, err := storage.FindTrackers(ctx, kind)
+		if err != nil {
+			slog.Error("failed to find persisted trackers", "kind", kind, "error", err)
+			return err
+		}
+		if !found {
+			continue
+		}
+		e.applyTrackers(kind, trackers)
+		slog.Info("trackers restored", "kind", kind, "trackers", len(trackers))
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultUnminedEvictionInterval is how often the background evictor runs when no interval is configured.
+	DefaultUnminedEvictionInterval = time.Hour
+
+	// DefaultUnminedMaxAge is how long a transaction may stay unmined before it is re-broadcast or evicted
+	// when no maximum age is configured.
+	DefaultUnminedMaxAge = 24 * time.Hour
+)
+
+// ErrUnminedEvictionNotConfigured is returned when evicting stale unmined transactions with an engine without an evictor
+var ErrUnminedEvictionNotConfigured = errors.New("no unmined transaction eviction configured")
+
+// UnminedEvictionConfig configures the background eviction of transactions that never got mined.
+type UnminedEvictionConfig struct {
+	// MaxAge is how long a transaction may stay without a merkle proof, since its outputs were admitted or
+	// it was last re-broadcast, before it is handled. Zero falls back to DefaultUnminedMaxAge.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// Interval is how often the background evictor runs. Zero falls back to DefaultUnminedEvictionInterval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// BatchSize is how many unmined transactions a run scans. Zero falls back to DefaultProofFetchBatchSize.
+	BatchSize uint32 `mapstructure:"batch_size"`
+
+	// MaxRebroadcasts is how many times a stale transaction is re-broadcast before it is evicted.
+	// Zero evicts stale transactions without re-broadcasting them.
+	MaxRebroadcasts int `mapstructure:"max_rebroadcasts"`
+
+	// Recheck, when its Source is set, is the service the status of a stale transaction is checked against
+	// before it is re-broadcast or evicted, such as ARC. Only Source, URL, APIKey and Network apply.
+	Recheck MerkleProofFetcherConfig `mapstructure:"recheck"`
+}
+
+// UnminedEvictor re-broadcasts, then evicts, the transactions that stayed unmined for longer than MaxAge.
+type UnminedEvictor struct {
+	MaxAge          time.Duration
+	Interval        time.Duration
+	BatchSize       uint32
+	MaxRebroadcasts int
+	Recheck         MerkleProofSource // nil skips the status check
+
+	mu    sync.Mutex
+	stale map[chainhash.Hash]*staleTransaction
+}
+
+// staleTransaction tracks an unmined transaction across runs.
+type staleTransaction struct {
+	firstSeen     time.Time // when a run first found the transaction, used when its outputs carry no ReceivedAt
+	rebroadcasts  int
+	rebroadcastAt time.Time
+}
+
+// NewUnminedEvictor creates an UnminedEvictor, checking the status of stale transactions against the source
+// described by cfg.Recheck when one is set.
+func NewUnminedEvictor(cfg UnminedEvictionConfig) (*UnminedEvictor, error) {
+	evictor := &UnminedEvictor{
+		MaxAge:          cfg.MaxAge,
+		Interval:        cfg.Interval,
+		BatchSize:       cfg.BatchSize,
+		MaxRebroadcasts: cfg.MaxRebroadcasts,
+	}
+	if cfg.Recheck.Source != "" {
+		source, err := NewMerkleProofSource(cfg.Recheck)
+		if err != nil {
+			return nil, err
+		}
+		evictor.Recheck = source
+	}
+	return evictor, nil
+}
+
+// track returns the state of the unmined transactions found by a run and forgets the transactions
+// that are no longer unmined.
+func (u *UnminedEvictor) track(txids []*chainhash.Hash, now time.Time) map[chainhash.Hash]*staleTransaction {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stale := make(map[chainhash.Hash]*staleTransaction, len(txids))
+	for _, txid := range txids {
+		tx, ok := u.stale[*txid]
+		if !ok {
+			tx = &staleTransaction{firstSeen: now}
+		}
+		stale[*txid] = tx
+	}
+	u.stale = stale
+	return stale
+}
+
+// rebroadcast records a re-broadcast of the transaction, restarting its age.
+func (u *UnminedEvictor) rebroadcast(tx *staleTransaction, now time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	tx.rebroadcasts++
+	tx.rebroadcastAt = now
+}
+
+// since returns when the age of an unmined transaction with the given outputs starts.
+func (tx *staleTransaction) since(outputs []*Output) time.Time {
+	since := tx.firstSeen
+	for _, output := range outputs {
+		if !output.ReceivedAt.IsZero() && output.ReceivedAt.Before(since) {
+			since = output.ReceivedAt
+		}
+	}
+	if tx.rebroadcastAt.After(since) {
+		since = tx.rebroadcastAt
+	}
+	return since
+}
+
+// UnminedEvictionReport describes the outcome of an eviction run.
+type UnminedEvictionReport struct {
+	Scanned     int                     // unmined transactions found in storage
+	Mined       []*chainhash.Hash       // stale transactions whose proof was found on recheck and applied
+	Rebroadcast []*chainhash.Hash       // stale transactions broadcast again
+	Evicted     []*transaction.Outpoint // outputs of the stale transactions evicted
+	Pending     int                     // transactions not unmined for long enough
+	Failed      int                     // transactions that could not be checked, re-broadcast or evicted
+}
+
+// EvictStaleUnmined handles the transactions that stayed without a merkle proof for longer than
+// UnminedEvictor.MaxAge. A stale transaction whose proof is found when rechecking its status is applied with
+// HandleNewMerkleProof; otherwise it is re-broadcast up to UnminedEvictor.MaxRebroadcasts times, each
+// attempt restarting its age, and then evicted: its outputs are deleted, unlinked from the outputs
+// they consumed, and lookup services are notified with OutputEvicted. Pinned outputs are kept.
+// A transaction that fails is logged and retried on the next run.
+func (e *Engine) EvictStaleUnmined(ctx context.Context) (*UnminedEvictionReport, error) {
+	ctx, done, err := e.beginOperation(ctx, operationSubmit)
+	if err != nil {
+		slog.Error("rejecting EvictStaleUnmined while stopping", "error", err)
+		return nil, err
+	}
+	defer done()
+	if e.UnminedEviction == nil {
+		slog.Error("cannot evict stale unmined transactions", "error", ErrUnminedEvictionNotConfigured)
+		return nil, ErrUnminedEvictionNotConfigured
+	}
+	unmined, ok := storageCapability[UnminedTransactionStorage](e.Storage)
+	if !ok {
+		slog.Error("cannot evict stale unmined transactions", "error", ErrUnminedLookupNotSupported)
+		return nil, ErrUnminedLookupNotSupported
+	}
+	if err := e.allowWrite(); err != nil {
+		slog.Error("rejecting EvictStaleUnmined in degraded mode", "error", err)
+		return nil, err
+	}
+
+	evictor := e.UnminedEviction
+	limit := evictor.BatchSize
+	if limit == 0 {
+		limit = DefaultProofFetchBatchSize
+	}
+	maxAge := evictor.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultUnminedMaxAge
+	}
+	txids, err := unmined.FindUnminedTransactions(ctx, limit)
+	if err != nil {
+		slog.Error("failed to find unmined transactions", "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	stale := evictor.track(txids, now)
+	report := &UnminedEvictionReport{Scanned: len(txids)}
+	for _, txid := range txids {
+		outputs, err := e.Storage.FindOutputsForTransaction(ctx, txid, true)
+		if err != nil {
+			slog.Error("failed to find outputs of unmined transaction", "txid", txid, "error", err)
+			report.Failed++
+			continue
+		}
+		tx := stale[*txid]
+		if now.Sub(tx.since(outputs)) < maxAge {
+			report.Pending++
+			continue
+		}
+
+		if evictor.Recheck != nil {
+			mined, err := e.recheckUnmined(ctx, evictor.Recheck, txid)
+			if errors.Is(err, ErrEngineStopping) {
+				return nil, err
+			} else if err != nil {
+				slog.Error("failed to recheck stale unmined transaction", "txid", txid, "error", err)
+				report.Failed++
+				continue
+			} else if mined {
+				report.Mined = append(report.Mined, txid)
+				continue
+			}
+		}
+
+		if e.Broadcaster != nil && tx.rebroadcasts < evictor.MaxRebroadcasts {
+			err := e.rebroadcastUnmined(ctx, outputs)
+			evictor.rebroadcast(tx, now)
+			if err != nil {
+				slog.Error("failed to re-broadcast stale unmined transaction", "txid", txid, "attempt", tx.rebroadcasts, "error", err)
+				report.Failed++
+				continue
+			}
+			report.Rebroadcast = append(report.Rebroadcast, txid)
+			continue
+		}
+
+		evicted, err := e.evictUnmined(ctx, outputs)
+		report.Evicted = append(report.Evicted, evicted...)
+		if err != nil {
+			report.Failed++
+			continue
+		}
+		slog.Warn("evicted stale unmined transaction", "txid", txid, "outputs", len(evicted), "rebroadcasts", tx.rebroadcasts)
+	}
+	slog.Info("stale unmined transactions handled", "scanned", report.Scanned, "mined", len(report.Mined), "rebroadcast", len(report.Rebroadcast), "evicted", len(report.Evicted), "pending", report.Pending, "failed", report.Failed)
+	return report, nil
+}
+
+// recheckUnmined fetches the merkle proof of a stale transaction from source and applies it, reporting whether it was mined.
+func (e *Engine) recheckUnmined(ctx context.Context, source MerkleProofSource, txid *chainhash.Hash) (bool, error) {
+	proof, err := source.FetchMerkleProof(ctx, txid)
+	if errors.Is(err, ErrMerkleProofNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if e.ChainTracker != nil {
+		if valid, err := proof.Verify(ctx, txid, e.ChainTracker); err != nil {
+			return false, err
+		} else if !valid {
+			return false, ErrInvalidMerkleProof
+		}
+	}
+	if err := e.HandleNewMerkleProof(ctx, txid, proof); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// rebroadcastUnmined broadcasts again the transaction of the given outputs.
+func (e *Engine) rebroadcastUnmined(ctx context.Context, outputs []*Output) error {
+	for _, output := range outputs {
+		if len(output.Beef) == 0 {
+			continue
+		}
+		_, tx, _, err := transaction.ParseBeef(output.Beef)
+		if err != nil {
+			return err
+		} else if tx == nil {
+			return ErrInvalidBeef
+		}
+		if _, failure := e.Broadcaster.BroadcastCtx(ctx, tx); failure != nil {
+			return failure
+		}
+		return nil
+	}
+	return ErrMissingInput
+}
+
+// evictUnmined deletes the unpinned outputs of a stale transaction, unlinking them from the outputs they
+// consumed, and notifies lookup services with OutputEvicted. It returns the outpoints evicted before any failure.
+func (e *Engine) evictUnmined(ctx context.Context, outputs []*Output) ([]*transaction.Outpoint, error) {
+	evicted := make([]*transaction.Outpoint, 0, len(outputs))
+	for _, output := range outputs {
+		if output.Pinned {
+			slog.Info("keeping pinned output of stale unmined transaction", "outpoint", output.Outpoint.String(), "topic", output.Topic)
+			continue
+		}
+		for _, outpoint := range output.OutputsConsumed {
+			consumed, err := e.Storage.FindOutput(ctx, outpoint, &output.Topic, nil, false)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				slog.Error("failed to find output consumed by stale unmined output", "outpoint", outpoint.String(), "topic", output.Topic, "error", err)
+				return evicted, err
+			} else if consumed == nil {
+				continue
+			}
+			consumedBy := make([]*transaction.Outpoint, 0, len(consumed.ConsumedBy))
+			for _, consumer := range consumed.ConsumedBy {
+				if !consumer.Txid.Equal(output.Outpoint.Txid) {
+					consumedBy = append(consumedBy, consumer)
+				}
+			}
+			if err := e.trackWrite(e.Storage.UpdateConsumedBy(ctx, &consumed.Outpoint, consumed.Topic, consumedBy)); err != nil {
+				slog.Error("failed to update consumed by of output consumed by stale unmined output", "outpoint", consumed.Outpoint.String(), "topic", consumed.Topic, "error", err)
+				return evicted, err
+			}
+		}
+
+		if err := e.removeOutput(ctx, e.Storage, &output.Outpoint, output.Topic, TombstoneReasonUnminedEvicted); err != nil {
+			slog.Error("failed to delete stale unmined output", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+			return evicted, err
+		}
+		if e.OutpointFilter != nil {
+			e.OutpointFilter.MarkDeleted(output.Topic)
+		}
+		for name, l := range e.lookupServices() {
+			if err := e.notifyLookupService(ctx, name, l, LookupHookOutputEvicted, func(ctx context.Context, l LookupService) error {
+				return l.OutputEvicted(ctx, &output.Outpoint)
+			}); err != nil {
+				slog.Error("failed to notify lookup service about evicted output", "outpoint", output.Outpoint.String(), "topic", output.Topic, "error", err)
+				return evicted, err
+			}
+		}
+		evicted = append(evicted, &output.Outpoint)
+	}
+	return evicted, nil
+}
+
+// RunUnminedEvictor evicts stale unmined transactions every UnminedEvictor.Interval until ctx is done or the engine stops.
+// It returns immediately when no evictor is configured. Failed runs are logged and retried on the next tick.
+func (e *Engine) RunUnminedEvictor(ctx context.Context) {
+	if e.UnminedEviction == nil {
+		return
+	}
+	interval := e.UnminedEviction.Interval
+	if interval <= 0 {
+		interval = DefaultUnminedEvictionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := e.EvictStaleUnmined(ctx); errors.Is(err, ErrEngineStopping) {
+			return
+		} else if err != nil {
+			slog.Error("scheduled unmined eviction failed", "interval", interval, "error", err)
+		}
+	}
+}
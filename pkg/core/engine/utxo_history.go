@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// DefaultUTXOHistoryMaxDepth is the number of ancestor levels FindUTXOHistory hydrates at most when no limit is configured.
+	DefaultUTXOHistoryMaxDepth = 100
+
+	// DefaultUTXOHistoryMaxBytes is the total size of the BEEFs FindUTXOHistory traverses at most when no limit is configured.
+	DefaultUTXOHistoryMaxBytes = 16 << 20
+)
+
+var (
+	// ErrUTXOHistoryDepthExceeded is returned when the requested history depth exceeds UTXOHistoryLimits.MaxDepth
+	ErrUTXOHistoryDepthExceeded = errors.New("requested history depth exceeds the limit")
+
+	// ErrUTXOHistoryTooLarge is returned when the BEEFs of a history exceed UTXOHistoryLimits.MaxBytes
+	ErrUTXOHistoryTooLarge = errors.New("history exceeds the size limit")
+)
+
+// UTXOHistoryLimits bounds the work and memory of a single FindUTXOHistory call.
+type UTXOHistoryLimits struct {
+	// MaxDepth caps the number of ancestor levels hydrated. Zero falls back to DefaultUTXOHistoryMaxDepth.
+	MaxDepth uint32 `mapstructure:"max_depth"`
+
+	// MaxBytes caps the total size of the BEEFs traversed. Zero falls back to DefaultUTXOHistoryMaxBytes.
+	MaxBytes int `mapstructure:"max_bytes"`
+}
+
+// UTXOHistoryQuery selects the output whose history FindUTXOHistory hydrates.
+type UTXOHistoryQuery struct {
+	Outpoint transaction.Outpoint
+	Topic    string
+	Depth    *uint32 // ancestor levels to hydrate; nil hydrates up to UTXOHistoryLimits.MaxDepth
+}
+
+// FindUTXOHistory looks up an output admitted to a topic and hydrates its BEEF with the ancestors admitted
+// to the topic, up to the requested depth. It exposes GetUTXOHistory without a lookup service, bounded by the
+// engine's HistoryLimits so that a deep or wide chain cannot exhaust the node.
+func (e *Engine) FindUTXOHistory(ctx context.Context, query UTXOHistoryQuery) (*Output, error) {
+	if _, ok := e.topicManager(query.Topic); !ok {
+		slog.Error("unknown topic in FindUTXOHistory", "topic", query.Topic, "error", ErrUnknownTopic)
+		return nil, ErrUnknownTopic
+	}
+
+	maxDepth, maxBytes := uint32(DefaultUTXOHistoryMaxDepth), DefaultUTXOHistoryMaxBytes
+	if e.HistoryLimits != nil {
+		if e.HistoryLimits.MaxDepth > 0 {
+			maxDepth = e.HistoryLimits.MaxDepth
+		}
+		if e.HistoryLimits.MaxBytes > 0 {
+			maxBytes = e.HistoryLimits.MaxBytes
+		}
+	}
+	depth := maxDepth
+	if query.Depth != nil {
+		if *query.Depth > maxDepth {
+			return nil, fmt.Errorf("%w: %d > %d", ErrUTXOHistoryDepthExceeded, *query.Depth, maxDepth)
+		}
+		depth = *query.Depth
+	}
+
+	output, err := e.Storage.FindOutput(ctx, &query.Outpoint, &query.Topic, nil, true)
+	if err != nil {
+		slog.Error("failed to find output in FindUTXOHistory", "outpoint", query.Outpoint.String(), "topic", query.Topic, "error", err)
+		return nil, err
+	} else if output == nil {
+		return nil, ErrUnableToFindOutput
+	}
+
+	var traversed int
+	tooLarge := false
+	selector := func(beef []byte, _, currentDepth uint32) bool {
+		if currentDepth > depth || tooLarge {
+			return false
+		}
+		traversed += len(beef)
+		if traversed > maxBytes {
+			tooLarge = true
+			return false
+		}
+		return true
+	}
+	history, err := e.GetUTXOHistory(ctx, output, selector, 0)
+	if err != nil {
+		return nil, err
+	}
+	if tooLarge {
+		err := fmt.Errorf("%w: more than %d bytes", ErrUTXOHistoryTooLarge, maxBytes)
+		slog.Error("rejecting UTXO history", "outpoint", query.Outpoint.String(), "topic", query.Topic, "error", err)
+		return nil, err
+	}
+	return history, nil
+}
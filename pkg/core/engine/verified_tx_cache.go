@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultVerifiedTxCacheSize caps the remembered verified txids when no cap is configured.
+const DefaultVerifiedTxCacheSize = 100000
+
+// VerifiedTxCacheConfig configures a VerifiedTxCache.
+type VerifiedTxCacheConfig struct {
+	// MaxEntries caps the remembered txids; the least recently used ones are forgotten first.
+	// Zero falls back to DefaultVerifiedTxCacheSize.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+type verifiedTx struct {
+	txid   chainhash.Hash
+	mined  bool
+	height uint32
+	root   chainhash.Hash
+}
+
+// VerifiedTxCache is a bounded LRU cache of txids whose SPV verification already succeeded against the ChainTracker.
+// Mined transactions are remembered together with the block height and merkle root their proof was validated for,
+// and only count as verified for a proof into that same block. Unmined transactions, verified through their
+// ancestry, count as verified until the next reorg.
+type VerifiedTxCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List // most recently used first
+	entries map[chainhash.Hash]*list.Element
+}
+
+// NewVerifiedTxCache creates an empty VerifiedTxCache with the given configuration.
+func NewVerifiedTxCache(cfg VerifiedTxCacheConfig) *VerifiedTxCache {
+	size := cfg.MaxEntries
+	if size <= 0 {
+		size = DefaultVerifiedTxCacheSize
+	}
+	return &VerifiedTxCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[chainhash.Hash]*list.Element),
+	}
+}
+
+// Contains reports whether the transaction was verified before. With a proof, the transaction must have been verified
+// with a proof into the same block; without one, any earlier verification counts.
+func (c *VerifiedTxCache) Contains(txid chainhash.Hash, proof *transaction.MerklePath) bool {
+	var root *chainhash.Hash
+	if proof != nil {
+		var err error
+		if root, err = proof.ComputeRoot(&txid); err != nil {
+			return false
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[txid]
+	if !ok {
+		return false
+	}
+	entry := elem.Value.(*verifiedTx)
+	if root != nil && (!entry.mined || entry.height != proof.BlockHeight || !entry.root.Equal(*root)) {
+		return false
+	}
+	c.order.MoveToFront(elem)
+	return true
+}
+
+// Add remembers the transaction as verified, with the proof it was verified against if it is mined,
+// forgetting the least recently used txids above the cap.
+func (c *VerifiedTxCache) Add(txid chainhash.Hash, proof *transaction.MerklePath) {
+	entry := &verifiedTx{txid: txid}
+	if proof != nil {
+		root, err := proof.ComputeRoot(&txid)
+		if err != nil {
+			return
+		}
+		entry.mined, entry.height, entry.root = true, proof.BlockHeight, *root
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[txid]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[txid] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*verifiedTx).txid)
+	}
+}
+
+// Invalidate forgets the transaction.
+func (c *VerifiedTxCache) Invalidate(txid chainhash.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[txid]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, txid)
+	}
+}
+
+// InvalidateFromHeight handles a reorg of the blocks from the given height: it forgets the transactions
+// verified with a proof into those blocks, and the unmined ones, whose ancestry may have been reorged out.
+func (c *VerifiedTxCache) InvalidateFromHeight(height uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for txid, elem := range c.entries {
+		entry := elem.Value.(*verifiedTx)
+		if !entry.mined || entry.height >= height {
+			c.order.Remove(elem)
+			delete(c.entries, txid)
+		}
+	}
+}
+
+// Len returns the number of remembered txids.
+func (c *VerifiedTxCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,295 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/google/uuid"
+)
+
+const (
+	// WebhookSignatureHeader carries the hex HMAC-SHA256 of the notification body, keyed by the subscription secret
+	// and prefixed with "sha256=".
+	WebhookSignatureHeader = "X-Overlay-Signature"
+
+	// WebhookIDHeader carries the ID of the subscription a notification is delivered for.
+	WebhookIDHeader = "X-Overlay-Webhook-Id"
+)
+
+const (
+	// DefaultWebhookTimeout bounds a single delivery attempt when no timeout is configured.
+	DefaultWebhookTimeout = 10 * time.Second
+
+	// DefaultWebhookMaxAttempts is how many times a notification is delivered before it is dropped when no limit is configured.
+	DefaultWebhookMaxAttempts = 3
+
+	// DefaultWebhookRetryBackoff is the delay before the first redelivery when no backoff is configured; it doubles on every attempt.
+	DefaultWebhookRetryBackoff = time.Second
+)
+
+var (
+	// ErrWebhooksNotConfigured is returned when managing webhook subscriptions on an engine without Webhooks
+	ErrWebhooksNotConfigured = errors.New("no webhooks configured")
+
+	// ErrWebhookNotFound is returned when unregistering a webhook subscription that does not exist
+	ErrWebhookNotFound = errors.New("webhook subscription not found")
+
+	// ErrInvalidWebhookURL is returned when registering a webhook whose URL is not an absolute http(s) URL
+	ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+)
+
+// WebhookSubscription is a callback URL notified with the STEAK of every submission admitting
+// outputs to, or removing coins from, one of its topics. A subscription without topics is
+// notified for every topic.
+type WebhookSubscription struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Topics    []string  `json:"topics"`
+	Secret    string    `json:"-"` // signs the notifications; only returned when registering
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookNotification is the JSON payload POSTed to a webhook subscription after a submission.
+// Steak only holds the topics of the subscription.
+type WebhookNotification struct {
+	Txid        string        `json:"txid"`
+	Steak       overlay.Steak `json:"steak"`
+	SubmittedAt time.Time     `json:"submittedAt"`
+}
+
+// WebhooksConfig configures the delivery of webhook notifications.
+type WebhooksConfig struct {
+	// Timeout bounds a single delivery attempt. Zero falls back to DefaultWebhookTimeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxAttempts is how many times a notification is delivered before it is dropped.
+	// Zero falls back to DefaultWebhookMaxAttempts.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// RetryBackoff is the delay before the first redelivery; it doubles on every attempt.
+	// Zero falls back to DefaultWebhookRetryBackoff.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+}
+
+// Webhooks turns the in-process OnSteakReady callback into an integration point for external systems:
+// after each Submit, the STEAK is POSTed to every subscription of an affected topic, signed with the
+// shared secret of the subscription. Subscriptions are kept in memory and notifications are delivered
+// in the background, so a slow subscriber never delays a submission.
+type Webhooks struct {
+	cfg    WebhooksConfig
+	client *http.Client
+
+	mu            sync.RWMutex
+	subscriptions map[string]*WebhookSubscription
+}
+
+// NewWebhooks creates Webhooks with the given configuration and no subscriptions.
+func NewWebhooks(cfg WebhooksConfig) *Webhooks {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	return &Webhooks{
+		cfg:           cfg,
+		client:        &http.Client{Timeout: timeout},
+		subscriptions: make(map[string]*WebhookSubscription),
+	}
+}
+
+func (w *Webhooks) maxAttempts() int {
+	if w.cfg.MaxAttempts > 0 {
+		return w.cfg.MaxAttempts
+	}
+	return DefaultWebhookMaxAttempts
+}
+
+func (w *Webhooks) retryBackoff() time.Duration {
+	if w.cfg.RetryBackoff > 0 {
+		return w.cfg.RetryBackoff
+	}
+	return DefaultWebhookRetryBackoff
+}
+
+// RegisterWebhook subscribes the callback URL to the STEAK of submissions for the given topics, or for
+// every topic when none are given. An empty secret is replaced by a random one; the returned subscription
+// is the only place the secret is exposed.
+// Returns ErrWebhooksNotConfigured without Webhooks, ErrInvalidWebhookURL for URLs that are not absolute
+// http(s) URLs and ErrUnknownTopic for topics the engine does not host.
+func (e *Engine) RegisterWebhook(_ context.Context, callbackURL string, topics []string, secret string) (*WebhookSubscription, error) {
+	if e.Webhooks == nil {
+		slog.Error("cannot register webhook", "error", ErrWebhooksNotConfigured)
+		return nil, ErrWebhooksNotConfigured
+	}
+	if u, err := url.Parse(callbackURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		slog.Error("cannot register webhook", "url", callbackURL, "error", ErrInvalidWebhookURL)
+		return nil, ErrInvalidWebhookURL
+	}
+	for _, topic := range topics {
+		if _, ok := e.topicManager(topic); !ok {
+			slog.Error("cannot register webhook for unknown topic", "topic", topic, "error", ErrUnknownTopic)
+			return nil, ErrUnknownTopic
+		}
+	}
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			slog.Error("failed to generate webhook secret", "error", err)
+			return nil, err
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	subscription := &WebhookSubscription{
+		ID:        uuid.NewString(),
+		URL:       callbackURL,
+		Topics:    slices.Clone(topics),
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+	e.Webhooks.mu.Lock()
+	e.Webhooks.subscriptions[subscription.ID] = subscription
+	e.Webhooks.mu.Unlock()
+
+	slog.Info("webhook registered", "id", subscription.ID, "url", callbackURL, "topics", topics)
+	cp := *subscription
+	return &cp, nil
+}
+
+// ListWebhooks returns the webhook subscriptions ordered by creation time, without their secrets.
+// Returns ErrWebhooksNotConfigured without Webhooks.
+func (e *Engine) ListWebhooks(_ context.Context) ([]*WebhookSubscription, error) {
+	if e.Webhooks == nil {
+		slog.Error("cannot list webhooks", "error", ErrWebhooksNotConfigured)
+		return nil, ErrWebhooksNotConfigured
+	}
+
+	e.Webhooks.mu.RLock()
+	defer e.Webhooks.mu.RUnlock()
+	subscriptions := make([]*WebhookSubscription, 0, len(e.Webhooks.subscriptions))
+	for _, subscription := range e.Webhooks.subscriptions {
+		cp := *subscription
+		cp.Secret = ""
+		subscriptions = append(subscriptions, &cp)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+// UnregisterWebhook removes the webhook subscription with the given ID.
+// Returns ErrWebhooksNotConfigured without Webhooks and ErrWebhookNotFound for unknown subscriptions.
+func (e *Engine) UnregisterWebhook(_ context.Context, id string) error {
+	if e.Webhooks == nil {
+		slog.Error("cannot unregister webhook", "error", ErrWebhooksNotConfigured)
+		return ErrWebhooksNotConfigured
+	}
+
+	e.Webhooks.mu.Lock()
+	defer e.Webhooks.mu.Unlock()
+	if _, ok := e.Webhooks.subscriptions[id]; !ok {
+		slog.Error("cannot unregister webhook", "id", id, "error", ErrWebhookNotFound)
+		return ErrWebhookNotFound
+	}
+	delete(e.Webhooks.subscriptions, id)
+	slog.Info("webhook unregistered", "id", id)
+	return nil
+}
+
+// notifyWebhooks delivers the STEAK of a submission, restricted to each subscription's topics, to every
+// subscription of a topic that admitted outputs or removed coins. Topics the transaction was already
+// applied to are skipped, so replays and retries do not notify twice.
+func (e *Engine) notifyWebhooks(ctx context.Context, txid *chainhash.Hash, steak overlay.Steak, skip map[string]struct{}) {
+	if e.Webhooks == nil {
+		return
+	}
+
+	submittedAt := time.Now()
+	e.Webhooks.mu.RLock()
+	defer e.Webhooks.mu.RUnlock()
+	for _, subscription := range e.Webhooks.subscriptions {
+		filtered := make(overlay.Steak)
+		for topic, admit := range steak {
+			if _, ok := skip[topic]; ok || admit == nil || (len(admit.OutputsToAdmit) == 0 && len(admit.CoinsRemoved) == 0) {
+				continue
+			}
+			if len(subscription.Topics) == 0 || slices.Contains(subscription.Topics, topic) {
+				filtered[topic] = admit
+			}
+		}
+		if len(filtered) == 0 {
+			continue
+		}
+		notification := WebhookNotification{Txid: txid.String(), Steak: filtered, SubmittedAt: submittedAt}
+		go e.Webhooks.deliver(context.WithoutCancel(ctx), *subscription, notification)
+	}
+}
+
+// deliver posts the notification to the subscription, retrying with exponential backoff until
+// it is acknowledged with a 2xx status or the attempts are exhausted.
+func (w *Webhooks) deliver(ctx context.Context, subscription WebhookSubscription, notification WebhookNotification) {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		slog.Error("failed to encode webhook notification", "id", subscription.ID, "error", err)
+		return
+	}
+
+	backoff := w.retryBackoff()
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, subscription, body)
+		if err == nil {
+			slog.Debug("webhook notification delivered", "id", subscription.ID, "txid", notification.Txid)
+			return
+		}
+		if attempt >= w.maxAttempts() {
+			slog.Error("failed to deliver webhook notification", "id", subscription.ID, "url", subscription.URL, "txid", notification.Txid, "attempts", attempt, "error", err)
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *Webhooks) post(ctx context.Context, subscription WebhookSubscription, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, subscription.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, body))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the value of the WebhookSignatureHeader for the body, so subscribers
+// can verify notifications by recomputing it with their secret and comparing with hmac.Equal.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -50,6 +50,12 @@ type Params struct {
 	Unidirectional  bool
 	LogLevel        slog.Level
 	Concurrency     int
+	// OnPageSynced is invoked after every fully processed page of the initial response,
+	// allowing callers to checkpoint the sync progress.
+	OnPageSynced func(ctx context.Context, lastInteraction float64)
+	// Ingested is shared by the syncs of a topic with several peers, so that a graph ingested
+	// from one peer is not requested again from another. Nil disables the deduplication.
+	Ingested *IngestedGraphs
 }
 
 // GASP implements the Graph Aware Sync Protocol for synchronizing transaction graphs.
@@ -61,6 +67,8 @@ type GASP struct {
 	LogPrefix       string
 	Unidirectional  bool
 	LogLevel        slog.Level
+	OnPageSynced    func(ctx context.Context, lastInteraction float64)
+	Ingested        *IngestedGraphs
 	limiter         chan struct{}
 }
 
@@ -71,6 +79,8 @@ func NewGASP(params Params) *GASP {
 		Remote:          params.Remote,
 		LastInteraction: params.LastInteraction,
 		Unidirectional:  params.Unidirectional,
+		OnPageSynced:    params.OnPageSynced,
+		Ingested:        params.Ingested,
 		// Sequential:      params.Sequential,
 	}
 	if params.Concurrency > 1 {
@@ -111,9 +121,10 @@ func (g *GASP) Sync(ctx context.Context, _ string, limit uint32) error {
 
 	var initialResponse *InitialResponse
 	for {
+		since := g.LastInteraction
 		initialRequest := &InitialRequest{
 			Version: g.Version,
-			Since:   g.LastInteraction,
+			Since:   since,
 			Limit:   limit,
 		}
 		initialResponse, err = g.Remote.GetInitialResponse(ctx, initialRequest)
@@ -145,17 +156,24 @@ func (g *GASP) Sync(ctx context.Context, _ string, limit uint32) error {
 					wg.Done()
 				}()
 				outpoint := utxo.Outpoint()
+				if !g.Ingested.claim(outpoint) {
+					slog.Debug(fmt.Sprintf("%sSkipping incoming UTXO %s: already ingested from another peer", g.LogPrefix, outpoint))
+					return
+				}
 				resolvedNode, err := g.Remote.RequestNode(ctx, outpoint, outpoint, true)
 				if err != nil {
+					g.Ingested.release(outpoint)
 					slog.Warn(fmt.Sprintf("%sError with incoming UTXO %s: %v", g.LogPrefix, outpoint, err))
 					return
 				}
 				slog.Debug(fmt.Sprintf("%sReceived unspent graph node from remote: %v", g.LogPrefix, resolvedNode))
 				if err = g.processIncomingNode(ctx, resolvedNode, nil, &sync.Map{}); err != nil {
+					g.Ingested.release(outpoint)
 					slog.Warn(fmt.Sprintf("%sError processing incoming node %s: %v", g.LogPrefix, outpoint, err))
 					return
 				}
 				if err = g.CompleteGraph(ctx, resolvedNode.GraphID); err != nil {
+					g.Ingested.release(outpoint)
 					slog.Warn(fmt.Sprintf("%sError completing graph for %s: %v", g.LogPrefix, outpoint, err))
 					return
 				}
@@ -164,8 +182,16 @@ func (g *GASP) Sync(ctx context.Context, _ string, limit uint32) error {
 		}
 		wg.Wait()
 
+		if g.OnPageSynced != nil {
+			g.OnPageSynced(ctx, g.LastInteraction)
+		}
+
 		// Check if we have more pages to fetch
-		// If we got fewer items than we requested (or no limit was set), we've reached the end
+		// If we got fewer items than we requested (or no limit was set), we've reached the end,
+		// unless the remote truncated its response and the page moved the sync forward
+		if initialResponse.Partial && g.LastInteraction > since {
+			continue
+		}
 		if limit == 0 || len(initialResponse.UTXOList) < int(limit) {
 			break
 		}
@@ -296,13 +322,17 @@ func (g *GASP) CompleteGraph(ctx context.Context, graphID *transaction.Outpoint)
 	slog.Info(fmt.Sprintf("%sCompleting newly-synced graph: %s", g.LogPrefix, graphID.String()))
 	if err = g.Storage.ValidateGraphAnchor(ctx, graphID); err == nil {
 		slog.Debug(fmt.Sprintf("%sGraph validated for node: %s", g.LogPrefix, graphID.String()))
-		if finalizeErr := g.Storage.FinalizeGraph(ctx, graphID); finalizeErr == nil {
+		if err = g.Storage.FinalizeGraph(ctx, graphID); err == nil {
+			slog.Info(fmt.Sprintf("%sGraph finalized for node: %s", g.LogPrefix, graphID.String()))
 			return nil
 		}
-		slog.Info(fmt.Sprintf("%sGraph finalized for node: %s", g.LogPrefix, graphID.String()))
 	}
 	slog.Warn(fmt.Sprintf("%sError completing graph %s: %v", g.LogPrefix, graphID.String(), err))
-	return g.Storage.DiscardGraph(ctx, graphID)
+	if discardErr := g.Storage.DiscardGraph(ctx, graphID); discardErr != nil {
+		return discardErr
+	}
+	// Surface the rejection reason so it can be communicated to the remote.
+	return err
 }
 
 func (g *GASP) processIncomingNode(ctx context.Context, node *Node, spentBy *transaction.Outpoint, seenNodes *sync.Map) error {
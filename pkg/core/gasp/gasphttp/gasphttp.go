@@ -0,0 +1,225 @@
+// Package gasphttp serves the Graph Aware Sync Protocol over HTTP for any application implementing gasp.Storage.
+//
+// The handler speaks the same wire format as the overlay's GASP endpoints, so a peer syncing with an overlay
+// node, such as the engine's OverlayGASPRemote, can sync with any application hosting it. It depends only on
+// net/http and the gasp package, not on the overlay engine or server.
+package gasphttp
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Paths of the GASP endpoints relative to the mount point of the handler.
+const (
+	PathInitialRequest = "/requestSyncResponse"    // InitialRequest in, InitialResponse out
+	PathInitialReply   = "/requestSyncReply"       // InitialResponse in, InitialReply out
+	PathRequestNode    = "/requestForeignGASPNode" // NodeRequest in, Node out
+	PathSubmitNode     = "/submitGASPNode"         // Node in, NodeResponse out
+	TopicHeader        = "X-BSV-Topic"             // names the topic whose GASP instance serves the request
+)
+
+var (
+	// ErrUnknownTopic is returned by a TopicResolver when it serves no GASP instance for the topic.
+	ErrUnknownTopic = errors.New("unknown topic")
+
+	// ErrMissingNodeRequestFields is returned when a node request omits the graph ID or transaction ID.
+	ErrMissingNodeRequestFields = errors.New("node request requires graphID and txid")
+)
+
+// DefaultLimits bounds the GASP requests decoded by the handler when no limits are configured.
+// An initial response carries one small entry per UTXO; a submitted node carries a raw transaction,
+// its proof and ancillary BEEF, which may each be large.
+var DefaultLimits = Limits{
+	InitialRequest: jsonlimit.Limits{
+		MaxBytes:        4 * 1024,
+		MaxStringLength: 1024,
+		MaxArrayLength:  16,
+		MaxDepth:        4,
+	},
+	InitialResponse: jsonlimit.Limits{
+		MaxBytes:        256 * 1024 * 1024,
+		MaxStringLength: 1024,
+		MaxArrayLength:  2_000_000,
+		MaxDepth:        8,
+	},
+	NodeRequest: jsonlimit.Limits{
+		MaxBytes:        4 * 1024,
+		MaxStringLength: 1024,
+		MaxArrayLength:  16,
+		MaxDepth:        4,
+	},
+	Node: jsonlimit.Limits{
+		MaxBytes:        1000 * 1024 * 1024,
+		MaxStringLength: 500 * 1024 * 1024,
+		MaxArrayLength:  100_000,
+		MaxDepth:        16,
+	},
+}
+
+// Limits bounds the JSON requests decoded by the handler, per message type.
+// Unset limits fall back to DefaultLimits; negative limits are disabled.
+type Limits struct {
+	InitialRequest  jsonlimit.Limits `mapstructure:"initial_request"`
+	InitialResponse jsonlimit.Limits `mapstructure:"initial_response"`
+	NodeRequest     jsonlimit.Limits `mapstructure:"node_request"`
+	Node            jsonlimit.Limits `mapstructure:"node"`
+}
+
+func (l Limits) withDefaults() Limits {
+	return Limits{
+		InitialRequest:  l.InitialRequest.Or(DefaultLimits.InitialRequest),
+		InitialResponse: l.InitialResponse.Or(DefaultLimits.InitialResponse),
+		NodeRequest:     l.NodeRequest.Or(DefaultLimits.NodeRequest),
+		Node:            l.Node.Or(DefaultLimits.Node),
+	}
+}
+
+// TopicResolver returns the GASP instance serving the topic named by the X-BSV-Topic header,
+// or ErrUnknownTopic when there is none.
+type TopicResolver func(topic string) (*gasp.GASP, error)
+
+// Handler is an http.Handler serving the GASP endpoints.
+type Handler struct {
+	resolve TopicResolver
+	limits  Limits
+	mux     *http.ServeMux
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithLimits sets the limits enforced while decoding requests.
+func WithLimits(limits Limits) Option {
+	return func(h *Handler) {
+		h.limits = limits
+	}
+}
+
+// NewHandler returns a Handler serving a single GASP instance, whatever the topic of the request.
+// Panics if the GASP instance is nil.
+func NewHandler(g *gasp.GASP, opts ...Option) *Handler {
+	if g == nil {
+		panic("gasp instance cannot be nil")
+	}
+	return NewTopicHandler(func(string) (*gasp.GASP, error) { return g, nil }, opts...)
+}
+
+// NewTopicHandler returns a Handler serving the GASP instance the resolver returns for the topic of each request.
+// Panics if the resolver is nil.
+func NewTopicHandler(resolve TopicResolver, opts ...Option) *Handler {
+	if resolve == nil {
+		panic("gasp topic resolver cannot be nil")
+	}
+	h := &Handler{resolve: resolve, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.limits = h.limits.withDefaults()
+
+	h.mux.HandleFunc("POST "+PathInitialRequest, h.handleInitialRequest)
+	h.mux.HandleFunc("POST "+PathInitialReply, h.handleInitialReply)
+	h.mux.HandleFunc("POST "+PathRequestNode, h.handleRequestNode)
+	h.mux.HandleFunc("POST "+PathSubmitNode, h.handleSubmitNode)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) handleInitialRequest(w http.ResponseWriter, r *http.Request) {
+	g, request, ok := decode[gasp.InitialRequest](h, w, r, h.limits.InitialRequest)
+	if !ok {
+		return
+	}
+	response, err := g.GetInitialResponse(r.Context(), request)
+	respond(w, r, response, err)
+}
+
+func (h *Handler) handleInitialReply(w http.ResponseWriter, r *http.Request) {
+	g, response, ok := decode[gasp.InitialResponse](h, w, r, h.limits.InitialResponse)
+	if !ok {
+		return
+	}
+	reply, err := g.GetInitialReply(r.Context(), response)
+	respond(w, r, reply, err)
+}
+
+func (h *Handler) handleRequestNode(w http.ResponseWriter, r *http.Request) {
+	g, request, ok := decode[gasp.NodeRequest](h, w, r, h.limits.NodeRequest)
+	if !ok {
+		return
+	}
+	if request.GraphID == nil || request.Txid == nil {
+		writeError(w, http.StatusBadRequest, ErrMissingNodeRequestFields)
+		return
+	}
+	outpoint := &transaction.Outpoint{Txid: *request.Txid, Index: request.OutputIndex}
+	node, err := g.RequestNode(r.Context(), request.GraphID, outpoint, request.Metadata)
+	respond(w, r, node, err)
+}
+
+func (h *Handler) handleSubmitNode(w http.ResponseWriter, r *http.Request) {
+	g, node, ok := decode[gasp.Node](h, w, r, h.limits.Node)
+	if !ok {
+		return
+	}
+	requested, err := g.SubmitNode(r.Context(), node)
+	respond(w, r, requested, err)
+}
+
+// decode resolves the GASP instance of the request topic and decodes the request body within the limits.
+// It writes the error response and returns false when either fails.
+func decode[T any](h *Handler, w http.ResponseWriter, r *http.Request, limits jsonlimit.Limits) (*gasp.GASP, *T, bool) {
+	g, err := h.resolve(r.Header.Get(TopicHeader))
+	if errors.Is(err, ErrUnknownTopic) {
+		writeError(w, http.StatusNotFound, err)
+		return nil, nil, false
+	} else if err != nil {
+		slog.Error("failed to resolve GASP instance", "topic", r.Header.Get(TopicHeader), "error", err)
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+
+	var v T
+	if err := jsonlimit.Decode(r.Body, &v, limits); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, nil, false
+	}
+	return g, &v, true
+}
+
+// respond writes the result of a GASP operation. Version mismatches are reported with the
+// VersionMismatchError body so that the peer can tell them apart from other failures.
+func respond(w http.ResponseWriter, r *http.Request, result any, err error) {
+	var mismatch *gasp.VersionMismatchError
+	switch {
+	case errors.As(err, &mismatch):
+		writeJSON(w, http.StatusConflict, mismatch)
+	case err != nil:
+		slog.Error("GASP request failed", "path", r.URL.Path, "topic", r.Header.Get(TopicHeader), "error", err)
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"message": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to encode GASP response", "error", err)
+	}
+}
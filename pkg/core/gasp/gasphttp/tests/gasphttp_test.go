@@ -0,0 +1,189 @@
+package gasphttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp/gasphttp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStorage is a gasp.Storage whose methods panic unless their func field is set.
+type fakeStorage struct {
+	findKnownUTXOs   func(ctx context.Context, since float64, limit uint32) ([]*gasp.Output, error)
+	hydrateGASPNode  func(ctx context.Context, graphID, outpoint *transaction.Outpoint, metadata bool) (*gasp.Node, error)
+	findNeededInputs func(ctx context.Context, node *gasp.Node) (*gasp.NodeResponse, error)
+	appendToGraph    func(ctx context.Context, node *gasp.Node, spentBy *transaction.Outpoint) error
+}
+
+func (f fakeStorage) FindKnownUTXOs(ctx context.Context, since float64, limit uint32) ([]*gasp.Output, error) {
+	return f.findKnownUTXOs(ctx, since, limit)
+}
+
+func (f fakeStorage) HydrateGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, metadata bool) (*gasp.Node, error) {
+	return f.hydrateGASPNode(ctx, graphID, outpoint, metadata)
+}
+
+func (f fakeStorage) FindNeededInputs(ctx context.Context, node *gasp.Node) (*gasp.NodeResponse, error) {
+	return f.findNeededInputs(ctx, node)
+}
+
+func (f fakeStorage) AppendToGraph(ctx context.Context, node *gasp.Node, spentBy *transaction.Outpoint) error {
+	return f.appendToGraph(ctx, node, spentBy)
+}
+
+func (fakeStorage) ValidateGraphAnchor(context.Context, *transaction.Outpoint) error {
+	panic("not implemented")
+}
+
+func (fakeStorage) DiscardGraph(context.Context, *transaction.Outpoint) error {
+	panic("not implemented")
+}
+
+func (fakeStorage) FinalizeGraph(context.Context, *transaction.Outpoint) error {
+	panic("not implemented")
+}
+
+func testTxID(t *testing.T) *chainhash.Hash {
+	t.Helper()
+	txid, err := chainhash.NewHashFromHex("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	require.NoError(t, err)
+	return txid
+}
+
+// newRemote serves the handler and returns an overlay GASP remote syncing the topic from it.
+func newRemote(t *testing.T, handler http.Handler) (*engine.OverlayGASPRemote, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &engine.OverlayGASPRemote{EndpointURL: srv.URL, Topic: "tm_a", HTTPClient: srv.Client()}, srv
+}
+
+func TestHandler_ShouldServeInitialResponse_ToOverlayRemote(t *testing.T) {
+	// given:
+	utxos := []*gasp.Output{{Txid: *testTxID(t), OutputIndex: 1, Score: 100}}
+	remote, _ := newRemote(t, gasphttp.NewHandler(gasp.NewGASP(gasp.Params{
+		Storage: fakeStorage{
+			findKnownUTXOs: func(_ context.Context, since float64, _ uint32) ([]*gasp.Output, error) {
+				require.InDelta(t, 10.0, since, 0)
+				return utxos, nil
+			},
+		},
+	})))
+
+	// when:
+	response, err := remote.GetInitialResponse(context.Background(), &gasp.InitialRequest{Version: 1, Since: 10})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, utxos, response.UTXOList)
+}
+
+func TestHandler_ShouldServeNode_ToOverlayRemote(t *testing.T) {
+	// given:
+	graphID := &transaction.Outpoint{Txid: *testTxID(t), Index: 0}
+	node := &gasp.Node{GraphID: graphID, RawTx: "0100", OutputIndex: 0}
+	remote, _ := newRemote(t, gasphttp.NewHandler(gasp.NewGASP(gasp.Params{
+		Storage: fakeStorage{
+			hydrateGASPNode: func(_ context.Context, actualGraphID, outpoint *transaction.Outpoint, metadata bool) (*gasp.Node, error) {
+				require.Equal(t, graphID, actualGraphID)
+				require.Equal(t, graphID, outpoint)
+				require.True(t, metadata)
+				return node, nil
+			},
+		},
+	})))
+
+	// when:
+	actual, err := remote.RequestNode(context.Background(), graphID, graphID, true)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, node, actual)
+}
+
+func TestHandler_ShouldAppendSubmittedNode(t *testing.T) {
+	// given:
+	graphID := &transaction.Outpoint{Txid: *testTxID(t), Index: 0}
+	var appended *gasp.Node
+	_, srv := newRemote(t, gasphttp.NewHandler(gasp.NewGASP(gasp.Params{
+		Storage: fakeStorage{
+			appendToGraph: func(_ context.Context, node *gasp.Node, _ *transaction.Outpoint) error {
+				appended = node
+				return nil
+			},
+			findNeededInputs: func(context.Context, *gasp.Node) (*gasp.NodeResponse, error) {
+				return nil, nil //nolint:nilnil // no inputs needed
+			},
+		},
+	})))
+	body, err := json.Marshal(&gasp.Node{GraphID: graphID, RawTx: "0100"})
+	require.NoError(t, err)
+
+	// when:
+	res, err := srv.Client().Post(srv.URL+gasphttp.PathSubmitNode, "application/json", strings.NewReader(string(body)))
+
+	// then:
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, graphID, appended.GraphID)
+}
+
+func TestHandler_ShouldReportVersionMismatch(t *testing.T) {
+	// given:
+	_, srv := newRemote(t, gasphttp.NewHandler(gasp.NewGASP(gasp.Params{Storage: fakeStorage{}})))
+
+	// when:
+	res, err := srv.Client().Post(srv.URL+gasphttp.PathInitialRequest, "application/json", strings.NewReader(`{"version":2,"since":0}`))
+
+	// then:
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var mismatch gasp.VersionMismatchError
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&mismatch))
+	require.Equal(t, 1, mismatch.CurrentVersion)
+	require.Equal(t, 2, mismatch.ForeignVersion)
+}
+
+func TestHandler_ShouldRespondNotFound_WhenTopicUnknown(t *testing.T) {
+	// given:
+	handler := gasphttp.NewTopicHandler(func(string) (*gasp.GASP, error) { return nil, gasphttp.ErrUnknownTopic })
+	remote, _ := newRemote(t, handler)
+
+	// when:
+	_, err := remote.GetInitialResponse(context.Background(), &gasp.InitialRequest{Version: 1})
+
+	// then:
+	var httpErr *util.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
+
+func TestHandler_ShouldRejectRequest_WhenBodyExceedsLimits(t *testing.T) {
+	// given:
+	handler := gasphttp.NewHandler(gasp.NewGASP(gasp.Params{Storage: fakeStorage{}}), gasphttp.WithLimits(gasphttp.Limits{
+		InitialRequest: jsonlimit.Limits{MaxBytes: 8},
+	}))
+	_, srv := newRemote(t, handler)
+
+	// when:
+	res, err := srv.Client().Post(srv.URL+gasphttp.PathInitialRequest, "application/json", strings.NewReader(`{"version":1,"since":0}`))
+
+	// then:
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
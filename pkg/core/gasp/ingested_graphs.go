@@ -0,0 +1,58 @@
+package gasp
+
+import (
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// IngestedGraphs is a set of graph IDs shared by concurrent syncs of the same topic with different peers.
+// A sync claims a graph before requesting it from its peer and skips the graphs already claimed by another,
+// releasing the claim when the graph cannot be ingested so that another peer may still provide it.
+// The zero value is ready to use, and a nil *IngestedGraphs claims every graph.
+type IngestedGraphs struct {
+	graphs sync.Map
+}
+
+// NewIngestedGraphs creates an empty IngestedGraphs.
+func NewIngestedGraphs() *IngestedGraphs {
+	return &IngestedGraphs{}
+}
+
+// Contains reports whether the graph identified by graphID is ingested or being ingested.
+func (i *IngestedGraphs) Contains(graphID *transaction.Outpoint) bool {
+	if i == nil {
+		return false
+	}
+	_, ok := i.graphs.Load(graphID.String())
+	return ok
+}
+
+// Len returns the number of graphs ingested or being ingested.
+func (i *IngestedGraphs) Len() int {
+	if i == nil {
+		return 0
+	}
+	n := 0
+	i.graphs.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// claim marks the graph as being ingested, returning false when it already was.
+func (i *IngestedGraphs) claim(graphID *transaction.Outpoint) bool {
+	if i == nil {
+		return true
+	}
+	_, loaded := i.graphs.LoadOrStore(graphID.String(), struct{}{})
+	return !loaded
+}
+
+// release forgets a graph that could not be ingested.
+func (i *IngestedGraphs) release(graphID *transaction.Outpoint) {
+	if i != nil {
+		i.graphs.Delete(graphID.String())
+	}
+}
@@ -493,3 +493,110 @@ func TestGASP_SyncBasicScenarios(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestGASP_Sync_ShouldSkipGraphsIngestedFromAnotherPeer(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	utxo := createMockUTXO("shared_rawtx", 0, 111)
+	ingested := gasp.NewIngestedGraphs()
+	local := newMockGASPStorage([]*mockUTXO{})
+	// Keep the ingested graph out of the known UTXOs, as when both peers are synced concurrently.
+	local.finalizeGraphFunc = func(_ context.Context, _ *transaction.Outpoint) error {
+		return nil
+	}
+
+	var requests []string
+	var mu sync.Mutex
+	newPeer := func(name string) *gasp.GASP {
+		peer := gasp.NewGASP(gasp.Params{Storage: newMockGASPStorage([]*mockUTXO{utxo})})
+		return gasp.NewGASP(gasp.Params{
+			Storage:        local,
+			Unidirectional: true,
+			Ingested:       ingested,
+			Remote: &mockGASPRemote{
+				targetGASP: peer,
+				requestNodeFunc: func(ctx context.Context, graphID, outpoint *transaction.Outpoint, metadata bool) (*gasp.Node, error) {
+					mu.Lock()
+					requests = append(requests, name)
+					mu.Unlock()
+					return peer.Storage.HydrateGASPNode(ctx, graphID, outpoint, metadata)
+				},
+			},
+		})
+	}
+
+	// when:
+	require.NoError(t, newPeer("alice").Sync(ctx, "alice", 0))
+	require.NoError(t, newPeer("bob").Sync(ctx, "bob", 0))
+
+	// then:
+	require.Equal(t, []string{"alice"}, requests)
+	require.True(t, ingested.Contains(utxo.GraphID))
+	require.Equal(t, 1, ingested.Len())
+}
+
+func TestGASP_Sync_ShouldReleaseGraphsThatFailToIngest(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	utxo := createMockUTXO("shared_rawtx", 0, 111)
+	ingested := gasp.NewIngestedGraphs()
+	storage := newMockGASPStorage([]*mockUTXO{})
+	storage.validateGraphAnchorFunc = func(_ context.Context, _ *transaction.Outpoint) error {
+		return errInvalidGraphAnchor
+	}
+	sut := gasp.NewGASP(gasp.Params{
+		Storage:        storage,
+		Unidirectional: true,
+		Ingested:       ingested,
+		Remote:         &mockGASPRemote{targetGASP: gasp.NewGASP(gasp.Params{Storage: newMockGASPStorage([]*mockUTXO{utxo})})},
+	})
+
+	// when:
+	err := sut.Sync(ctx, "test-host", 0)
+
+	// then:
+	require.NoError(t, err)
+	require.False(t, ingested.Contains(utxo.GraphID))
+}
+
+func TestGASP_Sync_ShouldKeepPagingPartialResponses(t *testing.T) {
+	// given:
+	ctx := context.Background()
+	utxos := []*mockUTXO{createMockUTXO("first", 0, 111), createMockUTXO("second", 1, 222), createMockUTXO("third", 2, 333)}
+	remote := gasp.NewGASP(gasp.Params{Storage: newMockGASPStorage(utxos)})
+	local := newMockGASPStorage([]*mockUTXO{})
+
+	var requests []float64
+	sut := gasp.NewGASP(gasp.Params{
+		Storage:        local,
+		Unidirectional: true,
+		Remote: &mockGASPRemote{
+			targetGASP: remote,
+			initialResponseFunc: func(ctx context.Context, request *gasp.InitialRequest) (*gasp.InitialResponse, error) {
+				requests = append(requests, request.Since)
+				response, err := remote.GetInitialResponse(ctx, request)
+				if err != nil {
+					return nil, err
+				}
+				// Serve a single unseen UTXO per page, as a responder capping its responses would.
+				var page []*gasp.Output
+				for _, utxo := range response.UTXOList {
+					if utxo.Score > request.Since {
+						page = append(page, utxo)
+						break
+					}
+				}
+				return &gasp.InitialResponse{UTXOList: page, Since: response.Since, Partial: len(page) > 0}, nil
+			},
+		},
+	})
+
+	// when:
+	err := sut.Sync(ctx, "test-host", 10)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, local.knownStore, 3)
+	require.Equal(t, float64(333), sut.LastInteraction)
+	require.Len(t, requests, 4)
+}
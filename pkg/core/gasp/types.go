@@ -2,6 +2,7 @@ package gasp
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
@@ -22,9 +23,12 @@ type Output struct {
 }
 
 // InitialResponse represents the response to an initial GASP request containing a list of UTXOs and timestamp.
+// A partial response was truncated by the responder, which serves the following UTXOs to a request for the
+// next page even when the requested limit was not reached.
 type InitialResponse struct {
 	UTXOList []*Output `json:"UTXOList"`
 	Since    float64   `json:"since"`
+	Partial  bool      `json:"partial,omitempty"`
 }
 
 // Outpoint converts the GASP Output to a transaction Outpoint.
@@ -60,6 +64,19 @@ type Node struct {
 	OutputMetadata string                `json:"outputMetadata"`
 	Inputs         map[string]*Input     `json:"inputs"`
 	AncillaryBeef  []byte                `json:"ancillaryBeef"`
+	Provenance     *NodeProvenance       `json:"provenance,omitempty"`
+	// OffChainValues holds the off-chain values submitted with the transaction of the node's output, which the
+	// receiving peer submits along with the transaction.
+	OffChainValues []byte `json:"offChainValues,omitempty"`
+}
+
+// NodeProvenance describes how the serving node came to hold a GASP node's output.
+// It is only included when the node is requested with metadata, so that downstream
+// peers can weigh retention and trust decisions.
+type NodeProvenance struct {
+	ReceivedAt *time.Time `json:"receivedAt,omitempty"`
+	Source     string     `json:"source,omitempty"`
+	Score      float64    `json:"score"`
 }
 
 // NodeResponseData contains metadata flags for a node response.
@@ -0,0 +1,264 @@
+// Package headers provides a chaintracker.ChainTracker served from a local store of block headers,
+// kept in sync with a configurable block header source.
+package headers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+// DefaultPollInterval is how often the tracker syncs with its source when no interval is configured.
+const DefaultPollInterval = 30 * time.Second
+
+var (
+	// ErrHeaderNotFound is returned when no header is known at the requested height.
+	ErrHeaderNotFound = errors.New("block header not found")
+
+	// ErrHeaderSourceRequired is returned when a tracker is configured without a header source.
+	ErrHeaderSourceRequired = errors.New("block header source is required")
+
+	// ErrReorgTooDeep is returned when the source's chain does not connect to any locally stored header.
+	ErrReorgTooDeep = errors.New("block header source does not connect to the local chain")
+)
+
+// Header is a block header of the active chain, reduced to the fields needed to verify merkle proofs.
+type Header struct {
+	Height        uint32
+	Hash          chainhash.Hash
+	PreviousBlock chainhash.Hash
+	MerkleRoot    chainhash.Hash
+}
+
+// Source provides the block headers of the active chain, e.g. a block headers service or a P2P header sync.
+type Source interface {
+	// Tip returns the header at the tip of the active chain.
+	Tip(ctx context.Context) (*Header, error)
+
+	// HeaderByHeight returns the header at the given height of the active chain.
+	HeaderByHeight(ctx context.Context, height uint32) (*Header, error)
+}
+
+// Store persists the synced headers, indexed by height. Headers are appended in height order.
+type Store interface {
+	// Tip returns the highest stored header, or ErrHeaderNotFound when the store is empty.
+	Tip(ctx context.Context) (*Header, error)
+
+	// HeaderByHeight returns the stored header at the given height, or ErrHeaderNotFound.
+	HeaderByHeight(ctx context.Context, height uint32) (*Header, error)
+
+	// Append stores the header directly above the current tip.
+	Append(ctx context.Context, header *Header) error
+
+	// DeleteFromHeight deletes the headers at or above the given height.
+	DeleteFromHeight(ctx context.Context, height uint32) error
+}
+
+// Config configures a Tracker and its built-in block headers service source.
+type Config struct {
+	// URL is the block headers service (Pulse) the headers are synced from.
+	URL string `mapstructure:"url"`
+
+	// APIKey authorizes the requests to the block headers service.
+	APIKey string `mapstructure:"api_key"`
+
+	// Path is the file the headers are persisted to. Empty keeps them in memory.
+	Path string `mapstructure:"path"`
+
+	// StartHeight is the first height synced into an empty store; proofs for lower blocks cannot be verified.
+	StartHeight uint32 `mapstructure:"start_height"`
+
+	// PollInterval is how often the tracker syncs with its source. Zero falls back to DefaultPollInterval.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// Tracker is a chaintracker.ChainTracker answering from a local header store, which Sync and Run keep
+// in line with the header source. It also resolves block hashes by height for merkle proof attestations.
+type Tracker struct {
+	Source       Source
+	Store        Store
+	StartHeight  uint32
+	PollInterval time.Duration
+
+	// OnReorg, when set, is called with the lowest reorged height after stale headers were deleted,
+	// e.g. to invalidate the engine's verified transactions cache.
+	OnReorg func(height uint32)
+
+	mu sync.Mutex // serializes syncs
+}
+
+var _ chaintracker.ChainTracker = (*Tracker)(nil)
+
+// NewTracker creates a Tracker syncing the given store from the given source.
+// It panics if the source or the store is nil.
+func NewTracker(source Source, store Store, cfg Config) *Tracker {
+	if source == nil {
+		panic("headers source is required")
+	}
+	if store == nil {
+		panic("headers store is required")
+	}
+	return &Tracker{
+		Source:       source,
+		Store:        store,
+		StartHeight:  cfg.StartHeight,
+		PollInterval: cfg.PollInterval,
+	}
+}
+
+// NewTrackerFromConfig creates a Tracker syncing from the block headers service at cfg.URL,
+// persisting the headers to cfg.Path, or in memory when no path is configured.
+func NewTrackerFromConfig(cfg Config) (*Tracker, error) {
+	if cfg.URL == "" {
+		return nil, ErrHeaderSourceRequired
+	}
+	var store Store = NewMemoryStore()
+	if cfg.Path != "" {
+		fileStore, err := OpenFileStore(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	}
+	return NewTracker(NewBlockHeadersServiceSource(cfg.URL, cfg.APIKey), store, cfg), nil
+}
+
+// IsValidRootForHeight reports whether the merkle root is the root of the locally stored header at the given height.
+// Heights not synced yet are reported as invalid.
+func (t *Tracker) IsValidRootForHeight(ctx context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+	header, err := t.Store.HeaderByHeight(ctx, height)
+	if errors.Is(err, ErrHeaderNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return header.MerkleRoot.IsEqual(root), nil
+}
+
+// CurrentHeight returns the height of the locally stored tip.
+func (t *Tracker) CurrentHeight(ctx context.Context) (uint32, error) {
+	tip, err := t.Store.Tip(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return tip.Height, nil
+}
+
+// BlockHashAtHeight returns the hash of the locally stored header at the given height.
+func (t *Tracker) BlockHashAtHeight(ctx context.Context, height uint32) (*chainhash.Hash, error) {
+	header, err := t.Store.HeaderByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &header.Hash, nil
+}
+
+// Sync appends the headers between the local tip and the source's tip to the store. When the source's chain
+// forks below the local tip, the stale local headers are deleted first and OnReorg is called.
+func (t *Tracker) Sync(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sourceTip, err := t.Source.Tip(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the source tip: %w", err)
+	}
+
+	next := t.StartHeight
+	localTip, err := t.Store.Tip(ctx)
+	switch {
+	case errors.Is(err, ErrHeaderNotFound):
+	case err != nil:
+		return err
+	default:
+		if next, err = t.reconcile(ctx, localTip, sourceTip); err != nil {
+			return err
+		}
+	}
+
+	for height := next; height <= sourceTip.Height; height++ {
+		header, err := t.Source.HeaderByHeight(ctx, height)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the header at height %d: %w", height, err)
+		}
+		if height > t.StartHeight {
+			previous, err := t.Store.HeaderByHeight(ctx, height-1)
+			if err != nil {
+				return err
+			}
+			if !header.PreviousBlock.IsEqual(&previous.Hash) {
+				// The source reorged while syncing; the next sync reconciles from the new tip.
+				slog.Warn("block header source reorged during sync", "height", height)
+				return nil
+			}
+		}
+		if err := t.Store.Append(ctx, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcile deletes the local headers not on the source's chain and returns the next height to sync.
+func (t *Tracker) reconcile(ctx context.Context, localTip, sourceTip *Header) (uint32, error) {
+	height := min(localTip.Height, sourceTip.Height)
+	for {
+		local, err := t.Store.HeaderByHeight(ctx, height)
+		if err != nil {
+			return 0, err
+		}
+		remote, err := t.Source.HeaderByHeight(ctx, height)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch the header at height %d: %w", height, err)
+		}
+		if remote.Hash.IsEqual(&local.Hash) {
+			break
+		}
+		if height == t.StartHeight {
+			return 0, ErrReorgTooDeep
+		}
+		height--
+	}
+	if height == localTip.Height {
+		return height + 1, nil
+	}
+
+	reorged := height + 1
+	slog.Warn("block header reorg detected", "fromHeight", reorged, "localTip", localTip.Height)
+	if err := t.Store.DeleteFromHeight(ctx, reorged); err != nil {
+		return 0, err
+	}
+	if t.OnReorg != nil {
+		t.OnReorg(reorged)
+	}
+	return reorged, nil
+}
+
+// Run syncs with the source immediately and then every PollInterval until ctx is done.
+// Failed syncs are logged and retried on the next tick.
+func (t *Tracker) Run(ctx context.Context) {
+	interval := t.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := t.Sync(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("block header sync failed", "interval", interval, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package headers
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker/headers_client"
+)
+
+// BlockHeadersServiceSource is a Source backed by a block headers service (Pulse).
+type BlockHeadersServiceSource struct {
+	client *headers_client.Client
+}
+
+// NewBlockHeadersServiceSource creates a Source for the block headers service at the given URL.
+func NewBlockHeadersServiceSource(url, apiKey string) *BlockHeadersServiceSource {
+	return &BlockHeadersServiceSource{client: &headers_client.Client{Url: url, ApiKey: apiKey}}
+}
+
+// Tip returns the header at the tip of the service's longest chain.
+func (s *BlockHeadersServiceSource) Tip(ctx context.Context) (*Header, error) {
+	state, err := s.client.GetChaintip(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state.Header.Height = state.Height
+	return toHeader(&state.Header), nil
+}
+
+// HeaderByHeight returns the header at the given height of the service's longest chain.
+func (s *BlockHeadersServiceSource) HeaderByHeight(ctx context.Context, height uint32) (*Header, error) {
+	header, err := s.client.BlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return toHeader(header), nil
+}
+
+func toHeader(header *headers_client.Header) *Header {
+	return &Header{
+		Height:        header.Height,
+		Hash:          header.Hash,
+		PreviousBlock: header.PreviousBlock,
+		MerkleRoot:    header.MerkleRoot,
+	}
+}
@@ -0,0 +1,181 @@
+package headers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// fileRecordSize is the size of a header record in a FileStore: height, hash, previous block hash and merkle root.
+const fileRecordSize = 4 + 3*32
+
+// MemoryStore is an in-memory Store.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	headers []*Header // contiguous, lowest height first
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Tip returns the highest stored header.
+func (s *MemoryStore) Tip(_ context.Context) (*Header, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.headers) == 0 {
+		return nil, ErrHeaderNotFound
+	}
+	header := *s.headers[len(s.headers)-1]
+	return &header, nil
+}
+
+// HeaderByHeight returns the stored header at the given height.
+func (s *MemoryStore) HeaderByHeight(_ context.Context, height uint32) (*Header, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i, ok := s.index(height)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+	header := *s.headers[i]
+	return &header, nil
+}
+
+// Append stores the header directly above the current tip.
+func (s *MemoryStore) Append(_ context.Context, header *Header) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n := len(s.headers); n > 0 && header.Height != s.headers[n-1].Height+1 {
+		return fmt.Errorf("cannot append header %d above tip %d", header.Height, s.headers[n-1].Height)
+	}
+	stored := *header
+	s.headers = append(s.headers, &stored)
+	return nil
+}
+
+// DeleteFromHeight deletes the headers at or above the given height.
+func (s *MemoryStore) DeleteFromHeight(_ context.Context, height uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.headers) == 0 || height > s.headers[len(s.headers)-1].Height {
+		return nil
+	}
+	if height <= s.headers[0].Height {
+		s.headers = nil
+		return nil
+	}
+	s.headers = s.headers[:height-s.headers[0].Height]
+	return nil
+}
+
+func (s *MemoryStore) index(height uint32) (int, bool) {
+	if len(s.headers) == 0 || height < s.headers[0].Height {
+		return 0, false
+	}
+	i := int(height - s.headers[0].Height)
+	return i, i < len(s.headers)
+}
+
+// FileStore is a Store persisting the headers to an append-only file of fixed-size records, which is
+// truncated on reorgs. The headers are also kept in memory, and loaded from the file when it is opened.
+type FileStore struct {
+	memory *MemoryStore
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileStore opens, or creates, the header file at the given path and loads its headers.
+func OpenFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	memory := NewMemoryStore()
+	record := make([]byte, fileRecordSize)
+	for {
+		if _, err := io.ReadFull(file, record); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to read header file %s: %w", path, err)
+		}
+		if err := memory.Append(context.Background(), decodeRecord(record)); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("corrupt header file %s: %w", path, err)
+		}
+	}
+	return &FileStore{memory: memory, file: file}, nil
+}
+
+// Tip returns the highest stored header.
+func (s *FileStore) Tip(ctx context.Context) (*Header, error) {
+	return s.memory.Tip(ctx)
+}
+
+// HeaderByHeight returns the stored header at the given height.
+func (s *FileStore) HeaderByHeight(ctx context.Context, height uint32) (*Header, error) {
+	return s.memory.HeaderByHeight(ctx, height)
+}
+
+// Append writes the header to the end of the file and stores it above the current tip.
+func (s *FileStore) Append(ctx context.Context, header *Header) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.memory.Append(ctx, header); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	_, err := s.file.Write(encodeRecord(header))
+	return err
+}
+
+// DeleteFromHeight truncates the file below the given height and deletes the headers at or above it.
+func (s *FileStore) DeleteFromHeight(ctx context.Context, height uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.memory.DeleteFromHeight(ctx, height); err != nil {
+		return err
+	}
+	s.memory.mu.RLock()
+	records := len(s.memory.headers)
+	s.memory.mu.RUnlock()
+	return s.file.Truncate(int64(records) * fileRecordSize)
+}
+
+// Close closes the header file.
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
+
+func encodeRecord(header *Header) []byte {
+	record := make([]byte, 0, fileRecordSize)
+	record = append(record, byte(header.Height), byte(header.Height>>8), byte(header.Height>>16), byte(header.Height>>24))
+	record = append(record, header.Hash[:]...)
+	record = append(record, header.PreviousBlock[:]...)
+	return append(record, header.MerkleRoot[:]...)
+}
+
+func decodeRecord(record []byte) *Header {
+	header := &Header{
+		Height: uint32(record[0]) | uint32(record[1])<<8 | uint32(record[2])<<16 | uint32(record[3])<<24,
+	}
+	copy(header.Hash[:], record[4:36])
+	copy(header.PreviousBlock[:], record[36:68])
+	copy(header.MerkleRoot[:], record[68:100])
+	return header
+}
@@ -0,0 +1,151 @@
+package headers_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/headers"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource serves a chain of headers starting at height 0.
+type fakeSource struct {
+	chain []*headers.Header
+}
+
+func (s *fakeSource) Tip(_ context.Context) (*headers.Header, error) {
+	return s.chain[len(s.chain)-1], nil
+}
+
+func (s *fakeSource) HeaderByHeight(_ context.Context, height uint32) (*headers.Header, error) {
+	if int(height) >= len(s.chain) {
+		return nil, headers.ErrHeaderNotFound
+	}
+	return s.chain[height], nil
+}
+
+// extend appends blocks above the given height, replacing the blocks above it, with hashes and roots
+// derived from the fork name.
+func (s *fakeSource) extend(fromHeight uint32, blocks int, fork string) {
+	s.chain = s.chain[:fromHeight]
+	for i := range blocks {
+		height := fromHeight + uint32(i) //nolint:gosec // test height
+		header := &headers.Header{
+			Height:     height,
+			Hash:       chainhash.DoubleHashH(fmt.Appendf(nil, "%s block %d", fork, height)),
+			MerkleRoot: chainhash.DoubleHashH(fmt.Appendf(nil, "%s root %d", fork, height)),
+		}
+		if height > 0 {
+			header.PreviousBlock = s.chain[height-1].Hash
+		}
+		s.chain = append(s.chain, header)
+	}
+}
+
+func newFakeSource(blocks int) *fakeSource {
+	source := &fakeSource{}
+	source.extend(0, blocks, "main")
+	return source
+}
+
+func TestTracker_Sync_ShouldServeRootsFromTheLocalStore(t *testing.T) {
+	// given:
+	source := newFakeSource(10)
+	tracker := headers.NewTracker(source, headers.NewMemoryStore(), headers.Config{StartHeight: 2})
+
+	// when:
+	err := tracker.Sync(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	height, err := tracker.CurrentHeight(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint32(9), height)
+
+	valid, err := tracker.IsValidRootForHeight(context.Background(), &source.chain[5].MerkleRoot, 5)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = tracker.IsValidRootForHeight(context.Background(), &source.chain[5].MerkleRoot, 6)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	valid, err = tracker.IsValidRootForHeight(context.Background(), &source.chain[1].MerkleRoot, 1)
+	require.NoError(t, err)
+	require.False(t, valid, "heights below the start height are not synced")
+
+	hash, err := tracker.BlockHashAtHeight(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, source.chain[7].Hash, *hash)
+}
+
+func TestTracker_Sync_ShouldReplaceReorgedHeaders(t *testing.T) {
+	// given:
+	source := newFakeSource(10)
+	tracker := headers.NewTracker(source, headers.NewMemoryStore(), headers.Config{})
+	var reorgedFrom []uint32
+	tracker.OnReorg = func(height uint32) { reorgedFrom = append(reorgedFrom, height) }
+	require.NoError(t, tracker.Sync(context.Background()))
+	staleRoot := source.chain[8].MerkleRoot
+
+	// when:
+	source.extend(7, 5, "fork")
+	err := tracker.Sync(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, []uint32{7}, reorgedFrom)
+
+	height, err := tracker.CurrentHeight(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint32(11), height)
+
+	valid, err := tracker.IsValidRootForHeight(context.Background(), &staleRoot, 8)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	valid, err = tracker.IsValidRootForHeight(context.Background(), &source.chain[8].MerkleRoot, 8)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestFileStore_ShouldPersistHeadersAcrossReopens(t *testing.T) {
+	// given:
+	path := filepath.Join(t.TempDir(), "headers.dat")
+	source := newFakeSource(6)
+	store, err := headers.OpenFileStore(path)
+	require.NoError(t, err)
+	require.NoError(t, headers.NewTracker(source, store, headers.Config{}).Sync(context.Background()))
+	require.NoError(t, store.DeleteFromHeight(context.Background(), 4))
+	require.NoError(t, store.Close())
+
+	// when:
+	reopened, err := headers.OpenFileStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reopened.Close() })
+
+	// then:
+	tip, err := reopened.Tip(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, *source.chain[3], *tip)
+
+	_, err = reopened.HeaderByHeight(context.Background(), 4)
+	require.ErrorIs(t, err, headers.ErrHeaderNotFound)
+
+	require.NoError(t, headers.NewTracker(source, reopened, headers.Config{}).Sync(context.Background()))
+	tip, err = reopened.Tip(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, *source.chain[5], *tip)
+}
+
+func TestNewTrackerFromConfig_ShouldRequireASource(t *testing.T) {
+	// when:
+	tracker, err := headers.NewTrackerFromConfig(headers.Config{})
+
+	// then:
+	require.ErrorIs(t, err, headers.ErrHeaderSourceRequired)
+	require.Nil(t, tracker)
+}
@@ -0,0 +1,168 @@
+// Package jsonlimit decodes JSON documents from untrusted peers with bounded memory.
+//
+// The input is scanned token by token as the decoder pulls it from the underlying reader,
+// so an oversized document, string, array or nesting level is rejected as soon as its
+// offending byte is read instead of after the whole payload has been buffered.
+package jsonlimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrLimitExceeded is returned when a JSON document exceeds one of its configured limits.
+var ErrLimitExceeded = errors.New("JSON payload limit exceeded")
+
+// Limits bounds the shape of a single JSON document. A zero or negative field disables that limit.
+type Limits struct {
+	// MaxBytes bounds the size of the whole document.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+
+	// MaxStringLength bounds the raw length of every string value and object key, escapes included.
+	MaxStringLength int64 `mapstructure:"max_string_length"`
+
+	// MaxArrayLength bounds the number of elements of every array.
+	MaxArrayLength int64 `mapstructure:"max_array_length"`
+
+	// MaxDepth bounds how deeply objects and arrays may be nested.
+	MaxDepth int `mapstructure:"max_depth"`
+}
+
+// Or returns the limits with every unset field taken from fallback.
+func (l Limits) Or(fallback Limits) Limits {
+	if l.MaxBytes == 0 {
+		l.MaxBytes = fallback.MaxBytes
+	}
+	if l.MaxStringLength == 0 {
+		l.MaxStringLength = fallback.MaxStringLength
+	}
+	if l.MaxArrayLength == 0 {
+		l.MaxArrayLength = fallback.MaxArrayLength
+	}
+	if l.MaxDepth == 0 {
+		l.MaxDepth = fallback.MaxDepth
+	}
+	return l
+}
+
+// Decode decodes the first JSON document read from r into v, enforcing the given limits.
+func Decode(r io.Reader, v any, limits Limits) error {
+	return NewDecoder(r, limits).Decode(v)
+}
+
+// NewDecoder returns a json.Decoder reading from r that fails with ErrLimitExceeded
+// once the input exceeds the given limits.
+func NewDecoder(r io.Reader, limits Limits) *json.Decoder {
+	return json.NewDecoder(NewReader(r, limits))
+}
+
+// NewReader returns a reader that passes r through unchanged until the JSON it carries
+// exceeds the given limits, after which every read fails with ErrLimitExceeded.
+// The limits apply to the stream as a whole, which must hold a single JSON document.
+func NewReader(r io.Reader, limits Limits) io.Reader {
+	return &reader{in: r, limits: limits}
+}
+
+// container tracks an open object or array.
+type container struct {
+	array    bool
+	elements int64
+	inValue  bool // an array element has started and no comma has been read since
+}
+
+type reader struct {
+	in     io.Reader
+	limits Limits
+	err    error
+
+	read     int64
+	inString bool
+	escaped  bool
+	strLen   int64
+	stack    []container
+}
+
+// Read reads from the underlying reader and scans what it read, returning the bytes that
+// precede the first limit violation together with the violation.
+func (r *reader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.in.Read(p)
+	for i := range n {
+		if scanErr := r.scan(p[i]); scanErr != nil {
+			r.err = scanErr
+			return i, scanErr
+		}
+	}
+	return n, err
+}
+
+func (r *reader) scan(c byte) error {
+	r.read++
+	if r.limits.MaxBytes > 0 && r.read > r.limits.MaxBytes {
+		return fmt.Errorf("%w: document is larger than %d bytes", ErrLimitExceeded, r.limits.MaxBytes)
+	}
+
+	if r.inString {
+		switch {
+		case r.escaped:
+			r.escaped = false
+		case c == '\\':
+			r.escaped = true
+		case c == '"':
+			r.inString = false
+			return nil
+		}
+		r.strLen++
+		if r.limits.MaxStringLength > 0 && r.strLen > r.limits.MaxStringLength {
+			return fmt.Errorf("%w: string is longer than %d bytes", ErrLimitExceeded, r.limits.MaxStringLength)
+		}
+		return nil
+	}
+
+	switch c {
+	case ' ', '\t', '\r', '\n':
+		return nil
+	case ',':
+		if top := r.top(); top != nil && top.array {
+			top.inValue = false
+		}
+		return nil
+	case ']', '}':
+		if len(r.stack) > 0 {
+			r.stack = r.stack[:len(r.stack)-1]
+		}
+		return nil
+	}
+
+	if top := r.top(); top != nil && top.array && !top.inValue {
+		top.inValue = true
+		top.elements++
+		if r.limits.MaxArrayLength > 0 && top.elements > r.limits.MaxArrayLength {
+			return fmt.Errorf("%w: array has more than %d elements", ErrLimitExceeded, r.limits.MaxArrayLength)
+		}
+	}
+
+	switch c {
+	case '"':
+		r.inString = true
+		r.strLen = 0
+	case '[', '{':
+		if r.limits.MaxDepth > 0 && len(r.stack) >= r.limits.MaxDepth {
+			return fmt.Errorf("%w: nesting is deeper than %d levels", ErrLimitExceeded, r.limits.MaxDepth)
+		}
+		r.stack = append(r.stack, container{array: c == '['})
+	}
+	return nil
+}
+
+func (r *reader) top() *container {
+	if len(r.stack) == 0 {
+		return nil
+	}
+	return &r.stack[len(r.stack)-1]
+}
@@ -0,0 +1,111 @@
+package jsonlimit_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/jsonlimit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_ShouldDecodeDocumentsWithinLimits(t *testing.T) {
+	// given:
+	limits := jsonlimit.Limits{MaxBytes: 64, MaxStringLength: 5, MaxArrayLength: 3, MaxDepth: 3}
+	var got struct {
+		Name  string  `json:"name"`
+		Items [][]int `json:"items"`
+	}
+
+	// when:
+	err := jsonlimit.Decode(strings.NewReader(`{"name":"a\"b","items":[[1,2,3],[],[4]]}`), &got, limits)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, `a"b`, got.Name)
+	require.Equal(t, [][]int{{1, 2, 3}, {}, {4}}, got.Items)
+}
+
+func TestDecode_ShouldRejectDocumentsExceedingLimits(t *testing.T) {
+	tests := map[string]struct {
+		document string
+		limits   jsonlimit.Limits
+	}{
+		"document too large": {
+			document: `{"name":"abcdef"}`,
+			limits:   jsonlimit.Limits{MaxBytes: 10},
+		},
+		"string too long": {
+			document: `{"name":"abcdef"}`,
+			limits:   jsonlimit.Limits{MaxStringLength: 5},
+		},
+		"escapes counted": {
+			document: `{"name":"\u0041\u0042"}`,
+			limits:   jsonlimit.Limits{MaxStringLength: 8},
+		},
+		"key too long": {
+			document: `{"abcdef":1}`,
+			limits:   jsonlimit.Limits{MaxStringLength: 5},
+		},
+		"array too long": {
+			document: `[1,[2,3],{"a":4},"5"]`,
+			limits:   jsonlimit.Limits{MaxArrayLength: 3},
+		},
+		"nested array too long": {
+			document: `[[1,2,3,4]]`,
+			limits:   jsonlimit.Limits{MaxArrayLength: 3},
+		},
+		"nesting too deep": {
+			document: `{"a":[{"b":1}]}`,
+			limits:   jsonlimit.Limits{MaxDepth: 2},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			var got any
+			err := jsonlimit.Decode(strings.NewReader(tc.document), &got, tc.limits)
+
+			// then:
+			require.ErrorIs(t, err, jsonlimit.ErrLimitExceeded)
+		})
+	}
+}
+
+func TestNewReader_ShouldStopReadingAtTheFirstViolation(t *testing.T) {
+	// given:
+	oversized := io.MultiReader(strings.NewReader(`{"rawTx":"`), infiniteReader{})
+	sut := jsonlimit.NewReader(oversized, jsonlimit.Limits{MaxStringLength: 1024})
+
+	// when:
+	n, err := io.Copy(io.Discard, sut)
+
+	// then:
+	require.ErrorIs(t, err, jsonlimit.ErrLimitExceeded)
+	require.Less(t, n, int64(2048))
+}
+
+func TestLimits_Or_ShouldFillUnsetLimits(t *testing.T) {
+	// given:
+	limits := jsonlimit.Limits{MaxBytes: 10, MaxDepth: -1}
+
+	// when:
+	got := limits.Or(jsonlimit.Limits{MaxBytes: 20, MaxStringLength: 30, MaxArrayLength: 40, MaxDepth: 50})
+
+	// then:
+	require.Equal(t, jsonlimit.Limits{MaxBytes: 10, MaxStringLength: 30, MaxArrayLength: 40, MaxDepth: -1}, got)
+}
+
+type infiniteReader struct{}
+
+func (infiniteReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+	}
+	if len(p) == 0 {
+		return 0, errors.New("empty buffer")
+	}
+	return len(p), nil
+}
@@ -0,0 +1,198 @@
+// Package lookupanswer serializes the lookup answers returned by the lookup endpoint in a binary format shared by
+// the overlay server and its clients, carrying BEEF as raw bytes rather than base64 strings. It is laid out as
+//
+//	header  magic "LKUP" | version (1 byte) | type | result | next cursor | output count
+//	output  output index | txid | beef | raw tx | off-chain values | metadata count | (key | value)*
+//
+// with uvarint counts and output indexes, and uvarint length-prefixed strings and byte fields. The txid is
+// either empty or the 32 bytes of the hash, and metadata is written in lexical order of its keys, so that
+// equal answers always encode to equal bytes.
+package lookupanswer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// MIMEBinary is the content type of the binary serialization, requested through the Accept header.
+const MIMEBinary = "application/octet-stream"
+
+// Version1 is the only version of the binary format.
+const Version1 byte = 1
+
+// Magic identifies a binary lookup answer.
+var Magic = [4]byte{'L', 'K', 'U', 'P'}
+
+var (
+	// ErrUnsupportedVersion is returned when the version of a binary lookup answer is unknown.
+	ErrUnsupportedVersion = errors.New("lookupanswer: unsupported version")
+
+	// ErrCorrupt is returned when a binary lookup answer cannot be decoded.
+	ErrCorrupt = errors.New("lookupanswer: corrupt data")
+)
+
+// Answer is a lookup answer, or a page of one.
+type Answer struct {
+	Type       string   // type of the answer, e.g. "output-list" or "freeform"
+	Result     string   // JSON-encoded result of freeform answers
+	NextCursor string   // cursor of the next page; empty after the last page
+	Outputs    []Output // outputs of output-list answers
+}
+
+// Output is an output of a lookup answer.
+type Output struct {
+	Txid           *chainhash.Hash   // set for outputs hydrated by the overlay
+	OutputIndex    uint32            // index of the output in its transaction
+	Beef           []byte            // BEEF of the output when hydrated in full
+	RawTx          []byte            // raw transaction of the output when hydrated with the raw transaction only
+	OffChainValues []byte            // off-chain values submitted with the transaction, if any
+	Metadata       map[string]string // tags set by the topic manager when admitting the output, if any
+}
+
+// MarshalBinary encodes the answer in the binary format.
+func MarshalBinary(answer *Answer) []byte {
+	var buf bytes.Buffer
+	buf.Write(Magic[:])
+	buf.WriteByte(Version1)
+	writeBytes(&buf, []byte(answer.Type))
+	writeBytes(&buf, []byte(answer.Result))
+	writeBytes(&buf, []byte(answer.NextCursor))
+	buf.Write(binary.AppendUvarint(nil, uint64(len(answer.Outputs))))
+	for _, output := range answer.Outputs {
+		buf.Write(binary.AppendUvarint(nil, uint64(output.OutputIndex)))
+		if output.Txid != nil {
+			writeBytes(&buf, output.Txid[:])
+		} else {
+			writeBytes(&buf, nil)
+		}
+		writeBytes(&buf, output.Beef)
+		writeBytes(&buf, output.RawTx)
+		writeBytes(&buf, output.OffChainValues)
+		buf.Write(binary.AppendUvarint(nil, uint64(len(output.Metadata))))
+		for _, key := range slices.Sorted(maps.Keys(output.Metadata)) {
+			writeBytes(&buf, []byte(key))
+			writeBytes(&buf, []byte(output.Metadata[key]))
+		}
+	}
+	return buf.Bytes()
+}
+
+// UnmarshalBinary decodes an answer encoded by MarshalBinary. Empty byte fields decode as nil.
+func UnmarshalBinary(data []byte) (*Answer, error) {
+	r := bytes.NewReader(data)
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil || !bytes.Equal(header[:4], Magic[:]) {
+		return nil, fmt.Errorf("%w: missing magic", ErrCorrupt)
+	}
+	if header[4] != Version1 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header[4])
+	}
+
+	answer := &Answer{}
+	for _, field := range []*string{&answer.Type, &answer.Result, &answer.NextCursor} {
+		value, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		*field = string(value)
+	}
+	count, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	for range count {
+		output, err := readOutput(r)
+		if err != nil {
+			return nil, err
+		}
+		answer.Outputs = append(answer.Outputs, output)
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrCorrupt, r.Len())
+	}
+	return answer, nil
+}
+
+// readOutput reads an output of the answer.
+func readOutput(r *bytes.Reader) (Output, error) {
+	var output Output
+	index, err := binary.ReadUvarint(r)
+	if err != nil {
+		return output, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	} else if index > uint64(^uint32(0)) {
+		return output, fmt.Errorf("%w: output index %d", ErrCorrupt, index)
+	}
+	output.OutputIndex = uint32(index)
+
+	txid, err := readBytes(r)
+	if err != nil {
+		return output, err
+	}
+	switch len(txid) {
+	case 0:
+	case chainhash.HashSize:
+		output.Txid = (*chainhash.Hash)(txid)
+	default:
+		return output, fmt.Errorf("%w: txid of %d bytes", ErrCorrupt, len(txid))
+	}
+	for _, field := range []*[]byte{&output.Beef, &output.RawTx, &output.OffChainValues} {
+		if *field, err = readBytes(r); err != nil {
+			return output, err
+		}
+	}
+
+	entries, err := readCount(r)
+	if err != nil || entries == 0 {
+		return output, err
+	}
+	output.Metadata = make(map[string]string, entries)
+	for range entries {
+		key, err := readBytes(r)
+		if err != nil {
+			return output, err
+		}
+		value, err := readBytes(r)
+		if err != nil {
+			return output, err
+		}
+		output.Metadata[string(key)] = string(value)
+	}
+	return output, nil
+}
+
+// writeBytes writes the bytes prefixed with their uvarint length.
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	buf.Write(binary.AppendUvarint(nil, uint64(len(data))))
+	buf.Write(data)
+}
+
+// readBytes reads bytes prefixed with their uvarint length, returning nil when empty.
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := readCount(r)
+	if err != nil || n == 0 {
+		return nil, err
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	return data, nil
+}
+
+// readCount reads a uvarint count, bounded by the bytes left so that a corrupt count cannot force a huge allocation.
+func readCount(r *bytes.Reader) (int, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	} else if n > uint64(r.Len()) {
+		return 0, fmt.Errorf("%w: count %d exceeds the remaining %d bytes", ErrCorrupt, n, r.Len())
+	}
+	return int(n), nil
+}
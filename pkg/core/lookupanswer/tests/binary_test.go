@@ -0,0 +1,82 @@
+package lookupanswer_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/lookupanswer"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func newAnswer() *lookupanswer.Answer {
+	return &lookupanswer.Answer{
+		Type:       "output-list",
+		NextCursor: "cursor",
+		Outputs: []lookupanswer.Output{
+			{OutputIndex: 300, Beef: []byte{0xbe, 0xef}, Metadata: map[string]string{"b": "2", "a": "1"}},
+			{Txid: &chainhash.Hash{1}, OutputIndex: 1, RawTx: []byte{0x01}, OffChainValues: []byte{0x02}},
+		},
+	}
+}
+
+func TestMarshalBinary_ShouldRoundTrip(t *testing.T) {
+	// given:
+	data := lookupanswer.MarshalBinary(newAnswer())
+
+	// when:
+	decoded, err := lookupanswer.UnmarshalBinary(data)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, newAnswer(), decoded)
+	require.Equal(t, data, lookupanswer.MarshalBinary(decoded))
+}
+
+func TestMarshalBinary_ShouldCarryBEEFAsRawBytes(t *testing.T) {
+	// given:
+	beef := make([]byte, 1024)
+	answer := &lookupanswer.Answer{Type: "output-list", Outputs: []lookupanswer.Output{{Beef: beef}}}
+
+	// when:
+	data := lookupanswer.MarshalBinary(answer)
+
+	// then:
+	require.Less(t, len(data), len(beef)+32, "BEEF must not be inflated by a text encoding")
+}
+
+func TestMarshalBinary_ShouldRoundTripFreeformAnswers(t *testing.T) {
+	// given:
+	answer := &lookupanswer.Answer{Type: "freeform", Result: `{"count":2}`}
+
+	// when:
+	decoded, err := lookupanswer.UnmarshalBinary(lookupanswer.MarshalBinary(answer))
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, answer, decoded)
+}
+
+func TestUnmarshalBinary_ShouldRejectCorruptData(t *testing.T) {
+	data := lookupanswer.MarshalBinary(newAnswer())
+	tests := map[string]struct {
+		data        []byte
+		expectedErr error
+	}{
+		"missing magic":       {data: []byte("nope"), expectedErr: lookupanswer.ErrCorrupt},
+		"unsupported version": {data: append([]byte("LKUP"), 9), expectedErr: lookupanswer.ErrUnsupportedVersion},
+		"truncated":           {data: data[:len(data)-1], expectedErr: lookupanswer.ErrCorrupt},
+		"trailing bytes":      {data: append(append([]byte{}, data...), 0), expectedErr: lookupanswer.ErrCorrupt},
+		"short txid":          {data: append([]byte("LKUP\x01\x00\x00\x00\x01\x00\x02"), 1, 2), expectedErr: lookupanswer.ErrCorrupt},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			decoded, err := lookupanswer.UnmarshalBinary(tc.data)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, decoded)
+		})
+	}
+}
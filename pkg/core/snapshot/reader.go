@@ -0,0 +1,271 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"time"
+)
+
+// Reader streams records out of a snapshot container, verifying every section checksum
+// and the trailing file checksum as it goes.
+type Reader struct {
+	in       *bufio.Reader
+	file     hash.Hash
+	section  hash.Hash
+	topic    string
+	records  uint64
+	sections []TopicManifest
+	manifest *Manifest
+}
+
+// NewReader reads and validates the snapshot header from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	sr := &Reader{
+		in:      bufio.NewReader(r),
+		file:    sha256.New(),
+		section: sha256.New(),
+	}
+
+	header := make([]byte, len(Magic)+1)
+	if err := sr.read(header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:len(Magic)], Magic[:]) {
+		return nil, ErrInvalidMagic
+	}
+	if header[len(Magic)] != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header[len(Magic)])
+	}
+	return sr, nil
+}
+
+// Next returns the next record. It returns io.EOF once the manifest and trailing checksum
+// have been read and verified; the manifest is then available through Manifest.
+func (r *Reader) Next() (*Record, error) {
+	if r.manifest != nil {
+		return nil, io.EOF
+	}
+
+	for {
+		tag, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tag {
+		case tagSectionStart:
+			if r.topic != "" {
+				return nil, fmt.Errorf("%w: nested section", ErrCorrupt)
+			}
+			if r.topic, err = r.readString(); err != nil {
+				return nil, err
+			}
+			if r.topic == "" {
+				return nil, fmt.Errorf("%w: empty topic", ErrCorrupt)
+			}
+			r.records = 0
+			r.section.Reset()
+
+		case tagRecord:
+			if r.topic == "" {
+				return nil, fmt.Errorf("%w: record outside of a section", ErrCorrupt)
+			}
+			return r.readRecord()
+
+		case tagSectionEnd:
+			if err := r.endSection(); err != nil {
+				return nil, err
+			}
+
+		case tagManifest:
+			if r.topic != "" {
+				return nil, fmt.Errorf("%w: unterminated section %q", ErrCorrupt, r.topic)
+			}
+			if err := r.readManifest(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+
+		default:
+			return nil, fmt.Errorf("%w: unknown tag 0x%02x", ErrCorrupt, tag)
+		}
+	}
+}
+
+// Manifest returns the verified snapshot manifest, or nil until Next has returned io.EOF.
+func (r *Reader) Manifest() *Manifest {
+	return r.manifest
+}
+
+func (r *Reader) readRecord() (*Record, error) {
+	fixed := make([]byte, 32+4+4+8+1)
+	if err := r.read(fixed); err != nil {
+		return nil, err
+	}
+
+	record := &Record{Topic: r.topic}
+	copy(record.Outpoint.Txid[:], fixed[:32])
+	record.Outpoint.Index = binary.LittleEndian.Uint32(fixed[32:36])
+	record.BlockHeight = binary.LittleEndian.Uint32(fixed[36:40])
+	record.Score = math.Float64frombits(binary.LittleEndian.Uint64(fixed[40:48]))
+	switch fixed[48] {
+	case 0:
+	case 1:
+		record.Spent = true
+	default:
+		return nil, fmt.Errorf("%w: invalid spent flag", ErrCorrupt)
+	}
+
+	length, lengthBytes, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if length > maxBEEFLength {
+		return nil, fmt.Errorf("%w: BEEF length %d exceeds limit", ErrCorrupt, length)
+	}
+	record.BEEF = make([]byte, length)
+	if err := r.read(record.BEEF); err != nil {
+		return nil, err
+	}
+
+	r.section.Write([]byte{tagRecord})
+	r.section.Write(fixed)
+	r.section.Write(lengthBytes)
+	r.section.Write(record.BEEF)
+	r.records++
+	return record, nil
+}
+
+func (r *Reader) endSection() error {
+	if r.topic == "" {
+		return fmt.Errorf("%w: section end without start", ErrCorrupt)
+	}
+
+	var checksum [sha256.Size]byte
+	if err := r.read(checksum[:]); err != nil {
+		return err
+	}
+	if !bytes.Equal(checksum[:], r.section.Sum(nil)) {
+		return fmt.Errorf("%w: topic %q", ErrChecksumMismatch, r.topic)
+	}
+
+	r.sections = append(r.sections, TopicManifest{Topic: r.topic, Records: r.records, Checksum: checksum})
+	r.topic = ""
+	return nil
+}
+
+func (r *Reader) readManifest() error {
+	fixed := make([]byte, 16)
+	if err := r.read(fixed); err != nil {
+		return err
+	}
+	manifest := &Manifest{
+		Version:        Version,
+		CreatedAt:      time.Unix(0, int64(binary.LittleEndian.Uint64(fixed[:8]))),
+		ScoreWatermark: math.Float64frombits(binary.LittleEndian.Uint64(fixed[8:])),
+	}
+
+	count, _, err := r.readUvarint()
+	if err != nil {
+		return err
+	}
+	if count != uint64(len(r.sections)) {
+		return fmt.Errorf("%w: manifest lists %d topics, found %d sections", ErrCorrupt, count, len(r.sections))
+	}
+	for _, section := range r.sections {
+		var topic TopicManifest
+		if topic.Topic, err = r.readString(); err != nil {
+			return err
+		}
+		if topic.Records, _, err = r.readUvarint(); err != nil {
+			return err
+		}
+		if err := r.read(topic.Checksum[:]); err != nil {
+			return err
+		}
+		if topic != section {
+			return fmt.Errorf("%w: manifest entry for topic %q does not match its section", ErrCorrupt, topic.Topic)
+		}
+		manifest.Topics = append(manifest.Topics, topic)
+	}
+
+	expected := r.file.Sum(nil)
+	trailer := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r.in, trailer); err != nil {
+		return unexpectedEOF(err)
+	}
+	if !bytes.Equal(trailer, expected) {
+		return fmt.Errorf("%w: file", ErrChecksumMismatch)
+	}
+	if _, err := r.in.ReadByte(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: trailing data after checksum", ErrCorrupt)
+	}
+
+	r.manifest = manifest
+	return nil
+}
+
+func (r *Reader) read(b []byte) error {
+	if _, err := io.ReadFull(r.in, b); err != nil {
+		return unexpectedEOF(err)
+	}
+	r.file.Write(b)
+	return nil
+}
+
+func (r *Reader) readByte() (byte, error) {
+	b := make([]byte, 1)
+	if err := r.read(b); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// readUvarint decodes a uvarint and also returns its encoded bytes so callers can hash them.
+func (r *Reader) readUvarint() (uint64, []byte, error) {
+	encoded := make([]byte, 0, binary.MaxVarintLen64)
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		encoded = append(encoded, b)
+		if b < 0x80 {
+			v, n := binary.Uvarint(encoded)
+			if n <= 0 {
+				return 0, nil, fmt.Errorf("%w: invalid length prefix", ErrCorrupt)
+			}
+			return v, encoded, nil
+		}
+	}
+	return 0, nil, fmt.Errorf("%w: length prefix overflow", ErrCorrupt)
+}
+
+func (r *Reader) readString() (string, error) {
+	length, _, err := r.readUvarint()
+	if err != nil {
+		return "", err
+	}
+	if length > maxTopicLength {
+		return "", fmt.Errorf("%w: string length %d exceeds limit", ErrCorrupt, length)
+	}
+	b := make([]byte, length)
+	if err := r.read(b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w", ErrCorrupt, io.ErrUnexpectedEOF)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+// Package snapshot implements a compact, verifiable binary container for exporting overlay outputs.
+//
+// A snapshot is laid out as:
+//
+//	header   magic "OVSN" | version (1 byte)
+//	section  0x01 | topic | record* | 0x03 | SHA-256 of the section records
+//	record   0x02 | txid (32) | output index (u32) | block height (u32) | score (f64) | spent (1) | BEEF
+//	manifest 0x04 | created at (i64 unix nanos) | score watermark (f64) | topic count | (topic | records | checksum)*
+//	trailer  SHA-256 of every preceding byte
+//
+// Integers are little-endian; strings and BEEF payloads are prefixed with their uvarint length.
+// Each topic occupies exactly one section, and the manifest records the highest score written
+// so that an importer can resume incremental syncs from the snapshot's watermark.
+package snapshot
+
+import (
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Version is the snapshot format version produced by Writer.
+const Version byte = 1
+
+// Magic identifies a snapshot file.
+var Magic = [4]byte{'O', 'V', 'S', 'N'}
+
+const (
+	tagSectionStart byte = 0x01
+	tagRecord       byte = 0x02
+	tagSectionEnd   byte = 0x03
+	tagManifest     byte = 0x04
+)
+
+// maxBEEFLength bounds a single BEEF payload so a corrupt length prefix cannot force a huge allocation.
+const maxBEEFLength = 1 << 30
+
+// maxTopicLength bounds a single topic name.
+const maxTopicLength = 1 << 10
+
+var (
+	// ErrInvalidMagic is returned when the input does not start with the snapshot magic bytes.
+	ErrInvalidMagic = errors.New("snapshot: invalid magic")
+
+	// ErrUnsupportedVersion is returned when the snapshot was produced by an unknown format version.
+	ErrUnsupportedVersion = errors.New("snapshot: unsupported version")
+
+	// ErrChecksumMismatch is returned when a section or the whole file fails SHA-256 verification.
+	ErrChecksumMismatch = errors.New("snapshot: checksum mismatch")
+
+	// ErrCorrupt is returned when the snapshot structure cannot be decoded.
+	ErrCorrupt = errors.New("snapshot: corrupt data")
+
+	// ErrTopicSectionClosed is returned when a record is written for a topic whose section was already closed.
+	ErrTopicSectionClosed = errors.New("snapshot: topic section already closed")
+
+	// ErrWriterClosed is returned when writing to a closed Writer.
+	ErrWriterClosed = errors.New("snapshot: writer closed")
+
+	// ErrEmptyTopic is returned when a record is written without a topic.
+	ErrEmptyTopic = errors.New("snapshot: empty topic")
+)
+
+// Record is a single exported output together with its BEEF.
+type Record struct {
+	Topic       string
+	Outpoint    transaction.Outpoint
+	BlockHeight uint32
+	Score       float64
+	Spent       bool
+	BEEF        []byte
+}
+
+// TopicManifest summarizes a single topic section.
+type TopicManifest struct {
+	Topic    string
+	Records  uint64
+	Checksum [sha256.Size]byte
+}
+
+// Manifest describes the content of a snapshot. It is written after all sections.
+type Manifest struct {
+	Version        byte
+	CreatedAt      time.Time
+	ScoreWatermark float64
+	Topics         []TopicManifest
+}
@@ -0,0 +1,199 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/snapshot"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecord(topic string, seed byte, score float64) *snapshot.Record {
+	return &snapshot.Record{
+		Topic:       topic,
+		Outpoint:    transaction.Outpoint{Txid: chainhash.Hash{seed}, Index: uint32(seed)},
+		BlockHeight: 800000 + uint32(seed),
+		Score:       score,
+		Spent:       seed%2 == 0,
+		BEEF:        bytes.Repeat([]byte{seed}, int(seed)*10),
+	}
+}
+
+func writeSnapshot(t *testing.T, records ...*snapshot.Record) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := snapshot.NewWriter(&buf)
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, writer.WriteRecord(record))
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func readSnapshot(data []byte) ([]*snapshot.Record, *snapshot.Manifest, error) {
+	reader, err := snapshot.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*snapshot.Record
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records, reader.Manifest(), nil
+		}
+		if err != nil {
+			return records, nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	// given:
+	records := []*snapshot.Record{
+		newRecord("tm_a", 1, 10),
+		newRecord("tm_a", 2, 42.5),
+		newRecord("tm_b", 3, 20),
+		{Topic: "tm_c", Outpoint: transaction.Outpoint{Txid: chainhash.Hash{4}}},
+	}
+	data := writeSnapshot(t, records...)
+
+	// when:
+	actual, manifest, err := readSnapshot(data)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, actual, len(records))
+	for i, record := range records {
+		require.Equal(t, record.Topic, actual[i].Topic)
+		require.Equal(t, record.Outpoint, actual[i].Outpoint)
+		require.Equal(t, record.BlockHeight, actual[i].BlockHeight)
+		require.InDelta(t, record.Score, actual[i].Score, 0)
+		require.Equal(t, record.Spent, actual[i].Spent)
+		require.Equal(t, len(record.BEEF), len(actual[i].BEEF))
+		require.True(t, bytes.Equal(record.BEEF, actual[i].BEEF))
+	}
+
+	require.Equal(t, snapshot.Version, manifest.Version)
+	require.InDelta(t, 42.5, manifest.ScoreWatermark, 0)
+	require.Len(t, manifest.Topics, 3)
+	require.Equal(t, "tm_a", manifest.Topics[0].Topic)
+	require.Equal(t, uint64(2), manifest.Topics[0].Records)
+	require.Equal(t, uint64(1), manifest.Topics[1].Records)
+	require.Equal(t, uint64(1), manifest.Topics[2].Records)
+	require.False(t, manifest.CreatedAt.IsZero())
+}
+
+func TestSnapshot_EmptySnapshot(t *testing.T) {
+	// given:
+	data := writeSnapshot(t)
+
+	// when:
+	records, manifest, err := readSnapshot(data)
+
+	// then:
+	require.NoError(t, err)
+	require.Empty(t, records)
+	require.Empty(t, manifest.Topics)
+}
+
+func TestSnapshot_ShouldDetectCorruption(t *testing.T) {
+	data := writeSnapshot(t, newRecord("tm_a", 1, 10), newRecord("tm_b", 3, 20))
+
+	tests := map[string]struct {
+		mutate        func(data []byte) []byte
+		expectedError error
+	}{
+		"flipped BEEF byte fails the section checksum": {
+			mutate: func(data []byte) []byte {
+				data[bytes.Index(data, bytes.Repeat([]byte{3}, 30))+5] ^= 0xff
+				return data
+			},
+			expectedError: snapshot.ErrChecksumMismatch,
+		},
+		"flipped trailer byte fails the file checksum": {
+			mutate: func(data []byte) []byte {
+				data[len(data)-1] ^= 0xff
+				return data
+			},
+			expectedError: snapshot.ErrChecksumMismatch,
+		},
+		"manifest entry not matching its section is reported as corrupt": {
+			mutate: func(data []byte) []byte {
+				data[len(data)-40] ^= 0xff
+				return data
+			},
+			expectedError: snapshot.ErrCorrupt,
+		},
+		"truncated input is reported as corrupt": {
+			mutate:        func(data []byte) []byte { return data[:len(data)-10] },
+			expectedError: snapshot.ErrCorrupt,
+		},
+		"trailing data is reported as corrupt": {
+			mutate:        func(data []byte) []byte { return append(data, 0) },
+			expectedError: snapshot.ErrCorrupt,
+		},
+		"invalid magic is rejected": {
+			mutate: func(data []byte) []byte {
+				data[0] = 'X'
+				return data
+			},
+			expectedError: snapshot.ErrInvalidMagic,
+		},
+		"unknown version is rejected": {
+			mutate: func(data []byte) []byte {
+				data[4] = 99
+				return data
+			},
+			expectedError: snapshot.ErrUnsupportedVersion,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			corrupted := tc.mutate(bytes.Clone(data))
+
+			// when:
+			_, manifest, err := readSnapshot(corrupted)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Nil(t, manifest)
+		})
+	}
+}
+
+func TestWriter_ShouldRejectReopenedTopicSection(t *testing.T) {
+	// given:
+	writer, err := snapshot.NewWriter(io.Discard)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteRecord(newRecord("tm_a", 1, 1)))
+	require.NoError(t, writer.WriteRecord(newRecord("tm_b", 2, 2)))
+
+	// when:
+	err = writer.WriteRecord(newRecord("tm_a", 3, 3))
+
+	// then:
+	require.ErrorIs(t, err, snapshot.ErrTopicSectionClosed)
+}
+
+func TestWriter_ShouldRejectWrites_AfterClose(t *testing.T) {
+	// given:
+	writer, err := snapshot.NewWriter(io.Discard)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	// when:
+	err = writer.WriteRecord(newRecord("tm_a", 1, 1))
+
+	// then:
+	require.ErrorIs(t, err, snapshot.ErrWriterClosed)
+}
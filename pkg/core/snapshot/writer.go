@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"math"
+	"time"
+)
+
+// Writer streams records into the snapshot container. Records of a topic must be written
+// contiguously; Close must be called to emit the manifest and trailing checksum.
+type Writer struct {
+	out      *bufio.Writer
+	file     hash.Hash
+	section  hash.Hash
+	manifest Manifest
+	current  *TopicManifest
+	closed   map[string]struct{}
+	done     bool
+	buf      []byte
+}
+
+// NewWriter writes the snapshot header to w and returns a Writer ready to accept records.
+func NewWriter(w io.Writer) (*Writer, error) {
+	sw := &Writer{
+		out:      bufio.NewWriter(w),
+		file:     sha256.New(),
+		section:  sha256.New(),
+		manifest: Manifest{Version: Version, CreatedAt: time.Now()},
+		closed:   make(map[string]struct{}),
+	}
+	if err := sw.write(append(Magic[:], Version)); err != nil {
+		return nil, err
+	}
+	return sw, nil
+}
+
+// WriteRecord appends the record to its topic section, opening a new section when the topic changes.
+func (w *Writer) WriteRecord(record *Record) error {
+	if w.done {
+		return ErrWriterClosed
+	}
+	if record.Topic == "" {
+		return ErrEmptyTopic
+	}
+	if w.current == nil || w.current.Topic != record.Topic {
+		if _, ok := w.closed[record.Topic]; ok {
+			return ErrTopicSectionClosed
+		}
+		if err := w.endSection(); err != nil {
+			return err
+		}
+		if err := w.startSection(record.Topic); err != nil {
+			return err
+		}
+	}
+
+	b := w.buf[:0]
+	b = append(b, tagRecord)
+	b = append(b, record.Outpoint.Txid[:]...)
+	b = binary.LittleEndian.AppendUint32(b, record.Outpoint.Index)
+	b = binary.LittleEndian.AppendUint32(b, record.BlockHeight)
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(record.Score))
+	if record.Spent {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = binary.AppendUvarint(b, uint64(len(record.BEEF)))
+	w.buf = b
+
+	w.section.Write(b)
+	w.section.Write(record.BEEF)
+	if err := w.write(b); err != nil {
+		return err
+	}
+	if err := w.write(record.BEEF); err != nil {
+		return err
+	}
+
+	w.current.Records++
+	if record.Score > w.manifest.ScoreWatermark {
+		w.manifest.ScoreWatermark = record.Score
+	}
+	return nil
+}
+
+// Close ends the open section, writes the manifest and the trailing file checksum, and flushes the output.
+// It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.done {
+		return nil
+	}
+	if err := w.endSection(); err != nil {
+		return err
+	}
+	w.done = true
+
+	b := []byte{tagManifest}
+	b = binary.LittleEndian.AppendUint64(b, uint64(w.manifest.CreatedAt.UnixNano()))
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(w.manifest.ScoreWatermark))
+	b = binary.AppendUvarint(b, uint64(len(w.manifest.Topics)))
+	for _, topic := range w.manifest.Topics {
+		b = appendString(b, topic.Topic)
+		b = binary.AppendUvarint(b, topic.Records)
+		b = append(b, topic.Checksum[:]...)
+	}
+	if err := w.write(b); err != nil {
+		return err
+	}
+	if _, err := w.out.Write(w.file.Sum(nil)); err != nil {
+		return err
+	}
+	return w.out.Flush()
+}
+
+// Manifest returns the manifest accumulated so far; it is complete once Close has returned.
+func (w *Writer) Manifest() Manifest {
+	return w.manifest
+}
+
+func (w *Writer) startSection(topic string) error {
+	w.section.Reset()
+	w.manifest.Topics = append(w.manifest.Topics, TopicManifest{Topic: topic})
+	w.current = &w.manifest.Topics[len(w.manifest.Topics)-1]
+	return w.write(appendString([]byte{tagSectionStart}, topic))
+}
+
+func (w *Writer) endSection() error {
+	if w.current == nil {
+		return nil
+	}
+	copy(w.current.Checksum[:], w.section.Sum(nil))
+	w.closed[w.current.Topic] = struct{}{}
+	checksum := w.current.Checksum
+	w.current = nil
+	return w.write(append([]byte{tagSectionEnd}, checksum[:]...))
+}
+
+func (w *Writer) write(b []byte) error {
+	w.file.Write(b)
+	_, err := w.out.Write(b)
+	return err
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,129 @@
+package steak
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// Magic identifies a binary STEAK.
+var Magic = [4]byte{'S', 'T', 'E', 'K'}
+
+// maxTopicLength bounds a single topic name.
+const maxTopicLength = 1 << 10
+
+// MarshalBinary encodes the STEAK in the binary format of Version2.
+func MarshalBinary(steak overlay.Steak) []byte {
+	var buf bytes.Buffer
+	buf.Write(Magic[:])
+	buf.WriteByte(byte(Version2))
+	buf.Write(binary.AppendUvarint(nil, uint64(len(steak))))
+	for _, topic := range slices.Sorted(maps.Keys(steak)) {
+		instructions := steak[topic]
+		if instructions == nil {
+			instructions = &overlay.AdmittanceInstructions{}
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(len(topic))))
+		buf.WriteString(topic)
+		for _, indexes := range [][]uint32{instructions.OutputsToAdmit, instructions.CoinsToRetain, instructions.CoinsRemoved} {
+			buf.Write(binary.AppendUvarint(nil, uint64(len(indexes))))
+			for _, index := range indexes {
+				buf.Write(binary.AppendUvarint(nil, uint64(index)))
+			}
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(len(instructions.AncillaryTxids))))
+		for _, txid := range instructions.AncillaryTxids {
+			buf.Write(txid[:])
+		}
+	}
+	return buf.Bytes()
+}
+
+// UnmarshalBinary decodes a STEAK encoded by MarshalBinary.
+func UnmarshalBinary(data []byte) (overlay.Steak, error) {
+	r := bytes.NewReader(data)
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil || !bytes.Equal(header[:4], Magic[:]) {
+		return nil, fmt.Errorf("%w: missing magic", ErrCorrupt)
+	}
+	if Version(header[4]) != Version2 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header[4])
+	}
+
+	topics, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	steak := make(overlay.Steak, topics)
+	for range topics {
+		length, err := readCount(r)
+		if err != nil {
+			return nil, err
+		} else if length > maxTopicLength {
+			return nil, fmt.Errorf("%w: topic of %d bytes", ErrCorrupt, length)
+		}
+		topic := make([]byte, length)
+		if _, err := io.ReadFull(r, topic); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+		}
+
+		instructions := &overlay.AdmittanceInstructions{}
+		for _, indexes := range []*[]uint32{&instructions.OutputsToAdmit, &instructions.CoinsToRetain, &instructions.CoinsRemoved} {
+			if *indexes, err = readIndexes(r); err != nil {
+				return nil, err
+			}
+		}
+		txids, err := readCount(r)
+		if err != nil {
+			return nil, err
+		}
+		for range txids {
+			var txid chainhash.Hash
+			if _, err := io.ReadFull(r, txid[:]); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+			}
+			instructions.AncillaryTxids = append(instructions.AncillaryTxids, &txid)
+		}
+		steak[string(topic)] = instructions
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrCorrupt, r.Len())
+	}
+	return steak, nil
+}
+
+// readCount reads a uvarint count, bounded by the bytes left so that a corrupt count cannot force a huge allocation.
+func readCount(r *bytes.Reader) (int, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	} else if n > uint64(r.Len()) {
+		return 0, fmt.Errorf("%w: count %d exceeds the remaining %d bytes", ErrCorrupt, n, r.Len())
+	}
+	return int(n), nil
+}
+
+// readIndexes reads a uvarint count followed by as many uvarint output indexes.
+func readIndexes(r *bytes.Reader) ([]uint32, error) {
+	n, err := readCount(r)
+	if err != nil || n == 0 {
+		return nil, err
+	}
+	indexes := make([]uint32, 0, n)
+	for range n {
+		index, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+		} else if index > uint64(^uint32(0)) {
+			return nil, fmt.Errorf("%w: output index %d", ErrCorrupt, index)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
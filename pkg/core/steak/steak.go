@@ -0,0 +1,237 @@
+// Package steak serializes the STEAKs (Submitted Transaction Execution AcKnowledgments) returned by the
+// submit endpoint in versioned, deterministic formats shared by the overlay server and its clients.
+//
+// The JSON formats are:
+//
+//	version 1  {"STEAK": {topic: {"outputsToAdmit", "coinsToRetain", "coinsRemoved", "ancillaryTxIDs"}}}
+//	version 2  {"version": 2, "topics": [{"topic", "outputsToAdmit", "coinsToRetain", "coinsRemoved", "ancillaryTxids"}]}
+//
+// Version 1 is the original shape of the submit response and stays the default for clients that do not ask
+// for a version. Version 2 lists topics in lexical order and never encodes a list as null, so that equal
+// STEAKs always encode to equal bytes. The binary format, laid out as
+//
+//	header  magic "STEK" | version (1 byte) | topic count
+//	topic   topic | outputs to admit | coins to retain | coins removed | ancillary txid count | txid (32)*
+//
+// with uvarint counts and output indexes and uvarint length-prefixed topics, follows the same ordering.
+package steak
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// Version identifies a STEAK serialization format.
+type Version int
+
+// STEAK serialization versions.
+const (
+	// Version1 is the original JSON shape of the submit response, keyed by topic.
+	Version1 Version = 1
+
+	// Version2 is the deterministic JSON and binary shape listing topics in lexical order.
+	Version2 Version = 2
+
+	// Latest is the most recent version, used by clients negotiating the format.
+	Latest = Version2
+)
+
+// Header is the HTTP header negotiating the STEAK version: clients send the version they understand
+// and the server answers with the version of the body it returned.
+const Header = "X-STEAK-Version"
+
+// MIMEBinary is the content type of the binary serialization, requested through the Accept header.
+const MIMEBinary = "application/vnd.bsv.steak"
+
+var (
+	// ErrUnsupportedVersion is returned when a STEAK version is unknown.
+	ErrUnsupportedVersion = errors.New("steak: unsupported version")
+
+	// ErrCorrupt is returned when a serialized STEAK cannot be decoded.
+	ErrCorrupt = errors.New("steak: corrupt data")
+)
+
+// ParseVersion returns the version named by a Header value, defaulting to Version1 when empty.
+func ParseVersion(value string) (Version, error) {
+	if value == "" {
+		return Version1, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || (Version(n) != Version1 && Version(n) != Version2) {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedVersion, value)
+	}
+	return Version(n), nil
+}
+
+// String returns the Header value of the version.
+func (v Version) String() string {
+	return strconv.Itoa(int(v))
+}
+
+// instructionsV1 is the JSON encoding of overlay.AdmittanceInstructions in Version1.
+type instructionsV1 struct {
+	OutputsToAdmit []uint32 `json:"outputsToAdmit"`
+	CoinsToRetain  []uint32 `json:"coinsToRetain"`
+	CoinsRemoved   []uint32 `json:"coinsRemoved"`
+	AncillaryTxIDs []string `json:"ancillaryTxIDs"`
+}
+
+// documentV1 is the JSON encoding of a STEAK in Version1.
+type documentV1 struct {
+	STEAK map[string]instructionsV1 `json:"STEAK"`
+}
+
+// topicV2 is the JSON encoding of the admittance instructions of a topic in Version2.
+type topicV2 struct {
+	Topic          string   `json:"topic"`
+	OutputsToAdmit []uint32 `json:"outputsToAdmit"`
+	CoinsToRetain  []uint32 `json:"coinsToRetain"`
+	CoinsRemoved   []uint32 `json:"coinsRemoved"`
+	AncillaryTxids []string `json:"ancillaryTxids"`
+}
+
+// documentV2 is the JSON encoding of a STEAK in Version2.
+type documentV2 struct {
+	Version Version   `json:"version"`
+	Topics  []topicV2 `json:"topics"`
+}
+
+// MarshalJSON encodes the STEAK in the JSON format of the version.
+func MarshalJSON(steak overlay.Steak, version Version) ([]byte, error) {
+	switch version {
+	case Version1:
+		doc := documentV1{STEAK: make(map[string]instructionsV1, len(steak))}
+		for topic, instructions := range steak {
+			if instructions == nil {
+				instructions = &overlay.AdmittanceInstructions{}
+			}
+			ancillary := make([]string, 0, len(instructions.AncillaryTxids))
+			for _, txid := range instructions.AncillaryTxids {
+				ancillary = append(ancillary, txid.String())
+			}
+			doc.STEAK[topic] = instructionsV1{
+				OutputsToAdmit: instructions.OutputsToAdmit,
+				CoinsToRetain:  instructions.CoinsToRetain,
+				CoinsRemoved:   instructions.CoinsRemoved,
+				AncillaryTxIDs: ancillary,
+			}
+		}
+		return json.Marshal(doc)
+	case Version2:
+		return json.Marshal(newDocumentV2(steak))
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+}
+
+// newDocumentV2 returns the Version2 document of the STEAK.
+func newDocumentV2(steak overlay.Steak) documentV2 {
+	doc := documentV2{Version: Version2, Topics: make([]topicV2, 0, len(steak))}
+	for _, topic := range slices.Sorted(maps.Keys(steak)) {
+		instructions := steak[topic]
+		if instructions == nil {
+			instructions = &overlay.AdmittanceInstructions{}
+		}
+		ancillary := make([]string, 0, len(instructions.AncillaryTxids))
+		for _, txid := range instructions.AncillaryTxids {
+			ancillary = append(ancillary, txid.String())
+		}
+		doc.Topics = append(doc.Topics, topicV2{
+			Topic:          topic,
+			OutputsToAdmit: nonNil(instructions.OutputsToAdmit),
+			CoinsToRetain:  nonNil(instructions.CoinsToRetain),
+			CoinsRemoved:   nonNil(instructions.CoinsRemoved),
+			AncillaryTxids: ancillary,
+		})
+	}
+	return doc
+}
+
+// UnmarshalJSON decodes a STEAK encoded in the JSON format of the version. Empty lists of Version2 decode
+// as nil, like those of UnmarshalBinary, while Version1 keeps its null and empty lists apart as sent.
+func UnmarshalJSON(data []byte, version Version) (overlay.Steak, error) {
+	switch version {
+	case Version1:
+		var doc documentV1
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+		}
+		steak := make(overlay.Steak, len(doc.STEAK))
+		for topic, instructions := range doc.STEAK {
+			ancillary, err := parseTxids(instructions.AncillaryTxIDs)
+			if err != nil {
+				return nil, err
+			}
+			steak[topic] = &overlay.AdmittanceInstructions{
+				OutputsToAdmit: instructions.OutputsToAdmit,
+				CoinsToRetain:  instructions.CoinsToRetain,
+				CoinsRemoved:   instructions.CoinsRemoved,
+				AncillaryTxids: ancillary,
+			}
+		}
+		return steak, nil
+	case Version2:
+		var doc documentV2
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+		}
+		if doc.Version != Version2 {
+			return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, doc.Version)
+		}
+		steak := make(overlay.Steak, len(doc.Topics))
+		for _, topic := range doc.Topics {
+			ancillary, err := parseTxids(topic.AncillaryTxids)
+			if err != nil {
+				return nil, err
+			}
+			steak[topic.Topic] = &overlay.AdmittanceInstructions{
+				OutputsToAdmit: nilIfEmpty(topic.OutputsToAdmit),
+				CoinsToRetain:  nilIfEmpty(topic.CoinsToRetain),
+				CoinsRemoved:   nilIfEmpty(topic.CoinsRemoved),
+				AncillaryTxids: ancillary,
+			}
+		}
+		return steak, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+}
+
+// parseTxids decodes hex transaction IDs.
+func parseTxids(txids []string) ([]*chainhash.Hash, error) {
+	if len(txids) == 0 {
+		return nil, nil
+	}
+	hashes := make([]*chainhash.Hash, 0, len(txids))
+	for _, txid := range txids {
+		hash, err := chainhash.NewHashFromHex(txid)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrupt, err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// nonNil returns an empty slice in place of nil, so that it encodes as an empty JSON array.
+func nonNil(indexes []uint32) []uint32 {
+	if indexes == nil {
+		return []uint32{}
+	}
+	return indexes
+}
+
+// nilIfEmpty returns nil in place of an empty slice.
+func nilIfEmpty(indexes []uint32) []uint32 {
+	if len(indexes) == 0 {
+		return nil
+	}
+	return indexes
+}
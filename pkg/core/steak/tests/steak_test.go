@@ -0,0 +1,116 @@
+package steak_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/steak"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+func newSteak() overlay.Steak {
+	return overlay.Steak{
+		"tm_b": {OutputsToAdmit: []uint32{0, 300}, CoinsToRetain: []uint32{1}, AncillaryTxids: []*chainhash.Hash{{1}, {2}}},
+		"tm_a": {CoinsRemoved: []uint32{2}},
+	}
+}
+
+func TestMarshalJSON_ShouldEncodeVersion2Deterministically(t *testing.T) {
+	// when:
+	data, err := steak.MarshalJSON(newSteak(), steak.Version2)
+
+	// then:
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":2,"topics":[
+		{"topic":"tm_a","outputsToAdmit":[],"coinsToRetain":[],"coinsRemoved":[2],"ancillaryTxids":[]},
+		{"topic":"tm_b","outputsToAdmit":[0,300],"coinsToRetain":[1],"coinsRemoved":[],"ancillaryTxids":["`+
+		chainhash.Hash{1}.String()+`","`+chainhash.Hash{2}.String()+`"]}
+	]}`, string(data))
+	again, err := steak.MarshalJSON(newSteak(), steak.Version2)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
+
+func TestMarshalJSON_ShouldRoundTripEveryVersion(t *testing.T) {
+	for _, version := range []steak.Version{steak.Version1, steak.Version2} {
+		t.Run(version.String(), func(t *testing.T) {
+			// given:
+			data, err := steak.MarshalJSON(newSteak(), version)
+			require.NoError(t, err)
+
+			// when:
+			decoded, err := steak.UnmarshalJSON(data, version)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, newSteak()["tm_b"], decoded["tm_b"])
+			require.Equal(t, []uint32{2}, decoded["tm_a"].CoinsRemoved)
+			require.Empty(t, decoded["tm_a"].OutputsToAdmit)
+		})
+	}
+}
+
+func TestMarshalBinary_ShouldRoundTrip(t *testing.T) {
+	// given:
+	data := steak.MarshalBinary(newSteak())
+
+	// when:
+	decoded, err := steak.UnmarshalBinary(data)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, overlay.Steak{
+		"tm_a": {CoinsRemoved: []uint32{2}},
+		"tm_b": newSteak()["tm_b"],
+	}, decoded)
+	require.Equal(t, data, steak.MarshalBinary(decoded))
+}
+
+func TestUnmarshalBinary_ShouldRejectCorruptData(t *testing.T) {
+	data := steak.MarshalBinary(newSteak())
+	tests := map[string]struct {
+		data        []byte
+		expectedErr error
+	}{
+		"missing magic":       {data: []byte("nope"), expectedErr: steak.ErrCorrupt},
+		"unsupported version": {data: append([]byte("STEK"), 9), expectedErr: steak.ErrUnsupportedVersion},
+		"truncated":           {data: data[:len(data)-1], expectedErr: steak.ErrCorrupt},
+		"trailing bytes":      {data: append(append([]byte{}, data...), 0), expectedErr: steak.ErrCorrupt},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			decoded, err := steak.UnmarshalBinary(tc.data)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Nil(t, decoded)
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]struct {
+		value           string
+		expectedVersion steak.Version
+		expectedErr     error
+	}{
+		"default":      {value: "", expectedVersion: steak.Version1},
+		"version 2":    {value: "2", expectedVersion: steak.Version2},
+		"unsupported":  {value: "3", expectedErr: steak.ErrUnsupportedVersion},
+		"not a number": {value: "latest", expectedErr: steak.ErrUnsupportedVersion},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			version, err := steak.ParseVersion(tc.value)
+
+			// then:
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
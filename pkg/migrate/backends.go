@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// ErrUnknownBackend is returned by Open when no backend is registered for the scheme of the DSN.
+var ErrUnknownBackend = errors.New("unknown storage backend")
+
+// OpenFunc opens the Storage addressed by dsn, e.g. "sqlite:///var/lib/overlay.db".
+type OpenFunc func(ctx context.Context, dsn string) (engine.Storage, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]OpenFunc)
+)
+
+// RegisterBackend makes the storage backend opened by open available to Open under the DSN scheme,
+// typically from the init function of the package implementing the storage. It panics if open is nil
+// or a backend is already registered for the scheme.
+func RegisterBackend(scheme string, open OpenFunc) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if open == nil {
+		panic("migrate: backend open function is nil")
+	}
+	if _, ok := backends[scheme]; ok {
+		panic("migrate: backend already registered for scheme " + scheme)
+	}
+	backends[scheme] = open
+}
+
+// Backends returns the sorted DSN schemes of the registered storage backends.
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	schemes := make([]string, 0, len(backends))
+	for scheme := range backends {
+		schemes = append(schemes, scheme)
+	}
+	slices.Sort(schemes)
+	return schemes
+}
+
+// Open opens the Storage addressed by dsn with the backend registered for its scheme.
+func Open(ctx context.Context, dsn string) (engine.Storage, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse storage DSN: %w", err)
+	}
+
+	backendsMu.RLock()
+	open, ok := backends[u.Scheme]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q, registered backends: %v", ErrUnknownBackend, u.Scheme, Backends())
+	}
+	return open(ctx, dsn)
+}
+
+// OpenSource opens the Storage addressed by dsn and returns it as a Source, failing when it cannot be enumerated.
+func OpenSource(ctx context.Context, dsn string) (Source, error) {
+	storage, err := Open(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	source, ok := storage.(Source)
+	if !ok {
+		return nil, fmt.Errorf("storage backend of %q cannot be migrated from: it does not implement migrate.Source", dsn)
+	}
+	return source, nil
+}
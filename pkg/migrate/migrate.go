@@ -0,0 +1,418 @@
+// Package migrate copies the data of an overlay engine from one Storage implementation to another,
+// e.g. from SQLite to Postgres, and verifies that the destination holds the same data as the source.
+//
+// The source must implement Source to enumerate everything it holds; the destination only needs to
+// implement engine.Storage. Outputs, with the BEEF of their transactions, applied transactions and the last
+// interaction scores of GASP peers are streamed page by page, so that topics of any size can be migrated.
+// The destination is expected to be empty and the engine using the source to be stopped.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// DefaultBatchSize is the number of outputs or applied transactions read from the source at once.
+const DefaultBatchSize = 500
+
+// ErrVerificationFailed is returned when the destination does not hold the data copied from the source.
+var ErrVerificationFailed = errors.New("migrated data does not match the source")
+
+// Stage names the part of a migration a Progress reports on.
+type Stage string
+
+const (
+	// StageOutputs copies the outputs of a topic.
+	StageOutputs Stage = "outputs"
+	// StageAppliedTransactions copies the applied transactions of a topic.
+	StageAppliedTransactions Stage = "applied-transactions"
+	// StageInteractions copies the last interaction scores of GASP peers.
+	StageInteractions Stage = "interactions"
+	// StageVerify reads the migrated data back from the destination.
+	StageVerify Stage = "verify"
+)
+
+// Interaction is the last interaction score recorded for a GASP peer and topic.
+type Interaction struct {
+	Host  string
+	Topic string
+	Score float64
+}
+
+// Source is a Storage able to enumerate everything it holds in a stable order, so that it can be migrated.
+type Source interface {
+	engine.Storage
+
+	// Topics returns the topics the storage holds outputs or applied transactions for.
+	Topics(ctx context.Context) ([]string, error)
+
+	// ScanOutputs returns up to limit outputs of the topic, spent or not and with their BEEF, ordered by outpoint
+	// and following after, or from the first one when after is nil.
+	ScanOutputs(ctx context.Context, topic string, after *transaction.Outpoint, limit int) ([]*engine.Output, error)
+
+	// ScanAppliedTransactions returns up to limit applied transactions of the topic, ordered by transaction ID
+	// and following after, or from the first one when after is nil.
+	ScanAppliedTransactions(ctx context.Context, topic string, after *chainhash.Hash, limit int) ([]*overlay.AppliedTransaction, error)
+
+	// Interactions returns the last interaction scores recorded for every GASP peer and topic.
+	Interactions(ctx context.Context) ([]Interaction, error)
+}
+
+// Options tunes a migration.
+type Options struct {
+	// Topics restricts the migration to the given topics. Empty migrates every topic of the source.
+	Topics []string
+
+	// BatchSize is the number of records read from the source at once. Zero uses DefaultBatchSize.
+	BatchSize int
+
+	// SkipVerify skips reading the migrated data back from the destination.
+	SkipVerify bool
+
+	// OnProgress, when set, is called after every page copied or verified.
+	OnProgress func(Progress)
+}
+
+// Progress reports how far a migration went.
+type Progress struct {
+	Stage               Stage
+	Topic               string // empty for StageInteractions
+	Outputs             int    // outputs copied, or verified in StageVerify, for the topic
+	AppliedTransactions int    // applied transactions copied, or verified in StageVerify, for the topic
+	Interactions        int    // interaction scores copied
+}
+
+// TopicReport describes the data migrated for a topic.
+type TopicReport struct {
+	Topic               string
+	Outputs             int
+	AppliedTransactions int
+	// Digest is the hex SHA-256 of the migrated outputs, in the order of the source. See Digest.
+	Digest string
+}
+
+// Report describes a completed migration.
+type Report struct {
+	Topics       []TopicReport
+	Interactions int
+	// Verified is set once the destination was read back and found to hold the data of the source.
+	Verified bool
+}
+
+// Migrate copies the outputs, applied transactions and last interaction scores of src to dst, then reads them back
+// from dst to verify their counts and digests, unless Options.SkipVerify is set. Provenance, pins and metadata are
+// copied with the outputs when dst persists them but are not verified, since storages may not persist them.
+func Migrate(ctx context.Context, src Source, dst engine.Storage, opts Options) (*Report, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	topics := opts.Topics
+	if len(topics) == 0 {
+		var err error
+		if topics, err = src.Topics(ctx); err != nil {
+			slog.Error("failed to list the topics to migrate", "error", err)
+			return nil, err
+		}
+		slices.Sort(topics)
+	}
+
+	m := &migration{src: src, dst: dst, opts: opts}
+	report := &Report{}
+	for _, topic := range topics {
+		topicReport, err := m.copyTopic(ctx, topic)
+		if err != nil {
+			return nil, err
+		}
+		report.Topics = append(report.Topics, *topicReport)
+		slog.Info("migrated topic", "topic", topic, "outputs", topicReport.Outputs, "appliedTransactions", topicReport.AppliedTransactions)
+	}
+	interactions, err := m.copyInteractions(ctx, topics)
+	if err != nil {
+		return nil, err
+	}
+	report.Interactions = len(interactions)
+	if opts.SkipVerify {
+		return report, nil
+	}
+
+	for _, topicReport := range report.Topics {
+		if err := m.verifyTopic(ctx, topicReport); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.verifyInteractions(ctx, interactions); err != nil {
+		return nil, err
+	}
+	report.Verified = true
+	slog.Info("verified migration", "topics", len(report.Topics), "interactions", report.Interactions)
+	return report, nil
+}
+
+// migration holds the state of a single Migrate run.
+type migration struct {
+	src  Source
+	dst  engine.Storage
+	opts Options
+}
+
+func (m *migration) progress(p Progress) {
+	if m.opts.OnProgress != nil {
+		m.opts.OnProgress(p)
+	}
+}
+
+func (m *migration) copyTopic(ctx context.Context, topic string) (*TopicReport, error) {
+	report := &TopicReport{Topic: topic}
+	digest := sha256.New()
+	err := m.scanOutputs(ctx, topic, func(page []*engine.Output) error {
+		for _, output := range page {
+			if err := m.dst.InsertOutput(ctx, output); err != nil {
+				slog.Error("failed to insert migrated output", "topic", topic, "outpoint", output.Outpoint.String(), "error", err)
+				return fmt.Errorf("insert output %s of topic %s: %w", output.Outpoint.String(), topic, err)
+			}
+			writeOutput(digest, output)
+		}
+		report.Outputs += len(page)
+		m.progress(Progress{Stage: StageOutputs, Topic: topic, Outputs: report.Outputs})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Digest = hex.EncodeToString(digest.Sum(nil))
+
+	err = m.scanAppliedTransactions(ctx, topic, func(page []*overlay.AppliedTransaction) error {
+		for _, tx := range page {
+			if err := m.dst.InsertAppliedTransaction(ctx, tx); err != nil {
+				slog.Error("failed to insert migrated applied transaction", "topic", topic, "txid", tx.Txid.String(), "error", err)
+				return fmt.Errorf("insert applied transaction %s of topic %s: %w", tx.Txid.String(), topic, err)
+			}
+		}
+		report.AppliedTransactions += len(page)
+		m.progress(Progress{Stage: StageAppliedTransactions, Topic: topic, Outputs: report.Outputs, AppliedTransactions: report.AppliedTransactions})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (m *migration) copyInteractions(ctx context.Context, topics []string) ([]Interaction, error) {
+	all, err := m.src.Interactions(ctx)
+	if err != nil {
+		slog.Error("failed to list the interactions to migrate", "error", err)
+		return nil, err
+	}
+	var interactions []Interaction
+	for _, interaction := range all {
+		if !slices.Contains(topics, interaction.Topic) {
+			continue
+		}
+		if err := m.dst.UpdateLastInteraction(ctx, interaction.Host, interaction.Topic, interaction.Score); err != nil {
+			slog.Error("failed to update migrated interaction", "host", interaction.Host, "topic", interaction.Topic, "error", err)
+			return nil, fmt.Errorf("update interaction of %s for topic %s: %w", interaction.Host, interaction.Topic, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	m.progress(Progress{Stage: StageInteractions, Interactions: len(interactions)})
+	return interactions, nil
+}
+
+func (m *migration) verifyTopic(ctx context.Context, report TopicReport) error {
+	verified := Progress{Stage: StageVerify, Topic: report.Topic}
+	digest := sha256.New()
+	err := m.scanOutputs(ctx, report.Topic, func(page []*engine.Output) error {
+		outpoints := make([]*transaction.Outpoint, 0, len(page))
+		for _, output := range page {
+			outpoints = append(outpoints, &output.Outpoint)
+		}
+		migrated, err := m.dst.FindOutputs(ctx, outpoints, report.Topic, nil, true)
+		if err != nil {
+			slog.Error("failed to read migrated outputs back", "topic", report.Topic, "error", err)
+			return err
+		}
+		byOutpoint := make(map[string]*engine.Output, len(migrated))
+		for _, output := range migrated {
+			if output != nil {
+				byOutpoint[output.Outpoint.String()] = output
+			}
+		}
+		for _, output := range page {
+			found, ok := byOutpoint[output.Outpoint.String()]
+			if !ok {
+				return fmt.Errorf("%w: output %s of topic %s is missing", ErrVerificationFailed, output.Outpoint.String(), report.Topic)
+			}
+			if Digest(found) != Digest(output) {
+				return fmt.Errorf("%w: output %s of topic %s differs", ErrVerificationFailed, output.Outpoint.String(), report.Topic)
+			}
+			writeOutput(digest, found)
+		}
+		verified.Outputs += len(page)
+		m.progress(verified)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if verified.Outputs != report.Outputs {
+		return fmt.Errorf("%w: topic %s has %d outputs, %d were migrated", ErrVerificationFailed, report.Topic, verified.Outputs, report.Outputs)
+	}
+	if actual := hex.EncodeToString(digest.Sum(nil)); actual != report.Digest {
+		return fmt.Errorf("%w: digest of topic %s is %s, expected %s", ErrVerificationFailed, report.Topic, actual, report.Digest)
+	}
+
+	err = m.scanAppliedTransactions(ctx, report.Topic, func(page []*overlay.AppliedTransaction) error {
+		for _, tx := range page {
+			exists, err := m.dst.DoesAppliedTransactionExist(ctx, tx)
+			if err != nil {
+				slog.Error("failed to read migrated applied transaction back", "topic", report.Topic, "txid", tx.Txid.String(), "error", err)
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: applied transaction %s of topic %s is missing", ErrVerificationFailed, tx.Txid.String(), report.Topic)
+			}
+		}
+		verified.AppliedTransactions += len(page)
+		m.progress(verified)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if verified.AppliedTransactions != report.AppliedTransactions {
+		return fmt.Errorf("%w: topic %s has %d applied transactions, %d were migrated", ErrVerificationFailed, report.Topic, verified.AppliedTransactions, report.AppliedTransactions)
+	}
+	return nil
+}
+
+func (m *migration) verifyInteractions(ctx context.Context, interactions []Interaction) error {
+	for _, interaction := range interactions {
+		score, err := m.dst.GetLastInteraction(ctx, interaction.Host, interaction.Topic)
+		if err != nil {
+			slog.Error("failed to read migrated interaction back", "host", interaction.Host, "topic", interaction.Topic, "error", err)
+			return err
+		}
+		if score != interaction.Score {
+			return fmt.Errorf("%w: interaction of %s for topic %s is %v, expected %v", ErrVerificationFailed, interaction.Host, interaction.Topic, score, interaction.Score)
+		}
+	}
+	return nil
+}
+
+// scanOutputs calls fn with every page of outputs of the topic.
+func (m *migration) scanOutputs(ctx context.Context, topic string, fn func([]*engine.Output) error) error {
+	var after *transaction.Outpoint
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := m.src.ScanOutputs(ctx, topic, after, m.opts.BatchSize)
+		if err != nil {
+			slog.Error("failed to scan outputs to migrate", "topic", topic, "error", err)
+			return err
+		}
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+			after = &page[len(page)-1].Outpoint
+		}
+		if len(page) < m.opts.BatchSize {
+			return nil
+		}
+	}
+}
+
+// scanAppliedTransactions calls fn with every page of applied transactions of the topic.
+func (m *migration) scanAppliedTransactions(ctx context.Context, topic string, fn func([]*overlay.AppliedTransaction) error) error {
+	var after *chainhash.Hash
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := m.src.ScanAppliedTransactions(ctx, topic, after, m.opts.BatchSize)
+		if err != nil {
+			slog.Error("failed to scan applied transactions to migrate", "topic", topic, "error", err)
+			return err
+		}
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+			after = page[len(page)-1].Txid
+		}
+		if len(page) < m.opts.BatchSize {
+			return nil
+		}
+	}
+}
+
+// Digest returns the hex SHA-256 of the fields of the output every Storage persists: its outpoint and topic,
+// locking script and satoshis, spent state and history, block position, score, BEEF and ancillary transactions.
+func Digest(output *engine.Output) string {
+	h := sha256.New()
+	writeOutput(h, output)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeOutput(h hash.Hash, output *engine.Output) {
+	writeBytes(h, []byte(output.Outpoint.String()))
+	writeBytes(h, []byte(output.Topic))
+	if output.Script != nil {
+		writeBytes(h, *output.Script)
+	} else {
+		writeBytes(h, nil)
+	}
+	writeUint(h, output.Satoshis)
+	if output.Spent {
+		writeUint(h, 1)
+	} else {
+		writeUint(h, 0)
+	}
+	writeOutpoints(h, output.OutputsConsumed)
+	writeOutpoints(h, output.ConsumedBy)
+	writeUint(h, uint64(output.BlockHeight))
+	writeUint(h, output.BlockIdx)
+	writeUint(h, math.Float64bits(output.Score))
+	writeBytes(h, output.Beef)
+	writeUint(h, uint64(len(output.AncillaryTxids)))
+	for _, txid := range output.AncillaryTxids {
+		writeBytes(h, txid[:])
+	}
+	writeBytes(h, output.AncillaryBeef)
+}
+
+func writeOutpoints(h hash.Hash, outpoints []*transaction.Outpoint) {
+	keys := make(map[string]struct{}, len(outpoints))
+	for _, outpoint := range outpoints {
+		keys[outpoint.String()] = struct{}{}
+	}
+	writeUint(h, uint64(len(keys)))
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		writeBytes(h, []byte(key))
+	}
+}
+
+func writeUint(h hash.Hash, v uint64) {
+	h.Write(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+func writeBytes(h hash.Hash, b []byte) {
+	writeUint(h, uint64(len(b)))
+	h.Write(b)
+}
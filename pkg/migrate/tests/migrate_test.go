@@ -0,0 +1,327 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/migrate"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// memStorage is an in-memory storage that can be migrated from and to.
+type memStorage struct {
+	outputs      map[string]*engine.Output
+	applied      map[string]*overlay.AppliedTransaction
+	interactions map[[2]string]float64
+
+	// mangle, when set, alters the outputs read back through FindOutputs.
+	mangle func(*engine.Output)
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		outputs:      make(map[string]*engine.Output),
+		applied:      make(map[string]*overlay.AppliedTransaction),
+		interactions: make(map[[2]string]float64),
+	}
+}
+
+func outputKey(outpoint *transaction.Outpoint, topic string) string {
+	return topic + "/" + outpoint.String()
+}
+
+func (s *memStorage) InsertOutput(_ context.Context, utxo *engine.Output) error {
+	copied := *utxo
+	s.outputs[outputKey(&utxo.Outpoint, utxo.Topic)] = &copied
+	return nil
+}
+
+func (s *memStorage) FindOutput(_ context.Context, outpoint *transaction.Outpoint, topic *string, _ *bool, _ bool) (*engine.Output, error) {
+	for _, output := range s.outputs {
+		if output.Outpoint == *outpoint && (topic == nil || output.Topic == *topic) {
+			return output, nil
+		}
+	}
+	return nil, engine.ErrNotFound
+}
+
+func (s *memStorage) FindOutputs(_ context.Context, outpoints []*transaction.Outpoint, topic string, _ *bool, _ bool) ([]*engine.Output, error) {
+	outputs := make([]*engine.Output, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		output, ok := s.outputs[outputKey(outpoint, topic)]
+		if !ok {
+			outputs = append(outputs, nil)
+			continue
+		}
+		copied := *output
+		if s.mangle != nil {
+			s.mangle(&copied)
+		}
+		outputs = append(outputs, &copied)
+	}
+	return outputs, nil
+}
+
+func (s *memStorage) FindOutputsForTransaction(context.Context, *chainhash.Hash, bool) ([]*engine.Output, error) {
+	return nil, nil
+}
+
+func (s *memStorage) FindUTXOsForTopic(context.Context, string, float64, uint32, bool) ([]*engine.Output, error) {
+	return nil, nil
+}
+
+func (s *memStorage) DeleteOutput(_ context.Context, outpoint *transaction.Outpoint, topic string) error {
+	delete(s.outputs, outputKey(outpoint, topic))
+	return nil
+}
+
+func (s *memStorage) MarkUTXOsAsSpent(context.Context, []*transaction.Outpoint, string, *chainhash.Hash) error {
+	return nil
+}
+
+func (s *memStorage) UpdateConsumedBy(context.Context, *transaction.Outpoint, string, []*transaction.Outpoint) error {
+	return nil
+}
+
+func (s *memStorage) UpdateTransactionBEEF(context.Context, *chainhash.Hash, []byte) error {
+	return nil
+}
+
+func (s *memStorage) UpdateOutputBlockHeight(context.Context, *transaction.Outpoint, string, uint32, uint64, []byte) error {
+	return nil
+}
+
+func (s *memStorage) InsertAppliedTransaction(_ context.Context, tx *overlay.AppliedTransaction) error {
+	s.applied[tx.Topic+"/"+tx.Txid.String()] = tx
+	return nil
+}
+
+func (s *memStorage) DoesAppliedTransactionExist(_ context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	_, ok := s.applied[tx.Topic+"/"+tx.Txid.String()]
+	return ok, nil
+}
+
+func (s *memStorage) UpdateLastInteraction(_ context.Context, host, topic string, since float64) error {
+	s.interactions[[2]string{host, topic}] = since
+	return nil
+}
+
+func (s *memStorage) GetLastInteraction(_ context.Context, host, topic string) (float64, error) {
+	return s.interactions[[2]string{host, topic}], nil
+}
+
+func (s *memStorage) Topics(context.Context) ([]string, error) {
+	var topics []string
+	for _, output := range s.outputs {
+		if !slices.Contains(topics, output.Topic) {
+			topics = append(topics, output.Topic)
+		}
+	}
+	return topics, nil
+}
+
+func (s *memStorage) ScanOutputs(_ context.Context, topic string, after *transaction.Outpoint, limit int) ([]*engine.Output, error) {
+	var outputs []*engine.Output
+	for _, output := range s.outputs {
+		if output.Topic == topic && (after == nil || bytes.Compare(outpointBytes(&output.Outpoint), outpointBytes(after)) > 0) {
+			outputs = append(outputs, output)
+		}
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return bytes.Compare(outpointBytes(&outputs[i].Outpoint), outpointBytes(&outputs[j].Outpoint)) < 0
+	})
+	if len(outputs) > limit {
+		outputs = outputs[:limit]
+	}
+	return outputs, nil
+}
+
+func (s *memStorage) ScanAppliedTransactions(_ context.Context, topic string, after *chainhash.Hash, limit int) ([]*overlay.AppliedTransaction, error) {
+	var txs []*overlay.AppliedTransaction
+	for _, tx := range s.applied {
+		if tx.Topic == topic && (after == nil || bytes.Compare(tx.Txid[:], after[:]) > 0) {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return bytes.Compare(txs[i].Txid[:], txs[j].Txid[:]) < 0 })
+	if len(txs) > limit {
+		txs = txs[:limit]
+	}
+	return txs, nil
+}
+
+func (s *memStorage) Interactions(context.Context) ([]migrate.Interaction, error) {
+	var interactions []migrate.Interaction
+	for key, score := range s.interactions {
+		interactions = append(interactions, migrate.Interaction{Host: key[0], Topic: key[1], Score: score})
+	}
+	return interactions, nil
+}
+
+func outpointBytes(outpoint *transaction.Outpoint) []byte {
+	return append(outpoint.Txid[:], byte(outpoint.Index>>24), byte(outpoint.Index>>16), byte(outpoint.Index>>8), byte(outpoint.Index))
+}
+
+// newPopulatedSource returns a source holding outputs, applied transactions and interactions of two topics.
+func newPopulatedSource(t *testing.T) *memStorage {
+	t.Helper()
+
+	ctx := context.Background()
+	src := newMemStorage()
+	for i, topic := range []string{"tm_a", "tm_a", "tm_a", "tm_b", "tm_b"} {
+		txid := chainhash.DoubleHashH([]byte{byte(i)})
+		consumed := &transaction.Outpoint{Txid: chainhash.DoubleHashH([]byte{byte(i), 1}), Index: 1}
+		require.NoError(t, src.InsertOutput(ctx, &engine.Output{
+			Outpoint:        transaction.Outpoint{Txid: txid, Index: uint32(i)},
+			Topic:           topic,
+			Script:          &script.Script{script.OpTRUE},
+			Satoshis:        uint64(1000 + i),
+			Spent:           i%2 == 0,
+			OutputsConsumed: []*transaction.Outpoint{consumed},
+			BlockHeight:     uint32(100 + i),
+			Score:           float64(i),
+			Beef:            []byte{byte(i), 0xbe, 0xef},
+		}))
+		require.NoError(t, src.InsertAppliedTransaction(ctx, &overlay.AppliedTransaction{Txid: &txid, Topic: topic}))
+	}
+	require.NoError(t, src.UpdateLastInteraction(ctx, "https://peer.example.com", "tm_a", 42))
+	require.NoError(t, src.UpdateLastInteraction(ctx, "https://peer.example.com", "tm_b", 7))
+	return src
+}
+
+func TestMigrate_ShouldCopyAndVerifyEveryTopic(t *testing.T) {
+	// given:
+	src := newPopulatedSource(t)
+	dst := newMemStorage()
+	var progress []migrate.Progress
+
+	// when:
+	report, err := migrate.Migrate(context.Background(), src, dst, migrate.Options{
+		BatchSize:  2,
+		OnProgress: func(p migrate.Progress) { progress = append(progress, p) },
+	})
+
+	// then:
+	require.NoError(t, err)
+	require.True(t, report.Verified)
+	require.Equal(t, 2, report.Interactions)
+	require.Len(t, report.Topics, 2)
+	require.Equal(t, "tm_a", report.Topics[0].Topic)
+	require.Equal(t, 3, report.Topics[0].Outputs)
+	require.Equal(t, 3, report.Topics[0].AppliedTransactions)
+	require.Equal(t, "tm_b", report.Topics[1].Topic)
+	require.Equal(t, 2, report.Topics[1].Outputs)
+	require.Equal(t, 2, report.Topics[1].AppliedTransactions)
+	require.NotEqual(t, report.Topics[0].Digest, report.Topics[1].Digest)
+
+	require.Equal(t, src.outputs, dst.outputs)
+	require.Equal(t, src.applied, dst.applied)
+	require.Equal(t, src.interactions, dst.interactions)
+
+	require.Contains(t, progress, migrate.Progress{Stage: migrate.StageOutputs, Topic: "tm_a", Outputs: 3})
+	require.Contains(t, progress, migrate.Progress{Stage: migrate.StageInteractions, Interactions: 2})
+	require.Equal(t, migrate.Progress{Stage: migrate.StageVerify, Topic: "tm_b", Outputs: 2, AppliedTransactions: 2}, progress[len(progress)-1])
+}
+
+func TestMigrate_ShouldRestrictToTopics(t *testing.T) {
+	// given:
+	src := newPopulatedSource(t)
+	dst := newMemStorage()
+
+	// when:
+	report, err := migrate.Migrate(context.Background(), src, dst, migrate.Options{Topics: []string{"tm_b"}})
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, report.Topics, 1)
+	require.Equal(t, "tm_b", report.Topics[0].Topic)
+	require.Len(t, dst.outputs, 2)
+	require.Equal(t, map[[2]string]float64{{"https://peer.example.com", "tm_b"}: 7}, dst.interactions)
+}
+
+func TestMigrate_ShouldFailVerification_WhenDestinationDiffers(t *testing.T) {
+	tests := map[string]func(dst *memStorage){
+		"altered output": func(dst *memStorage) {
+			dst.mangle = func(output *engine.Output) { output.Score++ }
+		},
+		"missing output": func(dst *memStorage) {
+			dst.mangle = nil
+			for key := range dst.outputs {
+				delete(dst.outputs, key)
+				return
+			}
+		},
+		"missing applied transaction": func(dst *memStorage) {
+			for key := range dst.applied {
+				delete(dst.applied, key)
+				return
+			}
+		},
+		"altered interaction": func(dst *memStorage) {
+			dst.interactions[[2]string{"https://peer.example.com", "tm_a"}] = 1
+		},
+	}
+	for name, alter := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			src := newPopulatedSource(t)
+			dst := newMemStorage()
+			_, err := migrate.Migrate(context.Background(), src, dst, migrate.Options{SkipVerify: true})
+			require.NoError(t, err)
+			alter(dst)
+
+			// when:
+			report, err := migrate.Migrate(context.Background(), src, &verifyOnlyStorage{memStorage: dst}, migrate.Options{})
+
+			// then:
+			require.ErrorIs(t, err, migrate.ErrVerificationFailed)
+			require.Nil(t, report)
+		})
+	}
+}
+
+func TestOpen_ShouldResolveRegisteredBackends(t *testing.T) {
+	// given:
+	storage := newMemStorage()
+	migrate.RegisterBackend("memtest", func(_ context.Context, dsn string) (engine.Storage, error) {
+		require.Equal(t, "memtest://local", dsn)
+		return storage, nil
+	})
+
+	// when:
+	source, err := migrate.OpenSource(context.Background(), "memtest://local")
+	_, unknownErr := migrate.Open(context.Background(), "unknown://local")
+
+	// then:
+	require.NoError(t, err)
+	require.Same(t, storage, source)
+	require.Contains(t, migrate.Backends(), "memtest")
+	require.True(t, errors.Is(unknownErr, migrate.ErrUnknownBackend))
+	require.Panics(t, func() {
+		migrate.RegisterBackend("memtest", func(context.Context, string) (engine.Storage, error) { return storage, nil })
+	})
+}
+
+// verifyOnlyStorage ignores writes, so that a migration into an already migrated storage only verifies it.
+type verifyOnlyStorage struct {
+	*memStorage
+}
+
+func (s *verifyOnlyStorage) InsertOutput(context.Context, *engine.Output) error { return nil }
+
+func (s *verifyOnlyStorage) InsertAppliedTransaction(context.Context, *overlay.AppliedTransaction) error {
+	return nil
+}
+
+func (s *verifyOnlyStorage) UpdateLastInteraction(context.Context, string, string, float64) error {
+	return nil
+}
@@ -53,15 +53,9 @@ func NewDefault() Config {
 // The function attempts to read and decode the config file, pretty-prints the configuration as JSON,
 // and returns the extracted server configuration on success. An error is returned if any step fails.
 func LoadFromPath(path, env string) (server.Config, error) {
-	loader := loaders.NewLoader(NewDefault, env)
-	err := loader.SetConfigFilePath(path)
-	if err != nil {
-		return server.Config{}, fmt.Errorf("invalid config file path: %w", err)
-	}
-
-	cfg, err := loader.Load()
+	cfg, err := load(path, env)
 	if err != nil {
-		return server.Config{}, fmt.Errorf("config loader load operation failed: %w", err)
+		return server.Config{}, err
 	}
 
 	err = PrettyPrintAs(cfg, "json")
@@ -70,3 +64,18 @@ func LoadFromPath(path, env string) (server.Config, error) {
 	}
 	return cfg.Server, nil
 }
+
+// load reads and decodes the config file at the specified path, applying the environment variables with the env prefix.
+func load(path, env string) (Config, error) {
+	loader := loaders.NewLoader(NewDefault, env)
+	err := loader.SetConfigFilePath(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid config file path: %w", err)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return Config{}, fmt.Errorf("config loader load operation failed: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+)
+
+// Source returns a server.ConfigSource reading the server configuration from the file at the specified path
+// again on every call, with the environment taking precedence as for LoadFromPath.
+func Source(path, env string) server.ConfigSource {
+	return func(context.Context) (server.Config, error) {
+		cfg, err := load(path, env)
+		if err != nil {
+			return server.Config{}, err
+		}
+		return cfg.Server, nil
+	}
+}
+
+// ReloadOnSignal reloads the configuration of the server every time the process receives SIGHUP, until ctx is done.
+// Rejected reloads are logged and leave the running configuration unchanged.
+func ReloadOnSignal(ctx context.Context, reloader server.ConfigReloader) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+		}
+		if _, err := reloader.ReloadConfig(ctx); err != nil {
+			slog.Error("failed to reload configuration on SIGHUP", "error", err)
+		}
+	}
+}
@@ -3,12 +3,14 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
@@ -21,6 +23,11 @@ func (n *NoopEngineProvider) HandleNewMerkleProof(_ context.Context, _ *chainhas
 	panic("unimplemented")
 }
 
+// HandleAttestedMerkleProof implements engine.OverlayEngineProvider.
+func (n *NoopEngineProvider) HandleAttestedMerkleProof(_ context.Context, _ *chainhash.Hash, _ *transaction.MerklePath, _ engine.MerkleProofAttestation) error {
+	panic("unimplemented")
+}
+
 // Submit is a no-op call that always returns an empty STEAK with nil error.
 func (*NoopEngineProvider) Submit(_ context.Context, _ overlay.TaggedBEEF, _ engine.SumbitMode, onSteakReady engine.OnSteakReady) (overlay.Steak, error) {
 	hex1, _ := chainhash.NewHashFromHex("03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119")
@@ -39,9 +46,109 @@ func (*NoopEngineProvider) Submit(_ context.Context, _ overlay.TaggedBEEF, _ eng
 	return overlay.Steak{}, nil
 }
 
+// Evaluate is a no-op call that always returns an empty STEAK with nil error.
+func (*NoopEngineProvider) Evaluate(_ context.Context, _ overlay.TaggedBEEF) (overlay.Steak, error) {
+	return overlay.Steak{}, nil
+}
+
 // SyncAdvertisements is a no-op call that always returns a nil error.
 func (*NoopEngineProvider) SyncAdvertisements(_ context.Context) error { return nil }
 
+// PlanAdvertisements is a no-op call that always returns an empty advertisement plan with nil error.
+func (*NoopEngineProvider) PlanAdvertisements(_ context.Context) (*engine.AdvertisementPlan, error) {
+	return &engine.AdvertisementPlan{Cost: engine.AdvertisementCost{WithinBudget: true}}, nil
+}
+
+// ListAdvertisements is a no-op call that always returns no advertisements with nil error.
+func (*NoopEngineProvider) ListAdvertisements(_ context.Context) ([]*engine.ListedAdvertisement, error) {
+	return []*engine.ListedAdvertisement{}, nil
+}
+
+// CreateAdvertisement is a no-op call that always returns an empty STEAK with nil error.
+func (*NoopEngineProvider) CreateAdvertisement(_ context.Context, _ overlay.Protocol, _ string) (overlay.Steak, error) {
+	return overlay.Steak{}, nil
+}
+
+// RevokeAdvertisement is a no-op call that always returns an empty STEAK with nil error.
+func (*NoopEngineProvider) RevokeAdvertisement(_ context.Context, _ *transaction.Outpoint) (overlay.Steak, error) {
+	return overlay.Steak{}, nil
+}
+
+// TopicStats is a no-op call that always returns empty statistics with nil error.
+func (*NoopEngineProvider) TopicStats(_ context.Context) (*engine.OverlayStats, error) {
+	return &engine.OverlayStats{}, nil
+}
+
+// GASPSyncStatus is a no-op call that always returns an empty sync status with nil error.
+func (*NoopEngineProvider) GASPSyncStatus(_ context.Context) ([]*engine.GASPPeerSyncStatus, error) {
+	return []*engine.GASPPeerSyncStatus{}, nil
+}
+
+// SyncTopic is a no-op call that always returns a completed job with an empty report and nil error.
+func (*NoopEngineProvider) SyncTopic(_ context.Context, request engine.TopicSyncRequest) (*engine.TopicSyncJob, error) {
+	return &engine.TopicSyncJob{ID: "noop_engine_provider", Topic: request.Topic, Status: engine.TopicSyncJobCompleted, Report: &engine.TopicSyncReport{Topic: request.Topic}}, nil
+}
+
+// FindTopicSyncJob is a no-op call that always returns a completed job with an empty report and nil error.
+func (*NoopEngineProvider) FindTopicSyncJob(_ context.Context, id string) (*engine.TopicSyncJob, error) {
+	return &engine.TopicSyncJob{ID: id, Status: engine.TopicSyncJobCompleted, Report: &engine.TopicSyncReport{}}, nil
+}
+
+// EnqueueSubmit is a no-op call that always returns a completed job with an empty STEAK and nil error.
+func (*NoopEngineProvider) EnqueueSubmit(_ context.Context, taggedBEEF overlay.TaggedBEEF, _ string) (*engine.SubmitJob, error) {
+	return &engine.SubmitJob{ID: "noop_engine_provider", Status: engine.SubmitJobCompleted, Topics: taggedBEEF.Topics, Steak: overlay.Steak{}}, nil
+}
+
+// VerifyPayment is a no-op call that always returns the first outpoint of an empty transaction with nil error.
+func (*NoopEngineProvider) VerifyPayment(_ context.Context, _ []byte, _ *script.Script, _ uint64) (*transaction.Outpoint, error) {
+	return &transaction.Outpoint{}, nil
+}
+
+// FindSubmitJob is a no-op call that always returns a completed job with an empty STEAK and nil error.
+func (*NoopEngineProvider) FindSubmitJob(_ context.Context, id string) (*engine.SubmitJob, error) {
+	return &engine.SubmitJob{ID: id, Status: engine.SubmitJobCompleted, Steak: overlay.Steak{}}, nil
+}
+
+// RegisterWebhook is a no-op call that always returns a subscription with the given URL, topics and secret and nil error.
+func (*NoopEngineProvider) RegisterWebhook(_ context.Context, callbackURL string, topics []string, secret string) (*engine.WebhookSubscription, error) {
+	return &engine.WebhookSubscription{ID: "noop_engine_provider", URL: callbackURL, Topics: topics, Secret: secret}, nil
+}
+
+// ListWebhooks is a no-op call that always returns an empty slice of webhook subscriptions and nil error.
+func (*NoopEngineProvider) ListWebhooks(_ context.Context) ([]*engine.WebhookSubscription, error) {
+	return []*engine.WebhookSubscription{}, nil
+}
+
+// UnregisterWebhook is a no-op call that always returns nil error.
+func (*NoopEngineProvider) UnregisterWebhook(_ context.Context, _ string) error {
+	return nil
+}
+
+// GetLookupQuerySchema is a no-op call that always returns an empty JSON object schema and nil error.
+func (*NoopEngineProvider) GetLookupQuerySchema(_ context.Context, _ string) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+
+// BroadcastQueueStatus is a no-op call that always returns an empty re-broadcast queue with nil error.
+func (*NoopEngineProvider) BroadcastQueueStatus(_ context.Context) (*engine.BroadcastQueueStatus, error) {
+	return &engine.BroadcastQueueStatus{}, nil
+}
+
+// FindUTXOHistory is a no-op call that always returns an empty engine output with nil error.
+func (*NoopEngineProvider) FindUTXOHistory(_ context.Context, _ engine.UTXOHistoryQuery) (*engine.Output, error) {
+	return &engine.Output{}, nil
+}
+
+// OutputGraph is a no-op call that always returns a graph holding only the output with nil error.
+func (*NoopEngineProvider) OutputGraph(_ context.Context, outpoint *transaction.Outpoint, topic string, _ uint32) (*engine.OutputGraph, error) {
+	return &engine.OutputGraph{Root: *outpoint, Topic: topic, Nodes: []*engine.OutputGraphNode{{Outpoint: *outpoint}}}, nil
+}
+
+// GetOutputStatus is a no-op call that always returns the status of an unknown transaction with nil error.
+func (*NoopEngineProvider) GetOutputStatus(_ context.Context, outpoint *transaction.Outpoint, topic string) (*engine.OutputStatus, error) {
+	return &engine.OutputStatus{Outpoint: *outpoint, Topic: topic}, nil
+}
+
 // GetTopicManagerDocumentation is a no-op call that always returns a nil error.
 func (*NoopEngineProvider) GetTopicManagerDocumentation(_ context.Context) error { return nil }
 
@@ -67,6 +174,19 @@ func (*NoopEngineProvider) Lookup(_ context.Context, _ *lookup.LookupQuestion) (
 	}, nil
 }
 
+// LookupPaged is a no-op call that always returns an empty page of a lookup answer with nil error.
+func (*NoopEngineProvider) LookupPaged(_ context.Context, _ *lookup.LookupQuestion, _ engine.LookupPage) (*engine.LookupPageAnswer, error) {
+	return &engine.LookupPageAnswer{
+		Type:    "noop_engine_provider",
+		Outputs: func(func(*engine.LookupOutput, error) bool) {},
+	}, nil
+}
+
+// HasOutputs is a no-op call that always reports every outpoint as missing with nil error.
+func (*NoopEngineProvider) HasOutputs(_ context.Context, outpoints []*transaction.Outpoint, _ string, _ bool) ([]bool, error) {
+	return make([]bool, len(outpoints)), nil
+}
+
 // GetUTXOHistory is a no-op call that always returns an empty engine output with nil error.
 func (*NoopEngineProvider) GetUTXOHistory(_ context.Context, _ *engine.Output, _ func(beef []byte, outputIndex, currentDepth uint32) bool, _ uint32) (*engine.Output, error) {
 	return &engine.Output{}, nil
@@ -84,7 +204,7 @@ func (*NoopEngineProvider) ProvideForeignSyncResponse(_ context.Context, _ *gasp
 }
 
 // ProvideForeignGASPNode is a no-op call that always returns an empty GASP node with nil error.
-func (*NoopEngineProvider) ProvideForeignGASPNode(_ context.Context, _, _ *transaction.Outpoint, _ string) (*gasp.Node, error) {
+func (*NoopEngineProvider) ProvideForeignGASPNode(_ context.Context, _, _ *transaction.Outpoint, _ string, _ bool) (*gasp.Node, error) {
 	return &gasp.Node{}, nil
 }
 
@@ -138,8 +258,125 @@ func (*NoopEngineProvider) GetDocumentationForTopicManager(_ string) (string, er
 	return "noop_engine_topic_manager_doc", nil
 }
 
+// AddTopicManager is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) AddTopicManager(_ context.Context, _ string, _ engine.SyncConfiguration) error {
+	return nil
+}
+
+// UnregisterTopicManager is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) UnregisterTopicManager(_ context.Context, _ string) error { return nil }
+
+// AddLookupService is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) AddLookupService(_ context.Context, _ string) error { return nil }
+
+// UnregisterLookupService is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) UnregisterLookupService(_ context.Context, _ string) error { return nil }
+
+// ReplayTopicIntoLookupService is a no-op call that always returns an empty progress with nil error.
+func (*NoopEngineProvider) ReplayTopicIntoLookupService(_ context.Context, topic, service string, fromScore float64) (*engine.LookupReplayProgress, error) {
+	return &engine.LookupReplayProgress{Topic: topic, Service: service, Score: fromScore}, nil
+}
+
+// PinOutput is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) PinOutput(_ context.Context, _ *transaction.Outpoint, _ string) error {
+	return nil
+}
+
+// UnpinOutput is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) UnpinOutput(_ context.Context, _ *transaction.Outpoint, _ string) error {
+	return nil
+}
+
+// PauseTopicSync is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) PauseTopicSync(_ context.Context, _ string) error {
+	return nil
+}
+
+// ResumeTopicSync is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) ResumeTopicSync(_ context.Context, _ string) error {
+	return nil
+}
+
+// PausedSyncTopics is a no-op call that always returns an empty list of topics.
+func (*NoopEngineProvider) PausedSyncTopics(_ context.Context) []string {
+	return []string{}
+}
+
+// Trackers is a no-op call that always returns empty tracker sets.
+func (*NoopEngineProvider) Trackers(_ context.Context) engine.Trackers {
+	return engine.Trackers{SHIP: []string{}, SLAP: []string{}}
+}
+
+// AddTracker is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) AddTracker(_ context.Context, _ engine.TrackerKind, _ string) error {
+	return nil
+}
+
+// RemoveTracker is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) RemoveTracker(_ context.Context, _ engine.TrackerKind, _ string) error {
+	return nil
+}
+
+// TopicQuotaSettings is a no-op call that always returns empty topic quota settings.
+func (*NoopEngineProvider) TopicQuotaSettings(_ context.Context) engine.TopicQuotaSettings {
+	return engine.TopicQuotaSettings{Topics: map[string]engine.TopicQuota{}, Overrides: map[string]engine.TopicQuota{}}
+}
+
+// SetTopicQuota is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) SetTopicQuota(_ context.Context, _ string, _ engine.TopicQuota) error {
+	return nil
+}
+
+// RemoveTopicQuota is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) RemoveTopicQuota(_ context.Context, _ string) error {
+	return nil
+}
+
+// SimulateReorg is a no-op call that always returns an empty reorg simulation with nil error.
+func (*NoopEngineProvider) SimulateReorg(_ context.Context, depth uint32) (*engine.ReorgSimulation, error) {
+	return &engine.ReorgSimulation{
+		Depth:          depth,
+		AffectedTopics: map[string]*engine.ReorgTopicImpact{},
+	}, nil
+}
+
 // NewNoopEngineProvider returns an OverlayEngineProvider implementation
 // and checks whether the engine contract matches the implemented method set.
 func NewNoopEngineProvider() engine.OverlayEngineProvider {
 	return &NoopEngineProvider{}
 }
+
+// RecordAudit is a no-op call that always returns a nil error.
+func (*NoopEngineProvider) RecordAudit(_ context.Context, _ *engine.AuditEntry) error {
+	return nil
+}
+
+// FindAuditEntries is a no-op call that always returns an empty audit log with nil error.
+func (*NoopEngineProvider) FindAuditEntries(_ context.Context, _ engine.AuditQuery) ([]*engine.AuditEntry, error) {
+	return []*engine.AuditEntry{}, nil
+}
+
+// ListDeadLetters is a no-op call that always returns an empty dead-letter queue with nil error.
+func (*NoopEngineProvider) ListDeadLetters(_ context.Context) ([]*engine.DeadLetter, error) {
+	return []*engine.DeadLetter{}, nil
+}
+
+// ReplayDeadLetter is a no-op call that always returns an empty STEAK with nil error.
+func (*NoopEngineProvider) ReplayDeadLetter(_ context.Context, _ string) (overlay.Steak, error) {
+	return overlay.Steak{}, nil
+}
+
+// PruneOutputs is a no-op call that always returns no prune reports with nil error.
+func (*NoopEngineProvider) PruneOutputs(_ context.Context) ([]*engine.PruneReport, error) {
+	return []*engine.PruneReport{}, nil
+}
+
+// NextMutations is a no-op call that always returns no storage mutations with nil error.
+func (*NoopEngineProvider) NextMutations(_ context.Context, _ string, _ uint64) ([]*engine.Mutation, error) {
+	return []*engine.Mutation{}, nil
+}
+
+// PromoteStandby is a no-op call that always returns nil error.
+func (*NoopEngineProvider) PromoteStandby(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+)
+
+// AccessControlList restricts which API keys may submit transactions to topics, read their outputs and query
+// lookup services. Topics and services without an entry are open to every client. The zero value restricts nothing.
+type AccessControlList struct {
+	// TopicKeys maps a topic to the API keys permitted to submit transactions to it and read its outputs.
+	TopicKeys map[string][]string
+
+	// ServiceKeys maps a lookup service to the API keys permitted to query it.
+	ServiceKeys map[string][]string
+}
+
+// apiKeyContextKey is the context key of the API key set with WithAPIKey.
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns a copy of ctx carrying the API key the client presented, which services check against
+// their access control list.
+func WithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, apiKey)
+}
+
+// apiKey returns the API key set on ctx with WithAPIKey, or an empty string if there is none.
+func apiKey(ctx context.Context) string {
+	key, _ := ctx.Value(apiKeyContextKey{}).(string)
+	return key
+}
+
+// AuthorizeTopics checks that the API key set on ctx is permitted to access every given topic.
+// Returns NewTopicAccessDeniedError for the first topic the key is not permitted to access.
+func (a AccessControlList) AuthorizeTopics(ctx context.Context, topics ...string) error {
+	for _, topic := range topics {
+		keys, restricted := a.TopicKeys[topic]
+		if restricted && !containsKey(keys, apiKey(ctx)) {
+			return NewTopicAccessDeniedError(topic)
+		}
+	}
+	return nil
+}
+
+// AuthorizeService checks that the API key set on ctx is permitted to query the given lookup service.
+// Returns NewLookupServiceAccessDeniedError if it is not.
+func (a AccessControlList) AuthorizeService(ctx context.Context, service string) error {
+	keys, restricted := a.ServiceKeys[service]
+	if restricted && !containsKey(keys, apiKey(ctx)) {
+		return NewLookupServiceAccessDeniedError(service)
+	}
+	return nil
+}
+
+// containsKey reports whether the API key is one of the keys, comparing in constant time.
+// An empty API key never matches.
+func containsKey(keys []string, apiKey string) bool {
+	if apiKey == "" {
+		return false
+	}
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTopicAccessDeniedError returns an Error indicating that the client is not permitted
+// to submit transactions to the topic or read its outputs.
+func NewTopicAccessDeniedError(topic string) Error {
+	return Error{
+		errorType: ErrorTypeAccessForbidden,
+		code:      TopicAccessDeniedErrorCode,
+		err:       fmt.Sprintf("The API key is not permitted to access topic %q.", topic),
+		slug:      "You are not permitted to access one or more of the requested topics.",
+	}
+}
+
+// NewLookupServiceAccessDeniedError returns an Error indicating that the client is not permitted
+// to query the lookup service.
+func NewLookupServiceAccessDeniedError(service string) Error {
+	return Error{
+		errorType: ErrorTypeAccessForbidden,
+		code:      LookupServiceAccessDeniedErrorCode,
+		err:       fmt.Sprintf("The API key is not permitted to query lookup service %q.", service),
+		slug:      "You are not permitted to query the requested lookup service.",
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// AdvertisementPlanProvider defines the contract for previewing an advertisement sync run.
+type AdvertisementPlanProvider interface {
+	PlanAdvertisements(ctx context.Context) (*engine.AdvertisementPlan, error)
+}
+
+// AdvertisementPlanService previews the advertisements a sync run would create and revoke, and their cost.
+type AdvertisementPlanService struct {
+	provider AdvertisementPlanProvider
+}
+
+// PlanAdvertisements returns the plan of the next advertisement sync run without executing it.
+// Returns an error if:
+// - The provider fails to plan the advertisements (ErrorTypeProviderFailure)
+func (s *AdvertisementPlanService) PlanAdvertisements(ctx context.Context) (*engine.AdvertisementPlan, error) {
+	plan, err := s.provider.PlanAdvertisements(ctx)
+	if err != nil {
+		return nil, NewAdvertisementPlanProviderError(err)
+	}
+	return plan, nil
+}
+
+// NewAdvertisementPlanService creates a new AdvertisementPlanService with the given provider.
+// Panics if the provider is nil.
+func NewAdvertisementPlanService(provider AdvertisementPlanProvider) *AdvertisementPlanService {
+	if provider == nil {
+		panic("advertisement plan provider cannot be nil")
+	}
+
+	return &AdvertisementPlanService{provider: provider}
+}
+
+// NewAdvertisementPlanProviderError returns an Error indicating that the configured provider
+// failed to plan the advertisements.
+func NewAdvertisementPlanProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to plan the advertisements due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,63 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errAdvertisementPlanTestError = errors.New("internal advertisement plan service test error")
+
+func TestAdvertisementPlanService_PlanAdvertisements(t *testing.T) {
+	plan := &engine.AdvertisementPlan{
+		Create: []*advertiser.AdvertisementData{{Protocol: "SHIP", TopicOrServiceName: "tm_a"}},
+		Cost:   engine.AdvertisementCost{OutputsCreated: 1, LockedSatoshis: 1, FeeSatoshis: 40, TotalSatoshis: 41, WithinBudget: true},
+	}
+
+	tests := map[string]struct {
+		expectations  testabilities.AdvertisementPlanProviderMockExpectations
+		expectedPlan  *engine.AdvertisementPlan
+		expectedError error
+	}{
+		"Returns the advertisement plan": {
+			expectations: testabilities.AdvertisementPlanProviderMockExpectations{
+				PlanAdvertisementsCall: true,
+				Plan:                   plan,
+			},
+			expectedPlan: plan,
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.AdvertisementPlanProviderMockExpectations{
+				PlanAdvertisementsCall: true,
+				Error:                  errAdvertisementPlanTestError,
+			},
+			expectedError: app.NewAdvertisementPlanProviderError(errAdvertisementPlanTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewAdvertisementPlanProviderMock(t, tc.expectations)
+			service := app.NewAdvertisementPlanService(mock)
+
+			// when:
+			actual, err := service.PlanAdvertisements(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedPlan, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// AdvertisementProvider defines the contract for manually managing the SHIP/SLAP advertisements of the overlay node.
+type AdvertisementProvider interface {
+	ListAdvertisements(ctx context.Context) ([]*engine.ListedAdvertisement, error)
+	CreateAdvertisement(ctx context.Context, protocol overlay.Protocol, topicOrService string) (overlay.Steak, error)
+	RevokeAdvertisement(ctx context.Context, outpoint *transaction.Outpoint) (overlay.Steak, error)
+}
+
+// AdvertisementService coordinates the manual management of SHIP/SLAP advertisements.
+type AdvertisementService struct {
+	provider AdvertisementProvider
+}
+
+// ListAdvertisements returns the current SHIP and SLAP advertisements of the overlay node.
+// Returns an error if:
+// - The engine has no advertiser configured (ErrorTypeUnsupportedOperation)
+// - The provider fails to find the advertisements (ErrorTypeProviderFailure)
+func (s *AdvertisementService) ListAdvertisements(ctx context.Context) ([]*engine.ListedAdvertisement, error) {
+	advertisements, err := s.provider.ListAdvertisements(ctx)
+	if err != nil {
+		return nil, newAdvertisementError(err)
+	}
+	return advertisements, nil
+}
+
+// CreateAdvertisement creates and submits an advertisement of the topic or service for the given protocol,
+// and returns the STEAK of the advertisement transaction.
+// Returns an error if:
+// - The protocol or the topic or service is empty (ErrorTypeIncorrectInput)
+// - The protocol is neither SHIP nor SLAP (ErrorTypeIncorrectInput)
+// - The engine has no advertiser configured (ErrorTypeUnsupportedOperation)
+// - The provider fails to create or submit the advertisement (ErrorTypeProviderFailure)
+func (s *AdvertisementService) CreateAdvertisement(ctx context.Context, protocol, topicOrService string) (overlay.Steak, error) {
+	if protocol == "" {
+		return nil, NewIncorrectInputWithFieldError("protocol")
+	}
+	if topicOrService == "" {
+		return nil, NewIncorrectInputWithFieldError("topicOrService")
+	}
+
+	steak, err := s.provider.CreateAdvertisement(ctx, overlay.Protocol(protocol), topicOrService)
+	if errors.Is(err, engine.ErrInvalidAdvertisementProtocol) {
+		return nil, NewInvalidAdvertisementProtocolError(protocol)
+	}
+	if err != nil {
+		return nil, newAdvertisementError(err)
+	}
+	return steak, nil
+}
+
+// RevokeAdvertisement revokes the advertisement held in the output at the given outpoint and returns
+// the STEAK of the revocation transaction.
+// Returns an error if:
+// - The outpoint cannot be parsed (ErrorTypeRawDataProcessing)
+// - The outpoint is not an advertisement of the node, or the engine has no advertiser configured (ErrorTypeUnsupportedOperation)
+// - The provider fails to revoke the advertisement (ErrorTypeProviderFailure)
+func (s *AdvertisementService) RevokeAdvertisement(ctx context.Context, outpoint string) (overlay.Steak, error) {
+	parsed, err := transaction.OutpointFromString(outpoint)
+	if err != nil {
+		return nil, NewRawDataProcessingWithFieldError(err, "Outpoint")
+	}
+
+	steak, err := s.provider.RevokeAdvertisement(ctx, parsed)
+	if errors.Is(err, engine.ErrAdvertisementNotFound) {
+		return nil, NewAdvertisementNotFoundError(outpoint)
+	}
+	if err != nil {
+		return nil, newAdvertisementError(err)
+	}
+	return steak, nil
+}
+
+// NewAdvertisementService creates a new AdvertisementService with the given provider.
+// Panics if the provider is nil.
+func NewAdvertisementService(provider AdvertisementProvider) *AdvertisementService {
+	if provider == nil {
+		panic("advertisement provider cannot be nil")
+	}
+
+	return &AdvertisementService{provider: provider}
+}
+
+func newAdvertisementError(err error) Error {
+	if errors.Is(err, engine.ErrAdvertiserNotConfigured) {
+		return NewAdvertiserNotConfiguredError()
+	}
+	return NewAdvertisementProviderError(err)
+}
+
+// NewInvalidAdvertisementProtocolError returns an Error indicating that the advertisement protocol is neither SHIP nor SLAP.
+func NewInvalidAdvertisementProtocolError(protocol string) Error {
+	return NewIncorrectInputError(
+		fmt.Sprintf("invalid advertisement protocol: %q", protocol),
+		"The advertisement protocol must be SHIP or SLAP.",
+	)
+}
+
+// NewAdvertisementNotFoundError returns an Error indicating that the outpoint does not hold an advertisement of the node.
+func NewAdvertisementNotFoundError(outpoint string) Error {
+	msg := fmt.Sprintf("The advertisement %q was not found.", outpoint)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewAdvertiserNotConfiguredError returns an Error indicating that the overlay has no advertiser configured.
+func NewAdvertiserNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrAdvertiserNotConfigured.Error(),
+		"Advertisements are not managed by this overlay node.",
+	)
+}
+
+// NewAdvertisementProviderError returns an Error indicating that the configured provider
+// failed to manage the advertisements.
+func NewAdvertisementProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the advertisements due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,218 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errAdvertisementTestError = errors.New("internal advertisement service test error")
+
+func TestAdvertisementService_ListAdvertisements(t *testing.T) {
+	advertisements := []*engine.ListedAdvertisement{{
+		Protocol:       overlay.ProtocolSHIP,
+		Domain:         "https://overlay.example.com",
+		TopicOrService: testabilities.DefaultValidTopic,
+	}}
+
+	tests := map[string]struct {
+		expectations           testabilities.AdvertisementProviderMockExpectations
+		expectedAdvertisements []*engine.ListedAdvertisement
+		expectedError          error
+	}{
+		"Returns the advertisements": {
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				ListAdvertisementsCall: true,
+				Advertisements:         advertisements,
+			},
+			expectedAdvertisements: advertisements,
+		},
+		"Fails when the advertiser is not configured": {
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				ListAdvertisementsCall: true,
+				Error:                  engine.ErrAdvertiserNotConfigured,
+			},
+			expectedError: app.NewAdvertiserNotConfiguredError(),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				ListAdvertisementsCall: true,
+				Error:                  errAdvertisementTestError,
+			},
+			expectedError: app.NewAdvertisementProviderError(errAdvertisementTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewAdvertisementProviderMock(t, tc.expectations)
+			service := app.NewAdvertisementService(mock)
+
+			// when:
+			actual, err := service.ListAdvertisements(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedAdvertisements, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestAdvertisementService_CreateAdvertisement(t *testing.T) {
+	steak := overlay.Steak{"tm_ship": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+
+	tests := map[string]struct {
+		protocol       string
+		topicOrService string
+		expectations   testabilities.AdvertisementProviderMockExpectations
+		expectedSteak  overlay.Steak
+		expectedError  error
+	}{
+		"Returns the STEAK of the advertisement": {
+			protocol:       "SHIP",
+			topicOrService: testabilities.DefaultValidTopic,
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				CreateAdvertisementCall: true,
+				Protocol:                overlay.ProtocolSHIP,
+				TopicOrService:          testabilities.DefaultValidTopic,
+				Steak:                   steak,
+			},
+			expectedSteak: steak,
+		},
+		"Fails when the protocol is empty": {
+			topicOrService: testabilities.DefaultValidTopic,
+			expectedError:  app.NewIncorrectInputWithFieldError("protocol"),
+		},
+		"Fails when the topic or service is empty": {
+			protocol:      "SHIP",
+			expectedError: app.NewIncorrectInputWithFieldError("topicOrService"),
+		},
+		"Fails when the protocol is invalid": {
+			protocol:       "GASP",
+			topicOrService: testabilities.DefaultValidTopic,
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				CreateAdvertisementCall: true,
+				Error:                   engine.ErrInvalidAdvertisementProtocol,
+			},
+			expectedError: app.NewInvalidAdvertisementProtocolError("GASP"),
+		},
+		"Fails when the provider fails": {
+			protocol:       "SLAP",
+			topicOrService: "ls_example",
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				CreateAdvertisementCall: true,
+				Error:                   errAdvertisementTestError,
+			},
+			expectedError: app.NewAdvertisementProviderError(errAdvertisementTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewAdvertisementProviderMock(t, tc.expectations)
+			service := app.NewAdvertisementService(mock)
+
+			// when:
+			actual, err := service.CreateAdvertisement(context.Background(), tc.protocol, tc.topicOrService)
+
+			// then:
+			require.Equal(t, tc.expectedSteak, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestAdvertisementService_RevokeAdvertisement(t *testing.T) {
+	const outpoint = "0000000000000000000000000000000000000000000000000000000000000001.1"
+	parsed, err := transaction.OutpointFromString(outpoint)
+	require.NoError(t, err)
+	steak := overlay.Steak{"tm_ship": &overlay.AdmittanceInstructions{CoinsRemoved: []uint32{0}}}
+
+	tests := map[string]struct {
+		outpoint      string
+		expectations  testabilities.AdvertisementProviderMockExpectations
+		expectedSteak overlay.Steak
+		expectedError error
+	}{
+		"Returns the STEAK of the revocation": {
+			outpoint: outpoint,
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				RevokeAdvertisementCall: true,
+				Outpoint:                parsed,
+				Steak:                   steak,
+			},
+			expectedSteak: steak,
+		},
+		"Fails when the advertisement is not found": {
+			outpoint: outpoint,
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				RevokeAdvertisementCall: true,
+				Error:                   engine.ErrAdvertisementNotFound,
+			},
+			expectedError: app.NewAdvertisementNotFoundError(outpoint),
+		},
+		"Fails when the advertiser is not configured": {
+			outpoint: outpoint,
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				RevokeAdvertisementCall: true,
+				Error:                   engine.ErrAdvertiserNotConfigured,
+			},
+			expectedError: app.NewAdvertiserNotConfiguredError(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewAdvertisementProviderMock(t, tc.expectations)
+			service := app.NewAdvertisementService(mock)
+
+			// when:
+			actual, err := service.RevokeAdvertisement(context.Background(), tc.outpoint)
+
+			// then:
+			require.Equal(t, tc.expectedSteak, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestAdvertisementService_RevokeAdvertisement_ShouldRejectInvalidOutpoint(t *testing.T) {
+	// given:
+	mock := testabilities.NewAdvertisementProviderMock(t, testabilities.AdvertisementProviderMockExpectations{})
+	service := app.NewAdvertisementService(mock)
+
+	// when:
+	steak, err := service.RevokeAdvertisement(context.Background(), "invalid")
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, app.ErrorTypeRawDataProcessing, actualErr.ErrorType())
+	require.Nil(t, steak)
+	mock.AssertCalled()
+}
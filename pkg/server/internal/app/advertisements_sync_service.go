@@ -3,6 +3,9 @@ package app
 
 import (
 	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 )
 
 // SyncAdvertisementsProvider defines the contract that must be fulfilled
@@ -19,10 +22,14 @@ type AdvertisementsSyncService struct {
 
 // SyncAdvertisements delegates the advertisement synchronization task to the configured provider.
 // If an error occurs, it wraps it as a SyncAdvertisementsProviderError to hide internal details
-// and return a slug message to the requester.
+// and return a slug message to the requester, unless the advertisement batch exceeds the
+// configured budget, which is reported as a SyncAdvertisementsBudgetExceededError.
 func (a *AdvertisementsSyncService) SyncAdvertisements(ctx context.Context) error {
 	err := a.provider.SyncAdvertisements(ctx)
-	if err != nil {
+	switch {
+	case errors.Is(err, engine.ErrAdvertisementBudgetExceeded):
+		return NewSyncAdvertisementsBudgetExceededError(err)
+	case err != nil:
 		return NewSyncAdvertisementsProviderError(err)
 	}
 	return nil
@@ -48,3 +55,12 @@ func NewSyncAdvertisementsProviderError(err error) Error {
 		slug:      "Unable to process sync advertisements request due to issues with the overlay engine.",
 	}
 }
+
+// NewSyncAdvertisementsBudgetExceededError returns an Error indicating that the estimated cost
+// of the advertisement batch exceeds the budget configured for a single sync run.
+func NewSyncAdvertisementsBudgetExceededError(err error) Error {
+	return NewAccessForbiddenError(
+		err.Error(),
+		"The advertisement batch exceeds the budget of a sync run. Review the advertisement plan or raise the budget.",
+	)
+}
@@ -3,8 +3,10 @@ package app_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
 	"github.com/stretchr/testify/require"
@@ -48,3 +50,23 @@ func TestAdvertisementsSyncService_InvalidCase(t *testing.T) {
 
 	mock.AssertCalled()
 }
+
+func TestAdvertisementsSyncService_BudgetExceededCase(t *testing.T) {
+	// given:
+	budgetErr := fmt.Errorf("%w: estimated 100 satoshis, budget 10 satoshis", engine.ErrAdvertisementBudgetExceeded)
+	mock := testabilities.NewSyncAdvertisementsProviderMock(t, testabilities.SyncAdvertisementsProviderMockExpectations{
+		SyncAdvertisementsCall: true,
+		Err:                    budgetErr,
+	})
+	service := app.NewAdvertisementsSyncService(mock)
+
+	// when:
+	err := service.SyncAdvertisements(context.Background())
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, app.NewSyncAdvertisementsBudgetExceededError(budgetErr), actualErr)
+
+	mock.AssertCalled()
+}
@@ -3,19 +3,61 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
+	"sync"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
-// ErrInvalidBlockHeight is returned when block height is zero or invalid.
-var ErrInvalidBlockHeight = errors.New("block height must be a positive integer (greater than 0)")
+var (
+	// ErrInvalidBlockHeight is returned when block height is zero or invalid.
+	ErrInvalidBlockHeight = errors.New("block height must be a positive integer (greater than 0)")
+
+	// ErrInvalidBlockHash is returned when the block hash is not a 64 characters long hexadecimal string.
+	ErrInvalidBlockHash = errors.New("block hash must be a 64 characters long hexadecimal string")
+
+	// ErrInvalidProofSource is returned when the proof source is too long or contains unsupported characters.
+	ErrInvalidProofSource = errors.New("proof source must be at most 128 characters of letters, digits and ._:/@-")
+)
+
+// ARC ingest batch bounds.
+const (
+	// MaxARCIngestBatchSize bounds the number of Merkle proofs of a single ARC ingest batch.
+	MaxARCIngestBatchSize = 1000
+
+	// ARCIngestBatchWorkers bounds the number of Merkle proofs of a batch processed at once.
+	ARCIngestBatchWorkers = 8
+)
+
+var (
+	blockHashPattern   = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	proofSourcePattern = regexp.MustCompile(`^[A-Za-z0-9._:/@-]{1,128}$`)
+)
 
 // ARCIngestProvider defines an interface for handling the ingestion of Merkle proofs
 // for a given transaction. It is typically implemented by a domain service or adapter
 // responsible for storing or processing Merkle proofs.
 type ARCIngestProvider interface {
 	HandleNewMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath) error
+	HandleAttestedMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath, attestation engine.MerkleProofAttestation) error
+}
+
+// ARCIngestEntry is a Merkle proof of an ARC ingest batch, in the string form accepted by ProcessIngest.
+type ARCIngestEntry struct {
+	TxID        string
+	MerklePath  string
+	BlockHeight uint32
+	BlockHash   string
+	ProofSource string
+}
+
+// ARCIngestResult is the outcome of an ARCIngestEntry: Err is nil when its Merkle proof was ingested.
+type ARCIngestResult struct {
+	TxID string
+	Err  error
 }
 
 // ARCIngestService coordinates the ingestion of Merkle proofs in the application layer.
@@ -27,8 +69,10 @@ type ARCIngestService struct {
 
 // ProcessIngest receives transaction and Merkle path data in string form,
 // performs input validation and parsing, sets the block height, and delegates
-// the actual proof handling to the ARCIngestProvider.
-func (a *ARCIngestService) ProcessIngest(ctx context.Context, txID, merklePath string, blockHeight uint32) error {
+// the actual proof handling to the ARCIngestProvider. The block hash and proof
+// source are optional; when either is given, the proof is handled as attested so
+// that the block hash is checked against the chain and the source is recorded.
+func (a *ARCIngestService) ProcessIngest(ctx context.Context, txID, merklePath string, blockHeight uint32, blockHash, proofSource string) error {
 	hash, err := chainhash.NewHashFromHex(txID)
 	if err != nil {
 		return NewInvalidTxIDFormatError(err)
@@ -45,12 +89,69 @@ func (a *ARCIngestService) ProcessIngest(ctx context.Context, txID, merklePath s
 
 	path.BlockHeight = blockHeight
 
-	err = a.provider.HandleNewMerkleProof(ctx, hash, path)
-	if err != nil {
+	if blockHash == "" && proofSource == "" {
+		err = a.provider.HandleNewMerkleProof(ctx, hash, path)
+		if err != nil {
+			return NewArcIngestProviderError(err)
+		}
+		return nil
+	}
+
+	attestation := engine.MerkleProofAttestation{Source: proofSource}
+	if blockHash != "" {
+		if !blockHashPattern.MatchString(blockHash) {
+			return NewInvalidBlockHashError(ErrInvalidBlockHash)
+		}
+		if attestation.BlockHash, err = chainhash.NewHashFromHex(blockHash); err != nil {
+			return NewInvalidBlockHashError(err)
+		}
+	}
+	if proofSource != "" && !proofSourcePattern.MatchString(proofSource) {
+		return NewInvalidProofSourceError(ErrInvalidProofSource)
+	}
+
+	err = a.provider.HandleAttestedMerkleProof(ctx, hash, path, attestation)
+	switch {
+	case errors.Is(err, engine.ErrBlockHashMismatch), errors.Is(err, engine.ErrInvalidMerkleProof):
+		return NewMerkleProofChainMismatchError(err)
+	case err != nil:
 		return NewArcIngestProviderError(err)
+	default:
+		return nil
 	}
+}
 
-	return nil
+// ProcessIngestBatch processes the entries of an ARC batch callback through ProcessIngest, up to
+// ARCIngestBatchWorkers at a time, and returns the outcome of each entry in the order of the entries.
+// Entries fail independently of each other, so the returned error only reports an empty batch
+// (ErrorTypeIncorrectInput) or one exceeding MaxARCIngestBatchSize (ErrorTypePayloadTooLarge).
+func (a *ARCIngestService) ProcessIngestBatch(ctx context.Context, entries []ARCIngestEntry) ([]ARCIngestResult, error) {
+	if len(entries) == 0 {
+		return nil, NewIncorrectInputWithFieldError("entries")
+	}
+	if len(entries) > MaxARCIngestBatchSize {
+		return nil, NewARCIngestBatchTooLargeError(len(entries))
+	}
+
+	results := make([]ARCIngestResult, len(entries))
+	limiter := make(chan struct{}, ARCIngestBatchWorkers)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			results[i] = ARCIngestResult{
+				TxID: entry.TxID,
+				Err:  a.ProcessIngest(ctx, entry.TxID, entry.MerklePath, entry.BlockHeight, entry.BlockHash, entry.ProofSource),
+			}
+		}()
+	}
+	wg.Wait()
+	return results, nil
 }
 
 // NewARCIngestService constructs a new ARCIngestService with the given provider.
@@ -99,3 +200,40 @@ func NewInvalidBlockHeightError(err error) Error {
 		"Unable to process block height due to an invalid value. Please verify the block height and try again.",
 	)
 }
+
+// NewInvalidBlockHashError returns an error indicating that the provided block hash
+// is not a valid hexadecimal-encoded block hash.
+func NewInvalidBlockHashError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"Unable to process block hash due to an invalid data format. Please verify the block hash and try again.",
+	)
+}
+
+// NewInvalidProofSourceError returns an error indicating that the provided proof source
+// identifier is too long or contains unsupported characters.
+func NewInvalidProofSourceError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"Unable to process proof source due to an invalid value. Please verify the proof source and try again.",
+	)
+}
+
+// NewMerkleProofChainMismatchError returns an error indicating that the Merkle proof does not
+// belong to the attested block of the chain followed by the overlay engine.
+func NewMerkleProofChainMismatchError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"Unable to process Merkle proof because it does not match the block at the given height. Please verify the block hash and try again.",
+	)
+}
+
+// NewARCIngestBatchTooLargeError returns an error indicating that an ARC ingest batch holds
+// more Merkle proofs than MaxARCIngestBatchSize.
+func NewARCIngestBatchTooLargeError(size int) Error {
+	return NewPayloadTooLargeError(
+		fmt.Sprintf("ARC ingest batch of %d entries exceeds %d", size, MaxARCIngestBatchSize),
+		fmt.Sprintf("The batch must hold at most %d Merkle proofs. Please split it and try again.", MaxARCIngestBatchSize),
+		PayloadTooLargeErrorCode,
+	)
+}
@@ -3,8 +3,10 @@ package app_test
 import (
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,6 +15,8 @@ func TestARCIngestService_InvalidCases(t *testing.T) {
 		txID            string
 		merklePath      string
 		blockHeight     uint32
+		blockHash       string
+		proofSource     string
 		expectedErrType app.ErrorType
 		expectations    testabilities.ARCIngestProviderMockExpectations
 	}{
@@ -43,6 +47,34 @@ func TestARCIngestService_InvalidCases(t *testing.T) {
 				HandleNewMerkleProofCall: false,
 			},
 		},
+		"ARC ingest service returns error for invalid block hash format": {
+			expectedErrType: app.ErrorTypeIncorrectInput,
+			txID:            testabilities.NewTxID(t),
+			merklePath:      testabilities.NewTestMerklePath(t),
+			blockHeight:     testabilities.DefaultBlockHeight,
+			blockHash:       "abc",
+			expectations:    testabilities.ARCIngestProviderMockExpectations{},
+		},
+		"ARC ingest service returns error for invalid proof source": {
+			expectedErrType: app.ErrorTypeIncorrectInput,
+			txID:            testabilities.NewTxID(t),
+			merklePath:      testabilities.NewTestMerklePath(t),
+			blockHeight:     testabilities.DefaultBlockHeight,
+			proofSource:     "arc taal\n",
+			expectations:    testabilities.ARCIngestProviderMockExpectations{},
+		},
+		"ARC ingest service returns error when the proof does not match the attested block": {
+			expectedErrType: app.ErrorTypeIncorrectInput,
+			txID:            testabilities.NewTxID(t),
+			merklePath:      testabilities.NewTestMerklePath(t),
+			blockHeight:     testabilities.DefaultBlockHeight,
+			proofSource:     "arc-taal",
+			expectations: testabilities.ARCIngestProviderMockExpectations{
+				HandleAttestedMerkleProofCall: true,
+				Attestation:                   engine.MerkleProofAttestation{Source: "arc-taal"},
+				Error:                         engine.ErrBlockHashMismatch,
+			},
+		},
 		"ARC ingest service returns error due to internal provider failure": {
 			txID:            testabilities.NewTxID(t),
 			merklePath:      testabilities.NewTestMerklePath(t),
@@ -67,6 +99,8 @@ func TestARCIngestService_InvalidCases(t *testing.T) {
 				tc.txID,
 				tc.merklePath,
 				tc.blockHeight,
+				tc.blockHash,
+				tc.proofSource,
 			)
 
 			// then:
@@ -94,9 +128,111 @@ func TestARCIngestService_ValidCase(t *testing.T) {
 		testabilities.NewTxID(t),
 		testabilities.NewTestMerklePath(t),
 		testabilities.DefaultBlockHeight,
+		"",
+		"",
 	)
 
 	// then:
 	require.NoError(t, err)
 	mock.AssertCalled()
 }
+
+func TestARCIngestService_ShouldHandleAttestedProof_WhenBlockHashOrProofSourceIsGiven(t *testing.T) {
+	// given:
+	blockHash := "0000000000000000000000000000000000000000000000000000000000000001"
+	expectedHash, err := chainhash.NewHashFromHex(blockHash)
+	require.NoError(t, err)
+
+	mock := testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{
+		HandleAttestedMerkleProofCall: true,
+		Attestation:                   engine.MerkleProofAttestation{BlockHash: expectedHash, Source: "arc.taal.com"},
+	})
+
+	service := app.NewARCIngestService(mock)
+
+	// when:
+	err = service.ProcessIngest(
+		t.Context(),
+		testabilities.NewTxID(t),
+		testabilities.NewTestMerklePath(t),
+		testabilities.DefaultBlockHeight,
+		blockHash,
+		"arc.taal.com",
+	)
+
+	// then:
+	require.NoError(t, err)
+	mock.AssertCalled()
+}
+
+func TestARCIngestService_ProcessIngestBatch(t *testing.T) {
+	// given:
+	mock := testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{
+		HandleNewMerkleProofCall: true,
+	})
+
+	service := app.NewARCIngestService(mock)
+
+	entries := make([]app.ARCIngestEntry, 2*app.ARCIngestBatchWorkers)
+	for i := range entries {
+		entries[i] = app.ARCIngestEntry{
+			TxID:        testabilities.NewTxID(t),
+			MerklePath:  testabilities.NewTestMerklePath(t),
+			BlockHeight: testabilities.DefaultBlockHeight,
+		}
+	}
+	entries[3].TxID = "INVALID-HEX-STR"
+
+	// when:
+	results, err := service.ProcessIngestBatch(t.Context(), entries)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, results, len(entries))
+	for i, result := range results {
+		require.Equal(t, entries[i].TxID, result.TxID)
+		if i == 3 {
+			var actualErr app.Error
+			require.ErrorAs(t, result.Err, &actualErr)
+			require.Equal(t, app.ErrorTypeIncorrectInput, actualErr.ErrorType())
+			continue
+		}
+		require.NoError(t, result.Err)
+	}
+
+	mock.AssertCalled()
+}
+
+func TestARCIngestService_ProcessIngestBatch_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		entries         []app.ARCIngestEntry
+		expectedErrType app.ErrorType
+	}{
+		"ARC ingest service returns error for an empty batch": {
+			expectedErrType: app.ErrorTypeIncorrectInput,
+		},
+		"ARC ingest service returns error for a batch exceeding the maximum size": {
+			entries:         make([]app.ARCIngestEntry, app.MaxARCIngestBatchSize+1),
+			expectedErrType: app.ErrorTypePayloadTooLarge,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{})
+			service := app.NewARCIngestService(mock)
+
+			// when:
+			results, err := service.ProcessIngestBatch(t.Context(), tc.entries)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedErrType, actualErr.ErrorType())
+			require.Nil(t, results)
+
+			mock.AssertCalled()
+		})
+	}
+}
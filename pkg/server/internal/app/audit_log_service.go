@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// AuditLogProvider defines the contract for querying the audit log of admin actions and submissions.
+type AuditLogProvider interface {
+	FindAuditEntries(ctx context.Context, query engine.AuditQuery) ([]*engine.AuditEntry, error)
+}
+
+// AuditRecorder defines the contract for appending entries to the audit log of admin actions and submissions.
+type AuditRecorder interface {
+	RecordAudit(ctx context.Context, entry *engine.AuditEntry) error
+}
+
+// AuditLogService coordinates queries of the audit log.
+type AuditLogService struct {
+	provider AuditLogProvider
+}
+
+// ListAuditEntries returns the audit entries selected by the query, most recent first.
+// Returns an error if:
+// - The limit is negative or the time range is empty (ErrorTypeIncorrectInput)
+// - The overlay node keeps no audit log (ErrorTypeUnsupportedOperation)
+// - The provider fails to query the audit log (ErrorTypeProviderFailure)
+func (s *AuditLogService) ListAuditEntries(ctx context.Context, query engine.AuditQuery) ([]*engine.AuditEntry, error) {
+	if query.Limit < 0 {
+		return nil, NewIncorrectInputWithFieldError("limit")
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return nil, NewIncorrectInputWithFieldError("until")
+	}
+
+	entries, err := s.provider.FindAuditEntries(ctx, query)
+	switch {
+	case errors.Is(err, engine.ErrAuditLogNotConfigured):
+		return nil, NewAuditLogNotConfiguredError()
+	case err != nil:
+		return nil, NewAuditLogProviderError(err)
+	}
+	return entries, nil
+}
+
+// NewAuditLogService creates a new AuditLogService with the given provider.
+// Panics if the provider is nil.
+func NewAuditLogService(provider AuditLogProvider) *AuditLogService {
+	if provider == nil {
+		panic("audit log provider cannot be nil")
+	}
+
+	return &AuditLogService{provider: provider}
+}
+
+// NewAuditLogNotConfiguredError returns an Error indicating that the overlay node keeps no audit log.
+func NewAuditLogNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrAuditLogNotConfigured.Error(),
+		"The audit log is not enabled on this overlay node.",
+	)
+}
+
+// NewAuditLogProviderError returns an Error indicating that the configured provider
+// failed to query the audit log.
+func NewAuditLogProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to query the audit log due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,80 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errAuditLogTestError = errors.New("internal audit log service test error")
+
+func TestAuditLogService_ListAuditEntries(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	query := engine.AuditQuery{Caller: "admin", Since: since, Until: since.Add(time.Hour), Limit: 10}
+	entries := []*engine.AuditEntry{{ID: "id", Time: since, Action: "POST /api/v1/admin/startGASPSync", Caller: "admin", Result: engine.AuditResultSuccess}}
+
+	tests := map[string]struct {
+		query           engine.AuditQuery
+		expectations    testabilities.AuditLogProviderMockExpectations
+		expectedEntries []*engine.AuditEntry
+		expectedError   error
+	}{
+		"Returns the audit entries selected by the query": {
+			query: query,
+			expectations: testabilities.AuditLogProviderMockExpectations{
+				FindAuditEntriesCall: true,
+				Query:                &query,
+				Entries:              entries,
+			},
+			expectedEntries: entries,
+		},
+		"Fails when the limit is negative": {
+			query:         engine.AuditQuery{Limit: -1},
+			expectedError: app.NewIncorrectInputWithFieldError("limit"),
+		},
+		"Fails when the time range is empty": {
+			query:         engine.AuditQuery{Since: since, Until: since},
+			expectedError: app.NewIncorrectInputWithFieldError("until"),
+		},
+		"Fails when the overlay node keeps no audit log": {
+			expectations: testabilities.AuditLogProviderMockExpectations{
+				FindAuditEntriesCall: true,
+				Error:                engine.ErrAuditLogNotConfigured,
+			},
+			expectedError: app.NewAuditLogNotConfiguredError(),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.AuditLogProviderMockExpectations{
+				FindAuditEntriesCall: true,
+				Error:                errAuditLogTestError,
+			},
+			expectedError: app.NewAuditLogProviderError(errAuditLogTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewAuditLogProviderMock(t, tc.expectations)
+			service := app.NewAuditLogService(mock)
+
+			// when:
+			actual, err := service.ListAuditEntries(context.Background(), tc.query)
+
+			// then:
+			require.Equal(t, tc.expectedEntries, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
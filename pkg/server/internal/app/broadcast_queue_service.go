@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// BroadcastQueueProvider defines the contract for inspecting the queue of transactions waiting to be re-broadcast.
+type BroadcastQueueProvider interface {
+	BroadcastQueueStatus(ctx context.Context) (*engine.BroadcastQueueStatus, error)
+}
+
+// BroadcastQueueService coordinates inspection of the re-broadcast queue.
+type BroadcastQueueService struct {
+	provider BroadcastQueueProvider
+}
+
+// BroadcastQueueStatus returns the queued broadcasts and the metrics of the re-broadcast queue.
+// Returns an error if:
+// - Broadcast retries are not configured or the storage has no re-broadcast queue (ErrorTypeUnsupportedOperation)
+// - The provider fails to inspect the queue (ErrorTypeProviderFailure)
+func (s *BroadcastQueueService) BroadcastQueueStatus(ctx context.Context) (*engine.BroadcastQueueStatus, error) {
+	status, err := s.provider.BroadcastQueueStatus(ctx)
+	switch {
+	case errors.Is(err, engine.ErrBroadcastRetryNotConfigured), errors.Is(err, engine.ErrBroadcastQueueNotSupported):
+		return nil, NewBroadcastQueueNotSupportedError(err)
+	case err != nil:
+		return nil, NewBroadcastQueueProviderError(err)
+	}
+	return status, nil
+}
+
+// NewBroadcastQueueService creates a new BroadcastQueueService with the given provider.
+// Panics if the provider is nil.
+func NewBroadcastQueueService(provider BroadcastQueueProvider) *BroadcastQueueService {
+	if provider == nil {
+		panic("broadcast queue provider cannot be nil")
+	}
+
+	return &BroadcastQueueService{provider: provider}
+}
+
+// NewBroadcastQueueNotSupportedError returns an Error indicating that this overlay node
+// does not retry failed broadcasts.
+func NewBroadcastQueueNotSupportedError(err error) Error {
+	return NewUnsupportedOperationError(
+		err.Error(),
+		"Retrying failed broadcasts is not enabled on this overlay node.",
+	)
+}
+
+// NewBroadcastQueueProviderError returns an Error indicating that the configured provider
+// failed to inspect the re-broadcast queue.
+func NewBroadcastQueueProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to inspect the re-broadcast queue due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,82 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errBroadcastQueueTestError = errors.New("internal broadcast queue service test error")
+
+func TestBroadcastQueueService_BroadcastQueueStatus(t *testing.T) {
+	status := &engine.BroadcastQueueStatus{
+		Broadcasts: []*engine.QueuedBroadcast{{
+			Attempts:      2,
+			LastError:     "ARC unavailable",
+			FirstFailedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			NextAttemptAt: time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC),
+		}},
+		Metrics: engine.BroadcastRetryMetrics{Queued: 1, Retried: 1},
+	}
+
+	tests := map[string]struct {
+		expectations   testabilities.BroadcastQueueProviderMockExpectations
+		expectedStatus *engine.BroadcastQueueStatus
+		expectedError  error
+	}{
+		"Returns the broadcast queue status": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Status:                   status,
+			},
+			expectedStatus: status,
+		},
+		"Fails when broadcast retries are not configured": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Error:                    engine.ErrBroadcastRetryNotConfigured,
+			},
+			expectedError: app.NewBroadcastQueueNotSupportedError(engine.ErrBroadcastRetryNotConfigured),
+		},
+		"Fails when the storage has no broadcast queue": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Error:                    engine.ErrBroadcastQueueNotSupported,
+			},
+			expectedError: app.NewBroadcastQueueNotSupportedError(engine.ErrBroadcastQueueNotSupported),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Error:                    errBroadcastQueueTestError,
+			},
+			expectedError: app.NewBroadcastQueueProviderError(errBroadcastQueueTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewBroadcastQueueProviderMock(t, tc.expectations)
+			service := app.NewBroadcastQueueService(mock)
+
+			// when:
+			actual, err := service.BroadcastQueueStatus(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedStatus, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ConfigReloadProvider defines the contract for re-reading the configuration of the overlay node
+// and applying the settings that can change without a restart. It returns the applied settings.
+// Providers report rejected configurations with the errors returned by NewConfigReloadUnsupportedError,
+// NewConfigReloadRestartRequiredError and NewConfigReloadInvalidError.
+type ConfigReloadProvider interface {
+	ReloadConfig(ctx context.Context) ([]string, error)
+}
+
+// ConfigReloadService coordinates on-demand reloads of the configuration of the overlay node.
+type ConfigReloadService struct {
+	provider ConfigReloadProvider
+}
+
+// ReloadConfig re-reads the configuration and returns the changed settings applied without a restart.
+// Returns an error if:
+// - The overlay node cannot reload its configuration (ErrorTypeUnsupportedOperation)
+// - The configuration is invalid or changes settings requiring a restart (ErrorTypeIncorrectInput)
+// - The provider fails to reload the configuration (ErrorTypeProviderFailure)
+func (s *ConfigReloadService) ReloadConfig(ctx context.Context) ([]string, error) {
+	applied, err := s.provider.ReloadConfig(ctx)
+	if err != nil {
+		var appErr Error
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, NewConfigReloadProviderError(err)
+	}
+	return applied, nil
+}
+
+// NewConfigReloadService creates a new ConfigReloadService with the given provider.
+// Panics if the provider is nil.
+func NewConfigReloadService(provider ConfigReloadProvider) *ConfigReloadService {
+	if provider == nil {
+		panic("config reload provider cannot be nil")
+	}
+
+	return &ConfigReloadService{provider: provider}
+}
+
+// NewConfigReloadUnsupportedError returns an Error indicating that the overlay node was not started
+// from a configuration file it can read again.
+func NewConfigReloadUnsupportedError(err error) Error {
+	return NewUnsupportedOperationError(
+		err.Error(),
+		"Reloading the configuration is not supported by this overlay node. Start it from a configuration file.",
+	)
+}
+
+// NewConfigReloadRestartRequiredError returns an Error indicating that the reloaded configuration changes
+// settings that only take effect after a restart. Nothing is applied in that case.
+func NewConfigReloadRestartRequiredError(err error, settings []string) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The configuration was not reloaded, since it changes settings requiring a restart: "+strings.Join(settings, ", ")+
+			". Restart the overlay node or revert these settings.",
+	).WithCode(RestartRequiredErrorCode).WithDetails(map[string]any{"settings": settings})
+}
+
+// NewConfigReloadInvalidError returns an Error indicating that the reloaded configuration is invalid.
+func NewConfigReloadInvalidError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The configuration was not reloaded, since it is invalid: "+err.Error(),
+	).WithCode(InvalidConfigErrorCode)
+}
+
+// NewConfigReloadProviderError returns an Error indicating that the configured provider
+// failed to reload the configuration.
+func NewConfigReloadProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to reload the configuration due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,65 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errConfigReloadTestError = errors.New("internal config reload service test error")
+
+func TestConfigReloadService_ReloadConfig(t *testing.T) {
+	restartRequired := app.NewConfigReloadRestartRequiredError(errConfigReloadTestError, []string{"port"})
+
+	tests := map[string]struct {
+		expectations    testabilities.ConfigReloadProviderMockExpectations
+		expectedApplied []string
+		expectedError   error
+	}{
+		"Returns the applied settings": {
+			expectations: testabilities.ConfigReloadProviderMockExpectations{
+				ReloadConfigCall: true,
+				Applied:          []string{"log_level"},
+			},
+			expectedApplied: []string{"log_level"},
+		},
+		"Fails with the error of the provider when the configuration is rejected": {
+			expectations: testabilities.ConfigReloadProviderMockExpectations{
+				ReloadConfigCall: true,
+				Error:            restartRequired,
+			},
+			expectedError: restartRequired,
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.ConfigReloadProviderMockExpectations{
+				ReloadConfigCall: true,
+				Error:            errConfigReloadTestError,
+			},
+			expectedError: app.NewConfigReloadProviderError(errConfigReloadTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewConfigReloadProviderMock(t, tc.expectations)
+			service := app.NewConfigReloadService(mock)
+
+			// when:
+			actual, err := service.ReloadConfig(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedApplied, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// DeadLetterProvider defines the contract for inspecting and replaying submissions
+// recorded in the dead-letter queue.
+type DeadLetterProvider interface {
+	ListDeadLetters(ctx context.Context) ([]*engine.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) (overlay.Steak, error)
+}
+
+// DeadLetterService coordinates inspection and replay of failed submissions.
+type DeadLetterService struct {
+	provider DeadLetterProvider
+}
+
+// ListDeadLetters returns every submission recorded in the dead-letter queue.
+// Returns an error if:
+// - The storage has no dead-letter queue (ErrorTypeUnsupportedOperation)
+// - The provider fails to list the dead letters (ErrorTypeProviderFailure)
+func (s *DeadLetterService) ListDeadLetters(ctx context.Context) ([]*engine.DeadLetter, error) {
+	deadLetters, err := s.provider.ListDeadLetters(ctx)
+	switch {
+	case errors.Is(err, engine.ErrDeadLetterQueueNotSupported):
+		return nil, NewDeadLetterQueueNotSupportedError()
+	case err != nil:
+		return nil, NewDeadLetterProviderError(err)
+	}
+	return deadLetters, nil
+}
+
+// ReplayDeadLetter resubmits the dead letter with the given ID and returns the resulting STEAK.
+// Returns an error if:
+// - The ID is empty (ErrorTypeIncorrectInput)
+// - The dead letter is not found or the storage has no dead-letter queue (ErrorTypeUnsupportedOperation)
+// - The provider temporarily rejects writes (ErrorTypeServiceUnavailable)
+// - The provider fails to replay the submission (ErrorTypeProviderFailure)
+func (s *DeadLetterService) ReplayDeadLetter(ctx context.Context, id string) (overlay.Steak, error) {
+	if id == "" {
+		return nil, NewIncorrectInputWithFieldError("id")
+	}
+
+	steak, err := s.provider.ReplayDeadLetter(ctx, id)
+	if err != nil {
+		var readOnlyErr *engine.StorageReadOnlyError
+		switch {
+		case errors.Is(err, engine.ErrDeadLetterNotFound):
+			return nil, NewDeadLetterNotFoundError(id)
+		case errors.Is(err, engine.ErrDeadLetterQueueNotSupported):
+			return nil, NewDeadLetterQueueNotSupportedError()
+		case errors.As(err, &readOnlyErr):
+			return nil, NewSubmitTransactionUnavailableError(readOnlyErr.RetryAfter)
+		default:
+			return nil, NewDeadLetterProviderError(err)
+		}
+	}
+	return steak, nil
+}
+
+// NewDeadLetterService creates a new DeadLetterService with the given provider.
+// Panics if the provider is nil.
+func NewDeadLetterService(provider DeadLetterProvider) *DeadLetterService {
+	if provider == nil {
+		panic("dead letter provider cannot be nil")
+	}
+
+	return &DeadLetterService{provider: provider}
+}
+
+// NewDeadLetterNotFoundError returns an Error indicating that no dead letter exists with the given ID.
+func NewDeadLetterNotFoundError(id string) Error {
+	msg := fmt.Sprintf("The dead letter %q was not found.", id)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewDeadLetterQueueNotSupportedError returns an Error indicating that the overlay storage
+// has no dead-letter queue.
+func NewDeadLetterQueueNotSupportedError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrDeadLetterQueueNotSupported.Error(),
+		"A dead-letter queue is not supported by the storage of this overlay node.",
+	)
+}
+
+// NewDeadLetterProviderError returns an Error indicating that the configured provider
+// failed to list or replay dead letters.
+func NewDeadLetterProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the dead-letter queue due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,136 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+var errDeadLetterTestError = errors.New("internal dead letter service test error")
+
+func TestDeadLetterService_ListDeadLetters(t *testing.T) {
+	deadLetters := []*engine.DeadLetter{{ID: "id", Stage: engine.DeadLetterStageBroadcast, Attempts: 1}}
+
+	tests := map[string]struct {
+		expectations        testabilities.DeadLetterProviderMockExpectations
+		expectedDeadLetters []*engine.DeadLetter
+		expectedError       error
+	}{
+		"Returns the dead-letter queue": {
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ListDeadLettersCall: true,
+				DeadLetters:         deadLetters,
+			},
+			expectedDeadLetters: deadLetters,
+		},
+		"Fails when the storage has no dead-letter queue": {
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ListDeadLettersCall: true,
+				Error:               engine.ErrDeadLetterQueueNotSupported,
+			},
+			expectedError: app.NewDeadLetterQueueNotSupportedError(),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ListDeadLettersCall: true,
+				Error:               errDeadLetterTestError,
+			},
+			expectedError: app.NewDeadLetterProviderError(errDeadLetterTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewDeadLetterProviderMock(t, tc.expectations)
+			service := app.NewDeadLetterService(mock)
+
+			// when:
+			actual, err := service.ListDeadLetters(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedDeadLetters, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestDeadLetterService_ReplayDeadLetter(t *testing.T) {
+	steak := overlay.Steak{"test-topic": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+
+	tests := map[string]struct {
+		id            string
+		expectations  testabilities.DeadLetterProviderMockExpectations
+		expectedSteak overlay.Steak
+		expectedError error
+	}{
+		"Returns the STEAK of the replayed submission": {
+			id: "id",
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ReplayDeadLetterCall: true,
+				ID:                   "id",
+				Steak:                steak,
+			},
+			expectedSteak: steak,
+		},
+		"Fails when the ID is empty": {
+			expectedError: app.NewIncorrectInputWithFieldError("id"),
+		},
+		"Fails when the dead letter is not found": {
+			id: "id",
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ReplayDeadLetterCall: true,
+				Error:                engine.ErrDeadLetterNotFound,
+			},
+			expectedError: app.NewDeadLetterNotFoundError("id"),
+		},
+		"Fails when the provider rejects writes": {
+			id: "id",
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ReplayDeadLetterCall: true,
+				Error:                &engine.StorageReadOnlyError{RetryAfter: time.Minute},
+			},
+			expectedError: app.NewSubmitTransactionUnavailableError(time.Minute),
+		},
+		"Fails when the provider fails": {
+			id: "id",
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ReplayDeadLetterCall: true,
+				Error:                errDeadLetterTestError,
+			},
+			expectedError: app.NewDeadLetterProviderError(errDeadLetterTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewDeadLetterProviderMock(t, tc.expectations)
+			service := app.NewDeadLetterService(mock)
+
+			// when:
+			actual, err := service.ReplayDeadLetter(context.Background(), tc.id)
+
+			// then:
+			require.Equal(t, tc.expectedSteak, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+// Machine-readable codes identifying the errors returned to the requester. Every ErrorType
+// has a default code, and the errors of specific failures override it with a more precise one
+// so that clients can react to them without parsing the messages.
+const (
+	// InternalErrorCode is the default code of ErrorTypeProviderFailure errors.
+	InternalErrorCode = "ERR_INTERNAL"
+	// UnauthorizedErrorCode is the default code of ErrorTypeAuthorization errors.
+	UnauthorizedErrorCode = "ERR_UNAUTHORIZED"
+	// ForbiddenErrorCode is the default code of ErrorTypeAccessForbidden errors.
+	ForbiddenErrorCode = "ERR_FORBIDDEN"
+	// InvalidInputErrorCode is the default code of ErrorTypeIncorrectInput errors.
+	InvalidInputErrorCode = "ERR_INVALID_INPUT"
+	// UnknownErrorCode is the default code of ErrorTypeUnknown errors.
+	UnknownErrorCode = "ERR_UNKNOWN"
+	// TimeoutErrorCode is the default code of ErrorTypeOperationTimeout errors.
+	TimeoutErrorCode = "ERR_TIMEOUT"
+	// InvalidRequestBodyErrorCode is the default code of ErrorTypeRawDataProcessing errors.
+	InvalidRequestBodyErrorCode = "ERR_INVALID_REQUEST_BODY"
+	// NotSupportedErrorCode is the default code of ErrorTypeUnsupportedOperation errors.
+	NotSupportedErrorCode = "ERR_NOT_SUPPORTED"
+	// UnavailableErrorCode is the default code of ErrorTypeServiceUnavailable errors.
+	UnavailableErrorCode = "ERR_UNAVAILABLE"
+	// PayloadTooLargeErrorCode is the default code of ErrorTypePayloadTooLarge errors.
+	PayloadTooLargeErrorCode = "ERR_PAYLOAD_TOO_LARGE"
+	// UnprocessableContentErrorCode is the default code of ErrorTypeUnprocessableContent errors.
+	UnprocessableContentErrorCode = "ERR_UNPROCESSABLE_CONTENT"
+	// PaymentRequiredErrorCode is the default code of ErrorTypePaymentRequired errors.
+	PaymentRequiredErrorCode = "ERR_PAYMENT_REQUIRED"
+	// TooManyRequestsErrorCode is the default code of ErrorTypeTooManyRequests errors.
+	TooManyRequestsErrorCode = "ERR_TOO_MANY_REQUESTS"
+	// DeadlineExceededErrorCode is the default code of ErrorTypeDeadlineExceeded errors.
+	DeadlineExceededErrorCode = "ERR_DEADLINE_EXCEEDED"
+
+	// NotFoundErrorCode identifies requests for resources that do not exist.
+	NotFoundErrorCode = "ERR_NOT_FOUND"
+	// UnknownTopicErrorCode identifies requests naming a topic no topic manager is registered for.
+	UnknownTopicErrorCode = "ERR_UNKNOWN_TOPIC"
+	// MissingTopicsErrorCode identifies submissions without topics.
+	MissingTopicsErrorCode = "ERR_MISSING_TOPICS"
+	// InvalidTopicErrorCode identifies requests naming a malformed topic.
+	InvalidTopicErrorCode = "ERR_INVALID_TOPIC"
+	// UnknownLookupServiceErrorCode identifies requests naming a lookup service that is not registered.
+	UnknownLookupServiceErrorCode = "ERR_UNKNOWN_LOOKUP_SERVICE"
+	// InvalidLookupQueryErrorCode identifies lookup requests whose query is rejected by the lookup service.
+	InvalidLookupQueryErrorCode = "ERR_INVALID_LOOKUP_QUERY"
+	// InvalidLookupCursorErrorCode identifies lookup requests paging from a cursor the overlay did not return.
+	InvalidLookupCursorErrorCode = "ERR_INVALID_LOOKUP_CURSOR"
+	// InvalidLookupHydrationErrorCode identifies lookup requests selecting an unknown hydration.
+	InvalidLookupHydrationErrorCode = "ERR_INVALID_LOOKUP_HYDRATION"
+	// TopicNotAllowedErrorCode identifies submissions tagging a topic that is not accepted for explicit tagging.
+	TopicNotAllowedErrorCode = "ERR_TOPIC_NOT_ALLOWED"
+	// TopicTrustedOnlyErrorCode identifies requests of untrusted clients for topics reserved to trusted ones.
+	TopicTrustedOnlyErrorCode = "ERR_TOPIC_TRUSTED_ONLY"
+	// TopicAccessDeniedErrorCode identifies requests for topics the API key is not permitted to access.
+	TopicAccessDeniedErrorCode = "ERR_TOPIC_ACCESS_DENIED"
+	// LookupServiceAccessDeniedErrorCode identifies lookups of services the API key is not permitted to query.
+	LookupServiceAccessDeniedErrorCode = "ERR_LOOKUP_SERVICE_ACCESS_DENIED"
+	// InvalidTransactionErrorCode identifies submissions whose transaction is rejected as invalid.
+	InvalidTransactionErrorCode = "ERR_INVALID_TRANSACTION"
+	// UnsupportedSTEAKVersionErrorCode identifies submissions requesting a STEAK serialization version the overlay does not support.
+	UnsupportedSTEAKVersionErrorCode = "ERR_UNSUPPORTED_STEAK_VERSION"
+	// RestartRequiredErrorCode identifies configuration reloads changing settings that require a restart.
+	RestartRequiredErrorCode = "ERR_RESTART_REQUIRED"
+	// InvalidConfigErrorCode identifies configuration reloads rejected because the configuration is invalid.
+	InvalidConfigErrorCode = "ERR_INVALID_CONFIG"
+	// GASPResponseTooLargeErrorCode identifies GASP requests whose response would exceed the budget served to peers.
+	GASPResponseTooLargeErrorCode = "ERR_GASP_RESPONSE_TOO_LARGE"
+	// TopicQuotaExceededErrorCode identifies submissions rejected because a topic admits more outputs than its quota allows.
+	TopicQuotaExceededErrorCode = "ERR_TOPIC_QUOTA_EXCEEDED"
+	// SubmitTimeoutErrorCode identifies submissions that did not complete within the submit timeout of the server.
+	SubmitTimeoutErrorCode = "ERR_SUBMIT_TIMEOUT"
+	// IdempotencyKeyConflictErrorCode identifies submissions reusing an idempotency key for another transaction or other topics.
+	IdempotencyKeyConflictErrorCode = "ERR_IDEMPOTENCY_KEY_CONFLICT"
+)
@@ -1,30 +1,59 @@
 package app
 
-import "fmt"
+import (
+	"fmt"
+	"maps"
+	"time"
+)
 
 // ErrorType represents a generic category of error used as descriptor
 // to clarify the nature of a failure that occurred in dependencies.
+// Each type carries the default machine-readable code of its errors and
+// whether the failed request may succeed when retried.
 type ErrorType struct {
-	s string
+	s         string
+	code      string
+	retryable bool
 }
 
+// Code returns the default machine-readable code of errors of this type.
+func (t ErrorType) Code() string { return t.code }
+
+// Retryable reports whether requests failing with errors of this type may succeed when retried.
+func (t ErrorType) Retryable() bool { return t.retryable }
+
 var (
 	// ErrorTypeProviderFailure indicates a failure in a service dependency or provider.
-	ErrorTypeProviderFailure = ErrorType{"provider-failure"}
+	ErrorTypeProviderFailure = ErrorType{s: "provider-failure", code: InternalErrorCode, retryable: true}
 	// ErrorTypeAuthorization indicates an authentication or authorization failure.
-	ErrorTypeAuthorization = ErrorType{"authorization"}
+	ErrorTypeAuthorization = ErrorType{s: "authorization", code: UnauthorizedErrorCode, retryable: false}
 	// ErrorTypeAccessForbidden indicates that access to a resource is forbidden.
-	ErrorTypeAccessForbidden = ErrorType{"access-forbidden"}
+	ErrorTypeAccessForbidden = ErrorType{s: "access-forbidden", code: ForbiddenErrorCode, retryable: false}
 	// ErrorTypeIncorrectInput indicates that the provided input is invalid or malformed.
-	ErrorTypeIncorrectInput = ErrorType{"incorrect-input"}
+	ErrorTypeIncorrectInput = ErrorType{s: "incorrect-input", code: InvalidInputErrorCode, retryable: false}
 	// ErrorTypeUnknown indicates an unclassified or unexpected error.
-	ErrorTypeUnknown = ErrorType{"unknown"}
+	ErrorTypeUnknown = ErrorType{s: "unknown", code: UnknownErrorCode, retryable: true}
 	// ErrorTypeOperationTimeout indicates that an operation exceeded its time limit.
-	ErrorTypeOperationTimeout = ErrorType{"operation-timeout"}
+	ErrorTypeOperationTimeout = ErrorType{s: "operation-timeout", code: TimeoutErrorCode, retryable: true}
 	// ErrorTypeRawDataProcessing indicates an error during raw data processing.
-	ErrorTypeRawDataProcessing = ErrorType{"raw-data-processing"}
+	ErrorTypeRawDataProcessing = ErrorType{s: "raw-data-processing", code: InvalidRequestBodyErrorCode, retryable: false}
 	// ErrorTypeUnsupportedOperation indicates that the requested operation is not supported.
-	ErrorTypeUnsupportedOperation = ErrorType{"unsupported-operation"}
+	ErrorTypeUnsupportedOperation = ErrorType{s: "unsupported-operation", code: NotSupportedErrorCode, retryable: false}
+	// ErrorTypeServiceUnavailable indicates that the operation is temporarily unavailable and may be retried later.
+	ErrorTypeServiceUnavailable = ErrorType{s: "service-unavailable", code: UnavailableErrorCode, retryable: true}
+	// ErrorTypePayloadTooLarge indicates that the provided input exceeds a size limit configured by the operator.
+	ErrorTypePayloadTooLarge = ErrorType{s: "payload-too-large", code: PayloadTooLargeErrorCode, retryable: false}
+	// ErrorTypeUnprocessableContent indicates that the provided input is well-formed at the transport level
+	// but its content cannot be processed, e.g. a structurally invalid BEEF.
+	ErrorTypeUnprocessableContent = ErrorType{s: "unprocessable-content", code: UnprocessableContentErrorCode, retryable: false}
+	// ErrorTypePaymentRequired indicates that the requested operation must be paid for before it is served.
+	ErrorTypePaymentRequired = ErrorType{s: "payment-required", code: PaymentRequiredErrorCode, retryable: false}
+	// ErrorTypeTooManyRequests indicates that the operation is rejected because too many are already in progress,
+	// and may be retried later.
+	ErrorTypeTooManyRequests = ErrorType{s: "too-many-requests", code: TooManyRequestsErrorCode, retryable: true}
+	// ErrorTypeDeadlineExceeded indicates that the operation did not complete within the time the server allows it,
+	// and may be retried later.
+	ErrorTypeDeadlineExceeded = ErrorType{s: "deadline-exceeded", code: DeadlineExceededErrorCode, retryable: true}
 )
 
 // Error defines a generic application-layer error that should be translated
@@ -40,16 +69,50 @@ var (
 // Instead, it is highly recommended to use the slug string, which is intended
 // for the response, ensuring no sensitive data is leaked to the requester.
 type Error struct {
-	err       string
-	slug      string
-	errorType ErrorType
+	err        string
+	slug       string
+	code       string
+	errorType  ErrorType
+	retryAfter time.Duration
+	details    map[string]any
 }
 
 // Slug returns the error slug identifier.
 func (e Error) Slug() string { return e.slug }
 
+// Code returns the machine-readable error code, falling back to the default code of the error type.
+func (e Error) Code() string {
+	if e.code != "" {
+		return e.code
+	}
+	return e.errorType.code
+}
+
+// Retryable reports whether the failed request may succeed when retried.
+func (e Error) Retryable() bool { return e.errorType.retryable }
+
+// Details returns additional machine-readable information about the error, or nil if it has none.
+func (e Error) Details() map[string]any { return e.details }
+
+// WithCode returns a copy of the error with the given machine-readable code.
+func (e Error) WithCode(code string) Error {
+	e.code = code
+	return e
+}
+
+// WithDetails returns a copy of the error with the given details merged into its existing ones.
+func (e Error) WithDetails(details map[string]any) Error {
+	merged := make(map[string]any, len(e.details)+len(details))
+	maps.Copy(merged, e.details)
+	maps.Copy(merged, details)
+	e.details = merged
+	return e
+}
+
 // IsZero returns true if the error is the zero value.
-func (e Error) IsZero() bool { return e == Error{} }
+func (e Error) IsZero() bool {
+	return e.err == "" && e.slug == "" && e.code == "" && e.errorType == ErrorType{} && e.retryAfter == 0 && e.details == nil
+}
 
 // Error returns the error message string.
 func (e Error) Error() string { return e.err }
@@ -57,6 +120,9 @@ func (e Error) Error() string { return e.err }
 // ErrorType returns the type of error.
 func (e Error) ErrorType() ErrorType { return e.errorType }
 
+// RetryAfter returns how long the requester should wait before retrying, or zero if unknown.
+func (e Error) RetryAfter() time.Duration { return e.retryAfter }
+
 // NewUnsupportedOperationError creates an error for unsupported operations.
 func NewUnsupportedOperationError(err, slug string) Error {
 	return Error{
@@ -150,6 +216,18 @@ func NewIncorrectInputWithFieldError(field string) Error {
 	)
 }
 
+// NewServiceUnavailableError returns an error indicating that the operation is temporarily
+// unavailable, e.g. while the overlay engine runs in a degraded mode, and may be retried after
+// the given duration.
+func NewServiceUnavailableError(err, slug string, retryAfter time.Duration) Error {
+	return Error{
+		slug:       slug,
+		errorType:  ErrorTypeServiceUnavailable,
+		err:        err,
+		retryAfter: retryAfter,
+	}
+}
+
 // NewContextCancellationError returns an error indicating that the submitted request exceeded the context timeout limit or
 // that a context cancellation signal was emitted.
 func NewContextCancellationError() Error {
@@ -160,3 +238,59 @@ func NewContextCancellationError() Error {
 		slug:      msg,
 	}
 }
+
+// NewDeadlineExceededError returns an error indicating that the operation did not complete within
+// the time the server allows it. The code identifies the exceeded time limit for the requester.
+func NewDeadlineExceededError(err, slug, code string) Error {
+	return Error{
+		slug:      slug,
+		code:      code,
+		errorType: ErrorTypeDeadlineExceeded,
+		err:       err,
+	}
+}
+
+// NewPayloadTooLargeError returns an error indicating that the provided input exceeds a size
+// limit configured by the operator. The code identifies the exceeded limit for the requester.
+func NewPayloadTooLargeError(err, slug, code string) Error {
+	return Error{
+		slug:      slug,
+		code:      code,
+		errorType: ErrorTypePayloadTooLarge,
+		err:       err,
+	}
+}
+
+// NewUnprocessableContentError returns an error indicating that the provided input cannot be
+// processed because of its content, e.g. a structurally invalid BEEF. The code identifies the
+// failed check for the requester.
+func NewUnprocessableContentError(err, slug, code string) Error {
+	return Error{
+		slug:      slug,
+		code:      code,
+		errorType: ErrorTypeUnprocessableContent,
+		err:       err,
+	}
+}
+
+// NewPaymentRequiredError returns an error indicating that the requested operation must be paid for
+// before it is served. The code identifies the reason for the requester.
+func NewPaymentRequiredError(err, slug, code string) Error {
+	return Error{
+		slug:      slug,
+		code:      code,
+		errorType: ErrorTypePaymentRequired,
+		err:       err,
+	}
+}
+
+// NewTooManyRequestsError returns an error indicating that the operation is rejected because
+// too many are already in progress, and may be retried after the given duration.
+func NewTooManyRequestsError(err, slug string, retryAfter time.Duration) Error {
+	return Error{
+		slug:       slug,
+		errorType:  ErrorTypeTooManyRequests,
+		err:        err,
+		retryAfter: retryAfter,
+	}
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// GASPSyncStatusProvider defines the contract for reporting the GASP sync of every topic peer.
+type GASPSyncStatusProvider interface {
+	GASPSyncStatus(ctx context.Context) ([]*engine.GASPPeerSyncStatus, error)
+}
+
+// GASPSyncStatusService coordinates the reporting of the GASP sync progress.
+type GASPSyncStatusService struct {
+	provider GASPSyncStatusProvider
+}
+
+// GASPSyncStatus returns the sync status of every topic peer, sorted by topic and peer.
+// Returns an error if the provider fails to report the sync status (ErrorTypeProviderFailure).
+func (s *GASPSyncStatusService) GASPSyncStatus(ctx context.Context) ([]*engine.GASPPeerSyncStatus, error) {
+	statuses, err := s.provider.GASPSyncStatus(ctx)
+	if err != nil {
+		return nil, NewGASPSyncStatusProviderError(err)
+	}
+	return statuses, nil
+}
+
+// NewGASPSyncStatusService creates a new GASPSyncStatusService with the given provider.
+// Panics if the provider is nil.
+func NewGASPSyncStatusService(provider GASPSyncStatusProvider) *GASPSyncStatusService {
+	if provider == nil {
+		panic("GASP sync status provider cannot be nil")
+	}
+
+	return &GASPSyncStatusService{provider: provider}
+}
+
+// NewGASPSyncStatusProviderError returns an Error indicating that the configured provider
+// failed to report the GASP sync status.
+func NewGASPSyncStatusProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to report the GASP sync status due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,61 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errGASPSyncStatusTestError = errors.New("internal GASP sync status service test error")
+
+func TestGASPSyncStatusService_GASPSyncStatus(t *testing.T) {
+	statuses := []*engine.GASPPeerSyncStatus{
+		{Topic: testabilities.DefaultValidTopic, Peer: "https://peer.example", Running: true, LastInteraction: 10, PagesCompleted: 2, NodesIngested: 5},
+	}
+
+	tests := map[string]struct {
+		expectations     testabilities.GASPSyncStatusProviderMockExpectations
+		expectedStatuses []*engine.GASPPeerSyncStatus
+		expectedError    error
+	}{
+		"Returns the sync status of every topic peer": {
+			expectations: testabilities.GASPSyncStatusProviderMockExpectations{
+				GASPSyncStatusCall: true,
+				Statuses:           statuses,
+			},
+			expectedStatuses: statuses,
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.GASPSyncStatusProviderMockExpectations{
+				GASPSyncStatusCall: true,
+				Error:              errGASPSyncStatusTestError,
+			},
+			expectedError: app.NewGASPSyncStatusProviderError(errGASPSyncStatusTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewGASPSyncStatusProviderMock(t, tc.expectations)
+			service := app.NewGASPSyncStatusService(mock)
+
+			// when:
+			actual, err := service.GASPSyncStatus(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedStatuses, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
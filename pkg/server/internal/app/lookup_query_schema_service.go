@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// LookupQuerySchemaProvider defines the contract for retrieving the query schema
+// declared by a lookup service.
+type LookupQuerySchemaProvider interface {
+	GetLookupQuerySchema(ctx context.Context, service string) (json.RawMessage, error)
+}
+
+// LookupQuerySchemaService provides functionality for retrieving the query schemas of lookup services.
+type LookupQuerySchemaService struct {
+	provider LookupQuerySchemaProvider
+	access   AccessControlList
+}
+
+// GetLookupQuerySchema returns the JSON Schema the queries of the lookup service are validated against.
+// Returns an error if:
+// - The lookup service name is empty (ErrorTypeIncorrectInput)
+// - The API key set on ctx is not permitted to query the lookup service (ErrorTypeAccessForbidden)
+// - The lookup service is unknown or declares no query schema (ErrorTypeUnsupportedOperation)
+// - The provider fails to retrieve the schema (ErrorTypeProviderFailure)
+func (s *LookupQuerySchemaService) GetLookupQuerySchema(ctx context.Context, service string) (json.RawMessage, error) {
+	if service == "" {
+		return nil, NewEmptyLookupServiceNameError()
+	}
+	if err := s.access.AuthorizeService(ctx, service); err != nil {
+		return nil, err
+	}
+
+	schema, err := s.provider.GetLookupQuerySchema(ctx, service)
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return nil, NewUnknownLookupServiceError(service)
+	case errors.Is(err, engine.ErrLookupQuerySchemaNotDeclared):
+		return nil, NewLookupQuerySchemaNotDeclaredError(service)
+	case err != nil:
+		return nil, NewLookupQuerySchemaProviderError(err)
+	}
+	return schema, nil
+}
+
+// NewLookupQuerySchemaService creates a new LookupQuerySchemaService with the given provider and the access
+// control list restricting the lookup services each API key may query.
+// Panics if the provider is nil.
+func NewLookupQuerySchemaService(provider LookupQuerySchemaProvider, access AccessControlList) *LookupQuerySchemaService {
+	if provider == nil {
+		panic("lookup query schema provider cannot be nil")
+	}
+
+	return &LookupQuerySchemaService{provider: provider, access: access}
+}
+
+// NewUnknownLookupServiceError returns an Error indicating that the overlay does not host the lookup service.
+func NewUnknownLookupServiceError(service string) Error {
+	msg := fmt.Sprintf("The lookup service %q is not hosted by this overlay node.", service)
+	return NewUnsupportedOperationError(msg, msg).WithCode(UnknownLookupServiceErrorCode)
+}
+
+// NewLookupQuerySchemaNotDeclaredError returns an Error indicating that the lookup service
+// does not declare the schema of its queries.
+func NewLookupQuerySchemaNotDeclaredError(service string) Error {
+	msg := fmt.Sprintf("The lookup service %q does not declare a query schema.", service)
+	return NewUnsupportedOperationError(msg, msg)
+}
+
+// NewLookupQuerySchemaProviderError returns an Error indicating that the configured provider
+// failed to retrieve the query schema of the lookup service.
+func NewLookupQuerySchemaProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to retrieve the query schema of the lookup service due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,98 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupQuerySchemaService_GetLookupQuerySchema(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","required":["name"]}`)
+
+	tests := map[string]struct {
+		service        string
+		apiKey         string
+		expectations   testabilities.LookupQuerySchemaProviderMockExpectations
+		expectedSchema json.RawMessage
+		expectedError  error
+	}{
+		"Returns the query schema": {
+			service: "ls_test",
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Service:                  "ls_test",
+				Schema:                   schema,
+			},
+			expectedSchema: schema,
+		},
+		"Returns the query schema of a restricted service to a permitted API key": {
+			service: "ls_private",
+			apiKey:  "private-key",
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Service:                  "ls_private",
+				Schema:                   schema,
+			},
+			expectedSchema: schema,
+		},
+		"Fails when the API key is not permitted to query the service": {
+			service:       "ls_private",
+			apiKey:        "other-key",
+			expectedError: app.NewLookupServiceAccessDeniedError("ls_private"),
+		},
+		"Fails when the service name is empty": {
+			expectedError: app.NewEmptyLookupServiceNameError(),
+		},
+		"Fails when the service is unknown": {
+			service: "ls_test",
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Error:                    engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewUnknownLookupServiceError("ls_test"),
+		},
+		"Fails when the service declares no schema": {
+			service: "ls_test",
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Error:                    engine.ErrLookupQuerySchemaNotDeclared,
+			},
+			expectedError: app.NewLookupQuerySchemaNotDeclaredError("ls_test"),
+		},
+		"Fails when the provider fails": {
+			service: "ls_test",
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Error:                    testabilities.ErrTestNoopOpFailure,
+			},
+			expectedError: app.NewLookupQuerySchemaProviderError(testabilities.ErrTestNoopOpFailure),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewLookupQuerySchemaProviderMock(t, tc.expectations)
+			service := app.NewLookupQuerySchemaService(mock, app.AccessControlList{
+				ServiceKeys: map[string][]string{"ls_private": {"private-key"}},
+			})
+
+			// when:
+			actual, err := service.GetLookupQuerySchema(app.WithAPIKey(context.Background(), tc.apiKey), tc.service)
+
+			// then:
+			require.Equal(t, tc.expectedSchema, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
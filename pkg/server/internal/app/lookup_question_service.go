@@ -3,15 +3,23 @@ package app
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"iter"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 )
 
 // OutputListItemDTO represents an individual output item returned as part of a lookup answer.
 // Each output includes the raw binary output ('BEEF') and its index in the overall output sequence.
 type OutputListItemDTO struct {
-	BEEF        []byte // Binary Encoded External Format (BEEF) of the output data.
-	OutputIndex uint32 // Index indicating the position of this output in the result set.
+	BEEF           []byte            // Binary Encoded External Format (BEEF) of the output data.
+	OutputIndex    uint32            // Index indicating the position of this output in the result set.
+	Metadata       map[string]string // Tags set by the topic manager when admitting the output, if any.
+	Txid           string            // Transaction ID of the output; set for paged outputs of formulas hydrated by the engine.
+	RawTx          []byte            // Raw transaction of the output when hydrated with engine.LookupHydrationRawTx.
+	OffChainValues []byte            // Off-chain values submitted with the transaction of the output, if any.
 }
 
 // LookupAnswerDTO encapsulates the response of a successful lookup question evaluation.
@@ -22,12 +30,24 @@ type LookupAnswerDTO struct {
 	Type    string              // Describes the type/category of the answer (e.g., "exact", "partial").
 }
 
+// LookupAnswerPageDTO encapsulates a page of a lookup answer. Its outputs are produced as they are
+// iterated, so that large answers can be streamed without being held in memory at once.
+type LookupAnswerPageDTO struct {
+	Outputs    iter.Seq2[OutputListItemDTO, error] // Output items of the page, stopping at the first error.
+	Result     string                              // JSON-encoded string representing the result object of freeform answers.
+	Type       string                              // Describes the type/category of the answer.
+	NextCursor string                              // Cursor of the next page; empty after the last page.
+}
+
 // LookupQuestionProvider defines the interface for any provider capable of evaluating
 // lookup questions. Implementations encapsulate the business logic to process questions
 // and produce corresponding answers.
 type LookupQuestionProvider interface {
 	// Lookup evaluates the given question and returns a structured answer or an error.
 	Lookup(ctx context.Context, question *lookup.LookupQuestion) (*lookup.LookupAnswer, error)
+
+	// LookupPaged evaluates the given question and returns the selected page of its answer or an error.
+	LookupPaged(ctx context.Context, question *lookup.LookupQuestion, page engine.LookupPage) (*engine.LookupPageAnswer, error)
 }
 
 // LookupQuestionService provides a higher-level abstraction over a LookupQuestionProvider.
@@ -35,11 +55,13 @@ type LookupQuestionProvider interface {
 // invokes the provider, and transforms the result into a transport-friendly DTO.
 type LookupQuestionService struct {
 	provider LookupQuestionProvider
+	access   AccessControlList
 }
 
 // LookupQuestion handles the end-to-end processing of a lookup question request.
 // It validates inputs, delegates evaluation to the underlying provider,
 // and returns a structured answer suitable for use in the presentation layer.
+// Lookup services restricted by the access control list require the API key set on ctx to be permitted to query them.
 // Returns an error if the input is invalid, the evaluation fails, or the result cannot be processed.
 func (s *LookupQuestionService) LookupQuestion(ctx context.Context, service string, query map[string]any) (*LookupAnswerDTO, error) {
 	if len(service) == 0 {
@@ -48,36 +70,130 @@ func (s *LookupQuestionService) LookupQuestion(ctx context.Context, service stri
 	if len(query) == 0 {
 		return nil, NewIncorrectInputWithFieldError("query")
 	}
-	bb, err := json.Marshal(query)
+	question, err := NewLookupQuestion(service, query)
 	if err != nil {
-		return nil, NewLookupQuestionParserError(err)
+		return nil, err
+	}
+	if err := s.access.AuthorizeService(ctx, service); err != nil {
+		return nil, err
 	}
 
-	answer, err := s.provider.Lookup(ctx, &lookup.LookupQuestion{
-		Service: service,
-		Query:   json.RawMessage(bb),
-	})
+	answer, err := s.provider.Lookup(ctx, question)
 	if err != nil {
-		return nil, NewLookupQuestionProviderError(err)
+		return nil, NewLookupQuestionFailureError(service, err)
 	}
 
 	return NewLookupQuestionAnswerDTO(answer)
 }
 
-// NewLookupQuestionService constructs a LookupQuestionService with the given provider.
+// LookupQuestionPage handles the processing of a lookup question request selecting a page of the answer,
+// starting at the cursor returned with the previous page and holding at most limit outputs, or every
+// remaining output when limit is zero, with the access control of LookupQuestion. The outputs of formula answers are hydrated as selected by hydration.
+// Outputs failing to be produced while iterated yield a provider error.
+// Returns an error if the input is invalid, including a cursor the overlay did not return, or the evaluation fails.
+func (s *LookupQuestionService) LookupQuestionPage(ctx context.Context, service string, query map[string]any, limit uint32, cursor string, hydration engine.LookupHydration) (*LookupAnswerPageDTO, error) {
+	question, err := NewLookupQuestion(service, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.access.AuthorizeService(ctx, service); err != nil {
+		return nil, err
+	}
+	if err := hydration.Validate(); err != nil {
+		return nil, NewInvalidLookupHydrationError(err)
+	}
+
+	answer, err := s.provider.LookupPaged(engine.WithLookupHydration(ctx, hydration), question, engine.LookupPage{Limit: limit, Cursor: cursor})
+	if err != nil {
+		return nil, NewLookupQuestionFailureError(service, err)
+	}
+
+	var result string
+	if answer.Result != nil {
+		bb, err := json.Marshal(answer.Result)
+		if err != nil {
+			return nil, NewLookupQuestionParserError(err)
+		}
+		result = string(bb)
+	}
+
+	return &LookupAnswerPageDTO{
+		Outputs: func(yield func(OutputListItemDTO, error) bool) {
+			for output, err := range answer.Outputs {
+				if err != nil {
+					yield(OutputListItemDTO{}, NewLookupQuestionProviderError(err))
+					return
+				}
+				item := OutputListItemDTO{
+					BEEF:           output.Beef,
+					OutputIndex:    output.OutputIndex,
+					Metadata:       output.Metadata,
+					RawTx:          output.RawTx,
+					OffChainValues: output.OffChainValues,
+				}
+				if output.Txid != (chainhash.Hash{}) {
+					item.Txid = output.Txid.String()
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+		},
+		Result:     result,
+		Type:       string(answer.Type),
+		NextCursor: answer.NextCursor,
+	}, nil
+}
+
+// NewLookupQuestion validates the service and query of a lookup question request and converts them
+// into a lookup question. Returns an error if the input is invalid or the query cannot be serialized.
+func NewLookupQuestion(service string, query map[string]any) (*lookup.LookupQuestion, error) {
+	if len(service) == 0 {
+		return nil, NewIncorrectInputWithFieldError("service")
+	}
+	if len(query) == 0 {
+		return nil, NewIncorrectInputWithFieldError("query")
+	}
+	bb, err := json.Marshal(query)
+	if err != nil {
+		return nil, NewLookupQuestionParserError(err)
+	}
+	return &lookup.LookupQuestion{Service: service, Query: json.RawMessage(bb)}, nil
+}
+
+// NewLookupQuestionFailureError maps an error evaluating a lookup question of the service
+// into the corresponding application error.
+func NewLookupQuestionFailureError(service string, err error) Error {
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return NewUnknownLookupServiceError(service)
+	case errors.Is(err, engine.ErrInvalidLookupQuery):
+		return NewInvalidLookupQueryError(err)
+	case errors.Is(err, engine.ErrInvalidLookupCursor):
+		return NewInvalidLookupCursorError(err)
+	default:
+		return NewLookupQuestionProviderError(err)
+	}
+}
+
+// NewLookupQuestionService constructs a LookupQuestionService with the given provider and the access
+// control list restricting the lookup services each API key may query.
 // Panics if the provider is nil, as service functionality depends on a valid provider.
-func NewLookupQuestionService(provider LookupQuestionProvider) *LookupQuestionService {
+func NewLookupQuestionService(provider LookupQuestionProvider, access AccessControlList) *LookupQuestionService {
 	if provider == nil {
 		panic("lookup question provider is nil")
 	}
-	return &LookupQuestionService{provider: provider}
+	return &LookupQuestionService{provider: provider, access: access}
 }
 
 // NewLookupQuestionAnswerDTO converts a core LookupAnswer model into a LookupAnswerDTO,
 // a transport-layer structure suitable for API responses. It serializes the Result object
-// to a JSON string and transforms output entries into DTO-compatible types.
+// to a JSON string and transforms output entries into DTO-compatible types. The engine.LookupOutputMetadata
+// and engine.LookupOutputDetails results of answers hydrated by the engine are attached to the outputs instead.
 // Returns an error if serialization fails.
 func NewLookupQuestionAnswerDTO(answer *lookup.LookupAnswer) (*LookupAnswerDTO, error) {
+	details, detailed := engine.LookupAnswerDetails(answer)
+
 	var outputs []OutputListItemDTO
 	if len(answer.Outputs) > 0 {
 		outputs = make([]OutputListItemDTO, len(answer.Outputs))
@@ -86,11 +202,15 @@ func NewLookupQuestionAnswerDTO(answer *lookup.LookupAnswer) (*LookupAnswerDTO,
 				BEEF:        output.Beef,
 				OutputIndex: output.OutputIndex,
 			}
+			if detailed {
+				outputs[i].Metadata = details.Metadata[i]
+				outputs[i].OffChainValues = details.OffChainValues[i]
+			}
 		}
 	}
 
 	var result string
-	if answer.Result != nil {
+	if answer.Result != nil && !detailed {
 		bb, err := json.Marshal(answer.Result)
 		if err != nil {
 			return nil, NewLookupQuestionParserError(err)
@@ -115,6 +235,33 @@ func NewLookupQuestionParserError(err error) Error {
 	)
 }
 
+// NewInvalidLookupQueryError returns an Error indicating that the query does not match
+// the query schema declared by the lookup service.
+func NewInvalidLookupQueryError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The query does not match the query schema of the lookup service. Please verify the query against the schema published at /api/v1/lookup/{service}/schema.",
+	).WithCode(InvalidLookupQueryErrorCode)
+}
+
+// NewInvalidLookupCursorError returns an Error indicating that the cursor of a paged lookup
+// was not returned by the overlay for the question.
+func NewInvalidLookupCursorError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The cursor is not valid for this lookup question. Please pass the nextCursor returned with the previous page, or omit it to start over.",
+	).WithCode(InvalidLookupCursorErrorCode)
+}
+
+// NewInvalidLookupHydrationError returns an Error indicating that the lookup requests a hydration
+// the overlay does not support.
+func NewInvalidLookupHydrationError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The hydration is not supported. Please use one of none, txid, rawtx or full.",
+	).WithCode(InvalidLookupHydrationErrorCode)
+}
+
 // NewLookupQuestionProviderError wraps an internal error that occurred during provider evaluation.
 // Produces a standardized user-facing error message while retaining the original error internally
 // for logging or diagnostics.
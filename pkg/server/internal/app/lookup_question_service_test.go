@@ -1,10 +1,13 @@
 package app_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 	"github.com/stretchr/testify/require"
 )
@@ -15,7 +18,7 @@ func TestLookupQuestionService_ValidCase(t *testing.T) {
 		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: map[string]any{"test": "value"}},
 		LookupQuestionCall: true,
 	})
-	service := app.NewLookupQuestionService(mock)
+	service := app.NewLookupQuestionService(mock, app.AccessControlList{})
 	expectedDTO := &app.LookupAnswerDTO{
 		Result: "{\"test\":\"value\"}",
 		Type:   string(lookup.AnswerTypeFreeform),
@@ -31,6 +34,72 @@ func TestLookupQuestionService_ValidCase(t *testing.T) {
 	mock.AssertCalled()
 }
 
+func TestLookupQuestionService_ShouldAttachOutputMetadata(t *testing.T) {
+	// given:
+	metadata := map[string]string{"ticker": "TEST"}
+	mock := testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		Answer: &lookup.LookupAnswer{
+			Type:    lookup.AnswerTypeOutputList,
+			Outputs: []*lookup.OutputListItem{{Beef: []byte("tagged"), OutputIndex: 0}, {Beef: []byte("untagged"), OutputIndex: 1}},
+			Result:  engine.LookupOutputMetadata{metadata, nil},
+		},
+		LookupQuestionCall: true,
+	})
+	service := app.NewLookupQuestionService(mock, app.AccessControlList{})
+	expectedDTO := &app.LookupAnswerDTO{
+		Outputs: []app.OutputListItemDTO{
+			{BEEF: []byte("tagged"), OutputIndex: 0, Metadata: metadata},
+			{BEEF: []byte("untagged"), OutputIndex: 1},
+		},
+		Type: string(lookup.AnswerTypeOutputList),
+	}
+
+	// when:
+	actualDTO, err := service.LookupQuestion(t.Context(), "service1", map[string]any{"key": "value"})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, expectedDTO, actualDTO)
+
+	mock.AssertCalled()
+}
+
+func TestLookupQuestionService_ShouldAttachOutputOffChainValues(t *testing.T) {
+	// given:
+	metadata := map[string]string{"ticker": "TEST"}
+	offChainValues := []byte("encrypted payload")
+	mock := testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		Answer: &lookup.LookupAnswer{
+			Type:    lookup.AnswerTypeOutputList,
+			Outputs: []*lookup.OutputListItem{{Beef: []byte("valued"), OutputIndex: 0}, {Beef: []byte("tagged"), OutputIndex: 1}},
+			Result: engine.LookupOutputDetails{
+				Metadata:       engine.LookupOutputMetadata{nil, metadata},
+				OffChainValues: [][]byte{offChainValues, nil},
+			},
+		},
+		LookupQuestionCall: true,
+	})
+	service := app.NewLookupQuestionService(mock, app.AccessControlList{})
+	expectedDTO := &app.LookupAnswerDTO{
+		Outputs: []app.OutputListItemDTO{
+			{BEEF: []byte("valued"), OutputIndex: 0, OffChainValues: offChainValues},
+			{BEEF: []byte("tagged"), OutputIndex: 1, Metadata: metadata},
+		},
+		Type: string(lookup.AnswerTypeOutputList),
+	}
+
+	// when:
+	actualDTO, err := service.LookupQuestion(t.Context(), "service1", map[string]any{"key": "value"})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, expectedDTO, actualDTO)
+
+	mock.AssertCalled()
+}
+
+var errInvalidLookupQuery = fmt.Errorf("%w: property \"name\" is missing", engine.ErrInvalidLookupQuery)
+
 func TestLookupQuestionService_InvalidCases(t *testing.T) {
 	tests := map[string]struct {
 		expectations  testabilities.LookupQuestionProviderMockExpectations
@@ -72,13 +141,24 @@ func TestLookupQuestionService_InvalidCases(t *testing.T) {
 			},
 			expectedError: app.NewLookupQuestionProviderError(testabilities.ErrTestNoopOpFailure),
 		},
+		"LookupQuestion should return error when the query does not match the schema": {
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupQuestionCall: true,
+				Error:              errInvalidLookupQuery,
+			},
+			service: "test-service",
+			query: map[string]any{
+				"query1": "value1",
+			},
+			expectedError: app.NewInvalidLookupQueryError(errInvalidLookupQuery),
+		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// given:
 			mock := testabilities.NewLookupQuestionProviderMock(t, tc.expectations)
-			service := app.NewLookupQuestionService(mock)
+			service := app.NewLookupQuestionService(mock, app.AccessControlList{})
 
 			// when:
 			actualDTO, err := service.LookupQuestion(t.Context(), tc.service, tc.query)
@@ -93,3 +173,99 @@ func TestLookupQuestionService_InvalidCases(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupQuestionService_LookupQuestionPage_ShouldReturnPageOfOutputs(t *testing.T) {
+	// given:
+	metadata := map[string]string{"ticker": "TEST"}
+	mock := testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupPagedCall: true,
+		Outputs: []*engine.LookupOutput{
+			{Beef: []byte("tagged"), OutputIndex: 0, Metadata: metadata},
+			{Beef: []byte("untagged"), OutputIndex: 1},
+			{RawTx: []byte("rawtx"), Txid: chainhash.Hash{1}, OutputIndex: 2},
+		},
+		NextCursor: "next",
+		Page:       engine.LookupPage{Limit: 2, Cursor: "cursor"},
+	})
+	service := app.NewLookupQuestionService(mock, app.AccessControlList{})
+
+	// when:
+	page, err := service.LookupQuestionPage(t.Context(), "service1", map[string]any{"key": "value"}, 2, "cursor", engine.LookupHydration{Mode: engine.LookupHydrationRawTx})
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, string(lookup.AnswerTypeOutputList), page.Type)
+	require.Equal(t, "next", page.NextCursor)
+	var outputs []app.OutputListItemDTO
+	for output, err := range page.Outputs {
+		require.NoError(t, err)
+		outputs = append(outputs, output)
+	}
+	require.Equal(t, []app.OutputListItemDTO{
+		{BEEF: []byte("tagged"), OutputIndex: 0, Metadata: metadata},
+		{BEEF: []byte("untagged"), OutputIndex: 1},
+		{RawTx: []byte("rawtx"), Txid: chainhash.Hash{1}.String(), OutputIndex: 2},
+	}, outputs)
+
+	mock.AssertCalled()
+}
+
+func TestLookupQuestionService_LookupQuestionPage_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		service       string
+		query         map[string]any
+		hydration     engine.LookupHydration
+		expectations  testabilities.LookupQuestionProviderMockExpectations
+		expectedError app.Error
+	}{
+		"missing service": {
+			query:         map[string]any{"key": "value"},
+			expectedError: app.NewIncorrectInputWithFieldError("service"),
+		},
+		"unknown hydration": {
+			service:       "service1",
+			query:         map[string]any{"key": "value"},
+			hydration:     engine.LookupHydration{Mode: "beefy"},
+			expectedError: app.NewInvalidLookupHydrationError(engine.LookupHydration{Mode: "beefy"}.Validate()),
+		},
+		"invalid cursor": {
+			service: "service1",
+			query:   map[string]any{"key": "value"},
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupPagedCall: true,
+				Error:           engine.ErrInvalidLookupCursor,
+				Page:            engine.LookupPage{Cursor: "cursor"},
+			},
+			expectedError: app.NewInvalidLookupCursorError(engine.ErrInvalidLookupCursor),
+		},
+		"unknown lookup service": {
+			service: "service1",
+			query:   map[string]any{"key": "value"},
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupPagedCall: true,
+				Error:           engine.ErrUnknownTopic,
+				Page:            engine.LookupPage{Cursor: "cursor"},
+			},
+			expectedError: app.NewUnknownLookupServiceError("service1"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewLookupQuestionProviderMock(t, tc.expectations)
+			service := app.NewLookupQuestionService(mock, app.AccessControlList{})
+
+			// when:
+			page, err := service.LookupQuestionPage(t.Context(), tc.service, tc.query, 0, "cursor", tc.hydration)
+
+			// then:
+			var actualErr app.Error
+			require.ErrorAs(t, err, &actualErr)
+			require.Equal(t, tc.expectedError, actualErr)
+			require.Nil(t, page)
+
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// LookupReplayProvider defines the contract for backfilling a lookup service with the stored history of a topic.
+type LookupReplayProvider interface {
+	ReplayTopicIntoLookupService(ctx context.Context, topic, service string, fromScore float64) (*engine.LookupReplayProgress, error)
+}
+
+// LookupReplayService coordinates the backfill of lookup services registered after their topics were populated.
+type LookupReplayService struct {
+	provider LookupReplayProvider
+}
+
+// ReplayTopicIntoLookupService replays the outputs stored for the topic, scored at or after fromScore, into the
+// named lookup service and returns how far the replay went.
+// Returns an error if:
+// - The topic or the lookup service name is empty, fromScore is negative or the topic is unknown (ErrorTypeIncorrectInput)
+// - The lookup service is not registered (ErrorTypeUnsupportedOperation)
+// - The provider fails midway (ErrorTypeProviderFailure), with the score to resume from in the details
+func (s *LookupReplayService) ReplayTopicIntoLookupService(ctx context.Context, topic, service string, fromScore float64) (*engine.LookupReplayProgress, error) {
+	switch {
+	case topic == "":
+		return nil, NewIncorrectInputWithFieldError("topic")
+	case service == "":
+		return nil, NewEmptyLookupServiceNameError()
+	case fromScore < 0:
+		return nil, NewIncorrectInputWithFieldError("fromScore")
+	}
+
+	progress, err := s.provider.ReplayTopicIntoLookupService(ctx, topic, service, fromScore)
+	if err != nil {
+		return nil, newLookupReplayError(err, topic, service, progress)
+	}
+	return progress, nil
+}
+
+// NewLookupReplayService creates a new LookupReplayService with the given provider.
+// Panics if the provider is nil.
+func NewLookupReplayService(provider LookupReplayProvider) *LookupReplayService {
+	if provider == nil {
+		panic("lookup replay provider cannot be nil")
+	}
+
+	return &LookupReplayService{provider: provider}
+}
+
+func newLookupReplayError(err error, topic, service string, progress *engine.LookupReplayProgress) Error {
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return NewLookupReplayUnknownTopicError(topic)
+	case errors.Is(err, engine.ErrLookupServiceNotRegistered):
+		return NewLookupServiceNotRegisteredError(service)
+	default:
+		return NewLookupReplayProviderError(err, progress)
+	}
+}
+
+// NewLookupReplayUnknownTopicError returns an Error indicating that the topic to replay
+// is not hosted by the overlay node.
+func NewLookupReplayUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewLookupReplayProviderError returns an Error indicating that the configured provider failed to replay the topic
+// into the lookup service. The progress reached, when known, is returned in the details so that the replay can be
+// resumed from its score.
+func NewLookupReplayProviderError(err error, progress *engine.LookupReplayProgress) Error {
+	replayErr := NewProviderFailureError(
+		err.Error(),
+		"Unable to replay the topic into the lookup service due to an internal error. Please try again later or contact the support team.",
+	)
+	if progress == nil {
+		return replayErr
+	}
+	return replayErr.WithDetails(map[string]any{
+		"admitted": progress.Admitted,
+		"spent":    progress.Spent,
+		"score":    progress.Score,
+	})
+}
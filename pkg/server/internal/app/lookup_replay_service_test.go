@@ -0,0 +1,105 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errLookupReplayTestError = errors.New("internal lookup replay service test error")
+
+func TestLookupReplayService_ReplayTopicIntoLookupService(t *testing.T) {
+	const service = "ls_test"
+	progress := &engine.LookupReplayProgress{Topic: testabilities.DefaultValidTopic, Service: service, Admitted: 2, Spent: 1, Score: 7}
+
+	tests := map[string]struct {
+		topic            string
+		service          string
+		fromScore        float64
+		expectations     testabilities.LookupReplayProviderMockExpectations
+		expectedProgress *engine.LookupReplayProgress
+		expectedError    error
+	}{
+		"Replays the topic into the lookup service": {
+			topic:     testabilities.DefaultValidTopic,
+			service:   service,
+			fromScore: 5,
+			expectations: testabilities.LookupReplayProviderMockExpectations{
+				ReplayTopicIntoLookupServiceCall: true,
+				Topic:                            testabilities.DefaultValidTopic,
+				Service:                          service,
+				FromScore:                        5,
+				Progress:                         progress,
+			},
+			expectedProgress: progress,
+		},
+		"Fails when the topic is empty": {
+			service:       service,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the lookup service name is empty": {
+			topic:         testabilities.DefaultValidTopic,
+			expectedError: app.NewEmptyLookupServiceNameError(),
+		},
+		"Fails when the score is negative": {
+			topic:         testabilities.DefaultValidTopic,
+			service:       service,
+			fromScore:     -1,
+			expectedError: app.NewIncorrectInputWithFieldError("fromScore"),
+		},
+		"Fails when the topic is unknown": {
+			topic:   testabilities.DefaultValidTopic,
+			service: service,
+			expectations: testabilities.LookupReplayProviderMockExpectations{
+				ReplayTopicIntoLookupServiceCall: true,
+				Error:                            engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewLookupReplayUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when the lookup service is not registered": {
+			topic:   testabilities.DefaultValidTopic,
+			service: service,
+			expectations: testabilities.LookupReplayProviderMockExpectations{
+				ReplayTopicIntoLookupServiceCall: true,
+				Error:                            engine.ErrLookupServiceNotRegistered,
+			},
+			expectedError: app.NewLookupServiceNotRegisteredError(service),
+		},
+		"Fails with the progress reached when the provider fails": {
+			topic:   testabilities.DefaultValidTopic,
+			service: service,
+			expectations: testabilities.LookupReplayProviderMockExpectations{
+				ReplayTopicIntoLookupServiceCall: true,
+				Progress:                         progress,
+				Error:                            errLookupReplayTestError,
+			},
+			expectedError: app.NewLookupReplayProviderError(errLookupReplayTestError, progress),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewLookupReplayProviderMock(t, tc.expectations)
+			service := app.NewLookupReplayService(mock)
+
+			// when:
+			actual, err := service.ReplayTopicIntoLookupService(context.Background(), tc.topic, tc.service, tc.fromScore)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+				require.Nil(t, actual)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedProgress, actual)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
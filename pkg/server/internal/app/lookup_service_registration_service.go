@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// LookupServiceRegistrationProvider defines the contract for adding and removing
+// lookup services of a running overlay engine.
+type LookupServiceRegistrationProvider interface {
+	AddLookupService(ctx context.Context, name string) error
+	UnregisterLookupService(ctx context.Context, name string) error
+}
+
+// LookupServiceRegistrationService coordinates the runtime registration of lookup services.
+type LookupServiceRegistrationService struct {
+	provider LookupServiceRegistrationProvider
+}
+
+// RegisterLookupService registers the named lookup service.
+// Returns an error if:
+// - The lookup service name is empty or already registered (ErrorTypeIncorrectInput)
+// - The engine cannot build lookup services by name (ErrorTypeUnsupportedOperation)
+// - The provider fails to register the lookup service (ErrorTypeProviderFailure)
+func (s *LookupServiceRegistrationService) RegisterLookupService(ctx context.Context, name string) error {
+	if name == "" {
+		return NewEmptyLookupServiceNameError()
+	}
+
+	if err := s.provider.AddLookupService(ctx, name); err != nil {
+		return newLookupServiceRegistrationError(err, name)
+	}
+	return nil
+}
+
+// UnregisterLookupService removes the named lookup service.
+// Returns an error if:
+// - The lookup service name is empty (ErrorTypeIncorrectInput)
+// - The lookup service is not registered (ErrorTypeUnsupportedOperation)
+// - The provider fails to unregister the lookup service (ErrorTypeProviderFailure)
+func (s *LookupServiceRegistrationService) UnregisterLookupService(ctx context.Context, name string) error {
+	if name == "" {
+		return NewEmptyLookupServiceNameError()
+	}
+
+	if err := s.provider.UnregisterLookupService(ctx, name); err != nil {
+		return newLookupServiceRegistrationError(err, name)
+	}
+	return nil
+}
+
+// NewLookupServiceRegistrationService creates a new LookupServiceRegistrationService with the given provider.
+// Panics if the provider is nil.
+func NewLookupServiceRegistrationService(provider LookupServiceRegistrationProvider) *LookupServiceRegistrationService {
+	if provider == nil {
+		panic("lookup service registration provider cannot be nil")
+	}
+
+	return &LookupServiceRegistrationService{provider: provider}
+}
+
+func newLookupServiceRegistrationError(err error, name string) Error {
+	switch {
+	case errors.Is(err, engine.ErrLookupServiceAlreadyRegistered):
+		return NewLookupServiceAlreadyRegisteredError(name)
+	case errors.Is(err, engine.ErrLookupServiceNotRegistered):
+		return NewLookupServiceNotRegisteredError(name)
+	case errors.Is(err, engine.ErrLookupServiceFactoryNotConfigured):
+		return NewLookupServiceFactoryNotConfiguredError()
+	default:
+		return NewLookupServiceRegistrationProviderError(err)
+	}
+}
+
+// NewLookupServiceAlreadyRegisteredError returns an Error indicating that a lookup service
+// with the given name is already registered.
+func NewLookupServiceAlreadyRegisteredError(name string) Error {
+	msg := fmt.Sprintf("The lookup service %q is already registered.", name)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewLookupServiceNotRegisteredError returns an Error indicating that no lookup service
+// with the given name is registered.
+func NewLookupServiceNotRegisteredError(name string) Error {
+	msg := fmt.Sprintf("The lookup service %q is not registered.", name)
+	return NewUnsupportedOperationError(msg, msg).WithCode(UnknownLookupServiceErrorCode)
+}
+
+// NewLookupServiceFactoryNotConfiguredError returns an Error indicating that the engine
+// cannot build lookup services by name.
+func NewLookupServiceFactoryNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		"lookup service factory not configured",
+		"Registering lookup services at runtime is not supported by this overlay node.",
+	)
+}
+
+// NewLookupServiceRegistrationProviderError returns an Error indicating that the configured provider
+// failed to update the lookup service registry.
+func NewLookupServiceRegistrationProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to update the lookup services due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,114 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errLookupServiceRegistrationTestError = errors.New("internal lookup service registration service test error")
+
+func TestLookupServiceRegistrationService_RegisterLookupService(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		expectations  testabilities.LookupServiceRegistrationProviderMockExpectations
+		expectedError error
+	}{
+		"Registers the lookup service": {
+			name: "ls_new",
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				AddLookupServiceCall: true,
+				Name:                 "ls_new",
+			},
+		},
+		"Fails when the lookup service name is empty": {
+			expectedError: app.NewEmptyLookupServiceNameError(),
+		},
+		"Fails when the lookup service is already registered": {
+			name: "ls_new",
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				AddLookupServiceCall: true,
+				Error:                engine.ErrLookupServiceAlreadyRegistered,
+			},
+			expectedError: app.NewLookupServiceAlreadyRegisteredError("ls_new"),
+		},
+		"Fails when the provider fails": {
+			name: "ls_new",
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				AddLookupServiceCall: true,
+				Error:                errLookupServiceRegistrationTestError,
+			},
+			expectedError: app.NewLookupServiceRegistrationProviderError(errLookupServiceRegistrationTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewLookupServiceRegistrationProviderMock(t, tc.expectations)
+			service := app.NewLookupServiceRegistrationService(mock)
+
+			// when:
+			err := service.RegisterLookupService(context.Background(), tc.name)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestLookupServiceRegistrationService_UnregisterLookupService(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		expectations  testabilities.LookupServiceRegistrationProviderMockExpectations
+		expectedError error
+	}{
+		"Unregisters the lookup service": {
+			name: "ls_old",
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				UnregisterLookupServiceCall: true,
+				Name:                        "ls_old",
+			},
+		},
+		"Fails when the lookup service name is empty": {
+			expectedError: app.NewEmptyLookupServiceNameError(),
+		},
+		"Fails when the lookup service is not registered": {
+			name: "ls_old",
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				UnregisterLookupServiceCall: true,
+				Error:                       engine.ErrLookupServiceNotRegistered,
+			},
+			expectedError: app.NewLookupServiceNotRegisteredError("ls_old"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewLookupServiceRegistrationProviderMock(t, tc.expectations)
+			service := app.NewLookupServiceRegistrationService(mock)
+
+			// when:
+			err := service.UnregisterLookupService(context.Background(), tc.name)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// OutputGraphProvider defines the contract for walking the dependency graph of an output in a topic.
+type OutputGraphProvider interface {
+	OutputGraph(ctx context.Context, outpoint *transaction.Outpoint, topic string, depth uint32) (*engine.OutputGraph, error)
+}
+
+// OutputGraphService coordinates the export of output dependency graphs, validating the
+// requested output before delegating to the provider.
+type OutputGraphService struct {
+	provider OutputGraphProvider
+	access   AccessControlList
+}
+
+// OutputGraph returns the dependency graph of the output at txID.vout in the topic, following at most
+// depth relations from it, 0 meaning engine.DefaultOutputGraphDepth.
+// Returns an error if:
+// - The transaction ID, topic or depth is invalid or the topic is unknown (ErrorTypeIncorrectInput)
+// - The output is not stored in the topic (ErrorTypeUnsupportedOperation)
+// - The API key set on ctx is not permitted to access the topic (ErrorTypeAccessForbidden)
+// - The provider fails to walk the graph (ErrorTypeProviderFailure)
+func (s *OutputGraphService) OutputGraph(ctx context.Context, txID string, vout uint32, topic string, depth uint32) (*engine.OutputGraph, error) {
+	hash, err := chainhash.NewHashFromHex(txID)
+	if err != nil {
+		return nil, NewInvalidTxIDFormatError(err)
+	}
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.access.AuthorizeTopics(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	outpoint := &transaction.Outpoint{Txid: *hash, Index: vout}
+	graph, err := s.provider.OutputGraph(ctx, outpoint, topic, depth)
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return nil, NewOutputStatusUnknownTopicError(topic)
+	case errors.Is(err, engine.ErrInvalidOutputGraphDepth):
+		return nil, NewInvalidOutputGraphDepthError(depth)
+	case errors.Is(err, engine.ErrMissingOutput):
+		return nil, NewOutputGraphNotFoundError(outpoint.String())
+	case err != nil:
+		return nil, NewOutputGraphProviderError(err)
+	}
+	return graph, nil
+}
+
+// NewOutputGraphService creates a new OutputGraphService with the given provider and the access control list
+// restricting the topics each API key may access.
+// Panics if the provider is nil.
+func NewOutputGraphService(provider OutputGraphProvider, access AccessControlList) *OutputGraphService {
+	if provider == nil {
+		panic("output graph provider cannot be nil")
+	}
+
+	return &OutputGraphService{provider: provider, access: access}
+}
+
+// NewInvalidOutputGraphDepthError returns an Error indicating that the requested depth exceeds engine.MaxOutputGraphDepth.
+func NewInvalidOutputGraphDepthError(depth uint32) Error {
+	return NewIncorrectInputError(
+		fmt.Sprintf("output graph depth %d exceeds %d", depth, engine.MaxOutputGraphDepth),
+		fmt.Sprintf("The depth of the output graph must be at most %d.", engine.MaxOutputGraphDepth),
+	)
+}
+
+// NewOutputGraphNotFoundError returns an Error indicating that the root output of the graph
+// is not stored in the given topic.
+func NewOutputGraphNotFoundError(outpoint string) Error {
+	msg := fmt.Sprintf("The output %q was not found in the given topic.", outpoint)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewOutputGraphProviderError returns an Error indicating that the configured provider
+// failed to walk the dependency graph of the output.
+func NewOutputGraphProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to retrieve the dependency graph of the output due to an internal error. Please try again later or contact the support team.",
+	)
+}
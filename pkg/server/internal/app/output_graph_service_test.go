@@ -0,0 +1,117 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errOutputGraphTestError = errors.New("internal output graph service test error")
+
+func TestOutputGraphService_OutputGraph(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *txid, Index: 2}
+	graph := &engine.OutputGraph{
+		Root:  *outpoint,
+		Topic: testabilities.DefaultValidTopic,
+		Depth: 3,
+		Nodes: []*engine.OutputGraphNode{{Outpoint: *outpoint}},
+	}
+
+	tests := map[string]struct {
+		txID          string
+		topic         string
+		depth         uint32
+		expectations  testabilities.OutputGraphProviderMockExpectations
+		expectedGraph *engine.OutputGraph
+		expectedError error
+	}{
+		"Returns the graph of the output": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			depth: 3,
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Outpoint:        outpoint,
+				Depth:           3,
+				Graph:           graph,
+			},
+			expectedGraph: graph,
+		},
+		"Fails when the transaction ID is invalid": {
+			txID:          testabilities.DefaultValidTxID + "0",
+			topic:         testabilities.DefaultValidTopic,
+			expectedError: app.NewInvalidTxIDFormatError(chainhash.ErrHashStrSize),
+		},
+		"Fails when the topic is empty": {
+			txID:          testabilities.DefaultValidTxID,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the topic is unknown": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Error:           engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewOutputStatusUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when the depth is too large": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			depth: engine.MaxOutputGraphDepth + 1,
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Depth:           engine.MaxOutputGraphDepth + 1,
+				Error:           engine.ErrInvalidOutputGraphDepth,
+			},
+			expectedError: app.NewInvalidOutputGraphDepthError(engine.MaxOutputGraphDepth + 1),
+		},
+		"Fails when the output is not stored": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Error:           engine.ErrMissingOutput,
+			},
+			expectedError: app.NewOutputGraphNotFoundError(outpoint.String()),
+		},
+		"Fails when the provider fails": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Error:           errOutputGraphTestError,
+			},
+			expectedError: app.NewOutputGraphProviderError(errOutputGraphTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewOutputGraphProviderMock(t, tc.expectations)
+			service := app.NewOutputGraphService(mock, app.AccessControlList{})
+
+			// when:
+			actual, err := service.OutputGraph(context.Background(), tc.txID, 2, tc.topic, tc.depth)
+
+			// then:
+			require.Equal(t, tc.expectedGraph, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// OutputPinningProvider defines the contract for pinning outputs against pruning and eviction.
+type OutputPinningProvider interface {
+	PinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
+	UnpinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error
+}
+
+// OutputPinningService coordinates pinning and unpinning of admitted outputs.
+type OutputPinningService struct {
+	provider OutputPinningProvider
+}
+
+// PinOutput marks the output admitted into the topic as never prunable or evictable.
+// Returns an error if:
+// - The topic is empty (ErrorTypeIncorrectInput)
+// - The outpoint cannot be parsed (ErrorTypeRawDataProcessing)
+// - The output is not found or the storage cannot pin outputs (ErrorTypeUnsupportedOperation)
+// - The provider fails to pin the output (ErrorTypeProviderFailure)
+func (s *OutputPinningService) PinOutput(ctx context.Context, topic, outpoint string) error {
+	parsed, err := parseOutputPin(topic, outpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := s.provider.PinOutput(ctx, parsed, topic); err != nil {
+		return newOutputPinningError(err, outpoint)
+	}
+	return nil
+}
+
+// UnpinOutput makes the output admitted into the topic subject to the retention policy again.
+// It returns the same errors as PinOutput.
+func (s *OutputPinningService) UnpinOutput(ctx context.Context, topic, outpoint string) error {
+	parsed, err := parseOutputPin(topic, outpoint)
+	if err != nil {
+		return err
+	}
+
+	if err := s.provider.UnpinOutput(ctx, parsed, topic); err != nil {
+		return newOutputPinningError(err, outpoint)
+	}
+	return nil
+}
+
+// NewOutputPinningService creates a new OutputPinningService with the given provider.
+// Panics if the provider is nil.
+func NewOutputPinningService(provider OutputPinningProvider) *OutputPinningService {
+	if provider == nil {
+		panic("output pinning provider cannot be nil")
+	}
+
+	return &OutputPinningService{provider: provider}
+}
+
+func parseOutputPin(topic, outpoint string) (*transaction.Outpoint, error) {
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+
+	parsed, err := transaction.OutpointFromString(outpoint)
+	if err != nil {
+		return nil, NewRawDataProcessingWithFieldError(err, "Outpoint")
+	}
+	return parsed, nil
+}
+
+func newOutputPinningError(err error, outpoint string) Error {
+	switch {
+	case errors.Is(err, engine.ErrMissingOutput):
+		return NewPinnedOutputNotFoundError(outpoint)
+	case errors.Is(err, engine.ErrOutputPinningNotSupported):
+		return NewOutputPinningNotSupportedError()
+	default:
+		return NewOutputPinningProviderError(err)
+	}
+}
+
+// NewPinnedOutputNotFoundError returns an Error indicating that the output to pin or unpin
+// was not admitted into the given topic.
+func NewPinnedOutputNotFoundError(outpoint string) Error {
+	msg := fmt.Sprintf("The output %q was not found in the given topic.", outpoint)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewOutputPinningNotSupportedError returns an Error indicating that the overlay storage
+// cannot pin outputs.
+func NewOutputPinningNotSupportedError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrOutputPinningNotSupported.Error(),
+		"Pinning outputs is not supported by the storage of this overlay node.",
+	)
+}
+
+// NewOutputPinningProviderError returns an Error indicating that the configured provider
+// failed to update the output pin.
+func NewOutputPinningProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to update the output pin due to an internal error. Please try again later or contact the support team.",
+	)
+}
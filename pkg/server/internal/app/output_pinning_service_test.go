@@ -0,0 +1,110 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errOutputPinningTestError = errors.New("internal output pinning service test error")
+
+func TestOutputPinningService_PinOutput(t *testing.T) {
+	outpoint, err := transaction.OutpointFromString(testabilities.DefaultValidGraphID)
+	require.NoError(t, err)
+	_, errInvalidOutpoint := transaction.OutpointFromString(testabilities.DefaultInvalidGraphID)
+	require.Error(t, errInvalidOutpoint)
+
+	tests := map[string]struct {
+		topic         string
+		outpoint      string
+		expectations  testabilities.OutputPinningProviderMockExpectations
+		expectedError error
+	}{
+		"Pins the output": {
+			topic:    testabilities.DefaultValidTopic,
+			outpoint: testabilities.DefaultValidGraphID,
+			expectations: testabilities.OutputPinningProviderMockExpectations{
+				PinOutputCall: true,
+				Outpoint:      outpoint,
+				Topic:         testabilities.DefaultValidTopic,
+			},
+		},
+		"Fails when the topic is empty": {
+			outpoint:      testabilities.DefaultValidGraphID,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the outpoint is malformed": {
+			topic:         testabilities.DefaultValidTopic,
+			outpoint:      testabilities.DefaultInvalidGraphID,
+			expectedError: app.NewRawDataProcessingWithFieldError(errInvalidOutpoint, "Outpoint"),
+		},
+		"Fails when the output is not found": {
+			topic:    testabilities.DefaultValidTopic,
+			outpoint: testabilities.DefaultValidGraphID,
+			expectations: testabilities.OutputPinningProviderMockExpectations{
+				PinOutputCall: true,
+				Error:         engine.ErrMissingOutput,
+			},
+			expectedError: app.NewPinnedOutputNotFoundError(testabilities.DefaultValidGraphID),
+		},
+		"Fails when the storage cannot pin outputs": {
+			topic:    testabilities.DefaultValidTopic,
+			outpoint: testabilities.DefaultValidGraphID,
+			expectations: testabilities.OutputPinningProviderMockExpectations{
+				PinOutputCall: true,
+				Error:         engine.ErrOutputPinningNotSupported,
+			},
+			expectedError: app.NewOutputPinningNotSupportedError(),
+		},
+		"Fails when the provider fails": {
+			topic:    testabilities.DefaultValidTopic,
+			outpoint: testabilities.DefaultValidGraphID,
+			expectations: testabilities.OutputPinningProviderMockExpectations{
+				PinOutputCall: true,
+				Error:         errOutputPinningTestError,
+			},
+			expectedError: app.NewOutputPinningProviderError(errOutputPinningTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewOutputPinningProviderMock(t, tc.expectations)
+			service := app.NewOutputPinningService(mock)
+
+			// when:
+			err := service.PinOutput(context.Background(), tc.topic, tc.outpoint)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestOutputPinningService_UnpinOutput(t *testing.T) {
+	// given:
+	mock := testabilities.NewOutputPinningProviderMock(t, testabilities.OutputPinningProviderMockExpectations{
+		UnpinOutputCall: true,
+		Topic:           testabilities.DefaultValidTopic,
+	})
+	service := app.NewOutputPinningService(mock)
+
+	// when:
+	err := service.UnpinOutput(context.Background(), testabilities.DefaultValidTopic, testabilities.DefaultValidGraphID)
+
+	// then:
+	require.NoError(t, err)
+	mock.AssertCalled()
+}
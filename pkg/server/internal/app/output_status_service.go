@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// OutputStatusProvider defines the contract for reporting the status of an outpoint in a topic.
+type OutputStatusProvider interface {
+	GetOutputStatus(ctx context.Context, outpoint *transaction.Outpoint, topic string) (*engine.OutputStatus, error)
+}
+
+// OutputStatusService coordinates the reporting of outpoint statuses, validating the
+// requested outpoint before delegating to the provider.
+type OutputStatusService struct {
+	provider OutputStatusProvider
+	access   AccessControlList
+}
+
+// GetOutputStatus returns the status of the output at txID.vout in the topic.
+// Returns an error if:
+// - The transaction ID or topic is invalid or the topic is unknown (ErrorTypeIncorrectInput)
+// - The API key set on ctx is not permitted to access the topic (ErrorTypeAccessForbidden)
+// - The provider fails to report the status (ErrorTypeProviderFailure)
+func (s *OutputStatusService) GetOutputStatus(ctx context.Context, txID string, vout uint32, topic string) (*engine.OutputStatus, error) {
+	hash, err := chainhash.NewHashFromHex(txID)
+	if err != nil {
+		return nil, NewInvalidTxIDFormatError(err)
+	}
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.access.AuthorizeTopics(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	status, err := s.provider.GetOutputStatus(ctx, &transaction.Outpoint{Txid: *hash, Index: vout}, topic)
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return nil, NewOutputStatusUnknownTopicError(topic)
+	case err != nil:
+		return nil, NewOutputStatusProviderError(err)
+	}
+	return status, nil
+}
+
+// NewOutputStatusService creates a new OutputStatusService with the given provider and the access control list
+// restricting the topics each API key may access.
+// Panics if the provider is nil.
+func NewOutputStatusService(provider OutputStatusProvider, access AccessControlList) *OutputStatusService {
+	if provider == nil {
+		panic("output status provider cannot be nil")
+	}
+
+	return &OutputStatusService{provider: provider, access: access}
+}
+
+// NewOutputStatusUnknownTopicError returns an Error indicating that the overlay node does not host the topic.
+func NewOutputStatusUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewOutputStatusProviderError returns an Error indicating that the configured provider
+// failed to report the status of the output.
+func NewOutputStatusProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to retrieve the status of the output due to an internal error. Please try again later or contact the support team.",
+	)
+}
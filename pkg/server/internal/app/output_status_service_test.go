@@ -0,0 +1,95 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errOutputStatusTestError = errors.New("internal output status service test error")
+
+func TestOutputStatusService_GetOutputStatus(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *txid, Index: 2}
+	status := &engine.OutputStatus{
+		Outpoint:           *outpoint,
+		Topic:              testabilities.DefaultValidTopic,
+		TransactionApplied: true,
+		Admitted:           true,
+		MerkleState:        engine.MerkleStateUnmined,
+	}
+
+	tests := map[string]struct {
+		txID           string
+		topic          string
+		expectations   testabilities.OutputStatusProviderMockExpectations
+		expectedStatus *engine.OutputStatus
+		expectedError  error
+	}{
+		"Returns the status of the output": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Outpoint:            outpoint,
+				Status:              status,
+			},
+			expectedStatus: status,
+		},
+		"Fails when the transaction ID is invalid": {
+			txID:          testabilities.DefaultValidTxID + "0",
+			topic:         testabilities.DefaultValidTopic,
+			expectedError: app.NewInvalidTxIDFormatError(chainhash.ErrHashStrSize),
+		},
+		"Fails when the topic is empty": {
+			txID:          testabilities.DefaultValidTxID,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the topic is unknown": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Error:               engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewOutputStatusUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when the provider fails": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Error:               errOutputStatusTestError,
+			},
+			expectedError: app.NewOutputStatusProviderError(errOutputStatusTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewOutputStatusProviderMock(t, tc.expectations)
+			service := app.NewOutputStatusService(mock, app.AccessControlList{})
+
+			// when:
+			actual, err := service.GetOutputStatus(context.Background(), tc.txID, 2, tc.topic)
+
+			// then:
+			require.Equal(t, tc.expectedStatus, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
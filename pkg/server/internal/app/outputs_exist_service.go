@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// OutputsExistProvider defines the contract for checking in bulk whether outpoints are held by a topic.
+type OutputsExistProvider interface {
+	HasOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, unspentOnly bool) ([]bool, error)
+}
+
+// OutputsExistService coordinates bulk existence checks of outpoints, validating the
+// requested outpoints before delegating the lookup to the provider.
+type OutputsExistService struct {
+	provider OutputsExistProvider
+	access   AccessControlList
+}
+
+// OutputsExist reports, for each outpoint in the format "txid.vout" and in order, whether it is held by the topic.
+// When unspentOnly is set, spent outputs are reported as missing.
+// Returns an error if:
+// - The topic or an outpoint is invalid, the topic is unknown or too many outpoints are given (ErrorTypeIncorrectInput)
+// - The API key set on ctx is not permitted to access the topic (ErrorTypeAccessForbidden)
+// - The provider fails to check the outpoints (ErrorTypeProviderFailure)
+func (s *OutputsExistService) OutputsExist(ctx context.Context, topic string, outpoints []string, unspentOnly bool) ([]bool, error) {
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.access.AuthorizeTopics(ctx, topic); err != nil {
+		return nil, err
+	}
+	if len(outpoints) > engine.MaxOutpointsPerExistenceCheck {
+		return nil, NewTooManyOutpointsError(len(outpoints))
+	}
+
+	parsed := make([]*transaction.Outpoint, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		op, err := transaction.OutpointFromString(outpoint)
+		if err != nil {
+			return nil, NewInvalidOutpointFormatError(outpoint, err)
+		}
+		parsed = append(parsed, op)
+	}
+
+	exists, err := s.provider.HasOutputs(ctx, parsed, topic, unspentOnly)
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return nil, NewOutputsExistUnknownTopicError(topic)
+	case errors.Is(err, engine.ErrTooManyOutpoints):
+		return nil, NewTooManyOutpointsError(len(outpoints))
+	case err != nil:
+		return nil, NewOutputsExistProviderError(err)
+	}
+	return exists, nil
+}
+
+// NewOutputsExistService creates a new OutputsExistService with the given provider and the access control list
+// restricting the topics each API key may access.
+// Panics if the provider is nil.
+func NewOutputsExistService(provider OutputsExistProvider, access AccessControlList) *OutputsExistService {
+	if provider == nil {
+		panic("outputs exist provider cannot be nil")
+	}
+
+	return &OutputsExistService{provider: provider, access: access}
+}
+
+// NewInvalidOutpointFormatError returns an Error indicating that an outpoint is not in the format "txid.vout".
+func NewInvalidOutpointFormatError(outpoint string, err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		fmt.Sprintf("The outpoint %q is invalid. Please provide outpoints in the format \"txid.vout\".", outpoint),
+	)
+}
+
+// NewTooManyOutpointsError returns an Error indicating that more outpoints were given than a single check accepts.
+func NewTooManyOutpointsError(count int) Error {
+	return NewIncorrectInputError(
+		fmt.Sprintf("%s: %d > %d", engine.ErrTooManyOutpoints, count, engine.MaxOutpointsPerExistenceCheck),
+		fmt.Sprintf("At most %d outpoints can be checked at once. Please split the request.", engine.MaxOutpointsPerExistenceCheck),
+	)
+}
+
+// NewOutputsExistUnknownTopicError returns an Error indicating that the overlay node does not host the topic.
+func NewOutputsExistUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewOutputsExistProviderError returns an Error indicating that the configured provider
+// failed to check the existence of the outputs.
+func NewOutputsExistProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to check the existence of the outputs due to an internal error. Please try again later or contact the support team.",
+	)
+}
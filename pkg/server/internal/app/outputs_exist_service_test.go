@@ -0,0 +1,108 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errOutputsExistTestError = errors.New("internal outputs exist service test error")
+
+func TestOutputsExistService_OutputsExist(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoints := []*transaction.Outpoint{{Txid: *txid, Index: 0}, {Txid: *txid, Index: 1}}
+	requested := []string{outpoints[0].String(), outpoints[1].String()}
+	_, invalidOutpointErr := transaction.OutpointFromString("invalid")
+	require.Error(t, invalidOutpointErr)
+
+	tests := map[string]struct {
+		topic          string
+		outpoints      []string
+		unspentOnly    bool
+		expectations   testabilities.OutputsExistProviderMockExpectations
+		expectedExists []bool
+		expectedError  error
+	}{
+		"Reports the existence of the outputs": {
+			topic:     testabilities.DefaultValidTopic,
+			outpoints: requested,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				Outpoints:      outpoints,
+				Exists:         []bool{true, false},
+			},
+			expectedExists: []bool{true, false},
+		},
+		"Passes the spent filter to the provider": {
+			topic:       testabilities.DefaultValidTopic,
+			outpoints:   requested,
+			unspentOnly: true,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				UnspentOnly:    true,
+				Exists:         []bool{false, false},
+			},
+			expectedExists: []bool{false, false},
+		},
+		"Fails when the topic is empty": {
+			outpoints:     requested,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when an outpoint is invalid": {
+			topic:         testabilities.DefaultValidTopic,
+			outpoints:     []string{requested[0], "invalid"},
+			expectedError: app.NewInvalidOutpointFormatError("invalid", invalidOutpointErr),
+		},
+		"Fails when too many outpoints are given": {
+			topic:         testabilities.DefaultValidTopic,
+			outpoints:     make([]string, engine.MaxOutpointsPerExistenceCheck+1),
+			expectedError: app.NewTooManyOutpointsError(engine.MaxOutpointsPerExistenceCheck + 1),
+		},
+		"Fails when the topic is unknown": {
+			topic:     testabilities.DefaultValidTopic,
+			outpoints: requested,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				Error:          engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewOutputsExistUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when the provider fails": {
+			topic:     testabilities.DefaultValidTopic,
+			outpoints: requested,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				Error:          errOutputsExistTestError,
+			},
+			expectedError: app.NewOutputsExistProviderError(errOutputsExistTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewOutputsExistProviderMock(t, tc.expectations)
+			service := app.NewOutputsExistService(mock, app.AccessControlList{})
+
+			// when:
+			actual, err := service.OutputsExist(context.Background(), tc.topic, tc.outpoints, tc.unspentOnly)
+
+			// then:
+			require.Equal(t, tc.expectedExists, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// PaymentProvider defines the contract for verifying the payment transactions of paid requests.
+type PaymentProvider interface {
+	VerifyPayment(ctx context.Context, beef []byte, lockingScript *script.Script, satoshis uint64) (*transaction.Outpoint, error)
+}
+
+// PaymentService coordinates the verification of the payments attached to paid requests,
+// validating the payment before delegating to the provider.
+type PaymentService struct {
+	provider PaymentProvider
+}
+
+// VerifyPayment verifies that the payment transaction carried by the BEEF pays at least the given amount
+// of satoshis to the locking script, and returns the outpoint of the paying output.
+// Returns an error if:
+// - The BEEF or the locking script is missing (ErrorTypeIncorrectInput)
+// - The payment is malformed, insufficient or fails SPV verification (ErrorTypeIncorrectInput)
+// - The provider fails to verify or broadcast the payment (ErrorTypeProviderFailure)
+func (s *PaymentService) VerifyPayment(ctx context.Context, beef []byte, lockingScript *script.Script, satoshis uint64) (*transaction.Outpoint, error) {
+	if len(beef) == 0 {
+		return nil, NewIncorrectInputWithFieldError("transaction")
+	}
+	if lockingScript == nil {
+		return nil, NewIncorrectInputWithFieldError("lockingScript")
+	}
+
+	outpoint, err := s.provider.VerifyPayment(ctx, beef, lockingScript, satoshis)
+	switch {
+	case errors.Is(err, engine.ErrInvalidPaymentTransaction),
+		errors.Is(err, engine.ErrInsufficientPayment),
+		errors.Is(err, engine.ErrPaymentSPVFailed):
+		return nil, NewInvalidPaymentError(err)
+	case err != nil:
+		return nil, NewPaymentProviderError(err)
+	}
+	return outpoint, nil
+}
+
+// NewPaymentService creates a new PaymentService with the given provider.
+// Panics if the provider is nil.
+func NewPaymentService(provider PaymentProvider) *PaymentService {
+	if provider == nil {
+		panic("payment provider cannot be nil")
+	}
+
+	return &PaymentService{provider: provider}
+}
+
+// NewInvalidPaymentError returns an Error indicating that the payment attached to the request
+// is malformed, pays less than required or fails SPV verification.
+func NewInvalidPaymentError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The payment attached to the request is invalid or does not pay the required amount.",
+	)
+}
+
+// NewPaymentProviderError returns an Error indicating that the configured provider
+// failed to verify or broadcast the payment.
+func NewPaymentProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the payment due to an internal error. Please try again later or contact the support team.",
+	)
+}
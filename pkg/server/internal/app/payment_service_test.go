@@ -0,0 +1,98 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errPaymentTestError = errors.New("internal payment service test error")
+
+func TestPaymentService_VerifyPayment(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *txid, Index: 0}
+	lockingScript := &script.Script{script.OpTRUE}
+
+	tests := map[string]struct {
+		beef             []byte
+		lockingScript    *script.Script
+		expectations     testabilities.PaymentProviderMockExpectations
+		expectedOutpoint *transaction.Outpoint
+		expectedError    error
+	}{
+		"Returns the outpoint of the paying output": {
+			beef:          []byte{1},
+			lockingScript: lockingScript,
+			expectations: testabilities.PaymentProviderMockExpectations{
+				VerifyPaymentCall: true,
+				Satoshis:          100,
+				Outpoint:          outpoint,
+			},
+			expectedOutpoint: outpoint,
+		},
+		"Fails when the transaction is missing": {
+			lockingScript: lockingScript,
+			expectedError: app.NewIncorrectInputWithFieldError("transaction"),
+		},
+		"Fails when the locking script is missing": {
+			beef:          []byte{1},
+			expectedError: app.NewIncorrectInputWithFieldError("lockingScript"),
+		},
+		"Fails when the payment is insufficient": {
+			beef:          []byte{1},
+			lockingScript: lockingScript,
+			expectations: testabilities.PaymentProviderMockExpectations{
+				VerifyPaymentCall: true,
+				Error:             engine.ErrInsufficientPayment,
+			},
+			expectedError: app.NewInvalidPaymentError(engine.ErrInsufficientPayment),
+		},
+		"Fails when the payment fails SPV verification": {
+			beef:          []byte{1},
+			lockingScript: lockingScript,
+			expectations: testabilities.PaymentProviderMockExpectations{
+				VerifyPaymentCall: true,
+				Error:             engine.ErrPaymentSPVFailed,
+			},
+			expectedError: app.NewInvalidPaymentError(engine.ErrPaymentSPVFailed),
+		},
+		"Fails when the provider fails": {
+			beef:          []byte{1},
+			lockingScript: lockingScript,
+			expectations: testabilities.PaymentProviderMockExpectations{
+				VerifyPaymentCall: true,
+				Error:             errPaymentTestError,
+			},
+			expectedError: app.NewPaymentProviderError(errPaymentTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewPaymentProviderMock(t, tc.expectations)
+			service := app.NewPaymentService(mock)
+
+			// when:
+			actual, err := service.VerifyPayment(context.Background(), tc.beef, tc.lockingScript, 100)
+
+			// then:
+			require.Equal(t, tc.expectedOutpoint, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
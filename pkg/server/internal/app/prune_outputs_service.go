@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// PruneOutputsProvider defines the contract for applying the retention policies of the overlay topics.
+type PruneOutputsProvider interface {
+	PruneOutputs(ctx context.Context) ([]*engine.PruneReport, error)
+}
+
+// PruneOutputsService coordinates on-demand pruning of outputs no longer retained by the topics.
+type PruneOutputsService struct {
+	provider PruneOutputsProvider
+}
+
+// PruneOutputs applies the retention policy of every configured topic and returns what was pruned.
+// Returns an error if:
+// - No retention is configured or the storage cannot prune outputs (ErrorTypeUnsupportedOperation)
+// - The provider temporarily rejects writes (ErrorTypeServiceUnavailable)
+// - The provider fails to prune the outputs (ErrorTypeProviderFailure)
+func (s *PruneOutputsService) PruneOutputs(ctx context.Context) ([]*engine.PruneReport, error) {
+	reports, err := s.provider.PruneOutputs(ctx)
+	if err != nil {
+		var readOnlyErr *engine.StorageReadOnlyError
+		switch {
+		case errors.Is(err, engine.ErrRetentionNotConfigured), errors.Is(err, engine.ErrPruningNotSupported):
+			return nil, NewPruneOutputsUnsupportedError(err)
+		case errors.As(err, &readOnlyErr):
+			return nil, NewPruneOutputsUnavailableError(readOnlyErr.RetryAfter)
+		case errors.Is(err, engine.ErrEngineStopping):
+			return nil, NewPruneOutputsUnavailableError(0)
+		default:
+			return nil, NewPruneOutputsProviderError(err)
+		}
+	}
+	return reports, nil
+}
+
+// NewPruneOutputsService creates a new PruneOutputsService with the given provider.
+// Panics if the provider is nil.
+func NewPruneOutputsService(provider PruneOutputsProvider) *PruneOutputsService {
+	if provider == nil {
+		panic("prune outputs provider cannot be nil")
+	}
+
+	return &PruneOutputsService{provider: provider}
+}
+
+// NewPruneOutputsUnsupportedError returns an Error indicating that the overlay node has no
+// retention configured or that its storage cannot prune outputs.
+func NewPruneOutputsUnsupportedError(err error) Error {
+	return NewUnsupportedOperationError(
+		err.Error(),
+		"Pruning outputs is not supported by this overlay node. Configure a retention policy and a storage able to prune outputs.",
+	)
+}
+
+// NewPruneOutputsUnavailableError returns an Error indicating that the configured provider
+// temporarily rejects pruning, e.g. because its storage became read-only or the engine is stopping.
+func NewPruneOutputsUnavailableError(retryAfter time.Duration) Error {
+	return NewServiceUnavailableError(
+		"prune outputs provider rejects writes",
+		"Pruning outputs is temporarily unavailable. Please try again later.",
+		retryAfter,
+	)
+}
+
+// NewPruneOutputsProviderError returns an Error indicating that the configured provider
+// failed to prune outputs.
+func NewPruneOutputsProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to prune outputs due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,82 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errPruneOutputsTestError = errors.New("internal prune outputs service test error")
+
+func TestPruneOutputsService_PruneOutputs(t *testing.T) {
+	reports := []*engine.PruneReport{{Topic: "tm_a", Pruned: []*transaction.Outpoint{{Index: 1}}}}
+
+	tests := map[string]struct {
+		expectations    testabilities.PruneOutputsProviderMockExpectations
+		expectedReports []*engine.PruneReport
+		expectedError   error
+	}{
+		"Returns the prune reports": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Reports:          reports,
+			},
+			expectedReports: reports,
+		},
+		"Fails when no retention is configured": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Error:            engine.ErrRetentionNotConfigured,
+			},
+			expectedError: app.NewPruneOutputsUnsupportedError(engine.ErrRetentionNotConfigured),
+		},
+		"Fails when the storage cannot prune outputs": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Error:            engine.ErrPruningNotSupported,
+			},
+			expectedError: app.NewPruneOutputsUnsupportedError(engine.ErrPruningNotSupported),
+		},
+		"Fails as unavailable when the storage is read-only": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Error:            &engine.StorageReadOnlyError{RetryAfter: time.Minute, Cause: errPruneOutputsTestError},
+			},
+			expectedError: app.NewPruneOutputsUnavailableError(time.Minute),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Error:            errPruneOutputsTestError,
+			},
+			expectedError: app.NewPruneOutputsProviderError(errPruneOutputsTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewPruneOutputsProviderMock(t, tc.expectations)
+			service := app.NewPruneOutputsService(mock)
+
+			// when:
+			actual, err := service.PruneOutputs(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedReports, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
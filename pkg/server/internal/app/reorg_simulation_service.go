@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// ReorgSimulationProvider defines the contract for simulating a chain reorg against the overlay storage.
+type ReorgSimulationProvider interface {
+	SimulateReorg(ctx context.Context, depth uint32) (*engine.ReorgSimulation, error)
+}
+
+// ReorgSimulationService coordinates dry-run reorg simulations, letting operators rehearse
+// the response to deep reorgs without mutating the storage.
+type ReorgSimulationService struct {
+	provider ReorgSimulationProvider
+}
+
+// SimulateReorg simulates a reorg of the given depth and returns its report.
+// Returns an error if:
+// - The depth is zero or deeper than the current chain (ErrorTypeIncorrectInput)
+// - The provider fails to run the simulation (ErrorTypeProviderFailure)
+func (s *ReorgSimulationService) SimulateReorg(ctx context.Context, depth uint32) (*engine.ReorgSimulation, error) {
+	if depth == 0 {
+		return nil, NewInvalidReorgDepthError()
+	}
+
+	simulation, err := s.provider.SimulateReorg(ctx, depth)
+	switch {
+	case errors.Is(err, engine.ErrInvalidReorgDepth):
+		return nil, NewInvalidReorgDepthError()
+	case err != nil:
+		return nil, NewReorgSimulationProviderError(err)
+	}
+	return simulation, nil
+}
+
+// NewReorgSimulationService creates a new ReorgSimulationService with the given provider.
+// Panics if the provider is nil.
+func NewReorgSimulationService(provider ReorgSimulationProvider) *ReorgSimulationService {
+	if provider == nil {
+		panic("reorg simulation provider cannot be nil")
+	}
+
+	return &ReorgSimulationService{provider: provider}
+}
+
+// NewInvalidReorgDepthError returns an Error indicating that the requested reorg depth
+// is zero or exceeds the current chain height.
+func NewInvalidReorgDepthError() Error {
+	return NewIncorrectInputError(
+		engine.ErrInvalidReorgDepth.Error(),
+		"The reorg depth must be greater than zero and must not exceed the current chain height.",
+	)
+}
+
+// NewReorgSimulationProviderError returns an Error indicating that the configured provider
+// failed to simulate the reorg.
+func NewReorgSimulationProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to simulate the reorg due to an internal error. Please try again later or contact the support team.",
+	)
+}
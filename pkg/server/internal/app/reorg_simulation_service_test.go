@@ -0,0 +1,74 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errReorgSimulationTestError = errors.New("internal reorg simulation service test error")
+
+func TestReorgSimulationService_SimulateReorg(t *testing.T) {
+	simulation := &engine.ReorgSimulation{Depth: 3, CurrentHeight: 100, ForkHeight: 98}
+
+	tests := map[string]struct {
+		depth              uint32
+		expectations       testabilities.ReorgSimulationProviderMockExpectations
+		expectedSimulation *engine.ReorgSimulation
+		expectedError      error
+	}{
+		"Returns the simulation report": {
+			depth: 3,
+			expectations: testabilities.ReorgSimulationProviderMockExpectations{
+				SimulateReorgCall: true,
+				Depth:             3,
+				Simulation:        simulation,
+			},
+			expectedSimulation: simulation,
+		},
+		"Fails when the depth is zero": {
+			expectedError: app.NewInvalidReorgDepthError(),
+		},
+		"Fails when the depth exceeds the chain": {
+			depth: 1000,
+			expectations: testabilities.ReorgSimulationProviderMockExpectations{
+				SimulateReorgCall: true,
+				Error:             engine.ErrInvalidReorgDepth,
+			},
+			expectedError: app.NewInvalidReorgDepthError(),
+		},
+		"Fails when the provider fails": {
+			depth: 3,
+			expectations: testabilities.ReorgSimulationProviderMockExpectations{
+				SimulateReorgCall: true,
+				Error:             errReorgSimulationTestError,
+			},
+			expectedError: app.NewReorgSimulationProviderError(errReorgSimulationTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewReorgSimulationProviderMock(t, tc.expectations)
+			service := app.NewReorgSimulationService(mock)
+
+			// when:
+			actual, err := service.SimulateReorg(context.Background(), tc.depth)
+
+			// then:
+			require.Equal(t, tc.expectedSimulation, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
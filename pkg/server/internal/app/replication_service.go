@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// ReplicationProvider defines the contract for streaming storage mutations to a standby
+// and for promoting a standby to primary.
+type ReplicationProvider interface {
+	NextMutations(ctx context.Context, epoch string, since uint64) ([]*engine.Mutation, error)
+	PromoteStandby(ctx context.Context) error
+}
+
+// ReplicationService coordinates the warm standby replication of an overlay node.
+type ReplicationService struct {
+	provider    ReplicationProvider
+	pollTimeout time.Duration
+}
+
+// NextMutations returns the storage mutations following the given position, waiting up to
+// engine.DefaultReplicationPollTimeout for a new mutation when there is none yet.
+// Returns an error if:
+// - The overlay node does not record its storage mutations (ErrorTypeUnsupportedOperation)
+// - The requested mutations are no longer available (ErrorTypeIncorrectInput)
+// - The provider fails to return the mutations (ErrorTypeProviderFailure)
+func (s *ReplicationService) NextMutations(ctx context.Context, epoch string, since uint64) ([]*engine.Mutation, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.pollTimeout)
+	defer cancel()
+
+	mutations, err := s.provider.NextMutations(ctx, epoch, since)
+	switch {
+	case errors.Is(err, engine.ErrReplicationNotConfigured):
+		return nil, NewReplicationNotConfiguredError()
+	case errors.Is(err, engine.ErrReplicationGap):
+		return nil, NewReplicationGapError(err)
+	case err != nil:
+		return nil, NewReplicationProviderError(err)
+	}
+	return mutations, nil
+}
+
+// PromoteStandby makes the standby stop following its primary and accept writes.
+// Returns an error if:
+// - The overlay node is not a standby (ErrorTypeUnsupportedOperation)
+// - The provider fails to promote the standby (ErrorTypeProviderFailure)
+func (s *ReplicationService) PromoteStandby(ctx context.Context) error {
+	err := s.provider.PromoteStandby(ctx)
+	switch {
+	case errors.Is(err, engine.ErrNotStandby):
+		return NewNotStandbyError()
+	case err != nil:
+		return NewReplicationProviderError(err)
+	}
+	return nil
+}
+
+// NewReplicationService creates a new ReplicationService with the given provider.
+// Panics if the provider is nil.
+func NewReplicationService(provider ReplicationProvider) *ReplicationService {
+	if provider == nil {
+		panic("replication provider cannot be nil")
+	}
+
+	return &ReplicationService{provider: provider, pollTimeout: engine.DefaultReplicationPollTimeout}
+}
+
+// NewReplicationNotConfiguredError returns an Error indicating that the overlay node
+// does not record its storage mutations for standbys.
+func NewReplicationNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrReplicationNotConfigured.Error(),
+		"Streaming storage mutations is not supported by this overlay node. Configure a replicating storage to serve standbys.",
+	)
+}
+
+// NewReplicationGapError returns an Error indicating that the primary no longer holds the mutations
+// following the position of the standby, which must be reseeded before following it again.
+func NewReplicationGapError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The requested storage mutations are no longer available. Reseed the standby before following this overlay node again.",
+	)
+}
+
+// NewNotStandbyError returns an Error indicating that the overlay node does not follow a primary.
+func NewNotStandbyError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrNotStandby.Error(),
+		"This overlay node is not a standby and cannot be promoted.",
+	)
+}
+
+// NewReplicationProviderError returns an Error indicating that the configured provider
+// failed to stream storage mutations or to promote the standby.
+func NewReplicationProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the replication request due to an internal error. Please try again later or contact the support team.",
+	)
+}
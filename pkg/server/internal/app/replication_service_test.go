@@ -0,0 +1,119 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errReplicationTestError = errors.New("internal replication service test error")
+
+func TestReplicationService_NextMutations(t *testing.T) {
+	mutations := []*engine.Mutation{{Epoch: "epoch", Seq: 8, Op: engine.MutationDeleteOutput, Topic: "tm_a"}}
+
+	tests := map[string]struct {
+		expectations      testabilities.ReplicationProviderMockExpectations
+		expectedMutations []*engine.Mutation
+		expectedError     error
+	}{
+		"Returns the mutations following the position": {
+			expectations: testabilities.ReplicationProviderMockExpectations{
+				NextMutationsCall: true,
+				Epoch:             "epoch",
+				Since:             7,
+				Mutations:         mutations,
+			},
+			expectedMutations: mutations,
+		},
+		"Fails when the storage does not record mutations": {
+			expectations: testabilities.ReplicationProviderMockExpectations{
+				NextMutationsCall: true,
+				Epoch:             "epoch",
+				Since:             7,
+				Error:             engine.ErrReplicationNotConfigured,
+			},
+			expectedError: app.NewReplicationNotConfiguredError(),
+		},
+		"Fails when the mutations are no longer available": {
+			expectations: testabilities.ReplicationProviderMockExpectations{
+				NextMutationsCall: true,
+				Epoch:             "epoch",
+				Since:             7,
+				Error:             engine.ErrReplicationGap,
+			},
+			expectedError: app.NewReplicationGapError(engine.ErrReplicationGap),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.ReplicationProviderMockExpectations{
+				NextMutationsCall: true,
+				Epoch:             "epoch",
+				Since:             7,
+				Error:             errReplicationTestError,
+			},
+			expectedError: app.NewReplicationProviderError(errReplicationTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewReplicationProviderMock(t, tc.expectations)
+			service := app.NewReplicationService(mock)
+
+			// when:
+			actual, err := service.NextMutations(context.Background(), "epoch", 7)
+
+			// then:
+			require.Equal(t, tc.expectedMutations, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestReplicationService_PromoteStandby(t *testing.T) {
+	tests := map[string]struct {
+		expectations  testabilities.ReplicationProviderMockExpectations
+		expectedError error
+	}{
+		"Promotes the standby": {
+			expectations: testabilities.ReplicationProviderMockExpectations{PromoteStandbyCall: true},
+		},
+		"Fails when the node is not a standby": {
+			expectations:  testabilities.ReplicationProviderMockExpectations{PromoteStandbyCall: true, Error: engine.ErrNotStandby},
+			expectedError: app.NewNotStandbyError(),
+		},
+		"Fails when the provider fails": {
+			expectations:  testabilities.ReplicationProviderMockExpectations{PromoteStandbyCall: true, Error: errReplicationTestError},
+			expectedError: app.NewReplicationProviderError(errReplicationTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewReplicationProviderMock(t, tc.expectations)
+			service := app.NewReplicationService(mock)
+
+			// when:
+			err := service.PromoteStandby(context.Background())
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
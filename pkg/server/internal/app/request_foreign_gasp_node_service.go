@@ -2,7 +2,9 @@ package app
 
 import (
 	"context"
+	"errors"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
@@ -15,25 +17,28 @@ type RequestForeignGASPNodeDTO struct {
 	TxID        string // TxID is the hexadecimal transaction ID that produced the desired output.
 	OutputIndex uint32 // OutputIndex specifies the index of the output within the transaction.
 	Topic       string // Topic is a metadata string for categorizing or filtering the request.
+	Metadata    bool   // Metadata requests the node's provenance alongside the transaction data.
 }
 
 // RequestForeignGASPNodeProvider defines the interface that must be implemented to fulfill a foreign GASP node request.
 type RequestForeignGASPNodeProvider interface {
 	// ProvideForeignGASPNode resolves the foreign GASP node using the given graphID, outpoint, and topic.
 	// Returns a pointer to a GASP node or an error if retrieval fails.
-	ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, topic string) (*gasp.Node, error)
+	ProvideForeignGASPNode(ctx context.Context, graphID, outpoint *transaction.Outpoint, topic string, metadata bool) (*gasp.Node, error)
 }
 
 // RequestForeignGASPNodeService coordinates and orchestrates the process of requesting a foreign GASP node.
 // It uses the injected provider to perform the actual node retrieval based on validated input.
 type RequestForeignGASPNodeService struct {
 	provider RequestForeignGASPNodeProvider
+	access   AccessControlList
 }
 
 // RequestForeignGASPNode validates and converts input DTO fields and delegates the request to the provider.
 // It parses the transaction ID into a chain hash, constructs a new outpoint using the parsed chain hash
 // and the output index, and creates a graph outpoint from the GraphID string.
-// All validated data is then passed to the configured provider.
+// All validated data is then passed to the configured provider. Topics restricted by the access control list
+// require the API key set on ctx to be permitted to access them.
 // Returns the GASP node on success, or a detailed error if processing fails.
 func (s *RequestForeignGASPNodeService) RequestForeignGASPNode(ctx context.Context, dto RequestForeignGASPNodeDTO) (*gasp.Node, error) {
 	txID, err := chainhash.NewHashFromHex(dto.TxID)
@@ -45,25 +50,36 @@ func (s *RequestForeignGASPNodeService) RequestForeignGASPNode(ctx context.Conte
 	if err != nil {
 		return nil, NewRawDataProcessingWithFieldError(err, "GraphID")
 	}
+	if err := s.access.AuthorizeTopics(ctx, dto.Topic); err != nil {
+		return nil, err
+	}
 
 	node, err := s.provider.ProvideForeignGASPNode(ctx, graphID, &transaction.Outpoint{
 		Index: dto.OutputIndex,
 		Txid:  *txID,
-	}, dto.Topic)
+	}, dto.Topic, dto.Metadata)
 	if err != nil {
+		var busyErr *engine.GASPPeerBusyError
+		switch {
+		case errors.As(err, &busyErr):
+			return nil, NewGASPPeerBusyError(busyErr.RetryAfter)
+		case errors.Is(err, engine.ErrGASPResponseBudgetExceeded):
+			return nil, NewGASPResponseTooLargeError(err)
+		}
 		return nil, NewForeignGASPNodeProviderError(err)
 	}
 	return node, nil
 }
 
-// NewRequestForeignGASPNodeService constructs and returns a new instance of RequestForeignGASPNodeService.
+// NewRequestForeignGASPNodeService constructs and returns a new instance of RequestForeignGASPNodeService
+// with the access control list restricting the topics each API key may access.
 // Panics if the given provider is nil, as a valid provider is required for service operation.
-func NewRequestForeignGASPNodeService(provider RequestForeignGASPNodeProvider) *RequestForeignGASPNodeService {
+func NewRequestForeignGASPNodeService(provider RequestForeignGASPNodeProvider, access AccessControlList) *RequestForeignGASPNodeService {
 	if provider == nil {
 		panic("request foreign GASP node service provider is nil")
 	}
 
-	return &RequestForeignGASPNodeService{provider: provider}
+	return &RequestForeignGASPNodeService{provider: provider, access: access}
 }
 
 // NewForeignGASPNodeProviderError wraps a lower-level provider error in a user-facing error with guidance.
@@ -74,3 +90,13 @@ func NewForeignGASPNodeProviderError(err error) Error {
 		"Unable to process foreign gasp node request due to an internal error. Please try again later or contact the support team.",
 	)
 }
+
+// NewGASPResponseTooLargeError returns an Error indicating that the requested GASP node exceeds
+// the budget the provider serves to peers.
+func NewGASPResponseTooLargeError(err error) Error {
+	return NewPayloadTooLargeError(
+		err.Error(),
+		"The requested GASP node exceeds the maximum response size served to peers.",
+		GASPResponseTooLargeErrorCode,
+	)
+}
@@ -1,8 +1,11 @@
 package app_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
 	"github.com/stretchr/testify/require"
@@ -46,13 +49,29 @@ func TestRequestForeignGASPNodeService_InvalidCases(t *testing.T) {
 			},
 			expectedErrorType: app.ErrorTypeProviderFailure,
 		},
+		"Request foreign GASP node service fails due to a busy peer": {
+			dto: testabilities.ForeignGASPNodeDefaultDTO,
+			expectations: testabilities.RequestForeignGASPNodeProviderMockExpectations{
+				ProvideForeignGASPNodeCall: true,
+				Error:                      &engine.GASPPeerBusyError{Peer: "peer", RetryAfter: time.Second},
+			},
+			expectedErrorType: app.ErrorTypeTooManyRequests,
+		},
+		"Request foreign GASP node service fails due to a node exceeding the response budget": {
+			dto: testabilities.ForeignGASPNodeDefaultDTO,
+			expectations: testabilities.RequestForeignGASPNodeProviderMockExpectations{
+				ProvideForeignGASPNodeCall: true,
+				Error:                      fmt.Errorf("%w: node too large", engine.ErrGASPResponseBudgetExceeded),
+			},
+			expectedErrorType: app.ErrorTypePayloadTooLarge,
+		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// given:
 			mock := testabilities.NewRequestForeignGASPNodeProviderMock(t, tc.expectations)
-			service := app.NewRequestForeignGASPNodeService(mock)
+			service := app.NewRequestForeignGASPNodeService(mock, app.AccessControlList{})
 
 			// when:
 			node, err := service.RequestForeignGASPNode(t.Context(), tc.dto)
@@ -71,7 +90,7 @@ func TestRequestForeignGASPNodeService_InvalidCases(t *testing.T) {
 func TestRequestForeignGASPNodeService_ValidCase(t *testing.T) {
 	// given:
 	mock := testabilities.NewRequestForeignGASPNodeProviderMock(t, testabilities.DefaultRequestForeignGASPNodeProviderMockExpectations)
-	service := app.NewRequestForeignGASPNodeService(mock)
+	service := app.NewRequestForeignGASPNodeService(mock, app.AccessControlList{})
 
 	// when:
 	node, err := service.RequestForeignGASPNode(t.Context(), testabilities.ForeignGASPNodeDefaultDTO)
@@ -2,7 +2,10 @@ package app
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 )
 
@@ -16,10 +19,11 @@ type OutpointDTO struct {
 
 // RequestSyncResponseDTO is a transport-friendly structure that encapsulates
 // the response to a sync request, including a list of UTXO outpoints and the
-// latest processed sync height (Since).
+// latest processed sync height (Since). Partial marks a UTXO list truncated by the provider.
 type RequestSyncResponseDTO struct {
 	UTXOList []OutpointDTO
 	Since    float64
+	Partial  bool
 }
 
 // Topic represents a named communication or synchronization channel identifier.
@@ -55,6 +59,20 @@ func NewSince(v float64) Since { return Since(v) }
 // Float64 returns the raw float64 value of the Since marker.
 func (s Since) Float64() float64 { return float64(s) }
 
+// Limit represents the maximum number of UTXOs requested, zero meaning no limit.
+type Limit uint32
+
+// NewLimit constructs a new Limit from an optional uint32, treating nil as no limit.
+func NewLimit(v *uint32) Limit {
+	if v == nil {
+		return 0
+	}
+	return Limit(*v)
+}
+
+// Uint32 returns the raw uint32 value of the Limit.
+func (l Limit) Uint32() uint32 { return uint32(l) }
+
 // RequestSyncResponseProvider defines the interface for components that can
 // fulfill requests for foreign sync responses. It abstracts the underlying
 // sync logic and data source.
@@ -67,22 +85,31 @@ type RequestSyncResponseProvider interface {
 // and adapts the response into a client-facing DTO.
 type RequestSyncResponseService struct {
 	provider RequestSyncResponseProvider
+	access   AccessControlList
 }
 
 // RequestSyncResponse performs a foreign sync request for a given topic.
 // It validates the input parameters, constructs the initial request payload,
 // and delegates the operation to the provider. The response is transformed
-// into a DTO suitable for external use.
-func (s *RequestSyncResponseService) RequestSyncResponse(ctx context.Context, topic Topic, version Version, since Since) (*RequestSyncResponseDTO, error) {
+// into a DTO suitable for external use. Topics restricted by the access control list
+// require the API key set on ctx to be permitted to access them.
+func (s *RequestSyncResponseService) RequestSyncResponse(ctx context.Context, topic Topic, version Version, since Since, limit Limit) (*RequestSyncResponseDTO, error) {
 	if topic.IsEmpty() {
 		return nil, NewIncorrectInputWithFieldError("topic")
 	}
 	if !version.IsGreaterThanZero() {
 		return nil, NewIncorrectInputWithFieldError("version")
 	}
+	if err := s.access.AuthorizeTopics(ctx, topic.String()); err != nil {
+		return nil, err
+	}
 
-	response, err := s.provider.ProvideForeignSyncResponse(ctx, &gasp.InitialRequest{Version: version.Int(), Since: since.Float64()}, topic.String())
+	response, err := s.provider.ProvideForeignSyncResponse(ctx, &gasp.InitialRequest{Version: version.Int(), Since: since.Float64(), Limit: limit.Uint32()}, topic.String())
 	if err != nil {
+		var busyErr *engine.GASPPeerBusyError
+		if errors.As(err, &busyErr) {
+			return nil, NewGASPPeerBusyError(busyErr.RetryAfter)
+		}
 		return nil, NewRequestSyncResponseProviderError(err)
 	}
 	return NewRequestSyncResponseDTO(response), nil
@@ -103,16 +130,17 @@ func NewRequestSyncResponseDTO(response *gasp.InitialResponse) *RequestSyncRespo
 	return &RequestSyncResponseDTO{
 		UTXOList: outpoints,
 		Since:    response.Since,
+		Partial:  response.Partial,
 	}
 }
 
 // NewRequestSyncResponseService constructs a new RequestSyncResponseService with the
-// provided provider. It panics if the provider is nil to enforce safe initialization.
-func NewRequestSyncResponseService(provider RequestSyncResponseProvider) *RequestSyncResponseService {
+// provided provider and the access control list restricting the topics each API key may access. It panics if the provider is nil to enforce safe initialization.
+func NewRequestSyncResponseService(provider RequestSyncResponseProvider, access AccessControlList) *RequestSyncResponseService {
 	if provider == nil {
 		panic("request sync response provider is nil")
 	}
-	return &RequestSyncResponseService{provider: provider}
+	return &RequestSyncResponseService{provider: provider, access: access}
 }
 
 // NewRequestSyncResponseProviderError wraps a low-level provider error that occurred
@@ -125,3 +153,13 @@ func NewRequestSyncResponseProviderError(err error) Error {
 		slug:      "Unable to process sync response request due to an error in the overlay engine.",
 	}
 }
+
+// NewGASPPeerBusyError returns an Error indicating that the peer already has as many GASP requests
+// in progress as the provider serves, and that the request may be retried later.
+func NewGASPPeerBusyError(retryAfter time.Duration) Error {
+	return NewTooManyRequestsError(
+		"peer has too many GASP requests in progress",
+		"Too many GASP requests of this peer are in progress. Please try again later.",
+		retryAfter,
+	)
+}
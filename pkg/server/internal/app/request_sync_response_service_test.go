@@ -2,7 +2,9 @@ package app_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
@@ -42,14 +44,15 @@ func TestRequestSyncResponseService_ValidCase(t *testing.T) {
 
 	expectedDTO := app.NewRequestSyncResponseDTO(expectations.Response)
 	provider := testabilities.NewRequestSyncResponseProviderMock(t, expectations)
-	service := app.NewRequestSyncResponseService(provider)
+	service := app.NewRequestSyncResponseService(provider, app.AccessControlList{})
 
 	// when:
 	actualDTO, err := service.RequestSyncResponse(
 		t.Context(),
 		testabilities.DefaultTopic,
 		testabilities.DefaultVersion,
-		app.NewSince(testabilities.DefaultSince))
+		app.NewSince(testabilities.DefaultSince),
+		app.NewLimit(nil))
 
 	// then:
 	require.NoError(t, err)
@@ -61,6 +64,7 @@ func TestRequestSyncResponseService_InvalidCases(t *testing.T) {
 	tests := map[string]struct {
 		version       app.Version
 		since         app.Since
+		limit         app.Limit
 		topic         app.Topic
 		expectations  testabilities.RequestSyncResponseProviderMockExpectations
 		expectedError app.Error
@@ -103,13 +107,30 @@ func TestRequestSyncResponseService_InvalidCases(t *testing.T) {
 			},
 			expectedError: app.NewRequestSyncResponseProviderError(testabilities.ErrTestNoopOpFailure),
 		},
+		"Request sync response service fails to handle the sync request - busy peer": {
+			version: testabilities.DefaultVersion,
+			since:   app.NewSince(testabilities.DefaultSince),
+			limit:   10,
+			topic:   testabilities.DefaultTopic,
+			expectations: testabilities.RequestSyncResponseProviderMockExpectations{
+				InitialRequest: &gasp.InitialRequest{
+					Version: testabilities.DefaultVersion,
+					Since:   testabilities.DefaultSince,
+					Limit:   10,
+				},
+				Topic:                          testabilities.DefaultTopic,
+				ProvideForeignSyncResponseCall: true,
+				Error:                          &engine.GASPPeerBusyError{Peer: "peer", RetryAfter: 3 * time.Second},
+			},
+			expectedError: app.NewGASPPeerBusyError(3 * time.Second),
+		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// given:
 			mock := testabilities.NewRequestSyncResponseProviderMock(t, tc.expectations)
-			service := app.NewRequestSyncResponseService(mock)
+			service := app.NewRequestSyncResponseService(mock, app.AccessControlList{})
 
 			// when:
 			response, err := service.RequestSyncResponse(
@@ -117,6 +138,7 @@ func TestRequestSyncResponseService_InvalidCases(t *testing.T) {
 				tc.topic,
 				tc.version,
 				tc.since,
+				tc.limit,
 			)
 
 			// then:
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+const (
+	// BEEFTooLargeErrorCode identifies submissions rejected for exceeding SubmitBEEFLimits.MaxBytes.
+	BEEFTooLargeErrorCode = "ERR_BEEF_TOO_LARGE"
+	// BEEFTooManyTransactionsErrorCode identifies submissions rejected for exceeding SubmitBEEFLimits.MaxTransactions.
+	BEEFTooManyTransactionsErrorCode = "ERR_BEEF_TOO_MANY_TRANSACTIONS"
+	// BEEFMalformedErrorCode identifies submissions rejected because the BEEF could not be parsed.
+	BEEFMalformedErrorCode = "ERR_INVALID_BEEF"
+)
+
+// SubmitBEEFLimits bounds the BEEF of a submitted transaction and controls its early
+// structural validation, performed before the transaction is handed to the provider.
+// The zero value applies no limits and hands the BEEF over without parsing it.
+type SubmitBEEFLimits struct {
+	// MaxBytes is the maximum size of the submitted BEEF in bytes. Zero means unlimited.
+	MaxBytes int
+
+	// MaxTransactions is the maximum number of transactions carried by the submitted BEEF.
+	// Zero means unlimited. A non-zero value implies ValidateStructure.
+	MaxTransactions int
+
+	// ValidateStructure parses the submitted BEEF and rejects it when it is malformed
+	// or does not identify the transaction being submitted.
+	ValidateStructure bool
+}
+
+// Check enforces the limits against the submitted BEEF.
+// Returns NewBEEFTooLargeError, NewMalformedBEEFError or NewTooManyBEEFTransactionsError on failure.
+func (l SubmitBEEFLimits) Check(beef []byte) error {
+	if l.MaxBytes > 0 && len(beef) > l.MaxBytes {
+		return NewBEEFTooLargeError(len(beef), l.MaxBytes)
+	}
+	if !l.ValidateStructure && l.MaxTransactions <= 0 {
+		return nil
+	}
+
+	parsed, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return NewMalformedBEEFError(err)
+	}
+	if tx == nil {
+		return NewMalformedBEEFError(errors.New("BEEF does not identify the submitted transaction"))
+	}
+	if l.MaxTransactions > 0 && len(parsed.Transactions) > l.MaxTransactions {
+		return NewTooManyBEEFTransactionsError(len(parsed.Transactions), l.MaxTransactions)
+	}
+	return nil
+}
+
+// NewBEEFTooLargeError returns an Error indicating that the submitted BEEF exceeds the configured size limit.
+func NewBEEFTooLargeError(size, limit int) Error {
+	return NewPayloadTooLargeError(
+		fmt.Sprintf("Submitted BEEF of %d bytes exceeds the limit of %d bytes.", size, limit),
+		fmt.Sprintf("The submitted BEEF exceeds the maximum size of %d bytes.", limit),
+		BEEFTooLargeErrorCode,
+	).WithDetails(map[string]any{"limit": limit})
+}
+
+// NewTooManyBEEFTransactionsError returns an Error indicating that the submitted BEEF carries
+// more transactions than the configured limit.
+func NewTooManyBEEFTransactionsError(count, limit int) Error {
+	return NewPayloadTooLargeError(
+		fmt.Sprintf("Submitted BEEF carries %d transactions, exceeding the limit of %d.", count, limit),
+		fmt.Sprintf("The submitted BEEF exceeds the maximum of %d transactions.", limit),
+		BEEFTooManyTransactionsErrorCode,
+	).WithDetails(map[string]any{"limit": limit})
+}
+
+// NewMalformedBEEFError returns an Error indicating that the submitted BEEF failed structural validation.
+func NewMalformedBEEFError(err error) Error {
+	return NewUnprocessableContentError(
+		err.Error(),
+		"The submitted BEEF is malformed. Please verify the transaction octet-stream and try again.",
+		BEEFMalformedErrorCode,
+	)
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+)
+
+// SubmitJobProvider defines the interface for enqueuing tagged transactions for asynchronous
+// processing by the overlay engine and polling the resulting jobs.
+type SubmitJobProvider interface {
+	EnqueueSubmit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, callbackURL string) (*engine.SubmitJob, error)
+	FindSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error)
+}
+
+// SubmitJobService coordinates asynchronous transaction submissions using the configured SubmitJobProvider.
+type SubmitJobService struct {
+	provider SubmitJobProvider
+	policy   SubmitTopicsPolicy
+	limits   SubmitBEEFLimits
+	access   AccessControlList
+}
+
+// EnqueueSubmitTransaction validates the submission like SubmitTransactionService.SubmitTransaction,
+// then enqueues it and returns the pending job without waiting for the STEAK.
+// A non-empty callbackURL must be an absolute http(s) URL allowed by the job queue; it receives the finished job.
+// Returns an error if:
+// - The topics are missing, invalid or rejected by the policy or the access control list, or the callback URL is invalid (ErrorTypeIncorrectInput, ErrorTypeAccessForbidden)
+// - The callback URL is not allowed by the job queue (ErrorTypeAccessForbidden)
+// - The BEEF exceeds the limits or is malformed (ErrorTypePayloadTooLarge, ErrorTypeUnprocessableContent)
+// - Asynchronous submissions are not enabled (ErrorTypeUnsupportedOperation)
+// - The job queue is full (ErrorTypeServiceUnavailable)
+// - The provider fails to enqueue the submission (ErrorTypeProviderFailure)
+func (s *SubmitJobService) EnqueueSubmitTransaction(ctx context.Context, topics TransactionTopics, trusted bool, callbackURL string, txBytes ...byte) (*engine.SubmitJob, error) {
+	topics, err := prepareSubmission(ctx, topics, trusted, s.policy, s.access, s.limits, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if callbackURL != "" {
+		parsed, err := url.Parse(callbackURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, NewInvalidCallbackURLError(callbackURL)
+		}
+	}
+
+	job, err := s.provider.EnqueueSubmit(ctx, overlay.TaggedBEEF{Beef: txBytes, Topics: topics}, callbackURL)
+	switch {
+	case errors.Is(err, engine.ErrSubmitJobsNotConfigured):
+		return nil, NewSubmitJobsNotConfiguredError()
+	case errors.Is(err, engine.ErrSubmitJobQueueFull):
+		return nil, NewSubmitJobQueueFullError()
+	case errors.Is(err, engine.ErrSubmitJobCallbackNotAllowed):
+		return nil, NewSubmitJobCallbackNotAllowedError(callbackURL)
+	case err != nil:
+		return nil, NewSubmitJobProviderError(err)
+	}
+	return job, nil
+}
+
+// GetSubmitJob returns the job with the given ID.
+// Returns an error if:
+// - The ID is empty (ErrorTypeIncorrectInput)
+// - The job is not found or asynchronous submissions are not enabled (ErrorTypeUnsupportedOperation)
+// - The provider fails to find the job (ErrorTypeProviderFailure)
+func (s *SubmitJobService) GetSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error) {
+	if id == "" {
+		return nil, NewIncorrectInputWithFieldError("jobID")
+	}
+
+	job, err := s.provider.FindSubmitJob(ctx, id)
+	switch {
+	case errors.Is(err, engine.ErrSubmitJobNotFound):
+		return nil, NewSubmitJobNotFoundError(id)
+	case errors.Is(err, engine.ErrSubmitJobsNotConfigured):
+		return nil, NewSubmitJobsNotConfiguredError()
+	case err != nil:
+		return nil, NewSubmitJobProviderError(err)
+	}
+	return job, nil
+}
+
+// NewSubmitJobService creates a new SubmitJobService with the given provider, topics policy, access control list
+// and BEEF limits. Panics if the provider is nil.
+func NewSubmitJobService(provider SubmitJobProvider, policy SubmitTopicsPolicy, access AccessControlList, limits SubmitBEEFLimits) *SubmitJobService {
+	if provider == nil {
+		panic("submit job provider cannot be nil")
+	}
+
+	return &SubmitJobService{provider: provider, policy: policy, access: access, limits: limits}
+}
+
+// NewInvalidCallbackURLError returns an Error indicating that the callback URL is not an absolute http(s) URL.
+func NewInvalidCallbackURLError(callbackURL string) Error {
+	return NewIncorrectInputError(
+		fmt.Sprintf("Invalid callback URL %q.", callbackURL),
+		"The callback URL must be an absolute http or https URL.",
+	)
+}
+
+// NewSubmitJobCallbackNotAllowedError returns an Error indicating that the overlay node does not deliver jobs
+// to the callback URL.
+func NewSubmitJobCallbackNotAllowedError(callbackURL string) Error {
+	return NewAccessForbiddenError(
+		fmt.Sprintf("The callback URL %q is not allowed.", callbackURL),
+		"This overlay node does not deliver submit jobs to the callback URL.",
+	)
+}
+
+// NewSubmitJobNotFoundError returns an Error indicating that no job exists with the given ID,
+// or that it is no longer retained.
+func NewSubmitJobNotFoundError(id string) Error {
+	msg := fmt.Sprintf("The submit job %q was not found.", id)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewSubmitJobsNotConfiguredError returns an Error indicating that the overlay engine
+// does not accept asynchronous submissions.
+func NewSubmitJobsNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrSubmitJobsNotConfigured.Error(),
+		"Asynchronous submissions are not enabled on this overlay node.",
+	)
+}
+
+// NewSubmitJobQueueFullError returns an Error indicating that the job queue is full
+// and the submission may be retried later.
+func NewSubmitJobQueueFullError() Error {
+	return NewServiceUnavailableError(
+		engine.ErrSubmitJobQueueFull.Error(),
+		"Too many asynchronous submissions are being processed. Please try again later.",
+		0,
+	)
+}
+
+// NewSubmitJobProviderError returns an Error indicating that the configured provider
+// failed to enqueue a submission or find a job.
+func NewSubmitJobProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the asynchronous submission due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,178 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errSubmitJobTestError = errors.New("internal submit job service test error")
+
+func TestSubmitJobService_EnqueueSubmitTransaction(t *testing.T) {
+	job := &engine.SubmitJob{
+		ID:        "job-1",
+		Status:    engine.SubmitJobPending,
+		Topics:    []string{"topic1"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		topics        app.TransactionTopics
+		callbackURL   string
+		expectations  testabilities.SubmitJobProviderMockExpectations
+		expectedJob   *engine.SubmitJob
+		expectedError error
+	}{
+		"Enqueues the submission": {
+			topics:      app.TransactionTopics{"topic1"},
+			callbackURL: "https://client.example/jobs",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				EnqueueSubmitCall: true,
+				Job:               job,
+				CallbackURL:       "https://client.example/jobs",
+			},
+			expectedJob: job,
+		},
+		"Fails when the topics are missing": {
+			expectedError: app.NewEmptyTransactionTopicsError(),
+		},
+		"Fails when the callback URL is not an http(s) URL": {
+			topics:        app.TransactionTopics{"topic1"},
+			callbackURL:   "ftp://client.example/jobs",
+			expectedError: app.NewInvalidCallbackURLError("ftp://client.example/jobs"),
+		},
+		"Fails when the callback URL is not allowed": {
+			topics:      app.TransactionTopics{"topic1"},
+			callbackURL: "http://127.0.0.1/jobs",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				EnqueueSubmitCall: true,
+				CallbackURL:       "http://127.0.0.1/jobs",
+				Error:             engine.ErrSubmitJobCallbackNotAllowed,
+			},
+			expectedError: app.NewSubmitJobCallbackNotAllowedError("http://127.0.0.1/jobs"),
+		},
+		"Fails when asynchronous submissions are not enabled": {
+			topics: app.TransactionTopics{"topic1"},
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				EnqueueSubmitCall: true,
+				Error:             engine.ErrSubmitJobsNotConfigured,
+			},
+			expectedError: app.NewSubmitJobsNotConfiguredError(),
+		},
+		"Fails when the job queue is full": {
+			topics: app.TransactionTopics{"topic1"},
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				EnqueueSubmitCall: true,
+				Error:             engine.ErrSubmitJobQueueFull,
+			},
+			expectedError: app.NewSubmitJobQueueFullError(),
+		},
+		"Fails when the provider fails": {
+			topics: app.TransactionTopics{"topic1"},
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				EnqueueSubmitCall: true,
+				Error:             errSubmitJobTestError,
+			},
+			expectedError: app.NewSubmitJobProviderError(errSubmitJobTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitJobProviderMock(t, tc.expectations)
+			service := app.NewSubmitJobService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
+
+			// when:
+			actual, err := service.EnqueueSubmitTransaction(context.Background(), tc.topics, false, tc.callbackURL, testabilities.DummyTxBEEF(t)...)
+
+			// then:
+			require.Equal(t, tc.expectedJob, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitJobService_GetSubmitJob(t *testing.T) {
+	job := &engine.SubmitJob{
+		ID:          "job-1",
+		Status:      engine.SubmitJobCompleted,
+		Topics:      []string{"topic1"},
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CompletedAt: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		id            string
+		expectations  testabilities.SubmitJobProviderMockExpectations
+		expectedJob   *engine.SubmitJob
+		expectedError error
+	}{
+		"Returns the job": {
+			id: "job-1",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Job:               job,
+			},
+			expectedJob: job,
+		},
+		"Fails when the ID is empty": {
+			expectedError: app.NewIncorrectInputWithFieldError("jobID"),
+		},
+		"Fails when the job is not found": {
+			id: "job-2",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Error:             engine.ErrSubmitJobNotFound,
+			},
+			expectedError: app.NewSubmitJobNotFoundError("job-2"),
+		},
+		"Fails when asynchronous submissions are not enabled": {
+			id: "job-1",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Error:             engine.ErrSubmitJobsNotConfigured,
+			},
+			expectedError: app.NewSubmitJobsNotConfiguredError(),
+		},
+		"Fails when the provider fails": {
+			id: "job-1",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Error:             errSubmitJobTestError,
+			},
+			expectedError: app.NewSubmitJobProviderError(errSubmitJobTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitJobProviderMock(t, tc.expectations)
+			service := app.NewSubmitJobService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
+
+			// when:
+			actual, err := service.GetSubmitJob(context.Background(), tc.id)
+
+			// then:
+			require.Equal(t, tc.expectedJob, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SubmitTopicsPolicy controls which topics are evaluated for a submitted transaction.
+// The zero value leaves client-provided topics unchanged.
+type SubmitTopicsPolicy struct {
+	// AutoAddedTopics are evaluated for every submission, even when the client did not tag them.
+	AutoAddedTopics []string
+
+	// ExplicitTopics, when non-empty, is the set of topics clients may tag explicitly.
+	// Tagging any other topic (except an auto-added or, for trusted clients, a trusted-only one) is rejected.
+	ExplicitTopics []string
+
+	// TrustedOnlyTopics are rejected when requested by untrusted clients.
+	TrustedOnlyTopics []string
+}
+
+// Apply enforces the policy against the client-provided topics and returns the topics
+// that should be evaluated for the submission, including any auto-added topics.
+// Returns NewTrustedOnlyTopicError or NewTopicNotAllowedError when a topic is rejected.
+func (p SubmitTopicsPolicy) Apply(topics TransactionTopics, trusted bool) (TransactionTopics, error) {
+	applied := make(TransactionTopics, 0, len(topics)+len(p.AutoAddedTopics))
+	for _, topic := range topics {
+		if slices.Contains(p.TrustedOnlyTopics, topic) {
+			if !trusted {
+				return nil, NewTrustedOnlyTopicError(topic)
+			}
+		} else if len(p.ExplicitTopics) > 0 && !slices.Contains(p.ExplicitTopics, topic) && !slices.Contains(p.AutoAddedTopics, topic) {
+			return nil, NewTopicNotAllowedError(topic)
+		}
+		if !slices.Contains(applied, topic) {
+			applied = append(applied, topic)
+		}
+	}
+
+	for _, topic := range p.AutoAddedTopics {
+		if !slices.Contains(applied, topic) {
+			applied = append(applied, topic)
+		}
+	}
+	return applied, nil
+}
+
+// NewTrustedOnlyTopicError returns an Error indicating that an untrusted client
+// requested a topic reserved for trusted clients.
+func NewTrustedOnlyTopicError(topic string) Error {
+	return Error{
+		errorType: ErrorTypeAccessForbidden,
+		code:      TopicTrustedOnlyErrorCode,
+		err:       fmt.Sprintf("Topic %q can only be requested by trusted clients.", topic),
+		slug:      "One or more requested topics are restricted to trusted clients.",
+	}
+}
+
+// NewTopicNotAllowedError returns an Error indicating that a client tagged a topic
+// outside of the set of topics accepted for explicit tagging.
+func NewTopicNotAllowedError(topic string) Error {
+	return Error{
+		errorType: ErrorTypeIncorrectInput,
+		code:      TopicNotAllowedErrorCode,
+		err:       fmt.Sprintf("Topic %q is not accepted for submission.", topic),
+		slug:      "One or more requested topics are not accepted by this overlay service.",
+	}
+}
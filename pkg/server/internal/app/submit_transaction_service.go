@@ -2,30 +2,39 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 )
 
 // SubmitTransactionProvider defines the interface for sending a tagged transaction
-// to the overlay engine for processing.
+// to the overlay engine for processing, or for evaluating it without applying it.
 type SubmitTransactionProvider interface {
 	Submit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, mode engine.SumbitMode, onSteakReady engine.OnSteakReady) (overlay.Steak, error)
+	Evaluate(ctx context.Context, taggedBEEF overlay.TaggedBEEF) (overlay.Steak, error)
 }
 
 // SubmitTransactionService coordinates the transaction submission process using configured SubmitTransactionProvider.
 type SubmitTransactionService struct {
 	provider SubmitTransactionProvider
+	policy   SubmitTopicsPolicy
+	limits   SubmitBEEFLimits
+	access   AccessControlList
 }
 
 // SubmitTransaction submits a transaction to the configured provider.
-// It validates the provided topics, sends the transaction, and waits for a response (STEAK).
+// It validates the provided topics, applies the topics policy for the (un)trusted client,
+// checks the BEEF against the configured limits, sends the transaction, and waits for a response (STEAK).
 // Returns a non-nil *overlay.Steak on success, or an error if topics are missing, invalid,
-// the provider fails, or a timeout occurs.
-func (s *SubmitTransactionService) SubmitTransaction(ctx context.Context, topics TransactionTopics, txBytes ...byte) (*overlay.Steak, error) {
-	err := topics.Verify()
+// rejected by the policy or the access control list, the BEEF exceeds the limits or is malformed, the provider fails,
+// temporarily rejects writes or is saturated with submissions, or a timeout occurs. Submissions exceeding the
+// timeout set on ctx with WithSubmitTimeout fail with NewSubmitTransactionTimeoutError.
+func (s *SubmitTransactionService) SubmitTransaction(ctx context.Context, topics TransactionTopics, trusted bool, txBytes ...byte) (*overlay.Steak, error) {
+	topics, err := prepareSubmission(ctx, topics, trusted, s.policy, s.access, s.limits, txBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -35,25 +44,136 @@ func (s *SubmitTransactionService) SubmitTransaction(ctx context.Context, topics
 		ch <- steak
 	})
 	if err != nil {
-		return nil, NewSubmitTransactionProviderError(err)
+		if ctx.Err() != nil {
+			return nil, submitContextError(ctx)
+		}
+		return nil, submitProviderError(err)
 	}
 
+	// A submission applied in full returns its STEAK even when ctx is done meanwhile.
+	select {
+	case steak := <-ch:
+		return steak, nil
+	default:
+	}
 	select {
 	case steak := <-ch:
 		return steak, nil
 	case <-ctx.Done():
-		return nil, NewContextCancellationError()
+		return nil, submitContextError(ctx)
+	}
+}
+
+// EvaluateTransaction evaluates a transaction with the configured provider without applying it,
+// returning the STEAK its submission would result in. It validates the topics and BEEF like SubmitTransaction,
+// while the provider verifies the transaction and identifies its admissible outputs without writing to storage,
+// broadcasting the transaction or notifying anyone.
+func (s *SubmitTransactionService) EvaluateTransaction(ctx context.Context, topics TransactionTopics, trusted bool, txBytes ...byte) (*overlay.Steak, error) {
+	topics, err := prepareSubmission(ctx, topics, trusted, s.policy, s.access, s.limits, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	steak, err := s.provider.Evaluate(ctx, overlay.TaggedBEEF{Beef: txBytes, Topics: topics})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, submitContextError(ctx)
+		}
+		return nil, submitProviderError(err)
+	}
+	return &steak, nil
+}
+
+// submitTimeout is the cause of the cancellation of submissions exceeding the timeout set with WithSubmitTimeout.
+type submitTimeout time.Duration
+
+func (t submitTimeout) Error() string {
+	return fmt.Sprintf("submission exceeded the submit timeout of %s", time.Duration(t))
+}
+
+// WithSubmitTimeout returns a copy of ctx bounding the submissions made with it to the timeout. Submissions exceeding
+// it fail with NewSubmitTransactionTimeoutError. A zero timeout leaves ctx unbounded.
+func WithSubmitTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, timeout, submitTimeout(timeout))
+}
+
+// submitContextError maps the cancellation of the context of a submission to an Error.
+func submitContextError(ctx context.Context) Error {
+	var timeout submitTimeout
+	if errors.As(context.Cause(ctx), &timeout) {
+		return NewSubmitTransactionTimeoutError(time.Duration(timeout))
+	}
+	return NewContextCancellationError()
+}
+
+// submitProviderError maps an error of the provider submitting or evaluating a transaction to an Error.
+func submitProviderError(err error) Error {
+	var readOnlyErr *engine.StorageReadOnlyError
+	if errors.As(err, &readOnlyErr) {
+		return NewSubmitTransactionUnavailableError(readOnlyErr.RetryAfter)
 	}
+	if errors.Is(err, engine.ErrEngineStopping) {
+		return NewSubmitTransactionUnavailableError(0)
+	}
+	var saturatedErr *engine.SubmitSaturatedError
+	if errors.As(err, &saturatedErr) {
+		return NewSubmitTransactionSaturatedError(saturatedErr.RetryAfter)
+	}
+	if errors.Is(err, engine.ErrUnknownTopic) {
+		return NewSubmitTransactionUnknownTopicError(err)
+	}
+	if errors.Is(err, engine.ErrInvalidBeef) {
+		return NewMalformedBEEFError(err)
+	}
+	if errors.Is(err, engine.ErrInvalidTransaction) {
+		return NewInvalidTransactionError(err)
+	}
+	if errors.Is(err, engine.ErrTopicQuotaExceeded) {
+		return NewTopicQuotaExceededError(err)
+	}
+	if errors.Is(err, engine.ErrIdempotencyKeyConflict) {
+		return NewIdempotencyKeyConflictError(err)
+	}
+	return NewSubmitTransactionProviderError(err)
 }
 
-// NewSubmitTransactionService creates a new SubmitTransactionService with the given provider and timeout.
-// Panics if the provider is nil.
-func NewSubmitTransactionService(provider SubmitTransactionProvider) *SubmitTransactionService {
+// NewSubmitTransactionService creates a new SubmitTransactionService with the given provider, topics policy, access
+// control list and BEEF limits. Panics if the provider is nil.
+func NewSubmitTransactionService(provider SubmitTransactionProvider, policy SubmitTopicsPolicy, access AccessControlList, limits SubmitBEEFLimits) *SubmitTransactionService {
 	if provider == nil {
 		panic("submit transaction service provider is nil")
 	}
 
-	return &SubmitTransactionService{provider: provider}
+	return &SubmitTransactionService{provider: provider, policy: policy, access: access, limits: limits}
+}
+
+// prepareSubmission validates the provided topics, applies the topics policy for the (un)trusted client,
+// checks that the API key set on ctx is permitted to submit to the resulting topics, auto-added ones included,
+// and checks the BEEF against the limits. It returns the topics the transaction should be submitted to.
+func prepareSubmission(ctx context.Context, topics TransactionTopics, trusted bool, policy SubmitTopicsPolicy, access AccessControlList, limits SubmitBEEFLimits, txBytes []byte) (TransactionTopics, error) {
+	err := topics.Verify()
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err = policy.Apply(topics, trusted)
+	if err != nil {
+		return nil, err
+	}
+
+	err = access.AuthorizeTopics(ctx, topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = limits.Check(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return topics, nil
 }
 
 // TransactionTopics represents a list of topics that must be provided when submitting a transaction.
@@ -81,6 +201,7 @@ func (tt TransactionTopics) Verify() error {
 func NewEmptyTransactionTopicsError() Error {
 	return Error{
 		errorType: ErrorTypeIncorrectInput,
+		code:      MissingTopicsErrorCode,
 		err:       "Provided topics cannot be an empty slice.",
 		slug:      "At least one topic must be provided in the correct string format. Empty topic values are not allowed.",
 	}
@@ -91,8 +212,10 @@ func NewEmptyTransactionTopicsError() Error {
 func NewErrInvalidTopicFormatError(i int) Error {
 	return Error{
 		errorType: ErrorTypeIncorrectInput,
+		code:      InvalidTopicErrorCode,
 		err:       fmt.Sprintf("Invalid topic header format for topic no. %d.", i+1),
 		slug:      "One or more topics are in an invalid format. Empty string values are not allowed.",
+		details:   map[string]any{"index": i},
 	}
 }
 
@@ -105,3 +228,83 @@ func NewSubmitTransactionProviderError(err error) Error {
 		slug:      "Unable to process submitted transaction octet-stream due to an internal error. Please try again later or contact the support team.",
 	}
 }
+
+// NewSubmitTransactionUnknownTopicError returns an Error indicating that one or more topics
+// of the submitted transaction are not hosted by the overlay node.
+func NewSubmitTransactionUnknownTopicError(err error) Error {
+	return Error{
+		errorType: ErrorTypeIncorrectInput,
+		code:      UnknownTopicErrorCode,
+		err:       err.Error(),
+		slug:      "One or more topics of the submitted transaction are not hosted by this overlay node.",
+	}
+}
+
+// NewInvalidTransactionError returns an Error indicating that the submitted transaction
+// failed verification, e.g. because its merkle proofs or scripts are invalid.
+func NewInvalidTransactionError(err error) Error {
+	return NewUnprocessableContentError(
+		err.Error(),
+		"The submitted transaction is invalid. Please verify its inputs, scripts and proofs and try again.",
+		InvalidTransactionErrorCode,
+	)
+}
+
+// NewTopicQuotaExceededError returns an Error indicating that a topic of the submission admits
+// more outputs than its quota allows.
+func NewTopicQuotaExceededError(err error) Error {
+	return NewUnprocessableContentError(
+		err.Error(),
+		"The submitted transaction admits more outputs than the quota of its topic allows.",
+		TopicQuotaExceededErrorCode,
+	)
+}
+
+// NewIdempotencyKeyConflictError returns an Error indicating that the Idempotency-Key of the submission
+// was already used for another transaction or other topics.
+func NewIdempotencyKeyConflictError(err error) Error {
+	return NewUnprocessableContentError(
+		err.Error(),
+		"The Idempotency-Key was already used for a different submission. Please use a new key for this transaction and topics.",
+		IdempotencyKeyConflictErrorCode,
+	)
+}
+
+// NewUnsupportedSTEAKVersionError returns an Error indicating that the submission requests
+// a STEAK serialization version the overlay does not support.
+func NewUnsupportedSTEAKVersionError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The requested STEAK version is not supported. Please request version 1 or 2 with the X-STEAK-Version header.",
+	).WithCode(UnsupportedSTEAKVersionErrorCode)
+}
+
+// NewSubmitTransactionUnavailableError returns an Error indicating that the configured provider
+// temporarily rejects transaction submissions, e.g. because its storage became read-only.
+func NewSubmitTransactionUnavailableError(retryAfter time.Duration) Error {
+	return NewServiceUnavailableError(
+		"submit transaction provider rejects writes",
+		"Transaction submissions are temporarily unavailable. Please try again later.",
+		retryAfter,
+	)
+}
+
+// NewSubmitTransactionTimeoutError returns an Error indicating that a submission did not complete within
+// the submit timeout of the server, and that its transaction was not applied.
+func NewSubmitTransactionTimeoutError(timeout time.Duration) Error {
+	return NewDeadlineExceededError(
+		submitTimeout(timeout).Error(),
+		"The transaction submission did not complete in time and was not applied. Please try again later.",
+		SubmitTimeoutErrorCode,
+	).WithDetails(map[string]any{"timeout": timeout.String()})
+}
+
+// NewSubmitTransactionSaturatedError returns an Error indicating that the configured provider
+// already processes as many submissions as it accepts, and that the submission may be retried later.
+func NewSubmitTransactionSaturatedError(retryAfter time.Duration) Error {
+	return NewTooManyRequestsError(
+		"submit transaction provider is saturated",
+		"Too many transaction submissions are in progress. Please try again later.",
+		retryAfter,
+	)
+}
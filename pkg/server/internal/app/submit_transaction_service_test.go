@@ -6,9 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
 	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,14 +28,14 @@ func TestSubmitTransactionService_InvalidCase_ContextCancellation(t *testing.T)
 	txBytes := testabilities.DummyTxBEEF(t)
 
 	mock := testabilities.NewSubmitTransactionProviderMock(t, expectations)
-	service := app.NewSubmitTransactionService(mock)
+	service := app.NewSubmitTransactionService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
 	expectedErr := app.NewContextCancellationError()
 
 	// when:
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	steak, err := service.SubmitTransaction(ctx, topics, txBytes...)
+	steak, err := service.SubmitTransaction(ctx, topics, false, txBytes...)
 
 	// then:
 	var actualErr app.Error
@@ -44,6 +46,37 @@ func TestSubmitTransactionService_InvalidCase_ContextCancellation(t *testing.T)
 	mock.AssertCalled()
 }
 
+func TestSubmitTransactionService_InvalidCase_SubmitTimeout(t *testing.T) {
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		SubmitCall:           true,
+		TriggerCallbackAfter: time.Second,
+		STEAK:                nil,
+	}
+
+	// given:
+	topics := app.TransactionTopics{"topic1", "topic2"}
+	txBytes := testabilities.DummyTxBEEF(t)
+
+	mock := testabilities.NewSubmitTransactionProviderMock(t, expectations)
+	service := app.NewSubmitTransactionService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
+	expectedErr := app.NewSubmitTransactionTimeoutError(10 * time.Millisecond)
+
+	// when:
+	ctx, cancel := app.WithSubmitTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	steak, err := service.SubmitTransaction(ctx, topics, false, txBytes...)
+
+	// then:
+	var actualErr app.Error
+	require.ErrorAs(t, err, &actualErr)
+	require.Equal(t, expectedErr, actualErr)
+	require.Equal(t, app.ErrorTypeDeadlineExceeded, actualErr.ErrorType())
+
+	require.Nil(t, steak)
+	mock.AssertCalled()
+}
+
 func TestSubmitTransactionService_InvalidCases(t *testing.T) {
 	tests := map[string]struct {
 		expectations  testabilities.SubmitTransactionProviderMockExpectations
@@ -77,16 +110,88 @@ func TestSubmitTransactionService_InvalidCases(t *testing.T) {
 			},
 			expectedError: app.NewErrInvalidTopicFormatError(1),
 		},
+		"Submit transaction service fails to handle the transaction submission - storage is read-only": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      &engine.StorageReadOnlyError{RetryAfter: time.Minute, Cause: errSubmitTransactionTestError},
+			},
+			expectedError: app.NewSubmitTransactionUnavailableError(time.Minute),
+		},
+		"Submit transaction service fails to handle the transaction submission - engine is stopping": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      engine.ErrEngineStopping,
+			},
+			expectedError: app.NewSubmitTransactionUnavailableError(0),
+		},
+		"Submit transaction service fails to handle the transaction submission - engine is saturated": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      &engine.SubmitSaturatedError{Topic: "topic1", RetryAfter: time.Second},
+			},
+			expectedError: app.NewSubmitTransactionSaturatedError(time.Second),
+		},
+		"Submit transaction service fails to handle the transaction submission - unknown topic": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewSubmitTransactionUnknownTopicError(engine.ErrUnknownTopic),
+		},
+		"Submit transaction service fails to handle the transaction submission - invalid BEEF": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      engine.ErrInvalidBeef,
+			},
+			expectedError: app.NewMalformedBEEFError(engine.ErrInvalidBeef),
+		},
+		"Submit transaction service fails to handle the transaction submission - invalid transaction": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      engine.ErrInvalidTransaction,
+			},
+			expectedError: app.NewInvalidTransactionError(engine.ErrInvalidTransaction),
+		},
+		"Submit transaction service fails to handle the transaction submission - topic quota exceeded": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      engine.ErrTopicQuotaExceeded,
+			},
+			expectedError: app.NewTopicQuotaExceededError(engine.ErrTopicQuotaExceeded),
+		},
+		"Submit transaction service fails to handle the transaction submission - idempotency key conflict": {
+			topics:  app.TransactionTopics{"topic1", "topic2"},
+			txBytes: testabilities.DummyTxBEEF(t),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				Error:      engine.ErrIdempotencyKeyConflict,
+			},
+			expectedError: app.NewIdempotencyKeyConflictError(engine.ErrIdempotencyKeyConflict),
+		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// given:
 			mock := testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)
-			service := app.NewSubmitTransactionService(mock)
+			service := app.NewSubmitTransactionService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
 
 			// when:
-			steak, err := service.SubmitTransaction(context.Background(), tc.topics, tc.txBytes...)
+			steak, err := service.SubmitTransaction(context.Background(), tc.topics, false, tc.txBytes...)
 
 			// then:
 			var actualErr app.Error
@@ -115,13 +220,240 @@ func TestSubmitTransactionService_ValidCase(t *testing.T) {
 
 	topics := app.TransactionTopics{"topic1", "topic2"}
 	mock := testabilities.NewSubmitTransactionProviderMock(t, expectations)
-	service := app.NewSubmitTransactionService(mock)
+	service := app.NewSubmitTransactionService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
 
 	// when:
-	actualSTEAK, err := service.SubmitTransaction(context.Background(), topics)
+	actualSTEAK, err := service.SubmitTransaction(context.Background(), topics, false)
 
 	// then:
 	require.NoError(t, err)
 	require.Equal(t, expectations.STEAK, actualSTEAK)
 	mock.AssertCalled()
 }
+
+func TestSubmitTransactionService_EvaluateTransaction(t *testing.T) {
+	evaluated := &overlay.Steak{
+		"topic1": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsRemoved: []uint32{1}},
+	}
+
+	tests := map[string]struct {
+		expectations  testabilities.SubmitTransactionProviderMockExpectations
+		topics        app.TransactionTopics
+		expectedSTEAK *overlay.Steak
+		expectedError error
+	}{
+		"returns the STEAK the submission would result in": {
+			topics:        app.TransactionTopics{"topic1"},
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: true, DryRun: true, STEAK: evaluated},
+			expectedSTEAK: evaluated,
+		},
+		"rejects empty topics": {
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewEmptyTransactionTopicsError(),
+		},
+		"maps an unknown topic": {
+			topics:        app.TransactionTopics{"topic1"},
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: true, DryRun: true, Error: engine.ErrUnknownTopic},
+			expectedError: app.NewSubmitTransactionUnknownTopicError(engine.ErrUnknownTopic),
+		},
+		"maps a provider failure": {
+			topics:        app.TransactionTopics{"topic1"},
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: true, DryRun: true, Error: errSubmitTransactionTestError},
+			expectedError: app.NewSubmitTransactionProviderError(errSubmitTransactionTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)
+			service := app.NewSubmitTransactionService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})
+
+			// when:
+			actualSTEAK, err := service.EvaluateTransaction(context.Background(), tc.topics, false, testabilities.DummyTxBEEF(t)...)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedSTEAK, actualSTEAK)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitTransactionService_TopicsPolicy(t *testing.T) {
+	policy := app.SubmitTopicsPolicy{
+		AutoAddedTopics:   []string{"tm_auto"},
+		ExplicitTopics:    []string{"tm_public"},
+		TrustedOnlyTopics: []string{"tm_private"},
+	}
+
+	tests := map[string]struct {
+		topics        app.TransactionTopics
+		trusted       bool
+		expectations  testabilities.SubmitTransactionProviderMockExpectations
+		expectedError error
+	}{
+		"Auto-added topics are appended to the client topics": {
+			topics: app.TransactionTopics{"tm_public"},
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+				Topics:     []string{"tm_public", "tm_auto"},
+			},
+		},
+		"Trusted-only topic requested by a trusted client": {
+			topics:  app.TransactionTopics{"tm_private"},
+			trusted: true,
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+				Topics:     []string{"tm_private", "tm_auto"},
+			},
+		},
+		"Trusted-only topic requested by an untrusted client": {
+			topics:        app.TransactionTopics{"tm_public", "tm_private"},
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewTrustedOnlyTopicError("tm_private"),
+		},
+		"Topic outside of the explicit topics": {
+			topics:        app.TransactionTopics{"tm_other"},
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewTopicNotAllowedError("tm_other"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)
+			service := app.NewSubmitTransactionService(mock, policy, app.AccessControlList{}, app.SubmitBEEFLimits{})
+
+			// when:
+			_, err := service.SubmitTransaction(context.Background(), tc.topics, tc.trusted, testabilities.DummyTxBEEF(t)...)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitTransactionService_AccessControl(t *testing.T) {
+	policy := app.SubmitTopicsPolicy{AutoAddedTopics: []string{"tm_auto"}}
+	acl := app.AccessControlList{TopicKeys: map[string][]string{"tm_auto": {"auto-key"}}}
+
+	tests := map[string]struct {
+		apiKey        string
+		expectations  testabilities.SubmitTransactionProviderMockExpectations
+		expectedError error
+	}{
+		"Restricted auto-added topic without a permitted API key is denied": {
+			apiKey:        "other-key",
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewTopicAccessDeniedError("tm_auto"),
+		},
+		"Restricted auto-added topic with a permitted API key is submitted": {
+			apiKey: "auto-key",
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+				Topics:     []string{"tm_public", "tm_auto"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)
+			service := app.NewSubmitTransactionService(mock, policy, acl, app.SubmitBEEFLimits{})
+			ctx := app.WithAPIKey(context.Background(), tc.apiKey)
+
+			// when:
+			_, err := service.SubmitTransaction(ctx, app.TransactionTopics{"tm_public"}, false, testabilities.DummyTxBEEF(t)...)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestSubmitTransactionService_BEEFLimits(t *testing.T) {
+	txBytes := testabilities.DummyTxBEEF(t)
+	beef, _, _, err := transaction.ParseBeef(txBytes)
+	require.NoError(t, err)
+	txCount := len(beef.Transactions)
+
+	tests := map[string]struct {
+		limits        app.SubmitBEEFLimits
+		txBytes       []byte
+		expectations  testabilities.SubmitTransactionProviderMockExpectations
+		expectedError error
+	}{
+		"BEEF within the configured limits": {
+			limits:  app.SubmitBEEFLimits{MaxBytes: len(txBytes), MaxTransactions: txCount, ValidateStructure: true},
+			txBytes: txBytes,
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+			},
+		},
+		"BEEF exceeding the maximum size": {
+			limits:        app.SubmitBEEFLimits{MaxBytes: len(txBytes) - 1},
+			txBytes:       txBytes,
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewBEEFTooLargeError(len(txBytes), len(txBytes)-1),
+		},
+		"BEEF exceeding the maximum transaction count": {
+			limits:        app.SubmitBEEFLimits{MaxTransactions: txCount - 1},
+			txBytes:       txBytes,
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewTooManyBEEFTransactionsError(txCount, txCount-1),
+		},
+		"Malformed BEEF rejected by the structural validation": {
+			limits:        app.SubmitBEEFLimits{ValidateStructure: true},
+			txBytes:       []byte("test transaction body"),
+			expectations:  testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+			expectedError: app.NewMalformedBEEFError(errors.New("invalid-atomic-beef")),
+		},
+		"Malformed BEEF handed over when the structural validation is disabled": {
+			txBytes: []byte("test transaction body"),
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)
+			service := app.NewSubmitTransactionService(mock, app.SubmitTopicsPolicy{}, app.AccessControlList{}, tc.limits)
+
+			// when:
+			_, err := service.SubmitTransaction(context.Background(), app.TransactionTopics{"topic1"}, false, tc.txBytes...)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// Topic synchronization types accepted when registering a topic manager.
+const (
+	TopicSyncTypePeers = "peers"
+	TopicSyncTypeSHIP  = "SHIP"
+	TopicSyncTypeNone  = "none"
+)
+
+// TopicManagerRegistrationProvider defines the contract for adding and removing
+// topic managers of a running overlay engine.
+type TopicManagerRegistrationProvider interface {
+	AddTopicManager(ctx context.Context, name string, syncConfig engine.SyncConfiguration) error
+	UnregisterTopicManager(ctx context.Context, name string) error
+}
+
+// TopicManagerRegistrationService coordinates the runtime registration of topic managers.
+type TopicManagerRegistrationService struct {
+	provider TopicManagerRegistrationProvider
+}
+
+// RegisterTopicManager registers the named topic manager, synchronized according to syncType and peers.
+// An empty syncType disables synchronization of the topic.
+// Returns an error if:
+// - The topic manager name is empty or the sync type is unknown (ErrorTypeIncorrectInput)
+// - The topic manager is already registered (ErrorTypeIncorrectInput)
+// - The engine cannot build topic managers by name (ErrorTypeUnsupportedOperation)
+// - The provider fails to register the topic manager (ErrorTypeProviderFailure)
+func (s *TopicManagerRegistrationService) RegisterTopicManager(ctx context.Context, name, syncType string, peers []string) error {
+	if name == "" {
+		return NewEmptyTopicManagerNameError()
+	}
+
+	syncConfig := engine.SyncConfiguration{Peers: peers}
+	switch syncType {
+	case TopicSyncTypePeers:
+		syncConfig.Type = engine.SyncConfigurationPeers
+	case TopicSyncTypeSHIP:
+		syncConfig.Type = engine.SyncConfigurationSHIP
+	case TopicSyncTypeNone, "":
+		syncConfig.Type = engine.SyncConfigurationNone
+	default:
+		return NewUnknownTopicSyncTypeError(syncType)
+	}
+
+	if err := s.provider.AddTopicManager(ctx, name, syncConfig); err != nil {
+		return newTopicManagerRegistrationError(err, name)
+	}
+	return nil
+}
+
+// UnregisterTopicManager removes the named topic manager.
+// Returns an error if:
+// - The topic manager name is empty (ErrorTypeIncorrectInput)
+// - The topic manager is not registered (ErrorTypeUnsupportedOperation)
+// - The provider fails to unregister the topic manager (ErrorTypeProviderFailure)
+func (s *TopicManagerRegistrationService) UnregisterTopicManager(ctx context.Context, name string) error {
+	if name == "" {
+		return NewEmptyTopicManagerNameError()
+	}
+
+	if err := s.provider.UnregisterTopicManager(ctx, name); err != nil {
+		return newTopicManagerRegistrationError(err, name)
+	}
+	return nil
+}
+
+// NewTopicManagerRegistrationService creates a new TopicManagerRegistrationService with the given provider.
+// Panics if the provider is nil.
+func NewTopicManagerRegistrationService(provider TopicManagerRegistrationProvider) *TopicManagerRegistrationService {
+	if provider == nil {
+		panic("topic manager registration provider cannot be nil")
+	}
+
+	return &TopicManagerRegistrationService{provider: provider}
+}
+
+func newTopicManagerRegistrationError(err error, name string) Error {
+	switch {
+	case errors.Is(err, engine.ErrTopicManagerAlreadyRegistered):
+		return NewTopicManagerAlreadyRegisteredError(name)
+	case errors.Is(err, engine.ErrTopicManagerNotRegistered):
+		return NewTopicManagerNotRegisteredError(name)
+	case errors.Is(err, engine.ErrTopicManagerFactoryNotConfigured):
+		return NewTopicManagerFactoryNotConfiguredError()
+	default:
+		return NewTopicManagerRegistrationProviderError(err)
+	}
+}
+
+// NewUnknownTopicSyncTypeError returns an Error indicating that the requested topic sync type is not supported.
+func NewUnknownTopicSyncTypeError(syncType string) Error {
+	msg := fmt.Sprintf("Unknown sync type %q. Use %q, %q or %q.", syncType, TopicSyncTypePeers, TopicSyncTypeSHIP, TopicSyncTypeNone)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewTopicManagerAlreadyRegisteredError returns an Error indicating that a topic manager
+// with the given name is already registered.
+func NewTopicManagerAlreadyRegisteredError(name string) Error {
+	msg := fmt.Sprintf("The topic manager %q is already registered.", name)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewTopicManagerNotRegisteredError returns an Error indicating that no topic manager
+// with the given name is registered.
+func NewTopicManagerNotRegisteredError(name string) Error {
+	msg := fmt.Sprintf("The topic manager %q is not registered.", name)
+	return NewUnsupportedOperationError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewTopicManagerFactoryNotConfiguredError returns an Error indicating that the engine
+// cannot build topic managers by name.
+func NewTopicManagerFactoryNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		"topic manager factory not configured",
+		"Registering topic managers at runtime is not supported by this overlay node.",
+	)
+}
+
+// NewTopicManagerRegistrationProviderError returns an Error indicating that the configured provider
+// failed to update the topic manager registry.
+func NewTopicManagerRegistrationProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to update the topic managers due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,143 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errTopicManagerRegistrationTestError = errors.New("internal topic manager registration service test error")
+
+func TestTopicManagerRegistrationService_RegisterTopicManager(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		syncType      string
+		peers         []string
+		expectations  testabilities.TopicManagerRegistrationProviderMockExpectations
+		expectedError error
+	}{
+		"Registers a topic manager synchronized with peers": {
+			name:     "tm_new",
+			syncType: app.TopicSyncTypePeers,
+			peers:    []string{"https://peer.example.com"},
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				Name:                "tm_new",
+				SyncConfiguration: &engine.SyncConfiguration{
+					Type:  engine.SyncConfigurationPeers,
+					Peers: []string{"https://peer.example.com"},
+				},
+			},
+		},
+		"Registers an unsynchronized topic manager when no sync type is given": {
+			name: "tm_new",
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				SyncConfiguration:   &engine.SyncConfiguration{Type: engine.SyncConfigurationNone},
+			},
+		},
+		"Fails when the topic manager name is empty": {
+			expectedError: app.NewEmptyTopicManagerNameError(),
+		},
+		"Fails when the sync type is unknown": {
+			name:          "tm_new",
+			syncType:      "gossip",
+			expectedError: app.NewUnknownTopicSyncTypeError("gossip"),
+		},
+		"Fails when the topic manager is already registered": {
+			name: "tm_new",
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				Error:               engine.ErrTopicManagerAlreadyRegistered,
+			},
+			expectedError: app.NewTopicManagerAlreadyRegisteredError("tm_new"),
+		},
+		"Fails when the engine has no topic manager factory": {
+			name: "tm_new",
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				Error:               fmt.Errorf("wrapped: %w", engine.ErrTopicManagerFactoryNotConfigured),
+			},
+			expectedError: app.NewTopicManagerFactoryNotConfiguredError(),
+		},
+		"Fails when the provider fails": {
+			name: "tm_new",
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				Error:               errTopicManagerRegistrationTestError,
+			},
+			expectedError: app.NewTopicManagerRegistrationProviderError(errTopicManagerRegistrationTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicManagerRegistrationProviderMock(t, tc.expectations)
+			service := app.NewTopicManagerRegistrationService(mock)
+
+			// when:
+			err := service.RegisterTopicManager(context.Background(), tc.name, tc.syncType, tc.peers)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestTopicManagerRegistrationService_UnregisterTopicManager(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		expectations  testabilities.TopicManagerRegistrationProviderMockExpectations
+		expectedError error
+	}{
+		"Unregisters the topic manager": {
+			name: "tm_old",
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				UnregisterTopicManagerCall: true,
+				Name:                       "tm_old",
+			},
+		},
+		"Fails when the topic manager name is empty": {
+			expectedError: app.NewEmptyTopicManagerNameError(),
+		},
+		"Fails when the topic manager is not registered": {
+			name: "tm_old",
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				UnregisterTopicManagerCall: true,
+				Error:                      engine.ErrTopicManagerNotRegistered,
+			},
+			expectedError: app.NewTopicManagerNotRegisteredError("tm_old"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicManagerRegistrationProviderMock(t, tc.expectations)
+			service := app.NewTopicManagerRegistrationService(mock)
+
+			// when:
+			err := service.UnregisterTopicManager(context.Background(), tc.name)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
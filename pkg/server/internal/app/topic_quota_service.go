@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// TopicQuotaProvider defines the contract for overriding the output quotas of topics at runtime.
+type TopicQuotaProvider interface {
+	TopicQuotaSettings(ctx context.Context) engine.TopicQuotaSettings
+	SetTopicQuota(ctx context.Context, topic string, quota engine.TopicQuota) error
+	RemoveTopicQuota(ctx context.Context, topic string) error
+}
+
+// TopicQuotaService coordinates overriding and restoring the output quotas of topics.
+type TopicQuotaService struct {
+	provider TopicQuotaProvider
+}
+
+// SetTopicQuota overrides the quota of the topic and returns the resulting quota settings.
+// Returns an error if:
+// - The topic is missing or not hosted, or the quota is invalid (ErrorTypeIncorrectInput)
+// - The provider enforces no topic quotas (ErrorTypeUnsupportedOperation)
+// - The provider fails to override the quota (ErrorTypeProviderFailure)
+func (s *TopicQuotaService) SetTopicQuota(ctx context.Context, topic string, quota engine.TopicQuota) (engine.TopicQuotaSettings, error) {
+	if topic == "" {
+		return engine.TopicQuotaSettings{}, NewIncorrectInputWithFieldError("topic")
+	}
+	switch quota.Action {
+	case "", engine.TopicQuotaActionReject, engine.TopicQuotaActionTruncate:
+	default:
+		return engine.TopicQuotaSettings{}, NewUnknownTopicQuotaActionError(string(quota.Action))
+	}
+	if quota.MaxOutputsPerTx < 0 {
+		return engine.TopicQuotaSettings{}, NewIncorrectInputWithFieldError("maxOutputsPerTx")
+	}
+	if err := s.provider.SetTopicQuota(ctx, topic, quota); err != nil {
+		return engine.TopicQuotaSettings{}, newTopicQuotaError(err, topic)
+	}
+	return s.provider.TopicQuotaSettings(ctx), nil
+}
+
+// RemoveTopicQuota removes the override of the topic and returns the resulting quota settings.
+// It returns the same errors as SetTopicQuota, with the ErrorTypeUnsupportedOperation error
+// carrying NotFoundErrorCode when the topic has no override.
+func (s *TopicQuotaService) RemoveTopicQuota(ctx context.Context, topic string) (engine.TopicQuotaSettings, error) {
+	if topic == "" {
+		return engine.TopicQuotaSettings{}, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.provider.RemoveTopicQuota(ctx, topic); err != nil {
+		return engine.TopicQuotaSettings{}, newTopicQuotaError(err, topic)
+	}
+	return s.provider.TopicQuotaSettings(ctx), nil
+}
+
+// TopicQuotaSettings returns the configured topic quotas and the overrides set at runtime.
+func (s *TopicQuotaService) TopicQuotaSettings(ctx context.Context) engine.TopicQuotaSettings {
+	return s.provider.TopicQuotaSettings(ctx)
+}
+
+// NewTopicQuotaService creates a new TopicQuotaService with the given provider.
+// Panics if the provider is nil.
+func NewTopicQuotaService(provider TopicQuotaProvider) *TopicQuotaService {
+	if provider == nil {
+		panic("topic quota provider cannot be nil")
+	}
+
+	return &TopicQuotaService{provider: provider}
+}
+
+func newTopicQuotaError(err error, topic string) Error {
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return NewTopicQuotaUnknownTopicError(topic)
+	case errors.Is(err, engine.ErrInvalidTopicQuota):
+		return NewInvalidTopicQuotaError(err)
+	case errors.Is(err, engine.ErrTopicQuotaNotOverridden):
+		return NewTopicQuotaNotOverriddenError(topic)
+	case errors.Is(err, engine.ErrTopicQuotasNotConfigured):
+		return NewTopicQuotasUnsupportedError(err)
+	default:
+		return NewTopicQuotaProviderError(err)
+	}
+}
+
+// NewUnknownTopicQuotaActionError returns an Error indicating that the requested quota action is not supported.
+func NewUnknownTopicQuotaActionError(action string) Error {
+	msg := fmt.Sprintf("Unknown topic quota action %q. Use %q or %q.", action, engine.TopicQuotaActionReject, engine.TopicQuotaActionTruncate)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewInvalidTopicQuotaError returns an Error indicating that the provider rejected the quota as invalid.
+func NewInvalidTopicQuotaError(err error) Error {
+	return NewIncorrectInputError(err.Error(), "The topic quota is invalid.")
+}
+
+// NewTopicQuotaUnknownTopicError returns an Error indicating that the topic whose quota
+// should be overridden is not hosted by the overlay node.
+func NewTopicQuotaUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewTopicQuotaNotOverriddenError returns an Error indicating that the topic has no quota override to remove.
+func NewTopicQuotaNotOverriddenError(topic string) Error {
+	msg := fmt.Sprintf("The quota of the topic %q is not overridden.", topic)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewTopicQuotasUnsupportedError returns an Error indicating that the overlay node enforces no topic quotas.
+func NewTopicQuotasUnsupportedError(err error) Error {
+	return NewUnsupportedOperationError(
+		err.Error(),
+		"Topic quotas are not enforced by this overlay node. Configure topic quotas to override them.",
+	)
+}
+
+// NewTopicQuotaProviderError returns an Error indicating that the configured provider
+// failed to change the topic quotas.
+func NewTopicQuotaProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to update the topic quotas due to an internal error. Please try again later or contact the support team.",
+	)
+}
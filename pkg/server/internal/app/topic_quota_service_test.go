@@ -0,0 +1,155 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errTopicQuotaTestError = errors.New("internal topic quota service test error")
+
+func TestTopicQuotaService_SetTopicQuota(t *testing.T) {
+	quota := engine.TopicQuota{MaxOutputsPerTx: 10, Action: engine.TopicQuotaActionTruncate}
+	settings := engine.TopicQuotaSettings{
+		Topics:    map[string]engine.TopicQuota{},
+		Overrides: map[string]engine.TopicQuota{"tm_helloworld": quota},
+	}
+
+	tests := map[string]struct {
+		topic            string
+		quota            engine.TopicQuota
+		expectations     testabilities.TopicQuotaProviderMockExpectations
+		expectedSettings engine.TopicQuotaSettings
+		expectedError    error
+	}{
+		"Overrides the quota": {
+			topic: "tm_helloworld",
+			quota: quota,
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Topic:             "tm_helloworld",
+				Quota:             &quota,
+				Settings:          settings,
+			},
+			expectedSettings: settings,
+		},
+		"Fails when the topic is empty": {
+			quota:         quota,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the action is unknown": {
+			topic:         "tm_helloworld",
+			quota:         engine.TopicQuota{Action: "drop"},
+			expectedError: app.NewUnknownTopicQuotaActionError("drop"),
+		},
+		"Fails when the max outputs per transaction is negative": {
+			topic:         "tm_helloworld",
+			quota:         engine.TopicQuota{MaxOutputsPerTx: -1},
+			expectedError: app.NewIncorrectInputWithFieldError("maxOutputsPerTx"),
+		},
+		"Fails when the topic is not hosted": {
+			topic: "tm_unknown",
+			quota: quota,
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Error:             engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewTopicQuotaUnknownTopicError("tm_unknown"),
+		},
+		"Fails when the topic quotas are not configured": {
+			topic: "tm_helloworld",
+			quota: quota,
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Error:             engine.ErrTopicQuotasNotConfigured,
+			},
+			expectedError: app.NewTopicQuotasUnsupportedError(engine.ErrTopicQuotasNotConfigured),
+		},
+		"Fails when the provider fails": {
+			topic: "tm_helloworld",
+			quota: quota,
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Error:             errTopicQuotaTestError,
+			},
+			expectedError: app.NewTopicQuotaProviderError(errTopicQuotaTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicQuotaProviderMock(t, tc.expectations)
+			service := app.NewTopicQuotaService(mock)
+
+			// when:
+			settings, err := service.SetTopicQuota(context.Background(), tc.topic, tc.quota)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedSettings, settings)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestTopicQuotaService_RemoveTopicQuota(t *testing.T) {
+	settings := engine.TopicQuotaSettings{Topics: map[string]engine.TopicQuota{}, Overrides: map[string]engine.TopicQuota{}}
+
+	tests := map[string]struct {
+		topic            string
+		expectations     testabilities.TopicQuotaProviderMockExpectations
+		expectedSettings engine.TopicQuotaSettings
+		expectedError    error
+	}{
+		"Removes the override": {
+			topic: "tm_helloworld",
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				RemoveTopicQuotaCall: true,
+				Topic:                "tm_helloworld",
+				Settings:             settings,
+			},
+			expectedSettings: settings,
+		},
+		"Fails when the topic is empty": {
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the topic has no override": {
+			topic: "tm_helloworld",
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				RemoveTopicQuotaCall: true,
+				Error:                engine.ErrTopicQuotaNotOverridden,
+			},
+			expectedError: app.NewTopicQuotaNotOverriddenError("tm_helloworld"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicQuotaProviderMock(t, tc.expectations)
+			service := app.NewTopicQuotaService(mock)
+
+			// when:
+			settings, err := service.RemoveTopicQuota(context.Background(), tc.topic)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedSettings, settings)
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// TopicStatsProvider defines the contract for aggregating the statistics of the hosted topics.
+type TopicStatsProvider interface {
+	TopicStats(ctx context.Context) (*engine.OverlayStats, error)
+}
+
+// TopicStatsService coordinates the aggregation of topic statistics.
+type TopicStatsService struct {
+	provider TopicStatsProvider
+}
+
+// TopicStats returns the output counts and GASP sync progress of every hosted topic.
+// Returns an error if:
+// - The storage does not support topic statistics (ErrorTypeUnsupportedOperation)
+// - The provider fails to aggregate the statistics (ErrorTypeProviderFailure)
+func (s *TopicStatsService) TopicStats(ctx context.Context) (*engine.OverlayStats, error) {
+	stats, err := s.provider.TopicStats(ctx)
+	switch {
+	case errors.Is(err, engine.ErrTopicStatsNotSupported):
+		return nil, NewTopicStatsNotSupportedError(err)
+	case err != nil:
+		return nil, NewTopicStatsProviderError(err)
+	}
+	return stats, nil
+}
+
+// NewTopicStatsService creates a new TopicStatsService with the given provider.
+// Panics if the provider is nil.
+func NewTopicStatsService(provider TopicStatsProvider) *TopicStatsService {
+	if provider == nil {
+		panic("topic stats provider cannot be nil")
+	}
+
+	return &TopicStatsService{provider: provider}
+}
+
+// NewTopicStatsNotSupportedError returns an Error indicating that the overlay storage
+// cannot aggregate topic statistics.
+func NewTopicStatsNotSupportedError(err error) Error {
+	return NewUnsupportedOperationError(
+		err.Error(),
+		"Topic statistics are not supported by the storage of this overlay node.",
+	)
+}
+
+// NewTopicStatsProviderError returns an Error indicating that the configured provider
+// failed to aggregate the topic statistics.
+func NewTopicStatsProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to aggregate the topic statistics due to an internal error. Please try again later or contact the support team.",
+	)
+}
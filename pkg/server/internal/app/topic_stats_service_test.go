@@ -0,0 +1,74 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errTopicStatsTestError = errors.New("internal topic stats service test error")
+
+func TestTopicStatsService_TopicStats(t *testing.T) {
+	stats := &engine.OverlayStats{
+		Topics: []*engine.TopicStats{{
+			Topic:            testabilities.DefaultValidTopic,
+			Outputs:          engine.TopicOutputCounts{Total: 3, Unspent: 2, Spent: 1},
+			PeerInteractions: map[string]float64{"https://peer.example": 10},
+		}},
+		LastAdvertisementSync: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		expectations  testabilities.TopicStatsProviderMockExpectations
+		expectedStats *engine.OverlayStats
+		expectedError error
+	}{
+		"Returns the topic statistics": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Stats:          stats,
+			},
+			expectedStats: stats,
+		},
+		"Fails when the storage does not support topic statistics": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Error:          engine.ErrTopicStatsNotSupported,
+			},
+			expectedError: app.NewTopicStatsNotSupportedError(engine.ErrTopicStatsNotSupported),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Error:          errTopicStatsTestError,
+			},
+			expectedError: app.NewTopicStatsProviderError(errTopicStatsTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicStatsProviderMock(t, tc.expectations)
+			service := app.NewTopicStatsService(mock)
+
+			// when:
+			actual, err := service.TopicStats(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedStats, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
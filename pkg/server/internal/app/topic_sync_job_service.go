@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// TopicSyncJobProvider defines the contract for running one-off GASP syncs of single topics
+// in the background and polling the resulting jobs.
+type TopicSyncJobProvider interface {
+	SyncTopic(ctx context.Context, request engine.TopicSyncRequest) (*engine.TopicSyncJob, error)
+	FindTopicSyncJob(ctx context.Context, id string) (*engine.TopicSyncJob, error)
+}
+
+// TopicSyncJobService coordinates one-off syncs of single topics using the configured TopicSyncJobProvider.
+type TopicSyncJobService struct {
+	provider TopicSyncJobProvider
+}
+
+// SyncTopic starts a one-off GASP sync of the requested topic and returns the running job.
+// Returns an error if:
+// - The topic or one of the peers is empty, or the topic is not hosted by the overlay node (ErrorTypeIncorrectInput)
+// - Topic sync jobs are not enabled (ErrorTypeUnsupportedOperation)
+// - The provider temporarily rejects syncs, e.g. while stopping (ErrorTypeServiceUnavailable)
+// - The provider fails to start the sync (ErrorTypeProviderFailure)
+func (s *TopicSyncJobService) SyncTopic(ctx context.Context, request engine.TopicSyncRequest) (*engine.TopicSyncJob, error) {
+	if request.Topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	for _, peer := range request.Peers {
+		if peer == "" {
+			return nil, NewIncorrectInputWithFieldError("peers")
+		}
+	}
+
+	job, err := s.provider.SyncTopic(ctx, request)
+	if err != nil {
+		var readOnlyErr *engine.StorageReadOnlyError
+		switch {
+		case errors.Is(err, engine.ErrUnknownTopic):
+			return nil, NewTopicSyncUnknownTopicError(request.Topic)
+		case errors.Is(err, engine.ErrTopicSyncJobsNotConfigured):
+			return nil, NewTopicSyncJobsNotConfiguredError()
+		case errors.As(err, &readOnlyErr):
+			return nil, NewTopicSyncUnavailableError(readOnlyErr.RetryAfter)
+		case errors.Is(err, engine.ErrEngineStopping):
+			return nil, NewTopicSyncUnavailableError(0)
+		default:
+			return nil, NewTopicSyncJobProviderError(err)
+		}
+	}
+	return job, nil
+}
+
+// GetTopicSyncJob returns the topic sync job with the given ID.
+// Returns an error if:
+// - The ID is empty (ErrorTypeIncorrectInput)
+// - The job is not found or topic sync jobs are not enabled (ErrorTypeUnsupportedOperation)
+// - The provider fails to find the job (ErrorTypeProviderFailure)
+func (s *TopicSyncJobService) GetTopicSyncJob(ctx context.Context, id string) (*engine.TopicSyncJob, error) {
+	if id == "" {
+		return nil, NewIncorrectInputWithFieldError("jobID")
+	}
+
+	job, err := s.provider.FindTopicSyncJob(ctx, id)
+	switch {
+	case errors.Is(err, engine.ErrTopicSyncJobNotFound):
+		return nil, NewTopicSyncJobNotFoundError(id)
+	case errors.Is(err, engine.ErrTopicSyncJobsNotConfigured):
+		return nil, NewTopicSyncJobsNotConfiguredError()
+	case err != nil:
+		return nil, NewTopicSyncJobProviderError(err)
+	}
+	return job, nil
+}
+
+// NewTopicSyncJobService creates a new TopicSyncJobService with the given provider.
+// Panics if the provider is nil.
+func NewTopicSyncJobService(provider TopicSyncJobProvider) *TopicSyncJobService {
+	if provider == nil {
+		panic("topic sync job provider cannot be nil")
+	}
+
+	return &TopicSyncJobService{provider: provider}
+}
+
+// NewTopicSyncUnknownTopicError returns an Error indicating that the topic to sync
+// is not hosted by the overlay node.
+func NewTopicSyncUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewTopicSyncJobNotFoundError returns an Error indicating that no topic sync job exists with the given ID,
+// or that it is no longer retained.
+func NewTopicSyncJobNotFoundError(id string) Error {
+	msg := fmt.Sprintf("The topic sync job %q was not found.", id)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewTopicSyncJobsNotConfiguredError returns an Error indicating that the overlay engine
+// does not run one-off topic syncs.
+func NewTopicSyncJobsNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrTopicSyncJobsNotConfigured.Error(),
+		"Syncing single topics is not enabled on this overlay node.",
+	)
+}
+
+// NewTopicSyncUnavailableError returns an Error indicating that the configured provider
+// temporarily rejects syncs, e.g. because its storage became read-only or it is stopping.
+func NewTopicSyncUnavailableError(retryAfter time.Duration) Error {
+	return NewServiceUnavailableError(
+		"topic sync provider rejects syncs",
+		"Syncing topics is temporarily unavailable. Please try again later.",
+		retryAfter,
+	)
+}
+
+// NewTopicSyncJobProviderError returns an Error indicating that the configured provider
+// failed to start a topic sync or find a job.
+func NewTopicSyncJobProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the topic sync due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,155 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errTopicSyncJobTestError = errors.New("internal topic sync job service test error")
+
+func TestTopicSyncJobService_SyncTopic(t *testing.T) {
+	job := &engine.TopicSyncJob{ID: "job-1", Topic: testabilities.DefaultValidTopic, Status: engine.TopicSyncJobRunning}
+
+	tests := map[string]struct {
+		request       engine.TopicSyncRequest
+		expectations  testabilities.TopicSyncJobProviderMockExpectations
+		expectedJob   *engine.TopicSyncJob
+		expectedError error
+	}{
+		"Starts the sync of the topic": {
+			request: engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic, Peers: []string{"https://peer.example"}},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Request:       &engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic, Peers: []string{"https://peer.example"}},
+				Job:           job,
+			},
+			expectedJob: job,
+		},
+		"Fails for an empty topic": {
+			request:       engine.TopicSyncRequest{},
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails for an empty peer": {
+			request:       engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic, Peers: []string{""}},
+			expectedError: app.NewIncorrectInputWithFieldError("peers"),
+		},
+		"Fails for a topic not hosted by the node": {
+			request: engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Error:         engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewTopicSyncUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when topic sync jobs are not configured": {
+			request: engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Error:         engine.ErrTopicSyncJobsNotConfigured,
+			},
+			expectedError: app.NewTopicSyncJobsNotConfiguredError(),
+		},
+		"Fails as unavailable when the storage is read-only": {
+			request: engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Error:         &engine.StorageReadOnlyError{RetryAfter: time.Minute},
+			},
+			expectedError: app.NewTopicSyncUnavailableError(time.Minute),
+		},
+		"Fails when the provider fails": {
+			request: engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Error:         errTopicSyncJobTestError,
+			},
+			expectedError: app.NewTopicSyncJobProviderError(errTopicSyncJobTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicSyncJobProviderMock(t, tc.expectations)
+			service := app.NewTopicSyncJobService(mock)
+
+			// when:
+			actual, err := service.SyncTopic(context.Background(), tc.request)
+
+			// then:
+			require.Equal(t, tc.expectedJob, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestTopicSyncJobService_GetTopicSyncJob(t *testing.T) {
+	job := &engine.TopicSyncJob{ID: "job-1", Topic: testabilities.DefaultValidTopic, Status: engine.TopicSyncJobCompleted}
+
+	tests := map[string]struct {
+		id            string
+		expectations  testabilities.TopicSyncJobProviderMockExpectations
+		expectedJob   *engine.TopicSyncJob
+		expectedError error
+	}{
+		"Returns the job": {
+			id: "job-1",
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				FindTopicSyncJobCall: true,
+				Job:                  job,
+			},
+			expectedJob: job,
+		},
+		"Fails for an empty ID": {
+			expectedError: app.NewIncorrectInputWithFieldError("jobID"),
+		},
+		"Fails for an unknown job": {
+			id: "job-2",
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				FindTopicSyncJobCall: true,
+				Error:                engine.ErrTopicSyncJobNotFound,
+			},
+			expectedError: app.NewTopicSyncJobNotFoundError("job-2"),
+		},
+		"Fails when the provider fails": {
+			id: "job-1",
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				FindTopicSyncJobCall: true,
+				Error:                errTopicSyncJobTestError,
+			},
+			expectedError: app.NewTopicSyncJobProviderError(errTopicSyncJobTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicSyncJobProviderMock(t, tc.expectations)
+			service := app.NewTopicSyncJobService(mock)
+
+			// when:
+			actual, err := service.GetTopicSyncJob(context.Background(), tc.id)
+
+			// then:
+			require.Equal(t, tc.expectedJob, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
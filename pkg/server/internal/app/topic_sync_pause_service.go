@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// TopicSyncPauseProvider defines the contract for pausing and resuming the GASP sync
+// and advertisement of single topics at runtime.
+type TopicSyncPauseProvider interface {
+	PauseTopicSync(ctx context.Context, topic string) error
+	ResumeTopicSync(ctx context.Context, topic string) error
+	PausedSyncTopics(ctx context.Context) []string
+}
+
+// TopicSyncPauseService coordinates pausing and resuming the sync of topics.
+type TopicSyncPauseService struct {
+	provider TopicSyncPauseProvider
+}
+
+// PauseTopicSync pauses the GASP sync and advertisement of the topic and returns the paused topics.
+// Returns an error if:
+// - The topic is empty or not hosted by the overlay node (ErrorTypeIncorrectInput)
+// - The provider temporarily rejects writes (ErrorTypeServiceUnavailable)
+// - The provider fails to pause the sync (ErrorTypeProviderFailure)
+func (s *TopicSyncPauseService) PauseTopicSync(ctx context.Context, topic string) ([]string, error) {
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.provider.PauseTopicSync(ctx, topic); err != nil {
+		return nil, newTopicSyncPauseError(err, topic)
+	}
+	return s.provider.PausedSyncTopics(ctx), nil
+}
+
+// ResumeTopicSync resumes the GASP sync and advertisement of the topic and returns the topics still paused.
+// It returns the same errors as PauseTopicSync.
+func (s *TopicSyncPauseService) ResumeTopicSync(ctx context.Context, topic string) ([]string, error) {
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.provider.ResumeTopicSync(ctx, topic); err != nil {
+		return nil, newTopicSyncPauseError(err, topic)
+	}
+	return s.provider.PausedSyncTopics(ctx), nil
+}
+
+// PausedSyncTopics returns the topics whose GASP sync and advertisement are paused.
+func (s *TopicSyncPauseService) PausedSyncTopics(ctx context.Context) []string {
+	return s.provider.PausedSyncTopics(ctx)
+}
+
+// NewTopicSyncPauseService creates a new TopicSyncPauseService with the given provider.
+// Panics if the provider is nil.
+func NewTopicSyncPauseService(provider TopicSyncPauseProvider) *TopicSyncPauseService {
+	if provider == nil {
+		panic("topic sync pause provider cannot be nil")
+	}
+
+	return &TopicSyncPauseService{provider: provider}
+}
+
+func newTopicSyncPauseError(err error, topic string) Error {
+	var readOnlyErr *engine.StorageReadOnlyError
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return NewTopicSyncPauseUnknownTopicError(topic)
+	case errors.As(err, &readOnlyErr):
+		return NewTopicSyncPauseUnavailableError(readOnlyErr.RetryAfter)
+	default:
+		return NewTopicSyncPauseProviderError(err)
+	}
+}
+
+// NewTopicSyncPauseUnknownTopicError returns an Error indicating that the topic whose sync
+// should be paused or resumed is not hosted by the overlay node.
+func NewTopicSyncPauseUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewTopicSyncPauseUnavailableError returns an Error indicating that the configured provider
+// temporarily rejects pausing and resuming syncs, e.g. because its storage became read-only.
+func NewTopicSyncPauseUnavailableError(retryAfter time.Duration) Error {
+	return NewServiceUnavailableError(
+		"topic sync pause provider rejects writes",
+		"Pausing and resuming topic syncs is temporarily unavailable. Please try again later.",
+		retryAfter,
+	)
+}
+
+// NewTopicSyncPauseProviderError returns an Error indicating that the configured provider
+// failed to pause or resume the sync of the topic.
+func NewTopicSyncPauseProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to update the topic sync pause due to an internal error. Please try again later or contact the support team.",
+	)
+}
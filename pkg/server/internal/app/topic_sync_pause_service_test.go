@@ -0,0 +1,99 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errTopicSyncPauseTestError = errors.New("internal topic sync pause service test error")
+
+func TestTopicSyncPauseService_PauseTopicSync(t *testing.T) {
+	tests := map[string]struct {
+		topic          string
+		expectations   testabilities.TopicSyncPauseProviderMockExpectations
+		expectedTopics []string
+		expectedError  error
+	}{
+		"Pauses the sync of the topic": {
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.TopicSyncPauseProviderMockExpectations{
+				PauseTopicSyncCall: true,
+				Topic:              testabilities.DefaultValidTopic,
+				PausedTopics:       []string{testabilities.DefaultValidTopic},
+			},
+			expectedTopics: []string{testabilities.DefaultValidTopic},
+		},
+		"Fails when the topic is empty": {
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the topic is not hosted": {
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.TopicSyncPauseProviderMockExpectations{
+				PauseTopicSyncCall: true,
+				Error:              engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewTopicSyncPauseUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when the storage is read-only": {
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.TopicSyncPauseProviderMockExpectations{
+				PauseTopicSyncCall: true,
+				Error:              &engine.StorageReadOnlyError{RetryAfter: time.Minute},
+			},
+			expectedError: app.NewTopicSyncPauseUnavailableError(time.Minute),
+		},
+		"Fails when the provider fails": {
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.TopicSyncPauseProviderMockExpectations{
+				PauseTopicSyncCall: true,
+				Error:              errTopicSyncPauseTestError,
+			},
+			expectedError: app.NewTopicSyncPauseProviderError(errTopicSyncPauseTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTopicSyncPauseProviderMock(t, tc.expectations)
+			service := app.NewTopicSyncPauseService(mock)
+
+			// when:
+			topics, err := service.PauseTopicSync(context.Background(), tc.topic)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedTopics, topics)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestTopicSyncPauseService_ResumeTopicSync(t *testing.T) {
+	// given:
+	mock := testabilities.NewTopicSyncPauseProviderMock(t, testabilities.TopicSyncPauseProviderMockExpectations{
+		ResumeTopicSyncCall: true,
+		Topic:               testabilities.DefaultValidTopic,
+		PausedTopics:        []string{},
+	})
+	service := app.NewTopicSyncPauseService(mock)
+
+	// when:
+	topics, err := service.ResumeTopicSync(context.Background(), testabilities.DefaultValidTopic)
+
+	// then:
+	require.NoError(t, err)
+	require.Empty(t, topics)
+	mock.AssertCalled()
+}
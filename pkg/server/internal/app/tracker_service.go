@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// TrackerProvider defines the contract for changing the SHIP and SLAP trackers of the overlay node at runtime.
+type TrackerProvider interface {
+	Trackers(ctx context.Context) engine.Trackers
+	AddTracker(ctx context.Context, kind engine.TrackerKind, tracker string) error
+	RemoveTracker(ctx context.Context, kind engine.TrackerKind, tracker string) error
+}
+
+// TrackerService coordinates adding and removing SHIP and SLAP trackers.
+type TrackerService struct {
+	provider TrackerProvider
+}
+
+// AddTracker adds the tracker of the kind, "ship" or "slap", and returns the resulting trackers.
+// Returns an error if:
+// - The kind or URL is missing or invalid, or the tracker is already configured (ErrorTypeIncorrectInput)
+// - The provider temporarily rejects writes (ErrorTypeServiceUnavailable)
+// - The provider fails to add the tracker (ErrorTypeProviderFailure)
+func (s *TrackerService) AddTracker(ctx context.Context, kind, tracker string) (engine.Trackers, error) {
+	trackerKind, err := parseTrackerKind(kind)
+	if err != nil {
+		return engine.Trackers{}, err
+	}
+	if tracker == "" {
+		return engine.Trackers{}, NewIncorrectInputWithFieldError("url")
+	}
+	if err := s.provider.AddTracker(ctx, trackerKind, tracker); err != nil {
+		return engine.Trackers{}, newTrackerError(err, trackerKind, tracker)
+	}
+	return s.provider.Trackers(ctx), nil
+}
+
+// RemoveTracker removes the tracker of the kind, "ship" or "slap", and returns the remaining trackers.
+// It returns the same errors as AddTracker, and an ErrorTypeUnsupportedOperation error
+// when the tracker is not configured.
+func (s *TrackerService) RemoveTracker(ctx context.Context, kind, tracker string) (engine.Trackers, error) {
+	trackerKind, err := parseTrackerKind(kind)
+	if err != nil {
+		return engine.Trackers{}, err
+	}
+	if tracker == "" {
+		return engine.Trackers{}, NewIncorrectInputWithFieldError("url")
+	}
+	if err := s.provider.RemoveTracker(ctx, trackerKind, tracker); err != nil {
+		return engine.Trackers{}, newTrackerError(err, trackerKind, tracker)
+	}
+	return s.provider.Trackers(ctx), nil
+}
+
+// Trackers returns the SHIP and SLAP trackers of the overlay node.
+func (s *TrackerService) Trackers(ctx context.Context) engine.Trackers {
+	return s.provider.Trackers(ctx)
+}
+
+// NewTrackerService creates a new TrackerService with the given provider.
+// Panics if the provider is nil.
+func NewTrackerService(provider TrackerProvider) *TrackerService {
+	if provider == nil {
+		panic("tracker provider cannot be nil")
+	}
+
+	return &TrackerService{provider: provider}
+}
+
+func parseTrackerKind(kind string) (engine.TrackerKind, error) {
+	switch engine.TrackerKind(kind) {
+	case engine.TrackerKindSHIP, engine.TrackerKindSLAP:
+		return engine.TrackerKind(kind), nil
+	case "":
+		return "", NewIncorrectInputWithFieldError("kind")
+	default:
+		return "", NewUnknownTrackerKindError(kind)
+	}
+}
+
+func newTrackerError(err error, kind engine.TrackerKind, tracker string) Error {
+	var readOnlyErr *engine.StorageReadOnlyError
+	switch {
+	case errors.Is(err, engine.ErrInvalidTracker):
+		return NewInvalidTrackerError(tracker)
+	case errors.Is(err, engine.ErrTrackerAlreadyConfigured):
+		return NewTrackerAlreadyConfiguredError(kind, tracker)
+	case errors.Is(err, engine.ErrTrackerNotConfigured):
+		return NewTrackerNotConfiguredError(kind, tracker)
+	case errors.As(err, &readOnlyErr):
+		return NewTrackerUnavailableError(readOnlyErr.RetryAfter)
+	default:
+		return NewTrackerProviderError(err)
+	}
+}
+
+// NewUnknownTrackerKindError returns an Error indicating that the requested tracker kind is not supported.
+func NewUnknownTrackerKindError(kind string) Error {
+	msg := fmt.Sprintf("Unknown tracker kind %q. Use %q or %q.", kind, engine.TrackerKindSHIP, engine.TrackerKindSLAP)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewInvalidTrackerError returns an Error indicating that the tracker URL is not an HTTP(S) URL.
+func NewInvalidTrackerError(tracker string) Error {
+	msg := fmt.Sprintf("The tracker %q is not a valid HTTP(S) URL.", tracker)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewTrackerAlreadyConfiguredError returns an Error indicating that the tracker is already
+// among the trackers of the kind.
+func NewTrackerAlreadyConfiguredError(kind engine.TrackerKind, tracker string) Error {
+	msg := fmt.Sprintf("The %s tracker %q is already configured.", kind, tracker)
+	return NewIncorrectInputError(msg, msg)
+}
+
+// NewTrackerNotConfiguredError returns an Error indicating that the tracker is not
+// among the trackers of the kind.
+func NewTrackerNotConfiguredError(kind engine.TrackerKind, tracker string) Error {
+	msg := fmt.Sprintf("The %s tracker %q is not configured.", kind, tracker)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewTrackerUnavailableError returns an Error indicating that the configured provider
+// temporarily rejects tracker changes, e.g. because its storage became read-only.
+func NewTrackerUnavailableError(retryAfter time.Duration) Error {
+	return NewServiceUnavailableError(
+		"tracker provider rejects writes",
+		"Changing the trackers is temporarily unavailable. Please try again later.",
+		retryAfter,
+	)
+}
+
+// NewTrackerProviderError returns an Error indicating that the configured provider
+// failed to change the trackers.
+func NewTrackerProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to update the trackers due to an internal error. Please try again later or contact the support team.",
+	)
+}
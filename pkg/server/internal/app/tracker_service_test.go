@@ -0,0 +1,153 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+const testTracker = "https://tracker.example.com"
+
+var errTrackerTestError = errors.New("internal tracker service test error")
+
+func TestTrackerService_AddTracker(t *testing.T) {
+	tests := map[string]struct {
+		kind             string
+		tracker          string
+		expectations     testabilities.TrackerProviderMockExpectations
+		expectedTrackers engine.Trackers
+		expectedError    error
+	}{
+		"Adds the tracker": {
+			kind:    "slap",
+			tracker: testTracker,
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Kind:           engine.TrackerKindSLAP,
+				Tracker:        testTracker,
+				Trackers:       engine.Trackers{SLAP: []string{testTracker}},
+			},
+			expectedTrackers: engine.Trackers{SLAP: []string{testTracker}},
+		},
+		"Fails when the kind is empty": {
+			tracker:       testTracker,
+			expectedError: app.NewIncorrectInputWithFieldError("kind"),
+		},
+		"Fails when the kind is unknown": {
+			kind:          "gasp",
+			tracker:       testTracker,
+			expectedError: app.NewUnknownTrackerKindError("gasp"),
+		},
+		"Fails when the URL is empty": {
+			kind:          "ship",
+			expectedError: app.NewIncorrectInputWithFieldError("url"),
+		},
+		"Fails when the URL is invalid": {
+			kind:    "ship",
+			tracker: "tracker.example.com",
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Error:          engine.ErrInvalidTracker,
+			},
+			expectedError: app.NewInvalidTrackerError("tracker.example.com"),
+		},
+		"Fails when the tracker is already configured": {
+			kind:    "ship",
+			tracker: testTracker,
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Error:          engine.ErrTrackerAlreadyConfigured,
+			},
+			expectedError: app.NewTrackerAlreadyConfiguredError(engine.TrackerKindSHIP, testTracker),
+		},
+		"Fails when the storage is read-only": {
+			kind:    "ship",
+			tracker: testTracker,
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Error:          &engine.StorageReadOnlyError{RetryAfter: time.Minute},
+			},
+			expectedError: app.NewTrackerUnavailableError(time.Minute),
+		},
+		"Fails when the provider fails": {
+			kind:    "ship",
+			tracker: testTracker,
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Error:          errTrackerTestError,
+			},
+			expectedError: app.NewTrackerProviderError(errTrackerTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTrackerProviderMock(t, tc.expectations)
+			service := app.NewTrackerService(mock)
+
+			// when:
+			trackers, err := service.AddTracker(context.Background(), tc.kind, tc.tracker)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedTrackers, trackers)
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestTrackerService_RemoveTracker(t *testing.T) {
+	tests := map[string]struct {
+		expectations     testabilities.TrackerProviderMockExpectations
+		expectedTrackers engine.Trackers
+		expectedError    error
+	}{
+		"Removes the tracker": {
+			expectations: testabilities.TrackerProviderMockExpectations{
+				RemoveTrackerCall: true,
+				Kind:              engine.TrackerKindSHIP,
+				Tracker:           testTracker,
+				Trackers:          engine.Trackers{SHIP: []string{}},
+			},
+			expectedTrackers: engine.Trackers{SHIP: []string{}},
+		},
+		"Fails when the tracker is not configured": {
+			expectations: testabilities.TrackerProviderMockExpectations{
+				RemoveTrackerCall: true,
+				Error:             engine.ErrTrackerNotConfigured,
+			},
+			expectedError: app.NewTrackerNotConfiguredError(engine.TrackerKindSHIP, testTracker),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewTrackerProviderMock(t, tc.expectations)
+			service := app.NewTrackerService(mock)
+
+			// when:
+			trackers, err := service.RemoveTracker(context.Background(), "ship", testTracker)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedTrackers, trackers)
+			mock.AssertCalled()
+		})
+	}
+}
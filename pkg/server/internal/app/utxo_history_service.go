@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// UTXOHistoryProvider defines the contract for hydrating the history of an output admitted to a topic.
+type UTXOHistoryProvider interface {
+	FindUTXOHistory(ctx context.Context, query engine.UTXOHistoryQuery) (*engine.Output, error)
+}
+
+// UTXOHistoryService coordinates the retrieval of output histories, validating the
+// requested outpoint before delegating the hydration to the provider.
+type UTXOHistoryService struct {
+	provider UTXOHistoryProvider
+	access   AccessControlList
+}
+
+// FindUTXOHistory returns the output at txID.outputIndex admitted to the topic, with its BEEF hydrated
+// with its ancestors up to the given depth. A nil depth hydrates up to the limit of the provider.
+// Returns an error if:
+// - The transaction ID or topic is invalid, the topic is unknown or the depth exceeds the limit (ErrorTypeIncorrectInput)
+// - The output was not admitted to the topic (ErrorTypeUnsupportedOperation)
+// - The API key set on ctx is not permitted to access the topic, or the history exceeds the size limit (ErrorTypeAccessForbidden)
+// - The provider fails to hydrate the history (ErrorTypeProviderFailure)
+func (s *UTXOHistoryService) FindUTXOHistory(ctx context.Context, txID string, outputIndex uint32, topic string, depth *uint32) (*engine.Output, error) {
+	hash, err := chainhash.NewHashFromHex(txID)
+	if err != nil {
+		return nil, NewInvalidTxIDFormatError(err)
+	}
+	if topic == "" {
+		return nil, NewIncorrectInputWithFieldError("topic")
+	}
+	if err := s.access.AuthorizeTopics(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	outpoint := transaction.Outpoint{Txid: *hash, Index: outputIndex}
+	output, err := s.provider.FindUTXOHistory(ctx, engine.UTXOHistoryQuery{Outpoint: outpoint, Topic: topic, Depth: depth})
+	switch {
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return nil, NewUTXOHistoryUnknownTopicError(topic)
+	case errors.Is(err, engine.ErrUTXOHistoryDepthExceeded):
+		return nil, NewUTXOHistoryDepthExceededError(err)
+	case errors.Is(err, engine.ErrUTXOHistoryTooLarge):
+		return nil, NewUTXOHistoryTooLargeError(err)
+	case errors.Is(err, engine.ErrUnableToFindOutput):
+		return nil, NewUTXOHistoryOutputNotFoundError(outpoint.String(), topic)
+	case err != nil:
+		return nil, NewUTXOHistoryProviderError(err)
+	case output == nil:
+		return nil, NewUTXOHistoryOutputNotFoundError(outpoint.String(), topic)
+	}
+	return output, nil
+}
+
+// NewUTXOHistoryService creates a new UTXOHistoryService with the given provider and the access control list
+// restricting the topics each API key may access.
+// Panics if the provider is nil.
+func NewUTXOHistoryService(provider UTXOHistoryProvider, access AccessControlList) *UTXOHistoryService {
+	if provider == nil {
+		panic("utxo history provider cannot be nil")
+	}
+
+	return &UTXOHistoryService{provider: provider, access: access}
+}
+
+// NewUTXOHistoryUnknownTopicError returns an Error indicating that the overlay node does not host the topic.
+func NewUTXOHistoryUnknownTopicError(topic string) Error {
+	msg := fmt.Sprintf("The topic %q is not hosted by this overlay node.", topic)
+	return NewIncorrectInputError(msg, msg).WithCode(UnknownTopicErrorCode)
+}
+
+// NewUTXOHistoryDepthExceededError returns an Error indicating that the requested history depth
+// exceeds the limit configured by the operator.
+func NewUTXOHistoryDepthExceededError(err error) Error {
+	return NewIncorrectInputError(
+		err.Error(),
+		"The requested history depth exceeds the limit of this overlay node. Please request a smaller depth.",
+	)
+}
+
+// NewUTXOHistoryTooLargeError returns an Error indicating that the history exceeds the size limit
+// configured by the operator.
+func NewUTXOHistoryTooLargeError(err error) Error {
+	return NewAccessForbiddenError(
+		err.Error(),
+		"The history of the output exceeds the size limit of this overlay node. Please request a smaller depth.",
+	)
+}
+
+// NewUTXOHistoryOutputNotFoundError returns an Error indicating that the output was not admitted to the topic.
+func NewUTXOHistoryOutputNotFoundError(outpoint, topic string) Error {
+	msg := fmt.Sprintf("The output %s was not found in topic %q.", outpoint, topic)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewUTXOHistoryProviderError returns an Error indicating that the configured provider
+// failed to hydrate the history of the output.
+func NewUTXOHistoryProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to retrieve the history of the output due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,167 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+var errUTXOHistoryTestError = errors.New("internal utxo history service test error")
+
+func TestUTXOHistoryService_FindUTXOHistory(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := transaction.Outpoint{Txid: *txid, Index: 1}
+	depth := uint32(3)
+	output := &engine.Output{Outpoint: outpoint, Topic: testabilities.DefaultValidTopic, Beef: []byte{0x01, 0x02}}
+	depthErr := fmt.Errorf("%w: 300 > 100", engine.ErrUTXOHistoryDepthExceeded)
+	sizeErr := fmt.Errorf("%w: more than 1024 bytes", engine.ErrUTXOHistoryTooLarge)
+
+	tests := map[string]struct {
+		txID           string
+		topic          string
+		expectations   testabilities.UTXOHistoryProviderMockExpectations
+		expectedOutput *engine.Output
+		expectedError  error
+	}{
+		"Returns the hydrated output": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Query:               &engine.UTXOHistoryQuery{Outpoint: outpoint, Topic: testabilities.DefaultValidTopic, Depth: &depth},
+				Output:              output,
+			},
+			expectedOutput: output,
+		},
+		"Fails when the transaction ID is invalid": {
+			txID:          testabilities.DefaultValidTxID + "0",
+			topic:         testabilities.DefaultValidTopic,
+			expectedError: app.NewInvalidTxIDFormatError(chainhash.ErrHashStrSize),
+		},
+		"Fails when the topic is empty": {
+			txID:          testabilities.DefaultValidTxID,
+			expectedError: app.NewIncorrectInputWithFieldError("topic"),
+		},
+		"Fails when the topic is unknown": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewUTXOHistoryUnknownTopicError(testabilities.DefaultValidTopic),
+		},
+		"Fails when the depth exceeds the limit": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               depthErr,
+			},
+			expectedError: app.NewUTXOHistoryDepthExceededError(depthErr),
+		},
+		"Fails when the history exceeds the size limit": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               sizeErr,
+			},
+			expectedError: app.NewUTXOHistoryTooLargeError(sizeErr),
+		},
+		"Fails when the output is not found": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               engine.ErrUnableToFindOutput,
+			},
+			expectedError: app.NewUTXOHistoryOutputNotFoundError(outpoint.String(), testabilities.DefaultValidTopic),
+		},
+		"Fails when the provider fails": {
+			txID:  testabilities.DefaultValidTxID,
+			topic: testabilities.DefaultValidTopic,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               errUTXOHistoryTestError,
+			},
+			expectedError: app.NewUTXOHistoryProviderError(errUTXOHistoryTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewUTXOHistoryProviderMock(t, tc.expectations)
+			service := app.NewUTXOHistoryService(mock, app.AccessControlList{})
+
+			// when:
+			actual, err := service.FindUTXOHistory(context.Background(), tc.txID, 1, tc.topic, &depth)
+
+			// then:
+			require.Equal(t, tc.expectedOutput, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestUTXOHistoryService_AccessControl(t *testing.T) {
+	acl := app.AccessControlList{TopicKeys: map[string][]string{testabilities.DefaultValidTopic: {"private-key"}}}
+
+	tests := map[string]struct {
+		apiKey        string
+		expectations  testabilities.UTXOHistoryProviderMockExpectations
+		expectedError error
+	}{
+		"History of a restricted topic without an API key is denied": {
+			expectations:  testabilities.UTXOHistoryProviderMockExpectations{FindUTXOHistoryCall: false},
+			expectedError: app.NewTopicAccessDeniedError(testabilities.DefaultValidTopic),
+		},
+		"History of a restricted topic with another API key is denied": {
+			apiKey:        "other-key",
+			expectations:  testabilities.UTXOHistoryProviderMockExpectations{FindUTXOHistoryCall: false},
+			expectedError: app.NewTopicAccessDeniedError(testabilities.DefaultValidTopic),
+		},
+		"History of a restricted topic with a permitted API key is returned": {
+			apiKey: "private-key",
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Output:              &engine.Output{Topic: testabilities.DefaultValidTopic},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewUTXOHistoryProviderMock(t, tc.expectations)
+			service := app.NewUTXOHistoryService(mock, acl)
+			ctx := app.WithAPIKey(context.Background(), tc.apiKey)
+
+			// when:
+			_, err := service.FindUTXOHistory(ctx, testabilities.DefaultValidTxID, 1, testabilities.DefaultValidTopic, nil)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+)
+
+// WebhookProvider defines the contract for managing the webhook subscriptions
+// notified with the STEAK of submissions.
+type WebhookProvider interface {
+	RegisterWebhook(ctx context.Context, callbackURL string, topics []string, secret string) (*engine.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]*engine.WebhookSubscription, error)
+	UnregisterWebhook(ctx context.Context, id string) error
+}
+
+// WebhookService coordinates the registration of webhook subscriptions.
+type WebhookService struct {
+	provider WebhookProvider
+}
+
+// RegisterWebhook subscribes the callback URL to the STEAK of submissions for the given topics.
+// Returns an error if:
+// - The URL is empty or not an absolute http(s) URL, or a topic is unknown (ErrorTypeIncorrectInput)
+// - The engine has no webhooks configured (ErrorTypeUnsupportedOperation)
+// - The provider fails to register the subscription (ErrorTypeProviderFailure)
+func (s *WebhookService) RegisterWebhook(ctx context.Context, callbackURL string, topics []string, secret string) (*engine.WebhookSubscription, error) {
+	if callbackURL == "" {
+		return nil, NewIncorrectInputWithFieldError("url")
+	}
+
+	subscription, err := s.provider.RegisterWebhook(ctx, callbackURL, topics, secret)
+	switch {
+	case errors.Is(err, engine.ErrInvalidWebhookURL):
+		return nil, NewInvalidWebhookURLError(callbackURL)
+	case errors.Is(err, engine.ErrUnknownTopic):
+		return nil, NewUnknownWebhookTopicError()
+	case err != nil:
+		return nil, newWebhookError(err)
+	}
+	return subscription, nil
+}
+
+// ListWebhooks returns the registered webhook subscriptions.
+// Returns an error if:
+// - The engine has no webhooks configured (ErrorTypeUnsupportedOperation)
+// - The provider fails to list the subscriptions (ErrorTypeProviderFailure)
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*engine.WebhookSubscription, error) {
+	subscriptions, err := s.provider.ListWebhooks(ctx)
+	if err != nil {
+		return nil, newWebhookError(err)
+	}
+	return subscriptions, nil
+}
+
+// UnregisterWebhook removes the webhook subscription with the given ID.
+// Returns an error if:
+// - The ID is empty (ErrorTypeIncorrectInput)
+// - The subscription is not found or the engine has no webhooks configured (ErrorTypeUnsupportedOperation)
+// - The provider fails to unregister the subscription (ErrorTypeProviderFailure)
+func (s *WebhookService) UnregisterWebhook(ctx context.Context, id string) error {
+	if id == "" {
+		return NewIncorrectInputWithFieldError("id")
+	}
+
+	err := s.provider.UnregisterWebhook(ctx, id)
+	switch {
+	case errors.Is(err, engine.ErrWebhookNotFound):
+		return NewWebhookNotFoundError(id)
+	case err != nil:
+		return newWebhookError(err)
+	}
+	return nil
+}
+
+// NewWebhookService creates a new WebhookService with the given provider.
+// Panics if the provider is nil.
+func NewWebhookService(provider WebhookProvider) *WebhookService {
+	if provider == nil {
+		panic("webhook provider cannot be nil")
+	}
+
+	return &WebhookService{provider: provider}
+}
+
+func newWebhookError(err error) Error {
+	if errors.Is(err, engine.ErrWebhooksNotConfigured) {
+		return NewWebhooksNotConfiguredError()
+	}
+	return NewWebhookProviderError(err)
+}
+
+// NewInvalidWebhookURLError returns an Error indicating that the webhook URL is not an absolute http(s) URL.
+func NewInvalidWebhookURLError(callbackURL string) Error {
+	return NewIncorrectInputError(
+		fmt.Sprintf("invalid webhook URL: %q", callbackURL),
+		"The webhook URL must be an absolute http or https URL.",
+	)
+}
+
+// NewUnknownWebhookTopicError returns an Error indicating that a topic of the webhook is not hosted by the overlay.
+func NewUnknownWebhookTopicError() Error {
+	return NewIncorrectInputError(
+		engine.ErrUnknownTopic.Error(),
+		"One or more topics of the webhook are not hosted by this overlay node.",
+	).WithCode(UnknownTopicErrorCode)
+}
+
+// NewWebhookNotFoundError returns an Error indicating that no webhook subscription exists with the given ID.
+func NewWebhookNotFoundError(id string) Error {
+	msg := fmt.Sprintf("The webhook %q was not found.", id)
+	return NewUnsupportedOperationError(msg, msg).WithCode(NotFoundErrorCode)
+}
+
+// NewWebhooksNotConfiguredError returns an Error indicating that the overlay does not deliver webhooks.
+func NewWebhooksNotConfiguredError() Error {
+	return NewUnsupportedOperationError(
+		engine.ErrWebhooksNotConfigured.Error(),
+		"Webhooks are not enabled on this overlay node.",
+	)
+}
+
+// NewWebhookProviderError returns an Error indicating that the configured provider
+// failed to manage the webhook subscriptions.
+func NewWebhookProviderError(err error) Error {
+	return NewProviderFailureError(
+		err.Error(),
+		"Unable to process the webhook subscriptions due to an internal error. Please try again later or contact the support team.",
+	)
+}
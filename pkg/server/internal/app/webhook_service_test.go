@@ -0,0 +1,198 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/stretchr/testify/require"
+)
+
+var errWebhookTestError = errors.New("internal webhook service test error")
+
+func TestWebhookService_RegisterWebhook(t *testing.T) {
+	const callbackURL = "https://example.com/steak"
+	subscription := &engine.WebhookSubscription{ID: "id", URL: callbackURL, Topics: []string{testabilities.DefaultValidTopic}, Secret: "secret"}
+
+	tests := map[string]struct {
+		url                  string
+		expectations         testabilities.WebhookProviderMockExpectations
+		expectedSubscription *engine.WebhookSubscription
+		expectedError        error
+	}{
+		"Returns the registered subscription": {
+			url: callbackURL,
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				URL:                 callbackURL,
+				Topics:              []string{testabilities.DefaultValidTopic},
+				Subscription:        subscription,
+			},
+			expectedSubscription: subscription,
+		},
+		"Fails when the URL is empty": {
+			expectedError: app.NewIncorrectInputWithFieldError("url"),
+		},
+		"Fails when the URL is invalid": {
+			url: "ftp://example.com",
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Error:               engine.ErrInvalidWebhookURL,
+			},
+			expectedError: app.NewInvalidWebhookURLError("ftp://example.com"),
+		},
+		"Fails when a topic is unknown": {
+			url: callbackURL,
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Error:               engine.ErrUnknownTopic,
+			},
+			expectedError: app.NewUnknownWebhookTopicError(),
+		},
+		"Fails when webhooks are not configured": {
+			url: callbackURL,
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Error:               engine.ErrWebhooksNotConfigured,
+			},
+			expectedError: app.NewWebhooksNotConfiguredError(),
+		},
+		"Fails when the provider fails": {
+			url: callbackURL,
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Error:               errWebhookTestError,
+			},
+			expectedError: app.NewWebhookProviderError(errWebhookTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewWebhookProviderMock(t, tc.expectations)
+			service := app.NewWebhookService(mock)
+
+			// when:
+			actual, err := service.RegisterWebhook(context.Background(), tc.url, []string{testabilities.DefaultValidTopic}, "secret")
+
+			// then:
+			require.Equal(t, tc.expectedSubscription, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestWebhookService_ListWebhooks(t *testing.T) {
+	subscriptions := []*engine.WebhookSubscription{{ID: "id", URL: "https://example.com/steak"}}
+
+	tests := map[string]struct {
+		expectations          testabilities.WebhookProviderMockExpectations
+		expectedSubscriptions []*engine.WebhookSubscription
+		expectedError         error
+	}{
+		"Returns the subscriptions": {
+			expectations: testabilities.WebhookProviderMockExpectations{
+				ListWebhooksCall: true,
+				Subscriptions:    subscriptions,
+			},
+			expectedSubscriptions: subscriptions,
+		},
+		"Fails when webhooks are not configured": {
+			expectations: testabilities.WebhookProviderMockExpectations{
+				ListWebhooksCall: true,
+				Error:            engine.ErrWebhooksNotConfigured,
+			},
+			expectedError: app.NewWebhooksNotConfiguredError(),
+		},
+		"Fails when the provider fails": {
+			expectations: testabilities.WebhookProviderMockExpectations{
+				ListWebhooksCall: true,
+				Error:            errWebhookTestError,
+			},
+			expectedError: app.NewWebhookProviderError(errWebhookTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewWebhookProviderMock(t, tc.expectations)
+			service := app.NewWebhookService(mock)
+
+			// when:
+			actual, err := service.ListWebhooks(context.Background())
+
+			// then:
+			require.Equal(t, tc.expectedSubscriptions, actual)
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
+
+func TestWebhookService_UnregisterWebhook(t *testing.T) {
+	tests := map[string]struct {
+		id            string
+		expectations  testabilities.WebhookProviderMockExpectations
+		expectedError error
+	}{
+		"Unregisters the subscription": {
+			id: "id",
+			expectations: testabilities.WebhookProviderMockExpectations{
+				UnregisterWebhookCall: true,
+				ID:                    "id",
+			},
+		},
+		"Fails when the ID is empty": {
+			expectedError: app.NewIncorrectInputWithFieldError("id"),
+		},
+		"Fails when the subscription is not found": {
+			id: "id",
+			expectations: testabilities.WebhookProviderMockExpectations{
+				UnregisterWebhookCall: true,
+				Error:                 engine.ErrWebhookNotFound,
+			},
+			expectedError: app.NewWebhookNotFoundError("id"),
+		},
+		"Fails when the provider fails": {
+			id: "id",
+			expectations: testabilities.WebhookProviderMockExpectations{
+				UnregisterWebhookCall: true,
+				Error:                 errWebhookTestError,
+			},
+			expectedError: app.NewWebhookProviderError(errWebhookTestError),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			mock := testabilities.NewWebhookProviderMock(t, tc.expectations)
+			service := app.NewWebhookService(mock)
+
+			// when:
+			err := service.UnregisterWebhook(context.Background(), tc.id)
+
+			// then:
+			if tc.expectedError != nil {
+				require.Equal(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mock.AssertCalled()
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdvertisementHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to manually list, create and revoke the SHIP/SLAP advertisements of the overlay node.
+// It acts as the adapter between HTTP requests and the application-layer AdvertisementService.
+type AdvertisementHandler struct {
+	service *app.AdvertisementService
+}
+
+// HandleList processes an HTTP GET request listing the current advertisements.
+//
+// On success, returns 200 OK with the Advertisements response. On failure, returns an application error.
+func (h *AdvertisementHandler) HandleList(c *fiber.Ctx) error {
+	advertisements, err := h.service.ListAdvertisements(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewAdvertisementsResponse(advertisements))
+}
+
+// HandleCreate processes an HTTP POST request to create an advertisement.
+// It expects a JSON request body matching the CreateAdvertisementJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK with the STEAK of the advertisement transaction.
+// On failure, returns a request parsing or application error.
+func (h *AdvertisementHandler) HandleCreate(c *fiber.Ctx) error {
+	var body openapi.CreateAdvertisementJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	steak, err := h.service.CreateAdvertisement(c.UserContext(), body.Protocol, body.TopicOrService)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(&steak))
+}
+
+// HandleRevoke processes an HTTP DELETE request to revoke the advertisement passed as the outpoint query parameter.
+//
+// On success, returns 200 OK with the STEAK of the revocation transaction. On failure, returns an application error.
+func (h *AdvertisementHandler) HandleRevoke(c *fiber.Ctx, params openapi.RevokeAdvertisementParams) error {
+	steak, err := h.service.RevokeAdvertisement(c.UserContext(), params.Outpoint)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(&steak))
+}
+
+// NewAdvertisementHandler creates a new AdvertisementHandler with the given provider.
+// If the provider is nil, it panics.
+func NewAdvertisementHandler(provider app.AdvertisementProvider) *AdvertisementHandler {
+	return &AdvertisementHandler{service: app.NewAdvertisementService(provider)}
+}
+
+// NewAdvertisementsResponse converts engine advertisements into an Advertisements object
+// compatible with the OpenAPI specification.
+func NewAdvertisementsResponse(advertisements []*engine.ListedAdvertisement) openapi.Advertisements {
+	response := openapi.Advertisements{Advertisements: make([]openapi.Advertisement, 0, len(advertisements))}
+	for _, ad := range advertisements {
+		response.Advertisements = append(response.Advertisements, openapi.Advertisement{
+			Outpoint:       ad.Outpoint.String(),
+			Protocol:       string(ad.Protocol),
+			IdentityKey:    ad.IdentityKey,
+			Domain:         ad.Domain,
+			TopicOrService: ad.TopicOrService,
+		})
+	}
+	return response
+}
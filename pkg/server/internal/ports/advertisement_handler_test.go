@@ -0,0 +1,177 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvertisementHandler_List(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	outpoint, err := transaction.OutpointFromString("0000000000000000000000000000000000000000000000000000000000000001.1")
+	require.NoError(t, err)
+	advertisements := []*engine.ListedAdvertisement{{
+		Outpoint:       *outpoint,
+		Protocol:       overlay.ProtocolSHIP,
+		IdentityKey:    "02aa",
+		Domain:         "https://overlay.example.com",
+		TopicOrService: testabilities.DefaultValidTopic,
+	}}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithAdvertisementProvider(
+		testabilities.NewAdvertisementProviderMock(t, testabilities.AdvertisementProviderMockExpectations{
+			ListAdvertisementsCall: true,
+			Advertisements:         advertisements,
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Advertisements
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/advertisements")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewAdvertisementsResponse(advertisements), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestAdvertisementHandler_Create(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	steak := overlay.Steak{"tm_ship": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.AdvertisementProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Creates the advertisement": {
+			body: map[string]any{"protocol": "SHIP", "topicOrService": testabilities.DefaultValidTopic},
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				CreateAdvertisementCall: true,
+				Protocol:                overlay.ProtocolSHIP,
+				TopicOrService:          testabilities.DefaultValidTopic,
+				Steak:                   steak,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: *ports.NewSubmitTransactionSuccessResponse(&steak),
+		},
+		"Rejects an empty topic or service": {
+			body:             map[string]any{"protocol": "SHIP"},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("topicOrService")),
+		},
+		"Responds with not found when the advertiser is not configured": {
+			body: map[string]any{"protocol": "SLAP", "topicOrService": "ls_example"},
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				CreateAdvertisementCall: true,
+				Error:                   engine.ErrAdvertiserNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewAdvertiserNotConfiguredError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithAdvertisementProvider(
+				testabilities.NewAdvertisementProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.SubmitTransactionResponse
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/advertisements")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestAdvertisementHandler_Revoke(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	const outpoint = "0000000000000000000000000000000000000000000000000000000000000001.1"
+	steak := overlay.Steak{"tm_ship": &overlay.AdmittanceInstructions{CoinsRemoved: []uint32{0}}}
+
+	tests := map[string]struct {
+		expectations     testabilities.AdvertisementProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Revokes the advertisement": {
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				RevokeAdvertisementCall: true,
+				Steak:                   steak,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: *ports.NewSubmitTransactionSuccessResponse(&steak),
+		},
+		"Responds with not found when the advertisement does not exist": {
+			expectations: testabilities.AdvertisementProviderMockExpectations{
+				RevokeAdvertisementCall: true,
+				Error:                   engine.ErrAdvertisementNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewAdvertisementNotFoundError(outpoint)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithAdvertisementProvider(
+				testabilities.NewAdvertisementProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.SubmitTransactionResponse
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("outpoint", outpoint).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Delete("/api/v1/admin/advertisements")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
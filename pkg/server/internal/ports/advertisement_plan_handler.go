@@ -0,0 +1,65 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdvertisementPlanHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to preview the next advertisement sync run. It acts as the adapter between HTTP requests
+// and the application-layer AdvertisementPlanService.
+type AdvertisementPlanHandler struct {
+	service *app.AdvertisementPlanService
+}
+
+// Handle processes an HTTP GET request previewing the advertisements a sync run would create and revoke.
+//
+// On success, returns 200 OK with the AdvertisementPlan response. On failure, returns an application error.
+func (h *AdvertisementPlanHandler) Handle(c *fiber.Ctx) error {
+	plan, err := h.service.PlanAdvertisements(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewAdvertisementPlanResponse(plan))
+}
+
+// NewAdvertisementPlanHandler creates a new AdvertisementPlanHandler with the given provider.
+// If the provider is nil, it panics.
+func NewAdvertisementPlanHandler(provider app.AdvertisementPlanProvider) *AdvertisementPlanHandler {
+	return &AdvertisementPlanHandler{service: app.NewAdvertisementPlanService(provider)}
+}
+
+// NewAdvertisementPlanResponse converts an engine advertisement plan into an AdvertisementPlan object
+// compatible with the OpenAPI specification.
+func NewAdvertisementPlanResponse(plan *engine.AdvertisementPlan) openapi.AdvertisementPlan {
+	response := openapi.AdvertisementPlan{
+		Create: make([]openapi.PlannedAdvertisement, 0, len(plan.Create)),
+		Revoke: make([]openapi.PlannedAdvertisement, 0, len(plan.Revoke)),
+		Cost: openapi.AdvertisementCost{
+			OutputsCreated: plan.Cost.OutputsCreated,
+			OutputsRevoked: plan.Cost.OutputsRevoked,
+			LockedSatoshis: plan.Cost.LockedSatoshis,
+			FeeSatoshis:    plan.Cost.FeeSatoshis,
+			TotalSatoshis:  plan.Cost.TotalSatoshis,
+			BudgetSatoshis: plan.Cost.BudgetSatoshis,
+			WithinBudget:   plan.Cost.WithinBudget,
+		},
+	}
+	for _, ad := range plan.Create {
+		response.Create = append(response.Create, openapi.PlannedAdvertisement{
+			Protocol:       string(ad.Protocol),
+			TopicOrService: ad.TopicOrServiceName,
+		})
+	}
+	for _, ad := range plan.Revoke {
+		domain := ad.Domain
+		response.Revoke = append(response.Revoke, openapi.PlannedAdvertisement{
+			Protocol:       string(ad.Protocol),
+			TopicOrService: ad.TopicOrService,
+			Domain:         &domain,
+		})
+	}
+	return response
+}
@@ -0,0 +1,83 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvertisementPlanHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	plan := &engine.AdvertisementPlan{
+		Create: []*advertiser.AdvertisementData{{Protocol: "SLAP", TopicOrServiceName: testabilities.DefaultValidTopic}},
+		Revoke: []*advertiser.Advertisement{{Protocol: "SHIP", TopicOrService: "tm_old", Domain: "https://overlay.example.com"}},
+		Cost: engine.AdvertisementCost{
+			OutputsCreated: 1,
+			OutputsRevoked: 1,
+			LockedSatoshis: 1,
+			FeeSatoshis:    71,
+			TotalSatoshis:  72,
+			BudgetSatoshis: 50,
+		},
+	}
+
+	tests := map[string]struct {
+		expectations     testabilities.AdvertisementPlanProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Previews the advertisement sync run": {
+			expectations: testabilities.AdvertisementPlanProviderMockExpectations{
+				PlanAdvertisementsCall: true,
+				Plan:                   plan,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewAdvertisementPlanResponse(plan),
+		},
+		"Responds with internal server error when the provider fails": {
+			expectations: testabilities.AdvertisementPlanProviderMockExpectations{
+				PlanAdvertisementsCall: true,
+				Error:                  testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewAdvertisementPlanProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithAdvertisementPlanProvider(
+				testabilities.NewAdvertisementPlanProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.AdvertisementPlan
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/admin/advertisementPlan")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
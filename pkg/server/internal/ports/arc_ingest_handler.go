@@ -2,6 +2,7 @@
 package ports
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
@@ -34,7 +35,15 @@ func (h *ARCIngestHandler) Handle(c *fiber.Ctx) error {
 		return NewRequestBodyParserError(err)
 	}
 
-	err = h.service.ProcessIngest(c.Context(), body.Txid, body.MerklePath, body.BlockHeight)
+	var blockHash, proofSource string
+	if body.BlockHash != nil {
+		blockHash = *body.BlockHash
+	}
+	if body.ProofSource != nil {
+		proofSource = *body.ProofSource
+	}
+
+	err = h.service.ProcessIngest(c.Context(), body.Txid, body.MerklePath, body.BlockHeight, blockHash, proofSource)
 	if err != nil {
 		return err
 	}
@@ -51,6 +60,51 @@ func NewARCIngestHandler(provider engine.OverlayEngineProvider) *ARCIngestHandle
 	return &ARCIngestHandler{service: app.NewARCIngestService(provider)}
 }
 
+// ARCIngestBatchHandler is a Fiber-compatible HTTP handler that accepts the batches of Merkle proofs
+// delivered by ARC batch callbacks and delegates their concurrent processing to the ARCIngestService.
+type ARCIngestBatchHandler struct {
+	service *app.ARCIngestService
+}
+
+// Handle processes an HTTP POST request for ingesting a batch of Merkle proofs.
+// It expects a JSON body matching the ArcIngestBatchBody OpenAPI definition.
+//
+// Each entry is validated and processed independently, so a malformed or rejected proof
+// does not fail the batch. On success, it returns a 200 OK response with the outcome of each entry
+// (openapi.ArcIngestBatchResponse); an empty or oversized batch returns the corresponding application error.
+func (h *ARCIngestBatchHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.ArcIngestBatchBody
+
+	err := c.BodyParser(&body)
+	if err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	entries := make([]app.ARCIngestEntry, len(body))
+	for i, entry := range body {
+		entries[i] = app.ARCIngestEntry{TxID: entry.Txid, MerklePath: entry.MerklePath, BlockHeight: entry.BlockHeight}
+		if entry.BlockHash != nil {
+			entries[i].BlockHash = *entry.BlockHash
+		}
+		if entry.ProofSource != nil {
+			entries[i].ProofSource = *entry.ProofSource
+		}
+	}
+
+	results, err := h.service.ProcessIngestBatch(c.Context(), entries)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(NewARCIngestBatchResponse(results))
+}
+
+// NewARCIngestBatchHandler creates a new ARCIngestBatchHandler using the given
+// OverlayEngineProvider as the underlying provider for the ARCIngestService.
+func NewARCIngestBatchHandler(provider engine.OverlayEngineProvider) *ARCIngestBatchHandler {
+	return &ARCIngestBatchHandler{service: app.NewARCIngestService(provider)}
+}
+
 // NewARCIngestSuccessResponse returns a standardized success response
 // when a Merkle proof is successfully ingested.
 //
@@ -61,3 +115,24 @@ func NewARCIngestSuccessResponse(txID string) *openapi.ArcIngestResponse {
 		Message: fmt.Sprintf("Transaction with ID:%s successfully ingested.", txID),
 	}
 }
+
+// NewARCIngestBatchResponse converts the outcomes of a batch of Merkle proofs into an
+// OpenAPI-compatible ArcIngestBatchResponse. Failed entries carry the code and slug of their application error.
+func NewARCIngestBatchResponse(results []app.ARCIngestResult) *openapi.ArcIngestBatchResponse {
+	response := openapi.ArcIngestBatchResponse{Results: make([]openapi.ArcIngestBatchResult, len(results))}
+	for i, result := range results {
+		if result.Err == nil {
+			success := NewARCIngestSuccessResponse(result.TxID)
+			response.Results[i] = openapi.ArcIngestBatchResult{Txid: result.TxID, Status: success.Status, Message: success.Message}
+			continue
+		}
+
+		var target app.Error
+		if !errors.As(result.Err, &target) {
+			target = app.NewUnknownError(result.Err.Error(), "Unable to process Merkle proof due to an unexpected error.")
+		}
+		code := target.Code()
+		response.Results[i] = openapi.ArcIngestBatchResult{Txid: result.TxID, Status: "error", Code: &code, Message: target.Slug()}
+	}
+	return &response
+}
@@ -1,13 +1,18 @@
 package ports_test
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/decorators"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/require"
 )
@@ -42,8 +47,8 @@ func TestArcIngestHandler_InvalidCases(t *testing.T) {
 			},
 		},
 		"Authorization header with invalid Bearer token": {
-			expectedStatusCode: fiber.StatusForbidden,
-			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, decorators.NewInvalidBearerTokenError()),
+			expectedStatusCode: fiber.StatusUnauthorized,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, decorators.NewInvalidCallbackTokenError()),
 			headers: map[string]string{
 				fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
 				fiber.HeaderAuthorization: "Bearer invalidtoken",
@@ -127,3 +132,268 @@ func TestArcIngestHandler_ValidCase(t *testing.T) {
 
 	stub.AssertProvidersState()
 }
+
+func TestArcIngestHandler_ShouldAcceptCallbackTokensOfAnyARCInstance(t *testing.T) {
+	// given:
+	pool, err := engine.NewARCPool(engine.ARCPoolConfig{
+		Endpoints: []engine.ARCEndpointConfig{
+			{URL: "https://arc-a.example.com", CallbackToken: "token-a"},
+			{URL: "https://arc-b.example.com", CallbackToken: "token-b"},
+		},
+	})
+	require.NoError(t, err)
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithARCIngestProvider(
+		testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{HandleNewMerkleProofCall: true})),
+	)
+
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithARCCallbackTokens(pool),
+	)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+			fiber.HeaderAuthorization: "Bearer token-b",
+		}).
+		SetBody(openapi.ArcIngestBody{
+			Txid:        testabilities.NewTxID(t),
+			MerklePath:  testabilities.NewTestMerklePath(t),
+			BlockHeight: testabilities.DefaultBlockHeight,
+		}).
+		Post("/api/v1/arc-ingest")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+
+	stub.AssertProvidersState()
+}
+
+func TestArcIngestHandler_ShouldVerifyCallbackSignaturesOfSigningARCInstances(t *testing.T) {
+	body, err := json.Marshal(openapi.ArcIngestBody{
+		Txid:        testabilities.NewTxID(t),
+		MerklePath:  testabilities.NewTestMerklePath(t),
+		BlockHeight: testabilities.DefaultBlockHeight,
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		signature          string
+		expectedStatusCode int
+		expectedResponse   openapi.Error
+		expectations       testabilities.ARCIngestProviderMockExpectations
+	}{
+		"Callback signed with the secret of the ARC instance": {
+			signature:          engine.SignARCCallback("secret-a", body),
+			expectedStatusCode: fiber.StatusOK,
+			expectations:       testabilities.ARCIngestProviderMockExpectations{HandleNewMerkleProofCall: true},
+		},
+		"Callback without signature": {
+			expectedStatusCode: fiber.StatusUnauthorized,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, decorators.NewInvalidCallbackSignatureError()),
+		},
+		"Callback signed with another secret": {
+			signature:          engine.SignARCCallback("secret-b", body),
+			expectedStatusCode: fiber.StatusUnauthorized,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, decorators.NewInvalidCallbackSignatureError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			pool, err := engine.NewARCPool(engine.ARCPoolConfig{
+				Endpoints: []engine.ARCEndpointConfig{{URL: "https://arc-a.example.com", CallbackToken: "token-a", SigningSecret: "secret-a"}},
+			})
+			require.NoError(t, err)
+
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithARCIngestProvider(
+				testabilities.NewARCIngestProviderMock(t, tc.expectations)),
+			)
+
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithARCCallbackTokens(pool),
+			)
+
+			// when:
+			var actualResponse openapi.Error
+
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(map[string]string{
+					fiber.HeaderContentType:               fiber.MIMEApplicationJSON,
+					fiber.HeaderAuthorization:             "Bearer token-a",
+					decorators.ARCCallbackSignatureHeader: tc.signature,
+				}).
+				SetBody(body).
+				SetError(&actualResponse).
+				Post("/api/v1/arc-ingest")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestArcIngestHandler_ShouldPassBlockHashAndProofSource(t *testing.T) {
+	// given:
+	blockHash := "0000000000000000000000000000000000000000000000000000000000000001"
+	proofSource := "arc.taal.com"
+	expectedHash, err := chainhash.NewHashFromHex(blockHash)
+	require.NoError(t, err)
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithARCIngestProvider(
+		testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{
+			HandleAttestedMerkleProofCall: true,
+			Attestation:                   engine.MerkleProofAttestation{BlockHash: expectedHash, Source: proofSource},
+		})),
+	)
+
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithARCCallbackToken(testabilities.DefaultARCCallbackToken),
+		server.WithARCAPIKey(testabilities.DefaultARCAPIKey),
+	)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+			fiber.HeaderAuthorization: "Bearer " + testabilities.DefaultARCCallbackToken,
+		}).
+		SetBody(openapi.ArcIngestBody{
+			Txid:        testabilities.NewTxID(t),
+			MerklePath:  testabilities.NewTestMerklePath(t),
+			BlockHeight: testabilities.DefaultBlockHeight,
+			BlockHash:   &blockHash,
+			ProofSource: &proofSource,
+		}).
+		Post("/api/v1/arc-ingest")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+
+	stub.AssertProvidersState()
+}
+
+func TestArcIngestBatchHandler_ValidCase(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithARCIngestProvider(
+		testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{HandleNewMerkleProofCall: true})),
+	)
+
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithARCCallbackToken(testabilities.DefaultARCCallbackToken),
+		server.WithARCAPIKey(testabilities.DefaultARCAPIKey),
+	)
+
+	txID := testabilities.NewTxID(t)
+	code := app.InvalidInputErrorCode
+	expectedResponse := openapi.ArcIngestBatch{
+		Results: []openapi.ArcIngestBatchResult{
+			{Txid: txID, Status: "success", Message: ports.NewARCIngestSuccessResponse(txID).Message},
+			{Txid: "INVALID-HEX-STR", Status: "error", Code: &code, Message: app.NewInvalidTxIDFormatError(errors.New("")).Slug()},
+		},
+	}
+
+	// when:
+	var actualResponse openapi.ArcIngestBatch
+
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+			fiber.HeaderAuthorization: "Bearer " + testabilities.DefaultARCCallbackToken,
+		}).
+		SetBody(openapi.ArcIngestBatchBody{
+			{Txid: txID, MerklePath: testabilities.NewTestMerklePath(t), BlockHeight: testabilities.DefaultBlockHeight},
+			{Txid: "INVALID-HEX-STR", MerklePath: testabilities.NewTestMerklePath(t), BlockHeight: testabilities.DefaultBlockHeight},
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/arc-ingest/batch")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+
+	stub.AssertProvidersState()
+}
+
+func TestArcIngestBatchHandler_InvalidCases(t *testing.T) {
+	tests := map[string]struct {
+		body               openapi.ArcIngestBatchBody
+		headers            map[string]string
+		expectedStatusCode int
+		expectedResponse   openapi.Error
+	}{
+		"Authorization header with invalid Bearer token": {
+			body: openapi.ArcIngestBatchBody{
+				{Txid: testabilities.NewTxID(t), MerklePath: testabilities.NewTestMerklePath(t), BlockHeight: testabilities.DefaultBlockHeight},
+			},
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+				fiber.HeaderAuthorization: "Bearer invalidtoken",
+			},
+			expectedStatusCode: fiber.StatusUnauthorized,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, decorators.NewInvalidCallbackTokenError()),
+		},
+		"Empty batch": {
+			body: openapi.ArcIngestBatchBody{},
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+				fiber.HeaderAuthorization: "Bearer " + testabilities.DefaultARCCallbackToken,
+			},
+			expectedStatusCode: fiber.StatusBadRequest,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("entries")),
+		},
+		"Batch exceeding the maximum size": {
+			body: make(openapi.ArcIngestBatchBody, app.MaxARCIngestBatchSize+1),
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+				fiber.HeaderAuthorization: "Bearer " + testabilities.DefaultARCCallbackToken,
+			},
+			expectedStatusCode: fiber.StatusRequestEntityTooLarge,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewARCIngestBatchTooLargeError(app.MaxARCIngestBatchSize+1)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithARCIngestProvider(
+				testabilities.NewARCIngestProviderMock(t, testabilities.ARCIngestProviderMockExpectations{})),
+			)
+
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithARCCallbackToken(testabilities.DefaultARCCallbackToken),
+				server.WithARCAPIKey(testabilities.DefaultARCAPIKey),
+			)
+
+			// when:
+			var actualResponse openapi.Error
+
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody(tc.body).
+				SetError(&actualResponse).
+				Post("/api/v1/arc-ingest/batch")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+
+			stub.AssertProvidersState()
+		})
+	}
+}
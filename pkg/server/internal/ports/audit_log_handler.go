@@ -0,0 +1,91 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditLogHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to query the audit log of admin actions and submissions. It acts as the adapter
+// between HTTP requests and the application-layer AuditLogService.
+type AuditLogHandler struct {
+	service *app.AuditLogService
+}
+
+// Handle processes an HTTP GET request querying the audit log with the filters of the query parameters.
+//
+// On success, returns 200 OK with the AuditLog response. On failure, returns an application error.
+func (h *AuditLogHandler) Handle(c *fiber.Ctx, params openapi.ListAuditEntriesParams) error {
+	entries, err := h.service.ListAuditEntries(c.UserContext(), NewAuditQuery(params))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewAuditLogResponse(entries))
+}
+
+// NewAuditLogHandler creates a new AuditLogHandler with the given provider.
+// If the provider is nil, it panics.
+func NewAuditLogHandler(provider app.AuditLogProvider) *AuditLogHandler {
+	return &AuditLogHandler{service: app.NewAuditLogService(provider)}
+}
+
+// NewAuditQuery converts the query parameters of the audit log endpoint into an engine.AuditQuery.
+func NewAuditQuery(params openapi.ListAuditEntriesParams) engine.AuditQuery {
+	var query engine.AuditQuery
+	if params.Action != nil {
+		query.Action = *params.Action
+	}
+	if params.Caller != nil {
+		query.Caller = *params.Caller
+	}
+	if params.Txid != nil {
+		query.Txid = *params.Txid
+	}
+	if params.Since != nil {
+		query.Since = *params.Since
+	}
+	if params.Until != nil {
+		query.Until = *params.Until
+	}
+	if params.Limit != nil {
+		query.Limit = *params.Limit
+	}
+	return query
+}
+
+// NewAuditLogResponse converts engine audit entries into an AuditLog object
+// compatible with the OpenAPI specification.
+func NewAuditLogResponse(entries []*engine.AuditEntry) openapi.AuditLog {
+	response := openapi.AuditLog{Entries: make([]openapi.AuditEntry, 0, len(entries))}
+	for _, entry := range entries {
+		auditEntry := openapi.AuditEntry{
+			Id:         entry.ID,
+			Time:       entry.Time,
+			Action:     entry.Action,
+			Caller:     entry.Caller,
+			RemoteAddr: optionalString(entry.RemoteAddr),
+			RequestId:  optionalString(entry.RequestID),
+			Txid:       optionalString(entry.Txid),
+			Result:     string(entry.Result),
+			Error:      optionalString(entry.Error),
+		}
+		if len(entry.Topics) > 0 {
+			auditEntry.Topics = &entry.Topics
+		}
+		if entry.Status != 0 {
+			auditEntry.Status = &entry.Status
+		}
+		response.Entries = append(response.Entries, auditEntry)
+	}
+	return response
+}
+
+// optionalString returns a pointer to s, or nil if s is empty.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
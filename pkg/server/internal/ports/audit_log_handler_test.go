@@ -0,0 +1,98 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []*engine.AuditEntry{{
+		ID:         "id",
+		Time:       since.Add(time.Minute),
+		Action:     "POST /api/v1/submit",
+		Caller:     "identity:02abc",
+		RemoteAddr: "0.0.0.0",
+		Topics:     []string{testabilities.DefaultValidTopic},
+		Txid:       "txid",
+		Result:     engine.AuditResultFailure,
+		Status:     fiber.StatusBadRequest,
+		Error:      "invalid BEEF",
+	}}
+
+	tests := map[string]struct {
+		queryParams      map[string]string
+		expectations     testabilities.AuditLogProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Returns the audit entries selected by the query parameters": {
+			queryParams: map[string]string{
+				"caller": "identity:02abc",
+				"txid":   "txid",
+				"since":  since.Format(time.RFC3339),
+				"limit":  "5",
+			},
+			expectations: testabilities.AuditLogProviderMockExpectations{
+				FindAuditEntriesCall: true,
+				Query:                &engine.AuditQuery{Caller: "identity:02abc", Txid: "txid", Since: since, Limit: 5},
+				Entries:              entries,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewAuditLogResponse(entries),
+		},
+		"Rejects a negative limit": {
+			queryParams:      map[string]string{"limit": "-1"},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("limit")),
+		},
+		"Responds with not found when the overlay node keeps no audit log": {
+			expectations: testabilities.AuditLogProviderMockExpectations{
+				FindAuditEntriesCall: true,
+				Error:                engine.ErrAuditLogNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewAuditLogNotConfiguredError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithAuditLogProvider(
+				testabilities.NewAuditLogProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.AuditLog
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParams(tc.queryParams).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/admin/auditLog")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BroadcastQueueHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to inspect the transactions waiting to be re-broadcast. It acts as the adapter between
+// HTTP requests and the application-layer BroadcastQueueService.
+type BroadcastQueueHandler struct {
+	service *app.BroadcastQueueService
+}
+
+// Handle processes an HTTP GET request inspecting the re-broadcast queue.
+//
+// On success, returns 200 OK with the BroadcastQueue response. On failure, returns an application error.
+func (h *BroadcastQueueHandler) Handle(c *fiber.Ctx) error {
+	status, err := h.service.BroadcastQueueStatus(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewBroadcastQueueResponse(status))
+}
+
+// NewBroadcastQueueHandler creates a new BroadcastQueueHandler with the given provider.
+// If the provider is nil, it panics.
+func NewBroadcastQueueHandler(provider app.BroadcastQueueProvider) *BroadcastQueueHandler {
+	return &BroadcastQueueHandler{service: app.NewBroadcastQueueService(provider)}
+}
+
+// NewBroadcastQueueResponse converts the engine re-broadcast queue status into a BroadcastQueue object
+// compatible with the OpenAPI specification. The BEEF of each transaction is omitted.
+func NewBroadcastQueueResponse(status *engine.BroadcastQueueStatus) openapi.BroadcastQueue {
+	response := openapi.BroadcastQueue{
+		Broadcasts: make([]openapi.QueuedBroadcast, 0, len(status.Broadcasts)),
+		Metrics: openapi.BroadcastRetryMetrics{
+			Queued:    status.Metrics.Queued,
+			Retried:   status.Metrics.Retried,
+			Succeeded: status.Metrics.Succeeded,
+			Abandoned: status.Metrics.Abandoned,
+		},
+	}
+	for _, broadcast := range status.Broadcasts {
+		response.Broadcasts = append(response.Broadcasts, openapi.QueuedBroadcast{
+			Txid:          broadcast.Txid.String(),
+			Attempts:      broadcast.Attempts,
+			LastError:     broadcast.LastError,
+			FirstFailedAt: broadcast.FirstFailedAt,
+			NextAttemptAt: broadcast.NextAttemptAt,
+			Abandoned:     broadcast.Abandoned,
+		})
+	}
+	return response
+}
@@ -0,0 +1,90 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastQueueHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	status := &engine.BroadcastQueueStatus{
+		Broadcasts: []*engine.QueuedBroadcast{{
+			Beef:          []byte{0x01},
+			Attempts:      20,
+			LastError:     "ARC unavailable",
+			FirstFailedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			NextAttemptAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			Abandoned:     true,
+		}},
+		Metrics: engine.BroadcastRetryMetrics{Queued: 1, Retried: 19, Abandoned: 1},
+	}
+
+	tests := map[string]struct {
+		expectations     testabilities.BroadcastQueueProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Lists the queued broadcasts and retry metrics": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Status:                   status,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewBroadcastQueueResponse(status),
+		},
+		"Responds with not found when broadcast retries are not configured": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Error:                    engine.ErrBroadcastRetryNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewBroadcastQueueNotSupportedError(engine.ErrBroadcastRetryNotConfigured)),
+		},
+		"Responds with internal server error when the provider fails": {
+			expectations: testabilities.BroadcastQueueProviderMockExpectations{
+				BroadcastQueueStatusCall: true,
+				Error:                    testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewBroadcastQueueProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithBroadcastQueueProvider(
+				testabilities.NewBroadcastQueueProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.BroadcastQueue
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/admin/broadcastQueue")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ConfigReloadHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to reload the configuration of the overlay node without restarting it. It acts as
+// the adapter between HTTP requests and the application-layer ConfigReloadService.
+type ConfigReloadHandler struct {
+	service *app.ConfigReloadService
+}
+
+// Handle processes an HTTP POST request reloading the configuration.
+//
+// On success, returns 200 OK with the ConfigReload response. On failure, returns an application error.
+func (h *ConfigReloadHandler) Handle(c *fiber.Ctx) error {
+	applied, err := h.service.ReloadConfig(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewConfigReloadResponse(applied))
+}
+
+// NewConfigReloadHandler creates a new ConfigReloadHandler with the given provider.
+// If the provider is nil, it panics.
+func NewConfigReloadHandler(provider app.ConfigReloadProvider) *ConfigReloadHandler {
+	return &ConfigReloadHandler{service: app.NewConfigReloadService(provider)}
+}
+
+// NewConfigReloadResponse converts the applied settings into a ConfigReload object
+// compatible with the OpenAPI specification.
+func NewConfigReloadResponse(applied []string) openapi.ConfigReload {
+	if applied == nil {
+		applied = []string{}
+	}
+	return openapi.ConfigReload{Applied: applied}
+}
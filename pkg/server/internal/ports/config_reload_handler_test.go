@@ -0,0 +1,171 @@
+package ports_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const configReloadTestToken = "33333333-3333-3333-3333-333333333333"
+
+// newConfigReloadTestConfig returns the configuration of a server whose admin endpoints accept configReloadTestToken.
+func newConfigReloadTestConfig() server.Config {
+	cfg := server.DefaultConfig
+	cfg.AdminBearerToken = configReloadTestToken
+	return cfg
+}
+
+func TestConfigReloadHandler_Handle(t *testing.T) {
+	tests := map[string]struct {
+		reload           func(cfg *server.Config)
+		noSource         bool
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Applies the changeable settings": {
+			reload: func(cfg *server.Config) {
+				cfg.LogLevel = "info"
+				cfg.RateLimit = server.RateLimitConfig{RequestsPerSecond: 100}
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewConfigReloadResponse([]string{"log_level", "rate_limit"}),
+		},
+		"Applies nothing when the configuration is unchanged": {
+			reload:           func(*server.Config) {},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewConfigReloadResponse(nil),
+		},
+		"Responds with bad request when settings requiring a restart change": {
+			reload: func(cfg *server.Config) {
+				cfg.Port++
+				cfg.LogLevel = "debug"
+			},
+			expectedStatus: fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewConfigReloadRestartRequiredError(
+				&server.RestartRequiredError{Settings: []string{"port"}}, []string{"port"},
+			)),
+		},
+		"Responds with bad request when the engine cannot change its sync settings": {
+			reload: func(cfg *server.Config) {
+				cfg.SyncInterval = time.Minute
+			},
+			expectedStatus: fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewConfigReloadRestartRequiredError(
+				&server.RestartRequiredError{Settings: []string{"sync_interval"}}, []string{"sync_interval"},
+			)),
+		},
+		"Responds with bad request when the log level is invalid": {
+			reload: func(cfg *server.Config) {
+				cfg.LogLevel = "verbose"
+			},
+			expectedStatus: fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewConfigReloadInvalidError(
+				fmt.Errorf("%w: log_level %q", server.ErrInvalidConfig, "verbose"),
+			)),
+		},
+		"Responds with not found when the server has no config source": {
+			noSource:         true,
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewConfigReloadUnsupportedError(server.ErrConfigSourceNotConfigured)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			opts := []server.Option{server.WithConfig(newConfigReloadTestConfig())}
+			if !tc.noSource {
+				opts = append(opts, server.WithConfigSource(func(context.Context) (server.Config, error) {
+					cfg := newConfigReloadTestConfig()
+					tc.reload(&cfg)
+					return cfg, nil
+				}))
+			}
+			fixture := server.NewTestFixture(t, opts...)
+
+			// when:
+			var actualSuccess openapi.ConfigReload
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+configReloadTestToken).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/config/reload")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func TestConfigReloadHandler_ShouldFail_WhenConfigSourceFails(t *testing.T) {
+	// given:
+	fixture := server.NewTestFixture(t,
+		server.WithConfig(newConfigReloadTestConfig()),
+		server.WithConfigSource(func(context.Context) (server.Config, error) {
+			return server.Config{}, errors.New("config file is unreadable")
+		}),
+	)
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+configReloadTestToken).
+		Post("/api/v1/admin/config/reload")
+
+	// then:
+	require.Equal(t, fiber.StatusInternalServerError, res.StatusCode())
+}
+
+func TestConfigReloadHandler_ShouldApplyTheReloadedRateLimit(t *testing.T) {
+	// given:
+	limit := server.RateLimitConfig{}
+	fixture := server.NewTestFixture(t,
+		server.WithConfig(newConfigReloadTestConfig()),
+		server.WithConfigSource(func(context.Context) (server.Config, error) {
+			cfg := newConfigReloadTestConfig()
+			cfg.RateLimit = limit
+			return cfg, nil
+		}),
+	)
+	reload := func() int {
+		res, _ := fixture.Client().
+			R().
+			SetHeader(fiber.HeaderAuthorization, "Bearer "+configReloadTestToken).
+			Post("/api/v1/admin/config/reload")
+		return res.StatusCode()
+	}
+	listTopicManagers := func() int {
+		res, _ := fixture.Client().R().Get("/api/v1/listTopicManagers")
+		return res.StatusCode()
+	}
+	require.Equal(t, fiber.StatusOK, listTopicManagers())
+	require.Equal(t, fiber.StatusOK, listTopicManagers())
+
+	// when:
+	limit = server.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 1}
+	status := reload()
+
+	// then:
+	require.Equal(t, fiber.StatusOK, status)
+	require.Equal(t, fiber.StatusOK, listTopicManagers(), "the burst allows a single request")
+	res, _ := fixture.Client().R().Get("/api/v1/listTopicManagers")
+	require.Equal(t, fiber.StatusTooManyRequests, res.StatusCode())
+	require.NotEmpty(t, res.Header().Get(fiber.HeaderRetryAfter))
+}
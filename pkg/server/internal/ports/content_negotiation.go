@@ -0,0 +1,14 @@
+package ports
+
+import "github.com/gofiber/fiber/v2"
+
+// negotiateContentType returns the content type among the offers that the Accept header of the request
+// prefers, honoring quality values and wildcards. The first offer is the default: it is returned when the
+// request has no Accept header or accepts none of the offers, so that clients not negotiating keep
+// receiving the original representation.
+func negotiateContentType(c *fiber.Ctx, offers ...string) string {
+	if accepted := c.Accepts(offers...); accepted != "" {
+		return accepted
+	}
+	return offers[0]
+}
@@ -0,0 +1,70 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeadLetterHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to inspect and replay failed submissions. It acts as the adapter between
+// HTTP requests and the application-layer DeadLetterService.
+type DeadLetterHandler struct {
+	service *app.DeadLetterService
+}
+
+// HandleList processes an HTTP GET request listing the dead-letter queue.
+//
+// On success, returns 200 OK with the DeadLetters response. On failure, returns an application error.
+func (h *DeadLetterHandler) HandleList(c *fiber.Ctx) error {
+	deadLetters, err := h.service.ListDeadLetters(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewDeadLettersResponse(deadLetters))
+}
+
+// HandleReplay processes an HTTP POST request to replay a dead letter.
+// It expects a JSON request body matching the ReplayDeadLetterJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK with the STEAK of the resubmitted transaction.
+// On failure, returns a request parsing or application error.
+func (h *DeadLetterHandler) HandleReplay(c *fiber.Ctx) error {
+	var body openapi.ReplayDeadLetterJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	steak, err := h.service.ReplayDeadLetter(c.UserContext(), body.Id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(&steak))
+}
+
+// NewDeadLetterHandler creates a new DeadLetterHandler with the given provider.
+// If the provider is nil, it panics.
+func NewDeadLetterHandler(provider app.DeadLetterProvider) *DeadLetterHandler {
+	return &DeadLetterHandler{service: app.NewDeadLetterService(provider)}
+}
+
+// NewDeadLettersResponse converts engine dead letters into a DeadLetters object
+// compatible with the OpenAPI specification. The BEEF of each submission is omitted.
+func NewDeadLettersResponse(deadLetters []*engine.DeadLetter) openapi.DeadLetters {
+	response := openapi.DeadLetters{DeadLetters: make([]openapi.DeadLetter, 0, len(deadLetters))}
+	for _, deadLetter := range deadLetters {
+		response.DeadLetters = append(response.DeadLetters, openapi.DeadLetter{
+			Id:            deadLetter.ID,
+			Txid:          deadLetter.Txid.String(),
+			Topics:        deadLetter.Topics,
+			Mode:          string(deadLetter.Mode),
+			Stage:         deadLetter.Stage,
+			Reason:        deadLetter.Reason,
+			Attempts:      deadLetter.Attempts,
+			FirstFailedAt: deadLetter.FirstFailedAt,
+			LastFailedAt:  deadLetter.LastFailedAt,
+		})
+	}
+	return response
+}
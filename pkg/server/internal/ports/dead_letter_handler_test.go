@@ -0,0 +1,119 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterHandler_List(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	failedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	deadLetters := []*engine.DeadLetter{{
+		ID:            "id",
+		Topics:        []string{testabilities.DefaultValidTopic},
+		Mode:          engine.SubmitModeCurrent,
+		Stage:         engine.DeadLetterStageBroadcast,
+		Reason:        "broadcast failed",
+		Attempts:      2,
+		FirstFailedAt: failedAt,
+		LastFailedAt:  failedAt.Add(time.Minute),
+	}}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithDeadLetterProvider(
+		testabilities.NewDeadLetterProviderMock(t, testabilities.DeadLetterProviderMockExpectations{
+			ListDeadLettersCall: true,
+			DeadLetters:         deadLetters,
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.DeadLetters
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/deadLetters")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewDeadLettersResponse(deadLetters), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestDeadLetterHandler_Replay(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	steak := overlay.Steak{testabilities.DefaultValidTopic: &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.DeadLetterProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Replays the dead letter": {
+			body: map[string]any{"id": "id"},
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ReplayDeadLetterCall: true,
+				ID:                   "id",
+				Steak:                steak,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: *ports.NewSubmitTransactionSuccessResponse(&steak),
+		},
+		"Rejects an empty ID": {
+			body:             map[string]any{"id": ""},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("id")),
+		},
+		"Responds with not found when the dead letter does not exist": {
+			body: map[string]any{"id": "id"},
+			expectations: testabilities.DeadLetterProviderMockExpectations{
+				ReplayDeadLetterCall: true,
+				Error:                engine.ErrDeadLetterNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewDeadLetterNotFoundError("id")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithDeadLetterProvider(
+				testabilities.NewDeadLetterProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.SubmitTransactionResponse
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/deadLetters/replay")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -2,6 +2,7 @@
 package decorators
 
 import (
+	"crypto/subtle"
 	"strings"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
@@ -14,12 +15,27 @@ type Handler interface {
 	Handle(c *fiber.Ctx) error
 }
 
+// ARCCallbackSignatureHeader is the HTTP header carrying the HMAC signature of the payload of an ARC callback.
+const ARCCallbackSignatureHeader = "X-Callback-Signature"
+
+// CallbackTokenVerifier reports whether a callback token was issued to one of the configured ARC instances.
+type CallbackTokenVerifier interface {
+	ValidCallbackToken(token string) bool
+}
+
+// CallbackSignatureVerifier is implemented by the CallbackTokenVerifiers whose ARC instances may sign their
+// callbacks. It reports whether the signature of the payload satisfies the instance the token was issued to.
+type CallbackSignatureVerifier interface {
+	ValidCallbackSignature(token string, payload []byte, signature string) bool
+}
+
 // ARCAuthorizationDecoratorConfig contains the configuration required
 // to enable and validate ARC-style authorization on an endpoint.
 type ARCAuthorizationDecoratorConfig struct {
-	APIKey        string // ARC API key required to enable this endpoint.
-	CallbackToken string // Expected token value to authorize the request.
-	Scheme        string // Authorization scheme prefix (usually "Bearer ").
+	APIKey         string                // ARC API key required to enable this endpoint.
+	CallbackToken  string                // Expected token value to authorize the request.
+	CallbackTokens CallbackTokenVerifier // Optional verifier of the tokens handed to multiple ARC instances; enables this endpoint when set.
+	Scheme         string                // Authorization scheme prefix (usually "Bearer ").
 }
 
 // ARCAuthorizationDecorator is a middleware that enforces ARC-style authorization
@@ -31,10 +47,12 @@ type ARCAuthorizationDecorator struct {
 }
 
 // Handle enforces ARC-style authorization by validating the presence and correctness
-// of the Authorization header against the provided configuration. Returns appropriate
-// errors if any validation step fails. If valid, it forwards the request to the next handler.
+// of the Authorization header against the provided configuration. The token is accepted when it matches
+// the configured callback token or is verified by the configured CallbackTokens, in which case the
+// ARCCallbackSignatureHeader must also satisfy them when they implement CallbackSignatureVerifier.
+// Unauthenticated callbacks are rejected with an authorization error. If valid, it forwards the request to the next handler.
 func (a *ARCAuthorizationDecorator) Handle(c *fiber.Ctx) error {
-	if a.cfg.APIKey == "" {
+	if a.cfg.APIKey == "" && a.cfg.CallbackTokens == nil {
 		return NewUnsupportedEndpointError()
 	}
 
@@ -48,8 +66,15 @@ func (a *ARCAuthorizationDecorator) Handle(c *fiber.Ctx) error {
 	}
 
 	token := strings.TrimPrefix(auth, a.cfg.Scheme)
-	if token != a.cfg.CallbackToken {
-		return NewInvalidBearerTokenError()
+	switch {
+	case a.cfg.CallbackToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.cfg.CallbackToken)) == 1:
+	case a.cfg.CallbackTokens != nil && a.cfg.CallbackTokens.ValidCallbackToken(token):
+		signatures, ok := a.cfg.CallbackTokens.(CallbackSignatureVerifier)
+		if ok && !signatures.ValidCallbackSignature(token, c.Body(), c.Get(ARCCallbackSignatureHeader)) {
+			return NewInvalidCallbackSignatureError()
+		}
+	default:
+		return NewInvalidCallbackTokenError()
 	}
 
 	return a.next.Handle(c)
@@ -92,6 +117,21 @@ func NewInvalidBearerTokenError() app.Error {
 	return app.NewAccessForbiddenError(msg, msg)
 }
 
+// NewInvalidCallbackTokenError returns an authorization error indicating that the Bearer token
+// of an ARC callback was not issued to any of the configured ARC instances.
+func NewInvalidCallbackTokenError() app.Error {
+	const msg = "The callback token provided is invalid or has expired."
+	return app.NewAuthorizationError(msg, msg)
+}
+
+// NewInvalidCallbackSignatureError returns an authorization error indicating that the ARC callback
+// is missing the signature required by the ARC instance its token was issued to, or that the signature
+// does not match the payload.
+func NewInvalidCallbackSignatureError() app.Error {
+	const msg = "The callback signature is missing or does not match the payload."
+	return app.NewAuthorizationError(msg, msg)
+}
+
 // NewUnsupportedEndpointError returns an error indicating that
 // the endpoint is not enabled or allowed in the current deployment or configuration.
 // This is useful for API stubs, disabled features, or restricted environments.
@@ -0,0 +1,61 @@
+package decorators
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReplicationAuthorizationDecoratorConfig contains the configuration required
+// to enable and validate the authorization of standbys streaming storage mutations.
+type ReplicationAuthorizationDecoratorConfig struct {
+	Token  string // Expected token value to authorize the request. An empty token disables the endpoint.
+	Scheme string // Authorization scheme prefix (usually "Bearer ").
+}
+
+// ReplicationAuthorizationDecorator is a middleware that restricts an endpoint to standbys
+// presenting the dedicated replication token, which is distinct from the admin bearer token.
+// If authorization is valid, it delegates the request to the next handler.
+type ReplicationAuthorizationDecorator struct {
+	cfg  *ReplicationAuthorizationDecoratorConfig
+	next Handler
+}
+
+// Handle enforces the replication authorization by validating the presence and correctness
+// of the Authorization header against the provided configuration. Returns appropriate
+// errors if any validation step fails. If valid, it forwards the request to the next handler.
+func (r *ReplicationAuthorizationDecorator) Handle(c *fiber.Ctx) error {
+	if r.cfg.Token == "" {
+		return NewUnsupportedEndpointError()
+	}
+
+	auth := c.Get(fiber.HeaderAuthorization)
+	if auth == "" {
+		return NewMissingAuthHeaderError()
+	}
+
+	if !strings.HasPrefix(auth, r.cfg.Scheme) {
+		return NewInvalidBearerTokenSchema()
+	}
+
+	token := strings.TrimPrefix(auth, r.cfg.Scheme)
+	if token != r.cfg.Token {
+		return NewInvalidBearerTokenError()
+	}
+
+	return r.next.Handle(c)
+}
+
+// NewReplicationAuthorizationDecorator constructs a new ReplicationAuthorizationDecorator,
+// wrapping a given handler with authorization logic. Panics if either `next` or `cfg` is nil.
+func NewReplicationAuthorizationDecorator(next Handler, cfg *ReplicationAuthorizationDecoratorConfig) *ReplicationAuthorizationDecorator {
+	if next == nil {
+		panic("next handler cannot be nil")
+	}
+
+	if cfg == nil {
+		panic("replication authorization decorator config cannot be nil")
+	}
+
+	return &ReplicationAuthorizationDecorator{next: next, cfg: cfg}
+}
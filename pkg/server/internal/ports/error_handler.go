@@ -2,28 +2,54 @@ package ports
 
 import (
 	"errors"
+	"math"
+	"strconv"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 )
 
+// ProblemContentType is the media type of the RFC 7807 problem details returned for failed requests.
+const ProblemContentType = "application/problem+json"
+
+// statusCodes maps the application error types to the HTTP status codes of their responses.
+var statusCodes = map[app.ErrorType]int{
+	app.ErrorTypeAuthorization:        fiber.StatusUnauthorized,
+	app.ErrorTypeAccessForbidden:      fiber.StatusForbidden,
+	app.ErrorTypeIncorrectInput:       fiber.StatusBadRequest,
+	app.ErrorTypeOperationTimeout:     fiber.StatusRequestTimeout,
+	app.ErrorTypeProviderFailure:      fiber.StatusInternalServerError,
+	app.ErrorTypeRawDataProcessing:    fiber.StatusInternalServerError,
+	app.ErrorTypeUnsupportedOperation: fiber.StatusNotFound,
+	app.ErrorTypeServiceUnavailable:   fiber.StatusServiceUnavailable,
+	app.ErrorTypePayloadTooLarge:      fiber.StatusRequestEntityTooLarge,
+	app.ErrorTypeUnprocessableContent: fiber.StatusUnprocessableEntity,
+	app.ErrorTypePaymentRequired:      fiber.StatusPaymentRequired,
+	app.ErrorTypeTooManyRequests:      fiber.StatusTooManyRequests,
+	app.ErrorTypeDeadlineExceeded:     fiber.StatusGatewayTimeout,
+}
+
+// fiberErrorCodes maps the status codes of errors raised by Fiber itself, e.g. for unknown routes,
+// to machine-readable error codes.
+var fiberErrorCodes = map[int]string{
+	fiber.StatusBadRequest:            app.InvalidInputErrorCode,
+	fiber.StatusNotFound:              app.NotFoundErrorCode,
+	fiber.StatusMethodNotAllowed:      "ERR_METHOD_NOT_ALLOWED",
+	fiber.StatusRequestTimeout:        app.TimeoutErrorCode,
+	fiber.StatusRequestEntityTooLarge: app.PayloadTooLargeErrorCode,
+	fiber.StatusTooManyRequests:       app.TooManyRequestsErrorCode,
+	fiber.StatusServiceUnavailable:    app.UnavailableErrorCode,
+}
+
 // ErrorHandler returns a Fiber error handler that translates application-level errors
-// into appropriate HTTP status codes and JSON responses. The handler maps specific
-// error types to corresponding HTTP status codes and includes a user-friendly message
-// (the slug) in the response body. If an error is unrecognized or zero, the handler
-// returns a generic internal server error response.
+// into RFC 7807 problem details. The handler maps specific error types to corresponding
+// HTTP status codes and includes a user-friendly detail (the slug), the machine-readable
+// error code, whether the request may be retried and the error details in the response body.
+// Errors raised by Fiber itself are translated the same way. If an error is unrecognized
+// or zero, the handler returns a generic internal server error response.
 func ErrorHandler() fiber.ErrorHandler {
-	codes := map[app.ErrorType]int{
-		app.ErrorTypeAuthorization:        fiber.StatusUnauthorized,
-		app.ErrorTypeAccessForbidden:      fiber.StatusForbidden,
-		app.ErrorTypeIncorrectInput:       fiber.StatusBadRequest,
-		app.ErrorTypeOperationTimeout:     fiber.StatusRequestTimeout,
-		app.ErrorTypeProviderFailure:      fiber.StatusInternalServerError,
-		app.ErrorTypeRawDataProcessing:    fiber.StatusInternalServerError,
-		app.ErrorTypeUnsupportedOperation: fiber.StatusNotFound,
-	}
-
 	return func(c *fiber.Ctx, err error) error {
 		if err == nil {
 			return nil
@@ -31,16 +57,60 @@ func ErrorHandler() fiber.ErrorHandler {
 
 		var fiberErr *fiber.Error
 		if errors.As(err, &fiberErr) {
-			return c.Status(fiberErr.Code).JSON(openapi.Error{Message: fiberErr.Message}) // TODO: Add more descriptive responses.
+			return c.Status(fiberErr.Code).JSON(NewFiberErrorResponse(fiberErr), ProblemContentType)
 		}
 
 		var appErr app.Error
 		if !errors.As(err, &appErr) || appErr.IsZero() {
-			return c.Status(fiber.StatusInternalServerError).JSON(NewUnhandledErrorTypeResponse())
+			return c.Status(fiber.StatusInternalServerError).JSON(NewUnhandledErrorTypeResponse(), ProblemContentType)
+		}
+
+		if retryAfter := appErr.RetryAfter(); retryAfter > 0 {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
 		}
+		response := NewErrorResponse(appErr)
+		return c.Status(response.Status).JSON(response, ProblemContentType)
+	}
+}
 
-		code := codes[appErr.ErrorType()]
-		return c.Status(code).JSON(openapi.Error{Message: appErr.Slug()})
+// NewErrorResponse converts an application error into an OpenAPI-compatible problem details
+// response carrying the status code of its type, the error slug, its machine-readable code,
+// whether it is retryable and its details, if any.
+func NewErrorResponse(err app.Error) openapi.Error {
+	status, ok := statusCodes[err.ErrorType()]
+	if !ok {
+		return NewUnhandledErrorTypeResponse()
+	}
+	response := openapi.Error{
+		Title:     utils.StatusMessage(status),
+		Status:    status,
+		Detail:    err.Slug(),
+		Code:      err.Code(),
+		Retryable: err.Retryable(),
+	}
+	if details := err.Details(); len(details) > 0 {
+		response.Details = &details
+	}
+	return response
+}
+
+// NewFiberErrorResponse converts an error raised by Fiber itself, e.g. for an unknown route,
+// into an OpenAPI-compatible problem details response.
+func NewFiberErrorResponse(err *fiber.Error) openapi.Error {
+	code, ok := fiberErrorCodes[err.Code]
+	switch {
+	case ok:
+	case err.Code >= fiber.StatusInternalServerError:
+		code = app.InternalErrorCode
+	default:
+		code = "ERR_REQUEST_FAILED"
+	}
+	return openapi.Error{
+		Title:     utils.StatusMessage(err.Code),
+		Status:    err.Code,
+		Detail:    err.Message,
+		Code:      code,
+		Retryable: err.Code == fiber.StatusRequestTimeout || err.Code == fiber.StatusTooManyRequests || err.Code >= fiber.StatusInternalServerError,
 	}
 }
 
@@ -49,7 +119,11 @@ func ErrorHandler() fiber.ErrorHandler {
 // It represents a generic internal server error to avoid exposing internal details to the client.
 func NewUnhandledErrorTypeResponse() openapi.Error {
 	return openapi.Error{
-		Message: "An internal error occurred during processing the request. Please try again later or contact the support team.",
+		Title:     utils.StatusMessage(fiber.StatusInternalServerError),
+		Status:    fiber.StatusInternalServerError,
+		Detail:    "An internal error occurred during processing the request. Please try again later or contact the support team.",
+		Code:      app.InternalErrorCode,
+		Retryable: true,
 	}
 }
 
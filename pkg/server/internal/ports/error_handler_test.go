@@ -0,0 +1,55 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandler_ShouldRespondWithProblemDetails_WhenRouteIsUnknown(t *testing.T) {
+	// given:
+	fixture := server.NewTestFixture(t)
+
+	// when:
+	var actual openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetError(&actual).
+		Get("/api/v1/unknown")
+
+	// then:
+	require.Equal(t, fiber.StatusNotFound, res.StatusCode())
+	require.Equal(t, ports.ProblemContentType, res.Header().Get(fiber.HeaderContentType))
+	require.Equal(t, "ERR_NOT_FOUND", actual.Code)
+	require.Equal(t, fiber.StatusNotFound, actual.Status)
+	require.Equal(t, "Not Found", actual.Title)
+	require.False(t, actual.Retryable)
+}
+
+func TestErrorHandler_ShouldRespondWithRetryableProblemDetails_WhenRateLimited(t *testing.T) {
+	// given:
+	fixture := server.NewTestFixture(t, server.WithRateLimit(server.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 1}))
+	res, _ := fixture.Client().R().Get("/api/v1/listTopicManagers")
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+
+	// when:
+	var actual openapi.Error
+	res, _ = fixture.Client().
+		R().
+		SetError(&actual).
+		Get("/api/v1/listTopicManagers")
+
+	// then:
+	require.Equal(t, fiber.StatusTooManyRequests, res.StatusCode())
+	require.Equal(t, ports.ProblemContentType, res.Header().Get(fiber.HeaderContentType))
+	require.NotEmpty(t, res.Header().Get(fiber.HeaderRetryAfter))
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, middleware.NewRateLimitExceededError(0)), actual)
+	require.Equal(t, "ERR_RATE_LIMITED", actual.Code)
+	require.True(t, actual.Retryable)
+}
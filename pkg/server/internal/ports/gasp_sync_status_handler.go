@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GASPSyncStatusHandler is a Fiber-compatible HTTP handler that processes admin requests
+// for the GASP sync progress of every topic peer. It acts as the adapter between HTTP requests
+// and the application-layer GASPSyncStatusService.
+type GASPSyncStatusHandler struct {
+	service *app.GASPSyncStatusService
+}
+
+// Handle processes an HTTP GET request for the GASP sync status.
+//
+// On success, returns 200 OK with the GASPSyncStatus response. On failure, returns an application error.
+func (h *GASPSyncStatusHandler) Handle(c *fiber.Ctx) error {
+	statuses, err := h.service.GASPSyncStatus(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewGASPSyncStatusResponse(statuses))
+}
+
+// NewGASPSyncStatusHandler creates a new GASPSyncStatusHandler with the given provider.
+// If the provider is nil, it panics.
+func NewGASPSyncStatusHandler(provider app.GASPSyncStatusProvider) *GASPSyncStatusHandler {
+	return &GASPSyncStatusHandler{service: app.NewGASPSyncStatusService(provider)}
+}
+
+// NewGASPSyncStatusResponse converts the engine sync statuses into a GASPSyncStatus object
+// compatible with the OpenAPI specification.
+func NewGASPSyncStatusResponse(statuses []*engine.GASPPeerSyncStatus) openapi.GASPSyncStatus {
+	response := openapi.GASPSyncStatus{Peers: make([]openapi.GASPPeerSyncStatus, 0, len(statuses))}
+	for _, status := range statuses {
+		peer := openapi.GASPPeerSyncStatus{
+			Topic:           status.Topic,
+			Peer:            status.Peer,
+			Running:         status.Running,
+			LastInteraction: status.LastInteraction,
+			PagesCompleted:  status.PagesCompleted,
+			NodesIngested:   status.NodesIngested,
+			Errors:          status.Errors,
+			LastError:       optionalString(status.LastError),
+			Resumable:       status.Resumable,
+			CheckpointNodes: status.CheckpointNodes,
+		}
+		if !status.StartedAt.IsZero() {
+			startedAt := status.StartedAt
+			peer.StartedAt = &startedAt
+		}
+		if !status.EndedAt.IsZero() {
+			endedAt := status.EndedAt
+			peer.EndedAt = &endedAt
+		}
+		response.Peers = append(response.Peers, peer)
+	}
+	return response
+}
@@ -0,0 +1,94 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGASPSyncStatusHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	startedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	statuses := []*engine.GASPPeerSyncStatus{
+		{
+			Topic:           testabilities.DefaultValidTopic,
+			Peer:            "https://peer-1.example",
+			LastInteraction: 1700000000,
+			PagesCompleted:  3,
+			NodesIngested:   12,
+			Errors:          1,
+			LastError:       "peer unavailable",
+			StartedAt:       startedAt,
+			EndedAt:         startedAt.Add(time.Minute),
+		},
+		{Topic: testabilities.DefaultValidTopic, Peer: "https://peer-2.example", Running: true, StartedAt: startedAt},
+		{Topic: "tm_resumable", Peer: "https://peer-1.example", Resumable: true, CheckpointNodes: 4},
+	}
+
+	tests := map[string]struct {
+		expectations     testabilities.GASPSyncStatusProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Reports the sync status of every topic peer": {
+			expectations: testabilities.GASPSyncStatusProviderMockExpectations{
+				GASPSyncStatusCall: true,
+				Statuses:           statuses,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewGASPSyncStatusResponse(statuses),
+		},
+		"Reports no peers before any sync": {
+			expectations: testabilities.GASPSyncStatusProviderMockExpectations{
+				GASPSyncStatusCall: true,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: openapi.GASPSyncStatus{Peers: []openapi.GASPPeerSyncStatus{}},
+		},
+		"Responds with internal server error when the provider fails": {
+			expectations: testabilities.GASPSyncStatusProviderMockExpectations{
+				GASPSyncStatusCall: true,
+				Error:              testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewGASPSyncStatusProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithGASPSyncStatusProvider(
+				testabilities.NewGASPSyncStatusProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.GASPSyncStatus
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/admin/syncStatus")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -13,14 +13,57 @@ import (
 type HandlerRegistryService struct {
 	lookupDocumentation       *LookupProviderDocumentationHandler
 	startGASPSync             *StartGASPSyncHandler
+	topicManagerRegistration  *TopicManagerRegistrationHandler
+	lookupServiceRegistration *LookupServiceRegistrationHandler
+	lookupReplay              *LookupReplayHandler
+	outputPinning             *OutputPinningHandler
+	topicSyncPause            *TopicSyncPauseHandler
+	trackers                  *TrackerHandler
+	topicQuotas               *TopicQuotaHandler
+	reorgSimulation           *ReorgSimulationHandler
+	deadLetters               *DeadLetterHandler
+	auditLog                  *AuditLogHandler
+	webhooks                  *WebhookHandler
+	pruneOutputs              *PruneOutputsHandler
+	outputGraph               *OutputGraphHandler
+	configReload              *ConfigReloadHandler
+	replication               *ReplicationHandler
 	topicManagerDocumentation *TopicManagerDocumentationHandler
 	submitTransaction         *SubmitTransactionHandler
+	submitJob                 *SubmitJobHandler
 	syncAdvertisements        *SyncAdvertisementsHandler
+	advertisementPlan         *AdvertisementPlanHandler
+	advertisements            *AdvertisementHandler
+	broadcastQueue            *BroadcastQueueHandler
+	topicStats                *TopicStatsHandler
+	gaspSyncStatus            *GASPSyncStatusHandler
+	topicSyncJobs             *TopicSyncJobHandler
 	requestForeignGASPNode    *RequestForeignGASPNodeHandler
 	requestSyncResponse       *RequestSyncResponseHandler
 	metadataHandler           *MetadataHandler
 	lookupQuestion            *LookupQuestionHandler
+	lookupQuerySchema         *LookupQuerySchemaHandler
+	utxoHistory               *UTXOHistoryHandler
+	outputStatus              *OutputStatusHandler
+	outputsExist              *OutputsExistHandler
 	arcIngest                 decorators.Handler
+	arcIngestBatch            decorators.Handler
+	replicationStream         decorators.Handler
+}
+
+// UTXOHistory implements openapi.ServerInterface.
+func (h *HandlerRegistryService) UTXOHistory(c *fiber.Ctx) error {
+	return h.utxoHistory.Handle(c)
+}
+
+// OutputStatus implements openapi.ServerInterface.
+func (h *HandlerRegistryService) OutputStatus(c *fiber.Ctx, txid string, vout uint32, params openapi.OutputStatusParams) error {
+	return h.outputStatus.Handle(c, txid, vout, params)
+}
+
+// OutputsExist implements openapi.ServerInterface.
+func (h *HandlerRegistryService) OutputsExist(c *fiber.Ctx) error {
+	return h.outputsExist.Handle(c)
 }
 
 // ArcIngest implements openapi.ServerInterface.
@@ -28,9 +71,19 @@ func (h *HandlerRegistryService) ArcIngest(c *fiber.Ctx) error {
 	return h.arcIngest.Handle(c)
 }
 
+// ArcIngestBatch implements openapi.ServerInterface.
+func (h *HandlerRegistryService) ArcIngestBatch(c *fiber.Ctx) error {
+	return h.arcIngestBatch.Handle(c)
+}
+
 // LookupQuestion implements openapi.ServerInterface.
-func (h *HandlerRegistryService) LookupQuestion(c *fiber.Ctx) error {
-	return h.lookupQuestion.Handle(c)
+func (h *HandlerRegistryService) LookupQuestion(c *fiber.Ctx, params openapi.LookupQuestionParams) error {
+	return h.lookupQuestion.Handle(c, params)
+}
+
+// GetLookupQuerySchema method delegates the request to the configured lookup query schema handler.
+func (h *HandlerRegistryService) GetLookupQuerySchema(c *fiber.Ctx, service string) error {
+	return h.lookupQuerySchema.Handle(c, service)
 }
 
 // ListLookupServiceProviders method delegates the request to the configured lookup list handler.
@@ -43,6 +96,36 @@ func (h *HandlerRegistryService) AdvertisementsSync(c *fiber.Ctx) error {
 	return h.syncAdvertisements.Handle(c)
 }
 
+// AdvertisementPlan method delegates the request to the configured advertisement plan handler.
+func (h *HandlerRegistryService) AdvertisementPlan(c *fiber.Ctx) error {
+	return h.advertisementPlan.Handle(c)
+}
+
+// TopicStats method delegates the request to the configured topic stats handler.
+func (h *HandlerRegistryService) TopicStats(c *fiber.Ctx) error {
+	return h.topicStats.Handle(c)
+}
+
+// GASPSyncStatus method delegates the request to the configured GASP sync status handler.
+func (h *HandlerRegistryService) GASPSyncStatus(c *fiber.Ctx) error {
+	return h.gaspSyncStatus.Handle(c)
+}
+
+// SyncTopic method delegates the request to the configured topic sync job handler.
+func (h *HandlerRegistryService) SyncTopic(c *fiber.Ctx) error {
+	return h.topicSyncJobs.HandleSync(c)
+}
+
+// GetTopicSyncJob method delegates the request to the configured topic sync job handler.
+func (h *HandlerRegistryService) GetTopicSyncJob(c *fiber.Ctx, jobID string) error {
+	return h.topicSyncJobs.HandleGet(c, jobID)
+}
+
+// BroadcastQueue method delegates the request to the configured broadcast queue handler.
+func (h *HandlerRegistryService) BroadcastQueue(c *fiber.Ctx) error {
+	return h.broadcastQueue.Handle(c)
+}
+
 // GetLookupServiceProviderDocumentation method delegates the request to the configured lookup service provider documentation handler.
 func (h *HandlerRegistryService) GetLookupServiceProviderDocumentation(c *fiber.Ctx, params openapi.GetLookupServiceProviderDocumentationParams) error {
 	return h.lookupDocumentation.Handle(c, params)
@@ -58,6 +141,11 @@ func (h *HandlerRegistryService) SubmitTransaction(c *fiber.Ctx, params openapi.
 	return h.submitTransaction.Handle(c, params)
 }
 
+// GetSubmitJob method delegates the request to the configured submit job handler.
+func (h *HandlerRegistryService) GetSubmitJob(c *fiber.Ctx, jobID string) error {
+	return h.submitJob.Handle(c, jobID)
+}
+
 // ListTopicManagers method delegates the request to the configured topic managers list handler.
 func (h *HandlerRegistryService) ListTopicManagers(c *fiber.Ctx) error {
 	return h.metadataHandler.Handle(c, app.TopicManagersServiceMetadataType)
@@ -68,6 +156,167 @@ func (h *HandlerRegistryService) StartGASPSync(c *fiber.Ctx) error {
 	return h.startGASPSync.Handle(c)
 }
 
+// RegisterTopicManager method delegates the request to the configured topic manager registration handler.
+func (h *HandlerRegistryService) RegisterTopicManager(c *fiber.Ctx) error {
+	return h.topicManagerRegistration.HandleRegister(c)
+}
+
+// UnregisterTopicManager method delegates the request to the configured topic manager registration handler.
+func (h *HandlerRegistryService) UnregisterTopicManager(c *fiber.Ctx, params openapi.UnregisterTopicManagerParams) error {
+	return h.topicManagerRegistration.HandleUnregister(c, params)
+}
+
+// ListRegisteredLookupServices method delegates the request to the configured lookup list handler.
+func (h *HandlerRegistryService) ListRegisteredLookupServices(c *fiber.Ctx) error {
+	return h.metadataHandler.Handle(c, app.LookupsMetadataServiceMetadataType)
+}
+
+// RegisterLookupService method delegates the request to the configured lookup service registration handler.
+func (h *HandlerRegistryService) RegisterLookupService(c *fiber.Ctx) error {
+	return h.lookupServiceRegistration.HandleRegister(c)
+}
+
+// UnregisterLookupService method delegates the request to the configured lookup service registration handler.
+func (h *HandlerRegistryService) UnregisterLookupService(c *fiber.Ctx, params openapi.UnregisterLookupServiceParams) error {
+	return h.lookupServiceRegistration.HandleUnregister(c, params)
+}
+
+// ReplayTopicIntoLookupService method delegates the request to the configured lookup replay handler.
+func (h *HandlerRegistryService) ReplayTopicIntoLookupService(c *fiber.Ctx) error {
+	return h.lookupReplay.Handle(c)
+}
+
+// PinOutput method delegates the request to the configured output pinning handler.
+func (h *HandlerRegistryService) PinOutput(c *fiber.Ctx) error {
+	return h.outputPinning.HandlePin(c)
+}
+
+// UnpinOutput method delegates the request to the configured output pinning handler.
+func (h *HandlerRegistryService) UnpinOutput(c *fiber.Ctx, params openapi.UnpinOutputParams) error {
+	return h.outputPinning.HandleUnpin(c, params)
+}
+
+// ListPausedSyncTopics method delegates the request to the configured topic sync pause handler.
+func (h *HandlerRegistryService) ListPausedSyncTopics(c *fiber.Ctx) error {
+	return h.topicSyncPause.HandleList(c)
+}
+
+// PauseTopicSync method delegates the request to the configured topic sync pause handler.
+func (h *HandlerRegistryService) PauseTopicSync(c *fiber.Ctx) error {
+	return h.topicSyncPause.HandlePause(c)
+}
+
+// ResumeTopicSync method delegates the request to the configured topic sync pause handler.
+func (h *HandlerRegistryService) ResumeTopicSync(c *fiber.Ctx, params openapi.ResumeTopicSyncParams) error {
+	return h.topicSyncPause.HandleResume(c, params)
+}
+
+// ListTrackers method delegates the request to the configured tracker handler.
+func (h *HandlerRegistryService) ListTrackers(c *fiber.Ctx) error {
+	return h.trackers.HandleList(c)
+}
+
+// AddTracker method delegates the request to the configured tracker handler.
+func (h *HandlerRegistryService) AddTracker(c *fiber.Ctx) error {
+	return h.trackers.HandleAdd(c)
+}
+
+// RemoveTracker method delegates the request to the configured tracker handler.
+func (h *HandlerRegistryService) RemoveTracker(c *fiber.Ctx, params openapi.RemoveTrackerParams) error {
+	return h.trackers.HandleRemove(c, params)
+}
+
+// ListTopicQuotas method delegates the request to the configured topic quota handler.
+func (h *HandlerRegistryService) ListTopicQuotas(c *fiber.Ctx) error {
+	return h.topicQuotas.HandleList(c)
+}
+
+// SetTopicQuota method delegates the request to the configured topic quota handler.
+func (h *HandlerRegistryService) SetTopicQuota(c *fiber.Ctx) error {
+	return h.topicQuotas.HandleSet(c)
+}
+
+// RemoveTopicQuota method delegates the request to the configured topic quota handler.
+func (h *HandlerRegistryService) RemoveTopicQuota(c *fiber.Ctx, params openapi.RemoveTopicQuotaParams) error {
+	return h.topicQuotas.HandleRemove(c, params)
+}
+
+// SimulateReorg method delegates the request to the configured reorg simulation handler.
+func (h *HandlerRegistryService) SimulateReorg(c *fiber.Ctx, params openapi.SimulateReorgParams) error {
+	return h.reorgSimulation.Handle(c, params)
+}
+
+// ListAuditEntries method delegates the request to the configured audit log handler.
+func (h *HandlerRegistryService) ListAuditEntries(c *fiber.Ctx, params openapi.ListAuditEntriesParams) error {
+	return h.auditLog.Handle(c, params)
+}
+
+// ListDeadLetters method delegates the request to the configured dead letter handler.
+func (h *HandlerRegistryService) ListDeadLetters(c *fiber.Ctx) error {
+	return h.deadLetters.HandleList(c)
+}
+
+// ReplayDeadLetter method delegates the request to the configured dead letter handler.
+func (h *HandlerRegistryService) ReplayDeadLetter(c *fiber.Ctx) error {
+	return h.deadLetters.HandleReplay(c)
+}
+
+// ListWebhooks method delegates the request to the configured webhook handler.
+func (h *HandlerRegistryService) ListWebhooks(c *fiber.Ctx) error {
+	return h.webhooks.HandleList(c)
+}
+
+// RegisterWebhook method delegates the request to the configured webhook handler.
+func (h *HandlerRegistryService) RegisterWebhook(c *fiber.Ctx) error {
+	return h.webhooks.HandleRegister(c)
+}
+
+// UnregisterWebhook method delegates the request to the configured webhook handler.
+func (h *HandlerRegistryService) UnregisterWebhook(c *fiber.Ctx, params openapi.UnregisterWebhookParams) error {
+	return h.webhooks.HandleUnregister(c, params)
+}
+
+// ListAdvertisements method delegates the request to the configured advertisement handler.
+func (h *HandlerRegistryService) ListAdvertisements(c *fiber.Ctx) error {
+	return h.advertisements.HandleList(c)
+}
+
+// CreateAdvertisement method delegates the request to the configured advertisement handler.
+func (h *HandlerRegistryService) CreateAdvertisement(c *fiber.Ctx) error {
+	return h.advertisements.HandleCreate(c)
+}
+
+// RevokeAdvertisement method delegates the request to the configured advertisement handler.
+func (h *HandlerRegistryService) RevokeAdvertisement(c *fiber.Ctx, params openapi.RevokeAdvertisementParams) error {
+	return h.advertisements.HandleRevoke(c, params)
+}
+
+// PruneOutputs method delegates the request to the configured prune outputs handler.
+func (h *HandlerRegistryService) PruneOutputs(c *fiber.Ctx) error {
+	return h.pruneOutputs.Handle(c)
+}
+
+// OutputGraph method delegates the request to the configured output graph handler.
+func (h *HandlerRegistryService) OutputGraph(c *fiber.Ctx, txid string, vout uint32, params openapi.OutputGraphParams) error {
+	return h.outputGraph.Handle(c, txid, vout, params)
+}
+
+// ReloadConfig method delegates the request to the configured config reload handler.
+func (h *HandlerRegistryService) ReloadConfig(c *fiber.Ctx) error {
+	return h.configReload.Handle(c)
+}
+
+// PromoteStandby method delegates the request to the configured replication handler.
+func (h *HandlerRegistryService) PromoteStandby(c *fiber.Ctx) error {
+	return h.replication.HandlePromote(c)
+}
+
+// ReplicationStream method delegates the request to the configured replication stream handler,
+// which authorizes standbys with the replication token before reading the query parameters.
+func (h *HandlerRegistryService) ReplicationStream(c *fiber.Ctx, _ openapi.ReplicationStreamParams) error {
+	return h.replicationStream.Handle(c)
+}
+
 // RequestForeignGASPNode method delegates the request to the configured request foreign GASP node handler.
 func (h *HandlerRegistryService) RequestForeignGASPNode(c *fiber.Ctx, params openapi.RequestForeignGASPNodeParams) error {
 	return h.requestForeignGASPNode.Handle(c, params)
@@ -79,22 +328,53 @@ func (h *HandlerRegistryService) RequestSyncResponse(c *fiber.Ctx, params openap
 }
 
 // NewHandlerRegistryService creates and returns a new HandlerRegistryService instance.
-// It initializes all handler implementations with their required dependencies.
-func NewHandlerRegistryService(provider engine.OverlayEngineProvider, cfg *decorators.ARCAuthorizationDecoratorConfig) *HandlerRegistryService {
+// It initializes all handler implementations with their required dependencies. The access control list
+// restricts the topics each API key may submit to and read the outputs of, and the lookup services it may query. The reloader re-reads
+// the configuration of the node on admin request.
+func NewHandlerRegistryService(provider engine.OverlayEngineProvider, cfg *decorators.ARCAuthorizationDecoratorConfig, submitCfg SubmitTransactionHandlerConfig, replicationCfg *decorators.ReplicationAuthorizationDecoratorConfig, access app.AccessControlList, reloader app.ConfigReloadProvider) *HandlerRegistryService {
+	replication := NewReplicationHandler(provider)
 	return &HandlerRegistryService{
-		lookupDocumentation: NewLookupProviderDocumentationHandler(provider),
-		startGASPSync:       NewStartGASPSyncHandler(provider),
-		arcIngest:           decorators.NewArcAuthorizationDecorator(NewARCIngestHandler(provider), cfg),
+		lookupDocumentation:       NewLookupProviderDocumentationHandler(provider),
+		startGASPSync:             NewStartGASPSyncHandler(provider),
+		arcIngest:                 decorators.NewArcAuthorizationDecorator(NewARCIngestHandler(provider), cfg),
+		arcIngestBatch:            decorators.NewArcAuthorizationDecorator(NewARCIngestBatchHandler(provider), cfg),
+		topicManagerRegistration:  NewTopicManagerRegistrationHandler(provider),
+		lookupServiceRegistration: NewLookupServiceRegistrationHandler(provider),
+		lookupReplay:              NewLookupReplayHandler(provider),
+		outputPinning:             NewOutputPinningHandler(provider),
+		topicSyncPause:            NewTopicSyncPauseHandler(provider),
+		trackers:                  NewTrackerHandler(provider),
+		topicQuotas:               NewTopicQuotaHandler(provider),
+		reorgSimulation:           NewReorgSimulationHandler(provider),
+		deadLetters:               NewDeadLetterHandler(provider),
+		auditLog:                  NewAuditLogHandler(provider),
+		webhooks:                  NewWebhookHandler(provider),
+		pruneOutputs:              NewPruneOutputsHandler(provider),
+		outputGraph:               NewOutputGraphHandler(provider, access),
+		configReload:              NewConfigReloadHandler(reloader),
+		replication:               replication,
+		replicationStream:         decorators.NewReplicationAuthorizationDecorator(replication, replicationCfg),
 		metadataHandler: NewMetadataHandler(
 			app.NewMetadataService(
 				app.NewLookupListService(provider),
 				app.NewTopicManagersMetadataService(provider),
 			)),
-		lookupQuestion:            NewLookupQuestionHandler(provider),
+		lookupQuestion:            NewLookupQuestionHandler(provider, access),
+		lookupQuerySchema:         NewLookupQuerySchemaHandler(provider, access),
+		utxoHistory:               NewUTXOHistoryHandler(provider, access),
+		outputStatus:              NewOutputStatusHandler(provider, access),
+		outputsExist:              NewOutputsExistHandler(provider, access),
 		topicManagerDocumentation: NewTopicManagerDocumentationHandler(provider),
-		submitTransaction:         NewSubmitTransactionHandler(provider),
+		submitTransaction:         NewSubmitTransactionHandler(provider, provider, submitCfg, access),
+		submitJob:                 NewSubmitJobHandler(provider),
 		syncAdvertisements:        NewSyncAdvertisementsHandler(provider),
-		requestForeignGASPNode:    NewRequestForeignGASPNodeHandler(provider),
-		requestSyncResponse:       NewRequestSyncResponseHandler(provider),
+		advertisementPlan:         NewAdvertisementPlanHandler(provider),
+		advertisements:            NewAdvertisementHandler(provider),
+		broadcastQueue:            NewBroadcastQueueHandler(provider),
+		topicStats:                NewTopicStatsHandler(provider),
+		gaspSyncStatus:            NewGASPSyncStatusHandler(provider),
+		topicSyncJobs:             NewTopicSyncJobHandler(provider),
+		requestForeignGASPNode:    NewRequestForeignGASPNodeHandler(provider, access),
+		requestSyncResponse:       NewRequestSyncResponseHandler(provider, access),
 	}
 }
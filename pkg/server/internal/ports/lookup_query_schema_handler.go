@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LookupQuerySchemaHandler is a Fiber-compatible HTTP handler that processes requests for
+// the query schema declared by a lookup service. It acts as the adapter between
+// HTTP requests and the application-layer LookupQuerySchemaService.
+type LookupQuerySchemaHandler struct {
+	service *app.LookupQuerySchemaService
+}
+
+// Handle processes an HTTP GET request for the query schema of the given lookup service.
+//
+// Lookup services restricted by the access control list require a permitted API key as the Bearer token.
+// On success, returns 200 OK with the JSON Schema as declared by the lookup service.
+// On failure, returns an application error.
+func (h *LookupQuerySchemaHandler) Handle(c *fiber.Ctx, service string) error {
+	schema, err := h.service.GetLookupQuerySchema(apiKeyContext(c), service)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(schema)
+}
+
+// NewLookupQuerySchemaHandler creates a new LookupQuerySchemaHandler with the given provider and the access
+// control list restricting the lookup services each API key may query.
+// If the provider is nil, it panics.
+func NewLookupQuerySchemaHandler(provider app.LookupQuerySchemaProvider, access app.AccessControlList) *LookupQuerySchemaHandler {
+	return &LookupQuerySchemaHandler{service: app.NewLookupQuerySchemaService(provider, access)}
+}
@@ -0,0 +1,93 @@
+package ports_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupQuerySchemaHandler(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","required":["name"]}`)
+
+	tests := map[string]struct {
+		expectations     testabilities.LookupQuerySchemaProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Returns the query schema": {
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Service:                  "ls_test",
+				Schema:                   schema,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: openapi.LookupQuerySchema{"type": "object", "required": []any{"name"}},
+		},
+		"Responds with not found when the service declares no schema": {
+			expectations: testabilities.LookupQuerySchemaProviderMockExpectations{
+				GetLookupQuerySchemaCall: true,
+				Error:                    engine.ErrLookupQuerySchemaNotDeclared,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewLookupQuerySchemaNotDeclaredError("ls_test")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuerySchemaProvider(
+				testabilities.NewLookupQuerySchemaProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			var actualSuccess openapi.LookupQuerySchema
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/lookup/ls_test/schema")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestLookupQuerySchemaHandler_ShouldRejectAPIKeysDeniedTheService(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuerySchemaProvider(
+		testabilities.NewLookupQuerySchemaProviderMock(t, testabilities.LookupQuerySchemaProviderMockExpectations{GetLookupQuerySchemaCall: false}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAccessControlList(server.AccessControlList{
+		LookupServices: map[string][]string{"ls_private": {"private-key"}},
+	}))
+
+	// when:
+	var actualError openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer other-key").
+		SetError(&actualError).
+		Get("/api/v1/lookup/ls_private/schema")
+
+	// then:
+	require.Equal(t, fiber.StatusForbidden, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewLookupServiceAccessDeniedError("ls_private")), actualError)
+	stub.AssertProvidersState()
+}
@@ -1,8 +1,15 @@
 package ports
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/lookupanswer"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -19,12 +26,20 @@ type LookupQuestionHandler struct {
 // It expects a JSON body matching the LookupQuestionBody OpenAPI definition.
 //
 // The handler parses and validates the request body, then delegates the lookup
-// operation to the LookupQuestionService. The response is formatted according
+// operation to the LookupQuestionService. Lookup services restricted by the access
+// control list require a permitted API key as the Bearer token. The response is formatted according
 // to the OpenAPI LookupAnswer schema.
 //
+// With the limit or cursor query parameters, only the selected page of the answer is returned,
+// together with the nextCursor of the following page. Requested with an Accept header of
+// application/x-ndjson, the page is streamed as newline delimited JSON, hydrating each output
+// as it is written. Requested with an Accept header of application/octet-stream, the answer is encoded
+// in the binary format of the lookupanswer package, carrying BEEF as raw bytes. The hydrate and depth query parameters select what the outputs are hydrated with,
+// so that clients needing only outpoints do not pay for the assembly of full BEEF.
+//
 // On success, it returns a 200 OK response with the lookup results.
 // On failure, it returns either a request parsing error or a service-level error.
-func (h *LookupQuestionHandler) Handle(c *fiber.Ctx) error {
+func (h *LookupQuestionHandler) Handle(c *fiber.Ctx, params openapi.LookupQuestionParams) error {
 	var body openapi.LookupQuestionBody
 
 	err := c.BodyParser(&body)
@@ -32,12 +47,61 @@ func (h *LookupQuestionHandler) Handle(c *fiber.Ctx) error {
 		return NewRequestBodyParserError(err)
 	}
 
-	dto, err := h.service.LookupQuestion(c.UserContext(), body.Service, body.Query)
+	contentType := negotiateContentType(c, fiber.MIMEApplicationJSON, MIMEApplicationNDJSON, lookupanswer.MIMEBinary)
+	stream := contentType == MIMEApplicationNDJSON
+	if params.Limit == nil && params.Cursor == nil && params.Hydrate == nil && params.Depth == nil && !stream {
+		dto, err := h.service.LookupQuestion(apiKeyContext(c), body.Service, body.Query)
+		if err != nil {
+			return err
+		}
+
+		if contentType == lookupanswer.MIMEBinary {
+			return sendLookupAnswerBinary(c, &app.LookupAnswerPageDTO{Type: dto.Type, Result: dto.Result}, dto.Outputs)
+		}
+
+		res, err := NewLookupQuestionSuccessResponse(dto)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusOK).JSON(res)
+	}
+
+	var limit uint32
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	var cursor string
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+	hydration := engine.LookupHydration{Depth: params.Depth}
+	if params.Hydrate != nil {
+		hydration.Mode = engine.LookupHydrationMode(*params.Hydrate)
+	}
+	page, err := h.service.LookupQuestionPage(apiKeyContext(c), body.Service, body.Query, limit, cursor, hydration)
 	if err != nil {
 		return err
 	}
 
-	res, err := NewLookupQuestionSuccessResponse(dto)
+	if stream {
+		c.Status(fiber.StatusOK).Set(fiber.HeaderContentType, MIMEApplicationNDJSON)
+		c.Context().SetBodyStreamWriter(NewLookupAnswerStreamWriter(page))
+		return nil
+	}
+
+	if contentType == lookupanswer.MIMEBinary {
+		var outputs []app.OutputListItemDTO
+		for output, err := range page.Outputs {
+			if err != nil {
+				return err
+			}
+			outputs = append(outputs, output)
+		}
+		return sendLookupAnswerBinary(c, page, outputs)
+	}
+
+	res, err := NewLookupAnswerPageResponse(page)
 	if err != nil {
 		return err
 	}
@@ -46,16 +110,17 @@ func (h *LookupQuestionHandler) Handle(c *fiber.Ctx) error {
 }
 
 // NewLookupQuestionHandler constructs a new LookupQuestionHandler using the given
-// LookupQuestionProvider to initialize the underlying LookupQuestionService.
+// LookupQuestionProvider to initialize the underlying LookupQuestionService,
+// and the access control list restricting the lookup services each API key may query.
 //
 // The provider must implement the LookupQuestionProvider interface.
 // This function bridges the infrastructure (provider) with the application logic.
 // Panics if the provider is nil.
-func NewLookupQuestionHandler(provider app.LookupQuestionProvider) *LookupQuestionHandler {
+func NewLookupQuestionHandler(provider app.LookupQuestionProvider, access app.AccessControlList) *LookupQuestionHandler {
 	if provider == nil {
 		panic("LookupQuestionProvider cannot be nil")
 	}
-	return &LookupQuestionHandler{service: app.NewLookupQuestionService(provider)}
+	return &LookupQuestionHandler{service: app.NewLookupQuestionService(provider, access)}
 }
 
 // NewLookupQuestionSuccessResponse transforms a LookupAnswerDTO into an OpenAPI-compatible
@@ -69,10 +134,7 @@ func NewLookupQuestionSuccessResponse(dto *app.LookupAnswerDTO) (*openapi.Lookup
 	if len(dto.Outputs) > 0 {
 		outputs = make([]openapi.OutputListItem, len(dto.Outputs))
 		for i, output := range dto.Outputs {
-			outputs[i] = openapi.OutputListItem{
-				Beef:        output.BEEF,
-				OutputIndex: output.OutputIndex,
-			}
+			outputs[i] = NewOutputListItem(output)
 		}
 	}
 
@@ -82,3 +144,129 @@ func NewLookupQuestionSuccessResponse(dto *app.LookupAnswerDTO) (*openapi.Lookup
 		Type:    dto.Type,
 	}, nil
 }
+
+// NewOutputListItem converts an OutputListItemDTO into an OpenAPI-compatible OutputListItem.
+func NewOutputListItem(output app.OutputListItemDTO) openapi.OutputListItem {
+	item := openapi.OutputListItem{
+		Beef:        output.BEEF,
+		OutputIndex: output.OutputIndex,
+	}
+	if len(output.Metadata) > 0 {
+		item.Metadata = &output.Metadata
+	}
+	if output.Txid != "" {
+		item.Txid = &output.Txid
+	}
+	if output.RawTx != nil {
+		item.RawTx = &output.RawTx
+	}
+	if len(output.OffChainValues) > 0 {
+		item.OffChainValues = &output.OffChainValues
+	}
+	return item
+}
+
+// NewLookupAnswerPageResponse collects the outputs of a LookupAnswerPageDTO into an OpenAPI-compatible
+// LookupAnswer response structure, carrying the cursor of the next page.
+// Returns an error if an output of the page cannot be produced.
+func NewLookupAnswerPageResponse(page *app.LookupAnswerPageDTO) (*openapi.LookupAnswer, error) {
+	var outputs []openapi.OutputListItem
+	for output, err := range page.Outputs {
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, NewOutputListItem(output))
+	}
+
+	res := &openapi.LookupAnswer{
+		Outputs: outputs,
+		Result:  page.Result,
+		Type:    page.Type,
+	}
+	if page.NextCursor != "" {
+		res.NextCursor = &page.NextCursor
+	}
+	return res, nil
+}
+
+// NewLookupAnswerBinary converts the outputs of a lookup answer, and the type, result and next cursor of its
+// page, into an answer encoded by lookupanswer.MarshalBinary.
+// Returns an error if the txid of an output is malformed.
+func NewLookupAnswerBinary(page *app.LookupAnswerPageDTO, outputs []app.OutputListItemDTO) (*lookupanswer.Answer, error) {
+	answer := &lookupanswer.Answer{Type: page.Type, Result: page.Result, NextCursor: page.NextCursor}
+	if len(outputs) > 0 {
+		answer.Outputs = make([]lookupanswer.Output, len(outputs))
+	}
+	for i, output := range outputs {
+		item := lookupanswer.Output{
+			OutputIndex:    output.OutputIndex,
+			Beef:           output.BEEF,
+			RawTx:          output.RawTx,
+			OffChainValues: output.OffChainValues,
+			Metadata:       output.Metadata,
+		}
+		if output.Txid != "" {
+			txid, err := chainhash.NewHashFromHex(output.Txid)
+			if err != nil {
+				return nil, app.NewLookupQuestionProviderError(err)
+			}
+			item.Txid = txid
+		}
+		answer.Outputs[i] = item
+	}
+	return answer, nil
+}
+
+// sendLookupAnswerBinary responds with the lookup answer encoded in the binary format of the lookupanswer package.
+func sendLookupAnswerBinary(c *fiber.Ctx, page *app.LookupAnswerPageDTO, outputs []app.OutputListItemDTO) error {
+	answer, err := NewLookupAnswerBinary(page, outputs)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, lookupanswer.MIMEBinary)
+	return c.Status(fiber.StatusOK).Send(lookupanswer.MarshalBinary(answer))
+}
+
+// lookupStreamLine is a line of a lookup answer streamed as newline delimited JSON.
+type lookupStreamLine struct {
+	Output *openapi.OutputListItem `json:"output,omitempty"`
+	Answer *lookupStreamAnswer     `json:"answer,omitempty"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+// lookupStreamAnswer is the final line of a streamed lookup answer.
+type lookupStreamAnswer struct {
+	Type       string `json:"type"`
+	Result     string `json:"result"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// NewLookupAnswerStreamWriter returns a body stream writer writing the outputs of the page one
+// {"output": ...} line at a time, flushing each, followed by an {"answer": ...} line. An output failing
+// to be produced ends the stream with an {"error": ...} line, as the status was already sent.
+func NewLookupAnswerStreamWriter(page *app.LookupAnswerPageDTO) func(w *bufio.Writer) {
+	return func(w *bufio.Writer) {
+		encoder := json.NewEncoder(w)
+		for output, err := range page.Outputs {
+			if err != nil {
+				message := "Unable to process lookup question due to an internal error."
+				var appErr app.Error
+				if errors.As(err, &appErr) {
+					message = appErr.Slug()
+				}
+				_ = encoder.Encode(lookupStreamLine{Error: message})
+				_ = w.Flush()
+				return
+			}
+			item := NewOutputListItem(output)
+			if err := encoder.Encode(lookupStreamLine{Output: &item}); err != nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return // the client went away
+			}
+		}
+		_ = encoder.Encode(lookupStreamLine{Answer: &lookupStreamAnswer{Type: page.Type, Result: page.Result, NextCursor: page.NextCursor}})
+		_ = w.Flush()
+	}
+}
@@ -1,13 +1,17 @@
 package ports_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/lookupanswer"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/require"
@@ -101,3 +105,328 @@ func TestLookupQuestionHandler_ValidCase(t *testing.T) {
 
 	stub.AssertProvidersState()
 }
+
+func TestLookupQuestionHandler_AccessControl(t *testing.T) {
+	acl := server.AccessControlList{
+		LookupServices: map[string][]string{"ls_private": {"private-key"}},
+	}
+
+	tests := map[string]struct {
+		service            string
+		headers            map[string]string
+		expectedStatusCode int
+		expectations       testabilities.LookupQuestionProviderMockExpectations
+	}{
+		"Query of a restricted lookup service without an API key is rejected": {
+			service:            "ls_private",
+			headers:            map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationJSON},
+			expectedStatusCode: fiber.StatusForbidden,
+			expectations:       testabilities.LookupQuestionProviderMockExpectations{LookupQuestionCall: false},
+		},
+		"Query of a restricted lookup service with another API key is rejected": {
+			service: "ls_private",
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+				fiber.HeaderAuthorization: "Bearer other-key",
+			},
+			expectedStatusCode: fiber.StatusForbidden,
+			expectations:       testabilities.LookupQuestionProviderMockExpectations{LookupQuestionCall: false},
+		},
+		"Query of a restricted lookup service with a permitted API key is accepted": {
+			service: "ls_private",
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEApplicationJSON,
+				fiber.HeaderAuthorization: "Bearer private-key",
+			},
+			expectedStatusCode: fiber.StatusOK,
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupQuestionCall: true,
+				Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "ok"},
+			},
+		},
+		"Query of an unrestricted lookup service without an API key is accepted": {
+			service:            "ls_public",
+			headers:            map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationJSON},
+			expectedStatusCode: fiber.StatusOK,
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupQuestionCall: true,
+				Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "ok"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, tc.expectations)))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAccessControlList(acl))
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody(openapi.LookupQuestionJSONRequestBody{
+					Query:   map[string]any{"test": "query"},
+					Service: tc.service,
+				}).
+				Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestLookupQuestionHandler_ShouldReturnPageOfAnswer(t *testing.T) {
+	// given:
+	expectations := testabilities.LookupQuestionProviderMockExpectations{
+		LookupPagedCall: true,
+		Outputs:         []*engine.LookupOutput{{Beef: []byte{1}, OutputIndex: 0}, {Beef: []byte{2}, OutputIndex: 1}},
+		NextCursor:      "next",
+		Page:            engine.LookupPage{Limit: 2, Cursor: "cursor"},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+	nextCursor := "next"
+
+	// when:
+	var actualResponse openapi.LookupAnswer
+
+	res, _ := fixture.Client().
+		R().
+		SetHeader("Content-Type", "application/json").
+		SetQueryParams(map[string]string{"limit": "2", "cursor": "cursor"}).
+		SetBody(openapi.LookupQuestionJSONRequestBody{
+			Query:   map[string]any{"test": "query"},
+			Service: "test-service",
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, openapi.LookupAnswer{
+		Type:       string(lookup.AnswerTypeOutputList),
+		Outputs:    []openapi.OutputListItem{{Beef: []byte{1}, OutputIndex: 0}, {Beef: []byte{2}, OutputIndex: 1}},
+		NextCursor: &nextCursor,
+	}, actualResponse)
+
+	stub.AssertProvidersState()
+}
+
+func TestLookupQuestionHandler_ShouldStreamAnswerAsNDJSON(t *testing.T) {
+	tests := map[string]struct {
+		expectations  testabilities.LookupQuestionProviderMockExpectations
+		expectedLines []string
+	}{
+		"streams every output followed by the answer": {
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupPagedCall: true,
+				Outputs:         []*engine.LookupOutput{{Beef: []byte{1}, OutputIndex: 0}, {Beef: []byte{2}, OutputIndex: 1, Metadata: map[string]string{"ticker": "TEST"}}},
+				NextCursor:      "next",
+			},
+			expectedLines: []string{
+				`{"output":{"beef":"AQ==","outputIndex":0}}`,
+				`{"output":{"beef":"Ag==","metadata":{"ticker":"TEST"},"outputIndex":1}}`,
+				`{"answer":{"type":"output-list","result":"","nextCursor":"next"}}`,
+			},
+		},
+		"ends the stream with an error line when an output fails": {
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupPagedCall: true,
+				Outputs:         []*engine.LookupOutput{{Beef: []byte{1}, OutputIndex: 0}},
+				OutputsError:    testabilities.ErrTestNoopOpFailure,
+			},
+			expectedLines: []string{
+				`{"output":{"beef":"AQ==","outputIndex":0}}`,
+				`{"error":"Unable to process lookup question due to an internal error. Please try again later or contact the support team."}`,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, tc.expectations)))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				SetHeader(fiber.HeaderAccept, ports.MIMEApplicationNDJSON).
+				SetBody(openapi.LookupQuestionJSONRequestBody{
+					Query:   map[string]any{"test": "query"},
+					Service: "test-service",
+				}).
+				Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, fiber.StatusOK, res.StatusCode())
+			require.Equal(t, ports.MIMEApplicationNDJSON, res.Header().Get(fiber.HeaderContentType))
+			require.Equal(t, tc.expectedLines, strings.Split(strings.TrimSpace(string(res.Body())), "\n"))
+
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestLookupQuestionHandler_ShouldRejectInvalidCursor(t *testing.T) {
+	// given:
+	expectations := testabilities.LookupQuestionProviderMockExpectations{
+		LookupPagedCall: true,
+		Error:           engine.ErrInvalidLookupCursor,
+		Page:            engine.LookupPage{Cursor: "bogus"},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.Error
+
+	res, _ := fixture.Client().
+		R().
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("cursor", "bogus").
+		SetBody(openapi.LookupQuestionJSONRequestBody{
+			Query:   map[string]any{"test": "query"},
+			Service: "test-service",
+		}).
+		SetError(&actualResponse).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewInvalidLookupCursorError(engine.ErrInvalidLookupCursor)), actualResponse)
+
+	stub.AssertProvidersState()
+}
+
+func TestLookupQuestionHandler_ShouldHydrateOutputsAsSelected(t *testing.T) {
+	// given:
+	txid := chainhash.Hash{1}
+	expectations := testabilities.LookupQuestionProviderMockExpectations{
+		LookupPagedCall: true,
+		Outputs:         []*engine.LookupOutput{{RawTx: []byte{1}, Txid: txid, OutputIndex: 2}},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+	expectedTxid := txid.String()
+	expectedRawTx := []byte{1}
+
+	// when:
+	var actualResponse openapi.LookupAnswer
+
+	res, _ := fixture.Client().
+		R().
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("hydrate", "rawtx").
+		SetBody(openapi.LookupQuestionJSONRequestBody{
+			Query:   map[string]any{"test": "query"},
+			Service: "test-service",
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, openapi.LookupAnswer{
+		Type:    string(lookup.AnswerTypeOutputList),
+		Outputs: []openapi.OutputListItem{{Txid: &expectedTxid, RawTx: &expectedRawTx, OutputIndex: 2}},
+	}, actualResponse)
+
+	stub.AssertProvidersState()
+}
+
+func TestLookupQuestionHandler_ShouldRejectUnknownHydration(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{})))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.Error
+
+	res, _ := fixture.Client().
+		R().
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("hydrate", "beefy").
+		SetBody(openapi.LookupQuestionJSONRequestBody{
+			Query:   map[string]any{"test": "query"},
+			Service: "test-service",
+		}).
+		SetError(&actualResponse).
+		Post("/api/v1/lookup")
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+	expectedErr := app.NewInvalidLookupHydrationError(engine.LookupHydration{Mode: "beefy"}.Validate())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, expectedErr), actualResponse)
+
+	stub.AssertProvidersState()
+}
+
+func TestLookupQuestionHandler_ShouldReturnBinaryAnswer(t *testing.T) {
+	tests := map[string]struct {
+		expectations   testabilities.LookupQuestionProviderMockExpectations
+		query          map[string]string
+		expectedAnswer *lookupanswer.Answer
+	}{
+		"whole answer": {
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupQuestionCall: true,
+				Answer: &lookup.LookupAnswer{
+					Type:    lookup.AnswerTypeOutputList,
+					Outputs: []*lookup.OutputListItem{{Beef: []byte{1}, OutputIndex: 0}, {Beef: []byte{2}, OutputIndex: 1}},
+				},
+			},
+			expectedAnswer: &lookupanswer.Answer{
+				Type:    string(lookup.AnswerTypeOutputList),
+				Outputs: []lookupanswer.Output{{Beef: []byte{1}, OutputIndex: 0}, {Beef: []byte{2}, OutputIndex: 1}},
+			},
+		},
+		"page of the answer": {
+			expectations: testabilities.LookupQuestionProviderMockExpectations{
+				LookupPagedCall: true,
+				Outputs:         []*engine.LookupOutput{{Beef: []byte{1}, OutputIndex: 0, Metadata: map[string]string{"ticker": "TEST"}}},
+				NextCursor:      "next",
+				Page:            engine.LookupPage{Limit: 1},
+			},
+			query: map[string]string{"limit": "1"},
+			expectedAnswer: &lookupanswer.Answer{
+				Type:       string(lookup.AnswerTypeOutputList),
+				NextCursor: "next",
+				Outputs:    []lookupanswer.Output{{Beef: []byte{1}, OutputIndex: 0, Metadata: map[string]string{"ticker": "TEST"}}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, tc.expectations)))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				SetHeader(fiber.HeaderAccept, lookupanswer.MIMEBinary).
+				SetQueryParams(tc.query).
+				SetBody(openapi.LookupQuestionJSONRequestBody{
+					Query:   map[string]any{"test": "query"},
+					Service: "test-service",
+				}).
+				Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, fiber.StatusOK, res.StatusCode())
+			require.Equal(t, lookupanswer.MIMEBinary, res.Header().Get(fiber.HeaderContentType))
+			answer, err := lookupanswer.UnmarshalBinary(res.Body())
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedAnswer, answer)
+
+			stub.AssertProvidersState()
+		})
+	}
+}
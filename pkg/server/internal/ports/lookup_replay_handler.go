@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LookupReplayHandler is a Fiber-compatible HTTP handler that processes admin requests to backfill
+// a lookup service with the stored outputs of a topic. It acts as the adapter between HTTP requests
+// and the application-layer LookupReplayService.
+type LookupReplayHandler struct {
+	service *app.LookupReplayService
+}
+
+// Handle processes an HTTP POST request to replay the outputs of a topic into a lookup service.
+// It expects a JSON request body matching the ReplayTopicIntoLookupServiceJSONRequestBody OpenAPI schema.
+// The replay runs within the request, so backfilling a large topic may take a while.
+//
+// On success, returns 200 OK with the replayed events and the score reached (openapi.LookupReplayResponse).
+// On failure, returns a request parsing or application error.
+func (h *LookupReplayHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.ReplayTopicIntoLookupServiceJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	var fromScore float64
+	if body.FromScore != nil {
+		fromScore = *body.FromScore
+	}
+	progress, err := h.service.ReplayTopicIntoLookupService(c.UserContext(), body.Topic, body.Service, fromScore)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewLookupReplayResponse(progress))
+}
+
+// NewLookupReplayHandler creates a new LookupReplayHandler with the given provider.
+// If the provider is nil, it panics.
+func NewLookupReplayHandler(provider app.LookupReplayProvider) *LookupReplayHandler {
+	return &LookupReplayHandler{service: app.NewLookupReplayService(provider)}
+}
+
+// NewLookupReplayResponse converts the progress of a replay into an OpenAPI LookupReplayResponse.
+func NewLookupReplayResponse(progress *engine.LookupReplayProgress) openapi.LookupReplayResponse {
+	return openapi.LookupReplayResponse{
+		Topic:    progress.Topic,
+		Service:  progress.Service,
+		Admitted: progress.Admitted,
+		Spent:    progress.Spent,
+		Score:    progress.Score,
+	}
+}
@@ -0,0 +1,84 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupReplayHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	const service = "ls_test"
+	progress := &engine.LookupReplayProgress{Topic: testabilities.DefaultValidTopic, Service: service, Admitted: 3, Spent: 1, Score: 42}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.LookupReplayProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Replays the topic into the lookup service": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic, "service": service, "fromScore": 40},
+			expectations: testabilities.LookupReplayProviderMockExpectations{
+				ReplayTopicIntoLookupServiceCall: true,
+				Topic:                            testabilities.DefaultValidTopic,
+				Service:                          service,
+				FromScore:                        40,
+				Progress:                         progress,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewLookupReplayResponse(progress),
+		},
+		"Rejects an empty topic": {
+			body:             map[string]any{"topic": "", "service": service},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("topic")),
+		},
+		"Responds with not found when the lookup service is not registered": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic, "service": service},
+			expectations: testabilities.LookupReplayProviderMockExpectations{
+				ReplayTopicIntoLookupServiceCall: true,
+				Error:                            engine.ErrLookupServiceNotRegistered,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewLookupServiceNotRegisteredError(service)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupReplayProvider(
+				testabilities.NewLookupReplayProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.LookupReplay
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/lookupServices/replay")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LookupServiceRegistrationHandler is a Fiber-compatible HTTP handler that processes
+// admin requests to register and unregister lookup services of the running overlay engine.
+// It acts as the adapter between HTTP requests and the application-layer
+// LookupServiceRegistrationService.
+type LookupServiceRegistrationHandler struct {
+	service *app.LookupServiceRegistrationService
+}
+
+// HandleRegister processes an HTTP POST request to register a lookup service.
+// It expects a JSON request body matching the RegisterLookupServiceJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK. On failure, returns a request parsing or application error.
+func (h *LookupServiceRegistrationHandler) HandleRegister(c *fiber.Ctx) error {
+	var body openapi.RegisterLookupServiceJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	if err := h.service.RegisterLookupService(c.UserContext(), body.Name); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewLookupServiceRegistrationResponse())
+}
+
+// HandleUnregister processes an HTTP DELETE request to unregister the lookup service
+// passed as the lookupService query parameter.
+//
+// On success, returns 200 OK. On failure, returns an application error.
+func (h *LookupServiceRegistrationHandler) HandleUnregister(c *fiber.Ctx, params openapi.UnregisterLookupServiceParams) error {
+	if err := h.service.UnregisterLookupService(c.UserContext(), params.LookupService); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewLookupServiceRegistrationResponse())
+}
+
+// NewLookupServiceRegistrationHandler creates a new LookupServiceRegistrationHandler with the given provider.
+// If the provider is nil, it panics.
+func NewLookupServiceRegistrationHandler(provider app.LookupServiceRegistrationProvider) *LookupServiceRegistrationHandler {
+	return &LookupServiceRegistrationHandler{service: app.NewLookupServiceRegistrationService(provider)}
+}
+
+// NewLookupServiceRegistrationResponse returns a new LookupServiceRegistration response.
+func NewLookupServiceRegistrationResponse() openapi.LookupServiceRegistration {
+	return openapi.LookupServiceRegistration{
+		Message: "OK",
+	}
+}
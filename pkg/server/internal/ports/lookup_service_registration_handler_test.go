@@ -0,0 +1,162 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupServiceRegistrationHandler_List(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupListProvider(testabilities.NewLookupListProviderMock(t, testabilities.LookupListProviderMockExpectations{
+		Metadata: map[string]*overlay.MetaData{
+			"ls_registered": {Name: "ls_registered_name"},
+		},
+		ListLookupServiceProvidersCall: true,
+	})))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.MetadataResponse
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/lookupServices")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, "ls_registered_name", actualResponse["ls_registered"].Name)
+	stub.AssertProvidersState()
+}
+
+func TestLookupServiceRegistrationHandler_Register(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.LookupServiceRegistrationProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Registers the lookup service": {
+			body: map[string]any{"name": "ls_new"},
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				AddLookupServiceCall: true,
+				Name:                 "ls_new",
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewLookupServiceRegistrationResponse(),
+		},
+		"Rejects an empty lookup service name": {
+			body:             map[string]any{"name": ""},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewEmptyLookupServiceNameError()),
+		},
+		"Responds with not found when the engine has no lookup service factory": {
+			body: map[string]any{"name": "ls_new"},
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				AddLookupServiceCall: true,
+				Error:                engine.ErrLookupServiceFactoryNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewLookupServiceFactoryNotConfiguredError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupServiceRegistrationProvider(
+				testabilities.NewLookupServiceRegistrationProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.LookupServiceRegistration
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/lookupServices")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestLookupServiceRegistrationHandler_Unregister(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		expectations     testabilities.LookupServiceRegistrationProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Unregisters the lookup service": {
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				UnregisterLookupServiceCall: true,
+				Name:                        "ls_old",
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewLookupServiceRegistrationResponse(),
+		},
+		"Responds with not found when the lookup service is not registered": {
+			expectations: testabilities.LookupServiceRegistrationProviderMockExpectations{
+				UnregisterLookupServiceCall: true,
+				Error:                       engine.ErrLookupServiceNotRegistered,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewLookupServiceNotRegisteredError("ls_old")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupServiceRegistrationProvider(
+				testabilities.NewLookupServiceRegistrationProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.LookupServiceRegistration
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("lookupService", "ls_old").
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Delete("/api/v1/admin/lookupServices")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
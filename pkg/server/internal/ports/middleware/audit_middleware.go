@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Paths of the endpoints recorded by the audit middleware.
+const (
+	auditAdminPathPrefix = "/api/v1/admin/"
+	auditSubmitPath      = "/api/v1/submit"
+)
+
+// Callers recorded by the audit middleware for requests without a BRC-31 identity or API key.
+const (
+	AuditCallerAdmin     = "admin"
+	AuditCallerAnonymous = "anonymous"
+)
+
+// AuditMiddlewareConfig configures the audit middleware.
+type AuditMiddlewareConfig struct {
+	// Recorder appends the entries to the audit log.
+	Recorder app.AuditRecorder
+
+	// AdminBearerToken identifies requests of the administrator, recorded with the AuditCallerAdmin caller.
+	AdminBearerToken string
+}
+
+// AuditMiddleware returns a fiber.Handler recording the admin API calls changing the state of the overlay node,
+// i.e. every admin request other than GET, HEAD and OPTIONS, and the transaction submissions to the audit log,
+// with the caller, the topics and txid of submissions, and the result of the request.
+//
+// The caller is the BRC-31 identity key ("identity:<key>") of authenticated requests, AuditCallerAdmin for
+// the admin bearer token, a fingerprint of any other Bearer token ("apikey:<fingerprint>"), never the token
+// itself, and AuditCallerAnonymous otherwise. Errors of the request are handled by the error handler of
+// the fiber.App, so that their status is recorded. Panics if the recorder is nil.
+func AuditMiddleware(cfg AuditMiddlewareConfig) fiber.Handler {
+	if cfg.Recorder == nil {
+		panic("audit recorder cannot be nil")
+	}
+
+	return func(c *fiber.Ctx) error {
+		submit := c.Path() == auditSubmitPath && c.Method() == fiber.MethodPost
+		if !submit && !isAuditedAdminRequest(c) {
+			return c.Next()
+		}
+
+		entry := &engine.AuditEntry{
+			Action:     c.Method() + " " + c.Path(),
+			Caller:     auditCaller(c, cfg.AdminBearerToken),
+			RemoteAddr: c.IP(),
+			Result:     engine.AuditResultSuccess,
+		}
+		if handlerErr := c.Next(); handlerErr != nil {
+			entry.Error = handlerErr.Error()
+			if err := c.App().Config().ErrorHandler(c, handlerErr); err != nil {
+				return err
+			}
+		}
+		entry.Status = c.Response().StatusCode()
+		if entry.Error != "" || entry.Status >= fiber.StatusBadRequest {
+			entry.Result = engine.AuditResultFailure
+		}
+		if submit {
+			entry.Topics = auditTopics(c.Get("x-topics"))
+			if tx, err := transaction.NewTransactionFromBEEF(c.Body()); err == nil {
+				entry.Txid = tx.TxID().String()
+			}
+		}
+
+		// A failure to record the entry is logged by the recorder and does not affect the served response.
+		_ = cfg.Recorder.RecordAudit(c.UserContext(), entry)
+		return nil
+	}
+}
+
+func isAuditedAdminRequest(c *fiber.Ctx) bool {
+	switch c.Method() {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return false
+	default:
+		return strings.HasPrefix(c.Path(), auditAdminPathPrefix)
+	}
+}
+
+// auditCaller identifies the caller of the request without recording its credentials.
+func auditCaller(c *fiber.Ctx, adminToken string) string {
+	if identityKey := BRC31IdentityKey(c); identityKey != "" {
+		return "identity:" + identityKey
+	}
+	token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	switch {
+	case !found || token == "":
+		return AuditCallerAnonymous
+	case adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1:
+		return AuditCallerAdmin
+	default:
+		sum := sha256.Sum256([]byte(token))
+		return "apikey:" + hex.EncodeToString(sum[:8])
+	}
+}
+
+// auditTopics splits the comma-separated topics of the x-topics header.
+func auditTopics(header string) []string {
+	var topics []string
+	for topic := range strings.SplitSeq(header, ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditMiddleware_ShouldRecordAdminActions(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	auditLog := testabilities.NewAuditLogProviderMock(t, testabilities.AuditLogProviderMockExpectations{
+		FindAuditEntriesCall: true,
+		RecordedEntries:      2,
+	})
+	stub := testabilities.NewTestOverlayEngineStub(t,
+		testabilities.WithAuditLogProvider(auditLog),
+		testabilities.WithStartGASPSyncProvider(testabilities.NewStartGASPSyncProviderMock(t, testabilities.StartGASPSyncProviderMockExpectations{
+			StartGASPSyncCall: true,
+		})),
+	)
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithAdminBearerToken(token),
+		server.WithAudit(server.AuditConfig{Enabled: true}),
+	)
+
+	// when:
+	authorized, _ := fixture.Client().R().SetHeader(fiber.HeaderAuthorization, "Bearer "+token).Post("/api/v1/admin/startGASPSync")
+	unauthorized, _ := fixture.Client().R().SetHeader(fiber.HeaderAuthorization, "Bearer other-token").Post("/api/v1/admin/startGASPSync")
+	query, _ := fixture.Client().R().SetHeader(fiber.HeaderAuthorization, "Bearer "+token).Get("/api/v1/admin/auditLog")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, authorized.StatusCode())
+	require.Equal(t, fiber.StatusForbidden, unauthorized.StatusCode())
+	require.Equal(t, fiber.StatusOK, query.StatusCode())
+
+	recorded := auditLog.RecordedEntries()
+	require.Len(t, recorded, 2)
+	require.Equal(t, "POST /api/v1/admin/startGASPSync", recorded[0].Action)
+	require.Equal(t, middleware.AuditCallerAdmin, recorded[0].Caller)
+	require.Equal(t, engine.AuditResultSuccess, recorded[0].Result)
+	require.Equal(t, fiber.StatusOK, recorded[0].Status)
+
+	require.Equal(t, "POST /api/v1/admin/startGASPSync", recorded[1].Action)
+	require.NotEqual(t, middleware.AuditCallerAdmin, recorded[1].Caller)
+	require.NotContains(t, recorded[1].Caller, "other-token")
+	require.Equal(t, engine.AuditResultFailure, recorded[1].Result)
+	require.Equal(t, fiber.StatusForbidden, recorded[1].Status)
+	stub.AssertProvidersState()
+}
+
+func TestAuditMiddleware_ShouldNotRecordWhenDisabled(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	stub := testabilities.NewTestOverlayEngineStub(t,
+		testabilities.WithAuditLogProvider(testabilities.NewAuditLogProviderMock(t, testabilities.AuditLogProviderMockExpectations{})),
+		testabilities.WithStartGASPSyncProvider(testabilities.NewStartGASPSyncProviderMock(t, testabilities.StartGASPSyncProviderMockExpectations{
+			StartGASPSyncCall: true,
+		})),
+	)
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	res, _ := fixture.Client().R().SetHeader(fiber.HeaderAuthorization, "Bearer "+token).Post("/api/v1/admin/startGASPSync")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	stub.AssertProvidersState()
+}
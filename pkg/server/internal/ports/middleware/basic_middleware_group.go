@@ -9,29 +9,30 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/gofiber/fiber/v2/middleware/recover"
-	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
 // BasicMiddlewareGroupConfig defines configuration options for building the middleware group.
 type BasicMiddlewareGroupConfig struct {
-	OctetStreamLimit int64 // Max allowed body size for octet-stream requests.
-	EnableStackTrace bool  // Enable stack traces in panic recovery middleware.
+	RequestBody      RequestBodyMiddlewareConfig // Limits, spooling and backpressure of request bodies.
+	Compression      CompressionMiddlewareConfig // Compression of response bodies.
+	EnableStackTrace bool                        // Enable stack traces in panic recovery middleware.
 }
 
 // BasicMiddlewareGroup returns a list of preconfigured middleware for the HTTP server.
-// It includes logging, CORS, request ID generation, panic recovery, PProf, request size limiting, health check.
+// It includes logging, CORS, request ID generation, panic recovery, PProf, response compression, request size limiting, health check.
 func BasicMiddlewareGroup(cfg BasicMiddlewareGroupConfig) []fiber.Handler {
 	return []fiber.Handler{
-		requestid.New(),
+		RequestIDMiddleware(),
 		idempotency.New(),
 		cors.New(),
 		recover.New(recover.Config{EnableStackTrace: cfg.EnableStackTrace}),
 		logger.New(logger.Config{
-			Format:     "date=${time} request_id=${locals:requestid} status=${status} method=${method} path=${path} err=${error}\n",
+			Format:     "date=${time} request_id=${locals:" + RequestIDLocalsKey + "} status=${status} method=${method} path=${path} err=${error}\n",
 			TimeFormat: "02-Jan-2006 15:04:05",
 		}),
+		CompressionMiddleware(cfg.Compression),
 		healthcheck.New(),
 		pprof.New(pprof.Config{Prefix: "/api/v1"}),
-		LimitOctetStreamBodyMiddleware(cfg.OctetStreamLimit),
+		RequestBodyMiddleware(cfg.RequestBody),
 	}
 }
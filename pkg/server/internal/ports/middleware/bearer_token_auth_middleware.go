@@ -53,21 +53,21 @@ func BearerTokenAuthorizationMiddleware(expectedToken string) fiber.Handler {
 // Authorization header is missing from the request.
 func NewMissingAuthorizationHeaderError() app.Error {
 	const str = "Unauthorized access: Missing Authorization header in the request"
-	return app.NewAuthorizationError(str, str)
+	return app.NewAuthorizationError(str, str).WithCode("ERR_MISSING_AUTHORIZATION")
 }
 
 // NewMissingBearerTokenValueError returns an app.Error indicating that the
 // Bearer token value is missing from the Authorization header.
 func NewMissingBearerTokenValueError() app.Error {
 	const str = "Unauthorized access: Missing Authorization header Bearer token value"
-	return app.NewAuthorizationError(str, str)
+	return app.NewAuthorizationError(str, str).WithCode("ERR_MISSING_AUTHORIZATION")
 }
 
 // NewInvalidBearerTokenValueError returns an app.Error indicating that the
 // Bearer token provided is invalid or not recognized.
 func NewInvalidBearerTokenValueError() app.Error {
 	const str = "Forbidden access: Invalid Bearer token value"
-	return app.NewAccessForbiddenError(str, str)
+	return app.NewAccessForbiddenError(str, str).WithCode("ERR_INVALID_TOKEN")
 }
 
 // NewBearerAuthScopesAssertionError returns an app.Error indicating that the
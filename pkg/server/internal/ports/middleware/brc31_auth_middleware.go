@@ -0,0 +1,312 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-sdk/auth"
+	"github.com/bsv-blockchain/go-sdk/auth/authpayload"
+	"github.com/bsv-blockchain/go-sdk/auth/brc104"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+// BRC31AuthPath is the path serving the BRC-31 handshake and certificate exchange messages.
+const BRC31AuthPath = "/.well-known/auth"
+
+// brc31SessionWaitMs bounds the wait for the authenticated session used to sign a response.
+const brc31SessionWaitMs = 30_000
+
+// brc31IdentityKeyLocal is the fiber.Ctx locals key holding the identity key of an authenticated request.
+const brc31IdentityKeyLocal = "brc31IdentityKey"
+
+var (
+	errBRC31NoMessageHandler = errors.New("BRC-31 peer has not registered a message handler")
+	errBRC31NoExchange       = errors.New("BRC-31 message sent outside of a request")
+)
+
+// BRC31AuthMiddlewareConfig configures the BRC-31 (Authrite) mutual authentication middleware.
+type BRC31AuthMiddlewareConfig struct {
+	// Wallet holds the identity of the server, used to complete handshakes and sign responses.
+	Wallet wallet.Interface
+
+	// Required rejects requests that are not BRC-31 authenticated with 401. Otherwise they pass through anonymously.
+	Required bool
+
+	// Identities, when non-empty, maps each identity key permitted to make authenticated requests
+	// to the path prefixes it may access. Other identities and paths are rejected with 403.
+	Identities map[string][]string
+}
+
+// permits reports whether the identity may access the path.
+func (c BRC31AuthMiddlewareConfig) permits(identityKey, path string) bool {
+	if len(c.Identities) == 0 {
+		return true
+	}
+	for _, prefix := range c.Identities[identityKey] {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BRC31AuthMiddleware returns a fiber.Handler authenticating requests with BRC-31 (Authrite).
+// It serves the handshake on BRC31AuthPath, verifies the signature of requests carrying the
+// x-bsv-auth headers, enforces the per-identity rules and signs the responses to authenticated requests.
+// The topics of authenticated requests are taken from the signed x-bsv-topics header, replacing any x-topics header.
+// The identity key of an authenticated request is logged and available through BRC31IdentityKey.
+// Panics if the wallet is nil.
+func BRC31AuthMiddleware(cfg BRC31AuthMiddlewareConfig) fiber.Handler {
+	if cfg.Wallet == nil {
+		panic("BRC-31 auth wallet cannot be nil")
+	}
+
+	transport := &brc31Transport{}
+	autoPersistLastSession := false
+	peer := auth.NewPeer(&auth.PeerOptions{
+		Wallet:                 cfg.Wallet,
+		Transport:              transport,
+		AutoPersistLastSession: &autoPersistLastSession,
+	})
+	peer.ListenForGeneralMessages(func(ctx context.Context, sender *ec.PublicKey, _ []byte) error {
+		if exchange, ok := ctx.Value(brc31ExchangeKey{}).(*brc31Exchange); ok {
+			exchange.identity = sender
+		}
+		return nil
+	})
+
+	return func(c *fiber.Ctx) error {
+		if c.Path() == BRC31AuthPath && c.Method() == fiber.MethodPost {
+			return handleBRC31Handshake(c, transport)
+		}
+		if c.Get(brc104.HeaderVersion) == "" {
+			if cfg.Required {
+				return NewBRC31AuthenticationRequiredError()
+			}
+			return c.Next()
+		}
+		return handleBRC31Request(c, cfg, peer, transport)
+	}
+}
+
+// BRC31IdentityKey returns the hex identity key of the BRC-31 authenticated request,
+// or an empty string if the request is not authenticated.
+func BRC31IdentityKey(c *fiber.Ctx) string {
+	identityKey, _ := c.Locals(brc31IdentityKeyLocal).(string)
+	return identityKey
+}
+
+func handleBRC31Handshake(c *fiber.Ctx, transport *brc31Transport) error {
+	var message auth.AuthMessage
+	if err := json.Unmarshal(c.Body(), &message); err != nil {
+		return NewBRC31InvalidMessageError(err)
+	}
+
+	exchange := &brc31Exchange{}
+	if err := transport.receive(context.WithValue(c.UserContext(), brc31ExchangeKey{}, exchange), &message); err != nil {
+		return NewBRC31AuthenticationFailedError(err)
+	}
+	if exchange.response == nil {
+		return c.SendStatus(fiber.StatusOK)
+	}
+	return c.Status(fiber.StatusOK).JSON(exchange.response)
+}
+
+func handleBRC31Request(c *fiber.Ctx, cfg BRC31AuthMiddlewareConfig, peer *auth.Peer, transport *brc31Transport) error {
+	requestID, err := base64.StdEncoding.DecodeString(c.Get(brc104.HeaderRequestID))
+	if err != nil {
+		return NewBRC31InvalidMessageError(err)
+	}
+	identityKey, err := ec.PublicKeyFromString(c.Get(brc104.HeaderIdentityKey))
+	if err != nil {
+		return NewBRC31InvalidMessageError(err)
+	}
+	signature, err := hex.DecodeString(c.Get(brc104.HeaderSignature))
+	if err != nil {
+		return NewBRC31InvalidMessageError(err)
+	}
+
+	var req http.Request
+	if err := fasthttpadaptor.ConvertRequest(c.Context(), &req, true); err != nil {
+		return NewBRC31InvalidMessageError(err)
+	}
+	payload, err := authpayload.FromHTTPRequest(requestID, &req)
+	if err != nil {
+		return NewBRC31InvalidMessageError(err)
+	}
+
+	exchange := &brc31Exchange{}
+	err = transport.receive(context.WithValue(c.UserContext(), brc31ExchangeKey{}, exchange), &auth.AuthMessage{
+		Version:     c.Get(brc104.HeaderVersion),
+		MessageType: auth.MessageTypeGeneral,
+		IdentityKey: identityKey,
+		Nonce:       c.Get(brc104.HeaderNonce),
+		YourNonce:   c.Get(brc104.HeaderYourNonce),
+		Signature:   signature,
+		Payload:     payload,
+	})
+	if err != nil {
+		return NewBRC31AuthenticationFailedError(err)
+	}
+	if exchange.identity == nil {
+		return NewBRC31AuthenticationFailedError(auth.ErrInvalidMessage)
+	}
+
+	identity := exchange.identity.ToDERHex()
+	c.Locals(brc31IdentityKeyLocal, identity)
+	slog.Info("BRC-31 authenticated request", "identityKey", identity, "method", c.Method(), "path", c.Path())
+
+	// The topics of an authenticated request are those the peer signed, never an unsigned x-topics header.
+	if topics := c.Get(engine.AuthTopicsHeader); topics != "" {
+		c.Request().Header.Set("x-topics", topics)
+	} else {
+		c.Request().Header.Del("x-topics")
+	}
+
+	var handlerErr error
+	if cfg.permits(identity, c.Path()) {
+		handlerErr = c.Next()
+	} else {
+		slog.Warn("BRC-31 identity is not permitted to access the path", "identityKey", identity, "path", c.Path())
+		handlerErr = NewBRC31IdentityForbiddenError(identity, c.Path())
+	}
+	if handlerErr != nil {
+		if err := c.App().Config().ErrorHandler(c, handlerErr); err != nil {
+			return err
+		}
+	}
+	return signBRC31Response(c, peer, exchange.identity, requestID)
+}
+
+// signBRC31Response signs the response written for the authenticated request and sets the x-bsv-auth response headers.
+func signBRC31Response(c *fiber.Ctx, peer *auth.Peer, identityKey *ec.PublicKey, requestID []byte) error {
+	header := make(http.Header)
+	c.Response().Header.VisitAll(func(key, value []byte) {
+		header.Add(string(key), string(value))
+	})
+	payload, err := authpayload.FromResponse(requestID, authpayload.SimplifiedHttpResponse{
+		StatusCode: c.Response().StatusCode(),
+		Header:     header,
+		Body:       c.Response().Body(),
+	})
+	if err != nil {
+		return NewBRC31ResponseSigningError(err)
+	}
+
+	exchange := &brc31Exchange{}
+	if err := peer.ToPeer(context.WithValue(c.UserContext(), brc31ExchangeKey{}, exchange), payload, identityKey, brc31SessionWaitMs); err != nil {
+		return NewBRC31ResponseSigningError(err)
+	}
+	message := exchange.response
+	c.Set(brc104.HeaderVersion, message.Version)
+	c.Set(brc104.HeaderMessageType, string(message.MessageType))
+	c.Set(brc104.HeaderIdentityKey, message.IdentityKey.ToDERHex())
+	c.Set(brc104.HeaderNonce, message.Nonce)
+	c.Set(brc104.HeaderYourNonce, message.YourNonce)
+	c.Set(brc104.HeaderSignature, hex.EncodeToString(message.Signature))
+	c.Set(brc104.HeaderRequestID, base64.StdEncoding.EncodeToString(requestID))
+	return nil
+}
+
+// brc31Exchange collects the outcome of handing a request's message to the peer:
+// the verified identity of the sender and the message the peer replies with.
+type brc31Exchange struct {
+	identity *ec.PublicKey
+	response *auth.AuthMessage
+}
+
+type brc31ExchangeKey struct{}
+
+// brc31Transport is the auth.Transport of the server peer. It hands the messages received by the
+// middleware to the peer and captures the peer's replies into the exchange of the request being processed.
+type brc31Transport struct {
+	mu     sync.RWMutex
+	onData func(context.Context, *auth.AuthMessage) error
+}
+
+// OnData registers the peer's handler of incoming messages.
+func (t *brc31Transport) OnData(callback func(context.Context, *auth.AuthMessage) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onData = callback
+	return nil
+}
+
+// GetRegisteredOnData returns the peer's handler of incoming messages.
+func (t *brc31Transport) GetRegisteredOnData() (func(context.Context, *auth.AuthMessage) error, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.onData == nil {
+		return nil, errBRC31NoMessageHandler
+	}
+	return t.onData, nil
+}
+
+// Send captures the peer's reply into the exchange of the request being processed.
+func (t *brc31Transport) Send(ctx context.Context, message *auth.AuthMessage) error {
+	exchange, ok := ctx.Value(brc31ExchangeKey{}).(*brc31Exchange)
+	if !ok {
+		return errBRC31NoExchange
+	}
+	exchange.response = message
+	return nil
+}
+
+func (t *brc31Transport) receive(ctx context.Context, message *auth.AuthMessage) error {
+	onData, err := t.GetRegisteredOnData()
+	if err != nil {
+		return err
+	}
+	return onData(ctx, message)
+}
+
+// NewBRC31AuthenticationRequiredError returns an app.Error indicating that the request is not BRC-31 authenticated.
+func NewBRC31AuthenticationRequiredError() app.Error {
+	const str = "Unauthorized access: BRC-31 mutual authentication is required"
+	return app.NewAuthorizationError(str, str)
+}
+
+// NewBRC31InvalidMessageError returns an app.Error indicating that the BRC-31 message of the request is malformed.
+func NewBRC31InvalidMessageError(err error) app.Error {
+	return app.NewAuthorizationError(
+		fmt.Sprintf("invalid BRC-31 message: %v", err),
+		"Unauthorized access: The BRC-31 authentication message is malformed.",
+	)
+}
+
+// NewBRC31AuthenticationFailedError returns an app.Error indicating that the BRC-31 message could not be verified.
+func NewBRC31AuthenticationFailedError(err error) app.Error {
+	return app.NewAuthorizationError(
+		fmt.Sprintf("BRC-31 authentication failed: %v", err),
+		"Unauthorized access: The BRC-31 authentication failed.",
+	)
+}
+
+// NewBRC31IdentityForbiddenError returns an app.Error indicating that the authenticated identity may not access the path.
+func NewBRC31IdentityForbiddenError(identityKey, path string) app.Error {
+	return app.NewAccessForbiddenError(
+		fmt.Sprintf("identity %s is not permitted to access %s", identityKey, path),
+		"Forbidden access: Your identity is not permitted to access this endpoint.",
+	)
+}
+
+// NewBRC31ResponseSigningError returns an app.Error indicating that the response to an authenticated request could not be signed.
+func NewBRC31ResponseSigningError(err error) app.Error {
+	return app.NewUnknownError(
+		fmt.Sprintf("failed to sign BRC-31 response: %v", err),
+		"Unable to sign the response due to an internal error. Please try again later or contact the support team.",
+	)
+}
@@ -0,0 +1,208 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/auth/brc104"
+	authhttp "github.com/bsv-blockchain/go-sdk/auth/clients/authhttp"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const brc31LookupURL = "http://overlay.test/api/v1/lookup"
+
+func newTestWallet(t *testing.T) *wallet.CompletedProtoWallet {
+	t.Helper()
+	key, err := ec.NewPrivateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewCompletedProtoWallet(key)
+	require.NoError(t, err)
+	return w
+}
+
+func identityKeyOf(t *testing.T, w *wallet.CompletedProtoWallet) string {
+	t.Helper()
+	res, err := w.GetPublicKey(context.Background(), wallet.GetPublicKeyArgs{IdentityKey: true}, "")
+	require.NoError(t, err)
+	return res.PublicKey.ToDERHex()
+}
+
+func brc31Config(auth server.BRC31AuthConfig) server.Config {
+	cfg := server.DefaultConfig
+	cfg.BRC31 = auth
+	return cfg
+}
+
+// fetch sends a BRC-31 authenticated POST request to the url with a client of its own, so that requests
+// never share the callbacks of an authhttp client, which its 402 payment retry accesses without locking.
+func fetch(t *testing.T, transport http.RoundTripper, client wallet.Interface, url string, headers map[string]string, body []byte) (*http.Response, error) {
+	t.Helper()
+	authFetch := authhttp.New(client, authhttp.WithHttpClientTransport(transport), authhttp.WithoutLogging())
+	res, err := authFetch.Fetch(context.Background(), url, &authhttp.SimplifiedFetchRequestOptions{
+		Method:  fiber.MethodPost,
+		Headers: headers,
+		Body:    body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { _ = res.Body.Close() })
+	return res, nil
+}
+
+// lookupBody returns the body of the lookup request sent by fetchLookup.
+func lookupBody(t *testing.T) []byte {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"service": "ls_test", "query": map[string]any{"test": "query"}})
+	require.NoError(t, err)
+	return body
+}
+
+func fetchLookup(t *testing.T, fixture *server.TestFixture, client wallet.Interface) *http.Response {
+	t.Helper()
+	res, err := fetch(t, fixture.RoundTripper(), client, brc31LookupURL, map[string]string{"Content-Type": fiber.MIMEApplicationJSON}, lookupBody(t))
+	require.NoError(t, err)
+	return res
+}
+
+func TestBRC31AuthMiddleware_ShouldServeMutuallyAuthenticatedRequests(t *testing.T) {
+	// given:
+	serverWallet := newTestWallet(t)
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+		LookupQuestionCall: true,
+		Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "ok"},
+	})))
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithBRC31Wallet(serverWallet),
+		server.WithConfig(brc31Config(server.BRC31AuthConfig{Required: true})),
+	)
+
+	// when:
+	res := fetchLookup(t, fixture, newTestWallet(t))
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+	require.Equal(t, identityKeyOf(t, serverWallet), res.Header.Get(brc104.HeaderIdentityKey))
+	stub.AssertProvidersState()
+}
+
+func TestBRC31AuthMiddleware_ShouldRejectIdentitiesOutsideOfTheirRules(t *testing.T) {
+	// given:
+	client := newTestWallet(t)
+	stub := testabilities.NewTestOverlayEngineStub(t)
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithBRC31Wallet(newTestWallet(t)),
+		server.WithConfig(brc31Config(server.BRC31AuthConfig{
+			Identities: map[string][]string{identityKeyOf(t, client): {"/api/v1/submit"}},
+		})),
+	)
+
+	// when:
+	res := fetchLookup(t, fixture, client)
+
+	// then:
+	require.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	stub.AssertProvidersState()
+}
+
+func TestBRC31AuthMiddleware_ShouldRejectUnauthenticatedRequestsWhenRequired(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t)
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithBRC31Wallet(newTestWallet(t)),
+		server.WithConfig(brc31Config(server.BRC31AuthConfig{Required: true})),
+	)
+
+	// when:
+	res, err := fixture.RoundTripper().RoundTrip(newLookupRequest(t))
+
+	// then:
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+	require.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	stub.AssertProvidersState()
+}
+
+// unsignedTopicsRoundTripper adds an x-topics header to the requests it forwards, after they were signed.
+type unsignedTopicsRoundTripper struct {
+	next   http.RoundTripper
+	topics string
+}
+
+func (r unsignedTopicsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(ports.XTopicsHeader, r.topics)
+	return r.next.RoundTrip(req)
+}
+
+func TestBRC31AuthMiddleware_ShouldSubmitToTheSignedTopicsOnly(t *testing.T) {
+	tests := map[string]struct {
+		signedTopics   string
+		submitCall     bool
+		expectedStatus int
+	}{
+		"Signed topics replace the unsigned ones": {
+			signedTopics:   "tm_signed",
+			submitCall:     true,
+			expectedStatus: fiber.StatusOK,
+		},
+		"Unsigned topics are ignored without signed ones": {
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			beef := []byte{1, 2, 3}
+			expectations := testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false}
+			if tc.submitCall {
+				expectations = testabilities.DefaultSubmitTransactionProviderMockExpectations
+				expectations.Topics = []string{tc.signedTopics}
+				expectations.BEEF = beef
+			}
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(
+				testabilities.NewSubmitTransactionProviderMock(t, expectations),
+			))
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithBRC31Wallet(newTestWallet(t)),
+				server.WithConfig(brc31Config(server.BRC31AuthConfig{Required: true})),
+			)
+			headers := map[string]string{"Content-Type": fiber.MIMEOctetStream}
+			if tc.signedTopics != "" {
+				headers[engine.AuthTopicsHeader] = tc.signedTopics
+			}
+			transport := unsignedTopicsRoundTripper{next: fixture.RoundTripper(), topics: "tm_unsigned"}
+
+			// when:
+			res, err := fetch(t, transport, newTestWallet(t), "http://overlay.test/api/v1/submit", headers, beef)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, res.StatusCode)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func newLookupRequest(t *testing.T) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), fiber.MethodPost, brc31LookupURL, http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return req
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+// Compression levels of CompressionMiddlewareConfig.
+const (
+	CompressionLevelDefault  = "default"  // balances CPU and size; also selected by an empty level
+	CompressionLevelSpeed    = "speed"    // spends the least CPU
+	CompressionLevelBest     = "best"     // produces the smallest bodies
+	CompressionLevelDisabled = "disabled" // leaves bodies uncompressed
+)
+
+// CompressionMiddlewareConfig configures the compression of response bodies.
+type CompressionMiddlewareConfig struct {
+	// Level is one of the CompressionLevel constants. Unknown levels fall back to CompressionLevelDefault.
+	Level string
+}
+
+// CompressionMiddleware returns a middleware compressing response bodies with brotli, gzip or deflate, as
+// negotiated through the Accept-Encoding header of the request. Requests without an Accept-Encoding header,
+// small bodies, bodies already carrying a Content-Encoding and BRC-31 handshake responses are left uncompressed.
+// Streamed bodies are compressed as they are written.
+func CompressionMiddleware(cfg CompressionMiddlewareConfig) fiber.Handler {
+	level := compress.LevelDefault
+	switch cfg.Level {
+	case CompressionLevelSpeed:
+		level = compress.LevelBestSpeed
+	case CompressionLevelBest:
+		level = compress.LevelBestCompression
+	case CompressionLevelDisabled:
+		level = compress.LevelDisabled
+	}
+	return compress.New(compress.Config{
+		// The BRC-31 transport of go-sdk ignores handshake responses without a Content-Length, which Go
+		// clients drop when they transparently decompress a response.
+		Next:  func(c *fiber.Ctx) bool { return c.Path() == BRC31AuthPath },
+		Level: level,
+	})
+}
@@ -0,0 +1,58 @@
+package middleware_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionMiddleware_ShouldCompressResponsesAsNegotiated(t *testing.T) {
+	tests := map[string]struct {
+		level            string
+		acceptEncoding   string
+		expectedEncoding string
+	}{
+		"gzip":                  {acceptEncoding: "gzip", expectedEncoding: "gzip"},
+		"brotli":                {level: middleware.CompressionLevelSpeed, acceptEncoding: "br", expectedEncoding: "br"},
+		"without accept header": {},
+		"disabled":              {level: middleware.CompressionLevelDisabled, acceptEncoding: "gzip"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			beef := bytes.Repeat([]byte{0xbe, 0xef}, 4096)
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithLookupQuestionProvider(
+				testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+					LookupPagedCall: true,
+					Outputs:         []*engine.LookupOutput{{Beef: beef}},
+					Page:            engine.LookupPage{Limit: 1},
+				}),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithCompression(server.CompressionConfig{Level: tc.level}))
+
+			// when:
+			req := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				SetQueryParam("limit", "1").
+				SetBody(openapi.LookupQuestionJSONRequestBody{Query: map[string]any{"test": "query"}, Service: "test-service"})
+			if tc.acceptEncoding != "" {
+				req.SetHeader(fiber.HeaderAcceptEncoding, tc.acceptEncoding)
+			}
+			res, _ := req.Post("/api/v1/lookup")
+
+			// then:
+			require.Equal(t, fiber.StatusOK, res.StatusCode())
+			require.Equal(t, tc.expectedEncoding, res.Header().Get(fiber.HeaderContentEncoding))
+			stub.AssertProvidersState()
+		})
+	}
+}
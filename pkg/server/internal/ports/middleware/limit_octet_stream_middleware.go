@@ -2,95 +2,206 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/gofiber/fiber/v2"
+	"golang.org/x/sync/semaphore"
 )
 
 // ReadBodyLimit1GB defines the maximum allowed bytes read size (in bytes).
 // This limit is set to 1GB to protect against excessively large payloads.
 const ReadBodyLimit1GB = 1000 * 1024 * 1024 // 1,000 MB
 
+// DefaultInMemoryBodyLimit is the size (in bytes) up to which request bodies are buffered in memory.
+// It matches the default body limit of Fiber.
+const DefaultInMemoryBodyLimit = 4 * 1024 * 1024 // 4 MB
+
+// DefaultBodyIngestWait bounds the wait of a request body for room in the in-flight bytes budget.
+const DefaultBodyIngestWait = 5 * time.Second
+
 // chunkSize defines the size of each chunk (in bytes) read from the input stream.
 // Reading in smaller chunks helps control memory usage during large reads.
 const chunkSize = 64 * 1024 // 64KB
 
-// LimitedBytesReader is a utility for safely reading bytes with an enforced size limit.
-// It is typically used to prevent reading more than a configured number of bytes
-// from an incoming payload (e.g., request body).
-type limitedBytesReader struct {
-	// Bytes is the source data to be read.
-	bytes []byte
+// errBodyLimitExceeded is returned by the spool when the body exceeds its limit.
+var errBodyLimitExceeded = errors.New("request body exceeds its limit")
+
+// RequestBodyMiddlewareConfig configures the ingestion of request bodies.
+type RequestBodyMiddlewareConfig struct {
+	// OctetStreamLimit is the maximum size (in bytes) of application/octet-stream request bodies.
+	OctetStreamLimit int64
+
+	// InMemoryLimit is the size (in bytes) up to which streamed request bodies are kept in memory while
+	// they are received; larger bodies are spooled to a temporary file. It is also the maximum size of
+	// request bodies other than octet-streams. Zero falls back to DefaultInMemoryBodyLimit.
+	InMemoryLimit int64
+
+	// RouteLimits maps a route path to the maximum size (in bytes) of its request bodies,
+	// overriding OctetStreamLimit and InMemoryLimit for that route.
+	RouteLimits map[string]int64
+
+	// SpoolDir is the directory of the spool files. Empty uses the default directory for temporary files.
+	SpoolDir string
 
-	// ReadLimit defines the maximum number of bytes allowed to be read from Bytes.
-	// If this limit is exceeded during reading, an error is returned.
-	readLimit int64
+	// MaxInFlightBytes bounds the total size of the request bodies ingested at once. Requests that do
+	// not fit wait for earlier ones to complete and are rejected with 503 after MaxWait. Zero means unlimited.
+	MaxInFlightBytes int64
+
+	// MaxWait bounds the wait for room in the in-flight bytes budget. Zero falls back to DefaultBodyIngestWait.
+	MaxWait time.Duration
 }
 
-// Read reads from the underlying byte slice up to the specified ReadLimit.
-// It processes the input in 64KB chunks and returns the entire read data as a byte slice.
-//
-// If more than ReadLimit bytes are encountered, the function returns BodySizeLimitExceededError.
-// If the byte slice is empty, it returns EmptyRequestBodyError.
-// If an I/O or buffering error occurs during the read, it returns BodyReadError.
-func (l *limitedBytesReader) Read() ([]byte, error) {
-	if len(l.bytes) == 0 {
-		return nil, NewEmptyRequestBodyError()
+// limit returns the maximum size of the request body and whether it is an octet-stream.
+func (cfg RequestBodyMiddlewareConfig) limit(c *fiber.Ctx) (int64, bool) {
+	octetStream := c.Is(fiber.MIMEOctetStream)
+	if limit, ok := cfg.RouteLimits[c.Path()]; ok {
+		return limit, octetStream
+	}
+	if octetStream {
+		return cfg.OctetStreamLimit, true
 	}
+	return cfg.InMemoryLimit, false
+}
 
-	reader := io.LimitReader(bytes.NewBuffer(l.bytes), l.readLimit+1)
-	buff := bytes.NewBuffer(nil)
-	bb := make([]byte, chunkSize)
-	var read int64
-
-	for {
-		n, err := reader.Read(bb)
-		if n > 0 {
-			read += int64(n)
-			if read > l.readLimit {
-				return nil, NewBodySizeLimitExceededError(l.readLimit)
-			}
-			_, writeErr := buff.Write(bb[:n])
-			if writeErr != nil {
-				return nil, NewBodyReadError(writeErr)
+// RequestBodyMiddleware is a Fiber middleware bounding the size of incoming request bodies.
+// Bodies streamed by the server, which happens when it is configured with StreamRequestBody, are rejected
+// before they are read when their Content-Length exceeds the limit of their route. Otherwise they are read
+// in chunks within the in-flight bytes budget and spooled to a temporary file once they outgrow InMemoryLimit,
+// so that slow or concurrent uploads do not hold their partial bodies in memory.
+// Bodies buffered by the server are checked against the octet-stream and route limits only.
+func RequestBodyMiddleware(cfg RequestBodyMiddlewareConfig) fiber.Handler {
+	if cfg.InMemoryLimit <= 0 {
+		cfg.InMemoryLimit = DefaultInMemoryBodyLimit
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = DefaultBodyIngestWait
+	}
+	var budget *semaphore.Weighted
+	if cfg.MaxInFlightBytes > 0 {
+		budget = semaphore.NewWeighted(cfg.MaxInFlightBytes)
+	}
+
+	return func(c *fiber.Ctx) error {
+		limit, octetStream := cfg.limit(c)
+		req := c.Request()
+		if !req.IsBodyStream() {
+			// The server has already buffered the body within its own body limit.
+			if _, routed := cfg.RouteLimits[c.Path()]; !routed && !octetStream {
+				return c.Next()
 			}
+			return checkBody(c, int64(len(c.Body())), limit, octetStream)
+		}
+		if contentLength := int64(req.Header.ContentLength()); contentLength > limit {
+			c.Context().SetConnectionClose()
+			return newBodyLimitError(limit, octetStream)
 		}
 
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
+		if budget != nil {
+			weight := min(max(int64(req.Header.ContentLength()), 0), cfg.MaxInFlightBytes)
+			if weight == 0 {
+				weight = min(limit, cfg.MaxInFlightBytes)
+			}
+			ctx, cancel := context.WithTimeout(c.UserContext(), cfg.MaxWait)
+			err := budget.Acquire(ctx, weight)
+			cancel()
+			if err != nil {
+				return NewBodyIngestBusyError(cfg.MaxWait)
 			}
-			return nil, NewBodyReadError(err)
+			defer budget.Release(weight)
 		}
+
+		body, err := spoolBody(req.BodyStream(), limit, cfg.InMemoryLimit, cfg.SpoolDir)
+		switch {
+		case errors.Is(err, errBodyLimitExceeded):
+			c.Context().SetConnectionClose()
+			return newBodyLimitError(limit, octetStream)
+		case err != nil:
+			return NewBodyReadError(err)
+		}
+		req.SetBodyRaw(body)
+		return checkBody(c, int64(len(body)), limit, octetStream)
 	}
-	return buff.Bytes(), nil
 }
 
 // LimitOctetStreamBodyMiddleware is a Fiber middleware that limits the size of incoming
-// request bodies with the Content-Type: application/octet-stream. It reads the body in chunks
-// and ensures that the body does not exceed the specified size limit.
+// request bodies with the Content-Type: application/octet-stream.
 func LimitOctetStreamBodyMiddleware(octetStreamLimit int64) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		if !c.Is(fiber.MIMEOctetStream) {
-			return c.Next()
-		}
+	return RequestBodyMiddleware(RequestBodyMiddlewareConfig{OctetStreamLimit: octetStreamLimit})
+}
 
-		reader := limitedBytesReader{
-			bytes:     c.Body(),
-			readLimit: octetStreamLimit,
-		}
+// checkBody rejects bodies exceeding the limit and empty octet-streams before passing the request on.
+func checkBody(c *fiber.Ctx, size, limit int64, octetStream bool) error {
+	if size > limit {
+		return newBodyLimitError(limit, octetStream)
+	}
+	if octetStream && size == 0 {
+		return NewEmptyRequestBodyError()
+	}
+	return c.Next()
+}
+
+// spoolBody reads the body stream in chunks up to the limit. Bodies outgrowing the in-memory limit are
+// written to a spool file in the directory as they are received and read back once complete.
+func spoolBody(stream io.Reader, limit, inMemoryLimit int64, dir string) ([]byte, error) {
+	reader := io.LimitReader(stream, limit+1)
+	buff := bytes.NewBuffer(nil)
+	if _, err := io.CopyBuffer(buff, io.LimitReader(reader, inMemoryLimit), make([]byte, chunkSize)); err != nil {
+		return nil, err
+	}
+	head := int64(buff.Len())
+	if head > limit {
+		return nil, errBodyLimitExceeded
+	}
+	if head < inMemoryLimit {
+		return buff.Bytes(), nil
+	}
 
-		bytes, err := reader.Read()
-		if err != nil {
-			return err
+	spool, err := os.CreateTemp(dir, "overlay-body-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := spool.Close(); err != nil {
+			slog.Error("failed to close request body spool", "file", spool.Name(), "error", err)
 		}
+		if err := os.Remove(spool.Name()); err != nil {
+			slog.Error("failed to remove request body spool", "file", spool.Name(), "error", err)
+		}
+	}()
 
-		c.Context().SetBody(bytes)
-		return c.Next()
+	if _, err := spool.Write(buff.Bytes()); err != nil {
+		return nil, err
+	}
+	rest, err := io.CopyBuffer(spool, reader, make([]byte, chunkSize))
+	if err != nil {
+		return nil, err
+	}
+	if head+rest > limit {
+		return nil, errBodyLimitExceeded
 	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	body := make([]byte, head+rest)
+	if _, err := io.ReadFull(spool, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// newBodyLimitError returns the error of a body exceeding its limit.
+func newBodyLimitError(limit int64, octetStream bool) app.Error {
+	if octetStream {
+		return NewBodySizeLimitExceededError(limit)
+	}
+	return NewRequestBodyTooLargeError(limit)
 }
 
 // NewBodySizeLimitExceededError returns an error indicating that the request body exceeds the allowed maximum size.
@@ -99,11 +210,25 @@ func NewBodySizeLimitExceededError(limit int64) app.Error {
 	return app.NewIncorrectInputError(msg, msg)
 }
 
+// NewRequestBodyTooLargeError returns an error indicating that a request body other than an octet-stream
+// exceeds the maximum size of its route.
+func NewRequestBodyTooLargeError(limit int64) app.Error {
+	msg := fmt.Sprintf("The request body exceeds the maximum allowed size: %d bytes.", limit)
+	return app.NewPayloadTooLargeError(msg, msg, "ERR_REQUEST_BODY_TOO_LARGE").WithDetails(map[string]any{"limit": limit})
+}
+
+// NewBodyIngestBusyError returns an error indicating that the server is ingesting too many request bodies
+// at once to accept another one, and that the request may be retried later.
+func NewBodyIngestBusyError(retryAfter time.Duration) app.Error {
+	const msg = "The server is busy receiving other requests. Please try again later."
+	return app.NewServiceUnavailableError(msg, msg, retryAfter)
+}
+
 // NewUnsupportedContentTypeError returns an error indicating that the submitted content type is not supported.
 // It includes the expected content type in the message.
 func NewUnsupportedContentTypeError(expected string) app.Error {
 	msg := fmt.Sprintf("Unsupported content type. Expected: %s.", expected)
-	return app.NewIncorrectInputError(msg, msg)
+	return app.NewIncorrectInputError(msg, msg).WithCode("ERR_UNSUPPORTED_CONTENT_TYPE")
 }
 
 // NewBodyReadError returns an error indicating that the request body could not be read or processed.
@@ -118,5 +243,5 @@ func NewBodyReadError(err error) app.Error {
 // NewEmptyRequestBodyError returns an error indicating that the request body is empty, which is not allowed.
 func NewEmptyRequestBodyError() app.Error {
 	const msg = "Unable to process request with content type octet-stream. The request body is empty."
-	return app.NewIncorrectInputError(msg, msg)
+	return app.NewIncorrectInputError(msg, msg).WithCode("ERR_EMPTY_REQUEST_BODY")
 }
@@ -139,3 +139,80 @@ func TestLimitOctetStreamMiddleware_InvalidCases(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestBodyMiddleware(t *testing.T) {
+	const inMemoryLimit = 16
+
+	tests := map[string]struct {
+		endpoint         string
+		body             string
+		headers          map[string]string
+		routeLimits      map[string]int64
+		submitCall       bool
+		expectedResponse *openapi.Error
+		expectedStatus   int
+	}{
+		"Octet-stream larger than the in-memory limit is spooled and served whole": {
+			endpoint:       "/api/v1/submit",
+			headers:        map[string]string{fiber.HeaderContentType: fiber.MIMEOctetStream, ports.XTopicsHeader: "topic1"},
+			body:           strings.Repeat("A", 4*inMemoryLimit),
+			submitCall:     true,
+			expectedStatus: fiber.StatusOK,
+		},
+		"Octet-stream exceeding the limit of its route": {
+			endpoint:         "/api/v1/submit",
+			headers:          map[string]string{fiber.HeaderContentType: fiber.MIMEOctetStream, ports.XTopicsHeader: "topic1"},
+			body:             strings.Repeat("A", 3*inMemoryLimit),
+			routeLimits:      map[string]int64{"/api/v1/submit": 2 * inMemoryLimit},
+			expectedResponse: ptr(testabilities.NewTestOpenapiErrorResponse(t, middleware.NewBodySizeLimitExceededError(2*inMemoryLimit))),
+			expectedStatus:   fiber.StatusBadRequest,
+		},
+		"JSON body exceeding the in-memory limit": {
+			endpoint:         "/api/v1/lookup",
+			headers:          map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationJSON},
+			body:             `{"service":"ls_test","query":"` + strings.Repeat("A", inMemoryLimit) + `"}`,
+			expectedResponse: ptr(testabilities.NewTestOpenapiErrorResponse(t, middleware.NewRequestBodyTooLargeError(inMemoryLimit))),
+			expectedStatus:   fiber.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			expectations := testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: tc.submitCall}
+			if tc.submitCall {
+				expectations = testabilities.DefaultSubmitTransactionProviderMockExpectations
+				expectations.BEEF = []byte(tc.body)
+			}
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(
+				testabilities.NewSubmitTransactionProviderMock(t, expectations),
+			))
+
+			cfg := server.DefaultConfig
+			cfg.RequestBody = server.RequestBodyConfig{
+				InMemoryLimit: inMemoryLimit,
+				RouteLimits:   tc.routeLimits,
+				SpoolDir:      t.TempDir(),
+			}
+			fixture := server.NewTestFixture(t, server.WithConfig(cfg), server.WithEngine(stub))
+
+			// when:
+			var actual openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody(tc.body).
+				SetError(&actual).
+				Post(tc.endpoint)
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedResponse != nil {
+				require.Equal(t, *tc.expectedResponse, actual)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
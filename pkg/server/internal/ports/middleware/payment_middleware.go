@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-sdk/auth/utils"
+	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BRC-105 payment headers exchanged with clients paying for requests.
+const (
+	// PaymentVersion is the version of the BRC-105 payment protocol spoken by the middleware.
+	PaymentVersion = "1.0"
+
+	// HeaderPayment carries the payment attached by the client to a paid request.
+	HeaderPayment = "x-bsv-payment"
+
+	// HeaderPaymentVersion carries the version of the payment protocol in payment requests.
+	HeaderPaymentVersion = "x-bsv-payment-version"
+
+	// HeaderPaymentSatoshisRequired carries the price of the request in payment requests.
+	HeaderPaymentSatoshisRequired = "x-bsv-payment-satoshis-required"
+
+	// HeaderPaymentDerivationPrefix carries the derivation prefix the client must pay to in payment requests.
+	HeaderPaymentDerivationPrefix = "x-bsv-payment-derivation-prefix"
+
+	// HeaderPaymentSatoshisPaid carries the amount paid for a served request.
+	HeaderPaymentSatoshisPaid = "x-bsv-payment-satoshis-paid"
+)
+
+// Paths of the endpoints priced by the payment middleware.
+const (
+	paymentLookupPath = "/api/v1/lookup"
+	paymentSubmitPath = "/api/v1/submit"
+)
+
+// errPaymentNotAccepted is returned when the wallet declines to internalize a payment.
+var errPaymentNotAccepted = errors.New("payment was not accepted")
+
+// paymentProtocol is the BRC-29 wallet payment protocol the payment keys are derived with.
+var paymentProtocol = wallet.Protocol{SecurityLevel: wallet.SecurityLevelEveryAppAndCounterparty, Protocol: "3241645161d8"}
+
+// PaymentMiddlewareConfig configures the BRC-105 payment middleware.
+type PaymentMiddlewareConfig struct {
+	// Wallet holds the identity of the server, used to issue derivation prefixes and receive payments.
+	// It must be the wallet of the BRC-31 mutual authentication.
+	Wallet wallet.Interface
+
+	// Provider verifies and broadcasts the payment transactions.
+	Provider app.PaymentProvider
+
+	// SatoshisPerLookup is the price of a lookup request. Zero serves lookups for free.
+	SatoshisPerLookup uint64
+
+	// SatoshisPerSubmitKB is the price of every started kilobyte of a submitted transaction. Zero serves submissions for free.
+	SatoshisPerSubmitKB uint64
+}
+
+// price returns the amount of satoshis the request must pay, or zero if it is free.
+func (c PaymentMiddlewareConfig) price(ctx *fiber.Ctx) uint64 {
+	if ctx.Method() != fiber.MethodPost {
+		return 0
+	}
+	switch ctx.Path() {
+	case paymentLookupPath:
+		return c.SatoshisPerLookup
+	case paymentSubmitPath:
+		kilobytes := (uint64(len(ctx.Body())) + 999) / 1000
+		return max(kilobytes, 1) * c.SatoshisPerSubmitKB
+	default:
+		return 0
+	}
+}
+
+// paymentRemittance is the payment attached by the client in the x-bsv-payment header.
+type paymentRemittance struct {
+	DerivationPrefix string `json:"derivationPrefix"`
+	DerivationSuffix string `json:"derivationSuffix"`
+	Transaction      string `json:"transaction"`
+}
+
+// PaymentMiddleware returns a fiber.Handler charging for lookup and submit requests with BRC-105 payments.
+// Paid requests must be BRC-31 authenticated, so it must be registered after BRC31AuthMiddleware.
+// Requests without a payment are answered with 402 and the x-bsv-payment headers of a payment request.
+// Requests carrying a payment are served once the payment transaction is verified by the provider
+// and internalized into the wallet.
+// Panics if the wallet or the provider is nil.
+func PaymentMiddleware(cfg PaymentMiddlewareConfig) fiber.Handler {
+	if cfg.Wallet == nil {
+		panic("payment wallet cannot be nil")
+	}
+	service := app.NewPaymentService(cfg.Provider)
+
+	return func(c *fiber.Ctx) error {
+		satoshis := cfg.price(c)
+		if satoshis == 0 {
+			return c.Next()
+		}
+		identityKey := BRC31IdentityKey(c)
+		if identityKey == "" {
+			return NewPaymentAuthenticationRequiredError()
+		}
+		if c.Get(HeaderPayment) == "" {
+			return requestPayment(c, cfg.Wallet, satoshis)
+		}
+		return acceptPayment(c, cfg.Wallet, service, identityKey, satoshis)
+	}
+}
+
+// requestPayment issues a new derivation prefix and answers the request with a payment request for the satoshis.
+func requestPayment(c *fiber.Ctx, w wallet.Interface, satoshis uint64) error {
+	prefix, err := utils.CreateNonce(c.UserContext(), w, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf})
+	if err != nil {
+		return NewPaymentWalletError(err)
+	}
+	c.Set(HeaderPaymentVersion, PaymentVersion)
+	c.Set(HeaderPaymentSatoshisRequired, strconv.FormatUint(satoshis, 10))
+	c.Set(HeaderPaymentDerivationPrefix, prefix)
+	return NewPaymentRequiredError(satoshis)
+}
+
+// acceptPayment verifies the payment attached to the request, internalizes it into the wallet and serves the request.
+func acceptPayment(c *fiber.Ctx, w wallet.Interface, service *app.PaymentService, identityKey string, satoshis uint64) error {
+	var payment paymentRemittance
+	if err := json.Unmarshal([]byte(c.Get(HeaderPayment)), &payment); err != nil {
+		return NewMalformedPaymentError(err)
+	}
+	beef, err := base64.StdEncoding.DecodeString(payment.Transaction)
+	if err != nil {
+		return NewMalformedPaymentError(err)
+	}
+	prefix, err := base64.StdEncoding.DecodeString(payment.DerivationPrefix)
+	if err != nil {
+		return NewMalformedPaymentError(err)
+	}
+	suffix, err := base64.StdEncoding.DecodeString(payment.DerivationSuffix)
+	if err != nil {
+		return NewMalformedPaymentError(err)
+	}
+	sender, err := ec.PublicKeyFromString(identityKey)
+	if err != nil {
+		return NewMalformedPaymentError(err)
+	}
+
+	ctx := c.UserContext()
+	if valid, err := utils.VerifyNonce(ctx, payment.DerivationPrefix, w, wallet.Counterparty{Type: wallet.CounterpartyTypeSelf}); err != nil || !valid {
+		return NewInvalidDerivationPrefixError()
+	}
+
+	derived, err := w.GetPublicKey(ctx, wallet.GetPublicKeyArgs{
+		EncryptionArgs: wallet.EncryptionArgs{
+			ProtocolID:   paymentProtocol,
+			KeyID:        payment.DerivationPrefix + " " + payment.DerivationSuffix,
+			Counterparty: wallet.Counterparty{Type: wallet.CounterpartyTypeOther, Counterparty: sender},
+		},
+		ForSelf: util.BoolPtr(true),
+	}, "")
+	if err != nil {
+		return NewPaymentWalletError(err)
+	}
+	lockingScript, err := paymentLockingScript(derived.PublicKey)
+	if err != nil {
+		return NewPaymentWalletError(err)
+	}
+
+	outpoint, err := service.VerifyPayment(ctx, beef, lockingScript, satoshis)
+	if err != nil {
+		return err
+	}
+
+	res, err := w.InternalizeAction(ctx, wallet.InternalizeActionArgs{
+		Tx:          beef,
+		Description: "Payment for request",
+		Outputs: []wallet.InternalizeOutput{{
+			OutputIndex: outpoint.Index,
+			Protocol:    wallet.InternalizeProtocolWalletPayment,
+			PaymentRemittance: &wallet.Payment{
+				DerivationPrefix:  prefix,
+				DerivationSuffix:  suffix,
+				SenderIdentityKey: sender,
+			},
+		}},
+	}, "")
+	if err != nil {
+		slog.Error("failed to internalize payment", "outpoint", outpoint.String(), "identityKey", identityKey, "error", err)
+		return NewPaymentRejectedError(err)
+	}
+	if res != nil && !res.Accepted {
+		slog.Warn("payment was not accepted by the wallet", "outpoint", outpoint.String(), "identityKey", identityKey)
+		return NewPaymentRejectedError(errPaymentNotAccepted)
+	}
+
+	slog.Info("payment received", "outpoint", outpoint.String(), "identityKey", identityKey, "satoshis", satoshis, "path", c.Path())
+	c.Set(HeaderPaymentSatoshisPaid, strconv.FormatUint(satoshis, 10))
+	return c.Next()
+}
+
+// paymentLockingScript returns the P2PKH locking script paying to the public key.
+func paymentLockingScript(key *ec.PublicKey) (*script.Script, error) {
+	address, err := script.NewAddressFromPublicKey(key, true)
+	if err != nil {
+		return nil, err
+	}
+	return p2pkh.Lock(address)
+}
+
+// NewPaymentRequiredError returns an app.Error indicating that the request must be paid for before it is served.
+func NewPaymentRequiredError(satoshis uint64) app.Error {
+	return app.NewPaymentRequiredError(
+		fmt.Sprintf("payment of %d satoshis required", satoshis),
+		fmt.Sprintf("Payment required: The request costs %d satoshis.", satoshis),
+		"ERR_PAYMENT_REQUIRED",
+	)
+}
+
+// NewPaymentAuthenticationRequiredError returns an app.Error indicating that a paid request is not BRC-31 authenticated.
+func NewPaymentAuthenticationRequiredError() app.Error {
+	const str = "Unauthorized access: Paid requests require BRC-31 mutual authentication"
+	return app.NewAuthorizationError(str, str)
+}
+
+// NewMalformedPaymentError returns an app.Error indicating that the payment attached to the request is malformed.
+func NewMalformedPaymentError(err error) app.Error {
+	return app.NewIncorrectInputError(
+		fmt.Sprintf("malformed payment: %v", err),
+		"The x-bsv-payment header of the request is malformed.",
+	)
+}
+
+// NewInvalidDerivationPrefixError returns an app.Error indicating that the derivation prefix of the payment
+// was not issued by the server.
+func NewInvalidDerivationPrefixError() app.Error {
+	const str = "The derivation prefix of the payment was not issued by this server."
+	return app.NewIncorrectInputError(str, str)
+}
+
+// NewPaymentRejectedError returns an app.Error indicating that the wallet refused to internalize the payment,
+// e.g. because it was already redeemed.
+func NewPaymentRejectedError(err error) app.Error {
+	return app.NewIncorrectInputError(
+		fmt.Sprintf("payment rejected by the wallet: %v", err),
+		"The payment attached to the request was rejected.",
+	)
+}
+
+// NewPaymentWalletError returns an app.Error indicating that the wallet failed to issue or derive a payment key.
+func NewPaymentWalletError(err error) app.Error {
+	return app.NewUnknownError(
+		fmt.Sprintf("payment wallet failure: %v", err),
+		"Unable to process the payment due to an internal error. Please try again later or contact the support team.",
+	)
+}
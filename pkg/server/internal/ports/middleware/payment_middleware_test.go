@@ -0,0 +1,199 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const paymentSubmitURL = "http://overlay.test/api/v1/submit"
+
+// errPaymentDeclined is returned by decliningWallet when asked to pay.
+var errPaymentDeclined = errors.New("payment declined")
+
+// decliningWallet is a client wallet declining to pay for requests, recording the payment outputs it was asked to create.
+type decliningWallet struct {
+	*wallet.CompletedProtoWallet
+
+	outputs []wallet.CreateActionOutput
+}
+
+func (w *decliningWallet) CreateAction(_ context.Context, args wallet.CreateActionArgs, _ string) (*wallet.CreateActionResult, error) {
+	w.outputs = append(w.outputs, args.Outputs...)
+	return nil, errPaymentDeclined
+}
+
+// paymentTerms are the price and derivation prefix of the payment requested by the server.
+type paymentTerms struct {
+	Satoshis         uint64
+	DerivationPrefix string `json:"derivationPrefix"`
+}
+
+// requestPaymentTerms sends the request unpaid and returns the terms of the payment the server answers it with.
+// The client declines to pay, so that the paid request is sent by the caller as an exchange of its own.
+func requestPaymentTerms(t *testing.T, fixture *server.TestFixture, client *wallet.CompletedProtoWallet, url string, headers map[string]string, body []byte) paymentTerms {
+	t.Helper()
+	declining := &decliningWallet{CompletedProtoWallet: client}
+	_, err := fetch(t, fixture.RoundTripper(), declining, url, headers, body)
+	require.ErrorIs(t, err, errPaymentDeclined)
+	require.Len(t, declining.outputs, 1)
+
+	var terms paymentTerms
+	require.NoError(t, json.Unmarshal([]byte(declining.outputs[0].CustomInstructions), &terms))
+	terms.Satoshis = declining.outputs[0].Satoshis
+	return terms
+}
+
+// fetchPaidLookup sends a lookup request unpaid, then again with a placeholder payment of the requested terms.
+func fetchPaidLookup(t *testing.T, fixture *server.TestFixture, client *wallet.CompletedProtoWallet) (*http.Response, paymentTerms) {
+	t.Helper()
+	headers := map[string]string{"Content-Type": fiber.MIMEApplicationJSON}
+	terms := requestPaymentTerms(t, fixture, client, brc31LookupURL, headers, lookupBody(t))
+
+	payment, err := json.Marshal(map[string]string{
+		"derivationPrefix": terms.DerivationPrefix,
+		"derivationSuffix": base64.StdEncoding.EncodeToString([]byte("suffix")),
+		"transaction":      base64.StdEncoding.EncodeToString([]byte{1, 2, 3}),
+	})
+	require.NoError(t, err)
+	headers[middleware.HeaderPayment] = string(payment)
+
+	res, err := fetch(t, fixture.RoundTripper(), client, brc31LookupURL, headers, lookupBody(t))
+	require.NoError(t, err)
+	return res, terms
+}
+
+func paymentConfig(payments server.PaymentConfig) server.Config {
+	cfg := server.DefaultConfig
+	cfg.Payments = payments
+	return cfg
+}
+
+func TestPaymentMiddleware_ShouldServeLookupsOncePaid(t *testing.T) {
+	// given:
+	client := newTestWallet(t)
+	stub := testabilities.NewTestOverlayEngineStub(t,
+		testabilities.WithLookupQuestionProvider(testabilities.NewLookupQuestionProviderMock(t, testabilities.LookupQuestionProviderMockExpectations{
+			LookupQuestionCall: true,
+			Answer:             &lookup.LookupAnswer{Type: lookup.AnswerTypeFreeform, Result: "ok"},
+		})),
+		testabilities.WithPaymentProvider(testabilities.NewPaymentProviderMock(t, testabilities.PaymentProviderMockExpectations{
+			VerifyPaymentCall: true,
+			Satoshis:          25,
+			Outpoint:          &transaction.Outpoint{},
+		})),
+	)
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithBRC31Wallet(newTestWallet(t)),
+		server.WithConfig(paymentConfig(server.PaymentConfig{SatoshisPerLookup: 25})),
+	)
+
+	// when:
+	res, terms := fetchPaidLookup(t, fixture, client)
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+	require.Equal(t, "25", res.Header.Get(middleware.HeaderPaymentSatoshisPaid))
+	require.Equal(t, uint64(25), terms.Satoshis)
+	stub.AssertProvidersState()
+}
+
+func TestPaymentMiddleware_ShouldNotServeRejectedPayments(t *testing.T) {
+	// given:
+	client := newTestWallet(t)
+	stub := testabilities.NewTestOverlayEngineStub(t,
+		testabilities.WithPaymentProvider(testabilities.NewPaymentProviderMock(t, testabilities.PaymentProviderMockExpectations{
+			VerifyPaymentCall: true,
+			Error:             engine.ErrInsufficientPayment,
+		})),
+	)
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithBRC31Wallet(newTestWallet(t)),
+		server.WithConfig(paymentConfig(server.PaymentConfig{SatoshisPerLookup: 25})),
+	)
+
+	// when:
+	res, _ := fetchPaidLookup(t, fixture, client)
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	stub.AssertProvidersState()
+}
+
+func TestPaymentMiddleware_ShouldPriceSubmissionsByTheirWholeBody(t *testing.T) {
+	const satoshisPerKB = 10
+
+	tests := map[string]struct {
+		inMemoryLimit    int64
+		bodySize         int
+		expectedSatoshis uint64
+	}{
+		"Body kept in memory": {
+			inMemoryLimit:    middleware.DefaultInMemoryBodyLimit,
+			bodySize:         1500,
+			expectedSatoshis: 2 * satoshisPerKB,
+		},
+		"Body streamed and spooled to disk": {
+			inMemoryLimit:    1024, // above the size of the BRC-31 handshake
+			bodySize:         2500,
+			expectedSatoshis: 3 * satoshisPerKB,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t)
+			cfg := paymentConfig(server.PaymentConfig{SatoshisPerSubmitKB: satoshisPerKB})
+			cfg.RequestBody = server.RequestBodyConfig{InMemoryLimit: tc.inMemoryLimit, SpoolDir: t.TempDir()}
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithBRC31Wallet(newTestWallet(t)),
+				server.WithConfig(cfg),
+			)
+			headers := map[string]string{"Content-Type": fiber.MIMEOctetStream, engine.AuthTopicsHeader: "topic1"}
+
+			// when:
+			terms := requestPaymentTerms(t, fixture, newTestWallet(t), paymentSubmitURL, headers, bytes.Repeat([]byte{1}, tc.bodySize))
+
+			// then:
+			require.Equal(t, tc.expectedSatoshis, terms.Satoshis)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestPaymentMiddleware_ShouldRequireAuthenticationOfPaidRequests(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t)
+	fixture := server.NewTestFixture(t,
+		server.WithEngine(stub),
+		server.WithBRC31Wallet(newTestWallet(t)),
+		server.WithConfig(paymentConfig(server.PaymentConfig{SatoshisPerLookup: 25})),
+	)
+
+	// when:
+	res, err := fixture.RoundTripper().RoundTrip(newLookupRequest(t))
+
+	// then:
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+	require.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterIdleTimeout is how long the token bucket of a client without requests is kept.
+const rateLimiterIdleTimeout = 10 * time.Minute
+
+// RateLimiter limits the rate of requests of every client, identified by its IP address, with token buckets
+// whose rate and burst can be changed while the server runs. A zero rate lets every request through.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	clients   map[string]*clientRateLimiter
+	lastSweep time.Time
+}
+
+type clientRateLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing every client requestsPerSecond requests per second,
+// with bursts of up to burst requests. A burst below one allows bursts of one second worth of requests.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	r := &RateLimiter{clients: make(map[string]*clientRateLimiter)}
+	r.SetLimit(requestsPerSecond, burst)
+	return r
+}
+
+// SetLimit changes the rate and burst of every client, including those that already made requests.
+func (r *RateLimiter) SetLimit(requestsPerSecond float64, burst int) {
+	if burst < 1 {
+		burst = max(1, int(math.Ceil(requestsPerSecond)))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limit, r.burst = rate.Limit(max(requestsPerSecond, 0)), burst
+	for _, client := range r.clients {
+		client.limiter.SetLimit(r.limit)
+		client.limiter.SetBurst(r.burst)
+	}
+}
+
+// Allow reports whether the client may make a request now and, when it may not, how long it should wait.
+func (r *RateLimiter) Allow(client string) (bool, time.Duration) {
+	now := time.Now()
+
+	r.mu.Lock()
+	if r.limit == 0 {
+		r.mu.Unlock()
+		return true, 0
+	}
+	r.sweep(now)
+	entry, ok := r.clients[client]
+	if !ok {
+		entry = &clientRateLimiter{limiter: rate.NewLimiter(r.limit, r.burst)}
+		r.clients[client] = entry
+	}
+	entry.lastSeen = now
+	r.mu.Unlock()
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep forgets the clients idle for longer than rateLimiterIdleTimeout. Must be called with the lock held.
+func (r *RateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < rateLimiterIdleTimeout {
+		return
+	}
+	r.lastSweep = now
+	for client, entry := range r.clients {
+		if now.Sub(entry.lastSeen) > rateLimiterIdleTimeout {
+			delete(r.clients, client)
+		}
+	}
+}
+
+// RateLimitMiddleware returns a fiber.Handler rejecting requests of clients exceeding the rate of the limiter
+// with 429 Too Many Requests and a Retry-After header.
+func RateLimitMiddleware(limiter *RateLimiter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if ok, retryAfter := limiter.Allow(c.IP()); !ok {
+			return NewRateLimitExceededError(retryAfter)
+		}
+		return c.Next()
+	}
+}
+
+// NewRateLimitExceededError returns an app.Error indicating that the client made too many requests.
+func NewRateLimitExceededError(retryAfter time.Duration) app.Error {
+	return app.NewTooManyRequestsError(
+		"rate limit exceeded",
+		"Too many requests. Please slow down and try again later.",
+		retryAfter,
+	).WithCode("ERR_RATE_LIMITED")
+}
@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware_ShouldRejectRequestsAboveTheBurst(t *testing.T) {
+	// given:
+	fixture := server.NewTestFixture(t, server.WithRateLimit(server.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 2}))
+	listTopicManagers := func() (int, string, openapi.Error) {
+		var actualError openapi.Error
+		res, _ := fixture.Client().R().SetError(&actualError).Get("/api/v1/listTopicManagers")
+		return res.StatusCode(), res.Header().Get(fiber.HeaderRetryAfter), actualError
+	}
+
+	// when:
+	first, _, _ := listTopicManagers()
+	second, _, _ := listTopicManagers()
+	third, retryAfter, actualError := listTopicManagers()
+
+	// then:
+	require.Equal(t, fiber.StatusOK, first)
+	require.Equal(t, fiber.StatusOK, second)
+	require.Equal(t, fiber.StatusTooManyRequests, third)
+	require.NotEmpty(t, retryAfter)
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, middleware.NewRateLimitExceededError(0)), actualError)
+}
+
+func TestRateLimiter_ShouldApplyChangedLimitsToKnownClients(t *testing.T) {
+	// given:
+	sut := middleware.NewRateLimiter(0.001, 1)
+	allowed, _ := sut.Allow("client")
+	require.True(t, allowed)
+	limited, retryAfter := sut.Allow("client")
+	require.False(t, limited)
+	require.Positive(t, retryAfter)
+
+	// when:
+	sut.SetLimit(0, 0)
+	unlimited, _ := sut.Allow("client")
+
+	// then:
+	require.True(t, unlimited)
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// RequestIDLocalsKey is the key of the fiber.Ctx locals holding the ID of the request.
+const RequestIDLocalsKey = "requestid"
+
+// maxRequestIDLength is the length of the longest request ID accepted from the requester.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware returns a fiber.Handler assigning every request an ID: the X-Request-ID header of the
+// request when it holds a valid one, or else a generated UUID. The ID is returned in the X-Request-ID header
+// of the response, stored in the locals under RequestIDLocalsKey and carried by the user context, so that
+// the engine includes it in the log records of the operations serving the request.
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !validRequestID(id) {
+			id = utils.UUIDv4()
+		}
+		c.Set(fiber.HeaderXRequestID, id)
+		c.Locals(RequestIDLocalsKey, id)
+		c.SetUserContext(engine.WithRequestID(c.UserContext(), id))
+		return c.Next()
+	}
+}
+
+// validRequestID reports whether a request ID sent by the requester is short and printable ASCII,
+// so that it can be logged verbatim.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := range len(id) {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
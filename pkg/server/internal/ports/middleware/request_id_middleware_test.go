@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		requestID string
+		generated bool
+	}{
+		"Propagates the request ID of the request": {
+			requestID: "9d1b2c3e-req",
+		},
+		"Generates a request ID when the request has none": {
+			generated: true,
+		},
+		"Generates a request ID when the request ID is too long": {
+			requestID: strings.Repeat("a", 129),
+			generated: true,
+		},
+		"Generates a request ID when the request ID is not printable": {
+			requestID: "req\tid",
+			generated: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			app := fiber.New()
+			app.Use(middleware.RequestIDMiddleware())
+			app.Get("/", func(c *fiber.Ctx) error {
+				require.Equal(t, c.Locals(middleware.RequestIDLocalsKey), engine.RequestID(c.UserContext()))
+				return c.SendString(engine.RequestID(c.UserContext()))
+			})
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tc.requestID != "" {
+				req.Header.Set(fiber.HeaderXRequestID, tc.requestID)
+			}
+
+			// when:
+			res, err := app.Test(req)
+
+			// then:
+			require.NoError(t, err)
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			actual := res.Header.Get(fiber.HeaderXRequestID)
+			require.Equal(t, actual, string(body))
+			if tc.generated {
+				require.NotEqual(t, tc.requestID, actual)
+				require.Len(t, actual, 36)
+			} else {
+				require.Equal(t, tc.requestID, actual)
+			}
+		})
+	}
+}
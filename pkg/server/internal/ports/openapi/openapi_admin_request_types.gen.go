@@ -0,0 +1,115 @@
+// Package openapi provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package openapi
+
+// AddTrackerBody defines model for AddTrackerBody.
+type AddTrackerBody struct {
+	// Kind Kind of the tracker, either "ship" or "slap"
+	Kind string `json:"kind"`
+
+	// Url HTTP(S) URL of the tracker
+	Url string `json:"url"`
+}
+
+// CreateAdvertisementBody defines model for CreateAdvertisementBody.
+type CreateAdvertisementBody struct {
+	// Protocol Advertisement protocol, SHIP or SLAP
+	Protocol string `json:"protocol"`
+
+	// TopicOrService Topic manager or lookup service to advertise
+	TopicOrService string `json:"topicOrService"`
+}
+
+// PauseTopicSyncBody defines model for PauseTopicSyncBody.
+type PauseTopicSyncBody struct {
+	// Topic Topic whose GASP sync and advertisement to pause, e.g. "tm_helloworld"
+	Topic string `json:"topic"`
+}
+
+// PinOutputBody defines model for PinOutputBody.
+type PinOutputBody struct {
+	// Outpoint Outpoint of the output to pin, in the format of "txID.outputIndex"
+	Outpoint string `json:"outpoint"`
+
+	// Topic Topic the output was admitted into
+	Topic string `json:"topic"`
+}
+
+// RegisterLookupServiceBody defines model for RegisterLookupServiceBody.
+type RegisterLookupServiceBody struct {
+	// Name Name of the lookup service to register, e.g. "ls_helloworld"
+	Name string `json:"name"`
+}
+
+// RegisterTopicManagerBody defines model for RegisterTopicManagerBody.
+type RegisterTopicManagerBody struct {
+	// Name Name of the topic manager to register, e.g. "tm_helloworld"
+	Name string `json:"name"`
+
+	// Peers Peer endpoints used when syncType is "peers"
+	Peers *[]string `json:"peers,omitempty"`
+
+	// SyncType How the topic is synchronized with other overlay nodes: "peers", "SHIP" or "none". Defaults to "none"
+	SyncType *string `json:"syncType,omitempty"`
+}
+
+// RegisterWebhookBody defines model for RegisterWebhookBody.
+type RegisterWebhookBody struct {
+	// Secret Shared secret signing the notifications; a random one is generated when omitted
+	Secret *string `json:"secret,omitempty"`
+
+	// Topics Topics to be notified for; omit to be notified for every topic
+	Topics *[]string `json:"topics,omitempty"`
+
+	// Url Absolute http(s) URL the STEAK of submissions is POSTed to
+	Url string `json:"url"`
+}
+
+// ReplayDeadLetterBody defines model for ReplayDeadLetterBody.
+type ReplayDeadLetterBody struct {
+	// Id ID of the dead letter to replay, i.e. the ID of the failed transaction
+	Id string `json:"id"`
+}
+
+// ReplayTopicIntoLookupServiceBody defines model for ReplayTopicIntoLookupServiceBody.
+type ReplayTopicIntoLookupServiceBody struct {
+	// FromScore Score to replay from, e.g. the score returned by an interrupted replay; zero replays every output
+	FromScore *float64 `json:"fromScore,omitempty"`
+
+	// Service Name of the lookup service to backfill, e.g. "ls_helloworld"
+	Service string `json:"service"`
+
+	// Topic Topic whose stored outputs are replayed, e.g. "tm_helloworld"
+	Topic string `json:"topic"`
+}
+
+// SetTopicQuotaBody defines model for SetTopicQuotaBody.
+type SetTopicQuotaBody struct {
+	// Action Action taken on submissions exceeding the quota, either "reject" or "truncate". Empty means "reject".
+	Action *string `json:"action,omitempty"`
+
+	// MaxOutputs Outputs of the topic held by the storage, spent or not. Zero disables the limit.
+	MaxOutputs *uint64 `json:"maxOutputs,omitempty"`
+
+	// MaxOutputsPerTx Outputs admitted from a single transaction. Zero disables the limit.
+	MaxOutputsPerTx *int `json:"maxOutputsPerTx,omitempty"`
+
+	// MaxSatoshis Summed satoshis of the outputs admitted from a single transaction. Zero disables the limit.
+	MaxSatoshis *uint64 `json:"maxSatoshis,omitempty"`
+
+	// Topic Topic whose quota to override
+	Topic string `json:"topic"`
+}
+
+// SyncTopicBody defines model for SyncTopicBody.
+type SyncTopicBody struct {
+	// Peers Peers to sync with instead of the peers of the sync configuration of the topic
+	Peers *[]string `json:"peers,omitempty"`
+
+	// Since Score to sync from instead of the last interaction with each peer
+	Since *float64 `json:"since,omitempty"`
+
+	// Topic Topic to sync, e.g. "tm_helloworld"
+	Topic string `json:"topic"`
+}
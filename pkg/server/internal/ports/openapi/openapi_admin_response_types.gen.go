@@ -3,18 +3,533 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package openapi
 
+import (
+	"time"
+)
+
+// Advertisement defines model for Advertisement.
+type Advertisement struct {
+	Domain      string `json:"domain"`
+	IdentityKey string `json:"identityKey"`
+
+	// Outpoint Outpoint of the advertisement output, in the format of "txID.outputIndex"
+	Outpoint string `json:"outpoint"`
+
+	// Protocol Advertisement protocol, SHIP or SLAP
+	Protocol       string `json:"protocol"`
+	TopicOrService string `json:"topicOrService"`
+}
+
+// AdvertisementCost defines model for AdvertisementCost.
+type AdvertisementCost struct {
+	// BudgetSatoshis Maximum cost of a single sync run, zero when uncapped
+	BudgetSatoshis uint64 `json:"budgetSatoshis"`
+
+	// FeeSatoshis Estimated fees of the creation and revocation transactions
+	FeeSatoshis uint64 `json:"feeSatoshis"`
+
+	// LockedSatoshis Satoshis locked in the created advertisement outputs
+	LockedSatoshis uint64 `json:"lockedSatoshis"`
+
+	// OutputsCreated Advertisement outputs created
+	OutputsCreated int `json:"outputsCreated"`
+
+	// OutputsRevoked Advertisement outputs spent by revocations
+	OutputsRevoked int `json:"outputsRevoked"`
+
+	// TotalSatoshis Locked satoshis and fees, without deducting the satoshis reclaimed by revocations
+	TotalSatoshis uint64 `json:"totalSatoshis"`
+
+	// WithinBudget Whether a sync run would proceed
+	WithinBudget bool `json:"withinBudget"`
+}
+
+// AdvertisementPlan defines model for AdvertisementPlan.
+type AdvertisementPlan struct {
+	Cost   AdvertisementCost      `json:"cost"`
+	Create []PlannedAdvertisement `json:"create"`
+	Revoke []PlannedAdvertisement `json:"revoke"`
+}
+
+// Advertisements defines model for Advertisements.
+type Advertisements struct {
+	Advertisements []Advertisement `json:"advertisements"`
+}
+
 // AdvertisementsSync defines model for AdvertisementsSync.
 type AdvertisementsSync struct {
 	Message string `json:"message"`
 }
 
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Action HTTP method and path of the audited request, e.g. "POST /api/v1/admin/syncAdvertisements"
+	Action string `json:"action"`
+
+	// Caller Identity of the caller: "admin", "identity:<BRC-31 identity key>", "apikey:<key fingerprint>" or "anonymous"
+	Caller     string  `json:"caller"`
+	Error      *string `json:"error,omitempty"`
+	Id         string  `json:"id"`
+	RemoteAddr *string `json:"remoteAddr,omitempty"`
+	RequestId  *string `json:"requestId,omitempty"`
+
+	// Result "success" or "failure"
+	Result string `json:"result"`
+
+	// Status HTTP status of the response
+	Status *int      `json:"status,omitempty"`
+	Time   time.Time `json:"time"`
+	Topics *[]string `json:"topics,omitempty"`
+	Txid   *string   `json:"txid,omitempty"`
+}
+
+// AuditLog defines model for AuditLog.
+type AuditLog struct {
+	// Entries Audit entries, most recent first
+	Entries []AuditEntry `json:"entries"`
+}
+
+// BroadcastQueue defines model for BroadcastQueue.
+type BroadcastQueue struct {
+	Broadcasts []QueuedBroadcast     `json:"broadcasts"`
+	Metrics    BroadcastRetryMetrics `json:"metrics"`
+}
+
+// BroadcastRetryMetrics defines model for BroadcastRetryMetrics.
+type BroadcastRetryMetrics struct {
+	// Abandoned Transactions that reached the maximum number of attempts
+	Abandoned uint64 `json:"abandoned"`
+
+	// Queued Failed broadcasts added to the queue
+	Queued uint64 `json:"queued"`
+
+	// Retried Re-broadcasts attempted
+	Retried uint64 `json:"retried"`
+
+	// Succeeded Re-broadcasts accepted by the broadcaster
+	Succeeded uint64 `json:"succeeded"`
+}
+
+// ConfigReload defines model for ConfigReload.
+type ConfigReload struct {
+	// Applied Settings changed by the reloaded configuration and applied without a restart
+	Applied []string `json:"applied"`
+}
+
+// DeadLetter defines model for DeadLetter.
+type DeadLetter struct {
+	// Attempts Number of times the submission has failed
+	Attempts      int       `json:"attempts"`
+	FirstFailedAt time.Time `json:"firstFailedAt"`
+	Id            string    `json:"id"`
+	LastFailedAt  time.Time `json:"lastFailedAt"`
+
+	// Mode Submit mode the transaction was originally submitted with
+	Mode   string `json:"mode"`
+	Reason string `json:"reason"`
+
+	// Stage Stage the submission failed at: "broadcast" or "lookup-service"
+	Stage  string   `json:"stage"`
+	Topics []string `json:"topics"`
+	Txid   string   `json:"txid"`
+}
+
+// DeadLetters defines model for DeadLetters.
+type DeadLetters struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+}
+
+// GASPPeerSyncStatus defines model for GASPPeerSyncStatus.
+type GASPPeerSyncStatus struct {
+	// CheckpointNodes In-flight nodes held by the checkpoint
+	CheckpointNodes int `json:"checkpointNodes"`
+
+	// EndedAt End of the last sync; omitted while running or when no sync ran
+	EndedAt *time.Time `json:"endedAt,omitempty"`
+
+	// Errors Failed syncs with the peer since the node started
+	Errors int `json:"errors"`
+
+	// LastError Error of the last failed sync
+	LastError *string `json:"lastError,omitempty"`
+
+	// LastInteraction Score up to which the topic is synced with the peer
+	LastInteraction float64 `json:"lastInteraction"`
+
+	// NodesIngested Transactions of the graphs ingested by the running or last sync
+	NodesIngested int `json:"nodesIngested"`
+
+	// PagesCompleted Pages of the initial response processed by the running or last sync
+	PagesCompleted int    `json:"pagesCompleted"`
+	Peer           string `json:"peer"`
+
+	// Resumable Whether a checkpoint holds the progress of an interrupted sync, resumed on the next start
+	Resumable bool `json:"resumable"`
+
+	// Running Whether a sync with the peer is in progress
+	Running bool `json:"running"`
+
+	// StartedAt Start of the running or last sync; omitted when no sync ran since the node started
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	Topic     string     `json:"topic"`
+}
+
+// GASPSyncStatus defines model for GASPSyncStatus.
+type GASPSyncStatus struct {
+	// Peers Sync status of every topic peer, sorted by topic and peer
+	Peers []GASPPeerSyncStatus `json:"peers"`
+}
+
+// LookupReplay defines model for LookupReplay.
+type LookupReplay struct {
+	// Admitted OutputAdmittedByTopic events replayed into the lookup service
+	Admitted int `json:"admitted"`
+
+	// Score Score of the last output replayed
+	Score   float64 `json:"score"`
+	Service string  `json:"service"`
+
+	// Spent OutputSpent events replayed into the lookup service
+	Spent int    `json:"spent"`
+	Topic string `json:"topic"`
+}
+
+// LookupServiceRegistration defines model for LookupServiceRegistration.
+type LookupServiceRegistration struct {
+	Message string `json:"message"`
+}
+
+// OutputGraph defines model for OutputGraph.
+type OutputGraph struct {
+	// Depth Number of relations followed from the root
+	Depth uint32            `json:"depth"`
+	Edges []OutputGraphEdge `json:"edges"`
+	Nodes []OutputGraphNode `json:"nodes"`
+	Root  string            `json:"root"`
+	Topic string            `json:"topic"`
+
+	// Truncated Whether the graph was cut short by the node limit before reaching the depth
+	Truncated bool `json:"truncated"`
+}
+
+// OutputGraphEdge Relation in which the transaction of the "to" output consumed the "from" output
+type OutputGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// OutputGraphNode defines model for OutputGraphNode.
+type OutputGraphNode struct {
+	BlockHeight uint32 `json:"blockHeight"`
+
+	// Depth Number of relations between the root and the output
+	Depth uint32 `json:"depth"`
+
+	// Missing Whether the output is referenced by a relation but no longer stored in the topic
+	Missing bool `json:"missing"`
+
+	// Outpoint Outpoint of the output, in the format of "txID.outputIndex"
+	Outpoint string `json:"outpoint"`
+	Pinned   bool   `json:"pinned"`
+	Spent    bool   `json:"spent"`
+}
+
+// OutputPin defines model for OutputPin.
+type OutputPin struct {
+	Message string `json:"message"`
+}
+
+// PausedSyncTopics defines model for PausedSyncTopics.
+type PausedSyncTopics struct {
+	// Topics Topics whose GASP sync and advertisement are paused
+	Topics []string `json:"topics"`
+}
+
+// PeerSyncFailure defines model for PeerSyncFailure.
+type PeerSyncFailure struct {
+	Error string `json:"error"`
+	Peer  string `json:"peer"`
+}
+
+// PlannedAdvertisement defines model for PlannedAdvertisement.
+type PlannedAdvertisement struct {
+	// Domain Domain of a revoked advertisement
+	Domain *string `json:"domain,omitempty"`
+
+	// Protocol Advertisement protocol, SHIP or SLAP
+	Protocol       string `json:"protocol"`
+	TopicOrService string `json:"topicOrService"`
+}
+
+// PromoteStandby defines model for PromoteStandby.
+type PromoteStandby struct {
+	Message string `json:"message"`
+}
+
+// PruneOutputs defines model for PruneOutputs.
+type PruneOutputs struct {
+	Topics []PrunedTopic `json:"topics"`
+}
+
+// PrunedTopic defines model for PrunedTopic.
+type PrunedTopic struct {
+	// Pruned Outpoints of the outputs pruned from the topic
+	Pruned []string `json:"pruned"`
+	Topic  string   `json:"topic"`
+}
+
+// QueuedBroadcast defines model for QueuedBroadcast.
+type QueuedBroadcast struct {
+	// Abandoned Whether the maximum number of attempts was reached and the broadcast is no longer retried
+	Abandoned bool `json:"abandoned"`
+
+	// Attempts Broadcasts attempted so far, including the one made when the transaction was submitted
+	Attempts      int       `json:"attempts"`
+	FirstFailedAt time.Time `json:"firstFailedAt"`
+	LastError     string    `json:"lastError"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	Txid          string    `json:"txid"`
+}
+
+// ReorgAffectedOutput defines model for ReorgAffectedOutput.
+type ReorgAffectedOutput struct {
+	BlockHeight uint32 `json:"blockHeight"`
+
+	// Outpoint Outpoint in the format of "txID.outputIndex"
+	Outpoint string `json:"outpoint"`
+	Spent    bool   `json:"spent"`
+	Topic    string `json:"topic"`
+}
+
+// ReorgSimulation defines model for ReorgSimulation.
+type ReorgSimulation struct {
+	// AffectedTopics Impact of the reorg per topic
+	AffectedTopics map[string]ReorgTopicImpact `json:"affectedTopics"`
+
+	// CurrentHeight Chain tip height the simulation was run against
+	CurrentHeight uint32 `json:"currentHeight"`
+
+	// Depth Number of most recent blocks reorganized by the simulation
+	Depth uint32 `json:"depth"`
+
+	// ForkHeight Lowest block height that would be reorganized
+	ForkHeight uint32 `json:"forkHeight"`
+
+	// InvalidatedOutputs Outputs whose merkle proofs would be invalidated
+	InvalidatedOutputs []ReorgAffectedOutput `json:"invalidatedOutputs"`
+
+	// ResyncBytes Estimated BEEF bytes of the transactions that would need new proofs
+	ResyncBytes int `json:"resyncBytes"`
+
+	// ResyncTransactions Estimated number of distinct transactions that would need new proofs
+	ResyncTransactions int `json:"resyncTransactions"`
+
+	// UtxosOnly Whether only unspent outputs were considered because the storage cannot report spent outputs by block height
+	UtxosOnly bool `json:"utxosOnly"`
+}
+
+// ReorgTopicImpact defines model for ReorgTopicImpact.
+type ReorgTopicImpact struct {
+	// Outputs Number of the topic's outputs whose proofs would be invalidated
+	Outputs int `json:"outputs"`
+
+	// Transactions Number of the topic's distinct transactions whose proofs would be invalidated
+	Transactions int `json:"transactions"`
+}
+
 // StartGASPSync defines model for StartGASPSync.
 type StartGASPSync struct {
 	Message string `json:"message"`
 }
 
+// TopicManagerRegistration defines model for TopicManagerRegistration.
+type TopicManagerRegistration struct {
+	Message string `json:"message"`
+}
+
+// TopicQuota defines model for TopicQuota.
+type TopicQuota struct {
+	// Action Action taken on submissions exceeding the quota, either "reject" or "truncate". Empty means "reject".
+	Action string `json:"action"`
+
+	// MaxOutputs Outputs of the topic held by the storage, spent or not. Zero disables the limit.
+	MaxOutputs uint64 `json:"maxOutputs"`
+
+	// MaxOutputsPerTx Outputs admitted from a single transaction. Zero disables the limit.
+	MaxOutputsPerTx int `json:"maxOutputsPerTx"`
+
+	// MaxSatoshis Summed satoshis of the outputs admitted from a single transaction. Zero disables the limit.
+	MaxSatoshis uint64 `json:"maxSatoshis"`
+}
+
+// TopicQuotas defines model for TopicQuotas.
+type TopicQuotas struct {
+	Default TopicQuota `json:"default"`
+
+	// Overrides Quotas per topic set at runtime, taking precedence over the configured ones
+	Overrides map[string]TopicQuota `json:"overrides"`
+
+	// Topics Configured quotas per topic, replacing the default quota
+	Topics map[string]TopicQuota `json:"topics"`
+}
+
+// TopicStats defines model for TopicStats.
+type TopicStats struct {
+	// LastAdvertisementSync Time of the last successful advertisement sync; omitted when unknown
+	LastAdvertisementSync *time.Time        `json:"lastAdvertisementSync,omitempty"`
+	Topics                []TopicStatsEntry `json:"topics"`
+}
+
+// TopicStatsEntry defines model for TopicStatsEntry.
+type TopicStatsEntry struct {
+	// PeerInteractions Last GASP interaction score, keyed by peer
+	PeerInteractions map[string]float64 `json:"peerInteractions"`
+	SpentOutputs     uint64             `json:"spentOutputs"`
+	Topic            string             `json:"topic"`
+	TotalOutputs     uint64             `json:"totalOutputs"`
+
+	// UnminedOutputs Outputs whose transaction has no merkle proof, either not mined yet or invalidated
+	UnminedOutputs uint64 `json:"unminedOutputs"`
+	UnspentOutputs uint64 `json:"unspentOutputs"`
+}
+
+// TopicSyncJob defines model for TopicSyncJob.
+type TopicSyncJob struct {
+	// CompletedAt Time the job completed or failed
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// Error Reason of the failure, set once the job failed
+	Error *string `json:"error,omitempty"`
+
+	// FailedPeers Peers the topic could not be synced with, set once the job completed
+	FailedPeers []PeerSyncFailure `json:"failedPeers"`
+
+	// IngestedGraphs Graphs ingested from the peers, set once the job completed
+	IngestedGraphs int `json:"ingestedGraphs"`
+
+	// JobId ID of the job, used to poll its outcome
+	JobId string `json:"jobId"`
+
+	// Status State of the sync, one of running, completed or failed
+	Status string `json:"status"`
+
+	// SucceededPeers Peers the topic was synced with, set once the job completed
+	SucceededPeers []string `json:"succeededPeers"`
+	Topic          string   `json:"topic"`
+}
+
+// Trackers defines model for Trackers.
+type Trackers struct {
+	// Ship SHIP trackers, synced with as peers of the tm_ship topic
+	Ship []string `json:"ship"`
+
+	// Slap SLAP trackers, used to discover lookup hosts and synced with as peers of the tm_slap topic
+	Slap []string `json:"slap"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Topics Topics the subscription is notified for; empty for every topic
+	Topics []string `json:"topics"`
+	Url    string   `json:"url"`
+}
+
+// WebhookRegistration defines model for WebhookRegistration.
+type WebhookRegistration struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Secret Shared secret signing the notifications in the X-Overlay-Signature header; it is not returned again
+	Secret string   `json:"secret"`
+	Topics []string `json:"topics"`
+	Url    string   `json:"url"`
+}
+
+// WebhookUnregistration defines model for WebhookUnregistration.
+type WebhookUnregistration struct {
+	Message string `json:"message"`
+}
+
+// Webhooks defines model for Webhooks.
+type Webhooks struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// AdvertisementPlanResponse defines model for AdvertisementPlanResponse.
+type AdvertisementPlanResponse = AdvertisementPlan
+
+// AdvertisementsResponse defines model for AdvertisementsResponse.
+type AdvertisementsResponse = Advertisements
+
 // AdvertisementsSyncResponse defines model for AdvertisementsSyncResponse.
 type AdvertisementsSyncResponse = AdvertisementsSync
 
+// AuditLogResponse defines model for AuditLogResponse.
+type AuditLogResponse = AuditLog
+
+// BroadcastQueueResponse defines model for BroadcastQueueResponse.
+type BroadcastQueueResponse = BroadcastQueue
+
+// ConfigReloadResponse defines model for ConfigReloadResponse.
+type ConfigReloadResponse = ConfigReload
+
+// DeadLettersResponse defines model for DeadLettersResponse.
+type DeadLettersResponse = DeadLetters
+
+// GASPSyncStatusResponse defines model for GASPSyncStatusResponse.
+type GASPSyncStatusResponse = GASPSyncStatus
+
+// LookupReplayResponse defines model for LookupReplayResponse.
+type LookupReplayResponse = LookupReplay
+
+// LookupServiceRegistrationResponse defines model for LookupServiceRegistrationResponse.
+type LookupServiceRegistrationResponse = LookupServiceRegistration
+
+// OutputGraphResponse defines model for OutputGraphResponse.
+type OutputGraphResponse = OutputGraph
+
+// OutputPinResponse defines model for OutputPinResponse.
+type OutputPinResponse = OutputPin
+
+// PausedSyncTopicsResponse defines model for PausedSyncTopicsResponse.
+type PausedSyncTopicsResponse = PausedSyncTopics
+
+// PromoteStandbyResponse defines model for PromoteStandbyResponse.
+type PromoteStandbyResponse = PromoteStandby
+
+// PruneOutputsResponse defines model for PruneOutputsResponse.
+type PruneOutputsResponse = PruneOutputs
+
+// ReorgSimulationResponse defines model for ReorgSimulationResponse.
+type ReorgSimulationResponse = ReorgSimulation
+
 // StartGASPSyncResponse defines model for StartGASPSyncResponse.
 type StartGASPSyncResponse = StartGASPSync
+
+// TopicManagerRegistrationResponse defines model for TopicManagerRegistrationResponse.
+type TopicManagerRegistrationResponse = TopicManagerRegistration
+
+// TopicQuotasResponse defines model for TopicQuotasResponse.
+type TopicQuotasResponse = TopicQuotas
+
+// TopicStatsResponse defines model for TopicStatsResponse.
+type TopicStatsResponse = TopicStats
+
+// TopicSyncJobResponse defines model for TopicSyncJobResponse.
+type TopicSyncJobResponse = TopicSyncJob
+
+// TrackersResponse defines model for TrackersResponse.
+type TrackersResponse = Trackers
+
+// WebhookRegistrationResponse defines model for WebhookRegistrationResponse.
+type WebhookRegistrationResponse = WebhookRegistration
+
+// WebhookUnregistrationResponse defines model for WebhookUnregistrationResponse.
+type WebhookUnregistrationResponse = WebhookUnregistration
+
+// WebhooksResponse defines model for WebhooksResponse.
+type WebhooksResponse = Webhooks
@@ -4,8 +4,10 @@
 package openapi
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime"
@@ -15,32 +17,320 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
-// Error defines model for Error.
+// Defines values for OutputGraphParamsFormat.
+const (
+	Dot  OutputGraphParamsFormat = "dot"
+	Json OutputGraphParamsFormat = "json"
+)
+
+// Defines values for SubmitTransactionParamsMode.
+const (
+	Async  SubmitTransactionParamsMode = "async"
+	DryRun SubmitTransactionParamsMode = "dry-run"
+	Sync   SubmitTransactionParamsMode = "sync"
+)
+
+// Error Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
 type Error struct {
-	// Message Human-readable error message
-	Message string `json:"message"`
+	// Code Machine-readable error code, e.g. ERR_UNKNOWN_TOPIC or ERR_INVALID_BEEF
+	Code string `json:"code"`
+
+	// Detail Human-readable explanation of this occurrence of the problem
+	Detail string `json:"detail"`
+
+	// Details Additional machine-readable information about the problem, e.g. the exceeded limit
+	Details *map[string]interface{} `json:"details,omitempty"`
+
+	// Retryable Whether the request may succeed when retried unchanged
+	Retryable bool `json:"retryable"`
+
+	// Status HTTP status code of the response
+	Status int `json:"status"`
+
+	// Title Short summary of the problem, the reason phrase of the status code
+	Title string `json:"title"`
 }
 
-// BadRequestResponse defines model for BadRequestResponse.
+// BadRequestResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
 type BadRequestResponse = Error
 
-// InternalServerErrorResponse defines model for InternalServerErrorResponse.
+// ForbiddenResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
+type ForbiddenResponse = Error
+
+// GatewayTimeoutResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
+type GatewayTimeoutResponse = Error
+
+// InternalServerErrorResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
 type InternalServerErrorResponse = Error
 
-// NotFoundResponse defines model for NotFoundResponse.
+// NotFoundResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
 type NotFoundResponse = Error
 
-// RequestTimeoutResponse defines model for RequestTimeoutResponse.
+// PayloadTooLargeResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
+type PayloadTooLargeResponse = Error
+
+// RequestTimeoutResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
 type RequestTimeoutResponse = Error
 
+// ServiceUnavailableResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
+type ServiceUnavailableResponse = Error
+
+// TooManyRequestsResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
+type TooManyRequestsResponse = Error
+
+// UnprocessableContentResponse Problem details of a failed request, following RFC 7807. The type member is omitted,
+// so the problem is described by its machine-readable code.
+type UnprocessableContentResponse = Error
+
+// RevokeAdvertisementParams defines parameters for RevokeAdvertisement.
+type RevokeAdvertisementParams struct {
+	// Outpoint Outpoint of the advertisement to revoke, in the format of "txID.outputIndex"
+	Outpoint string `form:"outpoint" json:"outpoint"`
+}
+
+// CreateAdvertisementJSONBody defines parameters for CreateAdvertisement.
+type CreateAdvertisementJSONBody struct {
+	// Protocol Advertisement protocol, SHIP or SLAP
+	Protocol string `json:"protocol"`
+
+	// TopicOrService Topic manager or lookup service to advertise
+	TopicOrService string `json:"topicOrService"`
+}
+
+// ListAuditEntriesParams defines parameters for ListAuditEntries.
+type ListAuditEntriesParams struct {
+	// Action Only returns entries of the action, e.g. "POST /api/v1/submit"
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// Caller Only returns entries of the caller, e.g. "admin" or "identity:<identity key>"
+	Caller *string `form:"caller,omitempty" json:"caller,omitempty"`
+
+	// Txid Only returns entries of submissions of the transaction
+	Txid *string `form:"txid,omitempty" json:"txid,omitempty"`
+
+	// Since Only returns entries recorded at or after the time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only returns entries recorded before the time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Limit Maximum number of entries to return, 100 by default and at most 1000
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ReplayDeadLetterJSONBody defines parameters for ReplayDeadLetter.
+type ReplayDeadLetterJSONBody struct {
+	// Id ID of the dead letter to replay, i.e. the ID of the failed transaction
+	Id string `json:"id"`
+}
+
+// OutputGraphParams defines parameters for OutputGraph.
+type OutputGraphParams struct {
+	// Topic Topic the root output was admitted into
+	Topic string `form:"topic" json:"topic"`
+
+	// Depth Number of relations followed from the root, 10 by default and at most 100
+	Depth *uint32 `form:"depth,omitempty" json:"depth,omitempty"`
+
+	// Format Format of the graph, JSON by default or the Graphviz DOT language
+	Format *OutputGraphParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// OutputGraphParamsFormat defines parameters for OutputGraph.
+type OutputGraphParamsFormat string
+
+// UnregisterLookupServiceParams defines parameters for UnregisterLookupService.
+type UnregisterLookupServiceParams struct {
+	// LookupService The name of the lookup service to unregister
+	LookupService string `form:"lookupService" json:"lookupService"`
+}
+
+// RegisterLookupServiceJSONBody defines parameters for RegisterLookupService.
+type RegisterLookupServiceJSONBody struct {
+	// Name Name of the lookup service to register, e.g. "ls_helloworld"
+	Name string `json:"name"`
+}
+
+// ReplayTopicIntoLookupServiceJSONBody defines parameters for ReplayTopicIntoLookupService.
+type ReplayTopicIntoLookupServiceJSONBody struct {
+	// FromScore Score to replay from, e.g. the score returned by an interrupted replay; zero replays every output
+	FromScore *float64 `json:"fromScore,omitempty"`
+
+	// Service Name of the lookup service to backfill, e.g. "ls_helloworld"
+	Service string `json:"service"`
+
+	// Topic Topic whose stored outputs are replayed, e.g. "tm_helloworld"
+	Topic string `json:"topic"`
+}
+
+// ResumeTopicSyncParams defines parameters for ResumeTopicSync.
+type ResumeTopicSyncParams struct {
+	// Topic Topic whose GASP sync and advertisement to resume
+	Topic string `form:"topic" json:"topic"`
+}
+
+// PauseTopicSyncJSONBody defines parameters for PauseTopicSync.
+type PauseTopicSyncJSONBody struct {
+	// Topic Topic whose GASP sync and advertisement to pause, e.g. "tm_helloworld"
+	Topic string `json:"topic"`
+}
+
+// UnpinOutputParams defines parameters for UnpinOutput.
+type UnpinOutputParams struct {
+	// Topic Topic the output was admitted into
+	Topic string `form:"topic" json:"topic"`
+
+	// Outpoint Outpoint of the output to unpin, in the format of "txID.outputIndex"
+	Outpoint string `form:"outpoint" json:"outpoint"`
+}
+
+// PinOutputJSONBody defines parameters for PinOutput.
+type PinOutputJSONBody struct {
+	// Outpoint Outpoint of the output to pin, in the format of "txID.outputIndex"
+	Outpoint string `json:"outpoint"`
+
+	// Topic Topic the output was admitted into
+	Topic string `json:"topic"`
+}
+
+// SimulateReorgParams defines parameters for SimulateReorg.
+type SimulateReorgParams struct {
+	// Depth Number of most recent blocks to reorganize
+	Depth uint32 `form:"depth" json:"depth"`
+}
+
+// SyncTopicJSONBody defines parameters for SyncTopic.
+type SyncTopicJSONBody struct {
+	// Peers Peers to sync with instead of the peers of the sync configuration of the topic
+	Peers *[]string `json:"peers,omitempty"`
+
+	// Since Score to sync from instead of the last interaction with each peer
+	Since *float64 `json:"since,omitempty"`
+
+	// Topic Topic to sync, e.g. "tm_helloworld"
+	Topic string `json:"topic"`
+}
+
+// UnregisterTopicManagerParams defines parameters for UnregisterTopicManager.
+type UnregisterTopicManagerParams struct {
+	// TopicManager The name of the topic manager to unregister
+	TopicManager string `form:"topicManager" json:"topicManager"`
+}
+
+// RegisterTopicManagerJSONBody defines parameters for RegisterTopicManager.
+type RegisterTopicManagerJSONBody struct {
+	// Name Name of the topic manager to register, e.g. "tm_helloworld"
+	Name string `json:"name"`
+
+	// Peers Peer endpoints used when syncType is "peers"
+	Peers *[]string `json:"peers,omitempty"`
+
+	// SyncType How the topic is synchronized with other overlay nodes: "peers", "SHIP" or "none". Defaults to "none"
+	SyncType *string `json:"syncType,omitempty"`
+}
+
+// RemoveTopicQuotaParams defines parameters for RemoveTopicQuota.
+type RemoveTopicQuotaParams struct {
+	// Topic Topic whose quota override to remove
+	Topic string `form:"topic" json:"topic"`
+}
+
+// SetTopicQuotaJSONBody defines parameters for SetTopicQuota.
+type SetTopicQuotaJSONBody struct {
+	// Action Action taken on submissions exceeding the quota, either "reject" or "truncate". Empty means "reject".
+	Action *string `json:"action,omitempty"`
+
+	// MaxOutputs Outputs of the topic held by the storage, spent or not. Zero disables the limit.
+	MaxOutputs *uint64 `json:"maxOutputs,omitempty"`
+
+	// MaxOutputsPerTx Outputs admitted from a single transaction. Zero disables the limit.
+	MaxOutputsPerTx *int `json:"maxOutputsPerTx,omitempty"`
+
+	// MaxSatoshis Summed satoshis of the outputs admitted from a single transaction. Zero disables the limit.
+	MaxSatoshis *uint64 `json:"maxSatoshis,omitempty"`
+
+	// Topic Topic whose quota to override
+	Topic string `json:"topic"`
+}
+
+// RemoveTrackerParams defines parameters for RemoveTracker.
+type RemoveTrackerParams struct {
+	// Kind Kind of the tracker to remove, either "ship" or "slap"
+	Kind string `form:"kind" json:"kind"`
+
+	// Url URL of the tracker to remove
+	Url string `form:"url" json:"url"`
+}
+
+// AddTrackerJSONBody defines parameters for AddTracker.
+type AddTrackerJSONBody struct {
+	// Kind Kind of the tracker, either "ship" or "slap"
+	Kind string `json:"kind"`
+
+	// Url HTTP(S) URL of the tracker
+	Url string `json:"url"`
+}
+
+// UnregisterWebhookParams defines parameters for UnregisterWebhook.
+type UnregisterWebhookParams struct {
+	// Id The ID of the webhook subscription to unregister
+	Id string `form:"id" json:"id"`
+}
+
+// RegisterWebhookJSONBody defines parameters for RegisterWebhook.
+type RegisterWebhookJSONBody struct {
+	// Secret Shared secret signing the notifications; a random one is generated when omitted
+	Secret *string `json:"secret,omitempty"`
+
+	// Topics Topics to be notified for; omit to be notified for every topic
+	Topics *[]string `json:"topics,omitempty"`
+
+	// Url Absolute http(s) URL the STEAK of submissions is POSTed to
+	Url string `json:"url"`
+}
+
 // ArcIngestJSONBody defines parameters for ArcIngest.
 type ArcIngestJSONBody struct {
+	// BlockHash Hash of the block where the transaction was included. When present, it must be the block at blockHeight according to the chain tracker
+	BlockHash *string `json:"blockHash,omitempty"`
+
+	// BlockHeight Block height where the transaction was included
+	BlockHeight uint32 `json:"blockHeight"`
+
+	// MerklePath Merkle path in hexadecimal format
+	MerklePath string `json:"merklePath"`
+
+	// ProofSource Identifier of the service that produced the Merkle proof, e.g. the ARC instance, recorded for auditability
+	ProofSource *string `json:"proofSource,omitempty"`
+
+	// Txid Transaction ID in hexadecimal format
+	Txid string `json:"txid"`
+}
+
+// ArcIngestBatchJSONBody defines parameters for ArcIngestBatch.
+type ArcIngestBatchJSONBody = []struct {
+	// BlockHash Hash of the block where the transaction was included. When present, it must be the block at blockHeight according to the chain tracker
+	BlockHash *string `json:"blockHash,omitempty"`
+
 	// BlockHeight Block height where the transaction was included
 	BlockHeight uint32 `json:"blockHeight"`
 
 	// MerklePath Merkle path in hexadecimal format
 	MerklePath string `json:"merklePath"`
 
+	// ProofSource Identifier of the service that produced the Merkle proof, e.g. the ARC instance, recorded for auditability
+	ProofSource *string `json:"proofSource,omitempty"`
+
 	// Txid Transaction ID in hexadecimal format
 	Txid string `json:"txid"`
 }
@@ -57,6 +347,21 @@ type GetTopicManagerDocumentationParams struct {
 	TopicManager string `form:"topicManager" json:"topicManager"`
 }
 
+// UTXOHistoryJSONBody defines parameters for UTXOHistory.
+type UTXOHistoryJSONBody struct {
+	// Depth Ancestor levels to hydrate into the BEEF; omitted hydrates up to the limit configured by the operator
+	Depth *uint32 `json:"depth,omitempty"`
+
+	// OutputIndex Index of the output in the transaction
+	OutputIndex uint32 `json:"outputIndex"`
+
+	// Topic Topic the output was admitted to
+	Topic string `json:"topic"`
+
+	// Txid ID of the transaction holding the output
+	Txid string `json:"txid"`
+}
+
 // LookupQuestionJSONBody defines parameters for LookupQuestion.
 type LookupQuestionJSONBody struct {
 	// Query Query parameters specific to the service
@@ -66,11 +371,58 @@ type LookupQuestionJSONBody struct {
 	Service string `json:"service"`
 }
 
+// LookupQuestionParams defines parameters for LookupQuestion.
+type LookupQuestionParams struct {
+	// Limit Maximum number of outputs of the answer; the rest are paged with nextCursor
+	Limit *uint32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Cursor of the page to return, as returned in the nextCursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Hydrate What the outputs of the answer are hydrated with: none (outpoints only, without reading storage),
+	// txid (outpoints of the outputs still stored), rawtx (the raw transaction, without ancestors)
+	// or full (the BEEF with the history selected by the lookup service, the default)
+	Hydrate *string `form:"hydrate,omitempty" json:"hydrate,omitempty"`
+
+	// Depth With full hydration, the maximum number of ancestor levels included in the BEEF of each output
+	Depth *uint32 `form:"depth,omitempty" json:"depth,omitempty"`
+}
+
+// OutputsExistJSONBody defines parameters for OutputsExist.
+type OutputsExistJSONBody struct {
+	// Outpoints Outpoints to check, in the format "txid.vout"
+	Outpoints []string `json:"outpoints"`
+
+	// Topic Topic to check the outputs in
+	Topic string `json:"topic"`
+
+	// UnspentOnly Report spent outputs as missing
+	UnspentOnly *bool `json:"unspentOnly,omitempty"`
+}
+
+// OutputStatusParams defines parameters for OutputStatus.
+type OutputStatusParams struct {
+	// Topic Topic to report the status of the output in
+	Topic string `form:"topic" json:"topic"`
+}
+
+// ReplicationStreamParams defines parameters for ReplicationStream.
+type ReplicationStreamParams struct {
+	// Since Sequence number of the last mutation applied by the standby, zero for a standby that applied none
+	Since uint64 `form:"since" json:"since"`
+
+	// Epoch Epoch of the last mutation applied by the standby, omitted for a standby that applied none
+	Epoch *string `form:"epoch,omitempty" json:"epoch,omitempty"`
+}
+
 // RequestForeignGASPNodeJSONBody defines parameters for RequestForeignGASPNode.
 type RequestForeignGASPNodeJSONBody struct {
 	// GraphID The graph ID in the format of "txID.outputIndex"
 	GraphID string `json:"graphID"`
 
+	// Metadata Whether to include the node's provenance (receive time, source and score)
+	Metadata *bool `json:"metadata,omitempty"`
+
 	// OutputIndex The output index
 	OutputIndex uint32 `json:"outputIndex"`
 
@@ -85,6 +437,9 @@ type RequestForeignGASPNodeParams struct {
 
 // RequestSyncResponseJSONBody defines parameters for RequestSyncResponse.
 type RequestSyncResponseJSONBody struct {
+	// Limit Maximum number of UTXOs to return, zero or absent meaning no limit
+	Limit *uint32 `json:"limit,omitempty"`
+
 	// Since Timestamp or sequence number from which to start synchronization
 	Since float64 `json:"since"`
 
@@ -100,15 +455,80 @@ type RequestSyncResponseParams struct {
 
 // SubmitTransactionParams defines parameters for SubmitTransaction.
 type SubmitTransactionParams struct {
-	XTopics []string `json:"x-topics"`
+	// Mode Submission mode. The default sync mode responds with the STEAK once the transaction is processed.
+	// The async mode enqueues the transaction and responds immediately with a job to poll.
+	// The dry-run mode verifies the transaction and responds with the STEAK its submission would result in,
+	// without storing, broadcasting or announcing anything.
+	Mode *SubmitTransactionParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// CallbackUrl URL receiving the finished job as a JSON POST request, for the async mode only. It must be allowed by
+	// the callback allowlist of the overlay node, or the submission is rejected with 403 Forbidden.
+	CallbackUrl *string  `form:"callbackUrl,omitempty" json:"callbackUrl,omitempty"`
+	XTopics     []string `json:"x-topics"`
+
+	// IdempotencyKey Key identifying the submission across client retries. A retried submission returns the STEAK of the
+	// original one instead of being processed again. Reusing the key for another transaction or other topics is
+	// rejected with 422 and ERR_IDEMPOTENCY_KEY_CONFLICT. Without a key, the txid and topics identify the submission.
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+
+	// XSTEAKVersion Version of the STEAK serialization the client understands: 1 (the default) returns the SubmitTransaction
+	// shape, 2 the deterministic VersionedSTEAK shape. Requested with an Accept header of
+	// application/vnd.bsv.steak, the STEAK is returned in the binary serialization of version 2.
+	// The response carries the version of its body in the same header.
+	XSTEAKVersion *string `json:"X-STEAK-Version,omitempty"`
 }
 
+// SubmitTransactionParamsMode defines parameters for SubmitTransaction.
+type SubmitTransactionParamsMode string
+
+// CreateAdvertisementJSONRequestBody defines body for CreateAdvertisement for application/json ContentType.
+type CreateAdvertisementJSONRequestBody CreateAdvertisementJSONBody
+
+// ReplayDeadLetterJSONRequestBody defines body for ReplayDeadLetter for application/json ContentType.
+type ReplayDeadLetterJSONRequestBody ReplayDeadLetterJSONBody
+
+// RegisterLookupServiceJSONRequestBody defines body for RegisterLookupService for application/json ContentType.
+type RegisterLookupServiceJSONRequestBody RegisterLookupServiceJSONBody
+
+// ReplayTopicIntoLookupServiceJSONRequestBody defines body for ReplayTopicIntoLookupService for application/json ContentType.
+type ReplayTopicIntoLookupServiceJSONRequestBody ReplayTopicIntoLookupServiceJSONBody
+
+// PauseTopicSyncJSONRequestBody defines body for PauseTopicSync for application/json ContentType.
+type PauseTopicSyncJSONRequestBody PauseTopicSyncJSONBody
+
+// PinOutputJSONRequestBody defines body for PinOutput for application/json ContentType.
+type PinOutputJSONRequestBody PinOutputJSONBody
+
+// SyncTopicJSONRequestBody defines body for SyncTopic for application/json ContentType.
+type SyncTopicJSONRequestBody SyncTopicJSONBody
+
+// RegisterTopicManagerJSONRequestBody defines body for RegisterTopicManager for application/json ContentType.
+type RegisterTopicManagerJSONRequestBody RegisterTopicManagerJSONBody
+
+// SetTopicQuotaJSONRequestBody defines body for SetTopicQuota for application/json ContentType.
+type SetTopicQuotaJSONRequestBody SetTopicQuotaJSONBody
+
+// AddTrackerJSONRequestBody defines body for AddTracker for application/json ContentType.
+type AddTrackerJSONRequestBody AddTrackerJSONBody
+
+// RegisterWebhookJSONRequestBody defines body for RegisterWebhook for application/json ContentType.
+type RegisterWebhookJSONRequestBody RegisterWebhookJSONBody
+
 // ArcIngestJSONRequestBody defines body for ArcIngest for application/json ContentType.
 type ArcIngestJSONRequestBody ArcIngestJSONBody
 
+// ArcIngestBatchJSONRequestBody defines body for ArcIngestBatch for application/json ContentType.
+type ArcIngestBatchJSONRequestBody = ArcIngestBatchJSONBody
+
+// UTXOHistoryJSONRequestBody defines body for UTXOHistory for application/json ContentType.
+type UTXOHistoryJSONRequestBody UTXOHistoryJSONBody
+
 // LookupQuestionJSONRequestBody defines body for LookupQuestion for application/json ContentType.
 type LookupQuestionJSONRequestBody LookupQuestionJSONBody
 
+// OutputsExistJSONRequestBody defines body for OutputsExist for application/json ContentType.
+type OutputsExistJSONRequestBody OutputsExistJSONBody
+
 // RequestForeignGASPNodeJSONRequestBody defines body for RequestForeignGASPNode for application/json ContentType.
 type RequestForeignGASPNodeJSONRequestBody RequestForeignGASPNodeJSONBody
 
@@ -117,49 +537,972 @@ type RequestSyncResponseJSONRequestBody RequestSyncResponseJSONBody
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// (GET /api/v1/admin/advertisementPlan)
+	AdvertisementPlan(c *fiber.Ctx) error
+
+	// (DELETE /api/v1/admin/advertisements)
+	RevokeAdvertisement(c *fiber.Ctx, params RevokeAdvertisementParams) error
+
+	// (GET /api/v1/admin/advertisements)
+	ListAdvertisements(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/advertisements)
+	CreateAdvertisement(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/auditLog)
+	ListAuditEntries(c *fiber.Ctx, params ListAuditEntriesParams) error
+
+	// (GET /api/v1/admin/broadcastQueue)
+	BroadcastQueue(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/config/reload)
+	ReloadConfig(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/deadLetters)
+	ListDeadLetters(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/deadLetters/replay)
+	ReplayDeadLetter(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/graph/{txid}/{vout})
+	OutputGraph(c *fiber.Ctx, txid string, vout uint32, params OutputGraphParams) error
+
+	// (DELETE /api/v1/admin/lookupServices)
+	UnregisterLookupService(c *fiber.Ctx, params UnregisterLookupServiceParams) error
+
+	// (GET /api/v1/admin/lookupServices)
+	ListRegisteredLookupServices(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/lookupServices)
+	RegisterLookupService(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/lookupServices/replay)
+	ReplayTopicIntoLookupService(c *fiber.Ctx) error
+
+	// (DELETE /api/v1/admin/pausedSyncTopics)
+	ResumeTopicSync(c *fiber.Ctx, params ResumeTopicSyncParams) error
+
+	// (GET /api/v1/admin/pausedSyncTopics)
+	ListPausedSyncTopics(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/pausedSyncTopics)
+	PauseTopicSync(c *fiber.Ctx) error
+
+	// (DELETE /api/v1/admin/pinnedOutputs)
+	UnpinOutput(c *fiber.Ctx, params UnpinOutputParams) error
+
+	// (POST /api/v1/admin/pinnedOutputs)
+	PinOutput(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/promoteStandby)
+	PromoteStandby(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/pruneOutputs)
+	PruneOutputs(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/reorgSimulation)
+	SimulateReorg(c *fiber.Ctx, params SimulateReorgParams) error
+
 	// (POST /api/v1/admin/startGASPSync)
 	StartGASPSync(c *fiber.Ctx) error
 
+	// (GET /api/v1/admin/stats)
+	TopicStats(c *fiber.Ctx) error
+
 	// (POST /api/v1/admin/syncAdvertisements)
 	AdvertisementsSync(c *fiber.Ctx) error
 
+	// (GET /api/v1/admin/syncStatus)
+	GASPSyncStatus(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/syncTopic)
+	SyncTopic(c *fiber.Ctx) error
+
+	// (GET /api/v1/admin/syncTopic/{jobID})
+	GetTopicSyncJob(c *fiber.Ctx, jobID string) error
+
+	// (DELETE /api/v1/admin/topicManagers)
+	UnregisterTopicManager(c *fiber.Ctx, params UnregisterTopicManagerParams) error
+
+	// (POST /api/v1/admin/topicManagers)
+	RegisterTopicManager(c *fiber.Ctx) error
+
+	// (DELETE /api/v1/admin/topicQuotas)
+	RemoveTopicQuota(c *fiber.Ctx, params RemoveTopicQuotaParams) error
+
+	// (GET /api/v1/admin/topicQuotas)
+	ListTopicQuotas(c *fiber.Ctx) error
+
+	// (PUT /api/v1/admin/topicQuotas)
+	SetTopicQuota(c *fiber.Ctx) error
+
+	// (DELETE /api/v1/admin/trackers)
+	RemoveTracker(c *fiber.Ctx, params RemoveTrackerParams) error
+
+	// (GET /api/v1/admin/trackers)
+	ListTrackers(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/trackers)
+	AddTracker(c *fiber.Ctx) error
+
+	// (DELETE /api/v1/admin/webhooks)
+	UnregisterWebhook(c *fiber.Ctx, params UnregisterWebhookParams) error
+
+	// (GET /api/v1/admin/webhooks)
+	ListWebhooks(c *fiber.Ctx) error
+
+	// (POST /api/v1/admin/webhooks)
+	RegisterWebhook(c *fiber.Ctx) error
+
 	// (POST /api/v1/arc-ingest)
 	ArcIngest(c *fiber.Ctx) error
 
+	// (POST /api/v1/arc-ingest/batch)
+	ArcIngestBatch(c *fiber.Ctx) error
+
 	// (GET /api/v1/getDocumentationForLookupServiceProvider)
 	GetLookupServiceProviderDocumentation(c *fiber.Ctx, params GetLookupServiceProviderDocumentationParams) error
 
 	// (GET /api/v1/getDocumentationForTopicManager)
 	GetTopicManagerDocumentation(c *fiber.Ctx, params GetTopicManagerDocumentationParams) error
 
+	// (POST /api/v1/history)
+	UTXOHistory(c *fiber.Ctx) error
+
 	// (GET /api/v1/listLookupServiceProviders)
 	ListLookupServiceProviders(c *fiber.Ctx) error
 
-	// (GET /api/v1/listTopicManagers)
-	ListTopicManagers(c *fiber.Ctx) error
+	// (GET /api/v1/listTopicManagers)
+	ListTopicManagers(c *fiber.Ctx) error
+
+	// (POST /api/v1/lookup)
+	LookupQuestion(c *fiber.Ctx, params LookupQuestionParams) error
+
+	// (GET /api/v1/lookup/{service}/schema)
+	GetLookupQuerySchema(c *fiber.Ctx, service string) error
+
+	// (POST /api/v1/outputs/exists)
+	OutputsExist(c *fiber.Ctx) error
+
+	// (GET /api/v1/outputs/{txid}/{vout})
+	OutputStatus(c *fiber.Ctx, txid string, vout uint32, params OutputStatusParams) error
+
+	// (GET /api/v1/replication/stream)
+	ReplicationStream(c *fiber.Ctx, params ReplicationStreamParams) error
+
+	// (POST /api/v1/requestForeignGASPNode)
+	RequestForeignGASPNode(c *fiber.Ctx, params RequestForeignGASPNodeParams) error
+
+	// (POST /api/v1/requestSyncResponse)
+	RequestSyncResponse(c *fiber.Ctx, params RequestSyncResponseParams) error
+
+	// (POST /api/v1/submit)
+	SubmitTransaction(c *fiber.Ctx, params SubmitTransactionParams) error
+
+	// (GET /api/v1/submit/{jobID})
+	GetSubmitJob(c *fiber.Ctx, jobID string) error
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	handler           ServerInterface
+	globalMiddleware  []fiber.Handler
+	handlerMiddleware []fiber.Handler
+}
+
+// AdvertisementPlan operation middleware
+func (siw *ServerInterfaceWrapper) AdvertisementPlan(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.AdvertisementPlan(c)
+}
+
+// RevokeAdvertisement operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAdvertisement(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RevokeAdvertisementParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "outpoint" -------------
+
+	if paramValue := c.Query("outpoint"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid outpoint must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "outpoint", query, &params.Outpoint)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter outpoint")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RevokeAdvertisement(c, params)
+}
+
+// ListAdvertisements operation middleware
+func (siw *ServerInterfaceWrapper) ListAdvertisements(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListAdvertisements(c)
+}
+
+// CreateAdvertisement operation middleware
+func (siw *ServerInterfaceWrapper) CreateAdvertisement(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.CreateAdvertisement(c)
+}
+
+// ListAuditEntries operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEntries(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEntriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", query, &params.Action)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter action")
+	}
+
+	// ------------- Optional query parameter "caller" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "caller", query, &params.Caller)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter caller")
+	}
+
+	// ------------- Optional query parameter "txid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "txid", query, &params.Txid)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter txid")
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", query, &params.Since)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter since")
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", query, &params.Until)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter until")
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter limit")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListAuditEntries(c, params)
+}
+
+// BroadcastQueue operation middleware
+func (siw *ServerInterfaceWrapper) BroadcastQueue(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.BroadcastQueue(c)
+}
+
+// ReloadConfig operation middleware
+func (siw *ServerInterfaceWrapper) ReloadConfig(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ReloadConfig(c)
+}
+
+// ListDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadLetters(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListDeadLetters(c)
+}
+
+// ReplayDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) ReplayDeadLetter(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ReplayDeadLetter(c)
+}
+
+// OutputGraph operation middleware
+func (siw *ServerInterfaceWrapper) OutputGraph(c *fiber.Ctx) error {
+	var err error
+
+	// ------------- Path parameter "txid" -------------
+	var txid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "txid", c.Params("txid"), &txid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter txid: %w", err).Error())
+	}
+
+	// ------------- Path parameter "vout" -------------
+	var vout uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "vout", c.Params("vout"), &vout, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter vout: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params OutputGraphParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "topic" -------------
+
+	if paramValue := c.Query("topic"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid topic must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	// ------------- Optional query parameter "depth" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "depth", query, &params.Depth)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter depth")
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter format")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.OutputGraph(c, txid, vout, params)
+}
+
+// UnregisterLookupService operation middleware
+func (siw *ServerInterfaceWrapper) UnregisterLookupService(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnregisterLookupServiceParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "lookupService" -------------
+
+	if paramValue := c.Query("lookupService"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid lookupService must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lookupService", query, &params.LookupService)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter lookupService")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.UnregisterLookupService(c, params)
+}
+
+// ListRegisteredLookupServices operation middleware
+func (siw *ServerInterfaceWrapper) ListRegisteredLookupServices(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListRegisteredLookupServices(c)
+}
+
+// RegisterLookupService operation middleware
+func (siw *ServerInterfaceWrapper) RegisterLookupService(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RegisterLookupService(c)
+}
+
+// ReplayTopicIntoLookupService operation middleware
+func (siw *ServerInterfaceWrapper) ReplayTopicIntoLookupService(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ReplayTopicIntoLookupService(c)
+}
+
+// ResumeTopicSync operation middleware
+func (siw *ServerInterfaceWrapper) ResumeTopicSync(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResumeTopicSyncParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "topic" -------------
+
+	if paramValue := c.Query("topic"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid topic must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ResumeTopicSync(c, params)
+}
+
+// ListPausedSyncTopics operation middleware
+func (siw *ServerInterfaceWrapper) ListPausedSyncTopics(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListPausedSyncTopics(c)
+}
+
+// PauseTopicSync operation middleware
+func (siw *ServerInterfaceWrapper) PauseTopicSync(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.PauseTopicSync(c)
+}
+
+// UnpinOutput operation middleware
+func (siw *ServerInterfaceWrapper) UnpinOutput(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnpinOutputParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "topic" -------------
+
+	if paramValue := c.Query("topic"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid topic must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	// ------------- Required query parameter "outpoint" -------------
+
+	if paramValue := c.Query("outpoint"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid outpoint must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "outpoint", query, &params.Outpoint)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter outpoint")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.UnpinOutput(c, params)
+}
+
+// PinOutput operation middleware
+func (siw *ServerInterfaceWrapper) PinOutput(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.PinOutput(c)
+}
+
+// PromoteStandby operation middleware
+func (siw *ServerInterfaceWrapper) PromoteStandby(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.PromoteStandby(c)
+}
+
+// PruneOutputs operation middleware
+func (siw *ServerInterfaceWrapper) PruneOutputs(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.PruneOutputs(c)
+}
+
+// SimulateReorg operation middleware
+func (siw *ServerInterfaceWrapper) SimulateReorg(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SimulateReorgParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "depth" -------------
+
+	if paramValue := c.Query("depth"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid depth must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "depth", query, &params.Depth)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter depth")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.SimulateReorg(c, params)
+}
+
+// StartGASPSync operation middleware
+func (siw *ServerInterfaceWrapper) StartGASPSync(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.StartGASPSync(c)
+}
+
+// TopicStats operation middleware
+func (siw *ServerInterfaceWrapper) TopicStats(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.TopicStats(c)
+}
+
+// AdvertisementsSync operation middleware
+func (siw *ServerInterfaceWrapper) AdvertisementsSync(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.AdvertisementsSync(c)
+}
+
+// GASPSyncStatus operation middleware
+func (siw *ServerInterfaceWrapper) GASPSyncStatus(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.GASPSyncStatus(c)
+}
+
+// SyncTopic operation middleware
+func (siw *ServerInterfaceWrapper) SyncTopic(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.SyncTopic(c)
+}
+
+// GetTopicSyncJob operation middleware
+func (siw *ServerInterfaceWrapper) GetTopicSyncJob(c *fiber.Ctx) error {
+	var err error
+
+	// ------------- Path parameter "jobID" -------------
+	var jobID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobID", c.Params("jobID"), &jobID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobID: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.GetTopicSyncJob(c, jobID)
+}
+
+// UnregisterTopicManager operation middleware
+func (siw *ServerInterfaceWrapper) UnregisterTopicManager(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnregisterTopicManagerParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "topicManager" -------------
+
+	if paramValue := c.Query("topicManager"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid topicManager must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "topicManager", query, &params.TopicManager)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topicManager")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.UnregisterTopicManager(c, params)
+}
+
+// RegisterTopicManager operation middleware
+func (siw *ServerInterfaceWrapper) RegisterTopicManager(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RegisterTopicManager(c)
+}
+
+// RemoveTopicQuota operation middleware
+func (siw *ServerInterfaceWrapper) RemoveTopicQuota(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveTopicQuotaParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "topic" -------------
+
+	if paramValue := c.Query("topic"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid topic must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RemoveTopicQuota(c, params)
+}
+
+// ListTopicQuotas operation middleware
+func (siw *ServerInterfaceWrapper) ListTopicQuotas(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListTopicQuotas(c)
+}
+
+// SetTopicQuota operation middleware
+func (siw *ServerInterfaceWrapper) SetTopicQuota(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.SetTopicQuota(c)
+}
+
+// RemoveTracker operation middleware
+func (siw *ServerInterfaceWrapper) RemoveTracker(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveTrackerParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "kind" -------------
+
+	if paramValue := c.Query("kind"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid kind must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "kind", query, &params.Kind)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter kind")
+	}
+
+	// ------------- Required query parameter "url" -------------
+
+	if paramValue := c.Query("url"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid url must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "url", query, &params.Url)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter url")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.RemoveTracker(c, params)
+}
+
+// ListTrackers operation middleware
+func (siw *ServerInterfaceWrapper) ListTrackers(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ListTrackers(c)
+}
+
+// AddTracker operation middleware
+func (siw *ServerInterfaceWrapper) AddTracker(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.AddTracker(c)
+}
+
+// UnregisterWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UnregisterWebhook(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnregisterWebhookParams
 
-	// (POST /api/v1/lookup)
-	LookupQuestion(c *fiber.Ctx) error
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
 
-	// (POST /api/v1/requestForeignGASPNode)
-	RequestForeignGASPNode(c *fiber.Ctx, params RequestForeignGASPNodeParams) error
+	// ------------- Required query parameter "id" -------------
 
-	// (POST /api/v1/requestSyncResponse)
-	RequestSyncResponse(c *fiber.Ctx, params RequestSyncResponseParams) error
+	if paramValue := c.Query("id"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid id must be provided to retrieve documentation.")
+	}
 
-	// (POST /api/v1/submit)
-	SubmitTransaction(c *fiber.Ctx, params SubmitTransactionParams) error
-}
+	err = runtime.BindQueryParameter("form", true, true, "id", query, &params.Id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter id")
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	handler           ServerInterface
-	globalMiddleware  []fiber.Handler
-	handlerMiddleware []fiber.Handler
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.UnregisterWebhook(c, params)
 }
 
-// StartGASPSync operation middleware
-func (siw *ServerInterfaceWrapper) StartGASPSync(c *fiber.Ctx) error {
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(c *fiber.Ctx) error {
 	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
 
 	for _, m := range siw.handlerMiddleware {
@@ -167,11 +1510,11 @@ func (siw *ServerInterfaceWrapper) StartGASPSync(c *fiber.Ctx) error {
 			return err
 		}
 	}
-	return siw.handler.StartGASPSync(c)
+	return siw.handler.ListWebhooks(c)
 }
 
-// AdvertisementsSync operation middleware
-func (siw *ServerInterfaceWrapper) AdvertisementsSync(c *fiber.Ctx) error {
+// RegisterWebhook operation middleware
+func (siw *ServerInterfaceWrapper) RegisterWebhook(c *fiber.Ctx) error {
 	c.Context().SetUserValue(BearerAuthScopes, []string{"admin"})
 
 	for _, m := range siw.handlerMiddleware {
@@ -179,7 +1522,7 @@ func (siw *ServerInterfaceWrapper) AdvertisementsSync(c *fiber.Ctx) error {
 			return err
 		}
 	}
-	return siw.handler.AdvertisementsSync(c)
+	return siw.handler.RegisterWebhook(c)
 }
 
 // ArcIngest operation middleware
@@ -194,6 +1537,18 @@ func (siw *ServerInterfaceWrapper) ArcIngest(c *fiber.Ctx) error {
 	return siw.handler.ArcIngest(c)
 }
 
+// ArcIngestBatch operation middleware
+func (siw *ServerInterfaceWrapper) ArcIngestBatch(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.ArcIngestBatch(c)
+}
+
 // GetLookupServiceProviderDocumentation operation middleware
 func (siw *ServerInterfaceWrapper) GetLookupServiceProviderDocumentation(c *fiber.Ctx) error {
 	var err error
@@ -264,6 +1619,18 @@ func (siw *ServerInterfaceWrapper) GetTopicManagerDocumentation(c *fiber.Ctx) er
 	return siw.handler.GetTopicManagerDocumentation(c, params)
 }
 
+// UTXOHistory operation middleware
+func (siw *ServerInterfaceWrapper) UTXOHistory(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.UTXOHistory(c)
+}
+
 // ListLookupServiceProviders operation middleware
 func (siw *ServerInterfaceWrapper) ListLookupServiceProviders(c *fiber.Ctx) error {
 	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
@@ -290,14 +1657,180 @@ func (siw *ServerInterfaceWrapper) ListTopicManagers(c *fiber.Ctx) error {
 
 // LookupQuestion operation middleware
 func (siw *ServerInterfaceWrapper) LookupQuestion(c *fiber.Ctx) error {
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupQuestionParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter limit")
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter cursor")
+	}
+
+	// ------------- Optional query parameter "hydrate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hydrate", query, &params.Hydrate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter hydrate")
+	}
+
+	// ------------- Optional query parameter "depth" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "depth", query, &params.Depth)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter depth")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.LookupQuestion(c, params)
+}
+
+// GetLookupQuerySchema operation middleware
+func (siw *ServerInterfaceWrapper) GetLookupQuerySchema(c *fiber.Ctx) error {
+	var err error
+
+	// ------------- Path parameter "service" -------------
+	var service string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service", c.Params("service"), &service, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.GetLookupQuerySchema(c, service)
+}
+
+// OutputsExist operation middleware
+func (siw *ServerInterfaceWrapper) OutputsExist(c *fiber.Ctx) error {
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.OutputsExist(c)
+}
+
+// OutputStatus operation middleware
+func (siw *ServerInterfaceWrapper) OutputStatus(c *fiber.Ctx) error {
+	var err error
+
+	// ------------- Path parameter "txid" -------------
+	var txid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "txid", c.Params("txid"), &txid, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter txid: %w", err).Error())
+	}
+
+	// ------------- Path parameter "vout" -------------
+	var vout uint32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "vout", c.Params("vout"), &vout, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter vout: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params OutputStatusParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "topic" -------------
+
+	if paramValue := c.Query("topic"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid topic must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "topic", query, &params.Topic)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter topic")
+	}
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.OutputStatus(c, txid, vout, params)
+}
+
+// ReplicationStream operation middleware
+func (siw *ServerInterfaceWrapper) ReplicationStream(c *fiber.Ctx) error {
+	var err error
+
 	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplicationStreamParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Required query parameter "since" -------------
+
+	if paramValue := c.Query("since"); paramValue != "" {
+	} else {
+		return fiber.NewError(fiber.StatusBadRequest, "A valid since must be provided to retrieve documentation.")
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "since", query, &params.Since)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter since")
+	}
+
+	// ------------- Optional query parameter "epoch" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "epoch", query, &params.Epoch)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter epoch")
+	}
+
 	for _, m := range siw.handlerMiddleware {
 		if err := m(c); err != nil {
 			return err
 		}
 	}
-	return siw.handler.LookupQuestion(c)
+	return siw.handler.ReplicationStream(c, params)
 }
 
 // RequestForeignGASPNode operation middleware
@@ -377,6 +1910,26 @@ func (siw *ServerInterfaceWrapper) SubmitTransaction(c *fiber.Ctx) error {
 	// Parameter object where we will unmarshal all parameters from the context
 	var params SubmitTransactionParams
 
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for query string")
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", query, &params.Mode)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter mode")
+	}
+
+	// ------------- Optional query parameter "callbackUrl" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "callbackUrl", query, &params.CallbackUrl)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format for parameter callbackUrl")
+	}
+
 	headers := c.GetReqHeaders()
 
 	// ------------- Required header parameter "x-topics" -------------
@@ -394,6 +1947,30 @@ func (siw *ServerInterfaceWrapper) SubmitTransaction(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "The submitted request does not include required header: x-topics.")
 	}
 
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "One or more topics are in an invalid format. Empty string values are not allowed.")
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
+	// ------------- Optional header parameter "X-STEAK-Version" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-STEAK-Version")]; found {
+		var XSTEAKVersion string
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-STEAK-Version", valueList[0], &XSTEAKVersion, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "One or more topics are in an invalid format. Empty string values are not allowed.")
+		}
+
+		params.XSTEAKVersion = &XSTEAKVersion
+	}
+
 	for _, m := range siw.handlerMiddleware {
 		if err := m(c); err != nil {
 			return err
@@ -402,6 +1979,28 @@ func (siw *ServerInterfaceWrapper) SubmitTransaction(c *fiber.Ctx) error {
 	return siw.handler.SubmitTransaction(c, params)
 }
 
+// GetSubmitJob operation middleware
+func (siw *ServerInterfaceWrapper) GetSubmitJob(c *fiber.Ctx) error {
+	var err error
+
+	// ------------- Path parameter "jobID" -------------
+	var jobID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobID", c.Params("jobID"), &jobID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobID: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{"user"})
+
+	for _, m := range siw.handlerMiddleware {
+		if err := m(c); err != nil {
+			return err
+		}
+	}
+	return siw.handler.GetSubmitJob(c, jobID)
+}
+
 // FiberServerOptions provides options for the Fiber server.
 type FiberServerOptions struct {
 	BaseURL           string
@@ -426,25 +2025,113 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(m)
 	}
 
+	router.Get(options.BaseURL+"/api/v1/admin/advertisementPlan", wrapper.AdvertisementPlan)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/advertisements", wrapper.RevokeAdvertisement)
+
+	router.Get(options.BaseURL+"/api/v1/admin/advertisements", wrapper.ListAdvertisements)
+
+	router.Post(options.BaseURL+"/api/v1/admin/advertisements", wrapper.CreateAdvertisement)
+
+	router.Get(options.BaseURL+"/api/v1/admin/auditLog", wrapper.ListAuditEntries)
+
+	router.Get(options.BaseURL+"/api/v1/admin/broadcastQueue", wrapper.BroadcastQueue)
+
+	router.Post(options.BaseURL+"/api/v1/admin/config/reload", wrapper.ReloadConfig)
+
+	router.Get(options.BaseURL+"/api/v1/admin/deadLetters", wrapper.ListDeadLetters)
+
+	router.Post(options.BaseURL+"/api/v1/admin/deadLetters/replay", wrapper.ReplayDeadLetter)
+
+	router.Get(options.BaseURL+"/api/v1/admin/graph/:txid/:vout", wrapper.OutputGraph)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/lookupServices", wrapper.UnregisterLookupService)
+
+	router.Get(options.BaseURL+"/api/v1/admin/lookupServices", wrapper.ListRegisteredLookupServices)
+
+	router.Post(options.BaseURL+"/api/v1/admin/lookupServices", wrapper.RegisterLookupService)
+
+	router.Post(options.BaseURL+"/api/v1/admin/lookupServices/replay", wrapper.ReplayTopicIntoLookupService)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/pausedSyncTopics", wrapper.ResumeTopicSync)
+
+	router.Get(options.BaseURL+"/api/v1/admin/pausedSyncTopics", wrapper.ListPausedSyncTopics)
+
+	router.Post(options.BaseURL+"/api/v1/admin/pausedSyncTopics", wrapper.PauseTopicSync)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/pinnedOutputs", wrapper.UnpinOutput)
+
+	router.Post(options.BaseURL+"/api/v1/admin/pinnedOutputs", wrapper.PinOutput)
+
+	router.Post(options.BaseURL+"/api/v1/admin/promoteStandby", wrapper.PromoteStandby)
+
+	router.Post(options.BaseURL+"/api/v1/admin/pruneOutputs", wrapper.PruneOutputs)
+
+	router.Get(options.BaseURL+"/api/v1/admin/reorgSimulation", wrapper.SimulateReorg)
+
 	router.Post(options.BaseURL+"/api/v1/admin/startGASPSync", wrapper.StartGASPSync)
 
+	router.Get(options.BaseURL+"/api/v1/admin/stats", wrapper.TopicStats)
+
 	router.Post(options.BaseURL+"/api/v1/admin/syncAdvertisements", wrapper.AdvertisementsSync)
 
+	router.Get(options.BaseURL+"/api/v1/admin/syncStatus", wrapper.GASPSyncStatus)
+
+	router.Post(options.BaseURL+"/api/v1/admin/syncTopic", wrapper.SyncTopic)
+
+	router.Get(options.BaseURL+"/api/v1/admin/syncTopic/:jobID", wrapper.GetTopicSyncJob)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/topicManagers", wrapper.UnregisterTopicManager)
+
+	router.Post(options.BaseURL+"/api/v1/admin/topicManagers", wrapper.RegisterTopicManager)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/topicQuotas", wrapper.RemoveTopicQuota)
+
+	router.Get(options.BaseURL+"/api/v1/admin/topicQuotas", wrapper.ListTopicQuotas)
+
+	router.Put(options.BaseURL+"/api/v1/admin/topicQuotas", wrapper.SetTopicQuota)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/trackers", wrapper.RemoveTracker)
+
+	router.Get(options.BaseURL+"/api/v1/admin/trackers", wrapper.ListTrackers)
+
+	router.Post(options.BaseURL+"/api/v1/admin/trackers", wrapper.AddTracker)
+
+	router.Delete(options.BaseURL+"/api/v1/admin/webhooks", wrapper.UnregisterWebhook)
+
+	router.Get(options.BaseURL+"/api/v1/admin/webhooks", wrapper.ListWebhooks)
+
+	router.Post(options.BaseURL+"/api/v1/admin/webhooks", wrapper.RegisterWebhook)
+
 	router.Post(options.BaseURL+"/api/v1/arc-ingest", wrapper.ArcIngest)
 
+	router.Post(options.BaseURL+"/api/v1/arc-ingest/batch", wrapper.ArcIngestBatch)
+
 	router.Get(options.BaseURL+"/api/v1/getDocumentationForLookupServiceProvider", wrapper.GetLookupServiceProviderDocumentation)
 
 	router.Get(options.BaseURL+"/api/v1/getDocumentationForTopicManager", wrapper.GetTopicManagerDocumentation)
 
+	router.Post(options.BaseURL+"/api/v1/history", wrapper.UTXOHistory)
+
 	router.Get(options.BaseURL+"/api/v1/listLookupServiceProviders", wrapper.ListLookupServiceProviders)
 
 	router.Get(options.BaseURL+"/api/v1/listTopicManagers", wrapper.ListTopicManagers)
 
 	router.Post(options.BaseURL+"/api/v1/lookup", wrapper.LookupQuestion)
 
+	router.Get(options.BaseURL+"/api/v1/lookup/:service/schema", wrapper.GetLookupQuerySchema)
+
+	router.Post(options.BaseURL+"/api/v1/outputs/exists", wrapper.OutputsExist)
+
+	router.Get(options.BaseURL+"/api/v1/outputs/:txid/:vout", wrapper.OutputStatus)
+
+	router.Get(options.BaseURL+"/api/v1/replication/stream", wrapper.ReplicationStream)
+
 	router.Post(options.BaseURL+"/api/v1/requestForeignGASPNode", wrapper.RequestForeignGASPNode)
 
 	router.Post(options.BaseURL+"/api/v1/requestSyncResponse", wrapper.RequestSyncResponse)
 
 	router.Post(options.BaseURL+"/api/v1/submit", wrapper.SubmitTransaction)
+
+	router.Get(options.BaseURL+"/api/v1/submit/:jobID", wrapper.GetSubmitJob)
 }
@@ -3,14 +3,38 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package openapi
 
+// ArcIngestBatchBody Merkle proofs delivered by a batch callback, processed concurrently
+type ArcIngestBatchBody = []struct {
+	// BlockHash Hash of the block where the transaction was included. When present, it must be the block at blockHeight according to the chain tracker
+	BlockHash *string `json:"blockHash,omitempty"`
+
+	// BlockHeight Block height where the transaction was included
+	BlockHeight uint32 `json:"blockHeight"`
+
+	// MerklePath Merkle path in hexadecimal format
+	MerklePath string `json:"merklePath"`
+
+	// ProofSource Identifier of the service that produced the Merkle proof, e.g. the ARC instance, recorded for auditability
+	ProofSource *string `json:"proofSource,omitempty"`
+
+	// Txid Transaction ID in hexadecimal format
+	Txid string `json:"txid"`
+}
+
 // ArcIngestBody defines model for ArcIngestBody.
 type ArcIngestBody struct {
+	// BlockHash Hash of the block where the transaction was included. When present, it must be the block at blockHeight according to the chain tracker
+	BlockHash *string `json:"blockHash,omitempty"`
+
 	// BlockHeight Block height where the transaction was included
 	BlockHeight uint32 `json:"blockHeight"`
 
 	// MerklePath Merkle path in hexadecimal format
 	MerklePath string `json:"merklePath"`
 
+	// ProofSource Identifier of the service that produced the Merkle proof, e.g. the ARC instance, recorded for auditability
+	ProofSource *string `json:"proofSource,omitempty"`
+
 	// Txid Transaction ID in hexadecimal format
 	Txid string `json:"txid"`
 }
@@ -24,11 +48,26 @@ type LookupQuestionBody struct {
 	Service string `json:"service"`
 }
 
+// OutputsExistBody defines model for OutputsExistBody.
+type OutputsExistBody struct {
+	// Outpoints Outpoints to check, in the format "txid.vout"
+	Outpoints []string `json:"outpoints"`
+
+	// Topic Topic to check the outputs in
+	Topic string `json:"topic"`
+
+	// UnspentOnly Report spent outputs as missing
+	UnspentOnly *bool `json:"unspentOnly,omitempty"`
+}
+
 // RequestForeignGASPNodeBody defines model for RequestForeignGASPNodeBody.
 type RequestForeignGASPNodeBody struct {
 	// GraphID The graph ID in the format of "txID.outputIndex"
 	GraphID string `json:"graphID"`
 
+	// Metadata Whether to include the node's provenance (receive time, source and score)
+	Metadata *bool `json:"metadata,omitempty"`
+
 	// OutputIndex The output index
 	OutputIndex uint32 `json:"outputIndex"`
 
@@ -38,9 +77,27 @@ type RequestForeignGASPNodeBody struct {
 
 // RequestSyncResponseBody defines model for RequestSyncResponseBody.
 type RequestSyncResponseBody struct {
+	// Limit Maximum number of UTXOs to return, zero or absent meaning no limit
+	Limit *uint32 `json:"limit,omitempty"`
+
 	// Since Timestamp or sequence number from which to start synchronization
 	Since float64 `json:"since"`
 
 	// Version The version number of the GASP protocol
 	Version int `json:"version"`
 }
+
+// UTXOHistoryBody defines model for UTXOHistoryBody.
+type UTXOHistoryBody struct {
+	// Depth Ancestor levels to hydrate into the BEEF; omitted hydrates up to the limit configured by the operator
+	Depth *uint32 `json:"depth,omitempty"`
+
+	// OutputIndex Index of the output in the transaction
+	OutputIndex uint32 `json:"outputIndex"`
+
+	// Topic Topic the output was admitted to
+	Topic string `json:"topic"`
+
+	// Txid ID of the transaction holding the output
+	Txid string `json:"txid"`
+}
@@ -3,6 +3,26 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package openapi
 
+import (
+	"time"
+)
+
+// Defines values for OutputStatusMerkleState.
+const (
+	Immutable   OutputStatusMerkleState = "immutable"
+	Invalidated OutputStatusMerkleState = "invalidated"
+	Mined       OutputStatusMerkleState = "mined"
+	Unmined     OutputStatusMerkleState = "unmined"
+)
+
+// Defines values for SubmitJobStatus.
+const (
+	Completed  SubmitJobStatus = "completed"
+	Failed     SubmitJobStatus = "failed"
+	Pending    SubmitJobStatus = "pending"
+	Processing SubmitJobStatus = "processing"
+)
+
 // AdmittanceInstructions defines model for AdmittanceInstructions.
 type AdmittanceInstructions struct {
 	AncillaryTxIDs []string `json:"ancillaryTxIDs"`
@@ -17,6 +37,25 @@ type ArcIngest struct {
 	Status  string `json:"status"`
 }
 
+// ArcIngestBatch defines model for ArcIngestBatch.
+type ArcIngestBatch struct {
+	// Results Outcome of each entry of the batch, in the order of the request
+	Results []ArcIngestBatchResult `json:"results"`
+}
+
+// ArcIngestBatchResult defines model for ArcIngestBatchResult.
+type ArcIngestBatchResult struct {
+	// Code Machine-readable code of the error the entry failed with
+	Code *string `json:"code,omitempty"`
+
+	// Message Description of the outcome of the entry
+	Message string `json:"message"`
+
+	// Status Either success or error
+	Status string `json:"status"`
+	Txid   string `json:"txid"`
+}
+
 // GASPNode A GASP node representation from the overlay engine
 type GASPNode struct {
 	// AncillaryBeef The ancillary beef of the GASP node
@@ -28,6 +67,9 @@ type GASPNode struct {
 	// Inputs The inputs of the GASP node
 	Inputs map[string]interface{} `json:"inputs"`
 
+	// OffChainValues Off-chain values submitted with the transaction of the GASP node; omitted when it has none
+	OffChainValues *[]byte `json:"offChainValues,omitempty"`
+
 	// OutputIndex The output index of the GASP node
 	OutputIndex uint32 `json:"outputIndex"`
 
@@ -37,6 +79,9 @@ type GASPNode struct {
 	// Proof The proof of the GASP node
 	Proof string `json:"proof"`
 
+	// Provenance How the serving node came to hold the GASP node's output. Only present when requested with metadata.
+	Provenance *GASPNodeProvenance `json:"provenance,omitempty"`
+
 	// RawTx The raw transaction of the GASP node
 	RawTx string `json:"rawTx"`
 
@@ -44,13 +89,30 @@ type GASPNode struct {
 	TxMetadata string `json:"txMetadata"`
 }
 
+// GASPNodeProvenance How the serving node came to hold the GASP node's output. Only present when requested with metadata.
+type GASPNodeProvenance struct {
+	// ReceivedAt When the serving node admitted the output
+	ReceivedAt *time.Time `json:"receivedAt,omitempty"`
+
+	// Score The serving node's sort score of the output
+	Score float64 `json:"score"`
+
+	// Source Where the serving node received the output from, e.g. "submit" or the GASP peer it was synced from
+	Source *string `json:"source,omitempty"`
+}
+
 // LookupAnswer defines model for LookupAnswer.
 type LookupAnswer struct {
-	Outputs []OutputListItem `json:"outputs"`
-	Result  string           `json:"result"`
-	Type    string           `json:"type"`
+	// NextCursor Cursor of the next page of outputs; omitted when the answer holds the last outputs
+	NextCursor *string          `json:"nextCursor,omitempty"`
+	Outputs    []OutputListItem `json:"outputs"`
+	Result     string           `json:"result"`
+	Type       string           `json:"type"`
 }
 
+// LookupQuerySchema JSON Schema of the queries accepted by a lookup service
+type LookupQuerySchema map[string]interface{}
+
 // LookupServiceDocumentation defines model for LookupServiceDocumentation.
 type LookupServiceDocumentation struct {
 	// Documentation Markdown-formatted documentation for the lookup service
@@ -62,14 +124,66 @@ type Metadata map[string]ServiceMetadata
 
 // OutputListItem defines model for OutputListItem.
 type OutputListItem struct {
-	Beef        []byte `json:"beef"`
-	OutputIndex uint32 `json:"outputIndex"`
+	Beef []byte `json:"beef"`
+
+	// Metadata Tags set by the topic manager when admitting the output; omitted when it has none
+	Metadata *map[string]string `json:"metadata,omitempty"`
+
+	// OffChainValues Off-chain values submitted with the transaction of the output; omitted when it has none
+	OffChainValues *[]byte `json:"offChainValues,omitempty"`
+	OutputIndex    uint32  `json:"outputIndex"`
+
+	// RawTx Raw transaction of the output when hydrated with rawtx, which leaves beef empty
+	RawTx *[]byte `json:"rawTx,omitempty"`
+
+	// Txid Transaction ID of the output; set on paged answers for outputs hydrated by the overlay
+	Txid *string `json:"txid,omitempty"`
+}
+
+// OutputStatus defines model for OutputStatus.
+type OutputStatus struct {
+	// Admitted Whether the output is held by the topic. The fields below are only set when it is
+	Admitted bool `json:"admitted"`
+
+	// BlockHeight Height of the block mining the transaction, zero while unmined
+	BlockHeight uint32 `json:"blockHeight"`
+
+	// BlockIdx Index of the transaction in its block
+	BlockIdx uint64 `json:"blockIdx"`
+
+	// ConsumedBy Outpoints of the topic spending the output, in the format "txid.vout"
+	ConsumedBy []string `json:"consumedBy"`
+
+	// MerkleState State of the merkle proof of the transaction: unmined, mined, invalidated by a reorg until a new proof arrives, or immutable once confirmed by enough blocks; omitted when the output was not admitted
+	MerkleState *OutputStatusMerkleState `json:"merkleState,omitempty"`
+
+	// Metadata Tags set by the topic manager when admitting the output; omitted when it has none
+	Metadata *map[string]string `json:"metadata,omitempty"`
+	Spent    bool               `json:"spent"`
+	Topic    string             `json:"topic"`
+
+	// TransactionApplied Whether the transaction was processed for the topic, whether or not the output was admitted
+	TransactionApplied bool   `json:"transactionApplied"`
+	Txid               string `json:"txid"`
+	Vout               uint32 `json:"vout"`
+}
+
+// OutputStatusMerkleState State of the merkle proof of the transaction: unmined, mined, invalidated by a reorg until a new proof arrives, or immutable once confirmed by enough blocks; omitted when the output was not admitted
+type OutputStatusMerkleState string
+
+// OutputsExist defines model for OutputsExist.
+type OutputsExist struct {
+	// Exists Whether each requested outpoint is held by the topic, in the order of the request
+	Exists []bool `json:"exists"`
 }
 
 // RequestSyncRes defines model for RequestSyncRes.
 type RequestSyncRes struct {
 	UTXOList []UTXOItem `json:"UTXOList"`
 
+	// Partial Whether the server truncated the UTXO list, in which case the following UTXOs are served to a request from the score of the last one
+	Partial *bool `json:"partial,omitempty"`
+
 	// Since Timestamp or sequence number from which synchronization data was generated
 	Since float64 `json:"since"`
 }
@@ -86,6 +200,30 @@ type ServiceMetadata struct {
 	Version          string `json:"version"`
 }
 
+// SubmitJob defines model for SubmitJob.
+type SubmitJob struct {
+	STEAK *STEAK `json:"STEAK,omitempty"`
+
+	// CompletedAt Time the job completed or failed
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// Error Reason of the failure, set once the job failed
+	Error *string `json:"error,omitempty"`
+
+	// JobId ID of the job, used to poll its outcome
+	JobId string `json:"jobId"`
+
+	// Status Processing state of the submission
+	Status SubmitJobStatus `json:"status"`
+
+	// Topics Topics the transaction was submitted to
+	Topics []string `json:"topics"`
+}
+
+// SubmitJobStatus Processing state of the submission
+type SubmitJobStatus string
+
 // SubmitTransaction defines model for SubmitTransaction.
 type SubmitTransaction struct {
 	STEAK STEAK `json:"STEAK"`
@@ -97,6 +235,15 @@ type TopicManagerDocumentation struct {
 	Documentation string `json:"documentation"`
 }
 
+// UTXOHistory defines model for UTXOHistory.
+type UTXOHistory struct {
+	// Beef BEEF of the output's transaction hydrated with its ancestors admitted to the topic
+	Beef        []byte `json:"beef"`
+	OutputIndex uint32 `json:"outputIndex"`
+	Topic       string `json:"topic"`
+	Txid        string `json:"txid"`
+}
+
 // UTXOItem defines model for UTXOItem.
 type UTXOItem struct {
 	// OutputIndex Output index number
@@ -109,9 +256,33 @@ type UTXOItem struct {
 	Txid string `json:"txid"`
 }
 
+// VersionedAdmittanceInstructions defines model for VersionedAdmittanceInstructions.
+type VersionedAdmittanceInstructions struct {
+	AncillaryTxids []string `json:"ancillaryTxids"`
+	CoinsRemoved   []uint32 `json:"coinsRemoved"`
+	CoinsToRetain  []uint32 `json:"coinsToRetain"`
+	OutputsToAdmit []uint32 `json:"outputsToAdmit"`
+	Topic          string   `json:"topic"`
+}
+
+// VersionedSTEAK STEAK returned with X-STEAK-Version 2. Topics are listed in lexical order and lists are never null,
+// so that equal STEAKs always encode to equal bytes.
+type VersionedSTEAK struct {
+	Topics []VersionedAdmittanceInstructions `json:"topics"`
+
+	// Version Version of the serialization, always 2
+	Version int `json:"version"`
+}
+
+// ArcIngestBatchResponse defines model for ArcIngestBatchResponse.
+type ArcIngestBatchResponse = ArcIngestBatch
+
 // ArcIngestResponse defines model for ArcIngestResponse.
 type ArcIngestResponse = ArcIngest
 
+// LookupQuerySchemaResponse JSON Schema of the queries accepted by a lookup service
+type LookupQuerySchemaResponse = LookupQuerySchema
+
 // LookupQuestionResponse defines model for LookupQuestionResponse.
 type LookupQuestionResponse = LookupAnswer
 
@@ -121,14 +292,26 @@ type LookupServiceProviderDocumentationResponse = LookupServiceDocumentation
 // MetadataResponse defines model for MetadataResponse.
 type MetadataResponse = Metadata
 
+// OutputStatusResponse defines model for OutputStatusResponse.
+type OutputStatusResponse = OutputStatus
+
+// OutputsExistResponse defines model for OutputsExistResponse.
+type OutputsExistResponse = OutputsExist
+
 // RequestForeignGASPNodeResponse A GASP node representation from the overlay engine
 type RequestForeignGASPNodeResponse = GASPNode
 
 // RequestSyncResResponse defines model for RequestSyncResResponse.
 type RequestSyncResResponse = RequestSyncRes
 
+// SubmitJobResponse defines model for SubmitJobResponse.
+type SubmitJobResponse = SubmitJob
+
 // SubmitTransactionResponse defines model for SubmitTransactionResponse.
 type SubmitTransactionResponse = SubmitTransaction
 
 // TopicManagerDocumentationResponse defines model for TopicManagerDocumentationResponse.
 type TopicManagerDocumentationResponse = TopicManagerDocumentation
+
+// UTXOHistoryResponse defines model for UTXOHistoryResponse.
+type UTXOHistoryResponse = UTXOHistory
@@ -0,0 +1,73 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEGraphviz is the content type of output graphs rendered in the Graphviz DOT language.
+const MIMEGraphviz = "text/vnd.graphviz"
+
+// OutputGraphHandler is a Fiber-compatible HTTP handler that processes admin requests for the
+// dependency graph of an output in a topic. It acts as the adapter between HTTP requests and the
+// application-layer OutputGraphService.
+type OutputGraphHandler struct {
+	service *app.OutputGraphService
+}
+
+// Handle processes an HTTP GET request for the dependency graph of the output identified by the txid and vout
+// path parameters in the topic passed as the topic query parameter, following at most depth relations.
+//
+// On success, returns 200 OK with the OutputGraph response, or with the graph in the DOT language when the
+// format query parameter is dot. On failure, returns an application error.
+func (h *OutputGraphHandler) Handle(c *fiber.Ctx, txid string, vout uint32, params openapi.OutputGraphParams) error {
+	var depth uint32
+	if params.Depth != nil {
+		depth = *params.Depth
+	}
+	graph, err := h.service.OutputGraph(apiKeyContext(c), txid, vout, params.Topic, depth)
+	if err != nil {
+		return err
+	}
+	if params.Format != nil && *params.Format == openapi.Dot {
+		c.Set(fiber.HeaderContentType, MIMEGraphviz)
+		return c.Status(fiber.StatusOK).SendString(graph.DOT())
+	}
+	return c.Status(fiber.StatusOK).JSON(NewOutputGraphResponse(graph))
+}
+
+// NewOutputGraphHandler creates a new OutputGraphHandler with the given provider and the access control list
+// restricting the topics each API key may access.
+// If the provider is nil, it panics.
+func NewOutputGraphHandler(provider app.OutputGraphProvider, access app.AccessControlList) *OutputGraphHandler {
+	return &OutputGraphHandler{service: app.NewOutputGraphService(provider, access)}
+}
+
+// NewOutputGraphResponse converts an engine.OutputGraph into an OutputGraph object
+// compatible with the OpenAPI specification.
+func NewOutputGraphResponse(graph *engine.OutputGraph) openapi.OutputGraph {
+	response := openapi.OutputGraph{
+		Root:      graph.Root.String(),
+		Topic:     graph.Topic,
+		Depth:     graph.Depth,
+		Truncated: graph.Truncated,
+		Nodes:     make([]openapi.OutputGraphNode, 0, len(graph.Nodes)),
+		Edges:     make([]openapi.OutputGraphEdge, 0, len(graph.Edges)),
+	}
+	for _, node := range graph.Nodes {
+		response.Nodes = append(response.Nodes, openapi.OutputGraphNode{
+			Outpoint:    node.Outpoint.String(),
+			Depth:       node.Depth,
+			Missing:     node.Missing,
+			Spent:       node.Spent,
+			Pinned:      node.Pinned,
+			BlockHeight: node.BlockHeight,
+		})
+	}
+	for _, edge := range graph.Edges {
+		response.Edges = append(response.Edges, openapi.OutputGraphEdge{From: edge.From.String(), To: edge.To.String()})
+	}
+	return response
+}
@@ -0,0 +1,130 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputGraphHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *txid, Index: 1}
+	consumed := transaction.Outpoint{Txid: *txid, Index: 0}
+	graph := &engine.OutputGraph{
+		Root:  *outpoint,
+		Topic: testabilities.DefaultValidTopic,
+		Depth: 2,
+		Nodes: []*engine.OutputGraphNode{
+			{Outpoint: *outpoint, Spent: true, BlockHeight: testabilities.DefaultBlockHeight},
+			{Outpoint: consumed, Depth: 1, Missing: true},
+		},
+		Edges: []engine.OutputGraphEdge{{From: consumed, To: *outpoint}},
+	}
+	path := "/api/v1/admin/graph/" + testabilities.DefaultValidTxID + "/1?topic=" + testabilities.DefaultValidTopic
+
+	tests := map[string]struct {
+		path             string
+		expectations     testabilities.OutputGraphProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Returns the graph of the output": {
+			path: path + "&depth=2",
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Outpoint:        outpoint,
+				Depth:           2,
+				Graph:           graph,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewOutputGraphResponse(graph),
+		},
+		"Responds with not found when the output is not stored": {
+			path: path,
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Error:           engine.ErrMissingOutput,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewOutputGraphNotFoundError(outpoint.String())),
+		},
+		"Responds with bad request when the depth is too large": {
+			path: path + "&depth=101",
+			expectations: testabilities.OutputGraphProviderMockExpectations{
+				OutputGraphCall: true,
+				Depth:           101,
+				Error:           engine.ErrInvalidOutputGraphDepth,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewInvalidOutputGraphDepthError(101)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputGraphProvider(
+				testabilities.NewOutputGraphProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.OutputGraph
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get(tc.path)
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestOutputGraphHandler_Handle_ShouldRenderDOT(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	graph := &engine.OutputGraph{
+		Root:  transaction.Outpoint{Txid: *txid, Index: 1},
+		Topic: testabilities.DefaultValidTopic,
+		Nodes: []*engine.OutputGraphNode{{Outpoint: transaction.Outpoint{Txid: *txid, Index: 1}}},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputGraphProvider(
+		testabilities.NewOutputGraphProviderMock(t, testabilities.OutputGraphProviderMockExpectations{OutputGraphCall: true, Graph: graph}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		Get("/api/v1/admin/graph/" + testabilities.DefaultValidTxID + "/1?format=dot&topic=" + testabilities.DefaultValidTopic)
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.MIMEGraphviz, res.Header().Get(fiber.HeaderContentType))
+	require.Equal(t, graph.DOT(), string(res.Body()))
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,54 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OutputPinningHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to pin and unpin outputs against pruning and eviction. It acts as the adapter between
+// HTTP requests and the application-layer OutputPinningService.
+type OutputPinningHandler struct {
+	service *app.OutputPinningService
+}
+
+// HandlePin processes an HTTP POST request to pin an output.
+// It expects a JSON request body matching the PinOutputJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK. On failure, returns a request parsing or application error.
+func (h *OutputPinningHandler) HandlePin(c *fiber.Ctx) error {
+	var body openapi.PinOutputJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	if err := h.service.PinOutput(c.UserContext(), body.Topic, body.Outpoint); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewOutputPinResponse())
+}
+
+// HandleUnpin processes an HTTP DELETE request to unpin the output passed as the
+// topic and outpoint query parameters.
+//
+// On success, returns 200 OK. On failure, returns an application error.
+func (h *OutputPinningHandler) HandleUnpin(c *fiber.Ctx, params openapi.UnpinOutputParams) error {
+	if err := h.service.UnpinOutput(c.UserContext(), params.Topic, params.Outpoint); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewOutputPinResponse())
+}
+
+// NewOutputPinningHandler creates a new OutputPinningHandler with the given provider.
+// If the provider is nil, it panics.
+func NewOutputPinningHandler(provider app.OutputPinningProvider) *OutputPinningHandler {
+	return &OutputPinningHandler{service: app.NewOutputPinningService(provider)}
+}
+
+// NewOutputPinResponse returns a new OutputPin response.
+func NewOutputPinResponse() openapi.OutputPin {
+	return openapi.OutputPin{
+		Message: "OK",
+	}
+}
@@ -0,0 +1,108 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputPinningHandler_Pin(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.OutputPinningProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Pins the output": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic, "outpoint": testabilities.DefaultValidGraphID},
+			expectations: testabilities.OutputPinningProviderMockExpectations{
+				PinOutputCall: true,
+				Topic:         testabilities.DefaultValidTopic,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewOutputPinResponse(),
+		},
+		"Rejects an empty topic": {
+			body:             map[string]any{"topic": "", "outpoint": testabilities.DefaultValidGraphID},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("topic")),
+		},
+		"Responds with not found when the output is not admitted into the topic": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic, "outpoint": testabilities.DefaultValidGraphID},
+			expectations: testabilities.OutputPinningProviderMockExpectations{
+				PinOutputCall: true,
+				Error:         engine.ErrMissingOutput,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewPinnedOutputNotFoundError(testabilities.DefaultValidGraphID)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputPinningProvider(
+				testabilities.NewOutputPinningProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.OutputPin
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/pinnedOutputs")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestOutputPinningHandler_Unpin(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputPinningProvider(
+		testabilities.NewOutputPinningProviderMock(t, testabilities.OutputPinningProviderMockExpectations{
+			UnpinOutputCall: true,
+			Topic:           testabilities.DefaultValidTopic,
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.OutputPin
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParams(map[string]string{
+			"topic":    testabilities.DefaultValidTopic,
+			"outpoint": testabilities.DefaultValidGraphID,
+		}).
+		SetResult(&actualResponse).
+		Delete("/api/v1/admin/pinnedOutputs")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewOutputPinResponse(), actualResponse)
+	stub.AssertProvidersState()
+}
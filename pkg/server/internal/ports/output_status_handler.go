@@ -0,0 +1,63 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OutputStatusHandler is a Fiber-compatible HTTP handler that processes requests for the
+// status of an outpoint in a topic. It acts as the adapter between HTTP requests and the
+// application-layer OutputStatusService.
+type OutputStatusHandler struct {
+	service *app.OutputStatusService
+}
+
+// Handle processes an HTTP GET request for the status of the output identified by the txid and vout
+// path parameters in the topic passed as the topic query parameter.
+//
+// On success, returns 200 OK with the OutputStatus response. On failure, returns an application error.
+func (h *OutputStatusHandler) Handle(c *fiber.Ctx, txid string, vout uint32, params openapi.OutputStatusParams) error {
+	status, err := h.service.GetOutputStatus(apiKeyContext(c), txid, vout, params.Topic)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewOutputStatusResponse(status))
+}
+
+// NewOutputStatusHandler creates a new OutputStatusHandler with the given provider and the access control list
+// restricting the topics each API key may access.
+// If the provider is nil, it panics.
+func NewOutputStatusHandler(provider app.OutputStatusProvider, access app.AccessControlList) *OutputStatusHandler {
+	return &OutputStatusHandler{service: app.NewOutputStatusService(provider, access)}
+}
+
+// NewOutputStatusResponse converts an engine.OutputStatus into an OutputStatus object
+// compatible with the OpenAPI specification.
+func NewOutputStatusResponse(status *engine.OutputStatus) openapi.OutputStatus {
+	consumedBy := make([]string, 0, len(status.ConsumedBy))
+	for _, outpoint := range status.ConsumedBy {
+		consumedBy = append(consumedBy, outpoint.String())
+	}
+
+	response := openapi.OutputStatus{
+		Txid:               status.Outpoint.Txid.String(),
+		Vout:               status.Outpoint.Index,
+		Topic:              status.Topic,
+		TransactionApplied: status.TransactionApplied,
+		Admitted:           status.Admitted,
+		Spent:              status.Spent,
+		BlockHeight:        status.BlockHeight,
+		BlockIdx:           status.BlockIdx,
+		ConsumedBy:         consumedBy,
+	}
+	if status.MerkleState != "" {
+		merkleState := openapi.OutputStatusMerkleState(status.MerkleState)
+		response.MerkleState = &merkleState
+	}
+	if len(status.Metadata) > 0 {
+		response.Metadata = &status.Metadata
+	}
+	return response
+}
@@ -0,0 +1,154 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputStatusHandler_Handle(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := &transaction.Outpoint{Txid: *txid, Index: 1}
+	status := &engine.OutputStatus{
+		Outpoint:           *outpoint,
+		Topic:              testabilities.DefaultValidTopic,
+		TransactionApplied: true,
+		Admitted:           true,
+		Spent:              true,
+		BlockHeight:        testabilities.DefaultBlockHeight,
+		BlockIdx:           3,
+		ConsumedBy:         []*transaction.Outpoint{{Txid: *txid, Index: 0}},
+		MerkleState:        engine.MerkleStateMined,
+		Metadata:           map[string]string{"ticker": "TEST"},
+	}
+	unknown := &engine.OutputStatus{Outpoint: *outpoint, Topic: testabilities.DefaultValidTopic}
+
+	tests := map[string]struct {
+		path             string
+		expectations     testabilities.OutputStatusProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Reports the status of an admitted output": {
+			path: "/api/v1/outputs/" + testabilities.DefaultValidTxID + "/1?topic=" + testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Outpoint:            outpoint,
+				Status:              status,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewOutputStatusResponse(status),
+		},
+		"Reports an output the topic has never seen": {
+			path: "/api/v1/outputs/" + testabilities.DefaultValidTxID + "/1?topic=" + testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Status:              unknown,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewOutputStatusResponse(unknown),
+		},
+		"Responds with bad request when the topic is unknown": {
+			path: "/api/v1/outputs/" + testabilities.DefaultValidTxID + "/1?topic=" + testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Error:               engine.ErrUnknownTopic,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewOutputStatusUnknownTopicError(testabilities.DefaultValidTopic)),
+		},
+		"Responds with internal server error when the provider fails": {
+			path: "/api/v1/outputs/" + testabilities.DefaultValidTxID + "/1?topic=" + testabilities.DefaultValidTopic,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Error:               testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewOutputStatusProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputStatusProvider(
+				testabilities.NewOutputStatusProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			var actualSuccess openapi.OutputStatus
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get(tc.path)
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestOutputStatusHandler_AccessControl(t *testing.T) {
+	acl := server.AccessControlList{
+		Topics: map[string][]string{testabilities.DefaultValidTopic: {"private-key"}},
+	}
+	path := "/api/v1/outputs/" + testabilities.DefaultValidTxID + "/1?topic=" + testabilities.DefaultValidTopic
+
+	tests := map[string]struct {
+		headers            map[string]string
+		expectedStatusCode int
+		expectations       testabilities.OutputStatusProviderMockExpectations
+	}{
+		"Status of an output of a restricted topic without an API key is forbidden": {
+			expectedStatusCode: fiber.StatusForbidden,
+			expectations:       testabilities.OutputStatusProviderMockExpectations{GetOutputStatusCall: false},
+		},
+		"Status of an output of a restricted topic with a permitted API key is returned": {
+			headers:            map[string]string{fiber.HeaderAuthorization: "Bearer private-key"},
+			expectedStatusCode: fiber.StatusOK,
+			expectations: testabilities.OutputStatusProviderMockExpectations{
+				GetOutputStatusCall: true,
+				Status:              &engine.OutputStatus{Topic: testabilities.DefaultValidTopic},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputStatusProvider(
+				testabilities.NewOutputStatusProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAccessControlList(acl))
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				Get(path)
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			stub.AssertProvidersState()
+		})
+	}
+}
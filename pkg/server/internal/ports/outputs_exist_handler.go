@@ -0,0 +1,49 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OutputsExistHandler is a Fiber-compatible HTTP handler that processes bulk existence checks
+// of outpoints in a topic. It acts as the adapter between HTTP requests and the
+// application-layer OutputsExistService.
+type OutputsExistHandler struct {
+	service *app.OutputsExistService
+}
+
+// Handle processes an HTTP POST request checking which of the given outpoints are held by a topic.
+// It expects a JSON body matching the OutputsExistBody OpenAPI definition.
+//
+// On success, returns 200 OK with the OutputsExist response. On failure, returns either
+// a request parsing error or an application error.
+func (h *OutputsExistHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.OutputsExistBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	unspentOnly := body.UnspentOnly != nil && *body.UnspentOnly
+	exists, err := h.service.OutputsExist(apiKeyContext(c), body.Topic, body.Outpoints, unspentOnly)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewOutputsExistResponse(exists))
+}
+
+// NewOutputsExistHandler creates a new OutputsExistHandler with the given provider and the access control list
+// restricting the topics each API key may access.
+// If the provider is nil, it panics.
+func NewOutputsExistHandler(provider app.OutputsExistProvider, access app.AccessControlList) *OutputsExistHandler {
+	return &OutputsExistHandler{service: app.NewOutputsExistService(provider, access)}
+}
+
+// NewOutputsExistResponse converts the existence vector into an OutputsExist object
+// compatible with the OpenAPI specification.
+func NewOutputsExistResponse(exists []bool) openapi.OutputsExist {
+	if exists == nil {
+		exists = []bool{}
+	}
+	return openapi.OutputsExist{Exists: exists}
+}
@@ -0,0 +1,114 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputsExistHandler_Handle(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoints := []*transaction.Outpoint{{Txid: *txid, Index: 0}, {Txid: *txid, Index: 1}}
+	unspentOnly := true
+	body := openapi.OutputsExistBody{
+		Topic:     testabilities.DefaultValidTopic,
+		Outpoints: []string{outpoints[0].String(), outpoints[1].String()},
+	}
+
+	tests := map[string]struct {
+		body             any
+		expectations     testabilities.OutputsExistProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Reports the existence of the outputs": {
+			body: body,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				Outpoints:      outpoints,
+				Exists:         []bool{false, true},
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewOutputsExistResponse([]bool{false, true}),
+		},
+		"Reports spent outputs as missing when requested": {
+			body: openapi.OutputsExistBody{
+				Topic:       body.Topic,
+				Outpoints:   body.Outpoints,
+				UnspentOnly: &unspentOnly,
+			},
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				UnspentOnly:    true,
+				Exists:         []bool{false, false},
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewOutputsExistResponse([]bool{false, false}),
+		},
+		"Responds with bad request when too many outpoints are given": {
+			body: openapi.OutputsExistBody{
+				Topic:     testabilities.DefaultValidTopic,
+				Outpoints: make([]string, engine.MaxOutpointsPerExistenceCheck+1),
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTooManyOutpointsError(engine.MaxOutpointsPerExistenceCheck+1)),
+		},
+		"Responds with bad request when the topic is unknown": {
+			body: body,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				Error:          engine.ErrUnknownTopic,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewOutputsExistUnknownTopicError(testabilities.DefaultValidTopic)),
+		},
+		"Responds with internal server error when the provider fails": {
+			body: body,
+			expectations: testabilities.OutputsExistProviderMockExpectations{
+				HasOutputsCall: true,
+				Error:          testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewOutputsExistProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithOutputsExistProvider(
+				testabilities.NewOutputsExistProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			var actualSuccess openapi.OutputsExist
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/outputs/exists")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
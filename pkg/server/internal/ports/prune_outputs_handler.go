@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PruneOutputsHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to prune outputs immediately instead of waiting for the background pruner. It acts as
+// the adapter between HTTP requests and the application-layer PruneOutputsService.
+type PruneOutputsHandler struct {
+	service *app.PruneOutputsService
+}
+
+// Handle processes an HTTP POST request applying the retention policies of the overlay topics.
+//
+// On success, returns 200 OK with the PruneOutputs response. On failure, returns an application error.
+func (h *PruneOutputsHandler) Handle(c *fiber.Ctx) error {
+	reports, err := h.service.PruneOutputs(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewPruneOutputsResponse(reports))
+}
+
+// NewPruneOutputsHandler creates a new PruneOutputsHandler with the given provider.
+// If the provider is nil, it panics.
+func NewPruneOutputsHandler(provider app.PruneOutputsProvider) *PruneOutputsHandler {
+	return &PruneOutputsHandler{service: app.NewPruneOutputsService(provider)}
+}
+
+// NewPruneOutputsResponse converts engine prune reports into a PruneOutputs object
+// compatible with the OpenAPI specification.
+func NewPruneOutputsResponse(reports []*engine.PruneReport) openapi.PruneOutputs {
+	response := openapi.PruneOutputs{Topics: make([]openapi.PrunedTopic, 0, len(reports))}
+	for _, report := range reports {
+		pruned := make([]string, 0, len(report.Pruned))
+		for _, outpoint := range report.Pruned {
+			pruned = append(pruned, outpoint.String())
+		}
+		response.Topics = append(response.Topics, openapi.PrunedTopic{Topic: report.Topic, Pruned: pruned})
+	}
+	return response
+}
@@ -0,0 +1,83 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneOutputsHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	reports := []*engine.PruneReport{{
+		Topic:  testabilities.DefaultValidTopic,
+		Pruned: []*transaction.Outpoint{{Index: 1}},
+	}}
+
+	tests := map[string]struct {
+		expectations     testabilities.PruneOutputsProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Prunes the outputs": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Reports:          reports,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewPruneOutputsResponse(reports),
+		},
+		"Responds with not found when no retention is configured": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Error:            engine.ErrRetentionNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewPruneOutputsUnsupportedError(engine.ErrRetentionNotConfigured)),
+		},
+		"Responds with service unavailable while the engine is stopping": {
+			expectations: testabilities.PruneOutputsProviderMockExpectations{
+				PruneOutputsCall: true,
+				Error:            engine.ErrEngineStopping,
+			},
+			expectedStatus:   fiber.StatusServiceUnavailable,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewPruneOutputsUnavailableError(0)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithPruneOutputsProvider(
+				testabilities.NewPruneOutputsProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.PruneOutputs
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/pruneOutputs")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
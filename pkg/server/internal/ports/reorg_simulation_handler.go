@@ -0,0 +1,65 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReorgSimulationHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to simulate a chain reorg against the overlay storage. It acts as the adapter between
+// HTTP requests and the application-layer ReorgSimulationService.
+type ReorgSimulationHandler struct {
+	service *app.ReorgSimulationService
+}
+
+// Handle processes an HTTP GET request simulating a reorg of the depth passed as the depth query parameter.
+//
+// On success, returns 200 OK with the ReorgSimulation report. On failure, returns an application error.
+func (h *ReorgSimulationHandler) Handle(c *fiber.Ctx, params openapi.SimulateReorgParams) error {
+	simulation, err := h.service.SimulateReorg(c.UserContext(), params.Depth)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewReorgSimulationResponse(simulation))
+}
+
+// NewReorgSimulationHandler creates a new ReorgSimulationHandler with the given provider.
+// If the provider is nil, it panics.
+func NewReorgSimulationHandler(provider app.ReorgSimulationProvider) *ReorgSimulationHandler {
+	return &ReorgSimulationHandler{service: app.NewReorgSimulationService(provider)}
+}
+
+// NewReorgSimulationResponse converts an engine.ReorgSimulation into a ReorgSimulation object
+// compatible with the OpenAPI specification.
+func NewReorgSimulationResponse(simulation *engine.ReorgSimulation) openapi.ReorgSimulation {
+	outputs := make([]openapi.ReorgAffectedOutput, 0, len(simulation.InvalidatedOutputs))
+	for _, output := range simulation.InvalidatedOutputs {
+		outputs = append(outputs, openapi.ReorgAffectedOutput{
+			Outpoint:    output.Outpoint.String(),
+			Topic:       output.Topic,
+			BlockHeight: output.BlockHeight,
+			Spent:       output.Spent,
+		})
+	}
+
+	topics := make(map[string]openapi.ReorgTopicImpact, len(simulation.AffectedTopics))
+	for topic, impact := range simulation.AffectedTopics {
+		topics[topic] = openapi.ReorgTopicImpact{
+			Outputs:      impact.Outputs,
+			Transactions: impact.Transactions,
+		}
+	}
+
+	return openapi.ReorgSimulation{
+		Depth:              simulation.Depth,
+		CurrentHeight:      simulation.CurrentHeight,
+		ForkHeight:         simulation.ForkHeight,
+		UtxosOnly:          simulation.UTXOsOnly,
+		InvalidatedOutputs: outputs,
+		AffectedTopics:     topics,
+		ResyncTransactions: simulation.ResyncTransactions,
+		ResyncBytes:        simulation.ResyncBytes,
+	}
+}
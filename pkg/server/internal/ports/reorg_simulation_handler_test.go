@@ -0,0 +1,75 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgSimulationHandler_ValidCase(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	simulation := &engine.ReorgSimulation{
+		Depth:         2,
+		CurrentHeight: 100,
+		ForkHeight:    99,
+		InvalidatedOutputs: []*engine.ReorgAffectedOutput{
+			{Outpoint: transaction.Outpoint{Txid: chainhash.Hash{1}}, Topic: "tm_a", BlockHeight: 99},
+		},
+		AffectedTopics:     map[string]*engine.ReorgTopicImpact{"tm_a": {Outputs: 1, Transactions: 1}},
+		ResyncTransactions: 1,
+		ResyncBytes:        10,
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithReorgSimulationProvider(
+		testabilities.NewReorgSimulationProviderMock(t, testabilities.ReorgSimulationProviderMockExpectations{
+			SimulateReorgCall: true,
+			Depth:             2,
+			Simulation:        simulation,
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.ReorgSimulation
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("depth", "2").
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/reorgSimulation")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewReorgSimulationResponse(simulation), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestReorgSimulationHandler_InvalidDepth(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	stub := testabilities.NewTestOverlayEngineStub(t)
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("depth", "0").
+		SetError(&actualResponse).
+		Get("/api/v1/admin/reorgSimulation")
+
+	// then:
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewInvalidReorgDepthError()), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,70 @@
+package ports
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEApplicationNDJSON is the content type of newline delimited JSON streams, such as the storage mutation stream.
+const MIMEApplicationNDJSON = "application/x-ndjson"
+
+// ReplicationHandler is a Fiber-compatible HTTP handler that serves the storage mutations
+// of a primary to its standbys and promotes a standby. It acts as the adapter between
+// HTTP requests and the application-layer ReplicationService.
+type ReplicationHandler struct {
+	service *app.ReplicationService
+}
+
+// Handle processes an HTTP GET request streaming the storage mutations following the
+// since and epoch query parameters. It is wrapped by the replication authorization decorator,
+// so the query parameters are read from the request rather than passed in.
+//
+// On success, returns 200 OK with one JSON encoded mutation per line, or an empty body when
+// no mutation was recorded before the poll timeout. On failure, returns an application error.
+func (h *ReplicationHandler) Handle(c *fiber.Ctx) error {
+	since, err := strconv.ParseUint(c.Query("since"), 10, 64)
+	if err != nil {
+		return app.NewIncorrectInputWithFieldError("since")
+	}
+
+	mutations, err := h.service.NextMutations(c.UserContext(), c.Query("epoch"), since)
+	if err != nil {
+		return err
+	}
+
+	c.Status(fiber.StatusOK).Set(fiber.HeaderContentType, MIMEApplicationNDJSON)
+	encoder := json.NewEncoder(c)
+	for _, mutation := range mutations {
+		if err := encoder.Encode(mutation); err != nil {
+			return NewRequestBodyParserError(err)
+		}
+	}
+	return nil
+}
+
+// HandlePromote processes an HTTP POST request promoting the standby to primary.
+//
+// On success, returns 200 OK. On failure, returns an application error.
+func (h *ReplicationHandler) HandlePromote(c *fiber.Ctx) error {
+	if err := h.service.PromoteStandby(c.UserContext()); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewPromoteStandbyResponse())
+}
+
+// NewReplicationHandler creates a new ReplicationHandler with the given provider.
+// If the provider is nil, it panics.
+func NewReplicationHandler(provider app.ReplicationProvider) *ReplicationHandler {
+	return &ReplicationHandler{service: app.NewReplicationService(provider)}
+}
+
+// NewPromoteStandbyResponse returns a new PromoteStandby response.
+func NewPromoteStandbyResponse() openapi.PromoteStandby {
+	return openapi.PromoteStandby{
+		Message: "OK",
+	}
+}
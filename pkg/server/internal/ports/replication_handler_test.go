@@ -0,0 +1,165 @@
+package ports_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/decorators"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicationHandler_Handle(t *testing.T) {
+	const (
+		adminToken       = "22222222-2222-2222-2222-222222222222"
+		replicationToken = "33333333-3333-3333-3333-333333333333"
+	)
+	mutations := []*engine.Mutation{
+		{Epoch: "epoch", Seq: 8, Op: engine.MutationDeleteOutput, Topic: testabilities.DefaultValidTopic},
+		{Epoch: "epoch", Seq: 9, Op: engine.MutationUpdateLastInteraction, Host: "https://peer.example", Since: 12},
+	}
+
+	tests := map[string]struct {
+		replicationToken  string
+		authorization     string
+		expectations      testabilities.ReplicationProviderMockExpectations
+		expectedStatus    int
+		expectedMutations []*engine.Mutation
+		expectedError     any
+	}{
+		"Streams the mutations following the position": {
+			replicationToken: replicationToken,
+			authorization:    "Bearer " + replicationToken,
+			expectations: testabilities.ReplicationProviderMockExpectations{
+				NextMutationsCall: true,
+				Epoch:             "epoch",
+				Since:             7,
+				Mutations:         mutations,
+			},
+			expectedStatus:    fiber.StatusOK,
+			expectedMutations: mutations,
+		},
+		"Responds with bad request when the mutations are no longer available": {
+			replicationToken: replicationToken,
+			authorization:    "Bearer " + replicationToken,
+			expectations: testabilities.ReplicationProviderMockExpectations{
+				NextMutationsCall: true,
+				Epoch:             "epoch",
+				Since:             7,
+				Error:             engine.ErrReplicationGap,
+			},
+			expectedStatus: fiber.StatusBadRequest,
+			expectedError:  testabilities.NewTestOpenapiErrorResponse(t, app.NewReplicationGapError(engine.ErrReplicationGap)),
+		},
+		"Responds with forbidden when the admin token is presented": {
+			replicationToken: replicationToken,
+			authorization:    "Bearer " + adminToken,
+			expectedStatus:   fiber.StatusForbidden,
+			expectedError:    testabilities.NewTestOpenapiErrorResponse(t, decorators.NewInvalidBearerTokenError()),
+		},
+		"Responds with not found when no replication token is configured": {
+			authorization:  "Bearer " + replicationToken,
+			expectedStatus: fiber.StatusNotFound,
+			expectedError:  testabilities.NewTestOpenapiErrorResponse(t, decorators.NewUnsupportedEndpointError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithReplicationProvider(
+				testabilities.NewReplicationProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithAdminBearerToken(adminToken),
+				server.WithReplicationToken(tc.replicationToken),
+			)
+
+			// when:
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, tc.authorization).
+				SetQueryParams(map[string]string{"since": "7", "epoch": "epoch"}).
+				SetError(&actualError).
+				Get("/api/v1/replication/stream")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, ports.MIMEApplicationNDJSON, res.Header().Get(fiber.HeaderContentType))
+
+				var actualMutations []*engine.Mutation
+				scanner := bufio.NewScanner(bytes.NewReader(res.Body()))
+				for scanner.Scan() {
+					var mutation engine.Mutation
+					require.NoError(t, json.Unmarshal(scanner.Bytes(), &mutation))
+					actualMutations = append(actualMutations, &mutation)
+				}
+				require.Equal(t, tc.expectedMutations, actualMutations)
+			} else {
+				require.Equal(t, tc.expectedError, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestReplicationHandler_HandlePromote(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		expectations     testabilities.ReplicationProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Promotes the standby": {
+			expectations:     testabilities.ReplicationProviderMockExpectations{PromoteStandbyCall: true},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewPromoteStandbyResponse(),
+		},
+		"Responds with not found when the node is not a standby": {
+			expectations:     testabilities.ReplicationProviderMockExpectations{PromoteStandbyCall: true, Error: engine.ErrNotStandby},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewNotStandbyError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithReplicationProvider(
+				testabilities.NewReplicationProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.PromoteStandby
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/promoteStandby")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
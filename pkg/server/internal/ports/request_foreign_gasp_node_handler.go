@@ -22,7 +22,8 @@ type RequestForeignGASPNodeHandler struct {
 // The response is formatted as a GASPNode object in OpenAPI-compatible JSON format.
 //
 // On success, returns a 200 OK response with the GASP node data.
-// On failure, returns a request parsing or service-level error.
+// On failure, returns a request parsing or service-level error, e.g. 429 Too Many Requests when the peer
+// already has too many GASP requests in progress or 413 Payload Too Large when the node exceeds the served budget.
 func (h *RequestForeignGASPNodeHandler) Handle(c *fiber.Ctx, params openapi.RequestForeignGASPNodeParams) error {
 	var body openapi.RequestForeignGASPNodeJSONBody
 
@@ -31,11 +32,12 @@ func (h *RequestForeignGASPNodeHandler) Handle(c *fiber.Ctx, params openapi.Requ
 		return NewRequestBodyParserError(err)
 	}
 
-	node, err := h.service.RequestForeignGASPNode(c.Context(), app.RequestForeignGASPNodeDTO{
+	node, err := h.service.RequestForeignGASPNode(gaspPeerContext(c), app.RequestForeignGASPNodeDTO{
 		GraphID:     body.GraphID,
 		TxID:        body.TxID,
 		OutputIndex: body.OutputIndex,
 		Topic:       params.XBSVTopic,
+		Metadata:    body.Metadata != nil && *body.Metadata,
 	})
 	if err != nil {
 		return err
@@ -45,20 +47,21 @@ func (h *RequestForeignGASPNodeHandler) Handle(c *fiber.Ctx, params openapi.Requ
 }
 
 // NewRequestForeignGASPNodeHandler constructs a new RequestForeignGASPNodeHandler
-// using the given RequestForeignGASPNodeProvider to instantiate the underlying service.
+// using the given RequestForeignGASPNodeProvider and the access control list restricting the topics
+// each API key may access to instantiate the underlying service.
 //
 // The provider must implement the RequestForeignGASPNodeProvider interface.
 // This function bridges infrastructure (provider) with application-layer logic.
 // Panics if the provider is nil.
-func NewRequestForeignGASPNodeHandler(provider app.RequestForeignGASPNodeProvider) *RequestForeignGASPNodeHandler {
-	return &RequestForeignGASPNodeHandler{service: app.NewRequestForeignGASPNodeService(provider)}
+func NewRequestForeignGASPNodeHandler(provider app.RequestForeignGASPNodeProvider, access app.AccessControlList) *RequestForeignGASPNodeHandler {
+	return &RequestForeignGASPNodeHandler{service: app.NewRequestForeignGASPNodeService(provider, access)}
 }
 
 // NewRequestForeignGASPNodeSuccessResponse converts a gasp.Node into a
 // GASPNode object compatible with the OpenAPI specification.
 //
 // It ensures proper mapping of fields including inputs, optional graph ID and proof,
-// and transaction/output metadata.
+// transaction/output metadata, the optional provenance and off-chain values.
 func NewRequestForeignGASPNodeSuccessResponse(node *gasp.Node) openapi.GASPNode {
 	var inputs map[string]any
 	if len(node.Inputs) > 0 {
@@ -78,6 +81,22 @@ func NewRequestForeignGASPNodeSuccessResponse(node *gasp.Node) openapi.GASPNode
 		proof = *node.Proof
 	}
 
+	var provenance *openapi.GASPNodeProvenance
+	if node.Provenance != nil {
+		provenance = &openapi.GASPNodeProvenance{
+			ReceivedAt: node.Provenance.ReceivedAt,
+			Score:      node.Provenance.Score,
+		}
+		if source := node.Provenance.Source; source != "" {
+			provenance.Source = &source
+		}
+	}
+
+	var offChainValues *[]byte
+	if len(node.OffChainValues) > 0 {
+		offChainValues = &node.OffChainValues
+	}
+
 	return openapi.GASPNode{
 		GraphID:        graphID,
 		RawTx:          node.RawTx,
@@ -87,5 +106,7 @@ func NewRequestForeignGASPNodeSuccessResponse(node *gasp.Node) openapi.GASPNode
 		OutputMetadata: node.OutputMetadata,
 		Inputs:         inputs,
 		AncillaryBeef:  node.AncillaryBeef,
+		Provenance:     provenance,
+		OffChainValues: offChainValues,
 	}
 }
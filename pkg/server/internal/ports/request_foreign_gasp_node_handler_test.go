@@ -2,7 +2,9 @@ package ports_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
@@ -40,6 +42,40 @@ func TestRequestForeignGASPNodeHandler_InvalidCases(t *testing.T) {
 				app.NewForeignGASPNodeProviderError(testabilities.ErrTestNoopOpFailure),
 			),
 		},
+		"Request foreign GASP node service rejects the request of a busy peer": {
+			payload: openapi.RequestForeignGASPNodeBody{
+				GraphID:     testabilities.DefaultValidGraphID,
+				OutputIndex: testabilities.DefaultValidOutputIndex,
+				TxID:        testabilities.DefaultValidTxID,
+			},
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+				"X-BSV-Topic":           testabilities.DefaultValidTopic,
+			},
+			expectations: testabilities.RequestForeignGASPNodeProviderMockExpectations{
+				ProvideForeignGASPNodeCall: true,
+				Error:                      &engine.GASPPeerBusyError{Peer: "peer", RetryAfter: time.Second},
+			},
+			expectedStatusCode: fiber.StatusTooManyRequests,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewGASPPeerBusyError(time.Second)),
+		},
+		"Request foreign GASP node service rejects a node exceeding the response budget": {
+			payload: openapi.RequestForeignGASPNodeBody{
+				GraphID:     testabilities.DefaultValidGraphID,
+				OutputIndex: testabilities.DefaultValidOutputIndex,
+				TxID:        testabilities.DefaultValidTxID,
+			},
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+				"X-BSV-Topic":           testabilities.DefaultValidTopic,
+			},
+			expectations: testabilities.RequestForeignGASPNodeProviderMockExpectations{
+				ProvideForeignGASPNodeCall: true,
+				Error:                      engine.ErrGASPResponseBudgetExceeded,
+			},
+			expectedStatusCode: fiber.StatusRequestEntityTooLarge,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewGASPResponseTooLargeError(engine.ErrGASPResponseBudgetExceeded)),
+		},
 		"Malformed request body content in the HTTP request": {
 			payload: "INVALID_JSON",
 			headers: map[string]string{
@@ -113,3 +149,50 @@ func TestRequestForeignGASPNodeHandler_ValidCase(t *testing.T) {
 	require.Equal(t, expectedResponse, actualResponse)
 	stub.AssertProvidersState()
 }
+
+func TestRequestForeignGASPNodeHandler_WithMetadata_ShouldIncludeProvenance(t *testing.T) {
+	// given:
+	receivedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectations := testabilities.RequestForeignGASPNodeProviderMockExpectations{
+		ProvideForeignGASPNodeCall: true,
+		Metadata:                   true,
+		Node: &gasp.Node{
+			Provenance: &gasp.NodeProvenance{
+				ReceivedAt: &receivedAt,
+				Source:     "https://peer.example.com",
+				Score:      42,
+			},
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithRequestForeignGASPNodeProvider(
+		testabilities.NewRequestForeignGASPNodeProviderMock(t, expectations),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+	metadata := true
+
+	// when:
+	var actualResponse openapi.GASPNode
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			"X-BSV-Topic":           testabilities.DefaultValidTopic,
+			fiber.HeaderContentType: fiber.MIMEApplicationJSON,
+		}).
+		SetBody(openapi.RequestForeignGASPNodeBody{
+			GraphID:     testabilities.DefaultValidGraphID,
+			OutputIndex: testabilities.DefaultValidOutputIndex,
+			TxID:        testabilities.DefaultValidTxID,
+			Metadata:    &metadata,
+		}).
+		SetResult(&actualResponse).
+		Post("/api/v1/requestForeignGASPNode")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.NotNil(t, actualResponse.Provenance)
+	require.True(t, receivedAt.Equal(*actualResponse.Provenance.ReceivedAt))
+	require.Equal(t, "https://peer.example.com", *actualResponse.Provenance.Source)
+	require.InDelta(t, 42.0, actualResponse.Provenance.Score, 0)
+	stub.AssertProvidersState()
+}
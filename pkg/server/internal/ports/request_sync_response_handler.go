@@ -1,7 +1,11 @@
 package ports
 
 import (
+	"context"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,7 +26,8 @@ type RequestSyncResponseHandler struct {
 // to the application service. The response is returned in OpenAPI-compatible format.
 //
 // On success, returns 200 OK with a list of UTXOs and a since marker.
-// On failure, returns a request parsing or application error.
+// On failure, returns a request parsing or application error, e.g. 429 Too Many Requests
+// when the peer already has too many GASP requests in progress.
 func (h *RequestSyncResponseHandler) Handle(c *fiber.Ctx, params openapi.RequestSyncResponseParams) error {
 	var body openapi.RequestSyncResponseJSONRequestBody
 
@@ -32,10 +37,11 @@ func (h *RequestSyncResponseHandler) Handle(c *fiber.Ctx, params openapi.Request
 	}
 
 	dto, err := h.service.RequestSyncResponse(
-		c.Context(),
+		gaspPeerContext(c),
 		app.NewTopic(params.XBSVTopic),
 		app.Version(body.Version),
 		app.Since(body.Since),
+		app.NewLimit(body.Limit),
 	)
 	if err != nil {
 		return err
@@ -45,12 +51,13 @@ func (h *RequestSyncResponseHandler) Handle(c *fiber.Ctx, params openapi.Request
 }
 
 // NewRequestSyncResponseHandler constructs a new RequestSyncResponseHandler
-// with the provided application-level RequestSyncResponseProvider.
+// with the provided application-level RequestSyncResponseProvider and the access control list
+// restricting the topics each API key may access.
 // It connects the infrastructure provider to the business logic service.
 //
 // Panics if the provider is nil.
-func NewRequestSyncResponseHandler(provider app.RequestSyncResponseProvider) *RequestSyncResponseHandler {
-	return &RequestSyncResponseHandler{service: app.NewRequestSyncResponseService(provider)}
+func NewRequestSyncResponseHandler(provider app.RequestSyncResponseProvider, access app.AccessControlList) *RequestSyncResponseHandler {
+	return &RequestSyncResponseHandler{service: app.NewRequestSyncResponseService(provider, access)}
 }
 
 // NewRequestSyncResponseSuccessResponse converts a RequestSyncResponseDTO into a
@@ -74,8 +81,23 @@ func NewRequestSyncResponseSuccessResponse(response *app.RequestSyncResponseDTO)
 		})
 	}
 
+	var partial *bool
+	if response.Partial {
+		partial = &response.Partial
+	}
+
 	return &openapi.RequestSyncResResponse{
 		UTXOList: utxos,
 		Since:    response.Since,
+		Partial:  partial,
+	}
+}
+
+// gaspPeerContext returns the request context identifying the peer whose GASP request is served,
+// by its BRC-31 identity key when authenticated or its IP address otherwise, and carrying its API key.
+func gaspPeerContext(c *fiber.Ctx) context.Context {
+	if identityKey := middleware.BRC31IdentityKey(c); identityKey != "" {
+		return engine.WithGASPPeer(apiKeyContext(c), "identity:"+identityKey)
 	}
+	return engine.WithGASPPeer(apiKeyContext(c), c.IP())
 }
@@ -2,7 +2,9 @@ package ports_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
@@ -30,7 +32,7 @@ func TestRequestSyncResponseHandler_InvalidCases(t *testing.T) {
 			expectations: testabilities.RequestSyncResponseProviderMockExpectations{
 				ProvideForeignSyncResponseCall: false,
 			},
-			expectedResponse: openapi.Error{Message: "The submitted request does not include required header: X-BSV-Topic."},
+			expectedResponse: ports.NewFiberErrorResponse(fiber.NewError(fiber.StatusBadRequest, "The submitted request does not include required header: X-BSV-Topic.")),
 		},
 		"Request sync response handler fails due to invalid JSON": {
 			payload: "INVALID_JSON",
@@ -62,6 +64,24 @@ func TestRequestSyncResponseHandler_InvalidCases(t *testing.T) {
 			expectedStatusCode: fiber.StatusInternalServerError,
 			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewRequestSyncResponseProviderError(testabilities.ErrTestNoopOpFailure)),
 		},
+		"Request sync response handler fails due to a busy peer": {
+			payload: testabilities.NewDefaultRequestSyncResponseBody(),
+			headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-BSV-Topic":  testabilities.DefaultTopic,
+			},
+			expectations: testabilities.RequestSyncResponseProviderMockExpectations{
+				Error:                          &engine.GASPPeerBusyError{Peer: "peer", RetryAfter: time.Second},
+				ProvideForeignSyncResponseCall: true,
+				InitialRequest: &gasp.InitialRequest{
+					Version: testabilities.DefaultVersion,
+					Since:   testabilities.DefaultSince,
+				},
+				Topic: testabilities.DefaultTopic,
+			},
+			expectedStatusCode: fiber.StatusTooManyRequests,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewGASPPeerBusyError(time.Second)),
+		},
 	}
 
 	for name, tc := range tests {
@@ -146,3 +166,44 @@ func TestRequestSyncResponseHandler_ValidCase(t *testing.T) {
 	require.Equal(t, expectedResponse, &actualResponse)
 	stub.AssertProvidersState()
 }
+
+func TestRequestSyncResponseHandler_ShouldPassLimitAndMarkPartialResponses(t *testing.T) {
+	// given:
+	limit := uint32(1)
+	response := testabilities.NewDefaultGASPInitialResponseTestHelper(t)
+	response.Partial = true
+	expectations := testabilities.RequestSyncResponseProviderMockExpectations{
+		ProvideForeignSyncResponseCall: true,
+		InitialRequest: &gasp.InitialRequest{
+			Version: testabilities.DefaultVersion,
+			Since:   testabilities.DefaultSince,
+			Limit:   limit,
+		},
+		Topic:    testabilities.DefaultTopic,
+		Response: response,
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithRequestSyncResponseProvider(testabilities.NewRequestSyncResponseProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	body := testabilities.NewDefaultRequestSyncResponseBody()
+	body.Limit = &limit
+
+	// when:
+	var actualResponse openapi.RequestSyncResResponse
+
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			"X-BSV-Topic":  testabilities.DefaultTopic,
+			"Content-Type": fiber.MIMEApplicationJSON,
+		}).
+		SetBody(body).
+		SetResult(&actualResponse).
+		Post("/api/v1/requestSyncResponse")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.NotNil(t, actualResponse.Partial)
+	require.True(t, *actualResponse.Partial)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,57 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SubmitJobHandler is a Fiber-compatible HTTP handler that processes requests polling
+// the outcome of asynchronous transaction submissions. It acts as the adapter between
+// HTTP requests and the application-layer SubmitJobService.
+type SubmitJobHandler struct {
+	service *app.SubmitJobService
+}
+
+// Handle processes an HTTP GET request for the job with the given ID.
+//
+// On success, returns 200 OK with the SubmitJob response. On failure, returns an application error.
+func (h *SubmitJobHandler) Handle(c *fiber.Ctx, jobID string) error {
+	job, err := h.service.GetSubmitJob(c.UserContext(), jobID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewSubmitJobResponse(job))
+}
+
+// NewSubmitJobHandler creates a new SubmitJobHandler with the given provider.
+// If the provider is nil, it panics.
+func NewSubmitJobHandler(provider app.SubmitJobProvider) *SubmitJobHandler {
+	return &SubmitJobHandler{service: app.NewSubmitJobService(provider, app.SubmitTopicsPolicy{}, app.AccessControlList{}, app.SubmitBEEFLimits{})}
+}
+
+// NewSubmitJobResponse converts the engine submit job into a SubmitJob object
+// compatible with the OpenAPI specification.
+func NewSubmitJobResponse(job *engine.SubmitJob) openapi.SubmitJob {
+	topics := job.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	response := openapi.SubmitJob{
+		JobId:     job.ID,
+		Status:    openapi.SubmitJobStatus(job.Status),
+		Topics:    topics,
+		CreatedAt: job.CreatedAt,
+	}
+	if job.Status == engine.SubmitJobCompleted {
+		response.STEAK = &NewSubmitTransactionSuccessResponse(&job.Steak).STEAK
+	}
+	if job.Error != "" {
+		response.Error = &job.Error
+	}
+	if !job.CompletedAt.IsZero() {
+		response.CompletedAt = &job.CompletedAt
+	}
+	return response
+}
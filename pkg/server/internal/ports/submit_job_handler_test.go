@@ -0,0 +1,107 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitJobHandler_Handle(t *testing.T) {
+	completed := &engine.SubmitJob{
+		ID:          "job-1",
+		Status:      engine.SubmitJobCompleted,
+		Topics:      []string{testabilities.DefaultValidTopic},
+		Steak:       overlay.Steak{testabilities.DefaultValidTopic: &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}}},
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CompletedAt: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+	failed := &engine.SubmitJob{
+		ID:          "job-2",
+		Status:      engine.SubmitJobFailed,
+		Topics:      []string{testabilities.DefaultValidTopic},
+		Error:       "invalid-transaction",
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CompletedAt: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		jobID            string
+		expectations     testabilities.SubmitJobProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Returns the STEAK of a completed job": {
+			jobID: "job-1",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Job:               completed,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewSubmitJobResponse(completed),
+		},
+		"Returns the error of a failed job": {
+			jobID: "job-2",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Job:               failed,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewSubmitJobResponse(failed),
+		},
+		"Responds with not found for an unknown job": {
+			jobID: "job-3",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Error:             engine.ErrSubmitJobNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitJobNotFoundError("job-3")),
+		},
+		"Responds with internal server error when the provider fails": {
+			jobID: "job-1",
+			expectations: testabilities.SubmitJobProviderMockExpectations{
+				FindSubmitJobCall: true,
+				Error:             testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitJobProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitJobProvider(
+				testabilities.NewSubmitJobProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			var actualSuccess openapi.SubmitJob
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/submit/" + tc.jobID)
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -1,6 +1,14 @@
 package ports
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/steak"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
 	"github.com/bsv-blockchain/go-sdk/overlay"
@@ -15,25 +23,138 @@ const XTopicsHeader = "x-topics"
 // It validates the request body and headers, delegates transaction submission to the service layer,
 // and returns a response formatted according to the OpenAPI specification.
 type SubmitTransactionHandler struct {
-	service *app.SubmitTransactionService
+	service      *app.SubmitTransactionService
+	jobs         *app.SubmitJobService
+	trustedToken string
+	timeout      time.Duration
+}
+
+// SubmitTransactionHandlerConfig holds the topics policy, BEEF limits and timeout enforced by the SubmitTransactionHandler.
+type SubmitTransactionHandlerConfig struct {
+	// TopicsPolicy defines the auto-added, explicit, and trusted-only submission topics.
+	TopicsPolicy app.SubmitTopicsPolicy
+
+	// TrustedBearerToken identifies trusted clients. Requests carrying it as a Bearer token
+	// may request trusted-only topics. An empty token means no client is trusted.
+	TrustedBearerToken string
+
+	// BEEFLimits bounds the size and transaction count of submitted BEEFs and enables their
+	// structural validation before they reach the provider.
+	BEEFLimits app.SubmitBEEFLimits
+
+	// Timeout bounds the time a synchronous or dry-run submission may take. Submissions exceeding it are
+	// answered with 504 Gateway Timeout. Zero leaves submissions unbounded.
+	Timeout time.Duration
 }
 
 // Handle processes an HTTP request to submit a transaction.
-// It expects the `x-topics` header to be present and valid.
-// On success, it returns HTTP 200 OK with a STEAK response (openapi.SubmitTransactionResponse).
+// It expects the `x-topics` header to be present and valid and the BEEF to satisfy the configured limits.
+// An `Idempotency-Key` header identifies the submission across client retries.
+// Topics restricted by the access control list, auto-added ones included, require a permitted API key as the Bearer token.
+// On success, it returns HTTP 200 OK with a STEAK response serialized in the version negotiated through the
+// X-STEAK-Version header (openapi.SubmitTransactionResponse by default), or in the binary serialization when
+// requested through the Accept header, or, in the async mode, HTTP 202 Accepted with the pending job
+// (openapi.SubmitJobResponse). The dry-run mode responds like the default mode with the STEAK the submission
+// would result in, without applying it. Synchronous and dry-run submissions exceeding the configured timeout
+// are answered with 504 Gateway Timeout.
 // If an error occurs during transaction submission, it returns the corresponding application error.
 func (s *SubmitTransactionHandler) Handle(c *fiber.Ctx, params openapi.SubmitTransactionParams) error {
-	steak, err := s.service.SubmitTransaction(c.UserContext(), params.XTopics, c.Body()...)
+	ctx := apiKeyContext(c)
+	if params.IdempotencyKey != nil && *params.IdempotencyKey != "" {
+		ctx = engine.WithIdempotencyKey(ctx, *params.IdempotencyKey)
+	}
+	// The topics and the BEEF outlive the request in the storage and in submit jobs, while fiber reuses
+	// the buffers of a request once it is handled.
+	topics := make([]string, len(params.XTopics))
+	for i, topic := range params.XTopics {
+		topics[i] = strings.Clone(topic)
+	}
+	beef := bytes.Clone(c.Body())
+	var requested string
+	if params.XSTEAKVersion != nil {
+		requested = *params.XSTEAKVersion
+	}
+	version, err := steak.ParseVersion(requested)
+	if err != nil {
+		return app.NewUnsupportedSTEAKVersionError(err)
+	}
+
+	if params.Mode != nil && *params.Mode == openapi.Async {
+		var callbackURL string
+		if params.CallbackUrl != nil {
+			callbackURL = *params.CallbackUrl
+		}
+		job, err := s.jobs.EnqueueSubmitTransaction(ctx, topics, s.isTrusted(c), callbackURL, beef...)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusAccepted).JSON(NewSubmitJobResponse(job))
+	}
+
+	submit := s.service.SubmitTransaction
+	if params.Mode != nil && *params.Mode == openapi.DryRun {
+		submit = s.service.EvaluateTransaction
+	}
+	ctx, cancel := app.WithSubmitTimeout(ctx, s.timeout)
+	defer cancel()
+	result, err := submit(ctx, topics, s.isTrusted(c), beef...)
 	if err != nil {
 		return err
 	}
-	return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(steak))
+	var submitted overlay.Steak
+	if result != nil {
+		submitted = *result
+	}
+	if negotiateContentType(c, fiber.MIMEApplicationJSON, steak.MIMEBinary) == steak.MIMEBinary {
+		c.Set(steak.Header, steak.Version2.String())
+		c.Set(fiber.HeaderContentType, steak.MIMEBinary)
+		return c.Status(fiber.StatusOK).Send(steak.MarshalBinary(submitted))
+	}
+	c.Set(steak.Header, version.String())
+	if version == steak.Version1 {
+		return c.Status(fiber.StatusOK).JSON(NewSubmitTransactionSuccessResponse(result))
+	}
+	body, err := steak.MarshalJSON(submitted, version)
+	if err != nil {
+		return app.NewSubmitTransactionProviderError(err)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(body)
 }
 
-// NewSubmitTransactionHandler creates a new SubmitTransactionHandler with the given provider.
-// It panics if the provider is nil.
-func NewSubmitTransactionHandler(provider app.SubmitTransactionProvider) *SubmitTransactionHandler {
-	return &SubmitTransactionHandler{service: app.NewSubmitTransactionService(provider)}
+func (s *SubmitTransactionHandler) isTrusted(c *fiber.Ctx) bool {
+	if s.trustedToken == "" {
+		return false
+	}
+	expected := "Bearer " + s.trustedToken
+	return subtle.ConstantTimeCompare([]byte(c.Get(fiber.HeaderAuthorization)), []byte(expected)) == 1
+}
+
+// bearerToken returns the token of the request's Bearer authorization header, or an empty string if there is none.
+func bearerToken(c *fiber.Ctx) string {
+	token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !found {
+		return ""
+	}
+	return token
+}
+
+// apiKeyContext returns the request context carrying the Bearer token of the request as the API key
+// checked against the access control list.
+func apiKeyContext(c *fiber.Ctx) context.Context {
+	return app.WithAPIKey(c.UserContext(), bearerToken(c))
+}
+
+// NewSubmitTransactionHandler creates a new SubmitTransactionHandler with the given providers, config,
+// and the access control list restricting the topics each API key may submit to.
+// It panics if any provider is nil.
+func NewSubmitTransactionHandler(provider app.SubmitTransactionProvider, jobs app.SubmitJobProvider, cfg SubmitTransactionHandlerConfig, access app.AccessControlList) *SubmitTransactionHandler {
+	return &SubmitTransactionHandler{
+		service:      app.NewSubmitTransactionService(provider, cfg.TopicsPolicy, access, cfg.BEEFLimits),
+		jobs:         app.NewSubmitJobService(jobs, cfg.TopicsPolicy, access, cfg.BEEFLimits),
+		trustedToken: cfg.TrustedBearerToken,
+		timeout:      cfg.Timeout,
+	}
 }
 
 // NewSubmitTransactionSuccessResponse converts the internal STEAK data structure
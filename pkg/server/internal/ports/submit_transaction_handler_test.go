@@ -3,7 +3,10 @@ package ports_test
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/steak"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
@@ -47,9 +50,9 @@ func TestSubmitTransactionHandler_InvalidCases(t *testing.T) {
 			headers: map[string]string{
 				fiber.HeaderContentType: fiber.MIMEOctetStream,
 			},
-			expectedResponse: openapi.Error{
-				Message: "The submitted request does not include required header: x-topics.",
-			},
+			expectedResponse: ports.NewFiberErrorResponse(
+				fiber.NewError(fiber.StatusBadRequest, "The submitted request does not include required header: x-topics."),
+			),
 			expectations: testabilities.SubmitTransactionProviderMockExpectations{
 				SubmitCall: false,
 			},
@@ -61,7 +64,9 @@ func TestSubmitTransactionHandler_InvalidCases(t *testing.T) {
 				fiber.HeaderContentType: fiber.MIMEOctetStream,
 				ports.XTopicsHeader:     "",
 			},
-			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewErrInvalidTopicFormatError(0)),
+			expectedResponse: ports.NewFiberErrorResponse(
+				fiber.NewError(fiber.StatusBadRequest, app.NewErrInvalidTopicFormatError(0).Slug()),
+			),
 			expectations: testabilities.SubmitTransactionProviderMockExpectations{
 				SubmitCall: false,
 			},
@@ -126,5 +131,425 @@ func TestSubmitTransactionHandler_ValidCase(t *testing.T) {
 
 	require.Equal(t, fiber.StatusOK, res.StatusCode())
 	require.Equal(t, expectedResponse, &actualResponse)
+	require.Equal(t, steak.Version1.String(), res.Header().Get(steak.Header))
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_STEAKVersions(t *testing.T) {
+	submitted := overlay.Steak{
+		"tm_b": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{1}},
+		"tm_a": &overlay.AdmittanceInstructions{CoinsRemoved: []uint32{0}},
+	}
+	expectedJSON, err := steak.MarshalJSON(submitted, steak.Version2)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		headers             map[string]string
+		expectedContentType string
+		expectedBody        []byte
+	}{
+		"version 2": {
+			headers:             map[string]string{steak.Header: "2"},
+			expectedContentType: fiber.MIMEApplicationJSON,
+			expectedBody:        expectedJSON,
+		},
+		"binary": {
+			headers:             map[string]string{fiber.HeaderAccept: steak.MIMEBinary},
+			expectedContentType: steak.MIMEBinary,
+			expectedBody:        steak.MarshalBinary(submitted),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			expectations := testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: true, STEAK: &submitted}
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(map[string]string{fiber.HeaderContentType: fiber.MIMEOctetStream, ports.XTopicsHeader: "tm_a,tm_b"}).
+				SetHeaders(tc.headers).
+				SetBody("test transaction body").
+				Post("/api/v1/submit")
+
+			// then:
+			require.Equal(t, fiber.StatusOK, res.StatusCode())
+			require.Equal(t, steak.Version2.String(), res.Header().Get(steak.Header))
+			require.Equal(t, tc.expectedContentType, res.Header().Get(fiber.HeaderContentType))
+			require.Equal(t, tc.expectedBody, res.Body())
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitTransactionHandler_DryRunMode(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		SubmitCall: true,
+		DryRun:     true,
+		STEAK: &overlay.Steak{
+			"test": &overlay.AdmittanceInstructions{OutputsToAdmit: []uint32{0}, CoinsRemoved: []uint32{1}},
+		},
+	}
+
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.SubmitTransactionResponse
+
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{fiber.HeaderContentType: fiber.MIMEOctetStream, ports.XTopicsHeader: "test"}).
+		SetQueryParam("mode", string(openapi.DryRun)).
+		SetBody("test transaction body").
+		SetResult(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewSubmitTransactionSuccessResponse(expectations.STEAK), &actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_ShouldRejectUnsupportedSTEAKVersion(t *testing.T) {
+	// given:
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, testabilities.SubmitTransactionProviderMockExpectations{})))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.Error
+
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{fiber.HeaderContentType: fiber.MIMEOctetStream, ports.XTopicsHeader: "tm_a", steak.Header: "3"}).
+		SetBody("test transaction body").
+		SetError(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	_, parseErr := steak.ParseVersion("3")
+	require.Equal(t, fiber.StatusBadRequest, res.StatusCode())
+	require.Equal(t, testabilities.NewTestOpenapiErrorResponse(t, app.NewUnsupportedSTEAKVersionError(parseErr)), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_TopicsPolicy(t *testing.T) {
+	const adminToken = "admin-token"
+	policy := server.SubmitTopicsPolicy{
+		AutoAdded:   []string{"tm_auto"},
+		TrustedOnly: []string{"tm_private"},
+	}
+
+	tests := map[string]struct {
+		headers            map[string]string
+		expectedStatusCode int
+		expectations       testabilities.SubmitTransactionProviderMockExpectations
+	}{
+		"Untrusted client requesting a trusted-only topic is rejected": {
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEOctetStream,
+				ports.XTopicsHeader:     "tm_private",
+			},
+			expectedStatusCode: fiber.StatusForbidden,
+			expectations:       testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+		},
+		"Trusted client requesting a trusted-only topic is accepted with auto-added topics": {
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEOctetStream,
+				fiber.HeaderAuthorization: "Bearer " + adminToken,
+				ports.XTopicsHeader:       "tm_private",
+			},
+			expectedStatusCode: fiber.StatusOK,
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+				Topics:     []string{"tm_private", "tm_auto"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)))
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithAdminBearerToken(adminToken),
+				server.WithSubmitTopicsPolicy(policy),
+			)
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody("test transaction body").
+				Post("/api/v1/submit")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitTransactionHandler_AccessControl(t *testing.T) {
+	acl := server.AccessControlList{
+		Topics: map[string][]string{"tm_private": {"private-key"}},
+	}
+
+	tests := map[string]struct {
+		headers            map[string]string
+		expectedStatusCode int
+		expectations       testabilities.SubmitTransactionProviderMockExpectations
+	}{
+		"Submission to a restricted topic without an API key is rejected": {
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEOctetStream,
+				ports.XTopicsHeader:     "tm_public,tm_private",
+			},
+			expectedStatusCode: fiber.StatusForbidden,
+			expectations:       testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+		},
+		"Submission to a restricted topic with another API key is rejected": {
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEOctetStream,
+				fiber.HeaderAuthorization: "Bearer other-key",
+				ports.XTopicsHeader:       "tm_private",
+			},
+			expectedStatusCode: fiber.StatusForbidden,
+			expectations:       testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false},
+		},
+		"Submission to a restricted topic with a permitted API key is accepted": {
+			headers: map[string]string{
+				fiber.HeaderContentType:   fiber.MIMEOctetStream,
+				fiber.HeaderAuthorization: "Bearer private-key",
+				ports.XTopicsHeader:       "tm_private",
+			},
+			expectedStatusCode: fiber.StatusOK,
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+				Topics:     []string{"tm_private"},
+			},
+		},
+		"Submission to an unrestricted topic without an API key is accepted": {
+			headers: map[string]string{
+				fiber.HeaderContentType: fiber.MIMEOctetStream,
+				ports.XTopicsHeader:     "tm_public",
+			},
+			expectedStatusCode: fiber.StatusOK,
+			expectations: testabilities.SubmitTransactionProviderMockExpectations{
+				SubmitCall: true,
+				STEAK:      &overlay.Steak{},
+				Topics:     []string{"tm_public"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, tc.expectations)))
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithAccessControlList(acl),
+			)
+
+			// when:
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(tc.headers).
+				SetBody("test transaction body").
+				Post("/api/v1/submit")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitTransactionHandler_StorageReadOnly(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		SubmitCall: true,
+		Error:      &engine.StorageReadOnlyError{RetryAfter: 1500 * time.Millisecond, Cause: errSubmitTxHandlerTestError},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+	expectedResponse := testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitTransactionUnavailableError(1500*time.Millisecond))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType: fiber.MIMEOctetStream,
+			ports.XTopicsHeader:     "topics1,topics2",
+		}).
+		SetBody("test transaction body").
+		SetError(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusServiceUnavailable, res.StatusCode())
+	require.Equal(t, "2", res.Header().Get(fiber.HeaderRetryAfter))
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_Saturated(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		SubmitCall: true,
+		Error:      &engine.SubmitSaturatedError{Topic: "topics1", RetryAfter: 3 * time.Second},
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+	expectedResponse := testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitTransactionSaturatedError(3*time.Second))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType: fiber.MIMEOctetStream,
+			ports.XTopicsHeader:     "topics1,topics2",
+		}).
+		SetBody("test transaction body").
+		SetError(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusTooManyRequests, res.StatusCode())
+	require.Equal(t, "3", res.Header().Get(fiber.HeaderRetryAfter))
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_Timeout(t *testing.T) {
+	// given:
+	expectations := testabilities.SubmitTransactionProviderMockExpectations{
+		SubmitCall:           true,
+		STEAK:                &overlay.Steak{},
+		TriggerCallbackAfter: time.Second,
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithSubmitTimeout(50*time.Millisecond))
+	expectedResponse := testabilities.NewTestOpenapiErrorResponse(t, app.NewSubmitTransactionTimeoutError(50*time.Millisecond))
+
+	// when:
+	var actualResponse openapi.Error
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType: fiber.MIMEOctetStream,
+			ports.XTopicsHeader:     "topics1,topics2",
+		}).
+		SetBody("test transaction body").
+		SetError(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusGatewayTimeout, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	require.Equal(t, app.SubmitTimeoutErrorCode, actualResponse.Code)
+	require.True(t, actualResponse.Retryable)
+	stub.AssertProvidersState()
+}
+
+func TestSubmitTransactionHandler_BEEFLimits(t *testing.T) {
+	txBytes := testabilities.DummyTxBEEF(t)
+
+	tests := map[string]struct {
+		limits             server.SubmitBEEFLimits
+		body               []byte
+		expectedStatusCode int
+		expectedResponse   openapi.Error
+	}{
+		"BEEF exceeding the maximum size": {
+			limits:             server.SubmitBEEFLimits{MaxBytes: len(txBytes) - 1},
+			body:               txBytes,
+			expectedStatusCode: fiber.StatusRequestEntityTooLarge,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewBEEFTooLargeError(len(txBytes), len(txBytes)-1)),
+		},
+		"Malformed BEEF": {
+			limits:             server.SubmitBEEFLimits{ValidateStructure: true},
+			body:               []byte("test transaction body"),
+			expectedStatusCode: fiber.StatusUnprocessableEntity,
+			expectedResponse:   testabilities.NewTestOpenapiErrorResponse(t, app.NewMalformedBEEFError(errSubmitTxHandlerTestError)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			expectations := testabilities.SubmitTransactionProviderMockExpectations{SubmitCall: false}
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitTransactionProvider(testabilities.NewSubmitTransactionProviderMock(t, expectations)))
+			fixture := server.NewTestFixture(t,
+				server.WithEngine(stub),
+				server.WithSubmitBEEFLimits(tc.limits),
+			)
+
+			// when:
+			var actualResponse openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeaders(map[string]string{
+					fiber.HeaderContentType: fiber.MIMEOctetStream,
+					ports.XTopicsHeader:     "topics1,topics2",
+				}).
+				SetBody(tc.body).
+				SetError(&actualResponse).
+				Post("/api/v1/submit")
+
+			// then:
+			require.Equal(t, tc.expectedStatusCode, res.StatusCode())
+			require.Equal(t, tc.expectedResponse, actualResponse)
+			require.NotNil(t, actualResponse.Code)
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestSubmitTransactionHandler_AsyncMode(t *testing.T) {
+	// given:
+	job := &engine.SubmitJob{
+		ID:        "job-1",
+		Status:    engine.SubmitJobPending,
+		Topics:    []string{"topics1", "topics2"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	expectations := testabilities.SubmitJobProviderMockExpectations{
+		EnqueueSubmitCall: true,
+		Job:               job,
+		CallbackURL:       "https://client.example/jobs",
+	}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithSubmitJobProvider(testabilities.NewSubmitJobProviderMock(t, expectations)))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	// when:
+	var actualResponse openapi.SubmitJob
+	res, _ := fixture.Client().
+		R().
+		SetHeaders(map[string]string{
+			fiber.HeaderContentType: fiber.MIMEOctetStream,
+			ports.XTopicsHeader:     "topics1,topics2",
+		}).
+		SetQueryParams(map[string]string{
+			"mode":        "async",
+			"callbackUrl": "https://client.example/jobs",
+		}).
+		SetBody("test transaction body").
+		SetResult(&actualResponse).
+		Post("/api/v1/submit")
+
+	// then:
+	require.Equal(t, fiber.StatusAccepted, res.StatusCode())
+	require.Equal(t, ports.NewSubmitJobResponse(job), actualResponse)
 	stub.AssertProvidersState()
 }
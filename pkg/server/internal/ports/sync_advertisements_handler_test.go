@@ -2,8 +2,10 @@ package ports_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
@@ -46,6 +48,36 @@ func TestSyncAdvertisementsHandler_InvalidCase(t *testing.T) {
 	stub.AssertProvidersState()
 }
 
+func TestSyncAdvertisementsHandler_BudgetExceededCase(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	budgetErr := fmt.Errorf("%w: estimated 100 satoshis, budget 10 satoshis", engine.ErrAdvertisementBudgetExceeded)
+	expectedResponse := testabilities.NewTestOpenapiErrorResponse(t, app.NewSyncAdvertisementsBudgetExceededError(budgetErr))
+	stub := testabilities.NewTestOverlayEngineStub(t,
+		testabilities.WithSyncAdvertisementsProvider(
+			testabilities.NewSyncAdvertisementsProviderMock(t, testabilities.SyncAdvertisementsProviderMockExpectations{
+				Err:                    budgetErr,
+				SyncAdvertisementsCall: true,
+			}),
+		),
+	)
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Error
+
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetError(&actualResponse).
+		Post("api/v1/admin/syncAdvertisements")
+
+	// then:
+	require.Equal(t, fiber.StatusForbidden, res.StatusCode())
+	require.Equal(t, expectedResponse, actualResponse)
+	stub.AssertProvidersState()
+}
+
 func TestSyncAdvertisementsHandler_ValidCase(t *testing.T) {
 	// given:
 	const token = "22222222-2222-2222-2222-222222222222"
@@ -0,0 +1,64 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TopicManagerRegistrationHandler is a Fiber-compatible HTTP handler that processes
+// admin requests to register and unregister topic managers of the running overlay engine.
+// It acts as the adapter between HTTP requests and the application-layer
+// TopicManagerRegistrationService.
+type TopicManagerRegistrationHandler struct {
+	service *app.TopicManagerRegistrationService
+}
+
+// HandleRegister processes an HTTP POST request to register a topic manager.
+// It expects a JSON request body matching the RegisterTopicManagerJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK. On failure, returns a request parsing or application error.
+func (h *TopicManagerRegistrationHandler) HandleRegister(c *fiber.Ctx) error {
+	var body openapi.RegisterTopicManagerJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	var syncType string
+	if body.SyncType != nil {
+		syncType = *body.SyncType
+	}
+	var peers []string
+	if body.Peers != nil {
+		peers = *body.Peers
+	}
+
+	if err := h.service.RegisterTopicManager(c.UserContext(), body.Name, syncType, peers); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTopicManagerRegistrationResponse())
+}
+
+// HandleUnregister processes an HTTP DELETE request to unregister the topic manager
+// passed as the topicManager query parameter.
+//
+// On success, returns 200 OK. On failure, returns an application error.
+func (h *TopicManagerRegistrationHandler) HandleUnregister(c *fiber.Ctx, params openapi.UnregisterTopicManagerParams) error {
+	if err := h.service.UnregisterTopicManager(c.UserContext(), params.TopicManager); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTopicManagerRegistrationResponse())
+}
+
+// NewTopicManagerRegistrationHandler creates a new TopicManagerRegistrationHandler with the given provider.
+// If the provider is nil, it panics.
+func NewTopicManagerRegistrationHandler(provider app.TopicManagerRegistrationProvider) *TopicManagerRegistrationHandler {
+	return &TopicManagerRegistrationHandler{service: app.NewTopicManagerRegistrationService(provider)}
+}
+
+// NewTopicManagerRegistrationResponse returns a new TopicManagerRegistration response.
+func NewTopicManagerRegistrationResponse() openapi.TopicManagerRegistration {
+	return openapi.TopicManagerRegistration{
+		Message: "OK",
+	}
+}
@@ -0,0 +1,140 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicManagerRegistrationHandler_Register(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.TopicManagerRegistrationProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Registers the topic manager": {
+			body: map[string]any{"name": "tm_new", "syncType": "peers", "peers": []string{"https://peer.example.com"}},
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				Name:                "tm_new",
+				SyncConfiguration: &engine.SyncConfiguration{
+					Type:  engine.SyncConfigurationPeers,
+					Peers: []string{"https://peer.example.com"},
+				},
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTopicManagerRegistrationResponse(),
+		},
+		"Rejects an unknown sync type": {
+			body:             map[string]any{"name": "tm_new", "syncType": "gossip"},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewUnknownTopicSyncTypeError("gossip")),
+		},
+		"Rejects an already registered topic manager": {
+			body: map[string]any{"name": "tm_new"},
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				AddTopicManagerCall: true,
+				Error:               engine.ErrTopicManagerAlreadyRegistered,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicManagerAlreadyRegisteredError("tm_new")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicManagerRegistrationProvider(
+				testabilities.NewTopicManagerRegistrationProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicManagerRegistration
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/topicManagers")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestTopicManagerRegistrationHandler_Unregister(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		expectations     testabilities.TopicManagerRegistrationProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Unregisters the topic manager": {
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				UnregisterTopicManagerCall: true,
+				Name:                       "tm_old",
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTopicManagerRegistrationResponse(),
+		},
+		"Responds with not found when the topic manager is not registered": {
+			expectations: testabilities.TopicManagerRegistrationProviderMockExpectations{
+				UnregisterTopicManagerCall: true,
+				Error:                      engine.ErrTopicManagerNotRegistered,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicManagerNotRegisteredError("tm_old")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicManagerRegistrationProvider(
+				testabilities.NewTopicManagerRegistrationProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicManagerRegistration
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("topicManager", "tm_old").
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Delete("/api/v1/admin/topicManagers")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
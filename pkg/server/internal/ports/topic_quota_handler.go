@@ -0,0 +1,99 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TopicQuotaHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to list, override and restore the output quotas of the overlay node's topics. It acts as the adapter
+// between HTTP requests and the application-layer TopicQuotaService.
+type TopicQuotaHandler struct {
+	service *app.TopicQuotaService
+}
+
+// HandleList processes an HTTP GET request listing the topic quotas.
+//
+// On success, returns 200 OK with the TopicQuotas response.
+func (h *TopicQuotaHandler) HandleList(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(NewTopicQuotasResponse(h.service.TopicQuotaSettings(c.UserContext())))
+}
+
+// HandleSet processes an HTTP PUT request to override the quota of a topic.
+// It expects a JSON request body matching the SetTopicQuotaJSONRequestBody OpenAPI schema;
+// omitted limits are disabled.
+//
+// On success, returns 200 OK with the TopicQuotas response. On failure, returns a request parsing or application error.
+func (h *TopicQuotaHandler) HandleSet(c *fiber.Ctx) error {
+	var body openapi.SetTopicQuotaJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	var quota engine.TopicQuota
+	if body.MaxOutputs != nil {
+		quota.MaxOutputs = *body.MaxOutputs
+	}
+	if body.MaxOutputsPerTx != nil {
+		quota.MaxOutputsPerTx = *body.MaxOutputsPerTx
+	}
+	if body.MaxSatoshis != nil {
+		quota.MaxSatoshis = *body.MaxSatoshis
+	}
+	if body.Action != nil {
+		quota.Action = engine.TopicQuotaAction(*body.Action)
+	}
+
+	settings, err := h.service.SetTopicQuota(c.UserContext(), body.Topic, quota)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTopicQuotasResponse(settings))
+}
+
+// HandleRemove processes an HTTP DELETE request to remove the quota override of the topic passed
+// as the topic query parameter.
+//
+// On success, returns 200 OK with the TopicQuotas response. On failure, returns an application error.
+func (h *TopicQuotaHandler) HandleRemove(c *fiber.Ctx, params openapi.RemoveTopicQuotaParams) error {
+	settings, err := h.service.RemoveTopicQuota(c.UserContext(), params.Topic)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTopicQuotasResponse(settings))
+}
+
+// NewTopicQuotaHandler creates a new TopicQuotaHandler with the given provider.
+// If the provider is nil, it panics.
+func NewTopicQuotaHandler(provider app.TopicQuotaProvider) *TopicQuotaHandler {
+	return &TopicQuotaHandler{service: app.NewTopicQuotaService(provider)}
+}
+
+// NewTopicQuotasResponse converts the topic quota settings into a TopicQuotas object
+// compatible with the OpenAPI specification.
+func NewTopicQuotasResponse(settings engine.TopicQuotaSettings) openapi.TopicQuotas {
+	return openapi.TopicQuotas{
+		Default:   newTopicQuotaResponse(settings.Default),
+		Topics:    newTopicQuotasByTopic(settings.Topics),
+		Overrides: newTopicQuotasByTopic(settings.Overrides),
+	}
+}
+
+func newTopicQuotasByTopic(quotas map[string]engine.TopicQuota) map[string]openapi.TopicQuota {
+	response := make(map[string]openapi.TopicQuota, len(quotas))
+	for topic, quota := range quotas {
+		response[topic] = newTopicQuotaResponse(quota)
+	}
+	return response
+}
+
+func newTopicQuotaResponse(quota engine.TopicQuota) openapi.TopicQuota {
+	return openapi.TopicQuota{
+		MaxOutputs:      quota.MaxOutputs,
+		MaxOutputsPerTx: quota.MaxOutputsPerTx,
+		MaxSatoshis:     quota.MaxSatoshis,
+		Action:          string(quota.Action),
+	}
+}
@@ -0,0 +1,149 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicQuotaHandler_Set(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	quota := engine.TopicQuota{MaxOutputsPerTx: 10, MaxSatoshis: 1000, Action: engine.TopicQuotaActionTruncate}
+	settings := engine.TopicQuotaSettings{Overrides: map[string]engine.TopicQuota{"tm_helloworld": quota}}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.TopicQuotaProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Overrides the quota": {
+			body: map[string]any{"topic": "tm_helloworld", "maxOutputsPerTx": 10, "maxSatoshis": 1000, "action": "truncate"},
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Topic:             "tm_helloworld",
+				Quota:             &quota,
+				Settings:          settings,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTopicQuotasResponse(settings),
+		},
+		"Rejects an unknown action": {
+			body:             map[string]any{"topic": "tm_helloworld", "action": "drop"},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewUnknownTopicQuotaActionError("drop")),
+		},
+		"Rejects a topic not hosted": {
+			body: map[string]any{"topic": "tm_unknown"},
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Error:             engine.ErrUnknownTopic,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicQuotaUnknownTopicError("tm_unknown")),
+		},
+		"Rejects overrides when the quotas are not configured": {
+			body: map[string]any{"topic": "tm_helloworld"},
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				SetTopicQuotaCall: true,
+				Error:             engine.ErrTopicQuotasNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicQuotasUnsupportedError(engine.ErrTopicQuotasNotConfigured)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicQuotaProvider(
+				testabilities.NewTopicQuotaProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicQuotas
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Put("/api/v1/admin/topicQuotas")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestTopicQuotaHandler_Remove(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		expectations     testabilities.TopicQuotaProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Removes the override": {
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				RemoveTopicQuotaCall: true,
+				Topic:                "tm_helloworld",
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTopicQuotasResponse(engine.TopicQuotaSettings{}),
+		},
+		"Rejects a topic without override": {
+			expectations: testabilities.TopicQuotaProviderMockExpectations{
+				RemoveTopicQuotaCall: true,
+				Error:                engine.ErrTopicQuotaNotOverridden,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicQuotaNotOverriddenError("tm_helloworld")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicQuotaProvider(
+				testabilities.NewTopicQuotaProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicQuotas
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("topic", "tm_helloworld").
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Delete("/api/v1/admin/topicQuotas")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
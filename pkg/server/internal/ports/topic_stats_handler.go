@@ -0,0 +1,57 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TopicStatsHandler is a Fiber-compatible HTTP handler that processes admin requests
+// for the statistics of the hosted topics. It acts as the adapter between HTTP requests
+// and the application-layer TopicStatsService.
+type TopicStatsHandler struct {
+	service *app.TopicStatsService
+}
+
+// Handle processes an HTTP GET request for the statistics of the hosted topics.
+//
+// On success, returns 200 OK with the TopicStats response. On failure, returns an application error.
+func (h *TopicStatsHandler) Handle(c *fiber.Ctx) error {
+	stats, err := h.service.TopicStats(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTopicStatsResponse(stats))
+}
+
+// NewTopicStatsHandler creates a new TopicStatsHandler with the given provider.
+// If the provider is nil, it panics.
+func NewTopicStatsHandler(provider app.TopicStatsProvider) *TopicStatsHandler {
+	return &TopicStatsHandler{service: app.NewTopicStatsService(provider)}
+}
+
+// NewTopicStatsResponse converts the engine overlay statistics into a TopicStats object
+// compatible with the OpenAPI specification.
+func NewTopicStatsResponse(stats *engine.OverlayStats) openapi.TopicStats {
+	response := openapi.TopicStats{Topics: make([]openapi.TopicStatsEntry, 0, len(stats.Topics))}
+	for _, topic := range stats.Topics {
+		interactions := topic.PeerInteractions
+		if interactions == nil {
+			interactions = map[string]float64{}
+		}
+		response.Topics = append(response.Topics, openapi.TopicStatsEntry{
+			Topic:            topic.Topic,
+			TotalOutputs:     topic.Outputs.Total,
+			UnspentOutputs:   topic.Outputs.Unspent,
+			SpentOutputs:     topic.Outputs.Spent,
+			UnminedOutputs:   topic.Outputs.Unmined,
+			PeerInteractions: interactions,
+		})
+	}
+	if !stats.LastAdvertisementSync.IsZero() {
+		lastSync := stats.LastAdvertisementSync
+		response.LastAdvertisementSync = &lastSync
+	}
+	return response
+}
@@ -0,0 +1,99 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicStatsHandler_Handle(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	stats := &engine.OverlayStats{
+		Topics: []*engine.TopicStats{
+			{
+				Topic:            testabilities.DefaultValidTopic,
+				Outputs:          engine.TopicOutputCounts{Total: 4, Unspent: 3, Spent: 1, Unmined: 2},
+				PeerInteractions: map[string]float64{"https://peer.example": 1700000000},
+			},
+			{Topic: "tm_idle"},
+		},
+		LastAdvertisementSync: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	neverSynced := &engine.OverlayStats{Topics: []*engine.TopicStats{}}
+
+	tests := map[string]struct {
+		expectations     testabilities.TopicStatsProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Reports the statistics of every hosted topic": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Stats:          stats,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTopicStatsResponse(stats),
+		},
+		"Omits the advertisement sync time when unknown": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Stats:          neverSynced,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: openapi.TopicStats{Topics: []openapi.TopicStatsEntry{}},
+		},
+		"Responds with not found when the storage does not support topic statistics": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Error:          engine.ErrTopicStatsNotSupported,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicStatsNotSupportedError(engine.ErrTopicStatsNotSupported)),
+		},
+		"Responds with internal server error when the provider fails": {
+			expectations: testabilities.TopicStatsProviderMockExpectations{
+				TopicStatsCall: true,
+				Error:          testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicStatsProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicStatsProvider(
+				testabilities.NewTopicStatsProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicStats
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/admin/stats")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TopicSyncJobHandler is a Fiber-compatible HTTP handler that processes admin requests
+// starting one-off syncs of single topics and polling their jobs. It acts as the adapter
+// between HTTP requests and the application-layer TopicSyncJobService.
+type TopicSyncJobHandler struct {
+	service *app.TopicSyncJobService
+}
+
+// HandleSync processes an HTTP POST request to sync a single topic.
+// It expects a JSON request body matching the SyncTopicJSONRequestBody OpenAPI schema.
+//
+// On success, returns 202 Accepted with the running TopicSyncJob. On failure, returns a request parsing or application error.
+func (h *TopicSyncJobHandler) HandleSync(c *fiber.Ctx) error {
+	var body openapi.SyncTopicJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	request := engine.TopicSyncRequest{Topic: body.Topic, Since: body.Since}
+	if body.Peers != nil {
+		request.Peers = *body.Peers
+	}
+	job, err := h.service.SyncTopic(c.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(NewTopicSyncJobResponse(job))
+}
+
+// HandleGet processes an HTTP GET request for the topic sync job with the given ID.
+//
+// On success, returns 200 OK with the TopicSyncJob response. On failure, returns an application error.
+func (h *TopicSyncJobHandler) HandleGet(c *fiber.Ctx, jobID string) error {
+	job, err := h.service.GetTopicSyncJob(c.UserContext(), jobID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTopicSyncJobResponse(job))
+}
+
+// NewTopicSyncJobHandler creates a new TopicSyncJobHandler with the given provider.
+// If the provider is nil, it panics.
+func NewTopicSyncJobHandler(provider app.TopicSyncJobProvider) *TopicSyncJobHandler {
+	return &TopicSyncJobHandler{service: app.NewTopicSyncJobService(provider)}
+}
+
+// NewTopicSyncJobResponse converts the engine topic sync job into a TopicSyncJob object
+// compatible with the OpenAPI specification.
+func NewTopicSyncJobResponse(job *engine.TopicSyncJob) openapi.TopicSyncJob {
+	response := openapi.TopicSyncJob{
+		JobId:          job.ID,
+		Topic:          job.Topic,
+		Status:         job.Status,
+		SucceededPeers: []string{},
+		FailedPeers:    []openapi.PeerSyncFailure{},
+		CreatedAt:      job.CreatedAt,
+	}
+	if job.Report != nil {
+		response.SucceededPeers = append(response.SucceededPeers, job.Report.Succeeded...)
+		for _, failure := range job.Report.Failed {
+			response.FailedPeers = append(response.FailedPeers, openapi.PeerSyncFailure{Peer: failure.Peer, Error: failure.Err.Error()})
+		}
+		response.IngestedGraphs = job.Report.IngestedGraphs
+	}
+	if job.Error != "" {
+		response.Error = &job.Error
+	}
+	if !job.CompletedAt.IsZero() {
+		response.CompletedAt = &job.CompletedAt
+	}
+	return response
+}
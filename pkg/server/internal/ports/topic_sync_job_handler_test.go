@@ -0,0 +1,172 @@
+package ports_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicSyncJobHandler_HandleSync(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	since := 42.0
+	running := &engine.TopicSyncJob{
+		ID:        "job-1",
+		Topic:     testabilities.DefaultValidTopic,
+		Peers:     []string{"https://peer.example"},
+		Since:     &since,
+		Status:    engine.TopicSyncJobRunning,
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.TopicSyncJobProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Starts the sync of the topic with the requested peers from the requested score": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic, "peers": []string{"https://peer.example"}, "since": since},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Request:       &engine.TopicSyncRequest{Topic: testabilities.DefaultValidTopic, Peers: []string{"https://peer.example"}, Since: &since},
+				Job:           running,
+			},
+			expectedStatus:   fiber.StatusAccepted,
+			expectedResponse: ports.NewTopicSyncJobResponse(running),
+		},
+		"Rejects an empty topic": {
+			body:             map[string]any{"topic": ""},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("topic")),
+		},
+		"Rejects a topic not hosted by the node": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Error:         engine.ErrUnknownTopic,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicSyncUnknownTopicError(testabilities.DefaultValidTopic)),
+		},
+		"Responds with service unavailable while the engine stops": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				SyncTopicCall: true,
+				Error:         engine.ErrEngineStopping,
+			},
+			expectedStatus:   fiber.StatusServiceUnavailable,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicSyncUnavailableError(0)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicSyncJobProvider(
+				testabilities.NewTopicSyncJobProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicSyncJob
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/syncTopic")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusAccepted {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestTopicSyncJobHandler_HandleGet(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	completed := &engine.TopicSyncJob{
+		ID:     "job-1",
+		Topic:  testabilities.DefaultValidTopic,
+		Status: engine.TopicSyncJobCompleted,
+		Report: &engine.TopicSyncReport{
+			Topic:          testabilities.DefaultValidTopic,
+			Succeeded:      []string{"https://healthy.example"},
+			Failed:         []engine.PeerSyncFailure{{Peer: "https://failing.example", Err: errors.New("peer unavailable")}},
+			IngestedGraphs: 3,
+		},
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CompletedAt: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		jobID            string
+		expectations     testabilities.TopicSyncJobProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Returns the report of a completed job": {
+			jobID: "job-1",
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				FindTopicSyncJobCall: true,
+				Job:                  completed,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTopicSyncJobResponse(completed),
+		},
+		"Responds with not found for an unknown job": {
+			jobID: "job-2",
+			expectations: testabilities.TopicSyncJobProviderMockExpectations{
+				FindTopicSyncJobCall: true,
+				Error:                engine.ErrTopicSyncJobNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicSyncJobNotFoundError("job-2")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicSyncJobProvider(
+				testabilities.NewTopicSyncJobProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.TopicSyncJob
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Get("/api/v1/admin/syncTopic/" + tc.jobID)
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TopicSyncPauseHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to pause and resume the GASP sync and advertisement of topics. It acts as the adapter
+// between HTTP requests and the application-layer TopicSyncPauseService.
+type TopicSyncPauseHandler struct {
+	service *app.TopicSyncPauseService
+}
+
+// HandleList processes an HTTP GET request listing the paused topics.
+//
+// On success, returns 200 OK with the PausedSyncTopics response.
+func (h *TopicSyncPauseHandler) HandleList(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(NewPausedSyncTopicsResponse(h.service.PausedSyncTopics(c.UserContext())))
+}
+
+// HandlePause processes an HTTP POST request to pause the sync of a topic.
+// It expects a JSON request body matching the PauseTopicSyncJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK with the PausedSyncTopics response. On failure, returns a request parsing or application error.
+func (h *TopicSyncPauseHandler) HandlePause(c *fiber.Ctx) error {
+	var body openapi.PauseTopicSyncJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	paused, err := h.service.PauseTopicSync(c.UserContext(), body.Topic)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewPausedSyncTopicsResponse(paused))
+}
+
+// HandleResume processes an HTTP DELETE request to resume the sync of the topic passed as the topic query parameter.
+//
+// On success, returns 200 OK with the PausedSyncTopics response. On failure, returns an application error.
+func (h *TopicSyncPauseHandler) HandleResume(c *fiber.Ctx, params openapi.ResumeTopicSyncParams) error {
+	paused, err := h.service.ResumeTopicSync(c.UserContext(), params.Topic)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewPausedSyncTopicsResponse(paused))
+}
+
+// NewTopicSyncPauseHandler creates a new TopicSyncPauseHandler with the given provider.
+// If the provider is nil, it panics.
+func NewTopicSyncPauseHandler(provider app.TopicSyncPauseProvider) *TopicSyncPauseHandler {
+	return &TopicSyncPauseHandler{service: app.NewTopicSyncPauseService(provider)}
+}
+
+// NewPausedSyncTopicsResponse converts the paused topics into a PausedSyncTopics object
+// compatible with the OpenAPI specification.
+func NewPausedSyncTopicsResponse(topics []string) openapi.PausedSyncTopics {
+	if topics == nil {
+		topics = []string{}
+	}
+	return openapi.PausedSyncTopics{Topics: topics}
+}
@@ -0,0 +1,130 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicSyncPauseHandler_Pause(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.TopicSyncPauseProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Pauses the sync of the topic": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncPauseProviderMockExpectations{
+				PauseTopicSyncCall: true,
+				Topic:              testabilities.DefaultValidTopic,
+				PausedTopics:       []string{testabilities.DefaultValidTopic},
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewPausedSyncTopicsResponse([]string{testabilities.DefaultValidTopic}),
+		},
+		"Rejects an empty topic": {
+			body:             map[string]any{"topic": ""},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("topic")),
+		},
+		"Rejects a topic not hosted by the node": {
+			body: map[string]any{"topic": testabilities.DefaultValidTopic},
+			expectations: testabilities.TopicSyncPauseProviderMockExpectations{
+				PauseTopicSyncCall: true,
+				Error:              engine.ErrUnknownTopic,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTopicSyncPauseUnknownTopicError(testabilities.DefaultValidTopic)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicSyncPauseProvider(
+				testabilities.NewTopicSyncPauseProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.PausedSyncTopics
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/pausedSyncTopics")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestTopicSyncPauseHandler_Resume(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicSyncPauseProvider(
+		testabilities.NewTopicSyncPauseProviderMock(t, testabilities.TopicSyncPauseProviderMockExpectations{
+			ResumeTopicSyncCall: true,
+			Topic:               testabilities.DefaultValidTopic,
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.PausedSyncTopics
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetQueryParam("topic", testabilities.DefaultValidTopic).
+		SetResult(&actualResponse).
+		Delete("/api/v1/admin/pausedSyncTopics")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewPausedSyncTopicsResponse(nil), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestTopicSyncPauseHandler_List(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTopicSyncPauseProvider(
+		testabilities.NewTopicSyncPauseProviderMock(t, testabilities.TopicSyncPauseProviderMockExpectations{
+			PausedTopics: []string{testabilities.DefaultValidTopic},
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.PausedSyncTopics
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/pausedSyncTopics")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewPausedSyncTopicsResponse([]string{testabilities.DefaultValidTopic}), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,69 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrackerHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to list, add and remove the SHIP and SLAP trackers of the overlay node. It acts as the adapter
+// between HTTP requests and the application-layer TrackerService.
+type TrackerHandler struct {
+	service *app.TrackerService
+}
+
+// HandleList processes an HTTP GET request listing the trackers.
+//
+// On success, returns 200 OK with the Trackers response.
+func (h *TrackerHandler) HandleList(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(NewTrackersResponse(h.service.Trackers(c.UserContext())))
+}
+
+// HandleAdd processes an HTTP POST request to add a tracker.
+// It expects a JSON request body matching the AddTrackerJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK with the Trackers response. On failure, returns a request parsing or application error.
+func (h *TrackerHandler) HandleAdd(c *fiber.Ctx) error {
+	var body openapi.AddTrackerJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	trackers, err := h.service.AddTracker(c.UserContext(), body.Kind, body.Url)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTrackersResponse(trackers))
+}
+
+// HandleRemove processes an HTTP DELETE request to remove the tracker passed as the kind and url query parameters.
+//
+// On success, returns 200 OK with the Trackers response. On failure, returns an application error.
+func (h *TrackerHandler) HandleRemove(c *fiber.Ctx, params openapi.RemoveTrackerParams) error {
+	trackers, err := h.service.RemoveTracker(c.UserContext(), params.Kind, params.Url)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewTrackersResponse(trackers))
+}
+
+// NewTrackerHandler creates a new TrackerHandler with the given provider.
+// If the provider is nil, it panics.
+func NewTrackerHandler(provider app.TrackerProvider) *TrackerHandler {
+	return &TrackerHandler{service: app.NewTrackerService(provider)}
+}
+
+// NewTrackersResponse converts the trackers into a Trackers object
+// compatible with the OpenAPI specification.
+func NewTrackersResponse(trackers engine.Trackers) openapi.Trackers {
+	response := openapi.Trackers{Ship: trackers.SHIP, Slap: trackers.SLAP}
+	if response.Ship == nil {
+		response.Ship = []string{}
+	}
+	if response.Slap == nil {
+		response.Slap = []string{}
+	}
+	return response
+}
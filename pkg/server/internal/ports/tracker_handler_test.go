@@ -0,0 +1,165 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerHandler_Add(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	const tracker = "https://tracker.example.com"
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.TrackerProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Adds the tracker": {
+			body: map[string]any{"kind": "slap", "url": tracker},
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Kind:           engine.TrackerKindSLAP,
+				Tracker:        tracker,
+				Trackers:       engine.Trackers{SLAP: []string{tracker}},
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTrackersResponse(engine.Trackers{SLAP: []string{tracker}}),
+		},
+		"Rejects an unknown kind": {
+			body:             map[string]any{"kind": "gasp", "url": tracker},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewUnknownTrackerKindError("gasp")),
+		},
+		"Rejects a tracker already configured": {
+			body: map[string]any{"kind": "ship", "url": tracker},
+			expectations: testabilities.TrackerProviderMockExpectations{
+				AddTrackerCall: true,
+				Error:          engine.ErrTrackerAlreadyConfigured,
+			},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTrackerAlreadyConfiguredError(engine.TrackerKindSHIP, tracker)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTrackerProvider(
+				testabilities.NewTrackerProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.Trackers
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/trackers")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestTrackerHandler_Remove(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	const tracker = "https://tracker.example.com"
+
+	tests := map[string]struct {
+		expectations     testabilities.TrackerProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Removes the tracker": {
+			expectations: testabilities.TrackerProviderMockExpectations{
+				RemoveTrackerCall: true,
+				Kind:              engine.TrackerKindSHIP,
+				Tracker:           tracker,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewTrackersResponse(engine.Trackers{}),
+		},
+		"Rejects a tracker not configured": {
+			expectations: testabilities.TrackerProviderMockExpectations{
+				RemoveTrackerCall: true,
+				Error:             engine.ErrTrackerNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewTrackerNotConfiguredError(engine.TrackerKindSHIP, tracker)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTrackerProvider(
+				testabilities.NewTrackerProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.Trackers
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("kind", "ship").
+				SetQueryParam("url", tracker).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Delete("/api/v1/admin/trackers")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestTrackerHandler_List(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	trackers := engine.Trackers{SHIP: []string{"https://ship.example.com"}, SLAP: []string{"https://slap.example.com"}}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithTrackerProvider(
+		testabilities.NewTrackerProviderMock(t, testabilities.TrackerProviderMockExpectations{Trackers: trackers}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Trackers
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/trackers")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewTrackersResponse(trackers), actualResponse)
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UTXOHistoryHandler is a Fiber-compatible HTTP handler that processes requests for the
+// history of an output admitted to a topic. It acts as the adapter between HTTP requests
+// and the application-layer UTXOHistoryService.
+type UTXOHistoryHandler struct {
+	service *app.UTXOHistoryService
+}
+
+// Handle processes an HTTP POST request for the history of an output.
+// It expects a JSON body matching the UTXOHistoryBody OpenAPI definition.
+//
+// Requested with an Accept header of application/octet-stream, the hydrated BEEF is returned as the raw
+// body rather than base64 encoded in JSON.
+//
+// On success, returns 200 OK with the UTXOHistory response. On failure, returns either
+// a request parsing error or an application error.
+func (h *UTXOHistoryHandler) Handle(c *fiber.Ctx) error {
+	var body openapi.UTXOHistoryBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	output, err := h.service.FindUTXOHistory(apiKeyContext(c), body.Txid, body.OutputIndex, body.Topic, body.Depth)
+	if err != nil {
+		return err
+	}
+	if negotiateContentType(c, fiber.MIMEApplicationJSON, fiber.MIMEOctetStream) == fiber.MIMEOctetStream {
+		c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+		return c.Status(fiber.StatusOK).Send(output.Beef)
+	}
+	return c.Status(fiber.StatusOK).JSON(NewUTXOHistoryResponse(output))
+}
+
+// NewUTXOHistoryHandler creates a new UTXOHistoryHandler with the given provider and the access control list
+// restricting the topics each API key may access.
+// If the provider is nil, it panics.
+func NewUTXOHistoryHandler(provider app.UTXOHistoryProvider, access app.AccessControlList) *UTXOHistoryHandler {
+	return &UTXOHistoryHandler{service: app.NewUTXOHistoryService(provider, access)}
+}
+
+// NewUTXOHistoryResponse converts the hydrated engine output into a UTXOHistory object
+// compatible with the OpenAPI specification.
+func NewUTXOHistoryResponse(output *engine.Output) openapi.UTXOHistory {
+	return openapi.UTXOHistory{
+		Txid:        output.Outpoint.Txid.String(),
+		OutputIndex: output.Outpoint.Index,
+		Topic:       output.Topic,
+		Beef:        output.Beef,
+	}
+}
@@ -0,0 +1,144 @@
+package ports_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTXOHistoryHandler_Handle(t *testing.T) {
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := transaction.Outpoint{Txid: *txid, Index: testabilities.DefaultValidOutputIndex}
+	output := &engine.Output{Outpoint: outpoint, Topic: testabilities.DefaultValidTopic, Beef: []byte{0x01, 0x02}}
+	sizeErr := fmt.Errorf("%w: more than 1024 bytes", engine.ErrUTXOHistoryTooLarge)
+	body := openapi.UTXOHistoryJSONRequestBody{
+		Txid:        testabilities.DefaultValidTxID,
+		OutputIndex: testabilities.DefaultValidOutputIndex,
+		Topic:       testabilities.DefaultValidTopic,
+	}
+
+	tests := map[string]struct {
+		payload          any
+		expectations     testabilities.UTXOHistoryProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Returns the hydrated history of the output": {
+			payload: body,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Query:               &engine.UTXOHistoryQuery{Outpoint: outpoint, Topic: testabilities.DefaultValidTopic},
+				Output:              output,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewUTXOHistoryResponse(output),
+		},
+		"Responds with bad request when the transaction ID is invalid": {
+			payload:          openapi.UTXOHistoryJSONRequestBody{Txid: "invalid", Topic: testabilities.DefaultValidTopic},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewInvalidTxIDFormatError(chainhash.ErrHashStrSize)),
+		},
+		"Responds with not found when the output is not in the topic": {
+			payload: body,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               engine.ErrUnableToFindOutput,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewUTXOHistoryOutputNotFoundError(outpoint.String(), testabilities.DefaultValidTopic)),
+		},
+		"Responds with forbidden when the history exceeds the size limit": {
+			payload: body,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               sizeErr,
+			},
+			expectedStatus:   fiber.StatusForbidden,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewUTXOHistoryTooLargeError(sizeErr)),
+		},
+		"Responds with internal server error when the provider fails": {
+			payload: body,
+			expectations: testabilities.UTXOHistoryProviderMockExpectations{
+				FindUTXOHistoryCall: true,
+				Error:               testabilities.ErrTestNoopOpFailure,
+			},
+			expectedStatus:   fiber.StatusInternalServerError,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewUTXOHistoryProviderError(testabilities.ErrTestNoopOpFailure)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithUTXOHistoryProvider(
+				testabilities.NewUTXOHistoryProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+			// when:
+			var actualSuccess openapi.UTXOHistory
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(tc.payload).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/history")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestUTXOHistoryHandler_ShouldReturnRawBEEF_WhenOctetStreamIsAccepted(t *testing.T) {
+	// given:
+	txid, err := chainhash.NewHashFromHex(testabilities.DefaultValidTxID)
+	require.NoError(t, err)
+	outpoint := transaction.Outpoint{Txid: *txid, Index: testabilities.DefaultValidOutputIndex}
+	beef := []byte{0x01, 0x02, 0x03}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithUTXOHistoryProvider(
+		testabilities.NewUTXOHistoryProviderMock(t, testabilities.UTXOHistoryProviderMockExpectations{
+			FindUTXOHistoryCall: true,
+			Query:               &engine.UTXOHistoryQuery{Outpoint: outpoint, Topic: testabilities.DefaultValidTopic},
+			Output:              &engine.Output{Outpoint: outpoint, Topic: testabilities.DefaultValidTopic, Beef: beef},
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub))
+
+	// when:
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		SetHeader(fiber.HeaderAccept, fiber.MIMEOctetStream).
+		SetBody(openapi.UTXOHistoryJSONRequestBody{
+			Txid:        testabilities.DefaultValidTxID,
+			OutputIndex: testabilities.DefaultValidOutputIndex,
+			Topic:       testabilities.DefaultValidTopic,
+		}).
+		Post("/api/v1/history")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, fiber.MIMEOctetStream, res.Header().Get(fiber.HeaderContentType))
+	require.Equal(t, beef, res.Body())
+	stub.AssertProvidersState()
+}
@@ -0,0 +1,111 @@
+package ports
+
+import (
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookHandler is a Fiber-compatible HTTP handler that processes admin requests
+// to manage the webhook subscriptions notified with the STEAK of submissions.
+// It acts as the adapter between HTTP requests and the application-layer WebhookService.
+type WebhookHandler struct {
+	service *app.WebhookService
+}
+
+// HandleList processes an HTTP GET request listing the webhook subscriptions.
+//
+// On success, returns 200 OK with the Webhooks response. On failure, returns an application error.
+func (h *WebhookHandler) HandleList(c *fiber.Ctx) error {
+	subscriptions, err := h.service.ListWebhooks(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewWebhooksResponse(subscriptions))
+}
+
+// HandleRegister processes an HTTP POST request to register a webhook subscription.
+// It expects a JSON request body matching the RegisterWebhookJSONRequestBody OpenAPI schema.
+//
+// On success, returns 200 OK with the registered subscription, including its secret.
+// On failure, returns a request parsing or application error.
+func (h *WebhookHandler) HandleRegister(c *fiber.Ctx) error {
+	var body openapi.RegisterWebhookJSONRequestBody
+	if err := c.BodyParser(&body); err != nil {
+		return NewRequestBodyParserError(err)
+	}
+
+	var topics []string
+	if body.Topics != nil {
+		topics = *body.Topics
+	}
+	var secret string
+	if body.Secret != nil {
+		secret = *body.Secret
+	}
+
+	subscription, err := h.service.RegisterWebhook(c.UserContext(), body.Url, topics, secret)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewWebhookRegistrationResponse(subscription))
+}
+
+// HandleUnregister processes an HTTP DELETE request to unregister the webhook subscription
+// passed as the id query parameter.
+//
+// On success, returns 200 OK. On failure, returns an application error.
+func (h *WebhookHandler) HandleUnregister(c *fiber.Ctx, params openapi.UnregisterWebhookParams) error {
+	if err := h.service.UnregisterWebhook(c.UserContext(), params.Id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(NewWebhookUnregistrationResponse())
+}
+
+// NewWebhookHandler creates a new WebhookHandler with the given provider.
+// If the provider is nil, it panics.
+func NewWebhookHandler(provider app.WebhookProvider) *WebhookHandler {
+	return &WebhookHandler{service: app.NewWebhookService(provider)}
+}
+
+// NewWebhooksResponse converts engine webhook subscriptions into a Webhooks object
+// compatible with the OpenAPI specification. Secrets are never listed.
+func NewWebhooksResponse(subscriptions []*engine.WebhookSubscription) openapi.Webhooks {
+	response := openapi.Webhooks{Webhooks: make([]openapi.Webhook, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		response.Webhooks = append(response.Webhooks, openapi.Webhook{
+			Id:        subscription.ID,
+			Url:       subscription.URL,
+			Topics:    webhookTopics(subscription.Topics),
+			CreatedAt: subscription.CreatedAt,
+		})
+	}
+	return response
+}
+
+// NewWebhookRegistrationResponse converts a registered engine webhook subscription into a
+// WebhookRegistration object compatible with the OpenAPI specification.
+func NewWebhookRegistrationResponse(subscription *engine.WebhookSubscription) openapi.WebhookRegistration {
+	return openapi.WebhookRegistration{
+		Id:        subscription.ID,
+		Url:       subscription.URL,
+		Topics:    webhookTopics(subscription.Topics),
+		Secret:    subscription.Secret,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+// NewWebhookUnregistrationResponse returns a new WebhookUnregistration response.
+func NewWebhookUnregistrationResponse() openapi.WebhookUnregistration {
+	return openapi.WebhookUnregistration{
+		Message: "OK",
+	}
+}
+
+func webhookTopics(topics []string) []string {
+	if topics == nil {
+		return []string{}
+	}
+	return topics
+}
@@ -0,0 +1,178 @@
+package ports_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/testabilities"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHandler_List(t *testing.T) {
+	// given:
+	const token = "22222222-2222-2222-2222-222222222222"
+	subscriptions := []*engine.WebhookSubscription{{
+		ID:        "id",
+		URL:       "https://example.com/steak",
+		Topics:    []string{testabilities.DefaultValidTopic},
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+	stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithWebhookProvider(
+		testabilities.NewWebhookProviderMock(t, testabilities.WebhookProviderMockExpectations{
+			ListWebhooksCall: true,
+			Subscriptions:    subscriptions,
+		}),
+	))
+	fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+	// when:
+	var actualResponse openapi.Webhooks
+	res, _ := fixture.Client().
+		R().
+		SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+		SetResult(&actualResponse).
+		Get("/api/v1/admin/webhooks")
+
+	// then:
+	require.Equal(t, fiber.StatusOK, res.StatusCode())
+	require.Equal(t, ports.NewWebhooksResponse(subscriptions), actualResponse)
+	stub.AssertProvidersState()
+}
+
+func TestWebhookHandler_Register(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+	const callbackURL = "https://example.com/steak"
+	subscription := &engine.WebhookSubscription{
+		ID:        "id",
+		URL:       callbackURL,
+		Topics:    []string{testabilities.DefaultValidTopic},
+		Secret:    "secret",
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		body             map[string]any
+		expectations     testabilities.WebhookProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Registers the webhook": {
+			body: map[string]any{"url": callbackURL, "topics": []string{testabilities.DefaultValidTopic}, "secret": "secret"},
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				URL:                 callbackURL,
+				Topics:              []string{testabilities.DefaultValidTopic},
+				Subscription:        subscription,
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewWebhookRegistrationResponse(subscription),
+		},
+		"Rejects an empty URL": {
+			body:             map[string]any{"url": ""},
+			expectedStatus:   fiber.StatusBadRequest,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewIncorrectInputWithFieldError("url")),
+		},
+		"Responds with not found when webhooks are not configured": {
+			body: map[string]any{"url": callbackURL},
+			expectations: testabilities.WebhookProviderMockExpectations{
+				RegisterWebhookCall: true,
+				Error:               engine.ErrWebhooksNotConfigured,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewWebhooksNotConfiguredError()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithWebhookProvider(
+				testabilities.NewWebhookProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.WebhookRegistration
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetBody(tc.body).
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Post("/api/v1/admin/webhooks")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
+
+func TestWebhookHandler_Unregister(t *testing.T) {
+	const token = "22222222-2222-2222-2222-222222222222"
+
+	tests := map[string]struct {
+		expectations     testabilities.WebhookProviderMockExpectations
+		expectedStatus   int
+		expectedResponse any
+	}{
+		"Unregisters the webhook": {
+			expectations: testabilities.WebhookProviderMockExpectations{
+				UnregisterWebhookCall: true,
+				ID:                    "id",
+			},
+			expectedStatus:   fiber.StatusOK,
+			expectedResponse: ports.NewWebhookUnregistrationResponse(),
+		},
+		"Responds with not found when the webhook does not exist": {
+			expectations: testabilities.WebhookProviderMockExpectations{
+				UnregisterWebhookCall: true,
+				Error:                 engine.ErrWebhookNotFound,
+			},
+			expectedStatus:   fiber.StatusNotFound,
+			expectedResponse: testabilities.NewTestOpenapiErrorResponse(t, app.NewWebhookNotFoundError("id")),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			stub := testabilities.NewTestOverlayEngineStub(t, testabilities.WithWebhookProvider(
+				testabilities.NewWebhookProviderMock(t, tc.expectations),
+			))
+			fixture := server.NewTestFixture(t, server.WithEngine(stub), server.WithAdminBearerToken(token))
+
+			// when:
+			var actualSuccess openapi.WebhookUnregistration
+			var actualError openapi.Error
+			res, _ := fixture.Client().
+				R().
+				SetHeader(fiber.HeaderAuthorization, "Bearer "+token).
+				SetQueryParam("id", "id").
+				SetResult(&actualSuccess).
+				SetError(&actualError).
+				Delete("/api/v1/admin/webhooks")
+
+			// then:
+			require.Equal(t, tc.expectedStatus, res.StatusCode())
+			if tc.expectedStatus == fiber.StatusOK {
+				require.Equal(t, tc.expectedResponse, actualSuccess)
+			} else {
+				require.Equal(t, tc.expectedResponse, actualError)
+			}
+			stub.AssertProvidersState()
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// AdvertisementPlanProviderMockExpectations defines the expected behavior of the AdvertisementPlanProviderMock during a test.
+type AdvertisementPlanProviderMockExpectations struct {
+	// Error is the error to return from PlanAdvertisements.
+	Error error
+
+	// Plan is the advertisement plan to return from PlanAdvertisements.
+	Plan *engine.AdvertisementPlan
+
+	// PlanAdvertisementsCall indicates whether the PlanAdvertisements method is expected to be called during the test.
+	PlanAdvertisementsCall bool
+}
+
+// AdvertisementPlanProviderMock is a mock implementation of an advertisement plan provider,
+// used for testing the behavior of components that preview advertisement sync runs.
+type AdvertisementPlanProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations AdvertisementPlanProviderMockExpectations
+
+	// called is true if the PlanAdvertisements method was called.
+	called bool
+}
+
+// PlanAdvertisements simulates previewing an advertisement sync run. It records the call
+// and returns the predefined plan or error.
+func (m *AdvertisementPlanProviderMock) PlanAdvertisements(context.Context) (*engine.AdvertisementPlan, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Plan, nil
+}
+
+// AssertCalled verifies that the PlanAdvertisements method was called if it was expected to be.
+func (m *AdvertisementPlanProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.PlanAdvertisementsCall, m.called, "Discrepancy between expected and actual PlanAdvertisements call")
+}
+
+// NewAdvertisementPlanProviderMock creates a new instance of AdvertisementPlanProviderMock with the given expectations.
+func NewAdvertisementPlanProviderMock(t *testing.T, expectations AdvertisementPlanProviderMockExpectations) *AdvertisementPlanProviderMock {
+	return &AdvertisementPlanProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
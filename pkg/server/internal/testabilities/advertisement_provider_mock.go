@@ -0,0 +1,120 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// AdvertisementProviderMockExpectations defines the expected behavior of the AdvertisementProviderMock during a test.
+type AdvertisementProviderMockExpectations struct {
+	// Error is the error to return from ListAdvertisements, CreateAdvertisement and RevokeAdvertisement.
+	Error error
+
+	// Advertisements are the advertisements to return from ListAdvertisements.
+	Advertisements []*engine.ListedAdvertisement
+
+	// Steak is the STEAK to return from CreateAdvertisement and RevokeAdvertisement.
+	Steak overlay.Steak
+
+	// Protocol is the expected protocol of the created advertisement. It is not verified when empty.
+	Protocol overlay.Protocol
+
+	// TopicOrService is the expected topic or service of the created advertisement. It is not verified when empty.
+	TopicOrService string
+
+	// Outpoint is the expected outpoint of the revoked advertisement. It is not verified when nil.
+	Outpoint *transaction.Outpoint
+
+	// ListAdvertisementsCall indicates whether the ListAdvertisements method is expected to be called during the test.
+	ListAdvertisementsCall bool
+
+	// CreateAdvertisementCall indicates whether the CreateAdvertisement method is expected to be called during the test.
+	CreateAdvertisementCall bool
+
+	// RevokeAdvertisementCall indicates whether the RevokeAdvertisement method is expected to be called during the test.
+	RevokeAdvertisementCall bool
+}
+
+// AdvertisementProviderMock is a mock implementation of an advertisement provider,
+// used for testing the behavior of components that manually manage advertisements.
+type AdvertisementProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations AdvertisementProviderMockExpectations
+
+	// listCalled is true if the ListAdvertisements method was called.
+	listCalled bool
+
+	// createCalled is true if the CreateAdvertisement method was called.
+	createCalled bool
+
+	// revokeCalled is true if the RevokeAdvertisement method was called.
+	revokeCalled bool
+}
+
+// ListAdvertisements simulates listing the advertisements. It records the call
+// and returns the predefined advertisements or error.
+func (m *AdvertisementProviderMock) ListAdvertisements(context.Context) ([]*engine.ListedAdvertisement, error) {
+	m.t.Helper()
+	m.listCalled = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Advertisements, nil
+}
+
+// CreateAdvertisement simulates creating an advertisement. It records the call, verifies the protocol
+// and topic or service against the expectations and returns the predefined STEAK or error.
+func (m *AdvertisementProviderMock) CreateAdvertisement(_ context.Context, protocol overlay.Protocol, topicOrService string) (overlay.Steak, error) {
+	m.t.Helper()
+	m.createCalled = true
+
+	if m.expectations.Protocol != "" {
+		require.Equal(m.t, m.expectations.Protocol, protocol, "Discrepancy between expected and actual advertisement protocol")
+	}
+	if m.expectations.TopicOrService != "" {
+		require.Equal(m.t, m.expectations.TopicOrService, topicOrService, "Discrepancy between expected and actual advertised topic or service")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Steak, nil
+}
+
+// RevokeAdvertisement simulates revoking an advertisement. It records the call, verifies the outpoint
+// against the expectations and returns the predefined STEAK or error.
+func (m *AdvertisementProviderMock) RevokeAdvertisement(_ context.Context, outpoint *transaction.Outpoint) (overlay.Steak, error) {
+	m.t.Helper()
+	m.revokeCalled = true
+
+	if m.expectations.Outpoint != nil {
+		require.Equal(m.t, m.expectations.Outpoint, outpoint, "Discrepancy between expected and actual advertisement outpoint")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Steak, nil
+}
+
+// AssertCalled verifies that the ListAdvertisements, CreateAdvertisement and RevokeAdvertisement methods were called if they were expected to be.
+func (m *AdvertisementProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ListAdvertisementsCall, m.listCalled, "Discrepancy between expected and actual ListAdvertisements call")
+	require.Equal(m.t, m.expectations.CreateAdvertisementCall, m.createCalled, "Discrepancy between expected and actual CreateAdvertisement call")
+	require.Equal(m.t, m.expectations.RevokeAdvertisementCall, m.revokeCalled, "Discrepancy between expected and actual RevokeAdvertisement call")
+}
+
+// NewAdvertisementProviderMock creates a new instance of AdvertisementProviderMock with the given expectations.
+func NewAdvertisementProviderMock(t *testing.T, expectations AdvertisementProviderMockExpectations) *AdvertisementProviderMock {
+	return &AdvertisementProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -5,8 +5,10 @@ package testabilities
 
 import (
 	"context"
+	"sync"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/stretchr/testify/require"
@@ -16,20 +18,46 @@ import (
 type ARCIngestProviderMockExpectations struct {
 	Error                    error
 	HandleNewMerkleProofCall bool
+
+	// HandleAttestedMerkleProofCall expects the proof to be handled as attested, with the given Attestation.
+	HandleAttestedMerkleProofCall bool
+	Attestation                   engine.MerkleProofAttestation
 }
 
 // ARCIngestProviderMock is a mock implementation for testing ARC ingest provider behavior.
+// It is safe for concurrent use, as the proofs of a batch are handled concurrently.
 type ARCIngestProviderMock struct {
 	t            *testing.T
 	expectations ARCIngestProviderMockExpectations
-	called       bool
+
+	mu       sync.Mutex
+	called   bool
+	attested bool
 }
 
 // HandleNewMerkleProof simulates the behavior of the ARCIngestProvider.
 // It returns the error set in expectations if provided, otherwise it returns nil.
 func (a *ARCIngestProviderMock) HandleNewMerkleProof(_ context.Context, _ *chainhash.Hash, _ *transaction.MerklePath) error {
 	a.t.Helper()
+	a.mu.Lock()
 	a.called = true
+	a.mu.Unlock()
+
+	if a.expectations.Error != nil {
+		return a.expectations.Error
+	}
+
+	return nil
+}
+
+// HandleAttestedMerkleProof simulates the behavior of the ARCIngestProvider for attested proofs.
+// It verifies the attestation against expectations and returns the error set in expectations if provided.
+func (a *ARCIngestProviderMock) HandleAttestedMerkleProof(_ context.Context, _ *chainhash.Hash, _ *transaction.MerklePath, attestation engine.MerkleProofAttestation) error {
+	a.t.Helper()
+	a.mu.Lock()
+	a.attested = true
+	a.mu.Unlock()
+	require.Equal(a.t, a.expectations.Attestation, attestation, "Discrepancy between expected and actual Merkle proof attestation")
 
 	if a.expectations.Error != nil {
 		return a.expectations.Error
@@ -38,10 +66,13 @@ func (a *ARCIngestProviderMock) HandleNewMerkleProof(_ context.Context, _ *chain
 	return nil
 }
 
-// AssertCalled verifies that the HandleNewMerkleProof method was called as expected.
+// AssertCalled verifies that the HandleNewMerkleProof and HandleAttestedMerkleProof methods were called as expected.
 func (a *ARCIngestProviderMock) AssertCalled() {
 	a.t.Helper()
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	require.Equal(a.t, a.expectations.HandleNewMerkleProofCall, a.called, "Discrepancy between expected and actual HandleNewMerkleProof call")
+	require.Equal(a.t, a.expectations.HandleAttestedMerkleProofCall, a.attested, "Discrepancy between expected and actual HandleAttestedMerkleProof call")
 }
 
 // NewARCIngestProviderMock creates a new ARCIngestProviderMock instance.
@@ -0,0 +1,85 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// AuditLogProviderMockExpectations defines the expected behavior of the AuditLogProviderMock during a test.
+type AuditLogProviderMockExpectations struct {
+	// Error is the error to return from FindAuditEntries.
+	Error error
+
+	// Entries is the audit log to return from FindAuditEntries.
+	Entries []*engine.AuditEntry
+
+	// Query is the expected query of FindAuditEntries. It is not verified when nil.
+	Query *engine.AuditQuery
+
+	// FindAuditEntriesCall indicates whether the FindAuditEntries method is expected to be called during the test.
+	FindAuditEntriesCall bool
+
+	// RecordedEntries is the number of entries expected to be recorded with RecordAudit during the test.
+	RecordedEntries int
+}
+
+// AuditLogProviderMock is a mock implementation of an audit log provider and recorder,
+// used for testing the behavior of components that record and query the audit log.
+type AuditLogProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations AuditLogProviderMockExpectations
+
+	// findCalled is true if the FindAuditEntries method was called.
+	findCalled bool
+
+	// recorded holds the entries recorded with RecordAudit.
+	recorded []*engine.AuditEntry
+}
+
+// RecordAudit simulates appending an entry to the audit log. It records the entry and returns a nil error.
+func (m *AuditLogProviderMock) RecordAudit(_ context.Context, entry *engine.AuditEntry) error {
+	m.t.Helper()
+	m.recorded = append(m.recorded, entry)
+	return nil
+}
+
+// FindAuditEntries simulates querying the audit log. It records the call, verifies the query
+// against the expectations and returns the predefined entries or error.
+func (m *AuditLogProviderMock) FindAuditEntries(_ context.Context, query engine.AuditQuery) ([]*engine.AuditEntry, error) {
+	m.t.Helper()
+	m.findCalled = true
+
+	if m.expectations.Query != nil {
+		require.Equal(m.t, *m.expectations.Query, query, "Discrepancy between expected and actual audit query")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Entries, nil
+}
+
+// RecordedEntries returns the entries recorded with RecordAudit.
+func (m *AuditLogProviderMock) RecordedEntries() []*engine.AuditEntry {
+	return m.recorded
+}
+
+// AssertCalled verifies that the FindAuditEntries method was called if it was expected to be,
+// and that the expected number of entries was recorded.
+func (m *AuditLogProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.FindAuditEntriesCall, m.findCalled, "Discrepancy between expected and actual FindAuditEntries call")
+	require.Len(m.t, m.recorded, m.expectations.RecordedEntries, "Discrepancy between expected and actual recorded audit entries")
+}
+
+// NewAuditLogProviderMock creates a new instance of AuditLogProviderMock with the given expectations.
+func NewAuditLogProviderMock(t *testing.T, expectations AuditLogProviderMockExpectations) *AuditLogProviderMock {
+	return &AuditLogProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
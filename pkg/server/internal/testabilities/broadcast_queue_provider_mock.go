@@ -0,0 +1,59 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// BroadcastQueueProviderMockExpectations defines the expected behavior of the BroadcastQueueProviderMock during a test.
+type BroadcastQueueProviderMockExpectations struct {
+	// Error is the error to return from BroadcastQueueStatus.
+	Error error
+
+	// Status is the re-broadcast queue status to return from BroadcastQueueStatus.
+	Status *engine.BroadcastQueueStatus
+
+	// BroadcastQueueStatusCall indicates whether the BroadcastQueueStatus method is expected to be called during the test.
+	BroadcastQueueStatusCall bool
+}
+
+// BroadcastQueueProviderMock is a mock implementation of a re-broadcast queue provider,
+// used for testing the behavior of components that inspect failed broadcasts.
+type BroadcastQueueProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations BroadcastQueueProviderMockExpectations
+
+	// called is true if the BroadcastQueueStatus method was called.
+	called bool
+}
+
+// BroadcastQueueStatus simulates inspecting the re-broadcast queue. It records the call
+// and returns the predefined status or error.
+func (m *BroadcastQueueProviderMock) BroadcastQueueStatus(context.Context) (*engine.BroadcastQueueStatus, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Status, nil
+}
+
+// AssertCalled verifies that the BroadcastQueueStatus method was called if it was expected to be.
+func (m *BroadcastQueueProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.BroadcastQueueStatusCall, m.called, "Discrepancy between expected and actual BroadcastQueueStatus call")
+}
+
+// NewBroadcastQueueProviderMock creates a new instance of BroadcastQueueProviderMock with the given expectations.
+func NewBroadcastQueueProviderMock(t *testing.T, expectations BroadcastQueueProviderMockExpectations) *BroadcastQueueProviderMock {
+	return &BroadcastQueueProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,58 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ConfigReloadProviderMockExpectations defines the expected behavior of the ConfigReloadProviderMock during a test.
+type ConfigReloadProviderMockExpectations struct {
+	// Error is the error to return from ReloadConfig.
+	Error error
+
+	// Applied are the applied settings to return from ReloadConfig.
+	Applied []string
+
+	// ReloadConfigCall indicates whether the ReloadConfig method is expected to be called during the test.
+	ReloadConfigCall bool
+}
+
+// ConfigReloadProviderMock is a mock implementation of a config reload provider,
+// used for testing the behavior of components that reload the configuration of the node.
+type ConfigReloadProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations ConfigReloadProviderMockExpectations
+
+	// called is true if the ReloadConfig method was called.
+	called bool
+}
+
+// ReloadConfig simulates reloading the configuration. It records the call
+// and returns the predefined applied settings or error.
+func (m *ConfigReloadProviderMock) ReloadConfig(context.Context) ([]string, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Applied, nil
+}
+
+// AssertCalled verifies that the ReloadConfig method was called if it was expected to be.
+func (m *ConfigReloadProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ReloadConfigCall, m.called, "Discrepancy between expected and actual ReloadConfig call")
+}
+
+// NewConfigReloadProviderMock creates a new instance of ConfigReloadProviderMock with the given expectations.
+func NewConfigReloadProviderMock(t *testing.T, expectations ConfigReloadProviderMockExpectations) *ConfigReloadProviderMock {
+	return &ConfigReloadProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
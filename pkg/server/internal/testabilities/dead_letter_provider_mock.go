@@ -0,0 +1,88 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// DeadLetterProviderMockExpectations defines the expected behavior of the DeadLetterProviderMock during a test.
+type DeadLetterProviderMockExpectations struct {
+	// Error is the error to return from ListDeadLetters and ReplayDeadLetter.
+	Error error
+
+	// DeadLetters is the dead-letter queue to return from ListDeadLetters.
+	DeadLetters []*engine.DeadLetter
+
+	// Steak is the STEAK to return from ReplayDeadLetter.
+	Steak overlay.Steak
+
+	// ID is the expected ID of the replayed dead letter. It is not verified when empty.
+	ID string
+
+	// ListDeadLettersCall indicates whether the ListDeadLetters method is expected to be called during the test.
+	ListDeadLettersCall bool
+
+	// ReplayDeadLetterCall indicates whether the ReplayDeadLetter method is expected to be called during the test.
+	ReplayDeadLetterCall bool
+}
+
+// DeadLetterProviderMock is a mock implementation of a dead-letter provider,
+// used for testing the behavior of components that inspect and replay dead letters.
+type DeadLetterProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations DeadLetterProviderMockExpectations
+
+	// listCalled is true if the ListDeadLetters method was called.
+	listCalled bool
+
+	// replayCalled is true if the ReplayDeadLetter method was called.
+	replayCalled bool
+}
+
+// ListDeadLetters simulates listing the dead-letter queue. It records the call
+// and returns the predefined dead letters or error.
+func (m *DeadLetterProviderMock) ListDeadLetters(context.Context) ([]*engine.DeadLetter, error) {
+	m.t.Helper()
+	m.listCalled = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.DeadLetters, nil
+}
+
+// ReplayDeadLetter simulates replaying a dead letter. It records the call, verifies the ID
+// against the expectations and returns the predefined STEAK or error.
+func (m *DeadLetterProviderMock) ReplayDeadLetter(_ context.Context, id string) (overlay.Steak, error) {
+	m.t.Helper()
+	m.replayCalled = true
+
+	if m.expectations.ID != "" {
+		require.Equal(m.t, m.expectations.ID, id, "Discrepancy between expected and actual dead letter ID")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Steak, nil
+}
+
+// AssertCalled verifies that the ListDeadLetters and ReplayDeadLetter methods were called if they were expected to be.
+func (m *DeadLetterProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ListDeadLettersCall, m.listCalled, "Discrepancy between expected and actual ListDeadLetters call")
+	require.Equal(m.t, m.expectations.ReplayDeadLetterCall, m.replayCalled, "Discrepancy between expected and actual ReplayDeadLetter call")
+}
+
+// NewDeadLetterProviderMock creates a new instance of DeadLetterProviderMock with the given expectations.
+func NewDeadLetterProviderMock(t *testing.T, expectations DeadLetterProviderMockExpectations) *DeadLetterProviderMock {
+	return &DeadLetterProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
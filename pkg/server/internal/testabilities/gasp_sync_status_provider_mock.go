@@ -0,0 +1,59 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// GASPSyncStatusProviderMockExpectations defines the expected behavior of the GASPSyncStatusProviderMock during a test.
+type GASPSyncStatusProviderMockExpectations struct {
+	// Error is the error to return from GASPSyncStatus.
+	Error error
+
+	// Statuses are the sync statuses to return from GASPSyncStatus.
+	Statuses []*engine.GASPPeerSyncStatus
+
+	// GASPSyncStatusCall indicates whether the GASPSyncStatus method is expected to be called during the test.
+	GASPSyncStatusCall bool
+}
+
+// GASPSyncStatusProviderMock is a mock implementation of a GASP sync status provider,
+// used for testing the behavior of components that report the GASP sync progress.
+type GASPSyncStatusProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations GASPSyncStatusProviderMockExpectations
+
+	// called is true if the GASPSyncStatus method was called.
+	called bool
+}
+
+// GASPSyncStatus simulates reporting the GASP sync of every topic peer. It records the call
+// and returns the predefined statuses or error.
+func (m *GASPSyncStatusProviderMock) GASPSyncStatus(context.Context) ([]*engine.GASPPeerSyncStatus, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Statuses, nil
+}
+
+// AssertCalled verifies that the GASPSyncStatus method was called if it was expected to be.
+func (m *GASPSyncStatusProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.GASPSyncStatusCall, m.called, "Discrepancy between expected and actual GASPSyncStatus call")
+}
+
+// NewGASPSyncStatusProviderMock creates a new instance of GASPSyncStatusProviderMock with the given expectations.
+func NewGASPSyncStatusProviderMock(t *testing.T, expectations GASPSyncStatusProviderMockExpectations) *GASPSyncStatusProviderMock {
+	return &GASPSyncStatusProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
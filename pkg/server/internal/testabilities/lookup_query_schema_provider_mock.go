@@ -0,0 +1,65 @@
+package testabilities
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// LookupQuerySchemaProviderMockExpectations defines the expected behavior of the LookupQuerySchemaProviderMock during a test.
+type LookupQuerySchemaProviderMockExpectations struct {
+	// Error is the error to return from GetLookupQuerySchema.
+	Error error
+
+	// Schema is the query schema to return from GetLookupQuerySchema.
+	Schema json.RawMessage
+
+	// Service is the expected name of the lookup service. It is not verified when empty.
+	Service string
+
+	// GetLookupQuerySchemaCall indicates whether the GetLookupQuerySchema method is expected to be called during the test.
+	GetLookupQuerySchemaCall bool
+}
+
+// LookupQuerySchemaProviderMock is a mock implementation of a lookup query schema provider,
+// used for testing the behavior of components that publish lookup query schemas.
+type LookupQuerySchemaProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations LookupQuerySchemaProviderMockExpectations
+
+	// called is true if the GetLookupQuerySchema method was called.
+	called bool
+}
+
+// GetLookupQuerySchema simulates retrieving the query schema of a lookup service. It records the call,
+// verifies the service against the expectations and returns the predefined schema or error.
+func (m *LookupQuerySchemaProviderMock) GetLookupQuerySchema(_ context.Context, service string) (json.RawMessage, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Service != "" {
+		require.Equal(m.t, m.expectations.Service, service, "Discrepancy between expected and actual lookup service")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Schema, nil
+}
+
+// AssertCalled verifies that the GetLookupQuerySchema method was called if it was expected to be.
+func (m *LookupQuerySchemaProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.GetLookupQuerySchemaCall, m.called, "Discrepancy between expected and actual GetLookupQuerySchema call")
+}
+
+// NewLookupQuerySchemaProviderMock creates a new instance of LookupQuerySchemaProviderMock with the given expectations.
+func NewLookupQuerySchemaProviderMock(t *testing.T, expectations LookupQuerySchemaProviderMockExpectations) *LookupQuerySchemaProviderMock {
+	return &LookupQuerySchemaProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
 	"github.com/stretchr/testify/require"
 )
@@ -11,8 +12,15 @@ import (
 // LookupQuestionProviderMockExpectations defines the expected behavior and outcomes for a LookupQuestionProviderMock.
 type LookupQuestionProviderMockExpectations struct {
 	LookupQuestionCall bool
+	LookupPagedCall    bool
 	Error              error
 	Answer             *lookup.LookupAnswer
+
+	// Outputs are the outputs of the page returned by LookupPaged, which yields OutputsError after them when set.
+	Outputs      []*engine.LookupOutput
+	OutputsError error
+	NextCursor   string
+	Page         engine.LookupPage // expected page requested from LookupPaged
 }
 
 // LookupQuestionProviderMock is a mock implementation for testing the behavior of a LookupQuestionProvider.
@@ -20,6 +28,7 @@ type LookupQuestionProviderMock struct {
 	t            *testing.T
 	expectations LookupQuestionProviderMockExpectations
 	called       bool
+	pagedCalled  bool
 }
 
 // Lookup simulates a lookup operation and returns the expected answer or error.
@@ -34,10 +43,42 @@ func (m *LookupQuestionProviderMock) Lookup(_ context.Context, _ *lookup.LookupQ
 	return m.expectations.Answer, nil
 }
 
+// LookupPaged simulates a paged lookup operation and returns a page of the expected answer or error.
+func (m *LookupQuestionProviderMock) LookupPaged(_ context.Context, _ *lookup.LookupQuestion, page engine.LookupPage) (*engine.LookupPageAnswer, error) {
+	m.t.Helper()
+	m.pagedCalled = true
+	require.Equal(m.t, m.expectations.Page, page, "Discrepancy between expected and actual lookup page")
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+
+	answer := &engine.LookupPageAnswer{
+		Type:       lookup.AnswerTypeOutputList,
+		NextCursor: m.expectations.NextCursor,
+		Outputs: func(yield func(*engine.LookupOutput, error) bool) {
+			for _, output := range m.expectations.Outputs {
+				if !yield(output, nil) {
+					return
+				}
+			}
+			if m.expectations.OutputsError != nil {
+				yield(nil, m.expectations.OutputsError)
+			}
+		},
+	}
+	if m.expectations.Answer != nil {
+		answer.Type = m.expectations.Answer.Type
+		answer.Result = m.expectations.Answer.Result
+	}
+	return answer, nil
+}
+
 // AssertCalled checks if the Lookup method was called with the expected arguments.
 func (m *LookupQuestionProviderMock) AssertCalled() {
 	m.t.Helper()
 	require.Equal(m.t, m.expectations.LookupQuestionCall, m.called, "Discrepancy between expected and actual LookupQuestionCall")
+	require.Equal(m.t, m.expectations.LookupPagedCall, m.pagedCalled, "Discrepancy between expected and actual LookupPagedCall")
 }
 
 // NewLookupQuestionProviderMock creates a new LookupQuestionProviderMock with the given options.
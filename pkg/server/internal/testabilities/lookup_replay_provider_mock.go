@@ -0,0 +1,72 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// LookupReplayProviderMockExpectations defines the expected behavior of the LookupReplayProviderMock during a test.
+type LookupReplayProviderMockExpectations struct {
+	// Error is the error to return from ReplayTopicIntoLookupService.
+	Error error
+
+	// Progress is the progress to return from ReplayTopicIntoLookupService.
+	Progress *engine.LookupReplayProgress
+
+	// ReplayTopicIntoLookupServiceCall indicates whether the ReplayTopicIntoLookupService method is expected to be called during the test.
+	ReplayTopicIntoLookupServiceCall bool
+
+	// Topic is the expected topic. It is not verified when empty.
+	Topic string
+
+	// Service is the expected lookup service name. It is not verified when empty.
+	Service string
+
+	// FromScore is the expected score to replay from.
+	FromScore float64
+}
+
+// LookupReplayProviderMock is a mock implementation of a lookup replay provider,
+// used for testing the behavior of components that backfill lookup services.
+type LookupReplayProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations LookupReplayProviderMockExpectations
+
+	// called is true if the ReplayTopicIntoLookupService method was called.
+	called bool
+}
+
+// ReplayTopicIntoLookupService simulates the replay of a topic into a lookup service. It records the call,
+// verifies the arguments against the expectations and returns the predefined progress and error.
+func (m *LookupReplayProviderMock) ReplayTopicIntoLookupService(_ context.Context, topic, service string, fromScore float64) (*engine.LookupReplayProgress, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Topic != "" {
+		require.Equal(m.t, m.expectations.Topic, topic, "Discrepancy between expected and actual topic")
+	}
+	if m.expectations.Service != "" {
+		require.Equal(m.t, m.expectations.Service, service, "Discrepancy between expected and actual lookup service")
+	}
+	require.InDelta(m.t, m.expectations.FromScore, fromScore, 0, "Discrepancy between expected and actual score")
+	return m.expectations.Progress, m.expectations.Error
+}
+
+// AssertCalled verifies that the ReplayTopicIntoLookupService method was called if it was expected to be.
+func (m *LookupReplayProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.ReplayTopicIntoLookupServiceCall, m.called, "Discrepancy between expected and actual ReplayTopicIntoLookupService call")
+}
+
+// NewLookupReplayProviderMock creates a new instance of LookupReplayProviderMock with the given expectations.
+func NewLookupReplayProviderMock(t *testing.T, expectations LookupReplayProviderMockExpectations) *LookupReplayProviderMock {
+	return &LookupReplayProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
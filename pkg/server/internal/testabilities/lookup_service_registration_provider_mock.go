@@ -0,0 +1,77 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// LookupServiceRegistrationProviderMockExpectations defines the expected behavior of the LookupServiceRegistrationProviderMock during a test.
+type LookupServiceRegistrationProviderMockExpectations struct {
+	// Error is the error to return from AddLookupService and UnregisterLookupService.
+	Error error
+
+	// AddLookupServiceCall indicates whether the AddLookupService method is expected to be called during the test.
+	AddLookupServiceCall bool
+
+	// UnregisterLookupServiceCall indicates whether the UnregisterLookupService method is expected to be called during the test.
+	UnregisterLookupServiceCall bool
+
+	// Name is the expected lookup service name. It is not verified when empty.
+	Name string
+}
+
+// LookupServiceRegistrationProviderMock is a mock implementation of a lookup service registration provider,
+// used for testing the behavior of components that register and unregister lookup services.
+type LookupServiceRegistrationProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations LookupServiceRegistrationProviderMockExpectations
+
+	// addCalled is true if the AddLookupService method was called.
+	addCalled bool
+
+	// unregisterCalled is true if the UnregisterLookupService method was called.
+	unregisterCalled bool
+}
+
+// AddLookupService simulates the registration of a lookup service. It records the call,
+// verifies the arguments against the expectations and returns the predefined error if set.
+func (m *LookupServiceRegistrationProviderMock) AddLookupService(_ context.Context, name string) error {
+	m.t.Helper()
+	m.addCalled = true
+
+	if m.expectations.Name != "" {
+		require.Equal(m.t, m.expectations.Name, name, "Discrepancy between expected and actual lookup service name")
+	}
+	return m.expectations.Error
+}
+
+// UnregisterLookupService simulates the removal of a lookup service. It records the call,
+// verifies the name against the expectations and returns the predefined error if set.
+func (m *LookupServiceRegistrationProviderMock) UnregisterLookupService(_ context.Context, name string) error {
+	m.t.Helper()
+	m.unregisterCalled = true
+
+	if m.expectations.Name != "" {
+		require.Equal(m.t, m.expectations.Name, name, "Discrepancy between expected and actual lookup service name")
+	}
+	return m.expectations.Error
+}
+
+// AssertCalled verifies that the AddLookupService and UnregisterLookupService methods were called if they were expected to be.
+func (m *LookupServiceRegistrationProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.AddLookupServiceCall, m.addCalled, "Discrepancy between expected and actual AddLookupService call")
+	require.Equal(m.t, m.expectations.UnregisterLookupServiceCall, m.unregisterCalled, "Discrepancy between expected and actual UnregisterLookupService call")
+}
+
+// NewLookupServiceRegistrationProviderMock creates a new instance of LookupServiceRegistrationProviderMock with the given expectations.
+func NewLookupServiceRegistrationProviderMock(t *testing.T, expectations LookupServiceRegistrationProviderMockExpectations) *LookupServiceRegistrationProviderMock {
+	return &LookupServiceRegistrationProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -1,17 +1,24 @@
 package testabilities
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/stretchr/testify/require"
 )
 
-// NewTestOpenapiErrorResponse creates an openapi.Error response from the given app.Error,
-// primarily for use in tests. It sets the error message to the error's slug.
+// NewTestOpenapiErrorResponse creates the openapi.Error problem details response expected for the given app.Error,
+// primarily for use in tests. It mirrors the response written by ports.ErrorHandler, with the details
+// decoded from JSON the way a client reads them.
 func NewTestOpenapiErrorResponse(t *testing.T, err app.Error) openapi.Error {
 	t.Helper()
-	return openapi.Error{
-		Message: err.Slug(),
-	}
+	bb, marshalErr := json.Marshal(ports.NewErrorResponse(err))
+	require.NoError(t, marshalErr)
+
+	var response openapi.Error
+	require.NoError(t, json.Unmarshal(bb, &response))
+	return response
 }
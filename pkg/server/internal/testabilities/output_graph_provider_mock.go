@@ -0,0 +1,70 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// OutputGraphProviderMockExpectations defines the expected behavior of the OutputGraphProviderMock during a test.
+type OutputGraphProviderMockExpectations struct {
+	// Error is the error to return from OutputGraph.
+	Error error
+
+	// Graph is the output graph to return from OutputGraph.
+	Graph *engine.OutputGraph
+
+	// Outpoint is the outpoint OutputGraph is expected to receive, checked when set.
+	Outpoint *transaction.Outpoint
+
+	// Depth is the depth OutputGraph is expected to receive.
+	Depth uint32
+
+	// OutputGraphCall indicates whether the OutputGraph method is expected to be called during the test.
+	OutputGraphCall bool
+}
+
+// OutputGraphProviderMock is a mock implementation of an output graph provider,
+// used for testing the behavior of components that export output dependency graphs.
+type OutputGraphProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations OutputGraphProviderMockExpectations
+
+	// called is true if the OutputGraph method was called.
+	called bool
+}
+
+// OutputGraph simulates walking the dependency graph of an outpoint. It records the call,
+// checks the outpoint if expected and the depth, and returns the predefined graph or error.
+func (m *OutputGraphProviderMock) OutputGraph(_ context.Context, outpoint *transaction.Outpoint, _ string, depth uint32) (*engine.OutputGraph, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Outpoint != nil {
+		require.Equal(m.t, m.expectations.Outpoint, outpoint, "Discrepancy between expected and actual OutputGraph outpoint")
+	}
+	require.Equal(m.t, m.expectations.Depth, depth, "Discrepancy between expected and actual OutputGraph depth")
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Graph, nil
+}
+
+// AssertCalled verifies that the OutputGraph method was called if it was expected to be.
+func (m *OutputGraphProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.OutputGraphCall, m.called, "Discrepancy between expected and actual OutputGraph call")
+}
+
+// NewOutputGraphProviderMock creates a new instance of OutputGraphProviderMock with the given expectations.
+func NewOutputGraphProviderMock(t *testing.T, expectations OutputGraphProviderMockExpectations) *OutputGraphProviderMock {
+	return &OutputGraphProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
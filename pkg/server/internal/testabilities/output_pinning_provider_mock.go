@@ -0,0 +1,88 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// OutputPinningProviderMockExpectations defines the expected behavior of the OutputPinningProviderMock during a test.
+type OutputPinningProviderMockExpectations struct {
+	// Error is the error to return from PinOutput and UnpinOutput.
+	Error error
+
+	// PinOutputCall indicates whether the PinOutput method is expected to be called during the test.
+	PinOutputCall bool
+
+	// UnpinOutputCall indicates whether the UnpinOutput method is expected to be called during the test.
+	UnpinOutputCall bool
+
+	// Outpoint is the expected outpoint. It is not verified when nil.
+	Outpoint *transaction.Outpoint
+
+	// Topic is the expected topic. It is not verified when empty.
+	Topic string
+}
+
+// OutputPinningProviderMock is a mock implementation of an output pinning provider,
+// used for testing the behavior of components that pin and unpin outputs.
+type OutputPinningProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations OutputPinningProviderMockExpectations
+
+	// pinCalled is true if the PinOutput method was called.
+	pinCalled bool
+
+	// unpinCalled is true if the UnpinOutput method was called.
+	unpinCalled bool
+}
+
+// PinOutput simulates pinning an output. It records the call, verifies the arguments
+// against the expectations and returns the predefined error if set.
+func (m *OutputPinningProviderMock) PinOutput(_ context.Context, outpoint *transaction.Outpoint, topic string) error {
+	m.t.Helper()
+	m.pinCalled = true
+
+	m.verifyArguments(outpoint, topic)
+	return m.expectations.Error
+}
+
+// UnpinOutput simulates unpinning an output. It records the call, verifies the arguments
+// against the expectations and returns the predefined error if set.
+func (m *OutputPinningProviderMock) UnpinOutput(_ context.Context, outpoint *transaction.Outpoint, topic string) error {
+	m.t.Helper()
+	m.unpinCalled = true
+
+	m.verifyArguments(outpoint, topic)
+	return m.expectations.Error
+}
+
+// AssertCalled verifies that the PinOutput and UnpinOutput methods were called if they were expected to be.
+func (m *OutputPinningProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.PinOutputCall, m.pinCalled, "Discrepancy between expected and actual PinOutput call")
+	require.Equal(m.t, m.expectations.UnpinOutputCall, m.unpinCalled, "Discrepancy between expected and actual UnpinOutput call")
+}
+
+func (m *OutputPinningProviderMock) verifyArguments(outpoint *transaction.Outpoint, topic string) {
+	m.t.Helper()
+
+	if m.expectations.Outpoint != nil {
+		require.Equal(m.t, m.expectations.Outpoint, outpoint, "Discrepancy between expected and actual outpoint")
+	}
+	if m.expectations.Topic != "" {
+		require.Equal(m.t, m.expectations.Topic, topic, "Discrepancy between expected and actual topic")
+	}
+}
+
+// NewOutputPinningProviderMock creates a new instance of OutputPinningProviderMock with the given expectations.
+func NewOutputPinningProviderMock(t *testing.T, expectations OutputPinningProviderMockExpectations) *OutputPinningProviderMock {
+	return &OutputPinningProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,66 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// OutputStatusProviderMockExpectations defines the expected behavior of the OutputStatusProviderMock during a test.
+type OutputStatusProviderMockExpectations struct {
+	// Error is the error to return from GetOutputStatus.
+	Error error
+
+	// Status is the output status to return from GetOutputStatus.
+	Status *engine.OutputStatus
+
+	// Outpoint is the outpoint GetOutputStatus is expected to receive, checked when set.
+	Outpoint *transaction.Outpoint
+
+	// GetOutputStatusCall indicates whether the GetOutputStatus method is expected to be called during the test.
+	GetOutputStatusCall bool
+}
+
+// OutputStatusProviderMock is a mock implementation of an output status provider,
+// used for testing the behavior of components that report outpoint statuses.
+type OutputStatusProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations OutputStatusProviderMockExpectations
+
+	// called is true if the GetOutputStatus method was called.
+	called bool
+}
+
+// GetOutputStatus simulates reporting the status of an outpoint. It records the call,
+// checks the outpoint if expected and returns the predefined status or error.
+func (m *OutputStatusProviderMock) GetOutputStatus(_ context.Context, outpoint *transaction.Outpoint, _ string) (*engine.OutputStatus, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Outpoint != nil {
+		require.Equal(m.t, m.expectations.Outpoint, outpoint, "Discrepancy between expected and actual GetOutputStatus outpoint")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Status, nil
+}
+
+// AssertCalled verifies that the GetOutputStatus method was called if it was expected to be.
+func (m *OutputStatusProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.GetOutputStatusCall, m.called, "Discrepancy between expected and actual GetOutputStatus call")
+}
+
+// NewOutputStatusProviderMock creates a new instance of OutputStatusProviderMock with the given expectations.
+func NewOutputStatusProviderMock(t *testing.T, expectations OutputStatusProviderMockExpectations) *OutputStatusProviderMock {
+	return &OutputStatusProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,69 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// OutputsExistProviderMockExpectations defines the expected behavior of the OutputsExistProviderMock during a test.
+type OutputsExistProviderMockExpectations struct {
+	// Error is the error to return from HasOutputs.
+	Error error
+
+	// Exists is the existence vector to return from HasOutputs.
+	Exists []bool
+
+	// Outpoints are the outpoints HasOutputs is expected to receive, checked when set.
+	Outpoints []*transaction.Outpoint
+
+	// UnspentOnly is the spent filter HasOutputs is expected to receive.
+	UnspentOnly bool
+
+	// HasOutputsCall indicates whether the HasOutputs method is expected to be called during the test.
+	HasOutputsCall bool
+}
+
+// OutputsExistProviderMock is a mock implementation of an outputs existence provider,
+// used for testing the behavior of components that check outpoints in bulk.
+type OutputsExistProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations OutputsExistProviderMockExpectations
+
+	// called is true if the HasOutputs method was called.
+	called bool
+}
+
+// HasOutputs simulates a bulk existence check of outpoints. It records the call,
+// checks the arguments and returns the predefined existence vector or error.
+func (m *OutputsExistProviderMock) HasOutputs(_ context.Context, outpoints []*transaction.Outpoint, _ string, unspentOnly bool) ([]bool, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Outpoints != nil {
+		require.Equal(m.t, m.expectations.Outpoints, outpoints, "Discrepancy between expected and actual HasOutputs outpoints")
+	}
+	require.Equal(m.t, m.expectations.UnspentOnly, unspentOnly, "Discrepancy between expected and actual HasOutputs spent filter")
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Exists, nil
+}
+
+// AssertCalled verifies that the HasOutputs method was called if it was expected to be.
+func (m *OutputsExistProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.HasOutputsCall, m.called, "Discrepancy between expected and actual HasOutputs call")
+}
+
+// NewOutputsExistProviderMock creates a new instance of OutputsExistProviderMock with the given expectations.
+func NewOutputsExistProviderMock(t *testing.T, expectations OutputsExistProviderMockExpectations) *OutputsExistProviderMock {
+	return &OutputsExistProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
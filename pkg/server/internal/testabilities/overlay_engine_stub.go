@@ -2,6 +2,7 @@ package testabilities
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/overlay"
 	"github.com/bsv-blockchain/go-sdk/overlay/lookup"
+	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
 
@@ -61,6 +63,13 @@ type TopicManagersListProvider interface {
 	ProviderStateAsserter
 }
 
+// LookupQuerySchemaProvider extends app.LookupQuerySchemaProvider with the ability
+// to assert whether it was called during a test.
+type LookupQuerySchemaProvider interface {
+	app.LookupQuerySchemaProvider
+	ProviderStateAsserter
+}
+
 // LookupServiceDocumentationProvider extends app.LookupServiceDocumentationProvider with the ability
 // to assert whether it was called during a test.
 type LookupServiceDocumentationProvider interface {
@@ -96,6 +105,182 @@ type RequestSyncResponseProvider interface {
 	ProviderStateAsserter
 }
 
+// TopicManagerRegistrationProvider extends app.TopicManagerRegistrationProvider with the ability
+// to assert whether it was called during a test.
+type TopicManagerRegistrationProvider interface {
+	app.TopicManagerRegistrationProvider
+	ProviderStateAsserter
+}
+
+// LookupServiceRegistrationProvider extends app.LookupServiceRegistrationProvider with the ability
+// to assert whether it was called during a test.
+type LookupServiceRegistrationProvider interface {
+	app.LookupServiceRegistrationProvider
+	ProviderStateAsserter
+}
+
+// LookupReplayProvider extends app.LookupReplayProvider with the ability
+// to assert whether it was called during a test.
+type LookupReplayProvider interface {
+	app.LookupReplayProvider
+	ProviderStateAsserter
+}
+
+// OutputPinningProvider extends app.OutputPinningProvider with the ability
+// to assert whether it was called during a test.
+type OutputPinningProvider interface {
+	app.OutputPinningProvider
+	ProviderStateAsserter
+}
+
+// TopicSyncPauseProvider extends app.TopicSyncPauseProvider with the ability
+// to assert whether it was called during a test.
+type TopicSyncPauseProvider interface {
+	app.TopicSyncPauseProvider
+	ProviderStateAsserter
+}
+
+// TrackerProvider extends app.TrackerProvider with the ability
+// to assert whether it was called during a test.
+type TrackerProvider interface {
+	app.TrackerProvider
+	ProviderStateAsserter
+}
+
+// TopicQuotaProvider extends app.TopicQuotaProvider with the ability
+// to assert whether it was called during a test.
+type TopicQuotaProvider interface {
+	app.TopicQuotaProvider
+	ProviderStateAsserter
+}
+
+// ReorgSimulationProvider extends app.ReorgSimulationProvider with the ability
+// to assert whether it was called during a test.
+type ReorgSimulationProvider interface {
+	app.ReorgSimulationProvider
+	ProviderStateAsserter
+}
+
+// AuditLogProvider extends app.AuditLogProvider and app.AuditRecorder with the ability
+// to assert whether it was called during a test.
+type AuditLogProvider interface {
+	app.AuditLogProvider
+	app.AuditRecorder
+	ProviderStateAsserter
+}
+
+// DeadLetterProvider extends app.DeadLetterProvider with the ability
+// to assert whether it was called during a test.
+type DeadLetterProvider interface {
+	app.DeadLetterProvider
+	ProviderStateAsserter
+}
+
+// WebhookProvider extends app.WebhookProvider with the ability
+// to assert whether it was called during a test.
+type WebhookProvider interface {
+	app.WebhookProvider
+	ProviderStateAsserter
+}
+
+// AdvertisementProvider extends app.AdvertisementProvider with the ability
+// to assert whether it was called during a test.
+type AdvertisementProvider interface {
+	app.AdvertisementProvider
+	ProviderStateAsserter
+}
+
+// PruneOutputsProvider extends app.PruneOutputsProvider with the ability
+// to assert whether it was called during a test.
+type PruneOutputsProvider interface {
+	app.PruneOutputsProvider
+	ProviderStateAsserter
+}
+
+// AdvertisementPlanProvider extends app.AdvertisementPlanProvider with the ability
+// to assert whether it was called during a test.
+type AdvertisementPlanProvider interface {
+	app.AdvertisementPlanProvider
+	ProviderStateAsserter
+}
+
+// OutputStatusProvider extends app.OutputStatusProvider with the ability
+// to assert whether it was called during a test.
+type OutputStatusProvider interface {
+	app.OutputStatusProvider
+	ProviderStateAsserter
+}
+
+// OutputGraphProvider extends app.OutputGraphProvider with the ability
+// to assert whether it was called during a test.
+type OutputGraphProvider interface {
+	app.OutputGraphProvider
+	ProviderStateAsserter
+}
+
+// OutputsExistProvider extends app.OutputsExistProvider with the ability
+// to assert whether it was called during a test.
+type OutputsExistProvider interface {
+	app.OutputsExistProvider
+	ProviderStateAsserter
+}
+
+// UTXOHistoryProvider extends app.UTXOHistoryProvider with the ability
+// to assert whether it was called during a test.
+type UTXOHistoryProvider interface {
+	app.UTXOHistoryProvider
+	ProviderStateAsserter
+}
+
+// TopicStatsProvider extends app.TopicStatsProvider with the ability
+// to assert whether it was called during a test.
+type TopicStatsProvider interface {
+	app.TopicStatsProvider
+	ProviderStateAsserter
+}
+
+// GASPSyncStatusProvider extends app.GASPSyncStatusProvider with the ability
+// to assert whether it was called during a test.
+type GASPSyncStatusProvider interface {
+	app.GASPSyncStatusProvider
+	ProviderStateAsserter
+}
+
+// TopicSyncJobProvider extends app.TopicSyncJobProvider with the ability
+// to assert whether it was called during a test.
+type TopicSyncJobProvider interface {
+	app.TopicSyncJobProvider
+	ProviderStateAsserter
+}
+
+// PaymentProvider extends app.PaymentProvider with the ability
+// to assert whether it was called during a test.
+type PaymentProvider interface {
+	app.PaymentProvider
+	ProviderStateAsserter
+}
+
+// SubmitJobProvider extends app.SubmitJobProvider with the ability
+// to assert whether it was called during a test.
+type SubmitJobProvider interface {
+	app.SubmitJobProvider
+	ProviderStateAsserter
+}
+
+// BroadcastQueueProvider extends app.BroadcastQueueProvider with the ability
+// to assert whether it was called during a test.
+type BroadcastQueueProvider interface {
+	app.BroadcastQueueProvider
+	ProviderStateAsserter
+}
+
+// ReplicationProvider extends app.ReplicationProvider with the ability
+// to assert whether it was called during a test.
+type ReplicationProvider interface {
+	app.ReplicationProvider
+	ProviderStateAsserter
+}
+
 // TestOverlayEngineStubOption is a functional option type used to configure a TestOverlayEngineStub.
 // It allows setting custom behaviors for different parts of the TestOverlayEngineStub.
 type TestOverlayEngineStubOption func(*TestOverlayEngineStub)
@@ -140,6 +325,14 @@ func WithLookupListProvider(provider LookupListProvider) TestOverlayEngineStubOp
 	}
 }
 
+// WithLookupQuerySchemaProvider allows setting a custom LookupQuerySchemaProvider in a TestOverlayEngineStub.
+// This can be used to mock lookup query schema behavior during tests.
+func WithLookupQuerySchemaProvider(provider LookupQuerySchemaProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.lookupQuerySchemaProvider = provider
+	}
+}
+
 // WithLookupDocumentationProvider allows setting a custom LookupServiceDocumentationProvider in a TestOverlayEngineStub.
 // This can be used to mock lookup service documentation retrieval behavior during tests.
 func WithLookupDocumentationProvider(provider LookupServiceDocumentationProvider) TestOverlayEngineStubOption {
@@ -188,6 +381,206 @@ func WithRequestSyncResponseProvider(provider RequestSyncResponseProvider) TestO
 	}
 }
 
+// WithTopicManagerRegistrationProvider allows setting a custom TopicManagerRegistrationProvider in a TestOverlayEngineStub.
+// This can be used to mock topic manager registration behavior during tests.
+func WithTopicManagerRegistrationProvider(provider TopicManagerRegistrationProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.topicManagerRegistrationProvider = provider
+	}
+}
+
+// WithLookupServiceRegistrationProvider allows setting a custom LookupServiceRegistrationProvider in a TestOverlayEngineStub.
+// This can be used to mock lookup service registration behavior during tests.
+func WithLookupServiceRegistrationProvider(provider LookupServiceRegistrationProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.lookupServiceRegistrationProvider = provider
+	}
+}
+
+// WithLookupReplayProvider allows setting a custom LookupReplayProvider in a TestOverlayEngineStub.
+// This can be used to mock lookup service backfill behavior during tests.
+func WithLookupReplayProvider(provider LookupReplayProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.lookupReplayProvider = provider
+	}
+}
+
+// WithOutputPinningProvider allows setting a custom OutputPinningProvider in a TestOverlayEngineStub.
+// This can be used to mock output pinning behavior during tests.
+func WithOutputPinningProvider(provider OutputPinningProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.outputPinningProvider = provider
+	}
+}
+
+// WithTopicSyncPauseProvider allows setting a custom TopicSyncPauseProvider in a TestOverlayEngineStub.
+// This can be used to mock pausing and resuming the sync of topics during tests.
+func WithTopicSyncPauseProvider(provider TopicSyncPauseProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.topicSyncPauseProvider = provider
+	}
+}
+
+// WithTrackerProvider allows setting a custom TrackerProvider in a TestOverlayEngineStub.
+// This can be used to mock adding and removing SHIP and SLAP trackers during tests.
+func WithTrackerProvider(provider TrackerProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.trackerProvider = provider
+	}
+}
+
+// WithTopicQuotaProvider allows setting a custom TopicQuotaProvider in a TestOverlayEngineStub.
+// This can be used to mock overriding the output quotas of topics during tests.
+func WithTopicQuotaProvider(provider TopicQuotaProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.topicQuotaProvider = provider
+	}
+}
+
+// WithReorgSimulationProvider allows setting a custom ReorgSimulationProvider in a TestOverlayEngineStub.
+// This can be used to mock reorg simulation behavior during tests.
+func WithReorgSimulationProvider(provider ReorgSimulationProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.reorgSimulationProvider = provider
+	}
+}
+
+// WithAuditLogProvider allows setting a custom AuditLogProvider in a TestOverlayEngineStub.
+// This can be used to mock recording and querying the audit log during tests.
+func WithAuditLogProvider(provider AuditLogProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.auditLogProvider = provider
+	}
+}
+
+// WithDeadLetterProvider allows setting a custom DeadLetterProvider in a TestOverlayEngineStub.
+// This can be used to mock dead-letter queue behavior during tests.
+func WithDeadLetterProvider(provider DeadLetterProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.deadLetterProvider = provider
+	}
+}
+
+// WithWebhookProvider allows setting a custom WebhookProvider in a TestOverlayEngineStub.
+// This can be used to mock webhook subscription behavior during tests.
+func WithWebhookProvider(provider WebhookProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.webhookProvider = provider
+	}
+}
+
+// WithAdvertisementProvider allows setting a custom AdvertisementProvider in a TestOverlayEngineStub.
+// This can be used to mock manual advertisement management behavior during tests.
+func WithAdvertisementProvider(provider AdvertisementProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.advertisementProvider = provider
+	}
+}
+
+// WithPruneOutputsProvider allows setting a custom PruneOutputsProvider in a TestOverlayEngineStub.
+// This can be used to mock output pruning behavior during tests.
+func WithPruneOutputsProvider(provider PruneOutputsProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.pruneOutputsProvider = provider
+	}
+}
+
+// WithReplicationProvider allows setting a custom ReplicationProvider in a TestOverlayEngineStub.
+// This can be used to mock warm standby replication behavior during tests.
+func WithReplicationProvider(provider ReplicationProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.replicationProvider = provider
+	}
+}
+
+// WithAdvertisementPlanProvider allows setting a custom AdvertisementPlanProvider in a TestOverlayEngineStub.
+// This can be used to mock advertisement planning behavior during tests.
+func WithAdvertisementPlanProvider(provider AdvertisementPlanProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.advertisementPlanProvider = provider
+	}
+}
+
+// WithTopicStatsProvider allows setting a custom TopicStatsProvider in a TestOverlayEngineStub.
+// This can be used to mock topic statistics aggregation during tests.
+func WithTopicStatsProvider(provider TopicStatsProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.topicStatsProvider = provider
+	}
+}
+
+// WithGASPSyncStatusProvider allows setting a custom GASPSyncStatusProvider in a TestOverlayEngineStub.
+// This can be used to mock reporting the GASP sync progress during tests.
+func WithGASPSyncStatusProvider(provider GASPSyncStatusProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.gaspSyncStatusProvider = provider
+	}
+}
+
+// WithTopicSyncJobProvider allows setting a custom TopicSyncJobProvider in a TestOverlayEngineStub.
+// This can be used to mock single topic syncs and job polling during tests.
+func WithTopicSyncJobProvider(provider TopicSyncJobProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.topicSyncJobProvider = provider
+	}
+}
+
+// WithPaymentProvider allows setting a custom PaymentProvider in a TestOverlayEngineStub.
+// This can be used to mock payment verification behavior during tests.
+func WithPaymentProvider(provider PaymentProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.paymentProvider = provider
+	}
+}
+
+// WithSubmitJobProvider allows setting a custom SubmitJobProvider in a TestOverlayEngineStub.
+// This can be used to mock asynchronous submissions and job polling during tests.
+func WithSubmitJobProvider(provider SubmitJobProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.submitJobProvider = provider
+	}
+}
+
+// WithBroadcastQueueProvider allows setting a custom BroadcastQueueProvider in a TestOverlayEngineStub.
+// This can be used to mock re-broadcast queue inspection behavior during tests.
+func WithBroadcastQueueProvider(provider BroadcastQueueProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.broadcastQueueProvider = provider
+	}
+}
+
+// WithUTXOHistoryProvider allows setting a custom UTXOHistoryProvider in a TestOverlayEngineStub.
+// This can be used to mock output history retrieval behavior during tests.
+func WithUTXOHistoryProvider(provider UTXOHistoryProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.utxoHistoryProvider = provider
+	}
+}
+
+// WithOutputStatusProvider allows setting a custom OutputStatusProvider in a TestOverlayEngineStub.
+// This can be used to mock outpoint status reporting behavior during tests.
+func WithOutputStatusProvider(provider OutputStatusProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.outputStatusProvider = provider
+	}
+}
+
+// WithOutputGraphProvider allows setting a custom OutputGraphProvider in a TestOverlayEngineStub.
+// This can be used to mock output dependency graph walks during tests.
+func WithOutputGraphProvider(provider OutputGraphProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.outputGraphProvider = provider
+	}
+}
+
+// WithOutputsExistProvider allows setting a custom OutputsExistProvider in a TestOverlayEngineStub.
+// This can be used to mock bulk outpoint existence checks during tests.
+func WithOutputsExistProvider(provider OutputsExistProvider) TestOverlayEngineStubOption {
+	return func(stub *TestOverlayEngineStub) {
+		stub.outputsExistProvider = provider
+	}
+}
+
 // TestOverlayEngineStub is a test implementation of the engine.OverlayEngineProvider interface.
 // It is used to mock engine behavior in unit tests, allowing the simulation of various engine actions
 // like submitting transactions and synchronizing advertisements.
@@ -197,6 +590,7 @@ type TestOverlayEngineStub struct {
 	lookupListProvider                LookupListProvider
 	topicManagersListProvider         TopicManagersListProvider
 	lookupDocumentationProvider       LookupServiceDocumentationProvider
+	lookupQuerySchemaProvider         LookupQuerySchemaProvider
 	topicManagerDocumentationProvider TopicManagerDocumentationProvider
 	startGASPSyncProvider             StartGASPSyncProvider
 	submitTransactionProvider         SubmitTransactionProvider
@@ -204,6 +598,295 @@ type TestOverlayEngineStub struct {
 	requestForeignGASPNodeProvider    RequestForeignGASPNodeProvider
 	requestSyncResponseProvider       RequestSyncResponseProvider
 	arcIngestProvider                 ARCIngestProvider
+	topicManagerRegistrationProvider  TopicManagerRegistrationProvider
+	lookupServiceRegistrationProvider LookupServiceRegistrationProvider
+	lookupReplayProvider              LookupReplayProvider
+	outputPinningProvider             OutputPinningProvider
+	topicSyncPauseProvider            TopicSyncPauseProvider
+	trackerProvider                   TrackerProvider
+	topicQuotaProvider                TopicQuotaProvider
+	reorgSimulationProvider           ReorgSimulationProvider
+	deadLetterProvider                DeadLetterProvider
+	auditLogProvider                  AuditLogProvider
+	webhookProvider                   WebhookProvider
+	pruneOutputsProvider              PruneOutputsProvider
+	replicationProvider               ReplicationProvider
+	advertisementPlanProvider         AdvertisementPlanProvider
+	advertisementProvider             AdvertisementProvider
+	broadcastQueueProvider            BroadcastQueueProvider
+	utxoHistoryProvider               UTXOHistoryProvider
+	outputStatusProvider              OutputStatusProvider
+	outputGraphProvider               OutputGraphProvider
+	outputsExistProvider              OutputsExistProvider
+	topicStatsProvider                TopicStatsProvider
+	gaspSyncStatusProvider            GASPSyncStatusProvider
+	topicSyncJobProvider              TopicSyncJobProvider
+	submitJobProvider                 SubmitJobProvider
+	paymentProvider                   PaymentProvider
+}
+
+// HasOutputs checks the existence of outpoints using the configured OutputsExistProvider.
+func (s *TestOverlayEngineStub) HasOutputs(ctx context.Context, outpoints []*transaction.Outpoint, topic string, unspentOnly bool) ([]bool, error) {
+	s.t.Helper()
+	return s.outputsExistProvider.HasOutputs(ctx, outpoints, topic, unspentOnly)
+}
+
+// OutputGraph walks the dependency graph of an outpoint using the configured OutputGraphProvider.
+func (s *TestOverlayEngineStub) OutputGraph(ctx context.Context, outpoint *transaction.Outpoint, topic string, depth uint32) (*engine.OutputGraph, error) {
+	s.t.Helper()
+	return s.outputGraphProvider.OutputGraph(ctx, outpoint, topic, depth)
+}
+
+// GetOutputStatus reports the status of an outpoint using the configured OutputStatusProvider.
+func (s *TestOverlayEngineStub) GetOutputStatus(ctx context.Context, outpoint *transaction.Outpoint, topic string) (*engine.OutputStatus, error) {
+	s.t.Helper()
+	return s.outputStatusProvider.GetOutputStatus(ctx, outpoint, topic)
+}
+
+// FindUTXOHistory hydrates the history of an output using the configured UTXOHistoryProvider.
+func (s *TestOverlayEngineStub) FindUTXOHistory(ctx context.Context, query engine.UTXOHistoryQuery) (*engine.Output, error) {
+	s.t.Helper()
+	return s.utxoHistoryProvider.FindUTXOHistory(ctx, query)
+}
+
+// TopicStats aggregates the statistics of the hosted topics using the configured TopicStatsProvider.
+func (s *TestOverlayEngineStub) TopicStats(ctx context.Context) (*engine.OverlayStats, error) {
+	s.t.Helper()
+	return s.topicStatsProvider.TopicStats(ctx)
+}
+
+// GASPSyncStatus reports the GASP sync of every topic peer using the configured GASPSyncStatusProvider.
+func (s *TestOverlayEngineStub) GASPSyncStatus(ctx context.Context) ([]*engine.GASPPeerSyncStatus, error) {
+	s.t.Helper()
+	return s.gaspSyncStatusProvider.GASPSyncStatus(ctx)
+}
+
+// SyncTopic starts a single topic sync using the configured TopicSyncJobProvider.
+func (s *TestOverlayEngineStub) SyncTopic(ctx context.Context, request engine.TopicSyncRequest) (*engine.TopicSyncJob, error) {
+	s.t.Helper()
+	return s.topicSyncJobProvider.SyncTopic(ctx, request)
+}
+
+// FindTopicSyncJob polls a single topic sync using the configured TopicSyncJobProvider.
+func (s *TestOverlayEngineStub) FindTopicSyncJob(ctx context.Context, id string) (*engine.TopicSyncJob, error) {
+	s.t.Helper()
+	return s.topicSyncJobProvider.FindTopicSyncJob(ctx, id)
+}
+
+// VerifyPayment verifies a payment transaction using the configured PaymentProvider.
+func (s *TestOverlayEngineStub) VerifyPayment(ctx context.Context, beef []byte, lockingScript *script.Script, satoshis uint64) (*transaction.Outpoint, error) {
+	s.t.Helper()
+	return s.paymentProvider.VerifyPayment(ctx, beef, lockingScript, satoshis)
+}
+
+// EnqueueSubmit enqueues an asynchronous submission using the configured SubmitJobProvider.
+func (s *TestOverlayEngineStub) EnqueueSubmit(ctx context.Context, taggedBEEF overlay.TaggedBEEF, callbackURL string) (*engine.SubmitJob, error) {
+	s.t.Helper()
+	return s.submitJobProvider.EnqueueSubmit(ctx, taggedBEEF, callbackURL)
+}
+
+// FindSubmitJob polls an asynchronous submission using the configured SubmitJobProvider.
+func (s *TestOverlayEngineStub) FindSubmitJob(ctx context.Context, id string) (*engine.SubmitJob, error) {
+	s.t.Helper()
+	return s.submitJobProvider.FindSubmitJob(ctx, id)
+}
+
+// BroadcastQueueStatus inspects the re-broadcast queue using the configured BroadcastQueueProvider.
+func (s *TestOverlayEngineStub) BroadcastQueueStatus(ctx context.Context) (*engine.BroadcastQueueStatus, error) {
+	s.t.Helper()
+	return s.broadcastQueueProvider.BroadcastQueueStatus(ctx)
+}
+
+// PlanAdvertisements previews the next advertisement sync run using the configured AdvertisementPlanProvider.
+func (s *TestOverlayEngineStub) PlanAdvertisements(ctx context.Context) (*engine.AdvertisementPlan, error) {
+	s.t.Helper()
+	return s.advertisementPlanProvider.PlanAdvertisements(ctx)
+}
+
+// NextMutations returns the storage mutations following a position using the configured ReplicationProvider.
+func (s *TestOverlayEngineStub) NextMutations(ctx context.Context, epoch string, since uint64) ([]*engine.Mutation, error) {
+	s.t.Helper()
+	return s.replicationProvider.NextMutations(ctx, epoch, since)
+}
+
+// PromoteStandby promotes the standby using the configured ReplicationProvider.
+func (s *TestOverlayEngineStub) PromoteStandby(ctx context.Context) error {
+	s.t.Helper()
+	return s.replicationProvider.PromoteStandby(ctx)
+}
+
+// PruneOutputs applies the retention policies using the configured PruneOutputsProvider.
+func (s *TestOverlayEngineStub) PruneOutputs(ctx context.Context) ([]*engine.PruneReport, error) {
+	s.t.Helper()
+	return s.pruneOutputsProvider.PruneOutputs(ctx)
+}
+
+// RecordAudit records an audit entry using the configured AuditLogProvider.
+func (s *TestOverlayEngineStub) RecordAudit(ctx context.Context, entry *engine.AuditEntry) error {
+	s.t.Helper()
+	return s.auditLogProvider.RecordAudit(ctx, entry)
+}
+
+// FindAuditEntries queries the audit log using the configured AuditLogProvider.
+func (s *TestOverlayEngineStub) FindAuditEntries(ctx context.Context, query engine.AuditQuery) ([]*engine.AuditEntry, error) {
+	s.t.Helper()
+	return s.auditLogProvider.FindAuditEntries(ctx, query)
+}
+
+// ListDeadLetters lists the dead-letter queue using the configured DeadLetterProvider.
+func (s *TestOverlayEngineStub) ListDeadLetters(ctx context.Context) ([]*engine.DeadLetter, error) {
+	s.t.Helper()
+	return s.deadLetterProvider.ListDeadLetters(ctx)
+}
+
+// ReplayDeadLetter replays a dead letter using the configured DeadLetterProvider.
+func (s *TestOverlayEngineStub) ReplayDeadLetter(ctx context.Context, id string) (overlay.Steak, error) {
+	s.t.Helper()
+	return s.deadLetterProvider.ReplayDeadLetter(ctx, id)
+}
+
+// ListAdvertisements lists the advertisements using the configured AdvertisementProvider.
+func (s *TestOverlayEngineStub) ListAdvertisements(ctx context.Context) ([]*engine.ListedAdvertisement, error) {
+	s.t.Helper()
+	return s.advertisementProvider.ListAdvertisements(ctx)
+}
+
+// CreateAdvertisement creates an advertisement using the configured AdvertisementProvider.
+func (s *TestOverlayEngineStub) CreateAdvertisement(ctx context.Context, protocol overlay.Protocol, topicOrService string) (overlay.Steak, error) {
+	s.t.Helper()
+	return s.advertisementProvider.CreateAdvertisement(ctx, protocol, topicOrService)
+}
+
+// RevokeAdvertisement revokes an advertisement using the configured AdvertisementProvider.
+func (s *TestOverlayEngineStub) RevokeAdvertisement(ctx context.Context, outpoint *transaction.Outpoint) (overlay.Steak, error) {
+	s.t.Helper()
+	return s.advertisementProvider.RevokeAdvertisement(ctx, outpoint)
+}
+
+// RegisterWebhook registers a webhook subscription using the configured WebhookProvider.
+func (s *TestOverlayEngineStub) RegisterWebhook(ctx context.Context, callbackURL string, topics []string, secret string) (*engine.WebhookSubscription, error) {
+	s.t.Helper()
+	return s.webhookProvider.RegisterWebhook(ctx, callbackURL, topics, secret)
+}
+
+// ListWebhooks lists the webhook subscriptions using the configured WebhookProvider.
+func (s *TestOverlayEngineStub) ListWebhooks(ctx context.Context) ([]*engine.WebhookSubscription, error) {
+	s.t.Helper()
+	return s.webhookProvider.ListWebhooks(ctx)
+}
+
+// UnregisterWebhook unregisters a webhook subscription using the configured WebhookProvider.
+func (s *TestOverlayEngineStub) UnregisterWebhook(ctx context.Context, id string) error {
+	s.t.Helper()
+	return s.webhookProvider.UnregisterWebhook(ctx, id)
+}
+
+// SimulateReorg simulates a reorg using the configured ReorgSimulationProvider.
+func (s *TestOverlayEngineStub) SimulateReorg(ctx context.Context, depth uint32) (*engine.ReorgSimulation, error) {
+	s.t.Helper()
+	return s.reorgSimulationProvider.SimulateReorg(ctx, depth)
+}
+
+// ReplayTopicIntoLookupService replays a topic into a lookup service using the configured LookupReplayProvider.
+func (s *TestOverlayEngineStub) ReplayTopicIntoLookupService(ctx context.Context, topic, service string, fromScore float64) (*engine.LookupReplayProgress, error) {
+	s.t.Helper()
+	return s.lookupReplayProvider.ReplayTopicIntoLookupService(ctx, topic, service, fromScore)
+}
+
+// PinOutput pins an output using the configured OutputPinningProvider.
+func (s *TestOverlayEngineStub) PinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	s.t.Helper()
+	return s.outputPinningProvider.PinOutput(ctx, outpoint, topic)
+}
+
+// UnpinOutput unpins an output using the configured OutputPinningProvider.
+func (s *TestOverlayEngineStub) UnpinOutput(ctx context.Context, outpoint *transaction.Outpoint, topic string) error {
+	s.t.Helper()
+	return s.outputPinningProvider.UnpinOutput(ctx, outpoint, topic)
+}
+
+// PauseTopicSync pauses the sync of a topic using the configured TopicSyncPauseProvider.
+func (s *TestOverlayEngineStub) PauseTopicSync(ctx context.Context, topic string) error {
+	s.t.Helper()
+	return s.topicSyncPauseProvider.PauseTopicSync(ctx, topic)
+}
+
+// ResumeTopicSync resumes the sync of a topic using the configured TopicSyncPauseProvider.
+func (s *TestOverlayEngineStub) ResumeTopicSync(ctx context.Context, topic string) error {
+	s.t.Helper()
+	return s.topicSyncPauseProvider.ResumeTopicSync(ctx, topic)
+}
+
+// PausedSyncTopics returns the paused topics using the configured TopicSyncPauseProvider.
+func (s *TestOverlayEngineStub) PausedSyncTopics(ctx context.Context) []string {
+	s.t.Helper()
+	return s.topicSyncPauseProvider.PausedSyncTopics(ctx)
+}
+
+// Trackers returns the trackers using the configured TrackerProvider.
+func (s *TestOverlayEngineStub) Trackers(ctx context.Context) engine.Trackers {
+	s.t.Helper()
+	return s.trackerProvider.Trackers(ctx)
+}
+
+// AddTracker adds a tracker using the configured TrackerProvider.
+func (s *TestOverlayEngineStub) AddTracker(ctx context.Context, kind engine.TrackerKind, tracker string) error {
+	s.t.Helper()
+	return s.trackerProvider.AddTracker(ctx, kind, tracker)
+}
+
+// RemoveTracker removes a tracker using the configured TrackerProvider.
+func (s *TestOverlayEngineStub) RemoveTracker(ctx context.Context, kind engine.TrackerKind, tracker string) error {
+	s.t.Helper()
+	return s.trackerProvider.RemoveTracker(ctx, kind, tracker)
+}
+
+// TopicQuotaSettings returns the topic quota settings using the configured TopicQuotaProvider.
+func (s *TestOverlayEngineStub) TopicQuotaSettings(ctx context.Context) engine.TopicQuotaSettings {
+	s.t.Helper()
+	return s.topicQuotaProvider.TopicQuotaSettings(ctx)
+}
+
+// SetTopicQuota overrides a topic quota using the configured TopicQuotaProvider.
+func (s *TestOverlayEngineStub) SetTopicQuota(ctx context.Context, topic string, quota engine.TopicQuota) error {
+	s.t.Helper()
+	return s.topicQuotaProvider.SetTopicQuota(ctx, topic, quota)
+}
+
+// RemoveTopicQuota removes a topic quota override using the configured TopicQuotaProvider.
+func (s *TestOverlayEngineStub) RemoveTopicQuota(ctx context.Context, topic string) error {
+	s.t.Helper()
+	return s.topicQuotaProvider.RemoveTopicQuota(ctx, topic)
+}
+
+// AddLookupService registers a lookup service using the configured LookupServiceRegistrationProvider.
+func (s *TestOverlayEngineStub) AddLookupService(ctx context.Context, name string) error {
+	s.t.Helper()
+	return s.lookupServiceRegistrationProvider.AddLookupService(ctx, name)
+}
+
+// UnregisterLookupService removes a lookup service using the configured LookupServiceRegistrationProvider.
+func (s *TestOverlayEngineStub) UnregisterLookupService(ctx context.Context, name string) error {
+	s.t.Helper()
+	return s.lookupServiceRegistrationProvider.UnregisterLookupService(ctx, name)
+}
+
+// AddTopicManager registers a topic manager using the configured TopicManagerRegistrationProvider.
+func (s *TestOverlayEngineStub) AddTopicManager(ctx context.Context, name string, syncConfig engine.SyncConfiguration) error {
+	s.t.Helper()
+	return s.topicManagerRegistrationProvider.AddTopicManager(ctx, name, syncConfig)
+}
+
+// UnregisterTopicManager removes a topic manager using the configured TopicManagerRegistrationProvider.
+func (s *TestOverlayEngineStub) UnregisterTopicManager(ctx context.Context, name string) error {
+	s.t.Helper()
+	return s.topicManagerRegistrationProvider.UnregisterTopicManager(ctx, name)
+}
+
+// GetLookupQuerySchema returns the query schema of a lookup service using the configured LookupQuerySchemaProvider.
+func (s *TestOverlayEngineStub) GetLookupQuerySchema(ctx context.Context, service string) (json.RawMessage, error) {
+	s.t.Helper()
+	return s.lookupQuerySchemaProvider.GetLookupQuerySchema(ctx, service)
 }
 
 // GetDocumentationForLookupServiceProvider returns documentation for a lookup service provider
@@ -233,6 +916,12 @@ func (s *TestOverlayEngineStub) HandleNewMerkleProof(ctx context.Context, txid *
 	return s.arcIngestProvider.HandleNewMerkleProof(ctx, txid, proof)
 }
 
+// HandleAttestedMerkleProof processes a new Merkle proof for a transaction together with its block hash and source.
+func (s *TestOverlayEngineStub) HandleAttestedMerkleProof(ctx context.Context, txid *chainhash.Hash, proof *transaction.MerklePath, attestation engine.MerkleProofAttestation) error {
+	s.t.Helper()
+	return s.arcIngestProvider.HandleAttestedMerkleProof(ctx, txid, proof, attestation)
+}
+
 // ListLookupServiceProviders lists the available lookup service providers.
 func (s *TestOverlayEngineStub) ListLookupServiceProviders() map[string]*overlay.MetaData {
 	s.t.Helper()
@@ -252,10 +941,16 @@ func (s *TestOverlayEngineStub) Lookup(ctx context.Context, question *lookup.Loo
 	return s.lookupQuestionProvider.Lookup(ctx, question)
 }
 
+// LookupPaged returns a page of the answer to a lookup query using the configured LookupQuestionProvider.
+func (s *TestOverlayEngineStub) LookupPaged(ctx context.Context, question *lookup.LookupQuestion, page engine.LookupPage) (*engine.LookupPageAnswer, error) {
+	s.t.Helper()
+	return s.lookupQuestionProvider.LookupPaged(ctx, question, page)
+}
+
 // ProvideForeignGASPNode returns a foreign GASP node using the configured RequestForeignGASPNodeProvider.
-func (s *TestOverlayEngineStub) ProvideForeignGASPNode(ctx context.Context, graphID, outpoints *transaction.Outpoint, topic string) (*gasp.Node, error) {
+func (s *TestOverlayEngineStub) ProvideForeignGASPNode(ctx context.Context, graphID, outpoints *transaction.Outpoint, topic string, metadata bool) (*gasp.Node, error) {
 	s.t.Helper()
-	return s.requestForeignGASPNodeProvider.ProvideForeignGASPNode(ctx, graphID, outpoints, topic)
+	return s.requestForeignGASPNodeProvider.ProvideForeignGASPNode(ctx, graphID, outpoints, topic, metadata)
 }
 
 // ProvideForeignSyncResponse returns a foreign sync response.
@@ -279,6 +974,13 @@ func (s *TestOverlayEngineStub) Submit(ctx context.Context, taggedBEEF overlay.T
 	return s.submitTransactionProvider.Submit(ctx, taggedBEEF, mode, onSteakReady)
 }
 
+// Evaluate evaluates a transaction submission without applying it and returns the resulting steak or error.
+// It calls the Evaluate method of the configured SubmitTransactionProvider.
+func (s *TestOverlayEngineStub) Evaluate(ctx context.Context, taggedBEEF overlay.TaggedBEEF) (overlay.Steak, error) {
+	s.t.Helper()
+	return s.submitTransactionProvider.Evaluate(ctx, taggedBEEF)
+}
+
 // SyncAdvertisements synchronizes advertisements using the configured SyncAdvertisementsProvider.
 // It calls the SyncAdvertisements method of the provider and handles the result.
 func (s *TestOverlayEngineStub) SyncAdvertisements(ctx context.Context) error {
@@ -297,11 +999,37 @@ func (s *TestOverlayEngineStub) AssertProvidersState() {
 		s.lookupListProvider,
 		s.topicManagersListProvider,
 		s.lookupDocumentationProvider,
+		s.lookupQuerySchemaProvider,
 		s.syncAdvertisementsProvider,
 		s.startGASPSyncProvider,
 		s.requestForeignGASPNodeProvider,
 		s.requestSyncResponseProvider,
 		s.arcIngestProvider,
+		s.topicManagerRegistrationProvider,
+		s.lookupServiceRegistrationProvider,
+		s.lookupReplayProvider,
+		s.outputPinningProvider,
+		s.topicSyncPauseProvider,
+		s.trackerProvider,
+		s.topicQuotaProvider,
+		s.reorgSimulationProvider,
+		s.deadLetterProvider,
+		s.auditLogProvider,
+		s.webhookProvider,
+		s.pruneOutputsProvider,
+		s.replicationProvider,
+		s.advertisementPlanProvider,
+		s.advertisementProvider,
+		s.broadcastQueueProvider,
+		s.utxoHistoryProvider,
+		s.outputStatusProvider,
+		s.outputGraphProvider,
+		s.outputsExistProvider,
+		s.topicStatsProvider,
+		s.gaspSyncStatusProvider,
+		s.topicSyncJobProvider,
+		s.submitJobProvider,
+		s.paymentProvider,
 	}
 	for _, p := range providers {
 		p.AssertCalled()
@@ -315,6 +1043,7 @@ func NewTestOverlayEngineStub(t *testing.T, opts ...TestOverlayEngineStubOption)
 		t:                                 t,
 		lookupQuestionProvider:            NewLookupQuestionProviderMock(t, LookupQuestionProviderMockExpectations{LookupQuestionCall: false}),
 		lookupDocumentationProvider:       NewLookupServiceDocumentationProviderMock(t, LookupServiceDocumentationProviderMockExpectations{DocumentationCall: false}),
+		lookupQuerySchemaProvider:         NewLookupQuerySchemaProviderMock(t, LookupQuerySchemaProviderMockExpectations{}),
 		topicManagerDocumentationProvider: NewTopicManagerDocumentationProviderMock(t, TopicManagerDocumentationProviderMockExpectations{DocumentationCall: false}),
 		topicManagersListProvider:         NewTopicManagersListProviderMock(t, TopicManagersListProviderMockExpectations{ListTopicManagersCall: false}),
 		startGASPSyncProvider:             NewStartGASPSyncProviderMock(t, StartGASPSyncProviderMockExpectations{StartGASPSyncCall: false}),
@@ -324,6 +1053,31 @@ func NewTestOverlayEngineStub(t *testing.T, opts ...TestOverlayEngineStubOption)
 		requestForeignGASPNodeProvider:    NewRequestForeignGASPNodeProviderMock(t, RequestForeignGASPNodeProviderMockExpectations{ProvideForeignGASPNodeCall: false}),
 		requestSyncResponseProvider:       NewRequestSyncResponseProviderMock(t, RequestSyncResponseProviderMockExpectations{ProvideForeignSyncResponseCall: false}),
 		arcIngestProvider:                 NewARCIngestProviderMock(t, ARCIngestProviderMockExpectations{HandleNewMerkleProofCall: false}),
+		topicManagerRegistrationProvider:  NewTopicManagerRegistrationProviderMock(t, TopicManagerRegistrationProviderMockExpectations{}),
+		lookupServiceRegistrationProvider: NewLookupServiceRegistrationProviderMock(t, LookupServiceRegistrationProviderMockExpectations{}),
+		lookupReplayProvider:              NewLookupReplayProviderMock(t, LookupReplayProviderMockExpectations{}),
+		outputPinningProvider:             NewOutputPinningProviderMock(t, OutputPinningProviderMockExpectations{}),
+		topicSyncPauseProvider:            NewTopicSyncPauseProviderMock(t, TopicSyncPauseProviderMockExpectations{}),
+		trackerProvider:                   NewTrackerProviderMock(t, TrackerProviderMockExpectations{}),
+		topicQuotaProvider:                NewTopicQuotaProviderMock(t, TopicQuotaProviderMockExpectations{}),
+		reorgSimulationProvider:           NewReorgSimulationProviderMock(t, ReorgSimulationProviderMockExpectations{}),
+		deadLetterProvider:                NewDeadLetterProviderMock(t, DeadLetterProviderMockExpectations{}),
+		auditLogProvider:                  NewAuditLogProviderMock(t, AuditLogProviderMockExpectations{}),
+		webhookProvider:                   NewWebhookProviderMock(t, WebhookProviderMockExpectations{}),
+		pruneOutputsProvider:              NewPruneOutputsProviderMock(t, PruneOutputsProviderMockExpectations{}),
+		replicationProvider:               NewReplicationProviderMock(t, ReplicationProviderMockExpectations{}),
+		advertisementPlanProvider:         NewAdvertisementPlanProviderMock(t, AdvertisementPlanProviderMockExpectations{}),
+		advertisementProvider:             NewAdvertisementProviderMock(t, AdvertisementProviderMockExpectations{}),
+		broadcastQueueProvider:            NewBroadcastQueueProviderMock(t, BroadcastQueueProviderMockExpectations{}),
+		utxoHistoryProvider:               NewUTXOHistoryProviderMock(t, UTXOHistoryProviderMockExpectations{}),
+		outputStatusProvider:              NewOutputStatusProviderMock(t, OutputStatusProviderMockExpectations{}),
+		outputGraphProvider:               NewOutputGraphProviderMock(t, OutputGraphProviderMockExpectations{}),
+		outputsExistProvider:              NewOutputsExistProviderMock(t, OutputsExistProviderMockExpectations{}),
+		topicStatsProvider:                NewTopicStatsProviderMock(t, TopicStatsProviderMockExpectations{}),
+		gaspSyncStatusProvider:            NewGASPSyncStatusProviderMock(t, GASPSyncStatusProviderMockExpectations{}),
+		topicSyncJobProvider:              NewTopicSyncJobProviderMock(t, TopicSyncJobProviderMockExpectations{}),
+		submitJobProvider:                 NewSubmitJobProviderMock(t, SubmitJobProviderMockExpectations{}),
+		paymentProvider:                   NewPaymentProviderMock(t, PaymentProviderMockExpectations{}),
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,66 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// PaymentProviderMockExpectations defines the expected behavior of the PaymentProviderMock during a test.
+type PaymentProviderMockExpectations struct {
+	// Error is the error to return from VerifyPayment.
+	Error error
+
+	// Outpoint is the outpoint of the paying output to return from VerifyPayment.
+	Outpoint *transaction.Outpoint
+
+	// Satoshis is the amount VerifyPayment is expected to require, checked when set.
+	Satoshis uint64
+
+	// VerifyPaymentCall indicates whether the VerifyPayment method is expected to be called during the test.
+	VerifyPaymentCall bool
+}
+
+// PaymentProviderMock is a mock implementation of a payment provider,
+// used for testing the behavior of components that verify payments.
+type PaymentProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations PaymentProviderMockExpectations
+
+	// called is true if the VerifyPayment method was called.
+	called bool
+}
+
+// VerifyPayment simulates the verification of a payment transaction. It records the call,
+// checks the required amount if expected and returns the predefined outpoint or error.
+func (m *PaymentProviderMock) VerifyPayment(_ context.Context, _ []byte, _ *script.Script, satoshis uint64) (*transaction.Outpoint, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Satoshis != 0 {
+		require.Equal(m.t, m.expectations.Satoshis, satoshis, "Discrepancy between expected and actual VerifyPayment satoshis")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Outpoint, nil
+}
+
+// AssertCalled verifies that the VerifyPayment method was called if it was expected to be.
+func (m *PaymentProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.VerifyPaymentCall, m.called, "Discrepancy between expected and actual VerifyPayment call")
+}
+
+// NewPaymentProviderMock creates a new instance of PaymentProviderMock with the given expectations.
+func NewPaymentProviderMock(t *testing.T, expectations PaymentProviderMockExpectations) *PaymentProviderMock {
+	return &PaymentProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
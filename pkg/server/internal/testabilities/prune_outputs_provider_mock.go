@@ -0,0 +1,59 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// PruneOutputsProviderMockExpectations defines the expected behavior of the PruneOutputsProviderMock during a test.
+type PruneOutputsProviderMockExpectations struct {
+	// Error is the error to return from PruneOutputs.
+	Error error
+
+	// Reports are the prune reports to return from PruneOutputs.
+	Reports []*engine.PruneReport
+
+	// PruneOutputsCall indicates whether the PruneOutputs method is expected to be called during the test.
+	PruneOutputsCall bool
+}
+
+// PruneOutputsProviderMock is a mock implementation of a prune outputs provider,
+// used for testing the behavior of components that apply retention policies.
+type PruneOutputsProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations PruneOutputsProviderMockExpectations
+
+	// called is true if the PruneOutputs method was called.
+	called bool
+}
+
+// PruneOutputs simulates applying the retention policies. It records the call
+// and returns the predefined reports or error.
+func (m *PruneOutputsProviderMock) PruneOutputs(context.Context) ([]*engine.PruneReport, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Reports, nil
+}
+
+// AssertCalled verifies that the PruneOutputs method was called if it was expected to be.
+func (m *PruneOutputsProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.PruneOutputsCall, m.called, "Discrepancy between expected and actual PruneOutputs call")
+}
+
+// NewPruneOutputsProviderMock creates a new instance of PruneOutputsProviderMock with the given expectations.
+func NewPruneOutputsProviderMock(t *testing.T, expectations PruneOutputsProviderMockExpectations) *PruneOutputsProviderMock {
+	return &PruneOutputsProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,65 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// ReorgSimulationProviderMockExpectations defines the expected behavior of the ReorgSimulationProviderMock during a test.
+type ReorgSimulationProviderMockExpectations struct {
+	// Error is the error to return from SimulateReorg.
+	Error error
+
+	// Simulation is the report to return from SimulateReorg.
+	Simulation *engine.ReorgSimulation
+
+	// Depth is the expected reorg depth. It is not verified when zero.
+	Depth uint32
+
+	// SimulateReorgCall indicates whether the SimulateReorg method is expected to be called during the test.
+	SimulateReorgCall bool
+}
+
+// ReorgSimulationProviderMock is a mock implementation of a reorg simulation provider,
+// used for testing the behavior of components that simulate reorgs.
+type ReorgSimulationProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations ReorgSimulationProviderMockExpectations
+
+	// called is true if the SimulateReorg method was called.
+	called bool
+}
+
+// SimulateReorg simulates a reorg simulation. It records the call, verifies the depth
+// against the expectations and returns the predefined report or error.
+func (m *ReorgSimulationProviderMock) SimulateReorg(_ context.Context, depth uint32) (*engine.ReorgSimulation, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Depth != 0 {
+		require.Equal(m.t, m.expectations.Depth, depth, "Discrepancy between expected and actual reorg depth")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Simulation, nil
+}
+
+// AssertCalled verifies that the SimulateReorg method was called if it was expected to be.
+func (m *ReorgSimulationProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SimulateReorgCall, m.called, "Discrepancy between expected and actual SimulateReorg call")
+}
+
+// NewReorgSimulationProviderMock creates a new instance of ReorgSimulationProviderMock with the given expectations.
+func NewReorgSimulationProviderMock(t *testing.T, expectations ReorgSimulationProviderMockExpectations) *ReorgSimulationProviderMock {
+	return &ReorgSimulationProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,81 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// ReplicationProviderMockExpectations defines the expected behavior of the ReplicationProviderMock during a test.
+type ReplicationProviderMockExpectations struct {
+	// Error is the error to return from NextMutations and PromoteStandby.
+	Error error
+
+	// Mutations are the storage mutations to return from NextMutations.
+	Mutations []*engine.Mutation
+
+	// Epoch is the expected epoch passed to NextMutations.
+	Epoch string
+
+	// Since is the expected sequence number passed to NextMutations.
+	Since uint64
+
+	// NextMutationsCall indicates whether the NextMutations method is expected to be called during the test.
+	NextMutationsCall bool
+
+	// PromoteStandbyCall indicates whether the PromoteStandby method is expected to be called during the test.
+	PromoteStandbyCall bool
+}
+
+// ReplicationProviderMock is a mock implementation of a replication provider,
+// used for testing the behavior of components that serve standbys and promote them.
+type ReplicationProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations ReplicationProviderMockExpectations
+
+	// nextMutationsCalled is true if the NextMutations method was called.
+	nextMutationsCalled bool
+
+	// promoteStandbyCalled is true if the PromoteStandby method was called.
+	promoteStandbyCalled bool
+}
+
+// NextMutations simulates returning the storage mutations following a position. It records the call,
+// verifies the position against the expectations and returns the predefined mutations or error.
+func (m *ReplicationProviderMock) NextMutations(_ context.Context, epoch string, since uint64) ([]*engine.Mutation, error) {
+	m.t.Helper()
+	m.nextMutationsCalled = true
+
+	require.Equal(m.t, m.expectations.Epoch, epoch, "Discrepancy between expected and actual epoch")
+	require.Equal(m.t, m.expectations.Since, since, "Discrepancy between expected and actual since")
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Mutations, nil
+}
+
+// PromoteStandby simulates promoting the standby. It records the call and returns the predefined error.
+func (m *ReplicationProviderMock) PromoteStandby(context.Context) error {
+	m.t.Helper()
+	m.promoteStandbyCalled = true
+	return m.expectations.Error
+}
+
+// AssertCalled verifies that the NextMutations and PromoteStandby methods were called if they were expected to be.
+func (m *ReplicationProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.NextMutationsCall, m.nextMutationsCalled, "Discrepancy between expected and actual NextMutations call")
+	require.Equal(m.t, m.expectations.PromoteStandbyCall, m.promoteStandbyCalled, "Discrepancy between expected and actual PromoteStandby call")
+}
+
+// NewReplicationProviderMock creates a new instance of ReplicationProviderMock with the given expectations.
+func NewReplicationProviderMock(t *testing.T, expectations ReplicationProviderMockExpectations) *ReplicationProviderMock {
+	return &ReplicationProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
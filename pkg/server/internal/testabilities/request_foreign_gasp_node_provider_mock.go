@@ -40,6 +40,7 @@ var DefaultRequestForeignGASPNodeProviderMockExpectations = RequestForeignGASPNo
 type RequestForeignGASPNodeProviderMockExpectations struct {
 	Error                      error
 	Node                       *gasp.Node
+	Metadata                   bool
 	ProvideForeignGASPNodeCall bool
 }
 
@@ -51,10 +52,12 @@ type RequestForeignGASPNodeProviderMock struct {
 }
 
 // ProvideForeignGASPNode mocks the ProvideForeignGASPNode method.
-func (m *RequestForeignGASPNodeProviderMock) ProvideForeignGASPNode(_ context.Context, _, _ *transaction.Outpoint, _ string) (*gasp.Node, error) {
+func (m *RequestForeignGASPNodeProviderMock) ProvideForeignGASPNode(_ context.Context, _, _ *transaction.Outpoint, _ string, metadata bool) (*gasp.Node, error) {
 	m.t.Helper()
 	m.called = true
 
+	require.Equal(m.t, m.expectations.Metadata, metadata, "Discrepancy between expected and actual metadata flag")
+
 	if m.expectations.Error != nil {
 		return nil, m.expectations.Error
 	}
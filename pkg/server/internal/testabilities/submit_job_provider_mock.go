@@ -0,0 +1,87 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// SubmitJobProviderMockExpectations defines the expected behavior of the SubmitJobProviderMock during a test.
+type SubmitJobProviderMockExpectations struct {
+	// Error is the error to return from EnqueueSubmit and FindSubmitJob.
+	Error error
+
+	// Job is the job to return from EnqueueSubmit and FindSubmitJob.
+	Job *engine.SubmitJob
+
+	// EnqueueSubmitCall indicates whether the EnqueueSubmit method is expected to be called during the test.
+	EnqueueSubmitCall bool
+
+	// FindSubmitJobCall indicates whether the FindSubmitJob method is expected to be called during the test.
+	FindSubmitJobCall bool
+
+	// CallbackURL, when non-empty, is the callback URL expected to be passed to EnqueueSubmit.
+	CallbackURL string
+}
+
+// SubmitJobProviderMock is a mock implementation of an asynchronous submission provider,
+// used for testing the behavior of components that enqueue submissions and poll their jobs.
+type SubmitJobProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations SubmitJobProviderMockExpectations
+
+	// enqueueSubmitCalled is true if the EnqueueSubmit method was called.
+	enqueueSubmitCalled bool
+
+	// findSubmitJobCalled is true if the FindSubmitJob method was called.
+	findSubmitJobCalled bool
+
+	// calledCallbackURL stores the callback URL argument passed to EnqueueSubmit.
+	calledCallbackURL string
+}
+
+// EnqueueSubmit simulates enqueuing a submission. It records the call and returns the predefined job or error.
+func (m *SubmitJobProviderMock) EnqueueSubmit(_ context.Context, _ overlay.TaggedBEEF, callbackURL string) (*engine.SubmitJob, error) {
+	m.t.Helper()
+	m.enqueueSubmitCalled = true
+	m.calledCallbackURL = callbackURL
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Job, nil
+}
+
+// FindSubmitJob simulates polling a job. It records the call and returns the predefined job or error.
+func (m *SubmitJobProviderMock) FindSubmitJob(context.Context, string) (*engine.SubmitJob, error) {
+	m.t.Helper()
+	m.findSubmitJobCalled = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Job, nil
+}
+
+// AssertCalled verifies that the EnqueueSubmit and FindSubmitJob methods were called if they were expected to be.
+func (m *SubmitJobProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.EnqueueSubmitCall, m.enqueueSubmitCalled, "Discrepancy between expected and actual EnqueueSubmit call")
+	require.Equal(m.t, m.expectations.FindSubmitJobCall, m.findSubmitJobCalled, "Discrepancy between expected and actual FindSubmitJob call")
+	if m.expectations.CallbackURL != "" {
+		require.Equal(m.t, m.expectations.CallbackURL, m.calledCallbackURL, "Discrepancy between expected and actual callback URL")
+	}
+}
+
+// NewSubmitJobProviderMock creates a new instance of SubmitJobProviderMock with the given expectations.
+func NewSubmitJobProviderMock(t *testing.T, expectations SubmitJobProviderMockExpectations) *SubmitJobProviderMock {
+	return &SubmitJobProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -19,11 +19,20 @@ type SubmitTransactionProviderMockExpectations struct {
 	// Error is the error to return from Submit. If set, the callback will not be invoked.
 	Error error
 
-	// SubmitCall indicates whether the Submit method is expected to be called during the test.
+	// SubmitCall indicates whether the Submit method, or Evaluate when DryRun is set, is expected to be called during the test.
 	SubmitCall bool
 
+	// DryRun indicates whether the submission is expected to be evaluated with Evaluate rather than submitted.
+	DryRun bool
+
 	// TriggerCallbackAfter specifies the duration after which the callback should be invoked.
 	TriggerCallbackAfter time.Duration
+
+	// Topics, when non-nil, are the topics expected to be passed to Submit.
+	Topics []string
+
+	// BEEF, when non-nil, is the BEEF expected to be passed to Submit.
+	BEEF []byte
 }
 
 // DefaultSubmitTransactionProviderMockExpectations provides default expectations for SubmitTransactionProviderMock,
@@ -57,6 +66,9 @@ type SubmitTransactionProviderMock struct {
 
 	// calledSubmitMode stores the SubmitMode argument passed to Submit.
 	calledSubmitMode engine.SumbitMode
+
+	// evaluated is true if the call was made to Evaluate rather than Submit.
+	evaluated bool
 }
 
 // Submit simulates the submission of a transaction. It records the call, returns
@@ -68,6 +80,7 @@ func (s *SubmitTransactionProviderMock) Submit(_ context.Context, taggedBEEF ove
 	s.called = true
 	s.calledTaggedBEEF = taggedBEEF
 	s.calledSubmitMode = mode
+	s.evaluated = false
 	s.callbackInvoked = false
 	err := s.expectations.Error
 	s.mu.Unlock()
@@ -86,13 +99,45 @@ func (s *SubmitTransactionProviderMock) Submit(_ context.Context, taggedBEEF ove
 	return overlay.Steak{}, nil
 }
 
+// Evaluate simulates the evaluation of a transaction. It records the call and returns
+// the predefined error if set, or the mock STEAK otherwise.
+func (s *SubmitTransactionProviderMock) Evaluate(_ context.Context, taggedBEEF overlay.TaggedBEEF) (overlay.Steak, error) {
+	s.t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.called = true
+	s.evaluated = true
+	s.calledTaggedBEEF = taggedBEEF
+
+	if s.expectations.Error != nil {
+		return nil, s.expectations.Error
+	}
+	if s.expectations.STEAK == nil {
+		return overlay.Steak{}, nil
+	}
+	return *s.expectations.STEAK, nil
+}
+
 // AssertCalled verifies that the Submit method was called if it was expected to be.
 func (s *SubmitTransactionProviderMock) AssertCalled() {
 	s.t.Helper()
 	s.mu.RLock()
 	called := s.called
+	evaluated := s.evaluated
+	topics := s.calledTaggedBEEF.Topics
+	beef := s.calledTaggedBEEF.Beef
 	s.mu.RUnlock()
 	require.Equal(s.t, s.expectations.SubmitCall, called, "Discrepancy between expected and actual Submit call")
+	if called {
+		require.Equal(s.t, s.expectations.DryRun, evaluated, "Discrepancy between expected and actual dry-run evaluation")
+	}
+	if s.expectations.Topics != nil {
+		require.Equal(s.t, s.expectations.Topics, topics, "Discrepancy between expected and actual submitted topics")
+	}
+	if s.expectations.BEEF != nil {
+		require.Equal(s.t, s.expectations.BEEF, beef, "Discrepancy between expected and actual submitted BEEF")
+	}
 }
 
 // NewSubmitTransactionProviderMock creates a new instance of SubmitTransactionProviderMock with the given expectations.
@@ -0,0 +1,85 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// TopicManagerRegistrationProviderMockExpectations defines the expected behavior of the TopicManagerRegistrationProviderMock during a test.
+type TopicManagerRegistrationProviderMockExpectations struct {
+	// Error is the error to return from AddTopicManager and UnregisterTopicManager.
+	Error error
+
+	// AddTopicManagerCall indicates whether the AddTopicManager method is expected to be called during the test.
+	AddTopicManagerCall bool
+
+	// UnregisterTopicManagerCall indicates whether the UnregisterTopicManager method is expected to be called during the test.
+	UnregisterTopicManagerCall bool
+
+	// Name is the expected topic manager name. It is not verified when empty.
+	Name string
+
+	// SyncConfiguration is the expected sync configuration passed to AddTopicManager.
+	// It is not verified when nil.
+	SyncConfiguration *engine.SyncConfiguration
+}
+
+// TopicManagerRegistrationProviderMock is a mock implementation of a topic manager registration provider,
+// used for testing the behavior of components that register and unregister topic managers.
+type TopicManagerRegistrationProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations TopicManagerRegistrationProviderMockExpectations
+
+	// addCalled is true if the AddTopicManager method was called.
+	addCalled bool
+
+	// unregisterCalled is true if the UnregisterTopicManager method was called.
+	unregisterCalled bool
+}
+
+// AddTopicManager simulates the registration of a topic manager. It records the call,
+// verifies the arguments against the expectations and returns the predefined error if set.
+func (m *TopicManagerRegistrationProviderMock) AddTopicManager(_ context.Context, name string, syncConfig engine.SyncConfiguration) error {
+	m.t.Helper()
+	m.addCalled = true
+
+	if m.expectations.Name != "" {
+		require.Equal(m.t, m.expectations.Name, name, "Discrepancy between expected and actual topic manager name")
+	}
+	if m.expectations.SyncConfiguration != nil {
+		require.Equal(m.t, *m.expectations.SyncConfiguration, syncConfig, "Discrepancy between expected and actual sync configuration")
+	}
+	return m.expectations.Error
+}
+
+// UnregisterTopicManager simulates the removal of a topic manager. It records the call,
+// verifies the name against the expectations and returns the predefined error if set.
+func (m *TopicManagerRegistrationProviderMock) UnregisterTopicManager(_ context.Context, name string) error {
+	m.t.Helper()
+	m.unregisterCalled = true
+
+	if m.expectations.Name != "" {
+		require.Equal(m.t, m.expectations.Name, name, "Discrepancy between expected and actual topic manager name")
+	}
+	return m.expectations.Error
+}
+
+// AssertCalled verifies that the AddTopicManager and UnregisterTopicManager methods were called if they were expected to be.
+func (m *TopicManagerRegistrationProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.AddTopicManagerCall, m.addCalled, "Discrepancy between expected and actual AddTopicManager call")
+	require.Equal(m.t, m.expectations.UnregisterTopicManagerCall, m.unregisterCalled, "Discrepancy between expected and actual UnregisterTopicManager call")
+}
+
+// NewTopicManagerRegistrationProviderMock creates a new instance of TopicManagerRegistrationProviderMock with the given expectations.
+func NewTopicManagerRegistrationProviderMock(t *testing.T, expectations TopicManagerRegistrationProviderMockExpectations) *TopicManagerRegistrationProviderMock {
+	return &TopicManagerRegistrationProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
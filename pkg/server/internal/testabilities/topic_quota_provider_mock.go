@@ -0,0 +1,97 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// TopicQuotaProviderMockExpectations defines the expected behavior of the TopicQuotaProviderMock during a test.
+type TopicQuotaProviderMockExpectations struct {
+	// Error is the error to return from SetTopicQuota and RemoveTopicQuota.
+	Error error
+
+	// Settings are the settings to return from TopicQuotaSettings.
+	Settings engine.TopicQuotaSettings
+
+	// SetTopicQuotaCall indicates whether the SetTopicQuota method is expected to be called during the test.
+	SetTopicQuotaCall bool
+
+	// RemoveTopicQuotaCall indicates whether the RemoveTopicQuota method is expected to be called during the test.
+	RemoveTopicQuotaCall bool
+
+	// Topic is the expected topic. It is not verified when empty.
+	Topic string
+
+	// Quota is the expected quota passed to SetTopicQuota. It is not verified when nil.
+	Quota *engine.TopicQuota
+}
+
+// TopicQuotaProviderMock is a mock implementation of a topic quota provider,
+// used for testing the behavior of components that override the output quotas of topics.
+type TopicQuotaProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations TopicQuotaProviderMockExpectations
+
+	// setCalled is true if the SetTopicQuota method was called.
+	setCalled bool
+
+	// removeCalled is true if the RemoveTopicQuota method was called.
+	removeCalled bool
+}
+
+// SetTopicQuota simulates overriding a topic quota. It records the call, verifies the topic and quota
+// against the expectations and returns the predefined error if set.
+func (m *TopicQuotaProviderMock) SetTopicQuota(_ context.Context, topic string, quota engine.TopicQuota) error {
+	m.t.Helper()
+	m.setCalled = true
+
+	m.verifyTopic(topic)
+	if m.expectations.Quota != nil {
+		require.Equal(m.t, *m.expectations.Quota, quota, "Discrepancy between expected and actual topic quota")
+	}
+	return m.expectations.Error
+}
+
+// RemoveTopicQuota simulates removing a topic quota override. It records the call, verifies the topic
+// against the expectations and returns the predefined error if set.
+func (m *TopicQuotaProviderMock) RemoveTopicQuota(_ context.Context, topic string) error {
+	m.t.Helper()
+	m.removeCalled = true
+
+	m.verifyTopic(topic)
+	return m.expectations.Error
+}
+
+// TopicQuotaSettings returns the predefined settings.
+func (m *TopicQuotaProviderMock) TopicQuotaSettings(_ context.Context) engine.TopicQuotaSettings {
+	m.t.Helper()
+	return m.expectations.Settings
+}
+
+// AssertCalled verifies that the SetTopicQuota and RemoveTopicQuota methods were called if they were expected to be.
+func (m *TopicQuotaProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SetTopicQuotaCall, m.setCalled, "Discrepancy between expected and actual SetTopicQuota call")
+	require.Equal(m.t, m.expectations.RemoveTopicQuotaCall, m.removeCalled, "Discrepancy between expected and actual RemoveTopicQuota call")
+}
+
+func (m *TopicQuotaProviderMock) verifyTopic(topic string) {
+	m.t.Helper()
+
+	if m.expectations.Topic != "" {
+		require.Equal(m.t, m.expectations.Topic, topic, "Discrepancy between expected and actual topic")
+	}
+}
+
+// NewTopicQuotaProviderMock creates a new instance of TopicQuotaProviderMock with the given expectations.
+func NewTopicQuotaProviderMock(t *testing.T, expectations TopicQuotaProviderMockExpectations) *TopicQuotaProviderMock {
+	return &TopicQuotaProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
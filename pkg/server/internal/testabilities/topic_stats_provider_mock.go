@@ -0,0 +1,59 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// TopicStatsProviderMockExpectations defines the expected behavior of the TopicStatsProviderMock during a test.
+type TopicStatsProviderMockExpectations struct {
+	// Error is the error to return from TopicStats.
+	Error error
+
+	// Stats are the statistics to return from TopicStats.
+	Stats *engine.OverlayStats
+
+	// TopicStatsCall indicates whether the TopicStats method is expected to be called during the test.
+	TopicStatsCall bool
+}
+
+// TopicStatsProviderMock is a mock implementation of a topic statistics provider,
+// used for testing the behavior of components that report topic statistics.
+type TopicStatsProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations TopicStatsProviderMockExpectations
+
+	// called is true if the TopicStats method was called.
+	called bool
+}
+
+// TopicStats simulates aggregating the statistics of the hosted topics. It records the call
+// and returns the predefined statistics or error.
+func (m *TopicStatsProviderMock) TopicStats(context.Context) (*engine.OverlayStats, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Stats, nil
+}
+
+// AssertCalled verifies that the TopicStats method was called if it was expected to be.
+func (m *TopicStatsProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.TopicStatsCall, m.called, "Discrepancy between expected and actual TopicStats call")
+}
+
+// NewTopicStatsProviderMock creates a new instance of TopicStatsProviderMock with the given expectations.
+func NewTopicStatsProviderMock(t *testing.T, expectations TopicStatsProviderMockExpectations) *TopicStatsProviderMock {
+	return &TopicStatsProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
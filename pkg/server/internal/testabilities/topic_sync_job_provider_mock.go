@@ -0,0 +1,86 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// TopicSyncJobProviderMockExpectations defines the expected behavior of the TopicSyncJobProviderMock during a test.
+type TopicSyncJobProviderMockExpectations struct {
+	// Error is the error to return from SyncTopic and FindTopicSyncJob.
+	Error error
+
+	// Job is the job to return from SyncTopic and FindTopicSyncJob.
+	Job *engine.TopicSyncJob
+
+	// SyncTopicCall indicates whether the SyncTopic method is expected to be called during the test.
+	SyncTopicCall bool
+
+	// FindTopicSyncJobCall indicates whether the FindTopicSyncJob method is expected to be called during the test.
+	FindTopicSyncJobCall bool
+
+	// Request, when set, is the request expected to be passed to SyncTopic.
+	Request *engine.TopicSyncRequest
+}
+
+// TopicSyncJobProviderMock is a mock implementation of a topic sync job provider,
+// used for testing the behavior of components that start single topic syncs and poll their jobs.
+type TopicSyncJobProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations TopicSyncJobProviderMockExpectations
+
+	// syncTopicCalled is true if the SyncTopic method was called.
+	syncTopicCalled bool
+
+	// findTopicSyncJobCalled is true if the FindTopicSyncJob method was called.
+	findTopicSyncJobCalled bool
+
+	// calledRequest stores the request argument passed to SyncTopic.
+	calledRequest engine.TopicSyncRequest
+}
+
+// SyncTopic simulates starting a topic sync. It records the call and returns the predefined job or error.
+func (m *TopicSyncJobProviderMock) SyncTopic(_ context.Context, request engine.TopicSyncRequest) (*engine.TopicSyncJob, error) {
+	m.t.Helper()
+	m.syncTopicCalled = true
+	m.calledRequest = request
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Job, nil
+}
+
+// FindTopicSyncJob simulates polling a topic sync job. It records the call and returns the predefined job or error.
+func (m *TopicSyncJobProviderMock) FindTopicSyncJob(context.Context, string) (*engine.TopicSyncJob, error) {
+	m.t.Helper()
+	m.findTopicSyncJobCalled = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Job, nil
+}
+
+// AssertCalled verifies that the SyncTopic and FindTopicSyncJob methods were called if they were expected to be.
+func (m *TopicSyncJobProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.SyncTopicCall, m.syncTopicCalled, "Discrepancy between expected and actual SyncTopic call")
+	require.Equal(m.t, m.expectations.FindTopicSyncJobCall, m.findTopicSyncJobCalled, "Discrepancy between expected and actual FindTopicSyncJob call")
+	if m.expectations.Request != nil {
+		require.Equal(m.t, *m.expectations.Request, m.calledRequest, "Discrepancy between expected and actual SyncTopic request")
+	}
+}
+
+// NewTopicSyncJobProviderMock creates a new instance of TopicSyncJobProviderMock with the given expectations.
+func NewTopicSyncJobProviderMock(t *testing.T, expectations TopicSyncJobProviderMockExpectations) *TopicSyncJobProviderMock {
+	return &TopicSyncJobProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
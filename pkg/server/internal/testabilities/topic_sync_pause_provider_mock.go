@@ -0,0 +1,90 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TopicSyncPauseProviderMockExpectations defines the expected behavior of the TopicSyncPauseProviderMock during a test.
+type TopicSyncPauseProviderMockExpectations struct {
+	// Error is the error to return from PauseTopicSync and ResumeTopicSync.
+	Error error
+
+	// PausedTopics is the list of topics to return from PausedSyncTopics.
+	PausedTopics []string
+
+	// PauseTopicSyncCall indicates whether the PauseTopicSync method is expected to be called during the test.
+	PauseTopicSyncCall bool
+
+	// ResumeTopicSyncCall indicates whether the ResumeTopicSync method is expected to be called during the test.
+	ResumeTopicSyncCall bool
+
+	// Topic is the expected topic. It is not verified when empty.
+	Topic string
+}
+
+// TopicSyncPauseProviderMock is a mock implementation of a topic sync pause provider,
+// used for testing the behavior of components that pause and resume the sync of topics.
+type TopicSyncPauseProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations TopicSyncPauseProviderMockExpectations
+
+	// pauseCalled is true if the PauseTopicSync method was called.
+	pauseCalled bool
+
+	// resumeCalled is true if the ResumeTopicSync method was called.
+	resumeCalled bool
+}
+
+// PauseTopicSync simulates pausing the sync of a topic. It records the call, verifies the topic
+// against the expectations and returns the predefined error if set.
+func (m *TopicSyncPauseProviderMock) PauseTopicSync(_ context.Context, topic string) error {
+	m.t.Helper()
+	m.pauseCalled = true
+
+	m.verifyTopic(topic)
+	return m.expectations.Error
+}
+
+// ResumeTopicSync simulates resuming the sync of a topic. It records the call, verifies the topic
+// against the expectations and returns the predefined error if set.
+func (m *TopicSyncPauseProviderMock) ResumeTopicSync(_ context.Context, topic string) error {
+	m.t.Helper()
+	m.resumeCalled = true
+
+	m.verifyTopic(topic)
+	return m.expectations.Error
+}
+
+// PausedSyncTopics returns the predefined list of paused topics.
+func (m *TopicSyncPauseProviderMock) PausedSyncTopics(_ context.Context) []string {
+	m.t.Helper()
+	return m.expectations.PausedTopics
+}
+
+// AssertCalled verifies that the PauseTopicSync and ResumeTopicSync methods were called if they were expected to be.
+func (m *TopicSyncPauseProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.PauseTopicSyncCall, m.pauseCalled, "Discrepancy between expected and actual PauseTopicSync call")
+	require.Equal(m.t, m.expectations.ResumeTopicSyncCall, m.resumeCalled, "Discrepancy between expected and actual ResumeTopicSync call")
+}
+
+func (m *TopicSyncPauseProviderMock) verifyTopic(topic string) {
+	m.t.Helper()
+
+	if m.expectations.Topic != "" {
+		require.Equal(m.t, m.expectations.Topic, topic, "Discrepancy between expected and actual topic")
+	}
+}
+
+// NewTopicSyncPauseProviderMock creates a new instance of TopicSyncPauseProviderMock with the given expectations.
+func NewTopicSyncPauseProviderMock(t *testing.T, expectations TopicSyncPauseProviderMockExpectations) *TopicSyncPauseProviderMock {
+	return &TopicSyncPauseProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
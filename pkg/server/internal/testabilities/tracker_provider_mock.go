@@ -0,0 +1,97 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// TrackerProviderMockExpectations defines the expected behavior of the TrackerProviderMock during a test.
+type TrackerProviderMockExpectations struct {
+	// Error is the error to return from AddTracker and RemoveTracker.
+	Error error
+
+	// Trackers are the trackers to return from Trackers.
+	Trackers engine.Trackers
+
+	// AddTrackerCall indicates whether the AddTracker method is expected to be called during the test.
+	AddTrackerCall bool
+
+	// RemoveTrackerCall indicates whether the RemoveTracker method is expected to be called during the test.
+	RemoveTrackerCall bool
+
+	// Kind is the expected tracker kind. It is not verified when empty.
+	Kind engine.TrackerKind
+
+	// Tracker is the expected tracker URL. It is not verified when empty.
+	Tracker string
+}
+
+// TrackerProviderMock is a mock implementation of a tracker provider,
+// used for testing the behavior of components that add and remove SHIP and SLAP trackers.
+type TrackerProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations TrackerProviderMockExpectations
+
+	// addCalled is true if the AddTracker method was called.
+	addCalled bool
+
+	// removeCalled is true if the RemoveTracker method was called.
+	removeCalled bool
+}
+
+// AddTracker simulates adding a tracker. It records the call, verifies the kind and tracker
+// against the expectations and returns the predefined error if set.
+func (m *TrackerProviderMock) AddTracker(_ context.Context, kind engine.TrackerKind, tracker string) error {
+	m.t.Helper()
+	m.addCalled = true
+
+	m.verifyTracker(kind, tracker)
+	return m.expectations.Error
+}
+
+// RemoveTracker simulates removing a tracker. It records the call, verifies the kind and tracker
+// against the expectations and returns the predefined error if set.
+func (m *TrackerProviderMock) RemoveTracker(_ context.Context, kind engine.TrackerKind, tracker string) error {
+	m.t.Helper()
+	m.removeCalled = true
+
+	m.verifyTracker(kind, tracker)
+	return m.expectations.Error
+}
+
+// Trackers returns the predefined trackers.
+func (m *TrackerProviderMock) Trackers(_ context.Context) engine.Trackers {
+	m.t.Helper()
+	return m.expectations.Trackers
+}
+
+// AssertCalled verifies that the AddTracker and RemoveTracker methods were called if they were expected to be.
+func (m *TrackerProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.AddTrackerCall, m.addCalled, "Discrepancy between expected and actual AddTracker call")
+	require.Equal(m.t, m.expectations.RemoveTrackerCall, m.removeCalled, "Discrepancy between expected and actual RemoveTracker call")
+}
+
+func (m *TrackerProviderMock) verifyTracker(kind engine.TrackerKind, tracker string) {
+	m.t.Helper()
+
+	if m.expectations.Kind != "" {
+		require.Equal(m.t, m.expectations.Kind, kind, "Discrepancy between expected and actual tracker kind")
+	}
+	if m.expectations.Tracker != "" {
+		require.Equal(m.t, m.expectations.Tracker, tracker, "Discrepancy between expected and actual tracker")
+	}
+}
+
+// NewTrackerProviderMock creates a new instance of TrackerProviderMock with the given expectations.
+func NewTrackerProviderMock(t *testing.T, expectations TrackerProviderMockExpectations) *TrackerProviderMock {
+	return &TrackerProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,65 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// UTXOHistoryProviderMockExpectations defines the expected behavior of the UTXOHistoryProviderMock during a test.
+type UTXOHistoryProviderMockExpectations struct {
+	// Error is the error to return from FindUTXOHistory.
+	Error error
+
+	// Output is the hydrated output to return from FindUTXOHistory.
+	Output *engine.Output
+
+	// Query is the query FindUTXOHistory is expected to receive, checked when set.
+	Query *engine.UTXOHistoryQuery
+
+	// FindUTXOHistoryCall indicates whether the FindUTXOHistory method is expected to be called during the test.
+	FindUTXOHistoryCall bool
+}
+
+// UTXOHistoryProviderMock is a mock implementation of an output history provider,
+// used for testing the behavior of components that retrieve output histories.
+type UTXOHistoryProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations UTXOHistoryProviderMockExpectations
+
+	// called is true if the FindUTXOHistory method was called.
+	called bool
+}
+
+// FindUTXOHistory simulates hydrating the history of an output. It records the call,
+// checks the query if expected and returns the predefined output or error.
+func (m *UTXOHistoryProviderMock) FindUTXOHistory(_ context.Context, query engine.UTXOHistoryQuery) (*engine.Output, error) {
+	m.t.Helper()
+	m.called = true
+
+	if m.expectations.Query != nil {
+		require.Equal(m.t, *m.expectations.Query, query, "Discrepancy between expected and actual FindUTXOHistory query")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Output, nil
+}
+
+// AssertCalled verifies that the FindUTXOHistory method was called if it was expected to be.
+func (m *UTXOHistoryProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.FindUTXOHistoryCall, m.called, "Discrepancy between expected and actual FindUTXOHistory call")
+}
+
+// NewUTXOHistoryProviderMock creates a new instance of UTXOHistoryProviderMock with the given expectations.
+func NewUTXOHistoryProviderMock(t *testing.T, expectations UTXOHistoryProviderMockExpectations) *UTXOHistoryProviderMock {
+	return &UTXOHistoryProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
@@ -0,0 +1,115 @@
+package testabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/stretchr/testify/require"
+)
+
+// WebhookProviderMockExpectations defines the expected behavior of the WebhookProviderMock during a test.
+type WebhookProviderMockExpectations struct {
+	// Error is the error to return from RegisterWebhook, ListWebhooks and UnregisterWebhook.
+	Error error
+
+	// Subscription is the subscription to return from RegisterWebhook.
+	Subscription *engine.WebhookSubscription
+
+	// Subscriptions are the subscriptions to return from ListWebhooks.
+	Subscriptions []*engine.WebhookSubscription
+
+	// URL is the expected callback URL of the registered webhook. It is not verified when empty.
+	URL string
+
+	// Topics are the expected topics of the registered webhook. They are not verified when nil.
+	Topics []string
+
+	// ID is the expected ID of the unregistered webhook. It is not verified when empty.
+	ID string
+
+	// RegisterWebhookCall indicates whether the RegisterWebhook method is expected to be called during the test.
+	RegisterWebhookCall bool
+
+	// ListWebhooksCall indicates whether the ListWebhooks method is expected to be called during the test.
+	ListWebhooksCall bool
+
+	// UnregisterWebhookCall indicates whether the UnregisterWebhook method is expected to be called during the test.
+	UnregisterWebhookCall bool
+}
+
+// WebhookProviderMock is a mock implementation of a webhook provider,
+// used for testing the behavior of components that manage webhook subscriptions.
+type WebhookProviderMock struct {
+	t *testing.T
+
+	// expectations defines the expected behavior and outcomes for this mock.
+	expectations WebhookProviderMockExpectations
+
+	// registerCalled is true if the RegisterWebhook method was called.
+	registerCalled bool
+
+	// listCalled is true if the ListWebhooks method was called.
+	listCalled bool
+
+	// unregisterCalled is true if the UnregisterWebhook method was called.
+	unregisterCalled bool
+}
+
+// RegisterWebhook simulates registering a webhook subscription. It records the call, verifies the URL
+// and topics against the expectations and returns the predefined subscription or error.
+func (m *WebhookProviderMock) RegisterWebhook(_ context.Context, callbackURL string, topics []string, _ string) (*engine.WebhookSubscription, error) {
+	m.t.Helper()
+	m.registerCalled = true
+
+	if m.expectations.URL != "" {
+		require.Equal(m.t, m.expectations.URL, callbackURL, "Discrepancy between expected and actual webhook URL")
+	}
+	if m.expectations.Topics != nil {
+		require.Equal(m.t, m.expectations.Topics, topics, "Discrepancy between expected and actual webhook topics")
+	}
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Subscription, nil
+}
+
+// ListWebhooks simulates listing the webhook subscriptions. It records the call
+// and returns the predefined subscriptions or error.
+func (m *WebhookProviderMock) ListWebhooks(context.Context) ([]*engine.WebhookSubscription, error) {
+	m.t.Helper()
+	m.listCalled = true
+
+	if m.expectations.Error != nil {
+		return nil, m.expectations.Error
+	}
+	return m.expectations.Subscriptions, nil
+}
+
+// UnregisterWebhook simulates unregistering a webhook subscription. It records the call,
+// verifies the ID against the expectations and returns the predefined error.
+func (m *WebhookProviderMock) UnregisterWebhook(_ context.Context, id string) error {
+	m.t.Helper()
+	m.unregisterCalled = true
+
+	if m.expectations.ID != "" {
+		require.Equal(m.t, m.expectations.ID, id, "Discrepancy between expected and actual webhook ID")
+	}
+	return m.expectations.Error
+}
+
+// AssertCalled verifies that the RegisterWebhook, ListWebhooks and UnregisterWebhook methods were called if they were expected to be.
+func (m *WebhookProviderMock) AssertCalled() {
+	m.t.Helper()
+	require.Equal(m.t, m.expectations.RegisterWebhookCall, m.registerCalled, "Discrepancy between expected and actual RegisterWebhook call")
+	require.Equal(m.t, m.expectations.ListWebhooksCall, m.listCalled, "Discrepancy between expected and actual ListWebhooks call")
+	require.Equal(m.t, m.expectations.UnregisterWebhookCall, m.unregisterCalled, "Discrepancy between expected and actual UnregisterWebhook call")
+}
+
+// NewWebhookProviderMock creates a new instance of WebhookProviderMock with the given expectations.
+func NewWebhookProviderMock(t *testing.T, expectations WebhookProviderMockExpectations) *WebhookProviderMock {
+	return &WebhookProviderMock{
+		t:            t,
+		expectations: expectations,
+	}
+}
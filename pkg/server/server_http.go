@@ -5,12 +5,19 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"sync"
 	"time"
 
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/headers"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/adapters"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
+	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
 	"github.com/google/uuid"
@@ -18,10 +25,12 @@ import (
 
 //go:generate go tool oapi-codegen --config=../../api/openapi/server/api-cfg.yaml         ../../api/openapi/server/api.yaml
 //go:generate go tool oapi-codegen --config=../../api/openapi/paths/admin/responses-cfg.yaml ../../api/openapi/paths/admin/responses.yaml
+//go:generate go tool oapi-codegen --config=../../api/openapi/paths/admin/request-bodies-cfg.yaml ../../api/openapi/paths/admin/request-bodies.yaml
 //go:generate go tool oapi-codegen --config=../../api/openapi/paths/non_admin/responses-cfg.yaml ../../api/openapi/paths/non_admin/responses.yaml
 //go:generate go tool oapi-codegen --config=../../api/openapi/paths/non_admin/request-bodies-cfg.yaml ../../api/openapi/paths/non_admin/request-bodies.yaml
 
-// Config holds the configuration settings for the HTTP server
+// Config holds the configuration settings for the HTTP server.
+// The engine settings are applied to the engine by New.
 type Config struct {
 	// AppName is the name of the application.
 	AppName string `mapstructure:"app_name"`
@@ -42,6 +51,22 @@ type Config struct {
 	// This limit by default is set to 1GB to protect against excessively large payloads.
 	OctetStreamLimit int64 `mapstructure:"octet_stream_limit"`
 
+	// RequestBody configures per-route body limits, the spooling of large streamed bodies to disk
+	// and the backpressure applied when too many bodies are received at once.
+	RequestBody RequestBodyConfig `mapstructure:"request_body"`
+
+	// Compression configures the compression of response bodies with brotli, gzip or deflate, as negotiated
+	// through the Accept-Encoding header of each request.
+	Compression CompressionConfig `mapstructure:"compression"`
+
+	// LogLevel is the level of the default logger, such as debug, info, warn or error.
+	// Empty leaves the level unchanged. It can be changed by reloading the configuration.
+	LogLevel string `mapstructure:"log_level"`
+
+	// RateLimit bounds the rate of requests of every client, identified by its IP address.
+	// It can be changed by reloading the configuration.
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
 	// ConnectionReadTimeout defines the maximum duration an active connection is allowed to stay open.
 	// Once this threshold is exceeded, the connection will be forcefully closed.
 	ConnectionReadTimeout time.Duration `mapstructure:"connection_read_timeout_limit"`
@@ -51,6 +76,182 @@ type Config struct {
 
 	// ARCCallbackToken is the token for authenticating ARC callback requests.
 	ARCCallbackToken string `mapstructure:"arc_callback_token"`
+
+	// ARC configures multiple ARC instances with independent keys, health checks and rotating callback tokens.
+	// New applies it to the engine through engine.NewARCPool and engine.Engine.Broadcaster, and accepts the callback tokens of the pool unless WithARCCallbackTokens is set.
+	ARC engine.ARCPoolConfig `mapstructure:"arc"`
+
+	// ReplicationToken is the token standbys present to stream the storage mutations of this node.
+	// An empty token disables the replication stream endpoint.
+	ReplicationToken string `mapstructure:"replication_token"`
+
+	// Standby configures this node as a warm standby following a primary.
+	// New applies it to the engine through engine.NewHTTPReplicationSource, engine.NewReplicationFollower and engine.Engine.Standby.
+	Standby engine.StandbyConfig `mapstructure:"standby"`
+
+	// SubmitTopics defines the topics policy enforced when transactions are submitted.
+	SubmitTopics SubmitTopicsPolicy `mapstructure:"submit_topics"`
+
+	// SubmitLimits bounds the BEEFs of submitted transactions and enables their early structural validation.
+	SubmitLimits SubmitBEEFLimits `mapstructure:"submit_limits"`
+
+	// SubmitTimeout bounds the time a synchronous submission may take. Submissions exceeding it are answered with
+	// 504 Gateway Timeout and are not applied. Zero leaves submissions unbounded.
+	SubmitTimeout time.Duration `mapstructure:"submit_timeout"`
+
+	// AccessControl restricts the API keys permitted to access private topics and query private lookup services.
+	AccessControl AccessControlList `mapstructure:"access_control"`
+
+	// BRC31 configures the BRC-31 (Authrite) mutual authentication of incoming requests.
+	// Enable it by passing the wallet holding the server identity through WithBRC31Wallet.
+	// Authenticate outbound GASP and broadcast peer calls through engine.GASPRemoteConfig.Wallet
+	// and engine.NewAuthBroadcastFacilitator with engine.Engine.BroadcastFacilitator.
+	BRC31 BRC31AuthConfig `mapstructure:"brc31"`
+
+	// Payments prices lookup and submit requests, charged to BRC-31 authenticated clients with BRC-105 payments
+	// received into the wallet passed through WithBRC31Wallet, without which New fails. Payment transactions are
+	// verified with SPV and broadcast through engine.Engine.VerifyPayment.
+	Payments PaymentConfig `mapstructure:"payments"`
+
+	// Audit configures the audit log of the admin API calls changing the state of the node and of the
+	// transaction submissions, recorded with their caller and result.
+	Audit AuditConfig `mapstructure:"audit"`
+
+	// SubmitJobs configures the queue of asynchronous submissions made with mode=async.
+	// New applies it to the engine through engine.NewSubmitJobQueue and engine.Engine.SubmitJobs.
+	SubmitJobs engine.SubmitJobQueueConfig `mapstructure:"submit_jobs"`
+
+	// Idempotency configures how long successful submissions are remembered, so that retried submissions
+	// carrying the same Idempotency-Key header, or the same transaction and topics, return the original STEAK.
+	// New applies it to the engine through engine.NewSubmitIdempotency and engine.Engine.Idempotency.
+	Idempotency engine.SubmitIdempotencyConfig `mapstructure:"idempotency"`
+
+	// SubmitReplays configures the window in which a transaction submitted again, as when several peers forward it,
+	// returns the STEAK of its original submission without being verified again.
+	// New applies it to the engine through engine.NewSubmitReplayCache and engine.Engine.SubmitReplays.
+	SubmitReplays engine.SubmitReplayCacheConfig `mapstructure:"submit_replays"`
+
+	// Webhooks configures the delivery of the STEAK of submissions to the webhook subscriptions
+	// registered through the admin API. New applies it to the engine through engine.NewWebhooks and engine.Engine.Webhooks.
+	Webhooks engine.WebhooksConfig `mapstructure:"webhooks"`
+
+	// LookupCache configures the caching of lookup answers per lookup service, invalidated whenever the
+	// service is notified of an admitted, spent or evicted output.
+	// New applies it to the engine through engine.NewLookupCacheWithConfig and engine.Engine.LocalLookupCache.
+	LookupCache engine.LookupCacheConfig `mapstructure:"lookup_cache"`
+
+	// LookupResolver configures the caching of SHIP and SLAP tracker answers, including failed queries, and the
+	// exponential backoff from unreachable trackers used for SHIP-based syncs and proxied lookups.
+	// New applies it to the engine through engine.NewLookupResolverWithConfig and engine.Engine.LookupResolver.
+	LookupResolver engine.LookupResolverConfig `mapstructure:"lookup_resolver"`
+
+	// GASPPeers holds per-peer HTTP client settings (timeouts, retries, auth, TLS) used for GASP sync,
+	// keyed by peer URL. New applies them to the engine through engine.SyncConfiguration.PeerRemotes.
+	GASPPeers map[string]engine.GASPRemoteConfig `mapstructure:"gasp_peers"`
+
+	// SyncPeers holds the peers every topic is synced with through GASP, keyed by topic.
+	// New applies them to the engine through engine.SyncConfiguration.Peers.
+	SyncPeers map[string][]string `mapstructure:"sync_peers"`
+
+	// SyncInterval is the interval of the periodic GASP syncs of every topic. Zero disables them.
+	// New applies it to the engine through engine.NewPeriodicSync and engine.Engine.PeriodicSync.
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+
+	// Retention holds the per-topic retention policies and the interval of the background pruner.
+	// New applies it to the engine through engine.Engine.Retention.
+	Retention engine.RetentionConfig `mapstructure:"retention"`
+
+	// ProofFetcher configures the background acquisition of merkle proofs missed by ARC callbacks.
+	// New applies it to the engine through engine.NewMerkleProofFetcher and engine.Engine.ProofFetcher.
+	ProofFetcher engine.MerkleProofFetcherConfig `mapstructure:"proof_fetcher"`
+
+	// UnminedEviction configures the re-broadcast and eviction of transactions that stay unmined for too long.
+	// New applies it to the engine through engine.NewUnminedEvictor and engine.Engine.UnminedEviction.
+	UnminedEviction engine.UnminedEvictionConfig `mapstructure:"unmined_eviction"`
+
+	// SpendReconciliation configures the per-topic reconciliation of unspent outputs against an external UTXO source.
+	// New applies it to the engine through engine.NewSpendReconciler and engine.Engine.SpendReconciler.
+	SpendReconciliation engine.SpendReconciliationConfig `mapstructure:"spend_reconciliation"`
+
+	// LookupNotifications sets, per lookup service and per hook, whether failing to notify it fails the submission,
+	// is ignored or is retried in the background.
+	// New applies it to the engine through engine.NewLookupNotifications and engine.Engine.LookupNotifications.
+	LookupNotifications engine.LookupNotificationsConfig `mapstructure:"lookup_notifications"`
+
+	// BroadcastRetry configures the backoff and attempt limit of re-broadcasting transactions whose broadcast failed.
+	// New applies it to the engine through engine.NewBroadcastRetry and engine.Engine.BroadcastRetry.
+	BroadcastRetry engine.BroadcastRetryConfig `mapstructure:"broadcast_retry"`
+
+	// PropagateHistorical makes historical submissions, such as transactions learned through GASP, propagate
+	// to overlay peers while still never being broadcast to miners.
+	// New applies it to the engine through engine.Engine.PropagateHistorical.
+	PropagateHistorical bool `mapstructure:"propagate_historical"`
+
+	// History bounds the depth and size of the output histories served by the history endpoint.
+	// New applies it to the engine through engine.Engine.HistoryLimits.
+	History engine.UTXOHistoryLimits `mapstructure:"history"`
+
+	// AdvertisementBudget configures how the cost of advertisement batches is estimated and capped.
+	// New applies it to the engine through engine.Engine.AdvertisementBudget.
+	AdvertisementBudget engine.AdvertisementBudget `mapstructure:"advertisement_budget"`
+
+	// SPV configures the worker pool verifying the merkle paths and scripts of submitted transactions in parallel.
+	// New applies it to the engine through engine.NewSPVVerifier and engine.Engine.SPVVerifier.
+	SPV engine.SPVVerifierConfig `mapstructure:"spv"`
+
+	// SubmitScheduler bounds the submissions processed at once and shares the free slots fairly between topics.
+	// New applies it to the engine through engine.NewSubmitScheduler and engine.Engine.SubmitScheduler.
+	SubmitScheduler engine.SubmitSchedulerConfig `mapstructure:"submit_scheduler"`
+
+	// GASPServe bounds the GASP sync requests served to each remote peer and the size of their responses.
+	// New applies it to the engine through engine.NewGASPServeLimiter and engine.Engine.GASPServeLimiter.
+	GASPServe engine.GASPServeLimits `mapstructure:"gasp_serve"`
+
+	// TopicQuotas bounds the outputs topic managers admit per transaction and per topic.
+	// New applies it to the engine through engine.NewTopicQuotas and engine.Engine.OutputQuotas.
+	TopicQuotas engine.TopicQuotasConfig `mapstructure:"topic_quotas"`
+
+	// TopicManagerSandbox bounds the time and memory each call of a topic manager may take, per topic.
+	// New applies it to the engine through engine.NewTopicManagerSandbox and engine.Engine.ManagerSandbox.
+	TopicManagerSandbox engine.TopicManagerSandboxConfig `mapstructure:"topic_manager_sandbox"`
+
+	// ScoreStrategy names the strategy scoring admitted outputs for GASP paging: "height", "timestamp" or "hybrid".
+	// Empty lets the storage assign scores.
+	// New applies it to the engine through engine.NewScoreStrategy and engine.Engine.ScoreStrategy.
+	ScoreStrategy string `mapstructure:"score_strategy"`
+
+	// Tombstones makes the engine soft-delete the outputs it removes and purge them after their retention.
+	// New applies it to the engine through engine.Engine.Tombstones.
+	Tombstones engine.TombstoneConfig `mapstructure:"tombstones"`
+
+	// MerkleStates configures the background sync invalidating reorged outputs and making confirmed ones immutable.
+	// New applies it to the engine through engine.Engine.MerkleStates.
+	MerkleStates engine.MerkleStateConfig `mapstructure:"merkle_states"`
+
+	// BEEFCompaction configures the background rewrite of the BEEF of deeply confirmed transactions to its minimal form.
+	// New applies it to the engine through engine.NewBEEFCompactor and engine.Engine.BEEFCompactor.
+	BEEFCompaction engine.BEEFCompactionConfig `mapstructure:"beef_compaction"`
+
+	// VerifiedTxCache bounds the cache of transactions whose SPV proofs were already validated against the chain tracker.
+	// New applies it to the engine through engine.NewVerifiedTxCache and engine.Engine.VerifiedTxs.
+	VerifiedTxCache engine.VerifiedTxCacheConfig `mapstructure:"verified_tx_cache"`
+
+	// OutpointFilter sizes the per-topic filters used to skip storage lookups of inputs that are not topic outputs.
+	// New applies it to the engine through engine.NewOutpointFilter and engine.Engine.OutpointFilter.
+	OutpointFilter engine.OutpointFilterConfig `mapstructure:"outpoint_filter"`
+
+	// Headers configures the block header source and local header store of the built-in chain tracker.
+	// New applies it to the engine through headers.NewTrackerFromConfig and engine.Engine.ChainTracker, and syncs the tracker while serving.
+	Headers headers.Config `mapstructure:"headers"`
+
+	// Advertiser configures the identity and domain of the SHIP/SLAP advertisements of the node.
+	// New applies it to the engine through advertiser.NewWalletAdvertiser, with the BRC-100 wallet funding
+	// the advertisements set through WithAdvertiserWallet, and engine.Engine.Advertiser.
+	Advertiser advertiser.WalletAdvertiserConfig `mapstructure:"advertiser"`
+
+	// AdmissionOracles holds the external admission oracles of topics delegating their admission decisions,
+	// keyed by topic name. New registers them with the engine as topic managers through engine.NewAdmissionOracle.
+	AdmissionOracles map[string]engine.AdmissionOracleConfig `mapstructure:"admission_oracles"`
 }
 
 // DefaultConfig provides a default configuration with reasonable values for local development.
@@ -61,11 +262,138 @@ var DefaultConfig = Config{
 	ServerHeader:          "Overlay API",
 	AdminBearerToken:      uuid.NewString(),
 	OctetStreamLimit:      middleware.ReadBodyLimit1GB,
+	RequestBody:           RequestBodyConfig{InMemoryLimit: middleware.DefaultInMemoryBodyLimit},
 	ConnectionReadTimeout: 10 * time.Second,
 	ARCAPIKey:             "",
 	ARCCallbackToken:      uuid.NewString(),
 }
 
+// RequestBodyConfig configures the ingestion of request bodies. The server streams bodies larger than
+// InMemoryLimit instead of buffering them, rejects them early when their Content-Length exceeds their limit,
+// and spools them to a temporary file while they are received.
+type RequestBodyConfig struct {
+	// InMemoryLimit is the size (in bytes) up to which request bodies are buffered in memory.
+	// It is also the maximum size of request bodies other than octet-streams.
+	InMemoryLimit int64 `mapstructure:"in_memory_limit"`
+
+	// RouteLimits maps a route path, such as /api/v1/submit, to the maximum size (in bytes) of its
+	// request bodies, overriding OctetStreamLimit and InMemoryLimit for that route.
+	RouteLimits map[string]int64 `mapstructure:"route_limits"`
+
+	// SpoolDir is the directory of the spool files. Empty uses the default directory for temporary files.
+	SpoolDir string `mapstructure:"spool_dir"`
+
+	// MaxInFlightBytes bounds the total size of the streamed request bodies received at once. Requests
+	// that do not fit wait for earlier ones to complete, up to MaxWait, and are then rejected with 503.
+	// Zero means unlimited.
+	MaxInFlightBytes int64 `mapstructure:"max_in_flight_bytes"`
+
+	// MaxWait bounds the wait for room in the in-flight bytes budget. Zero defaults to 5 seconds.
+	MaxWait time.Duration `mapstructure:"max_wait"`
+}
+
+// CompressionConfig configures the compression of response bodies. Only requests carrying an Accept-Encoding
+// header receive compressed bodies, so that large lookup answers and histories cost less bandwidth to clients
+// that can decode them.
+type CompressionConfig struct {
+	// Level trades CPU for size: "speed", "best" or "default", which is also used when empty.
+	// "disabled" turns compression off.
+	Level string `mapstructure:"level"`
+}
+
+// RateLimitConfig bounds the rate of requests of every client. Clients exceeding it are rejected with 429
+// and a Retry-After header. The zero value applies no limit.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained number of requests per second allowed to every client. Zero means unlimited.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// Burst is the number of requests a client may make at once above the sustained rate.
+	// Zero allows bursts of one second worth of requests.
+	Burst int `mapstructure:"burst"`
+}
+
+// SubmitTopicsPolicy controls which topics are evaluated for transactions submitted to the server.
+// Clients authenticated with the admin bearer token are treated as trusted.
+type SubmitTopicsPolicy struct {
+	// AutoAdded topics are evaluated for every submission, even when the client did not tag them.
+	AutoAdded []string `mapstructure:"auto_added"`
+
+	// Explicit, when non-empty, is the set of topics clients may tag; any other topic is rejected.
+	Explicit []string `mapstructure:"explicit"`
+
+	// TrustedOnly topics are rejected when requested by untrusted clients.
+	TrustedOnly []string `mapstructure:"trusted_only"`
+}
+
+// SubmitBEEFLimits bounds the BEEFs of transactions submitted to the server. Submissions exceeding
+// a limit are rejected with 413 and malformed BEEFs with 422, before they reach the engine.
+// The zero value applies no limits and leaves BEEF validation to the engine.
+type SubmitBEEFLimits struct {
+	// MaxBytes is the maximum size of a submitted BEEF in bytes. Zero means unlimited,
+	// although the octet-stream limit still applies.
+	MaxBytes int `mapstructure:"max_bytes"`
+
+	// MaxTransactions is the maximum number of transactions carried by a submitted BEEF.
+	// Zero means unlimited. A non-zero value implies ValidateStructure.
+	MaxTransactions int `mapstructure:"max_transactions"`
+
+	// ValidateStructure parses submitted BEEFs and rejects malformed ones before they reach the engine.
+	ValidateStructure bool `mapstructure:"validate_structure"`
+}
+
+// AccessControlList restricts which API keys may submit transactions to topics, read their outputs and query
+// lookup services. Clients present their API key as a Bearer token. Requests for a listed topic or service without
+// a permitted key are rejected with 403, including submissions the topics policy auto-adds a listed topic to, and
+// the history, output and GASP sync routes of the topic. Topics and services that are not listed are open to every client.
+type AccessControlList struct {
+	// Topics maps a topic to the API keys permitted to submit transactions to it and read its outputs.
+	Topics map[string][]string `mapstructure:"topics"`
+
+	// LookupServices maps a lookup service to the API keys permitted to query it.
+	LookupServices map[string][]string `mapstructure:"lookup_services"`
+}
+
+// BRC31AuthConfig configures the BRC-31 (Authrite) mutual authentication of incoming requests.
+// The identity key of every authenticated request is logged.
+type BRC31AuthConfig struct {
+	// Required rejects requests that are not BRC-31 authenticated with 401.
+	// Otherwise they are served anonymously, as without BRC-31.
+	Required bool `mapstructure:"required"`
+
+	// Identities, when non-empty, maps each identity key permitted to make authenticated requests
+	// to the path prefixes it may access. Other identities and paths are rejected with 403.
+	Identities map[string][]string `mapstructure:"identities"`
+}
+
+// PaymentConfig prices the requests paid for with BRC-105 payments. Zero prices serve requests for free.
+// Clients without a payment are answered with 402 Payment Required and the terms of the payment.
+type PaymentConfig struct {
+	// SatoshisPerLookup is the price of a lookup request.
+	SatoshisPerLookup uint64 `mapstructure:"satoshis_per_lookup"`
+
+	// SatoshisPerSubmitKB is the price of every started kilobyte of a submitted transaction.
+	SatoshisPerSubmitKB uint64 `mapstructure:"satoshis_per_submit_kb"`
+}
+
+// enabled reports whether any request is priced.
+func (p PaymentConfig) enabled() bool {
+	return p.SatoshisPerLookup > 0 || p.SatoshisPerSubmitKB > 0
+}
+
+// errPaymentsWalletRequired is returned when the Payments configuration prices requests without WithBRC31Wallet.
+var errPaymentsWalletRequired = errors.New("payments require a wallet set through WithBRC31Wallet")
+
+// AuditConfig configures the audit log of admin actions and submissions.
+type AuditConfig struct {
+	// Enabled records the admin API calls other than GET and the transaction submissions to the audit log
+	// of the engine, which is queried through the admin API.
+	Enabled bool `mapstructure:"enabled"`
+
+	// File is the path of the append-only audit log file.
+	// New applies it to the engine through engine.NewFileAuditStore and engine.Engine.Audit.
+	File string `mapstructure:"file"`
+}
+
 // Option defines a functional option for configuring an HTTP server.
 // These options allow for flexible setup of middlewares and configurations.
 type Option func(*HTTP)
@@ -87,6 +415,63 @@ func WithARCCallbackToken(token string) Option {
 	}
 }
 
+// WithARCCallbackTokens accepts ARC callbacks presenting any token verified by the verifier, such as the
+// current and recently rotated callback tokens of an engine.ARCPool, in addition to the ARC callback token.
+// It returns an Option that applies this configuration to HTTP.
+func WithARCCallbackTokens(verifier ARCCallbackTokenVerifier) Option {
+	return func(s *HTTP) {
+		s.arcCallbackTokens = verifier
+	}
+}
+
+// WithReplicationToken sets the token standbys present to stream the storage mutations of this node.
+// It returns an Option that applies this configuration to HTTP.
+func WithReplicationToken(token string) Option {
+	return func(s *HTTP) {
+		s.cfg.ReplicationToken = token
+	}
+}
+
+// WithSubmitTopicsPolicy sets the topics policy enforced when transactions are submitted.
+// It returns an Option that applies this configuration to HTTP.
+func WithSubmitTopicsPolicy(policy SubmitTopicsPolicy) Option {
+	return func(s *HTTP) {
+		s.cfg.SubmitTopics = policy
+	}
+}
+
+// WithSubmitBEEFLimits sets the limits and structural validation applied to the BEEFs of submitted transactions.
+// It returns an Option that applies this configuration to HTTP.
+func WithSubmitBEEFLimits(limits SubmitBEEFLimits) Option {
+	return func(s *HTTP) {
+		s.cfg.SubmitLimits = limits
+	}
+}
+
+// WithSubmitTimeout sets the time a synchronous submission may take before it is answered with 504 Gateway Timeout.
+// It returns an Option that applies this configuration to HTTP.
+func WithSubmitTimeout(timeout time.Duration) Option {
+	return func(s *HTTP) {
+		s.cfg.SubmitTimeout = timeout
+	}
+}
+
+// WithAccessControlList sets the API keys permitted to access topics and query lookup services.
+// It returns an Option that applies this configuration to HTTP.
+func WithAccessControlList(acl AccessControlList) Option {
+	return func(s *HTTP) {
+		s.cfg.AccessControl = acl
+	}
+}
+
+// WithBRC31Wallet enables BRC-31 mutual authentication of incoming requests with the identity of the wallet.
+// It returns an Option that applies this configuration to HTTP.
+func WithBRC31Wallet(w wallet.Interface) Option {
+	return func(s *HTTP) {
+		s.brc31Wallet = w
+	}
+}
+
 // WithMiddleware adds a Fiber middleware handler to the HTTP server configuration.
 // It returns a ServerOption that appends the given middleware to the server's middleware stack.
 func WithMiddleware(f fiber.Handler) Option {
@@ -125,6 +510,30 @@ func WithOctetStreamLimit(limit int64) Option {
 	}
 }
 
+// WithCompression sets the configuration of the compression of response bodies.
+// It returns an Option that applies this configuration to HTTP.
+func WithCompression(compression CompressionConfig) Option {
+	return func(s *HTTP) {
+		s.cfg.Compression = compression
+	}
+}
+
+// WithRateLimit sets the rate of requests allowed to every client.
+// It returns an Option that applies this configuration to HTTP.
+func WithRateLimit(limit RateLimitConfig) Option {
+	return func(s *HTTP) {
+		s.cfg.RateLimit = limit
+	}
+}
+
+// WithAudit sets the configuration of the audit log of admin actions and submissions.
+// It returns an Option that applies this configuration to HTTP.
+func WithAudit(audit AuditConfig) Option {
+	return func(s *HTTP) {
+		s.cfg.Audit = audit
+	}
+}
+
 // WithConfig sets the configuration for the HTTP server using the provided Config.
 func WithConfig(cfg Config) Option {
 	return func(s *HTTP) {
@@ -139,6 +548,16 @@ type HTTP struct {
 	app        *fiber.App                   // app is the Fiber application instance serving HTTP requests.
 	middleware []fiber.Handler              // middleware is a list of Fiber middleware functions to be applied globally.
 	engine     engine.OverlayEngineProvider // engine is a custom implementation of the overlay engine that serves as the main processor for incoming HTTP requests.
+
+	arcCallbackTokens ARCCallbackTokenVerifier // arcCallbackTokens verifies the callback tokens handed to multiple ARC instances.
+	brc31Wallet       wallet.Interface         // brc31Wallet holds the server identity of BRC-31 mutual authentication.
+	advertiserWallet  wallet.Interface         // advertiserWallet funds the advertisements created from the Advertiser configuration.
+	headerTracker     *headers.Tracker         // headerTracker is the chain tracker created from the Headers configuration, synced while serving.
+
+	cfgMu        sync.Mutex              // cfgMu serializes configuration reloads.
+	configSource ConfigSource            // configSource re-reads the configuration when it is reloaded.
+	rateLimiter  *middleware.RateLimiter // rateLimiter enforces the rate limit, changed when the configuration is reloaded.
+	syncLoaded   bool                    // syncLoaded is set once a configuration set the sync peers of the engine.
 }
 
 // SocketAddr builds the address string for binding.
@@ -148,14 +567,42 @@ func (s *HTTP) SocketAddr() string {
 
 // ListenAndServe starts the HTTP server and begins listening on the configured socket address.
 // It blocks until the server is stopped or an error occurs.
-func (s *HTTP) ListenAndServe(_ context.Context) error {
+// The chain tracker created from the Headers configuration syncs block headers until ctx is done.
+func (s *HTTP) ListenAndServe(ctx context.Context) error {
+	s.runHeaderTracker(ctx)
 	return s.app.Listen(s.SocketAddr())
 }
 
+// Serve starts the HTTP server on the given listener, e.g. one bound to a random port.
+// It blocks until the server is stopped or an error occurs.
+// The chain tracker created from the Headers configuration syncs block headers until ctx is done.
+func (s *HTTP) Serve(ctx context.Context, ln net.Listener) error {
+	s.runHeaderTracker(ctx)
+	return s.app.Listener(ln)
+}
+
+// runHeaderTracker syncs the chain tracker created from the Headers configuration in the background, if any.
+func (s *HTTP) runHeaderTracker(ctx context.Context) {
+	if s.headerTracker != nil {
+		go s.headerTracker.Run(ctx)
+	}
+}
+
+// engineStopper is implemented by engines that drain their in-flight operations on shutdown, such as engine.Engine.
+type engineStopper interface {
+	Stop(ctx context.Context) error
+}
+
 // Shutdown gracefully shuts down the HTTP server using the provided context,
 // allowing ongoing requests to complete within the context's deadline.
+// When the engine supports it, the engine is stopped first so that in-flight submits
+// and GASP syncs drain while new ones are rejected.
 func (s *HTTP) Shutdown(ctx context.Context) error {
-	return s.app.ShutdownWithContext(ctx)
+	var stopErr error
+	if stopper, ok := s.engine.(engineStopper); ok {
+		stopErr = stopper.Stop(ctx)
+	}
+	return errors.Join(stopErr, s.app.ShutdownWithContext(ctx))
 }
 
 // RegisterRoute registers a new route with the given HTTP method, path, and one or more handlers.
@@ -168,7 +615,11 @@ func (s *HTTP) RegisterRoute(method, path string, handlers ...fiber.Handler) {
 // It initializes the application with default settings and middleware, registers OpenAPI handlers,
 // sets up transaction submission and advertisement synchronization handlers using the provided OverlayEngineProvider,
 // and applies any optional functional configuration options passed via opts.
-func New(opts ...Option) *HTTP {
+// The engine settings of the configuration are applied to the engine, which must then be an engine.Engine that is
+// not started yet; each setting that is not zero replaces the corresponding field of the engine.
+// Returns an ErrInvalidConfig error when the configuration cannot be applied, or when it prices requests
+// without a wallet set through WithBRC31Wallet to receive the payments.
+func New(opts ...Option) (*HTTP, error) {
 	srv := &HTTP{
 		cfg:    DefaultConfig,
 		engine: adapters.NewNoopEngineProvider(),
@@ -178,6 +629,23 @@ func New(opts ...Option) *HTTP {
 		o(srv)
 	}
 
+	if srv.cfg.Payments.enabled() && srv.brc31Wallet == nil {
+		err := fmt.Errorf("%w: payments: %w", ErrInvalidConfig, errPaymentsWalletRequired)
+		slog.Error("failed to apply the payments configuration", "error", err)
+		return nil, err
+	}
+	if err := srv.applyEngineConfig(); err != nil {
+		slog.Error("failed to apply the engine configuration", "error", err)
+		return nil, err
+	}
+
+	if level, err := parseLogLevel(srv.cfg.LogLevel); err != nil {
+		slog.Error("ignoring log level", "error", err)
+	} else if srv.cfg.LogLevel != "" {
+		slog.SetLogLoggerLevel(level)
+	}
+	srv.rateLimiter = middleware.NewRateLimiter(srv.cfg.RateLimit.RequestsPerSecond, srv.cfg.RateLimit.Burst)
+
 	srv.app = RegisterRoutesWithErrorHandler(
 		fiber.New(fiber.Config{
 			CaseSensitive: true,
@@ -185,17 +653,36 @@ func New(opts ...Option) *HTTP {
 			ServerHeader:  srv.cfg.ServerHeader,
 			AppName:       srv.cfg.AppName,
 			ReadTimeout:   srv.cfg.ConnectionReadTimeout,
+			// Bodies above the in-memory limit are streamed to the request body middleware, which
+			// enforces their route limits and spools them to disk.
+			BodyLimit:         int(max(srv.cfg.RequestBody.InMemoryLimit, 0)),
+			StreamRequestBody: true,
 		}),
 		&RegisterRoutesConfig{
-			ARCAPIKey:        srv.cfg.ARCAPIKey,
-			ARCCallbackToken: srv.cfg.ARCCallbackToken,
-			AdminBearerToken: srv.cfg.AdminBearerToken,
-			Engine:           srv.engine,
-			OctetStreamLimit: srv.cfg.OctetStreamLimit,
+			ARCAPIKey:         srv.cfg.ARCAPIKey,
+			ARCCallbackToken:  srv.cfg.ARCCallbackToken,
+			ARCCallbackTokens: srv.arcCallbackTokens,
+			AdminBearerToken:  srv.cfg.AdminBearerToken,
+			ReplicationToken:  srv.cfg.ReplicationToken,
+			Engine:            srv.engine,
+			OctetStreamLimit:  srv.cfg.OctetStreamLimit,
+			RequestBody:       srv.cfg.RequestBody,
+			Compression:       srv.cfg.Compression,
+			SubmitTopics:      srv.cfg.SubmitTopics,
+			SubmitLimits:      srv.cfg.SubmitLimits,
+			SubmitTimeout:     srv.cfg.SubmitTimeout,
+			AccessControl:     srv.cfg.AccessControl,
+			BRC31Wallet:       srv.brc31Wallet,
+			BRC31:             srv.cfg.BRC31,
+			Payments:          srv.cfg.Payments,
+			RateLimit:         srv.cfg.RateLimit,
+			Audit:             srv.cfg.Audit,
+			ConfigReloader:    srv,
+			rateLimiter:       srv.rateLimiter,
 		},
 	)
 
 	srv.app.Get("/metrics", monitor.New(monitor.Config{Title: "Overlay-services API"}))
 
-	return srv
+	return srv, nil
 }
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	internalapp "github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
+)
+
+var (
+	// ErrConfigSourceNotConfigured is returned when reloading the configuration of a server without a ConfigSource.
+	ErrConfigSourceNotConfigured = errors.New("config source not configured")
+
+	// ErrRestartRequired is returned when a reloaded configuration changes settings that only take effect after a restart.
+	ErrRestartRequired = errors.New("configuration change requires a restart")
+
+	// ErrInvalidConfig is returned when a reloaded configuration holds invalid settings.
+	ErrInvalidConfig = errors.New("invalid configuration")
+)
+
+// Settings applied to a running server by ApplyConfig, named after their configuration keys.
+const (
+	settingLogLevel     = "log_level"
+	settingRateLimit    = "rate_limit"
+	settingSyncInterval = "sync_interval"
+	settingSyncPeers    = "sync_peers"
+	settingGASPPeers    = "gasp_peers"
+)
+
+// reloadableSettings are the settings ApplyConfig changes without a restart.
+var reloadableSettings = []string{settingLogLevel, settingRateLimit, settingSyncInterval, settingSyncPeers, settingGASPPeers}
+
+// RestartRequiredError reports the settings changed by a reloaded configuration that only take effect after a restart.
+type RestartRequiredError struct {
+	Settings []string
+}
+
+// Error returns the error message listing the settings.
+func (e *RestartRequiredError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRestartRequired, strings.Join(e.Settings, ", "))
+}
+
+// Unwrap returns ErrRestartRequired.
+func (e *RestartRequiredError) Unwrap() error {
+	return ErrRestartRequired
+}
+
+// ConfigSource loads the current configuration of the server, e.g. by reading its configuration file again.
+type ConfigSource func(ctx context.Context) (Config, error)
+
+// ConfigReloader re-reads the configuration of a running server and applies the settings that can change
+// without a restart, returning the applied settings. It is implemented by HTTP.
+type ConfigReloader interface {
+	ReloadConfig(ctx context.Context) ([]string, error)
+}
+
+// syncSettingsApplier is implemented by engines whose sync settings can change while they run, such as engine.Engine.
+type syncSettingsApplier interface {
+	SetSyncInterval(interval time.Duration) error
+	SetSyncPeers(topic string, peers []string) error
+	SetPeerRemotes(remotes map[string]engine.GASPRemoteConfig)
+}
+
+// WithConfigSource sets the source the configuration is re-read from when the server is asked to reload it,
+// e.g. on SIGHUP or through the admin API.
+// It returns an Option that applies this configuration to HTTP.
+func WithConfigSource(source ConfigSource) Option {
+	return func(s *HTTP) {
+		s.configSource = source
+	}
+}
+
+// ReloadConfig re-reads the configuration from the ConfigSource and applies it with ApplyConfig.
+// Returns ErrConfigSourceNotConfigured when the server has no ConfigSource.
+func (s *HTTP) ReloadConfig(ctx context.Context) ([]string, error) {
+	if s.configSource == nil {
+		slog.Error("cannot reload configuration", "error", ErrConfigSourceNotConfigured)
+		return nil, ErrConfigSourceNotConfigured
+	}
+	cfg, err := s.configSource(ctx)
+	if err != nil {
+		slog.Error("failed to load configuration", "error", err)
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return s.ApplyConfig(cfg)
+}
+
+// ApplyConfig applies the settings of cfg that can change while the server runs: the log level, the rate limit,
+// and, when the engine supports it, the sync interval, sync peers and GASP peer settings. It returns the names
+// of the applied settings. When cfg changes any other setting, nothing is applied and a RestartRequiredError
+// listing them is returned. Engine settings applied before an engine rejects one stay applied.
+// The first configuration applied sets the sync peers and GASP peer settings of the engine even when they are
+// unchanged, so that the engine follows the configuration whatever peers it was created with.
+func (s *HTTP) ApplyConfig(cfg Config) ([]string, error) {
+	s.cfgMu.Lock()
+	defer s.cfgMu.Unlock()
+
+	changed := changedSettings(s.cfg, cfg)
+	if restart := slices.DeleteFunc(slices.Clone(changed), isReloadable); len(restart) > 0 {
+		err := &RestartRequiredError{Settings: restart}
+		slog.Error("configuration not reloaded", "error", err)
+		return nil, err
+	}
+	prev := s.cfg
+	if !s.syncLoaded {
+		changed = s.firstLoadSyncSettings(cfg, changed)
+		prev.SyncPeers = nil
+	}
+	if len(changed) == 0 {
+		s.syncLoaded = true
+		slog.Info("configuration reloaded without changes")
+		return []string{}, nil
+	}
+
+	level, err := parseLogLevel(cfg.LogLevel)
+	if err != nil {
+		slog.Error("configuration not reloaded", "error", err)
+		return nil, err
+	}
+	if err := s.applySyncSettings(prev, cfg, changed); err != nil {
+		return nil, err
+	}
+	s.syncLoaded = true
+	if slices.Contains(changed, settingLogLevel) && cfg.LogLevel != "" {
+		slog.SetLogLoggerLevel(level)
+	}
+	if slices.Contains(changed, settingRateLimit) {
+		s.rateLimiter.SetLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	}
+
+	s.cfg = cfg
+	slog.Info("configuration reloaded", "applied", changed)
+	return changed, nil
+}
+
+// firstLoadSyncSettings adds the sync peers and GASP peer settings set by cfg to the changed settings, when the
+// engine can change them, so that the first load applies them even when they are unchanged.
+func (s *HTTP) firstLoadSyncSettings(cfg Config, changed []string) []string {
+	if _, ok := s.engine.(syncSettingsApplier); !ok {
+		return changed
+	}
+	if len(cfg.SyncPeers) > 0 && !slices.Contains(changed, settingSyncPeers) {
+		changed = append(changed, settingSyncPeers)
+	}
+	if len(cfg.GASPPeers) > 0 && !slices.Contains(changed, settingGASPPeers) {
+		changed = append(changed, settingGASPPeers)
+	}
+	return changed
+}
+
+// applySyncSettings applies the changed sync settings to the engine.
+func (s *HTTP) applySyncSettings(prev, next Config, changed []string) error {
+	if !slices.ContainsFunc(changed, func(setting string) bool {
+		return setting == settingSyncInterval || setting == settingSyncPeers || setting == settingGASPPeers
+	}) {
+		return nil
+	}
+	applier, ok := s.engine.(syncSettingsApplier)
+	if !ok {
+		err := &RestartRequiredError{Settings: slices.DeleteFunc(slices.Clone(changed), func(setting string) bool {
+			return setting == settingLogLevel || setting == settingRateLimit
+		})}
+		slog.Error("engine cannot change sync settings while running", "error", err)
+		return err
+	}
+
+	if slices.Contains(changed, settingSyncInterval) {
+		if err := applier.SetSyncInterval(next.SyncInterval); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", settingSyncInterval, err)
+		}
+	}
+	if slices.Contains(changed, settingSyncPeers) {
+		for _, topic := range slices.Sorted(maps.Keys(prev.SyncPeers)) {
+			if _, ok := next.SyncPeers[topic]; !ok {
+				if err := applier.SetSyncPeers(topic, nil); err != nil {
+					return fmt.Errorf("failed to apply %s of topic %s: %w", settingSyncPeers, topic, err)
+				}
+			}
+		}
+		for _, topic := range slices.Sorted(maps.Keys(next.SyncPeers)) {
+			if peers, ok := prev.SyncPeers[topic]; ok && slices.Equal(peers, next.SyncPeers[topic]) {
+				continue
+			}
+			if err := applier.SetSyncPeers(topic, next.SyncPeers[topic]); err != nil {
+				return fmt.Errorf("failed to apply %s of topic %s: %w", settingSyncPeers, topic, err)
+			}
+		}
+	}
+	if slices.Contains(changed, settingGASPPeers) {
+		applier.SetPeerRemotes(next.GASPPeers)
+	}
+	return nil
+}
+
+// changedSettings returns the configuration keys of the top-level settings that differ between the configurations.
+func changedSettings(prev, next Config) []string {
+	var changed []string
+	prevValue, nextValue := reflect.ValueOf(prev), reflect.ValueOf(next)
+	for i := range prevValue.NumField() {
+		if !reflect.DeepEqual(prevValue.Field(i).Interface(), nextValue.Field(i).Interface()) {
+			changed = append(changed, prevValue.Type().Field(i).Tag.Get("mapstructure"))
+		}
+	}
+	return changed
+}
+
+func isReloadable(setting string) bool {
+	return slices.Contains(reloadableSettings, setting)
+}
+
+// parseLogLevel parses a log level such as "debug", "info", "warn" or "error". An empty level is valid
+// and leaves the level of the default logger unchanged.
+func parseLogLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if level == "" {
+		return parsed, nil
+	}
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return parsed, fmt.Errorf("%w: %s %q", ErrInvalidConfig, settingLogLevel, level)
+	}
+	return parsed, nil
+}
+
+// configReloadProvider adapts a ConfigReloader to the admin API, translating its errors into application errors.
+type configReloadProvider struct {
+	reloader ConfigReloader
+}
+
+// ReloadConfig implements app.ConfigReloadProvider.
+func (p configReloadProvider) ReloadConfig(ctx context.Context) ([]string, error) {
+	if p.reloader == nil {
+		return nil, internalapp.NewConfigReloadUnsupportedError(ErrConfigSourceNotConfigured)
+	}
+	applied, err := p.reloader.ReloadConfig(ctx)
+	var restartErr *RestartRequiredError
+	switch {
+	case err == nil:
+		return applied, nil
+	case errors.Is(err, ErrConfigSourceNotConfigured):
+		return nil, internalapp.NewConfigReloadUnsupportedError(err)
+	case errors.As(err, &restartErr):
+		return nil, internalapp.NewConfigReloadRestartRequiredError(err, restartErr.Settings)
+	case errors.Is(err, ErrInvalidConfig):
+		return nil, internalapp.NewConfigReloadInvalidError(err)
+	default:
+		return nil, err
+	}
+}
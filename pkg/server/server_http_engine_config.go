@@ -0,0 +1,244 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/advertiser"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/headers"
+	"github.com/bsv-blockchain/go-sdk/wallet"
+)
+
+// engineSettings are the configuration keys of the settings New applies to an engine.Engine.
+var engineSettings = []string{
+	"arc", "standby", "submit_jobs", "idempotency", "submit_replays", "webhooks", "lookup_cache", "lookup_resolver",
+	"gasp_peers", "sync_peers", "sync_interval", "retention", "proof_fetcher", "unmined_eviction", "spend_reconciliation",
+	"lookup_notifications", "broadcast_retry", "propagate_historical", "history", "advertisement_budget", "spv",
+	"submit_scheduler", "gasp_serve", "topic_quotas", "topic_manager_sandbox", "score_strategy", "tombstones",
+	"merkle_states", "beef_compaction", "verified_tx_cache", "outpoint_filter", "headers", "advertiser", "admission_oracles",
+}
+
+// settingAuditFile names the audit log file, the only audit setting applied to the engine.
+const settingAuditFile = "audit.file"
+
+// WithAdvertiserWallet sets the BRC-100 wallet funding the SHIP/SLAP advertisements created from the Advertiser
+// configuration. It returns an Option that applies this configuration to HTTP.
+func WithAdvertiserWallet(w wallet.Interface) Option {
+	return func(s *HTTP) {
+		s.advertiserWallet = w
+	}
+}
+
+// errAdvertiserWalletRequired is returned when the Advertiser configuration is set without WithAdvertiserWallet.
+var errAdvertiserWalletRequired = errors.New("advertiser requires a wallet set through WithAdvertiserWallet")
+
+// isSet reports whether a setting differs from its zero value.
+func isSet(setting any) bool {
+	return !reflect.ValueOf(setting).IsZero()
+}
+
+// configuredEngineSettings returns the configuration keys of the engine settings cfg sets.
+func configuredEngineSettings(cfg Config) []string {
+	var configured []string
+	value := reflect.ValueOf(cfg)
+	for i := range value.NumField() {
+		key := value.Type().Field(i).Tag.Get("mapstructure")
+		if slices.Contains(engineSettings, key) && isSet(value.Field(i).Interface()) {
+			configured = append(configured, key)
+		}
+	}
+	if cfg.Audit.File != "" {
+		configured = append(configured, settingAuditFile)
+	}
+	return configured
+}
+
+// applyEngineConfig applies the engine settings of the configuration to the engine, replacing the corresponding
+// fields of the engine. Settings left zero leave the engine unchanged. It must run before the engine is started.
+// Returns an ErrInvalidConfig error when a setting is invalid, or when the engine is not an engine.Engine.
+func (s *HTTP) applyEngineConfig() error {
+	cfg := s.cfg
+	configured := configuredEngineSettings(cfg)
+	if len(configured) == 0 {
+		return nil
+	}
+	e, ok := s.engine.(*engine.Engine)
+	if !ok {
+		return fmt.Errorf("%w: %s can only be applied to an engine.Engine", ErrInvalidConfig, strings.Join(configured, ", "))
+	}
+
+	invalid := func(setting string, err error) error {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidConfig, setting, err)
+	}
+
+	if isSet(cfg.Headers) {
+		tracker, err := headers.NewTrackerFromConfig(cfg.Headers)
+		if err != nil {
+			return invalid("headers", err)
+		}
+		e.ChainTracker = tracker
+		s.headerTracker = tracker
+	}
+	if isSet(cfg.ARC) {
+		pool, err := engine.NewARCPool(cfg.ARC)
+		if err != nil {
+			return invalid("arc", err)
+		}
+		e.Broadcaster = pool
+		if s.arcCallbackTokens == nil {
+			s.arcCallbackTokens = pool
+		}
+	}
+	if cfg.Standby.PrimaryURL != "" {
+		e.Standby = engine.NewReplicationFollower(e.Storage, engine.NewHTTPReplicationSource(cfg.Standby))
+	}
+	if isSet(cfg.SubmitJobs) {
+		e.SubmitJobs = engine.NewSubmitJobQueue(cfg.SubmitJobs)
+	}
+	if isSet(cfg.Idempotency) {
+		e.Idempotency = engine.NewSubmitIdempotency(cfg.Idempotency)
+	}
+	if isSet(cfg.SubmitReplays) {
+		e.SubmitReplays = engine.NewSubmitReplayCache(cfg.SubmitReplays)
+	}
+	if isSet(cfg.Webhooks) {
+		e.Webhooks = engine.NewWebhooks(cfg.Webhooks)
+	}
+	if isSet(cfg.LookupCache) {
+		e.LocalLookupCache = engine.NewLookupCacheWithConfig(cfg.LookupCache)
+	}
+	if isSet(cfg.LookupResolver) {
+		e.LookupResolver = engine.NewLookupResolverWithConfig(cfg.LookupResolver)
+		if len(e.SLAPTrackers) > 0 {
+			e.LookupResolver.SetSLAPTrackers(e.SLAPTrackers)
+		}
+	}
+	if isSet(cfg.Retention) {
+		retention := cfg.Retention
+		e.Retention = &retention
+	}
+	if isSet(cfg.ProofFetcher) {
+		fetcher, err := engine.NewMerkleProofFetcher(cfg.ProofFetcher)
+		if err != nil {
+			return invalid("proof_fetcher", err)
+		}
+		e.ProofFetcher = fetcher
+	}
+	if isSet(cfg.UnminedEviction) {
+		evictor, err := engine.NewUnminedEvictor(cfg.UnminedEviction)
+		if err != nil {
+			return invalid("unmined_eviction", err)
+		}
+		e.UnminedEviction = evictor
+	}
+	if isSet(cfg.SpendReconciliation) {
+		reconciler, err := engine.NewSpendReconciler(cfg.SpendReconciliation)
+		if err != nil {
+			return invalid("spend_reconciliation", err)
+		}
+		e.SpendReconciler = reconciler
+	}
+	if isSet(cfg.LookupNotifications) {
+		e.LookupNotifications = engine.NewLookupNotifications(cfg.LookupNotifications)
+	}
+	if isSet(cfg.BroadcastRetry) {
+		e.BroadcastRetry = engine.NewBroadcastRetry(cfg.BroadcastRetry)
+	}
+	if cfg.PropagateHistorical {
+		e.PropagateHistorical = true
+	}
+	if isSet(cfg.History) {
+		history := cfg.History
+		e.HistoryLimits = &history
+	}
+	if isSet(cfg.AdvertisementBudget) {
+		budget := cfg.AdvertisementBudget
+		e.AdvertisementBudget = &budget
+	}
+	if isSet(cfg.SPV) {
+		e.SPVVerifier = engine.NewSPVVerifier(cfg.SPV)
+	}
+	if isSet(cfg.SubmitScheduler) {
+		e.SubmitScheduler = engine.NewSubmitScheduler(cfg.SubmitScheduler)
+	}
+	if isSet(cfg.GASPServe) {
+		e.GASPServeLimiter = engine.NewGASPServeLimiter(cfg.GASPServe)
+	}
+	if isSet(cfg.TopicQuotas) {
+		e.OutputQuotas = engine.NewTopicQuotas(cfg.TopicQuotas)
+	}
+	if isSet(cfg.TopicManagerSandbox) {
+		e.ManagerSandbox = engine.NewTopicManagerSandbox(cfg.TopicManagerSandbox)
+	}
+	if cfg.ScoreStrategy != "" {
+		strategy, err := engine.NewScoreStrategy(cfg.ScoreStrategy)
+		if err != nil {
+			return invalid("score_strategy", err)
+		}
+		e.ScoreStrategy = strategy
+	}
+	if isSet(cfg.Tombstones) {
+		tombstones := cfg.Tombstones
+		e.Tombstones = &tombstones
+	}
+	if isSet(cfg.MerkleStates) {
+		merkleStates := cfg.MerkleStates
+		e.MerkleStates = &merkleStates
+	}
+	if isSet(cfg.BEEFCompaction) {
+		e.BEEFCompactor = engine.NewBEEFCompactor(cfg.BEEFCompaction)
+	}
+	if isSet(cfg.VerifiedTxCache) {
+		e.VerifiedTxs = engine.NewVerifiedTxCache(cfg.VerifiedTxCache)
+	}
+	if s.headerTracker != nil && e.VerifiedTxs != nil {
+		s.headerTracker.OnReorg = e.VerifiedTxs.InvalidateFromHeight
+	}
+	if isSet(cfg.OutpointFilter) {
+		e.OutpointFilter = engine.NewOutpointFilter(cfg.OutpointFilter)
+	}
+	if cfg.Audit.File != "" {
+		store, err := engine.NewFileAuditStore(cfg.Audit.File)
+		if err != nil {
+			return invalid(settingAuditFile, err)
+		}
+		e.Audit = store
+	}
+	if isSet(cfg.Advertiser) {
+		if s.advertiserWallet == nil {
+			return invalid("advertiser", errAdvertiserWalletRequired)
+		}
+		a, err := advertiser.NewWalletAdvertiser(s.advertiserWallet, cfg.Advertiser)
+		if err != nil {
+			return invalid("advertiser", err)
+		}
+		e.Advertiser = a
+	}
+	for _, topic := range slices.Sorted(maps.Keys(cfg.AdmissionOracles)) {
+		oracle, err := engine.NewAdmissionOracle(cfg.AdmissionOracles[topic], nil)
+		if err != nil {
+			return invalid("admission_oracles", err)
+		}
+		if err := e.InstallTopicManager(topic, oracle); err != nil {
+			return invalid("admission_oracles", fmt.Errorf("topic %s: %w", topic, err))
+		}
+	}
+
+	if cfg.SyncInterval > 0 {
+		e.PeriodicSync = engine.NewPeriodicSync(cfg.SyncInterval)
+	}
+	for _, topic := range slices.Sorted(maps.Keys(cfg.SyncPeers)) {
+		if err := e.SetSyncPeers(topic, cfg.SyncPeers[topic]); err != nil {
+			return invalid(settingSyncPeers, fmt.Errorf("topic %s: %w", topic, err))
+		}
+	}
+	if len(cfg.GASPPeers) > 0 {
+		e.SetPeerRemotes(cfg.GASPPeers)
+	}
+	return nil
+}
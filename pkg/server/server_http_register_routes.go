@@ -1,12 +1,16 @@
 package server
 
 import (
+	"time"
+
 	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/adapters"
+	internalapp "github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/app"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/decorators"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/middleware"
 	"github.com/bsv-blockchain/go-overlay-services/pkg/server/internal/ports/openapi"
+	"github.com/bsv-blockchain/go-sdk/wallet"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -20,6 +24,13 @@ var DefaultRegisterRoutesConfig = RegisterRoutesConfig{
 	OctetStreamLimit: middleware.ReadBodyLimit1GB,
 }
 
+// ARCCallbackTokenVerifier reports whether a token presented by an ARC callback was issued to one of
+// the configured ARC instances. It is implemented by engine.ARCPool, which also verifies the
+// X-Callback-Signature of the callbacks of instances configured with a signing secret.
+type ARCCallbackTokenVerifier interface {
+	ValidCallbackToken(token string) bool
+}
+
 // RegisterRoutesConfig holds the configuration settings for the Overlay Engine HTTP API.
 type RegisterRoutesConfig struct {
 	// ARCAPIKey is the API key used for ARC service integration.
@@ -28,9 +39,17 @@ type RegisterRoutesConfig struct {
 	// ARCCallbackToken is the token used to authenticating ARC callback requests.
 	ARCCallbackToken string
 
+	// ARCCallbackTokens, when set, additionally accepts the callback tokens handed to multiple ARC instances,
+	// such as those of an engine.ARCPool, and enables the ARC ingest endpoint without an ARCAPIKey.
+	ARCCallbackTokens ARCCallbackTokenVerifier
+
 	// AdminBearerToken is the token required to access admin-only endpoints.
 	AdminBearerToken string
 
+	// ReplicationToken is the token standbys present to stream the storage mutations of this node.
+	// An empty token disables the replication stream endpoint.
+	ReplicationToken string
+
 	// Engine is a custom implementation of the overlay engine that serves
 	// as the main processor for incoming HTTP requests.
 	Engine engine.OverlayEngineProvider
@@ -38,6 +57,48 @@ type RegisterRoutesConfig struct {
 	// OctetStreamLimit defines the maximum size (in bytes) for reading applicaction/octet-stream
 	// request bodies. By default, it is set to 1GB to protect against excessively large payloads.
 	OctetStreamLimit int64
+
+	// RequestBody configures per-route body limits, spooling and backpressure of request bodies.
+	// Spooling and backpressure apply when the fiber.App is configured with StreamRequestBody.
+	RequestBody RequestBodyConfig
+
+	// Compression configures the compression of response bodies.
+	Compression CompressionConfig
+
+	// SubmitTopics defines the topics policy enforced by the submit transaction endpoint.
+	SubmitTopics SubmitTopicsPolicy
+
+	// SubmitLimits defines the BEEF limits enforced by the submit transaction endpoint.
+	SubmitLimits SubmitBEEFLimits
+
+	// SubmitTimeout bounds the time a synchronous submission may take. Zero leaves submissions unbounded.
+	SubmitTimeout time.Duration
+
+	// AccessControl restricts the API keys permitted to access topics and query lookup services.
+	AccessControl AccessControlList
+
+	// BRC31Wallet, when set, enables BRC-31 mutual authentication of incoming requests with the identity of the wallet.
+	BRC31Wallet wallet.Interface
+
+	// BRC31 defines the rules of BRC-31 mutual authentication.
+	BRC31 BRC31AuthConfig
+
+	// Payments defines the prices of paid requests. Payments are received into the BRC31Wallet,
+	// so they are only charged when BRC-31 mutual authentication is enabled.
+	Payments PaymentConfig
+
+	// RateLimit bounds the rate of requests of every client.
+	RateLimit RateLimitConfig
+
+	// Audit configures the recording of admin actions and submissions to the audit log of the Engine.
+	Audit AuditConfig
+
+	// ConfigReloader, when set, reloads the configuration on admin request. It is set to the HTTP server by New.
+	ConfigReloader ConfigReloader
+
+	// rateLimiter, when set, enforces the rate limit instead of a limiter built from RateLimit,
+	// letting the HTTP server change it when its configuration is reloaded.
+	rateLimiter *middleware.RateLimiter
 }
 
 // RegisterRoutesWithErrorHandler wraps RegisterRoutes by injecting a predefined error handler
@@ -74,19 +135,79 @@ func RegisterRoutes(app *fiber.App, cfg *RegisterRoutesConfig) *fiber.App {
 	}
 
 	registry := ports.NewHandlerRegistryService(cfg.Engine, &decorators.ARCAuthorizationDecoratorConfig{
-		APIKey:        cfg.ARCAPIKey,
-		CallbackToken: cfg.ARCCallbackToken,
-		Scheme:        "Bearer ",
+		APIKey:         cfg.ARCAPIKey,
+		CallbackToken:  cfg.ARCCallbackToken,
+		CallbackTokens: cfg.ARCCallbackTokens,
+		Scheme:         "Bearer ",
+	}, ports.SubmitTransactionHandlerConfig{
+		TopicsPolicy: internalapp.SubmitTopicsPolicy{
+			AutoAddedTopics:   cfg.SubmitTopics.AutoAdded,
+			ExplicitTopics:    cfg.SubmitTopics.Explicit,
+			TrustedOnlyTopics: cfg.SubmitTopics.TrustedOnly,
+		},
+		TrustedBearerToken: cfg.AdminBearerToken,
+		BEEFLimits: internalapp.SubmitBEEFLimits{
+			MaxBytes:          cfg.SubmitLimits.MaxBytes,
+			MaxTransactions:   cfg.SubmitLimits.MaxTransactions,
+			ValidateStructure: cfg.SubmitLimits.ValidateStructure,
+		},
+		Timeout: cfg.SubmitTimeout,
+	}, &decorators.ReplicationAuthorizationDecoratorConfig{
+		Token:  cfg.ReplicationToken,
+		Scheme: "Bearer ",
+	}, internalapp.AccessControlList{
+		TopicKeys:   cfg.AccessControl.Topics,
+		ServiceKeys: cfg.AccessControl.LookupServices,
+	}, configReloadProvider{reloader: cfg.ConfigReloader})
+
+	globalMiddleware := middleware.BasicMiddlewareGroup(middleware.BasicMiddlewareGroupConfig{
+		EnableStackTrace: true,
+		RequestBody: middleware.RequestBodyMiddlewareConfig{
+			OctetStreamLimit: cfg.OctetStreamLimit,
+			InMemoryLimit:    cfg.RequestBody.InMemoryLimit,
+			RouteLimits:      cfg.RequestBody.RouteLimits,
+			SpoolDir:         cfg.RequestBody.SpoolDir,
+			MaxInFlightBytes: cfg.RequestBody.MaxInFlightBytes,
+			MaxWait:          cfg.RequestBody.MaxWait,
+		},
+		Compression: middleware.CompressionMiddlewareConfig{Level: cfg.Compression.Level},
 	})
+	rateLimiter := cfg.rateLimiter
+	if rateLimiter == nil && cfg.RateLimit.RequestsPerSecond > 0 {
+		rateLimiter = middleware.NewRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	}
+	if rateLimiter != nil {
+		globalMiddleware = append(globalMiddleware, middleware.RateLimitMiddleware(rateLimiter))
+	}
+	if cfg.BRC31Wallet != nil {
+		globalMiddleware = append(globalMiddleware, middleware.BRC31AuthMiddleware(middleware.BRC31AuthMiddlewareConfig{
+			Wallet:     cfg.BRC31Wallet,
+			Required:   cfg.BRC31.Required,
+			Identities: cfg.BRC31.Identities,
+		}))
+	}
+	if cfg.Audit.Enabled {
+		globalMiddleware = append(globalMiddleware, middleware.AuditMiddleware(middleware.AuditMiddlewareConfig{
+			Recorder:         cfg.Engine,
+			AdminBearerToken: cfg.AdminBearerToken,
+		}))
+	}
+	if cfg.BRC31Wallet != nil {
+		if cfg.Payments.enabled() {
+			globalMiddleware = append(globalMiddleware, middleware.PaymentMiddleware(middleware.PaymentMiddlewareConfig{
+				Wallet:              cfg.BRC31Wallet,
+				Provider:            cfg.Engine,
+				SatoshisPerLookup:   cfg.Payments.SatoshisPerLookup,
+				SatoshisPerSubmitKB: cfg.Payments.SatoshisPerSubmitKB,
+			}))
+		}
+	}
 
 	openapi.RegisterHandlersWithOptions(app, registry, openapi.FiberServerOptions{
 		HandlerMiddleware: []fiber.Handler{
 			middleware.BearerTokenAuthorizationMiddleware(cfg.AdminBearerToken),
 		},
-		GlobalMiddleware: middleware.BasicMiddlewareGroup(middleware.BasicMiddlewareGroupConfig{
-			EnableStackTrace: true,
-			OctetStreamLimit: cfg.OctetStreamLimit,
-		}),
+		GlobalMiddleware: globalMiddleware,
 	})
 
 	return app
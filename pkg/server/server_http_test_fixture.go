@@ -1,6 +1,8 @@
 package server
 
 import (
+	"bytes"
+	"io"
 	"net/http"
 	"testing"
 
@@ -17,9 +19,18 @@ type fiberRoundTripper struct {
 }
 
 // RoundTrip executes an HTTP request using Fiber’s in-memory test engine.
-// It returns the response or fails the test if an error occurs.
+// Bodies of unknown length, which a network client would send chunked, are buffered
+// so that the test engine forwards them. It returns the response or fails the test if an error occurs.
 func (f *fiberRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	f.t.Helper()
+	if req.Body != nil && req.Body != http.NoBody && req.ContentLength == 0 {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
 	return f.srv.app.Test(req, f.timeout)
 }
 
@@ -44,15 +55,23 @@ func (f *TestFixture) Client() *resty.Client {
 	return c
 }
 
+// RoundTripper returns the in-memory HTTP round tripper of the test server, for clients other than Resty.
+func (f *TestFixture) RoundTripper() http.RoundTripper {
+	return f.roundTripper
+}
+
 // NewTestFixture creates a new test fixture with a fully initialized server instance
-// and a custom in-memory HTTP round tripper. Panics if server initialization fails.
+// and a custom in-memory HTTP round tripper. Fails the test if server initialization fails.
 func NewTestFixture(t *testing.T, opts ...Option) *TestFixture {
+	srv, err := New(opts...)
+	require.NoError(t, err, "failed to create the test server")
+
 	return &TestFixture{
 		t: t,
 		roundTripper: &fiberRoundTripper{
 			t:       t,
 			timeout: -1,
-			srv:     New(opts...),
+			srv:     srv,
 		},
 	}
 }
@@ -0,0 +1,39 @@
+package testharness
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// DefaultBlockHeight is the height reported by a ChainTracker until it is advanced.
+const DefaultBlockHeight = 800000
+
+// ChainTracker is a fake chain tracker accepting every merkle root, so that the transactions built by
+// NewMinedTransaction pass SPV verification. It is safe for concurrent use.
+type ChainTracker struct {
+	height atomic.Uint32
+}
+
+// NewChainTracker creates a ChainTracker at DefaultBlockHeight.
+func NewChainTracker() *ChainTracker {
+	tracker := &ChainTracker{}
+	tracker.height.Store(DefaultBlockHeight)
+	return tracker
+}
+
+// IsValidRootForHeight accepts every merkle root.
+func (c *ChainTracker) IsValidRootForHeight(context.Context, *chainhash.Hash, uint32) (bool, error) {
+	return true, nil
+}
+
+// CurrentHeight returns the height of the tip of the fake chain.
+func (c *ChainTracker) CurrentHeight(context.Context) (uint32, error) {
+	return c.height.Load(), nil
+}
+
+// Mine advances the tip of the fake chain by the given number of blocks.
+func (c *ChainTracker) Mine(blocks uint32) {
+	c.height.Add(blocks)
+}
@@ -0,0 +1,107 @@
+package testharness
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// DefaultContainerStartTimeout is how long a container is given to serve its API when no timeout is configured.
+const DefaultContainerStartTimeout = time.Minute
+
+// ContainerConfig runs a node of a Network from a docker image, e.g. an overlay node bundling the topic managers
+// under test. Containers share the network of the host, so that they reach the in-process nodes listening on
+// 127.0.0.1, and must be configured through Env and the variables below to host the topics of the Network.
+type ContainerConfig struct {
+	// Image is the docker image of the node.
+	Image string
+
+	// Port is the port the node listens on.
+	Port int
+
+	// Env holds the environment variables of the container.
+	Env map[string]string
+
+	// PeersEnv names the environment variable receiving the comma separated URLs of the other nodes.
+	// Empty leaves the peers of the container unconfigured.
+	PeersEnv string
+
+	// AdminTokenEnv names the environment variable receiving the admin token of the Network.
+	// Empty leaves the admin token of the container unconfigured.
+	AdminTokenEnv string
+
+	// StartTimeout is how long the node is given to serve its API. Zero falls back to DefaultContainerStartTimeout.
+	StartTimeout time.Duration
+}
+
+func (c ContainerConfig) url() string {
+	return fmt.Sprintf("http://127.0.0.1:%d", c.Port)
+}
+
+// requireDocker skips the test when the docker CLI is not available.
+func requireDocker(t testing.TB) {
+	t.Helper()
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker is not available:", err)
+	}
+	if err := exec.Command("docker", "info").Run(); err != nil {
+		t.Skip("docker daemon is not reachable:", err)
+	}
+}
+
+// startContainer runs the container and waits until it serves its API. The container is removed when the
+// test completes.
+func (n *Network) startContainer(cfg ContainerConfig, url string, peers []string) *Node {
+	n.t.Helper()
+	env := make(map[string]string, len(cfg.Env)+2)
+	maps.Copy(env, cfg.Env)
+	if cfg.PeersEnv != "" {
+		env[cfg.PeersEnv] = strings.Join(peers, ",")
+	}
+	if cfg.AdminTokenEnv != "" {
+		env[cfg.AdminTokenEnv] = n.config.AdminToken
+	}
+
+	args := []string{"run", "--detach", "--rm", "--network", "host"}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		args = append(args, "--env", key+"="+env[key])
+	}
+	out, err := exec.Command("docker", append(args, cfg.Image)...).Output()
+	if err != nil {
+		n.t.Fatalf("failed to run container of %s: %v", cfg.Image, err)
+	}
+	id := strings.TrimSpace(string(out))
+	n.t.Cleanup(func() {
+		if err := exec.Command("docker", "rm", "--force", id).Run(); err != nil {
+			n.t.Errorf("failed to remove container %s: %v", id, err)
+		}
+	})
+
+	node := &Node{Name: cfg.Image + "@" + id[:min(len(id), 12)], URL: url, Client: n.newClient(url)}
+	timeout := cfg.StartTimeout
+	if timeout <= 0 {
+		timeout = DefaultContainerStartTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for {
+		if _, err := node.Client.ListTopicManagers(ctx); err == nil {
+			return node
+		}
+		select {
+		case <-ctx.Done():
+			n.t.Fatalf("container %s did not serve its API within %s", node.Name, timeout)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package testharness
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/beefstore"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// MemoryStorage is an in-memory engine.Storage backing the in-process nodes of a Network. It implements the
+// engine.OutputTombstoneStorage, engine.MerkleStateStorage, engine.SpendRollbackStorage and engine.OutputScoreStorage
+// capabilities and passes the storagetest conformance suite. Outputs stored without a score are scored in insertion
+// order. The BEEF and ancillary BEEF of outputs are held once per content in a beefstore.Store, shared by every
+// output referencing them. It is safe for concurrent use.
+type MemoryStorage struct {
+	mu           sync.Mutex
+	beef         *beefstore.Store
+	outputs      map[string]*engine.Output
+	applied      map[string]struct{}
+	interactions map[string]float64
+	tombstones   []*engine.Tombstone
+	nextScore    float64
+}
+
+// NewMemoryStorage creates an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		beef:         beefstore.New(),
+		outputs:      make(map[string]*engine.Output),
+		applied:      make(map[string]struct{}),
+		interactions: make(map[string]float64),
+	}
+}
+
+func outputKey(outpoint *transaction.Outpoint, topic string) string {
+	return outpoint.String() + " " + topic
+}
+
+func (s *MemoryStorage) copyOf(output *engine.Output, includeBEEF bool) *engine.Output {
+	found := *output
+	if !includeBEEF {
+		found.Beef = nil
+	}
+	return &found
+}
+
+func (s *MemoryStorage) InsertOutput(_ context.Context, utxo *engine.Output) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *utxo
+	if stored.Score == 0 {
+		s.nextScore++
+		stored.Score = s.nextScore
+	}
+	stored.Beef = s.beef.Acquire(utxo.Beef)
+	stored.AncillaryBeef = s.beef.Acquire(utxo.AncillaryBeef)
+	key := outputKey(&utxo.Outpoint, utxo.Topic)
+	if replaced, ok := s.outputs[key]; ok {
+		s.release(replaced)
+	}
+	s.outputs[key] = &stored
+	return nil
+}
+
+// release drops the references of the output to its BEEF and ancillary BEEF.
+func (s *MemoryStorage) release(output *engine.Output) {
+	s.beef.Release(output.Beef)
+	s.beef.Release(output.AncillaryBeef)
+}
+
+// BEEFStats describes the BEEF held for the outputs and tombstones of the storage.
+func (s *MemoryStorage) BEEFStats() beefstore.Stats {
+	return s.beef.Stats()
+}
+
+func (s *MemoryStorage) find(outpoint *transaction.Outpoint, topic *string, spent *bool) *engine.Output {
+	for _, output := range s.outputs {
+		if output.Outpoint != *outpoint || (topic != nil && output.Topic != *topic) || (spent != nil && output.Spent != *spent) {
+			continue
+		}
+		return output
+	}
+	return nil
+}
+
+func (s *MemoryStorage) FindOutput(_ context.Context, outpoint *transaction.Outpoint, topic *string, spent *bool, includeBEEF bool) (*engine.Output, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if output := s.find(outpoint, topic, spent); output != nil {
+		return s.copyOf(output, includeBEEF), nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStorage) FindOutputs(_ context.Context, outpoints []*transaction.Outpoint, topic string, spent *bool, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := make([]*engine.Output, len(outpoints))
+	for i, outpoint := range outpoints {
+		if output := s.find(outpoint, &topic, spent); output != nil {
+			found[i] = s.copyOf(output, includeBEEF)
+		}
+	}
+	return found, nil
+}
+
+func (s *MemoryStorage) FindOutputsForTransaction(_ context.Context, txid *chainhash.Hash, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []*engine.Output
+	for _, output := range s.outputs {
+		if output.Outpoint.Txid == *txid {
+			found = append(found, s.copyOf(output, includeBEEF))
+		}
+	}
+	return found, nil
+}
+
+func (s *MemoryStorage) FindUTXOsForTopic(_ context.Context, topic string, since float64, limit uint32, includeBEEF bool) ([]*engine.Output, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []*engine.Output
+	for _, output := range s.outputs {
+		if output.Topic == topic && !output.Spent && output.Score >= since {
+			found = append(found, s.copyOf(output, includeBEEF))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Score < found[j].Score })
+	if limit > 0 && len(found) > int(limit) {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+func (s *MemoryStorage) DeleteOutput(_ context.Context, outpoint *transaction.Outpoint, topic string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := outputKey(outpoint, topic)
+	if output, ok := s.outputs[key]; ok {
+		s.release(output)
+		delete(s.outputs, key)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) TombstoneOutput(_ context.Context, outpoint *transaction.Outpoint, topic string, reason engine.TombstoneReason, deletedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := outputKey(outpoint, topic)
+	if output, ok := s.outputs[key]; ok {
+		s.tombstones = append(s.tombstones, &engine.Tombstone{Output: output, Reason: reason, DeletedAt: deletedAt})
+		delete(s.outputs, key)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) FindTombstones(_ context.Context, query *engine.TombstoneQuery) ([]*engine.Tombstone, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []*engine.Tombstone
+	for _, tombstone := range s.tombstones {
+		output := tombstone.Output
+		if (query.Topic != "" && output.Topic != query.Topic) ||
+			(query.Outpoint != nil && output.Outpoint != *query.Outpoint) ||
+			(query.Reason != "" && tombstone.Reason != query.Reason) ||
+			tombstone.DeletedAt.Before(query.DeletedAfter) {
+			continue
+		}
+		found = append(found, &engine.Tombstone{Output: s.copyOf(output, true), Reason: tombstone.Reason, DeletedAt: tombstone.DeletedAt})
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].DeletedAt.Before(found[j].DeletedAt) })
+	if query.Limit > 0 && len(found) > query.Limit {
+		found = found[:query.Limit]
+	}
+	return found, nil
+}
+
+func (s *MemoryStorage) PurgeTombstones(_ context.Context, deletedBefore time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.tombstones[:0]
+	for _, tombstone := range s.tombstones {
+		if !tombstone.DeletedAt.Before(deletedBefore) {
+			kept = append(kept, tombstone)
+		} else {
+			s.release(tombstone.Output)
+		}
+	}
+	purged := len(s.tombstones) - len(kept)
+	s.tombstones = kept
+	return purged, nil
+}
+
+func (s *MemoryStorage) MarkUTXOsAsSpent(_ context.Context, outpoints []*transaction.Outpoint, topic string, _ *chainhash.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, outpoint := range outpoints {
+		if output, ok := s.outputs[outputKey(outpoint, topic)]; ok {
+			output.Spent = true
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) MarkUTXOsAsUnspent(_ context.Context, outpoints []*transaction.Outpoint, topic string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, outpoint := range outpoints {
+		if output, ok := s.outputs[outputKey(outpoint, topic)]; ok {
+			output.Spent = false
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) UpdateConsumedBy(_ context.Context, outpoint *transaction.Outpoint, topic string, consumedBy []*transaction.Outpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if output, ok := s.outputs[outputKey(outpoint, topic)]; ok {
+		output.ConsumedBy = consumedBy
+	}
+	return nil
+}
+
+func (s *MemoryStorage) UpdateTransactionBEEF(_ context.Context, txid *chainhash.Hash, beef []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, output := range s.outputs {
+		if output.Outpoint.Txid == *txid {
+			output.Beef = s.beef.Replace(output.Beef, beef)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) UpdateOutputBlockHeight(_ context.Context, outpoint *transaction.Outpoint, topic string, blockHeight uint32, blockIndex uint64, ancillaryBeef []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if output, ok := s.outputs[outputKey(outpoint, topic)]; ok {
+		output.BlockHeight = blockHeight
+		output.BlockIdx = blockIndex
+		output.AncillaryBeef = s.beef.Replace(output.AncillaryBeef, ancillaryBeef)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) UpdateMerkleState(_ context.Context, outpoint *transaction.Outpoint, topic string, state engine.MerkleState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if output, ok := s.outputs[outputKey(outpoint, topic)]; ok {
+		output.MerkleState = state
+	}
+	return nil
+}
+
+func (s *MemoryStorage) UpdateOutputScore(_ context.Context, outpoint *transaction.Outpoint, topic string, score float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if output, ok := s.outputs[outputKey(outpoint, topic)]; ok {
+		output.Score = score
+	}
+	return nil
+}
+
+func (s *MemoryStorage) FindOutpointsByMerkleState(_ context.Context, topic string, state engine.MerkleState, limit uint32) ([]*transaction.Outpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []*engine.Output
+	for _, output := range s.outputs {
+		if output.Topic == topic && output.CurrentMerkleState() == state {
+			found = append(found, output)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].BlockHeight != found[j].BlockHeight {
+			return found[i].BlockHeight < found[j].BlockHeight
+		}
+		return found[i].Score < found[j].Score
+	})
+	if limit > 0 && len(found) > int(limit) {
+		found = found[:limit]
+	}
+	outpoints := make([]*transaction.Outpoint, len(found))
+	for i, output := range found {
+		outpoint := output.Outpoint
+		outpoints[i] = &outpoint
+	}
+	return outpoints, nil
+}
+
+func (s *MemoryStorage) InsertAppliedTransaction(_ context.Context, tx *overlay.AppliedTransaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applied[tx.Txid.String()+" "+tx.Topic] = struct{}{}
+	return nil
+}
+
+func (s *MemoryStorage) InsertOutputs(ctx context.Context, outputs []*engine.Output) error {
+	for _, output := range outputs {
+		if err := s.InsertOutput(ctx, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) InsertAppliedTransactions(ctx context.Context, txs []*overlay.AppliedTransaction) error {
+	for _, tx := range txs {
+		if err := s.InsertAppliedTransaction(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) DoesAppliedTransactionExist(_ context.Context, tx *overlay.AppliedTransaction) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.applied[tx.Txid.String()+" "+tx.Topic]
+	return ok, nil
+}
+
+func (s *MemoryStorage) UpdateLastInteraction(_ context.Context, host, topic string, since float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interactions[host+" "+topic] = since
+	return nil
+}
+
+func (s *MemoryStorage) GetLastInteraction(_ context.Context, host, topic string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.interactions[host+" "+topic], nil
+}
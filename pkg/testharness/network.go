@@ -0,0 +1,318 @@
+// Package testharness runs networks of overlay nodes wired as GASP peers, to regression-test multi-node sync
+// end to end. In-process nodes run the engine and the HTTP server of this module on random local ports, with
+// a MemoryStorage, a fake ChainTracker and a TopicManager admitting every output; nodes built from docker images
+// can join the same network. Every interaction goes through the HTTP API, so that tests exercise the same
+// requests as production peers:
+//
+//	func TestSync(t *testing.T) {
+//		network := testharness.NewNetwork(t, testharness.Config{Nodes: 3})
+//		tx, err := testharness.NewMinedTransaction(2, testharness.DefaultBlockHeight)
+//		require.NoError(t, err)
+//
+//		network.Submit(t.Context(), network.Nodes[0], tx)
+//		network.Sync(t.Context())
+//		network.RequireConverged(t.Context(), testharness.DefaultTopic)
+//	}
+package testharness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/client"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/gasp"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/server"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTopic is the topic hosted by the nodes of a Network configured without topics.
+	DefaultTopic = "tm_testharness"
+
+	// gaspPath is the path under which nodes serve GASP to their peers.
+	gaspPath = "/api/v1"
+
+	// DefaultConvergenceTimeout is how long RequireConverged waits for the nodes to agree when no timeout is configured.
+	DefaultConvergenceTimeout = 10 * time.Second
+)
+
+// Config configures a Network.
+type Config struct {
+	// Nodes is the number of in-process nodes.
+	Nodes int
+
+	// Topics are hosted by every in-process node and synced over GASP with every other node of the network.
+	// Empty defaults to DefaultTopic.
+	Topics []string
+
+	// Containers are nodes run from docker images, joining the network after the in-process nodes.
+	Containers []ContainerConfig
+
+	// AdminToken authenticates the admin calls of the harness on every node. Empty defaults to a random token.
+	AdminToken string
+
+	// ConvergenceTimeout is how long RequireConverged waits for the nodes to agree.
+	// Zero falls back to DefaultConvergenceTimeout.
+	ConvergenceTimeout time.Duration
+
+	// ScoreStrategy scores the outputs admitted by the in-process nodes. Nil lets their MemoryStorage score
+	// outputs in insertion order.
+	ScoreStrategy engine.ScoreStrategy
+}
+
+// Node is an overlay node of a Network.
+type Node struct {
+	Name   string
+	URL    string
+	Client *client.OverlayClient
+
+	// Engine, Storage and ChainTracker are set for in-process nodes only.
+	Engine       *engine.Engine
+	Storage      *MemoryStorage
+	ChainTracker *ChainTracker
+}
+
+// Network is a set of overlay nodes syncing their topics with each other over GASP. Nodes are stopped
+// when the test completes.
+type Network struct {
+	Nodes  []*Node
+	Topics []string
+
+	t      testing.TB
+	config Config
+}
+
+// NewNetwork starts the in-process nodes and the containers of the configuration and wires every node as a GASP
+// peer of every other node for each topic. Tests needing docker are skipped when docker is not available.
+func NewNetwork(t testing.TB, cfg Config) *Network {
+	t.Helper()
+	if cfg.AdminToken == "" {
+		cfg.AdminToken = uuid.NewString()
+	}
+	topics := cfg.Topics
+	if len(topics) == 0 {
+		topics = []string{DefaultTopic}
+	}
+	if len(cfg.Containers) > 0 {
+		requireDocker(t)
+	}
+
+	listeners := make([]net.Listener, cfg.Nodes)
+	urls := make([]string, 0, cfg.Nodes+len(cfg.Containers))
+	for i := range listeners {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen for node %d: %v", i, err)
+		}
+		listeners[i] = ln
+		urls = append(urls, "http://"+ln.Addr().String())
+	}
+	for _, container := range cfg.Containers {
+		urls = append(urls, container.url())
+	}
+
+	network := &Network{Topics: topics, t: t, config: cfg}
+	for i, ln := range listeners {
+		network.Nodes = append(network.Nodes, network.startNode(fmt.Sprintf("node-%d", i), urls[i], ln, peersOf(urls, i)))
+	}
+	for i, container := range cfg.Containers {
+		url := urls[cfg.Nodes+i]
+		network.Nodes = append(network.Nodes, network.startContainer(container, url, peersOf(urls, cfg.Nodes+i)))
+	}
+	return network
+}
+
+// startNode serves an in-process node on the listener.
+func (n *Network) startNode(name, url string, ln net.Listener, peers []string) *Node {
+	n.t.Helper()
+	storage := NewMemoryStorage()
+	tracker := NewChainTracker()
+	managers := make(map[string]engine.TopicManager, len(n.Topics))
+	syncConfiguration := make(map[string]engine.SyncConfiguration, len(n.Topics))
+	for _, topic := range n.Topics {
+		managers[topic] = &TopicManager{Topic: topic}
+		syncConfiguration[topic] = engine.SyncConfiguration{Type: engine.SyncConfigurationPeers, Peers: peers}
+	}
+	e := engine.NewEngine(engine.Engine{
+		Managers:          managers,
+		Storage:           storage,
+		ChainTracker:      tracker,
+		HostingURL:        url,
+		SyncConfiguration: syncConfiguration,
+		ScoreStrategy:     n.config.ScoreStrategy,
+	})
+
+	srv, err := server.New(server.WithEngine(e), server.WithAdminBearerToken(n.config.AdminToken))
+	if err != nil {
+		n.t.Fatalf("failed to create server of %s: %v", name, err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(context.Background(), ln) }()
+	n.t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			n.t.Errorf("failed to shut down %s: %v", name, err)
+		}
+		if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			n.t.Errorf("%s stopped serving: %v", name, err)
+		}
+	})
+
+	return &Node{
+		Name:         name,
+		URL:          url,
+		Client:       n.newClient(url),
+		Engine:       e,
+		Storage:      storage,
+		ChainTracker: tracker,
+	}
+}
+
+func (n *Network) newClient(url string) *client.OverlayClient {
+	n.t.Helper()
+	c, err := client.NewOverlayClient(url, client.WithBearerToken(n.config.AdminToken), client.WithRetries(0, 0))
+	if err != nil {
+		n.t.Fatalf("failed to create client of %s: %v", url, err)
+	}
+	return c
+}
+
+// Submit submits the transaction to the node, tagged with the given topics or with every topic of the network.
+// The test fails when the node rejects the submission.
+func (n *Network) Submit(ctx context.Context, node *Node, tx *transaction.Transaction, topics ...string) overlay.Steak {
+	n.t.Helper()
+	if len(topics) == 0 {
+		topics = n.Topics
+	}
+	beef, err := tx.BEEF()
+	if err != nil {
+		n.t.Fatalf("failed to serialize transaction %s: %v", tx.TxID(), err)
+	}
+	steak, err := node.Client.SubmitTaggedBEEF(ctx, overlay.TaggedBEEF{Beef: beef, Topics: topics}, nil)
+	if err != nil {
+		n.t.Fatalf("%s rejected transaction %s: %v", node.Name, tx.TxID(), err)
+	}
+	return steak
+}
+
+// Sync runs a GASP sync on every node, one node at a time. The test fails when a node cannot sync.
+func (n *Network) Sync(ctx context.Context) {
+	n.t.Helper()
+	for _, node := range n.Nodes {
+		if err := node.Client.StartGASPSync(ctx); err != nil {
+			n.t.Fatalf("%s failed to sync: %v", node.Name, err)
+		}
+	}
+}
+
+// UTXOs returns the unspent outputs of the topic held by the node, as served to its GASP peers, in the
+// order of their score.
+func (n *Network) UTXOs(ctx context.Context, node *Node, topic string) ([]transaction.Outpoint, error) {
+	var utxos []transaction.Outpoint
+	seen := make(map[transaction.Outpoint]struct{})
+	request := &gasp.InitialRequest{Version: 1}
+	for {
+		response, err := node.Client.RequestSyncResponse(ctx, topic, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, output := range response.UTXOList {
+			outpoint := *output.Outpoint()
+			if _, ok := seen[outpoint]; !ok {
+				seen[outpoint] = struct{}{}
+				utxos = append(utxos, outpoint)
+			}
+			request.Since = output.Score
+		}
+		if !response.Partial || len(response.UTXOList) == 0 {
+			return utxos, nil
+		}
+	}
+}
+
+// RequireConverged waits until every node holds the same unspent outputs of the topic, and the given outpoints
+// among them, failing the test when they still disagree after Config.ConvergenceTimeout.
+func (n *Network) RequireConverged(ctx context.Context, topic string, outpoints ...*transaction.Outpoint) {
+	n.t.Helper()
+	timeout := n.config.ConvergenceTimeout
+	if timeout <= 0 {
+		timeout = DefaultConvergenceTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		disagreement, err := n.disagreement(ctx, topic, outpoints)
+		if err == nil && disagreement == "" {
+			return
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				n.t.Fatalf("nodes did not converge on topic %q within %s: %v", topic, timeout, err)
+			}
+			n.t.Fatalf("nodes did not converge on topic %q within %s:\n%s", topic, timeout, disagreement)
+		}
+		select {
+		case <-ctx.Done():
+			n.t.Fatalf("nodes did not converge on topic %q: %v", topic, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// disagreement describes how the unspent outputs of the topic differ between the nodes, or returns an
+// empty string when every node holds the same outputs, including the expected ones.
+func (n *Network) disagreement(ctx context.Context, topic string, expected []*transaction.Outpoint) (string, error) {
+	sets := make([][]string, len(n.Nodes))
+	for i, node := range n.Nodes {
+		utxos, err := n.UTXOs(ctx, node, topic)
+		if err != nil {
+			return "", fmt.Errorf("failed to list the UTXOs of %s: %w", node.Name, err)
+		}
+		sets[i] = make([]string, len(utxos))
+		for j, utxo := range utxos {
+			sets[i][j] = utxo.String()
+		}
+		slices.Sort(sets[i])
+	}
+
+	agree := true
+	for i := 1; i < len(sets); i++ {
+		agree = agree && slices.Equal(sets[0], sets[i])
+	}
+	for _, outpoint := range expected {
+		for _, set := range sets {
+			_, found := slices.BinarySearch(set, outpoint.String())
+			agree = agree && found
+		}
+	}
+	if agree {
+		return "", nil
+	}
+
+	var b strings.Builder
+	for i, node := range n.Nodes {
+		fmt.Fprintf(&b, "  %s (%s): %v\n", node.Name, node.URL, sets[i])
+	}
+	return b.String(), nil
+}
+
+// peersOf returns the GASP endpoints of every node but the one at index i.
+func peersOf(urls []string, i int) []string {
+	peers := make([]string, 0, len(urls)-1)
+	for j, url := range urls {
+		if j != i {
+			peers = append(peers, url+gaspPath)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,47 @@
+package testharness_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/beefstore"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStorage_ShouldStoreTheBEEFOfATransactionOnce(t *testing.T) {
+	// given:
+	ctx := t.Context()
+	storage := testharness.NewMemoryStorage()
+	txid := chainhash.Hash{1}
+	beef, ancillary := []byte("beef of the transaction"), []byte("ancillary beef")
+	outputs := make([]*engine.Output, 3)
+	for i := range outputs {
+		outputs[i] = &engine.Output{
+			Outpoint:      transaction.Outpoint{Txid: txid, Index: uint32(i)},
+			Topic:         "tm_a",
+			Beef:          beef,
+			AncillaryBeef: ancillary,
+		}
+		require.NoError(t, storage.InsertOutput(ctx, outputs[i]))
+	}
+
+	// then:
+	require.Equal(t, beefstore.Stats{Blobs: 2, References: 6, Bytes: len(beef) + len(ancillary)}, storage.BEEFStats())
+
+	// when:
+	require.NoError(t, storage.UpdateTransactionBEEF(ctx, &txid, []byte("mined beef")))
+
+	// then:
+	require.Equal(t, beefstore.Stats{Blobs: 2, References: 6, Bytes: len("mined beef") + len(ancillary)}, storage.BEEFStats())
+
+	// when:
+	for _, output := range outputs {
+		require.NoError(t, storage.DeleteOutput(ctx, &output.Outpoint, output.Topic))
+	}
+
+	// then:
+	require.Equal(t, beefstore.Stats{}, storage.BEEFStats(), "the BEEF of deleted outputs must be released")
+}
@@ -0,0 +1,58 @@
+package testharness_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork_Sync_ShouldConvergeOutputsSubmittedToDifferentNodes(t *testing.T) {
+	// given:
+	ctx := t.Context()
+	network := testharness.NewNetwork(t, testharness.Config{Nodes: 3})
+
+	first, err := testharness.NewMinedTransaction(2, testharness.DefaultBlockHeight)
+	require.NoError(t, err)
+	second, err := testharness.NewMinedTransaction(1, testharness.DefaultBlockHeight)
+	require.NoError(t, err)
+
+	network.Submit(ctx, network.Nodes[0], first)
+	network.Submit(ctx, network.Nodes[2], second)
+
+	// when:
+	network.Sync(ctx)
+
+	// then:
+	network.RequireConverged(ctx, testharness.DefaultTopic,
+		&transaction.Outpoint{Txid: *first.TxID(), Index: 0},
+		&transaction.Outpoint{Txid: *first.TxID(), Index: 1},
+		&transaction.Outpoint{Txid: *second.TxID(), Index: 0},
+	)
+	for _, node := range network.Nodes {
+		utxos, err := network.UTXOs(ctx, node, testharness.DefaultTopic)
+		require.NoError(t, err)
+		require.Len(t, utxos, 3, node.Name)
+	}
+}
+
+func TestNetwork_Sync_ShouldOnlySyncTheTopicsOfEachOutput(t *testing.T) {
+	// given:
+	ctx := t.Context()
+	network := testharness.NewNetwork(t, testharness.Config{Nodes: 2, Topics: []string{"tm_a", "tm_b"}})
+
+	tx, err := testharness.NewMinedTransaction(1, testharness.DefaultBlockHeight)
+	require.NoError(t, err)
+	network.Submit(ctx, network.Nodes[1], tx, "tm_b")
+
+	// when:
+	network.Sync(ctx)
+
+	// then:
+	network.RequireConverged(ctx, "tm_b", &transaction.Outpoint{Txid: *tx.TxID(), Index: 0})
+	network.RequireConverged(ctx, "tm_a")
+	utxos, err := network.UTXOs(ctx, network.Nodes[0], "tm_a")
+	require.NoError(t, err)
+	require.Empty(t, utxos)
+}
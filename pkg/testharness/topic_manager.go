@@ -0,0 +1,46 @@
+package testharness
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// TopicManager admits every output of the transactions submitted to its topic and retains none of the outputs
+// they spend, so that the state of a topic only depends on the transactions the nodes received.
+type TopicManager struct {
+	Topic string
+}
+
+// IdentifyAdmissibleOutputs admits every output of the transaction.
+func (m *TopicManager) IdentifyAdmissibleOutputs(_ context.Context, beef []byte, _ map[uint32]*transaction.TransactionOutput) (overlay.AdmittanceInstructions, error) {
+	_, tx, _, err := transaction.ParseBeef(beef)
+	if err != nil {
+		return overlay.AdmittanceInstructions{}, err
+	}
+	if tx == nil {
+		return overlay.AdmittanceInstructions{}, fmt.Errorf("BEEF of topic %q holds no transaction", m.Topic)
+	}
+	admit := make([]uint32, len(tx.Outputs))
+	for vout := range tx.Outputs {
+		admit[vout] = uint32(vout) //nolint:gosec // bounded by the outputs of the transaction
+	}
+	return overlay.AdmittanceInstructions{OutputsToAdmit: admit}, nil
+}
+
+// IdentifyNeededInputs needs no inputs.
+func (m *TopicManager) IdentifyNeededInputs(context.Context, []byte) ([]*transaction.Outpoint, error) {
+	return nil, nil
+}
+
+// GetDocumentation returns the documentation of the topic manager.
+func (m *TopicManager) GetDocumentation() string {
+	return "Admits every output of the transactions submitted to " + m.Topic + "."
+}
+
+// GetMetaData returns the metadata of the topic manager.
+func (m *TopicManager) GetMetaData() *overlay.MetaData {
+	return &overlay.MetaData{Name: m.Topic, Description: m.GetDocumentation()}
+}
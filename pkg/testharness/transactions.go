@@ -0,0 +1,33 @@
+package testharness
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// NewMinedTransaction returns a transaction with the given number of outputs, mined at the given height by a
+// merkle path the ChainTracker accepts. Its outputs carry random data, so that every call returns a new transaction.
+func NewMinedTransaction(outputs int, blockHeight uint32) (*transaction.Transaction, error) {
+	tx := transaction.NewTransaction()
+	for range outputs {
+		nonce := make([]byte, 16)
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("failed to generate output data: %w", err)
+		}
+		lockingScript := &script.Script{}
+		if err := lockingScript.AppendOpcodes(script.OpFALSE, script.OpRETURN); err != nil {
+			return nil, err
+		}
+		if err := lockingScript.AppendPushData(nonce); err != nil {
+			return nil, err
+		}
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: 1, LockingScript: lockingScript})
+	}
+
+	isTxid := true
+	tx.MerklePath = transaction.NewMerklePath(blockHeight, [][]*transaction.PathElement{{{Offset: 0, Hash: tx.TxID(), Txid: &isTxid}}})
+	return tx, nil
+}
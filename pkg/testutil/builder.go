@@ -0,0 +1,220 @@
+// Package testutil provides deterministic test doubles for the authors of topic managers, lookup services and
+// storages: a programmable ChainTracker, a Builder of transaction graphs (chains, diamonds, unmined parents)
+// whose BEEF passes SPV verification against it, and assertions on the STEAK returned by submissions.
+//
+//	tracker := testutil.NewChainTracker(800000)
+//	builder := testutil.NewBuilder(t, tracker)
+//	chain := builder.Chain(3)
+//
+//	steak, err := e.Submit(ctx, overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, chain[2]), Topics: []string{"tm_a"}}, engine.SubmitModeCurrent, nil)
+//	require.NoError(t, err)
+//	testutil.RequireAdmitted(t, steak, "tm_a", 0)
+package testutil
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Input is an output of a transaction built by a Builder, spent by another one.
+type Input struct {
+	Tx   *transaction.Transaction
+	Vout uint32
+
+	// UnlockingScript unlocks the output. Nil unlocks the outputs created by Builder.Output, which need no signature.
+	UnlockingScript *script.Script
+}
+
+// Diamond is a graph where two transactions spend the outputs of a mined root and are both spent by the tip.
+type Diamond struct {
+	Root  *transaction.Transaction
+	Left  *transaction.Transaction
+	Right *transaction.Transaction
+	Tip   *transaction.Transaction
+}
+
+// Builder builds transaction graphs whose BEEF passes SPV verification against its ChainTracker. Transactions
+// are deterministic: the same sequence of calls builds the same transactions. Failures fail the test.
+type Builder struct {
+	t       testing.TB
+	tracker *ChainTracker
+	nonce   uint64
+}
+
+// NewBuilder creates a Builder mining the blocks of the given ChainTracker.
+func NewBuilder(t testing.TB, tracker *ChainTracker) *Builder {
+	return &Builder{t: t, tracker: tracker}
+}
+
+// Output returns an output of the given satoshis, locked by a script anyone can unlock with an empty unlocking
+// script. Every output carries a distinct nonce, so that transactions built from the same inputs differ.
+func (b *Builder) Output(satoshis uint64) *transaction.TransactionOutput {
+	b.t.Helper()
+	b.nonce++
+	nonce := binary.LittleEndian.AppendUint64(nil, b.nonce)
+	lockingScript := &script.Script{}
+	if err := lockingScript.AppendPushData(nonce); err != nil {
+		b.t.Fatalf("failed to build locking script: %v", err)
+	}
+	if err := lockingScript.AppendOpcodes(script.OpDROP, script.OpTRUE); err != nil {
+		b.t.Fatalf("failed to build locking script: %v", err)
+	}
+	return &transaction.TransactionOutput{Satoshis: satoshis, LockingScript: lockingScript}
+}
+
+// Tx returns an unmined transaction spending the inputs into the outputs.
+func (b *Builder) Tx(inputs []Input, outputs ...*transaction.TransactionOutput) *transaction.Transaction {
+	b.t.Helper()
+	tx := transaction.NewTransaction()
+	for _, input := range inputs {
+		if int(input.Vout) >= len(input.Tx.Outputs) {
+			b.t.Fatalf("transaction %s has no output %d", input.Tx.TxID(), input.Vout)
+		}
+		unlockingScript := input.UnlockingScript
+		if unlockingScript == nil {
+			unlockingScript = &script.Script{}
+		}
+		tx.AddInput(&transaction.TransactionInput{
+			SourceTXID:        input.Tx.TxID(),
+			SourceTxOutIndex:  input.Vout,
+			SourceTransaction: input.Tx,
+			UnlockingScript:   unlockingScript,
+			SequenceNumber:    transaction.DefaultSequenceNumber,
+		})
+	}
+	for _, output := range outputs {
+		tx.AddOutput(output)
+	}
+	return tx
+}
+
+// Spend returns an unmined transaction spending the inputs into outputs of the given satoshis built by Output.
+func (b *Builder) Spend(inputs []Input, satoshis ...uint64) *transaction.Transaction {
+	b.t.Helper()
+	outputs := make([]*transaction.TransactionOutput, len(satoshis))
+	for i, value := range satoshis {
+		outputs[i] = b.Output(value)
+	}
+	return b.Tx(inputs, outputs...)
+}
+
+// Mined returns a transaction without inputs creating outputs of the given satoshis, mined alone in a block at the
+// given height.
+func (b *Builder) Mined(height uint32, satoshis ...uint64) *transaction.Transaction {
+	b.t.Helper()
+	tx := b.Spend(nil, satoshis...)
+	b.Mine(height, tx)
+	return tx
+}
+
+// Mine mines the transactions in a block at the given height: it sets their merkle paths and makes the merkle
+// root of the block the valid root of the height, replacing the block previously mined there.
+func (b *Builder) Mine(height uint32, txs ...*transaction.Transaction) {
+	b.t.Helper()
+	if len(txs) == 0 {
+		b.t.Fatalf("cannot mine an empty block at height %d", height)
+	}
+	txids := make([]chainhash.Hash, len(txs))
+	for i, tx := range txs {
+		txids[i] = *tx.TxID()
+	}
+	levels := merkleTree(txids)
+	for i, tx := range txs {
+		tx.MerklePath = merklePath(height, levels, uint64(i))
+	}
+	b.tracker.SetRoot(height, levels[len(levels)-1][0])
+}
+
+// Chain returns a chain of transactions of the given length, each spending the single output of the previous
+// one. The first transaction is mined in a new block on top of the ChainTracker and the others are unmined.
+func (b *Builder) Chain(length int) []*transaction.Transaction {
+	b.t.Helper()
+	if length <= 0 {
+		return nil
+	}
+	chain := []*transaction.Transaction{b.Mined(b.nextHeight(), 1000)}
+	for i := 1; i < length; i++ {
+		chain = append(chain, b.Spend([]Input{{Tx: chain[i-1]}}, 1000))
+	}
+	return chain
+}
+
+// Diamond returns a diamond whose root is mined in a new block on top of the ChainTracker and whose other
+// transactions are unmined, so that the BEEF of the tip holds the root once.
+func (b *Builder) Diamond() *Diamond {
+	b.t.Helper()
+	root := b.Mined(b.nextHeight(), 1000, 1000)
+	left := b.Spend([]Input{{Tx: root, Vout: 0}}, 1000)
+	right := b.Spend([]Input{{Tx: root, Vout: 1}}, 1000)
+	tip := b.Spend([]Input{{Tx: left}, {Tx: right}}, 2000)
+	return &Diamond{Root: root, Left: left, Right: right, Tip: tip}
+}
+
+func (b *Builder) nextHeight() uint32 {
+	height, _ := b.tracker.CurrentHeight(b.t.Context())
+	return height + 1
+}
+
+// BEEF returns the BEEF of the transaction with its unmined ancestors, down to their mined ancestors.
+func BEEF(t testing.TB, tx *transaction.Transaction) []byte {
+	t.Helper()
+	beef, err := tx.BEEF()
+	if err != nil {
+		t.Fatalf("failed to serialize BEEF of transaction %s: %v", tx.TxID(), err)
+	}
+	return beef
+}
+
+// AtomicBEEF returns the atomic BEEF of the transaction with its unmined ancestors, down to their mined ancestors.
+func AtomicBEEF(t testing.TB, tx *transaction.Transaction) []byte {
+	t.Helper()
+	beef, err := tx.AtomicBEEF(false)
+	if err != nil {
+		t.Fatalf("failed to serialize atomic BEEF of transaction %s: %v", tx.TxID(), err)
+	}
+	return beef
+}
+
+// merkleTree returns the levels of the merkle tree of the txids, from the txids up to the root. Levels with an
+// odd number of nodes hash their last node with itself, as blocks do.
+func merkleTree(txids []chainhash.Hash) [][]chainhash.Hash {
+	levels := [][]chainhash.Hash{txids}
+	for level := txids; len(level) > 1; {
+		parents := make([]chainhash.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := &level[min(i+1, len(level)-1)]
+			parents = append(parents, *transaction.MerkleTreeParent(&level[i], right))
+		}
+		levels = append(levels, parents)
+		level = parents
+	}
+	return levels
+}
+
+// merklePath returns the merkle path of the txid at the offset of the tree.
+func merklePath(height uint32, levels [][]chainhash.Hash, offset uint64) *transaction.MerklePath {
+	isTxid, duplicate := true, true
+	leaf := &transaction.PathElement{Offset: offset, Hash: &levels[0][offset], Txid: &isTxid}
+	if len(levels) == 1 {
+		return transaction.NewMerklePath(height, [][]*transaction.PathElement{{leaf}})
+	}
+
+	path := make([][]*transaction.PathElement, len(levels)-1)
+	for h := range path {
+		sibling := (offset >> h) ^ 1
+		element := &transaction.PathElement{Offset: sibling, Duplicate: &duplicate}
+		if sibling < uint64(len(levels[h])) {
+			element = &transaction.PathElement{Offset: sibling, Hash: &levels[h][sibling]}
+		}
+		path[h] = []*transaction.PathElement{element}
+	}
+	path[0] = append(path[0], leaf)
+	slices.SortFunc(path[0], func(a, b *transaction.PathElement) int { return cmp.Compare(a.Offset, b.Offset) })
+	return transaction.NewMerklePath(height, path)
+}
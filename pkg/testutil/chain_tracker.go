@@ -0,0 +1,59 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// ChainTracker is a programmable chaintracker.ChainTracker holding one valid merkle root per block height, as
+// a real chain does. Builder.Mine registers the roots of the blocks it mines; SetRoot and RemoveRoot program
+// them directly, e.g. to simulate a reorg. It is safe for concurrent use.
+type ChainTracker struct {
+	mu     sync.RWMutex
+	height uint32
+	roots  map[uint32]chainhash.Hash
+}
+
+// NewChainTracker creates a ChainTracker whose tip is at the given height, without any valid root.
+func NewChainTracker(height uint32) *ChainTracker {
+	return &ChainTracker{height: height, roots: make(map[uint32]chainhash.Hash)}
+}
+
+// IsValidRootForHeight reports whether the root is the one set for the height.
+func (c *ChainTracker) IsValidRootForHeight(_ context.Context, root *chainhash.Hash, height uint32) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	valid, ok := c.roots[height]
+	return ok && root != nil && valid == *root, nil
+}
+
+// CurrentHeight returns the height of the tip.
+func (c *ChainTracker) CurrentHeight(context.Context) (uint32, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.height, nil
+}
+
+// SetHeight moves the tip to the given height.
+func (c *ChainTracker) SetHeight(height uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.height = height
+}
+
+// SetRoot makes the root the only valid one at the height, raising the tip to the height when it is below.
+func (c *ChainTracker) SetRoot(height uint32, root chainhash.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roots[height] = root
+	c.height = max(c.height, height)
+}
+
+// RemoveRoot invalidates every root at the height, e.g. to orphan the block mined there.
+func (c *ChainTracker) RemoveRoot(height uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.roots, height)
+}
@@ -0,0 +1,63 @@
+package testutil
+
+import (
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/stretchr/testify/require"
+)
+
+// RequireAdmitted asserts that the STEAK admits exactly the given outputs into the topic, in any order.
+func RequireAdmitted(t require.TestingT, steak overlay.Steak, topic string, vouts ...uint32) {
+	helper(t)
+	instructions := requireTopic(t, steak, topic)
+	require.ElementsMatch(t, vouts, instructions.OutputsToAdmit, "outputs admitted into topic %q", topic)
+}
+
+// RequireRetained asserts that the STEAK retains exactly the given inputs in the topic, in any order.
+func RequireRetained(t require.TestingT, steak overlay.Steak, topic string, vins ...uint32) {
+	helper(t)
+	instructions := requireTopic(t, steak, topic)
+	require.ElementsMatch(t, vins, instructions.CoinsToRetain, "coins retained in topic %q", topic)
+}
+
+// RequireRemoved asserts that the STEAK removes exactly the given inputs from the topic, in any order.
+func RequireRemoved(t require.TestingT, steak overlay.Steak, topic string, vins ...uint32) {
+	helper(t)
+	instructions := requireTopic(t, steak, topic)
+	require.ElementsMatch(t, vins, instructions.CoinsRemoved, "coins removed from topic %q", topic)
+}
+
+// RequireNotAdmitted asserts that the STEAK neither admits outputs into the topic nor retains inputs in it.
+func RequireNotAdmitted(t require.TestingT, steak overlay.Steak, topic string) {
+	helper(t)
+	instructions, ok := steak[topic]
+	if !ok || instructions == nil {
+		return
+	}
+	require.Empty(t, instructions.OutputsToAdmit, "outputs admitted into topic %q", topic)
+	require.Empty(t, instructions.CoinsToRetain, "coins retained in topic %q", topic)
+}
+
+// RequireTopics asserts that the STEAK holds admittance instructions for exactly the given topics, in any order.
+func RequireTopics(t require.TestingT, steak overlay.Steak, topics ...string) {
+	helper(t)
+	actual := make([]string, 0, len(steak))
+	for topic := range steak {
+		actual = append(actual, topic)
+	}
+	require.ElementsMatch(t, topics, actual, "topics of the STEAK")
+}
+
+// helper marks the caller as a test helper when t supports it, as testify does.
+func helper(t require.TestingT) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+}
+
+func requireTopic(t require.TestingT, steak overlay.Steak, topic string) *overlay.AdmittanceInstructions {
+	helper(t)
+	instructions, ok := steak[topic]
+	require.True(t, ok, "STEAK holds no admittance instructions for topic %q", topic)
+	require.NotNil(t, instructions, "STEAK holds no admittance instructions for topic %q", topic)
+	return instructions
+}
@@ -0,0 +1,149 @@
+package testutil_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-overlay-services/pkg/core/engine"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testharness"
+	"github.com/bsv-blockchain/go-overlay-services/pkg/testutil"
+	"github.com/bsv-blockchain/go-sdk/overlay"
+	"github.com/bsv-blockchain/go-sdk/spv"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records the failures of assertions instead of failing the test.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) FailNow() {}
+
+func TestBuilder_Chain_ShouldPassSPVVerification(t *testing.T) {
+	// given:
+	tracker := testutil.NewChainTracker(800000)
+	chain := testutil.NewBuilder(t, tracker).Chain(3)
+
+	// when:
+	_, tip, _, err := transaction.ParseBeef(testutil.AtomicBEEF(t, chain[2]))
+	require.NoError(t, err)
+	valid, verifyErr := spv.Verify(t.Context(), tip, tracker, nil)
+
+	// then:
+	require.NoError(t, verifyErr)
+	require.True(t, valid)
+	require.Equal(t, chain[1].TxID(), tip.Inputs[0].SourceTXID)
+	require.Nil(t, tip.Inputs[0].SourceTransaction.MerklePath)
+	require.Equal(t, uint32(800001), tip.Inputs[0].SourceTransaction.Inputs[0].SourceTransaction.MerklePath.BlockHeight)
+}
+
+func TestBuilder_Chain_ShouldFailSPVVerification_WhenTheBlockIsOrphaned(t *testing.T) {
+	// given:
+	tracker := testutil.NewChainTracker(800000)
+	chain := testutil.NewBuilder(t, tracker).Chain(2)
+	tracker.RemoveRoot(800001)
+
+	// when:
+	_, tip, _, err := transaction.ParseBeef(testutil.BEEF(t, chain[1]))
+	require.NoError(t, err)
+	valid, _ := spv.Verify(t.Context(), tip, tracker, nil)
+
+	// then:
+	require.False(t, valid)
+}
+
+func TestBuilder_Diamond_ShouldHoldTheRootOnce(t *testing.T) {
+	// given:
+	tracker := testutil.NewChainTracker(800000)
+	diamond := testutil.NewBuilder(t, tracker).Diamond()
+
+	// when:
+	beef, tip, _, err := transaction.ParseBeef(testutil.AtomicBEEF(t, diamond.Tip))
+	require.NoError(t, err)
+	valid, verifyErr := spv.Verify(t.Context(), tip, tracker, nil)
+
+	// then:
+	require.NoError(t, verifyErr)
+	require.True(t, valid)
+	require.Len(t, beef.Transactions, 4)
+	require.Equal(t, diamond.Root.TxID(), diamond.Left.Inputs[0].SourceTXID)
+	require.Equal(t, diamond.Root.TxID(), diamond.Right.Inputs[0].SourceTXID)
+	require.Equal(t, []uint32{0, 1}, []uint32{diamond.Left.Inputs[0].SourceTxOutIndex, diamond.Right.Inputs[0].SourceTxOutIndex})
+}
+
+func TestBuilder_Mine_ShouldProveEveryTransactionOfTheBlock(t *testing.T) {
+	// given:
+	tracker := testutil.NewChainTracker(800000)
+	builder := testutil.NewBuilder(t, tracker)
+	txs := []*transaction.Transaction{builder.Spend(nil, 1), builder.Spend(nil, 2), builder.Spend(nil, 3)}
+
+	// when:
+	builder.Mine(800005, txs...)
+
+	// then:
+	height, err := tracker.CurrentHeight(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uint32(800005), height)
+	for _, tx := range txs {
+		valid, err := tx.MerklePath.Verify(t.Context(), tx.TxID(), tracker)
+		require.NoError(t, err)
+		require.True(t, valid, tx.TxID().String())
+	}
+}
+
+func TestBuilder_ShouldBuildTheSameTransactions_WhenCalledInTheSameOrder(t *testing.T) {
+	// given:
+	first := testutil.NewBuilder(t, testutil.NewChainTracker(800000))
+	second := testutil.NewBuilder(t, testutil.NewChainTracker(800000))
+
+	// when:
+	firstDiamond, secondDiamond := first.Diamond(), second.Diamond()
+
+	// then:
+	require.Equal(t, firstDiamond.Tip.TxID(), secondDiamond.Tip.TxID())
+	require.NotEqual(t, firstDiamond.Tip.TxID(), first.Diamond().Tip.TxID())
+}
+
+func TestBuilder_ShouldBuildSubmissionsAcceptedByTheEngine(t *testing.T) {
+	// given:
+	tracker := testutil.NewChainTracker(800000)
+	chain := testutil.NewBuilder(t, tracker).Chain(3)
+	sut := engine.NewEngine(engine.Engine{
+		Managers:     map[string]engine.TopicManager{"tm_a": &testharness.TopicManager{Topic: "tm_a"}},
+		Storage:      testharness.NewMemoryStorage(),
+		ChainTracker: tracker,
+	})
+
+	// when:
+	steak, err := sut.Submit(context.Background(), overlay.TaggedBEEF{Beef: testutil.AtomicBEEF(t, chain[2]), Topics: []string{"tm_a"}}, engine.SubmitModeCurrent, nil)
+
+	// then:
+	require.NoError(t, err)
+	testutil.RequireTopics(t, steak, "tm_a")
+	testutil.RequireAdmitted(t, steak, "tm_a", 0)
+	testutil.RequireRetained(t, steak, "tm_a")
+}
+
+func TestRequireAdmitted_ShouldFail_WhenTheOutputsDiffer(t *testing.T) {
+	// given:
+	steak := overlay.Steak{"tm_a": {OutputsToAdmit: []uint32{1, 0}, CoinsToRetain: []uint32{2}, CoinsRemoved: []uint32{3}}}
+	passing, failing := &recordingT{}, &recordingT{}
+
+	// when:
+	testutil.RequireAdmitted(passing, steak, "tm_a", 0, 1)
+	testutil.RequireRetained(passing, steak, "tm_a", 2)
+	testutil.RequireRemoved(passing, steak, "tm_a", 3)
+	testutil.RequireNotAdmitted(passing, steak, "tm_b")
+	testutil.RequireAdmitted(failing, steak, "tm_a", 0)
+	testutil.RequireNotAdmitted(failing, steak, "tm_a")
+
+	// then:
+	require.Empty(t, passing.errors)
+	require.Len(t, failing.errors, 3)
+}